@@ -190,6 +190,13 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Secret references read the files and environment of the server, so they can only be
+	// introduced through the config file, the environment or local mode.
+	if paths := config.NewSecretReferences(cfg, c.App.SecretReferences()); len(paths) > 0 {
+		c.Err = model.NewAppError("updateConfig", "api.config.update_config.secret_reference.app_error", map[string]any{"Name": paths[0]}, "", http.StatusForbidden)
+		return
+	}
+
 	oldCfg, newCfg, appErr := c.App.SaveConfig(cfg, true)
 	if appErr != nil {
 		c.Err = appErr
@@ -340,6 +347,11 @@ func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if paths := config.NewSecretReferences(updatedCfg, c.App.SecretReferences()); len(paths) > 0 {
+		c.Err = model.NewAppError("patchConfig", "api.config.update_config.secret_reference.app_error", map[string]any{"Name": paths[0]}, "", http.StatusForbidden)
+		return
+	}
+
 	oldCfg, newCfg, appErr := c.App.SaveConfig(updatedCfg, true)
 	if appErr != nil {
 		c.Err = appErr
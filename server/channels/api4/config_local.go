@@ -37,7 +37,11 @@ func localGetConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	m, err := model.FilterConfig(c.App.Config(), filterOpts)
+	// The secrets set via a reference are never returned, only their references.
+	cfg := c.App.Config().Clone()
+	config.ApplySecretReferences(cfg, c.App.SecretReferences())
+
+	m, err := model.FilterConfig(cfg, filterOpts)
 	if err != nil {
 		c.Err = model.NewAppError("getConfig", "api.filter_config_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		return
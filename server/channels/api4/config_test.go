@@ -66,6 +66,75 @@ func TestGetConfig(t *testing.T) {
 		require.NotEqual(t, model.FakeSetting, *cfg.SqlSettings.DataSource)
 		require.NotEqual(t, model.FakeSetting, *cfg.FileSettings.PublicLinkSalt)
 	})
+
+	t.Run("Get config with a secret set via a reference", func(t *testing.T) {
+		t.Setenv("TEST_SMTP_PASSWORD", "secret")
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.EmailSettings.SMTPPassword = model.NewPointer("env:TEST_SMTP_PASSWORD")
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.EmailSettings.SMTPPassword = model.NewPointer("")
+		})
+		require.Equal(t, "secret", *th.App.Config().EmailSettings.SMTPPassword)
+
+		cfg, _, err := th.SystemAdminClient.GetConfig(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "env:TEST_SMTP_PASSWORD", *cfg.EmailSettings.SMTPPassword)
+
+		cfg, _, err = th.LocalClient.GetConfig(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "env:TEST_SMTP_PASSWORD", *cfg.EmailSettings.SMTPPassword)
+	})
+}
+
+func TestUpdateConfigSecretReferences(t *testing.T) {
+	th := Setup(t)
+
+	t.Setenv("TEST_SMTP_PASSWORD", "secret")
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.EmailSettings.SMTPPassword = model.NewPointer("env:TEST_SMTP_PASSWORD")
+	})
+	defer th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.EmailSettings.SMTPPassword = model.NewPointer("")
+		cfg.GitLabSettings.Secret = model.NewPointer("")
+	})
+
+	t.Run("existing references are kept", func(t *testing.T) {
+		cfg, _, err := th.SystemAdminClient.GetConfig(context.Background())
+		require.NoError(t, err)
+
+		cfg, _, err = th.SystemAdminClient.UpdateConfig(context.Background(), cfg)
+		require.NoError(t, err)
+		require.Equal(t, "env:TEST_SMTP_PASSWORD", *cfg.EmailSettings.SMTPPassword)
+		require.Equal(t, "secret", *th.App.Config().EmailSettings.SMTPPassword)
+	})
+
+	t.Run("new references are rejected", func(t *testing.T) {
+		cfg, _, err := th.SystemAdminClient.GetConfig(context.Background())
+		require.NoError(t, err)
+		cfg.EmailSettings.SMTPPassword = model.NewPointer("file:/etc/passwd")
+
+		_, resp, err := th.SystemAdminClient.UpdateConfig(context.Background(), cfg)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.PatchConfig(context.Background(), &model.Config{
+			GitLabSettings: model.SSOSettings{Secret: model.NewPointer("env:TEST_SMTP_PASSWORD")},
+		})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		require.Equal(t, "secret", *th.App.Config().EmailSettings.SMTPPassword)
+	})
+
+	t.Run("local mode can introduce references", func(t *testing.T) {
+		cfg, _, err := th.LocalClient.GetConfig(context.Background())
+		require.NoError(t, err)
+		cfg.GitLabSettings.Secret = model.NewPointer("env:TEST_SMTP_PASSWORD")
+
+		_, _, err = th.LocalClient.UpdateConfig(context.Background(), cfg)
+		require.NoError(t, err)
+		require.Equal(t, "secret", *th.App.Config().GitLabSettings.Secret)
+	})
 }
 
 func TestGetConfigWithAccessTag(t *testing.T) {
@@ -0,0 +1,264 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (api *API) InitEmail() {
+	api.BaseRoutes.Email.Handle("/outbound_queue", api.APISessionRequired(getOutgoingEmails)).Methods(http.MethodGet)
+	api.BaseRoutes.Email.Handle("/outbound_queue/{email_id:[A-Za-z0-9]+}/retry", api.APISessionRequired(retryOutgoingEmail)).Methods(http.MethodPost)
+	api.BaseRoutes.Email.Handle("/outbound_queue/{email_id:[A-Za-z0-9]+}", api.APISessionRequired(deleteOutgoingEmail)).Methods(http.MethodDelete)
+
+	api.BaseRoutes.Email.Handle("/dkim/private_key", api.APISessionRequired(addDKIMPrivateKey)).Methods(http.MethodPost)
+	api.BaseRoutes.Email.Handle("/dkim/private_key", api.APISessionRequired(removeDKIMPrivateKey)).Methods(http.MethodDelete)
+
+	api.BaseRoutes.Email.Handle("/templates", api.APISessionRequired(getEmailTemplates)).Methods(http.MethodGet)
+	api.BaseRoutes.Email.Handle("/templates/{template_name:[a-z_]+}/{locale:[A-Za-z_-]+}", api.APISessionRequired(uploadEmailTemplate)).Methods(http.MethodPost)
+	api.BaseRoutes.Email.Handle("/templates/{template_name:[a-z_]+}/{locale:[A-Za-z_-]+}", api.APISessionRequired(resetEmailTemplate)).Methods(http.MethodDelete)
+	api.BaseRoutes.Email.Handle("/templates/{template_name:[a-z_]+}/{locale:[A-Za-z_-]+}/preview", api.APISessionRequired(previewEmailTemplate)).Methods(http.MethodGet)
+}
+
+func getOutgoingEmails(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadEnvironmentSMTP) {
+		c.SetPermissionError(model.PermissionSysconsoleReadEnvironmentSMTP)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = model.OutgoingEmailStatusDead
+	}
+	if status != model.OutgoingEmailStatusDead && status != model.OutgoingEmailStatusPending {
+		c.SetInvalidURLParam("status")
+		return
+	}
+
+	emails, appErr := c.App.GetOutgoingEmails(status, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(emails); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func retryOutgoingEmail(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOutgoingEmailId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteEnvironmentSMTP) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteEnvironmentSMTP)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventRetryOutgoingEmail, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "email_id", c.Params.OutgoingEmailId)
+
+	email, appErr := c.App.RetryOutgoingEmail(c.Params.OutgoingEmailId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(email)
+
+	if err := json.NewEncoder(w).Encode(email); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteOutgoingEmail(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOutgoingEmailId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteEnvironmentSMTP) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteEnvironmentSMTP)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventDeleteOutgoingEmail, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "email_id", c.Params.OutgoingEmailId)
+
+	if appErr := c.App.DeleteOutgoingEmail(c.Params.OutgoingEmailId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func parseDKIMPrivateKeyRequest(r *http.Request, maxFileSize int64) (*multipart.FileHeader, *model.AppError) {
+	err := r.ParseMultipartForm(maxFileSize)
+	if err != nil {
+		return nil, model.NewAppError("addDKIMPrivateKey", "api.admin.add_dkim_private_key.no_file.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	m := r.MultipartForm
+
+	fileArray, ok := m.File["private_key"]
+	if !ok || len(fileArray) == 0 {
+		return nil, model.NewAppError("addDKIMPrivateKey", "api.admin.add_dkim_private_key.no_file.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(fileArray) > 1 {
+		return nil, model.NewAppError("addDKIMPrivateKey", "api.admin.add_dkim_private_key.multiple_files.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return fileArray[0], nil
+}
+
+func addDKIMPrivateKey(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteEnvironmentSMTP) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteEnvironmentSMTP)
+		return
+	}
+
+	fileData, appErr := parseDKIMPrivateKeyRequest(r, *c.App.Config().FileSettings.MaxFileSize)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventAddDKIMPrivateKey, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "filename", fileData.Filename)
+
+	if appErr := c.App.AddDKIMPrivateKey(fileData); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func removeDKIMPrivateKey(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteEnvironmentSMTP) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteEnvironmentSMTP)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventRemoveDKIMPrivateKey, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+
+	if appErr := c.App.RemoveDKIMPrivateKey(); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func getEmailTemplates(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadSiteCustomization) {
+		c.SetPermissionError(model.PermissionSysconsoleReadSiteCustomization)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(c.App.GetEmailTemplates()); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func previewEmailTemplate(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadSiteCustomization) {
+		c.SetPermissionError(model.PermissionSysconsoleReadSiteCustomization)
+		return
+	}
+
+	preview, appErr := c.App.GetEmailTemplatePreview(c.Params.EmailTemplateName, c.Params.Locale)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(preview); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func parseEmailTemplateRequest(r *http.Request, maxFileSize int64) (*multipart.FileHeader, *model.AppError) {
+	err := r.ParseMultipartForm(maxFileSize)
+	if err != nil {
+		return nil, model.NewAppError("uploadEmailTemplate", "api.email_template.upload.no_file.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	m := r.MultipartForm
+
+	fileArray, ok := m.File["template"]
+	if !ok || len(fileArray) == 0 {
+		return nil, model.NewAppError("uploadEmailTemplate", "api.email_template.upload.no_file.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(fileArray) > 1 {
+		return nil, model.NewAppError("uploadEmailTemplate", "api.email_template.upload.multiple_files.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return fileArray[0], nil
+}
+
+func uploadEmailTemplate(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteSiteCustomization) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteSiteCustomization)
+		return
+	}
+
+	fileData, appErr := parseEmailTemplateRequest(r, *c.App.Config().FileSettings.MaxFileSize)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventUploadEmailTemplate, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "template_name", c.Params.EmailTemplateName)
+	model.AddEventParameterToAuditRec(auditRec, "locale", c.Params.Locale)
+	model.AddEventParameterToAuditRec(auditRec, "filename", fileData.Filename)
+
+	if appErr := c.App.SetEmailTemplateOverride(c.Params.EmailTemplateName, c.Params.Locale, fileData); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func resetEmailTemplate(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteSiteCustomization) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteSiteCustomization)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventResetEmailTemplate, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "template_name", c.Params.EmailTemplateName)
+	model.AddEventParameterToAuditRec(auditRec, "locale", c.Params.Locale)
+
+	if appErr := c.App.ResetEmailTemplate(c.Params.EmailTemplateName, c.Params.Locale); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
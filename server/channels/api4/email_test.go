@@ -0,0 +1,206 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+)
+
+func TestOutboundEmailQueue(t *testing.T) {
+	th := Setup(t)
+
+	dead, err := th.App.Srv().Store().OutgoingEmail().Save(&model.OutgoingEmail{
+		To:       "user@example.com",
+		Subject:  "subject",
+		HTMLBody: "<p>secret</p>",
+		Status:   model.OutgoingEmailStatusDead,
+		Attempts: 8,
+	})
+	require.NoError(t, err)
+
+	t.Run("list as regular user", func(t *testing.T) {
+		_, resp, err := th.Client.GetOutgoingEmails(context.Background(), model.OutgoingEmailStatusDead, 0, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("list dead emails", func(t *testing.T) {
+		emails, _, err := th.SystemAdminClient.GetOutgoingEmails(context.Background(), model.OutgoingEmailStatusDead, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, emails.Emails, 1)
+		assert.Equal(t, int64(1), emails.TotalCount)
+		assert.Equal(t, dead.Id, emails.Emails[0].Id)
+		assert.Empty(t, emails.Emails[0].HTMLBody, "body should be sanitized")
+	})
+
+	t.Run("list with invalid status", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetOutgoingEmails(context.Background(), "sent", 0, 10)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("retry as regular user", func(t *testing.T) {
+		_, resp, err := th.Client.RetryOutgoingEmail(context.Background(), dead.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		email, _, err := th.SystemAdminClient.RetryOutgoingEmail(context.Background(), dead.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.OutgoingEmailStatusPending, email.Status)
+		assert.Zero(t, email.Attempts)
+	})
+
+	t.Run("retry missing email", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.RetryOutgoingEmail(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp, err := th.Client.DeleteOutgoingEmail(context.Background(), dead.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, err = th.SystemAdminClient.DeleteOutgoingEmail(context.Background(), dead.Id)
+		require.NoError(t, err)
+
+		resp, err = th.SystemAdminClient.DeleteOutgoingEmail(context.Background(), dead.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
+
+func TestDKIMPrivateKey(t *testing.T) {
+	th := Setup(t)
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	t.Run("upload as regular user", func(t *testing.T) {
+		resp, err := th.Client.UploadDKIMPrivateKey(context.Background(), keyPEM, "dkim.pem")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("upload invalid key", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.UploadDKIMPrivateKey(context.Background(), []byte("not a key"), "dkim.pem")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("upload and remove", func(t *testing.T) {
+		_, err := th.SystemAdminClient.UploadDKIMPrivateKey(context.Background(), keyPEM, "dkim.pem")
+		require.NoError(t, err)
+		assert.Equal(t, app.DKIMPrivateKeyFilename, *th.App.Config().EmailSettings.DKIMPrivateKeyFile)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.EmailSettings.EnableDKIMSigning = true
+			*cfg.EmailSettings.DKIMDomain = "example.com"
+			*cfg.EmailSettings.DKIMSelector = "mm"
+		})
+
+		_, err = th.SystemAdminClient.RemoveDKIMPrivateKey(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, *th.App.Config().EmailSettings.DKIMPrivateKeyFile)
+		assert.False(t, *th.App.Config().EmailSettings.EnableDKIMSigning)
+	})
+}
+
+func TestEmailTemplates(t *testing.T) {
+	th := Setup(t)
+
+	override := []byte(`{{define "reset_body"}}<a href="{{.Props.ButtonURL}}">{{.Props.Button}}</a>{{end}}`)
+
+	t.Run("list as regular user", func(t *testing.T) {
+		_, resp, err := th.Client.GetEmailTemplates(context.Background())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		emailTemplates, _, err := th.SystemAdminClient.GetEmailTemplates(context.Background())
+		require.NoError(t, err)
+		require.NotEmpty(t, emailTemplates)
+		for _, emailTemplate := range emailTemplates {
+			assert.Empty(t, emailTemplate.OverriddenLocales)
+		}
+	})
+
+	t.Run("preview", func(t *testing.T) {
+		preview, _, err := th.SystemAdminClient.GetEmailTemplatePreview(context.Background(), "reset_body", "en")
+		require.NoError(t, err)
+		assert.Equal(t, "reset_body", preview.Name)
+		assert.NotEmpty(t, preview.HTML)
+
+		_, resp, err := th.Client.GetEmailTemplatePreview(context.Background(), "reset_body", "en")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.GetEmailTemplatePreview(context.Background(), "email_footer", "en")
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.GetEmailTemplatePreview(context.Background(), "reset_body", "xx")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("upload as regular user", func(t *testing.T) {
+		resp, err := th.Client.UploadEmailTemplate(context.Background(), "reset_body", "en", override)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("upload invalid template", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.UploadEmailTemplate(context.Background(), "reset_body", "en", []byte(`{{define "reset_body"}}{{.Props.Missing}}{{end}}`))
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("upload, preview and reset", func(t *testing.T) {
+		_, err := th.SystemAdminClient.UploadEmailTemplate(context.Background(), "reset_body", "en", override)
+		require.NoError(t, err)
+
+		emailTemplates, _, err := th.SystemAdminClient.GetEmailTemplates(context.Background())
+		require.NoError(t, err)
+		for _, emailTemplate := range emailTemplates {
+			if emailTemplate.Name == "reset_body" {
+				assert.Equal(t, []string{"en"}, emailTemplate.OverriddenLocales)
+			}
+		}
+
+		preview, _, err := th.SystemAdminClient.GetEmailTemplatePreview(context.Background(), "reset_body", "en")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(preview.HTML, "<a href="))
+
+		resp, err := th.Client.ResetEmailTemplate(context.Background(), "reset_body", "en")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, err = th.SystemAdminClient.ResetEmailTemplate(context.Background(), "reset_body", "en")
+		require.NoError(t, err)
+		assert.Empty(t, th.App.Config().EmailSettings.TemplateOverrideFiles)
+
+		resp, err = th.SystemAdminClient.ResetEmailTemplate(context.Background(), "reset_body", "en")
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
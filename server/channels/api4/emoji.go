@@ -4,6 +4,7 @@
 package api4
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -25,6 +26,12 @@ func (api *API) InitEmoji() {
 	api.BaseRoutes.Emojis.Handle("/names", api.APISessionRequired(getEmojisByNames)).Methods(http.MethodPost)
 	api.BaseRoutes.Emojis.Handle("/search", api.APISessionRequired(searchEmojis)).Methods(http.MethodPost)
 	api.BaseRoutes.Emojis.Handle("/autocomplete", api.APISessionRequired(autocompleteEmojis)).Methods(http.MethodGet)
+	api.BaseRoutes.Emojis.Handle("/categories", api.APISessionRequired(getEmojiCategories)).Methods(http.MethodGet)
+	api.BaseRoutes.Emojis.Handle("/categories", api.APISessionRequired(createEmojiCategory)).Methods(http.MethodPost)
+	api.BaseRoutes.Emojis.Handle("/categories/{category_id:[A-Za-z0-9]+}", api.APISessionRequired(deleteEmojiCategory)).Methods(http.MethodDelete)
+	api.BaseRoutes.Emojis.Handle("/import", api.APISessionRequired(importEmojiPack, handlerParamFileAPI)).Methods(http.MethodPost)
+	api.BaseRoutes.Emojis.Handle("/export", api.APISessionRequired(exportEmojiPack)).Methods(http.MethodGet)
+	api.BaseRoutes.Emoji.Handle("/patch", api.APISessionRequired(patchEmoji)).Methods(http.MethodPut)
 	api.BaseRoutes.Emoji.Handle("", api.APISessionRequired(deleteEmoji)).Methods(http.MethodDelete)
 	api.BaseRoutes.Emoji.Handle("", api.APISessionRequired(getEmoji)).Methods(http.MethodGet)
 	api.BaseRoutes.EmojiByName.Handle("", api.APISessionRequired(getEmojiByName)).Methods(http.MethodGet)
@@ -198,6 +205,256 @@ func deleteEmoji(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func patchEmoji(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireEmojiId()
+	if c.Err != nil {
+		return
+	}
+
+	if !*c.App.Config().ServiceSettings.EnableCustomEmoji {
+		c.Err = model.NewAppError("patchEmoji", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	var patch model.EmojiPatch
+	if jsonErr := json.NewDecoder(r.Body).Decode(&patch); jsonErr != nil {
+		c.SetInvalidParamWithErr("emoji", jsonErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventPatchEmoji, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "emoji_patch", &patch)
+
+	emoji, err := c.App.GetEmoji(c.AppContext, c.Params.EmojiId)
+	if err != nil {
+		model.AddEventParameterToAuditRec(auditRec, "emoji_id", c.Params.EmojiId)
+		c.Err = err
+		return
+	}
+	auditRec.AddEventPriorState(emoji)
+	auditRec.AddEventObjectType("emoji")
+
+	// The creator of an emoji can update it as long as they are still allowed to create emojis,
+	// system admins can update any emoji.
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteSiteEmoji) {
+		if c.AppContext.Session().UserId != emoji.CreatorId {
+			c.SetPermissionError(model.PermissionSysconsoleWriteSiteEmoji)
+			return
+		}
+
+		memberships, err := c.App.GetTeamMembersForUser(c.AppContext, c.AppContext.Session().UserId, "", true)
+		if err != nil {
+			c.Err = err
+			return
+		}
+
+		if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionCreateEmojis) {
+			hasPermission := false
+			for _, membership := range memberships {
+				if c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), membership.TeamId, model.PermissionCreateEmojis) {
+					hasPermission = true
+					break
+				}
+			}
+			if !hasPermission {
+				c.SetPermissionError(model.PermissionCreateEmojis)
+				return
+			}
+		}
+	}
+
+	patchedEmoji, err := c.App.PatchEmoji(c.AppContext, c.Params.EmojiId, &patch)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.AddEventResultState(patchedEmoji)
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(patchedEmoji); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getEmojiCategories(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !*c.App.Config().ServiceSettings.EnableCustomEmoji {
+		c.Err = model.NewAppError("getEmojiCategories", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	categories, err := c.App.GetEmojiCategories()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(categories); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func createEmojiCategory(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !*c.App.Config().ServiceSettings.EnableCustomEmoji {
+		c.Err = model.NewAppError("createEmojiCategory", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	var category model.EmojiCategory
+	if jsonErr := json.NewDecoder(r.Body).Decode(&category); jsonErr != nil {
+		c.SetInvalidParamWithErr("category", jsonErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCreateEmojiCategory, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "category", &category)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteSiteEmoji) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteSiteEmoji)
+		return
+	}
+
+	savedCategory, err := c.App.CreateEmojiCategory(c.AppContext, &category)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.AddEventResultState(savedCategory)
+	auditRec.AddEventObjectType("emoji_category")
+	auditRec.Success()
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(savedCategory); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteEmojiCategory(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !model.IsValidId(c.Params.CategoryId) {
+		c.SetInvalidURLParam("category_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventDeleteEmojiCategory, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "category_id", c.Params.CategoryId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteSiteEmoji) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteSiteEmoji)
+		return
+	}
+
+	category, err := c.App.GetEmojiCategory(c.Params.CategoryId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	auditRec.AddEventPriorState(category)
+	auditRec.AddEventObjectType("emoji_category")
+
+	if err := c.App.DeleteEmojiCategory(c.AppContext, c.Params.CategoryId); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
+func importEmojiPack(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		if _, err := io.Copy(io.Discard, r.Body); err != nil {
+			c.Logger.Warn("Error while discarding request body", mlog.Err(err))
+		}
+	}()
+
+	if !*c.App.Config().ServiceSettings.EnableCustomEmoji {
+		c.Err = model.NewAppError("importEmojiPack", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventImportEmojiPack, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteSiteEmoji) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteSiteEmoji)
+		return
+	}
+
+	maxFileSize := *c.App.Config().FileSettings.MaxFileSize
+	if r.ContentLength > maxFileSize {
+		c.Err = model.NewAppError("importEmojiPack", "api.emoji.import.too_large.app_error", nil, "", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxFileSize); err != nil {
+		c.Err = model.NewAppError("importEmojiPack", "api.emoji.import.parse.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		return
+	}
+
+	fileData := r.MultipartForm.File["file"]
+	if len(fileData) == 0 {
+		c.SetInvalidParam("file")
+		return
+	}
+	model.AddEventParameterToAuditRec(auditRec, "filename", fileData[0].Filename)
+
+	file, err := fileData[0].Open()
+	if err != nil {
+		c.Err = model.NewAppError("importEmojiPack", "api.emoji.import.open.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		return
+	}
+	defer file.Close()
+
+	result, appErr := c.App.ImportEmojiPack(c.AppContext, c.AppContext.Session().UserId, file, fileData[0].Size)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	model.AddEventParameterToAuditRec(auditRec, "created", len(result.Created))
+	model.AddEventParameterToAuditRec(auditRec, "skipped", len(result.Skipped))
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func exportEmojiPack(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !*c.App.Config().ServiceSettings.EnableCustomEmoji {
+		c.Err = model.NewAppError("exportEmojiPack", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventExportEmojiPack, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadSiteEmoji) {
+		c.SetPermissionError(model.PermissionSysconsoleReadSiteEmoji)
+		return
+	}
+
+	// The emoji pack is buffered so that a failure can still be reported as an error response.
+	var buf bytes.Buffer
+	if appErr := c.App.ExportEmojiPack(c.AppContext, &buf); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", "attachment; filename=\"emoji_pack.zip\"")
+	if _, err := io.Copy(w, &buf); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func getEmoji(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireEmojiId()
 	if c.Err != nil {
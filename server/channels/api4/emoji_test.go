@@ -880,3 +880,103 @@ func TestAutocompleteEmoji(t *testing.T) {
 		CheckUnauthorizedStatus(t, resp)
 	})
 }
+
+func TestEmojiCategoriesAliasesAndPacks(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = true })
+
+	categoryName := "category_" + model.NewId()[:10]
+
+	t.Run("only admins can manage categories", func(t *testing.T) {
+		_, resp, err := client.CreateEmojiCategory(context.Background(), &model.EmojiCategory{Name: categoryName})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	category, resp, err := th.SystemAdminClient.CreateEmojiCategory(context.Background(), &model.EmojiCategory{Name: categoryName, DisplayName: "Category"})
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+
+	categories, _, err := client.GetEmojiCategories(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, categories, category)
+
+	emoji, _, err := client.CreateEmoji(context.Background(), &model.Emoji{
+		CreatorId:  th.BasicUser.Id,
+		Name:       "emoji_" + model.NewId()[:10],
+		Aliases:    []string{"alias_" + model.NewId()[:10]},
+		CategoryId: category.Id,
+	}, utils.CreateTestGif(t, 10, 10), "image.gif")
+	require.NoError(t, err)
+
+	t.Run("get an emoji by alias", func(t *testing.T) {
+		received, _, err := client.GetEmojiByName(context.Background(), emoji.Aliases[0])
+		require.NoError(t, err)
+		assert.Equal(t, emoji.Id, received.Id)
+	})
+
+	t.Run("the creator can patch the emoji", func(t *testing.T) {
+		aliases := []string{"alias_" + model.NewId()[:10]}
+		patched, _, err := client.PatchEmoji(context.Background(), emoji.Id, &model.EmojiPatch{Aliases: &aliases})
+		require.NoError(t, err)
+		assert.Equal(t, aliases, patched.Aliases)
+		assert.Equal(t, category.Id, patched.CategoryId)
+		emoji = patched
+	})
+
+	t.Run("other users can't patch the emoji", func(t *testing.T) {
+		_, err := th.Client.Logout(context.Background())
+		require.NoError(t, err)
+		defer th.LoginBasic(t)
+
+		th.LoginBasic2(t)
+		_, resp, err := th.Client.PatchEmoji(context.Background(), emoji.Id, &model.EmojiPatch{CategoryId: model.NewPointer("")})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("aliases can't take the name of another emoji", func(t *testing.T) {
+		_, resp, err := client.CreateEmoji(context.Background(), &model.Emoji{
+			CreatorId: th.BasicUser.Id,
+			Name:      "emoji_" + model.NewId()[:10],
+			Aliases:   []string{emoji.Name},
+		}, utils.CreateTestGif(t, 10, 10), "image.gif")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorID(t, err, "api.emoji.create.duplicate.app_error")
+	})
+
+	t.Run("export and import an emoji pack", func(t *testing.T) {
+		_, resp, err := client.ExportEmojiPack(context.Background())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		pack, _, err := th.SystemAdminClient.ExportEmojiPack(context.Background())
+		require.NoError(t, err)
+
+		_, resp, err = client.ImportEmojiPack(context.Background(), pack, "emojis.zip")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		result, _, err := th.SystemAdminClient.ImportEmojiPack(context.Background(), pack, "emojis.zip")
+		require.NoError(t, err)
+		assert.Empty(t, result.Created)
+		assert.Contains(t, result.Skipped, emoji.Name)
+	})
+
+	t.Run("delete a category", func(t *testing.T) {
+		resp, err := client.DeleteEmojiCategory(context.Background(), category.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, err = th.SystemAdminClient.DeleteEmojiCategory(context.Background(), category.Id)
+		require.NoError(t, err)
+
+		received, _, err := client.GetEmoji(context.Background(), emoji.Id)
+		require.NoError(t, err)
+		assert.Empty(t, received.CategoryId)
+	})
+}
@@ -167,7 +167,7 @@ func createGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if group.Source != model.GroupSourceCustom {
+	if group.Source != model.GroupSourceCustom && group.Source != model.GroupSourceAttribute {
 		c.Err = model.NewAppError("createGroup", "app.group.crud_permission", nil, "", http.StatusBadRequest)
 		return
 	}
@@ -178,12 +178,17 @@ func createGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionCreateCustomGroup) {
-		c.SetPermissionError(model.PermissionCreateCustomGroup)
+	// Attribute groups are managed by system admins as their members are computed, not chosen.
+	requiredPermission := model.PermissionCreateCustomGroup
+	if group.Source == model.GroupSourceAttribute {
+		requiredPermission = model.PermissionSysconsoleWriteUserManagementGroups
+	}
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), requiredPermission) {
+		c.SetPermissionError(requiredPermission)
 		return
 	}
 
-	if !group.AllowReference {
+	if group.Source == model.GroupSourceCustom && !group.AllowReference {
 		c.Err = model.NewAppError("createGroup", "api.custom_groups.must_be_referenceable", nil, "", http.StatusBadRequest)
 		return
 	}
@@ -193,6 +198,18 @@ func createGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if group.Source == model.GroupSourceAttribute {
+		if len(group.UserIds) > 0 {
+			c.Err = model.NewAppError("createGroup", "api.attribute_groups.no_user_ids", nil, "", http.StatusBadRequest)
+			return
+		}
+
+		if appErr := c.App.ValidateGroupMembershipRule(c.AppContext, group.MembershipRule); appErr != nil {
+			c.Err = appErr
+			return
+		}
+	}
+
 	auditRec := c.MakeAuditRecord(model.AuditEventCreateGroup, model.AuditStatusFail)
 	defer c.LogAuditRec(auditRec)
 	model.AddEventParameterAuditableToAuditRec(auditRec, "group", group)
@@ -203,6 +220,12 @@ func createGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if newGroup.Source == model.GroupSourceAttribute {
+		if _, appErr := c.App.ScheduleAttributeGroupSync(c.AppContext, newGroup.Id); appErr != nil {
+			c.Logger.Warn("Failed to schedule attribute group sync", mlog.String("group_id", newGroup.Id), mlog.Err(appErr))
+		}
+	}
+
 	auditRec.AddEventResultState(newGroup)
 	auditRec.AddEventObjectType("group")
 	js, err := json.Marshal(newGroup)
@@ -263,6 +286,14 @@ func patchGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ruleChanged := groupPatch.MembershipRule != nil && *groupPatch.MembershipRule != group.MembershipRule
+	if ruleChanged && group.Source == model.GroupSourceAttribute {
+		if appErr = c.App.ValidateGroupMembershipRule(c.AppContext, *groupPatch.MembershipRule); appErr != nil {
+			c.Err = appErr
+			return
+		}
+	}
+
 	auditRec := c.MakeAuditRecord(model.AuditEventPatchGroup, model.AuditStatusFail)
 	defer c.LogAuditRec(auditRec)
 	model.AddEventParameterAuditableToAuditRec(auditRec, "group", group)
@@ -304,6 +335,12 @@ func patchGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec.AddEventResultState(group)
 	auditRec.AddEventObjectType("group")
 
+	if ruleChanged {
+		if _, appErr = c.App.ScheduleAttributeGroupSync(c.AppContext, group.Id); appErr != nil {
+			c.Logger.Warn("Failed to schedule attribute group sync", mlog.String("group_id", group.Id), mlog.Err(appErr))
+		}
+	}
+
 	b, err := json.Marshal(group)
 	if err != nil {
 		c.Err = model.NewAppError("Api4.patchGroup", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -1247,19 +1284,23 @@ func deleteGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if group.Source != model.GroupSourceCustom {
+	if group.Source != model.GroupSourceCustom && group.Source != model.GroupSourceAttribute {
 		c.Err = model.NewAppError("Api4.deleteGroup", "app.group.crud_permission", nil, "", http.StatusBadRequest)
 		return
 	}
 
-	if lcErr := licensedAndConfiguredForGroupBySource(c.App, model.GroupSourceCustom); lcErr != nil {
+	if lcErr := licensedAndConfiguredForGroupBySource(c.App, group.Source); lcErr != nil {
 		lcErr.Where = "Api4.deleteGroup"
 		c.Err = lcErr
 		return
 	}
 
-	if !c.App.SessionHasPermissionToGroup(*c.AppContext.Session(), c.Params.GroupId, model.PermissionDeleteCustomGroup) {
-		c.SetPermissionError(model.PermissionDeleteCustomGroup)
+	requiredPermission := model.PermissionDeleteCustomGroup
+	if group.Source == model.GroupSourceAttribute {
+		requiredPermission = model.PermissionSysconsoleWriteUserManagementGroups
+	}
+	if !c.App.SessionHasPermissionToGroup(*c.AppContext.Session(), c.Params.GroupId, requiredPermission) {
+		c.SetPermissionError(requiredPermission)
 		return
 	}
 
@@ -1302,19 +1343,23 @@ func restoreGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if group.Source != model.GroupSourceCustom {
+	if group.Source != model.GroupSourceCustom && group.Source != model.GroupSourceAttribute {
 		c.Err = model.NewAppError("Api4.restoreGroup", "app.group.crud_permission", nil, "", http.StatusNotImplemented)
 		return
 	}
 
-	if lcErr := licensedAndConfiguredForGroupBySource(c.App, model.GroupSourceCustom); lcErr != nil {
+	if lcErr := licensedAndConfiguredForGroupBySource(c.App, group.Source); lcErr != nil {
 		lcErr.Where = "Api4.restoreGroup"
 		c.Err = lcErr
 		return
 	}
 
-	if !c.App.SessionHasPermissionToGroup(*c.AppContext.Session(), c.Params.GroupId, model.PermissionRestoreCustomGroup) {
-		c.SetPermissionError(model.PermissionRestoreCustomGroup)
+	requiredPermission := model.PermissionRestoreCustomGroup
+	if group.Source == model.GroupSourceAttribute {
+		requiredPermission = model.PermissionSysconsoleWriteUserManagementGroups
+	}
+	if !c.App.SessionHasPermissionToGroup(*c.AppContext.Session(), c.Params.GroupId, requiredPermission) {
+		c.SetPermissionError(requiredPermission)
 		return
 	}
 
@@ -1328,6 +1373,12 @@ func restoreGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if restoredGroup.Source == model.GroupSourceAttribute {
+		if _, appErr := c.App.ScheduleAttributeGroupSync(c.AppContext, restoredGroup.Id); appErr != nil {
+			c.Logger.Warn("Failed to schedule attribute group sync", mlog.String("group_id", restoredGroup.Id), mlog.Err(appErr))
+		}
+	}
+
 	b, jsonErr := json.Marshal(restoredGroup)
 	if jsonErr != nil {
 		c.Err = model.NewAppError("Api4.restoreGroup", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
@@ -1524,5 +1575,13 @@ func licensedAndConfiguredForGroupBySource(app *app.App, source model.GroupSourc
 		return model.NewAppError("", "api.custom_groups.feature_disabled", nil, "", http.StatusBadRequest)
 	}
 
+	if source == model.GroupSourceAttribute && !model.MinimumEnterpriseAdvancedLicense(lic) {
+		return model.NewAppError("", "api.attribute_groups.license_error", nil, "", http.StatusForbidden)
+	}
+
+	if source == model.GroupSourceAttribute && !*app.Config().AccessControlSettings.EnableAttributeBasedAccessControl {
+		return model.NewAppError("", "api.attribute_groups.feature_disabled", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
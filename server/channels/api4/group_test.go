@@ -5,6 +5,7 @@ package api4
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -12,9 +13,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
 )
 
 func TestGetGroup(t *testing.T) {
@@ -178,6 +181,104 @@ func TestCreateGroup(t *testing.T) {
 	CheckUnauthorizedStatus(t, response)
 }
 
+func TestCreateAttributeGroup(t *testing.T) {
+	th := Setup(t).InitBasic(t)
+
+	rule := `user.attributes.Department == "Engineering"`
+	newGroup := func() *model.Group {
+		id := model.NewId()
+		return &model.Group{
+			DisplayName:    "dn_" + id,
+			Name:           model.NewPointer("name" + id),
+			Source:         model.GroupSourceAttribute,
+			AllowReference: true,
+			MembershipRule: rule,
+		}
+	}
+
+	t.Run("requires an enterprise advanced license", func(t *testing.T) {
+		th.App.Srv().SetLicense(model.NewTestLicenseSKU(model.LicenseShortSkuProfessional, "ldap"))
+
+		_, resp, err := th.SystemAdminClient.CreateGroup(context.Background(), newGroup())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.App.Srv().SetLicense(model.NewTestLicenseSKU(model.LicenseShortSkuEnterpriseAdvanced))
+
+	t.Run("requires attribute based access control", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.AccessControlSettings.EnableAttributeBasedAccessControl = model.NewPointer(false)
+		})
+
+		_, resp, err := th.SystemAdminClient.CreateGroup(context.Background(), newGroup())
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.AccessControlSettings.EnableAttributeBasedAccessControl = model.NewPointer(true)
+	})
+
+	mockAccessControlService := &mocks.AccessControlServiceInterface{}
+	th.App.Srv().Channels().AccessControl = mockAccessControlService
+	mockAccessControlService.On("CheckExpression", mock.AnythingOfType("*request.Context"), rule).Return([]model.CELExpressionError{}, nil)
+	mockAccessControlService.On("CheckExpression", mock.AnythingOfType("*request.Context"), "user.attributes.Department ==").Return([]model.CELExpressionError{{Line: 1, Column: 29, Message: "Syntax error"}}, nil)
+
+	t.Run("regular users cannot create attribute groups", func(t *testing.T) {
+		_, resp, err := th.Client.CreateGroup(context.Background(), newGroup())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("members cannot be set directly", func(t *testing.T) {
+		payload, err := json.Marshal(&model.GroupWithUserIds{
+			Group:   *newGroup(),
+			UserIds: []string{th.BasicUser.Id},
+		})
+		require.NoError(t, err)
+		r, err := th.SystemAdminClient.DoAPIPost(context.Background(), "/groups", string(payload))
+		require.Error(t, err)
+		closeBody(r)
+		require.Equal(t, http.StatusBadRequest, r.StatusCode)
+	})
+
+	t.Run("rejects an invalid rule", func(t *testing.T) {
+		group := newGroup()
+		group.MembershipRule = "user.attributes.Department =="
+		_, resp, err := th.SystemAdminClient.CreateGroup(context.Background(), group)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("creates, patches and deletes an attribute group", func(t *testing.T) {
+		group, resp, err := th.SystemAdminClient.CreateGroup(context.Background(), newGroup())
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, model.GroupSourceAttribute, group.Source)
+		assert.Equal(t, rule, group.MembershipRule)
+
+		_, resp, err = th.SystemAdminClient.UpsertGroupMembers(context.Background(), group.Id, &model.GroupModifyMembers{UserIds: []string{th.BasicUser.Id}})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.PatchGroup(context.Background(), group.Id, &model.GroupPatch{MembershipRule: model.NewPointer("user.attributes.Department ==")})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, _, err = th.SystemAdminClient.DeleteGroup(context.Background(), group.Id)
+		require.NoError(t, err)
+	})
+
+	t.Run("other groups cannot have a membership rule", func(t *testing.T) {
+		group := newGroup()
+		group.Source = model.GroupSourceCustom
+		_, resp, err := th.SystemAdminClient.CreateGroup(context.Background(), group)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestDeleteGroup(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t)
@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func getGuestAccessExpiry(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.Params.UserId != c.AppContext.Session().UserId && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionDemoteToGuest) {
+		c.SetPermissionError(model.PermissionDemoteToGuest)
+		return
+	}
+
+	expiry, appErr := c.App.GetGuestAccessExpiry(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(expiry); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func setGuestAccessExpiry(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	var expiry model.GuestAccessExpiry
+	if jsonErr := json.NewDecoder(r.Body).Decode(&expiry); jsonErr != nil {
+		c.SetInvalidParamWithErr("guest_access_expiry", jsonErr)
+		return
+	}
+	expiry.UserId = c.Params.UserId
+
+	auditRec := c.MakeAuditRecord(model.AuditEventSetGuestAccessExpiry, model.AuditStatusFail)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "guest_access_expiry", &expiry)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionDemoteToGuest) {
+		c.SetPermissionError(model.PermissionDemoteToGuest)
+		return
+	}
+
+	if expiry.ExpiresAt <= 0 {
+		c.SetInvalidParam("expires_at")
+		return
+	}
+
+	savedExpiry, appErr := c.App.SetGuestAccessExpiry(c.AppContext, c.Params.UserId, expiry.ExpiresAt)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(savedExpiry)
+	auditRec.AddEventObjectType("guest_access_expiry")
+
+	if err := json.NewEncoder(w).Encode(savedExpiry); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func removeGuestAccessExpiry(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventRemoveGuestAccessExpiry, model.AuditStatusFail)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionDemoteToGuest) {
+		c.SetPermissionError(model.PermissionDemoteToGuest)
+		return
+	}
+
+	if appErr := c.App.RemoveGuestAccessExpiry(c.Params.UserId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestGuestAccessExpiry(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.GuestAccountsSettings.Enable = true })
+	th.App.Srv().SetLicense(model.NewTestLicense("guest_accounts"))
+
+	guest, guestClient := th.CreateGuestAndClient(t)
+	expiresAt := model.GetMillis() + time.Hour.Milliseconds()
+
+	t.Run("members can't set access expiries", func(t *testing.T) {
+		_, resp, err := th.Client.SetGuestAccessExpiry(context.Background(), guest.Id, expiresAt)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("regular users can't have an access expiry", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.SetGuestAccessExpiry(context.Background(), th.BasicUser.Id, expiresAt)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("set the access expiry of a guest", func(t *testing.T) {
+		expiry, _, err := th.SystemAdminClient.SetGuestAccessExpiry(context.Background(), guest.Id, expiresAt)
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt, expiry.ExpiresAt)
+
+		expiry, _, err = guestClient.GetGuestAccessExpiry(context.Background(), guest.Id)
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt, expiry.ExpiresAt)
+
+		_, resp, err := th.Client.GetGuestAccessExpiry(context.Background(), guest.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("demote a user to a guest with an access expiry", func(t *testing.T) {
+		user := th.CreateUser(t)
+
+		resp, err := th.SystemAdminClient.DemoteUserToGuestWithAccessExpiry(context.Background(), user.Id, model.GetMillis()-1000)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, err = th.SystemAdminClient.DemoteUserToGuestWithAccessExpiry(context.Background(), user.Id, expiresAt)
+		require.NoError(t, err)
+
+		expiry, _, err := th.SystemAdminClient.GetGuestAccessExpiry(context.Background(), user.Id)
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt, expiry.ExpiresAt)
+	})
+
+	t.Run("remove the access expiry of a guest", func(t *testing.T) {
+		_, err := th.SystemAdminClient.RemoveGuestAccessExpiry(context.Background(), guest.Id)
+		require.NoError(t, err)
+
+		_, resp, err := th.SystemAdminClient.GetGuestAccessExpiry(context.Background(), guest.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
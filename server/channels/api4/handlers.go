@@ -22,6 +22,11 @@ type APIHandlerOption string
 
 const (
 	handlerParamFileAPI = APIHandlerOption("fileAPI")
+
+	handlerParamRateLimitLogin      = APIHandlerOption("rateLimitLogin")
+	handlerParamRateLimitSearch     = APIHandlerOption("rateLimitSearch")
+	handlerParamRateLimitFileUpload = APIHandlerOption("rateLimitFileUpload")
+	handlerParamRateLimitPostCreate = APIHandlerOption("rateLimitPostCreate")
 )
 
 // APIHandler provides a handler for API endpoints which do not require the user to be logged in order for access to be
@@ -247,6 +252,14 @@ func setHandlerOpts(handler *web.Handler, opts ...APIHandlerOption) {
 		switch option {
 		case handlerParamFileAPI:
 			handler.FileAPI = true
+		case handlerParamRateLimitLogin:
+			handler.RateLimitRouteGroup = model.RateLimitRouteGroupLogin
+		case handlerParamRateLimitSearch:
+			handler.RateLimitRouteGroup = model.RateLimitRouteGroupSearch
+		case handlerParamRateLimitFileUpload:
+			handler.RateLimitRouteGroup = model.RateLimitRouteGroupFileUpload
+		case handlerParamRateLimitPostCreate:
+			handler.RateLimitRouteGroup = model.RateLimitRouteGroupPostCreate
 		}
 	}
 }
@@ -33,6 +33,7 @@ func (api *API) InitPlugin() {
 	api.BaseRoutes.Plugins.Handle("/statuses", api.APISessionRequired(getPluginStatuses)).Methods(http.MethodGet)
 	api.BaseRoutes.Plugin.Handle("/enable", api.APISessionRequired(enablePlugin)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugin.Handle("/disable", api.APISessionRequired(disablePlugin)).Methods(http.MethodPost)
+	api.BaseRoutes.Plugin.Handle("/permissions/approve", api.APISessionRequired(approvePluginPermissions)).Methods(http.MethodPost)
 
 	api.BaseRoutes.Plugins.Handle("/webapp", api.APIHandler(getWebappPlugins)).Methods(http.MethodGet)
 
@@ -349,6 +350,43 @@ func enablePlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func approvePluginPermissions(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePluginId()
+	if c.Err != nil {
+		return
+	}
+
+	if !*c.App.Config().PluginSettings.Enable {
+		c.Err = model.NewAppError("approvePluginPermissions", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventApprovePluginPermissions, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "plugin_id", c.Params.PluginId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWritePlugins) {
+		c.SetPermissionError(model.PermissionSysconsoleWritePlugins)
+		return
+	}
+
+	priorPermissions, approvedPermissions, err := c.App.ApprovePluginPermissions(c.Params.PluginId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if priorPermissions != nil {
+		auditRec.AddEventPriorState(priorPermissions)
+	}
+	if approvedPermissions != nil {
+		auditRec.AddEventResultState(approvedPermissions)
+	}
+	auditRec.AddEventObjectType("plugin_permissions")
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
 func disablePlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePluginId()
 	if c.Err != nil {
@@ -20,6 +20,14 @@ func (api *API) InitSharedChannels() {
 	api.BaseRoutes.SharedChannelRemotes.Handle("", api.APISessionRequired(getSharedChannelRemotesByRemoteCluster)).Methods(http.MethodGet)
 	api.BaseRoutes.ChannelForRemote.Handle("/invite", api.APISessionRequired(inviteRemoteClusterToChannel)).Methods(http.MethodPost)
 	api.BaseRoutes.ChannelForRemote.Handle("/uninvite", api.APISessionRequired(uninviteRemoteClusterToChannel)).Methods(http.MethodPost)
+
+	api.BaseRoutes.RemoteCluster.Handle("/{remote_id:[A-Za-z0-9]+}/content_policy", api.APISessionRequired(getSharedChannelContentPolicy)).Methods(http.MethodGet)
+	api.BaseRoutes.RemoteCluster.Handle("/{remote_id:[A-Za-z0-9]+}/content_policy", api.APISessionRequired(updateSharedChannelContentPolicy)).Methods(http.MethodPut)
+	api.BaseRoutes.RemoteCluster.Handle("/{remote_id:[A-Za-z0-9]+}/content_policy", api.APISessionRequired(deleteSharedChannelContentPolicy)).Methods(http.MethodDelete)
+	api.BaseRoutes.RemoteCluster.Handle("/{remote_id:[A-Za-z0-9]+}/content_policy/log", api.APISessionRequired(getSharedChannelFilterLog)).Methods(http.MethodGet)
+	api.BaseRoutes.RemoteCluster.Handle("/{remote_id:[A-Za-z0-9]+}/held_posts", api.APISessionRequired(getSharedChannelHeldPosts)).Methods(http.MethodGet)
+	api.BaseRoutes.RemoteCluster.Handle("/{remote_id:[A-Za-z0-9]+}/held_posts/{post_id:[A-Za-z0-9]+}/approve", api.APISessionRequired(approveSharedChannelHeldPost)).Methods(http.MethodPost)
+	api.BaseRoutes.RemoteCluster.Handle("/{remote_id:[A-Za-z0-9]+}/held_posts/{post_id:[A-Za-z0-9]+}/reject", api.APISessionRequired(rejectSharedChannelHeldPost)).Methods(http.MethodPost)
 }
 
 func getSharedChannels(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,195 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// requireRemoteForContentPolicy checks the remote id, the session's permission and that the
+// remote cluster exists.
+func requireRemoteForContentPolicy(c *Context, permission *model.Permission) {
+	c.RequireRemoteId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), permission) {
+		c.SetPermissionError(permission)
+		return
+	}
+
+	// make sure remote cluster service is enabled.
+	if _, appErr := c.App.GetRemoteClusterService(); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if _, appErr := c.App.GetRemoteCluster(c.Params.RemoteId, true); appErr != nil {
+		c.SetInvalidRemoteIdError(c.Params.RemoteId)
+		return
+	}
+}
+
+func getSharedChannelContentPolicy(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireRemoteForContentPolicy(c, model.PermissionManageSecureConnections)
+	if c.Err != nil {
+		return
+	}
+
+	policy, appErr := c.App.GetSharedChannelContentPolicy(c.Params.RemoteId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(policy); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateSharedChannelContentPolicy(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireRemoteForContentPolicy(c, model.PermissionManageSecureConnections)
+	if c.Err != nil {
+		return
+	}
+
+	var policy model.SharedChannelContentPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		c.SetInvalidParamWithErr("content_policy", err)
+		return
+	}
+	policy.RemoteId = c.Params.RemoteId
+	policy.CreatorId = c.AppContext.Session().UserId
+
+	auditRec := c.MakeAuditRecord(model.AuditEventUpdateSharedChannelContentPolicy, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "content_policy", &policy)
+
+	saved, appErr := c.App.SaveSharedChannelContentPolicy(&policy)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(saved)
+	auditRec.AddEventObjectType("sharedchannelcontentpolicy")
+
+	if err := json.NewEncoder(w).Encode(saved); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteSharedChannelContentPolicy(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireRemoteForContentPolicy(c, model.PermissionManageSecureConnections)
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventDeleteSharedChannelContentPolicy, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "remote_id", c.Params.RemoteId)
+
+	deleted, appErr := c.App.DeleteSharedChannelContentPolicy(c.Params.RemoteId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	// if the remote has no policy, there is nothing to delete.
+	if !deleted {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func getSharedChannelFilterLog(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireRemoteForContentPolicy(c, model.PermissionManageSharedChannels)
+	if c.Err != nil {
+		return
+	}
+
+	entries, appErr := c.App.GetSharedChannelFilterLog(c.Params.RemoteId, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getSharedChannelHeldPosts(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireRemoteForContentPolicy(c, model.PermissionManageSharedChannels)
+	if c.Err != nil {
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", model.SharedChannelHeldPostStatusPending, model.SharedChannelHeldPostStatusApproved, model.SharedChannelHeldPostStatusRejected:
+	default:
+		c.SetInvalidURLParam("status")
+		return
+	}
+
+	held, appErr := c.App.GetSharedChannelHeldPosts(c.Params.RemoteId, status, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(held); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func approveSharedChannelHeldPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	reviewSharedChannelHeldPost(c, w, true)
+}
+
+func rejectSharedChannelHeldPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	reviewSharedChannelHeldPost(c, w, false)
+}
+
+func reviewSharedChannelHeldPost(c *Context, w http.ResponseWriter, approve bool) {
+	requireRemoteForContentPolicy(c, model.PermissionManageSharedChannels)
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventReviewSharedChannelHeldPost, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "remote_id", c.Params.RemoteId)
+	model.AddEventParameterToAuditRec(auditRec, "post_id", c.Params.PostId)
+	model.AddEventParameterToAuditRec(auditRec, "approve", approve)
+
+	held, appErr := c.App.ReviewSharedChannelHeldPost(c.Params.PostId, c.Params.RemoteId, c.AppContext.Session().UserId, approve)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(held)
+	auditRec.AddEventObjectType("sharedchannelheldpost")
+
+	if err := json.NewEncoder(w).Encode(held); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
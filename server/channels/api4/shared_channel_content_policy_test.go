@@ -0,0 +1,160 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSharedChannelContentPolicy(t *testing.T) {
+	mainHelper.Parallel(t)
+	t.Run("Should not work if the remote cluster service is not enabled", func(t *testing.T) {
+		th := Setup(t)
+
+		_, resp, err := th.SystemAdminClient.GetSharedChannelContentPolicy(context.Background(), model.NewId())
+		CheckNotImplementedStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	th := setupForSharedChannels(t).InitBasic(t)
+
+	rc, appErr := th.App.AddRemoteCluster(&model.RemoteCluster{Name: "rc", SiteURL: "http://example.com", CreatorId: th.SystemAdminUser.Id})
+	require.Nil(t, appErr)
+
+	policy := &model.SharedChannelContentPolicy{
+		BlockedFileTypes: model.StringArray{"exe"},
+		MaxFileSize:      1024 * 1024,
+		RedactPatterns:   model.StringArray{`TICKET-\d+`},
+		StripPermalinks:  true,
+	}
+
+	t.Run("Should not work if the user doesn't have the right permissions", func(t *testing.T) {
+		_, resp, err := th.Client.UpdateSharedChannelContentPolicy(context.Background(), rc.RemoteId, policy)
+		CheckForbiddenStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("Should not work if the remote cluster is nonexistent", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.UpdateSharedChannelContentPolicy(context.Background(), model.NewId(), policy)
+		CheckBadRequestStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("Should return not found if the remote has no policy", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetSharedChannelContentPolicy(context.Background(), rc.RemoteId)
+		CheckNotFoundStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("Should reject an invalid policy", func(t *testing.T) {
+		invalid := &model.SharedChannelContentPolicy{RedactPatterns: model.StringArray{"TICKET-("}}
+		_, resp, err := th.SystemAdminClient.UpdateSharedChannelContentPolicy(context.Background(), rc.RemoteId, invalid)
+		CheckBadRequestStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("Should save, get and delete a policy", func(t *testing.T) {
+		saved, _, err := th.SystemAdminClient.UpdateSharedChannelContentPolicy(context.Background(), rc.RemoteId, policy)
+		require.NoError(t, err)
+		assert.Equal(t, rc.RemoteId, saved.RemoteId)
+		assert.Equal(t, th.SystemAdminUser.Id, saved.CreatorId)
+		assert.Equal(t, model.SharedChannelContentPolicyDefaultReplacement, saved.RedactReplacement)
+
+		fetched, _, err := th.SystemAdminClient.GetSharedChannelContentPolicy(context.Background(), rc.RemoteId)
+		require.NoError(t, err)
+		assert.Equal(t, saved, fetched)
+
+		resp, err := th.SystemAdminClient.DeleteSharedChannelContentPolicy(context.Background(), rc.RemoteId)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		resp, err = th.SystemAdminClient.DeleteSharedChannelContentPolicy(context.Background(), rc.RemoteId)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
+
+func TestReviewSharedChannelHeldPost(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := setupForSharedChannels(t).InitBasic(t)
+
+	rc, appErr := th.App.AddRemoteCluster(&model.RemoteCluster{Name: "rc", SiteURL: "http://example.com", CreatorId: th.SystemAdminUser.Id})
+	require.Nil(t, appErr)
+
+	holdPost := func(post *model.Post) {
+		t.Helper()
+		_, err := th.App.Srv().Store().SharedChannel().SaveHeldPost(&model.SharedChannelHeldPost{
+			PostId:    post.Id,
+			RemoteId:  rc.RemoteId,
+			ChannelId: post.ChannelId,
+			UserId:    post.UserId,
+		})
+		require.NoError(t, err)
+	}
+
+	approved := th.CreatePost(t)
+	holdPost(approved)
+	rejected := th.CreatePost(t)
+	holdPost(rejected)
+
+	t.Run("Should not work if the user doesn't have the right permissions", func(t *testing.T) {
+		_, resp, err := th.Client.GetSharedChannelHeldPosts(context.Background(), rc.RemoteId, "", 0, 10)
+		CheckForbiddenStatus(t, resp)
+		require.Error(t, err)
+
+		_, resp, err = th.Client.ApproveSharedChannelHeldPost(context.Background(), rc.RemoteId, approved.Id)
+		CheckForbiddenStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("Should list pending posts", func(t *testing.T) {
+		held, _, err := th.SystemAdminClient.GetSharedChannelHeldPosts(context.Background(), rc.RemoteId, model.SharedChannelHeldPostStatusPending, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, held, 2)
+		assert.ElementsMatch(t, []string{approved.Id, rejected.Id}, []string{held[0].PostId, held[1].PostId})
+
+		_, resp, err := th.SystemAdminClient.GetSharedChannelHeldPosts(context.Background(), rc.RemoteId, "unknown", 0, 10)
+		CheckBadRequestStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("Should approve and reject held posts", func(t *testing.T) {
+		held, _, err := th.SystemAdminClient.ApproveSharedChannelHeldPost(context.Background(), rc.RemoteId, approved.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.SharedChannelHeldPostStatusApproved, held.Status)
+		assert.Equal(t, th.SystemAdminUser.Id, held.ReviewerId)
+
+		held, _, err = th.SystemAdminClient.RejectSharedChannelHeldPost(context.Background(), rc.RemoteId, rejected.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.SharedChannelHeldPostStatusRejected, held.Status)
+
+		pending, _, err := th.SystemAdminClient.GetSharedChannelHeldPosts(context.Background(), rc.RemoteId, model.SharedChannelHeldPostStatusPending, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		log, _, err := th.SystemAdminClient.GetSharedChannelFilterLog(context.Background(), rc.RemoteId, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, log, 2)
+		assert.ElementsMatch(t, []string{model.SharedChannelFilterActionApprove, model.SharedChannelFilterActionReject}, []string{log[0].Action, log[1].Action})
+	})
+
+	t.Run("Should not review a post twice", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.RejectSharedChannelHeldPost(context.Background(), rc.RemoteId, approved.Id)
+		CheckBadRequestStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("Should return not found for a post that is not held", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.ApproveSharedChannelHeldPost(context.Background(), rc.RemoteId, th.BasicPost.Id)
+		CheckNotFoundStatus(t, resp)
+		require.Error(t, err)
+	})
+}
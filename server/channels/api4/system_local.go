@@ -20,6 +20,9 @@ func (api *API) InitSystemLocal() {
 	api.BaseRoutes.System.Handle("/support_packet", api.APILocal(generateSupportPacket)).Methods(http.MethodGet)
 	api.BaseRoutes.APIRoot.Handle("/integrity", api.APILocal(localCheckIntegrity)).Methods(http.MethodPost)
 	api.BaseRoutes.System.Handle("/schema/version", api.APILocal(getAppliedSchemaMigrations)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/store_faults", api.APILocal(localGetStoreFaults)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/store_faults", api.APILocal(localSetStoreFaults)).Methods(http.MethodPut)
+	api.BaseRoutes.APIRoot.Handle("/store_faults", api.APILocal(localClearStoreFaults)).Methods(http.MethodDelete)
 }
 
 func localCheckIntegrity(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -43,3 +46,50 @@ func localCheckIntegrity(c *Context, w http.ResponseWriter, r *http.Request) {
 		c.Logger.Warn("Failed to write response", mlog.Err(err))
 	}
 }
+
+func localGetStoreFaults(c *Context, w http.ResponseWriter, r *http.Request) {
+	faults, appErr := c.App.GetStoreFaults()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(faults); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func localSetStoreFaults(c *Context, w http.ResponseWriter, r *http.Request) {
+	var faults []*model.StoreFault
+	if err := json.NewDecoder(r.Body).Decode(&faults); err != nil {
+		c.SetInvalidParamWithErr("store_faults", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventLocalSetStoreFaults, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterAuditableArrayToAuditRec(auditRec, "store_faults", faults)
+
+	if appErr := c.App.SetStoreFaults(faults); appErr != nil {
+		c.Err = appErr
+		return
+	}
+	c.Logger.Warn("Store faults are being injected", mlog.Int("count", len(faults)))
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func localClearStoreFaults(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord(model.AuditEventLocalClearStoreFaults, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+
+	if appErr := c.App.SetStoreFaults(nil); appErr != nil {
+		c.Err = appErr
+		return
+	}
+	c.Logger.Info("Store faults cleared")
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
@@ -916,6 +916,27 @@ func TestServerBusy503(t *testing.T) {
 	})
 }
 
+func TestStoreFaults(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+
+	t.Run("only available in local mode", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetStoreFaults(context.Background())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("not implemented without the fault-injection layer", func(t *testing.T) {
+		_, resp, err := th.LocalClient.GetStoreFaults(context.Background())
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+
+		resp, err = th.LocalClient.SetStoreFaults(context.Background(), []*model.StoreFault{{Method: "PostStore.Save", ErrorRate: 1}})
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
+
 func TestPushNotificationAck(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic(t)
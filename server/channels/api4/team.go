@@ -46,6 +46,12 @@ func (api *API) InitTeam() {
 	api.BaseRoutes.Team.Handle("/privacy", api.APISessionRequired(updateTeamPrivacy)).Methods(http.MethodPut)
 	api.BaseRoutes.Team.Handle("/stats", api.APISessionRequired(getTeamStats)).Methods(http.MethodGet)
 	api.BaseRoutes.Team.Handle("/regenerate_invite_id", api.APISessionRequired(regenerateTeamInviteId)).Methods(http.MethodPost)
+	api.BaseRoutes.Team.Handle("/invite_links", api.APISessionRequired(createTeamInviteLink)).Methods(http.MethodPost)
+	api.BaseRoutes.Team.Handle("/invite_links", api.APISessionRequired(getTeamInviteLinks)).Methods(http.MethodGet)
+	api.BaseRoutes.Team.Handle("/invite_links/{invite_link_id:[A-Za-z0-9]+}", api.APISessionRequired(getTeamInviteLink)).Methods(http.MethodGet)
+	api.BaseRoutes.Team.Handle("/invite_links/{invite_link_id:[A-Za-z0-9]+}/patch", api.APISessionRequired(patchTeamInviteLink)).Methods(http.MethodPut)
+	api.BaseRoutes.Team.Handle("/invite_links/{invite_link_id:[A-Za-z0-9]+}", api.APISessionRequired(revokeTeamInviteLink)).Methods(http.MethodDelete)
+	api.BaseRoutes.Team.Handle("/invite_links/{invite_link_id:[A-Za-z0-9]+}/uses", api.APISessionRequired(getTeamInviteLinkUses)).Methods(http.MethodGet)
 
 	api.BaseRoutes.Team.Handle("/image", api.APISessionRequiredTrustRequester(getTeamIcon)).Methods(http.MethodGet)
 	api.BaseRoutes.Team.Handle("/image", api.APISessionRequired(setTeamIcon, handlerParamFileAPI)).Methods(http.MethodPost)
@@ -877,11 +883,7 @@ func addUserToTeamFromInvite(c *Context, w http.ResponseWriter, r *http.Request)
 	if tokenId != "" {
 		member, err = c.App.AddTeamMemberByToken(c.AppContext, c.AppContext.Session().UserId, tokenId)
 	} else if inviteId != "" {
-		if c.AppContext.Session().Props[model.SessionPropIsGuest] == "true" {
-			c.Err = model.NewAppError("addUserToTeamFromInvite", "api.team.add_user_to_team_from_invite.guest.app_error", nil, "", http.StatusForbidden)
-			return
-		}
-
+		// Guests are rejected by the app unless the invite id is a guest invite link.
 		member, err = c.App.AddTeamMemberByInviteId(c.AppContext, inviteId, c.AppContext.Session().UserId)
 	} else {
 		err = model.NewAppError("addTeamMember", "api.team.add_user_to_team.missing_parameter.app_error", nil, "", http.StatusBadRequest)
@@ -0,0 +1,234 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// checkTeamInviteLinkPermissions requires the permissions needed to manage the invite links of
+// the team, and the permission to invite guests for guest links.
+func checkTeamInviteLinkPermissions(c *Context, role string) bool {
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionManageTeam) {
+		c.SetPermissionError(model.PermissionManageTeam)
+		return false
+	}
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionInviteUser) {
+		c.SetPermissionError(model.PermissionInviteUser)
+		return false
+	}
+	if role == model.TeamInviteLinkRoleGuest && !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionInviteGuest) {
+		c.SetPermissionError(model.PermissionInviteGuest)
+		return false
+	}
+	return true
+}
+
+// getTeamInviteLinkFromParams returns the invite link of the URL, making sure it belongs to the
+// team of the URL.
+func getTeamInviteLinkFromParams(c *Context) *model.TeamInviteLink {
+	link, appErr := c.App.GetTeamInviteLink(c.Params.InviteLinkId)
+	if appErr != nil {
+		c.Err = appErr
+		return nil
+	}
+
+	if link.TeamId != c.Params.TeamId {
+		c.Err = model.NewAppError("getTeamInviteLinkFromParams", "app.team.invite_link.not_found.app_error", nil, "", http.StatusNotFound)
+		return nil
+	}
+
+	return link
+}
+
+func createTeamInviteLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	var link model.TeamInviteLink
+	if jsonErr := json.NewDecoder(r.Body).Decode(&link); jsonErr != nil {
+		c.SetInvalidParamWithErr("invite_link", jsonErr)
+		return
+	}
+
+	link.Id = ""
+	link.TeamId = c.Params.TeamId
+	link.CreatorId = c.AppContext.Session().UserId
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCreateTeamInviteLink, model.AuditStatusFail)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "invite_link", &link)
+	defer c.LogAuditRec(auditRec)
+
+	if !checkTeamInviteLinkPermissions(c, link.Role) {
+		return
+	}
+
+	savedLink, appErr := c.App.CreateTeamInviteLink(c.AppContext, &link)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(savedLink)
+	auditRec.AddEventObjectType("team_invite_link")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(savedLink); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getTeamInviteLinks(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !checkTeamInviteLinkPermissions(c, model.TeamInviteLinkRoleMember) {
+		return
+	}
+
+	links, appErr := c.App.GetTeamInviteLinks(c.Params.TeamId, c.Params.IncludeDeleted)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(links); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getTeamInviteLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId().RequireInviteLinkId()
+	if c.Err != nil {
+		return
+	}
+
+	if !checkTeamInviteLinkPermissions(c, model.TeamInviteLinkRoleMember) {
+		return
+	}
+
+	link := getTeamInviteLinkFromParams(c)
+	if c.Err != nil {
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(link); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func patchTeamInviteLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId().RequireInviteLinkId()
+	if c.Err != nil {
+		return
+	}
+
+	var patch model.TeamInviteLinkPatch
+	if jsonErr := json.NewDecoder(r.Body).Decode(&patch); jsonErr != nil {
+		c.SetInvalidParamWithErr("invite_link_patch", jsonErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventPatchTeamInviteLink, model.AuditStatusFail)
+	model.AddEventParameterToAuditRec(auditRec, "invite_link_id", c.Params.InviteLinkId)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "invite_link_patch", &patch)
+	defer c.LogAuditRec(auditRec)
+
+	if !checkTeamInviteLinkPermissions(c, model.TeamInviteLinkRoleMember) {
+		return
+	}
+
+	link := getTeamInviteLinkFromParams(c)
+	if c.Err != nil {
+		return
+	}
+	auditRec.AddEventPriorState(link)
+
+	if !checkTeamInviteLinkPermissions(c, link.Role) {
+		return
+	}
+
+	patchedLink, appErr := c.App.PatchTeamInviteLink(c.AppContext, link.Id, &patch, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(patchedLink)
+	auditRec.AddEventObjectType("team_invite_link")
+
+	if err := json.NewEncoder(w).Encode(patchedLink); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func revokeTeamInviteLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId().RequireInviteLinkId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventRevokeTeamInviteLink, model.AuditStatusFail)
+	model.AddEventParameterToAuditRec(auditRec, "team_id", c.Params.TeamId)
+	model.AddEventParameterToAuditRec(auditRec, "invite_link_id", c.Params.InviteLinkId)
+	defer c.LogAuditRec(auditRec)
+
+	if !checkTeamInviteLinkPermissions(c, model.TeamInviteLinkRoleMember) {
+		return
+	}
+
+	link := getTeamInviteLinkFromParams(c)
+	if c.Err != nil {
+		return
+	}
+	auditRec.AddEventPriorState(link)
+
+	revokedLink, appErr := c.App.RevokeTeamInviteLink(link.Id)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(revokedLink)
+	auditRec.AddEventObjectType("team_invite_link")
+
+	ReturnStatusOK(w)
+}
+
+func getTeamInviteLinkUses(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId().RequireInviteLinkId()
+	if c.Err != nil {
+		return
+	}
+
+	if !checkTeamInviteLinkPermissions(c, model.TeamInviteLinkRoleMember) {
+		return
+	}
+
+	link := getTeamInviteLinkFromParams(c)
+	if c.Err != nil {
+		return
+	}
+
+	uses, appErr := c.App.GetTeamInviteLinkUses(link.Id, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(uses); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestTeamInviteLinks(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	link := &model.TeamInviteLink{
+		TeamId:     th.BasicTeam.Id,
+		Name:       "Conference",
+		ChannelIds: model.StringArray{th.BasicChannel.Id},
+		MaxUses:    2,
+	}
+
+	t.Run("members can't manage invite links", func(t *testing.T) {
+		_, resp, err := th.Client.CreateTeamInviteLink(context.Background(), link)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetTeamInviteLinks(context.Background(), th.BasicTeam.Id, false)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	createdLink, resp, err := th.SystemAdminClient.CreateTeamInviteLink(context.Background(), link)
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.SystemAdminUser.Id, createdLink.CreatorId)
+	assert.Equal(t, model.TeamInviteLinkRoleMember, createdLink.Role)
+
+	t.Run("join through the link", func(t *testing.T) {
+		client := th.CreateClient()
+		user := th.CreateUser(t)
+		_, _, err := client.Login(context.Background(), user.Email, user.Password)
+		require.NoError(t, err)
+
+		member, _, err := client.AddTeamMemberFromInvite(context.Background(), "", createdLink.Id)
+		require.NoError(t, err)
+		assert.Equal(t, user.Id, member.UserId)
+
+		uses, _, err := th.SystemAdminClient.GetTeamInviteLinkUses(context.Background(), th.BasicTeam.Id, createdLink.Id, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, uses, 1)
+		assert.Equal(t, user.Id, uses[0].UserId)
+	})
+
+	t.Run("patch the link", func(t *testing.T) {
+		patchedLink, _, err := th.SystemAdminClient.PatchTeamInviteLink(context.Background(), th.BasicTeam.Id, createdLink.Id, &model.TeamInviteLinkPatch{Name: model.NewPointer("Workshop")})
+		require.NoError(t, err)
+		assert.Equal(t, "Workshop", patchedLink.Name)
+		assert.EqualValues(t, 1, patchedLink.UseCount)
+	})
+
+	t.Run("link of another team", func(t *testing.T) {
+		otherTeam := th.CreateTeam(t)
+		_, resp, err := th.SystemAdminClient.GetTeamInviteLink(context.Background(), otherTeam.Id, createdLink.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("revoke the link", func(t *testing.T) {
+		_, err := th.SystemAdminClient.RevokeTeamInviteLink(context.Background(), th.BasicTeam.Id, createdLink.Id)
+		require.NoError(t, err)
+
+		links, _, err := th.SystemAdminClient.GetTeamInviteLinks(context.Background(), th.BasicTeam.Id, false)
+		require.NoError(t, err)
+		assert.Empty(t, links)
+
+		links, _, err = th.SystemAdminClient.GetTeamInviteLinks(context.Background(), th.BasicTeam.Id, true)
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.NotZero(t, links[0].DeleteAt)
+
+		client := th.CreateClient()
+		user := th.CreateUser(t)
+		_, _, err = client.Login(context.Background(), user.Email, user.Password)
+		require.NoError(t, err)
+
+		_, resp, err := client.AddTeamMemberFromInvite(context.Background(), "", createdLink.Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
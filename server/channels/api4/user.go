@@ -49,6 +49,9 @@ func (api *API) InitUser() {
 	api.BaseRoutes.User.Handle("/password", api.APISessionRequired(updatePassword)).Methods(http.MethodPut)
 	api.BaseRoutes.User.Handle("/promote", api.APISessionRequired(promoteGuestToUser)).Methods(http.MethodPost)
 	api.BaseRoutes.User.Handle("/demote", api.APISessionRequired(demoteUserToGuest)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/guest_access_expiry", api.APISessionRequired(getGuestAccessExpiry)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/guest_access_expiry", api.APISessionRequired(setGuestAccessExpiry)).Methods(http.MethodPut)
+	api.BaseRoutes.User.Handle("/guest_access_expiry", api.APISessionRequired(removeGuestAccessExpiry)).Methods(http.MethodDelete)
 	api.BaseRoutes.User.Handle("/convert_to_bot", api.APISessionRequired(convertUserToBot)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/password/reset", api.APIHandler(resetPassword)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/password/reset/send", api.APIHandler(sendPasswordReset)).Methods(http.MethodPost)
@@ -64,10 +67,12 @@ func (api *API) InitUser() {
 	api.BaseRoutes.User.Handle("/mfa", api.APISessionRequiredMfa(updateUserMfa)).Methods(http.MethodPut)
 	api.BaseRoutes.User.Handle("/mfa/generate", api.APISessionRequiredMfa(generateMfaSecret)).Methods(http.MethodPost)
 
-	api.BaseRoutes.Users.Handle("/login", api.APIHandler(login)).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/login", api.APIHandler(login, handlerParamRateLimitLogin)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/login/sso/code-exchange", api.APIHandler(loginSSOCodeExchange)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/login/desktop_token", api.RateLimitedHandler(api.APIHandler(loginWithDesktopToken), model.RateLimitSettings{PerSec: model.NewPointer(2), MaxBurst: model.NewPointer(1)})).Methods(http.MethodPost)
-	api.BaseRoutes.Users.Handle("/login/switch", api.APIHandler(switchAccountType)).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/login/magic_link", api.APIHandler(loginWithMagicLink, handlerParamRateLimitLogin)).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/login/magic_link/send", api.APIHandler(sendMagicLink, handlerParamRateLimitLogin)).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/login/switch", api.APIHandler(switchAccountType, handlerParamRateLimitLogin)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/login/cws", api.APIHandlerTrustRequester(loginCWS)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/logout", api.APIHandler(logout)).Methods(http.MethodPost)
 
@@ -75,6 +80,7 @@ func (api *API) InitUser() {
 	api.BaseRoutes.UserByEmail.Handle("", api.APISessionRequired(getUserByEmail)).Methods(http.MethodGet)
 
 	api.BaseRoutes.User.Handle("/sessions", api.APISessionRequired(getSessions)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/sessions/details", api.APISessionRequired(getSessionDetails)).Methods(http.MethodGet)
 	api.BaseRoutes.User.Handle("/sessions/revoke", api.APISessionRequired(revokeSession)).Methods(http.MethodPost)
 	api.BaseRoutes.User.Handle("/sessions/revoke/all", api.APISessionRequired(revokeAllSessionsForUser)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/sessions/revoke/all", api.APISessionRequired(revokeAllSessionsAllUsers)).Methods(http.MethodPost)
@@ -2107,6 +2113,91 @@ func loginWithDesktopToken(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func sendMagicLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	props := model.MapFromJSON(r.Body)
+
+	email := strings.ToLower(props["email"])
+	if email == "" {
+		c.SetInvalidParam("email")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventSendMagicLink, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "email", email)
+
+	sent, err := c.App.SendMagicLink(c.AppContext, email, c.App.GetSiteURL())
+	if err != nil {
+		if *c.App.Config().ServiceSettings.ExperimentalEnableHardenedMode && err.StatusCode != http.StatusTooManyRequests {
+			ReturnStatusOK(w)
+		} else {
+			c.Err = err
+		}
+		return
+	}
+
+	if sent {
+		auditRec.Success()
+		c.LogAudit("sent=" + email)
+	}
+	ReturnStatusOK(w)
+}
+
+func loginWithMagicLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	props := model.MapFromJSON(r.Body)
+
+	token := props["token"]
+	if len(token) != model.TokenSize {
+		c.SetInvalidParam("token")
+		return
+	}
+	mfaToken := props["mfa_token"]
+	deviceId := props["device_id"]
+
+	auditRec := c.MakeAuditRecord(model.AuditEventLoginWithMagicLink, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "device_id", deviceId)
+
+	user, err := c.App.AuthenticateUserWithMagicLink(c.AppContext, token, mfaToken)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	auditRec.AddEventResultState(user)
+
+	if user.IsGuest() {
+		if c.App.Channels().License() == nil {
+			c.Err = model.NewAppError("loginWithMagicLink", "api.user.login.guest_accounts.license.error", nil, "", http.StatusUnauthorized)
+			return
+		}
+		if !*c.App.Config().GuestAccountsSettings.Enable {
+			c.Err = model.NewAppError("loginWithMagicLink", "api.user.login.guest_accounts.disabled.error", nil, "", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	isMobileDevice := utils.IsMobileRequest(r)
+	session, err := c.App.DoLogin(c.AppContext, w, r, user, deviceId, isMobileDevice, false, false)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	c.AppContext = c.AppContext.WithSession(session)
+
+	c.LogAuditWithUserId(user.Id, "success")
+
+	if r.Header.Get(model.HeaderRequestedWith) == model.HeaderRequestedWithXML {
+		c.App.AttachSessionCookies(c.AppContext, w, r)
+	}
+
+	user.Sanitize(map[string]bool{})
+
+	auditRec.Success()
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func loginCWS(c *Context, w http.ResponseWriter, r *http.Request) {
 	campaignToURL := map[string]string{
 		"focalboard": "/boards",
@@ -2239,6 +2330,28 @@ func getSessions(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func getSessionDetails(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadUserManagementUsers) {
+		c.SetPermissionError(model.PermissionSysconsoleReadUserManagementUsers)
+		return
+	}
+
+	details, appErr := c.App.GetSessionDetails(c.AppContext, c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(details); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func revokeSession(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
@@ -2982,6 +3095,16 @@ func demoteUserToGuest(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var accessExpiresAt int64
+	if accessExpiresAtString := r.URL.Query().Get("access_expires_at"); accessExpiresAtString != "" {
+		var parseErr error
+		accessExpiresAt, parseErr = strconv.ParseInt(accessExpiresAtString, 10, 64)
+		if parseErr != nil {
+			c.SetInvalidParamWithErr("access_expires_at", parseErr)
+			return
+		}
+	}
+
 	if c.App.Channels().License() == nil {
 		c.Err = model.NewAppError("Api4.demoteUserToGuest", "api.team.demote_user_to_guest.license.error", nil, "", http.StatusNotImplemented)
 		return
@@ -3001,6 +3124,7 @@ func demoteUserToGuest(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	auditRec := c.MakeAuditRecord(model.AuditEventDemoteUserToGuest, model.AuditStatusFail)
 	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+	model.AddEventParameterToAuditRec(auditRec, "access_expires_at", accessExpiresAt)
 	defer c.LogAuditRec(auditRec)
 
 	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionDemoteToGuest) {
@@ -3026,7 +3150,7 @@ func demoteUserToGuest(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := c.App.DemoteUserToGuest(c.AppContext, user); err != nil {
+	if err := c.App.DemoteUserToGuestWithAccessExpiry(c.AppContext, user, accessExpiresAt); err != nil {
 		c.Err = err
 		return
 	}
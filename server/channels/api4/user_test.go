@@ -3840,6 +3840,27 @@ func TestGetSessions(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetSessionDetails(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	_, _, err := th.Client.Login(context.Background(), th.BasicUser.Email, th.BasicUser.Password)
+	require.NoError(t, err)
+
+	_, resp, err := th.Client.GetSessionDetails(context.Background(), th.BasicUser.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	details, _, err := th.SystemAdminClient.GetSessionDetails(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	require.NotEmpty(t, details)
+	for _, d := range details {
+		assert.Equal(t, th.BasicUser.Id, d.UserId)
+		assert.NotEmpty(t, d.LastIpAddress)
+		assert.NotEmpty(t, d.UserAgent)
+	}
+}
+
 func TestRevokeSessions(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic(t)
@@ -8343,6 +8364,44 @@ func TestLoginWithDesktopToken(t *testing.T) {
 	})
 }
 
+func TestLoginWithMagicLink(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.MagicLinkSettings.EnableForEmail = true })
+
+	t.Run("requesting a magic link for an unknown email succeeds", func(t *testing.T) {
+		resp, err := th.Client.SendMagicLinkEmail(context.Background(), "unknown@example.com")
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+	})
+
+	t.Run("login with a magic link", func(t *testing.T) {
+		user := th.CreateUser(t)
+		token, appErr := th.App.CreateMagicLinkToken(th.Context, user.Id, user.Email)
+		require.Nil(t, appErr)
+
+		client := th.CreateClient()
+		loggedInUser, _, err := client.LoginWithMagicLink(context.Background(), token.Token, "", "")
+		require.NoError(t, err)
+		assert.Equal(t, user.Id, loggedInUser.Id)
+
+		me, _, err := client.GetMe(context.Background(), "")
+		require.NoError(t, err)
+		assert.Equal(t, user.Id, me.Id)
+
+		_, resp, err := th.CreateClient().LoginWithMagicLink(context.Background(), token.Token, "", "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("invalid magic link", func(t *testing.T) {
+		_, resp, err := th.Client.LoginWithMagicLink(context.Background(), model.NewRandomString(model.TokenSize), "", "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestLoginSSOCodeExchange(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic(t)
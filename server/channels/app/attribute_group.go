@@ -0,0 +1,313 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const attributeGroupSyncBatchSize = 1000
+
+// IsAttributeGroupsEnabled returns true if groups whose membership is computed from user attributes
+// can be created and synchronized.
+func (a *App) IsAttributeGroupsEnabled() bool {
+	return model.MinimumEnterpriseAdvancedLicense(a.License()) &&
+		*a.Config().AccessControlSettings.EnableAttributeBasedAccessControl &&
+		a.Srv().ch.AccessControl != nil
+}
+
+// ValidateGroupMembershipRule checks that the given membership rule is a valid CEL expression.
+func (a *App) ValidateGroupMembershipRule(rctx request.CTX, rule string) *model.AppError {
+	errs, appErr := a.CheckExpression(rctx, rule)
+	if appErr != nil {
+		return appErr
+	}
+
+	if len(errs) > 0 {
+		return model.NewAppError("ValidateGroupMembershipRule", "app.group.membership_rule.invalid.app_error", map[string]any{"Line": errs[0].Line, "Column": errs[0].Column, "Message": errs[0].Message}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// ScheduleAttributeGroupSync creates a job recomputing the members of the given attribute group.
+func (a *App) ScheduleAttributeGroupSync(rctx request.CTX, groupID string) (*model.Job, *model.AppError) {
+	return a.Srv().Jobs.CreateJob(rctx, model.JobTypeAttributeGroupSync, map[string]string{"group_id": groupID})
+}
+
+// SyncAttributeGroups recomputes the members of the given attribute group, or of every attribute
+// group if groupID is empty.
+func (a *App) SyncAttributeGroups(rctx request.CTX, groupID string) *model.AppError {
+	var groups []*model.Group
+	if groupID != "" {
+		group, appErr := a.GetGroup(groupID, nil, nil)
+		if appErr != nil {
+			return appErr
+		}
+		groups = append(groups, group)
+	} else {
+		var appErr *model.AppError
+		groups, appErr = a.GetGroupsBySource(model.GroupSourceAttribute)
+		if appErr != nil {
+			return appErr
+		}
+	}
+
+	var multiErr *multierror.Error
+	for _, group := range groups {
+		if err := a.SyncAttributeGroup(rctx, group); err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
+	}
+
+	if err := multiErr.ErrorOrNil(); err != nil {
+		return model.NewAppError("SyncAttributeGroups", "app.group.attribute_sync.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// SyncAttributeGroup evaluates the membership rule of the given group, updates its members and
+// reconciles the memberships of the teams and channels the group is synced to.
+func (a *App) SyncAttributeGroup(rctx request.CTX, group *model.Group) *model.AppError {
+	if group.Source != model.GroupSourceAttribute {
+		return model.NewAppError("SyncAttributeGroup", "app.group.attribute_sync.source.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if group.DeleteAt > 0 {
+		return nil
+	}
+
+	matching, appErr := a.getAttributeGroupMatchingUserIDs(rctx, group.MembershipRule)
+	if appErr != nil {
+		return appErr
+	}
+
+	members, err := a.Srv().Store().Group().GetMemberUsers(group.Id)
+	if err != nil {
+		return model.NewAppError("SyncAttributeGroup", "app.select_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var toRemove []string
+	for _, member := range members {
+		if matching[member.Id] {
+			delete(matching, member.Id)
+			continue
+		}
+		toRemove = append(toRemove, member.Id)
+	}
+
+	toAdd := make([]string, 0, len(matching))
+	for userID := range matching {
+		toAdd = append(toAdd, userID)
+	}
+
+	since := model.GetMillis()
+	for chunk := range slices.Chunk(toAdd, attributeGroupSyncBatchSize) {
+		if _, appErr := a.UpsertGroupMembers(group.Id, chunk); appErr != nil {
+			return appErr
+		}
+	}
+	for chunk := range slices.Chunk(toRemove, attributeGroupSyncBatchSize) {
+		if _, appErr := a.DeleteGroupMembers(group.Id, chunk); appErr != nil {
+			return appErr
+		}
+	}
+
+	rctx.Logger().Info("Synchronized attribute group members",
+		mlog.String("group_id", group.Id),
+		mlog.Int("added", len(toAdd)),
+		mlog.Int("removed", len(toRemove)),
+	)
+
+	if len(toAdd) == 0 && len(toRemove) == 0 {
+		return nil
+	}
+
+	return a.syncAttributeGroupSyncables(rctx, group.Id, since, len(toRemove) > 0)
+}
+
+// SyncAttributeGroupsForUser updates the user's membership in every attribute group after their
+// attributes changed.
+func (a *App) SyncAttributeGroupsForUser(rctx request.CTX, userID string) *model.AppError {
+	groups, appErr := a.GetGroupsBySource(model.GroupSourceAttribute)
+	if appErr != nil || len(groups) == 0 {
+		return appErr
+	}
+
+	userGroups, appErr := a.GetGroupsByUserId(userID, model.GroupSearchOpts{})
+	if appErr != nil {
+		return appErr
+	}
+	isMember := make(map[string]bool, len(userGroups))
+	for _, group := range userGroups {
+		isMember[group.Id] = true
+	}
+
+	acs := a.Srv().ch.AccessControl
+	if acs == nil {
+		return model.NewAppError("SyncAttributeGroupsForUser", "app.pap.check_expression.app_error", nil, "Policy Administration Point is not initialized", http.StatusNotImplemented)
+	}
+
+	var multiErr *multierror.Error
+	for _, group := range groups {
+		users, _, appErr := acs.QueryUsersForExpression(rctx, group.MembershipRule, model.SubjectSearchOptions{
+			SubjectID:   userID,
+			Limit:       1,
+			IgnoreCount: true,
+		})
+		if appErr != nil {
+			multiErr = multierror.Append(multiErr, appErr)
+			continue
+		}
+		matches := len(users) == 1 && users[0].Id == userID && !users[0].IsBot
+
+		since := model.GetMillis()
+		switch {
+		case matches && !isMember[group.Id]:
+			if _, appErr := a.UpsertGroupMember(group.Id, userID); appErr != nil {
+				multiErr = multierror.Append(multiErr, appErr)
+				continue
+			}
+		case !matches && isMember[group.Id]:
+			if _, appErr := a.DeleteGroupMember(group.Id, userID); appErr != nil {
+				multiErr = multierror.Append(multiErr, appErr)
+				continue
+			}
+		default:
+			continue
+		}
+
+		if appErr := a.syncAttributeGroupSyncables(rctx, group.Id, since, !matches); appErr != nil {
+			multiErr = multierror.Append(multiErr, appErr)
+		}
+	}
+
+	if err := multiErr.ErrorOrNil(); err != nil {
+		return model.NewAppError("SyncAttributeGroupsForUser", "app.group.attribute_sync.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// onCPAValuesChanged recomputes the attribute group memberships of a user in the background.
+func (a *App) onCPAValuesChanged(userID string) {
+	if !a.IsAttributeGroupsEnabled() {
+		return
+	}
+
+	a.Srv().Go(func() {
+		rctx := request.EmptyContext(a.Log())
+		if appErr := a.SyncAttributeGroupsForUser(rctx, userID); appErr != nil {
+			rctx.Logger().Warn("Failed to synchronize attribute groups for user", mlog.String("user_id", userID), mlog.Err(appErr))
+		}
+	})
+}
+
+func (a *App) getAttributeGroupMatchingUserIDs(rctx request.CTX, rule string) (map[string]bool, *model.AppError) {
+	acs := a.Srv().ch.AccessControl
+	if acs == nil {
+		return nil, model.NewAppError("getAttributeGroupMatchingUserIDs", "app.pap.check_expression.app_error", nil, "Policy Administration Point is not initialized", http.StatusNotImplemented)
+	}
+
+	matching := make(map[string]bool)
+	opts := model.SubjectSearchOptions{
+		Limit:       attributeGroupSyncBatchSize,
+		IgnoreCount: true,
+	}
+	for {
+		users, _, appErr := acs.QueryUsersForExpression(rctx, rule, opts)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		for _, user := range users {
+			if !user.IsBot {
+				matching[user.Id] = true
+			}
+		}
+
+		if len(users) < attributeGroupSyncBatchSize {
+			break
+		}
+		opts.Cursor.TargetID = users[len(users)-1].Id
+	}
+
+	return matching, nil
+}
+
+// syncAttributeGroupSyncables adds the members that joined the group since the given time to the
+// teams and channels the group is linked to. If members were removed, memberships of
+// group-constrained teams and channels are reconciled as well.
+func (a *App) syncAttributeGroupSyncables(rctx request.CTX, groupID string, since int64, membersRemoved bool) *model.AppError {
+	var multiErr *multierror.Error
+	for _, syncableType := range []model.GroupSyncableType{model.GroupSyncableTypeTeam, model.GroupSyncableTypeChannel} {
+		syncables, appErr := a.GetGroupSyncables(groupID, syncableType)
+		if appErr != nil {
+			return appErr
+		}
+
+		for _, syncable := range syncables {
+			params := model.CreateDefaultMembershipParams{Since: since, ReAddRemovedMembers: false}
+
+			var groupConstrained bool
+			switch syncableType {
+			case model.GroupSyncableTypeTeam:
+				team, appErr := a.GetTeam(syncable.SyncableId)
+				if appErr != nil {
+					multiErr = multierror.Append(multiErr, appErr)
+					continue
+				}
+				groupConstrained = team.IsGroupConstrained()
+
+				params.ScopedTeamID = &syncable.SyncableId
+				if err := a.createDefaultTeamMemberships(rctx, params); err != nil {
+					multiErr = multierror.Append(multiErr, err)
+				}
+				if membersRemoved && groupConstrained {
+					if err := a.DeleteGroupConstrainedTeamMemberships(rctx, &syncable.SyncableId); err != nil {
+						multiErr = multierror.Append(multiErr, err)
+					}
+				}
+			case model.GroupSyncableTypeChannel:
+				channel, appErr := a.GetChannel(rctx, syncable.SyncableId)
+				if appErr != nil {
+					multiErr = multierror.Append(multiErr, appErr)
+					continue
+				}
+				groupConstrained = channel.IsGroupConstrained()
+
+				params.ScopedChannelID = &syncable.SyncableId
+				if err := a.createDefaultChannelMemberships(rctx, params); err != nil {
+					multiErr = multierror.Append(multiErr, err)
+				}
+				if membersRemoved && groupConstrained {
+					if err := a.DeleteGroupConstrainedChannelMemberships(rctx, &syncable.SyncableId); err != nil {
+						multiErr = multierror.Append(multiErr, err)
+					}
+				}
+			}
+
+			// Scheme roles are only managed by groups on group-constrained teams and channels.
+			if groupConstrained {
+				if appErr := a.SyncSyncableRoles(rctx, syncable.SyncableId, syncableType); appErr != nil {
+					multiErr = multierror.Append(multiErr, appErr)
+				}
+			}
+		}
+	}
+
+	if err := multiErr.ErrorOrNil(); err != nil {
+		return model.NewAppError("syncAttributeGroupSyncables", "app.group.attribute_sync.syncables.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
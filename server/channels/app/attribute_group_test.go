@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
+)
+
+func setupAttributeGroupTest(t *testing.T) (*TestHelper, *mocks.AccessControlServiceInterface) {
+	th := Setup(t).InitBasic(t)
+
+	th.App.Srv().SetLicense(model.NewTestLicenseSKU(model.LicenseShortSkuEnterpriseAdvanced))
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.AccessControlSettings.EnableAttributeBasedAccessControl = model.NewPointer(true)
+	})
+
+	mockAccessControl := &mocks.AccessControlServiceInterface{}
+	th.App.Srv().ch.AccessControl = mockAccessControl
+
+	return th, mockAccessControl
+}
+
+func createAttributeGroup(t *testing.T, th *TestHelper, rule string) *model.Group {
+	t.Helper()
+
+	group, appErr := th.App.CreateGroup(&model.Group{
+		Name:           model.NewPointer(model.NewUsername()),
+		DisplayName:    "Attribute group",
+		Source:         model.GroupSourceAttribute,
+		AllowReference: true,
+		MembershipRule: rule,
+	})
+	require.Nil(t, appErr)
+	return group
+}
+
+func groupMemberIDs(t *testing.T, th *TestHelper, groupID string) []string {
+	t.Helper()
+
+	members, appErr := th.App.GetGroupMemberUsers(groupID)
+	require.Nil(t, appErr)
+
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.Id)
+	}
+	return ids
+}
+
+func TestIsAttributeGroupsEnabled(t *testing.T) {
+	th, _ := setupAttributeGroupTest(t)
+
+	assert.True(t, th.App.IsAttributeGroupsEnabled())
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.AccessControlSettings.EnableAttributeBasedAccessControl = model.NewPointer(false)
+	})
+	assert.False(t, th.App.IsAttributeGroupsEnabled())
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.AccessControlSettings.EnableAttributeBasedAccessControl = model.NewPointer(true)
+	})
+	th.App.Srv().SetLicense(model.NewTestLicenseSKU(model.LicenseShortSkuProfessional))
+	assert.False(t, th.App.IsAttributeGroupsEnabled())
+}
+
+func TestValidateGroupMembershipRule(t *testing.T) {
+	th, mockAccessControl := setupAttributeGroupTest(t)
+
+	mockAccessControl.On("CheckExpression", mock.Anything, "user.attributes.Department == 'Sales'").Return([]model.CELExpressionError{}, nil)
+	mockAccessControl.On("CheckExpression", mock.Anything, "user.attributes.Department ==").Return([]model.CELExpressionError{{Line: 1, Column: 29, Message: "Syntax error"}}, nil)
+
+	require.Nil(t, th.App.ValidateGroupMembershipRule(th.Context, "user.attributes.Department == 'Sales'"))
+
+	appErr := th.App.ValidateGroupMembershipRule(th.Context, "user.attributes.Department ==")
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.group.membership_rule.invalid.app_error", appErr.Id)
+}
+
+func TestSyncAttributeGroup(t *testing.T) {
+	th, mockAccessControl := setupAttributeGroupTest(t)
+
+	rule := "user.attributes.Department == 'Engineering'"
+	group := createAttributeGroup(t, th, rule)
+	opts := model.SubjectSearchOptions{Limit: attributeGroupSyncBatchSize, IgnoreCount: true}
+
+	team := th.CreateTeam(t)
+	_, appErr := th.App.UpsertGroupSyncable(model.NewGroupTeam(group.Id, team.Id, true))
+	require.Nil(t, appErr)
+
+	t.Run("adds matching users and their team memberships", func(t *testing.T) {
+		mockAccessControl.On("QueryUsersForExpression", mock.Anything, rule, opts).Return([]*model.User{th.BasicUser, th.BasicUser2}, int64(0), nil).Once()
+
+		require.Nil(t, th.App.SyncAttributeGroup(th.Context, group))
+		assert.ElementsMatch(t, []string{th.BasicUser.Id, th.BasicUser2.Id}, groupMemberIDs(t, th, group.Id))
+
+		_, appErr := th.App.GetTeamMember(th.Context, team.Id, th.BasicUser2.Id)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("removes users that no longer match", func(t *testing.T) {
+		mockAccessControl.On("QueryUsersForExpression", mock.Anything, rule, opts).Return([]*model.User{th.BasicUser}, int64(0), nil).Once()
+
+		require.Nil(t, th.App.SyncAttributeGroup(th.Context, group))
+		assert.Equal(t, []string{th.BasicUser.Id}, groupMemberIDs(t, th, group.Id))
+	})
+
+	t.Run("ignores groups from other sources", func(t *testing.T) {
+		appErr := th.App.SyncAttributeGroup(th.Context, &model.Group{Id: model.NewId(), Source: model.GroupSourceCustom})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.group.attribute_sync.source.app_error", appErr.Id)
+	})
+}
+
+func TestSyncAttributeGroupsForUser(t *testing.T) {
+	th, mockAccessControl := setupAttributeGroupTest(t)
+
+	rule := "user.attributes.Location == 'Berlin'"
+	group := createAttributeGroup(t, th, rule)
+	opts := model.SubjectSearchOptions{SubjectID: th.BasicUser.Id, Limit: 1, IgnoreCount: true}
+
+	mockAccessControl.On("QueryUsersForExpression", mock.Anything, rule, opts).Return([]*model.User{th.BasicUser}, int64(1), nil).Once()
+	require.Nil(t, th.App.SyncAttributeGroupsForUser(th.Context, th.BasicUser.Id))
+	assert.Equal(t, []string{th.BasicUser.Id}, groupMemberIDs(t, th, group.Id))
+
+	mockAccessControl.On("QueryUsersForExpression", mock.Anything, rule, opts).Return([]*model.User{}, int64(0), nil).Once()
+	require.Nil(t, th.App.SyncAttributeGroupsForUser(th.Context, th.BasicUser.Id))
+	assert.Empty(t, groupMemberIDs(t, th, group.Id))
+}
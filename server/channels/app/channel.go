@@ -304,7 +304,7 @@ func (a *App) CreateChannel(rctx request.CTX, channel *model.Channel, addMember
 
 	a.Srv().Go(func() {
 		pluginContext := pluginContext(rctx)
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.ChannelHasBeenCreated(pluginContext, sc)
 			return true
 		}, plugin.ChannelHasBeenCreatedID)
@@ -388,7 +388,7 @@ func (a *App) handleCreationEvent(rctx request.CTX, userID, otherUserID string,
 
 	a.Srv().Go(func() {
 		pluginContext := pluginContext(rctx)
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.ChannelHasBeenCreated(pluginContext, channel)
 			return true
 		}, plugin.ChannelHasBeenCreatedID)
@@ -674,7 +674,7 @@ func (a *App) createGroupChannel(rctx request.CTX, userIDs []string, creatorID s
 
 	a.Srv().Go(func() {
 		pluginContext := pluginContext(rctx)
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.ChannelHasBeenCreated(pluginContext, channel)
 			return true
 		}, plugin.ChannelHasBeenCreatedID)
@@ -715,6 +715,21 @@ func (a *App) UpdateChannel(rctx request.CTX, channel *model.Channel) (*model.Ch
 		return nil, model.NewAppError("UpdateChannel", "api.channel.update_channel.not_allowed.app_error", nil, "", http.StatusForbidden)
 	}
 
+	oldChannel, appErr := a.GetChannel(rctx, channel.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var rejectionReason string
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.ChannelWillBeUpdated(pluginContext, channel, oldChannel)
+		return rejectionReason == ""
+	}, plugin.ChannelWillBeUpdatedID)
+	if rejectionReason != "" {
+		return nil, model.NewAppError("UpdateChannel", "Channel update rejected by plugin. "+rejectionReason, nil, "", http.StatusBadRequest)
+	}
+
 	_, err := a.Srv().Store().Channel().Update(rctx, channel)
 	if err != nil {
 		var appErr *model.AppError
@@ -742,6 +757,14 @@ func (a *App) UpdateChannel(rctx request.CTX, channel *model.Channel) (*model.Ch
 	messageWs.Add("channel", string(channelJSON))
 	a.Publish(messageWs)
 
+	updatedChannel := channel.DeepCopy()
+	a.Srv().Go(func() {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.ChannelHasBeenUpdated(pluginContext, updatedChannel, oldChannel)
+			return true
+		}, plugin.ChannelHasBeenUpdatedID)
+	})
+
 	return channel, nil
 }
 
@@ -872,6 +895,15 @@ func (a *App) RestoreChannel(rctx request.CTX, channel *model.Channel, userID st
 	message.Add("channel_id", channel.Id)
 	a.Publish(message)
 
+	restoredChannel := channel.DeepCopy()
+	a.Srv().Go(func() {
+		pluginContext := pluginContext(rctx)
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.ChannelHasBeenRestored(pluginContext, restoredChannel)
+			return true
+		}, plugin.ChannelHasBeenRestoredID)
+	})
+
 	var user *model.User
 	if userID != "" {
 		var nErr error
@@ -1552,6 +1584,16 @@ func (a *App) DeleteChannel(rctx request.CTX, channel *model.Channel, userID str
 		return err
 	}
 
+	var rejectionReason string
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.ChannelWillBeArchived(pluginContext, channel)
+		return rejectionReason == ""
+	}, plugin.ChannelWillBeArchivedID)
+	if rejectionReason != "" {
+		return model.NewAppError("DeleteChannel", "Channel archival rejected by plugin. "+rejectionReason, nil, "", http.StatusBadRequest)
+	}
+
 	if user != nil {
 		T := i18n.GetUserTranslations(user.Locale)
 
@@ -1625,6 +1667,15 @@ func (a *App) DeleteChannel(rctx request.CTX, channel *model.Channel, userID str
 	message.Add("delete_at", deleteAt)
 	a.Publish(message)
 
+	archivedChannel := channel.DeepCopy()
+	archivedChannel.DeleteAt = deleteAt
+	a.Srv().Go(func() {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.ChannelHasBeenArchived(pluginContext, archivedChannel)
+			return true
+		}, plugin.ChannelHasBeenArchivedID)
+	})
+
 	return nil
 }
 
@@ -1814,7 +1865,7 @@ func (a *App) AddChannelMember(rctx request.CTX, userID string, channel *model.C
 
 	a.Srv().Go(func() {
 		pluginContext := pluginContext(rctx)
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.UserHasJoinedChannel(pluginContext, cm, userRequestor)
 			return true
 		}, plugin.UserHasJoinedChannelID)
@@ -2442,7 +2493,7 @@ func (a *App) JoinChannel(rctx request.CTX, channel *model.Channel, userID strin
 
 	a.Srv().Go(func() {
 		pluginContext := pluginContext(rctx)
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.UserHasJoinedChannel(pluginContext, cm, nil)
 			return true
 		}, plugin.UserHasJoinedChannelID)
@@ -2740,7 +2791,7 @@ func (a *App) removeUserFromChannel(rctx request.CTX, userIDToRemove string, rem
 
 	a.Srv().Go(func() {
 		pluginContext := pluginContext(rctx)
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.UserHasLeftChannel(pluginContext, cm, actorUser)
 			return true
 		}, plugin.UserHasLeftChannelID)
@@ -3274,6 +3325,15 @@ func (a *App) PermanentDeleteChannel(rctx request.CTX, channel *model.Channel) *
 	message.Add("delete_at", deleteAt)
 	a.Publish(message)
 
+	deletedChannel := channel.DeepCopy()
+	a.Srv().Go(func() {
+		pluginContext := pluginContext(rctx)
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.ChannelHasBeenDeleted(pluginContext, deletedChannel)
+			return true
+		}, plugin.ChannelHasBeenDeletedID)
+	})
+
 	return nil
 }
 
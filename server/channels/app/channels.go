@@ -4,6 +4,7 @@
 package app
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"os/signal"
@@ -13,6 +14,7 @@ import (
 	"syscall"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/plugin"
@@ -22,6 +24,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/config"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
 	"github.com/mattermost/mattermost/server/v8/platform/services/imageproxy"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
 
@@ -87,6 +90,9 @@ type Channels struct {
 	scheduledPostTask     *model.ScheduledTask
 	emailLoginAttemptsMut sync.Mutex
 	ldapLoginAttemptsMut  sync.Mutex
+
+	magicLinkRateLimitersMut sync.Mutex
+	magicLinkRateLimiters    *magicLinkRateLimiters
 }
 
 func NewChannels(s *Server) (*Channels, error) {
@@ -213,6 +219,8 @@ func NewChannels(s *Server) (*Channels, error) {
 	pluginsRoute.HandleFunc("/public/{public_file:.*}", ch.ServePluginPublicRequest)
 	pluginsRoute.HandleFunc("/{anything:.*}", ch.ServePluginRequest)
 
+	ch.srv.Router.PathPrefix("/_matrix/app/").HandlerFunc(ch.ServeMatrixAppServiceRequest)
+
 	return ch, nil
 }
 
@@ -298,8 +306,22 @@ func (ch *Channels) RemoveConfigListener(id string) {
 }
 
 func (ch *Channels) RunMultiHook(hookRunnerFunc func(hooks plugin.Hooks, manifest *model.Manifest) bool, hookId int) {
+	ch.RunMultiHookWithContext(context.Background(), hookRunnerFunc, hookId)
+}
+
+// RunMultiHookWithContext runs the hook like RunMultiHook, tracing each plugin's run as a child of
+// the span of the context, if any.
+func (ch *Channels) RunMultiHookWithContext(ctx context.Context, hookRunnerFunc func(hooks plugin.Hooks, manifest *model.Manifest) bool, hookId int) {
 	if env := ch.GetPluginsEnvironment(); env != nil {
-		env.RunMultiPluginHook(hookRunnerFunc, hookId)
+		hookName := plugin.HookName(hookId)
+		env.RunMultiPluginHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+			_, span := tracing.StartChildSpan(ctx, "Plugin."+hookName,
+				attribute.String("plugin_id", manifest.Id),
+			)
+			defer span.End()
+
+			return hookRunnerFunc(hooks, manifest)
+		}, hookId)
 	}
 }
 
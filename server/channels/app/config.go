@@ -17,6 +17,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/config"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
 )
 
@@ -36,6 +37,10 @@ func (a *App) EnvironmentConfig(filter func(reflect.StructField) bool) map[strin
 	return a.Srv().platform.GetEnvironmentOverridesWithFilter(filter)
 }
 
+func (a *App) SecretReferences() map[string]string {
+	return a.Srv().platform.SecretReferences()
+}
+
 func (a *App) UpdateConfig(f func(*model.Config)) {
 	a.Srv().platform.UpdateConfig(f)
 }
@@ -221,16 +226,17 @@ func (a *App) GetSanitizedConfig() *model.Config {
 }
 
 // SanitizedConfig sanitizes a given configuration for a system admin without any secrets.
+// The secret settings set via a reference show the reference instead.
 func (a *App) SanitizedConfig(cfg *model.Config) {
 	manifests, err := a.getPluginManifests()
 	if err != nil {
 		// GetPluginManifests might error, e.g. when plugins are disabled.
 		// Sanitize all plugin settings in this case.
-		cfg.Sanitize(nil, nil)
-		return
+		manifests = nil
 	}
 
 	cfg.Sanitize(manifests, nil)
+	config.ApplySecretReferences(cfg, a.SecretReferences())
 }
 
 // GetEnvironmentConfig returns a map of configuration keys whose values have been overridden by an environment variable.
@@ -308,6 +308,8 @@ func (a *App) PatchCPAValues(userID string, fieldValueMap map[string]json.RawMes
 	message.Add("values", updatedFieldValueMap)
 	a.Publish(message)
 
+	a.onCPAValuesChanged(userID)
+
 	return updatedValues, nil
 }
 
@@ -326,5 +328,7 @@ func (a *App) DeleteCPAValues(userID string) *model.AppError {
 	message.Add("values", map[string]json.RawMessage{})
 	a.Publish(message)
 
+	a.onCPAValuesChanged(userID)
+
 	return nil
 }
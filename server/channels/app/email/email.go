@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -47,7 +48,7 @@ func (es *Service) SendChangeUsernameEmail(newUsername, email, locale, siteURL s
 		map[string]any{"TeamDisplayName": es.config().TeamSettings.SiteName, "NewUsername": newUsername})
 	data.Props["Warning"] = T("api.templates.email_warning")
 
-	body, err := es.templatesContainer.RenderToString("email_change_body", data)
+	body, err := es.RenderTemplate("email_change_body", locale, data)
 	if err != nil {
 		return err
 	}
@@ -80,7 +81,7 @@ func (es *Service) SendEmailChangeVerifyEmail(newUserEmail, locale, siteURL, tok
 	data.Props["SupportEmail"] = "feedback@mattermost.com"
 	data.Props["FooterV2"] = T("api.templates.email_footer_v2")
 
-	body, err := es.templatesContainer.RenderToString("email_change_verify_body", data)
+	body, err := es.RenderTemplate("email_change_verify_body", locale, data)
 	if err != nil {
 		return err
 	}
@@ -106,7 +107,7 @@ func (es *Service) SendEmailChangeEmail(oldEmail, newEmail, locale, siteURL stri
 		map[string]any{"TeamDisplayName": es.config().TeamSettings.SiteName, "NewEmail": newEmail})
 	data.Props["Warning"] = T("api.templates.email_warning")
 
-	body, err := es.templatesContainer.RenderToString("email_change_body", data)
+	body, err := es.RenderTemplate("email_change_body", locale, data)
 	if err != nil {
 		return err
 	}
@@ -144,7 +145,7 @@ func (es *Service) SendVerifyEmail(userEmail, locale, siteURL, token, redirect s
 	data.Props["QuestionTitle"] = T("api.templates.questions_footer.title")
 	data.Props["QuestionInfo"] = T("api.templates.questions_footer.info")
 
-	body, err := es.templatesContainer.RenderToString("verify_body", data)
+	body, err := es.RenderTemplate("verify_body", locale, data)
 	if err != nil {
 		return err
 	}
@@ -169,7 +170,7 @@ func (es *Service) SendSignInChangeEmail(email, method, locale, siteURL string)
 		map[string]any{"SiteName": es.config().TeamSettings.SiteName, "Method": method})
 	data.Props["Warning"] = T("api.templates.email_warning")
 
-	body, err := es.templatesContainer.RenderToString("signin_change_body", data)
+	body, err := es.RenderTemplate("signin_change_body", locale, data)
 	if err != nil {
 		return err
 	}
@@ -227,7 +228,7 @@ func (es *Service) SendWelcomeEmail(userID string, email string, verified bool,
 		data.Props["ButtonURL"] = link
 	}
 
-	body, err := es.templatesContainer.RenderToString("welcome_body", data)
+	body, err := es.RenderTemplate("welcome_body", locale, data)
 	if err != nil {
 		return err
 	}
@@ -297,7 +298,7 @@ func (es *Service) SendPasswordChangeEmail(email, method, locale, siteURL string
 		map[string]any{"TeamDisplayName": es.config().TeamSettings.SiteName, "TeamURL": siteURL, "Method": method})
 	data.Props["Warning"] = T("api.templates.email_warning")
 
-	body, err := es.templatesContainer.RenderToString("password_change_body", data)
+	body, err := es.RenderTemplate("password_change_body", locale, data)
 	if err != nil {
 		return err
 	}
@@ -322,7 +323,7 @@ func (es *Service) SendUserAccessTokenAddedEmail(email, locale, siteURL string)
 		map[string]any{"SiteName": es.config().TeamSettings.SiteName, "SiteURL": siteURL})
 	data.Props["Warning"] = T("api.templates.email_warning")
 
-	body, err := es.templatesContainer.RenderToString("password_change_body", data)
+	body, err := es.RenderTemplate("password_change_body", locale, data)
 	if err != nil {
 		return err
 	}
@@ -352,7 +353,7 @@ func (es *Service) SendPasswordResetEmail(email string, token *model.Token, loca
 	data.Props["QuestionTitle"] = T("api.templates.questions_footer.title")
 	data.Props["QuestionInfo"] = T("api.templates.questions_footer.info")
 
-	body, err := es.templatesContainer.RenderToString("reset_body", data)
+	body, err := es.RenderTemplate("reset_body", locale, data)
 	if err != nil {
 		return false, err
 	}
@@ -364,6 +365,37 @@ func (es *Service) SendPasswordResetEmail(email string, token *model.Token, loca
 	return true, nil
 }
 
+func (es *Service) SendMagicLinkEmail(email string, token *model.Token, expiryInMinutes int, locale, siteURL string) error {
+	T := i18n.GetUserTranslations(locale)
+
+	link := fmt.Sprintf("%s/login/magic_link?token=%s", siteURL, url.QueryEscape(token.Token))
+
+	subject := T("api.templates.magic_link_subject",
+		map[string]any{"SiteName": es.config().TeamSettings.SiteName})
+
+	data := es.NewEmailTemplateData(locale)
+	data.Props["SiteURL"] = siteURL
+	data.Props["Title"] = T("api.templates.magic_link_body.title")
+	data.Props["Info"] = T("api.templates.magic_link_body.info",
+		map[string]any{"SiteName": es.config().TeamSettings.SiteName, "Minutes": expiryInMinutes})
+	data.Props["ButtonURL"] = link
+	data.Props["Button"] = T("api.templates.magic_link_body.button")
+	data.Props["Warning"] = T("api.templates.magic_link_body.warning")
+	data.Props["QuestionTitle"] = T("api.templates.questions_footer.title")
+	data.Props["EmailInfo1"] = T("api.templates.email_us_anytime_at")
+
+	body, err := es.RenderTemplate("magic_link_body", locale, data)
+	if err != nil {
+		return err
+	}
+
+	if err := es.sendMail(email, subject, body, "MagicLinkEmail"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (es *Service) SendMfaChangeEmail(email string, activated bool, locale, siteURL string) error {
 	T := i18n.GetUserTranslations(locale)
 
@@ -382,7 +414,7 @@ func (es *Service) SendMfaChangeEmail(email string, activated bool, locale, site
 	}
 	data.Props["Warning"] = T("api.templates.email_warning")
 
-	body, err := es.templatesContainer.RenderToString("mfa_change_body", data)
+	body, err := es.RenderTemplate("mfa_change_body", locale, data)
 	if err != nil {
 		return err
 	}
@@ -394,6 +426,17 @@ func (es *Service) SendMfaChangeEmail(email string, activated bool, locale, site
 	return nil
 }
 
+// inviteLocale returns the locale the invites of the sender are translated to:
+// the sender's own, or the default server locale when the sender isn't a user.
+func (es *Service) inviteLocale(senderUserId string) string {
+	if es.userService != nil {
+		if sender, err := es.userService.GetUser(senderUserId); err == nil && sender.Locale != "" {
+			return sender.Locale
+		}
+	}
+	return *es.config().LocalizationSettings.DefaultServerLocale
+}
+
 func (es *Service) SendInviteEmails(
 	team *model.Team,
 	senderName string,
@@ -419,21 +462,24 @@ func (es *Service) SendInviteEmails(
 		return RateLimitExceededError
 	}
 
+	locale := es.inviteLocale(senderUserId)
+	T := i18n.GetUserTranslations(locale)
+
 	for _, invite := range invites {
 		if invite != "" {
-			subject := i18n.T("api.templates.invite_subject",
+			subject := T("api.templates.invite_subject",
 				map[string]any{"SenderName": senderName,
 					"TeamDisplayName": team.DisplayName,
 					"SiteName":        es.config().TeamSettings.SiteName})
 
-			data := es.NewEmailTemplateData("")
+			data := es.NewEmailTemplateData(locale)
 			data.Props["SiteURL"] = siteURL
-			data.Props["SubTitle"] = i18n.T("api.templates.invite_body.subTitle")
-			data.Props["Button"] = i18n.T("api.templates.invite_body.button")
+			data.Props["SubTitle"] = T("api.templates.invite_body.subTitle")
+			data.Props["Button"] = T("api.templates.invite_body.button")
 			data.Props["SenderName"] = senderName
-			data.Props["InviteFooterTitle"] = i18n.T("api.templates.invite_body_footer.title")
-			data.Props["InviteFooterInfo"] = i18n.T("api.templates.invite_body_footer.info")
-			data.Props["InviteFooterLearnMore"] = i18n.T("api.templates.invite_body_footer.learn_more")
+			data.Props["InviteFooterTitle"] = T("api.templates.invite_body_footer.title")
+			data.Props["InviteFooterInfo"] = T("api.templates.invite_body_footer.info")
+			data.Props["InviteFooterLearnMore"] = T("api.templates.invite_body_footer.learn_more")
 
 			token := model.NewToken(
 				TokenTypeTeamInvitation,
@@ -445,9 +491,9 @@ func (es *Service) SendInviteEmails(
 			tokenProps["display_name"] = team.DisplayName
 			tokenProps["name"] = team.Name
 
-			title := i18n.T("api.templates.invite_body.title", map[string]any{"SenderName": senderName, "TeamDisplayName": team.DisplayName})
+			title := T("api.templates.invite_body.title", map[string]any{"SenderName": senderName, "TeamDisplayName": team.DisplayName})
 			if reminderData != nil {
-				reminder := i18n.T("api.templates.invite_body.title.reminder")
+				reminder := T("api.templates.invite_body.title.reminder")
 				title = fmt.Sprintf("%s: %s", reminder, title)
 				tokenProps["reminder_interval"] = reminderData.Interval
 			}
@@ -468,7 +514,7 @@ func (es *Service) SendInviteEmails(
 			queryString.Add("sbr", es.GetTrackFlowStartedByRole(isFirstAdmin, isSystemAdmin))
 			data.Props["ButtonURL"] = fmt.Sprintf("%s/signup_user_complete/?%s", siteURL, queryString.Encode())
 
-			body, err := es.templatesContainer.RenderToString("invite_body", data)
+			body, err := es.RenderTemplate("invite_body", locale, data)
 			if err != nil {
 				mlog.Error("Failed to send invite email successfully ", mlog.Err(err))
 			}
@@ -493,6 +539,7 @@ func (es *Service) SendGuestInviteEmails(
 	invites []string,
 	siteURL string,
 	message string,
+	accessExpiresAt int64,
 	errorWhenNotSent bool,
 	isSystemAdmin bool,
 	isFirstAdmin bool,
@@ -511,42 +558,46 @@ func (es *Service) SendGuestInviteEmails(
 		return RateLimitExceededError
 	}
 
+	locale := es.inviteLocale(senderUserId)
+	T := i18n.GetUserTranslations(locale)
+
 	for _, invite := range invites {
 		if invite != "" {
-			subject := i18n.T("api.templates.invite_guest_subject",
+			subject := T("api.templates.invite_guest_subject",
 				map[string]any{"SenderName": senderName,
 					"TeamDisplayName": team.DisplayName,
 					"SiteName":        es.config().TeamSettings.SiteName})
 
-			data := es.NewEmailTemplateData("")
+			data := es.NewEmailTemplateData(locale)
 			data.Props["SiteURL"] = siteURL
-			data.Props["Title"] = i18n.T("api.templates.invite_body.title", map[string]any{"SenderName": senderName, "TeamDisplayName": team.DisplayName})
-			data.Props["SubTitle"] = i18n.T("api.templates.invite_body_guest.subTitle")
-			data.Props["Button"] = i18n.T("api.templates.invite_body.button")
+			data.Props["Title"] = T("api.templates.invite_body.title", map[string]any{"SenderName": senderName, "TeamDisplayName": team.DisplayName})
+			data.Props["SubTitle"] = T("api.templates.invite_body_guest.subTitle")
+			data.Props["Button"] = T("api.templates.invite_body.button")
 			data.Props["SenderName"] = senderName
 			if message != "" {
 				message = bluemonday.NewPolicy().Sanitize(message)
 			}
 			data.Props["Message"] = message
-			data.Props["InviteFooterTitle"] = i18n.T("api.templates.invite_body_footer.title")
-			data.Props["InviteFooterInfo"] = i18n.T("api.templates.invite_body_footer.info")
-			data.Props["InviteFooterLearnMore"] = i18n.T("api.templates.invite_body_footer.learn_more")
+			data.Props["InviteFooterTitle"] = T("api.templates.invite_body_footer.title")
+			data.Props["InviteFooterInfo"] = T("api.templates.invite_body_footer.info")
+			data.Props["InviteFooterLearnMore"] = T("api.templates.invite_body_footer.learn_more")
 
 			channelIDs := []string{}
 			for _, channel := range channels {
 				channelIDs = append(channelIDs, channel.Id)
 			}
 
-			token := model.NewToken(
-				TokenTypeGuestInvitation,
-				model.MapToJSON(map[string]string{
-					"teamId":   team.Id,
-					"channels": strings.Join(channelIDs, " "),
-					"email":    invite,
-					"guest":    "true",
-					"senderId": senderUserId,
-				}),
-			)
+			tokenExtra := map[string]string{
+				"teamId":   team.Id,
+				"channels": strings.Join(channelIDs, " "),
+				"email":    invite,
+				"guest":    "true",
+				"senderId": senderUserId,
+			}
+			if accessExpiresAt > 0 {
+				tokenExtra["accessExpiresAt"] = strconv.FormatInt(accessExpiresAt, 10)
+			}
+			token := model.NewToken(TokenTypeGuestInvitation, model.MapToJSON(tokenExtra))
 
 			tokenProps := make(map[string]string)
 			tokenProps["email"] = invite
@@ -584,7 +635,7 @@ func (es *Service) SendGuestInviteEmails(
 
 			data.Props["Posts"] = []postData{pData}
 
-			body, err := es.templatesContainer.RenderToString("invite_body", data)
+			body, err := es.RenderTemplate("invite_body", locale, data)
 			if err != nil {
 				mlog.Error("Failed to send invite email successfully", mlog.Err(err))
 			}
@@ -628,15 +679,18 @@ func (es *Service) SendInviteEmailsToTeamAndChannels(
 		return nil, RateLimitExceededError
 	}
 
+	locale := es.inviteLocale(senderUserId)
+	T := i18n.GetUserTranslations(locale)
+
 	channelsLen := len(channels)
 
-	subject := i18n.T("api.templates.invite_team_and_channels_subject", map[string]any{
+	subject := T("api.templates.invite_team_and_channels_subject", map[string]any{
 		"SenderName":      senderName,
 		"TeamDisplayName": team.DisplayName,
 		"ChannelsLen":     channelsLen,
 		"SiteName":        es.config().TeamSettings.SiteName})
 
-	title := i18n.T("api.templates.invite_team_and_channels_body.title", map[string]any{
+	title := T("api.templates.invite_team_and_channels_body.title", map[string]any{
 		"SenderName":      senderName,
 		"ChannelsLen":     channelsLen,
 		"TeamDisplayName": team.DisplayName})
@@ -644,14 +698,14 @@ func (es *Service) SendInviteEmailsToTeamAndChannels(
 	if channelsLen == 1 {
 		channelName := channels[0].DisplayName
 
-		subject = i18n.T("api.templates.invite_team_and_channel_subject",
+		subject = T("api.templates.invite_team_and_channel_subject",
 			map[string]any{"SenderName": senderName,
 				"TeamDisplayName": team.DisplayName,
 				"ChannelName":     channelName,
 				"SiteName":        es.config().TeamSettings.SiteName},
 		)
 
-		title = i18n.T("api.templates.invite_team_and_channel_body.title", map[string]any{
+		title = T("api.templates.invite_team_and_channel_body.title", map[string]any{
 			"SenderName":      senderName,
 			"ChannelName":     channelName,
 			"TeamDisplayName": team.DisplayName,
@@ -668,14 +722,14 @@ func (es *Service) SendInviteEmailsToTeamAndChannels(
 			channelIDs = append(channelIDs, channel.Id)
 		}
 
-		data := es.NewEmailTemplateData("")
+		data := es.NewEmailTemplateData(locale)
 		data.Props["SiteURL"] = siteURL
-		data.Props["SubTitle"] = i18n.T("api.templates.invite_body.subTitle")
-		data.Props["Button"] = i18n.T("api.templates.invite_body.button")
+		data.Props["SubTitle"] = T("api.templates.invite_body.subTitle")
+		data.Props["Button"] = T("api.templates.invite_body.button")
 		data.Props["SenderName"] = senderName
-		data.Props["InviteFooterTitle"] = i18n.T("api.templates.invite_body_footer.title")
-		data.Props["InviteFooterInfo"] = i18n.T("api.templates.invite_body_footer.info")
-		data.Props["InviteFooterLearnMore"] = i18n.T("api.templates.invite_body_footer.learn_more")
+		data.Props["InviteFooterTitle"] = T("api.templates.invite_body_footer.title")
+		data.Props["InviteFooterInfo"] = T("api.templates.invite_body_footer.info")
+		data.Props["InviteFooterLearnMore"] = T("api.templates.invite_body_footer.learn_more")
 
 		if message != "" {
 			message = bluemonday.NewPolicy().Sanitize(message)
@@ -698,7 +752,7 @@ func (es *Service) SendInviteEmailsToTeamAndChannels(
 		tokenProps["name"] = team.Name
 
 		if reminderData != nil {
-			reminder := i18n.T("api.templates.invite_body.title.reminder")
+			reminder := T("api.templates.invite_body.title.reminder")
 			title = fmt.Sprintf("%s: %s", reminder, title)
 			tokenProps["reminder_interval"] = reminderData.Interval
 		}
@@ -732,7 +786,7 @@ func (es *Service) SendInviteEmailsToTeamAndChannels(
 
 		data.Props["Posts"] = []postData{pData}
 
-		body, err := es.templatesContainer.RenderToString("invite_body", data)
+		body, err := es.RenderTemplate("invite_body", locale, data)
 		if err != nil {
 			mlog.Error("Failed to send invite email successfully ", mlog.Err(err))
 		}
@@ -795,7 +849,7 @@ func (es *Service) SendDeactivateAccountEmail(email string, locale, siteURL stri
 		map[string]any{"SiteURL": siteURL})
 	data.Props["Warning"] = T("api.templates.deactivate_body.warning")
 
-	body, err := es.templatesContainer.RenderToString("deactivate_body", data)
+	body, err := es.RenderTemplate("deactivate_body", locale, data)
 	if err != nil {
 		return err
 	}
@@ -807,6 +861,36 @@ func (es *Service) SendDeactivateAccountEmail(email string, locale, siteURL stri
 	return nil
 }
 
+// SendGuestAccessExpiryEmail reminds a guest that their access expires soon.
+func (es *Service) SendGuestAccessExpiryEmail(email string, expiresAt int64, locale, siteURL string) error {
+	T := i18n.GetUserTranslations(locale)
+
+	serverURL := condenseSiteURL(siteURL)
+	expiryDate := model.GetTimeForMillis(expiresAt).UTC().Format("January 2, 2006 15:04 MST")
+
+	subject := T("api.templates.guest_access_expiry_subject",
+		map[string]any{"SiteName": es.config().TeamSettings.SiteName,
+			"ServerURL": serverURL})
+
+	data := es.NewEmailTemplateData(locale)
+	data.Props["SiteURL"] = siteURL
+	data.Props["Title"] = T("api.templates.guest_access_expiry_body.title", map[string]any{"ServerURL": serverURL})
+	data.Props["Info"] = T("api.templates.guest_access_expiry_body.info",
+		map[string]any{"ExpiryDate": expiryDate, "SiteURL": siteURL})
+	data.Props["Warning"] = T("api.templates.guest_access_expiry_body.warning")
+
+	body, err := es.RenderTemplate("guest_access_expiry_body", locale, data)
+	if err != nil {
+		return err
+	}
+
+	if err := es.sendMail(email, subject, body, "GuestAccessExpiryEmail"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (es *Service) SendNotificationMail(to, subject, htmlBody string) error {
 	if !*es.config().EmailSettings.SendEmailNotifications {
 		return nil
@@ -820,19 +904,27 @@ func (es *Service) sendMail(to, subject, htmlBody, category string) error {
 
 func (es *Service) sendEmailWithCustomReplyTo(to, subject, htmlBody, replyToAddress, category string) error {
 	license := es.license()
-	mailConfig := es.mailServiceConfig(replyToAddress)
-
 	category = getSendGridCategory(category, license.IsCloud())
 
+	if es.outboundQueueEnabled() {
+		return es.enqueueMail(&model.OutgoingEmail{To: to, ReplyTo: replyToAddress, Subject: subject, HTMLBody: htmlBody, Category: category})
+	}
+
+	mailConfig := es.mailServiceConfig(replyToAddress)
+
 	return mail.SendMailUsingConfig(to, subject, htmlBody, mailConfig, license != nil && *license.Features.Compliance, "", "", "", "", category)
 }
 
 func (es *Service) sendMailWithCC(to, subject, htmlBody, ccMail, category string) error {
 	license := es.license()
-	mailConfig := es.mailServiceConfig("")
-
 	category = getSendGridCategory(category, license.IsCloud())
 
+	if es.outboundQueueEnabled() {
+		return es.enqueueMail(&model.OutgoingEmail{To: to, Cc: ccMail, Subject: subject, HTMLBody: htmlBody, Category: category})
+	}
+
+	mailConfig := es.mailServiceConfig("")
+
 	return mail.SendMailUsingConfig(to, subject, htmlBody, mailConfig, license != nil && *license.Features.Compliance, "", "", "", ccMail, category)
 }
 
@@ -847,10 +939,24 @@ func (es *Service) SendMailWithEmbeddedFilesAndCustomReplyTo(to, subject, htmlBo
 
 func (es *Service) SendMailWithEmbeddedFiles(to, subject, htmlBody string, embeddedFiles map[string]io.Reader, messageID string, inReplyTo string, references string, category string) error {
 	license := es.license()
-	mailConfig := es.mailServiceConfig("")
-
 	category = getSendGridCategory(category, license.IsCloud())
 
+	// Embedded files are streamed from readers and can't be persisted, so only
+	// plain emails go through the outbound queue.
+	if es.outboundQueueEnabled() && len(embeddedFiles) == 0 {
+		return es.enqueueMail(&model.OutgoingEmail{
+			To:         to,
+			Subject:    subject,
+			HTMLBody:   htmlBody,
+			MessageId:  messageID,
+			InReplyTo:  inReplyTo,
+			References: references,
+			Category:   category,
+		})
+	}
+
+	mailConfig := es.mailServiceConfig("")
+
 	return mail.SendMailWithEmbeddedFilesUsingConfig(to, subject, htmlBody, embeddedFiles, mailConfig, license != nil && *license.Features.Compliance, messageID, inReplyTo, references, "", category)
 }
 
@@ -115,6 +115,7 @@ func TestSendInviteEmails(t *testing.T) {
 			[]string{emailTo},
 			"http://testserver",
 			"hello world",
+			0,
 			false,
 			false,
 			false,
@@ -145,6 +146,7 @@ func TestSendInviteEmails(t *testing.T) {
 			[]string{emailTo},
 			"http://testserver",
 			"hello world",
+			0,
 			false,
 			false,
 			false,
@@ -160,6 +162,7 @@ func TestSendInviteEmails(t *testing.T) {
 			[]string{emailTo},
 			"http://testserver",
 			"hello world",
+			0,
 			true,
 			false,
 			false,
@@ -181,6 +184,7 @@ func TestSendInviteEmails(t *testing.T) {
 			[]string{emailTo},
 			"http://testserver",
 			message,
+			0,
 			false,
 			false,
 			false,
@@ -146,6 +146,11 @@ func (_m *ServiceInterface) InitEmailBatching() {
 	_m.Called()
 }
 
+// InitOutboundEmailQueue provides a mock function with no fields
+func (_m *ServiceInterface) InitOutboundEmailQueue() {
+	_m.Called()
+}
+
 // NewEmailTemplateData provides a mock function with given fields: locale
 func (_m *ServiceInterface) NewEmailTemplateData(locale string) templates.Data {
 	ret := _m.Called(locale)
@@ -164,6 +169,130 @@ func (_m *ServiceInterface) NewEmailTemplateData(locale string) templates.Data {
 	return r0
 }
 
+// RenderTemplate provides a mock function with given fields: name, locale, data
+func (_m *ServiceInterface) RenderTemplate(name string, locale string, data templates.Data) (string, error) {
+	ret := _m.Called(name, locale, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderTemplate")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, templates.Data) (string, error)); ok {
+		return rf(name, locale, data)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, templates.Data) string); ok {
+		r0 = rf(name, locale, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, templates.Data) error); ok {
+		r1 = rf(name, locale, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenderTemplatePreview provides a mock function with given fields: name, locale
+func (_m *ServiceInterface) RenderTemplatePreview(name string, locale string) (string, error) {
+	ret := _m.Called(name, locale)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderTemplatePreview")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(name, locale)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(name, locale)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(name, locale)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetDKIMSigner provides a mock function with no fields
+func (_m *ServiceInterface) ResetDKIMSigner() {
+	_m.Called()
+}
+
+// ResetTemplateOverrides provides a mock function with no fields
+func (_m *ServiceInterface) ResetTemplateOverrides() {
+	_m.Called()
+}
+
+// RetryOutgoingEmail provides a mock function with given fields: id
+func (_m *ServiceInterface) RetryOutgoingEmail(id string) (*model.OutgoingEmail, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryOutgoingEmail")
+	}
+
+	var r0 *model.OutgoingEmail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.OutgoingEmail, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.OutgoingEmail); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OutgoingEmail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SampleTemplateData provides a mock function with given fields: name, locale
+func (_m *ServiceInterface) SampleTemplateData(name string, locale string) (templates.Data, error) {
+	ret := _m.Called(name, locale)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SampleTemplateData")
+	}
+
+	var r0 templates.Data
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (templates.Data, error)); ok {
+		return rf(name, locale)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) templates.Data); ok {
+		r0 = rf(name, locale)
+	} else {
+		r0 = ret.Get(0).(templates.Data)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(name, locale)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendChangeUsernameEmail provides a mock function with given fields: newUsername, _a1, locale, siteURL
 func (_m *ServiceInterface) SendChangeUsernameEmail(newUsername string, _a1 string, locale string, siteURL string) error {
 	ret := _m.Called(newUsername, _a1, locale, siteURL)
@@ -254,17 +383,35 @@ func (_m *ServiceInterface) SendEmailChangeVerifyEmail(newUserEmail string, loca
 	return r0
 }
 
-// SendGuestInviteEmails provides a mock function with given fields: team, channels, senderName, senderUserId, senderProfileImage, invites, siteURL, message, errorWhenNotSent, isSystemAdmin, isFirstAdmin
-func (_m *ServiceInterface) SendGuestInviteEmails(team *model.Team, channels []*model.Channel, senderName string, senderUserId string, senderProfileImage []byte, invites []string, siteURL string, message string, errorWhenNotSent bool, isSystemAdmin bool, isFirstAdmin bool) error {
-	ret := _m.Called(team, channels, senderName, senderUserId, senderProfileImage, invites, siteURL, message, errorWhenNotSent, isSystemAdmin, isFirstAdmin)
+// SendGuestAccessExpiryEmail provides a mock function with given fields: email, expiresAt, locale, siteURL
+func (_m *ServiceInterface) SendGuestAccessExpiryEmail(email string, expiresAt int64, locale string, siteURL string) error {
+	ret := _m.Called(email, expiresAt, locale, siteURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendGuestAccessExpiryEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64, string, string) error); ok {
+		r0 = rf(email, expiresAt, locale, siteURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendGuestInviteEmails provides a mock function with given fields: team, channels, senderName, senderUserId, senderProfileImage, invites, siteURL, message, accessExpiresAt, errorWhenNotSent, isSystemAdmin, isFirstAdmin
+func (_m *ServiceInterface) SendGuestInviteEmails(team *model.Team, channels []*model.Channel, senderName string, senderUserId string, senderProfileImage []byte, invites []string, siteURL string, message string, accessExpiresAt int64, errorWhenNotSent bool, isSystemAdmin bool, isFirstAdmin bool) error {
+	ret := _m.Called(team, channels, senderName, senderUserId, senderProfileImage, invites, siteURL, message, accessExpiresAt, errorWhenNotSent, isSystemAdmin, isFirstAdmin)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SendGuestInviteEmails")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*model.Team, []*model.Channel, string, string, []byte, []string, string, string, bool, bool, bool) error); ok {
-		r0 = rf(team, channels, senderName, senderUserId, senderProfileImage, invites, siteURL, message, errorWhenNotSent, isSystemAdmin, isFirstAdmin)
+	if rf, ok := ret.Get(0).(func(*model.Team, []*model.Channel, string, string, []byte, []string, string, string, int64, bool, bool, bool) error); ok {
+		r0 = rf(team, channels, senderName, senderUserId, senderProfileImage, invites, siteURL, message, accessExpiresAt, errorWhenNotSent, isSystemAdmin, isFirstAdmin)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -356,6 +503,24 @@ func (_m *ServiceInterface) SendLicenseUpForRenewalEmail(_a0 string, name string
 	return r0
 }
 
+// SendMagicLinkEmail provides a mock function with given fields: email, token, expiryInMinutes, locale, siteURL
+func (_m *ServiceInterface) SendMagicLinkEmail(email string, token *model.Token, expiryInMinutes int, locale string, siteURL string) error {
+	ret := _m.Called(email, token, expiryInMinutes, locale, siteURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMagicLinkEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *model.Token, int, string, string) error); ok {
+		r0 = rf(email, token, expiryInMinutes, locale, siteURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendMailWithEmbeddedFiles provides a mock function with given fields: to, subject, htmlBody, embeddedFiles, messageID, inReplyTo, references, category
 func (_m *ServiceInterface) SendMailWithEmbeddedFiles(to string, subject string, htmlBody string, embeddedFiles map[string]io.Reader, messageID string, inReplyTo string, references string, category string) error {
 	ret := _m.Called(to, subject, htmlBody, embeddedFiles, messageID, inReplyTo, references, category)
@@ -556,6 +721,24 @@ func (_m *ServiceInterface) Stop() {
 	_m.Called()
 }
 
+// ValidateTemplateOverride provides a mock function with given fields: name, locale, text
+func (_m *ServiceInterface) ValidateTemplateOverride(name string, locale string, text string) error {
+	ret := _m.Called(name, locale, text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateTemplateOverride")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(name, locale, text)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewServiceInterface creates a new instance of ServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewServiceInterface(t interface {
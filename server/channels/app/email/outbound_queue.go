@@ -0,0 +1,329 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package email
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
+)
+
+const (
+	OutboundEmailQueueTaskName = "Outbound Email Queue"
+
+	outboundEmailQueueInterval  = 10 * time.Second
+	outboundEmailQueueBatchSize = 100
+	// outboundEmailQueueLease is how long a claimed email stays invisible to
+	// other workers and nodes. It must comfortably exceed the SMTP timeout.
+	outboundEmailQueueLease       = 5 * time.Minute
+	outboundEmailQueueBaseBackoff = 30 * time.Second
+	outboundEmailQueueMaxBackoff  = 6 * time.Hour
+
+	// outgoingEmailKeyInfo binds the key derived from the at rest encryption
+	// key to the outbound queue.
+	outgoingEmailKeyInfo = "mattermost outgoing email queue"
+)
+
+// OutboundEmailQueue periodically delivers the emails persisted in the
+// OutgoingEmails table. Every node runs it; rows are leased when claimed so that
+// each email is delivered by a single worker.
+type OutboundEmailQueue struct {
+	service *Service
+	// deliver is overridden in tests to avoid talking to an SMTP server.
+	deliver func(email *model.OutgoingEmail) error
+
+	task      *model.ScheduledTask
+	taskMutex sync.Mutex
+}
+
+func NewOutboundEmailQueue(es *Service) *OutboundEmailQueue {
+	return &OutboundEmailQueue{
+		service: es,
+		deliver: es.deliverOutgoingEmail,
+	}
+}
+
+// InitOutboundEmailQueue starts or stops the outbound queue according to the
+// current configuration. It is safe to call on every configuration change.
+func (es *Service) InitOutboundEmailQueue() {
+	if !*es.config().EmailSettings.EnableOutboundEmailQueue {
+		if es.OutboundQueue != nil {
+			es.OutboundQueue.Stop()
+		}
+		return
+	}
+
+	if es.OutboundQueue == nil {
+		es.OutboundQueue = NewOutboundEmailQueue(es)
+	}
+	es.OutboundQueue.Start()
+}
+
+func (q *OutboundEmailQueue) Start() {
+	q.taskMutex.Lock()
+	defer q.taskMutex.Unlock()
+
+	if q.task != nil {
+		return
+	}
+
+	mlog.Debug("Outbound email queue starting.", mlog.Float("interval_in_seconds", outboundEmailQueueInterval.Seconds()))
+	q.task = model.CreateRecurringTask(OutboundEmailQueueTaskName, q.ProcessPending, outboundEmailQueueInterval)
+}
+
+func (q *OutboundEmailQueue) Stop() {
+	q.taskMutex.Lock()
+	defer q.taskMutex.Unlock()
+
+	if q.task != nil {
+		q.task.Cancel()
+		q.task = nil
+	}
+}
+
+// ProcessPending claims the emails that are due and delivers them using the
+// configured number of workers, rescheduling the ones that fail.
+func (q *OutboundEmailQueue) ProcessPending() {
+	workers := max(*q.service.config().EmailSettings.OutboundEmailQueueWorkers, 1)
+
+	now := model.GetMillis()
+	emails, err := q.service.store.OutgoingEmail().ClaimPending(now, outboundEmailQueueLease.Milliseconds(), outboundEmailQueueBatchSize)
+	if err != nil {
+		mlog.Error("Failed to claim pending outgoing emails", mlog.Err(err))
+		return
+	}
+	if len(emails) == 0 {
+		return
+	}
+
+	queue := make(chan *model.OutgoingEmail)
+	var wg sync.WaitGroup
+	for range min(workers, len(emails)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for email := range queue {
+				q.process(email)
+			}
+		}()
+	}
+	for _, email := range emails {
+		queue <- email
+	}
+	close(queue)
+	wg.Wait()
+}
+
+func (q *OutboundEmailQueue) process(email *model.OutgoingEmail) {
+	sendErr := q.deliver(email)
+	if sendErr == nil {
+		if err := q.service.store.OutgoingEmail().Delete(email.Id); err != nil {
+			mlog.Error("Failed to remove delivered email from the outbound queue", mlog.String("email_id", email.Id), mlog.Err(err))
+		}
+		return
+	}
+
+	email.Attempts++
+	email.LastError = sendErr.Error()
+	maxAttempts := *q.service.config().EmailSettings.OutboundEmailQueueMaxAttempts
+	if email.Attempts >= maxAttempts {
+		email.Status = model.OutgoingEmailStatusDead
+		mlog.Warn("Giving up on outgoing email", mlog.String("email_id", email.Id), mlog.Int("attempts", email.Attempts), mlog.Err(sendErr))
+	} else {
+		email.NextAttemptAt = model.GetMillis() + outboundEmailBackoff(email.Attempts).Milliseconds()
+		mlog.Debug("Failed to send outgoing email, will retry", mlog.String("email_id", email.Id), mlog.Int("attempts", email.Attempts), mlog.Err(sendErr))
+	}
+
+	if _, err := q.service.store.OutgoingEmail().Update(email); err != nil {
+		mlog.Error("Failed to reschedule outgoing email", mlog.String("email_id", email.Id), mlog.Err(err))
+	}
+}
+
+// outboundEmailBackoff returns the delay before the next delivery attempt,
+// doubling after each failure up to outboundEmailQueueMaxBackoff.
+func outboundEmailBackoff(attempts int) time.Duration {
+	backoff := outboundEmailQueueBaseBackoff
+	for i := 1; i < attempts && backoff < outboundEmailQueueMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, outboundEmailQueueMaxBackoff)
+}
+
+func (es *Service) outboundQueueEnabled() bool {
+	return *es.config().EmailSettings.EnableOutboundEmailQueue
+}
+
+// enqueueMail persists an email for delivery by the outbound queue. The body is
+// encrypted since it may carry tokens, such as password reset links.
+func (es *Service) enqueueMail(email *model.OutgoingEmail) error {
+	key, err := es.getOutgoingEmailKey()
+	if err != nil {
+		return errors.Wrap(err, "unable to queue email")
+	}
+
+	email.HTMLBody, err = encryptOutgoingEmailBody(key, email.HTMLBody)
+	if err != nil {
+		return errors.Wrap(err, "unable to queue email")
+	}
+
+	if _, err := es.store.OutgoingEmail().Save(email); err != nil {
+		return errors.Wrap(err, "unable to queue email")
+	}
+	return nil
+}
+
+func (es *Service) deliverOutgoingEmail(email *model.OutgoingEmail) error {
+	key, err := es.getOutgoingEmailKey()
+	if err != nil {
+		return err
+	}
+
+	htmlBody, err := decryptOutgoingEmailBody(key, email.HTMLBody)
+	if err != nil {
+		return err
+	}
+
+	license := es.license()
+	mailConfig := es.mailServiceConfig(email.ReplyTo)
+
+	return mail.SendMailUsingConfig(email.To, email.Subject, htmlBody, mailConfig, license != nil && *license.Features.Compliance, email.MessageId, email.InReplyTo, email.References, email.Cc, email.Category)
+}
+
+// getOutgoingEmailKey returns the key the queued email bodies are encrypted
+// with. It's derived from SqlSettings.AtRestEncryptKey, which every node of
+// the cluster shares and which isn't stored alongside the queue in the
+// database. Changing that setting makes the pending emails undeliverable.
+func (es *Service) getOutgoingEmailKey() ([]byte, error) {
+	secret := *es.config().SqlSettings.AtRestEncryptKey
+	if secret == "" {
+		return nil, errors.New("no at rest encryption key is configured")
+	}
+
+	key, err := hkdf.Key(sha256.New, []byte(secret), nil, outgoingEmailKeyInfo, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to derive the outgoing email key")
+	}
+	return key, nil
+}
+
+func encryptOutgoingEmailBody(key []byte, body string) (string, error) {
+	aead, err := newOutgoingEmailCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Wrap(err, "unable to generate a nonce")
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(body), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decryptOutgoingEmailBody(key []byte, encoded string) (string, error) {
+	aead, err := newOutgoingEmailCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to decode the email body")
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("the email body is too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	body, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to decrypt the email body")
+	}
+	return string(body), nil
+}
+
+func newOutgoingEmailCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the cipher")
+	}
+	return cipher.NewGCM(block)
+}
+
+// RetryOutgoingEmail moves an email back to the pending state so that it is
+// picked up by the outbound queue on its next run.
+func (es *Service) RetryOutgoingEmail(id string) (*model.OutgoingEmail, error) {
+	email, err := es.store.OutgoingEmail().Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	email.Status = model.OutgoingEmailStatusPending
+	email.Attempts = 0
+	email.NextAttemptAt = model.GetMillis()
+
+	return es.store.OutgoingEmail().Update(email)
+}
+
+// dkimOptions returns the DKIM signing options for outgoing mail, or nil when
+// signing is disabled or the key cannot be loaded. The parsed key is cached
+// until ResetDKIMSigner is called, which every node does when the key is
+// replaced through the API, or the configured key file changes.
+func (es *Service) dkimOptions() *mail.DKIMOptions {
+	emailSettings := es.config().EmailSettings
+	if !*emailSettings.EnableDKIMSigning || es.getConfigFile == nil {
+		return nil
+	}
+
+	es.dkimMutex.Lock()
+	defer es.dkimMutex.Unlock()
+
+	keyFile := *emailSettings.DKIMPrivateKeyFile
+	if es.dkimSigner == nil || es.dkimKeyFile != keyFile {
+		signer, err := es.loadDKIMSigner(keyFile)
+		if err != nil {
+			mlog.Error("Unable to load DKIM private key, sending unsigned email", mlog.String("file", keyFile), mlog.Err(err))
+			return nil
+		}
+		es.dkimSigner = signer
+		es.dkimKeyFile = keyFile
+	}
+
+	return &mail.DKIMOptions{
+		Domain:   *emailSettings.DKIMDomain,
+		Selector: *emailSettings.DKIMSelector,
+		Signer:   es.dkimSigner,
+	}
+}
+
+func (es *Service) loadDKIMSigner(keyFile string) (crypto.Signer, error) {
+	data, err := es.getConfigFile(keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read DKIM private key")
+	}
+	return mail.ParseDKIMPrivateKey(data)
+}
+
+// ResetDKIMSigner drops the cached DKIM key so that it is reloaded from the
+// config store on the next send.
+func (es *Service) ResetDKIMSigner() {
+	es.dkimMutex.Lock()
+	defer es.dkimMutex.Unlock()
+
+	es.dkimSigner = nil
+	es.dkimKeyFile = ""
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package email
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestOutboundEmailBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, outboundEmailBackoff(1))
+	assert.Equal(t, 60*time.Second, outboundEmailBackoff(2))
+	assert.Equal(t, 4*time.Minute, outboundEmailBackoff(4))
+	assert.Equal(t, outboundEmailQueueMaxBackoff, outboundEmailBackoff(20))
+}
+
+func TestOutboundEmailQueueProcessPending(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := SetupWithStoreMock(t)
+	th.UpdateConfig(t, func(cfg *model.Config) {
+		*cfg.EmailSettings.OutboundEmailQueueMaxAttempts = 3
+		*cfg.EmailSettings.OutboundEmailQueueWorkers = 2
+	})
+
+	delivered := &model.OutgoingEmail{Id: model.NewId(), To: "ok@example.com", Status: model.OutgoingEmailStatusPending}
+	retried := &model.OutgoingEmail{Id: model.NewId(), To: "retry@example.com", Status: model.OutgoingEmailStatusPending, Attempts: 1}
+	dead := &model.OutgoingEmail{Id: model.NewId(), To: "dead@example.com", Status: model.OutgoingEmailStatusPending, Attempts: 2}
+
+	outgoingEmailStore := mocks.OutgoingEmailStore{}
+	outgoingEmailStore.On("ClaimPending", mock.AnythingOfType("int64"), outboundEmailQueueLease.Milliseconds(), outboundEmailQueueBatchSize).
+		Return([]*model.OutgoingEmail{delivered, retried, dead}, nil)
+	outgoingEmailStore.On("Delete", delivered.Id).Return(nil)
+	outgoingEmailStore.On("Update", mock.AnythingOfType("*model.OutgoingEmail")).
+		Return(func(email *model.OutgoingEmail) (*model.OutgoingEmail, error) { return email, nil })
+
+	mockStore := mocks.Store{}
+	mockStore.On("OutgoingEmail").Return(&outgoingEmailStore)
+	th.service.store = &mockStore
+
+	var mut sync.Mutex
+	var sent []string
+	queue := NewOutboundEmailQueue(th.service)
+	queue.deliver = func(email *model.OutgoingEmail) error {
+		mut.Lock()
+		defer mut.Unlock()
+		sent = append(sent, email.To)
+		if email.Id == delivered.Id {
+			return nil
+		}
+		return errors.New("connection refused")
+	}
+
+	before := model.GetMillis()
+	queue.ProcessPending()
+
+	assert.ElementsMatch(t, []string{delivered.To, retried.To, dead.To}, sent)
+	outgoingEmailStore.AssertCalled(t, "Delete", delivered.Id)
+	outgoingEmailStore.AssertNumberOfCalls(t, "Update", 2)
+
+	assert.Equal(t, model.OutgoingEmailStatusPending, retried.Status)
+	assert.Equal(t, 2, retried.Attempts)
+	assert.Equal(t, "connection refused", retried.LastError)
+	assert.GreaterOrEqual(t, retried.NextAttemptAt, before+outboundEmailBackoff(2).Milliseconds())
+
+	assert.Equal(t, model.OutgoingEmailStatusDead, dead.Status)
+	assert.Equal(t, 3, dead.Attempts)
+}
+
+func TestSendMailEnqueuesWhenQueueEnabled(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := SetupWithStoreMock(t)
+	th.UpdateConfig(t, func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableOutboundEmailQueue = true
+		*cfg.SqlSettings.AtRestEncryptKey = model.NewRandomString(32)
+	})
+
+	var saved *model.OutgoingEmail
+	outgoingEmailStore := mocks.OutgoingEmailStore{}
+	outgoingEmailStore.On("Save", mock.MatchedBy(func(email *model.OutgoingEmail) bool {
+		return email.To == "user@example.com" && email.Cc == "cc@example.com" && email.Subject == "subject"
+	})).Return(func(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
+		saved = email
+		return email, nil
+	}).Once()
+
+	mockStore := mocks.Store{}
+	mockStore.On("OutgoingEmail").Return(&outgoingEmailStore)
+	th.service.store = &mockStore
+
+	err := th.service.sendMailWithCC("user@example.com", "subject", "<p>body</p>", "cc@example.com", "Test")
+	require.NoError(t, err)
+	outgoingEmailStore.AssertExpectations(t)
+
+	require.NotNil(t, saved)
+	assert.NotContains(t, saved.HTMLBody, "body", "the body must be stored encrypted")
+
+	key, err := th.service.getOutgoingEmailKey()
+	require.NoError(t, err)
+	body, err := decryptOutgoingEmailBody(key, saved.HTMLBody)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>body</p>", body)
+
+	th.UpdateConfig(t, func(cfg *model.Config) {
+		*cfg.SqlSettings.AtRestEncryptKey = model.NewRandomString(32)
+	})
+	otherKey, err := th.service.getOutgoingEmailKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherKey, "the key must be derived from the at rest encryption key")
+}
+
+func TestOutgoingEmailBodyEncryption(t *testing.T) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	encrypted, err := encryptOutgoingEmailBody(key, "<a href=\"https://example.com/reset?token=secret\">reset</a>")
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, "secret")
+
+	body, err := decryptOutgoingEmailBody(key, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "<a href=\"https://example.com/reset?token=secret\">reset</a>", body)
+
+	otherKey := make([]byte, 32)
+	_, err = rand.Read(otherKey)
+	require.NoError(t, err)
+	_, err = decryptOutgoingEmailBody(otherKey, encrypted)
+	require.Error(t, err, "a body can't be decrypted with another key")
+
+	_, err = decryptOutgoingEmailBody(key, "not encrypted")
+	require.Error(t, err)
+}
+
+func TestDKIMOptions(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := SetupWithStoreMock(t)
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	loads := 0
+	th.service.getConfigFile = func(name string) ([]byte, error) {
+		loads++
+		if name != "dkim.pem" {
+			return nil, errors.New("not found")
+		}
+		return keyPEM, nil
+	}
+
+	require.Nil(t, th.service.dkimOptions(), "signing is disabled by default")
+
+	th.UpdateConfig(t, func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableDKIMSigning = true
+		*cfg.EmailSettings.DKIMDomain = "example.com"
+		*cfg.EmailSettings.DKIMSelector = "mm"
+		*cfg.EmailSettings.DKIMPrivateKeyFile = "dkim.pem"
+	})
+
+	opts := th.service.dkimOptions()
+	require.NotNil(t, opts)
+	assert.Equal(t, "example.com", opts.Domain)
+	assert.Equal(t, "mm", opts.Selector)
+	assert.Equal(t, key, opts.Signer)
+
+	require.NotNil(t, th.service.dkimOptions())
+	assert.Equal(t, 1, loads, "key should be cached")
+
+	th.service.ResetDKIMSigner()
+	require.NotNil(t, th.service.dkimOptions())
+	assert.Equal(t, 2, loads, "key should be reloaded after a reset")
+
+	th.UpdateConfig(t, func(cfg *model.Config) {
+		*cfg.EmailSettings.DKIMPrivateKeyFile = "missing.pem"
+	})
+	assert.Nil(t, th.service.dkimOptions(), "unsigned when the key can't be loaded")
+}
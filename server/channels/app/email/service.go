@@ -4,9 +4,11 @@
 package email
 
 import (
+	"crypto"
 	"io"
 	"net/url"
 	"path"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/throttled/throttled"
@@ -45,18 +47,31 @@ type Service struct {
 	config  func() *model.Config
 	license func() *model.License
 
-	userService *users.UserService
-	store       store.Store
+	userService   *users.UserService
+	store         store.Store
+	getConfigFile func(name string) ([]byte, error)
 
 	templatesContainer      *templates.Container
 	perHourEmailRateLimiter *throttled.GCRARateLimiter
 	perDayEmailRateLimiter  *throttled.GCRARateLimiter
 	EmailBatching           *EmailBatchingJob
+	OutboundQueue           *OutboundEmailQueue
+
+	dkimMutex   sync.Mutex
+	dkimSigner  crypto.Signer
+	dkimKeyFile string
+
+	templateOverridesMutex sync.Mutex
+	templateOverrides      map[string]*templates.Container
 }
 
 type ServiceConfig struct {
 	ConfigFn  func() *model.Config
 	LicenseFn func() *model.License
+	// ConfigFileFn reads files stored alongside the configuration, such as
+	// the DKIM private key and the email template overrides. Neither is
+	// available when it is nil.
+	ConfigFileFn func(name string) ([]byte, error)
 
 	TemplatesContainer *templates.Container
 	UserService        *users.UserService
@@ -73,11 +88,13 @@ func NewService(config ServiceConfig) (*Service, error) {
 		license:            config.LicenseFn,
 		store:              config.Store,
 		userService:        config.UserService,
+		getConfigFile:      config.ConfigFileFn,
 	}
 	if err := service.setUpRateLimiters(); err != nil {
 		return nil, err
 	}
 	service.InitEmailBatching()
+	service.InitOutboundEmailQueue()
 	return service, nil
 }
 
@@ -86,6 +103,9 @@ func (es *Service) Stop() {
 	if es.EmailBatching != nil {
 		es.EmailBatching.Stop()
 	}
+	if es.OutboundQueue != nil {
+		es.OutboundQueue.Stop()
+	}
 }
 
 func (c *ServiceConfig) validate() error {
@@ -138,11 +158,13 @@ type ServiceInterface interface {
 	SendPasswordChangeEmail(email, method, locale, siteURL string) error
 	SendUserAccessTokenAddedEmail(email, locale, siteURL string) error
 	SendPasswordResetEmail(email string, token *model.Token, locale, siteURL string) (bool, error)
+	SendMagicLinkEmail(email string, token *model.Token, expiryInMinutes int, locale, siteURL string) error
 	SendMfaChangeEmail(email string, activated bool, locale, siteURL string) error
 	SendInviteEmails(team *model.Team, senderName string, senderUserId string, invites []string, siteURL string, reminderData *model.TeamInviteReminderData, errorWhenNotSent bool, isSystemAdmin bool, isFirstAdmin bool) error
-	SendGuestInviteEmails(team *model.Team, channels []*model.Channel, senderName string, senderUserId string, senderProfileImage []byte, invites []string, siteURL string, message string, errorWhenNotSent bool, isSystemAdmin bool, isFirstAdmin bool) error
+	SendGuestInviteEmails(team *model.Team, channels []*model.Channel, senderName string, senderUserId string, senderProfileImage []byte, invites []string, siteURL string, message string, accessExpiresAt int64, errorWhenNotSent bool, isSystemAdmin bool, isFirstAdmin bool) error
 	SendInviteEmailsToTeamAndChannels(team *model.Team, channels []*model.Channel, senderName string, senderUserId string, senderProfileImage []byte, invites []string, siteURL string, reminderData *model.TeamInviteReminderData, message string, errorWhenNotSent bool, isSystemAdmin bool, isFirstAdmin bool) ([]*model.EmailInviteWithError, error)
 	SendDeactivateAccountEmail(email string, locale, siteURL string) error
+	SendGuestAccessExpiryEmail(email string, expiresAt int64, locale, siteURL string) error
 	SendNotificationMail(to, subject, htmlBody string) error
 	SendMailWithEmbeddedFiles(to, subject, htmlBody string, embeddedFiles map[string]io.Reader, messageID string, inReplyTo string, references string, category string) error
 	SendLicenseUpForRenewalEmail(email, name, locale, siteURL, ctaTitle, ctaLink, ctaText string, daysToExpiration int) error
@@ -151,6 +173,14 @@ type ServiceInterface interface {
 	GetMessageForNotification(post *model.Post, teamName, siteUrl string, translateFunc i18n.TranslateFunc) string
 	GenerateHyperlinkForChannels(postMessage, teamName, teamURL string) (string, error)
 	InitEmailBatching()
+	InitOutboundEmailQueue()
+	RetryOutgoingEmail(id string) (*model.OutgoingEmail, error)
+	ResetDKIMSigner()
+	SampleTemplateData(name, locale string) (templates.Data, error)
+	ValidateTemplateOverride(name, locale, text string) error
+	RenderTemplatePreview(name, locale string) (string, error)
+	RenderTemplate(name, locale string, data templates.Data) (string, error)
+	ResetTemplateOverrides()
 	SendChangeUsernameEmail(newUsername, email, locale, siteURL string) error
 	CreateVerifyEmailToken(userID string, newEmail string) (*model.Token, error)
 	SendIPFiltersChangedEmail(email string, userWhoChangedFilter *model.User, siteURL, portalURL, locale string, isWorkspaceOwner bool) error
@@ -0,0 +1,285 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package email
+
+import (
+	"fmt"
+	"html/template"
+	"slices"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/templates"
+)
+
+const (
+	sampleUserName        = "Alice Smith"
+	sampleEmail           = "alice@example.com"
+	sampleTeamDisplayName = "Sample Team"
+	sampleChannelName     = "Town Square"
+	sampleMessage         = "Looking forward to working with you!"
+)
+
+// templateSampleProps fills in sample values for the props that a template
+// receives on top of the ones set by NewEmailTemplateData. Some senders only
+// set a prop conditionally, so the samples include every prop a template may
+// receive: overrides are validated against them.
+type templateSampleProps func(T i18n.TranslateFunc, siteName, siteURL string, data templates.Data)
+
+var overridableTemplates = map[string]templateSampleProps{
+	"deactivate_body": func(T i18n.TranslateFunc, siteName, siteURL string, data templates.Data) {
+		data.Props["SiteURL"] = siteURL
+		data.Props["Title"] = T("api.templates.deactivate_body.title", map[string]any{"ServerURL": condenseSiteURL(siteURL)})
+		data.Props["Info"] = T("api.templates.deactivate_body.info", map[string]any{"SiteURL": siteURL})
+		data.Props["Warning"] = T("api.templates.deactivate_body.warning")
+	},
+	"email_change_body": func(T i18n.TranslateFunc, siteName, siteURL string, data templates.Data) {
+		data.Props["SiteURL"] = siteURL
+		data.Props["Title"] = T("api.templates.email_change_body.title")
+		data.Props["Info"] = T("api.templates.email_change_body.info",
+			map[string]any{"TeamDisplayName": siteName, "NewEmail": sampleEmail})
+		data.Props["Warning"] = T("api.templates.email_warning")
+	},
+	"email_change_verify_body": func(T i18n.TranslateFunc, siteName, siteURL string, data templates.Data) {
+		data.Props["SiteURL"] = siteURL
+		data.Props["Title"] = T("api.templates.email_change_verify_body.title")
+		data.Props["Info"] = T("api.templates.email_change_verify_body.info", map[string]any{"TeamDisplayName": siteName})
+		data.Props["VerifyUrl"] = siteURL + "/do_verify_email"
+		data.Props["VerifyButton"] = T("api.templates.email_change_verify_body.button")
+		data.Props["QuestionTitle"] = T("api.templates.questions_footer.title")
+		data.Props["EmailInfo1"] = T("api.templates.email_us_anytime_at")
+		data.Props["SupportEmail"] = "feedback@mattermost.com"
+		data.Props["FooterV2"] = T("api.templates.email_footer_v2")
+	},
+	"invite_body": func(T i18n.TranslateFunc, siteName, siteURL string, data templates.Data) {
+		data.Props["SiteURL"] = siteURL
+		data.Props["Title"] = T("api.templates.invite_body.title",
+			map[string]any{"SenderName": sampleUserName, "TeamDisplayName": sampleTeamDisplayName})
+		data.Props["SubTitle"] = T("api.templates.invite_body.subTitle")
+		data.Props["Button"] = T("api.templates.invite_body.button")
+		data.Props["ButtonURL"] = siteURL + "/signup_user_complete"
+		data.Props["SenderName"] = sampleUserName
+		data.Props["Message"] = sampleMessage
+		data.Props["InviteFooterTitle"] = T("api.templates.invite_body_footer.title")
+		data.Props["InviteFooterInfo"] = T("api.templates.invite_body_footer.info")
+		data.Props["InviteFooterLearnMore"] = T("api.templates.invite_body_footer.learn_more")
+		data.Props["Posts"] = []postData{{
+			SenderName: sampleUserName,
+			Message:    template.HTML(sampleMessage),
+		}}
+	},
+	"messages_notification": func(T i18n.TranslateFunc, siteName, siteURL string, data templates.Data) {
+		data.Props["SiteURL"] = siteURL
+		data.Props["Title"] = T("api.email_batching.send_batched_email_notification.title", 1)
+		data.Props["SubTitle"] = T("api.email_batching.send_batched_email_notification.subTitle")
+		data.Props["Button"] = T("api.email_batching.send_batched_email_notification.button")
+		data.Props["ButtonURL"] = siteURL
+		data.Props["SenderName"] = sampleUserName
+		data.Props["MessageButton"] = T("api.email_batching.send_batched_email_notification.messageButton")
+		data.Props["NotificationFooterTitle"] = T("app.notification.footer.title")
+		data.Props["NotificationFooterInfoLogin"] = T("app.notification.footer.infoLogin")
+		data.Props["NotificationFooterInfo"] = T("app.notification.footer.info")
+		data.Props["Posts"] = []postData{{
+			SenderName:      sampleUserName,
+			ChannelName:     sampleChannelName,
+			Message:         template.HTML(sampleMessage),
+			MessageURL:      siteURL,
+			ShowChannelIcon: true,
+		}}
+	},
+	"mfa_change_body": func(T i18n.TranslateFunc, siteName, siteURL string, data templates.Data) {
+		data.Props["SiteURL"] = siteURL
+		data.Props["Title"] = T("api.templates.mfa_activated_body.title")
+		data.Props["Info"] = T("api.templates.mfa_activated_body.info", map[string]any{"SiteURL": siteURL})
+		data.Props["Warning"] = T("api.templates.email_warning")
+	},
+	"password_change_body": func(T i18n.TranslateFunc, siteName, siteURL string, data templates.Data) {
+		data.Props["SiteURL"] = siteURL
+		data.Props["Title"] = T("api.templates.password_change_body.title")
+		data.Props["Info"] = T("api.templates.password_change_body.info",
+			map[string]any{"TeamDisplayName": siteName, "TeamURL": siteURL, "Method": "email"})
+		data.Props["Warning"] = T("api.templates.email_warning")
+	},
+	"reset_body": func(T i18n.TranslateFunc, siteName, siteURL string, data templates.Data) {
+		data.Props["SiteURL"] = siteURL
+		data.Props["Title"] = T("api.templates.reset_body.title")
+		data.Props["SubTitle"] = T("api.templates.reset_body.subTitle")
+		data.Props["Info"] = T("api.templates.reset_body.info")
+		data.Props["ButtonURL"] = siteURL + "/reset_password_complete"
+		data.Props["Button"] = T("api.templates.reset_body.button")
+		data.Props["QuestionTitle"] = T("api.templates.questions_footer.title")
+		data.Props["QuestionInfo"] = T("api.templates.questions_footer.info")
+	},
+	"signin_change_body": func(T i18n.TranslateFunc, siteName, siteURL string, data templates.Data) {
+		data.Props["SiteURL"] = siteURL
+		data.Props["Title"] = T("api.templates.signin_change_email.body.title")
+		data.Props["Info"] = T("api.templates.signin_change_email.body.info",
+			map[string]any{"SiteName": siteName, "Method": "email"})
+		data.Props["Warning"] = T("api.templates.email_warning")
+	},
+	"verify_body": func(T i18n.TranslateFunc, siteName, siteURL string, data templates.Data) {
+		data.Props["SiteURL"] = siteURL
+		data.Props["Title"] = T("api.templates.verify_body.title")
+		data.Props["SubTitle1"] = T("api.templates.verify_body.subTitle1")
+		data.Props["ServerURL"] = T("api.templates.verify_body.serverURL", map[string]any{"ServerURL": condenseSiteURL(siteURL)})
+		data.Props["SubTitle2"] = T("api.templates.verify_body.subTitle2")
+		data.Props["ButtonURL"] = siteURL + "/do_verify_email"
+		data.Props["Button"] = T("api.templates.verify_body.button")
+		data.Props["Info"] = T("api.templates.verify_body.info")
+		data.Props["Info1"] = T("api.templates.verify_body.info1")
+		data.Props["QuestionTitle"] = T("api.templates.questions_footer.title")
+		data.Props["QuestionInfo"] = T("api.templates.questions_footer.info")
+	},
+	"welcome_body": func(T i18n.TranslateFunc, siteName, siteURL string, data templates.Data) {
+		data.Props["SiteURL"] = siteURL
+		data.Props["Title"] = T("api.templates.welcome_body.title")
+		data.Props["SubTitle1"] = T("api.templates.welcome_body.subTitle1")
+		data.Props["ServerURL"] = T("api.templates.welcome_body.serverURL", map[string]any{"ServerURL": condenseSiteURL(siteURL)})
+		data.Props["SubTitle2"] = T("api.templates.welcome_body.subTitle2")
+		data.Props["Button"] = T("api.templates.welcome_body.button")
+		data.Props["ButtonURL"] = siteURL + "/do_verify_email"
+		data.Props["Info"] = T("api.templates.welcome_body.info")
+		data.Props["Info1"] = T("api.templates.welcome_body.info1")
+		data.Props["AppDownloadTitle"] = T("api.templates.welcome_body.app_download_title")
+		data.Props["AppDownloadInfo"] = T("api.templates.welcome_body.app_download_info")
+		data.Props["AppDownloadButton"] = T("api.templates.welcome_body.app_download_button")
+		data.Props["AppDownloadLink"] = "https://mattermost.com/pl/download-apps"
+	},
+}
+
+// OverridableTemplates returns the sorted names of the email templates that
+// can be overridden.
+func OverridableTemplates() []string {
+	names := make([]string, 0, len(overridableTemplates))
+	for name := range overridableTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsOverridableTemplate returns whether the email template can be overridden.
+func IsOverridableTemplate(name string) bool {
+	_, ok := overridableTemplates[name]
+	return ok
+}
+
+// TemplateOverrideFilename returns the name of the configuration file holding
+// the override of the template for the locale.
+func TemplateOverrideFilename(name, locale string) string {
+	return fmt.Sprintf("email_template_%s.%s.html", name, locale)
+}
+
+// templateLocale returns the locale the overrides are looked up with. Emails
+// sent without a locale are translated to the default server locale.
+func (es *Service) templateLocale(locale string) string {
+	if locale == "" {
+		return *es.config().LocalizationSettings.DefaultServerLocale
+	}
+	return locale
+}
+
+// SampleTemplateData returns the data the template is rendered with in
+// previews and validated against when overridden.
+func (es *Service) SampleTemplateData(name, locale string) (templates.Data, error) {
+	sampleProps, ok := overridableTemplates[name]
+	if !ok {
+		return templates.Data{}, errors.Errorf("email template %q can't be overridden", name)
+	}
+
+	locale = es.templateLocale(locale)
+	data := es.NewEmailTemplateData(locale)
+	sampleProps(i18n.GetUserTranslations(locale), *es.config().TeamSettings.SiteName, *es.config().ServiceSettings.SiteURL, data)
+
+	return data, nil
+}
+
+// ValidateTemplateOverride checks that the text parses and only uses the props
+// the template receives.
+func (es *Service) ValidateTemplateOverride(name, locale, text string) error {
+	data, err := es.SampleTemplateData(name, locale)
+	if err != nil {
+		return err
+	}
+
+	container, err := es.templatesContainer.Override(name, text)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the email template")
+	}
+
+	if err := container.Validate(name, data); err != nil {
+		return errors.Wrap(err, "failed to render the email template")
+	}
+
+	return nil
+}
+
+// RenderTemplatePreview renders the template as it would be sent for the
+// locale, using sample data.
+func (es *Service) RenderTemplatePreview(name, locale string) (string, error) {
+	data, err := es.SampleTemplateData(name, locale)
+	if err != nil {
+		return "", err
+	}
+
+	return es.RenderTemplate(name, locale, data)
+}
+
+// RenderTemplate renders the template, using its override for the locale if
+// there is one.
+func (es *Service) RenderTemplate(name, locale string, data templates.Data) (string, error) {
+	if container := es.templateOverride(name, es.templateLocale(locale)); container != nil {
+		return container.RenderToString(name, data)
+	}
+
+	return es.templatesContainer.RenderToString(name, data)
+}
+
+func (es *Service) templateOverride(name, locale string) *templates.Container {
+	if !IsOverridableTemplate(name) {
+		return nil
+	}
+
+	filename := TemplateOverrideFilename(name, locale)
+	if !slices.Contains(es.config().EmailSettings.TemplateOverrideFiles, filename) {
+		return nil
+	}
+
+	es.templateOverridesMutex.Lock()
+	defer es.templateOverridesMutex.Unlock()
+
+	if container, ok := es.templateOverrides[filename]; ok {
+		return container
+	}
+
+	// Failures are cached too, so that the default template is used until the
+	// override is replaced.
+	var container *templates.Container
+	if es.getConfigFile == nil {
+		mlog.Warn("Unable to load email template override, configuration files are unavailable", mlog.String("filename", filename))
+	} else if text, err := es.getConfigFile(filename); err != nil {
+		mlog.Warn("Unable to load email template override", mlog.String("filename", filename), mlog.Err(err))
+	} else if container, err = es.templatesContainer.Override(name, string(text)); err != nil {
+		mlog.Warn("Unable to parse email template override", mlog.String("filename", filename), mlog.Err(err))
+	}
+
+	if es.templateOverrides == nil {
+		es.templateOverrides = make(map[string]*templates.Container)
+	}
+	es.templateOverrides[filename] = container
+
+	return container
+}
+
+// ResetTemplateOverrides drops the loaded template overrides so that they are
+// read again from the configuration files.
+func (es *Service) ResetTemplateOverrides() {
+	es.templateOverridesMutex.Lock()
+	defer es.templateOverridesMutex.Unlock()
+
+	es.templateOverrides = nil
+}
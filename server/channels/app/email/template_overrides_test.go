@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package email
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestDefaultTemplatesMatchSampleData(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := SetupWithStoreMock(t)
+
+	for _, name := range OverridableTemplates() {
+		t.Run(name, func(t *testing.T) {
+			data, err := th.service.SampleTemplateData(name, "en")
+			require.NoError(t, err)
+			require.NoError(t, th.service.templatesContainer.Validate(name, data))
+		})
+	}
+}
+
+func TestValidateTemplateOverride(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := SetupWithStoreMock(t)
+
+	t.Run("valid override", func(t *testing.T) {
+		err := th.service.ValidateTemplateOverride("welcome_body", "en", `{{define "welcome_body"}}<p>{{.Props.Title}}</p>{{template "email_footer" .}}{{end}}`)
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		err := th.service.ValidateTemplateOverride("welcome_body", "en", `{{define "welcome_body"}}{{.Props.NoSuchField}}{{end}}`)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid syntax", func(t *testing.T) {
+		err := th.service.ValidateTemplateOverride("welcome_body", "en", `{{define "welcome_body"}}{{.Props.Title}`)
+		require.Error(t, err)
+	})
+
+	t.Run("template can't be overridden", func(t *testing.T) {
+		err := th.service.ValidateTemplateOverride("email_footer", "en", `footer`)
+		require.Error(t, err)
+	})
+}
+
+func TestRenderTemplateOverride(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := SetupWithStoreMock(t)
+
+	files := map[string][]byte{
+		TemplateOverrideFilename("welcome_body", "en"): []byte(`{{define "welcome_body"}}custom {{.Props.Title}}{{end}}`),
+	}
+	th.service.getConfigFile = func(name string) ([]byte, error) {
+		if data, ok := files[name]; ok {
+			return data, nil
+		}
+		return nil, os.ErrNotExist
+	}
+
+	data, err := th.service.SampleTemplateData("welcome_body", "en")
+	require.NoError(t, err)
+	data.Props["Title"] = "title"
+
+	defaultHTML, err := th.service.RenderTemplate("welcome_body", "en", data)
+	require.NoError(t, err)
+	assert.NotContains(t, defaultHTML, "custom title", "override should be ignored until configured")
+
+	th.UpdateConfig(t, func(cfg *model.Config) {
+		cfg.EmailSettings.TemplateOverrideFiles = []string{TemplateOverrideFilename("welcome_body", "en")}
+	})
+
+	html, err := th.service.RenderTemplate("welcome_body", "en", data)
+	require.NoError(t, err)
+	assert.Equal(t, "custom title", html)
+
+	html, err = th.service.RenderTemplate("welcome_body", "es", data)
+	require.NoError(t, err)
+	assert.Equal(t, defaultHTML, html, "other locales should keep the default template")
+
+	files[TemplateOverrideFilename("welcome_body", "en")] = []byte(`{{define "welcome_body"}}replaced{{end}}`)
+	th.service.ResetTemplateOverrides()
+
+	html, err = th.service.RenderTemplate("welcome_body", "en", data)
+	require.NoError(t, err)
+	assert.Equal(t, "replaced", html)
+}
+
+func TestInviteLocale(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic(t)
+
+	assert.Equal(t, "en", th.service.inviteLocale(th.BasicUser.Id))
+	assert.Equal(t, "en", th.service.inviteLocale("mmctl "+model.NewId()), "senders that aren't users should use the default server locale")
+
+	th.BasicUser.Locale = "es"
+	_, err := th.service.userService.UpdateUser(th.Context, th.BasicUser, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "es", th.service.inviteLocale(th.BasicUser.Id))
+}
@@ -31,6 +31,7 @@ func (es *Service) mailServiceConfig(replyToAddress string) *mail.SMTPConfig {
 		FeedbackName:                      *emailSettings.FeedbackName,
 		FeedbackEmail:                     *emailSettings.FeedbackEmail,
 		ReplyToAddress:                    replyToAddress,
+		DKIM:                              es.dkimOptions(),
 	}
 	return &cfg
 }
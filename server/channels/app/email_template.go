@@ -0,0 +1,130 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/v8/channels/app/email"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+)
+
+func checkEmailTemplate(name, locale string) *model.AppError {
+	if !email.IsOverridableTemplate(name) {
+		return model.NewAppError("checkEmailTemplate", "app.email_template.not_found.app_error", map[string]any{"Name": name}, "", http.StatusNotFound)
+	}
+
+	if _, ok := i18n.GetSupportedLocales()[locale]; !ok {
+		return model.NewAppError("checkEmailTemplate", "app.email_template.invalid_locale.app_error", map[string]any{"Locale": locale}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// GetEmailTemplates returns the email templates that can be overridden.
+func (a *App) GetEmailTemplates() []*model.EmailTemplate {
+	overrides := a.Config().EmailSettings.TemplateOverrideFiles
+
+	locales := make([]string, 0, len(i18n.GetSupportedLocales()))
+	for locale := range i18n.GetSupportedLocales() {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	names := email.OverridableTemplates()
+	emailTemplates := make([]*model.EmailTemplate, 0, len(names))
+	for _, name := range names {
+		emailTemplate := &model.EmailTemplate{Name: name, OverriddenLocales: []string{}}
+		for _, locale := range locales {
+			if slices.Contains(overrides, email.TemplateOverrideFilename(name, locale)) {
+				emailTemplate.OverriddenLocales = append(emailTemplate.OverriddenLocales, locale)
+			}
+		}
+		emailTemplates = append(emailTemplates, emailTemplate)
+	}
+
+	return emailTemplates
+}
+
+// GetEmailTemplatePreview renders the email template as it's sent for the
+// locale, filled with sample data.
+func (a *App) GetEmailTemplatePreview(name, locale string) (*model.EmailTemplatePreview, *model.AppError) {
+	if appErr := checkEmailTemplate(name, locale); appErr != nil {
+		return nil, appErr
+	}
+
+	html, err := a.Srv().EmailService.RenderTemplatePreview(name, locale)
+	if err != nil {
+		return nil, model.NewAppError("GetEmailTemplatePreview", "app.email_template.render.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return &model.EmailTemplatePreview{Name: name, Locale: locale, HTML: html}, nil
+}
+
+// SetEmailTemplateOverride replaces the email template for the locale with
+// the uploaded one, once validated against the data the template receives.
+func (a *App) SetEmailTemplateOverride(name, locale string, fileData *multipart.FileHeader) *model.AppError {
+	if appErr := checkEmailTemplate(name, locale); appErr != nil {
+		return appErr
+	}
+
+	file, err := fileData.Open()
+	if err != nil {
+		return model.NewAppError("SetEmailTemplateOverride", "api.email_template.upload.open.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return model.NewAppError("SetEmailTemplateOverride", "api.email_template.upload.saving.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err = a.Srv().EmailService.ValidateTemplateOverride(name, locale, string(data)); err != nil {
+		return model.NewAppError("SetEmailTemplateOverride", "app.email_template.invalid.app_error", map[string]any{"Error": err.Error()}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	filename := email.TemplateOverrideFilename(name, locale)
+	if err = a.Srv().platform.SetConfigFile(filename, data); err != nil {
+		return model.NewAppError("SetEmailTemplateOverride", "api.email_template.upload.saving.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.UpdateConfig(func(cfg *model.Config) {
+		if !slices.Contains(cfg.EmailSettings.TemplateOverrideFiles, filename) {
+			cfg.EmailSettings.TemplateOverrideFiles = append(cfg.EmailSettings.TemplateOverrideFiles, filename)
+		}
+	})
+	a.Srv().EmailService.ResetTemplateOverrides()
+
+	return nil
+}
+
+// ResetEmailTemplate removes the override of the email template for the
+// locale, so that the default template is used again.
+func (a *App) ResetEmailTemplate(name, locale string) *model.AppError {
+	if appErr := checkEmailTemplate(name, locale); appErr != nil {
+		return appErr
+	}
+
+	filename := email.TemplateOverrideFilename(name, locale)
+	if !slices.Contains(a.Config().EmailSettings.TemplateOverrideFiles, filename) {
+		return model.NewAppError("ResetEmailTemplate", "app.email_template.not_overridden.app_error", nil, "", http.StatusNotFound)
+	}
+
+	if err := a.Srv().platform.RemoveConfigFile(filename); err != nil {
+		return model.NewAppError("ResetEmailTemplate", "app.email_template.reset.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.UpdateConfig(func(cfg *model.Config) {
+		cfg.EmailSettings.TemplateOverrideFiles = utils.RemoveStringFromSlice(filename, cfg.EmailSettings.TemplateOverrideFiles)
+	})
+	a.Srv().EmailService.ResetTemplateOverrides()
+
+	return nil
+}
@@ -59,8 +59,8 @@ func (a *App) CreateEmoji(rctx request.CTX, sessionUserId string, emoji *model.E
 		return nil, model.NewAppError("CreateEmoji", "api.emoji.create.other_user.app_error", nil, "", http.StatusForbidden)
 	}
 
-	if existingEmoji, err := a.Srv().Store().Emoji().GetByName(rctx, emoji.Name, true); err == nil && existingEmoji != nil {
-		return nil, model.NewAppError("CreateEmoji", "api.emoji.create.duplicate.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	if appErr := a.validateEmojiNamesAndCategory(rctx, emoji); appErr != nil {
+		return nil, appErr
 	}
 
 	imageData := multiPartImageData.File["image"]
@@ -75,23 +75,157 @@ func (a *App) CreateEmoji(rctx request.CTX, sessionUserId string, emoji *model.E
 	}
 	defer file.Close()
 
+	return a.createEmoji(rctx, emoji, filename, file)
+}
+
+// createEmoji uploads the image of the already validated emoji, saves it and notifies the clients.
+func (a *App) createEmoji(rctx request.CTX, emoji *model.Emoji, filename string, file io.ReadSeeker) (*model.Emoji, *model.AppError) {
 	if appErr := a.uploadEmojiImage(rctx, emoji.Id, filename, file); appErr != nil {
 		return nil, appErr
 	}
 
-	emoji, err = a.Srv().Store().Emoji().Save(emoji)
+	emoji, err := a.Srv().Store().Emoji().Save(emoji)
 	if err != nil {
-		return nil, model.NewAppError("CreateEmoji", "app.emoji.create.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		var cErr *store.ErrConflict
+		switch {
+		case errors.As(err, &cErr):
+			return nil, model.NewAppError("CreateEmoji", "api.emoji.create.duplicate.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("CreateEmoji", "app.emoji.create.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
 	}
 
-	message := model.NewWebSocketEvent(model.WebsocketEventEmojiAdded, "", "", "", nil, "")
+	if appErr := a.publishEmojiEvent(model.WebsocketEventEmojiAdded, emoji); appErr != nil {
+		return nil, appErr
+	}
+
+	return emoji, nil
+}
+
+func (a *App) publishEmojiEvent(event model.WebsocketEventType, emoji *model.Emoji) *model.AppError {
+	message := model.NewWebSocketEvent(event, "", "", "", nil, "")
 	emojiJSON, jsonErr := json.Marshal(emoji)
 	if jsonErr != nil {
-		return nil, model.NewAppError("CreateEmoji", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
+		return model.NewAppError("publishEmojiEvent", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
 	}
 	message.Add("emoji", string(emojiJSON))
 	a.Publish(message)
-	return emoji, nil
+	return nil
+}
+
+// validateEmojiNamesAndCategory checks that the name and the aliases of the emoji aren't taken by
+// another emoji, and that its category exists.
+func (a *App) validateEmojiNamesAndCategory(rctx request.CTX, emoji *model.Emoji) *model.AppError {
+	for _, name := range emoji.Names() {
+		existingEmoji, err := a.Srv().Store().Emoji().GetByName(rctx, name, true)
+		if err == nil && existingEmoji != nil && existingEmoji.Id != emoji.Id {
+			return model.NewAppError("CreateEmoji", "api.emoji.create.duplicate.app_error", nil, "name="+name, http.StatusBadRequest)
+		}
+	}
+
+	if emoji.CategoryId != "" {
+		if _, appErr := a.GetEmojiCategory(emoji.CategoryId); appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
+}
+
+// PatchEmoji updates the category and the aliases of an emoji.
+func (a *App) PatchEmoji(rctx request.CTX, emojiId string, patch *model.EmojiPatch) (*model.Emoji, *model.AppError) {
+	emoji, appErr := a.GetEmoji(rctx, emojiId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	emoji.Patch(patch)
+	if appErr = emoji.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr = a.validateEmojiNamesAndCategory(rctx, emoji); appErr != nil {
+		return nil, appErr
+	}
+
+	updatedEmoji, err := a.Srv().Store().Emoji().Update(emoji)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		var cErr *store.ErrConflict
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("PatchEmoji", "app.emoji.get.no_result", nil, "", http.StatusNotFound).Wrap(err)
+		case errors.As(err, &cErr):
+			return nil, model.NewAppError("PatchEmoji", "api.emoji.create.duplicate.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("PatchEmoji", "app.emoji.update.app_error", nil, "id="+emojiId, http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if appErr := a.publishEmojiEvent(model.WebsocketEventEmojiUpdated, updatedEmoji); appErr != nil {
+		return nil, appErr
+	}
+
+	return updatedEmoji, nil
+}
+
+func (a *App) CreateEmojiCategory(rctx request.CTX, category *model.EmojiCategory) (*model.EmojiCategory, *model.AppError) {
+	category.Id = ""
+
+	savedCategory, err := a.Srv().Store().Emoji().SaveCategory(category)
+	if err != nil {
+		var appErr *model.AppError
+		var cErr *store.ErrConflict
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &cErr):
+			return nil, model.NewAppError("CreateEmojiCategory", "app.emoji_category.create.duplicate.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("CreateEmojiCategory", "app.emoji_category.create.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return savedCategory, nil
+}
+
+func (a *App) GetEmojiCategory(categoryId string) (*model.EmojiCategory, *model.AppError) {
+	category, err := a.Srv().Store().Emoji().GetCategory(categoryId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetEmojiCategory", "app.emoji_category.get.no_result", nil, "id="+categoryId, http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetEmojiCategory", "app.emoji_category.get.app_error", nil, "id="+categoryId, http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return category, nil
+}
+
+func (a *App) GetEmojiCategories() ([]*model.EmojiCategory, *model.AppError) {
+	categories, err := a.Srv().Store().Emoji().GetAllCategories()
+	if err != nil {
+		return nil, model.NewAppError("GetEmojiCategories", "app.emoji_category.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return categories, nil
+}
+
+// DeleteEmojiCategory deletes the category. Its emojis are kept, uncategorized.
+func (a *App) DeleteEmojiCategory(rctx request.CTX, categoryId string) *model.AppError {
+	if err := a.Srv().Store().Emoji().DeleteCategory(categoryId); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteEmojiCategory", "app.emoji_category.get.no_result", nil, "id="+categoryId, http.StatusNotFound).Wrap(err)
+		default:
+			return model.NewAppError("DeleteEmojiCategory", "app.emoji_category.delete.app_error", nil, "id="+categoryId, http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return nil
 }
 
 func (a *App) GetEmojiList(rctx request.CTX, page, perPage int, sort string) ([]*model.Emoji, *model.AppError) {
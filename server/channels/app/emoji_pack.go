@@ -0,0 +1,267 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"image"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	emojiPackMaxManifestSize = 1 << 20 // 1 MiB
+	emojiPackExportPageSize  = 200
+)
+
+// ImportEmojiPack creates the custom emojis of an emoji pack on behalf of the given user. Emojis
+// whose name is already taken are skipped and the categories of the pack are created as needed.
+func (a *App) ImportEmojiPack(rctx request.CTX, userId string, data io.ReaderAt, size int64) (*model.EmojiPackImportResult, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableCustomEmoji {
+		return nil, model.NewAppError("ImportEmojiPack", "api.emoji.disabled.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if *a.Config().FileSettings.DriverName == "" {
+		return nil, model.NewAppError("ImportEmojiPack", "api.emoji.storage.app_error", nil, "", http.StatusForbidden)
+	}
+
+	zipReader, err := zip.NewReader(data, size)
+	if err != nil {
+		return nil, model.NewAppError("ImportEmojiPack", "app.emoji_pack.import.open.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	files := make(map[string]*zip.File, len(zipReader.File))
+	for _, file := range zipReader.File {
+		files[file.Name] = file
+	}
+
+	manifest, appErr := readEmojiPackManifest(files[model.EmojiPackManifestName])
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	categoryIds := map[string]string{}
+	for _, category := range manifest.Categories {
+		categoryId, appErr := a.getOrCreateEmojiCategory(rctx, category.Name, category.DisplayName)
+		if appErr != nil {
+			return nil, appErr
+		}
+		categoryIds[category.Name] = categoryId
+	}
+
+	result := &model.EmojiPackImportResult{
+		Created: []*model.Emoji{},
+		Skipped: []string{},
+		Errors:  map[string]string{},
+	}
+
+	for _, packEmoji := range manifest.Emojis {
+		if _, err := a.Srv().Store().Emoji().GetByName(rctx, packEmoji.Name, false); err == nil {
+			result.Skipped = append(result.Skipped, packEmoji.Name)
+			continue
+		}
+
+		if packEmoji.Category != "" {
+			if _, ok := categoryIds[packEmoji.Category]; !ok {
+				categoryId, appErr := a.getOrCreateEmojiCategory(rctx, packEmoji.Category, "")
+				if appErr != nil {
+					result.Errors[packEmoji.Name] = appErr.Error()
+					continue
+				}
+				categoryIds[packEmoji.Category] = categoryId
+			}
+		}
+
+		emoji, appErr := a.importEmojiPackEmoji(rctx, userId, packEmoji, categoryIds[packEmoji.Category], files[packEmoji.Image])
+		if appErr != nil {
+			result.Errors[packEmoji.Name] = appErr.Error()
+			continue
+		}
+
+		result.Created = append(result.Created, emoji)
+	}
+
+	return result, nil
+}
+
+func readEmojiPackManifest(file *zip.File) (*model.EmojiPackManifest, *model.AppError) {
+	if file == nil {
+		return nil, model.NewAppError("ImportEmojiPack", "app.emoji_pack.import.no_manifest.app_error", map[string]any{"Name": model.EmojiPackManifestName}, "", http.StatusBadRequest)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, model.NewAppError("ImportEmojiPack", "app.emoji_pack.import.manifest.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	defer reader.Close()
+
+	var manifest model.EmojiPackManifest
+	if err := json.NewDecoder(io.LimitReader(reader, emojiPackMaxManifestSize)).Decode(&manifest); err != nil {
+		return nil, model.NewAppError("ImportEmojiPack", "app.emoji_pack.import.manifest.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	if appErr := manifest.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	return &manifest, nil
+}
+
+func (a *App) getOrCreateEmojiCategory(rctx request.CTX, name, displayName string) (string, *model.AppError) {
+	category, err := a.Srv().Store().Emoji().GetCategoryByName(name)
+	if err == nil {
+		return category.Id, nil
+	}
+
+	var nfErr *store.ErrNotFound
+	if !errors.As(err, &nfErr) {
+		return "", model.NewAppError("ImportEmojiPack", "app.emoji_category.get.app_error", nil, "name="+name, http.StatusInternalServerError).Wrap(err)
+	}
+
+	category, appErr := a.CreateEmojiCategory(rctx, &model.EmojiCategory{Name: name, DisplayName: displayName})
+	if appErr != nil {
+		return "", appErr
+	}
+
+	return category.Id, nil
+}
+
+func (a *App) importEmojiPackEmoji(rctx request.CTX, userId string, packEmoji *model.EmojiPackEmoji, categoryId string, file *zip.File) (*model.Emoji, *model.AppError) {
+	emoji := &model.Emoji{
+		CreatorId:  userId,
+		Name:       packEmoji.Name,
+		Aliases:    packEmoji.Aliases,
+		CategoryId: categoryId,
+	}
+
+	emoji.PreSave()
+	if appErr := emoji.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.validateEmojiNamesAndCategory(rctx, emoji); appErr != nil {
+		return nil, appErr
+	}
+
+	if file == nil {
+		return nil, model.NewAppError("ImportEmojiPack", "app.emoji_pack.import.missing_image.app_error", map[string]any{"Image": packEmoji.Image}, "", http.StatusBadRequest)
+	}
+
+	if file.UncompressedSize64 > MaxEmojiFileSize {
+		return nil, model.NewAppError("ImportEmojiPack", "api.emoji.create.too_large.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, model.NewAppError("ImportEmojiPack", "api.emoji.upload.open.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	defer reader.Close()
+
+	imageData, err := io.ReadAll(io.LimitReader(reader, MaxEmojiFileSize))
+	if err != nil {
+		return nil, model.NewAppError("ImportEmojiPack", "api.emoji.upload.open.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return a.createEmoji(rctx, emoji, path.Base(packEmoji.Image), bytes.NewReader(imageData))
+}
+
+// ExportEmojiPack writes all the custom emojis and emoji categories as an emoji pack.
+func (a *App) ExportEmojiPack(rctx request.CTX, w io.Writer) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableCustomEmoji {
+		return model.NewAppError("ExportEmojiPack", "api.emoji.disabled.app_error", nil, "", http.StatusForbidden)
+	}
+
+	categories, appErr := a.GetEmojiCategories()
+	if appErr != nil {
+		return appErr
+	}
+
+	manifest := &model.EmojiPackManifest{
+		Version:    model.EmojiPackManifestVersion,
+		Categories: make([]*model.EmojiPackCategory, 0, len(categories)),
+		Emojis:     []*model.EmojiPackEmoji{},
+	}
+
+	categoryNames := make(map[string]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.Id] = category.Name
+		manifest.Categories = append(manifest.Categories, &model.EmojiPackCategory{
+			Name:        category.Name,
+			DisplayName: category.DisplayName,
+		})
+	}
+
+	zipWriter := zip.NewWriter(w)
+
+	for page := 0; ; page++ {
+		emojis, appErr := a.GetEmojiList(rctx, page, emojiPackExportPageSize, model.EmojiSortByName)
+		if appErr != nil {
+			return appErr
+		}
+
+		for _, emoji := range emojis {
+			img, appErr := a.ReadFile(getEmojiImagePath(emoji.Id))
+			if appErr != nil {
+				rctx.Logger().Warn("Failed to read the image of an emoji, skipping it from the export", mlog.String("emoji_id", emoji.Id), mlog.Err(appErr))
+				continue
+			}
+
+			_, imageType, err := image.DecodeConfig(bytes.NewReader(img))
+			if err != nil {
+				rctx.Logger().Warn("Failed to decode the image of an emoji, skipping it from the export", mlog.String("emoji_id", emoji.Id), mlog.Err(err))
+				continue
+			}
+
+			imagePath := path.Join(model.EmojiPackImagesDir, emoji.Name+"."+imageType)
+			if err := writeEmojiPackFile(zipWriter, imagePath, img); err != nil {
+				return model.NewAppError("ExportEmojiPack", "app.emoji_pack.export.write.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+
+			manifest.Emojis = append(manifest.Emojis, &model.EmojiPackEmoji{
+				Name:     emoji.Name,
+				Aliases:  emoji.Aliases,
+				Category: categoryNames[emoji.CategoryId],
+				Image:    imagePath,
+			})
+		}
+
+		if len(emojis) < emojiPackExportPageSize {
+			break
+		}
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return model.NewAppError("ExportEmojiPack", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := writeEmojiPackFile(zipWriter, model.EmojiPackManifestName, manifestJSON); err != nil {
+		return model.NewAppError("ExportEmojiPack", "app.emoji_pack.export.write.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return model.NewAppError("ExportEmojiPack", "app.emoji_pack.export.write.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+func writeEmojiPackFile(zipWriter *zip.Writer, name string, data []byte) error {
+	fileWriter, err := zipWriter.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = fileWriter.Write(data)
+	return err
+}
@@ -4,9 +4,13 @@
 package app
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -27,3 +31,134 @@ func TestGetMultipleEmojiByName(t *testing.T) {
 	require.Nil(t, appErr)
 	assert.Empty(t, emojis)
 }
+
+func createTestEmojiPack(t *testing.T, manifest *model.EmojiPackManifest, images map[string][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+
+	manifestJSON, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, writeEmojiPackFile(zipWriter, model.EmojiPackManifestName, manifestJSON))
+
+	for name, data := range images {
+		require.NoError(t, writeEmojiPackFile(zipWriter, name, data))
+	}
+
+	require.NoError(t, zipWriter.Close())
+	return buf.Bytes()
+}
+
+func TestEmojiAliasesAndPacks(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableCustomEmoji = true
+	})
+
+	name := "emoji_" + model.NewId()[:10]
+	alias := "alias_" + model.NewId()[:10]
+	category := "category_" + model.NewId()[:10]
+
+	pack := createTestEmojiPack(t, &model.EmojiPackManifest{
+		Version:    model.EmojiPackManifestVersion,
+		Categories: []*model.EmojiPackCategory{{Name: category, DisplayName: "Category"}},
+		Emojis: []*model.EmojiPackEmoji{
+			{Name: name, Aliases: []string{alias}, Category: category, Image: "images/emoji.gif"},
+			{Name: "broken_" + model.NewId()[:10], Image: "images/missing.gif"},
+		},
+	}, map[string][]byte{
+		"images/emoji.gif": utils.CreateTestGif(t, 10, 10),
+	})
+
+	result, appErr := th.App.ImportEmojiPack(th.Context, th.BasicUser.Id, bytes.NewReader(pack), int64(len(pack)))
+	require.Nil(t, appErr)
+	require.Len(t, result.Created, 1)
+	assert.Empty(t, result.Skipped)
+	assert.Len(t, result.Errors, 1)
+
+	emoji := result.Created[0]
+	assert.Equal(t, name, emoji.Name)
+	assert.Equal(t, []string{alias}, emoji.Aliases)
+	require.NotEmpty(t, emoji.CategoryId)
+
+	t.Run("aliases resolve to the emoji", func(t *testing.T) {
+		emojis, appErr := th.App.GetMultipleEmojiByName(th.Context, []string{alias})
+		require.Nil(t, appErr)
+		require.Len(t, emojis, 1)
+		assert.Equal(t, emoji.Id, emojis[0].Id)
+
+		received, appErr := th.App.GetEmojiByName(th.Context, alias)
+		require.Nil(t, appErr)
+		assert.Equal(t, emoji.Id, received.Id)
+	})
+
+	t.Run("reactions using an alias are stored under the name of the emoji", func(t *testing.T) {
+		reaction, appErr := th.App.SaveReactionForPost(th.Context, &model.Reaction{
+			UserId:    th.BasicUser.Id,
+			PostId:    th.BasicPost.Id,
+			EmojiName: alias,
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, name, reaction.EmojiName)
+	})
+
+	t.Run("aliases can't collide with existing names", func(t *testing.T) {
+		other := createTestEmojiPack(t, &model.EmojiPackManifest{
+			Version: model.EmojiPackManifestVersion,
+			Emojis:  []*model.EmojiPackEmoji{{Name: "other_" + model.NewId()[:10], Aliases: []string{alias}, Image: "emoji.gif"}},
+		}, map[string][]byte{
+			"emoji.gif": utils.CreateTestGif(t, 10, 10),
+		})
+
+		result, appErr := th.App.ImportEmojiPack(th.Context, th.BasicUser.Id, bytes.NewReader(other), int64(len(other)))
+		require.Nil(t, appErr)
+		assert.Empty(t, result.Created)
+		assert.Len(t, result.Errors, 1)
+
+		_, appErr = th.App.PatchEmoji(th.Context, emoji.Id, &model.EmojiPatch{Aliases: &[]string{alias, alias}})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.emoji.duplicate_alias.app_error", appErr.Id)
+	})
+
+	t.Run("exported packs can be imported again", func(t *testing.T) {
+		var buf bytes.Buffer
+		appErr := th.App.ExportEmojiPack(th.Context, &buf)
+		require.Nil(t, appErr)
+
+		zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		files := map[string]*zip.File{}
+		for _, file := range zipReader.File {
+			files[file.Name] = file
+		}
+		manifest, appErr := readEmojiPackManifest(files[model.EmojiPackManifestName])
+		require.Nil(t, appErr)
+
+		var exported *model.EmojiPackEmoji
+		for _, packEmoji := range manifest.Emojis {
+			if packEmoji.Name == name {
+				exported = packEmoji
+			}
+		}
+		require.NotNil(t, exported)
+		assert.Equal(t, category, exported.Category)
+		assert.Equal(t, "images/"+name+".gif", exported.Image)
+		assert.Contains(t, files, exported.Image)
+
+		result, appErr := th.App.ImportEmojiPack(th.Context, th.BasicUser.Id, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.Nil(t, appErr)
+		assert.Contains(t, result.Skipped, name)
+	})
+
+	t.Run("deleting a category keeps its emojis", func(t *testing.T) {
+		appErr := th.App.DeleteEmojiCategory(th.Context, emoji.CategoryId)
+		require.Nil(t, appErr)
+
+		received, appErr := th.App.GetEmoji(th.Context, emoji.Id)
+		require.Nil(t, appErr)
+		assert.Empty(t, received.CategoryId)
+	})
+}
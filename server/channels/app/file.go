@@ -1081,7 +1081,7 @@ func (a *App) DoUploadFileExpectModification(rctx request.CTX, now time.Time, ra
 
 	var rejectionError *model.AppError
 	pluginContext := pluginContext(rctx)
-	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+	a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 		var newBytes bytes.Buffer
 		replacementInfo, rejectionReason := hooks.FileWillBeUploaded(pluginContext, info, bytes.NewReader(data), &newBytes)
 		if rejectionReason != "" {
@@ -0,0 +1,247 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const guestAccessExpiryDateFormat = "January 2, 2006 15:04 MST"
+
+// SetGuestAccessExpiry sets the time after which the guest is deactivated. An expiresAt of 0
+// removes the expiry.
+func (a *App) SetGuestAccessExpiry(rctx request.CTX, userID string, expiresAt int64) (*model.GuestAccessExpiry, *model.AppError) {
+	if expiresAt == 0 {
+		return nil, a.RemoveGuestAccessExpiry(userID)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !user.IsGuest() {
+		return nil, model.NewAppError("SetGuestAccessExpiry", "app.guest_access_expiry.not_guest.app_error", nil, "user_id="+userID, http.StatusBadRequest)
+	}
+
+	if expiresAt <= model.GetMillis() {
+		return nil, model.NewAppError("SetGuestAccessExpiry", "app.guest_access_expiry.in_past.app_error", nil, "user_id="+userID, http.StatusBadRequest)
+	}
+
+	expiry := &model.GuestAccessExpiry{
+		UserId:    userID,
+		ExpiresAt: expiresAt,
+	}
+	if appErr := expiry.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	savedExpiry, err := a.Srv().Store().User().SaveGuestAccessExpiry(expiry)
+	if err != nil {
+		return nil, model.NewAppError("SetGuestAccessExpiry", "app.guest_access_expiry.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return savedExpiry, nil
+}
+
+// DemoteUserToGuestWithAccessExpiry demotes the user to a guest and sets the time after which the
+// guest is deactivated. An expiresAt of 0 sets no expiry.
+func (a *App) DemoteUserToGuestWithAccessExpiry(rctx request.CTX, user *model.User, expiresAt int64) *model.AppError {
+	if expiresAt != 0 && expiresAt <= model.GetMillis() {
+		return model.NewAppError("DemoteUserToGuestWithAccessExpiry", "app.guest_access_expiry.in_past.app_error", nil, "user_id="+user.Id, http.StatusBadRequest)
+	}
+
+	if appErr := a.DemoteUserToGuest(rctx, user); appErr != nil {
+		return appErr
+	}
+
+	if expiresAt == 0 {
+		return nil
+	}
+
+	_, appErr := a.SetGuestAccessExpiry(rctx, user.Id, expiresAt)
+	return appErr
+}
+
+func (a *App) GetGuestAccessExpiry(userID string) (*model.GuestAccessExpiry, *model.AppError) {
+	expiry, err := a.Srv().Store().User().GetGuestAccessExpiry(userID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetGuestAccessExpiry", "app.guest_access_expiry.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetGuestAccessExpiry", "app.guest_access_expiry.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return expiry, nil
+}
+
+func (a *App) RemoveGuestAccessExpiry(userID string) *model.AppError {
+	if err := a.Srv().Store().User().DeleteGuestAccessExpiry(userID); err != nil {
+		return model.NewAppError("RemoveGuestAccessExpiry", "app.guest_access_expiry.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// ProcessGuestAccessExpiries deactivates the guests whose access expired and reminds the guests
+// whose access expires within GuestAccountsSettings.AccessExpiryReminderDays.
+func (a *App) ProcessGuestAccessExpiries() error {
+	rctx := request.EmptyContext(a.Log())
+	now := model.GetMillis()
+
+	for {
+		expiries, err := a.Srv().Store().User().GetGuestAccessExpiriesBefore(now, false, model.GuestAccessExpiryBatchSize)
+		if err != nil {
+			return model.NewAppError("ProcessGuestAccessExpiries", "app.guest_access_expiry.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		processed := 0
+		for _, expiry := range expiries {
+			if appErr := a.expireGuestAccess(rctx, expiry); appErr != nil {
+				rctx.Logger().Warn("Failed to expire guest access", mlog.String("user_id", expiry.UserId), mlog.Err(appErr))
+				continue
+			}
+			processed++
+		}
+
+		// Stop when every expiry was handled or when none of the remaining ones can be, to avoid
+		// fetching the same failing batch again.
+		if len(expiries) < model.GuestAccessExpiryBatchSize || processed == 0 {
+			break
+		}
+	}
+
+	reminderDays := *a.Config().GuestAccountsSettings.AccessExpiryReminderDays
+	if reminderDays <= 0 {
+		return nil
+	}
+
+	remindBefore := now + int64(reminderDays)*24*time.Hour.Milliseconds()
+	for {
+		expiries, err := a.Srv().Store().User().GetGuestAccessExpiriesBefore(remindBefore, true, model.GuestAccessExpiryBatchSize)
+		if err != nil {
+			return model.NewAppError("ProcessGuestAccessExpiries", "app.guest_access_expiry.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		processed := 0
+		for _, expiry := range expiries {
+			if appErr := a.remindGuestAccessExpiry(rctx, expiry); appErr != nil {
+				rctx.Logger().Warn("Failed to remind guest of access expiry", mlog.String("user_id", expiry.UserId), mlog.Err(appErr))
+				continue
+			}
+			processed++
+		}
+
+		if len(expiries) < model.GuestAccessExpiryBatchSize || processed == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (a *App) expireGuestAccess(rctx request.CTX, expiry *model.GuestAccessExpiry) *model.AppError {
+	user, appErr := a.GetUser(expiry.UserId)
+	if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+		return appErr
+	}
+
+	// Users that were deleted, promoted or already deactivated only need their expiry cleaned up.
+	if user != nil && user.IsGuest() && user.DeleteAt == 0 {
+		if appErr := a.removeGuestFromChannels(rctx, user); appErr != nil {
+			return appErr
+		}
+
+		if _, appErr := a.UpdateActive(rctx, user, false); appErr != nil {
+			return appErr
+		}
+
+		rctx.Logger().Info("Deactivated guest after access expiry", mlog.String("user_id", user.Id))
+	}
+
+	return a.RemoveGuestAccessExpiry(expiry.UserId)
+}
+
+func (a *App) removeGuestFromChannels(rctx request.CTX, user *model.User) *model.AppError {
+	systemBot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		return appErr
+	}
+
+	teams, appErr := a.GetTeamsForUser(user.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, team := range teams {
+		channels, appErr := a.GetChannelsForTeamForUser(rctx, team.Id, user.Id, &model.ChannelSearchOpts{})
+		if appErr != nil {
+			return appErr
+		}
+
+		for _, channel := range channels {
+			if channel.IsGroupOrDirect() {
+				continue
+			}
+
+			if appErr := a.RemoveUserFromChannel(rctx, user.Id, systemBot.UserId, channel); appErr != nil {
+				rctx.Logger().Warn("Failed to remove expired guest from channel", mlog.String("user_id", user.Id), mlog.String("channel_id", channel.Id), mlog.Err(appErr))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *App) remindGuestAccessExpiry(rctx request.CTX, expiry *model.GuestAccessExpiry) *model.AppError {
+	user, appErr := a.GetUser(expiry.UserId)
+	if appErr != nil {
+		return appErr
+	}
+
+	systemBot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		return appErr
+	}
+
+	channel, appErr := a.GetOrCreateDirectChannel(rctx, user.Id, systemBot.UserId)
+	if appErr != nil {
+		return appErr
+	}
+
+	T := i18n.GetUserTranslations(user.Locale)
+	post := &model.Post{
+		ChannelId: channel.Id,
+		UserId:    systemBot.UserId,
+		Message: T("app.guest_access_expiry.reminder_message", map[string]any{
+			"ExpiryDate": model.GetTimeForMillis(expiry.ExpiresAt).UTC().Format(guestAccessExpiryDateFormat),
+		}),
+	}
+	if _, appErr := a.CreatePost(rctx, post, channel, model.CreatePostFlags{SetOnline: true}); appErr != nil {
+		return appErr
+	}
+
+	if *a.Config().EmailSettings.SendEmailNotifications {
+		if err := a.Srv().EmailService.SendGuestAccessExpiryEmail(user.Email, expiry.ExpiresAt, user.Locale, a.GetSiteURL()); err != nil {
+			rctx.Logger().Warn("Failed to send guest access expiry email", mlog.String("user_id", user.Id), mlog.Err(err))
+		}
+	}
+
+	if err := a.Srv().Store().User().MarkGuestAccessExpiryReminded(expiry.UserId, model.GetMillis()); err != nil {
+		return model.NewAppError("remindGuestAccessExpiry", "app.guest_access_expiry.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestGuestAccessExpiry(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.GuestAccountsSettings.Enable = true
+		*cfg.GuestAccountsSettings.AccessExpiryReminderDays = 3
+	})
+
+	newGuest := func(t *testing.T) *model.User {
+		t.Helper()
+
+		guest := th.CreateGuest(t)
+		th.LinkUserToTeam(t, guest, th.BasicTeam)
+		th.AddUserToChannel(t, guest, th.BasicChannel)
+		return guest
+	}
+
+	t.Run("only guests can have an access expiry", func(t *testing.T) {
+		_, appErr := th.App.SetGuestAccessExpiry(th.Context, th.BasicUser.Id, model.GetMillis()+time.Hour.Milliseconds())
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.guest_access_expiry.not_guest.app_error", appErr.Id)
+	})
+
+	t.Run("the access expiry must be in the future", func(t *testing.T) {
+		_, appErr := th.App.SetGuestAccessExpiry(th.Context, newGuest(t).Id, model.GetMillis()-1000)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.guest_access_expiry.in_past.app_error", appErr.Id)
+	})
+
+	t.Run("set and remove an access expiry", func(t *testing.T) {
+		guest := newGuest(t)
+		expiresAt := model.GetMillis() + time.Hour.Milliseconds()
+
+		expiry, appErr := th.App.SetGuestAccessExpiry(th.Context, guest.Id, expiresAt)
+		require.Nil(t, appErr)
+		assert.Equal(t, expiresAt, expiry.ExpiresAt)
+
+		expiry, appErr = th.App.GetGuestAccessExpiry(guest.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, expiresAt, expiry.ExpiresAt)
+
+		_, appErr = th.App.SetGuestAccessExpiry(th.Context, guest.Id, 0)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetGuestAccessExpiry(guest.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("promoting a guest removes the access expiry", func(t *testing.T) {
+		guest := newGuest(t)
+		_, appErr := th.App.SetGuestAccessExpiry(th.Context, guest.Id, model.GetMillis()+time.Hour.Milliseconds())
+		require.Nil(t, appErr)
+
+		appErr = th.App.PromoteGuestToUser(th.Context, guest, th.SystemAdminUser.Id)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetGuestAccessExpiry(guest.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("demote a user to a guest with an access expiry", func(t *testing.T) {
+		user := th.CreateUser(t)
+
+		appErr := th.App.DemoteUserToGuestWithAccessExpiry(th.Context, user, model.GetMillis()-1000)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.guest_access_expiry.in_past.app_error", appErr.Id)
+		user, appErr = th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.False(t, user.IsGuest(), "the user must not be demoted when the expiry is invalid")
+
+		expiresAt := model.GetMillis() + time.Hour.Milliseconds()
+		appErr = th.App.DemoteUserToGuestWithAccessExpiry(th.Context, user, expiresAt)
+		require.Nil(t, appErr)
+
+		expiry, appErr := th.App.GetGuestAccessExpiry(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, expiresAt, expiry.ExpiresAt)
+	})
+
+	t.Run("sign up with a guest invitation granting access until a given time", func(t *testing.T) {
+		newInvitation := func(t *testing.T, expiresAt int64) (*model.User, *model.Token) {
+			t.Helper()
+
+			email := strings.ToLower(model.NewId()) + "@example.com"
+			token := model.NewToken(TokenTypeGuestInvitation, model.MapToJSON(map[string]string{
+				"teamId":          th.BasicTeam.Id,
+				"email":           email,
+				"channels":        th.BasicChannel.Id,
+				"senderId":        th.BasicUser.Id,
+				"accessExpiresAt": strconv.FormatInt(expiresAt, 10),
+			}))
+			require.NoError(t, th.App.Srv().Store().Token().Save(token))
+			return &model.User{Email: email, Username: "guest" + model.NewId(), Password: "passwd1"}, token
+		}
+
+		user, token := newInvitation(t, model.GetMillis()-1000)
+		_, appErr := th.App.CreateUserWithToken(th.Context, user, token)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.create_user.guest_access_expired.app_error", appErr.Id)
+		_, err := th.App.Srv().Store().User().GetByEmail(user.Email)
+		require.Error(t, err, "no account must be created when the access already expired")
+
+		expiresAt := model.GetMillis() + time.Hour.Milliseconds()
+		user, token = newInvitation(t, expiresAt)
+		guest, appErr := th.App.CreateUserWithToken(th.Context, user, token)
+		require.Nil(t, appErr)
+
+		expiry, appErr := th.App.GetGuestAccessExpiry(guest.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, expiresAt, expiry.ExpiresAt)
+	})
+
+	t.Run("expired guests are deactivated and removed from their channels", func(t *testing.T) {
+		guest := newGuest(t)
+		_, err := th.App.Srv().Store().User().SaveGuestAccessExpiry(&model.GuestAccessExpiry{UserId: guest.Id, ExpiresAt: model.GetMillis() - 1000})
+		require.NoError(t, err)
+
+		require.NoError(t, th.App.ProcessGuestAccessExpiries())
+
+		guest, appErr := th.App.GetUser(guest.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, guest.DeleteAt)
+
+		_, appErr = th.App.GetChannelMember(th.Context, th.BasicChannel.Id, guest.Id)
+		assert.NotNil(t, appErr)
+
+		_, appErr = th.App.GetGuestAccessExpiry(guest.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("guests are reminded before their access expires", func(t *testing.T) {
+		guest := newGuest(t)
+		expiresAt := model.GetMillis() + 24*time.Hour.Milliseconds()
+		_, appErr := th.App.SetGuestAccessExpiry(th.Context, guest.Id, expiresAt)
+		require.Nil(t, appErr)
+
+		require.NoError(t, th.App.ProcessGuestAccessExpiries())
+
+		expiry, appErr := th.App.GetGuestAccessExpiry(guest.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, expiry.ReminderSentAt)
+
+		guest, appErr = th.App.GetUser(guest.Id)
+		require.Nil(t, appErr)
+		assert.Zero(t, guest.DeleteAt)
+
+		systemBot, appErr := th.App.GetSystemBot(th.Context)
+		require.Nil(t, appErr)
+		channel, appErr := th.App.GetOrCreateDirectChannel(th.Context, guest.Id, systemBot.UserId)
+		require.Nil(t, appErr)
+		posts, appErr := th.App.GetPosts(th.Context, channel.Id, 0, 10)
+		require.Nil(t, appErr)
+		assert.Len(t, posts.Order, 1)
+	})
+}
@@ -4,6 +4,8 @@
 package app
 
 import (
+	"net"
+
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 )
@@ -38,3 +40,38 @@ func (a *App) SendIPFiltersChangedEmail(rctx request.CTX, userID string) error {
 
 	return nil
 }
+
+// isIPAddressAllowed reports whether the IP filters of the workspace allow the given address.
+// Every address is allowed while IP filtering is unavailable or has no enabled range.
+func (a *App) isIPAddressAllowed(ipAddress string) (bool, error) {
+	if a.IPFiltering() == nil || !a.Config().FeatureFlags.CloudIPFiltering {
+		return true, nil
+	}
+
+	allowedRanges, err := a.IPFiltering().GetIPFilters()
+	if err != nil {
+		return false, err
+	}
+	if allowedRanges == nil {
+		return true, nil
+	}
+
+	ip := net.ParseIP(ipAddress)
+	filtered := false
+	for _, allowedRange := range *allowedRanges {
+		if !allowedRange.Enabled {
+			continue
+		}
+		filtered = true
+
+		_, network, err := net.ParseCIDR(allowedRange.CIDRBlock)
+		if err != nil {
+			continue
+		}
+		if ip != nil && network.Contains(ip) {
+			return true, nil
+		}
+	}
+
+	return !filtered, nil
+}
@@ -142,7 +142,7 @@ func (a *App) SessionHasPermissionToCreateJob(session model.Session, job *model.
 		return a.SessionHasPermissionTo(session, model.PermissionCreateDataRetentionJob), model.PermissionCreateDataRetentionJob
 	case model.JobTypeMessageExport:
 		return a.SessionHasPermissionTo(session, model.PermissionCreateComplianceExportJob), model.PermissionCreateComplianceExportJob
-	case model.JobTypeElasticsearchPostIndexing:
+	case model.JobTypeElasticsearchPostIndexing, model.JobTypePluginSearchIndexing:
 		return a.SessionHasPermissionTo(session, model.PermissionCreateElasticsearchPostIndexingJob), model.PermissionCreateElasticsearchPostIndexingJob
 	case model.JobTypeElasticsearchPostAggregation:
 		return a.SessionHasPermissionTo(session, model.PermissionCreateElasticsearchPostAggregationJob), model.PermissionCreateElasticsearchPostAggregationJob
@@ -153,12 +153,14 @@ func (a *App) SessionHasPermissionToCreateJob(session model.Session, job *model.
 		model.JobTypePlugins,
 		model.JobTypeProductNotices,
 		model.JobTypeExpiryNotify,
+		model.JobTypeGuestAccessExpiry,
 		model.JobTypeActiveUsers,
 		model.JobTypeImportProcess,
 		model.JobTypeImportDelete,
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
+		model.JobTypeAttributeGroupSync,
 		model.JobTypeExtractContent:
 		return a.SessionHasPermissionTo(session, model.PermissionManageJobs), model.PermissionManageJobs
 	case model.JobTypeAccessControlSync:
@@ -193,7 +195,7 @@ func (a *App) SessionHasPermissionToManageJob(session model.Session, job *model.
 		permission = model.PermissionManageDataRetentionJob
 	case model.JobTypeMessageExport:
 		permission = model.PermissionManageComplianceExportJob
-	case model.JobTypeElasticsearchPostIndexing:
+	case model.JobTypeElasticsearchPostIndexing, model.JobTypePluginSearchIndexing:
 		permission = model.PermissionManageElasticsearchPostIndexingJob
 	case model.JobTypeElasticsearchPostAggregation:
 		permission = model.PermissionManageElasticsearchPostAggregationJob
@@ -204,12 +206,14 @@ func (a *App) SessionHasPermissionToManageJob(session model.Session, job *model.
 		model.JobTypePlugins,
 		model.JobTypeProductNotices,
 		model.JobTypeExpiryNotify,
+		model.JobTypeGuestAccessExpiry,
 		model.JobTypeActiveUsers,
 		model.JobTypeImportProcess,
 		model.JobTypeImportDelete,
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
+		model.JobTypeAttributeGroupSync,
 		model.JobTypeExtractContent:
 		permission = model.PermissionManageJobs
 	case model.JobTypeAccessControlSync:
@@ -229,7 +233,7 @@ func (a *App) SessionHasPermissionToReadJob(session model.Session, jobType strin
 		return a.SessionHasPermissionTo(session, model.PermissionReadDataRetentionJob), model.PermissionReadDataRetentionJob
 	case model.JobTypeMessageExport:
 		return a.SessionHasPermissionTo(session, model.PermissionReadComplianceExportJob), model.PermissionReadComplianceExportJob
-	case model.JobTypeElasticsearchPostIndexing:
+	case model.JobTypeElasticsearchPostIndexing, model.JobTypePluginSearchIndexing:
 		return a.SessionHasPermissionTo(session, model.PermissionReadElasticsearchPostIndexingJob), model.PermissionReadElasticsearchPostIndexingJob
 	case model.JobTypeElasticsearchPostAggregation:
 		return a.SessionHasPermissionTo(session, model.PermissionReadElasticsearchPostAggregationJob), model.PermissionReadElasticsearchPostAggregationJob
@@ -240,6 +244,7 @@ func (a *App) SessionHasPermissionToReadJob(session model.Session, jobType strin
 		model.JobTypePlugins,
 		model.JobTypeProductNotices,
 		model.JobTypeExpiryNotify,
+		model.JobTypeGuestAccessExpiry,
 		model.JobTypeActiveUsers,
 		model.JobTypeImportProcess,
 		model.JobTypeImportDelete,
@@ -247,6 +252,7 @@ func (a *App) SessionHasPermissionToReadJob(session model.Session, jobType strin
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
 		model.JobTypeMobileSessionMetadata,
+		model.JobTypeAttributeGroupSync,
 		model.JobTypeExtractContent:
 		return a.SessionHasPermissionTo(session, model.PermissionReadJobs), model.PermissionReadJobs
 	case model.JobTypeAccessControlSync:
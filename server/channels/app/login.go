@@ -130,7 +130,7 @@ func (a *App) GetUserForLogin(rctx request.CTX, id, loginId string) (*model.User
 func (a *App) DoLogin(rctx request.CTX, w http.ResponseWriter, r *http.Request, user *model.User, deviceID string, isMobile, isOAuthUser, isSaml bool) (*model.Session, *model.AppError) {
 	var rejectionReason string
 	pluginContext := pluginContext(rctx)
-	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+	a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 		rejectionReason = hooks.UserWillLogIn(pluginContext, user)
 		return rejectionReason == ""
 	}, plugin.UserWillLogInID)
@@ -172,6 +172,8 @@ func (a *App) DoLogin(rctx request.CTX, w http.ResponseWriter, r *http.Request,
 	session.AddProp(model.SessionPropPlatform, plat)
 	session.AddProp(model.SessionPropOs, os)
 	session.AddProp(model.SessionPropBrowser, fmt.Sprintf("%v/%v", bname, bversion))
+	session.AddProp(model.SessionPropUserAgent, r.UserAgent())
+	session.AddProp(model.SessionPropLastIpAddress, rctx.IPAddress())
 	if user.IsGuest() {
 		session.AddProp(model.SessionPropIsGuest, "true")
 	} else {
@@ -180,7 +182,9 @@ func (a *App) DoLogin(rctx request.CTX, w http.ResponseWriter, r *http.Request,
 
 	var err *model.AppError
 	if session, err = a.CreateSession(rctx, session); err != nil {
-		err.StatusCode = http.StatusInternalServerError
+		if !isSessionPolicyError(err) {
+			err.StatusCode = http.StatusInternalServerError
+		}
 		return nil, err
 	}
 
@@ -201,7 +205,7 @@ func (a *App) DoLogin(rctx request.CTX, w http.ResponseWriter, r *http.Request,
 	}
 
 	a.Srv().Go(func() {
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.UserHasLoggedIn(pluginContext, user)
 			return true
 		}, plugin.UserHasLoggedInID)
@@ -0,0 +1,272 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/throttled/throttled"
+	"github.com/throttled/throttled/store/memstore"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const magicLinkRateLimitingMemstoreSize = 65536
+
+// magicLinkRateLimiters throttle the magic link requests per user and per IP address. They are
+// rebuilt whenever the configured limits change.
+type magicLinkRateLimiters struct {
+	maxPerUser int
+	maxPerIP   int
+	perUser    *throttled.GCRARateLimiter
+	perIP      *throttled.GCRARateLimiter
+}
+
+type magicLinkTokenExtra struct {
+	UserId string
+	Email  string
+}
+
+func newMagicLinkRateLimiters(maxPerUser, maxPerIP int) (*magicLinkRateLimiters, error) {
+	gcraStore, err := memstore.New(magicLinkRateLimitingMemstoreSize)
+	if err != nil {
+		return nil, err
+	}
+
+	perUser, err := throttled.NewGCRARateLimiter(gcraStore, throttled.RateQuota{
+		MaxRate:  throttled.PerHour(maxPerUser),
+		MaxBurst: maxPerUser - 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	perIP, err := throttled.NewGCRARateLimiter(gcraStore, throttled.RateQuota{
+		MaxRate:  throttled.PerHour(maxPerIP),
+		MaxBurst: maxPerIP - 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &magicLinkRateLimiters{
+		maxPerUser: maxPerUser,
+		maxPerIP:   maxPerIP,
+		perUser:    perUser,
+		perIP:      perIP,
+	}, nil
+}
+
+func (a *App) getMagicLinkRateLimiters() (*magicLinkRateLimiters, error) {
+	settings := a.Config().MagicLinkSettings
+
+	a.ch.magicLinkRateLimitersMut.Lock()
+	defer a.ch.magicLinkRateLimitersMut.Unlock()
+
+	limiters := a.ch.magicLinkRateLimiters
+	if limiters != nil && limiters.maxPerUser == *settings.MaxRequestsPerUserPerHour && limiters.maxPerIP == *settings.MaxRequestsPerIpPerHour {
+		return limiters, nil
+	}
+
+	limiters, err := newMagicLinkRateLimiters(*settings.MaxRequestsPerUserPerHour, *settings.MaxRequestsPerIpPerHour)
+	if err != nil {
+		return nil, err
+	}
+	a.ch.magicLinkRateLimiters = limiters
+
+	return limiters, nil
+}
+
+func (a *App) checkMagicLinkRateLimit(limiter *throttled.GCRARateLimiter, key string) *model.AppError {
+	limited, _, err := limiter.RateLimit(key, 1)
+	if err != nil {
+		return model.NewAppError("checkMagicLinkRateLimit", "app.magic_link.rate_limit.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if limited {
+		return model.NewAppError("checkMagicLinkRateLimit", "app.magic_link.too_many_requests.app_error", nil, "", http.StatusTooManyRequests)
+	}
+	return nil
+}
+
+// SendMagicLink emails the user with the given email address a single-use link signing them in.
+// Apart from the rate limit per IP address, the outcome is the same whether a link was sent or
+// not, so that the endpoint can't be used to discover accounts. The reason a link wasn't sent is
+// only logged.
+func (a *App) SendMagicLink(rctx request.CTX, email, siteURL string) (bool, *model.AppError) {
+	limiters, err := a.getMagicLinkRateLimiters()
+	if err != nil {
+		return false, model.NewAppError("SendMagicLink", "app.magic_link.rate_limit.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if ipAddress := rctx.IPAddress(); ipAddress != "" {
+		if appErr := a.checkMagicLinkRateLimit(limiters.perIP, "ip:"+ipAddress); appErr != nil {
+			return false, appErr
+		}
+	}
+
+	user, appErr := a.GetUserByEmail(email)
+	if appErr != nil {
+		rctx.Logger().Debug("Magic link not sent, no user found with the email address", mlog.Err(appErr))
+		return false, nil
+	}
+
+	if !a.Config().MagicLinkSettings.IsEnabledForAuthService(user.AuthService) {
+		rctx.Logger().Info("Magic link not sent, magic links are disabled for the auth service of the user", mlog.String("user_id", user.Id), mlog.String("auth_service", user.AuthService))
+		return false, nil
+	}
+
+	// don't allow magic links for remote/synthetic users, bots and deactivated users
+	if user.IsRemote() || user.IsBot || user.DeleteAt != 0 {
+		rctx.Logger().Info("Magic link not sent, the user is remote, a bot or deactivated", mlog.String("user_id", user.Id))
+		return false, nil
+	}
+
+	if appErr := a.checkMagicLinkRateLimit(limiters.perUser, "user:"+user.Id); appErr != nil {
+		rctx.Logger().Info("Magic link not sent, too many requests for the user", mlog.String("user_id", user.Id), mlog.Err(appErr))
+		return false, nil
+	}
+
+	token, appErr := a.CreateMagicLinkToken(rctx, user.Id, user.Email)
+	if appErr != nil {
+		rctx.Logger().Error("Magic link not sent, failed to create the token", mlog.String("user_id", user.Id), mlog.Err(appErr))
+		return false, nil
+	}
+
+	if err := a.Srv().EmailService.SendMagicLinkEmail(user.Email, token, *a.Config().MagicLinkSettings.ExpiryInMinutes, user.Locale, siteURL); err != nil {
+		rctx.Logger().Error("Magic link not sent, failed to send the email", mlog.String("user_id", user.Id), mlog.Err(err))
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// CreateMagicLinkToken stores a new magic link token for the user, invalidating the previous ones.
+func (a *App) CreateMagicLinkToken(rctx request.CTX, userID, email string) (*model.Token, *model.AppError) {
+	jsonData, err := json.Marshal(magicLinkTokenExtra{UserId: userID, Email: email})
+	if err != nil {
+		return nil, model.NewAppError("CreateMagicLinkToken", "app.magic_link.create_token.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if appErr := a.invalidateMagicLinkTokensForUser(userID); appErr != nil {
+		rctx.Logger().Warn("Error while deleting previous magic link tokens", mlog.String("user_id", userID), mlog.Err(appErr))
+	}
+
+	token := model.NewToken(TokenTypeMagicLink, string(jsonData))
+	if err := a.Srv().Store().Token().Save(token); err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateMagicLinkToken", "app.magic_link.create_token.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return token, nil
+}
+
+func (a *App) invalidateMagicLinkTokensForUser(userID string) *model.AppError {
+	if err := a.Srv().Store().Token().RemoveTokensByTypeAndUserId(TokenTypeMagicLink, userID); err != nil {
+		return model.NewAppError("invalidateMagicLinkTokensForUser", "app.magic_link.invalidate_tokens.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+// AuthenticateUserWithMagicLink returns the user a magic link token was sent to, consuming the
+// token. The login criteria of the user, MFA and the IP filters of the workspace are checked
+// first, so that the link remains usable while the MFA token is missing.
+func (a *App) AuthenticateUserWithMagicLink(rctx request.CTX, tokenString, mfaToken string) (*model.User, *model.AppError) {
+	token, err := a.Srv().Store().Token().GetByToken(tokenString)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.magic_link.invalid_link.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+		return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.magic_link.get_token.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if token.Type != TokenTypeMagicLink {
+		return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.magic_link.invalid_link.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	settings := a.Config().MagicLinkSettings
+	if model.GetMillis()-token.CreateAt >= int64(*settings.ExpiryInMinutes)*60*1000 {
+		if appErr := a.DeleteToken(token); appErr != nil {
+			rctx.Logger().Warn("Error while deleting expired magic link token", mlog.Err(appErr))
+		}
+		return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.magic_link.link_expired.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	var tokenExtra magicLinkTokenExtra
+	if err := json.Unmarshal([]byte(token.Extra), &tokenExtra); err != nil {
+		return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.magic_link.invalid_link.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	allowed, err := a.isIPAddressAllowed(rctx.IPAddress())
+	if err != nil {
+		return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.magic_link.ip_filters.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if !allowed {
+		return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.magic_link.ip_not_allowed.app_error", nil, "ip="+rctx.IPAddress(), http.StatusForbidden)
+	}
+
+	user, appErr := a.checkMagicLinkUserAndAllCriteria(rctx, tokenExtra, mfaToken)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Consuming the token makes sure that concurrent requests can't both use it.
+	if _, appErr := a.ConsumeTokenOnce(TokenTypeMagicLink, token.Token); appErr != nil {
+		return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.magic_link.invalid_link.app_error", nil, "", http.StatusBadRequest).Wrap(appErr)
+	}
+
+	return user, nil
+}
+
+func (a *App) checkMagicLinkUserAndAllCriteria(rctx request.CTX, tokenExtra magicLinkTokenExtra, mfaToken string) (*model.User, *model.AppError) {
+	// Use the lock of the email logins to avoid concurrently checking AND updating the failed login attempts.
+	a.ch.emailLoginAttemptsMut.Lock()
+	defer a.ch.emailLoginAttemptsMut.Unlock()
+
+	user, appErr := a.GetUser(tokenExtra.UserId)
+	if appErr != nil {
+		return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.magic_link.invalid_link.app_error", nil, "", http.StatusBadRequest).Wrap(appErr)
+	}
+
+	if user.Email != tokenExtra.Email || user.IsRemote() {
+		return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.magic_link.invalid_link.app_error", nil, "userId="+user.Id, http.StatusBadRequest)
+	}
+
+	if !a.Config().MagicLinkSettings.IsEnabledForAuthService(user.AuthService) {
+		return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.magic_link.disabled.app_error", nil, "userId="+user.Id, http.StatusBadRequest)
+	}
+
+	if appErr := a.CheckUserPreflightAuthenticationCriteria(rctx, user, mfaToken); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.CheckUserMfa(rctx, user, mfaToken); appErr != nil {
+		// Without an MFA token, the client is only asking whether the user needs one.
+		if mfaToken != "" {
+			if err := a.Srv().Store().User().UpdateFailedPasswordAttempts(user.Id, user.FailedAttempts+1); err != nil {
+				return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.user.update_failed_pwd_attempts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+		return nil, appErr
+	}
+
+	if err := a.Srv().Store().User().UpdateFailedPasswordAttempts(user.Id, 0); err != nil {
+		return nil, model.NewAppError("AuthenticateUserWithMagicLink", "app.user.update_failed_pwd_attempts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if appErr := a.CheckUserPostflightAuthenticationCriteria(rctx, user); appErr != nil {
+		return nil, appErr
+	}
+
+	return user, nil
+}
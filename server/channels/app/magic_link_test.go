@@ -0,0 +1,147 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	emailmocks "github.com/mattermost/mattermost/server/v8/channels/app/email/mocks"
+)
+
+func TestMagicLink(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.MagicLinkSettings.EnableForEmail = true
+	})
+
+	t.Run("authenticate with a magic link", func(t *testing.T) {
+		token, appErr := th.App.CreateMagicLinkToken(th.Context, th.BasicUser.Id, th.BasicUser.Email)
+		require.Nil(t, appErr)
+
+		user, appErr := th.App.AuthenticateUserWithMagicLink(th.Context, token.Token, "")
+		require.Nil(t, appErr)
+		assert.Equal(t, th.BasicUser.Id, user.Id)
+
+		_, appErr = th.App.AuthenticateUserWithMagicLink(th.Context, token.Token, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.magic_link.invalid_link.app_error", appErr.Id)
+	})
+
+	t.Run("a new magic link invalidates the previous ones", func(t *testing.T) {
+		first, appErr := th.App.CreateMagicLinkToken(th.Context, th.BasicUser.Id, th.BasicUser.Email)
+		require.Nil(t, appErr)
+		_, appErr = th.App.CreateMagicLinkToken(th.Context, th.BasicUser.Id, th.BasicUser.Email)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.AuthenticateUserWithMagicLink(th.Context, first.Token, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.magic_link.invalid_link.app_error", appErr.Id)
+	})
+
+	t.Run("expired magic links are rejected", func(t *testing.T) {
+		token := model.NewToken(TokenTypeMagicLink, model.MapToJSON(map[string]string{"UserId": th.BasicUser.Id, "Email": th.BasicUser.Email}))
+		token.CreateAt = model.GetMillis() - time.Hour.Milliseconds()
+		require.NoError(t, th.App.Srv().Store().Token().Save(token))
+
+		_, appErr := th.App.AuthenticateUserWithMagicLink(th.Context, token.Token, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.magic_link.link_expired.app_error", appErr.Id)
+	})
+
+	t.Run("magic links must be enabled for the auth service of the user", func(t *testing.T) {
+		token, appErr := th.App.CreateMagicLinkToken(th.Context, th.BasicUser.Id, th.BasicUser.Email)
+		require.Nil(t, appErr)
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.MagicLinkSettings.EnableForEmail = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.MagicLinkSettings.EnableForEmail = true })
+
+		_, appErr = th.App.AuthenticateUserWithMagicLink(th.Context, token.Token, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.magic_link.disabled.app_error", appErr.Id)
+	})
+
+	t.Run("deactivated users can't use a magic link", func(t *testing.T) {
+		user := th.CreateUser(t)
+		token, appErr := th.App.CreateMagicLinkToken(th.Context, user.Id, user.Email)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.UpdateActive(th.Context, user, false)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.AuthenticateUserWithMagicLink(th.Context, token.Token, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.login.inactive.app_error", appErr.Id)
+	})
+
+	t.Run("magic link requests are rate limited per user and per IP address", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.MagicLinkSettings.MaxRequestsPerUserPerHour = 1
+			*cfg.MagicLinkSettings.MaxRequestsPerIpPerHour = 2
+		})
+
+		emailServiceMock := emailmocks.ServiceInterface{}
+		emailServiceMock.On("SendMagicLinkEmail", mock.AnythingOfType("string"), mock.AnythingOfType("*model.Token"), model.MagicLinkSettingsDefaultExpiryInMinutes, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+		emailServiceMock.On("Stop").Return()
+		th.App.Srv().EmailService = &emailServiceMock
+
+		rctx := th.Context.WithIPAddress("10.0.0.1")
+		first, second := th.CreateUser(t), th.CreateUser(t)
+
+		sent, appErr := th.App.SendMagicLink(rctx, first.Email, "http://localhost")
+		require.Nil(t, appErr)
+		assert.True(t, sent)
+
+		// The per user limit isn't reported, to not reveal that the account exists.
+		sent, appErr = th.App.SendMagicLink(rctx, first.Email, "http://localhost")
+		require.Nil(t, appErr)
+		assert.False(t, sent)
+
+		_, appErr = th.App.SendMagicLink(rctx, second.Email, "http://localhost")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusTooManyRequests, appErr.StatusCode)
+
+		sent, appErr = th.App.SendMagicLink(th.Context.WithIPAddress("10.0.0.2"), second.Email, "http://localhost")
+		require.Nil(t, appErr)
+		assert.True(t, sent)
+
+		emailServiceMock.AssertNumberOfCalls(t, "SendMagicLinkEmail", 2)
+	})
+
+	t.Run("requests for accounts that can't use a magic link look the same as the others", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.MagicLinkSettings.MaxRequestsPerUserPerHour = 10
+			*cfg.MagicLinkSettings.MaxRequestsPerIpPerHour = 10
+		})
+
+		emailServiceMock := emailmocks.ServiceInterface{}
+		emailServiceMock.On("Stop").Return()
+		th.App.Srv().EmailService = &emailServiceMock
+
+		deactivated := th.CreateUser(t)
+		_, appErr := th.App.UpdateActive(th.Context, deactivated, false)
+		require.Nil(t, appErr)
+
+		bot, appErr := th.App.CreateBot(th.Context, &model.Bot{Username: "bot" + model.NewId(), OwnerId: th.BasicUser.Id})
+		require.Nil(t, appErr)
+		botUser, appErr := th.App.GetUser(bot.UserId)
+		require.Nil(t, appErr)
+
+		for _, email := range []string{"unknown@example.com", deactivated.Email, botUser.Email} {
+			sent, appErr := th.App.SendMagicLink(th.Context.WithIPAddress("10.0.0.3"), email, "http://localhost")
+			require.Nil(t, appErr)
+			assert.False(t, sent)
+		}
+
+		emailServiceMock.AssertNotCalled(t, "SendMagicLinkEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
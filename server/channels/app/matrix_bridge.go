@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/services/matrixbridge"
+	"github.com/mattermost/mattermost/server/v8/platform/services/sharedchannel"
+)
+
+// startMatrixBridge creates the Matrix bridge and registers it for shared channels
+// the same way plugins register.
+func (s *Server) startMatrixBridge(scs *sharedchannel.Service, appInstance *App) error {
+	rctx := request.EmptyContext(s.Log())
+
+	bot, appErr := appInstance.GetSystemBot(rctx)
+	if appErr != nil {
+		return appErr
+	}
+
+	bridge := matrixbridge.New(s, scs, appInstance, s.HTTPService().MakeClient(true))
+
+	s.serviceMux.Lock()
+	s.matrixBridge = bridge
+	s.serviceMux.Unlock()
+
+	_, err := appInstance.RegisterPluginForSharedChannels(rctx, model.RegisterPluginOpts{
+		Displayname: matrixbridge.RemoteName,
+		PluginID:    matrixbridge.BridgeID,
+		CreatorID:   bot.UserId,
+	})
+	return err
+}
+
+// restartMatrixBridge drops the running Matrix bridge and starts a new one if it's still enabled,
+// so that the bridge follows the changes of the Matrix settings. The registration of the bridge
+// for shared channels is kept while it's disabled, the channels it shares are synced again once
+// it's re-enabled.
+func (s *Server) restartMatrixBridge(scs *sharedchannel.Service, appInstance *App) {
+	s.serviceMux.Lock()
+	s.matrixBridge = nil
+	s.serviceMux.Unlock()
+
+	if !*s.platform.Config().ConnectedWorkspacesSettings.EnableMatrixBridge {
+		s.Log().Info("Matrix bridge disabled via config")
+		return
+	}
+
+	if err := s.startMatrixBridge(scs, appInstance); err != nil {
+		s.Log().Error("Failed to restart the Matrix bridge", mlog.Err(err))
+	}
+}
+
+// matrixBridgeSettingsChanged checks whether a config change requires the Matrix bridge to be
+// restarted.
+func matrixBridgeSettingsChanged(oldCfg, newCfg *model.Config) bool {
+	oldSettings, newSettings := oldCfg.ConnectedWorkspacesSettings, newCfg.ConnectedWorkspacesSettings
+	return *oldSettings.EnableMatrixBridge != *newSettings.EnableMatrixBridge ||
+		*oldSettings.MatrixHomeserverURL != *newSettings.MatrixHomeserverURL ||
+		*oldSettings.MatrixAppServiceToken != *newSettings.MatrixAppServiceToken
+}
+
+// GetMatrixBridge returns the Matrix bridge instantiated by the server.
+// May be nil if the bridge is not enabled via config.
+func (s *Server) GetMatrixBridge() *matrixbridge.Bridge {
+	s.serviceMux.RLock()
+	defer s.serviceMux.RUnlock()
+	return s.matrixBridge
+}
+
+// ServeMatrixAppServiceRequest handles the requests the Matrix homeserver makes to the
+// application service API of the bridge.
+func (ch *Channels) ServeMatrixAppServiceRequest(w http.ResponseWriter, r *http.Request) {
+	bridge := ch.srv.GetMatrixBridge()
+	if bridge == nil {
+		matrixbridge.WriteError(w, http.StatusNotFound, matrixbridge.ErrCodeUnrecognized, "The Matrix bridge is not enabled")
+		return
+	}
+
+	// the bridge serves the API from the root; strip the subpath of the site URL.
+	if i := strings.Index(r.URL.Path, "/_matrix/app/"); i > 0 {
+		http.StripPrefix(r.URL.Path[:i], bridge).ServeHTTP(w, r)
+		return
+	}
+	bridge.ServeHTTP(w, r)
+}
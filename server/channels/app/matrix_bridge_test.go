@@ -0,0 +1,40 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestMatrixBridgeSettingsChanged(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	oldCfg := &model.Config{}
+	oldCfg.SetDefaults()
+
+	for name, update := range map[string]func(cfg *model.Config){
+		"enabled": func(cfg *model.Config) { *cfg.ConnectedWorkspacesSettings.EnableMatrixBridge = true },
+		"homeserver url": func(cfg *model.Config) {
+			*cfg.ConnectedWorkspacesSettings.MatrixHomeserverURL = "https://matrix.example.com"
+		},
+		"token rotated": func(cfg *model.Config) { *cfg.ConnectedWorkspacesSettings.MatrixAppServiceToken = "new_token" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			newCfg := oldCfg.Clone()
+			update(newCfg)
+			assert.True(t, matrixBridgeSettingsChanged(oldCfg, newCfg))
+		})
+	}
+
+	t.Run("other settings", func(t *testing.T) {
+		newCfg := oldCfg.Clone()
+		*newCfg.ConnectedWorkspacesSettings.MatrixUserPrefix = "bridged_"
+		*newCfg.ServiceSettings.SiteURL = "https://mattermost.example.com"
+		assert.False(t, matrixBridgeSettingsChanged(oldCfg, newCfg))
+	})
+}
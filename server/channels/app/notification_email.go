@@ -174,7 +174,7 @@ func (a *App) sendNotificationEmail(rctx request.CTX, notification *PostNotifica
 
 	// Call plugin hook to allow customization of emailNotification
 	rejectionReason := ""
-	a.ch.RunMultiHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+	a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, manifest *model.Manifest) bool {
 		var replacementContent *model.EmailNotificationContent
 		replacementContent, rejectionReason = hooks.EmailNotificationWillBeSent(emailNotification)
 		if rejectionReason != "" {
@@ -392,5 +392,5 @@ func (a *App) getNotificationEmailBodyFromEmailNotification(rctx request.CTX, re
 		data.Props["Posts"] = []postData{}
 	}
 
-	return a.Srv().TemplatesContainer().RenderToString("messages_notification", data)
+	return a.Srv().EmailService.RenderTemplate("messages_notification", recipient.Locale, data)
 }
@@ -128,6 +128,10 @@ func (a *App) DeleteOAuthApp(rctx request.CTX, appID string) *model.AppError {
 		return model.NewAppError("DeleteOAuthApp", "app.oauth.delete_app.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if appErr := a.disableOAuthAppBot(rctx, appID); appErr != nil {
+		rctx.Logger().Warn("Failed to disable the bot of the deleted OAuth app", mlog.String("app_id", appID), mlog.Err(appErr))
+	}
+
 	if err := a.Srv().InvalidateAllCaches(); err != nil {
 		rctx.Logger().Warn("error in invalidating cache", mlog.Err(err))
 	}
@@ -188,6 +192,7 @@ func (a *App) GetOAuthCodeRedirect(userID string, authRequest *model.AuthorizeRe
 		CodeChallenge:       authRequest.CodeChallenge,
 		CodeChallengeMethod: authRequest.CodeChallengeMethod,
 		Resource:            authRequest.Resource,
+		Nonce:               authRequest.Nonce,
 	}
 	authData.Code = model.NewId() + model.NewId()
 
@@ -313,8 +318,11 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(rctx request.CTX, clientId, grantTy
 		return nil, err
 	}
 
-	if grantType == model.AccessTokenGrantType {
+	switch grantType {
+	case model.AccessTokenGrantType:
 		return a.handleAuthorizationCodeGrant(rctx, oauthApp, redirectURI, code, codeVerifier, clientId, resource)
+	case model.GrantTypeClientCredentials:
+		return a.handleClientCredentialsGrant(rctx, oauthApp, resource)
 	}
 
 	return a.handleRefreshTokenGrant(rctx, oauthApp, refreshToken, resource)
@@ -381,7 +389,12 @@ func (a *App) handleAuthorizationCodeGrant(rctx request.CTX, oauthApp *model.OAu
 		audience = authData.Resource // Use resource from authorization request
 	}
 
-	return a.generateAccessTokenResponse(rctx, oauthApp, user, clientId, redirectURI, authData.Scope, audience)
+	accessRsp, appErr := a.generateAccessTokenResponse(rctx, oauthApp, user, clientId, redirectURI, authData.Scope, audience)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.addOIDCIDToken(rctx, accessRsp, oauthApp, user, authData.Scope, authData.Nonce)
 }
 
 func (a *App) handleRefreshTokenGrant(rctx request.CTX, oauthApp *model.OAuthApp, refreshToken, resource string) (*model.AccessResponse, *model.AppError) {
@@ -415,9 +428,35 @@ func (a *App) handleRefreshTokenGrant(rctx request.CTX, oauthApp *model.OAuthApp
 		audience = resource
 	}
 
-	return a.newSessionUpdateToken(rctx, oauthApp, accessData, user, audience)
+	accessRsp, appErr := a.newSessionUpdateToken(rctx, oauthApp, accessData, user, audience)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.addOIDCIDToken(rctx, accessRsp, oauthApp, user, accessData.Scope, "")
+}
+
+// addOIDCIDToken adds a signed ID token to the access token response if the openid scope was
+// granted.
+func (a *App) addOIDCIDToken(rctx request.CTX, accessRsp *model.AccessResponse, oauthApp *model.OAuthApp, user *model.User, scope, nonce string) (*model.AccessResponse, *model.AppError) {
+	if !model.HasScope(scope, model.ScopeOpenID) {
+		return accessRsp, nil
+	}
+
+	idToken, appErr := a.createOIDCIDToken(rctx, oauthApp, user, scope, nonce)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	accessRsp.IDToken = idToken
+	accessRsp.Scope = scope
+
+	return accessRsp, nil
 }
 
+// generateAccessTokenResponse issues an access token for the user, reusing the one previously
+// issued to the client if any. Grants without a redirect URI, i.e. the client credentials grant,
+// get no refresh token since the client can request a new token with its credentials.
 func (a *App) generateAccessTokenResponse(rctx request.CTX, oauthApp *model.OAuthApp, user *model.User, clientId, redirectURI, scope, audience string) (*model.AccessResponse, *model.AppError) {
 	accessData, nErr := a.Srv().Store().OAuth().GetPreviousAccessData(user.Id, clientId)
 	if nErr != nil {
@@ -425,6 +464,11 @@ func (a *App) generateAccessTokenResponse(rctx request.CTX, oauthApp *model.OAut
 	}
 
 	if accessData != nil {
+		// A token granted for a different scope is replaced so that the new scope applies to it.
+		if accessData.Scope != scope {
+			accessData.Scope = scope
+			return a.newSessionUpdateToken(rctx, oauthApp, accessData, user, audience)
+		}
 		return a.handleExistingAccessData(rctx, oauthApp, accessData, user, audience)
 	}
 
@@ -459,7 +503,7 @@ func (a *App) createNewAccessData(rctx request.CTX, oauthApp *model.OAuthApp, us
 	}
 
 	refreshToken := ""
-	if !oauthApp.IsPublicClient() {
+	if !oauthApp.IsPublicClient() && redirectURI != "" {
 		refreshToken = model.NewId()
 	}
 
@@ -509,6 +553,9 @@ func (a *App) newSession(rctx request.CTX, app *model.OAuthApp, user *model.User
 	session.AddProp(model.SessionPropMattermostAppID, app.MattermostAppID)
 	session.AddProp(model.SessionPropOs, "OAuth2")
 	session.AddProp(model.SessionPropBrowser, "OAuth2")
+	if user.IsBot {
+		session.AddProp(model.SessionPropIsBot, model.SessionPropIsBotValue)
+	}
 
 	session, err := a.Srv().Store().Session().Save(rctx, session)
 	if err != nil {
@@ -534,8 +581,8 @@ func (a *App) newSessionUpdateToken(rctx request.CTX, app *model.OAuthApp, acces
 	}
 
 	accessData.Token = session.Token
-	// Generate refresh token only for confidential clients
-	if !app.IsPublicClient() {
+	// Generate refresh token only for confidential clients of grants with a redirect URI
+	if !app.IsPublicClient() && accessData.RedirectUri != "" {
 		accessData.RefreshToken = model.NewId()
 	} else {
 		accessData.RefreshToken = ""
@@ -612,6 +659,33 @@ func (a *App) GetOAuthSignupEndpoint(rctx request.CTX, w http.ResponseWriter, r
 	return authURL, nil
 }
 
+// GetOAuthLogoutURL returns where to send the browser after logging out of an SSO session: the
+// end session endpoint of the provider if it supports RP-initiated logout, or the login page.
+func (a *App) GetOAuthLogoutURL(rctx request.CTX, service string) (string, *model.AppError) {
+	loginURL := a.GetSiteURL() + "/login"
+
+	provider, appErr := a.getSSOProvider(service)
+	if appErr != nil {
+		return "", appErr
+	}
+
+	logoutProvider, ok := provider.(einterfaces.OAuthLogoutProvider)
+	if !ok {
+		return loginURL, nil
+	}
+
+	logoutURL, err := logoutProvider.GetLogoutURL(rctx, a.Config(), service, loginURL)
+	if err != nil {
+		return "", model.NewAppError("GetOAuthLogoutURL", "app.oauth.logout_url.app_error", map[string]any{"Service": service}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if logoutURL == "" {
+		return loginURL, nil
+	}
+
+	return logoutURL, nil
+}
+
 func (a *App) GetAuthorizedAppsForUser(userID string, page, perPage int) ([]*model.OAuthApp, *model.AppError) {
 	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
 		return nil, model.NewAppError("GetAuthorizedAppsForUser", "api.oauth.allow_oauth.turn_off.app_error", nil, "", http.StatusNotImplemented)
@@ -700,6 +774,68 @@ func (a *App) RevokeAccessToken(rctx request.CTX, token string) *model.AppError
 	return nil
 }
 
+// IntrospectOAuthToken describes an access token issued to the given confidential client as
+// defined in RFC 7662. Unknown, expired and revoked tokens, as well as tokens issued to other
+// clients, are reported as inactive.
+func (a *App) IntrospectOAuthToken(rctx request.CTX, clientId, secret, token string) (*model.TokenIntrospectionResponse, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("IntrospectOAuthToken", "api.oauth.get_access_token.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	oauthApp, nErr := a.Srv().Store().OAuth().GetApp(clientId)
+	if nErr != nil {
+		return nil, model.NewAppError("IntrospectOAuthToken", "app.oauth.introspect.invalid_client.app_error", nil, "", http.StatusUnauthorized).Wrap(nErr)
+	}
+
+	if oauthApp.IsPublicClient() {
+		return nil, model.NewAppError("IntrospectOAuthToken", "app.oauth.introspect.invalid_client.app_error", nil, "client_id="+clientId, http.StatusUnauthorized)
+	}
+
+	if err := oauthApp.ValidateForGrantType("", secret, ""); err != nil {
+		return nil, model.NewAppError("IntrospectOAuthToken", "app.oauth.introspect.invalid_client.app_error", nil, "client_id="+clientId, http.StatusUnauthorized).Wrap(err)
+	}
+
+	inactive := &model.TokenIntrospectionResponse{Active: false}
+
+	accessData, nErr := a.Srv().Store().OAuth().GetAccessData(token)
+	if nErr != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(nErr, &nfErr) {
+			return nil, model.NewAppError("IntrospectOAuthToken", "app.oauth.introspect.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
+		return inactive, nil
+	}
+
+	if accessData.ClientId != oauthApp.Id || accessData.IsExpired() {
+		return inactive, nil
+	}
+
+	// Revoking the session of a token leaves its access data behind, so the session decides
+	// whether the token can still be used.
+	session, nErr := a.Srv().Store().Session().Get(rctx, token)
+	if nErr != nil || session.IsExpired() {
+		return inactive, nil
+	}
+
+	user, nErr := a.Srv().Store().User().Get(context.Background(), accessData.UserId)
+	if nErr != nil || user.DeleteAt != 0 {
+		return inactive, nil
+	}
+
+	return &model.TokenIntrospectionResponse{
+		Active:    true,
+		Scope:     accessData.Scope,
+		ClientId:  accessData.ClientId,
+		Username:  user.Username,
+		TokenType: model.AccessTokenType,
+		Exp:       accessData.ExpiresAt / 1000,
+		Iat:       session.CreateAt / 1000,
+		Sub:       user.Id,
+		Aud:       accessData.Audience,
+		Iss:       *a.Config().ServiceSettings.SiteURL,
+	}, nil
+}
+
 func (a *App) CompleteOAuth(rctx request.CTX, service string, body io.ReadCloser, props map[string]string, tokenUser *model.User) (*model.User, *model.AppError) {
 	defer body.Close()
 
@@ -709,18 +845,32 @@ func (a *App) CompleteOAuth(rctx request.CTX, service string, body io.ReadCloser
 	inviteToken := props["invite_token"]
 	inviteId := props["invite_id"]
 
+	buf := bytes.Buffer{}
+	if _, err := buf.ReadFrom(body); err != nil {
+		return nil, model.NewAppError("CompleteOAuth", "api.user.login_by_oauth.parse.app_error",
+			map[string]any{"Service": service}, "", http.StatusBadRequest).Wrap(err)
+	}
+	userData := bytes.NewReader(buf.Bytes())
+
+	var user *model.User
+	var appErr *model.AppError
 	switch action {
 	case model.OAuthActionSignup:
-		return a.CreateOAuthUser(rctx, service, body, inviteToken, inviteId, tokenUser)
+		user, appErr = a.CreateOAuthUser(rctx, service, userData, inviteToken, inviteId, tokenUser)
 	case model.OAuthActionLogin:
-		return a.LoginByOAuth(rctx, service, body, inviteToken, inviteId, tokenUser)
+		user, appErr = a.LoginByOAuth(rctx, service, userData, inviteToken, inviteId, tokenUser)
 	case model.OAuthActionEmailToSSO:
-		return a.CompleteSwitchWithOAuth(rctx, service, body, props["email"], tokenUser)
+		user, appErr = a.CompleteSwitchWithOAuth(rctx, service, userData, props["email"], tokenUser)
 	case model.OAuthActionSSOToEmail:
-		return a.LoginByOAuth(rctx, service, body, inviteToken, inviteId, tokenUser)
+		user, appErr = a.LoginByOAuth(rctx, service, userData, inviteToken, inviteId, tokenUser)
 	default:
-		return a.LoginByOAuth(rctx, service, body, inviteToken, inviteId, tokenUser)
+		user, appErr = a.LoginByOAuth(rctx, service, userData, inviteToken, inviteId, tokenUser)
+	}
+	if appErr != nil {
+		return nil, appErr
 	}
+
+	return a.applyOAuthUserClaims(rctx, service, buf.Bytes(), user, tokenUser)
 }
 
 func (a *App) getSSOProvider(service string) (einterfaces.OAuthProvider, *model.AppError) {
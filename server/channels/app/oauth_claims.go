@@ -0,0 +1,184 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+// applyOAuthUserClaims updates the system role and the OIDC group memberships of a user that just
+// authenticated, for providers mapping claims to them. The returned user reflects the new role.
+func (a *App) applyOAuthUserClaims(rctx request.CTX, service string, userData []byte, user *model.User, tokenUser *model.User) (*model.User, *model.AppError) {
+	provider, appErr := a.getSSOProvider(service)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	claimsProvider, ok := provider.(einterfaces.OAuthClaimsProvider)
+	if !ok {
+		return user, nil
+	}
+
+	claims, err := claimsProvider.GetUserClaimsFromJSON(rctx, bytes.NewReader(userData), tokenUser)
+	if err != nil {
+		return nil, model.NewAppError("applyOAuthUserClaims", "api.user.login_by_oauth.parse.app_error", map[string]any{"Service": service}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	if claims.Groups != nil {
+		if appErr := a.syncOAuthUserGroups(rctx, user.Id, claims.Groups); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if claims.Role == "" {
+		return user, nil
+	}
+
+	if appErr := a.syncOAuthUserRole(rctx, user, claims.Role); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.GetUser(user.Id)
+}
+
+// syncOAuthUserRole makes the user a guest, a regular user or a system admin as asserted by the
+// provider. Other roles of the user are kept.
+func (a *App) syncOAuthUserRole(rctx request.CTX, user *model.User, role string) *model.AppError {
+	if role == model.SystemGuestRoleId {
+		if user.IsGuest() {
+			return nil
+		}
+
+		if !*a.Config().GuestAccountsSettings.Enable {
+			return model.NewAppError("syncOAuthUserRole", "app.oauth.claims.guest_accounts_disabled.app_error", nil, "user_id="+user.Id, http.StatusForbidden)
+		}
+
+		rctx.Logger().Info("Demoting user to guest as asserted by the OAuth provider", mlog.String("user_id", user.Id))
+		return a.DemoteUserToGuest(rctx, user)
+	}
+
+	if user.IsGuest() {
+		rctx.Logger().Info("Promoting guest to user as asserted by the OAuth provider", mlog.String("user_id", user.Id))
+		if appErr := a.PromoteGuestToUser(rctx, user, ""); appErr != nil {
+			return appErr
+		}
+
+		var appErr *model.AppError
+		if user, appErr = a.GetUser(user.Id); appErr != nil {
+			return appErr
+		}
+	}
+
+	isSystemAdmin := role == model.SystemAdminRoleId
+	if user.IsSystemAdmin() == isSystemAdmin {
+		return nil
+	}
+
+	roles := slices.DeleteFunc(strings.Fields(user.Roles), func(r string) bool { return r == model.SystemAdminRoleId })
+	if isSystemAdmin {
+		roles = append(roles, model.SystemAdminRoleId)
+	}
+
+	rctx.Logger().Info("Updating system admin role as asserted by the OAuth provider", mlog.String("user_id", user.Id), mlog.Bool("system_admin", isSystemAdmin))
+	_, appErr := a.UpdateUserRolesWithUser(rctx, user, strings.Join(roles, " "), true)
+	return appErr
+}
+
+// syncOAuthUserGroups makes the user a member of exactly the given OIDC groups, creating the ones
+// that don't exist yet. Groups deleted by an administrator are left alone.
+func (a *App) syncOAuthUserGroups(rctx request.CTX, userID string, remoteIDs []string) *model.AppError {
+	since := model.GetMillis()
+
+	current, appErr := a.GetGroupsByUserId(userID, model.GroupSearchOpts{})
+	if appErr != nil {
+		return appErr
+	}
+
+	wanted := make(map[string]bool, len(remoteIDs))
+	for _, remoteID := range remoteIDs {
+		if len(remoteID) > model.GroupRemoteIDMaxLength {
+			rctx.Logger().Warn("Skipping OIDC group with a too long name", mlog.String("remote_id", remoteID))
+			continue
+		}
+		wanted[remoteID] = true
+	}
+
+	for _, group := range current {
+		if group.Source != model.GroupSourceOIDC {
+			continue
+		}
+
+		if wanted[group.GetRemoteId()] {
+			delete(wanted, group.GetRemoteId())
+			continue
+		}
+
+		if _, appErr := a.DeleteGroupMember(group.Id, userID); appErr != nil {
+			return appErr
+		}
+		a.syncOAuthGroupSyncables(rctx, group.Id, since, true)
+	}
+
+	for remoteID := range wanted {
+		group, appErr := a.getOrCreateOIDCGroup(remoteID)
+		if appErr != nil {
+			return appErr
+		}
+		if group.DeleteAt != 0 {
+			continue
+		}
+
+		if _, appErr := a.UpsertGroupMember(group.Id, userID); appErr != nil {
+			return appErr
+		}
+		a.syncOAuthGroupSyncables(rctx, group.Id, since, false)
+	}
+
+	return nil
+}
+
+func (a *App) getOrCreateOIDCGroup(remoteID string) (*model.Group, *model.AppError) {
+	group, appErr := a.GetGroupByRemoteID(remoteID, model.GroupSourceOIDC)
+	if appErr == nil || appErr.StatusCode != http.StatusNotFound {
+		return group, appErr
+	}
+
+	// Keycloak reports groups as paths, e.g. "/engineering/backend".
+	displayName := strings.TrimPrefix(remoteID, "/")
+	if displayName == "" {
+		displayName = remoteID
+	}
+
+	group, appErr = a.CreateGroup(&model.Group{
+		DisplayName: displayName,
+		Source:      model.GroupSourceOIDC,
+		RemoteId:    model.NewPointer(remoteID),
+	})
+	if appErr != nil {
+		// A concurrent login may have created the group in the meantime.
+		if existing, err := a.GetGroupByRemoteID(remoteID, model.GroupSourceOIDC); err == nil {
+			return existing, nil
+		}
+		return nil, appErr
+	}
+
+	return group, nil
+}
+
+// syncOAuthGroupSyncables applies a membership change to the teams and channels linked to the
+// group. Failures are logged rather than failing the login.
+func (a *App) syncOAuthGroupSyncables(rctx request.CTX, groupID string, since int64, memberRemoved bool) {
+	if appErr := a.syncAttributeGroupSyncables(rctx, groupID, since, memberRemoved); appErr != nil {
+		appErr = model.NewAppError("syncOAuthGroupSyncables", "app.oauth.claims.group_syncables.app_error", nil, "", http.StatusInternalServerError).Wrap(appErr)
+		rctx.Logger().Warn("Failed to sync teams and channels of OIDC group", mlog.String("group_id", groupID), mlog.Err(appErr))
+	}
+}
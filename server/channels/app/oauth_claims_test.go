@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSyncOAuthUserRole(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	user := th.CreateUser(t)
+	user.Roles = model.SystemUserRoleId + " " + model.SystemUserManagerRoleId
+
+	t.Run("promote to system admin keeps other roles", func(t *testing.T) {
+		require.Nil(t, th.App.syncOAuthUserRole(th.Context, user, model.SystemAdminRoleId))
+
+		updated, appErr := th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.True(t, updated.IsSystemAdmin())
+		assert.Contains(t, updated.Roles, model.SystemUserManagerRoleId)
+		user = updated
+	})
+
+	t.Run("demote to regular user", func(t *testing.T) {
+		require.Nil(t, th.App.syncOAuthUserRole(th.Context, user, model.SystemUserRoleId))
+
+		updated, appErr := th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.False(t, updated.IsSystemAdmin())
+		assert.Contains(t, updated.Roles, model.SystemUserManagerRoleId)
+		user = updated
+	})
+
+	t.Run("guest accounts disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.GuestAccountsSettings.Enable = false })
+
+		appErr := th.App.syncOAuthUserRole(th.Context, user, model.SystemGuestRoleId)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.claims.guest_accounts_disabled.app_error", appErr.Id)
+	})
+
+	t.Run("demote to guest and promote back", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.GuestAccountsSettings.Enable = true })
+
+		require.Nil(t, th.App.syncOAuthUserRole(th.Context, user, model.SystemGuestRoleId))
+		guest, appErr := th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.True(t, guest.IsGuest())
+
+		require.Nil(t, th.App.syncOAuthUserRole(th.Context, guest, model.SystemAdminRoleId))
+		updated, appErr := th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.False(t, updated.IsGuest())
+		assert.True(t, updated.IsSystemAdmin())
+	})
+}
+
+func TestSyncOAuthUserGroups(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	getOIDCGroups := func(t *testing.T, userID string) []string {
+		t.Helper()
+
+		groups, appErr := th.App.GetGroupsByUserId(userID, model.GroupSearchOpts{})
+		require.Nil(t, appErr)
+
+		var remoteIDs []string
+		for _, group := range groups {
+			if group.Source == model.GroupSourceOIDC {
+				remoteIDs = append(remoteIDs, group.GetRemoteId())
+			}
+		}
+		return remoteIDs
+	}
+
+	ldapGroup := th.CreateGroup(t)
+	_, appErr := th.App.UpsertGroupMember(ldapGroup.Id, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	require.Nil(t, th.App.syncOAuthUserGroups(th.Context, th.BasicUser.Id, []string{"/engineering", "/engineering/backend"}))
+	assert.ElementsMatch(t, []string{"/engineering", "/engineering/backend"}, getOIDCGroups(t, th.BasicUser.Id))
+
+	group, appErr := th.App.GetGroupByRemoteID("/engineering/backend", model.GroupSourceOIDC)
+	require.Nil(t, appErr)
+	assert.Equal(t, "engineering/backend", group.DisplayName)
+
+	t.Run("existing groups are reused", func(t *testing.T) {
+		require.Nil(t, th.App.syncOAuthUserGroups(th.Context, th.BasicUser2.Id, []string{"/engineering"}))
+		assert.Equal(t, []string{"/engineering"}, getOIDCGroups(t, th.BasicUser2.Id))
+
+		engineering, appErr := th.App.GetGroupByRemoteID("/engineering", model.GroupSourceOIDC)
+		require.Nil(t, appErr)
+		count, appErr := th.App.GetGroupMemberCount(engineering.Id, nil)
+		require.Nil(t, appErr)
+		assert.EqualValues(t, 2, count)
+	})
+
+	t.Run("memberships no longer asserted are removed", func(t *testing.T) {
+		require.Nil(t, th.App.syncOAuthUserGroups(th.Context, th.BasicUser.Id, []string{"/engineering/backend"}))
+		assert.Equal(t, []string{"/engineering/backend"}, getOIDCGroups(t, th.BasicUser.Id))
+
+		require.Nil(t, th.App.syncOAuthUserGroups(th.Context, th.BasicUser.Id, []string{}))
+		assert.Empty(t, getOIDCGroups(t, th.BasicUser.Id))
+	})
+
+	t.Run("groups of other sources are left alone", func(t *testing.T) {
+		groups, appErr := th.App.GetGroupsByUserId(th.BasicUser.Id, model.GroupSearchOpts{})
+		require.Nil(t, appErr)
+		require.Len(t, groups, 1)
+		assert.Equal(t, ldapGroup.Id, groups[0].Id)
+	})
+}
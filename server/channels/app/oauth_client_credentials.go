@@ -0,0 +1,119 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// oauthAppBotUsernamePrefix is prepended to the id of an OAuth app to form the username of the
+// bot the app acts as in the client credentials grant.
+const oauthAppBotUsernamePrefix = "oauth-app-"
+
+// handleClientCredentialsGrant issues an access token for the bot owned by the OAuth app, see
+// RFC 6749 section 4.4. The token is not paired with a refresh token since the client can
+// request a new one with its credentials at any time.
+func (a *App) handleClientCredentialsGrant(rctx request.CTX, oauthApp *model.OAuthApp, resource string) (*model.AccessResponse, *model.AppError) {
+	if oauthApp.IsDynamicallyRegistered {
+		return nil, model.NewAppError("handleClientCredentialsGrant", "app.oauth.client_credentials.dynamic_client.app_error", nil, "client_id="+oauthApp.Id, http.StatusBadRequest)
+	}
+
+	if !*a.Config().ServiceSettings.EnableBotAccountCreation {
+		return nil, model.NewAppError("handleClientCredentialsGrant", "app.oauth.client_credentials.bots_disabled.app_error", nil, "client_id="+oauthApp.Id, http.StatusForbidden)
+	}
+
+	// The app acts on its own behalf through a bot, so it needs a creator allowed to create bots.
+	// Checking on every request revokes the grant once the creator loses the permission.
+	if !a.HasPermissionTo(oauthApp.CreatorId, model.PermissionCreateBot) {
+		return nil, model.NewAppError("handleClientCredentialsGrant", "app.oauth.client_credentials.creator_permission.app_error", nil, "client_id="+oauthApp.Id, http.StatusForbidden)
+	}
+
+	var audience string
+	if resource != "" {
+		// Validate the resource parameter per RFC 8707
+		if err := model.ValidateResourceParameter(resource, oauthApp.Id, "handleClientCredentialsGrant"); err != nil {
+			return nil, err
+		}
+		audience = resource
+	}
+
+	bot, appErr := a.getOrCreateOAuthAppBot(rctx, oauthApp)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	user, nErr := a.Srv().Store().User().Get(context.Background(), bot.UserId)
+	if nErr != nil {
+		return nil, model.NewAppError("handleClientCredentialsGrant", "api.oauth.get_access_token.internal_user.app_error", nil, "", http.StatusNotFound).Wrap(nErr)
+	}
+
+	if user.DeleteAt != 0 {
+		return nil, model.NewAppError("handleClientCredentialsGrant", "app.oauth.client_credentials.bot_disabled.app_error", nil, "client_id="+oauthApp.Id, http.StatusForbidden)
+	}
+
+	accessRsp, appErr := a.generateAccessTokenResponse(rctx, oauthApp, user, oauthApp.Id, "", model.DefaultScope, audience)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	accessRsp.RefreshToken = ""
+	accessRsp.Scope = model.DefaultScope
+
+	return accessRsp, nil
+}
+
+// getOrCreateOAuthAppBot returns the bot owned by the OAuth app, creating it on first use. An
+// administrator disabling the bot revokes the app's ability to act on its own behalf.
+func (a *App) getOrCreateOAuthAppBot(rctx request.CTX, oauthApp *model.OAuthApp) (*model.Bot, *model.AppError) {
+	bot, appErr := a.getOAuthAppBot(rctx, oauthApp.Id)
+	if appErr != nil || bot != nil {
+		return bot, appErr
+	}
+
+	bot, appErr = a.CreateBot(rctx, &model.Bot{
+		Username:    oauthAppBotUsernamePrefix + oauthApp.Id,
+		DisplayName: oauthApp.Name,
+		Description: oauthApp.Description,
+		OwnerId:     oauthApp.Id,
+	})
+	if appErr != nil {
+		// A concurrent request may have created the bot in the meantime.
+		if existing, err := a.getOAuthAppBot(rctx, oauthApp.Id); err == nil && existing != nil {
+			return existing, nil
+		}
+		return nil, appErr
+	}
+
+	return bot, nil
+}
+
+func (a *App) getOAuthAppBot(rctx request.CTX, appID string) (*model.Bot, *model.AppError) {
+	bots, appErr := a.GetBots(rctx, &model.BotGetOptions{
+		OwnerId:        appID,
+		IncludeDeleted: true,
+		Page:           0,
+		PerPage:        1,
+	})
+	if appErr != nil || len(bots) == 0 {
+		return nil, appErr
+	}
+
+	return bots[0], nil
+}
+
+// disableOAuthAppBot deactivates the bot of a deleted OAuth app, if it ever used the client
+// credentials grant.
+func (a *App) disableOAuthAppBot(rctx request.CTX, appID string) *model.AppError {
+	bot, appErr := a.getOAuthAppBot(rctx, appID)
+	if appErr != nil || bot == nil || bot.DeleteAt != 0 {
+		return appErr
+	}
+
+	_, appErr = a.UpdateBotActive(rctx, bot.UserId, false)
+	return appErr
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestOAuthClientCredentialsGrant(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOAuthServiceProvider = true
+		*cfg.ServiceSettings.EnableBotAccountCreation = true
+	})
+
+	createAppBy := func(t *testing.T, creatorID string) *model.OAuthApp {
+		t.Helper()
+
+		oauthApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+			Name:         "TestApp" + model.NewId(),
+			CreatorId:    creatorID,
+			Homepage:     "https://nowhere.com",
+			Description:  "test",
+			CallbackUrls: []string{"https://nowhere.com/callback"},
+		})
+		require.Nil(t, appErr)
+		return oauthApp
+	}
+
+	createApp := func(t *testing.T) *model.OAuthApp {
+		t.Helper()
+		return createAppBy(t, th.SystemAdminUser.Id)
+	}
+
+	getToken := func(oauthApp *model.OAuthApp, secret string) (*model.AccessResponse, *model.AppError) {
+		return th.App.GetOAuthAccessTokenForCodeFlow(th.Context, oauthApp.Id, model.GrantTypeClientCredentials, "", "", secret, "", "", "")
+	}
+
+	t.Run("issues a token for the app's bot", func(t *testing.T) {
+		oauthApp := createApp(t)
+
+		accessRsp, appErr := getToken(oauthApp, oauthApp.ClientSecret)
+		require.Nil(t, appErr)
+		require.NotEmpty(t, accessRsp.AccessToken)
+		assert.Empty(t, accessRsp.RefreshToken)
+		assert.Equal(t, model.DefaultScope, accessRsp.Scope)
+
+		session, appErr := th.App.GetSession(accessRsp.AccessToken)
+		require.Nil(t, appErr)
+		assert.True(t, session.IsOAuth)
+		assert.Equal(t, model.SessionPropIsBotValue, session.Props[model.SessionPropIsBot])
+
+		bot, appErr := th.App.GetBot(th.Context, session.UserId, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, oauthApp.Id, bot.OwnerId)
+		assert.Equal(t, oauthAppBotUsernamePrefix+oauthApp.Id, bot.Username)
+
+		accessData, err := th.App.Srv().Store().OAuth().GetAccessData(accessRsp.AccessToken)
+		require.NoError(t, err)
+		assert.Empty(t, accessData.RedirectUri)
+		assert.Empty(t, accessData.RefreshToken)
+
+		t.Run("reuses the bot", func(t *testing.T) {
+			accessRsp, appErr := getToken(oauthApp, oauthApp.ClientSecret)
+			require.Nil(t, appErr)
+
+			session, appErr := th.App.GetSession(accessRsp.AccessToken)
+			require.Nil(t, appErr)
+			assert.Equal(t, bot.UserId, session.UserId)
+		})
+
+		t.Run("disabled bot", func(t *testing.T) {
+			_, appErr := th.App.UpdateBotActive(th.Context, bot.UserId, false)
+			require.Nil(t, appErr)
+
+			_, appErr = getToken(oauthApp, oauthApp.ClientSecret)
+			require.NotNil(t, appErr)
+			assert.Equal(t, "app.oauth.client_credentials.bot_disabled.app_error", appErr.Id)
+		})
+	})
+
+	t.Run("creator not allowed to create bots", func(t *testing.T) {
+		oauthApp := createAppBy(t, th.BasicUser.Id)
+
+		_, appErr := getToken(oauthApp, oauthApp.ClientSecret)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.client_credentials.creator_permission.app_error", appErr.Id)
+
+		bot, appErr := th.App.getOAuthAppBot(th.Context, oauthApp.Id)
+		require.Nil(t, appErr)
+		assert.Nil(t, bot)
+	})
+
+	t.Run("wrong client secret", func(t *testing.T) {
+		oauthApp := createApp(t)
+
+		_, appErr := getToken(oauthApp, model.NewId())
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusUnauthorized, appErr.StatusCode)
+	})
+
+	t.Run("public client", func(t *testing.T) {
+		publicApp, appErr := th.App.RegisterOAuthClient(th.Context, &model.ClientRegistrationRequest{
+			RedirectURIs:            []string{"https://example.com/callback"},
+			TokenEndpointAuthMethod: model.NewPointer(model.ClientAuthMethodNone),
+		}, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		_, appErr = getToken(publicApp, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.oauth.validate_grant.public_client_credentials.app_error", appErr.Id)
+	})
+
+	t.Run("bot account creation disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableBotAccountCreation = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableBotAccountCreation = true })
+
+		oauthApp := createApp(t)
+
+		_, appErr := getToken(oauthApp, oauthApp.ClientSecret)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.client_credentials.bots_disabled.app_error", appErr.Id)
+	})
+
+	t.Run("deleting the app disables the bot", func(t *testing.T) {
+		oauthApp := createApp(t)
+
+		accessRsp, appErr := getToken(oauthApp, oauthApp.ClientSecret)
+		require.Nil(t, appErr)
+
+		session, appErr := th.App.GetSession(accessRsp.AccessToken)
+		require.Nil(t, appErr)
+
+		require.Nil(t, th.App.DeleteOAuthApp(th.Context, oauthApp.Id))
+
+		bot, appErr := th.App.GetBot(th.Context, session.UserId, true)
+		require.Nil(t, appErr)
+		assert.NotZero(t, bot.DeleteAt)
+	})
+}
+
+func TestIntrospectOAuthToken(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOAuthServiceProvider = true
+		*cfg.ServiceSettings.EnableBotAccountCreation = true
+	})
+
+	createApp := func(t *testing.T) *model.OAuthApp {
+		t.Helper()
+
+		oauthApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+			Name:         "TestApp" + model.NewId(),
+			CreatorId:    th.SystemAdminUser.Id,
+			Homepage:     "https://nowhere.com",
+			Description:  "test",
+			CallbackUrls: []string{"https://nowhere.com/callback"},
+		})
+		require.Nil(t, appErr)
+		return oauthApp
+	}
+
+	oauthApp := createApp(t)
+	accessRsp, appErr := th.App.GetOAuthAccessTokenForCodeFlow(th.Context, oauthApp.Id, model.GrantTypeClientCredentials, "", "", oauthApp.ClientSecret, "", "", "")
+	require.Nil(t, appErr)
+
+	t.Run("active token", func(t *testing.T) {
+		introspection, appErr := th.App.IntrospectOAuthToken(th.Context, oauthApp.Id, oauthApp.ClientSecret, accessRsp.AccessToken)
+		require.Nil(t, appErr)
+		assert.True(t, introspection.Active)
+		assert.Equal(t, oauthApp.Id, introspection.ClientId)
+		assert.Equal(t, model.DefaultScope, introspection.Scope)
+		assert.Equal(t, model.AccessTokenType, introspection.TokenType)
+		assert.Equal(t, oauthAppBotUsernamePrefix+oauthApp.Id, introspection.Username)
+		assert.NotEmpty(t, introspection.Sub)
+		assert.Greater(t, introspection.Exp, introspection.Iat)
+	})
+
+	t.Run("invalid client credentials", func(t *testing.T) {
+		_, appErr := th.App.IntrospectOAuthToken(th.Context, oauthApp.Id, model.NewId(), accessRsp.AccessToken)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.introspect.invalid_client.app_error", appErr.Id)
+		assert.Equal(t, http.StatusUnauthorized, appErr.StatusCode)
+
+		_, appErr = th.App.IntrospectOAuthToken(th.Context, model.NewId(), oauthApp.ClientSecret, accessRsp.AccessToken)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.introspect.invalid_client.app_error", appErr.Id)
+	})
+
+	t.Run("token of another client", func(t *testing.T) {
+		otherApp := createApp(t)
+
+		introspection, appErr := th.App.IntrospectOAuthToken(th.Context, otherApp.Id, otherApp.ClientSecret, accessRsp.AccessToken)
+		require.Nil(t, appErr)
+		assert.False(t, introspection.Active)
+		assert.Empty(t, introspection.ClientId)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		introspection, appErr := th.App.IntrospectOAuthToken(th.Context, oauthApp.Id, oauthApp.ClientSecret, model.NewId())
+		require.Nil(t, appErr)
+		assert.False(t, introspection.Active)
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		session, appErr := th.App.GetSession(accessRsp.AccessToken)
+		require.Nil(t, appErr)
+		require.Nil(t, th.App.RevokeSessionById(th.Context, session.Id))
+
+		introspection, appErr := th.App.IntrospectOAuthToken(th.Context, oauthApp.Id, oauthApp.ClientSecret, accessRsp.AccessToken)
+		require.Nil(t, appErr)
+		assert.False(t, introspection.Active)
+	})
+}
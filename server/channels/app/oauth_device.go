@@ -0,0 +1,354 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	mmctlOAuthAppName        = "mmctl"
+	mmctlOAuthAppDescription = "Mattermost command line tool"
+	mmctlOAuthAppHomepage    = "https://docs.mattermost.com/manage/mmctl-command-line-tool.html"
+
+	deviceAuthorizationSaveAttempts = 3
+)
+
+// CreateOAuthDeviceAuthorization starts a device authorization grant (RFC 8628) for the given
+// client. The returned user code is entered by the user on the verification page while the device
+// polls the token endpoint with the device code.
+func (a *App) CreateOAuthDeviceAuthorization(rctx request.CTX, clientID, clientSecret, scope string) (*model.DeviceAuthorizationResponse, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("CreateOAuthDeviceAuthorization", "api.oauth.allow_oauth.turn_off.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	siteURL := *a.Config().ServiceSettings.SiteURL
+	if siteURL == "" {
+		return nil, model.NewAppError("CreateOAuthDeviceAuthorization", "api.oauth.authorization_server_metadata.site_url_required.app_error", nil, "", http.StatusInternalServerError)
+	}
+
+	oauthApp, appErr := a.getOAuthDeviceClient(rctx, clientID, true)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := oauthApp.ValidateForGrantType(model.GrantTypeDeviceCode, clientSecret, ""); appErr != nil {
+		return nil, model.NewAppError("CreateOAuthDeviceAuthorization", "app.oauth.device.invalid_client.app_error", nil, "", http.StatusUnauthorized).Wrap(appErr)
+	}
+
+	if err := a.Srv().Store().OAuth().RemoveExpiredDeviceAuthorizations(model.GetMillis()); err != nil {
+		rctx.Logger().Warn("Failed to remove expired device authorizations", mlog.Err(err))
+	}
+
+	var authorization *model.DeviceAuthorization
+	for attempt := 1; ; attempt++ {
+		var err error
+		authorization, err = a.Srv().Store().OAuth().SaveDeviceAuthorization(model.NewDeviceAuthorization(oauthApp.Id, scope))
+		if err == nil {
+			break
+		}
+
+		var appErr *model.AppError
+		var cErr *store.ErrConflict
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &cErr) && attempt < deviceAuthorizationSaveAttempts:
+			// The random user code is already in use, try again with a new one.
+			continue
+		default:
+			return nil, model.NewAppError("CreateOAuthDeviceAuthorization", "app.oauth.device.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	verificationURI, err := url.JoinPath(siteURL, model.OAuthDeviceVerificationEndpoint)
+	if err != nil {
+		return nil, model.NewAppError("CreateOAuthDeviceAuthorization", "api.oauth.authorization_server_metadata.invalid_url.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return &model.DeviceAuthorizationResponse{
+		DeviceCode:              authorization.DeviceCode,
+		UserCode:                model.FormatDeviceUserCode(authorization.UserCode),
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?user_code=" + url.QueryEscape(model.FormatDeviceUserCode(authorization.UserCode)),
+		ExpiresIn:               (authorization.ExpiresAt - authorization.CreateAt) / 1000,
+		Interval:                authorization.PollInterval,
+	}, nil
+}
+
+// GetOAuthDeviceAuthorizationByUserCode returns the pending authorization the user code was issued
+// for, along with the app that requested it.
+func (a *App) GetOAuthDeviceAuthorizationByUserCode(userCode string) (*model.DeviceAuthorization, *model.OAuthApp, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, nil, model.NewAppError("GetOAuthDeviceAuthorizationByUserCode", "api.oauth.allow_oauth.turn_off.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	authorization, err := a.Srv().Store().OAuth().GetDeviceAuthorizationByUserCode(model.NormalizeDeviceUserCode(userCode))
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, nil, model.NewAppError("GetOAuthDeviceAuthorizationByUserCode", "app.oauth.device.invalid_user_code.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, nil, model.NewAppError("GetOAuthDeviceAuthorizationByUserCode", "app.oauth.device.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if authorization.IsExpired() || authorization.Status != model.DeviceAuthorizationStatusPending {
+		return nil, nil, model.NewAppError("GetOAuthDeviceAuthorizationByUserCode", "app.oauth.device.invalid_user_code.app_error", nil, "", http.StatusNotFound)
+	}
+
+	oauthApp, appErr := a.GetOAuthApp(authorization.ClientId)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	return authorization, oauthApp, nil
+}
+
+// VerifyOAuthDeviceAuthorization records the decision of the user on the authorization the user
+// code was issued for. Each authorization can only be decided once.
+func (a *App) VerifyOAuthDeviceAuthorization(rctx request.CTX, userID, userCode string, approve bool) (*model.DeviceAuthorization, *model.AppError) {
+	authorization, _, appErr := a.GetOAuthDeviceAuthorizationByUserCode(userCode)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	status := model.DeviceAuthorizationStatusDenied
+	if approve {
+		status = model.DeviceAuthorizationStatusApproved
+	}
+
+	if err := a.Srv().Store().OAuth().UpdateDeviceAuthorizationStatus(authorization.DeviceCode, userID, status); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("VerifyOAuthDeviceAuthorization", "app.oauth.device.invalid_user_code.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("VerifyOAuthDeviceAuthorization", "app.oauth.device.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+	authorization.UserId = userID
+	authorization.Status = status
+
+	if approve {
+		// This saves the OAuth2 app as authorized, so that it can be deauthorized by the user
+		authorizedApp := model.Preference{
+			UserId:   userID,
+			Category: model.PreferenceCategoryAuthorizedOAuthApp,
+			Name:     authorization.ClientId,
+			Value:    authorization.Scope,
+		}
+		if err := a.Srv().Store().Preference().Save(model.Preferences{authorizedApp}); err != nil {
+			rctx.Logger().Warn("error saving store preference", mlog.Err(err))
+		}
+	}
+
+	return authorization, nil
+}
+
+// GetOAuthAccessTokenForDeviceCode exchanges a device code for an access token once the user has
+// approved the authorization. While the authorization is pending, the returned error tells the
+// device to keep polling or to slow down.
+func (a *App) GetOAuthAccessTokenForDeviceCode(rctx request.CTX, clientID, clientSecret, deviceCode string) (*model.AccessResponse, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("GetOAuthAccessTokenForDeviceCode", "api.oauth.get_access_token.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	oauthApp, appErr := a.getOAuthDeviceClient(rctx, clientID, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := oauthApp.ValidateForGrantType(model.GrantTypeDeviceCode, clientSecret, ""); appErr != nil {
+		return nil, model.NewAppError("GetOAuthAccessTokenForDeviceCode", "app.oauth.device.invalid_client.app_error", nil, "", http.StatusUnauthorized).Wrap(appErr)
+	}
+
+	authorization, err := a.Srv().Store().OAuth().GetDeviceAuthorization(deviceCode)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetOAuthAccessTokenForDeviceCode", "app.oauth.device.invalid_grant.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetOAuthAccessTokenForDeviceCode", "app.oauth.device.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if authorization.ClientId != oauthApp.Id {
+		return nil, model.NewAppError("GetOAuthAccessTokenForDeviceCode", "app.oauth.device.invalid_grant.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if authorization.IsExpired() {
+		a.removeDeviceAuthorization(rctx, authorization.DeviceCode)
+		return nil, model.NewAppError("GetOAuthAccessTokenForDeviceCode", "app.oauth.device.expired_token.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	switch authorization.Status {
+	case model.DeviceAuthorizationStatusPending:
+		return nil, a.pollOAuthDeviceAuthorization(authorization)
+	case model.DeviceAuthorizationStatusDenied:
+		a.removeDeviceAuthorization(rctx, authorization.DeviceCode)
+		return nil, model.NewAppError("GetOAuthAccessTokenForDeviceCode", "app.oauth.device.access_denied.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	// Removing the authorization first makes sure that concurrent polls can't exchange the same
+	// device code twice.
+	if err := a.Srv().Store().OAuth().RemoveDeviceAuthorization(authorization.DeviceCode); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetOAuthAccessTokenForDeviceCode", "app.oauth.device.invalid_grant.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetOAuthAccessTokenForDeviceCode", "app.oauth.device.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	user, err := a.Srv().Store().User().Get(context.Background(), authorization.UserId)
+	if err != nil {
+		return nil, model.NewAppError("GetOAuthAccessTokenForDeviceCode", "api.oauth.get_access_token.internal_user.app_error", nil, "", http.StatusNotFound).Wrap(err)
+	}
+
+	if user.DeleteAt != 0 {
+		return nil, model.NewAppError("GetOAuthAccessTokenForDeviceCode", "app.oauth.device.invalid_grant.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	// Devices don't redirect, the callback is only recorded to issue refresh tokens to the
+	// confidential clients that have one.
+	redirectURI := ""
+	if len(oauthApp.CallbackUrls) > 0 {
+		redirectURI = oauthApp.CallbackUrls[0]
+	}
+
+	accessRsp, appErr := a.generateAccessTokenResponse(rctx, oauthApp, user, oauthApp.Id, redirectURI, authorization.Scope, "")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.addOIDCIDToken(rctx, accessRsp, oauthApp, user, authorization.Scope, "")
+}
+
+// pollOAuthDeviceAuthorization records a poll of a pending authorization. A device polling faster
+// than its interval is told to slow down, and its interval is increased as per RFC 8628 section 3.5.
+func (a *App) pollOAuthDeviceAuthorization(authorization *model.DeviceAuthorization) *model.AppError {
+	now := model.GetMillis()
+
+	errorID := "app.oauth.device.authorization_pending.app_error"
+	if authorization.LastPolledAt != 0 && now-authorization.LastPolledAt < int64(authorization.PollInterval)*1000 {
+		errorID = "app.oauth.device.slow_down.app_error"
+		authorization.PollInterval += model.DeviceCodeSlowDownIncrement
+	}
+
+	if err := a.Srv().Store().OAuth().UpdateDeviceAuthorizationPoll(authorization.DeviceCode, now, authorization.PollInterval); err != nil {
+		return model.NewAppError("GetOAuthAccessTokenForDeviceCode", "app.oauth.device.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return model.NewAppError("GetOAuthAccessTokenForDeviceCode", errorID, nil, "", http.StatusBadRequest)
+}
+
+func (a *App) removeDeviceAuthorization(rctx request.CTX, deviceCode string) {
+	if err := a.Srv().Store().OAuth().RemoveDeviceAuthorization(deviceCode); err != nil {
+		rctx.Logger().Warn("Unable to remove device authorization", mlog.Err(err))
+	}
+}
+
+// getOAuthDeviceClient returns the OAuth app with the given client id. The mmctl client id is an
+// alias for the app managed by the server for mmctl, which is created on first use if create is true.
+func (a *App) getOAuthDeviceClient(rctx request.CTX, clientID string, create bool) (*model.OAuthApp, *model.AppError) {
+	if clientID == model.OAuthAppMmctlClientId {
+		return a.getMmctlOAuthApp(rctx, create)
+	}
+
+	if !model.IsValidId(clientID) {
+		return nil, model.NewAppError("getOAuthDeviceClient", "app.oauth.device.invalid_client.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	oauthApp, err := a.Srv().Store().OAuth().GetApp(clientID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("getOAuthDeviceClient", "app.oauth.device.invalid_client.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+		default:
+			return nil, model.NewAppError("getOAuthDeviceClient", "app.oauth.get_app.finding.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return oauthApp, nil
+}
+
+// getMmctlOAuthApp returns the public OAuth app the sessions of mmctl are bound to. The app is owned
+// by the system bot and its id is kept in the Systems table.
+func (a *App) getMmctlOAuthApp(rctx request.CTX, create bool) (*model.OAuthApp, *model.AppError) {
+	system, err := a.Srv().Store().System().GetByName(model.SystemMmctlOAuthAppId)
+	var nfErr *store.ErrNotFound
+	switch {
+	case err == nil:
+		oauthApp, err := a.Srv().Store().OAuth().GetApp(system.Value)
+		if err == nil {
+			return oauthApp, nil
+		}
+		if !errors.As(err, &nfErr) {
+			return nil, model.NewAppError("getMmctlOAuthApp", "app.oauth.get_app.finding.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if !create {
+			return nil, model.NewAppError("getMmctlOAuthApp", "app.oauth.device.invalid_client.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+		}
+
+		// The app was deleted by an admin, so a new one is created.
+		if _, err := a.Srv().Store().System().PermanentDeleteByName(model.SystemMmctlOAuthAppId); err != nil {
+			return nil, model.NewAppError("getMmctlOAuthApp", "app.oauth.device.mmctl_app.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	case !errors.As(err, &nfErr):
+		return nil, model.NewAppError("getMmctlOAuthApp", "app.oauth.device.mmctl_app.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	case !create:
+		return nil, model.NewAppError("getMmctlOAuthApp", "app.oauth.device.invalid_client.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+	}
+
+	bot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	callbackURL, err := url.JoinPath(*a.Config().ServiceSettings.SiteURL, model.OAuthDeviceVerificationEndpoint)
+	if err != nil {
+		return nil, model.NewAppError("getMmctlOAuthApp", "api.oauth.authorization_server_metadata.invalid_url.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	oauthApp, appErr := a.CreateOAuthAppInternal(&model.OAuthApp{
+		CreatorId:    bot.UserId,
+		Name:         mmctlOAuthAppName,
+		Description:  mmctlOAuthAppDescription,
+		Homepage:     mmctlOAuthAppHomepage,
+		CallbackUrls: []string{callbackURL},
+	}, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Another node of the cluster may have created the app concurrently, in which case the app stored
+	// first is kept.
+	system, err = a.Srv().Store().System().InsertIfExists(&model.System{Name: model.SystemMmctlOAuthAppId, Value: oauthApp.Id})
+	if err != nil {
+		return nil, model.NewAppError("getMmctlOAuthApp", "app.oauth.device.mmctl_app.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if system.Value != oauthApp.Id {
+		if err := a.Srv().Store().OAuth().DeleteApp(oauthApp.Id); err != nil {
+			rctx.Logger().Warn("Failed to delete duplicate mmctl OAuth app", mlog.Err(err))
+		}
+		return a.getMmctlOAuthApp(rctx, false)
+	}
+
+	return oauthApp, nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestOAuthDeviceAuthorization(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOAuthServiceProvider = true
+		*cfg.ServiceSettings.SiteURL = "https://mattermost.example.com"
+	})
+
+	t.Run("mmctl device login", func(t *testing.T) {
+		deviceRsp, appErr := th.App.CreateOAuthDeviceAuthorization(th.Context, model.OAuthAppMmctlClientId, "", "")
+		require.Nil(t, appErr)
+		assert.Equal(t, "https://mattermost.example.com/oauth/device", deviceRsp.VerificationURI)
+		assert.Contains(t, deviceRsp.VerificationURIComplete, "user_code=")
+		assert.Equal(t, int64(model.DeviceCodeExpireTime), deviceRsp.ExpiresIn)
+		assert.Equal(t, int32(model.DeviceCodePollInterval), deviceRsp.Interval)
+
+		_, appErr = th.App.GetOAuthAccessTokenForDeviceCode(th.Context, model.OAuthAppMmctlClientId, "", deviceRsp.DeviceCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.device.authorization_pending.app_error", appErr.Id)
+
+		_, appErr = th.App.GetOAuthAccessTokenForDeviceCode(th.Context, model.OAuthAppMmctlClientId, "", deviceRsp.DeviceCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.device.slow_down.app_error", appErr.Id)
+
+		authorization, oauthApp, appErr := th.App.GetOAuthDeviceAuthorizationByUserCode(deviceRsp.UserCode)
+		require.Nil(t, appErr)
+		assert.Equal(t, int32(model.DeviceCodePollInterval+model.DeviceCodeSlowDownIncrement), authorization.PollInterval)
+		assert.Equal(t, "mmctl", oauthApp.Name)
+		assert.True(t, oauthApp.IsPublicClient())
+
+		_, appErr = th.App.VerifyOAuthDeviceAuthorization(th.Context, th.BasicUser.Id, strings.ToLower(deviceRsp.UserCode), true)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.VerifyOAuthDeviceAuthorization(th.Context, th.BasicUser.Id, deviceRsp.UserCode, false)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.device.invalid_user_code.app_error", appErr.Id)
+
+		accessRsp, appErr := th.App.GetOAuthAccessTokenForDeviceCode(th.Context, model.OAuthAppMmctlClientId, "", deviceRsp.DeviceCode)
+		require.Nil(t, appErr)
+		assert.Empty(t, accessRsp.RefreshToken)
+
+		session, appErr := th.App.GetSession(accessRsp.AccessToken)
+		require.Nil(t, appErr)
+		assert.Equal(t, th.BasicUser.Id, session.UserId)
+		assert.True(t, session.IsOAuth)
+		assert.Equal(t, oauthApp.Id, session.Props[model.SessionPropOAuthAppID])
+
+		apps, appErr := th.App.GetAuthorizedAppsForUser(th.BasicUser.Id, 0, 10)
+		require.Nil(t, appErr)
+		require.Len(t, apps, 1)
+		assert.Equal(t, oauthApp.Id, apps[0].Id)
+
+		_, appErr = th.App.GetOAuthAccessTokenForDeviceCode(th.Context, model.OAuthAppMmctlClientId, "", deviceRsp.DeviceCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.device.invalid_grant.app_error", appErr.Id)
+
+		// The mmctl app is only created once
+		deviceRsp, appErr = th.App.CreateOAuthDeviceAuthorization(th.Context, model.OAuthAppMmctlClientId, "", "")
+		require.Nil(t, appErr)
+		authorization, _, appErr = th.App.GetOAuthDeviceAuthorizationByUserCode(deviceRsp.UserCode)
+		require.Nil(t, appErr)
+		assert.Equal(t, oauthApp.Id, authorization.ClientId)
+	})
+
+	oauthApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "device" + model.NewRandomString(10),
+		CreatorId:    th.BasicUser2.Id,
+		Homepage:     "https://nowhere.com",
+		Description:  "test",
+		CallbackUrls: []string{"https://example.com/callback"},
+	})
+	require.Nil(t, appErr)
+
+	t.Run("denied", func(t *testing.T) {
+		deviceRsp, appErr := th.App.CreateOAuthDeviceAuthorization(th.Context, oauthApp.Id, oauthApp.ClientSecret, "")
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.VerifyOAuthDeviceAuthorization(th.Context, th.BasicUser.Id, deviceRsp.UserCode, false)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetOAuthAccessTokenForDeviceCode(th.Context, oauthApp.Id, oauthApp.ClientSecret, deviceRsp.DeviceCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.device.access_denied.app_error", appErr.Id)
+	})
+
+	t.Run("confidential clients must authenticate", func(t *testing.T) {
+		_, appErr := th.App.CreateOAuthDeviceAuthorization(th.Context, oauthApp.Id, "wrong", "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.device.invalid_client.app_error", appErr.Id)
+
+		deviceRsp, appErr := th.App.CreateOAuthDeviceAuthorization(th.Context, oauthApp.Id, oauthApp.ClientSecret, "")
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetOAuthAccessTokenForDeviceCode(th.Context, oauthApp.Id, "wrong", deviceRsp.DeviceCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.device.invalid_client.app_error", appErr.Id)
+
+		_, appErr = th.App.GetOAuthAccessTokenForDeviceCode(th.Context, model.OAuthAppMmctlClientId, "", deviceRsp.DeviceCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.device.invalid_grant.app_error", appErr.Id)
+	})
+
+	t.Run("app without callback URLs", func(t *testing.T) {
+		deviceApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+			Name:         "device" + model.NewRandomString(10),
+			CreatorId:    th.BasicUser2.Id,
+			Homepage:     "https://nowhere.com",
+			Description:  "test",
+			CallbackUrls: []string{"https://example.com/callback"},
+		})
+		require.Nil(t, appErr)
+		_, err := th.SQLStore.GetMaster().Exec("UPDATE OAuthApps SET CallbackUrls = '[]' WHERE Id = ?", deviceApp.Id)
+		require.NoError(t, err)
+
+		deviceRsp, appErr := th.App.CreateOAuthDeviceAuthorization(th.Context, deviceApp.Id, deviceApp.ClientSecret, "")
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.VerifyOAuthDeviceAuthorization(th.Context, th.BasicUser.Id, deviceRsp.UserCode, true)
+		require.Nil(t, appErr)
+
+		accessRsp, appErr := th.App.GetOAuthAccessTokenForDeviceCode(th.Context, deviceApp.Id, deviceApp.ClientSecret, deviceRsp.DeviceCode)
+		require.Nil(t, appErr)
+		assert.NotEmpty(t, accessRsp.AccessToken)
+		assert.Empty(t, accessRsp.RefreshToken)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		authorization := model.NewDeviceAuthorization(oauthApp.Id, "")
+		authorization.CreateAt = model.GetMillis() - 2*model.DeviceCodeExpireTime*1000
+		authorization, err := th.App.Srv().Store().OAuth().SaveDeviceAuthorization(authorization)
+		require.NoError(t, err)
+
+		_, _, appErr := th.App.GetOAuthDeviceAuthorizationByUserCode(authorization.UserCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.device.invalid_user_code.app_error", appErr.Id)
+
+		_, appErr = th.App.GetOAuthAccessTokenForDeviceCode(th.Context, oauthApp.Id, oauthApp.ClientSecret, authorization.DeviceCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.device.expired_token.app_error", appErr.Id)
+	})
+}
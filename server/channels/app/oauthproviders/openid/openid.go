@@ -0,0 +1,471 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package oauthopenid
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+const (
+	requestTimeout = 30 * time.Second
+
+	// discoveryCacheTTL is how long provider metadata is reused before being fetched again.
+	discoveryCacheTTL = time.Hour
+
+	// keySetRefreshInterval limits how often the signing keys are fetched again when an ID token
+	// is signed with an unknown key, which happens after the provider rotated its keys.
+	keySetRefreshInterval = time.Minute
+
+	maxResponseSize = 1024 * 1024
+
+	openIDScope = "openid"
+)
+
+var signingMethods = []string{"RS256", "RS384", "RS512"}
+
+type OpenIdProvider struct {
+	httpClient *http.Client
+	config     atomic.Pointer[model.Config]
+
+	mut       sync.Mutex
+	discovery map[string]*cachedMetadata
+	keySets   map[string]*cachedKeySet
+}
+
+type cachedMetadata struct {
+	metadata  *model.OpenIDProviderMetadata
+	fetchedAt time.Time
+}
+
+type cachedKeySet struct {
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+func init() {
+	// The enterprise provider takes precedence when both are linked in.
+	if einterfaces.GetOAuthProvider(model.ServiceOpenid) != nil {
+		return
+	}
+	einterfaces.RegisterOAuthProvider(model.ServiceOpenid, NewOpenIdProvider(&http.Client{Timeout: requestTimeout}))
+}
+
+// NewOpenIdProvider creates an OAuth provider for generic OpenID Connect identity providers,
+// fetching discovery documents and signing keys with the given client.
+func NewOpenIdProvider(httpClient *http.Client) *OpenIdProvider {
+	return &OpenIdProvider{
+		httpClient: httpClient,
+		discovery:  make(map[string]*cachedMetadata),
+		keySets:    make(map[string]*cachedKeySet),
+	}
+}
+
+func (op *OpenIdProvider) GetSSOSettings(rctx request.CTX, config *model.Config, service string) (*model.SSOSettings, error) {
+	op.config.Store(config)
+
+	sso := config.GetSSOService(service)
+	if sso == nil {
+		return nil, fmt.Errorf("unsupported service %q", service)
+	}
+
+	settings := *sso
+	if model.SafeDereference(sso.DiscoveryEndpoint) != "" {
+		metadata, err := op.getMetadata(rctx.Context(), *sso.DiscoveryEndpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		settings.AuthEndpoint = model.NewPointer(metadata.AuthorizationEndpoint)
+		settings.TokenEndpoint = model.NewPointer(metadata.TokenEndpoint)
+		settings.UserAPIEndpoint = model.NewPointer(metadata.UserInfoEndpoint)
+	}
+
+	if model.SafeDereference(settings.AuthEndpoint) == "" || model.SafeDereference(settings.TokenEndpoint) == "" || model.SafeDereference(settings.UserAPIEndpoint) == "" {
+		return nil, fmt.Errorf("missing endpoints for service %q", service)
+	}
+
+	return &settings, nil
+}
+
+// GetUserFromIdToken validates the signature, issuer, audience and expiry of the ID token against
+// the discovery document of the service that issued it.
+func (op *OpenIdProvider) GetUserFromIdToken(rctx request.CTX, idToken string) (*model.User, error) {
+	config := op.config.Load()
+	if config == nil {
+		return nil, errors.New("provider is not configured")
+	}
+
+	var unverified jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(idToken, &unverified); err != nil {
+		return nil, errors.Wrap(err, "failed to parse ID token")
+	}
+
+	sso, metadata, err := op.getIssuer(rctx.Context(), config, unverified.Issuer, unverified.Audience)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return op.getSigningKey(rctx.Context(), metadata.JWKSURI, kid)
+	},
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(metadata.Issuer),
+		jwt.WithAudience(*sso.Id),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid ID token")
+	}
+
+	return userFromClaims(rctx.Logger(), &config.OpenIdSettings, claims)
+}
+
+// GetUserFromJSON maps the claims returned by the userinfo endpoint to a user. The subject must
+// match the one of the ID token, if any, as required by OpenID Connect Core section 5.3.2.
+func (op *OpenIdProvider) GetUserFromJSON(rctx request.CTX, data io.Reader, tokenUser *model.User) (*model.User, error) {
+	claims, err := claimsFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := userFromClaims(rctx.Logger(), op.getSettings(), claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenUser != nil && tokenUser.AuthData != nil && *tokenUser.AuthData != *user.AuthData {
+		return nil, errors.New("userinfo subject doesn't match the ID token")
+	}
+
+	return user, nil
+}
+
+// GetUserClaimsFromJSON maps the groups and roles claims returned by the userinfo endpoint. Either
+// is left empty when the corresponding claim isn't configured.
+func (op *OpenIdProvider) GetUserClaimsFromJSON(rctx request.CTX, data io.Reader, tokenUser *model.User) (*einterfaces.OAuthUserClaims, error) {
+	claims, err := claimsFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := op.getSettings()
+	userClaims := &einterfaces.OAuthUserClaims{}
+
+	if *settings.GroupsClaim != "" {
+		userClaims.Groups = claimStrings(claims, *settings.GroupsClaim)
+		if userClaims.Groups == nil {
+			userClaims.Groups = []string{}
+		}
+	}
+
+	if *settings.RolesClaim != "" {
+		roles := claimStrings(claims, *settings.RolesClaim)
+		switch {
+		case containsAny(roles, *settings.SystemAdminRoles):
+			userClaims.Role = model.SystemAdminRoleId
+		case containsAny(roles, *settings.GuestRoles):
+			userClaims.Role = model.SystemGuestRoleId
+		default:
+			userClaims.Role = model.SystemUserRoleId
+		}
+	}
+
+	return userClaims, nil
+}
+
+// GetLogoutURL returns the end session endpoint of the provider, see OpenID Connect RP-Initiated
+// Logout 1.0, or an empty string when RP-initiated logout is disabled.
+func (op *OpenIdProvider) GetLogoutURL(rctx request.CTX, config *model.Config, service, postLogoutRedirectURI string) (string, error) {
+	if service != model.ServiceOpenid || !*config.OpenIdSettings.EnableRPInitiatedLogout {
+		return "", nil
+	}
+
+	if *config.OpenIdSettings.DiscoveryEndpoint == "" {
+		return "", errors.New("RP-initiated logout requires a discovery endpoint")
+	}
+
+	metadata, err := op.getMetadata(rctx.Context(), *config.OpenIdSettings.DiscoveryEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	if metadata.EndSessionEndpoint == "" {
+		return "", errors.New("provider doesn't advertise an end session endpoint")
+	}
+
+	logoutURL, err := url.Parse(metadata.EndSessionEndpoint)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid end session endpoint")
+	}
+
+	query := logoutURL.Query()
+	query.Set("client_id", *config.OpenIdSettings.Id)
+	if postLogoutRedirectURI != "" {
+		query.Set("post_logout_redirect_uri", postLogoutRedirectURI)
+	}
+	logoutURL.RawQuery = query.Encode()
+
+	return logoutURL.String(), nil
+}
+
+func (op *OpenIdProvider) IsSameUser(_ request.CTX, dbUser, oauthUser *model.User) bool {
+	return dbUser.AuthData != nil && oauthUser.AuthData != nil && *dbUser.AuthData == *oauthUser.AuthData
+}
+
+func (op *OpenIdProvider) getSettings() *model.OpenIdSettings {
+	if config := op.config.Load(); config != nil {
+		return &config.OpenIdSettings
+	}
+
+	config := &model.Config{}
+	config.SetDefaults()
+	return &config.OpenIdSettings
+}
+
+// getIssuer finds the enabled OpenID Connect service whose provider issued a token for the given
+// issuer and audience.
+func (op *OpenIdProvider) getIssuer(ctx context.Context, config *model.Config, issuer string, audience []string) (*model.SSOSettings, *model.OpenIDProviderMetadata, error) {
+	for _, service := range []string{model.ServiceOpenid, model.ServiceGitlab, model.ServiceGoogle, model.ServiceOffice365} {
+		sso := config.GetSSOService(service)
+		if sso == nil || !*sso.Enable || !strings.Contains(*sso.Scope, openIDScope) || *sso.DiscoveryEndpoint == "" {
+			continue
+		}
+
+		if !slices.Contains(audience, *sso.Id) {
+			continue
+		}
+
+		metadata, err := op.getMetadata(ctx, *sso.DiscoveryEndpoint)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if metadata.Issuer == issuer {
+			return sso, metadata, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no OpenID Connect service configured for issuer %q", issuer)
+}
+
+func (op *OpenIdProvider) getMetadata(ctx context.Context, discoveryEndpoint string) (*model.OpenIDProviderMetadata, error) {
+	op.mut.Lock()
+	cached, ok := op.discovery[discoveryEndpoint]
+	op.mut.Unlock()
+
+	if ok && time.Since(cached.fetchedAt) < discoveryCacheTTL {
+		return cached.metadata, nil
+	}
+
+	var metadata model.OpenIDProviderMetadata
+	if err := op.getJSON(ctx, discoveryEndpoint, &metadata); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch discovery document")
+	}
+
+	if metadata.Issuer == "" || metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, errors.New("incomplete discovery document")
+	}
+
+	op.mut.Lock()
+	op.discovery[discoveryEndpoint] = &cachedMetadata{metadata: &metadata, fetchedAt: time.Now()}
+	op.mut.Unlock()
+
+	return &metadata, nil
+}
+
+func (op *OpenIdProvider) getSigningKey(ctx context.Context, jwksURI, kid string) (*rsa.PublicKey, error) {
+	op.mut.Lock()
+	cached, ok := op.keySets[jwksURI]
+	op.mut.Unlock()
+
+	if ok {
+		if key := findKey(cached.keys, kid); key != nil {
+			return key, nil
+		}
+		if time.Since(cached.fetchedAt) < keySetRefreshInterval {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+	}
+
+	var keySet model.JSONWebKeySet
+	if err := op.getJSON(ctx, jwksURI, &keySet); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch signing keys")
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(keySet.Keys))
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.RSAPublicKey()
+		if err != nil {
+			// Keys of other types are published alongside the RSA ones by some providers.
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	op.mut.Lock()
+	op.keySets[jwksURI] = &cachedKeySet{keys: keys, fetchedAt: time.Now()}
+	op.mut.Unlock()
+
+	if key := findKey(keys, kid); key != nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (op *OpenIdProvider) getJSON(ctx context.Context, endpoint string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := op.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, endpoint)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v)
+}
+
+// findKey returns the key with the given id. Tokens without a key id may only be verified when
+// the provider publishes a single key.
+func findKey(keys map[string]*rsa.PublicKey, kid string) *rsa.PublicKey {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key
+		}
+	}
+
+	return keys[kid]
+}
+
+func claimsFromJSON(data io.Reader) (map[string]any, error) {
+	var claims map[string]any
+	if err := json.NewDecoder(data).Decode(&claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func userFromClaims(logger mlog.LoggerIFace, settings *model.OpenIdSettings, claims map[string]any) (*model.User, error) {
+	subject := claimString(claims, *settings.SubjectClaim)
+	if subject == "" {
+		return nil, errors.New("user subject can't be empty")
+	}
+
+	user := &model.User{}
+	user.AuthData = &subject
+	user.Email = strings.ToLower(claimString(claims, *settings.EmailClaim))
+	if user.Email == "" {
+		return nil, errors.New("user e-mail should not be empty")
+	}
+
+	username := claimString(claims, *settings.UsernameClaim)
+	if username == "" {
+		username, _, _ = strings.Cut(user.Email, "@")
+	}
+	user.Username = model.CleanUsername(logger, username)
+
+	user.FirstName = claimString(claims, *settings.FirstNameClaim)
+	user.LastName = claimString(claims, *settings.LastNameClaim)
+	user.Nickname = claimString(claims, *settings.NicknameClaim)
+
+	return user, nil
+}
+
+// claimValue looks up a claim by name, falling back to a dot-separated path into nested objects
+// when no claim has that exact name, so that both "https://example.com/groups" and
+// "realm_access.roles" can be used.
+func claimValue(claims map[string]any, name string) any {
+	if name == "" {
+		return nil
+	}
+
+	if value, ok := claims[name]; ok {
+		return value
+	}
+
+	var value any = claims
+	for part := range strings.SplitSeq(name, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[part]
+	}
+
+	return value
+}
+
+func claimString(claims map[string]any, name string) string {
+	switch value := claimValue(claims, name).(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// claimStrings returns a claim holding either a list of strings or a single string.
+func claimStrings(claims map[string]any, name string) []string {
+	switch value := claimValue(claims, name).(type) {
+	case string:
+		return []string{value}
+	case []any:
+		var values []string
+		for _, v := range value {
+			if s, ok := v.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// containsAny reports whether any of the values is part of the comma-separated list.
+func containsAny(values []string, list string) bool {
+	for item := range strings.SplitSeq(list, ",") {
+		if item = strings.TrimSpace(item); item != "" && slices.Contains(values, item) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,365 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package oauthopenid
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const testClientID = "mattermost"
+
+// mockIdP is a minimal OpenID Connect provider serving discovery and signing keys.
+type mockIdP struct {
+	server *httptest.Server
+
+	mut  sync.Mutex
+	keys map[string]*rsa.PrivateKey
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	t.Helper()
+
+	idp := &mockIdP{keys: make(map[string]*rsa.PrivateKey)}
+	idp.addKey(t, "key1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, model.OpenIDProviderMetadata{
+			AuthorizationServerMetadata: model.AuthorizationServerMetadata{
+				Issuer:                idp.server.URL,
+				AuthorizationEndpoint: idp.server.URL + "/auth",
+				TokenEndpoint:         idp.server.URL + "/token",
+			},
+			UserInfoEndpoint:   idp.server.URL + "/userinfo",
+			JWKSURI:            idp.server.URL + "/jwks",
+			EndSessionEndpoint: idp.server.URL + "/logout?ui_locales=en",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.mut.Lock()
+		defer idp.mut.Unlock()
+
+		keySet := model.JSONWebKeySet{}
+		for kid, key := range idp.keys {
+			keySet.Keys = append(keySet.Keys, model.NewRSAJSONWebKey(kid, &key.PublicKey))
+		}
+		writeJSON(w, keySet)
+	})
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+func (idp *mockIdP) addKey(t *testing.T, kid string) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp.mut.Lock()
+	idp.keys[kid] = key
+	idp.mut.Unlock()
+
+	return key
+}
+
+func (idp *mockIdP) signToken(t *testing.T, kid string, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func (idp *mockIdP) claims(overrides jwt.MapClaims) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"iss":                idp.server.URL,
+		"aud":                testClientID,
+		"sub":                "8f1c5a0e-3b1d-4c4e-9a53-1f2c3d4e5f60",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"iat":                time.Now().Unix(),
+		"email":              "Jane.Doe@example.com",
+		"preferred_username": "jane.doe",
+		"given_name":         "Jane",
+		"family_name":        "Doe",
+	}
+	for name, value := range overrides {
+		claims[name] = value
+	}
+
+	return claims
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestConfig(idp *mockIdP) *model.Config {
+	config := &model.Config{}
+	config.SetDefaults()
+	config.OpenIdSettings.Enable = model.NewPointer(true)
+	config.OpenIdSettings.Id = model.NewPointer(testClientID)
+	config.OpenIdSettings.Secret = model.NewPointer("secret")
+	config.OpenIdSettings.DiscoveryEndpoint = model.NewPointer(idp.server.URL + "/.well-known/openid-configuration")
+
+	return config
+}
+
+func setupProvider(t *testing.T, config *model.Config) (*OpenIdProvider, request.CTX) {
+	t.Helper()
+
+	provider := NewOpenIdProvider(&http.Client{Timeout: 5 * time.Second})
+	rctx := request.TestContext(t)
+
+	_, err := provider.GetSSOSettings(rctx, config, model.ServiceOpenid)
+	require.NoError(t, err)
+
+	return provider, rctx
+}
+
+func TestGetSSOSettings(t *testing.T) {
+	idp := newMockIdP(t)
+	config := newTestConfig(idp)
+	provider := NewOpenIdProvider(&http.Client{Timeout: 5 * time.Second})
+
+	sso, err := provider.GetSSOSettings(request.TestContext(t), config, model.ServiceOpenid)
+	require.NoError(t, err)
+	assert.Equal(t, idp.server.URL+"/auth", *sso.AuthEndpoint)
+	assert.Equal(t, idp.server.URL+"/token", *sso.TokenEndpoint)
+	assert.Equal(t, idp.server.URL+"/userinfo", *sso.UserAPIEndpoint)
+	assert.Equal(t, testClientID, *sso.Id)
+
+	// The endpoints resolved through discovery must not leak into the configuration.
+	assert.Empty(t, *config.OpenIdSettings.AuthEndpoint)
+
+	t.Run("unreachable discovery endpoint", func(t *testing.T) {
+		config := newTestConfig(idp)
+		config.OpenIdSettings.DiscoveryEndpoint = model.NewPointer(idp.server.URL + "/missing")
+
+		_, err := provider.GetSSOSettings(request.TestContext(t), config, model.ServiceOpenid)
+		require.Error(t, err)
+	})
+
+	t.Run("static endpoints", func(t *testing.T) {
+		config := newTestConfig(idp)
+		config.OpenIdSettings.DiscoveryEndpoint = model.NewPointer("")
+		config.OpenIdSettings.AuthEndpoint = model.NewPointer("https://idp.example.com/auth")
+		config.OpenIdSettings.TokenEndpoint = model.NewPointer("https://idp.example.com/token")
+		config.OpenIdSettings.UserAPIEndpoint = model.NewPointer("https://idp.example.com/userinfo")
+
+		sso, err := provider.GetSSOSettings(request.TestContext(t), config, model.ServiceOpenid)
+		require.NoError(t, err)
+		assert.Equal(t, "https://idp.example.com/auth", *sso.AuthEndpoint)
+
+		config.OpenIdSettings.TokenEndpoint = model.NewPointer("")
+		_, err = provider.GetSSOSettings(request.TestContext(t), config, model.ServiceOpenid)
+		require.Error(t, err)
+	})
+}
+
+func TestGetUserFromIdToken(t *testing.T) {
+	idp := newMockIdP(t)
+	provider, rctx := setupProvider(t, newTestConfig(idp))
+	key := idp.keys["key1"]
+
+	t.Run("valid token", func(t *testing.T) {
+		user, err := provider.GetUserFromIdToken(rctx, idp.signToken(t, "key1", key, idp.claims(nil)))
+		require.NoError(t, err)
+		assert.Equal(t, "8f1c5a0e-3b1d-4c4e-9a53-1f2c3d4e5f60", *user.AuthData)
+		assert.Equal(t, "jane.doe@example.com", user.Email)
+		assert.Equal(t, "jane.doe", user.Username)
+		assert.Equal(t, "Jane", user.FirstName)
+		assert.Equal(t, "Doe", user.LastName)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		_, err := provider.GetUserFromIdToken(rctx, idp.signToken(t, "key1", key, idp.claims(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})))
+		require.Error(t, err)
+	})
+
+	t.Run("other audience", func(t *testing.T) {
+		_, err := provider.GetUserFromIdToken(rctx, idp.signToken(t, "key1", key, idp.claims(jwt.MapClaims{"aud": "other-client"})))
+		require.Error(t, err)
+	})
+
+	t.Run("other issuer", func(t *testing.T) {
+		_, err := provider.GetUserFromIdToken(rctx, idp.signToken(t, "key1", key, idp.claims(jwt.MapClaims{"iss": "https://evil.example.com"})))
+		require.Error(t, err)
+	})
+
+	t.Run("forged signature", func(t *testing.T) {
+		forger, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		_, err = provider.GetUserFromIdToken(rctx, idp.signToken(t, "key1", forger, idp.claims(nil)))
+		require.Error(t, err)
+	})
+
+	t.Run("unsigned token", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodNone, idp.claims(nil)).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = provider.GetUserFromIdToken(rctx, token)
+		require.Error(t, err)
+	})
+
+	t.Run("rotated key", func(t *testing.T) {
+		// Let the cached key set be refetched right away.
+		provider.mut.Lock()
+		for _, keySet := range provider.keySets {
+			keySet.fetchedAt = time.Time{}
+		}
+		provider.mut.Unlock()
+
+		rotated := idp.addKey(t, "key2")
+
+		user, err := provider.GetUserFromIdToken(rctx, idp.signToken(t, "key2", rotated, idp.claims(nil)))
+		require.NoError(t, err)
+		assert.Equal(t, "jane.doe@example.com", user.Email)
+	})
+
+	t.Run("unknown key is not refetched within the refresh interval", func(t *testing.T) {
+		unknown := idp.addKey(t, "key3")
+
+		_, err := provider.GetUserFromIdToken(rctx, idp.signToken(t, "key3", unknown, idp.claims(nil)))
+		require.Error(t, err)
+	})
+}
+
+func TestGetUserFromJSON(t *testing.T) {
+	idp := newMockIdP(t)
+	config := newTestConfig(idp)
+	config.OpenIdSettings.UsernameClaim = model.NewPointer("attributes.login")
+	config.OpenIdSettings.NicknameClaim = model.NewPointer("https://example.com/nickname")
+	provider, rctx := setupProvider(t, config)
+
+	userinfo := `{
+		"sub": "8f1c5a0e-3b1d-4c4e-9a53-1f2c3d4e5f60",
+		"email": "JDOE@example.com",
+		"given_name": "Jane",
+		"family_name": "Doe",
+		"attributes": {"login": "JDoe"},
+		"https://example.com/nickname": "jd"
+	}`
+
+	user, err := provider.GetUserFromJSON(rctx, strings.NewReader(userinfo), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "8f1c5a0e-3b1d-4c4e-9a53-1f2c3d4e5f60", *user.AuthData)
+	assert.Equal(t, "jdoe@example.com", user.Email)
+	assert.Equal(t, "jdoe", user.Username)
+	assert.Equal(t, "jd", user.Nickname)
+
+	t.Run("subject matching the ID token", func(t *testing.T) {
+		_, err := provider.GetUserFromJSON(rctx, strings.NewReader(userinfo), &model.User{AuthData: model.NewPointer("8f1c5a0e-3b1d-4c4e-9a53-1f2c3d4e5f60")})
+		require.NoError(t, err)
+	})
+
+	t.Run("subject not matching the ID token", func(t *testing.T) {
+		_, err := provider.GetUserFromJSON(rctx, strings.NewReader(userinfo), &model.User{AuthData: model.NewPointer("other")})
+		require.Error(t, err)
+	})
+
+	t.Run("missing email", func(t *testing.T) {
+		_, err := provider.GetUserFromJSON(rctx, strings.NewReader(`{"sub": "1"}`), nil)
+		require.Error(t, err)
+	})
+
+	t.Run("missing subject", func(t *testing.T) {
+		_, err := provider.GetUserFromJSON(rctx, strings.NewReader(`{"email": "jdoe@example.com"}`), nil)
+		require.Error(t, err)
+	})
+
+	t.Run("username falls back to the email", func(t *testing.T) {
+		user, err := provider.GetUserFromJSON(rctx, strings.NewReader(`{"sub": 42, "email": "jane@example.com"}`), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "42", *user.AuthData)
+		assert.Equal(t, "jane", user.Username)
+	})
+}
+
+func TestGetUserClaimsFromJSON(t *testing.T) {
+	idp := newMockIdP(t)
+	userinfo := `{
+		"sub": "1",
+		"email": "jdoe@example.com",
+		"groups": ["/engineering", "/engineering/backend"],
+		"realm_access": {"roles": ["offline_access", "mm-admin"]}
+	}`
+
+	t.Run("not configured", func(t *testing.T) {
+		provider, rctx := setupProvider(t, newTestConfig(idp))
+
+		claims, err := provider.GetUserClaimsFromJSON(rctx, strings.NewReader(userinfo), nil)
+		require.NoError(t, err)
+		assert.Nil(t, claims.Groups)
+		assert.Empty(t, claims.Role)
+	})
+
+	t.Run("groups and roles", func(t *testing.T) {
+		config := newTestConfig(idp)
+		config.OpenIdSettings.GroupsClaim = model.NewPointer("groups")
+		config.OpenIdSettings.RolesClaim = model.NewPointer("realm_access.roles")
+		config.OpenIdSettings.SystemAdminRoles = model.NewPointer("mm-admin, admin")
+		config.OpenIdSettings.GuestRoles = model.NewPointer("mm-guest")
+		provider, rctx := setupProvider(t, config)
+
+		claims, err := provider.GetUserClaimsFromJSON(rctx, strings.NewReader(userinfo), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/engineering", "/engineering/backend"}, claims.Groups)
+		assert.Equal(t, model.SystemAdminRoleId, claims.Role)
+
+		claims, err = provider.GetUserClaimsFromJSON(rctx, strings.NewReader(`{"sub": "1", "realm_access": {"roles": ["mm-guest"]}}`), nil)
+		require.NoError(t, err)
+		assert.NotNil(t, claims.Groups)
+		assert.Empty(t, claims.Groups)
+		assert.Equal(t, model.SystemGuestRoleId, claims.Role)
+
+		claims, err = provider.GetUserClaimsFromJSON(rctx, strings.NewReader(`{"sub": "1"}`), nil)
+		require.NoError(t, err)
+		assert.Equal(t, model.SystemUserRoleId, claims.Role)
+	})
+}
+
+func TestGetLogoutURL(t *testing.T) {
+	idp := newMockIdP(t)
+	config := newTestConfig(idp)
+	provider, rctx := setupProvider(t, config)
+
+	logoutURL, err := provider.GetLogoutURL(rctx, config, model.ServiceOpenid, "https://mattermost.example.com/login")
+	require.NoError(t, err)
+	assert.Empty(t, logoutURL)
+
+	config.OpenIdSettings.EnableRPInitiatedLogout = model.NewPointer(true)
+	logoutURL, err = provider.GetLogoutURL(rctx, config, model.ServiceOpenid, "https://mattermost.example.com/login")
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(logoutURL)
+	require.NoError(t, err)
+	assert.Equal(t, idp.server.URL+"/logout", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.Equal(t, "en", parsed.Query().Get("ui_locales"))
+	assert.Equal(t, testClientID, parsed.Query().Get("client_id"))
+	assert.Equal(t, "https://mattermost.example.com/login", parsed.Query().Get("post_logout_redirect_uri"))
+}
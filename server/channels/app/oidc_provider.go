@@ -0,0 +1,242 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type oidcIDTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce string `json:"nonce,omitempty"`
+	model.OIDCProfileClaims
+}
+
+type oidcSigningKey struct {
+	id         string
+	privateKey *rsa.PrivateKey
+}
+
+// oidcSigningKeyPeriod returns the index of the signing key rotation period the given time falls in.
+func oidcSigningKeyPeriod(t time.Time) int64 {
+	return t.UnixMilli() / model.OIDCSigningKeyRotationPeriod.Milliseconds()
+}
+
+func (a *App) GetOpenIDProviderMetadata(rctx request.CTX) (*model.OpenIDProviderMetadata, *model.AppError) {
+	if _, appErr := a.GetAuthorizationServerMetadata(rctx); appErr != nil {
+		return nil, appErr
+	}
+
+	metadata, err := model.GetOpenIDProviderMetadata(*a.Config().ServiceSettings.SiteURL)
+	if err != nil {
+		return nil, model.NewAppError("GetOpenIDProviderMetadata", "api.oauth.authorization_server_metadata.invalid_url.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return metadata, nil
+}
+
+// GetOIDCJSONWebKeySet returns the public keys that ID tokens issued by this server can be
+// verified with: the key of the current rotation period and, if it still exists, the previous one.
+func (a *App) GetOIDCJSONWebKeySet(rctx request.CTX) (*model.JSONWebKeySet, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("GetOIDCJSONWebKeySet", "app.oauth.oidc.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	period := oidcSigningKeyPeriod(time.Now())
+
+	current, err := a.getOIDCSigningKey(rctx, period, true)
+	if err != nil {
+		return nil, model.NewAppError("GetOIDCJSONWebKeySet", "app.oauth.oidc.signing_key.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	keySet := &model.JSONWebKeySet{
+		Keys: []model.JSONWebKey{model.NewRSAJSONWebKey(current.id, &current.privateKey.PublicKey)},
+	}
+
+	previous, err := a.getOIDCSigningKey(rctx, period-1, false)
+	var nfErr *store.ErrNotFound
+	switch {
+	case err == nil:
+		keySet.Keys = append(keySet.Keys, model.NewRSAJSONWebKey(previous.id, &previous.privateKey.PublicKey))
+	case !errors.As(err, &nfErr):
+		return nil, model.NewAppError("GetOIDCJSONWebKeySet", "app.oauth.oidc.signing_key.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return keySet, nil
+}
+
+// getOIDCSigningKey loads the signing key of the given rotation period. If create is true and the
+// key doesn't exist yet, it is generated. Concurrent generation across the cluster is resolved by
+// keeping whichever key was stored first.
+func (a *App) getOIDCSigningKey(rctx request.CTX, period int64, create bool) (*oidcSigningKey, error) {
+	name := model.OIDCSigningKeySystemName(period)
+
+	system, err := a.Srv().Store().System().GetByName(name)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !create || !errors.As(err, &nfErr) {
+			return nil, err
+		}
+
+		system, err = a.createOIDCSigningKey(name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Keys older than the previous period can't have signed any unexpired token.
+		if _, err := a.Srv().Store().System().PermanentDeleteByName(model.OIDCSigningKeySystemName(period - 2)); err != nil {
+			rctx.Logger().Warn("Failed to delete expired OpenID Connect signing key", mlog.Err(err))
+		}
+	}
+
+	var key model.OIDCSigningKey
+	if err := json.Unmarshal([]byte(system.Value), &key); err != nil {
+		return nil, errors.Wrap(err, "failed to decode signing key")
+	}
+
+	privateKey, err := x509.ParsePKCS1PrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse signing key")
+	}
+
+	return &oidcSigningKey{id: key.Id, privateKey: privateKey}, nil
+}
+
+func (a *App) createOIDCSigningKey(name string) (*model.System, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, model.OIDCSigningKeyBits)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate signing key")
+	}
+
+	value, err := json.Marshal(&model.OIDCSigningKey{
+		Id:         model.NewId(),
+		CreateAt:   model.GetMillis(),
+		PrivateKey: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Srv().Store().System().InsertIfExists(&model.System{Name: name, Value: string(value)})
+}
+
+// createOIDCIDToken issues a signed ID token for the given user and client, releasing the claims
+// allowed by the granted scope.
+func (a *App) createOIDCIDToken(rctx request.CTX, oauthApp *model.OAuthApp, user *model.User, scope, nonce string) (string, *model.AppError) {
+	siteURL := *a.Config().ServiceSettings.SiteURL
+	if siteURL == "" {
+		return "", model.NewAppError("createOIDCIDToken", "api.oauth.authorization_server_metadata.site_url_required.app_error", nil, "", http.StatusInternalServerError)
+	}
+
+	key, err := a.getOIDCSigningKey(rctx, oidcSigningKeyPeriod(time.Now()), true)
+	if err != nil {
+		return "", model.NewAppError("createOIDCIDToken", "app.oauth.oidc.signing_key.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	profileClaims, appErr := a.getOIDCProfileClaims(user, scope)
+	if appErr != nil {
+		return "", appErr
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, oidcIDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    siteURL,
+			Subject:   user.Id,
+			Audience:  jwt.ClaimStrings{oauthApp.Id},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(model.OIDCIDTokenExpiry)),
+		},
+		Nonce:             nonce,
+		OIDCProfileClaims: profileClaims,
+	})
+	token.Header["kid"] = key.id
+
+	signed, err := token.SignedString(key.privateKey)
+	if err != nil {
+		return "", model.NewAppError("createOIDCIDToken", "app.oauth.oidc.sign_id_token.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return signed, nil
+}
+
+// GetOIDCUserInfo returns the claims about the user of an OAuth session, as allowed by the scope
+// granted to its access token.
+func (a *App) GetOIDCUserInfo(rctx request.CTX, session *model.Session) (*model.OIDCUserInfo, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("GetOIDCUserInfo", "app.oauth.oidc.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if !session.IsOAuth {
+		return nil, model.NewAppError("GetOIDCUserInfo", "app.oauth.oidc.userinfo.not_oauth.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	accessData, err := a.Srv().Store().OAuth().GetAccessData(session.Token)
+	if err != nil {
+		return nil, model.NewAppError("GetOIDCUserInfo", "app.oauth.oidc.userinfo.not_oauth.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+	}
+
+	if !model.HasScope(accessData.Scope, model.ScopeOpenID) {
+		return nil, model.NewAppError("GetOIDCUserInfo", "app.oauth.oidc.userinfo.insufficient_scope.app_error", nil, "", http.StatusForbidden)
+	}
+
+	user, appErr := a.GetUser(session.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	profileClaims, appErr := a.getOIDCProfileClaims(user, accessData.Scope)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &model.OIDCUserInfo{
+		Sub:               user.Id,
+		OIDCProfileClaims: profileClaims,
+	}, nil
+}
+
+func (a *App) getOIDCProfileClaims(user *model.User, scope string) (model.OIDCProfileClaims, *model.AppError) {
+	var claims model.OIDCProfileClaims
+
+	if model.HasScope(scope, model.ScopeProfile) {
+		claims.Name = user.GetFullName()
+		claims.GivenName = user.FirstName
+		claims.FamilyName = user.LastName
+		claims.Nickname = user.Nickname
+		claims.PreferredUsername = user.Username
+		claims.Locale = user.Locale
+		claims.UpdatedAt = user.UpdateAt / 1000
+		claims.Picture = *a.Config().ServiceSettings.SiteURL + "/api/v4/users/" + user.Id + "/image"
+
+		groups, appErr := a.GetGroupsByUserId(user.Id, model.GroupSearchOpts{})
+		if appErr != nil {
+			return claims, appErr
+		}
+		for _, group := range groups {
+			if group.Name != nil && *group.Name != "" {
+				claims.Groups = append(claims.Groups, *group.Name)
+			}
+		}
+	}
+
+	if model.HasScope(scope, model.ScopeEmail) {
+		claims.Email = user.Email
+		claims.EmailVerified = model.NewPointer(user.EmailVerified)
+	}
+
+	return claims, nil
+}
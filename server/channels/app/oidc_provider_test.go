@@ -0,0 +1,189 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func parseOIDCIDToken(t *testing.T, keySet *model.JSONWebKeySet, idToken string) *oidcIDTokenClaims {
+	t.Helper()
+
+	claims := &oidcIDTokenClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (any, error) {
+		for _, key := range keySet.Keys {
+			if key.Kid != token.Header["kid"] {
+				continue
+			}
+			n, err := base64.RawURLEncoding.DecodeString(key.N)
+			require.NoError(t, err)
+			e, err := base64.RawURLEncoding.DecodeString(key.E)
+			require.NoError(t, err)
+			return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+		}
+		return nil, jwt.ErrTokenUnverifiable
+	}, jwt.WithValidMethods([]string{model.OIDCSigningAlgorithmRS256}))
+	require.NoError(t, err)
+
+	return claims
+}
+
+func TestOIDCProvider(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOAuthServiceProvider = true
+		*cfg.ServiceSettings.SiteURL = "https://mattermost.example.com"
+	})
+
+	oauthApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "oidc" + model.NewRandomString(10),
+		CreatorId:    th.BasicUser2.Id,
+		Homepage:     "https://nowhere.com",
+		Description:  "test",
+		CallbackUrls: []string{"https://example.com/callback"},
+	})
+	require.Nil(t, appErr)
+
+	group := th.CreateGroup(t)
+	_, appErr = th.App.UpsertGroupMember(group.Id, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	getAccessToken := func(t *testing.T, scope, nonce string) *model.AccessResponse {
+		t.Helper()
+
+		redirectURI, appErr := th.App.AllowOAuthAppAccessToUser(th.Context, th.BasicUser.Id, &model.AuthorizeRequest{
+			ResponseType: model.AuthCodeResponseType,
+			ClientId:     oauthApp.Id,
+			RedirectURI:  oauthApp.CallbackUrls[0],
+			Scope:        scope,
+			State:        "state",
+			Nonce:        nonce,
+		})
+		require.Nil(t, appErr)
+
+		uri, err := url.Parse(redirectURI)
+		require.NoError(t, err)
+
+		accessRsp, appErr := th.App.GetOAuthAccessTokenForCodeFlow(th.Context, oauthApp.Id, model.AccessTokenGrantType, oauthApp.CallbackUrls[0], uri.Query().Get("code"), oauthApp.ClientSecret, "", "", "")
+		require.Nil(t, appErr)
+		return accessRsp
+	}
+
+	t.Run("no ID token without the openid scope", func(t *testing.T) {
+		accessRsp := getAccessToken(t, model.DefaultScope, "")
+		assert.Empty(t, accessRsp.IDToken)
+	})
+
+	t.Run("ID token carries the claims of the granted scopes", func(t *testing.T) {
+		accessRsp := getAccessToken(t, "openid profile email", "n-0S6_WzA2Mj")
+		require.NotEmpty(t, accessRsp.IDToken)
+		assert.Equal(t, "openid profile email", accessRsp.Scope)
+
+		keySet, appErr := th.App.GetOIDCJSONWebKeySet(th.Context)
+		require.Nil(t, appErr)
+		require.NotEmpty(t, keySet.Keys)
+
+		claims := parseOIDCIDToken(t, keySet, accessRsp.IDToken)
+		assert.Equal(t, "https://mattermost.example.com", claims.Issuer)
+		assert.Equal(t, th.BasicUser.Id, claims.Subject)
+		assert.Equal(t, jwt.ClaimStrings{oauthApp.Id}, claims.Audience)
+		assert.Equal(t, "n-0S6_WzA2Mj", claims.Nonce)
+		assert.Equal(t, th.BasicUser.Username, claims.PreferredUsername)
+		assert.Equal(t, th.BasicUser.Email, claims.Email)
+		assert.Contains(t, claims.Groups, *group.Name)
+		assert.WithinDuration(t, time.Now().Add(model.OIDCIDTokenExpiry), claims.ExpiresAt.Time, time.Minute)
+
+		session, appErr := th.App.GetSession(accessRsp.AccessToken)
+		require.Nil(t, appErr)
+		userInfo, appErr := th.App.GetOIDCUserInfo(th.Context, session)
+		require.Nil(t, appErr)
+		assert.Equal(t, th.BasicUser.Id, userInfo.Sub)
+		assert.Equal(t, th.BasicUser.Email, userInfo.Email)
+		assert.Equal(t, th.BasicUser.Username, userInfo.PreferredUsername)
+
+		refreshRsp, appErr := th.App.GetOAuthAccessTokenForCodeFlow(th.Context, oauthApp.Id, model.RefreshTokenGrantType, "", "", oauthApp.ClientSecret, accessRsp.RefreshToken, "", "")
+		require.Nil(t, appErr)
+		require.NotEmpty(t, refreshRsp.IDToken)
+		assert.Empty(t, parseOIDCIDToken(t, keySet, refreshRsp.IDToken).Nonce)
+	})
+
+	t.Run("only the email scope releases the email claims", func(t *testing.T) {
+		accessRsp := getAccessToken(t, "openid email", "")
+
+		session, appErr := th.App.GetSession(accessRsp.AccessToken)
+		require.Nil(t, appErr)
+		userInfo, appErr := th.App.GetOIDCUserInfo(th.Context, session)
+		require.Nil(t, appErr)
+		assert.Equal(t, th.BasicUser.Email, userInfo.Email)
+		assert.Empty(t, userInfo.PreferredUsername)
+		assert.Empty(t, userInfo.Groups)
+	})
+
+	t.Run("userinfo requires the openid scope", func(t *testing.T) {
+		accessRsp := getAccessToken(t, model.DefaultScope, "")
+
+		session, appErr := th.App.GetSession(accessRsp.AccessToken)
+		require.Nil(t, appErr)
+		_, appErr = th.App.GetOIDCUserInfo(th.Context, session)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.oidc.userinfo.insufficient_scope.app_error", appErr.Id)
+	})
+
+	t.Run("userinfo requires an OAuth session", func(t *testing.T) {
+		_, appErr := th.App.GetOIDCUserInfo(th.Context, &model.Session{UserId: th.BasicUser.Id})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.oauth.oidc.userinfo.not_oauth.app_error", appErr.Id)
+	})
+}
+
+func TestGetOIDCJSONWebKeySet(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	period := oidcSigningKeyPeriod(time.Now())
+
+	t.Run("key is stable within a rotation period", func(t *testing.T) {
+		first, appErr := th.App.GetOIDCJSONWebKeySet(th.Context)
+		require.Nil(t, appErr)
+		second, appErr := th.App.GetOIDCJSONWebKeySet(th.Context)
+		require.Nil(t, appErr)
+		assert.Equal(t, first.Keys[0], second.Keys[0])
+	})
+
+	t.Run("previous key is published after rotation", func(t *testing.T) {
+		previous, err := th.App.getOIDCSigningKey(th.Context, period-1, true)
+		require.NoError(t, err)
+
+		keySet, appErr := th.App.GetOIDCJSONWebKeySet(th.Context)
+		require.Nil(t, appErr)
+		require.Len(t, keySet.Keys, 2)
+		assert.Equal(t, previous.id, keySet.Keys[1].Kid)
+		assert.NotEqual(t, keySet.Keys[0].Kid, keySet.Keys[1].Kid)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+		_, appErr := th.App.GetOIDCJSONWebKeySet(th.Context)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
+)
+
+const (
+	DKIMPrivateKeyFilename = "dkim_private_key.pem"
+)
+
+func (a *App) GetOutgoingEmails(status string, page, perPage int) (*model.OutgoingEmailsWithCount, *model.AppError) {
+	emails, err := a.Srv().Store().OutgoingEmail().GetByStatus(status, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetOutgoingEmails", "app.outgoing_email.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	count, err := a.Srv().Store().OutgoingEmail().CountByStatus(status)
+	if err != nil {
+		return nil, model.NewAppError("GetOutgoingEmails", "app.outgoing_email.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, email := range emails {
+		email.Sanitize()
+	}
+
+	return &model.OutgoingEmailsWithCount{Emails: emails, TotalCount: count}, nil
+}
+
+func (a *App) RetryOutgoingEmail(id string) (*model.OutgoingEmail, *model.AppError) {
+	email, err := a.Srv().EmailService.RetryOutgoingEmail(id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("RetryOutgoingEmail", "app.outgoing_email.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return nil, model.NewAppError("RetryOutgoingEmail", "app.outgoing_email.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	email.Sanitize()
+	return email, nil
+}
+
+func (a *App) DeleteOutgoingEmail(id string) *model.AppError {
+	if err := a.Srv().Store().OutgoingEmail().Delete(id); err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return model.NewAppError("DeleteOutgoingEmail", "app.outgoing_email.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return model.NewAppError("DeleteOutgoingEmail", "app.outgoing_email.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+func (a *App) AddDKIMPrivateKey(fileData *multipart.FileHeader) *model.AppError {
+	file, err := fileData.Open()
+	if err != nil {
+		return model.NewAppError("AddDKIMPrivateKey", "api.admin.add_dkim_private_key.open.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return model.NewAppError("AddDKIMPrivateKey", "api.admin.add_dkim_private_key.saving.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if _, err = mail.ParseDKIMPrivateKey(data); err != nil {
+		return model.NewAppError("AddDKIMPrivateKey", "api.admin.add_dkim_private_key.invalid.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	err = a.Srv().platform.SetConfigFile(DKIMPrivateKeyFilename, data)
+	if err != nil {
+		return model.NewAppError("AddDKIMPrivateKey", "api.admin.add_dkim_private_key.saving.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	cfg := a.Config().Clone()
+
+	*cfg.EmailSettings.DKIMPrivateKeyFile = DKIMPrivateKeyFilename
+
+	if err := cfg.IsValid(); err != nil {
+		return err
+	}
+
+	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	a.resetDKIMSigner()
+
+	return nil
+}
+
+func (a *App) RemoveDKIMPrivateKey() *model.AppError {
+	err := a.Srv().platform.RemoveConfigFile(DKIMPrivateKeyFilename)
+	if err != nil {
+		return model.NewAppError("RemoveDKIMPrivateKey", "api.admin.remove_dkim_private_key.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	cfg := a.Config().Clone()
+
+	// Signing can't stay enabled without a key.
+	*cfg.EmailSettings.DKIMPrivateKeyFile = ""
+	*cfg.EmailSettings.EnableDKIMSigning = false
+
+	if err := cfg.IsValid(); err != nil {
+		return model.NewAppError("RemoveDKIMPrivateKey", "api.admin.remove_dkim_private_key.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	a.resetDKIMSigner()
+
+	return nil
+}
+
+// resetDKIMSigner drops the DKIM key cached by every node of the cluster, so
+// that they all sign with the key currently in the config store.
+func (a *App) resetDKIMSigner() {
+	a.Srv().EmailService.ResetDKIMSigner()
+
+	if cluster := a.Cluster(); cluster != nil {
+		cluster.SendClusterMessage(&model.ClusterMessage{
+			Event:            model.ClusterEventResetDKIMSigner,
+			SendType:         model.ClusterSendReliable,
+			WaitForAllToSend: true,
+		})
+	}
+}
@@ -140,6 +140,10 @@ func (ps *PlatformService) GetEnvironmentOverrides() map[string]any {
 	return ps.configStore.GetEnvironmentOverrides()
 }
 
+func (ps *PlatformService) SecretReferences() map[string]string {
+	return ps.configStore.SecretReferences()
+}
+
 func (ps *PlatformService) DescribeConfig() string {
 	return ps.configStore.String()
 }
@@ -21,15 +21,18 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/app/featureflag"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/faultinjectionlayer"
 	"github.com/mattermost/mattermost/server/v8/channels/store/localcachelayer"
 	"github.com/mattermost/mattermost/server/v8/channels/store/retrylayer"
 	"github.com/mattermost/mattermost/server/v8/channels/store/searchlayer"
 	"github.com/mattermost/mattermost/server/v8/channels/store/sqlstore"
 	"github.com/mattermost/mattermost/server/v8/channels/store/timerlayer"
+	"github.com/mattermost/mattermost/server/v8/channels/store/tracinglayer"
 	"github.com/mattermost/mattermost/server/v8/config"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
 	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
 
@@ -72,6 +75,10 @@ type PlatformService struct {
 	metrics      *platformMetrics
 	metricsIFace einterfaces.MetricsInterface
 
+	tracer *tracing.Tracer
+
+	storeFaultInjector *faultinjectionlayer.Injector
+
 	featureFlagSynchronizerMutex sync.Mutex
 	featureFlagSynchronizer      *featureflag.Synchronizer
 	featureFlagStop              chan struct{}
@@ -214,6 +221,7 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 				RedisDB:          *cacheConfig.RedisDB,
 				RedisCachePrefix: *cacheConfig.RedisCachePrefix,
 				DisableCache:     *cacheConfig.DisableClientCache,
+				Logger:           ps.Log(),
 			},
 		)
 	}
@@ -250,20 +258,31 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 			// The layer cake is as follows: (From bottom to top)
 			// SQL layer
 			// |
+			// Fault injection layer (only with the StoreFaultInjection feature flag)
+			// |
 			// Retry layer
 			// |
 			// Search layer
 			// |
 			// Timer layer
 			// |
+			// Tracing layer
+			// |
 			// Cache layer
 			ps.sqlStore, err = sqlstore.New(ps.Config().SqlSettings, ps.Log(), ps.metricsIFace, ps.storeOptions...)
 			if err != nil {
 				return nil, err
 			}
 
+			var childStore store.Store = ps.sqlStore
+			if ps.Config().FeatureFlags.StoreFaultInjection {
+				ps.storeFaultInjector = faultinjectionlayer.NewInjector()
+				childStore = faultinjectionlayer.New(ps.sqlStore, ps.storeFaultInjector)
+				ps.Log().Warn("Store fault injection is enabled, which must only be used for testing")
+			}
+
 			searchStore := searchlayer.NewSearchLayer(
-				retrylayer.New(ps.sqlStore),
+				retrylayer.New(childStore),
 				ps.SearchEngine,
 				ps.Config(),
 			)
@@ -273,7 +292,7 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 			})
 
 			lcl, err2 := localcachelayer.NewLocalCacheLayer(
-				timerlayer.New(searchStore, ps.metricsIFace),
+				tracinglayer.New(timerlayer.New(searchStore, ps.metricsIFace)),
 				ps.metricsIFace,
 				ps.clusterIFace,
 				ps.cacheProvider,
@@ -381,7 +400,18 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 		})
 	}
 
-	// Step 11: Init AsymmetricSigningKey depends on step 6 (store)
+	// Step 11: Init Tracing
+	ps.tracer = tracing.New()
+	if tErr := ps.tracer.Configure(&ps.Config().MetricsSettings); tErr != nil {
+		ps.logger.Error("Failed to configure tracing", mlog.Err(tErr))
+	}
+	ps.configStore.AddListener(func(oldCfg, newCfg *model.Config) {
+		if tErr := ps.tracer.Configure(&newCfg.MetricsSettings); tErr != nil {
+			ps.logger.Error("Failed to configure tracing", mlog.Err(tErr))
+		}
+	})
+
+	// Step 12: Init AsymmetricSigningKey depends on step 6 (store)
 	if err = ps.EnsureAsymmetricSigningKey(); err != nil {
 		return nil, fmt.Errorf("unable to ensure asymmetric signing key: %w", err)
 	}
@@ -461,6 +491,14 @@ func (ps *PlatformService) ShutdownMetrics() error {
 	return nil
 }
 
+func (ps *PlatformService) ShutdownTracing() error {
+	if ps.tracer != nil {
+		return ps.tracer.Shutdown()
+	}
+
+	return nil
+}
+
 func (ps *PlatformService) ShutdownConfig() error {
 	ps.RemoveConfigListener(ps.configListenerId)
 
@@ -556,6 +594,12 @@ func (ps *PlatformService) CacheProvider() cache.Provider {
 	return ps.cacheProvider
 }
 
+// StoreFaultInjector returns the injector of the store faults, or nil if the store isn't wrapped
+// in the fault-injection layer.
+func (ps *PlatformService) StoreFaultInjector() *faultinjectionlayer.Injector {
+	return ps.storeFaultInjector
+}
+
 // SetSqlStore is used for plugin testing
 func (ps *PlatformService) SetSqlStore(s *sqlstore.SqlStore) {
 	ps.sqlStore = s
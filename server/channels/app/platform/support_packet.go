@@ -19,6 +19,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/config"
 )
 
 const (
@@ -208,10 +209,17 @@ func (ps *PlatformService) getSupportPacketDiagnostics(rctx request.CTX) (*model
 }
 
 func (ps *PlatformService) getSanitizedConfigFile(rctx request.CTX) (*model.FileData, error) {
-	config := ps.getSanitizedConfig(rctx, &model.SanitizeOptions{PartiallyRedactDataSources: true})
+	// The secret settings set via a reference are only reported as such.
+	secretReferences := ps.SecretReferences()
+	for path := range secretReferences {
+		secretReferences[path] = config.SecretReferenceSupportPacketValue
+	}
+	cfg := ps.getSanitizedConfig(rctx, &model.SanitizeOptions{PartiallyRedactDataSources: true})
+	config.ApplySecretReferences(cfg, secretReferences)
+
 	spConfig := model.SupportPacketConfig{
-		Config:       config,
-		FeatureFlags: *config.FeatureFlags,
+		Config:       cfg,
+		FeatureFlags: *cfg.FeatureFlags,
 	}
 	sanitizedConfigPrettyJSON, err := json.MarshalIndent(spConfig, "", "    ")
 	if err != nil {
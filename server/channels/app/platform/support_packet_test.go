@@ -485,11 +485,13 @@ func TestGetSupportPacketDiagnostics(t *testing.T) {
 
 func TestGetSanitizedConfigFile(t *testing.T) {
 	t.Setenv("MM_FEATUREFLAGS_TestFeature", "true")
+	t.Setenv("TEST_SMTP_PASSWORD", "secret")
 
 	th := Setup(t)
 
 	th.Service.UpdateConfig(func(cfg *model.Config) {
 		cfg.ServiceSettings.AllowedUntrustedInternalConnections = model.NewPointer("example.com")
+		cfg.EmailSettings.SMTPPassword = model.NewPointer("env:TEST_SMTP_PASSWORD")
 	})
 
 	// Happy path where we have a sanitized config file with no err
@@ -506,6 +508,9 @@ func TestGetSanitizedConfigFile(t *testing.T) {
 	// Ensure sensitive fields are redacted
 	assert.Equal(t, model.FakeSetting, *config.FileSettings.PublicLinkSalt)
 
+	// Ensure secrets set via a reference are only reported as such
+	assert.Equal(t, "set via reference", *config.EmailSettings.SMTPPassword)
+
 	// Ensure non-sensitive fields are present
 	assert.Equal(t, "example.com", *config.ServiceSettings.AllowedUntrustedInternalConnections)
 
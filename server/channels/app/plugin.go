@@ -105,6 +105,11 @@ func (ch *Channels) syncPluginsActiveState() {
 				pluginEnabled = value
 			}
 
+			if pluginEnabled && !ch.arePluginPermissionsApproved(plugin.Manifest) {
+				ch.srv.Log().Warn("Not activating plugin: its permissions have not been approved by a system admin", mlog.String("plugin_id", pluginID))
+				pluginEnabled = false
+			}
+
 			if pluginEnabled {
 				enabledPlugins = append(enabledPlugins, plugin)
 			} else {
@@ -112,48 +117,55 @@ func (ch *Channels) syncPluginsActiveState() {
 			}
 		}
 
-		// Concurrently activate/deactivate each plugin appropriately.
-		var wg sync.WaitGroup
-
-		// Deactivate any plugins that have been disabled.
-		for _, plugin := range disabledPlugins {
-			wg.Add(1)
-			go func(plugin *model.BundleInfo) {
-				defer wg.Done()
-
-				deactivated := pluginsEnvironment.Deactivate(plugin.Manifest.Id)
-				if deactivated && plugin.Manifest.HasClient() {
-					message := model.NewWebSocketEvent(model.WebsocketEventPluginDisabled, "", "", "", nil, "")
-					message.Add("manifest", plugin.Manifest.ClientManifest())
-					ch.srv.platform.Publish(message)
-				}
-			}(plugin)
+		// Deactivate any plugins that have been disabled, dependents before their dependencies.
+		// The plugins of a tier don't depend on each other and are deactivated concurrently.
+		disabledTiers := plugin.SortByDependencies(disabledPlugins)
+		for i := len(disabledTiers) - 1; i >= 0; i-- {
+			var wg sync.WaitGroup
+			for _, disabledPlugin := range disabledTiers[i] {
+				wg.Add(1)
+				go func(plugin *model.BundleInfo) {
+					defer wg.Done()
+
+					deactivated := pluginsEnvironment.Deactivate(plugin.Manifest.Id)
+					if deactivated && plugin.Manifest.HasClient() {
+						message := model.NewWebSocketEvent(model.WebsocketEventPluginDisabled, "", "", "", nil, "")
+						message.Add("manifest", plugin.Manifest.ClientManifest())
+						ch.srv.platform.Publish(message)
+					}
+				}(disabledPlugin)
+			}
+			wg.Wait()
 		}
 
-		// Activate any plugins that have been enabled
-		for _, plugin := range enabledPlugins {
-			wg.Add(1)
-			go func(plugin *model.BundleInfo) {
-				defer wg.Done()
-
-				pluginID := plugin.Manifest.Id
-				logger := ch.srv.Log().With(mlog.String("plugin_id", pluginID), mlog.String("bundle_path", plugin.Path))
-
-				updatedManifest, activated, err := pluginsEnvironment.Activate(pluginID)
-				if err != nil {
-					logger.Error("Unable to activate plugin", mlog.Err(err))
-					return
-				}
+		// Activate any plugins that have been enabled, dependencies before their dependents.
+		// The plugins of a tier don't depend on each other and are activated concurrently.
+		for _, tier := range plugin.SortByDependencies(enabledPlugins) {
+			var wg sync.WaitGroup
+			for _, enabledPlugin := range tier {
+				wg.Add(1)
+				go func(plugin *model.BundleInfo) {
+					defer wg.Done()
+
+					pluginID := plugin.Manifest.Id
+					logger := ch.srv.Log().With(mlog.String("plugin_id", pluginID), mlog.String("bundle_path", plugin.Path))
+
+					updatedManifest, activated, err := pluginsEnvironment.Activate(pluginID)
+					if err != nil {
+						logger.Error("Unable to activate plugin", mlog.Err(err))
+						return
+					}
 
-				if activated {
-					// Notify all cluster clients if ready
-					if err := ch.notifyPluginEnabled(updatedManifest); err != nil {
-						logger.Error("Failed to notify cluster on plugin enable", mlog.Err(err))
+					if activated {
+						// Notify all cluster clients if ready
+						if err := ch.notifyPluginEnabled(updatedManifest); err != nil {
+							logger.Error("Failed to notify cluster on plugin enable", mlog.Err(err))
+						}
 					}
-				}
-			}(plugin)
+				}(enabledPlugin)
+			}
+			wg.Wait()
 		}
-		wg.Wait()
 	} else { // If plugins are disabled, shutdown plugins.
 		pluginsEnvironment.Shutdown()
 	}
@@ -181,6 +193,9 @@ func (ch *Channels) initPlugins(rctx request.CTX, pluginDir, webappPluginDir str
 	pluginsEnvironment := ch.pluginsEnvironment
 	ch.pluginsLock.RUnlock()
 	if pluginsEnvironment != nil || !*ch.cfgSvc.Config().PluginSettings.Enable {
+		if pluginsEnvironment != nil {
+			pluginsEnvironment.SetResourceLimits(ch.cfgSvc.Config().PluginSettings.ResourceLimits)
+		}
 		ch.syncPluginsActiveState()
 		if pluginsEnvironment != nil {
 			pluginsEnvironment.TogglePluginHealthCheckJob(*ch.cfgSvc.Config().PluginSettings.EnableHealthCheck)
@@ -216,6 +231,8 @@ func (ch *Channels) initPlugins(rctx request.CTX, pluginDir, webappPluginDir str
 		ch.srv.Log().Error("Failed to start up plugins", mlog.Err(err))
 		return
 	}
+	env.SetResourceLimits(ch.cfgSvc.Config().PluginSettings.ResourceLimits)
+
 	ch.pluginsLock.Lock()
 	ch.pluginsEnvironment = env
 	ch.pluginsLock.Unlock()
@@ -244,7 +261,7 @@ func (ch *Channels) initPlugins(rctx request.CTX, pluginDir, webappPluginDir str
 			ch.syncPluginsActiveState()
 		}
 
-		ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			if err := hooks.OnConfigurationChange(); err != nil {
 				ch.srv.Log().Error("Plugin OnConfigurationChange hook failed", mlog.Err(err))
 			}
@@ -442,7 +459,12 @@ func (ch *Channels) enablePlugin(id string) *model.AppError {
 	}
 
 	ch.cfgSvc.UpdateConfig(func(cfg *model.Config) {
-		cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: true}
+		state := cfg.PluginSettings.PluginStates[id]
+		if state == nil {
+			state = &model.PluginState{}
+			cfg.PluginSettings.PluginStates[id] = state
+		}
+		state.Enable = true
 	})
 
 	// This call will implicitly invoke SyncPluginsActiveState which will activate enabled plugins.
@@ -493,7 +515,12 @@ func (ch *Channels) disablePlugin(id string) *model.AppError {
 	}
 
 	ch.cfgSvc.UpdateConfig(func(cfg *model.Config) {
-		cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: false}
+		state := cfg.PluginSettings.PluginStates[id]
+		if state == nil {
+			state = &model.PluginState{}
+			cfg.PluginSettings.PluginStates[id] = state
+		}
+		state.Enable = false
 	})
 	ch.unregisterPluginCommands(id)
 
@@ -369,6 +369,22 @@ func (api *PluginAPI) UpdateUserActive(userID string, active bool) *model.AppErr
 	return api.app.UpdateUserActive(api.ctx, userID, active)
 }
 
+func (api *PluginAPI) SetGuestAccessExpiry(userID string, expiresAt int64) (*model.GuestAccessExpiry, *model.AppError) {
+	return api.app.SetGuestAccessExpiry(api.ctx, userID, expiresAt)
+}
+
+func (api *PluginAPI) GetGuestAccessExpiry(userID string) (*model.GuestAccessExpiry, *model.AppError) {
+	return api.app.GetGuestAccessExpiry(userID)
+}
+
+func (api *PluginAPI) RegisterSearchEngine() *model.AppError {
+	return api.app.RegisterPluginSearchEngine(api.id)
+}
+
+func (api *PluginAPI) UnregisterSearchEngine() *model.AppError {
+	return api.app.UnregisterPluginSearchEngine(api.id)
+}
+
 func (api *PluginAPI) GetUserStatus(userID string) (*model.Status, *model.AppError) {
 	return api.app.GetStatus(userID)
 }
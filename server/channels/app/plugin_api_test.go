@@ -2090,6 +2090,7 @@ func TestAPIMetrics(t *testing.T) {
 
 		// Setup mocks
 		metricsMock.On("ObservePluginAPIDuration", pluginID, "UpdateUser", true, mock.Anything).Return()
+		metricsMock.On("ClearPluginResourceUsage", pluginID).Return()
 
 		_, _, activationErr := env.Activate(pluginID)
 		require.NoError(t, activationErr)
@@ -1197,6 +1197,7 @@ func TestHookMetrics(t *testing.T) {
 		metricsMock.On("ObservePluginHookDuration", pluginID, "OnDeactivate", true, mock.Anything).Return()
 		metricsMock.On("ObservePluginHookDuration", pluginID, "OnConfigurationChange", true, mock.Anything).Return()
 		metricsMock.On("ObservePluginHookDuration", pluginID, "UserHasBeenCreated", true, mock.Anything).Return()
+		metricsMock.On("ClearPluginResourceUsage", pluginID).Return()
 
 		// Don't care about these calls.
 		metricsMock.On("ObservePluginAPIDuration", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
@@ -2306,3 +2307,242 @@ func TestUserHasJoinedChannel(t *testing.T) {
 		}
 	})
 }
+
+func TestHookChannelLifecycle(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	pluginCode := `
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) ChannelWillBeUpdated(c *plugin.Context, newChannel, oldChannel *model.Channel) string {
+			if newChannel.DisplayName == "rejected" {
+				return "display name not allowed"
+			}
+			return ""
+		}
+
+		func (p *MyPlugin) ChannelHasBeenUpdated(c *plugin.Context, newChannel, oldChannel *model.Channel) {
+			p.API.KVSet("updated", []byte(oldChannel.DisplayName+" -> "+newChannel.DisplayName))
+		}
+
+		func (p *MyPlugin) ChannelWillBeArchived(c *plugin.Context, channel *model.Channel) string {
+			if channel.Purpose == "keep" {
+				return "channel must be kept"
+			}
+			return ""
+		}
+
+		func (p *MyPlugin) ChannelHasBeenArchived(c *plugin.Context, channel *model.Channel) {
+			p.API.KVSet("archived", []byte(channel.Id))
+		}
+
+		func (p *MyPlugin) ChannelHasBeenRestored(c *plugin.Context, channel *model.Channel) {
+			p.API.KVSet("restored", []byte(channel.Id))
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`
+	pluginID := "testplugin"
+	pluginManifest := `{"id": "testplugin", "server": {"executable": "backend.exe"}}`
+	setupPluginAPITest(t, pluginCode, pluginManifest, pluginID, th.App, th.Context)
+
+	requireKey := func(t *testing.T, key, expected string) {
+		t.Helper()
+		assert.EventuallyWithT(t, func(t *assert.CollectT) {
+			value, appErr := th.App.GetPluginKey(pluginID, key)
+			require.Nil(t, appErr)
+			assert.Equal(t, expected, string(value))
+		}, 5*time.Second, 100*time.Millisecond)
+	}
+
+	channel := th.CreateChannel(t, th.BasicTeam)
+	oldDisplayName := channel.DisplayName
+
+	channel.DisplayName = "rejected"
+	_, appErr := th.App.UpdateChannel(th.Context, channel)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "Channel update rejected by plugin. display name not allowed", appErr.Id)
+
+	channel.DisplayName = "accepted"
+	_, appErr = th.App.UpdateChannel(th.Context, channel)
+	require.Nil(t, appErr)
+	requireKey(t, "updated", oldDisplayName+" -> accepted")
+
+	keptChannel := th.CreateChannel(t, th.BasicTeam)
+	keptChannel.Purpose = "keep"
+	keptChannel, appErr = th.App.UpdateChannel(th.Context, keptChannel)
+	require.Nil(t, appErr)
+	appErr = th.App.DeleteChannel(th.Context, keptChannel, th.BasicUser.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "Channel archival rejected by plugin. channel must be kept", appErr.Id)
+
+	require.Nil(t, th.App.DeleteChannel(th.Context, channel, th.BasicUser.Id))
+	requireKey(t, "archived", channel.Id)
+
+	channel, appErr = th.App.GetChannel(th.Context, channel.Id)
+	require.Nil(t, appErr)
+	_, appErr = th.App.RestoreChannel(th.Context, channel, th.BasicUser.Id)
+	require.Nil(t, appErr)
+	requireKey(t, "restored", channel.Id)
+}
+
+func TestHookTeamLifecycle(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	pluginCode := `
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) TeamHasBeenCreated(c *plugin.Context, team *model.Team) {
+			p.API.KVSet("created", []byte(team.Id))
+		}
+
+		func (p *MyPlugin) TeamWillBeUpdated(c *plugin.Context, newTeam, oldTeam *model.Team) string {
+			if newTeam.Description == "rejected" {
+				return "description not allowed"
+			}
+			return ""
+		}
+
+		func (p *MyPlugin) TeamHasBeenUpdated(c *plugin.Context, newTeam, oldTeam *model.Team) {
+			p.API.KVSet("updated", []byte(oldTeam.Description+" -> "+newTeam.Description))
+		}
+
+		func (p *MyPlugin) TeamHasBeenArchived(c *plugin.Context, team *model.Team) {
+			p.API.KVSet("archived", []byte(team.Id))
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`
+	pluginID := "testplugin"
+	pluginManifest := `{"id": "testplugin", "server": {"executable": "backend.exe"}}`
+	setupPluginAPITest(t, pluginCode, pluginManifest, pluginID, th.App, th.Context)
+
+	requireKey := func(t *testing.T, key, expected string) {
+		t.Helper()
+		assert.EventuallyWithT(t, func(t *assert.CollectT) {
+			value, appErr := th.App.GetPluginKey(pluginID, key)
+			require.Nil(t, appErr)
+			assert.Equal(t, expected, string(value))
+		}, 5*time.Second, 100*time.Millisecond)
+	}
+
+	team := th.CreateTeam(t)
+	requireKey(t, "created", team.Id)
+
+	_, appErr := th.App.PatchTeam(team.Id, &model.TeamPatch{Description: model.NewPointer("rejected")})
+	require.NotNil(t, appErr)
+	assert.Equal(t, "Team update rejected by plugin. description not allowed", appErr.Id)
+
+	_, appErr = th.App.PatchTeam(team.Id, &model.TeamPatch{Description: model.NewPointer("accepted")})
+	require.Nil(t, appErr)
+	requireKey(t, "updated", team.Description+" -> accepted")
+
+	require.Nil(t, th.App.SoftDeleteTeam(team.Id))
+	requireKey(t, "archived", team.Id)
+}
+
+func TestHookUserAndMessageLifecycle(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	pluginCode := `
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) UserHasBeenUpdated(c *plugin.Context, newUser, oldUser *model.User) {
+			p.API.KVSet("user_updated", []byte(oldUser.Nickname+" -> "+newUser.Nickname))
+			p.API.KVSet("user_updated_secrets", []byte("secrets:"+oldUser.Password+oldUser.MfaSecret+newUser.Password+newUser.MfaSecret))
+		}
+
+		func (p *MyPlugin) UserRolesHaveChanged(c *plugin.Context, user *model.User, oldRoles string) {
+			p.API.KVSet("roles_changed", []byte(oldRoles+" -> "+user.Roles))
+			p.API.KVSet("roles_changed_secrets", []byte("secrets:"+user.Password+user.MfaSecret))
+		}
+
+		func (p *MyPlugin) MessageHasBeenPinned(c *plugin.Context, post *model.Post) {
+			p.API.KVSet("pinned", []byte(post.Id))
+		}
+
+		func (p *MyPlugin) MessageHasBeenUnpinned(c *plugin.Context, post *model.Post) {
+			p.API.KVSet("unpinned", []byte(post.Id))
+		}
+
+		func (p *MyPlugin) MessageHasBeenAcknowledged(c *plugin.Context, post *model.Post, acknowledgement *model.PostAcknowledgement) {
+			p.API.KVSet("acknowledged", []byte(post.Id+" "+acknowledgement.UserId))
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`
+	pluginID := "testplugin"
+	pluginManifest := `{"id": "testplugin", "server": {"executable": "backend.exe"}}`
+	setupPluginAPITest(t, pluginCode, pluginManifest, pluginID, th.App, th.Context)
+
+	requireKey := func(t *testing.T, key, expected string) {
+		t.Helper()
+		assert.EventuallyWithT(t, func(t *assert.CollectT) {
+			value, appErr := th.App.GetPluginKey(pluginID, key)
+			require.Nil(t, appErr)
+			assert.Equal(t, expected, string(value))
+		}, 5*time.Second, 100*time.Millisecond)
+	}
+
+	user := th.CreateUser(t)
+	oldNickname := user.Nickname
+	user.Nickname = "updated"
+	_, appErr := th.App.UpdateUser(th.Context, user, false)
+	require.Nil(t, appErr)
+	requireKey(t, "user_updated", oldNickname+" -> updated")
+	requireKey(t, "user_updated_secrets", "secrets:")
+
+	_, appErr = th.App.UpdateUserRoles(th.Context, user.Id, model.SystemUserRoleId+" "+model.SystemAdminRoleId, false)
+	require.Nil(t, appErr)
+	requireKey(t, "roles_changed", model.SystemUserRoleId+" -> "+model.SystemUserRoleId+" "+model.SystemAdminRoleId)
+	requireKey(t, "roles_changed_secrets", "secrets:")
+
+	post := th.CreatePost(t, th.BasicChannel)
+	_, appErr = th.App.PatchPost(th.Context, post.Id, &model.PostPatch{IsPinned: model.NewPointer(true)}, nil)
+	require.Nil(t, appErr)
+	requireKey(t, "pinned", post.Id)
+
+	_, appErr = th.App.PatchPost(th.Context, post.Id, &model.PostPatch{IsPinned: model.NewPointer(false)}, nil)
+	require.Nil(t, appErr)
+	requireKey(t, "unpinned", post.Id)
+
+	_, appErr = th.App.SaveAcknowledgementForPost(th.Context, post.Id, th.BasicUser2.Id)
+	require.Nil(t, appErr)
+	requireKey(t, "acknowledged", post.Id+" "+th.BasicUser2.Id)
+}
@@ -485,6 +485,8 @@ func (ch *Channels) installExtractedPlugin(manifest *model.Manifest, fromPluginD
 		if err := ch.removePluginLocally(existingManifest.Id); err != nil {
 			return nil, model.NewAppError("installExtractedPlugin", "app.plugin.install_id_failed_remove.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
+
+		ch.requirePluginPermissionsApproval(manifest, existingManifest)
 	}
 
 	bundlePath := filepath.Join(*ch.cfgSvc.Config().PluginSettings.Directory, manifest.Id)
@@ -508,6 +510,12 @@ func (ch *Channels) installExtractedPlugin(manifest *model.Manifest, fromPluginD
 			return manifest, nil
 		}
 
+		// Plugins extending their permissions on upgrade wait for the approval of a system admin.
+		if !ch.arePluginPermissionsApproved(manifest) {
+			logger.Warn("Not activating plugin: its permissions have not been approved by a system admin")
+			return manifest, nil
+		}
+
 		updatedManifest, _, err := pluginsEnvironment.Activate(manifest.Id)
 		if err != nil {
 			return nil, model.NewAppError("installExtractedPlugin", "app.plugin.restart.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -0,0 +1,119 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+)
+
+// pluginPermissionsNotApprovedError is the error shown in the status of a plugin whose
+// permissions are waiting for the approval of a system admin.
+const pluginPermissionsNotApprovedError = "the permissions of the plugin have not been approved by a system admin"
+
+// getApprovedPluginPermissions returns the permissions a system admin approved for the plugin.
+func (ch *Channels) getApprovedPluginPermissions(pluginID string) *model.PluginPermissions {
+	state := ch.cfgSvc.Config().PluginSettings.PluginStates[pluginID]
+	if state == nil {
+		return nil
+	}
+
+	return state.ApprovedPermissions
+}
+
+// pluginPermissionsApproved returns whether the permissions declared by a plugin are covered by
+// the approved ones. A plugin without permissions has access to everything, which only needs
+// approval when restricted permissions were approved, or requested, for a previous version.
+func pluginPermissionsApproved(permissions, approved *model.PluginPermissions) bool {
+	if permissions == nil {
+		return approved == nil
+	}
+
+	return approved != nil && permissions.IsSubsetOf(approved)
+}
+
+// arePluginPermissionsApproved returns whether the permissions declared in the manifest of a
+// plugin have been approved.
+func (ch *Channels) arePluginPermissionsApproved(manifest *model.Manifest) bool {
+	return pluginPermissionsApproved(manifest.Permissions, ch.getApprovedPluginPermissions(manifest.Id))
+}
+
+// requirePluginPermissionsApproval records that a plugin upgraded from a version declaring
+// permissions to one without any, and so requesting access to everything, needs the approval
+// of a system admin even though the previous version never got it.
+func (ch *Channels) requirePluginPermissionsApproval(manifest, existingManifest *model.Manifest) {
+	if manifest.Permissions != nil || existingManifest.Permissions == nil || ch.getApprovedPluginPermissions(manifest.Id) != nil {
+		return
+	}
+
+	ch.cfgSvc.UpdateConfig(func(cfg *model.Config) {
+		state := cfg.PluginSettings.PluginStates[manifest.Id]
+		if state == nil {
+			state = &model.PluginState{}
+			cfg.PluginSettings.PluginStates[manifest.Id] = state
+		}
+		state.ApprovedPermissions = &model.PluginPermissions{}
+	})
+}
+
+// setPluginPermissionsStatus adds the approved permissions of a plugin to its status.
+func (ch *Channels) setPluginPermissionsStatus(status *model.PluginStatus) {
+	status.ApprovedPermissions = ch.getApprovedPluginPermissions(status.PluginId)
+
+	if status.Error == "" && !pluginPermissionsApproved(status.Permissions, status.ApprovedPermissions) {
+		status.Error = pluginPermissionsNotApprovedError
+	}
+}
+
+// ApprovePluginPermissions approves the permissions declared in the manifest of an installed
+// plugin, allowing it to be activated. It returns the permissions approved before and after.
+// Approving a plugin without permissions grants it access to everything, leaving no approved
+// permissions.
+func (a *App) ApprovePluginPermissions(id string) (*model.PluginPermissions, *model.PluginPermissions, *model.AppError) {
+	return a.ch.approvePluginPermissions(id)
+}
+
+func (ch *Channels) approvePluginPermissions(id string) (*model.PluginPermissions, *model.PluginPermissions, *model.AppError) {
+	pluginsEnvironment := ch.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return nil, nil, model.NewAppError("ApprovePluginPermissions", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	id = strings.ToLower(id)
+
+	manifest, err := pluginsEnvironment.GetManifest(id)
+	if errors.Is(err, plugin.ErrNotFound) {
+		return nil, nil, model.NewAppError("ApprovePluginPermissions", "app.plugin.not_installed.app_error", nil, "", http.StatusNotFound)
+	} else if err != nil {
+		return nil, nil, model.NewAppError("ApprovePluginPermissions", "app.plugin.config.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	priorPermissions := ch.getApprovedPluginPermissions(id)
+	if manifest.Permissions == nil && priorPermissions == nil {
+		return nil, nil, model.NewAppError("ApprovePluginPermissions", "app.plugin.approve_permissions.no_permissions.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	ch.cfgSvc.UpdateConfig(func(cfg *model.Config) {
+		state := cfg.PluginSettings.PluginStates[id]
+		if state == nil {
+			state = &model.PluginState{}
+			cfg.PluginSettings.PluginStates[id] = state
+		}
+		state.ApprovedPermissions = manifest.Permissions.Clone()
+	})
+
+	// This call will implicitly invoke SyncPluginsActiveState which will activate the plugin if enabled.
+	if _, _, err := ch.cfgSvc.SaveConfig(ch.cfgSvc.Config(), true); err != nil {
+		if err.Id == "ent.cluster.save_config.error" {
+			return nil, nil, model.NewAppError("ApprovePluginPermissions", "app.plugin.cluster.save_config.app_error", nil, "", http.StatusInternalServerError)
+		}
+		return nil, nil, model.NewAppError("ApprovePluginPermissions", "app.plugin.config.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return priorPermissions, manifest.Permissions, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestPluginPermissions(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	pluginID := "com.mattermost.permissions"
+	pluginCode := `
+		package main
+
+		import (
+			"fmt"
+
+			"github.com/mattermost/mattermost/server/public/plugin"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) OnActivate() error {
+			if _, appErr := p.API.GetUser("` + model.NewId() + `"); appErr == nil || appErr.Id != "plugin.api.permission_denied.app_error" {
+				return fmt.Errorf("GetUser should be denied, got %v", appErr)
+			}
+
+			if _, appErr := p.API.GetChannel("` + model.NewId() + `"); appErr == nil || appErr.Id != "app.channel.get.existing.app_error" {
+				return fmt.Errorf("GetChannel should be allowed, got %v", appErr)
+			}
+
+			if appErr := p.API.KVSet("key", []byte("value")); appErr != nil {
+				return fmt.Errorf("KVSet should always be allowed, got %v", appErr)
+			}
+
+			return nil
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`
+	pluginManifest := `{"id": "com.mattermost.permissions", "server": {"executable": "backend.exe"}, "permissions": {"api": ["channels.read"], "hooks": ["MessageHasBeenPosted"]}}`
+
+	t.Run("undeclared API calls are denied", func(t *testing.T) {
+		th := Setup(t)
+
+		setupPluginAPITest(t, pluginCode, pluginManifest, pluginID, th.App, th.Context)
+
+		status, appErr := th.App.GetPluginStatus(pluginID)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.PluginStateRunning, status.State)
+		assert.Equal(t, []string{model.PluginAPIGroupChannelsRead}, status.Permissions.API)
+	})
+
+	t.Run("plugins are activated once their permissions are approved", func(t *testing.T) {
+		th := Setup(t)
+
+		setupPluginAPITest(t, pluginCode, pluginManifest, pluginID, th.App, th.Context)
+
+		// The permissions were never approved, so syncing deactivates the plugin.
+		th.App.ch.syncPluginsActiveState()
+
+		status, appErr := th.App.GetPluginStatus(pluginID)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.PluginStateNotRunning, status.State)
+		assert.Equal(t, pluginPermissionsNotApprovedError, status.Error)
+		assert.Nil(t, status.ApprovedPermissions)
+
+		prior, approved, appErr := th.App.ApprovePluginPermissions(pluginID)
+		require.Nil(t, appErr)
+		assert.Nil(t, prior)
+		assert.Equal(t, []string{model.PluginAPIGroupChannelsRead}, approved.API)
+		th.App.ch.syncPluginsActiveState()
+
+		status, appErr = th.App.GetPluginStatus(pluginID)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.PluginStateRunning, status.State)
+		assert.Empty(t, status.Error)
+		require.NotNil(t, status.ApprovedPermissions)
+		assert.Equal(t, []string{"MessageHasBeenPosted"}, status.ApprovedPermissions.Hooks)
+		assert.True(t, th.App.Config().PluginSettings.PluginStates[pluginID].Enable)
+
+		// Disabling the plugin keeps the approved permissions.
+		require.Nil(t, th.App.DisablePlugin(pluginID))
+		assert.NotNil(t, th.App.Config().PluginSettings.PluginStates[pluginID].ApprovedPermissions)
+	})
+
+	t.Run("upgrading to a version without permissions requires approval", func(t *testing.T) {
+		th := Setup(t)
+
+		setupPluginAPITest(t, `
+			package main
+
+			import (
+				"github.com/mattermost/mattermost/server/public/plugin"
+			)
+
+			type MyPlugin struct {
+				plugin.MattermostPlugin
+			}
+
+			func main() {
+				plugin.ClientMain(&MyPlugin{})
+			}
+		`, `{"id": "com.mattermost.permissions", "server": {"executable": "backend.exe"}}`, pluginID, th.App, th.Context)
+
+		// The previous version declared permissions that were approved.
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.PluginSettings.PluginStates[pluginID].ApprovedPermissions = &model.PluginPermissions{API: []string{model.PluginAPIGroupChannelsRead}}
+		})
+		th.App.ch.syncPluginsActiveState()
+
+		status, appErr := th.App.GetPluginStatus(pluginID)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.PluginStateNotRunning, status.State)
+		assert.Equal(t, pluginPermissionsNotApprovedError, status.Error)
+
+		prior, approved, appErr := th.App.ApprovePluginPermissions(pluginID)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{model.PluginAPIGroupChannelsRead}, prior.API)
+		assert.Nil(t, approved)
+		th.App.ch.syncPluginsActiveState()
+
+		status, appErr = th.App.GetPluginStatus(pluginID)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.PluginStateRunning, status.State)
+		assert.Empty(t, status.Error)
+		assert.Nil(t, status.ApprovedPermissions)
+
+		_, _, appErr = th.App.ApprovePluginPermissions(pluginID)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.approve_permissions.no_permissions.app_error", appErr.Id)
+	})
+
+	t.Run("upgrading from a version waiting for approval to one without permissions", func(t *testing.T) {
+		th := Setup(t)
+
+		manifest := &model.Manifest{Id: pluginID}
+		existingManifest := &model.Manifest{Id: pluginID, Permissions: &model.PluginPermissions{API: []string{model.PluginAPIGroupChannelsRead}}}
+		require.True(t, th.App.ch.arePluginPermissionsApproved(manifest))
+
+		th.App.ch.requirePluginPermissionsApproval(manifest, existingManifest)
+		assert.False(t, th.App.ch.arePluginPermissionsApproved(manifest))
+		assert.False(t, th.App.ch.arePluginPermissionsApproved(existingManifest))
+	})
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
+)
+
+const pluginSearchEngineName = "plugin"
+
+// RegisterPluginSearchEngine routes the searches to the plugin while it is active.
+func (a *App) RegisterPluginSearchEngine(pluginID string) *model.AppError {
+	broker := a.SearchEngine()
+	if engine, ok := broker.PluginEngine().(*pluginSearchEngine); ok && engine.pluginID != pluginID && engine.IsActive() {
+		return model.NewAppError("RegisterPluginSearchEngine", "app.plugin.search_engine.already_registered.app_error", map[string]any{"PluginId": engine.pluginID}, "", http.StatusConflict)
+	}
+
+	broker.RegisterPluginEngine(&pluginSearchEngine{ch: a.ch, pluginID: pluginID})
+	return nil
+}
+
+// UnregisterPluginSearchEngine stops routing the searches to the plugin, if it is the registered
+// search engine.
+func (a *App) UnregisterPluginSearchEngine(pluginID string) *model.AppError {
+	broker := a.SearchEngine()
+	if engine, ok := broker.PluginEngine().(*pluginSearchEngine); ok && engine.pluginID == pluginID {
+		broker.UnregisterPluginEngine()
+	}
+	return nil
+}
+
+// pluginSearchEngine is the search engine implemented by a plugin through the search engine hooks.
+// It is only active while the plugin is active, and its errors make the search layer fall back to
+// the database search.
+type pluginSearchEngine struct {
+	ch       *Channels
+	pluginID string
+}
+
+var _ searchengine.SearchEngineInterface = (*pluginSearchEngine)(nil)
+
+func (e *pluginSearchEngine) hooks() (plugin.Hooks, error) {
+	pluginsEnvironment := e.ch.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return nil, errors.New("plugins are disabled")
+	}
+
+	return pluginsEnvironment.HooksForPlugin(e.pluginID)
+}
+
+// run runs a search engine hook of the plugin. The hooks the plugin doesn't implement are
+// ignored unless required, which makes the searches fall back to the database.
+func (e *pluginSearchEngine) run(where string, required bool, fn func(hooks plugin.Hooks) error) *model.AppError {
+	hooks, err := e.hooks()
+	if err == nil {
+		err = fn(hooks)
+	}
+	if errors.Is(err, plugin.ErrSearchEngineHookNotImplemented) && !required {
+		return nil
+	}
+	if err != nil {
+		return model.NewAppError(where, "app.plugin.search_engine.app_error", map[string]any{"PluginId": e.pluginID}, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func (e *pluginSearchEngine) Start() *model.AppError { return nil }
+func (e *pluginSearchEngine) Stop() *model.AppError  { return nil }
+
+func (e *pluginSearchEngine) GetFullVersion() string {
+	pluginsEnvironment := e.ch.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return ""
+	}
+	manifest, err := pluginsEnvironment.GetManifest(e.pluginID)
+	if err != nil {
+		return ""
+	}
+	return manifest.Version
+}
+
+func (e *pluginSearchEngine) GetVersion() int                { return 0 }
+func (e *pluginSearchEngine) GetPlugins() []string           { return []string{e.pluginID} }
+func (e *pluginSearchEngine) UpdateConfig(cfg *model.Config) {}
+func (e *pluginSearchEngine) GetName() string                { return pluginSearchEngineName }
+func (e *pluginSearchEngine) IsEnabled() bool                { return true }
+
+func (e *pluginSearchEngine) IsActive() bool {
+	pluginsEnvironment := e.ch.GetPluginsEnvironment()
+	return pluginsEnvironment != nil && pluginsEnvironment.IsActive(e.pluginID)
+}
+
+func (e *pluginSearchEngine) IsIndexingEnabled() bool       { return true }
+func (e *pluginSearchEngine) IsSearchEnabled() bool         { return true }
+func (e *pluginSearchEngine) IsAutocompletionEnabled() bool { return true }
+func (e *pluginSearchEngine) IsIndexingSync() bool          { return false }
+
+func (e *pluginSearchEngine) IndexPost(post *model.Post, teamId string) *model.AppError {
+	return e.run("pluginSearchEngine.IndexPost", false, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineIndexPost(&plugin.Context{}, post.ForPlugin(), teamId)
+	})
+}
+
+func (e *pluginSearchEngine) SearchPosts(channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
+	var postIDs []string
+	var matches model.PostSearchMatches
+	appErr := e.run("pluginSearchEngine.SearchPosts", true, func(hooks plugin.Hooks) error {
+		var err error
+		postIDs, matches, err = hooks.SearchEngineSearchPosts(&plugin.Context{}, channels, searchParams, page, perPage)
+		return err
+	})
+	return postIDs, matches, appErr
+}
+
+func (e *pluginSearchEngine) DeletePost(post *model.Post) *model.AppError {
+	return e.run("pluginSearchEngine.DeletePost", false, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineDeletePost(&plugin.Context{}, post.ForPlugin())
+	})
+}
+
+// The bulk deletions are required, so that the jobs relying on them report an error when the
+// plugin doesn't implement them instead of leaving the deleted documents in its index.
+
+func (e *pluginSearchEngine) DeleteChannelPosts(rctx request.CTX, channelID string) *model.AppError {
+	return e.run("pluginSearchEngine.DeleteChannelPosts", true, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineDeleteChannelPosts(pluginContext(rctx), channelID)
+	})
+}
+
+func (e *pluginSearchEngine) DeleteUserPosts(rctx request.CTX, userID string) *model.AppError {
+	return e.run("pluginSearchEngine.DeleteUserPosts", true, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineDeleteUserPosts(pluginContext(rctx), userID)
+	})
+}
+
+func (e *pluginSearchEngine) IndexChannel(rctx request.CTX, channel *model.Channel, userIDs, teamMemberIDs []string) *model.AppError {
+	return e.run("pluginSearchEngine.IndexChannel", false, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineIndexChannel(pluginContext(rctx), channel, userIDs, teamMemberIDs)
+	})
+}
+
+func (e *pluginSearchEngine) SyncBulkIndexChannels(rctx request.CTX, channels []*model.Channel, getUserIDsForChannel func(channel *model.Channel) ([]string, error), teamMemberIDs []string) *model.AppError {
+	for _, channel := range channels {
+		userIDs, err := getUserIDsForChannel(channel)
+		if err != nil {
+			return model.NewAppError("pluginSearchEngine.SyncBulkIndexChannels", "app.plugin.search_engine.app_error", map[string]any{"PluginId": e.pluginID}, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if appErr := e.IndexChannel(rctx, channel, userIDs, teamMemberIDs); appErr != nil {
+			return appErr
+		}
+	}
+	return nil
+}
+
+func (e *pluginSearchEngine) SearchChannels(teamId, userID, term string, isGuest, includeDeleted bool) ([]string, *model.AppError) {
+	var channelIDs []string
+	appErr := e.run("pluginSearchEngine.SearchChannels", true, func(hooks plugin.Hooks) error {
+		var err error
+		channelIDs, err = hooks.SearchEngineSearchChannels(&plugin.Context{}, teamId, userID, term, isGuest, includeDeleted)
+		return err
+	})
+	return channelIDs, appErr
+}
+
+func (e *pluginSearchEngine) DeleteChannel(channel *model.Channel) *model.AppError {
+	return e.run("pluginSearchEngine.DeleteChannel", false, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineDeleteChannel(&plugin.Context{}, channel)
+	})
+}
+
+func (e *pluginSearchEngine) IndexUser(rctx request.CTX, user *model.User, teamsIds, channelsIds []string) *model.AppError {
+	return e.run("pluginSearchEngine.IndexUser", false, func(hooks plugin.Hooks) error {
+		sanitizedUser := user.DeepCopy()
+		sanitizedUser.Sanitize(map[string]bool{})
+		return hooks.SearchEngineIndexUser(pluginContext(rctx), sanitizedUser, teamsIds, channelsIds)
+	})
+}
+
+func (e *pluginSearchEngine) SearchUsersInChannel(teamId, channelId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, *model.AppError) {
+	var inChannelIDs, notInChannelIDs []string
+	appErr := e.run("pluginSearchEngine.SearchUsersInChannel", true, func(hooks plugin.Hooks) error {
+		var err error
+		inChannelIDs, notInChannelIDs, err = hooks.SearchEngineSearchUsersInChannel(&plugin.Context{}, teamId, channelId, restrictedToChannels, term, options)
+		return err
+	})
+	return inChannelIDs, notInChannelIDs, appErr
+}
+
+func (e *pluginSearchEngine) SearchUsersInTeam(teamId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, *model.AppError) {
+	var userIDs []string
+	appErr := e.run("pluginSearchEngine.SearchUsersInTeam", true, func(hooks plugin.Hooks) error {
+		var err error
+		userIDs, err = hooks.SearchEngineSearchUsersInTeam(&plugin.Context{}, teamId, restrictedToChannels, term, options)
+		return err
+	})
+	return userIDs, appErr
+}
+
+func (e *pluginSearchEngine) DeleteUser(user *model.User) *model.AppError {
+	return e.run("pluginSearchEngine.DeleteUser", false, func(hooks plugin.Hooks) error {
+		sanitizedUser := user.DeepCopy()
+		sanitizedUser.Sanitize(map[string]bool{})
+		return hooks.SearchEngineDeleteUser(&plugin.Context{}, sanitizedUser)
+	})
+}
+
+func (e *pluginSearchEngine) IndexFile(file *model.FileInfo, channelId string) *model.AppError {
+	return e.run("pluginSearchEngine.IndexFile", false, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineIndexFile(&plugin.Context{}, file, channelId)
+	})
+}
+
+func (e *pluginSearchEngine) SearchFiles(channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, *model.AppError) {
+	var fileIDs []string
+	appErr := e.run("pluginSearchEngine.SearchFiles", true, func(hooks plugin.Hooks) error {
+		var err error
+		fileIDs, err = hooks.SearchEngineSearchFiles(&plugin.Context{}, channels, searchParams, page, perPage)
+		return err
+	})
+	return fileIDs, appErr
+}
+
+func (e *pluginSearchEngine) DeleteFile(fileID string) *model.AppError {
+	return e.run("pluginSearchEngine.DeleteFile", false, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineDeleteFile(&plugin.Context{}, fileID)
+	})
+}
+
+func (e *pluginSearchEngine) DeletePostFiles(rctx request.CTX, postID string) *model.AppError {
+	return e.run("pluginSearchEngine.DeletePostFiles", true, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineDeletePostFiles(pluginContext(rctx), postID)
+	})
+}
+
+func (e *pluginSearchEngine) DeleteUserFiles(rctx request.CTX, userID string) *model.AppError {
+	return e.run("pluginSearchEngine.DeleteUserFiles", true, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineDeleteUserFiles(pluginContext(rctx), userID)
+	})
+}
+
+func (e *pluginSearchEngine) DeleteFilesBatch(rctx request.CTX, endTime, limit int64) *model.AppError {
+	return e.run("pluginSearchEngine.DeleteFilesBatch", true, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineDeleteFilesBefore(pluginContext(rctx), endTime, limit)
+	})
+}
+
+func (e *pluginSearchEngine) TestConfig(rctx request.CTX, cfg *model.Config) *model.AppError {
+	return nil
+}
+
+func (e *pluginSearchEngine) PurgeIndexes(rctx request.CTX) *model.AppError {
+	return e.run("pluginSearchEngine.PurgeIndexes", true, func(hooks plugin.Hooks) error {
+		return hooks.SearchEnginePurgeIndexes(pluginContext(rctx))
+	})
+}
+
+func (e *pluginSearchEngine) PurgeIndexList(rctx request.CTX, indexes []string) *model.AppError {
+	return model.NewAppError("pluginSearchEngine.PurgeIndexList", "app.plugin.search_engine.purge_index_list.app_error", nil, "", http.StatusNotImplemented)
+}
+
+func (e *pluginSearchEngine) RefreshIndexes(rctx request.CTX) *model.AppError {
+	return nil
+}
+
+func (e *pluginSearchEngine) DataRetentionDeleteIndexes(rctx request.CTX, cutoff time.Time) *model.AppError {
+	return e.run("pluginSearchEngine.DataRetentionDeleteIndexes", true, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineDeletePostsBefore(pluginContext(rctx), model.GetMillisForTime(cutoff))
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestPluginSearchEngine(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	pluginCode := fmt.Sprintf(`
+		package main
+
+		import (
+			"errors"
+
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) OnActivate() error {
+			if appErr := p.API.RegisterSearchEngine(); appErr != nil {
+				return appErr
+			}
+			return nil
+		}
+
+		func (p *MyPlugin) SearchEngineIndexChannel(c *plugin.Context, channel *model.Channel, userIDs, teamMemberIDs []string) error {
+			return nil
+		}
+
+		func (p *MyPlugin) SearchEngineDeleteChannelPosts(c *plugin.Context, channelID string) error {
+			return nil
+		}
+
+		func (p *MyPlugin) SearchEngineSearchChannels(c *plugin.Context, teamID, userID, term string, isGuest, includeDeleted bool) ([]string, error) {
+			return []string{%q}, nil
+		}
+
+		func (p *MyPlugin) SearchEngineSearchUsersInTeam(c *plugin.Context, teamID string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, error) {
+			return nil, errors.New("search service unavailable")
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`, th.BasicChannel.Id)
+	pluginID := "testplugin"
+	pluginManifest := `{"id": "testplugin", "server": {"executable": "backend.exe"}}`
+	setupPluginAPITest(t, pluginCode, pluginManifest, pluginID, th.App, th.Context)
+
+	engine := th.App.SearchEngine().PluginEngine()
+	require.NotNil(t, engine)
+	assert.Equal(t, pluginSearchEngineName, th.App.ActiveSearchBackend())
+
+	t.Run("searches are routed to the plugin", func(t *testing.T) {
+		channelIDs, appErr := engine.SearchChannels(th.BasicTeam.Id, th.BasicUser.Id, "term", false, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{th.BasicChannel.Id}, channelIDs)
+	})
+
+	t.Run("search errors are returned to fall back to the database", func(t *testing.T) {
+		_, appErr := engine.SearchUsersInTeam(th.BasicTeam.Id, nil, "term", &model.UserSearchOptions{})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.search_engine.app_error", appErr.Id)
+
+		_, _, appErr = engine.SearchPosts(model.ChannelList{th.BasicChannel}, []*model.SearchParams{{Terms: "term"}}, 0, 10)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.search_engine.app_error", appErr.Id)
+	})
+
+	t.Run("indexing hooks the plugin doesn't implement are ignored", func(t *testing.T) {
+		assert.Nil(t, engine.IndexChannel(th.Context, th.BasicChannel, nil, nil))
+		assert.Nil(t, engine.IndexPost(th.BasicPost, th.BasicTeam.Id))
+	})
+
+	t.Run("bulk deletions the plugin doesn't implement are reported", func(t *testing.T) {
+		assert.Nil(t, engine.DeleteChannelPosts(th.Context, th.BasicChannel.Id))
+
+		appErr := engine.DeleteUserPosts(th.Context, th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.search_engine.app_error", appErr.Id)
+
+		appErr = engine.DataRetentionDeleteIndexes(th.Context, time.Now())
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.search_engine.app_error", appErr.Id)
+	})
+
+	t.Run("another plugin can't register while the plugin is active", func(t *testing.T) {
+		appErr := th.App.RegisterPluginSearchEngine("otherplugin")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+	})
+
+	t.Run("the database is used once the plugin is disabled", func(t *testing.T) {
+		require.Nil(t, th.App.DisablePlugin(pluginID))
+		assert.False(t, engine.IsActive())
+		assert.Equal(t, "database", th.App.ActiveSearchBackend())
+
+		require.Nil(t, th.App.RegisterPluginSearchEngine("otherplugin"))
+		require.Nil(t, th.App.UnregisterPluginSearchEngine("otherplugin"))
+		assert.Nil(t, th.App.SearchEngine().PluginEngine())
+	})
+}
@@ -27,6 +27,7 @@ func (ch *Channels) GetPluginStatus(id string) (*model.PluginStatus, *model.AppE
 			if ch.srv.platform.Cluster() != nil {
 				status.ClusterId = ch.srv.platform.Cluster().GetClusterId()
 			}
+			ch.setPluginPermissionsStatus(status)
 
 			return status, nil
 		}
@@ -59,6 +60,7 @@ func (ch *Channels) GetPluginStatuses() (model.PluginStatuses, *model.AppError)
 		} else {
 			status.ClusterId = ""
 		}
+		ch.setPluginPermissionsStatus(status)
 	}
 
 	return pluginStatuses, nil
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
@@ -77,6 +78,8 @@ func (a *App) saveAcknowledgementForPostWithPost(rctx request.CTX, post *model.P
 	// Trigger post updated event to ensure shared channel sync
 	a.sendPostUpdateEvent(rctx, post)
 
+	a.runMessageHasBeenAcknowledgedHook(rctx, post, savedAck)
+
 	return savedAck, nil
 }
 
@@ -233,6 +236,8 @@ func (a *App) SaveAcknowledgementsForPost(rctx request.CTX, postID string, userI
 	// Trigger post updated event to ensure shared channel sync
 	a.sendPostUpdateEvent(rctx, post)
 
+	a.runMessageHasBeenAcknowledgedHook(rctx, post, savedAcks...)
+
 	return savedAcks, nil
 }
 
@@ -248,6 +253,20 @@ func (a *App) sendAcknowledgementEvent(rctx request.CTX, event model.WebsocketEv
 	a.Publish(message)
 }
 
+// runMessageHasBeenAcknowledgedHook notifies plugins of the acknowledgements of a post.
+func (a *App) runMessageHasBeenAcknowledgedHook(rctx request.CTX, post *model.Post, acknowledgements ...*model.PostAcknowledgement) {
+	pluginPost := post.ForPlugin()
+	a.Srv().Go(func() {
+		pluginContext := pluginContext(rctx)
+		for _, acknowledgement := range acknowledgements {
+			a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
+				hooks.MessageHasBeenAcknowledged(pluginContext, pluginPost, acknowledgement)
+				return true
+			}, plugin.MessageHasBeenAcknowledgedID)
+		}
+	})
+}
+
 func (a *App) SaveAcknowledgementForPostWithModel(rctx request.CTX, acknowledgement *model.PostAcknowledgement) (*model.PostAcknowledgement, *model.AppError) {
 	// Get the post to verify it exists and get the channel
 	post, err := a.GetSinglePost(rctx, acknowledgement.PostId, false)
@@ -300,6 +319,8 @@ func (a *App) SaveAcknowledgementForPostWithModel(rctx request.CTX, acknowledgem
 	// Trigger post updated event to ensure shared channel sync
 	a.sendPostUpdateEvent(rctx, post)
 
+	a.runMessageHasBeenAcknowledgedHook(rctx, post, savedAck)
+
 	return savedAck, nil
 }
 
@@ -77,7 +77,7 @@ func (a *App) UpdatePreferences(rctx request.CTX, userID string, preferences mod
 
 	pluginContext := pluginContext(rctx)
 	a.Srv().Go(func() {
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.PreferencesHaveChanged(pluginContext, preferences)
 			return true
 		}, plugin.PreferencesHaveChangedID)
@@ -19,32 +19,85 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 )
 
+// gcraRateLimiter limits the rate in the distributed store when there is one, falling back to
+// the local store when it fails, so that the rate limiting keeps working on each node when
+// Redis is unavailable.
+type gcraRateLimiter struct {
+	distributed *throttled.GCRARateLimiter
+	local       *throttled.GCRARateLimiter
+}
+
+func newGCRARateLimiter(distributedStore, localStore throttled.GCRAStore, perSec, maxBurst int) (*gcraRateLimiter, error) {
+	quota := throttled.RateQuota{
+		MaxRate:  throttled.PerSec(perSec),
+		MaxBurst: maxBurst,
+	}
+
+	local, err := throttled.NewGCRARateLimiter(localStore, quota)
+	if err != nil {
+		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_rate_limiter"))
+	}
+
+	limiter := &gcraRateLimiter{local: local}
+	if distributedStore != nil {
+		if limiter.distributed, err = throttled.NewGCRARateLimiter(distributedStore, quota); err != nil {
+			return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_rate_limiter"))
+		}
+	}
+	return limiter, nil
+}
+
+func (l *gcraRateLimiter) RateLimit(key string) (bool, throttled.RateLimitResult, error) {
+	if l.distributed != nil {
+		limited, result, err := l.distributed.RateLimit(key, 1)
+		if err == nil {
+			return limited, result, nil
+		}
+		mlog.Debug("Failed to rate limit in the distributed store, falling back to the local store.", mlog.Err(err))
+	}
+	return l.local.RateLimit(key, 1)
+}
+
 type RateLimiter struct {
-	throttledRateLimiter *throttled.GCRARateLimiter
+	throttledRateLimiter *gcraRateLimiter
+	// policyRateLimiters are the rate limiters of the route groups, by route group and
+	// principal.
+	policyRateLimiters   map[string]*gcraRateLimiter
 	useAuth              bool
 	useIP                bool
 	header               string
 	trustedProxyIPHeader []string
 }
 
+// NewRateLimiter returns a rate limiter keeping the limits in memory, for this node only.
 func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []string) (*RateLimiter, error) {
+	return NewDistributedRateLimiter(settings, trustedProxyIPHeader, nil)
+}
+
+// NewDistributedRateLimiter returns a rate limiter keeping the limits in the distributed store,
+// shared by all the nodes, and falling back to memory when the store fails or is nil.
+func NewDistributedRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []string, distributedStore throttled.GCRAStore) (*RateLimiter, error) {
 	store, err := memstore.New(*settings.MemoryStoreSize)
 	if err != nil {
 		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_memory_store"))
 	}
 
-	quota := throttled.RateQuota{
-		MaxRate:  throttled.PerSec(*settings.PerSec),
-		MaxBurst: *settings.MaxBurst,
+	throttledRateLimiter, err := newGCRARateLimiter(distributedStore, store, *settings.PerSec, *settings.MaxBurst)
+	if err != nil {
+		return nil, err
 	}
 
-	throttledRateLimiter, err := throttled.NewGCRARateLimiter(store, quota)
-	if err != nil {
-		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_rate_limiter"))
+	policyRateLimiters := make(map[string]*gcraRateLimiter, len(settings.Policies))
+	for _, policy := range settings.Policies {
+		policyRateLimiters[*policy.RouteGroup+":"+*policy.Principal], err = newGCRARateLimiter(distributedStore, store, *policy.PerSec, *policy.MaxBurst)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &RateLimiter{
 		throttledRateLimiter: throttledRateLimiter,
+		policyRateLimiters:   policyRateLimiters,
 		useAuth:              *settings.VaryByUser,
 		useIP:                *settings.VaryByRemoteAddr,
 		header:               settings.VaryByHeader,
@@ -76,7 +129,11 @@ func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 }
 
 func (rl *RateLimiter) RateLimitWriter(key string, w http.ResponseWriter) bool {
-	limited, context, err := rl.throttledRateLimiter.RateLimit(key, 1)
+	return rl.rateLimitWriter(rl.throttledRateLimiter, key, w)
+}
+
+func (rl *RateLimiter) rateLimitWriter(limiter *gcraRateLimiter, key string, w http.ResponseWriter) bool {
+	limited, context, err := limiter.RateLimit(key)
 	if err != nil {
 		mlog.Error("Internal server error when rate limiting. Rate Limiting broken.", mlog.Err(err))
 		return false
@@ -99,6 +156,35 @@ func (rl *RateLimiter) UserIdRateLimit(userID string, w http.ResponseWriter) boo
 	return false
 }
 
+// RateLimitPrincipal returns the principal type the requests of the session are limited by, and
+// its identifier. The requests without a session are limited by IP address.
+func (rl *RateLimiter) RateLimitPrincipal(session *model.Session, r *http.Request) (string, string) {
+	switch {
+	case session == nil || session.UserId == "":
+		return model.RateLimitPrincipalIP, utils.GetIPAddress(r, rl.trustedProxyIPHeader)
+	case session.IsBotUser():
+		return model.RateLimitPrincipalBot, session.UserId
+	case session.IsUserAccessToken():
+		if tokenID := session.Props[model.SessionPropUserAccessTokenId]; tokenID != "" {
+			return model.RateLimitPrincipalToken, tokenID
+		}
+		return model.RateLimitPrincipalToken, session.UserId
+	default:
+		return model.RateLimitPrincipalUser, session.UserId
+	}
+}
+
+// RouteRateLimit limits the requests of the principal to the routes of the group, if there is a
+// policy for them, and returns whether the request was denied.
+func (rl *RateLimiter) RouteRateLimit(routeGroup string, session *model.Session, w http.ResponseWriter, r *http.Request) bool {
+	principal, principalID := rl.RateLimitPrincipal(session, r)
+	limiter, ok := rl.policyRateLimiters[routeGroup+":"+principal]
+	if !ok {
+		return false
+	}
+	return rl.rateLimitWriter(limiter, "policy:"+routeGroup+":"+principal+":"+principalID, w)
+}
+
 func (rl *RateLimiter) RateLimitHandler(wrappedHandler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		key := rl.GenerateKey(r)
@@ -109,23 +195,32 @@ func (rl *RateLimiter) RateLimitHandler(wrappedHandler http.Handler) http.Handle
 	})
 }
 
-// Copied from https://github.com/throttled/throttled http.go
+// Copied from https://github.com/throttled/throttled http.go, with the RateLimit-* headers of
+// the IETF draft added. When several rate limits apply to a request, the headers describe the one
+// denying it, or else the one with the fewest remaining requests.
 func setRateLimitHeaders(w http.ResponseWriter, context throttled.RateLimitResult) {
+	if remaining, err := strconv.Atoi(w.Header().Get("RateLimit-Remaining")); err == nil && context.RetryAfter < 0 && remaining <= context.Remaining {
+		return
+	}
+
 	if v := context.Limit; v >= 0 {
-		w.Header().Add("X-RateLimit-Limit", strconv.Itoa(v))
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(v))
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(v))
 	}
 
 	if v := context.Remaining; v >= 0 {
-		w.Header().Add("X-RateLimit-Remaining", strconv.Itoa(v))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(v))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(v))
 	}
 
 	if v := context.ResetAfter; v >= 0 {
 		vi := int(math.Ceil(v.Seconds()))
-		w.Header().Add("X-RateLimit-Reset", strconv.Itoa(vi))
+		w.Header().Set("X-RateLimit-Reset", strconv.Itoa(vi))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(vi))
 	}
 
 	if v := context.RetryAfter; v >= 0 {
 		vi := int(math.Ceil(v.Seconds()))
-		w.Header().Add("Retry-After", strconv.Itoa(vi))
+		w.Header().Set("Retry-After", strconv.Itoa(vi))
 	}
 }
@@ -4,12 +4,15 @@
 package app
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled"
 
 	"github.com/mattermost/mattermost/server/public/model"
 )
@@ -107,3 +110,124 @@ func TestGenerateKey_TrustedHeader(t *testing.T) {
 	key = rateLimiter.GenerateKey(req)
 	require.Equal(t, "10.10.10.5", key, "Wrong key on test without allowed trusted proxy header")
 }
+
+// failingGCRAStore stands in for a distributed store which is unavailable.
+type failingGCRAStore struct{}
+
+func (failingGCRAStore) GetWithTime(key string) (int64, time.Time, error) {
+	return 0, time.Time{}, errors.New("unavailable")
+}
+
+func (failingGCRAStore) SetIfNotExistsWithTTL(key string, value int64, ttl time.Duration) (bool, error) {
+	return false, errors.New("unavailable")
+}
+
+func (failingGCRAStore) CompareAndSwapWithTTL(key string, old, new int64, ttl time.Duration) (bool, error) {
+	return false, errors.New("unavailable")
+}
+
+func TestDistributedRateLimiterFallback(t *testing.T) {
+	mainHelper.Parallel(t)
+	settings := genRateLimitSettings(false, true, "")
+	settings.MaxBurst = model.NewPointer(1)
+
+	rateLimiter, err := NewDistributedRateLimiter(settings, nil, failingGCRAStore{})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	require.False(t, rateLimiter.RateLimitWriter("key", w))
+	require.False(t, rateLimiter.RateLimitWriter("key", httptest.NewRecorder()))
+
+	w = httptest.NewRecorder()
+	require.True(t, rateLimiter.RateLimitWriter("key", w), "the local store must limit the requests")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+func TestRateLimitPrincipal(t *testing.T) {
+	mainHelper.Parallel(t)
+	rateLimiter, err := NewRateLimiter(genRateLimitSettings(false, true, ""), nil)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "10.10.10.5:80"
+	userID := model.NewId()
+	tokenID := model.NewId()
+
+	cases := []struct {
+		name              string
+		session           *model.Session
+		expectedPrincipal string
+		expectedID        string
+	}{
+		{"no session", nil, model.RateLimitPrincipalIP, "10.10.10.5"},
+		{"empty session", &model.Session{}, model.RateLimitPrincipalIP, "10.10.10.5"},
+		{"user", &model.Session{UserId: userID}, model.RateLimitPrincipalUser, userID},
+		{"bot", &model.Session{UserId: userID, Props: model.StringMap{model.SessionPropIsBot: model.SessionPropIsBotValue}}, model.RateLimitPrincipalBot, userID},
+		{"token", &model.Session{UserId: userID, Props: model.StringMap{model.SessionPropType: model.SessionTypeUserAccessToken, model.SessionPropUserAccessTokenId: tokenID}}, model.RateLimitPrincipalToken, tokenID},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			principal, id := rateLimiter.RateLimitPrincipal(tc.session, req)
+			require.Equal(t, tc.expectedPrincipal, principal)
+			require.Equal(t, tc.expectedID, id)
+		})
+	}
+}
+
+func TestRouteRateLimit(t *testing.T) {
+	mainHelper.Parallel(t)
+	settings := genRateLimitSettings(false, true, "")
+	settings.Policies = []*model.RateLimitPolicy{
+		{RouteGroup: model.NewPointer(model.RateLimitRouteGroupSearch), Principal: model.NewPointer(model.RateLimitPrincipalUser), PerSec: model.NewPointer(1), MaxBurst: model.NewPointer(1)},
+	}
+	settings.SetDefaults()
+	rateLimiter, err := NewRateLimiter(settings, nil)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/", nil)
+	session := &model.Session{UserId: model.NewId()}
+
+	t.Run("limits the principal of the policy", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		require.False(t, rateLimiter.RouteRateLimit(model.RateLimitRouteGroupSearch, session, w, req))
+		require.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+		require.False(t, rateLimiter.RouteRateLimit(model.RateLimitRouteGroupSearch, session, httptest.NewRecorder(), req))
+
+		w = httptest.NewRecorder()
+		require.True(t, rateLimiter.RouteRateLimit(model.RateLimitRouteGroupSearch, session, w, req))
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		require.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+		require.NotEmpty(t, w.Header().Get("Retry-After"))
+
+		require.False(t, rateLimiter.RouteRateLimit(model.RateLimitRouteGroupSearch, &model.Session{UserId: model.NewId()}, httptest.NewRecorder(), req))
+	})
+
+	t.Run("doesn't limit without a policy", func(t *testing.T) {
+		for range 5 {
+			w := httptest.NewRecorder()
+			require.False(t, rateLimiter.RouteRateLimit(model.RateLimitRouteGroupPostCreate, session, w, req))
+			require.Empty(t, w.Header().Get("RateLimit-Limit"))
+		}
+	})
+}
+
+func TestSetRateLimitHeaders(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	w := httptest.NewRecorder()
+	setRateLimitHeaders(w, throttled.RateLimitResult{Limit: 100, Remaining: 50, ResetAfter: time.Second, RetryAfter: -1})
+	require.Equal(t, "100", w.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "50", w.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "1", w.Header().Get("RateLimit-Reset"))
+	require.Equal(t, "50", w.Header().Get("X-RateLimit-Remaining"))
+	require.Empty(t, w.Header().Get("Retry-After"))
+
+	// The rate limit with the fewest remaining requests is described.
+	setRateLimitHeaders(w, throttled.RateLimitResult{Limit: 10, Remaining: 60, ResetAfter: time.Second, RetryAfter: -1})
+	require.Equal(t, "100", w.Header().Get("RateLimit-Limit"))
+	setRateLimitHeaders(w, throttled.RateLimitResult{Limit: 10, Remaining: 5, ResetAfter: time.Second, RetryAfter: -1})
+	require.Equal(t, "10", w.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "5", w.Header().Get("RateLimit-Remaining"))
+	require.Len(t, w.Header().Values("X-RateLimit-Limit"), 1)
+}
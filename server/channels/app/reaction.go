@@ -22,9 +22,13 @@ func (a *App) SaveReactionForPost(rctx request.CTX, reaction *model.Reaction) (*
 
 	// Check whether this is a valid emoji
 	if _, ok := model.GetSystemEmojiId(reaction.EmojiName); !ok {
-		if _, emojiErr := a.GetEmojiByName(rctx, reaction.EmojiName); emojiErr != nil {
+		emoji, emojiErr := a.GetEmojiByName(rctx, reaction.EmojiName)
+		if emojiErr != nil {
 			return nil, emojiErr
 		}
+
+		// Reactions using an alias are stored under the name of the emoji.
+		reaction.EmojiName = emoji.Name
 	}
 
 	existing, dErr := a.Srv().Store().Reaction().ExistsOnPost(reaction.PostId, reaction.EmojiName)
@@ -94,7 +98,7 @@ func (a *App) SaveReactionForPost(rctx request.CTX, reaction *model.Reaction) (*
 
 	pluginContext := pluginContext(rctx)
 	a.Srv().Go(func() {
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.ReactionHasBeenAdded(pluginContext, reaction)
 			return true
 		}, plugin.ReactionHasBeenAddedID)
@@ -175,7 +179,7 @@ func (a *App) DeleteReactionForPost(rctx request.CTX, reaction *model.Reaction)
 
 	pluginContext := pluginContext(rctx)
 	a.Srv().Go(func() {
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.ReactionHasBeenRemoved(pluginContext, reaction)
 			return true
 		}, plugin.ReactionHasBeenRemovedID)
@@ -0,0 +1,908 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+func (a *App) scimBaseURL() string {
+	return a.GetSiteURL() + model.ScimBasePath
+}
+
+func scimTime(millis int64) string {
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
+
+// scimUsername maps a SCIM userName to a username. Identity providers commonly use the email
+// address as userName, of which the local part is used.
+func scimUsername(logger mlog.LoggerIFace, userName string) string {
+	username := strings.ToLower(strings.TrimSpace(userName))
+	if model.IsValidUsername(username) {
+		return username
+	}
+
+	if local, _, found := strings.Cut(username, "@"); found {
+		username = local
+	}
+	return model.CleanUsername(logger, username)
+}
+
+// scimPatchError converts the errors of parsing a filter or applying PATCH operations.
+func scimPatchError(where string, err error) *model.AppError {
+	id := "app.scim.invalid_value.app_error"
+	switch {
+	case errors.Is(err, model.ErrScimInvalidFilter):
+		id = "app.scim.invalid_filter.app_error"
+	case errors.Is(err, model.ErrScimInvalidPath):
+		id = "app.scim.invalid_path.app_error"
+	}
+	return model.NewAppError(where, id, nil, "", http.StatusBadRequest).Wrap(err)
+}
+
+// scimUserError reports the conflicts on username and email as such.
+func scimUserError(where string, appErr *model.AppError) *model.AppError {
+	switch appErr.Id {
+	case "app.user.save.username_exists.app_error", "app.user.save.email_exists.app_error":
+		return model.NewAppError(where, "app.scim.user_exists.app_error", nil, "", http.StatusConflict).Wrap(appErr)
+	}
+	return appErr
+}
+
+func isScimNotFound(appErr *model.AppError) bool {
+	// GetUserByAuth doesn't report a missing account as not found.
+	return appErr.StatusCode == http.StatusNotFound || appErr.Id == MissingAuthAccountError
+}
+
+// scimPage returns count items from the 1-based startIndex, fetching them with pages of count
+// items.
+func scimPage[T any](startIndex, count int, getPage func(page, perPage int) ([]T, *model.AppError)) ([]T, *model.AppError) {
+	if count <= 0 {
+		return nil, nil
+	}
+
+	offset := (startIndex - 1) % count
+	page := (startIndex - 1) / count
+
+	items, appErr := getPage(page, count)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if offset > 0 && len(items) == count {
+		next, appErr := getPage(page+1, count)
+		if appErr != nil {
+			return nil, appErr
+		}
+		items = append(items, next...)
+	}
+
+	return items[min(offset, len(items)):min(offset+count, len(items))], nil
+}
+
+// getScimFields returns the custom profile attributes mapped to SCIM attributes.
+func (a *App) getScimFields() ([]*model.CPAField, *model.AppError) {
+	fields, appErr := a.ListCPAFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return slices.DeleteFunc(fields, func(field *model.CPAField) bool { return field.Attrs.SCIM == "" }), nil
+}
+
+// getScimFieldValues returns the values of the mapped attributes of the given users by user and
+// field.
+func (a *App) getScimFieldValues(userIDs []string, fields []*model.CPAField) (map[string]map[string]string, *model.AppError) {
+	result := make(map[string]map[string]string, len(userIDs))
+	if len(fields) == 0 || len(userIDs) == 0 {
+		return result, nil
+	}
+
+	groupID, err := a.CpaGroupID()
+	if err != nil {
+		return nil, model.NewAppError("getScimFieldValues", "app.custom_profile_attributes.cpa_group_id.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	values, err := a.Srv().propertyService.SearchPropertyValues(groupID, model.PropertyValueSearchOpts{
+		TargetIDs: userIDs,
+		PerPage:   CustomProfileAttributesFieldLimit * len(userIDs),
+	})
+	if err != nil {
+		return nil, model.NewAppError("getScimFieldValues", "app.custom_profile_attributes.list_property_values.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, value := range values {
+		var text string
+		if json.Unmarshal(value.Value, &text) != nil {
+			continue
+		}
+		if result[value.TargetID] == nil {
+			result[value.TargetID] = map[string]string{}
+		}
+		result[value.TargetID][value.FieldID] = text
+	}
+
+	return result, nil
+}
+
+// userToScim converts a user, its SCIM groups and the values of the mapped attributes to a SCIM
+// resource. Groups are left out when nil.
+func (a *App) userToScim(rctx request.CTX, user *model.User, groups []*model.Group, fields []*model.CPAField, fieldValues map[string]string) *model.ScimUser {
+	baseURL := a.scimBaseURL()
+
+	scimUser := &model.ScimUser{
+		Schemas:     []string{model.ScimSchemaUser},
+		Id:          user.Id,
+		UserName:    user.Username,
+		DisplayName: user.GetDisplayName(model.ShowFullName),
+		NickName:    user.Nickname,
+		Title:       user.Position,
+		Locale:      user.Locale,
+		Active:      model.NewPointer(user.DeleteAt == 0),
+		Emails:      []model.ScimMultiValue{{Value: user.Email, Type: "work", Primary: true}},
+		Meta: &model.ScimMeta{
+			ResourceType: model.ScimResourceTypeUser,
+			Created:      scimTime(user.CreateAt),
+			LastModified: scimTime(user.UpdateAt),
+			Location:     baseURL + "/Users/" + user.Id,
+		},
+	}
+
+	if user.FirstName != "" || user.LastName != "" {
+		scimUser.Name = &model.ScimName{
+			Formatted:  user.GetFullName(),
+			GivenName:  user.FirstName,
+			FamilyName: user.LastName,
+		}
+	}
+
+	if service := *a.Config().ScimSettings.AuthService; service != "" && user.AuthService == service {
+		scimUser.ExternalId = model.SafeDereference(user.AuthData)
+	}
+
+	for _, group := range groups {
+		scimUser.Groups = append(scimUser.Groups, model.ScimMultiValue{
+			Value:   group.Id,
+			Display: group.DisplayName,
+			Ref:     baseURL + "/Groups/" + group.Id,
+		})
+	}
+
+	var operations []model.ScimPatchOperation
+	for _, field := range fields {
+		if value, ok := fieldValues[field.ID]; ok && value != "" {
+			operations = append(operations, model.ScimPatchOperation{Op: model.ScimPatchOpReplace, Path: field.Attrs.SCIM, Value: value})
+		}
+	}
+	if len(operations) > 0 {
+		withAttributes := *scimUser
+		if err := model.ApplyScimPatch(&withAttributes, operations); err != nil {
+			rctx.Logger().Warn("Failed to map custom profile attributes to SCIM attributes", mlog.String("user_id", user.Id), mlog.Err(err))
+		} else {
+			scimUser = &withAttributes
+		}
+	}
+
+	if scimUser.EnterpriseUser != nil {
+		scimUser.Schemas = append(scimUser.Schemas, model.ScimSchemaEnterpriseUser)
+	}
+
+	return scimUser
+}
+
+func (a *App) getScimUserGroups(userID string) ([]*model.Group, *model.AppError) {
+	groups, appErr := a.GetGroupsByUserId(userID, model.GroupSearchOpts{})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return slices.DeleteFunc(groups, func(group *model.Group) bool { return group.Source != model.GroupSourceScim }), nil
+}
+
+// getScimUserModel returns the user, bots and users of remote clusters not being provisioned.
+func (a *App) getScimUserModel(userID string) (*model.User, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		if isScimNotFound(appErr) {
+			return nil, model.NewAppError("getScimUserModel", "app.scim.user_not_found.app_error", nil, "user_id="+userID, http.StatusNotFound).Wrap(appErr)
+		}
+		return nil, appErr
+	}
+
+	if user.IsBot || user.IsRemote() {
+		return nil, model.NewAppError("getScimUserModel", "app.scim.user_not_found.app_error", nil, "user_id="+userID, http.StatusNotFound)
+	}
+
+	return user, nil
+}
+
+// getScimWritableUserModel is getScimUserModel for the users being modified. System admins can't
+// be modified through SCIM, so that the SCIM token can't be used to take over their accounts.
+func (a *App) getScimWritableUserModel(userID string) (*model.User, *model.AppError) {
+	user, appErr := a.getScimUserModel(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if user.IsSystemAdmin() {
+		return nil, model.NewAppError("getScimWritableUserModel", "app.scim.system_admin.app_error", nil, "user_id="+userID, http.StatusForbidden)
+	}
+
+	return user, nil
+}
+
+func (a *App) GetScimUser(rctx request.CTX, userID string) (*model.ScimUser, *model.AppError) {
+	user, appErr := a.getScimUserModel(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.scimUser(rctx, user)
+}
+
+func (a *App) scimUser(rctx request.CTX, user *model.User) (*model.ScimUser, *model.AppError) {
+	groups, appErr := a.getScimUserGroups(user.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	fields, appErr := a.getScimFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	values, appErr := a.getScimFieldValues([]string{user.Id}, fields)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.userToScim(rctx, user, groups, fields, values[user.Id]), nil
+}
+
+// findScimUsers looks up the users matching a filter by the unique attribute it compares, which
+// is how identity providers match their accounts. The filter itself is applied by the caller.
+func (a *App) findScimUsers(rctx request.CTX, filter *model.ScimFilter) ([]*model.User, *model.AppError) {
+	var user *model.User
+	var appErr *model.AppError
+
+	if id, ok := filter.EqualityValue("id"); ok {
+		user, appErr = a.GetUser(id)
+	} else if userName, ok := filter.EqualityValue("userName"); ok {
+		user, appErr = a.GetUserByUsername(scimUsername(rctx.Logger(), userName))
+		if appErr != nil && isScimNotFound(appErr) && strings.Contains(userName, "@") {
+			user, appErr = a.GetUserByEmail(userName)
+		}
+	} else if externalID, ok := filter.EqualityValue("externalId"); ok {
+		service := *a.Config().ScimSettings.AuthService
+		if service == "" {
+			return nil, nil
+		}
+		user, appErr = a.GetUserByAuth(&externalID, service)
+	} else {
+		return nil, model.NewAppError("findScimUsers", "app.scim.unsupported_filter.app_error", map[string]any{"Attributes": "id, userName, externalId"}, "", http.StatusBadRequest)
+	}
+
+	if appErr != nil {
+		if isScimNotFound(appErr) {
+			return nil, nil
+		}
+		return nil, appErr
+	}
+
+	if user.IsBot || user.IsRemote() {
+		return nil, nil
+	}
+	return []*model.User{user}, nil
+}
+
+func (a *App) ListScimUsers(rctx request.CTX, params *model.ScimListParams) (*model.ScimListResponse, *model.AppError) {
+	var users []*model.User
+	var total int64
+
+	if params.Filter != nil {
+		var appErr *model.AppError
+		if users, appErr = a.findScimUsers(rctx, params.Filter); appErr != nil {
+			return nil, appErr
+		}
+	} else {
+		count, err := a.Srv().Store().User().Count(model.UserCountOptions{IncludeDeleted: true})
+		if err != nil {
+			return nil, model.NewAppError("ListScimUsers", "app.user.get_total_users_count.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		total = count
+
+		var appErr *model.AppError
+		users, appErr = scimPage(params.StartIndex, params.Count, func(page, perPage int) ([]*model.User, *model.AppError) {
+			users, err := a.Srv().Store().User().GetAllProfiles(&model.UserGetOptions{
+				Page:          page,
+				PerPage:       perPage,
+				ExcludeBots:   true,
+				ExcludeRemote: true,
+			})
+			if err != nil {
+				return nil, model.NewAppError("ListScimUsers", "app.user.get_profiles.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			return users, nil
+		})
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	fields, appErr := a.getScimFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.Id)
+	}
+	values, appErr := a.getScimFieldValues(userIDs, fields)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	resources := make([]any, 0, len(users))
+	for _, user := range users {
+		var groups []*model.Group
+		if !params.Excludes("groups") {
+			if groups, appErr = a.getScimUserGroups(user.Id); appErr != nil {
+				return nil, appErr
+			}
+		}
+
+		scimUser := a.userToScim(rctx, user, groups, fields, values[user.Id])
+		if params.Filter != nil && !params.Filter.Matches(scimUser) && !matchesScimUserName(params.Filter, user) {
+			continue
+		}
+		resources = append(resources, scimUser)
+	}
+
+	if params.Filter != nil {
+		total = int64(len(resources))
+	}
+
+	return model.NewScimListResponse(resources, total, params.StartIndex), nil
+}
+
+// matchesScimUserName accepts the users found by email for a userName filter, the userName the
+// identity provider knows them by not being their username.
+func matchesScimUserName(filter *model.ScimFilter, user *model.User) bool {
+	userName, ok := filter.EqualityValue("userName")
+	return ok && len(filter.Conditions) == 1 && strings.EqualFold(userName, user.Email)
+}
+
+// scimToUser applies the attributes of a SCIM resource to a user.
+func scimToUser(rctx request.CTX, scimUser *model.ScimUser, user *model.User) *model.AppError {
+	username := scimUsername(rctx.Logger(), scimUser.UserName)
+	if !model.IsValidUsername(username) {
+		return model.NewAppError("scimToUser", "app.scim.invalid_username.app_error", map[string]any{"UserName": scimUser.UserName}, "", http.StatusBadRequest)
+	}
+
+	email := model.NormalizeEmail(scimUser.PrimaryEmail())
+	if email == "" {
+		return model.NewAppError("scimToUser", "app.scim.missing_email.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	user.Username = username
+	user.Email = email
+	user.FirstName = ""
+	user.LastName = ""
+	if scimUser.Name != nil {
+		user.FirstName = scimUser.Name.GivenName
+		user.LastName = scimUser.Name.FamilyName
+	}
+	user.Nickname = scimUser.NickName
+	user.Position = scimUser.Title
+
+	return nil
+}
+
+// syncScimFieldValues stores the mapped attributes of a SCIM resource as custom profile
+// attribute values. Failures are logged rather than failing the provisioning.
+func (a *App) syncScimFieldValues(rctx request.CTX, userID string, scimUser *model.ScimUser, fields []*model.CPAField) {
+	if len(fields) == 0 {
+		return
+	}
+
+	fieldValueMap := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		value, err := model.ScimAttributeString(scimUser, field.Attrs.SCIM)
+		if err != nil {
+			rctx.Logger().Warn("Invalid SCIM attribute mapped to a custom profile attribute", mlog.String("field_id", field.ID), mlog.String("attribute", field.Attrs.SCIM), mlog.Err(err))
+			continue
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		fieldValueMap[field.ID] = data
+	}
+
+	if _, appErr := a.PatchCPAValues(userID, fieldValueMap, true); appErr != nil {
+		rctx.Logger().Warn("Failed to store the custom profile attributes of a SCIM user", mlog.String("user_id", userID), mlog.Err(appErr))
+	}
+}
+
+// CreateScimUser provisions a user. Users sign in through the configured authentication service,
+// with the externalId as their AuthData, or with email and password otherwise.
+func (a *App) CreateScimUser(rctx request.CTX, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	user := &model.User{EmailVerified: true}
+	if appErr := scimToUser(rctx, scimUser, user); appErr != nil {
+		return nil, appErr
+	}
+
+	if service := *a.Config().ScimSettings.AuthService; service != "" {
+		authData := scimUser.ExternalId
+		if authData == "" {
+			authData = scimUser.UserName
+		}
+		user.AuthService = service
+		user.AuthData = &authData
+	} else {
+		user.Password = scimUser.Password
+		if user.Password == "" {
+			password, err := generatePassword(*a.Config().PasswordSettings.MinimumLength)
+			if err != nil {
+				return nil, model.NewAppError("CreateScimUser", "app.scim.generate_password.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			user.Password = password
+		}
+	}
+
+	ruser, appErr := a.CreateUser(rctx, user)
+	if appErr != nil {
+		return nil, scimUserError("CreateScimUser", appErr)
+	}
+
+	rctx.Logger().Info("Provisioned user through SCIM", mlog.String("user_id", ruser.Id))
+
+	if scimUser.Active != nil && !*scimUser.Active {
+		if ruser, appErr = a.UpdateActive(rctx, ruser, false); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	fields, appErr := a.getScimFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+	a.syncScimFieldValues(rctx, ruser.Id, scimUser, fields)
+
+	return a.scimUser(rctx, ruser)
+}
+
+// ReplaceScimUser replaces the attributes of a user with those of the resource. The password is
+// left alone.
+func (a *App) ReplaceScimUser(rctx request.CTX, userID string, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	user, appErr := a.getScimWritableUserModel(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	fields, appErr := a.getScimFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.saveScimUser(rctx, user, scimUser, fields)
+}
+
+func (a *App) PatchScimUser(rctx request.CTX, userID string, operations []model.ScimPatchOperation) (*model.ScimUser, *model.AppError) {
+	user, appErr := a.getScimWritableUserModel(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	fields, appErr := a.getScimFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	values, appErr := a.getScimFieldValues([]string{user.Id}, fields)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	scimUser := a.userToScim(rctx, user, nil, fields, values[user.Id])
+	if err := model.ApplyScimPatch(scimUser, operations); err != nil {
+		return nil, scimPatchError("PatchScimUser", err)
+	}
+
+	return a.saveScimUser(rctx, user, scimUser, fields)
+}
+
+func (a *App) saveScimUser(rctx request.CTX, user *model.User, scimUser *model.ScimUser, fields []*model.CPAField) (*model.ScimUser, *model.AppError) {
+	updated := user.DeepCopy()
+	if appErr := scimToUser(rctx, scimUser, updated); appErr != nil {
+		return nil, appErr
+	}
+
+	if updated.Username != user.Username || updated.Email != user.Email || updated.FirstName != user.FirstName ||
+		updated.LastName != user.LastName || updated.Nickname != user.Nickname || updated.Position != user.Position {
+		var appErr *model.AppError
+		if user, appErr = a.UpdateUser(rctx, updated, false); appErr != nil {
+			return nil, scimUserError("saveScimUser", appErr)
+		}
+	}
+
+	service := *a.Config().ScimSettings.AuthService
+	if service != "" && user.AuthService == service && scimUser.ExternalId != "" && scimUser.ExternalId != model.SafeDereference(user.AuthData) {
+		if _, appErr := a.UpdateUserAuth(rctx, user.Id, &model.UserAuth{AuthService: service, AuthData: &scimUser.ExternalId}); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if scimUser.Active != nil && *scimUser.Active != (user.DeleteAt == 0) {
+		rctx.Logger().Info("Updating user active status through SCIM", mlog.String("user_id", user.Id), mlog.Bool("active", *scimUser.Active))
+
+		var appErr *model.AppError
+		if user, appErr = a.UpdateActive(rctx, user, *scimUser.Active); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	a.syncScimFieldValues(rctx, user.Id, scimUser, fields)
+
+	return a.GetScimUser(rctx, user.Id)
+}
+
+// DeleteScimUser deprovisions a user by deactivating it, so that its content is kept.
+func (a *App) DeleteScimUser(rctx request.CTX, userID string) *model.AppError {
+	user, appErr := a.getScimWritableUserModel(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if user.DeleteAt != 0 {
+		return nil
+	}
+
+	rctx.Logger().Info("Deprovisioning user through SCIM", mlog.String("user_id", user.Id))
+	_, appErr = a.UpdateActive(rctx, user, false)
+	return appErr
+}
+
+// groupToScim converts a group and its members to a SCIM resource. Members are left out when nil.
+func (a *App) groupToScim(group *model.Group, members []*model.User) *model.ScimGroup {
+	baseURL := a.scimBaseURL()
+
+	scimGroup := &model.ScimGroup{
+		Schemas:     []string{model.ScimSchemaGroup},
+		Id:          group.Id,
+		ExternalId:  group.GetRemoteId(),
+		DisplayName: group.DisplayName,
+		Meta: &model.ScimMeta{
+			ResourceType: model.ScimResourceTypeGroup,
+			Created:      scimTime(group.CreateAt),
+			LastModified: scimTime(group.UpdateAt),
+			Location:     baseURL + "/Groups/" + group.Id,
+		},
+	}
+
+	for _, member := range members {
+		scimGroup.Members = append(scimGroup.Members, model.ScimMultiValue{
+			Value:   member.Id,
+			Display: member.Username,
+			Ref:     baseURL + "/Users/" + member.Id,
+		})
+	}
+
+	return scimGroup
+}
+
+// getScimGroupModel returns the group, groups of other sources not being provisioned.
+func (a *App) getScimGroupModel(groupID string) (*model.Group, *model.AppError) {
+	group, appErr := a.GetGroup(groupID, nil, nil)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return nil, model.NewAppError("getScimGroupModel", "app.scim.group_not_found.app_error", nil, "group_id="+groupID, http.StatusNotFound).Wrap(appErr)
+		}
+		return nil, appErr
+	}
+
+	if group.Source != model.GroupSourceScim || group.DeleteAt != 0 {
+		return nil, model.NewAppError("getScimGroupModel", "app.scim.group_not_found.app_error", nil, "group_id="+groupID, http.StatusNotFound)
+	}
+
+	return group, nil
+}
+
+func (a *App) scimGroup(group *model.Group, excludeMembers bool) (*model.ScimGroup, *model.AppError) {
+	var members []*model.User
+	if !excludeMembers {
+		var appErr *model.AppError
+		if members, appErr = a.GetGroupMemberUsers(group.Id); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return a.groupToScim(group, members), nil
+}
+
+func (a *App) GetScimGroup(rctx request.CTX, groupID string, excludeMembers bool) (*model.ScimGroup, *model.AppError) {
+	group, appErr := a.getScimGroupModel(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.scimGroup(group, excludeMembers)
+}
+
+// findScimGroups looks up the groups matching a filter by the attribute it compares for equality.
+// The filter itself is applied by the caller.
+func (a *App) findScimGroups(filter *model.ScimFilter) ([]*model.Group, *model.AppError) {
+	if id, ok := filter.EqualityValue("id"); ok {
+		group, appErr := a.getScimGroupModel(id)
+		if appErr != nil {
+			if appErr.StatusCode == http.StatusNotFound {
+				return nil, nil
+			}
+			return nil, appErr
+		}
+		return []*model.Group{group}, nil
+	}
+
+	if externalID, ok := filter.EqualityValue("externalId"); ok {
+		group, appErr := a.GetGroupByRemoteID(externalID, model.GroupSourceScim)
+		if appErr != nil {
+			if appErr.StatusCode == http.StatusNotFound {
+				return nil, nil
+			}
+			return nil, appErr
+		}
+		return []*model.Group{group}, nil
+	}
+
+	if displayName, ok := filter.EqualityValue("displayName"); ok {
+		return a.GetGroups(0, model.ScimMaxCount, model.GroupSearchOpts{Q: displayName, Source: model.GroupSourceScim}, nil)
+	}
+
+	return nil, model.NewAppError("findScimGroups", "app.scim.unsupported_filter.app_error", map[string]any{"Attributes": "id, externalId, displayName"}, "", http.StatusBadRequest)
+}
+
+func (a *App) ListScimGroups(rctx request.CTX, params *model.ScimListParams) (*model.ScimListResponse, *model.AppError) {
+	excludeMembers := params.Excludes("members")
+
+	if params.Filter != nil {
+		groups, appErr := a.findScimGroups(params.Filter)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		// Entra checks memberships with filters such as members[value eq "id"].
+		loadMembers := !excludeMembers || params.Filter.References("members")
+
+		resources := make([]any, 0, len(groups))
+		for _, group := range groups {
+			if group.DeleteAt != 0 {
+				continue
+			}
+
+			scimGroup, appErr := a.scimGroup(group, !loadMembers)
+			if appErr != nil {
+				return nil, appErr
+			}
+			if !params.Filter.Matches(scimGroup) {
+				continue
+			}
+			if excludeMembers {
+				scimGroup.Members = nil
+			}
+			resources = append(resources, scimGroup)
+		}
+
+		return model.NewScimListResponse(resources, int64(len(resources)), params.StartIndex), nil
+	}
+
+	total, err := a.Srv().Store().Group().GroupCountBySource(model.GroupSourceScim)
+	if err != nil {
+		return nil, model.NewAppError("ListScimGroups", "app.select_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	groups, appErr := scimPage(params.StartIndex, params.Count, func(page, perPage int) ([]*model.Group, *model.AppError) {
+		return a.GetGroups(page, perPage, model.GroupSearchOpts{Source: model.GroupSourceScim}, nil)
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	resources := make([]any, 0, len(groups))
+	for _, group := range groups {
+		scimGroup, appErr := a.scimGroup(group, excludeMembers)
+		if appErr != nil {
+			return nil, appErr
+		}
+		resources = append(resources, scimGroup)
+	}
+
+	return model.NewScimListResponse(resources, total, params.StartIndex), nil
+}
+
+func (a *App) CreateScimGroup(rctx request.CTX, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	group := &model.Group{
+		DisplayName: scimGroup.DisplayName,
+		Source:      model.GroupSourceScim,
+	}
+
+	if scimGroup.ExternalId != "" {
+		if existing, appErr := a.GetGroupByRemoteID(scimGroup.ExternalId, model.GroupSourceScim); appErr == nil && existing.DeleteAt == 0 {
+			return nil, model.NewAppError("CreateScimGroup", "app.scim.group_exists.app_error", nil, "group_id="+existing.Id, http.StatusConflict)
+		}
+		group.RemoteId = model.NewPointer(scimGroup.ExternalId)
+	}
+
+	group, appErr := a.CreateGroup(group)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	rctx.Logger().Info("Provisioned group through SCIM", mlog.String("group_id", group.Id))
+
+	if appErr := a.setScimGroupMembers(rctx, group.Id, scimMemberIDs(scimGroup.Members)); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.scimGroup(group, false)
+}
+
+// ReplaceScimGroup replaces the display name and the members of a group. The externalId is kept
+// if none is given.
+func (a *App) ReplaceScimGroup(rctx request.CTX, groupID string, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	group, appErr := a.getScimGroupModel(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if group, appErr = a.updateScimGroup(group, scimGroup); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.setScimGroupMembers(rctx, group.Id, scimMemberIDs(scimGroup.Members)); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.scimGroup(group, false)
+}
+
+// PatchScimGroup applies PATCH operations to a group. Changes to the members are applied to the
+// memberships directly, without listing the members of large groups.
+func (a *App) PatchScimGroup(rctx request.CTX, groupID string, operations []model.ScimPatchOperation) *model.AppError {
+	group, appErr := a.getScimGroupModel(groupID)
+	if appErr != nil {
+		return appErr
+	}
+
+	membersPatch, rest, err := model.ExtractScimMembersPatch(operations)
+	if err != nil {
+		return scimPatchError("PatchScimGroup", err)
+	}
+
+	if len(rest) > 0 {
+		scimGroup := a.groupToScim(group, nil)
+		if err := model.ApplyScimPatch(scimGroup, rest); err != nil {
+			return scimPatchError("PatchScimGroup", err)
+		}
+
+		if group, appErr = a.updateScimGroup(group, scimGroup); appErr != nil {
+			return appErr
+		}
+	}
+
+	if membersPatch.Replace {
+		return a.setScimGroupMembers(rctx, group.Id, membersPatch.Set)
+	}
+
+	return a.updateScimGroupMembers(rctx, group.Id, membersPatch.Add, membersPatch.Remove)
+}
+
+func (a *App) updateScimGroup(group *model.Group, scimGroup *model.ScimGroup) (*model.Group, *model.AppError) {
+	remoteID := group.GetRemoteId()
+	if scimGroup.ExternalId != "" {
+		remoteID = scimGroup.ExternalId
+	}
+
+	if group.DisplayName == scimGroup.DisplayName && group.GetRemoteId() == remoteID {
+		return group, nil
+	}
+
+	group.DisplayName = scimGroup.DisplayName
+	if remoteID != "" {
+		group.RemoteId = model.NewPointer(remoteID)
+	}
+
+	return a.UpdateGroup(group)
+}
+
+// DeleteScimGroup archives a group. Its members are kept in the teams and channels it is linked to.
+func (a *App) DeleteScimGroup(rctx request.CTX, groupID string) *model.AppError {
+	if _, appErr := a.getScimGroupModel(groupID); appErr != nil {
+		return appErr
+	}
+
+	rctx.Logger().Info("Deprovisioning group through SCIM", mlog.String("group_id", groupID))
+	_, appErr := a.DeleteGroup(groupID)
+	return appErr
+}
+
+func scimMemberIDs(members []model.ScimMultiValue) []string {
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		if !slices.Contains(ids, member.Value) {
+			ids = append(ids, member.Value)
+		}
+	}
+	return ids
+}
+
+// setScimGroupMembers makes the given users the members of the group.
+func (a *App) setScimGroupMembers(rctx request.CTX, groupID string, userIDs []string) *model.AppError {
+	members, appErr := a.GetGroupMemberUsers(groupID)
+	if appErr != nil {
+		return appErr
+	}
+
+	var remove []string
+	for _, member := range members {
+		if !slices.Contains(userIDs, member.Id) {
+			remove = append(remove, member.Id)
+		}
+	}
+
+	add := slices.DeleteFunc(slices.Clone(userIDs), func(userID string) bool {
+		return slices.ContainsFunc(members, func(member *model.User) bool { return member.Id == userID })
+	})
+
+	return a.updateScimGroupMembers(rctx, groupID, add, remove)
+}
+
+func (a *App) updateScimGroupMembers(rctx request.CTX, groupID string, add, remove []string) *model.AppError {
+	since := model.GetMillis()
+
+	if len(add) > 0 {
+		users, appErr := a.GetUsers(rctx, add)
+		if appErr != nil {
+			return appErr
+		}
+		for _, userID := range add {
+			if !slices.ContainsFunc(users, func(user *model.User) bool { return user.Id == userID && !user.IsBot && !user.IsRemote() }) {
+				return model.NewAppError("updateScimGroupMembers", "app.scim.invalid_member.app_error", map[string]any{"UserId": userID}, "", http.StatusBadRequest)
+			}
+		}
+
+		if _, appErr := a.UpsertGroupMembers(groupID, add); appErr != nil {
+			return appErr
+		}
+		a.syncScimGroupSyncables(rctx, groupID, since, false)
+	}
+
+	if len(remove) > 0 {
+		if _, appErr := a.DeleteGroupMembers(groupID, remove); appErr != nil {
+			return appErr
+		}
+		a.syncScimGroupSyncables(rctx, groupID, since, true)
+	}
+
+	return nil
+}
+
+// syncScimGroupSyncables applies a membership change to the teams and channels linked to the
+// group. Failures are logged rather than failing the provisioning.
+func (a *App) syncScimGroupSyncables(rctx request.CTX, groupID string, since int64, memberRemoved bool) {
+	if appErr := a.syncAttributeGroupSyncables(rctx, groupID, since, memberRemoved); appErr != nil {
+		appErr = model.NewAppError("syncScimGroupSyncables", "app.scim.group_syncables.app_error", nil, "", http.StatusInternalServerError).Wrap(appErr)
+		rctx.Logger().Warn("Failed to sync teams and channels of SCIM group", mlog.String("group_id", groupID), mlog.Err(appErr))
+	}
+}
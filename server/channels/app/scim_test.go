@@ -0,0 +1,273 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func newTestScimUser(userName string) *model.ScimUser {
+	return &model.ScimUser{
+		Schemas:  []string{model.ScimSchemaUser},
+		UserName: userName,
+		Name:     &model.ScimName{GivenName: "Jane", FamilyName: "Doe"},
+		Emails:   []model.ScimMultiValue{{Value: userName, Primary: true}},
+		Active:   model.NewPointer(true),
+	}
+}
+
+func TestScimPage(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	getPage := func(page, perPage int) ([]int, *model.AppError) {
+		start := min(page*perPage, len(items))
+		return items[start:min(start+perPage, len(items))], nil
+	}
+
+	for name, tc := range map[string]struct {
+		startIndex, count int
+		expected          []int
+	}{
+		"first page":      {1, 3, []int{1, 2, 3}},
+		"aligned page":    {4, 3, []int{4, 5, 6}},
+		"misaligned page": {3, 3, []int{3, 4, 5}},
+		"last items":      {6, 3, []int{6, 7}},
+		"past the end":    {10, 3, []int{}},
+		"no items":        {1, 0, nil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			page, appErr := scimPage(tc.startIndex, tc.count, getPage)
+			require.Nil(t, appErr)
+			assert.Equal(t, tc.expected, page)
+		})
+	}
+}
+
+func TestScimUsers(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	t.Run("create, get and deprovision a user", func(t *testing.T) {
+		scimUser, appErr := th.App.CreateScimUser(th.Context, newTestScimUser("jane.doe@example.com"))
+		require.Nil(t, appErr)
+		assert.Equal(t, "jane.doe@example.com", scimUser.UserName)
+		assert.True(t, *scimUser.Active)
+
+		user, appErr := th.App.GetUser(scimUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "jane.doe@example.com", user.Email)
+		assert.Equal(t, "Jane", user.FirstName)
+		assert.True(t, user.EmailVerified)
+
+		list, appErr := th.App.ListScimUsers(th.Context, &model.ScimListParams{
+			Filter:     mustParseScimFilter(t, `userName eq "jane.doe@example.com"`),
+			StartIndex: 1,
+			Count:      model.ScimDefaultCount,
+		})
+		require.Nil(t, appErr)
+		require.Len(t, list.Resources, 1)
+
+		appErr = th.App.DeleteScimUser(th.Context, scimUser.Id)
+		require.Nil(t, appErr)
+
+		user, appErr = th.App.GetUser(scimUser.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, user.DeleteAt)
+	})
+
+	t.Run("conflicting user", func(t *testing.T) {
+		_, appErr := th.App.CreateScimUser(th.Context, newTestScimUser(th.BasicUser.Username))
+		require.NotNil(t, appErr)
+
+		scimUser := newTestScimUser(th.BasicUser.Username)
+		scimUser.Emails[0].Value = th.BasicUser.Email
+		_, appErr = th.App.CreateScimUser(th.Context, scimUser)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scim.user_exists.app_error", appErr.Id)
+		assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+	})
+
+	t.Run("patch a user", func(t *testing.T) {
+		scimUser, appErr := th.App.CreateScimUser(th.Context, newTestScimUser("john.smith@example.com"))
+		require.Nil(t, appErr)
+
+		scimUser, appErr = th.App.PatchScimUser(th.Context, scimUser.Id, []model.ScimPatchOperation{
+			{Op: "Replace", Path: "title", Value: "Engineer"},
+			{Op: "Replace", Path: "active", Value: "False"},
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, "Engineer", scimUser.Title)
+		assert.False(t, *scimUser.Active)
+
+		_, appErr = th.App.PatchScimUser(th.Context, scimUser.Id, []model.ScimPatchOperation{{Op: "replace", Path: "emails[type eq", Value: "x"}})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scim.invalid_path.app_error", appErr.Id)
+	})
+
+	t.Run("system admins can't be modified", func(t *testing.T) {
+		scimUser, appErr := th.App.GetScimUser(th.Context, th.SystemAdminUser.Id)
+		require.Nil(t, appErr)
+		scimUser.Emails[0].Value = "attacker@example.com"
+
+		_, appErr = th.App.ReplaceScimUser(th.Context, th.SystemAdminUser.Id, scimUser)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scim.system_admin.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+
+		_, appErr = th.App.PatchScimUser(th.Context, th.SystemAdminUser.Id, []model.ScimPatchOperation{{Op: "Replace", Path: "active", Value: "False"}})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scim.system_admin.app_error", appErr.Id)
+
+		appErr = th.App.DeleteScimUser(th.Context, th.SystemAdminUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scim.system_admin.app_error", appErr.Id)
+
+		user, appErr := th.App.GetUser(th.SystemAdminUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, th.SystemAdminUser.Email, user.Email)
+		assert.Zero(t, user.DeleteAt)
+	})
+
+	t.Run("bots are hidden", func(t *testing.T) {
+		bot := th.CreateBot(t)
+
+		_, appErr := th.App.GetScimUser(th.Context, bot.UserId)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scim.user_not_found.app_error", appErr.Id)
+	})
+
+	t.Run("unsupported filter", func(t *testing.T) {
+		_, appErr := th.App.ListScimUsers(th.Context, &model.ScimListParams{
+			Filter:     mustParseScimFilter(t, `title eq "Engineer"`),
+			StartIndex: 1,
+			Count:      model.ScimDefaultCount,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scim.unsupported_filter.app_error", appErr.Id)
+	})
+}
+
+func TestScimUserAuthService(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.ScimSettings.AuthService = model.NewPointer(model.UserAuthServiceSaml)
+	})
+
+	scimUser := newTestScimUser("sso.user@example.com")
+	scimUser.ExternalId = "00u1"
+	scimUser, appErr := th.App.CreateScimUser(th.Context, scimUser)
+	require.Nil(t, appErr)
+	assert.Equal(t, "00u1", scimUser.ExternalId)
+
+	user, appErr := th.App.GetUser(scimUser.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, model.UserAuthServiceSaml, user.AuthService)
+	assert.Equal(t, "00u1", *user.AuthData)
+
+	list, appErr := th.App.ListScimUsers(th.Context, &model.ScimListParams{
+		Filter:     mustParseScimFilter(t, `externalId eq "00u1"`),
+		StartIndex: 1,
+		Count:      model.ScimDefaultCount,
+	})
+	require.Nil(t, appErr)
+	require.Len(t, list.Resources, 1)
+
+	list, appErr = th.App.ListScimUsers(th.Context, &model.ScimListParams{
+		Filter:     mustParseScimFilter(t, `externalId eq "unknown"`),
+		StartIndex: 1,
+		Count:      model.ScimDefaultCount,
+	})
+	require.Nil(t, appErr)
+	assert.Empty(t, list.Resources)
+}
+
+func TestScimGroups(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	scimGroup, appErr := th.App.CreateScimGroup(th.Context, &model.ScimGroup{
+		DisplayName: "Engineering",
+		ExternalId:  "grp1",
+		Members:     []model.ScimMultiValue{{Value: th.BasicUser.Id}},
+	})
+	require.Nil(t, appErr)
+	require.Len(t, scimGroup.Members, 1)
+
+	t.Run("duplicate external id", func(t *testing.T) {
+		_, appErr := th.App.CreateScimGroup(th.Context, &model.ScimGroup{DisplayName: "Other", ExternalId: "grp1"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scim.group_exists.app_error", appErr.Id)
+	})
+
+	t.Run("patch members", func(t *testing.T) {
+		appErr := th.App.PatchScimGroup(th.Context, scimGroup.Id, []model.ScimPatchOperation{
+			{Op: "add", Path: "members", Value: []any{map[string]any{"value": th.BasicUser2.Id}}},
+			{Op: "remove", Path: `members[value eq "` + th.BasicUser.Id + `"]`},
+			{Op: "replace", Path: "displayName", Value: "Platform"},
+		})
+		require.Nil(t, appErr)
+
+		group, appErr := th.App.GetScimGroup(th.Context, scimGroup.Id, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, "Platform", group.DisplayName)
+		require.Len(t, group.Members, 1)
+		assert.Equal(t, th.BasicUser2.Id, group.Members[0].Value)
+	})
+
+	t.Run("invalid member", func(t *testing.T) {
+		appErr := th.App.PatchScimGroup(th.Context, scimGroup.Id, []model.ScimPatchOperation{
+			{Op: "add", Path: "members", Value: []any{map[string]any{"value": model.NewId()}}},
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scim.invalid_member.app_error", appErr.Id)
+	})
+
+	t.Run("list groups", func(t *testing.T) {
+		list, appErr := th.App.ListScimGroups(th.Context, &model.ScimListParams{
+			Filter:             mustParseScimFilter(t, `externalId eq "grp1"`),
+			StartIndex:         1,
+			Count:              model.ScimDefaultCount,
+			ExcludedAttributes: []string{"members"},
+		})
+		require.Nil(t, appErr)
+		require.Len(t, list.Resources, 1)
+		assert.Empty(t, list.Resources[0].(*model.ScimGroup).Members)
+
+		list, appErr = th.App.ListScimGroups(th.Context, &model.ScimListParams{StartIndex: 1, Count: model.ScimDefaultCount})
+		require.Nil(t, appErr)
+		assert.EqualValues(t, 1, list.TotalResults)
+	})
+
+	t.Run("groups of other sources are hidden", func(t *testing.T) {
+		group := th.CreateGroup(t)
+
+		_, appErr := th.App.GetScimGroup(th.Context, group.Id, true)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scim.group_not_found.app_error", appErr.Id)
+	})
+
+	t.Run("delete group", func(t *testing.T) {
+		appErr := th.App.DeleteScimGroup(th.Context, scimGroup.Id)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetScimGroup(th.Context, scimGroup.Id, true)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
+
+func mustParseScimFilter(t *testing.T, filter string) *model.ScimFilter {
+	t.Helper()
+
+	parsed, err := model.ParseScimFilter(filter)
+	require.NoError(t, err)
+	return parsed
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/rs/cors"
+	"github.com/throttled/throttled"
 	"golang.org/x/crypto/acme/autocert"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -40,6 +41,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/audit"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/active_users"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/attribute_group_sync"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/cleanup_desktop_tokens"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_dms_preferences_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_empty_drafts_migration"
@@ -49,6 +51,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_process"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_users_to_csv"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/extract_content"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/guest_access_expiry"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/hosted_purchase_screening"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/import_delete"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/import_process"
@@ -57,6 +60,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/migrations"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/mobile_session_metadata"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/notify_admin"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugin_search_indexing"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugins"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/post_persistent_notifications"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/product_notices"
@@ -69,7 +73,9 @@ import (
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
 	"github.com/mattermost/mattermost/server/v8/platform/services/awsmeter"
 	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+	"github.com/mattermost/mattermost/server/v8/platform/services/matrixbridge"
 	"github.com/mattermost/mattermost/server/v8/platform/services/remotecluster"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
 	"github.com/mattermost/mattermost/server/v8/platform/services/sharedchannel"
 	"github.com/mattermost/mattermost/server/v8/platform/services/telemetry"
 	"github.com/mattermost/mattermost/server/v8/platform/services/upgrader"
@@ -133,6 +139,7 @@ type Server struct {
 	serviceMux           sync.RWMutex
 	remoteClusterService remotecluster.RemoteClusterServiceIFace
 	sharedChannelService SharedChannelServiceIFace // TODO: platform: move to platform package
+	matrixBridge         *matrixbridge.Bridge
 
 	phase2PermissionsMigrationComplete bool
 
@@ -349,6 +356,7 @@ func NewServer(options ...Option) (*Server, error) {
 	emailService, err := email.NewService(email.ServiceConfig{
 		ConfigFn:           s.platform.Config,
 		LicenseFn:          s.License,
+		ConfigFileFn:       s.platform.GetConfigFile,
 		TemplatesContainer: s.TemplatesContainer(),
 		UserService:        s.userService,
 		Store:              s.GetStore(),
@@ -390,9 +398,12 @@ func NewServer(options ...Option) (*Server, error) {
 		mlog.Error("SiteURL must be set. Some features will operate incorrectly if the SiteURL is not set. See documentation for details: https://mattermost.com/pl/configure-site-url")
 	}
 
-	// Start email batching because it's not like the other jobs
+	// Start email batching and the outbound queue because they're not like the other jobs
 	s.platform.AddConfigListener(func(_, _ *model.Config) {
 		s.EmailService.InitEmailBatching()
+		s.EmailService.InitOutboundEmailQueue()
+		// Template overrides may have been replaced by another node.
+		s.EmailService.ResetTemplateOverrides()
 	})
 
 	pwd, _ := os.Getwd()
@@ -599,6 +610,18 @@ func (s *Server) startInterClusterServices(license *model.License) error {
 	s.sharedChannelService = scs
 	s.serviceMux.Unlock()
 
+	// Matrix bridge (depends on shared channels service)
+	if *s.platform.Config().ConnectedWorkspacesSettings.EnableMatrixBridge {
+		if err = s.startMatrixBridge(scs, appInstance); err != nil {
+			mlog.Error("Failed to start the Matrix bridge", mlog.Err(err))
+		}
+	}
+	s.platform.AddConfigListener(func(oldCfg, newCfg *model.Config) {
+		if matrixBridgeSettingsChanged(oldCfg, newCfg) {
+			s.restartMatrixBridge(scs, appInstance)
+		}
+	})
+
 	return nil
 }
 
@@ -675,6 +698,10 @@ func (s *Server) Shutdown() {
 		s.Log().Warn("Failed to stop metrics server", mlog.Err(err))
 	}
 
+	if err = s.platform.ShutdownTracing(); err != nil {
+		s.Log().Warn("Failed to stop tracing", mlog.Err(err))
+	}
+
 	// Stopping email service after HTTP server has stopped to prevent
 	// any stray notifications from being queued.
 	s.EmailService.Stop()
@@ -878,7 +905,13 @@ func (s *Server) Start() error {
 	if *s.platform.Config().RateLimitSettings.Enable {
 		mlog.Info("RateLimiter is enabled")
 
-		rateLimiter, err2 := NewRateLimiter(&s.platform.Config().RateLimitSettings, s.platform.Config().ServiceSettings.TrustedProxyIPHeader)
+		// With Redis, the rate limits are shared by the nodes instead of multiplying with them.
+		var distributedStore throttled.GCRAStore
+		if redisStore := cache.NewRedisRateLimitStore(s.platform.CacheProvider()); redisStore != nil {
+			distributedStore = redisStore
+		}
+
+		rateLimiter, err2 := NewDistributedRateLimiter(&s.platform.Config().RateLimitSettings, s.platform.Config().ServiceSettings.TrustedProxyIPHeader, distributedStore)
 		if err2 != nil {
 			return err2
 		}
@@ -1477,6 +1510,12 @@ func (s *Server) initJobs() {
 		expirynotify.MakeScheduler(s.Jobs),
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeGuestAccessExpiry,
+		guest_access_expiry.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())).ProcessGuestAccessExpiries),
+		guest_access_expiry.MakeScheduler(s.Jobs),
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeProductNotices,
 		product_notices.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
@@ -1611,6 +1650,18 @@ func (s *Server) initJobs() {
 		delete_dms_preferences_migration.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
 		nil)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeAttributeGroupSync,
+		attribute_group_sync.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		attribute_group_sync.MakeScheduler(s.Jobs, func() *model.License { return s.License() }),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypePluginSearchIndexing,
+		plugin_search_indexing.MakeWorker(s.Jobs, s.Store(), func() searchengine.SearchEngineInterface { return s.platform.SearchEngine.PluginEngine() }),
+		nil,
+	)
+
 	s.platform.Jobs = s.Jobs
 }
 
@@ -22,10 +22,6 @@ import (
 const maxSessionsLimit = 500
 
 func (a *App) CreateSession(rctx request.CTX, session *model.Session) (*model.Session, *model.AppError) {
-	if appErr := a.limitNumberOfSessions(rctx, session.UserId); appErr != nil {
-		return nil, appErr
-	}
-
 	// remote/synthetic users cannot create sessions. This lookup will already be cached.
 	// Some unit tests rely on sessions being created for users that don't exist, therefore
 	// missing users are allowed.
@@ -37,6 +33,16 @@ func (a *App) CreateSession(rctx request.CTX, session *model.Session) (*model.Se
 		return nil, model.NewAppError("login", "api.user.login.remote_users.login.error", nil, "", http.StatusUnauthorized)
 	}
 
+	if user != nil {
+		if appErr := a.enforceSessionPolicy(rctx, user, session); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if appErr := a.limitNumberOfSessions(rctx, session.UserId); appErr != nil {
+		return nil, appErr
+	}
+
 	session, err := a.ch.srv.platform.CreateSession(rctx, session)
 	if err != nil {
 		var invErr *store.ErrInvalidInput
@@ -113,11 +119,13 @@ func (a *App) GetSession(token string) (*model.Session, *model.AppError) {
 		return nil, model.NewAppError("GetSession", "api.context.invalid_token.error", map[string]any{"Token": token, "Error": ""}, "session is either nil or expired", http.StatusUnauthorized)
 	}
 
-	if *a.Config().ServiceSettings.SessionIdleTimeoutInMinutes > 0 &&
+	// The idle timeout of a session policy is enforced even when the sessions are extended with activity,
+	// which otherwise supersedes the global one.
+	if idleTimeout, fromPolicy := a.sessionIdleTimeoutInMinutes(session); idleTimeout > 0 &&
 		!session.IsOAuth && !session.IsMobileApp() &&
 		session.Props[model.SessionPropType] != model.SessionTypeUserAccessToken &&
-		!*a.Config().ServiceSettings.ExtendSessionLengthWithActivity {
-		timeout := int64(*a.Config().ServiceSettings.SessionIdleTimeoutInMinutes) * 1000 * 60
+		(fromPolicy || !*a.Config().ServiceSettings.ExtendSessionLengthWithActivity) {
+		timeout := int64(idleTimeout) * 1000 * 60
 		if (model.GetMillis() - session.LastActivityAt) > timeout {
 			// Revoking the session is an asynchronous task anyways since we are not checking
 			// for the return value of the call before returning the error.
@@ -0,0 +1,212 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const sessionPolicyErrorPrefix = "app.session.policy."
+
+// GetSessionPolicyForUser returns the first session policy matching the roles or the groups of
+// the user, nil if session policies are disabled or none matches.
+func (a *App) GetSessionPolicyForUser(user *model.User) (*model.SessionPolicy, *model.AppError) {
+	settings := a.Config().SessionPolicySettings
+	if !*settings.Enable || len(settings.Policies) == 0 {
+		return nil, nil
+	}
+
+	var groupIDs map[string]bool
+	for _, policy := range settings.Policies {
+		for _, role := range user.GetRoles() {
+			if slices.Contains(policy.Roles, role) {
+				return policy, nil
+			}
+		}
+
+		if len(policy.GroupIds) == 0 {
+			continue
+		}
+
+		// The groups are only fetched when a policy needs them.
+		if groupIDs == nil {
+			groups, appErr := a.GetGroupsByUserId(user.Id, model.GroupSearchOpts{})
+			if appErr != nil {
+				return nil, appErr
+			}
+			groupIDs = make(map[string]bool, len(groups))
+			for _, group := range groups {
+				groupIDs[group.Id] = true
+			}
+		}
+
+		for _, groupID := range policy.GroupIds {
+			if groupIDs[groupID] {
+				return policy, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// enforceSessionPolicy applies the session policy of the user to a session about to be created.
+// When a limit is reached, either the least recently used sessions are revoked or the session is
+// denied, depending on the policy.
+func (a *App) enforceSessionPolicy(rctx request.CTX, user *model.User, session *model.Session) *model.AppError {
+	// Policies only restrict interactive logins.
+	if user.IsBot || session.IsOAuth || session.Props[model.SessionPropType] != "" {
+		return nil
+	}
+
+	policy, appErr := a.GetSessionPolicyForUser(user)
+	if appErr != nil {
+		return appErr
+	}
+	if policy == nil {
+		return nil
+	}
+
+	session.AddProp(model.SessionPropPolicyName, *policy.Name)
+	if *policy.IdleTimeoutInMinutes > 0 {
+		session.AddProp(model.SessionPropIdleTimeoutInMinutes, strconv.Itoa(*policy.IdleTimeoutInMinutes))
+	}
+
+	maxSessions := *policy.MaxSessions
+	deviceType := session.DeviceType()
+	maxDeviceSessions := policy.MaxSessionsForDeviceType(deviceType)
+	if maxSessions == 0 && maxDeviceSessions == 0 {
+		return nil
+	}
+
+	sessions, appErr := a.GetSessions(rctx, user.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	active := make([]*model.Session, 0, len(sessions))
+	for _, s := range sessions {
+		if s.IsExpired() || s.IsOAuth || s.Props[model.SessionPropType] != "" {
+			continue
+		}
+		active = append(active, s)
+	}
+
+	// Least recently used first.
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].LastActivityAt < active[j].LastActivityAt
+	})
+
+	var toRevoke []*model.Session
+	if maxDeviceSessions > 0 {
+		var deviceSessions []*model.Session
+		for _, s := range active {
+			if s.DeviceType() == deviceType {
+				deviceSessions = append(deviceSessions, s)
+			}
+		}
+
+		if over := len(deviceSessions) - maxDeviceSessions + 1; over > 0 {
+			if *policy.LimitAction == model.SessionPolicyLimitActionDeny {
+				return model.NewAppError("enforceSessionPolicy", sessionPolicyErrorPrefix+"max_device_sessions.app_error", map[string]any{"Max": maxDeviceSessions, "DeviceType": deviceType}, "policy="+*policy.Name, http.StatusForbidden)
+			}
+			toRevoke = append(toRevoke, deviceSessions[:over]...)
+		}
+	}
+
+	if maxSessions > 0 {
+		remaining := make([]*model.Session, 0, len(active))
+		for _, s := range active {
+			if !slices.Contains(toRevoke, s) {
+				remaining = append(remaining, s)
+			}
+		}
+
+		if over := len(remaining) - maxSessions + 1; over > 0 {
+			if *policy.LimitAction == model.SessionPolicyLimitActionDeny {
+				return model.NewAppError("enforceSessionPolicy", sessionPolicyErrorPrefix+"max_sessions.app_error", map[string]any{"Max": maxSessions}, "policy="+*policy.Name, http.StatusForbidden)
+			}
+			toRevoke = append(toRevoke, remaining[:over]...)
+		}
+	}
+
+	for _, s := range toRevoke {
+		if appErr := a.RevokeSession(rctx, s); appErr != nil {
+			return appErr
+		}
+
+		rctx.Logger().Debug("Session revoked; user's number of sessions were over the session policy limit",
+			mlog.String("user_id", user.Id),
+			mlog.String("session_id", s.Id),
+			mlog.String("policy", *policy.Name))
+	}
+
+	return nil
+}
+
+// isSessionPolicyError reports whether the error denies a login because of a session policy.
+func isSessionPolicyError(appErr *model.AppError) bool {
+	return strings.HasPrefix(appErr.Id, sessionPolicyErrorPrefix)
+}
+
+// sessionIdleTimeoutInMinutes returns the idle timeout of the session, which its session policy
+// can override, and whether it comes from the policy.
+func (a *App) sessionIdleTimeoutInMinutes(session *model.Session) (int, bool) {
+	if timeout, err := strconv.Atoi(session.Props[model.SessionPropIdleTimeoutInMinutes]); err == nil && timeout > 0 {
+		return timeout, true
+	}
+	return *a.Config().ServiceSettings.SessionIdleTimeoutInMinutes, false
+}
+
+// UpdateSessionLastIPAddress records the IP address a session was last used from, when it changed.
+// The address is only tracked while session policies are enabled, the login address is always kept.
+func (a *App) UpdateSessionLastIPAddress(rctx request.CTX, session *model.Session, ipAddress string) {
+	if !*a.Config().SessionPolicySettings.Enable {
+		return
+	}
+
+	if session.Id == "" || ipAddress == "" || session.Props[model.SessionPropLastIpAddress] == ipAddress {
+		return
+	}
+
+	updatedSession := session.DeepCopy()
+	updatedSession.AddProp(model.SessionPropLastIpAddress, ipAddress)
+	a.Srv().Go(func() {
+		if err := a.Srv().Store().Session().UpdateProps(updatedSession); err != nil {
+			rctx.Logger().Warn("Failed to update the last IP address of the session", mlog.String("session_id", updatedSession.Id), mlog.Err(err))
+			return
+		}
+
+		if err := a.ch.srv.platform.AddSessionToCache(updatedSession); err != nil {
+			rctx.Logger().Warn("Failed to add session to cache", mlog.String("session_id", updatedSession.Id), mlog.Err(err))
+		}
+	})
+}
+
+// GetSessionDetails returns the sessions of a user, with the device, IP address and user agent
+// they were last used from.
+func (a *App) GetSessionDetails(rctx request.CTX, userID string) ([]*model.SessionDetails, *model.AppError) {
+	sessions, appErr := a.GetSessions(rctx, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	details := make([]*model.SessionDetails, 0, len(sessions))
+	for _, session := range sessions {
+		if session.IsExpired() {
+			continue
+		}
+		details = append(details, session.Details())
+	}
+
+	return details, nil
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSessionPolicies(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	setPolicy := func(policy *model.SessionPolicy) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.SessionPolicySettings.Enable = true
+			cfg.SessionPolicySettings.Policies = []*model.SessionPolicy{policy}
+		})
+	}
+
+	login := func(t *testing.T, user *model.User, userAgent string) (*model.Session, *model.AppError) {
+		t.Helper()
+
+		r := httptest.NewRequest(http.MethodPost, "/api/v4/users/login", nil)
+		r.Header.Set("User-Agent", userAgent)
+		session, appErr := th.App.DoLogin(th.Context, httptest.NewRecorder(), r, user, "", false, false, false)
+		time.Sleep(time.Millisecond)
+		return session, appErr
+	}
+
+	const (
+		browserUserAgent = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+		desktopUserAgent = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Mattermost/5.6.0 Chrome/120.0.0.0 Electron/28.0.0 Safari/537.36"
+	)
+
+	t.Run("newest login wins", func(t *testing.T) {
+		user := th.CreateUser(t)
+		setPolicy(&model.SessionPolicy{
+			Name:        model.NewPointer("Users"),
+			Roles:       []string{model.SystemUserRoleId},
+			MaxSessions: model.NewPointer(2),
+			LimitAction: model.NewPointer(model.SessionPolicyLimitActionRevokeOldest),
+		})
+
+		first, appErr := login(t, user, browserUserAgent)
+		require.Nil(t, appErr)
+		_, appErr = login(t, user, browserUserAgent)
+		require.Nil(t, appErr)
+		_, appErr = login(t, user, browserUserAgent)
+		require.Nil(t, appErr)
+
+		sessions, appErr := th.App.GetSessions(th.Context, user.Id)
+		require.Nil(t, appErr)
+		require.Len(t, sessions, 2)
+		for _, session := range sessions {
+			assert.NotEqual(t, first.Id, session.Id)
+			assert.Equal(t, "Users", session.Props[model.SessionPropPolicyName])
+		}
+	})
+
+	t.Run("deny new logins", func(t *testing.T) {
+		user := th.CreateUser(t)
+		setPolicy(&model.SessionPolicy{
+			Name:        model.NewPointer("Users"),
+			Roles:       []string{model.SystemUserRoleId},
+			MaxSessions: model.NewPointer(1),
+			LimitAction: model.NewPointer(model.SessionPolicyLimitActionDeny),
+		})
+
+		_, appErr := login(t, user, browserUserAgent)
+		require.Nil(t, appErr)
+
+		_, appErr = login(t, user, browserUserAgent)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.session.policy.max_sessions.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	t.Run("limit per device type", func(t *testing.T) {
+		user := th.CreateUser(t)
+		setPolicy(&model.SessionPolicy{
+			Name:               model.NewPointer("Users"),
+			Roles:              []string{model.SystemUserRoleId},
+			MaxDesktopSessions: model.NewPointer(1),
+			LimitAction:        model.NewPointer(model.SessionPolicyLimitActionDeny),
+		})
+
+		desktopSession, appErr := login(t, user, desktopUserAgent)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.SessionDeviceTypeDesktop, desktopSession.DeviceType())
+
+		_, appErr = login(t, user, browserUserAgent)
+		require.Nil(t, appErr)
+
+		_, appErr = login(t, user, desktopUserAgent)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.session.policy.max_device_sessions.app_error", appErr.Id)
+	})
+
+	t.Run("idle timeout override", func(t *testing.T) {
+		user := th.CreateUser(t)
+		setPolicy(&model.SessionPolicy{
+			Name:                 model.NewPointer("Users"),
+			Roles:                []string{model.SystemUserRoleId},
+			IdleTimeoutInMinutes: model.NewPointer(5),
+		})
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.SessionIdleTimeoutInMinutes = 0
+			*cfg.ServiceSettings.ExtendSessionLengthWithActivity = false
+		})
+
+		session, appErr := login(t, user, browserUserAgent)
+		require.Nil(t, appErr)
+		idleTimeout, fromPolicy := th.App.sessionIdleTimeoutInMinutes(session)
+		assert.Equal(t, 5, idleTimeout)
+		assert.True(t, fromPolicy)
+
+		session.LastActivityAt = model.GetMillis() - (10 * time.Minute).Milliseconds()
+		require.NoError(t, th.App.Srv().Store().Session().UpdateLastActivityAt(session.Id, session.LastActivityAt))
+		th.App.ClearSessionCacheForUser(user.Id)
+
+		_, appErr = th.App.GetSession(session.Token)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("idle timeout override with the default service settings", func(t *testing.T) {
+		user := th.CreateUser(t)
+		setPolicy(&model.SessionPolicy{
+			Name:                 model.NewPointer("Users"),
+			Roles:                []string{model.SystemUserRoleId},
+			IdleTimeoutInMinutes: model.NewPointer(5),
+		})
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.SessionIdleTimeoutInMinutes = nil
+			cfg.ServiceSettings.ExtendSessionLengthWithActivity = nil
+			cfg.ServiceSettings.SetDefaults(false)
+		})
+		require.True(t, *th.App.Config().ServiceSettings.ExtendSessionLengthWithActivity)
+
+		session, appErr := login(t, user, browserUserAgent)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetSession(session.Token)
+		require.Nil(t, appErr)
+
+		session.LastActivityAt = model.GetMillis() - (10 * time.Minute).Milliseconds()
+		require.NoError(t, th.App.Srv().Store().Session().UpdateLastActivityAt(session.Id, session.LastActivityAt))
+		th.App.ClearSessionCacheForUser(user.Id)
+
+		_, appErr = th.App.GetSession(session.Token)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("policies of other roles don't apply", func(t *testing.T) {
+		user := th.CreateUser(t)
+		setPolicy(&model.SessionPolicy{
+			Name:        model.NewPointer("Admins"),
+			Roles:       []string{model.SystemAdminRoleId},
+			MaxSessions: model.NewPointer(1),
+			LimitAction: model.NewPointer(model.SessionPolicyLimitActionDeny),
+		})
+
+		_, appErr := login(t, user, browserUserAgent)
+		require.Nil(t, appErr)
+		_, appErr = login(t, user, browserUserAgent)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("session details", func(t *testing.T) {
+		user := th.CreateUser(t)
+
+		session, appErr := login(t, user, browserUserAgent)
+		require.Nil(t, appErr)
+
+		details, appErr := th.App.GetSessionDetails(th.Context, user.Id)
+		require.Nil(t, appErr)
+		require.Len(t, details, 1)
+		assert.Equal(t, session.Id, details[0].Id)
+		assert.Equal(t, model.SessionDeviceTypeWeb, details[0].DeviceType)
+		assert.Equal(t, browserUserAgent, details[0].UserAgent)
+	})
+}
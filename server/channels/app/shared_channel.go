@@ -14,6 +14,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/services/matrixbridge"
 )
 
 func (a *App) getSharedChannelsService(ensureIsActive bool) (SharedChannelServiceIFace, error) {
@@ -233,10 +234,35 @@ func getPluginHooks(env *plugin.Environment, pluginID string) (plugin.Hooks, err
 	return env.HooksForPlugin(pluginID)
 }
 
+// sharedChannelsHooks are the hooks called for remotes registered like plugins, which
+// are either plugins or the Matrix bridge.
+type sharedChannelsHooks interface {
+	OnSharedChannelsSyncMsg(msg *model.SyncMsg, rc *model.RemoteCluster) (model.SyncResponse, error)
+	OnSharedChannelsPing(rc *model.RemoteCluster) bool
+	OnSharedChannelsAttachmentSyncMsg(fi *model.FileInfo, post *model.Post, rc *model.RemoteCluster) error
+	OnSharedChannelsProfileImageSyncMsg(user *model.User, rc *model.RemoteCluster) error
+}
+
+func (a *App) getSharedChannelsHooks(pluginID string) (sharedChannelsHooks, error) {
+	if pluginID == matrixbridge.BridgeID {
+		bridge := a.Srv().GetMatrixBridge()
+		if bridge == nil {
+			return nil, ErrPluginUnavailable
+		}
+		return bridge, nil
+	}
+
+	pluginHooks, err := getPluginHooks(a.GetPluginsEnvironment(), pluginID)
+	if err != nil {
+		return nil, err
+	}
+	return pluginHooks, nil
+}
+
 // OnSharedChannelsSyncMsg is called by the Shared Channels service for a registered plugin when there is new content
 // that needs to be synchronized.
 func (a *App) OnSharedChannelsSyncMsg(msg *model.SyncMsg, rc *model.RemoteCluster) (model.SyncResponse, error) {
-	pluginHooks, err := getPluginHooks(a.GetPluginsEnvironment(), rc.PluginID)
+	pluginHooks, err := a.getSharedChannelsHooks(rc.PluginID)
 	if err != nil {
 		return model.SyncResponse{}, fmt.Errorf("cannot deliver sync msg to plugin %s: %w", rc.PluginID, err)
 	}
@@ -247,7 +273,7 @@ func (a *App) OnSharedChannelsSyncMsg(msg *model.SyncMsg, rc *model.RemoteCluste
 // OnSharedChannelsPing is called by the Shared Channels service for a registered plugin to check that the plugin
 // is still responding and has a connection to any upstream services it needs (e.g. MS Graph API).
 func (a *App) OnSharedChannelsPing(rc *model.RemoteCluster) bool {
-	pluginHooks, err := getPluginHooks(a.GetPluginsEnvironment(), rc.PluginID)
+	pluginHooks, err := a.getSharedChannelsHooks(rc.PluginID)
 	if err != nil {
 		// plugin was likely uninstalled. Issue a warning once per hour, with instructions how to clean up if this is
 		// intentional.
@@ -268,7 +294,7 @@ func (a *App) OnSharedChannelsPing(rc *model.RemoteCluster) bool {
 // OnSharedChannelsAttachmentSyncMsg is called by the Shared Channels service for a registered plugin when a file attachment
 // needs to be synchronized.
 func (a *App) OnSharedChannelsAttachmentSyncMsg(fi *model.FileInfo, post *model.Post, rc *model.RemoteCluster) error {
-	pluginHooks, err := getPluginHooks(a.GetPluginsEnvironment(), rc.PluginID)
+	pluginHooks, err := a.getSharedChannelsHooks(rc.PluginID)
 	if err != nil {
 		return fmt.Errorf("cannot deliver file attachment sync msg to plugin %s: %w", rc.PluginID, err)
 	}
@@ -279,7 +305,7 @@ func (a *App) OnSharedChannelsAttachmentSyncMsg(fi *model.FileInfo, post *model.
 // OnSharedChannelsProfileImageSyncMsg is called by the Shared Channels service for a registered plugin when a user's
 // profile image needs to be synchronized.
 func (a *App) OnSharedChannelsProfileImageSyncMsg(user *model.User, rc *model.RemoteCluster) error {
-	pluginHooks, err := getPluginHooks(a.GetPluginsEnvironment(), rc.PluginID)
+	pluginHooks, err := a.getSharedChannelsHooks(rc.PluginID)
 	if err != nil {
 		return fmt.Errorf("cannot deliver user profile image sync msg to plugin %s: %w", rc.PluginID, err)
 	}
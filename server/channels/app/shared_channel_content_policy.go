@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/services/sharedchannel"
+)
+
+func (a *App) GetSharedChannelContentPolicy(remoteID string) (*model.SharedChannelContentPolicy, *model.AppError) {
+	policy, err := a.Srv().Store().SharedChannel().GetContentPolicy(remoteID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("GetSharedChannelContentPolicy", "app.shared_channel.content_policy.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return nil, model.NewAppError("GetSharedChannelContentPolicy", "app.shared_channel.content_policy.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return policy, nil
+}
+
+func (a *App) SaveSharedChannelContentPolicy(policy *model.SharedChannelContentPolicy) (*model.SharedChannelContentPolicy, *model.AppError) {
+	saved, err := a.Srv().Store().SharedChannel().SaveContentPolicy(policy)
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, model.NewAppError("SaveSharedChannelContentPolicy", "app.shared_channel.content_policy.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return saved, nil
+}
+
+func (a *App) DeleteSharedChannelContentPolicy(remoteID string) (bool, *model.AppError) {
+	deleted, err := a.Srv().Store().SharedChannel().DeleteContentPolicy(remoteID)
+	if err != nil {
+		return false, model.NewAppError("DeleteSharedChannelContentPolicy", "app.shared_channel.content_policy.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return deleted, nil
+}
+
+func (a *App) GetSharedChannelHeldPosts(remoteID, status string, page, perPage int) ([]*model.SharedChannelHeldPost, *model.AppError) {
+	held, err := a.Srv().Store().SharedChannel().GetHeldPosts(remoteID, status, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetSharedChannelHeldPosts", "app.shared_channel.held_posts.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return held, nil
+}
+
+// ReviewSharedChannelHeldPost approves or rejects a post held by a remote's content policy.
+// Approved posts are sent to the remote right away.
+func (a *App) ReviewSharedChannelHeldPost(postID, remoteID, reviewerID string, approve bool) (*model.SharedChannelHeldPost, *model.AppError) {
+	scService, err := a.getSharedChannelsService(true)
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, model.NewAppError("ReviewSharedChannelHeldPost", "app.shared_channel.held_posts.review.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	held, err := scService.ReviewHeldPost(postID, remoteID, reviewerID, approve)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("ReviewSharedChannelHeldPost", "app.shared_channel.held_posts.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		case errors.Is(err, sharedchannel.ErrHeldPostNotPending), errors.Is(err, sharedchannel.ErrHeldPostDeleted):
+			return nil, model.NewAppError("ReviewSharedChannelHeldPost", "app.shared_channel.held_posts.not_pending.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("ReviewSharedChannelHeldPost", "app.shared_channel.held_posts.review.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+	return held, nil
+}
+
+func (a *App) GetSharedChannelFilterLog(remoteID string, page, perPage int) ([]*model.SharedChannelFilterLogEntry, *model.AppError) {
+	entries, err := a.Srv().Store().SharedChannel().GetFilterLog(remoteID, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetSharedChannelFilterLog", "app.shared_channel.filter_log.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store/faultinjectionlayer"
+)
+
+func (a *App) storeFaultInjector() (*faultinjectionlayer.Injector, *model.AppError) {
+	injector := a.Srv().Platform().StoreFaultInjector()
+	if injector == nil {
+		return nil, model.NewAppError("storeFaultInjector", "app.store_fault.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return injector, nil
+}
+
+// GetStoreFaults returns the faults injected in the store methods.
+func (a *App) GetStoreFaults() ([]*model.StoreFault, *model.AppError) {
+	injector, appErr := a.storeFaultInjector()
+	if appErr != nil {
+		return nil, appErr
+	}
+	return injector.Faults(), nil
+}
+
+// SetStoreFaults replaces the faults injected in the store methods. The store must have been
+// wrapped in the fault-injection layer on start, through the StoreFaultInjection feature flag.
+func (a *App) SetStoreFaults(faults []*model.StoreFault) *model.AppError {
+	injector, appErr := a.storeFaultInjector()
+	if appErr != nil {
+		return appErr
+	}
+
+	methods := make(map[string]bool, len(faults))
+	for _, fault := range faults {
+		if appErr := fault.IsValid(); appErr != nil {
+			return appErr
+		}
+		if methods[fault.Method] {
+			return model.NewAppError("SetStoreFaults", "app.store_fault.duplicate_method.app_error", map[string]any{"Method": fault.Method}, "", http.StatusBadRequest)
+		}
+		methods[fault.Method] = true
+	}
+
+	injector.SetFaults(faults)
+	return nil
+}
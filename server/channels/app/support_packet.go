@@ -107,7 +107,7 @@ func (a *App) GenerateSupportPacket(rctx request.CTX, options *model.SupportPack
 	wg.Wait()
 
 	pluginContext := pluginContext(rctx)
-	a.ch.RunMultiHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+	a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, manifest *model.Manifest) bool {
 		// If the plugin defined the support_packet prop it means there is a UI element to include it in the support packet.
 		// Check if the plugin is in the list of plugins to include in the Support Packet.
 		if _, ok := manifest.Props["support_packet"]; ok {
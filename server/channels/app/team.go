@@ -136,6 +136,14 @@ func (a *App) CreateTeam(rctx request.CTX, team *model.Team) (*model.Team, *mode
 		}
 	}
 
+	a.Srv().Go(func() {
+		pluginContext := pluginContext(rctx)
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.TeamHasBeenCreated(pluginContext, rteam)
+			return true
+		}, plugin.TeamHasBeenCreatedID)
+	})
+
 	return rteam, nil
 }
 
@@ -162,7 +170,40 @@ func (a *App) CreateTeamWithUser(rctx request.CTX, team *model.Team, userID stri
 	return rteam, nil
 }
 
+// runTeamWillBeUpdatedHook lets plugins reject the update of a team before it is committed.
+func (a *App) runTeamWillBeUpdatedHook(where string, newTeam, oldTeam *model.Team) *model.AppError {
+	var rejectionReason string
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.TeamWillBeUpdated(&plugin.Context{}, newTeam, oldTeam)
+		return rejectionReason == ""
+	}, plugin.TeamWillBeUpdatedID)
+	if rejectionReason != "" {
+		return model.NewAppError(where, "Team update rejected by plugin. "+rejectionReason, nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// runTeamHasBeenUpdatedHook notifies plugins of the update of a team.
+func (a *App) runTeamHasBeenUpdatedHook(newTeam, oldTeam *model.Team) {
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.TeamHasBeenUpdated(&plugin.Context{}, newTeam, oldTeam)
+			return true
+		}, plugin.TeamHasBeenUpdatedID)
+	})
+}
+
 func (a *App) UpdateTeam(team *model.Team) (*model.Team, *model.AppError) {
+	prevTeam, appErr := a.GetTeam(team.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr = a.runTeamWillBeUpdatedHook("UpdateTeam", team, prevTeam); appErr != nil {
+		return nil, appErr
+	}
+
 	oldTeam, err := a.ch.srv.teamService.UpdateTeam(team, teams.UpdateOptions{Sanitized: true})
 	if err != nil {
 		var invErr *store.ErrInvalidInput
@@ -187,6 +228,8 @@ func (a *App) UpdateTeam(team *model.Team) (*model.Team, *model.AppError) {
 		return nil, appErr
 	}
 
+	a.runTeamHasBeenUpdatedHook(oldTeam, prevTeam)
+
 	return oldTeam, nil
 }
 
@@ -279,9 +322,14 @@ func (a *App) UpdateTeamPrivacy(teamID string, teamType string, allowOpenInvite
 		oldTeam.InviteId = model.NewId()
 	}
 
+	prevTeam := oldTeam.ShallowCopy()
 	oldTeam.Type = teamType
 	oldTeam.AllowOpenInvite = allowOpenInvite
 
+	if appErr := a.runTeamWillBeUpdatedHook("UpdateTeamPrivacy", oldTeam, prevTeam); appErr != nil {
+		return appErr
+	}
+
 	oldTeam, nErr := a.Srv().Store().Team().Update(oldTeam)
 	if nErr != nil {
 		var invErr *store.ErrInvalidInput
@@ -300,10 +348,23 @@ func (a *App) UpdateTeamPrivacy(teamID string, teamType string, allowOpenInvite
 		return appErr
 	}
 
+	a.runTeamHasBeenUpdatedHook(oldTeam, prevTeam)
+
 	return nil
 }
 
 func (a *App) PatchTeam(teamID string, patch *model.TeamPatch) (*model.Team, *model.AppError) {
+	prevTeam, appErr := a.GetTeam(teamID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	patchedTeam := prevTeam.ShallowCopy()
+	patchedTeam.Patch(patch)
+	if appErr = a.runTeamWillBeUpdatedHook("PatchTeam", patchedTeam, prevTeam); appErr != nil {
+		return nil, appErr
+	}
+
 	team, err := a.ch.srv.teamService.PatchTeam(teamID, patch)
 	if err != nil {
 		var invErr *store.ErrInvalidInput
@@ -328,6 +389,8 @@ func (a *App) PatchTeam(teamID string, patch *model.TeamPatch) (*model.Team, *mo
 		return nil, appErr
 	}
 
+	a.runTeamHasBeenUpdatedHook(team, prevTeam)
+
 	return team, nil
 }
 
@@ -694,13 +757,6 @@ func (a *App) AddUserToTeamByToken(rctx request.CTX, userID string, tokenID stri
 }
 
 func (a *App) AddUserToTeamByInviteId(rctx request.CTX, inviteId string, userID string) (*model.Team, *model.TeamMember, *model.AppError) {
-	tchan := make(chan store.StoreResult[*model.Team], 1)
-	go func() {
-		team, err := a.Srv().Store().Team().GetByInviteId(inviteId)
-		tchan <- store.StoreResult[*model.Team]{Data: team, NErr: err}
-		close(tchan)
-	}()
-
 	uchan := make(chan store.StoreResult[*model.User], 1)
 	go func() {
 		user, err := a.Srv().Store().User().Get(context.Background(), userID)
@@ -708,17 +764,10 @@ func (a *App) AddUserToTeamByInviteId(rctx request.CTX, inviteId string, userID
 		close(uchan)
 	}()
 
-	teamChanResult := <-tchan
-	if teamChanResult.NErr != nil {
-		var nfErr *store.ErrNotFound
-		switch {
-		case errors.As(teamChanResult.NErr, &nfErr):
-			return nil, nil, model.NewAppError("AddUserToTeamByInviteId", "app.team.get_by_invite_id.finding.app_error", nil, "", http.StatusNotFound).Wrap(teamChanResult.NErr)
-		default:
-			return nil, nil, model.NewAppError("AddUserToTeamByInviteId", "app.team.get_by_invite_id.finding.app_error", nil, "", http.StatusInternalServerError).Wrap(teamChanResult.NErr)
-		}
+	team, link, appErr := a.getTeamByInviteIdOrLink("AddUserToTeamByInviteId", inviteId)
+	if appErr != nil {
+		return nil, nil, appErr
 	}
-	team := teamChanResult.Data
 
 	if team.IsGroupConstrained() {
 		return nil, nil, model.NewAppError("AddUserToTeamByInviteId", "app.team.invite_id.group_constrained.error", nil, "", http.StatusForbidden)
@@ -736,6 +785,19 @@ func (a *App) AddUserToTeamByInviteId(rctx request.CTX, inviteId string, userID
 	}
 	user := userChanResult.Data
 
+	if link != nil {
+		teamMember, appErr := a.joinUserToTeamByInviteLink(rctx, team, link, user)
+		if appErr != nil {
+			return nil, nil, appErr
+		}
+		return team, teamMember, nil
+	}
+
+	// Guests can only join teams through guest invitations.
+	if user.IsGuest() {
+		return nil, nil, model.NewAppError("AddUserToTeamByInviteId", "api.team.add_user_to_team_from_invite.guest.app_error", nil, "", http.StatusForbidden)
+	}
+
 	teamMember, err := a.JoinUserToTeam(rctx, team, user, "")
 	if err != nil {
 		return nil, nil, err
@@ -809,7 +871,7 @@ func (a *App) JoinUserToTeam(rctx request.CTX, team *model.Team, user *model.Use
 
 	a.Srv().Go(func() {
 		pluginContext := pluginContext(rctx)
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.UserHasJoinedTeam(pluginContext, teamMember, actor)
 			return true
 		}, plugin.UserHasJoinedTeamID)
@@ -868,16 +930,11 @@ func (a *App) GetTeamByName(name string) (*model.Team, *model.AppError) {
 	return team, nil
 }
 
+// GetTeamByInviteId returns the team of the static invite id of a team or of a usable invite link.
 func (a *App) GetTeamByInviteId(inviteId string) (*model.Team, *model.AppError) {
-	team, err := a.Srv().Store().Team().GetByInviteId(inviteId)
-	if err != nil {
-		var nfErr *store.ErrNotFound
-		switch {
-		case errors.As(err, &nfErr):
-			return nil, model.NewAppError("GetTeamByInviteId", "app.team.get_by_invite_id.finding.app_error", nil, "", http.StatusNotFound).Wrap(err)
-		default:
-			return nil, model.NewAppError("GetTeamByInviteId", "app.team.get_by_invite_id.finding.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
-		}
+	team, _, appErr := a.getTeamByInviteIdOrLink("GetTeamByInviteId", inviteId)
+	if appErr != nil {
+		return nil, appErr
 	}
 
 	return team, nil
@@ -1176,7 +1233,7 @@ func (a *App) postProcessTeamMemberLeave(rctx request.CTX, teamMember *model.Tea
 
 	a.Srv().Go(func() {
 		pluginContext := pluginContext(rctx)
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.UserHasLeftTeam(pluginContext, teamMember, actor)
 			return true
 		}, plugin.UserHasLeftTeamID)
@@ -1545,7 +1602,7 @@ func (a *App) InviteGuestsToChannelsGracefully(rctx request.CTX, teamID string,
 			rctx.Logger().Warn("Unable to get the sender user profile image.", mlog.String("user_id", user.Id), mlog.String("team_id", team.Id), mlog.Err(err))
 		}
 
-		eErr := a.Srv().EmailService.SendGuestInviteEmails(team, channels, user.GetDisplayName(nameFormat), user.Id, senderProfileImage, goodEmails, a.GetSiteURL(), guestsInvite.Message, true, user.IsSystemAdmin(), a.UserIsFirstAdmin(rctx, user))
+		eErr := a.Srv().EmailService.SendGuestInviteEmails(team, channels, user.GetDisplayName(nameFormat), user.Id, senderProfileImage, goodEmails, a.GetSiteURL(), guestsInvite.Message, guestsInvite.AccessExpiresAt, true, user.IsSystemAdmin(), a.UserIsFirstAdmin(rctx, user))
 		if eErr != nil {
 			switch {
 			case errors.Is(eErr, email.SendMailError):
@@ -1644,7 +1701,7 @@ func (a *App) InviteGuestsToChannels(rctx request.CTX, teamID string, guestsInvi
 		rctx.Logger().Warn("Unable to get the sender user profile image.", mlog.String("user_id", user.Id), mlog.String("team_id", team.Id), mlog.Err(err))
 	}
 
-	eErr := a.Srv().EmailService.SendGuestInviteEmails(team, channels, user.GetDisplayName(nameFormat), user.Id, senderProfileImage, guestsInvite.Emails, a.GetSiteURL(), guestsInvite.Message, false, user.IsSystemAdmin(), a.UserIsFirstAdmin(rctx, user))
+	eErr := a.Srv().EmailService.SendGuestInviteEmails(team, channels, user.GetDisplayName(nameFormat), user.Id, senderProfileImage, guestsInvite.Emails, a.GetSiteURL(), guestsInvite.Message, guestsInvite.AccessExpiresAt, false, user.IsSystemAdmin(), a.UserIsFirstAdmin(rctx, user))
 	if eErr != nil {
 		switch {
 		case errors.Is(eErr, email.NoRateLimiterError):
@@ -1791,6 +1848,15 @@ func (a *App) SoftDeleteTeam(teamID string) *model.AppError {
 		return err
 	}
 
+	var rejectionReason string
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.TeamWillBeArchived(&plugin.Context{}, team)
+		return rejectionReason == ""
+	}, plugin.TeamWillBeArchivedID)
+	if rejectionReason != "" {
+		return model.NewAppError("SoftDeleteTeam", "Team archival rejected by plugin. "+rejectionReason, nil, "", http.StatusBadRequest)
+	}
+
 	if err := a.Srv().Store().PostPersistentNotification().DeleteByTeam([]string{team.Id}); err != nil {
 		return model.NewAppError("SoftDeleteTeam", "app.post_persistent_notification.delete_by_team.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -1814,6 +1880,13 @@ func (a *App) SoftDeleteTeam(teamID string) *model.AppError {
 		return appErr
 	}
 
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.TeamHasBeenArchived(&plugin.Context{}, team)
+			return true
+		}, plugin.TeamHasBeenArchivedID)
+	})
+
 	return nil
 }
 
@@ -1842,6 +1915,13 @@ func (a *App) RestoreTeam(teamID string) *model.AppError {
 		return appErr
 	}
 
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.TeamHasBeenRestored(&plugin.Context{}, team)
+			return true
+		}, plugin.TeamHasBeenRestoredID)
+	})
+
 	return nil
 }
 
@@ -1903,12 +1983,12 @@ func (a *App) GetTeamIdFromQuery(rctx request.CTX, query url.Values) (string, *m
 		return tokenData["teamId"], nil
 	}
 	if inviteId != "" {
-		team, err := a.Srv().Store().Team().GetByInviteId(inviteId)
-		if err == nil {
+		team, _, appErr := a.getTeamByInviteIdOrLink("GetTeamIdFromQuery", inviteId)
+		if appErr == nil {
 			return team.Id, nil
 		}
 		// soft fail, so we still create user but don't auto-join team
-		rctx.Logger().Warn("Error getting team by inviteId.", mlog.String("invite_id", inviteId), mlog.Err(err))
+		rctx.Logger().Warn("Error getting team by inviteId.", mlog.String("invite_id", inviteId), mlog.Err(appErr))
 	}
 
 	return "", nil
@@ -0,0 +1,291 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// validateTeamInviteLink checks that the team can be joined through links and that the user
+// creating or patching the link can add members to the channels it grants. Only the granted
+// channels are checked, since the others were granted by whoever added them to the link.
+func (a *App) validateTeamInviteLink(rctx request.CTX, link *model.TeamInviteLink, userID string, grantedChannelIds []string) *model.AppError {
+	if appErr := link.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	team, appErr := a.GetTeam(link.TeamId)
+	if appErr != nil {
+		return appErr
+	}
+
+	if team.IsGroupConstrained() {
+		return model.NewAppError("validateTeamInviteLink", "app.team.invite_id.group_constrained.error", nil, "", http.StatusBadRequest)
+	}
+
+	if link.Role == model.TeamInviteLinkRoleGuest && (a.License() == nil || !*a.Config().GuestAccountsSettings.Enable) {
+		return model.NewAppError("validateTeamInviteLink", "app.team.invite_link.guests_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if len(link.ChannelIds) == 0 {
+		return nil
+	}
+
+	channels, err := a.Srv().Store().Channel().GetChannelsByIds(link.ChannelIds, false)
+	if err != nil {
+		return model.NewAppError("validateTeamInviteLink", "app.channel.get_channels_by_ids.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if len(channels) != len(link.ChannelIds) {
+		return model.NewAppError("validateTeamInviteLink", "app.team.invite_link.invalid_channel.app_error", nil, "", http.StatusBadRequest)
+	}
+	for _, channel := range channels {
+		if channel.TeamId != link.TeamId || channel.PolicyEnforced {
+			return model.NewAppError("validateTeamInviteLink", "app.team.invite_link.invalid_channel.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+		}
+	}
+
+	if len(grantedChannelIds) == 0 {
+		return nil
+	}
+
+	if allowed := a.ValidateUserPermissionsOnChannels(rctx, userID, grantedChannelIds); len(allowed) != len(grantedChannelIds) {
+		return model.NewAppError("validateTeamInviteLink", "app.team.invite_link.channel_permission.app_error", nil, "", http.StatusForbidden)
+	}
+
+	return nil
+}
+
+func (a *App) CreateTeamInviteLink(rctx request.CTX, link *model.TeamInviteLink) (*model.TeamInviteLink, *model.AppError) {
+	link.PreSave()
+	if appErr := a.validateTeamInviteLink(rctx, link, link.CreatorId, link.ChannelIds); appErr != nil {
+		return nil, appErr
+	}
+
+	savedLink, err := a.Srv().Store().Team().SaveInviteLink(link)
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, model.NewAppError("CreateTeamInviteLink", "app.team.invite_link.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return savedLink, nil
+}
+
+func (a *App) GetTeamInviteLink(linkID string) (*model.TeamInviteLink, *model.AppError) {
+	link, err := a.Srv().Store().Team().GetInviteLink(linkID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetTeamInviteLink", "app.team.invite_link.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetTeamInviteLink", "app.team.invite_link.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return link, nil
+}
+
+func (a *App) GetTeamInviteLinks(teamID string, includeRevoked bool) ([]*model.TeamInviteLink, *model.AppError) {
+	links, err := a.Srv().Store().Team().GetInviteLinksForTeam(teamID, includeRevoked)
+	if err != nil {
+		return nil, model.NewAppError("GetTeamInviteLinks", "app.team.invite_link.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return links, nil
+}
+
+// PatchTeamInviteLink updates a link on behalf of the given user, who must be able to add members
+// to the channels the patch adds to the link.
+func (a *App) PatchTeamInviteLink(rctx request.CTX, linkID string, patch *model.TeamInviteLinkPatch, userID string) (*model.TeamInviteLink, *model.AppError) {
+	link, appErr := a.GetTeamInviteLink(linkID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if link.DeleteAt != 0 {
+		return nil, model.NewAppError("PatchTeamInviteLink", "app.team.invite_link.revoked.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	var grantedChannelIds []string
+	if patch.ChannelIds != nil {
+		for _, channelID := range *patch.ChannelIds {
+			if !slices.Contains(link.ChannelIds, channelID) {
+				grantedChannelIds = append(grantedChannelIds, channelID)
+			}
+		}
+	}
+
+	link.Patch(patch)
+	if appErr := a.validateTeamInviteLink(rctx, link, userID, grantedChannelIds); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.updateTeamInviteLink(link)
+}
+
+// RevokeTeamInviteLink disables a link. Revoked links are kept along with their usage history.
+func (a *App) RevokeTeamInviteLink(linkID string) (*model.TeamInviteLink, *model.AppError) {
+	link, appErr := a.GetTeamInviteLink(linkID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if link.DeleteAt != 0 {
+		return link, nil
+	}
+
+	link.DeleteAt = model.GetMillis()
+	return a.updateTeamInviteLink(link)
+}
+
+func (a *App) updateTeamInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, *model.AppError) {
+	updatedLink, err := a.Srv().Store().Team().UpdateInviteLink(link)
+	if err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("updateTeamInviteLink", "app.team.invite_link.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("updateTeamInviteLink", "app.team.invite_link.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return updatedLink, nil
+}
+
+func (a *App) GetTeamInviteLinkUses(linkID string, page, perPage int) ([]*model.TeamInviteLinkUse, *model.AppError) {
+	uses, err := a.Srv().Store().Team().GetInviteLinkUses(linkID, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetTeamInviteLinkUses", "app.team.invite_link.get_uses.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return uses, nil
+}
+
+// getTeamByInviteIdOrLink returns the team of an invite id, which is either the static invite id
+// of the team or the id of an invite link. The link is nil for static invite ids.
+func (a *App) getTeamByInviteIdOrLink(where, inviteId string) (*model.Team, *model.TeamInviteLink, *model.AppError) {
+	team, err := a.Srv().Store().Team().GetByInviteId(inviteId)
+	if err == nil {
+		return team, nil, nil
+	}
+
+	var nfErr *store.ErrNotFound
+	if !errors.As(err, &nfErr) {
+		return nil, nil, model.NewAppError(where, "app.team.get_by_invite_id.finding.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	link, err := a.Srv().Store().Team().GetInviteLink(inviteId)
+	if err != nil {
+		if errors.As(err, &nfErr) {
+			return nil, nil, model.NewAppError(where, "app.team.get_by_invite_id.finding.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return nil, nil, model.NewAppError(where, "app.team.get_by_invite_id.finding.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if !link.IsUsable(model.GetMillis()) {
+		return nil, nil, model.NewAppError(where, "app.team.invite_link.expired.app_error", nil, "link_id="+link.Id, http.StatusBadRequest)
+	}
+
+	team, err = a.Srv().Store().Team().Get(link.TeamId)
+	if err != nil {
+		if errors.As(err, &nfErr) {
+			return nil, nil, model.NewAppError(where, "app.team.get_by_invite_id.finding.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return nil, nil, model.NewAppError(where, "app.team.get_by_invite_id.finding.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return team, link, nil
+}
+
+// claimInviteLinkUse counts a use of an invite link, failing if the link can't be used anymore.
+// The use is claimed before joining, so that the maximum number of uses holds with concurrent
+// joins, and must be released with releaseInviteLinkUse if the join fails.
+func (a *App) claimInviteLinkUse(where string, link *model.TeamInviteLink) *model.AppError {
+	if err := a.Srv().Store().Team().ClaimInviteLinkUse(link.Id, model.GetMillis()); err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return model.NewAppError(where, "app.team.invite_link.expired.app_error", nil, "link_id="+link.Id, http.StatusBadRequest).Wrap(err)
+		}
+		return model.NewAppError(where, "app.team.invite_link.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+func (a *App) releaseInviteLinkUse(rctx request.CTX, link *model.TeamInviteLink) {
+	if err := a.Srv().Store().Team().ReleaseInviteLinkUse(link.Id); err != nil {
+		rctx.Logger().Warn("Failed to release use of invite link", mlog.String("link_id", link.Id), mlog.Err(err))
+	}
+}
+
+// joinUserToTeamByInviteLink adds a user to the team and to the channels of an invite link, and
+// records the use of the link. Members of the team don't use the link and aren't added to its
+// channels.
+func (a *App) joinUserToTeamByInviteLink(rctx request.CTX, team *model.Team, link *model.TeamInviteLink, user *model.User) (*model.TeamMember, *model.AppError) {
+	if user.IsGuest() != (link.Role == model.TeamInviteLinkRoleGuest) {
+		return nil, model.NewAppError("joinUserToTeamByInviteLink", "api.user.create_user.invalid_invitation_type.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	teamMember, err := a.Srv().Store().Team().GetMember(rctx, team.Id, user.Id)
+	if err == nil && teamMember.DeleteAt == 0 {
+		return teamMember, nil
+	}
+	var nfErr *store.ErrNotFound
+	if err != nil && !errors.As(err, &nfErr) {
+		return nil, model.NewAppError("joinUserToTeamByInviteLink", "app.team.get_member.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if appErr := a.claimInviteLinkUse("joinUserToTeamByInviteLink", link); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.joinUserToTeamByClaimedInviteLink(rctx, team, link, user)
+}
+
+// joinUserToTeamByClaimedInviteLink is joinUserToTeamByInviteLink for a link whose use was
+// already claimed. The use is released if the user can't join the team.
+func (a *App) joinUserToTeamByClaimedInviteLink(rctx request.CTX, team *model.Team, link *model.TeamInviteLink, user *model.User) (*model.TeamMember, *model.AppError) {
+	teamMember, appErr := a.JoinUserToTeam(rctx, team, user, "")
+	if appErr != nil {
+		a.releaseInviteLinkUse(rctx, link)
+		return nil, appErr
+	}
+
+	if err := a.Srv().Store().Team().SaveInviteLinkUse(&model.TeamInviteLinkUse{LinkId: link.Id, UserId: user.Id, UseAt: model.GetMillis()}); err != nil {
+		rctx.Logger().Warn("Failed to record use of invite link", mlog.String("link_id", link.Id), mlog.String("user_id", user.Id), mlog.Err(err))
+	}
+
+	if len(link.ChannelIds) > 0 {
+		channels, err := a.Srv().Store().Channel().GetChannelsByIds(link.ChannelIds, false)
+		if err != nil {
+			return nil, model.NewAppError("joinUserToTeamByClaimedInviteLink", "app.channel.get_channels_by_ids.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, channel := range channels {
+			if channel.TeamId != team.Id {
+				continue
+			}
+			if _, err := a.AddUserToChannel(rctx, user, channel, false); err != nil {
+				rctx.Logger().Warn("Error adding user to channel of invite link", mlog.String("link_id", link.Id), mlog.String("channel_id", channel.Id), mlog.Err(err))
+			}
+		}
+	}
+
+	return teamMember, nil
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestTeamInviteLinks(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	newLink := func(t *testing.T, maxUses int64) *model.TeamInviteLink {
+		t.Helper()
+
+		link, appErr := th.App.CreateTeamInviteLink(th.Context, &model.TeamInviteLink{
+			TeamId:     th.BasicTeam.Id,
+			CreatorId:  th.BasicUser.Id,
+			Name:       "Conference",
+			ChannelIds: model.StringArray{th.BasicChannel.Id},
+			MaxUses:    maxUses,
+		})
+		require.Nil(t, appErr)
+		return link
+	}
+
+	t.Run("join through a link", func(t *testing.T) {
+		link := newLink(t, 1)
+		user := th.CreateUser(t)
+
+		team, member, appErr := th.App.AddUserToTeamByInviteId(th.Context, link.Id, user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, th.BasicTeam.Id, team.Id)
+		assert.Equal(t, user.Id, member.UserId)
+
+		_, appErr = th.App.GetChannelMember(th.Context, th.BasicChannel.Id, user.Id)
+		assert.Nil(t, appErr)
+
+		uses, appErr := th.App.GetTeamInviteLinkUses(link.Id, 0, 10)
+		require.Nil(t, appErr)
+		require.Len(t, uses, 1)
+		assert.Equal(t, user.Id, uses[0].UserId)
+
+		t.Run("the link is exhausted", func(t *testing.T) {
+			_, _, appErr := th.App.AddUserToTeamByInviteId(th.Context, link.Id, th.CreateUser(t).Id)
+			require.NotNil(t, appErr)
+			assert.Equal(t, "app.team.invite_link.expired.app_error", appErr.Id)
+		})
+	})
+
+	t.Run("create a user through a link", func(t *testing.T) {
+		link := newLink(t, 0)
+		user := &model.User{Email: "invite-link-" + model.NewId() + "@example.com", Username: "u" + model.NewId(), Password: "passwd1"}
+
+		user, appErr := th.App.CreateUserWithInviteId(th.Context, user, link.Id, "")
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetTeamMember(th.Context, th.BasicTeam.Id, user.Id)
+		assert.Nil(t, appErr)
+	})
+
+	t.Run("no user is created through an exhausted link", func(t *testing.T) {
+		link := newLink(t, 1)
+		require.NoError(t, th.App.Srv().Store().Team().ClaimInviteLinkUse(link.Id, model.GetMillis()))
+		user := &model.User{Email: "invite-link-" + model.NewId() + "@example.com", Username: "u" + model.NewId(), Password: "passwd1"}
+
+		_, appErr := th.App.CreateUserWithInviteId(th.Context, user, link.Id, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.invite_link.expired.app_error", appErr.Id)
+
+		_, err := th.App.Srv().Store().User().GetByEmail(user.Email)
+		require.Error(t, err)
+	})
+
+	t.Run("the use of a link is released when the user can't be created", func(t *testing.T) {
+		link := newLink(t, 1)
+		user := &model.User{Email: "invite-link-" + model.NewId() + "@example.com", Username: th.BasicUser.Username, Password: "passwd1"}
+
+		_, appErr := th.App.CreateUserWithInviteId(th.Context, user, link.Id, "")
+		require.NotNil(t, appErr)
+
+		user.Username = "u" + model.NewId()
+		user, appErr = th.App.CreateUserWithInviteId(th.Context, user, link.Id, "")
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetTeamMember(th.Context, th.BasicTeam.Id, user.Id)
+		assert.Nil(t, appErr)
+	})
+
+	t.Run("team members don't use the link", func(t *testing.T) {
+		link := newLink(t, 1)
+		user := th.CreateUser(t)
+		th.LinkUserToTeam(t, user, th.BasicTeam)
+
+		_, member, appErr := th.App.AddUserToTeamByInviteId(th.Context, link.Id, user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, user.Id, member.UserId)
+
+		_, appErr = th.App.GetChannelMember(th.Context, th.BasicChannel.Id, user.Id)
+		require.NotNil(t, appErr, "team members shouldn't be added to the channels of the link")
+
+		uses, appErr := th.App.GetTeamInviteLinkUses(link.Id, 0, 10)
+		require.Nil(t, appErr)
+		assert.Empty(t, uses)
+
+		_, _, appErr = th.App.AddUserToTeamByInviteId(th.Context, link.Id, th.CreateUser(t).Id)
+		require.Nil(t, appErr, "the link should still have a use left")
+	})
+
+	t.Run("only channels the patcher can access can be added", func(t *testing.T) {
+		link := newLink(t, 0)
+		patcher := th.CreateUser(t)
+		th.LinkUserToTeam(t, patcher, th.BasicTeam)
+		privateChannel := th.CreatePrivateChannel(t, th.BasicTeam)
+
+		_, appErr := th.App.PatchTeamInviteLink(th.Context, link.Id, &model.TeamInviteLinkPatch{ChannelIds: &[]string{th.BasicChannel.Id, privateChannel.Id}}, patcher.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.invite_link.channel_permission.app_error", appErr.Id)
+
+		patchedLink, appErr := th.App.PatchTeamInviteLink(th.Context, link.Id, &model.TeamInviteLinkPatch{ChannelIds: &[]string{th.BasicChannel.Id, privateChannel.Id}}, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.ElementsMatch(t, []string{th.BasicChannel.Id, privateChannel.Id}, []string(patchedLink.ChannelIds))
+
+		patchedLink, appErr = th.App.PatchTeamInviteLink(th.Context, link.Id, &model.TeamInviteLinkPatch{MaxUses: model.NewPointer(int64(5))}, patcher.Id)
+		require.Nil(t, appErr, "channels granted by others don't need to be accessible to the patcher")
+		assert.Equal(t, int64(5), patchedLink.MaxUses)
+	})
+
+	t.Run("expired link", func(t *testing.T) {
+		link := newLink(t, 0)
+		_, appErr := th.App.PatchTeamInviteLink(th.Context, link.Id, &model.TeamInviteLinkPatch{ExpiresAt: model.NewPointer(model.GetMillis() - 1000)}, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		_, _, appErr = th.App.AddUserToTeamByInviteId(th.Context, link.Id, th.CreateUser(t).Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.invite_link.expired.app_error", appErr.Id)
+	})
+
+	t.Run("revoked link", func(t *testing.T) {
+		link := newLink(t, 0)
+		link, appErr := th.App.RevokeTeamInviteLink(link.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, link.DeleteAt)
+
+		_, _, appErr = th.App.AddUserToTeamByInviteId(th.Context, link.Id, th.CreateUser(t).Id)
+		require.NotNil(t, appErr)
+
+		_, appErr = th.App.PatchTeamInviteLink(th.Context, link.Id, &model.TeamInviteLinkPatch{MaxUses: model.NewPointer(int64(5))}, th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.invite_link.revoked.app_error", appErr.Id)
+
+		links, appErr := th.App.GetTeamInviteLinks(th.BasicTeam.Id, false)
+		require.Nil(t, appErr)
+		for _, l := range links {
+			assert.NotEqual(t, link.Id, l.Id)
+		}
+	})
+
+	t.Run("guests can't use member links", func(t *testing.T) {
+		link := newLink(t, 0)
+
+		_, _, appErr := th.App.AddUserToTeamByInviteId(th.Context, link.Id, th.CreateGuest(t).Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.create_user.invalid_invitation_type.app_error", appErr.Id)
+	})
+
+	t.Run("channel of another team", func(t *testing.T) {
+		otherTeam := th.CreateTeam(t)
+		_, appErr := th.App.CreateTeamInviteLink(th.Context, &model.TeamInviteLink{
+			TeamId:     th.BasicTeam.Id,
+			CreatorId:  th.BasicUser.Id,
+			Name:       "Conference",
+			ChannelIds: model.StringArray{th.CreateChannel(t, otherTeam).Id},
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.invite_link.invalid_channel.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("guest links require guest accounts", func(t *testing.T) {
+		_, appErr := th.App.CreateTeamInviteLink(th.Context, &model.TeamInviteLink{
+			TeamId:     th.BasicTeam.Id,
+			CreatorId:  th.BasicUser.Id,
+			Name:       "Contractors",
+			Role:       model.TeamInviteLinkRoleGuest,
+			ChannelIds: model.StringArray{th.BasicChannel.Id},
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.invite_link.guests_disabled.app_error", appErr.Id)
+	})
+}
@@ -1642,6 +1642,7 @@ func TestInviteGuestsToChannelsGracefully(t *testing.T) {
 			[]string{"idontexist@mattermost.com"},
 			"",
 			"",
+			int64(0),
 			true,
 			false,
 			false,
@@ -1669,6 +1670,7 @@ func TestInviteGuestsToChannelsGracefully(t *testing.T) {
 			[]string{"idontexist@mattermost.com"},
 			"",
 			"",
+			int64(0),
 			true,
 			false,
 			false,
@@ -38,6 +38,7 @@ const (
 	TokenTypeTeamInvitation    = "team_invitation"
 	TokenTypeGuestInvitation   = "guest_invitation"
 	TokenTypeCWSAccess         = "cws_access_token"
+	TokenTypeMagicLink         = "magic_link"
 	PasswordRecoverExpiryTime  = 1000 * 60 * 60 * 24 // 24 hours
 	InvitationExpiryTime       = 1000 * 60 * 60 * 48 // 48 hours
 	ImageProfilePixelDimension = 128
@@ -93,6 +94,23 @@ func (a *App) CreateUserWithToken(rctx request.CTX, user *model.User, token *mod
 	user.Email = tokenData["email"]
 	user.EmailVerified = true
 
+	var accessExpiresAt int64
+	if token.Type == TokenTypeGuestInvitation && tokenData["accessExpiresAt"] != "" {
+		var parseErr error
+		accessExpiresAt, parseErr = strconv.ParseInt(tokenData["accessExpiresAt"], 10, 64)
+		if parseErr != nil {
+			return nil, model.NewAppError("CreateUserWithToken", "api.user.create_user.signup_link_invalid.app_error", nil, "", http.StatusBadRequest).Wrap(parseErr)
+		}
+
+		// The guest would be deactivated right away, so don't create the account at all.
+		if accessExpiresAt <= model.GetMillis() {
+			if appErr := a.DeleteToken(token); appErr != nil {
+				rctx.Logger().Warn("Error while deleting expired guest invite token", mlog.Err(appErr))
+			}
+			return nil, model.NewAppError("CreateUserWithToken", "api.user.create_user.guest_access_expired.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
 	var ruser *model.User
 	var err *model.AppError
 	if token.Type == TokenTypeTeamInvitation {
@@ -112,6 +130,12 @@ func (a *App) CreateUserWithToken(rctx request.CTX, user *model.User, token *mod
 		return nil, appErr
 	}
 
+	if accessExpiresAt > 0 {
+		if _, appErr := a.SetGuestAccessExpiry(rctx, ruser.Id, accessExpiresAt); appErr != nil {
+			return nil, appErr
+		}
+	}
+
 	if token.Type == TokenTypeGuestInvitation || (token.Type == TokenTypeTeamInvitation && len(channels) > 0) {
 		for _, channel := range channels {
 			_, err := a.AddChannelMember(rctx, ruser.Id, channel, ChannelMemberOpts{})
@@ -133,15 +157,9 @@ func (a *App) CreateUserWithInviteId(rctx request.CTX, user *model.User, inviteI
 		return nil, err
 	}
 
-	team, nErr := a.Srv().Store().Team().GetByInviteId(inviteId)
-	if nErr != nil {
-		var nfErr *store.ErrNotFound
-		switch {
-		case errors.As(nErr, &nfErr):
-			return nil, model.NewAppError("CreateUserWithInviteId", "app.team.get_by_invite_id.finding.app_error", nil, "", http.StatusNotFound).Wrap(nErr)
-		default:
-			return nil, model.NewAppError("CreateUserWithInviteId", "app.team.get_by_invite_id.finding.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
-		}
+	team, link, appErr := a.getTeamByInviteIdOrLink("CreateUserWithInviteId", inviteId)
+	if appErr != nil {
+		return nil, appErr
 	}
 
 	if team.IsGroupConstrained() {
@@ -154,12 +172,42 @@ func (a *App) CreateUserWithInviteId(rctx request.CTX, user *model.User, inviteI
 
 	user.EmailVerified = false
 
-	ruser, err := a.CreateUser(rctx, user)
+	if link != nil && link.Role == model.TeamInviteLinkRoleGuest {
+		if !*a.Config().GuestAccountsSettings.Enable {
+			return nil, model.NewAppError("CreateUserWithInviteId", "app.team.invite_link.guests_disabled.app_error", nil, "", http.StatusNotImplemented)
+		}
+		if !users.CheckEmailDomain(user.Email, *a.Config().GuestAccountsSettings.RestrictCreationToDomains) {
+			return nil, model.NewAppError("CreateUserWithInviteId", "api.team.invite_members.invalid_email.app_error", map[string]any{"Addresses": *a.Config().GuestAccountsSettings.RestrictCreationToDomains}, "", http.StatusForbidden)
+		}
+	}
+
+	// Claim the use of the link before creating the user, so that no account is left behind when
+	// the link was used up in the meantime.
+	if link != nil {
+		if appErr := a.claimInviteLinkUse("CreateUserWithInviteId", link); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	var ruser *model.User
+	var err *model.AppError
+	if link != nil && link.Role == model.TeamInviteLinkRoleGuest {
+		ruser, err = a.CreateGuest(rctx, user)
+	} else {
+		ruser, err = a.CreateUser(rctx, user)
+	}
 	if err != nil {
+		if link != nil {
+			a.releaseInviteLinkUse(rctx, link)
+		}
 		return nil, err
 	}
 
-	if _, err := a.JoinUserToTeam(rctx, team, ruser, ""); err != nil {
+	if link != nil {
+		if _, err := a.joinUserToTeamByClaimedInviteLink(rctx, team, link, ruser); err != nil {
+			return nil, err
+		}
+	} else if _, err := a.JoinUserToTeam(rctx, team, ruser, ""); err != nil {
 		return nil, err
 	}
 
@@ -323,7 +371,7 @@ func (a *App) createUserOrGuest(rctx request.CTX, user *model.User, guest bool)
 
 	pluginContext := pluginContext(rctx)
 	a.Srv().Go(func() {
-		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.UserHasBeenCreated(pluginContext, ruser)
 			return true
 		}, plugin.UserHasBeenCreatedID)
@@ -1119,7 +1167,7 @@ func (a *App) UpdateActive(rctx request.CTX, user *model.User, active bool) (*mo
 	if !active && user.DeleteAt != 0 {
 		a.Srv().Go(func() {
 			pluginContext := pluginContext(rctx)
-			a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
 				hooks.UserHasBeenDeactivated(pluginContext, user)
 				return true
 			}, plugin.UserHasBeenDeactivatedID)
@@ -1422,6 +1470,17 @@ func (a *App) UpdateUser(rctx request.CTX, user *model.User, sendNotifications b
 
 	newUser.Sanitize(map[string]bool{})
 
+	pluginNewUser := newUser.DeepCopy()
+	pluginOldUser := userUpdate.Old.DeepCopy()
+	pluginOldUser.Sanitize(map[string]bool{})
+	a.Srv().Go(func() {
+		pluginContext := pluginContext(rctx)
+		a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.UserHasBeenUpdated(pluginContext, pluginNewUser, pluginOldUser)
+			return true
+		}, plugin.UserHasBeenUpdatedID)
+	})
+
 	return newUser, nil
 }
 
@@ -1848,6 +1907,18 @@ func (a *App) UpdateUserRolesWithUser(rctx request.CTX, user *model.User, newRol
 		a.Publish(message)
 	}
 
+	if oldRoles := result.Data.Old.Roles; oldRoles != ruser.Roles {
+		pluginUser := ruser.DeepCopy()
+		pluginUser.Sanitize(map[string]bool{})
+		a.Srv().Go(func() {
+			pluginContext := pluginContext(rctx)
+			a.ch.RunMultiHookWithContext(rctx.Context(), func(hooks plugin.Hooks, _ *model.Manifest) bool {
+				hooks.UserRolesHaveChanged(pluginContext, pluginUser, oldRoles)
+				return true
+			}, plugin.UserRolesHaveChangedID)
+		})
+	}
+
 	return ruser, nil
 }
 
@@ -2511,6 +2582,10 @@ func (a *App) PromoteGuestToUser(rctx request.CTX, user *model.User, requestorId
 	if nErr != nil {
 		return model.NewAppError("PromoteGuestToUser", "app.user.promote_guest.user_update.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 	}
+	if nErr = a.Srv().Store().User().DeleteGuestAccessExpiry(user.Id); nErr != nil {
+		rctx.Logger().Warn("Failed to remove guest access expiry on promote guest to user", mlog.String("user_id", user.Id), mlog.Err(nErr))
+	}
+
 	userTeams, nErr := a.Srv().Store().Team().GetTeamsByUserId(user.Id)
 	if nErr != nil {
 		return model.NewAppError("PromoteGuestToUser", "app.team.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_outgoingemails_status_nextattemptat;
+DROP TABLE IF EXISTS OutgoingEmails;
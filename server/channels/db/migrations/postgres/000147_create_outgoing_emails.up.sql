@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS OutgoingEmails (
+	Id VARCHAR(26) PRIMARY KEY,
+	CreateAt bigint NOT NULL,
+	UpdateAt bigint NOT NULL,
+	ToAddress text NOT NULL,
+	Cc text,
+	ReplyTo text,
+	Subject text,
+	HTMLBody text,
+	MessageId text,
+	InReplyTo text,
+	ReferencesHeader text,
+	Category VARCHAR(64),
+	Status VARCHAR(32) NOT NULL,
+	Attempts integer NOT NULL DEFAULT 0,
+	NextAttemptAt bigint NOT NULL,
+	LastError text
+);
+
+CREATE INDEX IF NOT EXISTS idx_outgoingemails_status_nextattemptat ON OutgoingEmails (Status, NextAttemptAt);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_sharedchannelfilterlog_remoteid_createat;
+DROP TABLE IF EXISTS SharedChannelFilterLog;
+
+DROP INDEX IF EXISTS idx_sharedchannelheldposts_remoteid_status_createat;
+DROP TABLE IF EXISTS SharedChannelHeldPosts;
+
+DROP TABLE IF EXISTS SharedChannelContentPolicies;
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS SharedChannelContentPolicies (
+	RemoteId VARCHAR(26) PRIMARY KEY,
+	BlockedFileTypes text,
+	MaxFileSize bigint NOT NULL DEFAULT 0,
+	RedactPatterns text,
+	RedactReplacement text,
+	StripPermalinks boolean NOT NULL DEFAULT false,
+	RequireApproval boolean NOT NULL DEFAULT false,
+	CreatorId VARCHAR(26),
+	CreateAt bigint NOT NULL,
+	UpdateAt bigint NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS SharedChannelHeldPosts (
+	PostId VARCHAR(26) NOT NULL,
+	RemoteId VARCHAR(26) NOT NULL,
+	ChannelId VARCHAR(26) NOT NULL,
+	UserId VARCHAR(26),
+	Status VARCHAR(16) NOT NULL,
+	ReviewerId VARCHAR(26),
+	CreateAt bigint NOT NULL,
+	ReviewedAt bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY (PostId, RemoteId)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sharedchannelheldposts_remoteid_status_createat ON SharedChannelHeldPosts (RemoteId, Status, CreateAt);
+
+CREATE TABLE IF NOT EXISTS SharedChannelFilterLog (
+	Id VARCHAR(26) PRIMARY KEY,
+	RemoteId VARCHAR(26) NOT NULL,
+	ChannelId VARCHAR(26),
+	PostId VARCHAR(26),
+	FileId VARCHAR(26),
+	UserId VARCHAR(26),
+	Action VARCHAR(32) NOT NULL,
+	Details text,
+	CreateAt bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sharedchannelfilterlog_remoteid_createat ON SharedChannelFilterLog (RemoteId, CreateAt);
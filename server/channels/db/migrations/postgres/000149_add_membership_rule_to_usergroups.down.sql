@@ -0,0 +1 @@
+ALTER TABLE usergroups DROP COLUMN IF EXISTS membershiprule;
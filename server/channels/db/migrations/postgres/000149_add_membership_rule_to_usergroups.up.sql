@@ -0,0 +1 @@
+ALTER TABLE usergroups ADD COLUMN IF NOT EXISTS membershiprule text DEFAULT '';
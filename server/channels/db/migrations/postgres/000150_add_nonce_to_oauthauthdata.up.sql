@@ -0,0 +1 @@
+ALTER TABLE oauthauthdata ADD COLUMN IF NOT EXISTS nonce varchar(512) DEFAULT '';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS oauthdeviceauthorizations (
+    devicecode varchar(64) PRIMARY KEY,
+    usercode varchar(16) NOT NULL,
+    clientid varchar(26) NOT NULL,
+    userid varchar(26) DEFAULT '',
+    scope varchar(128) DEFAULT '',
+    status varchar(16) NOT NULL,
+    createat bigint NOT NULL,
+    expiresat bigint NOT NULL,
+    pollinterval integer NOT NULL,
+    lastpolledat bigint DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_oauthdeviceauthorizations_usercode ON oauthdeviceauthorizations (usercode);
+CREATE INDEX IF NOT EXISTS idx_oauthdeviceauthorizations_expiresat ON oauthdeviceauthorizations (expiresat);
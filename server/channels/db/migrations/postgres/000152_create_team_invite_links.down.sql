@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS teaminvitelinkuses;
+DROP TABLE IF EXISTS teaminvitelinks;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS teaminvitelinks (
+    id varchar(26) PRIMARY KEY,
+    teamid varchar(26) NOT NULL,
+    creatorid varchar(26) NOT NULL,
+    name varchar(64) NOT NULL,
+    role varchar(16) NOT NULL,
+    channelids jsonb NOT NULL DEFAULT '[]',
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    deleteat bigint NOT NULL DEFAULT 0,
+    expiresat bigint NOT NULL DEFAULT 0,
+    maxuses bigint NOT NULL DEFAULT 0,
+    usecount bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_teaminvitelinks_teamid ON teaminvitelinks (teamid);
+
+CREATE TABLE IF NOT EXISTS teaminvitelinkuses (
+    linkid varchar(26) NOT NULL,
+    userid varchar(26) NOT NULL,
+    useat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_teaminvitelinkuses_linkid_useat ON teaminvitelinkuses (linkid, useat);
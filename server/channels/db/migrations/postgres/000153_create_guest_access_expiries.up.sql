@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS guestaccessexpiries (
+    userid varchar(26) PRIMARY KEY,
+    expiresat bigint NOT NULL,
+    remindersentat bigint NOT NULL DEFAULT 0,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_guestaccessexpiries_expiresat ON guestaccessexpiries (expiresat);
@@ -0,0 +1,4 @@
+ALTER TABLE emoji DROP COLUMN IF EXISTS categoryid;
+
+DROP TABLE IF EXISTS emojialiases;
+DROP TABLE IF EXISTS emojicategories;
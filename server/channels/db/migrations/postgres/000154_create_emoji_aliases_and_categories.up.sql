@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS emojicategories (
+    id varchar(26) PRIMARY KEY,
+    name varchar(64) NOT NULL,
+    displayname varchar(64) NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS emojialiases (
+    alias varchar(64) PRIMARY KEY,
+    emojiid varchar(26) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_emojialiases_emojiid ON emojialiases (emojiid);
+
+ALTER TABLE emoji ADD COLUMN IF NOT EXISTS categoryid varchar(26) NOT NULL DEFAULT '';
@@ -0,0 +1,20 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package attribute_group_sync
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+const schedFreq = 1 * time.Hour
+
+func MakeScheduler(jobServer *jobs.JobServer, licenseFunc func() *model.License) *jobs.PeriodicScheduler {
+	isEnabled := func(cfg *model.Config) bool {
+		return model.MinimumEnterpriseAdvancedLicense(licenseFunc()) && *cfg.AccessControlSettings.EnableAttributeBasedAccessControl
+	}
+	return jobs.NewPeriodicScheduler(jobServer, model.JobTypeAttributeGroupSync, schedFreq, isEnabled)
+}
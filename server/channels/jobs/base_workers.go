@@ -4,10 +4,14 @@
 package jobs
 
 import (
+	"context"
 	"net/http"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 type SimpleWorker struct {
@@ -81,7 +85,9 @@ func (worker *SimpleWorker) DoJob(job *model.Job) {
 		return
 	}
 
+	_, span := tracing.StartSpan(context.Background(), "Job."+job.Type, attribute.String("job_id", job.Id))
 	err := worker.execute(logger, job)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		logger.Error("SimpleWorker: job execution error", mlog.Err(err))
 		worker.setJobError(logger, job, model.NewAppError("DoJob", "app.job.error", nil, "", http.StatusInternalServerError).Wrap(err))
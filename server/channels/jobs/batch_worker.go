@@ -4,13 +4,17 @@
 package jobs
 
 import (
+	"context"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 type BatchWorker struct {
@@ -143,7 +147,10 @@ func (worker *BatchWorker) DoJob(job *model.Job) {
 			}
 			return
 		case <-time.After(worker.timeBetweenBatches):
-			if stop := worker.doBatch(c, job); stop {
+			ctx, span := tracing.StartSpan(context.Background(), "Job."+job.Type+".Batch", attribute.String("job_id", job.Id))
+			stop := worker.doBatch(c.WithContext(ctx), job)
+			span.End()
+			if stop {
 				return
 			}
 		}
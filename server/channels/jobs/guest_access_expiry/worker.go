@@ -0,0 +1,26 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package guest_access_expiry
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+// MakeWorker returns a worker that reminds guests whose access expires soon, and deactivates the
+// guests whose access has expired.
+func MakeWorker(jobServer *jobs.JobServer, processGuestAccessExpiries func() error) *jobs.SimpleWorker {
+	const workerName = "GuestAccessExpiry"
+
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.GuestAccountsSettings.Enable
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		return processGuestAccessExpiries()
+	}
+	return jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+}
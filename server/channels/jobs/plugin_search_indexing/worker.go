@@ -0,0 +1,202 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin_search_indexing
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
+)
+
+const batchSize = 500
+
+// MakeWorker returns a worker that indexes the existing posts, files, channels and users with the
+// search engine registered by a plugin.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, getEngine func() searchengine.SearchEngineInterface) *jobs.SimpleWorker {
+	const workerName = "PluginSearchIndexing"
+
+	isEnabled := func(_ *model.Config) bool {
+		return true
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		engine := getEngine()
+		if engine == nil || !engine.IsActive() {
+			return errors.New("no plugin is registered as an active search engine")
+		}
+
+		indexer := &indexer{
+			rctx:    request.EmptyContext(logger),
+			store:   store,
+			engine:  engine,
+			endTime: model.GetMillis(),
+		}
+
+		steps := []func() error{indexer.indexPosts, indexer.indexFiles, indexer.indexChannels, indexer.indexUsers}
+		for i, step := range steps {
+			if err := step(); err != nil {
+				return err
+			}
+			if err := jobServer.SetJobProgress(job, int64((i+1)*100/len(steps))); err != nil {
+				logger.Warn("Failed to set the progress of the job", mlog.Err(err))
+			}
+		}
+
+		return nil
+	}
+	return jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+}
+
+// indexer indexes the entities created until endTime, in batches ordered by creation.
+type indexer struct {
+	rctx    request.CTX
+	store   store.Store
+	engine  searchengine.SearchEngineInterface
+	endTime int64
+}
+
+func (i *indexer) indexPosts() error {
+	var startTime int64
+	var startID string
+	for {
+		posts, err := i.store.Post().GetPostsBatchForIndexing(startTime, startID, batchSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the posts to index")
+		}
+		if len(posts) == 0 {
+			return nil
+		}
+
+		for _, post := range posts {
+			var appErr *model.AppError
+			if post.DeleteAt == 0 {
+				appErr = i.engine.IndexPost(&post.Post, post.TeamId)
+			} else {
+				appErr = i.engine.DeletePost(&post.Post)
+			}
+			if appErr != nil {
+				return errors.Wrapf(appErr, "failed to index the post %s", post.Id)
+			}
+		}
+
+		last := posts[len(posts)-1]
+		if last.CreateAt >= i.endTime {
+			return nil
+		}
+		startTime, startID = last.CreateAt, last.Id
+	}
+}
+
+func (i *indexer) indexFiles() error {
+	var startTime int64
+	var startID string
+	for {
+		files, err := i.store.FileInfo().GetFilesBatchForIndexing(startTime, startID, true, batchSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the files to index")
+		}
+		if len(files) == 0 {
+			return nil
+		}
+
+		for _, file := range files {
+			var appErr *model.AppError
+			if file.ShouldIndex() {
+				appErr = i.engine.IndexFile(&file.FileInfo, file.ChannelId)
+			} else {
+				appErr = i.engine.DeleteFile(file.Id)
+			}
+			if appErr != nil {
+				return errors.Wrapf(appErr, "failed to index the file %s", file.Id)
+			}
+		}
+
+		last := files[len(files)-1]
+		if last.CreateAt >= i.endTime {
+			return nil
+		}
+		startTime, startID = last.CreateAt, last.Id
+	}
+}
+
+func (i *indexer) indexChannels() error {
+	var startTime int64
+	var startID string
+	for {
+		channels, err := i.store.Channel().GetChannelsBatchForIndexing(startTime, startID, batchSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the channels to index")
+		}
+		if len(channels) == 0 {
+			return nil
+		}
+
+		for _, channel := range channels {
+			var userIDs []string
+			if channel.Type == model.ChannelTypePrivate {
+				userIDs, err = i.store.Channel().GetAllChannelMemberIdsByChannelId(channel.Id)
+				if err != nil {
+					return errors.Wrapf(err, "failed to get the members of the channel %s", channel.Id)
+				}
+			}
+
+			teamMemberIDs, err := i.store.Channel().GetTeamMembersForChannel(i.rctx, channel.Id)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get the team members of the channel %s", channel.Id)
+			}
+
+			if appErr := i.engine.IndexChannel(i.rctx, channel, userIDs, teamMemberIDs); appErr != nil {
+				return errors.Wrapf(appErr, "failed to index the channel %s", channel.Id)
+			}
+		}
+
+		last := channels[len(channels)-1]
+		if last.CreateAt >= i.endTime {
+			return nil
+		}
+		startTime, startID = last.CreateAt, last.Id
+	}
+}
+
+func (i *indexer) indexUsers() error {
+	var startTime int64
+	var startID string
+	for {
+		users, err := i.store.User().GetUsersBatchForIndexing(startTime, startID, batchSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the users to index")
+		}
+		if len(users) == 0 {
+			return nil
+		}
+
+		for _, user := range users {
+			indexedUser := &model.User{
+				Id:        user.Id,
+				Username:  user.Username,
+				Nickname:  user.Nickname,
+				FirstName: user.FirstName,
+				LastName:  user.LastName,
+				Roles:     user.Roles,
+				CreateAt:  user.CreateAt,
+				DeleteAt:  user.DeleteAt,
+			}
+			if appErr := i.engine.IndexUser(i.rctx, indexedUser, user.TeamsIds, user.ChannelsIds); appErr != nil {
+				return errors.Wrapf(appErr, "failed to index the user %s", user.Id)
+			}
+		}
+
+		last := users[len(users)-1]
+		if last.CreateAt >= i.endTime {
+			return nil
+		}
+		startTime, startID = last.CreateAt, last.Id
+	}
+}
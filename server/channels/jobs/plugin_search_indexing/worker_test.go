@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin_search_indexing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/mocks"
+)
+
+func TestIndexer(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(t)
+
+	t.Run("posts are indexed in batches until the end time", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		engine := &mocks.SearchEngineInterface{}
+
+		post1 := &model.PostForIndexing{Post: model.Post{Id: "post1", CreateAt: 10}, TeamId: "team"}
+		post2 := &model.PostForIndexing{Post: model.Post{Id: "post2", CreateAt: 20, DeleteAt: 30}, TeamId: "team"}
+		post3 := &model.PostForIndexing{Post: model.Post{Id: "post3", CreateAt: 200}, TeamId: "team"}
+		mockStore.PostStore.On("GetPostsBatchForIndexing", int64(0), "", batchSize).Return([]*model.PostForIndexing{post1, post2}, nil)
+		mockStore.PostStore.On("GetPostsBatchForIndexing", int64(20), "post2", batchSize).Return([]*model.PostForIndexing{post3}, nil)
+		engine.On("IndexPost", &post1.Post, "team").Return(nil).Once()
+		engine.On("DeletePost", &post2.Post).Return(nil).Once()
+		engine.On("IndexPost", &post3.Post, "team").Return(nil).Once()
+
+		indexer := &indexer{rctx: request.EmptyContext(logger), store: mockStore, engine: engine, endTime: 100}
+		require.NoError(t, indexer.indexPosts())
+
+		mockStore.PostStore.AssertExpectations(t)
+		engine.AssertExpectations(t)
+	})
+
+	t.Run("only the files that should be indexed are indexed", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		engine := &mocks.SearchEngineInterface{}
+
+		file1 := &model.FileForIndexing{FileInfo: model.FileInfo{Id: "file1", PostId: "post", CreateAt: 10}, ChannelId: "channel"}
+		file2 := &model.FileForIndexing{FileInfo: model.FileInfo{Id: "file2", CreateAt: 20}, ChannelId: "channel"}
+		mockStore.FileInfoStore.On("GetFilesBatchForIndexing", int64(0), "", true, batchSize).Return([]*model.FileForIndexing{file1, file2}, nil)
+		mockStore.FileInfoStore.On("GetFilesBatchForIndexing", int64(20), "file2", true, batchSize).Return([]*model.FileForIndexing{}, nil)
+		engine.On("IndexFile", &file1.FileInfo, "channel").Return(nil).Once()
+		engine.On("DeleteFile", "file2").Return(nil).Once()
+
+		indexer := &indexer{rctx: request.EmptyContext(logger), store: mockStore, engine: engine, endTime: 100}
+		require.NoError(t, indexer.indexFiles())
+
+		engine.AssertExpectations(t)
+	})
+
+	t.Run("the members of private channels are indexed", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		engine := &mocks.SearchEngineInterface{}
+
+		open := &model.Channel{Id: "open", Type: model.ChannelTypeOpen, CreateAt: 10}
+		private := &model.Channel{Id: "private", Type: model.ChannelTypePrivate, CreateAt: 20}
+		mockStore.ChannelStore.On("GetChannelsBatchForIndexing", int64(0), "", batchSize).Return([]*model.Channel{open, private}, nil)
+		mockStore.ChannelStore.On("GetChannelsBatchForIndexing", int64(20), "private", batchSize).Return([]*model.Channel{}, nil)
+		mockStore.ChannelStore.On("GetAllChannelMemberIdsByChannelId", "private").Return([]string{"user1"}, nil)
+		mockStore.ChannelStore.On("GetTeamMembersForChannel", mock.Anything, mock.Anything).Return([]string{"user1", "user2"}, nil)
+		engine.On("IndexChannel", mock.Anything, open, []string(nil), []string{"user1", "user2"}).Return(nil).Once()
+		engine.On("IndexChannel", mock.Anything, private, []string{"user1"}, []string{"user1", "user2"}).Return(nil).Once()
+
+		indexer := &indexer{rctx: request.EmptyContext(logger), store: mockStore, engine: engine, endTime: 100}
+		require.NoError(t, indexer.indexChannels())
+
+		engine.AssertExpectations(t)
+	})
+
+	t.Run("indexing errors stop the job", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		engine := &mocks.SearchEngineInterface{}
+
+		user := &model.UserForIndexing{Id: "user", Username: "username", CreateAt: 10, TeamsIds: []string{"team"}}
+		mockStore.UserStore.On("GetUsersBatchForIndexing", int64(0), "", batchSize).Return([]*model.UserForIndexing{user}, nil)
+		engine.On("IndexUser", mock.Anything, &model.User{Id: "user", Username: "username", CreateAt: 10}, []string{"team"}, []string(nil)).Return(model.NewAppError("IndexUser", "id", nil, "", 500))
+
+		indexer := &indexer{rctx: request.EmptyContext(logger), store: mockStore, engine: engine, endTime: 100}
+		require.ErrorContains(t, indexer.indexUsers(), "failed to index the user user")
+	})
+}
@@ -83,6 +83,18 @@ Provides performance monitoring and metrics:
 - Integration with Prometheus metrics
 - Performance bottleneck identification
 
+## Fault Injection Layer (faultinjectionlayer/)
+Simulates a slow or failing database for resilience testing:
+- Latency, errors and serialization failures at configurable rates
+- Faults selected per store method, per store or for all the methods
+- Only wrapped with the StoreFaultInjection feature flag, controlled through local mode
+
+## Tracing Layer (tracinglayer/)
+Provides OpenTelemetry spans for the store methods:
+- Spans for the methods called with the context of a traced request
+- Method errors recorded on the spans
+- Export through the tracing service configured in MetricsSettings
+
 ## Store Test Framework (storetest/)
 Comprehensive testing infrastructure:
 - Mock implementations for all store interfaces
@@ -0,0 +1,9909 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package faultinjectionlayer
+
+import (
+	"context"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type FaultInjectionLayer struct {
+	store.Store
+	Injector                        *Injector
+	AccessControlPolicyStore        store.AccessControlPolicyStore
+	AttributesStore                 store.AttributesStore
+	AuditStore                      store.AuditStore
+	BotStore                        store.BotStore
+	ChannelStore                    store.ChannelStore
+	ChannelBookmarkStore            store.ChannelBookmarkStore
+	ChannelMemberHistoryStore       store.ChannelMemberHistoryStore
+	ClusterDiscoveryStore           store.ClusterDiscoveryStore
+	CommandStore                    store.CommandStore
+	CommandWebhookStore             store.CommandWebhookStore
+	ComplianceStore                 store.ComplianceStore
+	ContentFlaggingStore            store.ContentFlaggingStore
+	DesktopTokensStore              store.DesktopTokensStore
+	DraftStore                      store.DraftStore
+	EmojiStore                      store.EmojiStore
+	FileInfoStore                   store.FileInfoStore
+	GroupStore                      store.GroupStore
+	JobStore                        store.JobStore
+	LicenseStore                    store.LicenseStore
+	LinkMetadataStore               store.LinkMetadataStore
+	NotifyAdminStore                store.NotifyAdminStore
+	OAuthStore                      store.OAuthStore
+	OutgoingEmailStore              store.OutgoingEmailStore
+	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
+	PluginStore                     store.PluginStore
+	PostStore                       store.PostStore
+	PostAcknowledgementStore        store.PostAcknowledgementStore
+	PostPersistentNotificationStore store.PostPersistentNotificationStore
+	PostPriorityStore               store.PostPriorityStore
+	PreferenceStore                 store.PreferenceStore
+	ProductNoticesStore             store.ProductNoticesStore
+	PropertyFieldStore              store.PropertyFieldStore
+	PropertyGroupStore              store.PropertyGroupStore
+	PropertyValueStore              store.PropertyValueStore
+	ReactionStore                   store.ReactionStore
+	RemoteClusterStore              store.RemoteClusterStore
+	RetentionPolicyStore            store.RetentionPolicyStore
+	RoleStore                       store.RoleStore
+	ScheduledPostStore              store.ScheduledPostStore
+	SchemeStore                     store.SchemeStore
+	SessionStore                    store.SessionStore
+	SharedChannelStore              store.SharedChannelStore
+	StatusStore                     store.StatusStore
+	SystemStore                     store.SystemStore
+	TeamStore                       store.TeamStore
+	TermsOfServiceStore             store.TermsOfServiceStore
+	ThreadStore                     store.ThreadStore
+	TokenStore                      store.TokenStore
+	UploadSessionStore              store.UploadSessionStore
+	UserStore                       store.UserStore
+	UserAccessTokenStore            store.UserAccessTokenStore
+	UserTermsOfServiceStore         store.UserTermsOfServiceStore
+	WebhookStore                    store.WebhookStore
+}
+
+func (s *FaultInjectionLayer) AccessControlPolicy() store.AccessControlPolicyStore {
+	return s.AccessControlPolicyStore
+}
+
+func (s *FaultInjectionLayer) Attributes() store.AttributesStore {
+	return s.AttributesStore
+}
+
+func (s *FaultInjectionLayer) Audit() store.AuditStore {
+	return s.AuditStore
+}
+
+func (s *FaultInjectionLayer) Bot() store.BotStore {
+	return s.BotStore
+}
+
+func (s *FaultInjectionLayer) Channel() store.ChannelStore {
+	return s.ChannelStore
+}
+
+func (s *FaultInjectionLayer) ChannelBookmark() store.ChannelBookmarkStore {
+	return s.ChannelBookmarkStore
+}
+
+func (s *FaultInjectionLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
+	return s.ChannelMemberHistoryStore
+}
+
+func (s *FaultInjectionLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
+	return s.ClusterDiscoveryStore
+}
+
+func (s *FaultInjectionLayer) Command() store.CommandStore {
+	return s.CommandStore
+}
+
+func (s *FaultInjectionLayer) CommandWebhook() store.CommandWebhookStore {
+	return s.CommandWebhookStore
+}
+
+func (s *FaultInjectionLayer) Compliance() store.ComplianceStore {
+	return s.ComplianceStore
+}
+
+func (s *FaultInjectionLayer) ContentFlagging() store.ContentFlaggingStore {
+	return s.ContentFlaggingStore
+}
+
+func (s *FaultInjectionLayer) DesktopTokens() store.DesktopTokensStore {
+	return s.DesktopTokensStore
+}
+
+func (s *FaultInjectionLayer) Draft() store.DraftStore {
+	return s.DraftStore
+}
+
+func (s *FaultInjectionLayer) Emoji() store.EmojiStore {
+	return s.EmojiStore
+}
+
+func (s *FaultInjectionLayer) FileInfo() store.FileInfoStore {
+	return s.FileInfoStore
+}
+
+func (s *FaultInjectionLayer) Group() store.GroupStore {
+	return s.GroupStore
+}
+
+func (s *FaultInjectionLayer) Job() store.JobStore {
+	return s.JobStore
+}
+
+func (s *FaultInjectionLayer) License() store.LicenseStore {
+	return s.LicenseStore
+}
+
+func (s *FaultInjectionLayer) LinkMetadata() store.LinkMetadataStore {
+	return s.LinkMetadataStore
+}
+
+func (s *FaultInjectionLayer) NotifyAdmin() store.NotifyAdminStore {
+	return s.NotifyAdminStore
+}
+
+func (s *FaultInjectionLayer) OAuth() store.OAuthStore {
+	return s.OAuthStore
+}
+
+func (s *FaultInjectionLayer) OutgoingEmail() store.OutgoingEmailStore {
+	return s.OutgoingEmailStore
+}
+
+func (s *FaultInjectionLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
+	return s.OutgoingOAuthConnectionStore
+}
+
+func (s *FaultInjectionLayer) Plugin() store.PluginStore {
+	return s.PluginStore
+}
+
+func (s *FaultInjectionLayer) Post() store.PostStore {
+	return s.PostStore
+}
+
+func (s *FaultInjectionLayer) PostAcknowledgement() store.PostAcknowledgementStore {
+	return s.PostAcknowledgementStore
+}
+
+func (s *FaultInjectionLayer) PostPersistentNotification() store.PostPersistentNotificationStore {
+	return s.PostPersistentNotificationStore
+}
+
+func (s *FaultInjectionLayer) PostPriority() store.PostPriorityStore {
+	return s.PostPriorityStore
+}
+
+func (s *FaultInjectionLayer) Preference() store.PreferenceStore {
+	return s.PreferenceStore
+}
+
+func (s *FaultInjectionLayer) ProductNotices() store.ProductNoticesStore {
+	return s.ProductNoticesStore
+}
+
+func (s *FaultInjectionLayer) PropertyField() store.PropertyFieldStore {
+	return s.PropertyFieldStore
+}
+
+func (s *FaultInjectionLayer) PropertyGroup() store.PropertyGroupStore {
+	return s.PropertyGroupStore
+}
+
+func (s *FaultInjectionLayer) PropertyValue() store.PropertyValueStore {
+	return s.PropertyValueStore
+}
+
+func (s *FaultInjectionLayer) Reaction() store.ReactionStore {
+	return s.ReactionStore
+}
+
+func (s *FaultInjectionLayer) RemoteCluster() store.RemoteClusterStore {
+	return s.RemoteClusterStore
+}
+
+func (s *FaultInjectionLayer) RetentionPolicy() store.RetentionPolicyStore {
+	return s.RetentionPolicyStore
+}
+
+func (s *FaultInjectionLayer) Role() store.RoleStore {
+	return s.RoleStore
+}
+
+func (s *FaultInjectionLayer) ScheduledPost() store.ScheduledPostStore {
+	return s.ScheduledPostStore
+}
+
+func (s *FaultInjectionLayer) Scheme() store.SchemeStore {
+	return s.SchemeStore
+}
+
+func (s *FaultInjectionLayer) Session() store.SessionStore {
+	return s.SessionStore
+}
+
+func (s *FaultInjectionLayer) SharedChannel() store.SharedChannelStore {
+	return s.SharedChannelStore
+}
+
+func (s *FaultInjectionLayer) Status() store.StatusStore {
+	return s.StatusStore
+}
+
+func (s *FaultInjectionLayer) System() store.SystemStore {
+	return s.SystemStore
+}
+
+func (s *FaultInjectionLayer) Team() store.TeamStore {
+	return s.TeamStore
+}
+
+func (s *FaultInjectionLayer) TermsOfService() store.TermsOfServiceStore {
+	return s.TermsOfServiceStore
+}
+
+func (s *FaultInjectionLayer) Thread() store.ThreadStore {
+	return s.ThreadStore
+}
+
+func (s *FaultInjectionLayer) Token() store.TokenStore {
+	return s.TokenStore
+}
+
+func (s *FaultInjectionLayer) UploadSession() store.UploadSessionStore {
+	return s.UploadSessionStore
+}
+
+func (s *FaultInjectionLayer) User() store.UserStore {
+	return s.UserStore
+}
+
+func (s *FaultInjectionLayer) UserAccessToken() store.UserAccessTokenStore {
+	return s.UserAccessTokenStore
+}
+
+func (s *FaultInjectionLayer) UserTermsOfService() store.UserTermsOfServiceStore {
+	return s.UserTermsOfServiceStore
+}
+
+func (s *FaultInjectionLayer) Webhook() store.WebhookStore {
+	return s.WebhookStore
+}
+
+type FaultInjectionLayerAccessControlPolicyStore struct {
+	store.AccessControlPolicyStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerAttributesStore struct {
+	store.AttributesStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerAuditStore struct {
+	store.AuditStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerBotStore struct {
+	store.BotStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerChannelStore struct {
+	store.ChannelStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerChannelBookmarkStore struct {
+	store.ChannelBookmarkStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerChannelMemberHistoryStore struct {
+	store.ChannelMemberHistoryStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerClusterDiscoveryStore struct {
+	store.ClusterDiscoveryStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerCommandStore struct {
+	store.CommandStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerCommandWebhookStore struct {
+	store.CommandWebhookStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerComplianceStore struct {
+	store.ComplianceStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerContentFlaggingStore struct {
+	store.ContentFlaggingStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerDesktopTokensStore struct {
+	store.DesktopTokensStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerDraftStore struct {
+	store.DraftStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerEmojiStore struct {
+	store.EmojiStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerFileInfoStore struct {
+	store.FileInfoStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerGroupStore struct {
+	store.GroupStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerJobStore struct {
+	store.JobStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerLicenseStore struct {
+	store.LicenseStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerLinkMetadataStore struct {
+	store.LinkMetadataStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerNotifyAdminStore struct {
+	store.NotifyAdminStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerOAuthStore struct {
+	store.OAuthStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerOutgoingEmailStore struct {
+	store.OutgoingEmailStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerOutgoingOAuthConnectionStore struct {
+	store.OutgoingOAuthConnectionStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerPluginStore struct {
+	store.PluginStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerPostStore struct {
+	store.PostStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerPostAcknowledgementStore struct {
+	store.PostAcknowledgementStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerPostPersistentNotificationStore struct {
+	store.PostPersistentNotificationStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerPostPriorityStore struct {
+	store.PostPriorityStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerPreferenceStore struct {
+	store.PreferenceStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerProductNoticesStore struct {
+	store.ProductNoticesStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerPropertyFieldStore struct {
+	store.PropertyFieldStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerPropertyGroupStore struct {
+	store.PropertyGroupStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerPropertyValueStore struct {
+	store.PropertyValueStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerReactionStore struct {
+	store.ReactionStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerRemoteClusterStore struct {
+	store.RemoteClusterStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerRetentionPolicyStore struct {
+	store.RetentionPolicyStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerRoleStore struct {
+	store.RoleStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerScheduledPostStore struct {
+	store.ScheduledPostStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerSchemeStore struct {
+	store.SchemeStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerSessionStore struct {
+	store.SessionStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerSharedChannelStore struct {
+	store.SharedChannelStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerStatusStore struct {
+	store.StatusStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerSystemStore struct {
+	store.SystemStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerTeamStore struct {
+	store.TeamStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerTermsOfServiceStore struct {
+	store.TermsOfServiceStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerThreadStore struct {
+	store.ThreadStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerTokenStore struct {
+	store.TokenStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerUploadSessionStore struct {
+	store.UploadSessionStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerUserStore struct {
+	store.UserStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerUserAccessTokenStore struct {
+	store.UserAccessTokenStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerUserTermsOfServiceStore struct {
+	store.UserTermsOfServiceStore
+	Root *FaultInjectionLayer
+}
+
+type FaultInjectionLayerWebhookStore struct {
+	store.WebhookStore
+	Root *FaultInjectionLayer
+}
+
+func (s *FaultInjectionLayerAccessControlPolicyStore) Delete(rctx request.CTX, id string) error {
+
+	if err := s.Root.Injector.inject("AccessControlPolicyStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.AccessControlPolicyStore.Delete(rctx, id)
+
+}
+
+func (s *FaultInjectionLayerAccessControlPolicyStore) Get(rctx request.CTX, id string) (*model.AccessControlPolicy, error) {
+
+	if err := s.Root.Injector.inject("AccessControlPolicyStore.Get"); err != nil {
+		var result *model.AccessControlPolicy
+		return result, err
+	}
+
+	return s.AccessControlPolicyStore.Get(rctx, id)
+
+}
+
+func (s *FaultInjectionLayerAccessControlPolicyStore) Save(rctx request.CTX, policy *model.AccessControlPolicy) (*model.AccessControlPolicy, error) {
+
+	if err := s.Root.Injector.inject("AccessControlPolicyStore.Save"); err != nil {
+		var result *model.AccessControlPolicy
+		return result, err
+	}
+
+	return s.AccessControlPolicyStore.Save(rctx, policy)
+
+}
+
+func (s *FaultInjectionLayerAccessControlPolicyStore) SearchPolicies(rctx request.CTX, opts model.AccessControlPolicySearch) ([]*model.AccessControlPolicy, int64, error) {
+
+	if err := s.Root.Injector.inject("AccessControlPolicyStore.SearchPolicies"); err != nil {
+		var result []*model.AccessControlPolicy
+		var resultVar1 int64
+		return result, resultVar1, err
+	}
+
+	return s.AccessControlPolicyStore.SearchPolicies(rctx, opts)
+
+}
+
+func (s *FaultInjectionLayerAccessControlPolicyStore) SetActiveStatus(rctx request.CTX, id string, active bool) (*model.AccessControlPolicy, error) {
+
+	if err := s.Root.Injector.inject("AccessControlPolicyStore.SetActiveStatus"); err != nil {
+		var result *model.AccessControlPolicy
+		return result, err
+	}
+
+	return s.AccessControlPolicyStore.SetActiveStatus(rctx, id, active)
+
+}
+
+func (s *FaultInjectionLayerAttributesStore) GetChannelMembersToRemove(rctx request.CTX, channelID string, opts model.SubjectSearchOptions) ([]*model.ChannelMember, error) {
+
+	if err := s.Root.Injector.inject("AttributesStore.GetChannelMembersToRemove"); err != nil {
+		var result []*model.ChannelMember
+		return result, err
+	}
+
+	return s.AttributesStore.GetChannelMembersToRemove(rctx, channelID, opts)
+
+}
+
+func (s *FaultInjectionLayerAttributesStore) GetSubject(rctx request.CTX, ID string, groupID string) (*model.Subject, error) {
+
+	if err := s.Root.Injector.inject("AttributesStore.GetSubject"); err != nil {
+		var result *model.Subject
+		return result, err
+	}
+
+	return s.AttributesStore.GetSubject(rctx, ID, groupID)
+
+}
+
+func (s *FaultInjectionLayerAttributesStore) RefreshAttributes() error {
+
+	if err := s.Root.Injector.inject("AttributesStore.RefreshAttributes"); err != nil {
+
+		return err
+	}
+
+	return s.AttributesStore.RefreshAttributes()
+
+}
+
+func (s *FaultInjectionLayerAttributesStore) SearchUsers(rctx request.CTX, opts model.SubjectSearchOptions) ([]*model.User, int64, error) {
+
+	if err := s.Root.Injector.inject("AttributesStore.SearchUsers"); err != nil {
+		var result []*model.User
+		var resultVar1 int64
+		return result, resultVar1, err
+	}
+
+	return s.AttributesStore.SearchUsers(rctx, opts)
+
+}
+
+func (s *FaultInjectionLayerAuditStore) Get(userID string, offset int, limit int) (model.Audits, error) {
+
+	if err := s.Root.Injector.inject("AuditStore.Get"); err != nil {
+		var result model.Audits
+		return result, err
+	}
+
+	return s.AuditStore.Get(userID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerAuditStore) PermanentDeleteByUser(userID string) error {
+
+	if err := s.Root.Injector.inject("AuditStore.PermanentDeleteByUser"); err != nil {
+
+		return err
+	}
+
+	return s.AuditStore.PermanentDeleteByUser(userID)
+
+}
+
+func (s *FaultInjectionLayerAuditStore) Save(audit *model.Audit) error {
+
+	if err := s.Root.Injector.inject("AuditStore.Save"); err != nil {
+
+		return err
+	}
+
+	return s.AuditStore.Save(audit)
+
+}
+
+func (s *FaultInjectionLayerBotStore) Get(userID string, includeDeleted bool) (*model.Bot, error) {
+
+	if err := s.Root.Injector.inject("BotStore.Get"); err != nil {
+		var result *model.Bot
+		return result, err
+	}
+
+	return s.BotStore.Get(userID, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, error) {
+
+	if err := s.Root.Injector.inject("BotStore.GetAll"); err != nil {
+		var result []*model.Bot
+		return result, err
+	}
+
+	return s.BotStore.GetAll(options)
+
+}
+
+func (s *FaultInjectionLayerBotStore) GetAllAfter(limit int, afterId string) ([]*model.Bot, error) {
+
+	if err := s.Root.Injector.inject("BotStore.GetAllAfter"); err != nil {
+		var result []*model.Bot
+		return result, err
+	}
+
+	return s.BotStore.GetAllAfter(limit, afterId)
+
+}
+
+func (s *FaultInjectionLayerBotStore) GetByUsername(username string) (*model.Bot, error) {
+
+	if err := s.Root.Injector.inject("BotStore.GetByUsername"); err != nil {
+		var result *model.Bot
+		return result, err
+	}
+
+	return s.BotStore.GetByUsername(username)
+
+}
+
+func (s *FaultInjectionLayerBotStore) PermanentDelete(userID string) error {
+
+	if err := s.Root.Injector.inject("BotStore.PermanentDelete"); err != nil {
+
+		return err
+	}
+
+	return s.BotStore.PermanentDelete(userID)
+
+}
+
+func (s *FaultInjectionLayerBotStore) Save(bot *model.Bot) (*model.Bot, error) {
+
+	if err := s.Root.Injector.inject("BotStore.Save"); err != nil {
+		var result *model.Bot
+		return result, err
+	}
+
+	return s.BotStore.Save(bot)
+
+}
+
+func (s *FaultInjectionLayerBotStore) Update(bot *model.Bot) (*model.Bot, error) {
+
+	if err := s.Root.Injector.inject("BotStore.Update"); err != nil {
+		var result *model.Bot
+		return result, err
+	}
+
+	return s.BotStore.Update(bot)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) AnalyticsCountAll(teamID string) (map[model.ChannelType]int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.AnalyticsCountAll"); err != nil {
+		var result map[model.ChannelType]int64
+		return result, err
+	}
+
+	return s.ChannelStore.AnalyticsCountAll(teamID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) AnalyticsDeletedTypeCount(teamID string, channelType model.ChannelType) (int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.AnalyticsDeletedTypeCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ChannelStore.AnalyticsDeletedTypeCount(teamID, channelType)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) AnalyticsTypeCount(teamID string, channelType model.ChannelType) (int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.AnalyticsTypeCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ChannelStore.AnalyticsTypeCount(teamID, channelType)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) Autocomplete(rctx request.CTX, userID string, term string, includeDeleted bool, isGuest bool) (model.ChannelListWithTeamData, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.Autocomplete"); err != nil {
+		var result model.ChannelListWithTeamData
+		return result, err
+	}
+
+	return s.ChannelStore.Autocomplete(rctx, userID, term, includeDeleted, isGuest)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) AutocompleteInTeam(rctx request.CTX, teamID string, userID string, term string, includeDeleted bool, isGuest bool) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.AutocompleteInTeam"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.AutocompleteInTeam(rctx, teamID, userID, term, includeDeleted, isGuest)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) AutocompleteInTeamForSearch(teamID string, userID string, term string, includeDeleted bool) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.AutocompleteInTeamForSearch"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.AutocompleteInTeamForSearch(teamID, userID, term, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) ClearAllCustomRoleAssignments() error {
+
+	if err := s.Root.Injector.inject("ChannelStore.ClearAllCustomRoleAssignments"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.ClearAllCustomRoleAssignments()
+
+}
+
+func (s *FaultInjectionLayerChannelStore) ClearCaches() {
+
+	s.Root.Injector.delay("ChannelStore.ClearCaches")
+
+	s.ChannelStore.ClearCaches()
+
+}
+
+func (s *FaultInjectionLayerChannelStore) ClearMembersForUserCache() {
+
+	s.Root.Injector.delay("ChannelStore.ClearMembersForUserCache")
+
+	s.ChannelStore.ClearMembersForUserCache()
+
+}
+
+func (s *FaultInjectionLayerChannelStore) ClearSidebarOnTeamLeave(userID string, teamID string) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.ClearSidebarOnTeamLeave"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.ClearSidebarOnTeamLeave(userID, teamID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) CountPostsAfter(channelID string, timestamp int64, excludedUserID string) (int, int, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.CountPostsAfter"); err != nil {
+		var result int
+		var resultVar1 int
+		return result, resultVar1, err
+	}
+
+	return s.ChannelStore.CountPostsAfter(channelID, timestamp, excludedUserID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) CountUrgentPostsAfter(channelID string, timestamp int64, excludedUserID string) (int, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.CountUrgentPostsAfter"); err != nil {
+		var result int
+		return result, err
+	}
+
+	return s.ChannelStore.CountUrgentPostsAfter(channelID, timestamp, excludedUserID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) CreateDirectChannel(rctx request.CTX, userID *model.User, otherUserID *model.User, channelOptions ...model.ChannelOption) (*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.CreateDirectChannel"); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.CreateDirectChannel(rctx, userID, otherUserID, channelOptions...)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) CreateInitialSidebarCategories(rctx request.CTX, userID string, teamID string) (*model.OrderedSidebarCategories, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.CreateInitialSidebarCategories"); err != nil {
+		var result *model.OrderedSidebarCategories
+		return result, err
+	}
+
+	return s.ChannelStore.CreateInitialSidebarCategories(rctx, userID, teamID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) CreateSidebarCategory(userID string, teamID string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.CreateSidebarCategory"); err != nil {
+		var result *model.SidebarCategoryWithChannels
+		return result, err
+	}
+
+	return s.ChannelStore.CreateSidebarCategory(userID, teamID, newCategory)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) Delete(channelID string, timestamp int64) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.Delete(channelID, timestamp)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) DeleteAllSidebarChannelForChannel(channelID string) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.DeleteAllSidebarChannelForChannel"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.DeleteAllSidebarChannelForChannel(channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) DeleteSidebarCategory(categoryID string) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.DeleteSidebarCategory"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.DeleteSidebarCategory(categoryID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) DeleteSidebarChannelsByPreferences(preferences model.Preferences) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.DeleteSidebarChannelsByPreferences"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.DeleteSidebarChannelsByPreferences(preferences)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) Get(id string, allowFromCache bool) (*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.Get"); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.Get(id, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetAll(teamID string) ([]*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetAll"); err != nil {
+		var result []*model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.GetAll(teamID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetAllChannelMemberIdsByChannelId(id string) ([]string, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetAllChannelMemberIdsByChannelId"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.ChannelStore.GetAllChannelMemberIdsByChannelId(id)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetAllChannelMembersForUser(rctx request.CTX, userID string, allowFromCache bool, includeDeleted bool) (map[string]string, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetAllChannelMembersForUser"); err != nil {
+		var result map[string]string
+		return result, err
+	}
+
+	return s.ChannelStore.GetAllChannelMembersForUser(rctx, userID, allowFromCache, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetAllChannelMembersNotifyPropsForChannel(channelID string, allowFromCache bool) (map[string]model.StringMap, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetAllChannelMembersNotifyPropsForChannel"); err != nil {
+		var result map[string]model.StringMap
+		return result, err
+	}
+
+	return s.ChannelStore.GetAllChannelMembersNotifyPropsForChannel(channelID, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetAllChannels(page int, perPage int, opts store.ChannelSearchOpts) (model.ChannelListWithTeamData, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetAllChannels"); err != nil {
+		var result model.ChannelListWithTeamData
+		return result, err
+	}
+
+	return s.ChannelStore.GetAllChannels(page, perPage, opts)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetAllChannelsCount(opts store.ChannelSearchOpts) (int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetAllChannelsCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ChannelStore.GetAllChannelsCount(opts)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetAllChannelsForExportAfter(limit int, afterID string) ([]*model.ChannelForExport, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetAllChannelsForExportAfter"); err != nil {
+		var result []*model.ChannelForExport
+		return result, err
+	}
+
+	return s.ChannelStore.GetAllChannelsForExportAfter(limit, afterID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetAllDirectChannelsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectChannelForExport, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetAllDirectChannelsForExportAfter"); err != nil {
+		var result []*model.DirectChannelForExport
+		return result, err
+	}
+
+	return s.ChannelStore.GetAllDirectChannelsForExportAfter(limit, afterID, includeArchivedChannels)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetByName(teamID string, name string, allowFromCache bool) (*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetByName"); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.GetByName(teamID, name, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetByNameIncludeDeleted(teamID string, name string, allowFromCache bool) (*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetByNameIncludeDeleted"); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.GetByNameIncludeDeleted(teamID, name, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetByNames(teamID string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetByNames"); err != nil {
+		var result []*model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.GetByNames(teamID, names, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetByNamesIncludeDeleted(teamID string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetByNamesIncludeDeleted"); err != nil {
+		var result []*model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.GetByNamesIncludeDeleted(teamID, names, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetChannelCounts(teamID string, userID string) (*model.ChannelCounts, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetChannelCounts"); err != nil {
+		var result *model.ChannelCounts
+		return result, err
+	}
+
+	return s.ChannelStore.GetChannelCounts(teamID, userID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetChannelMembersForExport(userID string, teamID string, includeArchivedChannel bool) ([]*model.ChannelMemberForExport, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetChannelMembersForExport"); err != nil {
+		var result []*model.ChannelMemberForExport
+		return result, err
+	}
+
+	return s.ChannelStore.GetChannelMembersForExport(userID, teamID, includeArchivedChannel)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetChannelMembersTimezones(channelID string) ([]model.StringMap, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetChannelMembersTimezones"); err != nil {
+		var result []model.StringMap
+		return result, err
+	}
+
+	return s.ChannelStore.GetChannelMembersTimezones(channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetChannelUnread(channelID string, userID string) (*model.ChannelUnread, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetChannelUnread"); err != nil {
+		var result *model.ChannelUnread
+		return result, err
+	}
+
+	return s.ChannelStore.GetChannelUnread(channelID, userID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetChannels(teamID string, userID string, opts *model.ChannelSearchOpts) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetChannels"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.GetChannels(teamID, userID, opts)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetChannelsBatchForIndexing(startTime int64, startChannelID string, limit int) ([]*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetChannelsBatchForIndexing"); err != nil {
+		var result []*model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.GetChannelsBatchForIndexing(startTime, startChannelID, limit)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetChannelsByIds(channelIds []string, includeDeleted bool) ([]*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetChannelsByIds"); err != nil {
+		var result []*model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.GetChannelsByIds(channelIds, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetChannelsByScheme(schemeID string, offset int, limit int) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetChannelsByScheme"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.GetChannelsByScheme(schemeID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetChannelsByUser(userID string, includeDeleted bool, lastDeleteAt int, pageSize int, fromChannelID string) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetChannelsByUser"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.GetChannelsByUser(userID, includeDeleted, lastDeleteAt, pageSize, fromChannelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetChannelsMemberCount(channelIDs []string) (map[string]int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetChannelsMemberCount"); err != nil {
+		var result map[string]int64
+		return result, err
+	}
+
+	return s.ChannelStore.GetChannelsMemberCount(channelIDs)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetChannelsWithTeamDataByIds(channelIds []string, includeDeleted bool) ([]*model.ChannelWithTeamData, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetChannelsWithTeamDataByIds"); err != nil {
+		var result []*model.ChannelWithTeamData
+		return result, err
+	}
+
+	return s.ChannelStore.GetChannelsWithTeamDataByIds(channelIds, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetChannelsWithUnreadsAndWithMentions(rctx request.CTX, channelIDs []string, userID string, userNotifyProps model.StringMap) ([]string, []string, map[string]int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetChannelsWithUnreadsAndWithMentions"); err != nil {
+		var result []string
+		var resultVar1 []string
+		var resultVar2 map[string]int64
+		return result, resultVar1, resultVar2, err
+	}
+
+	return s.ChannelStore.GetChannelsWithUnreadsAndWithMentions(rctx, channelIDs, userID, userNotifyProps)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetDeleted(teamID string, offset int, limit int, userID string, skipTeamMembershipCheck bool) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetDeleted"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.GetDeleted(teamID, offset, limit, userID, skipTeamMembershipCheck)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetDeletedByName(teamID string, name string) (*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetDeletedByName"); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.GetDeletedByName(teamID, name)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetFileCount(channelID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetFileCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ChannelStore.GetFileCount(channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetForPost(postID string) (*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetForPost"); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.GetForPost(postID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetGuestCount(channelID string, allowFromCache bool) (int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetGuestCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ChannelStore.GetGuestCount(channelID, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMany(ids []string, allowFromCache bool) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMany"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.GetMany(ids, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMember(rctx request.CTX, channelID string, userID string) (*model.ChannelMember, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMember"); err != nil {
+		var result *model.ChannelMember
+		return result, err
+	}
+
+	return s.ChannelStore.GetMember(rctx, channelID, userID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMemberCount(channelID string, allowFromCache bool) (int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMemberCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ChannelStore.GetMemberCount(channelID, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMemberCountFromCache(channelID string) int64 {
+
+	s.Root.Injector.delay("ChannelStore.GetMemberCountFromCache")
+
+	return s.ChannelStore.GetMemberCountFromCache(channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMemberCountsByGroup(rctx request.CTX, channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMemberCountsByGroup"); err != nil {
+		var result []*model.ChannelMemberCountByGroup
+		return result, err
+	}
+
+	return s.ChannelStore.GetMemberCountsByGroup(rctx, channelID, includeTimezones)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMemberForPost(postID string, userID string) (*model.ChannelMember, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMemberForPost"); err != nil {
+		var result *model.ChannelMember
+		return result, err
+	}
+
+	return s.ChannelStore.GetMemberForPost(postID, userID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMemberLastViewedAt(rctx request.CTX, channelID string, userID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMemberLastViewedAt"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ChannelStore.GetMemberLastViewedAt(rctx, channelID, userID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMembers(opts model.ChannelMembersGetOptions) (model.ChannelMembers, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMembers"); err != nil {
+		var result model.ChannelMembers
+		return result, err
+	}
+
+	return s.ChannelStore.GetMembers(opts)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMembersByChannelIds(channelIds []string, userID string) (model.ChannelMembers, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMembersByChannelIds"); err != nil {
+		var result model.ChannelMembers
+		return result, err
+	}
+
+	return s.ChannelStore.GetMembersByChannelIds(channelIds, userID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMembersByIds(channelID string, userIds []string) (model.ChannelMembers, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMembersByIds"); err != nil {
+		var result model.ChannelMembers
+		return result, err
+	}
+
+	return s.ChannelStore.GetMembersByIds(channelID, userIds)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMembersForUser(teamID string, userID string) (model.ChannelMembers, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMembersForUser"); err != nil {
+		var result model.ChannelMembers
+		return result, err
+	}
+
+	return s.ChannelStore.GetMembersForUser(teamID, userID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMembersForUserWithCursorPagination(userId string, perPage int, fromChanneID string) (model.ChannelMembersWithTeamData, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMembersForUserWithCursorPagination"); err != nil {
+		var result model.ChannelMembersWithTeamData
+		return result, err
+	}
+
+	return s.ChannelStore.GetMembersForUserWithCursorPagination(userId, perPage, fromChanneID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMembersForUserWithPagination(userID string, page int, perPage int) (model.ChannelMembersWithTeamData, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMembersForUserWithPagination"); err != nil {
+		var result model.ChannelMembersWithTeamData
+		return result, err
+	}
+
+	return s.ChannelStore.GetMembersForUserWithPagination(userID, page, perPage)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMembersInfoByChannelIds(channelIDs []string) (map[string][]*model.User, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMembersInfoByChannelIds"); err != nil {
+		var result map[string][]*model.User
+		return result, err
+	}
+
+	return s.ChannelStore.GetMembersInfoByChannelIds(channelIDs)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetMoreChannels(teamID string, userID string, offset int, limit int) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetMoreChannels"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.GetMoreChannels(teamID, userID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetPinnedPostCount(channelID string, allowFromCache bool) (int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetPinnedPostCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ChannelStore.GetPinnedPostCount(channelID, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetPinnedPosts(channelID string) (*model.PostList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetPinnedPosts"); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+
+	return s.ChannelStore.GetPinnedPosts(channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetPrivateChannelsForTeam(teamID string, offset int, limit int) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetPrivateChannelsForTeam"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.GetPrivateChannelsForTeam(teamID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetPublicChannelsByIdsForTeam(teamID string, channelIds []string) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetPublicChannelsByIdsForTeam"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.GetPublicChannelsByIdsForTeam(teamID, channelIds)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetPublicChannelsForTeam(teamID string, offset int, limit int) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetPublicChannelsForTeam"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.GetPublicChannelsForTeam(teamID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetSidebarCategories(userID string, teamID string) (*model.OrderedSidebarCategories, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetSidebarCategories"); err != nil {
+		var result *model.OrderedSidebarCategories
+		return result, err
+	}
+
+	return s.ChannelStore.GetSidebarCategories(userID, teamID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetSidebarCategoriesForTeamForUser(userID string, teamID string) (*model.OrderedSidebarCategories, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetSidebarCategoriesForTeamForUser"); err != nil {
+		var result *model.OrderedSidebarCategories
+		return result, err
+	}
+
+	return s.ChannelStore.GetSidebarCategoriesForTeamForUser(userID, teamID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetSidebarCategory(categoryID string) (*model.SidebarCategoryWithChannels, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetSidebarCategory"); err != nil {
+		var result *model.SidebarCategoryWithChannels
+		return result, err
+	}
+
+	return s.ChannelStore.GetSidebarCategory(categoryID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetSidebarCategoryOrder(userID string, teamID string) ([]string, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetSidebarCategoryOrder"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.ChannelStore.GetSidebarCategoryOrder(userID, teamID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetTeamChannels(teamID string) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetTeamChannels"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.GetTeamChannels(teamID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetTeamForChannel(channelID string) (*model.Team, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetTeamForChannel"); err != nil {
+		var result *model.Team
+		return result, err
+	}
+
+	return s.ChannelStore.GetTeamForChannel(channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GetTeamMembersForChannel(rctx request.CTX, channelID string) ([]string, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GetTeamMembersForChannel"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.ChannelStore.GetTeamMembersForChannel(rctx, channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) GroupSyncedChannelCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.GroupSyncedChannelCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ChannelStore.GroupSyncedChannelCount()
+
+}
+
+func (s *FaultInjectionLayerChannelStore) IncrementMentionCount(channelID string, userIDs []string, isRoot bool, isUrgent bool) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.IncrementMentionCount"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.IncrementMentionCount(channelID, userIDs, isRoot, isUrgent)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) InvalidateAllChannelMembersForUser(userID string) {
+
+	s.Root.Injector.delay("ChannelStore.InvalidateAllChannelMembersForUser")
+
+	s.ChannelStore.InvalidateAllChannelMembersForUser(userID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) InvalidateCacheForChannelMembersNotifyProps(channelID string) {
+
+	s.Root.Injector.delay("ChannelStore.InvalidateCacheForChannelMembersNotifyProps")
+
+	s.ChannelStore.InvalidateCacheForChannelMembersNotifyProps(channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) InvalidateChannel(id string) {
+
+	s.Root.Injector.delay("ChannelStore.InvalidateChannel")
+
+	s.ChannelStore.InvalidateChannel(id)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) InvalidateChannelByName(teamID string, name string) {
+
+	s.Root.Injector.delay("ChannelStore.InvalidateChannelByName")
+
+	s.ChannelStore.InvalidateChannelByName(teamID, name)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) InvalidateGuestCount(channelID string) {
+
+	s.Root.Injector.delay("ChannelStore.InvalidateGuestCount")
+
+	s.ChannelStore.InvalidateGuestCount(channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) InvalidateMemberCount(channelID string) {
+
+	s.Root.Injector.delay("ChannelStore.InvalidateMemberCount")
+
+	s.ChannelStore.InvalidateMemberCount(channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) InvalidatePinnedPostCount(channelID string) {
+
+	s.Root.Injector.delay("ChannelStore.InvalidatePinnedPostCount")
+
+	s.ChannelStore.InvalidatePinnedPostCount(channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) IsChannelReadOnlyScheme(schemeID string) (bool, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.IsChannelReadOnlyScheme"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.ChannelStore.IsChannelReadOnlyScheme(schemeID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) IsReadOnlyChannel(channelID string) (bool, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.IsReadOnlyChannel"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.ChannelStore.IsReadOnlyChannel(channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) MigrateChannelMembers(fromChannelID string, fromUserID string) (map[string]string, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.MigrateChannelMembers"); err != nil {
+		var result map[string]string
+		return result, err
+	}
+
+	return s.ChannelStore.MigrateChannelMembers(fromChannelID, fromUserID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) PatchMultipleMembersNotifyProps(members []*model.ChannelMemberIDentifier, notifyProps map[string]string) ([]*model.ChannelMember, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.PatchMultipleMembersNotifyProps"); err != nil {
+		var result []*model.ChannelMember
+		return result, err
+	}
+
+	return s.ChannelStore.PatchMultipleMembersNotifyProps(members, notifyProps)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) PermanentDelete(rctx request.CTX, channelID string) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.PermanentDelete"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.PermanentDelete(rctx, channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) PermanentDeleteByTeam(teamID string) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.PermanentDeleteByTeam"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.PermanentDeleteByTeam(teamID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) PermanentDeleteMembersByChannel(rctx request.CTX, channelID string) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.PermanentDeleteMembersByChannel"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.PermanentDeleteMembersByChannel(rctx, channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) PermanentDeleteMembersByUser(rctx request.CTX, userID string) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.PermanentDeleteMembersByUser"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.PermanentDeleteMembersByUser(rctx, userID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) RemoveAllDeactivatedMembers(rctx request.CTX, channelID string) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.RemoveAllDeactivatedMembers"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.RemoveAllDeactivatedMembers(rctx, channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) RemoveMember(rctx request.CTX, channelID string, userID string) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.RemoveMember"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.RemoveMember(rctx, channelID, userID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) RemoveMembers(rctx request.CTX, channelID string, userIds []string) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.RemoveMembers"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.RemoveMembers(rctx, channelID, userIds)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) ResetAllChannelSchemes() error {
+
+	if err := s.Root.Injector.inject("ChannelStore.ResetAllChannelSchemes"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.ResetAllChannelSchemes()
+
+}
+
+func (s *FaultInjectionLayerChannelStore) Restore(channelID string, timestamp int64) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.Restore"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.Restore(channelID, timestamp)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) Save(rctx request.CTX, channel *model.Channel, maxChannelsPerTeam int64, channelOptions ...model.ChannelOption) (*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.Save"); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.Save(rctx, channel, maxChannelsPerTeam, channelOptions...)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) SaveDirectChannel(rctx request.CTX, channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.SaveDirectChannel"); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.SaveDirectChannel(rctx, channel, member1, member2)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) SaveMember(rctx request.CTX, member *model.ChannelMember) (*model.ChannelMember, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.SaveMember"); err != nil {
+		var result *model.ChannelMember
+		return result, err
+	}
+
+	return s.ChannelStore.SaveMember(rctx, member)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.SaveMultipleMembers"); err != nil {
+		var result []*model.ChannelMember
+		return result, err
+	}
+
+	return s.ChannelStore.SaveMultipleMembers(members)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) SearchAllChannels(term string, opts store.ChannelSearchOpts) (model.ChannelListWithTeamData, int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.SearchAllChannels"); err != nil {
+		var result model.ChannelListWithTeamData
+		var resultVar1 int64
+		return result, resultVar1, err
+	}
+
+	return s.ChannelStore.SearchAllChannels(term, opts)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) SearchForUserInTeam(userID string, teamID string, term string, includeDeleted bool) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.SearchForUserInTeam"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.SearchForUserInTeam(userID, teamID, term, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) SearchGroupChannels(userID string, term string) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.SearchGroupChannels"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.SearchGroupChannels(userID, term)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) SearchInTeam(teamID string, term string, includeDeleted bool) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.SearchInTeam"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.SearchInTeam(teamID, term, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) SearchMore(userID string, teamID string, term string) (model.ChannelList, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.SearchMore"); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+
+	return s.ChannelStore.SearchMore(userID, teamID, term)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) SetDeleteAt(channelID string, deleteAt int64, updateAt int64) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.SetDeleteAt"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.SetDeleteAt(channelID, deleteAt, updateAt)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) SetShared(channelID string, shared bool) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.SetShared"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.SetShared(channelID, shared)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) Update(rctx request.CTX, channel *model.Channel) (*model.Channel, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.Update"); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+
+	return s.ChannelStore.Update(rctx, channel)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) UpdateLastViewedAt(channelIds []string, userID string) (map[string]int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.UpdateLastViewedAt"); err != nil {
+		var result map[string]int64
+		return result, err
+	}
+
+	return s.ChannelStore.UpdateLastViewedAt(channelIds, userID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID string, mentionCount int, mentionCountRoot int, urgentMentionCount int, setUnreadCountRoot bool) (*model.ChannelUnreadAt, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.UpdateLastViewedAtPost"); err != nil {
+		var result *model.ChannelUnreadAt
+		return result, err
+	}
+
+	return s.ChannelStore.UpdateLastViewedAtPost(unreadPost, userID, mentionCount, mentionCountRoot, urgentMentionCount, setUnreadCountRoot)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) UpdateMember(rctx request.CTX, member *model.ChannelMember) (*model.ChannelMember, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.UpdateMember"); err != nil {
+		var result *model.ChannelMember
+		return result, err
+	}
+
+	return s.ChannelStore.UpdateMember(rctx, member)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) UpdateMemberNotifyProps(channelID string, userID string, props map[string]string) (*model.ChannelMember, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.UpdateMemberNotifyProps"); err != nil {
+		var result *model.ChannelMember
+		return result, err
+	}
+
+	return s.ChannelStore.UpdateMemberNotifyProps(channelID, userID, props)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) UpdateMembersRole(channelID string, userIDs []string) ([]*model.ChannelMember, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.UpdateMembersRole"); err != nil {
+		var result []*model.ChannelMember
+		return result, err
+	}
+
+	return s.ChannelStore.UpdateMembersRole(channelID, userIDs)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) UpdateMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.UpdateMultipleMembers"); err != nil {
+		var result []*model.ChannelMember
+		return result, err
+	}
+
+	return s.ChannelStore.UpdateMultipleMembers(members)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) UpdateSidebarCategories(userID string, teamID string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, []*model.SidebarCategoryWithChannels, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.UpdateSidebarCategories"); err != nil {
+		var result []*model.SidebarCategoryWithChannels
+		var resultVar1 []*model.SidebarCategoryWithChannels
+		return result, resultVar1, err
+	}
+
+	return s.ChannelStore.UpdateSidebarCategories(userID, teamID, categories)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) UpdateSidebarCategoryOrder(userID string, teamID string, categoryOrder []string) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.UpdateSidebarCategoryOrder"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.UpdateSidebarCategoryOrder(userID, teamID, categoryOrder)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) UpdateSidebarChannelCategoryOnMove(channel *model.Channel, newTeamID string) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.UpdateSidebarChannelCategoryOnMove"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.UpdateSidebarChannelCategoryOnMove(channel, newTeamID)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) UpdateSidebarChannelsByPreferences(preferences model.Preferences) error {
+
+	if err := s.Root.Injector.inject("ChannelStore.UpdateSidebarChannelsByPreferences"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelStore.UpdateSidebarChannelsByPreferences(preferences)
+
+}
+
+func (s *FaultInjectionLayerChannelStore) UserBelongsToChannels(userID string, channelIds []string) (bool, error) {
+
+	if err := s.Root.Injector.inject("ChannelStore.UserBelongsToChannels"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.ChannelStore.UserBelongsToChannels(userID, channelIds)
+
+}
+
+func (s *FaultInjectionLayerChannelBookmarkStore) Delete(bookmarkID string, deleteFile bool) error {
+
+	if err := s.Root.Injector.inject("ChannelBookmarkStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelBookmarkStore.Delete(bookmarkID, deleteFile)
+
+}
+
+func (s *FaultInjectionLayerChannelBookmarkStore) ErrorIfBookmarkFileInfoAlreadyAttached(fileID string, channelID string) error {
+
+	if err := s.Root.Injector.inject("ChannelBookmarkStore.ErrorIfBookmarkFileInfoAlreadyAttached"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelBookmarkStore.ErrorIfBookmarkFileInfoAlreadyAttached(fileID, channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelBookmarkStore) Get(Id string, includeDeleted bool) (*model.ChannelBookmarkWithFileInfo, error) {
+
+	if err := s.Root.Injector.inject("ChannelBookmarkStore.Get"); err != nil {
+		var result *model.ChannelBookmarkWithFileInfo
+		return result, err
+	}
+
+	return s.ChannelBookmarkStore.Get(Id, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerChannelBookmarkStore) GetBookmarksForChannelSince(channelID string, since int64) ([]*model.ChannelBookmarkWithFileInfo, error) {
+
+	if err := s.Root.Injector.inject("ChannelBookmarkStore.GetBookmarksForChannelSince"); err != nil {
+		var result []*model.ChannelBookmarkWithFileInfo
+		return result, err
+	}
+
+	return s.ChannelBookmarkStore.GetBookmarksForChannelSince(channelID, since)
+
+}
+
+func (s *FaultInjectionLayerChannelBookmarkStore) Save(bookmark *model.ChannelBookmark, increaseSortOrder bool) (*model.ChannelBookmarkWithFileInfo, error) {
+
+	if err := s.Root.Injector.inject("ChannelBookmarkStore.Save"); err != nil {
+		var result *model.ChannelBookmarkWithFileInfo
+		return result, err
+	}
+
+	return s.ChannelBookmarkStore.Save(bookmark, increaseSortOrder)
+
+}
+
+func (s *FaultInjectionLayerChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) error {
+
+	if err := s.Root.Injector.inject("ChannelBookmarkStore.Update"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelBookmarkStore.Update(bookmark)
+
+}
+
+func (s *FaultInjectionLayerChannelBookmarkStore) UpdateSortOrder(bookmarkID string, channelID string, newIndex int64) ([]*model.ChannelBookmarkWithFileInfo, error) {
+
+	if err := s.Root.Injector.inject("ChannelBookmarkStore.UpdateSortOrder"); err != nil {
+		var result []*model.ChannelBookmarkWithFileInfo
+		return result, err
+	}
+
+	return s.ChannelBookmarkStore.UpdateSortOrder(bookmarkID, channelID, newIndex)
+
+}
+
+func (s *FaultInjectionLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelMemberHistoryStore.DeleteOrphanedRows"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ChannelMemberHistoryStore.DeleteOrphanedRows(limit)
+
+}
+
+func (s *FaultInjectionLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
+
+	if err := s.Root.Injector.inject("ChannelMemberHistoryStore.GetChannelsLeftSince"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.ChannelMemberHistoryStore.GetChannelsLeftSince(userID, since)
+
+}
+
+func (s *FaultInjectionLayerChannelMemberHistoryStore) GetChannelsWithActivityDuring(startTime int64, endTime int64) ([]string, error) {
+
+	if err := s.Root.Injector.inject("ChannelMemberHistoryStore.GetChannelsWithActivityDuring"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.ChannelMemberHistoryStore.GetChannelsWithActivityDuring(startTime, endTime)
+
+}
+
+func (s *FaultInjectionLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelID []string) ([]*model.ChannelMemberHistoryResult, error) {
+
+	if err := s.Root.Injector.inject("ChannelMemberHistoryStore.GetUsersInChannelDuring"); err != nil {
+		var result []*model.ChannelMemberHistoryResult
+		return result, err
+	}
+
+	return s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelID)
+
+}
+
+func (s *FaultInjectionLayerChannelMemberHistoryStore) LogJoinEvent(userID string, channelID string, joinTime int64) error {
+
+	if err := s.Root.Injector.inject("ChannelMemberHistoryStore.LogJoinEvent"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelMemberHistoryStore.LogJoinEvent(userID, channelID, joinTime)
+
+}
+
+func (s *FaultInjectionLayerChannelMemberHistoryStore) LogLeaveEvent(userID string, channelID string, leaveTime int64) error {
+
+	if err := s.Root.Injector.inject("ChannelMemberHistoryStore.LogLeaveEvent"); err != nil {
+
+		return err
+	}
+
+	return s.ChannelMemberHistoryStore.LogLeaveEvent(userID, channelID, leaveTime)
+
+}
+
+func (s *FaultInjectionLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	if err := s.Root.Injector.inject("ChannelMemberHistoryStore.PermanentDeleteBatch"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+
+}
+
+func (s *FaultInjectionLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs model.RetentionPolicyBatchConfigs, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+
+	if err := s.Root.Injector.inject("ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies"); err != nil {
+		var result int64
+		var resultVar1 model.RetentionPolicyCursor
+		return result, resultVar1, err
+	}
+
+	return s.ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs, cursor)
+
+}
+
+func (s *FaultInjectionLayerClusterDiscoveryStore) Cleanup() error {
+
+	if err := s.Root.Injector.inject("ClusterDiscoveryStore.Cleanup"); err != nil {
+
+		return err
+	}
+
+	return s.ClusterDiscoveryStore.Cleanup()
+
+}
+
+func (s *FaultInjectionLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+
+	if err := s.Root.Injector.inject("ClusterDiscoveryStore.Delete"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.ClusterDiscoveryStore.Delete(discovery)
+
+}
+
+func (s *FaultInjectionLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
+
+	if err := s.Root.Injector.inject("ClusterDiscoveryStore.Exists"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.ClusterDiscoveryStore.Exists(discovery)
+
+}
+
+func (s *FaultInjectionLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
+
+	if err := s.Root.Injector.inject("ClusterDiscoveryStore.GetAll"); err != nil {
+		var result []*model.ClusterDiscovery
+		return result, err
+	}
+
+	return s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+
+}
+
+func (s *FaultInjectionLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
+
+	if err := s.Root.Injector.inject("ClusterDiscoveryStore.Save"); err != nil {
+
+		return err
+	}
+
+	return s.ClusterDiscoveryStore.Save(discovery)
+
+}
+
+func (s *FaultInjectionLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
+
+	if err := s.Root.Injector.inject("ClusterDiscoveryStore.SetLastPingAt"); err != nil {
+
+		return err
+	}
+
+	return s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+
+}
+
+func (s *FaultInjectionLayerCommandStore) AnalyticsCommandCount(teamID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("CommandStore.AnalyticsCommandCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.CommandStore.AnalyticsCommandCount(teamID)
+
+}
+
+func (s *FaultInjectionLayerCommandStore) Delete(commandID string, timestamp int64) error {
+
+	if err := s.Root.Injector.inject("CommandStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.CommandStore.Delete(commandID, timestamp)
+
+}
+
+func (s *FaultInjectionLayerCommandStore) Get(id string) (*model.Command, error) {
+
+	if err := s.Root.Injector.inject("CommandStore.Get"); err != nil {
+		var result *model.Command
+		return result, err
+	}
+
+	return s.CommandStore.Get(id)
+
+}
+
+func (s *FaultInjectionLayerCommandStore) GetByTeam(teamID string) ([]*model.Command, error) {
+
+	if err := s.Root.Injector.inject("CommandStore.GetByTeam"); err != nil {
+		var result []*model.Command
+		return result, err
+	}
+
+	return s.CommandStore.GetByTeam(teamID)
+
+}
+
+func (s *FaultInjectionLayerCommandStore) GetByTrigger(teamID string, trigger string) (*model.Command, error) {
+
+	if err := s.Root.Injector.inject("CommandStore.GetByTrigger"); err != nil {
+		var result *model.Command
+		return result, err
+	}
+
+	return s.CommandStore.GetByTrigger(teamID, trigger)
+
+}
+
+func (s *FaultInjectionLayerCommandStore) PermanentDeleteByTeam(teamID string) error {
+
+	if err := s.Root.Injector.inject("CommandStore.PermanentDeleteByTeam"); err != nil {
+
+		return err
+	}
+
+	return s.CommandStore.PermanentDeleteByTeam(teamID)
+
+}
+
+func (s *FaultInjectionLayerCommandStore) PermanentDeleteByUser(userID string) error {
+
+	if err := s.Root.Injector.inject("CommandStore.PermanentDeleteByUser"); err != nil {
+
+		return err
+	}
+
+	return s.CommandStore.PermanentDeleteByUser(userID)
+
+}
+
+func (s *FaultInjectionLayerCommandStore) Save(webhook *model.Command) (*model.Command, error) {
+
+	if err := s.Root.Injector.inject("CommandStore.Save"); err != nil {
+		var result *model.Command
+		return result, err
+	}
+
+	return s.CommandStore.Save(webhook)
+
+}
+
+func (s *FaultInjectionLayerCommandStore) Update(hook *model.Command) (*model.Command, error) {
+
+	if err := s.Root.Injector.inject("CommandStore.Update"); err != nil {
+		var result *model.Command
+		return result, err
+	}
+
+	return s.CommandStore.Update(hook)
+
+}
+
+func (s *FaultInjectionLayerCommandWebhookStore) Cleanup() {
+
+	s.Root.Injector.delay("CommandWebhookStore.Cleanup")
+
+	s.CommandWebhookStore.Cleanup()
+
+}
+
+func (s *FaultInjectionLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, error) {
+
+	if err := s.Root.Injector.inject("CommandWebhookStore.Get"); err != nil {
+		var result *model.CommandWebhook
+		return result, err
+	}
+
+	return s.CommandWebhookStore.Get(id)
+
+}
+
+func (s *FaultInjectionLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, error) {
+
+	if err := s.Root.Injector.inject("CommandWebhookStore.Save"); err != nil {
+		var result *model.CommandWebhook
+		return result, err
+	}
+
+	return s.CommandWebhookStore.Save(webhook)
+
+}
+
+func (s *FaultInjectionLayerCommandWebhookStore) TryUse(id string, limit int) error {
+
+	if err := s.Root.Injector.inject("CommandWebhookStore.TryUse"); err != nil {
+
+		return err
+	}
+
+	return s.CommandWebhookStore.TryUse(id, limit)
+
+}
+
+func (s *FaultInjectionLayerComplianceStore) ComplianceExport(compliance *model.Compliance, cursor model.ComplianceExportCursor, limit int) ([]*model.CompliancePost, model.ComplianceExportCursor, error) {
+
+	if err := s.Root.Injector.inject("ComplianceStore.ComplianceExport"); err != nil {
+		var result []*model.CompliancePost
+		var resultVar1 model.ComplianceExportCursor
+		return result, resultVar1, err
+	}
+
+	return s.ComplianceStore.ComplianceExport(compliance, cursor, limit)
+
+}
+
+func (s *FaultInjectionLayerComplianceStore) Get(id string) (*model.Compliance, error) {
+
+	if err := s.Root.Injector.inject("ComplianceStore.Get"); err != nil {
+		var result *model.Compliance
+		return result, err
+	}
+
+	return s.ComplianceStore.Get(id)
+
+}
+
+func (s *FaultInjectionLayerComplianceStore) GetAll(offset int, limit int) (model.Compliances, error) {
+
+	if err := s.Root.Injector.inject("ComplianceStore.GetAll"); err != nil {
+		var result model.Compliances
+		return result, err
+	}
+
+	return s.ComplianceStore.GetAll(offset, limit)
+
+}
+
+func (s *FaultInjectionLayerComplianceStore) MessageExport(rctx request.CTX, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
+
+	if err := s.Root.Injector.inject("ComplianceStore.MessageExport"); err != nil {
+		var result []*model.MessageExport
+		var resultVar1 model.MessageExportCursor
+		return result, resultVar1, err
+	}
+
+	return s.ComplianceStore.MessageExport(rctx, cursor, limit)
+
+}
+
+func (s *FaultInjectionLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
+
+	if err := s.Root.Injector.inject("ComplianceStore.Save"); err != nil {
+		var result *model.Compliance
+		return result, err
+	}
+
+	return s.ComplianceStore.Save(compliance)
+
+}
+
+func (s *FaultInjectionLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, error) {
+
+	if err := s.Root.Injector.inject("ComplianceStore.Update"); err != nil {
+		var result *model.Compliance
+		return result, err
+	}
+
+	return s.ComplianceStore.Update(compliance)
+
+}
+
+func (s *FaultInjectionLayerContentFlaggingStore) ClearCaches() {
+
+	s.Root.Injector.delay("ContentFlaggingStore.ClearCaches")
+
+	s.ContentFlaggingStore.ClearCaches()
+
+}
+
+func (s *FaultInjectionLayerContentFlaggingStore) GetReviewerSettings() (*model.ReviewerIDsSettings, error) {
+
+	if err := s.Root.Injector.inject("ContentFlaggingStore.GetReviewerSettings"); err != nil {
+		var result *model.ReviewerIDsSettings
+		return result, err
+	}
+
+	return s.ContentFlaggingStore.GetReviewerSettings()
+
+}
+
+func (s *FaultInjectionLayerContentFlaggingStore) SaveReviewerSettings(reviewerSettings model.ReviewerIDsSettings) error {
+
+	if err := s.Root.Injector.inject("ContentFlaggingStore.SaveReviewerSettings"); err != nil {
+
+		return err
+	}
+
+	return s.ContentFlaggingStore.SaveReviewerSettings(reviewerSettings)
+
+}
+
+func (s *FaultInjectionLayerDesktopTokensStore) Delete(token string) error {
+
+	if err := s.Root.Injector.inject("DesktopTokensStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.DesktopTokensStore.Delete(token)
+
+}
+
+func (s *FaultInjectionLayerDesktopTokensStore) DeleteByUserId(userID string) error {
+
+	if err := s.Root.Injector.inject("DesktopTokensStore.DeleteByUserId"); err != nil {
+
+		return err
+	}
+
+	return s.DesktopTokensStore.DeleteByUserId(userID)
+
+}
+
+func (s *FaultInjectionLayerDesktopTokensStore) DeleteOlderThan(minCreatedAt int64) error {
+
+	if err := s.Root.Injector.inject("DesktopTokensStore.DeleteOlderThan"); err != nil {
+
+		return err
+	}
+
+	return s.DesktopTokensStore.DeleteOlderThan(minCreatedAt)
+
+}
+
+func (s *FaultInjectionLayerDesktopTokensStore) GetUserId(token string, minCreatedAt int64) (*string, error) {
+
+	if err := s.Root.Injector.inject("DesktopTokensStore.GetUserId"); err != nil {
+		var result *string
+		return result, err
+	}
+
+	return s.DesktopTokensStore.GetUserId(token, minCreatedAt)
+
+}
+
+func (s *FaultInjectionLayerDesktopTokensStore) Insert(token string, createAt int64, userID string) error {
+
+	if err := s.Root.Injector.inject("DesktopTokensStore.Insert"); err != nil {
+
+		return err
+	}
+
+	return s.DesktopTokensStore.Insert(token, createAt, userID)
+
+}
+
+func (s *FaultInjectionLayerDraftStore) Delete(userID string, channelID string, rootID string) error {
+
+	if err := s.Root.Injector.inject("DraftStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.DraftStore.Delete(userID, channelID, rootID)
+
+}
+
+func (s *FaultInjectionLayerDraftStore) DeleteDraftsAssociatedWithPost(channelID string, rootID string) error {
+
+	if err := s.Root.Injector.inject("DraftStore.DeleteDraftsAssociatedWithPost"); err != nil {
+
+		return err
+	}
+
+	return s.DraftStore.DeleteDraftsAssociatedWithPost(channelID, rootID)
+
+}
+
+func (s *FaultInjectionLayerDraftStore) DeleteEmptyDraftsByCreateAtAndUserId(createAt int64, userID string) error {
+
+	if err := s.Root.Injector.inject("DraftStore.DeleteEmptyDraftsByCreateAtAndUserId"); err != nil {
+
+		return err
+	}
+
+	return s.DraftStore.DeleteEmptyDraftsByCreateAtAndUserId(createAt, userID)
+
+}
+
+func (s *FaultInjectionLayerDraftStore) DeleteOrphanDraftsByCreateAtAndUserId(createAt int64, userID string) error {
+
+	if err := s.Root.Injector.inject("DraftStore.DeleteOrphanDraftsByCreateAtAndUserId"); err != nil {
+
+		return err
+	}
+
+	return s.DraftStore.DeleteOrphanDraftsByCreateAtAndUserId(createAt, userID)
+
+}
+
+func (s *FaultInjectionLayerDraftStore) Get(userID string, channelID string, rootID string, includeDeleted bool) (*model.Draft, error) {
+
+	if err := s.Root.Injector.inject("DraftStore.Get"); err != nil {
+		var result *model.Draft
+		return result, err
+	}
+
+	return s.DraftStore.Get(userID, channelID, rootID, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerDraftStore) GetDraftsForUser(userID string, teamID string) ([]*model.Draft, error) {
+
+	if err := s.Root.Injector.inject("DraftStore.GetDraftsForUser"); err != nil {
+		var result []*model.Draft
+		return result, err
+	}
+
+	return s.DraftStore.GetDraftsForUser(userID, teamID)
+
+}
+
+func (s *FaultInjectionLayerDraftStore) GetLastCreateAtAndUserIdValuesForEmptyDraftsMigration(createAt int64, userID string) (int64, string, error) {
+
+	if err := s.Root.Injector.inject("DraftStore.GetLastCreateAtAndUserIdValuesForEmptyDraftsMigration"); err != nil {
+		var result int64
+		var resultVar1 string
+		return result, resultVar1, err
+	}
+
+	return s.DraftStore.GetLastCreateAtAndUserIdValuesForEmptyDraftsMigration(createAt, userID)
+
+}
+
+func (s *FaultInjectionLayerDraftStore) PermanentDeleteByUser(userId string) error {
+
+	if err := s.Root.Injector.inject("DraftStore.PermanentDeleteByUser"); err != nil {
+
+		return err
+	}
+
+	return s.DraftStore.PermanentDeleteByUser(userId)
+
+}
+
+func (s *FaultInjectionLayerDraftStore) Upsert(d *model.Draft) (*model.Draft, error) {
+
+	if err := s.Root.Injector.inject("DraftStore.Upsert"); err != nil {
+		var result *model.Draft
+		return result, err
+	}
+
+	return s.DraftStore.Upsert(d)
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) Delete(emoji *model.Emoji, timestamp int64) error {
+
+	if err := s.Root.Injector.inject("EmojiStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.EmojiStore.Delete(emoji, timestamp)
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) DeleteCategory(id string) error {
+
+	if err := s.Root.Injector.inject("EmojiStore.DeleteCategory"); err != nil {
+
+		return err
+	}
+
+	return s.EmojiStore.DeleteCategory(id)
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) Get(rctx request.CTX, id string, allowFromCache bool) (*model.Emoji, error) {
+
+	if err := s.Root.Injector.inject("EmojiStore.Get"); err != nil {
+		var result *model.Emoji
+		return result, err
+	}
+
+	return s.EmojiStore.Get(rctx, id, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) GetAllCategories() ([]*model.EmojiCategory, error) {
+
+	if err := s.Root.Injector.inject("EmojiStore.GetAllCategories"); err != nil {
+		var result []*model.EmojiCategory
+		return result, err
+	}
+
+	return s.EmojiStore.GetAllCategories()
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) GetByName(rctx request.CTX, name string, allowFromCache bool) (*model.Emoji, error) {
+
+	if err := s.Root.Injector.inject("EmojiStore.GetByName"); err != nil {
+		var result *model.Emoji
+		return result, err
+	}
+
+	return s.EmojiStore.GetByName(rctx, name, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) GetCategory(id string) (*model.EmojiCategory, error) {
+
+	if err := s.Root.Injector.inject("EmojiStore.GetCategory"); err != nil {
+		var result *model.EmojiCategory
+		return result, err
+	}
+
+	return s.EmojiStore.GetCategory(id)
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) GetCategoryByName(name string) (*model.EmojiCategory, error) {
+
+	if err := s.Root.Injector.inject("EmojiStore.GetCategoryByName"); err != nil {
+		var result *model.EmojiCategory
+		return result, err
+	}
+
+	return s.EmojiStore.GetCategoryByName(name)
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+
+	if err := s.Root.Injector.inject("EmojiStore.GetList"); err != nil {
+		var result []*model.Emoji
+		return result, err
+	}
+
+	return s.EmojiStore.GetList(offset, limit, sort)
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) GetMultipleByName(rctx request.CTX, names []string) ([]*model.Emoji, error) {
+
+	if err := s.Root.Injector.inject("EmojiStore.GetMultipleByName"); err != nil {
+		var result []*model.Emoji
+		return result, err
+	}
+
+	return s.EmojiStore.GetMultipleByName(rctx, names)
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+
+	if err := s.Root.Injector.inject("EmojiStore.Save"); err != nil {
+		var result *model.Emoji
+		return result, err
+	}
+
+	return s.EmojiStore.Save(emoji)
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) SaveCategory(category *model.EmojiCategory) (*model.EmojiCategory, error) {
+
+	if err := s.Root.Injector.inject("EmojiStore.SaveCategory"); err != nil {
+		var result *model.EmojiCategory
+		return result, err
+	}
+
+	return s.EmojiStore.SaveCategory(category)
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
+
+	if err := s.Root.Injector.inject("EmojiStore.Search"); err != nil {
+		var result []*model.Emoji
+		return result, err
+	}
+
+	return s.EmojiStore.Search(name, prefixOnly, limit)
+
+}
+
+func (s *FaultInjectionLayerEmojiStore) Update(emoji *model.Emoji) (*model.Emoji, error) {
+
+	if err := s.Root.Injector.inject("EmojiStore.Update"); err != nil {
+		var result *model.Emoji
+		return result, err
+	}
+
+	return s.EmojiStore.Update(emoji)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) AttachToPost(rctx request.CTX, fileID string, postID string, channelID string, creatorID string) error {
+
+	if err := s.Root.Injector.inject("FileInfoStore.AttachToPost"); err != nil {
+
+		return err
+	}
+
+	return s.FileInfoStore.AttachToPost(rctx, fileID, postID, channelID, creatorID)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) ClearCaches() {
+
+	s.Root.Injector.delay("FileInfoStore.ClearCaches")
+
+	s.FileInfoStore.ClearCaches()
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) CountAll() (int64, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.CountAll"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.FileInfoStore.CountAll()
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) DeleteForPost(rctx request.CTX, postID string) (string, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.DeleteForPost"); err != nil {
+		var result string
+		return result, err
+	}
+
+	return s.FileInfoStore.DeleteForPost(rctx, postID)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) DeleteForPostByIds(rctx request.CTX, postId string, fileIDs []string) error {
+
+	if err := s.Root.Injector.inject("FileInfoStore.DeleteForPostByIds"); err != nil {
+
+		return err
+	}
+
+	return s.FileInfoStore.DeleteForPostByIds(rctx, postId, fileIDs)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.Get"); err != nil {
+		var result *model.FileInfo
+		return result, err
+	}
+
+	return s.FileInfoStore.Get(id)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) GetByIds(ids []string, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.GetByIds"); err != nil {
+		var result []*model.FileInfo
+		return result, err
+	}
+
+	return s.FileInfoStore.GetByIds(ids, includeDeleted, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.GetByPath"); err != nil {
+		var result *model.FileInfo
+		return result, err
+	}
+
+	return s.FileInfoStore.GetByPath(path)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.GetFilesBatchForIndexing"); err != nil {
+		var result []*model.FileForIndexing
+		return result, err
+	}
+
+	return s.FileInfoStore.GetFilesBatchForIndexing(startTime, startFileID, includeDeleted, limit)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) GetForPost(postID string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.GetForPost"); err != nil {
+		var result []*model.FileInfo
+		return result, err
+	}
+
+	return s.FileInfoStore.GetForPost(postID, readFromMaster, includeDeleted, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) GetForUser(userID string) ([]*model.FileInfo, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.GetForUser"); err != nil {
+		var result []*model.FileInfo
+		return result, err
+	}
+
+	return s.FileInfoStore.GetForUser(userID)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) GetFromMaster(id string) (*model.FileInfo, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.GetFromMaster"); err != nil {
+		var result *model.FileInfo
+		return result, err
+	}
+
+	return s.FileInfoStore.GetFromMaster(id)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) GetStorageUsage(allowFromCache bool, includeDeleted bool) (int64, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.GetStorageUsage"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.FileInfoStore.GetStorageUsage(allowFromCache, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) GetUptoNSizeFileTime(n int64) (int64, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.GetUptoNSizeFileTime"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.FileInfoStore.GetUptoNSizeFileTime(n)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.GetWithOptions"); err != nil {
+		var result []*model.FileInfo
+		return result, err
+	}
+
+	return s.FileInfoStore.GetWithOptions(page, perPage, opt)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) InvalidateFileInfosForPostCache(postID string, deleted bool) {
+
+	s.Root.Injector.delay("FileInfoStore.InvalidateFileInfosForPostCache")
+
+	s.FileInfoStore.InvalidateFileInfosForPostCache(postID, deleted)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) PermanentDelete(rctx request.CTX, fileID string) error {
+
+	if err := s.Root.Injector.inject("FileInfoStore.PermanentDelete"); err != nil {
+
+		return err
+	}
+
+	return s.FileInfoStore.PermanentDelete(rctx, fileID)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) PermanentDeleteBatch(rctx request.CTX, endTime int64, limit int64) (int64, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.PermanentDeleteBatch"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.FileInfoStore.PermanentDeleteBatch(rctx, endTime, limit)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) PermanentDeleteByUser(rctx request.CTX, userID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.PermanentDeleteByUser"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.FileInfoStore.PermanentDeleteByUser(rctx, userID)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) PermanentDeleteForPost(rctx request.CTX, postID string) error {
+
+	if err := s.Root.Injector.inject("FileInfoStore.PermanentDeleteForPost"); err != nil {
+
+		return err
+	}
+
+	return s.FileInfoStore.PermanentDeleteForPost(rctx, postID)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) RefreshFileStats() error {
+
+	if err := s.Root.Injector.inject("FileInfoStore.RefreshFileStats"); err != nil {
+
+		return err
+	}
+
+	return s.FileInfoStore.RefreshFileStats()
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) RestoreForPostByIds(rctx request.CTX, postId string, fileIDs []string) error {
+
+	if err := s.Root.Injector.inject("FileInfoStore.RestoreForPostByIds"); err != nil {
+
+		return err
+	}
+
+	return s.FileInfoStore.RestoreForPostByIds(rctx, postId, fileIDs)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) Save(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.Save"); err != nil {
+		var result *model.FileInfo
+		return result, err
+	}
+
+	return s.FileInfoStore.Save(rctx, info)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) Search(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.FileInfoList, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.Search"); err != nil {
+		var result *model.FileInfoList
+		return result, err
+	}
+
+	return s.FileInfoStore.Search(rctx, paramsList, userID, teamID, page, perPage)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) SetContent(rctx request.CTX, fileID string, content string) error {
+
+	if err := s.Root.Injector.inject("FileInfoStore.SetContent"); err != nil {
+
+		return err
+	}
+
+	return s.FileInfoStore.SetContent(rctx, fileID, content)
+
+}
+
+func (s *FaultInjectionLayerFileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
+
+	if err := s.Root.Injector.inject("FileInfoStore.Upsert"); err != nil {
+		var result *model.FileInfo
+		return result, err
+	}
+
+	return s.FileInfoStore.Upsert(rctx, info)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.AdminRoleGroupsForSyncableMember"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.ChannelMembersMinusGroupMembers"); err != nil {
+		var result []*model.UserWithGroups
+		return result, err
+	}
+
+	return s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string, reAddRemovedMembers bool) ([]*model.UserChannelIDPair, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.ChannelMembersToAdd"); err != nil {
+		var result []*model.UserChannelIDPair
+		return result, err
+	}
+
+	return s.GroupStore.ChannelMembersToAdd(since, channelID, reAddRemovedMembers)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.ChannelMember, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.ChannelMembersToRemove"); err != nil {
+		var result []*model.ChannelMember
+		return result, err
+	}
+
+	return s.GroupStore.ChannelMembersToRemove(channelID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.CountChannelMembersMinusGroupMembers"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) CountGroupsByChannel(channelID string, opts model.GroupSearchOpts) (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.CountGroupsByChannel"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.CountGroupsByChannel(channelID, opts)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) CountGroupsByTeam(teamID string, opts model.GroupSearchOpts) (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.CountGroupsByTeam"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.CountGroupsByTeam(teamID, opts)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.CountTeamMembersMinusGroupMembers"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) Create(group *model.Group) (*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.Create"); err != nil {
+		var result *model.Group
+		return result, err
+	}
+
+	return s.GroupStore.Create(group)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.CreateGroupSyncable"); err != nil {
+		var result *model.GroupSyncable
+		return result, err
+	}
+
+	return s.GroupStore.CreateGroupSyncable(groupSyncable)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) CreateWithUserIds(group *model.GroupWithUserIds) (*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.CreateWithUserIds"); err != nil {
+		var result *model.Group
+		return result, err
+	}
+
+	return s.GroupStore.CreateWithUserIds(group)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) Delete(groupID string) (*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.Delete"); err != nil {
+		var result *model.Group
+		return result, err
+	}
+
+	return s.GroupStore.Delete(groupID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.DeleteGroupSyncable"); err != nil {
+		var result *model.GroupSyncable
+		return result, err
+	}
+
+	return s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.DeleteMember"); err != nil {
+		var result *model.GroupMember
+		return result, err
+	}
+
+	return s.GroupStore.DeleteMember(groupID, userID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) DeleteMembers(groupID string, userIDs []string) ([]*model.GroupMember, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.DeleteMembers"); err != nil {
+		var result []*model.GroupMember
+		return result, err
+	}
+
+	return s.GroupStore.DeleteMembers(groupID, userIDs)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) DistinctGroupMemberCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.DistinctGroupMemberCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.DistinctGroupMemberCount()
+
+}
+
+func (s *FaultInjectionLayerGroupStore) DistinctGroupMemberCountForSource(source model.GroupSource) (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.DistinctGroupMemberCountForSource"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.DistinctGroupMemberCountForSource(source)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) Get(groupID string) (*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.Get"); err != nil {
+		var result *model.Group
+		return result, err
+	}
+
+	return s.GroupStore.Get(groupID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetAllBySource"); err != nil {
+		var result []*model.Group
+		return result, err
+	}
+
+	return s.GroupStore.GetAllBySource(groupSource)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetAllGroupSyncablesByGroupId"); err != nil {
+		var result []*model.GroupSyncable
+		return result, err
+	}
+
+	return s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetByIDs"); err != nil {
+		var result []*model.Group
+		return result, err
+	}
+
+	return s.GroupStore.GetByIDs(groupIDs)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts) (*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetByName"); err != nil {
+		var result *model.Group
+		return result, err
+	}
+
+	return s.GroupStore.GetByName(name, opts)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetByNames(names []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetByNames"); err != nil {
+		var result []*model.Group
+		return result, err
+	}
+
+	return s.GroupStore.GetByNames(names, viewRestrictions)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetByRemoteID"); err != nil {
+		var result *model.Group
+		return result, err
+	}
+
+	return s.GroupStore.GetByRemoteID(remoteID, groupSource)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetByUser(userID string, opts model.GroupSearchOpts) ([]*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetByUser"); err != nil {
+		var result []*model.Group
+		return result, err
+	}
+
+	return s.GroupStore.GetByUser(userID, opts)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetGroupSyncable"); err != nil {
+		var result *model.GroupSyncable
+		return result, err
+	}
+
+	return s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts, viewRestrictions *model.ViewUsersRestrictions) ([]*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetGroups"); err != nil {
+		var result []*model.Group
+		return result, err
+	}
+
+	return s.GroupStore.GetGroups(page, perPage, opts, viewRestrictions)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamID string, opts model.GroupSearchOpts) (map[string][]*model.GroupWithSchemeAdmin, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetGroupsAssociatedToChannelsByTeam"); err != nil {
+		var result map[string][]*model.GroupWithSchemeAdmin
+		return result, err
+	}
+
+	return s.GroupStore.GetGroupsAssociatedToChannelsByTeam(teamID, opts)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetGroupsByChannel(channelID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetGroupsByChannel"); err != nil {
+		var result []*model.GroupWithSchemeAdmin
+		return result, err
+	}
+
+	return s.GroupStore.GetGroupsByChannel(channelID, opts)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetGroupsByTeam(teamID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetGroupsByTeam"); err != nil {
+		var result []*model.GroupWithSchemeAdmin
+		return result, err
+	}
+
+	return s.GroupStore.GetGroupsByTeam(teamID, opts)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetMember(groupID string, userID string) (*model.GroupMember, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetMember"); err != nil {
+		var result *model.GroupMember
+		return result, err
+	}
+
+	return s.GroupStore.GetMember(groupID, userID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetMemberCount(groupID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetMemberCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.GetMemberCount(groupID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetMemberCountWithRestrictions(groupID string, viewRestrictions *model.ViewUsersRestrictions) (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetMemberCountWithRestrictions"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.GetMemberCountWithRestrictions(groupID, viewRestrictions)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetMemberUsers"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.GroupStore.GetMemberUsers(groupID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetMemberUsersInTeam"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.GroupStore.GetMemberUsersInTeam(groupID, teamID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channelID string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetMemberUsersNotInChannel"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.GroupStore.GetMemberUsersNotInChannel(groupID, channelID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetMemberUsersPage"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.GroupStore.GetMemberUsersPage(groupID, page, perPage, viewRestrictions)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetMemberUsersSortedPage(groupID string, page int, perPage int, viewRestrictions *model.ViewUsersRestrictions, teammateNameDisplay string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetMemberUsersSortedPage"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.GroupStore.GetMemberUsersSortedPage(groupID, page, perPage, viewRestrictions, teammateNameDisplay)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GetNonMemberUsersPage(groupID string, page int, perPage int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GetNonMemberUsersPage"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.GroupStore.GetNonMemberUsersPage(groupID, page, perPage, viewRestrictions)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GroupChannelCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GroupChannelCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.GroupChannelCount()
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GroupCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GroupCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.GroupCount()
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GroupCountBySource(source model.GroupSource) (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GroupCountBySource"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.GroupCountBySource(source)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GroupCountWithAllowReference() (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GroupCountWithAllowReference"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.GroupCountWithAllowReference()
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GroupMemberCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GroupMemberCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.GroupMemberCount()
+
+}
+
+func (s *FaultInjectionLayerGroupStore) GroupTeamCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.GroupTeamCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.GroupStore.GroupTeamCount()
+
+}
+
+func (s *FaultInjectionLayerGroupStore) PermanentDeleteMembersByUser(userID string) error {
+
+	if err := s.Root.Injector.inject("GroupStore.PermanentDeleteMembersByUser"); err != nil {
+
+		return err
+	}
+
+	return s.GroupStore.PermanentDeleteMembersByUser(userID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.PermittedSyncableAdmins"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.GroupStore.PermittedSyncableAdmins(syncableID, syncableType)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) Restore(groupID string) (*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.Restore"); err != nil {
+		var result *model.Group
+		return result, err
+	}
+
+	return s.GroupStore.Restore(groupID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.TeamMembersMinusGroupMembers"); err != nil {
+		var result []*model.UserWithGroups
+		return result, err
+	}
+
+	return s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) TeamMembersToAdd(since int64, teamID *string, reAddRemovedMembers bool) ([]*model.UserTeamIDPair, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.TeamMembersToAdd"); err != nil {
+		var result []*model.UserTeamIDPair
+		return result, err
+	}
+
+	return s.GroupStore.TeamMembersToAdd(since, teamID, reAddRemovedMembers)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.TeamMembersToRemove"); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+
+	return s.GroupStore.TeamMembersToRemove(teamID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) Update(group *model.Group) (*model.Group, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.Update"); err != nil {
+		var result *model.Group
+		return result, err
+	}
+
+	return s.GroupStore.Update(group)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.UpdateGroupSyncable"); err != nil {
+		var result *model.GroupSyncable
+		return result, err
+	}
+
+	return s.GroupStore.UpdateGroupSyncable(groupSyncable)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.UpsertMember"); err != nil {
+		var result *model.GroupMember
+		return result, err
+	}
+
+	return s.GroupStore.UpsertMember(groupID, userID)
+
+}
+
+func (s *FaultInjectionLayerGroupStore) UpsertMembers(groupID string, userIDs []string) ([]*model.GroupMember, error) {
+
+	if err := s.Root.Injector.inject("GroupStore.UpsertMembers"); err != nil {
+		var result []*model.GroupMember
+		return result, err
+	}
+
+	return s.GroupStore.UpsertMembers(groupID, userIDs)
+
+}
+
+func (s *FaultInjectionLayerJobStore) Cleanup(expiryTime int64, batchSize int) error {
+
+	if err := s.Root.Injector.inject("JobStore.Cleanup"); err != nil {
+
+		return err
+	}
+
+	return s.JobStore.Cleanup(expiryTime, batchSize)
+
+}
+
+func (s *FaultInjectionLayerJobStore) Delete(id string) (string, error) {
+
+	if err := s.Root.Injector.inject("JobStore.Delete"); err != nil {
+		var result string
+		return result, err
+	}
+
+	return s.JobStore.Delete(id)
+
+}
+
+func (s *FaultInjectionLayerJobStore) Get(rctx request.CTX, id string) (*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.Get"); err != nil {
+		var result *model.Job
+		return result, err
+	}
+
+	return s.JobStore.Get(rctx, id)
+
+}
+
+func (s *FaultInjectionLayerJobStore) GetAllByStatus(rctx request.CTX, status string) ([]*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.GetAllByStatus"); err != nil {
+		var result []*model.Job
+		return result, err
+	}
+
+	return s.JobStore.GetAllByStatus(rctx, status)
+
+}
+
+func (s *FaultInjectionLayerJobStore) GetAllByType(rctx request.CTX, jobType string) ([]*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.GetAllByType"); err != nil {
+		var result []*model.Job
+		return result, err
+	}
+
+	return s.JobStore.GetAllByType(rctx, jobType)
+
+}
+
+func (s *FaultInjectionLayerJobStore) GetAllByTypeAndStatus(rctx request.CTX, jobType string, status string) ([]*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.GetAllByTypeAndStatus"); err != nil {
+		var result []*model.Job
+		return result, err
+	}
+
+	return s.JobStore.GetAllByTypeAndStatus(rctx, jobType, status)
+
+}
+
+func (s *FaultInjectionLayerJobStore) GetAllByTypePage(rctx request.CTX, jobType string, offset int, limit int) ([]*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.GetAllByTypePage"); err != nil {
+		var result []*model.Job
+		return result, err
+	}
+
+	return s.JobStore.GetAllByTypePage(rctx, jobType, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerJobStore) GetAllByTypesAndStatusesPage(rctx request.CTX, jobType []string, status []string, offset int, limit int) ([]*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.GetAllByTypesAndStatusesPage"); err != nil {
+		var result []*model.Job
+		return result, err
+	}
+
+	return s.JobStore.GetAllByTypesAndStatusesPage(rctx, jobType, status, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerJobStore) GetAllByTypesPage(rctx request.CTX, jobTypes []string, offset int, limit int) ([]*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.GetAllByTypesPage"); err != nil {
+		var result []*model.Job
+		return result, err
+	}
+
+	return s.JobStore.GetAllByTypesPage(rctx, jobTypes, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerJobStore) GetByTypeAndData(rctx request.CTX, jobType string, data map[string]string, useMaster bool, statuses ...string) ([]*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.GetByTypeAndData"); err != nil {
+		var result []*model.Job
+		return result, err
+	}
+
+	return s.JobStore.GetByTypeAndData(rctx, jobType, data, useMaster, statuses...)
+
+}
+
+func (s *FaultInjectionLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
+
+	if err := s.Root.Injector.inject("JobStore.GetCountByStatusAndType"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.JobStore.GetCountByStatusAndType(status, jobType)
+
+}
+
+func (s *FaultInjectionLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.GetNewestJobByStatusAndType"); err != nil {
+		var result *model.Job
+		return result, err
+	}
+
+	return s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+
+}
+
+func (s *FaultInjectionLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.GetNewestJobByStatusesAndType"); err != nil {
+		var result *model.Job
+		return result, err
+	}
+
+	return s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
+
+}
+
+func (s *FaultInjectionLayerJobStore) Save(job *model.Job) (*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.Save"); err != nil {
+		var result *model.Job
+		return result, err
+	}
+
+	return s.JobStore.Save(job)
+
+}
+
+func (s *FaultInjectionLayerJobStore) SaveOnce(job *model.Job) (*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.SaveOnce"); err != nil {
+		var result *model.Job
+		return result, err
+	}
+
+	return s.JobStore.SaveOnce(job)
+
+}
+
+func (s *FaultInjectionLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
+
+	if err := s.Root.Injector.inject("JobStore.UpdateOptimistically"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.JobStore.UpdateOptimistically(job, currentStatus)
+
+}
+
+func (s *FaultInjectionLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.UpdateStatus"); err != nil {
+		var result *model.Job
+		return result, err
+	}
+
+	return s.JobStore.UpdateStatus(id, status)
+
+}
+
+func (s *FaultInjectionLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (*model.Job, error) {
+
+	if err := s.Root.Injector.inject("JobStore.UpdateStatusOptimistically"); err != nil {
+		var result *model.Job
+		return result, err
+	}
+
+	return s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+
+}
+
+func (s *FaultInjectionLayerLicenseStore) Get(rctx request.CTX, id string) (*model.LicenseRecord, error) {
+
+	if err := s.Root.Injector.inject("LicenseStore.Get"); err != nil {
+		var result *model.LicenseRecord
+		return result, err
+	}
+
+	return s.LicenseStore.Get(rctx, id)
+
+}
+
+func (s *FaultInjectionLayerLicenseStore) GetAll() ([]*model.LicenseRecord, error) {
+
+	if err := s.Root.Injector.inject("LicenseStore.GetAll"); err != nil {
+		var result []*model.LicenseRecord
+		return result, err
+	}
+
+	return s.LicenseStore.GetAll()
+
+}
+
+func (s *FaultInjectionLayerLicenseStore) Save(license *model.LicenseRecord) error {
+
+	if err := s.Root.Injector.inject("LicenseStore.Save"); err != nil {
+
+		return err
+	}
+
+	return s.LicenseStore.Save(license)
+
+}
+
+func (s *FaultInjectionLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, error) {
+
+	if err := s.Root.Injector.inject("LinkMetadataStore.Get"); err != nil {
+		var result *model.LinkMetadata
+		return result, err
+	}
+
+	return s.LinkMetadataStore.Get(url, timestamp)
+
+}
+
+func (s *FaultInjectionLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
+
+	if err := s.Root.Injector.inject("LinkMetadataStore.Save"); err != nil {
+		var result *model.LinkMetadata
+		return result, err
+	}
+
+	return s.LinkMetadataStore.Save(linkMetadata)
+
+}
+
+func (s *FaultInjectionLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) error {
+
+	if err := s.Root.Injector.inject("NotifyAdminStore.DeleteBefore"); err != nil {
+
+		return err
+	}
+
+	return s.NotifyAdminStore.DeleteBefore(trial, now)
+
+}
+
+func (s *FaultInjectionLayerNotifyAdminStore) Get(trial bool) ([]*model.NotifyAdminData, error) {
+
+	if err := s.Root.Injector.inject("NotifyAdminStore.Get"); err != nil {
+		var result []*model.NotifyAdminData
+		return result, err
+	}
+
+	return s.NotifyAdminStore.Get(trial)
+
+}
+
+func (s *FaultInjectionLayerNotifyAdminStore) GetDataByUserIdAndFeature(userID string, feature model.MattermostFeature) ([]*model.NotifyAdminData, error) {
+
+	if err := s.Root.Injector.inject("NotifyAdminStore.GetDataByUserIdAndFeature"); err != nil {
+		var result []*model.NotifyAdminData
+		return result, err
+	}
+
+	return s.NotifyAdminStore.GetDataByUserIdAndFeature(userID, feature)
+
+}
+
+func (s *FaultInjectionLayerNotifyAdminStore) Save(data *model.NotifyAdminData) (*model.NotifyAdminData, error) {
+
+	if err := s.Root.Injector.inject("NotifyAdminStore.Save"); err != nil {
+		var result *model.NotifyAdminData
+		return result, err
+	}
+
+	return s.NotifyAdminStore.Save(data)
+
+}
+
+func (s *FaultInjectionLayerNotifyAdminStore) Update(userID string, requiredPlan string, requiredFeature model.MattermostFeature, now int64) error {
+
+	if err := s.Root.Injector.inject("NotifyAdminStore.Update"); err != nil {
+
+		return err
+	}
+
+	return s.NotifyAdminStore.Update(userID, requiredPlan, requiredFeature, now)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) DeleteApp(id string) error {
+
+	if err := s.Root.Injector.inject("OAuthStore.DeleteApp"); err != nil {
+
+		return err
+	}
+
+	return s.OAuthStore.DeleteApp(id)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.GetAccessData"); err != nil {
+		var result *model.AccessData
+		return result, err
+	}
+
+	return s.OAuthStore.GetAccessData(token)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.GetAccessDataByRefreshToken"); err != nil {
+		var result *model.AccessData
+		return result, err
+	}
+
+	return s.OAuthStore.GetAccessDataByRefreshToken(token)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) GetAccessDataByUserForApp(userID string, clientID string) ([]*model.AccessData, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.GetAccessDataByUserForApp"); err != nil {
+		var result []*model.AccessData
+		return result, err
+	}
+
+	return s.OAuthStore.GetAccessDataByUserForApp(userID, clientID)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.GetApp"); err != nil {
+		var result *model.OAuthApp
+		return result, err
+	}
+
+	return s.OAuthStore.GetApp(id)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) GetAppByUser(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.GetAppByUser"); err != nil {
+		var result []*model.OAuthApp
+		return result, err
+	}
+
+	return s.OAuthStore.GetAppByUser(userID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.GetApps"); err != nil {
+		var result []*model.OAuthApp
+		return result, err
+	}
+
+	return s.OAuthStore.GetApps(offset, limit)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.GetAuthData"); err != nil {
+		var result *model.AuthData
+		return result, err
+	}
+
+	return s.OAuthStore.GetAuthData(code)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) GetAuthorizedApps(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.GetAuthorizedApps"); err != nil {
+		var result []*model.OAuthApp
+		return result, err
+	}
+
+	return s.OAuthStore.GetAuthorizedApps(userID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) GetDeviceAuthorization(deviceCode string) (*model.DeviceAuthorization, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.GetDeviceAuthorization"); err != nil {
+		var result *model.DeviceAuthorization
+		return result, err
+	}
+
+	return s.OAuthStore.GetDeviceAuthorization(deviceCode)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) GetDeviceAuthorizationByUserCode(userCode string) (*model.DeviceAuthorization, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.GetDeviceAuthorizationByUserCode"); err != nil {
+		var result *model.DeviceAuthorization
+		return result, err
+	}
+
+	return s.OAuthStore.GetDeviceAuthorizationByUserCode(userCode)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) GetPreviousAccessData(userID string, clientID string) (*model.AccessData, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.GetPreviousAccessData"); err != nil {
+		var result *model.AccessData
+		return result, err
+	}
+
+	return s.OAuthStore.GetPreviousAccessData(userID, clientID)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) PermanentDeleteAuthDataByUser(userID string) error {
+
+	if err := s.Root.Injector.inject("OAuthStore.PermanentDeleteAuthDataByUser"); err != nil {
+
+		return err
+	}
+
+	return s.OAuthStore.PermanentDeleteAuthDataByUser(userID)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) RemoveAccessData(token string) error {
+
+	if err := s.Root.Injector.inject("OAuthStore.RemoveAccessData"); err != nil {
+
+		return err
+	}
+
+	return s.OAuthStore.RemoveAccessData(token)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) RemoveAllAccessData() error {
+
+	if err := s.Root.Injector.inject("OAuthStore.RemoveAllAccessData"); err != nil {
+
+		return err
+	}
+
+	return s.OAuthStore.RemoveAllAccessData()
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) RemoveAuthData(code string) error {
+
+	if err := s.Root.Injector.inject("OAuthStore.RemoveAuthData"); err != nil {
+
+		return err
+	}
+
+	return s.OAuthStore.RemoveAuthData(code)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) RemoveAuthDataByClientId(clientID string, userID string) error {
+
+	if err := s.Root.Injector.inject("OAuthStore.RemoveAuthDataByClientId"); err != nil {
+
+		return err
+	}
+
+	return s.OAuthStore.RemoveAuthDataByClientId(clientID, userID)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) RemoveAuthDataByUserId(userID string) error {
+
+	if err := s.Root.Injector.inject("OAuthStore.RemoveAuthDataByUserId"); err != nil {
+
+		return err
+	}
+
+	return s.OAuthStore.RemoveAuthDataByUserId(userID)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) RemoveDeviceAuthorization(deviceCode string) error {
+
+	if err := s.Root.Injector.inject("OAuthStore.RemoveDeviceAuthorization"); err != nil {
+
+		return err
+	}
+
+	return s.OAuthStore.RemoveDeviceAuthorization(deviceCode)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) RemoveExpiredDeviceAuthorizations(expiredBefore int64) error {
+
+	if err := s.Root.Injector.inject("OAuthStore.RemoveExpiredDeviceAuthorizations"); err != nil {
+
+		return err
+	}
+
+	return s.OAuthStore.RemoveExpiredDeviceAuthorizations(expiredBefore)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.SaveAccessData"); err != nil {
+		var result *model.AccessData
+		return result, err
+	}
+
+	return s.OAuthStore.SaveAccessData(accessData)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.SaveApp"); err != nil {
+		var result *model.OAuthApp
+		return result, err
+	}
+
+	return s.OAuthStore.SaveApp(app)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.SaveAuthData"); err != nil {
+		var result *model.AuthData
+		return result, err
+	}
+
+	return s.OAuthStore.SaveAuthData(authData)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) SaveDeviceAuthorization(authorization *model.DeviceAuthorization) (*model.DeviceAuthorization, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.SaveDeviceAuthorization"); err != nil {
+		var result *model.DeviceAuthorization
+		return result, err
+	}
+
+	return s.OAuthStore.SaveDeviceAuthorization(authorization)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.UpdateAccessData"); err != nil {
+		var result *model.AccessData
+		return result, err
+	}
+
+	return s.OAuthStore.UpdateAccessData(accessData)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+
+	if err := s.Root.Injector.inject("OAuthStore.UpdateApp"); err != nil {
+		var result *model.OAuthApp
+		return result, err
+	}
+
+	return s.OAuthStore.UpdateApp(app)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) UpdateDeviceAuthorizationPoll(deviceCode string, lastPolledAt int64, pollInterval int32) error {
+
+	if err := s.Root.Injector.inject("OAuthStore.UpdateDeviceAuthorizationPoll"); err != nil {
+
+		return err
+	}
+
+	return s.OAuthStore.UpdateDeviceAuthorizationPoll(deviceCode, lastPolledAt, pollInterval)
+
+}
+
+func (s *FaultInjectionLayerOAuthStore) UpdateDeviceAuthorizationStatus(deviceCode string, userID string, status string) error {
+
+	if err := s.Root.Injector.inject("OAuthStore.UpdateDeviceAuthorizationStatus"); err != nil {
+
+		return err
+	}
+
+	return s.OAuthStore.UpdateDeviceAuthorizationStatus(deviceCode, userID, status)
+
+}
+
+func (s *FaultInjectionLayerOutgoingEmailStore) ClaimPending(now int64, leaseMillis int64, limit int) ([]*model.OutgoingEmail, error) {
+
+	if err := s.Root.Injector.inject("OutgoingEmailStore.ClaimPending"); err != nil {
+		var result []*model.OutgoingEmail
+		return result, err
+	}
+
+	return s.OutgoingEmailStore.ClaimPending(now, leaseMillis, limit)
+
+}
+
+func (s *FaultInjectionLayerOutgoingEmailStore) CountByStatus(status string) (int64, error) {
+
+	if err := s.Root.Injector.inject("OutgoingEmailStore.CountByStatus"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.OutgoingEmailStore.CountByStatus(status)
+
+}
+
+func (s *FaultInjectionLayerOutgoingEmailStore) Delete(id string) error {
+
+	if err := s.Root.Injector.inject("OutgoingEmailStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.OutgoingEmailStore.Delete(id)
+
+}
+
+func (s *FaultInjectionLayerOutgoingEmailStore) Get(id string) (*model.OutgoingEmail, error) {
+
+	if err := s.Root.Injector.inject("OutgoingEmailStore.Get"); err != nil {
+		var result *model.OutgoingEmail
+		return result, err
+	}
+
+	return s.OutgoingEmailStore.Get(id)
+
+}
+
+func (s *FaultInjectionLayerOutgoingEmailStore) GetByStatus(status string, offset int, limit int) ([]*model.OutgoingEmail, error) {
+
+	if err := s.Root.Injector.inject("OutgoingEmailStore.GetByStatus"); err != nil {
+		var result []*model.OutgoingEmail
+		return result, err
+	}
+
+	return s.OutgoingEmailStore.GetByStatus(status, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerOutgoingEmailStore) Save(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
+
+	if err := s.Root.Injector.inject("OutgoingEmailStore.Save"); err != nil {
+		var result *model.OutgoingEmail
+		return result, err
+	}
+
+	return s.OutgoingEmailStore.Save(email)
+
+}
+
+func (s *FaultInjectionLayerOutgoingEmailStore) Update(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
+
+	if err := s.Root.Injector.inject("OutgoingEmailStore.Update"); err != nil {
+		var result *model.OutgoingEmail
+		return result, err
+	}
+
+	return s.OutgoingEmailStore.Update(email)
+
+}
+
+func (s *FaultInjectionLayerOutgoingOAuthConnectionStore) DeleteConnection(rctx request.CTX, id string) error {
+
+	if err := s.Root.Injector.inject("OutgoingOAuthConnectionStore.DeleteConnection"); err != nil {
+
+		return err
+	}
+
+	return s.OutgoingOAuthConnectionStore.DeleteConnection(rctx, id)
+
+}
+
+func (s *FaultInjectionLayerOutgoingOAuthConnectionStore) GetConnection(rctx request.CTX, id string) (*model.OutgoingOAuthConnection, error) {
+
+	if err := s.Root.Injector.inject("OutgoingOAuthConnectionStore.GetConnection"); err != nil {
+		var result *model.OutgoingOAuthConnection
+		return result, err
+	}
+
+	return s.OutgoingOAuthConnectionStore.GetConnection(rctx, id)
+
+}
+
+func (s *FaultInjectionLayerOutgoingOAuthConnectionStore) GetConnections(rctx request.CTX, filters model.OutgoingOAuthConnectionGetConnectionsFilter) ([]*model.OutgoingOAuthConnection, error) {
+
+	if err := s.Root.Injector.inject("OutgoingOAuthConnectionStore.GetConnections"); err != nil {
+		var result []*model.OutgoingOAuthConnection
+		return result, err
+	}
+
+	return s.OutgoingOAuthConnectionStore.GetConnections(rctx, filters)
+
+}
+
+func (s *FaultInjectionLayerOutgoingOAuthConnectionStore) SaveConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+
+	if err := s.Root.Injector.inject("OutgoingOAuthConnectionStore.SaveConnection"); err != nil {
+		var result *model.OutgoingOAuthConnection
+		return result, err
+	}
+
+	return s.OutgoingOAuthConnectionStore.SaveConnection(rctx, conn)
+
+}
+
+func (s *FaultInjectionLayerOutgoingOAuthConnectionStore) UpdateConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+
+	if err := s.Root.Injector.inject("OutgoingOAuthConnectionStore.UpdateConnection"); err != nil {
+		var result *model.OutgoingOAuthConnection
+		return result, err
+	}
+
+	return s.OutgoingOAuthConnectionStore.UpdateConnection(rctx, conn)
+
+}
+
+func (s *FaultInjectionLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+
+	if err := s.Root.Injector.inject("PluginStore.CompareAndDelete"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.PluginStore.CompareAndDelete(keyVal, oldValue)
+
+}
+
+func (s *FaultInjectionLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+
+	if err := s.Root.Injector.inject("PluginStore.CompareAndSet"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.PluginStore.CompareAndSet(keyVal, oldValue)
+
+}
+
+func (s *FaultInjectionLayerPluginStore) Delete(pluginID string, key string) error {
+
+	if err := s.Root.Injector.inject("PluginStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.PluginStore.Delete(pluginID, key)
+
+}
+
+func (s *FaultInjectionLayerPluginStore) DeleteAllExpired() error {
+
+	if err := s.Root.Injector.inject("PluginStore.DeleteAllExpired"); err != nil {
+
+		return err
+	}
+
+	return s.PluginStore.DeleteAllExpired()
+
+}
+
+func (s *FaultInjectionLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
+
+	if err := s.Root.Injector.inject("PluginStore.DeleteAllForPlugin"); err != nil {
+
+		return err
+	}
+
+	return s.PluginStore.DeleteAllForPlugin(PluginID)
+
+}
+
+func (s *FaultInjectionLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
+
+	if err := s.Root.Injector.inject("PluginStore.Get"); err != nil {
+		var result *model.PluginKeyValue
+		return result, err
+	}
+
+	return s.PluginStore.Get(pluginID, key)
+
+}
+
+func (s *FaultInjectionLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
+
+	if err := s.Root.Injector.inject("PluginStore.List"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.PluginStore.List(pluginID, page, perPage)
+
+}
+
+func (s *FaultInjectionLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+
+	if err := s.Root.Injector.inject("PluginStore.SaveOrUpdate"); err != nil {
+		var result *model.PluginKeyValue
+		return result, err
+	}
+
+	return s.PluginStore.SaveOrUpdate(keyVal)
+
+}
+
+func (s *FaultInjectionLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+
+	if err := s.Root.Injector.inject("PluginStore.SetWithOptions"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.PluginStore.SetWithOptions(pluginID, key, value, options)
+
+}
+
+func (s *FaultInjectionLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
+
+	if err := s.Root.Injector.inject("PostStore.AnalyticsPostCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.PostStore.AnalyticsPostCount(options)
+
+}
+
+func (s *FaultInjectionLayerPostStore) AnalyticsPostCountByTeam(teamID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("PostStore.AnalyticsPostCountByTeam"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.PostStore.AnalyticsPostCountByTeam(teamID)
+
+}
+
+func (s *FaultInjectionLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+
+	if err := s.Root.Injector.inject("PostStore.AnalyticsPostCountsByDay"); err != nil {
+		var result model.AnalyticsRows
+		return result, err
+	}
+
+	return s.PostStore.AnalyticsPostCountsByDay(options)
+
+}
+
+func (s *FaultInjectionLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error) {
+
+	if err := s.Root.Injector.inject("PostStore.AnalyticsUserCountsWithPostsByDay"); err != nil {
+		var result model.AnalyticsRows
+		return result, err
+	}
+
+	return s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
+
+}
+
+func (s *FaultInjectionLayerPostStore) ClearCaches() {
+
+	s.Root.Injector.delay("PostStore.ClearCaches")
+
+	s.PostStore.ClearCaches()
+
+}
+
+func (s *FaultInjectionLayerPostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
+
+	if err := s.Root.Injector.inject("PostStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.PostStore.Delete(rctx, postID, timestamp, deleteByID)
+
+}
+
+func (s *FaultInjectionLayerPostStore) DeleteAllPostRemindersForPost(postId string) error {
+
+	if err := s.Root.Injector.inject("PostStore.DeleteAllPostRemindersForPost"); err != nil {
+
+		return err
+	}
+
+	return s.PostStore.DeleteAllPostRemindersForPost(postId)
+
+}
+
+func (s *FaultInjectionLayerPostStore) Get(rctx request.CTX, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
+
+	if err := s.Root.Injector.inject("PostStore.Get"); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+
+	return s.PostStore.Get(rctx, id, opts, userID, sanitizeOptions)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetDirectPostParentsForExportAfter"); err != nil {
+		var result []*model.DirectPostForExport
+		return result, err
+	}
+
+	return s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetEditHistoryForPost(postID string) ([]*model.Post, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetEditHistoryForPost"); err != nil {
+		var result []*model.Post
+		return result, err
+	}
+
+	return s.PostStore.GetEditHistoryForPost(postID)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
+
+	s.Root.Injector.delay("PostStore.GetEtag")
+
+	return s.PostStore.GetEtag(channelID, allowFromCache, collapsedThreads)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetFlaggedPosts"); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+
+	return s.PostStore.GetFlaggedPosts(userID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID string, offset int, limit int) (*model.PostList, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetFlaggedPostsForChannel"); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+
+	return s.PostStore.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset int, limit int) (*model.PostList, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetFlaggedPostsForTeam"); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+
+	return s.PostStore.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetMaxPostSize() int {
+
+	s.Root.Injector.delay("PostStore.GetMaxPostSize")
+
+	return s.PostStore.GetMaxPostSize()
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetNthRecentPostTime(n int64) (int64, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetNthRecentPostTime"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.PostStore.GetNthRecentPostTime(n)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetOldest() (*model.Post, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetOldest"); err != nil {
+		var result *model.Post
+		return result, err
+	}
+
+	return s.PostStore.GetOldest()
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetOldestEntityCreationTime"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.PostStore.GetOldestEntityCreationTime()
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetParentsForExportAfter"); err != nil {
+		var result []*model.PostForExport
+		return result, err
+	}
+
+	return s.PostStore.GetParentsForExportAfter(limit, afterID, includeArchivedChannels)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostAfterTime(channelID string, timestamp int64, collapsedThreads bool) (*model.Post, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostAfterTime"); err != nil {
+		var result *model.Post
+		return result, err
+	}
+
+	return s.PostStore.GetPostAfterTime(channelID, timestamp, collapsedThreads)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostIdAfterTime(channelID string, timestamp int64, collapsedThreads bool) (string, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostIdAfterTime"); err != nil {
+		var result string
+		return result, err
+	}
+
+	return s.PostStore.GetPostIdAfterTime(channelID, timestamp, collapsedThreads)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostIdBeforeTime(channelID string, timestamp int64, collapsedThreads bool) (string, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostIdBeforeTime"); err != nil {
+		var result string
+		return result, err
+	}
+
+	return s.PostStore.GetPostIdBeforeTime(channelID, timestamp, collapsedThreads)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostReminderMetadata(postID string) (*store.PostReminderMetadata, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostReminderMetadata"); err != nil {
+		var result *store.PostReminderMetadata
+		return result, err
+	}
+
+	return s.PostStore.GetPostReminderMetadata(postID)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostReminders(now int64) ([]*model.PostReminder, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostReminders"); err != nil {
+		var result []*model.PostReminder
+		return result, err
+	}
+
+	return s.PostStore.GetPostReminders(now)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPosts(rctx request.CTX, options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPosts"); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+
+	return s.PostStore.GetPosts(rctx, options, allowFromCache, sanitizeOptions)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostsAfter(rctx request.CTX, options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostsAfter"); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+
+	return s.PostStore.GetPostsAfter(rctx, options, sanitizeOptions)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostsBatchForIndexing"); err != nil {
+		var result []*model.PostForIndexing
+		return result, err
+	}
+
+	return s.PostStore.GetPostsBatchForIndexing(startTime, startPostID, limit)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostsBefore(rctx request.CTX, options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostsBefore"); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+
+	return s.PostStore.GetPostsBefore(rctx, options, sanitizeOptions)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostsByIds"); err != nil {
+		var result []*model.Post
+		return result, err
+	}
+
+	return s.PostStore.GetPostsByIds(postIds)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostsByThread(threadID string, since int64) ([]*model.Post, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostsByThread"); err != nil {
+		var result []*model.Post
+		return result, err
+	}
+
+	return s.PostStore.GetPostsByThread(threadID, since)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostsCreatedAt(channelID string, timestamp int64) ([]*model.Post, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostsCreatedAt"); err != nil {
+		var result []*model.Post
+		return result, err
+	}
+
+	return s.PostStore.GetPostsCreatedAt(channelID, timestamp)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostsSince"); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+
+	return s.PostStore.GetPostsSince(rctx, options, allowFromCache, sanitizeOptions)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetPostsSinceForSync"); err != nil {
+		var result []*model.Post
+		var resultVar1 model.GetPostsSinceForSyncCursor
+		return result, resultVar1, err
+	}
+
+	return s.PostStore.GetPostsSinceForSync(options, cursor, limit)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetRepliesForExport"); err != nil {
+		var result []*model.ReplyForExport
+		return result, err
+	}
+
+	return s.PostStore.GetRepliesForExport(parentID)
+
+}
+
+func (s *FaultInjectionLayerPostStore) GetSingle(rctx request.CTX, id string, inclDeleted bool) (*model.Post, error) {
+
+	if err := s.Root.Injector.inject("PostStore.GetSingle"); err != nil {
+		var result *model.Post
+		return result, err
+	}
+
+	return s.PostStore.GetSingle(rctx, id, inclDeleted)
+
+}
+
+func (s *FaultInjectionLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userID string) (bool, error) {
+
+	if err := s.Root.Injector.inject("PostStore.HasAutoResponsePostByUserSince"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.PostStore.HasAutoResponsePostByUserSince(options, userID)
+
+}
+
+func (s *FaultInjectionLayerPostStore) InvalidateLastPostTimeCache(channelID string) {
+
+	s.Root.Injector.delay("PostStore.InvalidateLastPostTimeCache")
+
+	s.PostStore.InvalidateLastPostTimeCache(channelID)
+
+}
+
+func (s *FaultInjectionLayerPostStore) Overwrite(rctx request.CTX, post *model.Post) (*model.Post, error) {
+
+	if err := s.Root.Injector.inject("PostStore.Overwrite"); err != nil {
+		var result *model.Post
+		return result, err
+	}
+
+	return s.PostStore.Overwrite(rctx, post)
+
+}
+
+func (s *FaultInjectionLayerPostStore) OverwriteMultiple(rctx request.CTX, posts []*model.Post) ([]*model.Post, int, error) {
+
+	if err := s.Root.Injector.inject("PostStore.OverwriteMultiple"); err != nil {
+		var result []*model.Post
+		var resultVar1 int
+		return result, resultVar1, err
+	}
+
+	return s.PostStore.OverwriteMultiple(rctx, posts)
+
+}
+
+func (s *FaultInjectionLayerPostStore) PermanentDelete(rctx request.CTX, postID string) error {
+
+	if err := s.Root.Injector.inject("PostStore.PermanentDelete"); err != nil {
+
+		return err
+	}
+
+	return s.PostStore.PermanentDelete(rctx, postID)
+
+}
+
+func (s *FaultInjectionLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	if err := s.Root.Injector.inject("PostStore.PermanentDeleteBatch"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.PostStore.PermanentDeleteBatch(endTime, limit)
+
+}
+
+func (s *FaultInjectionLayerPostStore) PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs model.RetentionPolicyBatchConfigs, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+
+	if err := s.Root.Injector.inject("PostStore.PermanentDeleteBatchForRetentionPolicies"); err != nil {
+		var result int64
+		var resultVar1 model.RetentionPolicyCursor
+		return result, resultVar1, err
+	}
+
+	return s.PostStore.PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs, cursor)
+
+}
+
+func (s *FaultInjectionLayerPostStore) PermanentDeleteByChannel(rctx request.CTX, channelID string) error {
+
+	if err := s.Root.Injector.inject("PostStore.PermanentDeleteByChannel"); err != nil {
+
+		return err
+	}
+
+	return s.PostStore.PermanentDeleteByChannel(rctx, channelID)
+
+}
+
+func (s *FaultInjectionLayerPostStore) PermanentDeleteByUser(rctx request.CTX, userID string) error {
+
+	if err := s.Root.Injector.inject("PostStore.PermanentDeleteByUser"); err != nil {
+
+		return err
+	}
+
+	return s.PostStore.PermanentDeleteByUser(rctx, userID)
+
+}
+
+func (s *FaultInjectionLayerPostStore) RefreshPostStats() error {
+
+	if err := s.Root.Injector.inject("PostStore.RefreshPostStats"); err != nil {
+
+		return err
+	}
+
+	return s.PostStore.RefreshPostStats()
+
+}
+
+func (s *FaultInjectionLayerPostStore) RestoreContentFlaggedPost(post *model.Post, statusFieldId string, contentFlaggingManagedFieldId string) error {
+
+	if err := s.Root.Injector.inject("PostStore.RestoreContentFlaggedPost"); err != nil {
+
+		return err
+	}
+
+	return s.PostStore.RestoreContentFlaggedPost(post, statusFieldId, contentFlaggingManagedFieldId)
+
+}
+
+func (s *FaultInjectionLayerPostStore) Save(rctx request.CTX, post *model.Post) (*model.Post, error) {
+
+	if err := s.Root.Injector.inject("PostStore.Save"); err != nil {
+		var result *model.Post
+		return result, err
+	}
+
+	return s.PostStore.Save(rctx, post)
+
+}
+
+func (s *FaultInjectionLayerPostStore) SaveMultiple(rctx request.CTX, posts []*model.Post) ([]*model.Post, int, error) {
+
+	if err := s.Root.Injector.inject("PostStore.SaveMultiple"); err != nil {
+		var result []*model.Post
+		var resultVar1 int
+		return result, resultVar1, err
+	}
+
+	return s.PostStore.SaveMultiple(rctx, posts)
+
+}
+
+func (s *FaultInjectionLayerPostStore) Search(teamID string, userID string, params *model.SearchParams) (*model.PostList, error) {
+
+	if err := s.Root.Injector.inject("PostStore.Search"); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+
+	return s.PostStore.Search(teamID, userID, params)
+
+}
+
+func (s *FaultInjectionLayerPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
+
+	if err := s.Root.Injector.inject("PostStore.SearchPostsForUser"); err != nil {
+		var result *model.PostSearchResults
+		return result, err
+	}
+
+	return s.PostStore.SearchPostsForUser(rctx, paramsList, userID, teamID, page, perPage)
+
+}
+
+func (s *FaultInjectionLayerPostStore) SetPostReminder(reminder *model.PostReminder) error {
+
+	if err := s.Root.Injector.inject("PostStore.SetPostReminder"); err != nil {
+
+		return err
+	}
+
+	return s.PostStore.SetPostReminder(reminder)
+
+}
+
+func (s *FaultInjectionLayerPostStore) Update(rctx request.CTX, newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+
+	if err := s.Root.Injector.inject("PostStore.Update"); err != nil {
+		var result *model.Post
+		return result, err
+	}
+
+	return s.PostStore.Update(rctx, newPost, oldPost)
+
+}
+
+func (s *FaultInjectionLayerPostAcknowledgementStore) BatchDelete(acknowledgements []*model.PostAcknowledgement) error {
+
+	if err := s.Root.Injector.inject("PostAcknowledgementStore.BatchDelete"); err != nil {
+
+		return err
+	}
+
+	return s.PostAcknowledgementStore.BatchDelete(acknowledgements)
+
+}
+
+func (s *FaultInjectionLayerPostAcknowledgementStore) BatchSave(acknowledgements []*model.PostAcknowledgement) ([]*model.PostAcknowledgement, error) {
+
+	if err := s.Root.Injector.inject("PostAcknowledgementStore.BatchSave"); err != nil {
+		var result []*model.PostAcknowledgement
+		return result, err
+	}
+
+	return s.PostAcknowledgementStore.BatchSave(acknowledgements)
+
+}
+
+func (s *FaultInjectionLayerPostAcknowledgementStore) Delete(acknowledgement *model.PostAcknowledgement) error {
+
+	if err := s.Root.Injector.inject("PostAcknowledgementStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.PostAcknowledgementStore.Delete(acknowledgement)
+
+}
+
+func (s *FaultInjectionLayerPostAcknowledgementStore) DeleteAllForPost(postID string) error {
+
+	if err := s.Root.Injector.inject("PostAcknowledgementStore.DeleteAllForPost"); err != nil {
+
+		return err
+	}
+
+	return s.PostAcknowledgementStore.DeleteAllForPost(postID)
+
+}
+
+func (s *FaultInjectionLayerPostAcknowledgementStore) Get(postID string, userID string) (*model.PostAcknowledgement, error) {
+
+	if err := s.Root.Injector.inject("PostAcknowledgementStore.Get"); err != nil {
+		var result *model.PostAcknowledgement
+		return result, err
+	}
+
+	return s.PostAcknowledgementStore.Get(postID, userID)
+
+}
+
+func (s *FaultInjectionLayerPostAcknowledgementStore) GetForPost(postID string) ([]*model.PostAcknowledgement, error) {
+
+	if err := s.Root.Injector.inject("PostAcknowledgementStore.GetForPost"); err != nil {
+		var result []*model.PostAcknowledgement
+		return result, err
+	}
+
+	return s.PostAcknowledgementStore.GetForPost(postID)
+
+}
+
+func (s *FaultInjectionLayerPostAcknowledgementStore) GetForPostSince(postID string, since int64, excludeRemoteID string, inclDeleted bool) ([]*model.PostAcknowledgement, error) {
+
+	if err := s.Root.Injector.inject("PostAcknowledgementStore.GetForPostSince"); err != nil {
+		var result []*model.PostAcknowledgement
+		return result, err
+	}
+
+	return s.PostAcknowledgementStore.GetForPostSince(postID, since, excludeRemoteID, inclDeleted)
+
+}
+
+func (s *FaultInjectionLayerPostAcknowledgementStore) GetForPosts(postIds []string) ([]*model.PostAcknowledgement, error) {
+
+	if err := s.Root.Injector.inject("PostAcknowledgementStore.GetForPosts"); err != nil {
+		var result []*model.PostAcknowledgement
+		return result, err
+	}
+
+	return s.PostAcknowledgementStore.GetForPosts(postIds)
+
+}
+
+func (s *FaultInjectionLayerPostAcknowledgementStore) GetSingle(userID string, postID string, remoteID string) (*model.PostAcknowledgement, error) {
+
+	if err := s.Root.Injector.inject("PostAcknowledgementStore.GetSingle"); err != nil {
+		var result *model.PostAcknowledgement
+		return result, err
+	}
+
+	return s.PostAcknowledgementStore.GetSingle(userID, postID, remoteID)
+
+}
+
+func (s *FaultInjectionLayerPostAcknowledgementStore) SaveWithModel(acknowledgement *model.PostAcknowledgement) (*model.PostAcknowledgement, error) {
+
+	if err := s.Root.Injector.inject("PostAcknowledgementStore.SaveWithModel"); err != nil {
+		var result *model.PostAcknowledgement
+		return result, err
+	}
+
+	return s.PostAcknowledgementStore.SaveWithModel(acknowledgement)
+
+}
+
+func (s *FaultInjectionLayerPostPersistentNotificationStore) Delete(postIds []string) error {
+
+	if err := s.Root.Injector.inject("PostPersistentNotificationStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.PostPersistentNotificationStore.Delete(postIds)
+
+}
+
+func (s *FaultInjectionLayerPostPersistentNotificationStore) DeleteByChannel(channelIds []string) error {
+
+	if err := s.Root.Injector.inject("PostPersistentNotificationStore.DeleteByChannel"); err != nil {
+
+		return err
+	}
+
+	return s.PostPersistentNotificationStore.DeleteByChannel(channelIds)
+
+}
+
+func (s *FaultInjectionLayerPostPersistentNotificationStore) DeleteByTeam(teamIds []string) error {
+
+	if err := s.Root.Injector.inject("PostPersistentNotificationStore.DeleteByTeam"); err != nil {
+
+		return err
+	}
+
+	return s.PostPersistentNotificationStore.DeleteByTeam(teamIds)
+
+}
+
+func (s *FaultInjectionLayerPostPersistentNotificationStore) DeleteExpired(maxSentCount int16) error {
+
+	if err := s.Root.Injector.inject("PostPersistentNotificationStore.DeleteExpired"); err != nil {
+
+		return err
+	}
+
+	return s.PostPersistentNotificationStore.DeleteExpired(maxSentCount)
+
+}
+
+func (s *FaultInjectionLayerPostPersistentNotificationStore) Get(params model.GetPersistentNotificationsPostsParams) ([]*model.PostPersistentNotifications, error) {
+
+	if err := s.Root.Injector.inject("PostPersistentNotificationStore.Get"); err != nil {
+		var result []*model.PostPersistentNotifications
+		return result, err
+	}
+
+	return s.PostPersistentNotificationStore.Get(params)
+
+}
+
+func (s *FaultInjectionLayerPostPersistentNotificationStore) GetSingle(postID string) (*model.PostPersistentNotifications, error) {
+
+	if err := s.Root.Injector.inject("PostPersistentNotificationStore.GetSingle"); err != nil {
+		var result *model.PostPersistentNotifications
+		return result, err
+	}
+
+	return s.PostPersistentNotificationStore.GetSingle(postID)
+
+}
+
+func (s *FaultInjectionLayerPostPersistentNotificationStore) UpdateLastActivity(postIds []string) error {
+
+	if err := s.Root.Injector.inject("PostPersistentNotificationStore.UpdateLastActivity"); err != nil {
+
+		return err
+	}
+
+	return s.PostPersistentNotificationStore.UpdateLastActivity(postIds)
+
+}
+
+func (s *FaultInjectionLayerPostPriorityStore) Delete(postID string) error {
+
+	if err := s.Root.Injector.inject("PostPriorityStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.PostPriorityStore.Delete(postID)
+
+}
+
+func (s *FaultInjectionLayerPostPriorityStore) GetForPost(postID string) (*model.PostPriority, error) {
+
+	if err := s.Root.Injector.inject("PostPriorityStore.GetForPost"); err != nil {
+		var result *model.PostPriority
+		return result, err
+	}
+
+	return s.PostPriorityStore.GetForPost(postID)
+
+}
+
+func (s *FaultInjectionLayerPostPriorityStore) GetForPosts(ids []string) ([]*model.PostPriority, error) {
+
+	if err := s.Root.Injector.inject("PostPriorityStore.GetForPosts"); err != nil {
+		var result []*model.PostPriority
+		return result, err
+	}
+
+	return s.PostPriorityStore.GetForPosts(ids)
+
+}
+
+func (s *FaultInjectionLayerPostPriorityStore) Save(priority *model.PostPriority) (*model.PostPriority, error) {
+
+	if err := s.Root.Injector.inject("PostPriorityStore.Save"); err != nil {
+		var result *model.PostPriority
+		return result, err
+	}
+
+	return s.PostPriorityStore.Save(priority)
+
+}
+
+func (s *FaultInjectionLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+
+	if err := s.Root.Injector.inject("PreferenceStore.CleanupFlagsBatch"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.PreferenceStore.CleanupFlagsBatch(limit)
+
+}
+
+func (s *FaultInjectionLayerPreferenceStore) Delete(userID string, category string, name string) error {
+
+	if err := s.Root.Injector.inject("PreferenceStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.PreferenceStore.Delete(userID, category, name)
+
+}
+
+func (s *FaultInjectionLayerPreferenceStore) DeleteCategory(userID string, category string) error {
+
+	if err := s.Root.Injector.inject("PreferenceStore.DeleteCategory"); err != nil {
+
+		return err
+	}
+
+	return s.PreferenceStore.DeleteCategory(userID, category)
+
+}
+
+func (s *FaultInjectionLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+
+	if err := s.Root.Injector.inject("PreferenceStore.DeleteCategoryAndName"); err != nil {
+
+		return err
+	}
+
+	return s.PreferenceStore.DeleteCategoryAndName(category, name)
+
+}
+
+func (s *FaultInjectionLayerPreferenceStore) DeleteInvalidVisibleDmsGms() (int64, error) {
+
+	if err := s.Root.Injector.inject("PreferenceStore.DeleteInvalidVisibleDmsGms"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.PreferenceStore.DeleteInvalidVisibleDmsGms()
+
+}
+
+func (s *FaultInjectionLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
+
+	if err := s.Root.Injector.inject("PreferenceStore.DeleteOrphanedRows"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.PreferenceStore.DeleteOrphanedRows(limit)
+
+}
+
+func (s *FaultInjectionLayerPreferenceStore) Get(userID string, category string, name string) (*model.Preference, error) {
+
+	if err := s.Root.Injector.inject("PreferenceStore.Get"); err != nil {
+		var result *model.Preference
+		return result, err
+	}
+
+	return s.PreferenceStore.Get(userID, category, name)
+
+}
+
+func (s *FaultInjectionLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
+
+	if err := s.Root.Injector.inject("PreferenceStore.GetAll"); err != nil {
+		var result model.Preferences
+		return result, err
+	}
+
+	return s.PreferenceStore.GetAll(userID)
+
+}
+
+func (s *FaultInjectionLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
+
+	if err := s.Root.Injector.inject("PreferenceStore.GetCategory"); err != nil {
+		var result model.Preferences
+		return result, err
+	}
+
+	return s.PreferenceStore.GetCategory(userID, category)
+
+}
+
+func (s *FaultInjectionLayerPreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, error) {
+
+	if err := s.Root.Injector.inject("PreferenceStore.GetCategoryAndName"); err != nil {
+		var result model.Preferences
+		return result, err
+	}
+
+	return s.PreferenceStore.GetCategoryAndName(category, name)
+
+}
+
+func (s *FaultInjectionLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
+
+	if err := s.Root.Injector.inject("PreferenceStore.PermanentDeleteByUser"); err != nil {
+
+		return err
+	}
+
+	return s.PreferenceStore.PermanentDeleteByUser(userID)
+
+}
+
+func (s *FaultInjectionLayerPreferenceStore) Save(preferences model.Preferences) error {
+
+	if err := s.Root.Injector.inject("PreferenceStore.Save"); err != nil {
+
+		return err
+	}
+
+	return s.PreferenceStore.Save(preferences)
+
+}
+
+func (s *FaultInjectionLayerProductNoticesStore) Clear(notices []string) error {
+
+	if err := s.Root.Injector.inject("ProductNoticesStore.Clear"); err != nil {
+
+		return err
+	}
+
+	return s.ProductNoticesStore.Clear(notices)
+
+}
+
+func (s *FaultInjectionLayerProductNoticesStore) ClearOldNotices(currentNotices model.ProductNotices) error {
+
+	if err := s.Root.Injector.inject("ProductNoticesStore.ClearOldNotices"); err != nil {
+
+		return err
+	}
+
+	return s.ProductNoticesStore.ClearOldNotices(currentNotices)
+
+}
+
+func (s *FaultInjectionLayerProductNoticesStore) GetViews(userID string) ([]model.ProductNoticeViewState, error) {
+
+	if err := s.Root.Injector.inject("ProductNoticesStore.GetViews"); err != nil {
+		var result []model.ProductNoticeViewState
+		return result, err
+	}
+
+	return s.ProductNoticesStore.GetViews(userID)
+
+}
+
+func (s *FaultInjectionLayerProductNoticesStore) View(userID string, notices []string) error {
+
+	if err := s.Root.Injector.inject("ProductNoticesStore.View"); err != nil {
+
+		return err
+	}
+
+	return s.ProductNoticesStore.View(userID, notices)
+
+}
+
+func (s *FaultInjectionLayerPropertyFieldStore) CountForGroup(groupID string, includeDeleted bool) (int64, error) {
+
+	if err := s.Root.Injector.inject("PropertyFieldStore.CountForGroup"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.PropertyFieldStore.CountForGroup(groupID, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerPropertyFieldStore) CountForTarget(groupID string, targetType string, targetID string, includeDeleted bool) (int64, error) {
+
+	if err := s.Root.Injector.inject("PropertyFieldStore.CountForTarget"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.PropertyFieldStore.CountForTarget(groupID, targetType, targetID, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerPropertyFieldStore) Create(field *model.PropertyField) (*model.PropertyField, error) {
+
+	if err := s.Root.Injector.inject("PropertyFieldStore.Create"); err != nil {
+		var result *model.PropertyField
+		return result, err
+	}
+
+	return s.PropertyFieldStore.Create(field)
+
+}
+
+func (s *FaultInjectionLayerPropertyFieldStore) Delete(groupID string, id string) error {
+
+	if err := s.Root.Injector.inject("PropertyFieldStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.PropertyFieldStore.Delete(groupID, id)
+
+}
+
+func (s *FaultInjectionLayerPropertyFieldStore) Get(groupID string, id string) (*model.PropertyField, error) {
+
+	if err := s.Root.Injector.inject("PropertyFieldStore.Get"); err != nil {
+		var result *model.PropertyField
+		return result, err
+	}
+
+	return s.PropertyFieldStore.Get(groupID, id)
+
+}
+
+func (s *FaultInjectionLayerPropertyFieldStore) GetFieldByName(groupID string, targetID string, name string) (*model.PropertyField, error) {
+
+	if err := s.Root.Injector.inject("PropertyFieldStore.GetFieldByName"); err != nil {
+		var result *model.PropertyField
+		return result, err
+	}
+
+	return s.PropertyFieldStore.GetFieldByName(groupID, targetID, name)
+
+}
+
+func (s *FaultInjectionLayerPropertyFieldStore) GetMany(groupID string, ids []string) ([]*model.PropertyField, error) {
+
+	if err := s.Root.Injector.inject("PropertyFieldStore.GetMany"); err != nil {
+		var result []*model.PropertyField
+		return result, err
+	}
+
+	return s.PropertyFieldStore.GetMany(groupID, ids)
+
+}
+
+func (s *FaultInjectionLayerPropertyFieldStore) SearchPropertyFields(opts model.PropertyFieldSearchOpts) ([]*model.PropertyField, error) {
+
+	if err := s.Root.Injector.inject("PropertyFieldStore.SearchPropertyFields"); err != nil {
+		var result []*model.PropertyField
+		return result, err
+	}
+
+	return s.PropertyFieldStore.SearchPropertyFields(opts)
+
+}
+
+func (s *FaultInjectionLayerPropertyFieldStore) Update(groupID string, fields []*model.PropertyField) ([]*model.PropertyField, error) {
+
+	if err := s.Root.Injector.inject("PropertyFieldStore.Update"); err != nil {
+		var result []*model.PropertyField
+		return result, err
+	}
+
+	return s.PropertyFieldStore.Update(groupID, fields)
+
+}
+
+func (s *FaultInjectionLayerPropertyGroupStore) Get(name string) (*model.PropertyGroup, error) {
+
+	if err := s.Root.Injector.inject("PropertyGroupStore.Get"); err != nil {
+		var result *model.PropertyGroup
+		return result, err
+	}
+
+	return s.PropertyGroupStore.Get(name)
+
+}
+
+func (s *FaultInjectionLayerPropertyGroupStore) Register(name string) (*model.PropertyGroup, error) {
+
+	if err := s.Root.Injector.inject("PropertyGroupStore.Register"); err != nil {
+		var result *model.PropertyGroup
+		return result, err
+	}
+
+	return s.PropertyGroupStore.Register(name)
+
+}
+
+func (s *FaultInjectionLayerPropertyValueStore) Create(value *model.PropertyValue) (*model.PropertyValue, error) {
+
+	if err := s.Root.Injector.inject("PropertyValueStore.Create"); err != nil {
+		var result *model.PropertyValue
+		return result, err
+	}
+
+	return s.PropertyValueStore.Create(value)
+
+}
+
+func (s *FaultInjectionLayerPropertyValueStore) CreateMany(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+
+	if err := s.Root.Injector.inject("PropertyValueStore.CreateMany"); err != nil {
+		var result []*model.PropertyValue
+		return result, err
+	}
+
+	return s.PropertyValueStore.CreateMany(values)
+
+}
+
+func (s *FaultInjectionLayerPropertyValueStore) Delete(groupID string, id string) error {
+
+	if err := s.Root.Injector.inject("PropertyValueStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.PropertyValueStore.Delete(groupID, id)
+
+}
+
+func (s *FaultInjectionLayerPropertyValueStore) DeleteForField(groupID string, fieldID string) error {
+
+	if err := s.Root.Injector.inject("PropertyValueStore.DeleteForField"); err != nil {
+
+		return err
+	}
+
+	return s.PropertyValueStore.DeleteForField(groupID, fieldID)
+
+}
+
+func (s *FaultInjectionLayerPropertyValueStore) DeleteForTarget(groupID string, targetType string, targetID string) error {
+
+	if err := s.Root.Injector.inject("PropertyValueStore.DeleteForTarget"); err != nil {
+
+		return err
+	}
+
+	return s.PropertyValueStore.DeleteForTarget(groupID, targetType, targetID)
+
+}
+
+func (s *FaultInjectionLayerPropertyValueStore) Get(groupID string, id string) (*model.PropertyValue, error) {
+
+	if err := s.Root.Injector.inject("PropertyValueStore.Get"); err != nil {
+		var result *model.PropertyValue
+		return result, err
+	}
+
+	return s.PropertyValueStore.Get(groupID, id)
+
+}
+
+func (s *FaultInjectionLayerPropertyValueStore) GetMany(groupID string, ids []string) ([]*model.PropertyValue, error) {
+
+	if err := s.Root.Injector.inject("PropertyValueStore.GetMany"); err != nil {
+		var result []*model.PropertyValue
+		return result, err
+	}
+
+	return s.PropertyValueStore.GetMany(groupID, ids)
+
+}
+
+func (s *FaultInjectionLayerPropertyValueStore) SearchPropertyValues(opts model.PropertyValueSearchOpts) ([]*model.PropertyValue, error) {
+
+	if err := s.Root.Injector.inject("PropertyValueStore.SearchPropertyValues"); err != nil {
+		var result []*model.PropertyValue
+		return result, err
+	}
+
+	return s.PropertyValueStore.SearchPropertyValues(opts)
+
+}
+
+func (s *FaultInjectionLayerPropertyValueStore) Update(groupID string, values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+
+	if err := s.Root.Injector.inject("PropertyValueStore.Update"); err != nil {
+		var result []*model.PropertyValue
+		return result, err
+	}
+
+	return s.PropertyValueStore.Update(groupID, values)
+
+}
+
+func (s *FaultInjectionLayerPropertyValueStore) Upsert(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+
+	if err := s.Root.Injector.inject("PropertyValueStore.Upsert"); err != nil {
+		var result []*model.PropertyValue
+		return result, err
+	}
+
+	return s.PropertyValueStore.Upsert(values)
+
+}
+
+func (s *FaultInjectionLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+
+	if err := s.Root.Injector.inject("ReactionStore.BulkGetForPosts"); err != nil {
+		var result []*model.Reaction
+		return result, err
+	}
+
+	return s.ReactionStore.BulkGetForPosts(postIds)
+
+}
+
+func (s *FaultInjectionLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+
+	if err := s.Root.Injector.inject("ReactionStore.Delete"); err != nil {
+		var result *model.Reaction
+		return result, err
+	}
+
+	return s.ReactionStore.Delete(reaction)
+
+}
+
+func (s *FaultInjectionLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+
+	if err := s.Root.Injector.inject("ReactionStore.DeleteAllWithEmojiName"); err != nil {
+
+		return err
+	}
+
+	return s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+
+}
+
+func (s *FaultInjectionLayerReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsForDeletion) (int64, error) {
+
+	if err := s.Root.Injector.inject("ReactionStore.DeleteOrphanedRowsByIds"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ReactionStore.DeleteOrphanedRowsByIds(r)
+
+}
+
+func (s *FaultInjectionLayerReactionStore) ExistsOnPost(postID string, emojiName string) (bool, error) {
+
+	if err := s.Root.Injector.inject("ReactionStore.ExistsOnPost"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.ReactionStore.ExistsOnPost(postID, emojiName)
+
+}
+
+func (s *FaultInjectionLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
+
+	if err := s.Root.Injector.inject("ReactionStore.GetForPost"); err != nil {
+		var result []*model.Reaction
+		return result, err
+	}
+
+	return s.ReactionStore.GetForPost(postID, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerReactionStore) GetForPostSince(postID string, since int64, excludeRemoteID string, inclDeleted bool) ([]*model.Reaction, error) {
+
+	if err := s.Root.Injector.inject("ReactionStore.GetForPostSince"); err != nil {
+		var result []*model.Reaction
+		return result, err
+	}
+
+	return s.ReactionStore.GetForPostSince(postID, since, excludeRemoteID, inclDeleted)
+
+}
+
+func (s *FaultInjectionLayerReactionStore) GetSingle(userID string, postID string, remoteID string, emojiName string) (*model.Reaction, error) {
+
+	if err := s.Root.Injector.inject("ReactionStore.GetSingle"); err != nil {
+		var result *model.Reaction
+		return result, err
+	}
+
+	return s.ReactionStore.GetSingle(userID, postID, remoteID, emojiName)
+
+}
+
+func (s *FaultInjectionLayerReactionStore) GetUniqueCountForPost(postID string) (int, error) {
+
+	if err := s.Root.Injector.inject("ReactionStore.GetUniqueCountForPost"); err != nil {
+		var result int
+		return result, err
+	}
+
+	return s.ReactionStore.GetUniqueCountForPost(postID)
+
+}
+
+func (s *FaultInjectionLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	if err := s.Root.Injector.inject("ReactionStore.PermanentDeleteBatch"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+
+}
+
+func (s *FaultInjectionLayerReactionStore) PermanentDeleteByUser(userID string) error {
+
+	if err := s.Root.Injector.inject("ReactionStore.PermanentDeleteByUser"); err != nil {
+
+		return err
+	}
+
+	return s.ReactionStore.PermanentDeleteByUser(userID)
+
+}
+
+func (s *FaultInjectionLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+
+	if err := s.Root.Injector.inject("ReactionStore.Save"); err != nil {
+		var result *model.Reaction
+		return result, err
+	}
+
+	return s.ReactionStore.Save(reaction)
+
+}
+
+func (s *FaultInjectionLayerRemoteClusterStore) Delete(remoteClusterID string) (bool, error) {
+
+	if err := s.Root.Injector.inject("RemoteClusterStore.Delete"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.RemoteClusterStore.Delete(remoteClusterID)
+
+}
+
+func (s *FaultInjectionLayerRemoteClusterStore) Get(remoteClusterID string, includeDeleted bool) (*model.RemoteCluster, error) {
+
+	if err := s.Root.Injector.inject("RemoteClusterStore.Get"); err != nil {
+		var result *model.RemoteCluster
+		return result, err
+	}
+
+	return s.RemoteClusterStore.Get(remoteClusterID, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerRemoteClusterStore) GetAll(offset int, limit int, filter model.RemoteClusterQueryFilter) ([]*model.RemoteCluster, error) {
+
+	if err := s.Root.Injector.inject("RemoteClusterStore.GetAll"); err != nil {
+		var result []*model.RemoteCluster
+		return result, err
+	}
+
+	return s.RemoteClusterStore.GetAll(offset, limit, filter)
+
+}
+
+func (s *FaultInjectionLayerRemoteClusterStore) GetByPluginID(pluginID string) (*model.RemoteCluster, error) {
+
+	if err := s.Root.Injector.inject("RemoteClusterStore.GetByPluginID"); err != nil {
+		var result *model.RemoteCluster
+		return result, err
+	}
+
+	return s.RemoteClusterStore.GetByPluginID(pluginID)
+
+}
+
+func (s *FaultInjectionLayerRemoteClusterStore) Save(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
+
+	if err := s.Root.Injector.inject("RemoteClusterStore.Save"); err != nil {
+		var result *model.RemoteCluster
+		return result, err
+	}
+
+	return s.RemoteClusterStore.Save(rc)
+
+}
+
+func (s *FaultInjectionLayerRemoteClusterStore) SetLastPingAt(remoteClusterID string) error {
+
+	if err := s.Root.Injector.inject("RemoteClusterStore.SetLastPingAt"); err != nil {
+
+		return err
+	}
+
+	return s.RemoteClusterStore.SetLastPingAt(remoteClusterID)
+
+}
+
+func (s *FaultInjectionLayerRemoteClusterStore) Update(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
+
+	if err := s.Root.Injector.inject("RemoteClusterStore.Update"); err != nil {
+		var result *model.RemoteCluster
+		return result, err
+	}
+
+	return s.RemoteClusterStore.Update(rc)
+
+}
+
+func (s *FaultInjectionLayerRemoteClusterStore) UpdateLastGlobalUserSyncAt(remoteID string, syncAt int64) error {
+
+	if err := s.Root.Injector.inject("RemoteClusterStore.UpdateLastGlobalUserSyncAt"); err != nil {
+
+		return err
+	}
+
+	return s.RemoteClusterStore.UpdateLastGlobalUserSyncAt(remoteID, syncAt)
+
+}
+
+func (s *FaultInjectionLayerRemoteClusterStore) UpdateTopics(remoteClusterID string, topics string) (*model.RemoteCluster, error) {
+
+	if err := s.Root.Injector.inject("RemoteClusterStore.UpdateTopics"); err != nil {
+		var result *model.RemoteCluster
+		return result, err
+	}
+
+	return s.RemoteClusterStore.UpdateTopics(remoteClusterID, topics)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) AddChannels(policyID string, channelIds []string) error {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.AddChannels"); err != nil {
+
+		return err
+	}
+
+	return s.RetentionPolicyStore.AddChannels(policyID, channelIds)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) AddTeams(policyID string, teamIds []string) error {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.AddTeams"); err != nil {
+
+		return err
+	}
+
+	return s.RetentionPolicyStore.AddTeams(policyID, teamIds)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) Delete(id string) error {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.RetentionPolicyStore.Delete(id)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) DeleteOrphanedRows(limit int) (int64, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.DeleteOrphanedRows"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.DeleteOrphanedRows(limit)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) Get(id string) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.Get"); err != nil {
+		var result *model.RetentionPolicyWithTeamAndChannelCounts
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.Get(id)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) GetAll(offset int, limit int) ([]*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.GetAll"); err != nil {
+		var result []*model.RetentionPolicyWithTeamAndChannelCounts
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.GetAll(offset, limit)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) GetChannelPoliciesCountForUser(userID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.GetChannelPoliciesCountForUser"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.GetChannelPoliciesCountForUser(userID)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) GetChannelPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForChannel, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.GetChannelPoliciesForUser"); err != nil {
+		var result []*model.RetentionPolicyForChannel
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.GetChannelPoliciesForUser(userID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) GetChannels(policyID string, offset int, limit int) (model.ChannelListWithTeamData, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.GetChannels"); err != nil {
+		var result model.ChannelListWithTeamData
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.GetChannels(policyID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) GetChannelsCount(policyID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.GetChannelsCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.GetChannelsCount(policyID)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) GetCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.GetCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.GetCount()
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) GetIdsForDeletionByTableName(tableName string, limit int) ([]*model.RetentionIdsForDeletion, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.GetIdsForDeletionByTableName"); err != nil {
+		var result []*model.RetentionIdsForDeletion
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.GetIdsForDeletionByTableName(tableName, limit)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) GetTeamPoliciesCountForUser(userID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.GetTeamPoliciesCountForUser"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.GetTeamPoliciesCountForUser(userID)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForTeam, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.GetTeamPoliciesForUser"); err != nil {
+		var result []*model.RetentionPolicyForTeam
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.GetTeamPoliciesForUser(userID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) GetTeams(policyID string, offset int, limit int) ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.GetTeams"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.GetTeams(policyID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) GetTeamsCount(policyID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.GetTeamsCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.GetTeamsCount(policyID)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.Patch"); err != nil {
+		var result *model.RetentionPolicyWithTeamAndChannelCounts
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.Patch(patch)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) RemoveChannels(policyID string, channelIds []string) error {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.RemoveChannels"); err != nil {
+
+		return err
+	}
+
+	return s.RetentionPolicyStore.RemoveChannels(policyID, channelIds)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) RemoveTeams(policyID string, teamIds []string) error {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.RemoveTeams"); err != nil {
+
+		return err
+	}
+
+	return s.RetentionPolicyStore.RemoveTeams(policyID, teamIds)
+
+}
+
+func (s *FaultInjectionLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+
+	if err := s.Root.Injector.inject("RetentionPolicyStore.Save"); err != nil {
+		var result *model.RetentionPolicyWithTeamAndChannelCounts
+		return result, err
+	}
+
+	return s.RetentionPolicyStore.Save(policy)
+
+}
+
+func (s *FaultInjectionLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+
+	if err := s.Root.Injector.inject("RoleStore.AllChannelSchemeRoles"); err != nil {
+		var result []*model.Role
+		return result, err
+	}
+
+	return s.RoleStore.AllChannelSchemeRoles()
+
+}
+
+func (s *FaultInjectionLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+
+	if err := s.Root.Injector.inject("RoleStore.ChannelHigherScopedPermissions"); err != nil {
+		var result map[string]*model.RolePermissions
+		return result, err
+	}
+
+	return s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+
+}
+
+func (s *FaultInjectionLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+
+	if err := s.Root.Injector.inject("RoleStore.ChannelRolesUnderTeamRole"); err != nil {
+		var result []*model.Role
+		return result, err
+	}
+
+	return s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+
+}
+
+func (s *FaultInjectionLayerRoleStore) Delete(roleID string) (*model.Role, error) {
+
+	if err := s.Root.Injector.inject("RoleStore.Delete"); err != nil {
+		var result *model.Role
+		return result, err
+	}
+
+	return s.RoleStore.Delete(roleID)
+
+}
+
+func (s *FaultInjectionLayerRoleStore) Get(roleID string) (*model.Role, error) {
+
+	if err := s.Root.Injector.inject("RoleStore.Get"); err != nil {
+		var result *model.Role
+		return result, err
+	}
+
+	return s.RoleStore.Get(roleID)
+
+}
+
+func (s *FaultInjectionLayerRoleStore) GetAll() ([]*model.Role, error) {
+
+	if err := s.Root.Injector.inject("RoleStore.GetAll"); err != nil {
+		var result []*model.Role
+		return result, err
+	}
+
+	return s.RoleStore.GetAll()
+
+}
+
+func (s *FaultInjectionLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
+
+	if err := s.Root.Injector.inject("RoleStore.GetByName"); err != nil {
+		var result *model.Role
+		return result, err
+	}
+
+	return s.RoleStore.GetByName(ctx, name)
+
+}
+
+func (s *FaultInjectionLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+
+	if err := s.Root.Injector.inject("RoleStore.GetByNames"); err != nil {
+		var result []*model.Role
+		return result, err
+	}
+
+	return s.RoleStore.GetByNames(names)
+
+}
+
+func (s *FaultInjectionLayerRoleStore) PermanentDeleteAll() error {
+
+	if err := s.Root.Injector.inject("RoleStore.PermanentDeleteAll"); err != nil {
+
+		return err
+	}
+
+	return s.RoleStore.PermanentDeleteAll()
+
+}
+
+func (s *FaultInjectionLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+
+	if err := s.Root.Injector.inject("RoleStore.Save"); err != nil {
+		var result *model.Role
+		return result, err
+	}
+
+	return s.RoleStore.Save(role)
+
+}
+
+func (s *FaultInjectionLayerScheduledPostStore) CreateScheduledPost(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error) {
+
+	if err := s.Root.Injector.inject("ScheduledPostStore.CreateScheduledPost"); err != nil {
+		var result *model.ScheduledPost
+		return result, err
+	}
+
+	return s.ScheduledPostStore.CreateScheduledPost(scheduledPost)
+
+}
+
+func (s *FaultInjectionLayerScheduledPostStore) Get(scheduledPostId string) (*model.ScheduledPost, error) {
+
+	if err := s.Root.Injector.inject("ScheduledPostStore.Get"); err != nil {
+		var result *model.ScheduledPost
+		return result, err
+	}
+
+	return s.ScheduledPostStore.Get(scheduledPostId)
+
+}
+
+func (s *FaultInjectionLayerScheduledPostStore) GetMaxMessageSize() int {
+
+	s.Root.Injector.delay("ScheduledPostStore.GetMaxMessageSize")
+
+	return s.ScheduledPostStore.GetMaxMessageSize()
+
+}
+
+func (s *FaultInjectionLayerScheduledPostStore) GetPendingScheduledPosts(beforeTime int64, afterTime int64, lastScheduledPostId string, perPage uint64) ([]*model.ScheduledPost, error) {
+
+	if err := s.Root.Injector.inject("ScheduledPostStore.GetPendingScheduledPosts"); err != nil {
+		var result []*model.ScheduledPost
+		return result, err
+	}
+
+	return s.ScheduledPostStore.GetPendingScheduledPosts(beforeTime, afterTime, lastScheduledPostId, perPage)
+
+}
+
+func (s *FaultInjectionLayerScheduledPostStore) GetScheduledPostsForUser(userId string, teamId string) ([]*model.ScheduledPost, error) {
+
+	if err := s.Root.Injector.inject("ScheduledPostStore.GetScheduledPostsForUser"); err != nil {
+		var result []*model.ScheduledPost
+		return result, err
+	}
+
+	return s.ScheduledPostStore.GetScheduledPostsForUser(userId, teamId)
+
+}
+
+func (s *FaultInjectionLayerScheduledPostStore) PermanentDeleteByUser(userId string) error {
+
+	if err := s.Root.Injector.inject("ScheduledPostStore.PermanentDeleteByUser"); err != nil {
+
+		return err
+	}
+
+	return s.ScheduledPostStore.PermanentDeleteByUser(userId)
+
+}
+
+func (s *FaultInjectionLayerScheduledPostStore) PermanentlyDeleteScheduledPosts(scheduledPostIDs []string) error {
+
+	if err := s.Root.Injector.inject("ScheduledPostStore.PermanentlyDeleteScheduledPosts"); err != nil {
+
+		return err
+	}
+
+	return s.ScheduledPostStore.PermanentlyDeleteScheduledPosts(scheduledPostIDs)
+
+}
+
+func (s *FaultInjectionLayerScheduledPostStore) UpdateOldScheduledPosts(beforeTime int64) error {
+
+	if err := s.Root.Injector.inject("ScheduledPostStore.UpdateOldScheduledPosts"); err != nil {
+
+		return err
+	}
+
+	return s.ScheduledPostStore.UpdateOldScheduledPosts(beforeTime)
+
+}
+
+func (s *FaultInjectionLayerScheduledPostStore) UpdatedScheduledPost(scheduledPost *model.ScheduledPost) error {
+
+	if err := s.Root.Injector.inject("ScheduledPostStore.UpdatedScheduledPost"); err != nil {
+
+		return err
+	}
+
+	return s.ScheduledPostStore.UpdatedScheduledPost(scheduledPost)
+
+}
+
+func (s *FaultInjectionLayerSchemeStore) CountByScope(scope string) (int64, error) {
+
+	if err := s.Root.Injector.inject("SchemeStore.CountByScope"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.SchemeStore.CountByScope(scope)
+
+}
+
+func (s *FaultInjectionLayerSchemeStore) CountWithoutPermission(scope string, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error) {
+
+	if err := s.Root.Injector.inject("SchemeStore.CountWithoutPermission"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.SchemeStore.CountWithoutPermission(scope, permissionID, roleScope, roleType)
+
+}
+
+func (s *FaultInjectionLayerSchemeStore) Delete(schemeID string) (*model.Scheme, error) {
+
+	if err := s.Root.Injector.inject("SchemeStore.Delete"); err != nil {
+		var result *model.Scheme
+		return result, err
+	}
+
+	return s.SchemeStore.Delete(schemeID)
+
+}
+
+func (s *FaultInjectionLayerSchemeStore) Get(schemeID string) (*model.Scheme, error) {
+
+	if err := s.Root.Injector.inject("SchemeStore.Get"); err != nil {
+		var result *model.Scheme
+		return result, err
+	}
+
+	return s.SchemeStore.Get(schemeID)
+
+}
+
+func (s *FaultInjectionLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, error) {
+
+	if err := s.Root.Injector.inject("SchemeStore.GetAllPage"); err != nil {
+		var result []*model.Scheme
+		return result, err
+	}
+
+	return s.SchemeStore.GetAllPage(scope, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
+
+	if err := s.Root.Injector.inject("SchemeStore.GetByName"); err != nil {
+		var result *model.Scheme
+		return result, err
+	}
+
+	return s.SchemeStore.GetByName(schemeName)
+
+}
+
+func (s *FaultInjectionLayerSchemeStore) PermanentDeleteAll() error {
+
+	if err := s.Root.Injector.inject("SchemeStore.PermanentDeleteAll"); err != nil {
+
+		return err
+	}
+
+	return s.SchemeStore.PermanentDeleteAll()
+
+}
+
+func (s *FaultInjectionLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+
+	if err := s.Root.Injector.inject("SchemeStore.Save"); err != nil {
+		var result *model.Scheme
+		return result, err
+	}
+
+	return s.SchemeStore.Save(scheme)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) AnalyticsSessionCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("SessionStore.AnalyticsSessionCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.SessionStore.AnalyticsSessionCount()
+
+}
+
+func (s *FaultInjectionLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) error {
+
+	if err := s.Root.Injector.inject("SessionStore.Cleanup"); err != nil {
+
+		return err
+	}
+
+	return s.SessionStore.Cleanup(expiryTime, batchSize)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) Get(rctx request.CTX, sessionIDOrToken string) (*model.Session, error) {
+
+	if err := s.Root.Injector.inject("SessionStore.Get"); err != nil {
+		var result *model.Session
+		return result, err
+	}
+
+	return s.SessionStore.Get(rctx, sessionIDOrToken)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) GetLRUSessions(rctx request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, error) {
+
+	if err := s.Root.Injector.inject("SessionStore.GetLRUSessions"); err != nil {
+		var result []*model.Session
+		return result, err
+	}
+
+	return s.SessionStore.GetLRUSessions(rctx, userID, limit, offset)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) GetMobileSessionMetadata() ([]*model.MobileSessionMetadata, error) {
+
+	if err := s.Root.Injector.inject("SessionStore.GetMobileSessionMetadata"); err != nil {
+		var result []*model.MobileSessionMetadata
+		return result, err
+	}
+
+	return s.SessionStore.GetMobileSessionMetadata()
+
+}
+
+func (s *FaultInjectionLayerSessionStore) GetSessions(rctx request.CTX, userID string) ([]*model.Session, error) {
+
+	if err := s.Root.Injector.inject("SessionStore.GetSessions"); err != nil {
+		var result []*model.Session
+		return result, err
+	}
+
+	return s.SessionStore.GetSessions(rctx, userID)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+
+	if err := s.Root.Injector.inject("SessionStore.GetSessionsExpired"); err != nil {
+		var result []*model.Session
+		return result, err
+	}
+
+	return s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) ([]*model.Session, error) {
+
+	if err := s.Root.Injector.inject("SessionStore.GetSessionsWithActiveDeviceIds"); err != nil {
+		var result []*model.Session
+		return result, err
+	}
+
+	return s.SessionStore.GetSessionsWithActiveDeviceIds(userID)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) error {
+
+	if err := s.Root.Injector.inject("SessionStore.PermanentDeleteSessionsByUser"); err != nil {
+
+		return err
+	}
+
+	return s.SessionStore.PermanentDeleteSessionsByUser(teamID)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) Remove(sessionIDOrToken string) error {
+
+	if err := s.Root.Injector.inject("SessionStore.Remove"); err != nil {
+
+		return err
+	}
+
+	return s.SessionStore.Remove(sessionIDOrToken)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) RemoveAllSessions() error {
+
+	if err := s.Root.Injector.inject("SessionStore.RemoveAllSessions"); err != nil {
+
+		return err
+	}
+
+	return s.SessionStore.RemoveAllSessions()
+
+}
+
+func (s *FaultInjectionLayerSessionStore) Save(rctx request.CTX, session *model.Session) (*model.Session, error) {
+
+	if err := s.Root.Injector.inject("SessionStore.Save"); err != nil {
+		var result *model.Session
+		return result, err
+	}
+
+	return s.SessionStore.Save(rctx, session)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) UpdateDeviceId(id string, deviceID string, expiresAt int64) (string, error) {
+
+	if err := s.Root.Injector.inject("SessionStore.UpdateDeviceId"); err != nil {
+		var result string
+		return result, err
+	}
+
+	return s.SessionStore.UpdateDeviceId(id, deviceID, expiresAt)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+
+	if err := s.Root.Injector.inject("SessionStore.UpdateExpiredNotify"); err != nil {
+
+		return err
+	}
+
+	return s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) UpdateExpiresAt(sessionID string, timestamp int64) error {
+
+	if err := s.Root.Injector.inject("SessionStore.UpdateExpiresAt"); err != nil {
+
+		return err
+	}
+
+	return s.SessionStore.UpdateExpiresAt(sessionID, timestamp)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) UpdateLastActivityAt(sessionID string, timestamp int64) error {
+
+	if err := s.Root.Injector.inject("SessionStore.UpdateLastActivityAt"); err != nil {
+
+		return err
+	}
+
+	return s.SessionStore.UpdateLastActivityAt(sessionID, timestamp)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) UpdateProps(session *model.Session) error {
+
+	if err := s.Root.Injector.inject("SessionStore.UpdateProps"); err != nil {
+
+		return err
+	}
+
+	return s.SessionStore.UpdateProps(session)
+
+}
+
+func (s *FaultInjectionLayerSessionStore) UpdateRoles(userID string, roles string) (string, error) {
+
+	if err := s.Root.Injector.inject("SessionStore.UpdateRoles"); err != nil {
+		var result string
+		return result, err
+	}
+
+	return s.SessionStore.UpdateRoles(userID, roles)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) Delete(channelID string) (bool, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.Delete"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.SharedChannelStore.Delete(channelID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) DeleteContentPolicy(remoteID string) (bool, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.DeleteContentPolicy"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.SharedChannelStore.DeleteContentPolicy(remoteID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) DeleteRemote(remoteID string) (bool, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.DeleteRemote"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.SharedChannelStore.DeleteRemote(remoteID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) Get(channelID string) (*model.SharedChannel, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.Get"); err != nil {
+		var result *model.SharedChannel
+		return result, err
+	}
+
+	return s.SharedChannelStore.Get(channelID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetAll(offset int, limit int, opts model.SharedChannelFilterOpts) ([]*model.SharedChannel, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetAll"); err != nil {
+		var result []*model.SharedChannel
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetAll(offset, limit, opts)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetAllCount(opts model.SharedChannelFilterOpts) (int64, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetAllCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetAllCount(opts)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetAttachment(fileID string, remoteID string) (*model.SharedChannelAttachment, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetAttachment"); err != nil {
+		var result *model.SharedChannelAttachment
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetAttachment(fileID, remoteID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetContentPolicy(remoteID string) (*model.SharedChannelContentPolicy, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetContentPolicy"); err != nil {
+		var result *model.SharedChannelContentPolicy
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetContentPolicy(remoteID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetFilterLog(remoteID string, offset int, limit int) ([]*model.SharedChannelFilterLogEntry, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetFilterLog"); err != nil {
+		var result []*model.SharedChannelFilterLogEntry
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetFilterLog(remoteID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetHeldPost(postID string, remoteID string) (*model.SharedChannelHeldPost, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetHeldPost"); err != nil {
+		var result *model.SharedChannelHeldPost
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetHeldPost(postID, remoteID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetHeldPosts(remoteID string, status string, offset int, limit int) ([]*model.SharedChannelHeldPost, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetHeldPosts"); err != nil {
+		var result []*model.SharedChannelHeldPost
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetHeldPosts(remoteID, status, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetRemote(id string) (*model.SharedChannelRemote, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetRemote"); err != nil {
+		var result *model.SharedChannelRemote
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetRemote(id)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetRemoteByIds(channelID string, remoteID string) (*model.SharedChannelRemote, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetRemoteByIds"); err != nil {
+		var result *model.SharedChannelRemote
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetRemoteByIds(channelID, remoteID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetRemoteForUser(remoteID string, userID string) (*model.RemoteCluster, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetRemoteForUser"); err != nil {
+		var result *model.RemoteCluster
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetRemoteForUser(remoteID, userID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetRemotes(offset int, limit int, opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetRemotes"); err != nil {
+		var result []*model.SharedChannelRemote
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetRemotes(offset, limit, opts)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetRemotesStatus(channelID string) ([]*model.SharedChannelRemoteStatus, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetRemotesStatus"); err != nil {
+		var result []*model.SharedChannelRemoteStatus
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetRemotesStatus(channelID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetSingleUser"); err != nil {
+		var result *model.SharedChannelUser
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetSingleUser(userID, channelID, remoteID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetUserChanges(userID string, channelID string, afterTime int64) ([]*model.SharedChannelUser, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetUserChanges"); err != nil {
+		var result []*model.SharedChannelUser
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetUserChanges(userID, channelID, afterTime)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetUsersForSync(filter model.GetUsersForSyncFilter) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetUsersForSync"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetUsersForSync(filter)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) GetUsersForUser(userID string) ([]*model.SharedChannelUser, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.GetUsersForUser"); err != nil {
+		var result []*model.SharedChannelUser
+		return result, err
+	}
+
+	return s.SharedChannelStore.GetUsersForUser(userID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) HasChannel(channelID string) (bool, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.HasChannel"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.SharedChannelStore.HasChannel(channelID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) HasRemote(channelID string, remoteID string) (bool, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.HasRemote"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.SharedChannelStore.HasRemote(channelID, remoteID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) Save(sc *model.SharedChannel) (*model.SharedChannel, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.Save"); err != nil {
+		var result *model.SharedChannel
+		return result, err
+	}
+
+	return s.SharedChannelStore.Save(sc)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) SaveAttachment(remote *model.SharedChannelAttachment) (*model.SharedChannelAttachment, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.SaveAttachment"); err != nil {
+		var result *model.SharedChannelAttachment
+		return result, err
+	}
+
+	return s.SharedChannelStore.SaveAttachment(remote)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) SaveContentPolicy(policy *model.SharedChannelContentPolicy) (*model.SharedChannelContentPolicy, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.SaveContentPolicy"); err != nil {
+		var result *model.SharedChannelContentPolicy
+		return result, err
+	}
+
+	return s.SharedChannelStore.SaveContentPolicy(policy)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) SaveFilterLogEntry(entry *model.SharedChannelFilterLogEntry) (*model.SharedChannelFilterLogEntry, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.SaveFilterLogEntry"); err != nil {
+		var result *model.SharedChannelFilterLogEntry
+		return result, err
+	}
+
+	return s.SharedChannelStore.SaveFilterLogEntry(entry)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) SaveHeldPost(held *model.SharedChannelHeldPost) (*model.SharedChannelHeldPost, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.SaveHeldPost"); err != nil {
+		var result *model.SharedChannelHeldPost
+		return result, err
+	}
+
+	return s.SharedChannelStore.SaveHeldPost(held)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.SaveRemote"); err != nil {
+		var result *model.SharedChannelRemote
+		return result, err
+	}
+
+	return s.SharedChannelStore.SaveRemote(remote)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.SaveUser"); err != nil {
+		var result *model.SharedChannelUser
+		return result, err
+	}
+
+	return s.SharedChannelStore.SaveUser(remote)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) Update(sc *model.SharedChannel) (*model.SharedChannel, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.Update"); err != nil {
+		var result *model.SharedChannel
+		return result, err
+	}
+
+	return s.SharedChannelStore.Update(sc)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syncTime int64) error {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.UpdateAttachmentLastSyncAt"); err != nil {
+
+		return err
+	}
+
+	return s.SharedChannelStore.UpdateAttachmentLastSyncAt(id, syncTime)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.UpdateRemote"); err != nil {
+		var result *model.SharedChannelRemote
+		return result, err
+	}
+
+	return s.SharedChannelStore.UpdateRemote(remote)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.UpdateRemoteCursor"); err != nil {
+
+		return err
+	}
+
+	return s.SharedChannelStore.UpdateRemoteCursor(id, cursor)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) UpdateRemoteMembershipCursor(id string, syncTime int64) error {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.UpdateRemoteMembershipCursor"); err != nil {
+
+		return err
+	}
+
+	return s.SharedChannelStore.UpdateRemoteMembershipCursor(id, syncTime)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) UpdateUserLastMembershipSyncAt(userID string, channelID string, remoteID string, syncTime int64) error {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.UpdateUserLastMembershipSyncAt"); err != nil {
+
+		return err
+	}
+
+	return s.SharedChannelStore.UpdateUserLastMembershipSyncAt(userID, channelID, remoteID, syncTime)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.UpdateUserLastSyncAt"); err != nil {
+
+		return err
+	}
+
+	return s.SharedChannelStore.UpdateUserLastSyncAt(userID, channelID, remoteID)
+
+}
+
+func (s *FaultInjectionLayerSharedChannelStore) UpsertAttachment(remote *model.SharedChannelAttachment) (string, error) {
+
+	if err := s.Root.Injector.inject("SharedChannelStore.UpsertAttachment"); err != nil {
+		var result string
+		return result, err
+	}
+
+	return s.SharedChannelStore.UpsertAttachment(remote)
+
+}
+
+func (s *FaultInjectionLayerStatusStore) Get(userID string) (*model.Status, error) {
+
+	if err := s.Root.Injector.inject("StatusStore.Get"); err != nil {
+		var result *model.Status
+		return result, err
+	}
+
+	return s.StatusStore.Get(userID)
+
+}
+
+func (s *FaultInjectionLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
+
+	if err := s.Root.Injector.inject("StatusStore.GetByIds"); err != nil {
+		var result []*model.Status
+		return result, err
+	}
+
+	return s.StatusStore.GetByIds(userIds)
+
+}
+
+func (s *FaultInjectionLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("StatusStore.GetTotalActiveUsersCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.StatusStore.GetTotalActiveUsersCount()
+
+}
+
+func (s *FaultInjectionLayerStatusStore) ResetAll() error {
+
+	if err := s.Root.Injector.inject("StatusStore.ResetAll"); err != nil {
+
+		return err
+	}
+
+	return s.StatusStore.ResetAll()
+
+}
+
+func (s *FaultInjectionLayerStatusStore) SaveOrUpdate(status *model.Status) error {
+
+	if err := s.Root.Injector.inject("StatusStore.SaveOrUpdate"); err != nil {
+
+		return err
+	}
+
+	return s.StatusStore.SaveOrUpdate(status)
+
+}
+
+func (s *FaultInjectionLayerStatusStore) SaveOrUpdateMany(statuses map[string]*model.Status) error {
+
+	if err := s.Root.Injector.inject("StatusStore.SaveOrUpdateMany"); err != nil {
+
+		return err
+	}
+
+	return s.StatusStore.SaveOrUpdateMany(statuses)
+
+}
+
+func (s *FaultInjectionLayerStatusStore) UpdateExpiredDNDStatuses() ([]*model.Status, error) {
+
+	if err := s.Root.Injector.inject("StatusStore.UpdateExpiredDNDStatuses"); err != nil {
+		var result []*model.Status
+		return result, err
+	}
+
+	return s.StatusStore.UpdateExpiredDNDStatuses()
+
+}
+
+func (s *FaultInjectionLayerStatusStore) UpdateLastActivityAt(userID string, lastActivityAt int64) error {
+
+	if err := s.Root.Injector.inject("StatusStore.UpdateLastActivityAt"); err != nil {
+
+		return err
+	}
+
+	return s.StatusStore.UpdateLastActivityAt(userID, lastActivityAt)
+
+}
+
+func (s *FaultInjectionLayerSystemStore) Get() (model.StringMap, error) {
+
+	if err := s.Root.Injector.inject("SystemStore.Get"); err != nil {
+		var result model.StringMap
+		return result, err
+	}
+
+	return s.SystemStore.Get()
+
+}
+
+func (s *FaultInjectionLayerSystemStore) GetByName(name string) (*model.System, error) {
+
+	if err := s.Root.Injector.inject("SystemStore.GetByName"); err != nil {
+		var result *model.System
+		return result, err
+	}
+
+	return s.SystemStore.GetByName(name)
+
+}
+
+func (s *FaultInjectionLayerSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+
+	if err := s.Root.Injector.inject("SystemStore.InsertIfExists"); err != nil {
+		var result *model.System
+		return result, err
+	}
+
+	return s.SystemStore.InsertIfExists(system)
+
+}
+
+func (s *FaultInjectionLayerSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+
+	if err := s.Root.Injector.inject("SystemStore.PermanentDeleteByName"); err != nil {
+		var result *model.System
+		return result, err
+	}
+
+	return s.SystemStore.PermanentDeleteByName(name)
+
+}
+
+func (s *FaultInjectionLayerSystemStore) Save(system *model.System) error {
+
+	if err := s.Root.Injector.inject("SystemStore.Save"); err != nil {
+
+		return err
+	}
+
+	return s.SystemStore.Save(system)
+
+}
+
+func (s *FaultInjectionLayerSystemStore) SaveOrUpdate(system *model.System) error {
+
+	if err := s.Root.Injector.inject("SystemStore.SaveOrUpdate"); err != nil {
+
+		return err
+	}
+
+	return s.SystemStore.SaveOrUpdate(system)
+
+}
+
+func (s *FaultInjectionLayerSystemStore) Update(system *model.System) error {
+
+	if err := s.Root.Injector.inject("SystemStore.Update"); err != nil {
+
+		return err
+	}
+
+	return s.SystemStore.Update(system)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.AnalyticsGetTeamCountForScheme"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.TeamStore.AnalyticsGetTeamCountForScheme(schemeID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.AnalyticsTeamCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.TeamStore.AnalyticsTeamCount(opts)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) ClaimInviteLinkUse(id string, now int64) error {
+
+	if err := s.Root.Injector.inject("TeamStore.ClaimInviteLinkUse"); err != nil {
+
+		return err
+	}
+
+	return s.TeamStore.ClaimInviteLinkUse(id, now)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) ClearAllCustomRoleAssignments() error {
+
+	if err := s.Root.Injector.inject("TeamStore.ClearAllCustomRoleAssignments"); err != nil {
+
+		return err
+	}
+
+	return s.TeamStore.ClearAllCustomRoleAssignments()
+
+}
+
+func (s *FaultInjectionLayerTeamStore) ClearCaches() {
+
+	s.Root.Injector.delay("TeamStore.ClearCaches")
+
+	s.TeamStore.ClearCaches()
+
+}
+
+func (s *FaultInjectionLayerTeamStore) Get(id string) (*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.Get"); err != nil {
+		var result *model.Team
+		return result, err
+	}
+
+	return s.TeamStore.Get(id)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetActiveMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetActiveMemberCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.TeamStore.GetActiveMemberCount(teamID, restrictions)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetAll() ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetAll"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.TeamStore.GetAll()
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetAllForExportAfter(limit int, afterID string) ([]*model.TeamForExport, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetAllForExportAfter"); err != nil {
+		var result []*model.TeamForExport
+		return result, err
+	}
+
+	return s.TeamStore.GetAllForExportAfter(limit, afterID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetAllPage(offset int, limit int, opts *model.TeamSearch) ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetAllPage"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.TeamStore.GetAllPage(offset, limit, opts)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetAllPrivateTeamListing"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.TeamStore.GetAllPrivateTeamListing()
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetAllTeamListing"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.TeamStore.GetAllTeamListing()
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetByEmptyInviteID() ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetByEmptyInviteID"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.TeamStore.GetByEmptyInviteID()
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetByInviteId(inviteID string) (*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetByInviteId"); err != nil {
+		var result *model.Team
+		return result, err
+	}
+
+	return s.TeamStore.GetByInviteId(inviteID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetByName(name string) (*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetByName"); err != nil {
+		var result *model.Team
+		return result, err
+	}
+
+	return s.TeamStore.GetByName(name)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetByNames"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.TeamStore.GetByNames(name)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamID string, userID string) ([]*model.ChannelUnread, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetChannelUnreadsForAllTeams"); err != nil {
+		var result []*model.ChannelUnread
+		return result, err
+	}
+
+	return s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamID, userID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetChannelUnreadsForTeam(teamID string, userID string) ([]*model.ChannelUnread, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetChannelUnreadsForTeam"); err != nil {
+		var result []*model.ChannelUnread
+		return result, err
+	}
+
+	return s.TeamStore.GetChannelUnreadsForTeam(teamID, userID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetCommonTeamIDsForMultipleUsers(userIDs []string) ([]string, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetCommonTeamIDsForMultipleUsers"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.TeamStore.GetCommonTeamIDsForMultipleUsers(userIDs)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUserID string) ([]string, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetCommonTeamIDsForTwoUsers"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.TeamStore.GetCommonTeamIDsForTwoUsers(userID, otherUserID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetInviteLink(id string) (*model.TeamInviteLink, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetInviteLink"); err != nil {
+		var result *model.TeamInviteLink
+		return result, err
+	}
+
+	return s.TeamStore.GetInviteLink(id)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetInviteLinkUses(linkID string, offset int, limit int) ([]*model.TeamInviteLinkUse, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetInviteLinkUses"); err != nil {
+		var result []*model.TeamInviteLinkUse
+		return result, err
+	}
+
+	return s.TeamStore.GetInviteLinkUses(linkID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetInviteLinksForTeam(teamID string, includeDeleted bool) ([]*model.TeamInviteLink, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetInviteLinksForTeam"); err != nil {
+		var result []*model.TeamInviteLink
+		return result, err
+	}
+
+	return s.TeamStore.GetInviteLinksForTeam(teamID, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetMany"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.TeamStore.GetMany(ids)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetMember(rctx request.CTX, teamID string, userID string) (*model.TeamMember, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetMember"); err != nil {
+		var result *model.TeamMember
+		return result, err
+	}
+
+	return s.TeamStore.GetMember(rctx, teamID, userID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetMembers"); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+
+	return s.TeamStore.GetMembers(teamID, offset, limit, teamMembersGetOptions)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetMembersByIds(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetMembersByIds"); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+
+	return s.TeamStore.GetMembersByIds(teamID, userIds, restrictions)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetTeamMembersForExport"); err != nil {
+		var result []*model.TeamMemberForExport
+		return result, err
+	}
+
+	return s.TeamStore.GetTeamMembersForExport(userID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetTeamsByScheme(schemeID string, offset int, limit int) ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetTeamsByScheme"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.TeamStore.GetTeamsByScheme(schemeID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetTeamsByUserId"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.TeamStore.GetTeamsByUserId(userID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetTeamsForUser(rctx request.CTX, userID string, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetTeamsForUser"); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+
+	return s.TeamStore.GetTeamsForUser(rctx, userID, excludeTeamID, includeDeleted)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetTeamsForUserWithPagination(userID string, page int, perPage int) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetTeamsForUserWithPagination"); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+
+	return s.TeamStore.GetTeamsForUserWithPagination(userID, page, perPage)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetTotalMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetTotalMemberCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.TeamStore.GetTotalMemberCount(teamID, restrictions)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GetUserTeamIds(userID string, allowFromCache bool) ([]string, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GetUserTeamIds"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.TeamStore.GetUserTeamIds(userID, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.GroupSyncedTeamCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.TeamStore.GroupSyncedTeamCount()
+
+}
+
+func (s *FaultInjectionLayerTeamStore) InvalidateAllTeamIdsForUser(userID string) {
+
+	s.Root.Injector.delay("TeamStore.InvalidateAllTeamIdsForUser")
+
+	s.TeamStore.InvalidateAllTeamIdsForUser(userID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) MigrateTeamMembers(fromTeamID string, fromUserID string) (map[string]string, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.MigrateTeamMembers"); err != nil {
+		var result map[string]string
+		return result, err
+	}
+
+	return s.TeamStore.MigrateTeamMembers(fromTeamID, fromUserID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) PermanentDelete(teamID string) error {
+
+	if err := s.Root.Injector.inject("TeamStore.PermanentDelete"); err != nil {
+
+		return err
+	}
+
+	return s.TeamStore.PermanentDelete(teamID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) ReleaseInviteLinkUse(id string) error {
+
+	if err := s.Root.Injector.inject("TeamStore.ReleaseInviteLinkUse"); err != nil {
+
+		return err
+	}
+
+	return s.TeamStore.ReleaseInviteLinkUse(id)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
+
+	if err := s.Root.Injector.inject("TeamStore.RemoveAllMembersByTeam"); err != nil {
+
+		return err
+	}
+
+	return s.TeamStore.RemoveAllMembersByTeam(teamID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) RemoveAllMembersByUser(rctx request.CTX, userID string) error {
+
+	if err := s.Root.Injector.inject("TeamStore.RemoveAllMembersByUser"); err != nil {
+
+		return err
+	}
+
+	return s.TeamStore.RemoveAllMembersByUser(rctx, userID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) RemoveMember(rctx request.CTX, teamID string, userID string) error {
+
+	if err := s.Root.Injector.inject("TeamStore.RemoveMember"); err != nil {
+
+		return err
+	}
+
+	return s.TeamStore.RemoveMember(rctx, teamID, userID)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) RemoveMembers(rctx request.CTX, teamID string, userIds []string) error {
+
+	if err := s.Root.Injector.inject("TeamStore.RemoveMembers"); err != nil {
+
+		return err
+	}
+
+	return s.TeamStore.RemoveMembers(rctx, teamID, userIds)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) ResetAllTeamSchemes() error {
+
+	if err := s.Root.Injector.inject("TeamStore.ResetAllTeamSchemes"); err != nil {
+
+		return err
+	}
+
+	return s.TeamStore.ResetAllTeamSchemes()
+
+}
+
+func (s *FaultInjectionLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.Save"); err != nil {
+		var result *model.Team
+		return result, err
+	}
+
+	return s.TeamStore.Save(team)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) SaveInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.SaveInviteLink"); err != nil {
+		var result *model.TeamInviteLink
+		return result, err
+	}
+
+	return s.TeamStore.SaveInviteLink(link)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) SaveInviteLinkUse(use *model.TeamInviteLinkUse) error {
+
+	if err := s.Root.Injector.inject("TeamStore.SaveInviteLinkUse"); err != nil {
+
+		return err
+	}
+
+	return s.TeamStore.SaveInviteLinkUse(use)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) SaveMember(rctx request.CTX, member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.SaveMember"); err != nil {
+		var result *model.TeamMember
+		return result, err
+	}
+
+	return s.TeamStore.SaveMember(rctx, member, maxUsersPerTeam)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.SaveMultipleMembers"); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+
+	return s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) SearchAll(opts *model.TeamSearch) ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.SearchAll"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.TeamStore.SearchAll(opts)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) SearchAllPaged(opts *model.TeamSearch) ([]*model.Team, int64, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.SearchAllPaged"); err != nil {
+		var result []*model.Team
+		var resultVar1 int64
+		return result, resultVar1, err
+	}
+
+	return s.TeamStore.SearchAllPaged(opts)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) SearchOpen(opts *model.TeamSearch) ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.SearchOpen"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.TeamStore.SearchOpen(opts)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) SearchPrivate(opts *model.TeamSearch) ([]*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.SearchPrivate"); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+
+	return s.TeamStore.SearchPrivate(opts)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.Update"); err != nil {
+		var result *model.Team
+		return result, err
+	}
+
+	return s.TeamStore.Update(team)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) UpdateInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.UpdateInviteLink"); err != nil {
+		var result *model.TeamInviteLink
+		return result, err
+	}
+
+	return s.TeamStore.UpdateInviteLink(link)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curTime int64) error {
+
+	if err := s.Root.Injector.inject("TeamStore.UpdateLastTeamIconUpdate"); err != nil {
+
+		return err
+	}
+
+	return s.TeamStore.UpdateLastTeamIconUpdate(teamID, curTime)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) UpdateMember(rctx request.CTX, member *model.TeamMember) (*model.TeamMember, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.UpdateMember"); err != nil {
+		var result *model.TeamMember
+		return result, err
+	}
+
+	return s.TeamStore.UpdateMember(rctx, member)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) UpdateMembersRole(teamID string, adminIDs []string) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.UpdateMembersRole"); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+
+	return s.TeamStore.UpdateMembersRole(teamID, adminIDs)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.UpdateMultipleMembers"); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+
+	return s.TeamStore.UpdateMultipleMembers(members)
+
+}
+
+func (s *FaultInjectionLayerTeamStore) UserBelongsToTeams(userID string, teamIds []string) (bool, error) {
+
+	if err := s.Root.Injector.inject("TeamStore.UserBelongsToTeams"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.TeamStore.UserBelongsToTeams(userID, teamIds)
+
+}
+
+func (s *FaultInjectionLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, error) {
+
+	if err := s.Root.Injector.inject("TermsOfServiceStore.Get"); err != nil {
+		var result *model.TermsOfService
+		return result, err
+	}
+
+	return s.TermsOfServiceStore.Get(id, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, error) {
+
+	if err := s.Root.Injector.inject("TermsOfServiceStore.GetLatest"); err != nil {
+		var result *model.TermsOfService
+		return result, err
+	}
+
+	return s.TermsOfServiceStore.GetLatest(allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, error) {
+
+	if err := s.Root.Injector.inject("TermsOfServiceStore.Save"); err != nil {
+		var result *model.TermsOfService
+		return result, err
+	}
+
+	return s.TermsOfServiceStore.Save(termsOfService)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) DeleteMembershipForUser(userID string, postID string) error {
+
+	if err := s.Root.Injector.inject("ThreadStore.DeleteMembershipForUser"); err != nil {
+
+		return err
+	}
+
+	return s.ThreadStore.DeleteMembershipForUser(userID, postID)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) DeleteMembershipsForChannel(userID string, channelID string) error {
+
+	if err := s.Root.Injector.inject("ThreadStore.DeleteMembershipsForChannel"); err != nil {
+
+		return err
+	}
+
+	return s.ThreadStore.DeleteMembershipsForChannel(userID, channelID)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) DeleteOrphanedRows(limit int) (int64, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.DeleteOrphanedRows"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ThreadStore.DeleteOrphanedRows(limit)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) Get(id string) (*model.Thread, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.Get"); err != nil {
+		var result *model.Thread
+		return result, err
+	}
+
+	return s.ThreadStore.Get(id)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) GetMembershipForUser(userID string, postID string) (*model.ThreadMembership, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.GetMembershipForUser"); err != nil {
+		var result *model.ThreadMembership
+		return result, err
+	}
+
+	return s.ThreadStore.GetMembershipForUser(userID, postID)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) GetMembershipsForUser(userID string, teamID string) ([]*model.ThreadMembership, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.GetMembershipsForUser"); err != nil {
+		var result []*model.ThreadMembership
+		return result, err
+	}
+
+	return s.ThreadStore.GetMembershipsForUser(userID, teamID)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) GetTeamsUnreadForUser(userID string, teamIDs []string, includeUrgentMentionCount bool) (map[string]*model.TeamUnread, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.GetTeamsUnreadForUser"); err != nil {
+		var result map[string]*model.TeamUnread
+		return result, err
+	}
+
+	return s.ThreadStore.GetTeamsUnreadForUser(userID, teamIDs, includeUrgentMentionCount)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) GetThreadFollowers(threadID string, fetchOnlyActive bool) ([]string, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.GetThreadFollowers"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.ThreadStore.GetThreadFollowers(threadID, fetchOnlyActive)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) GetThreadForUser(rctx request.CTX, threadMembership *model.ThreadMembership, extended bool, postPriorityIsEnabled bool) (*model.ThreadResponse, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.GetThreadForUser"); err != nil {
+		var result *model.ThreadResponse
+		return result, err
+	}
+
+	return s.ThreadStore.GetThreadForUser(rctx, threadMembership, extended, postPriorityIsEnabled)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) GetThreadMembershipsForExport(postID string) ([]*model.ThreadMembershipForExport, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.GetThreadMembershipsForExport"); err != nil {
+		var result []*model.ThreadMembershipForExport
+		return result, err
+	}
+
+	return s.ThreadStore.GetThreadMembershipsForExport(postID)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) GetThreadUnreadReplyCount(threadMembership *model.ThreadMembership) (int64, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.GetThreadUnreadReplyCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ThreadStore.GetThreadUnreadReplyCount(threadMembership)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) GetThreadsForUser(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.GetThreadsForUser"); err != nil {
+		var result []*model.ThreadResponse
+		return result, err
+	}
+
+	return s.ThreadStore.GetThreadsForUser(rctx, userID, teamID, opts)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) GetTotalThreads(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.GetTotalThreads"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ThreadStore.GetTotalThreads(userID, teamID, opts)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) GetTotalUnreadMentions(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.GetTotalUnreadMentions"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ThreadStore.GetTotalUnreadMentions(userID, teamID, opts)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) GetTotalUnreadThreads(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.GetTotalUnreadThreads"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ThreadStore.GetTotalUnreadThreads(userID, teamID, opts)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) GetTotalUnreadUrgentMentions(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.GetTotalUnreadUrgentMentions"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.ThreadStore.GetTotalUnreadUrgentMentions(userID, teamID, opts)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) MaintainMembership(userID string, postID string, opts store.ThreadMembershipOpts) (*model.ThreadMembership, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.MaintainMembership"); err != nil {
+		var result *model.ThreadMembership
+		return result, err
+	}
+
+	return s.ThreadStore.MaintainMembership(userID, postID, opts)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) MaintainMultipleFromImport(memberships []*model.ThreadMembership) ([]*model.ThreadMembership, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.MaintainMultipleFromImport"); err != nil {
+		var result []*model.ThreadMembership
+		return result, err
+	}
+
+	return s.ThreadStore.MaintainMultipleFromImport(memberships)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) MarkAllAsRead(userID string, threadIds []string) error {
+
+	if err := s.Root.Injector.inject("ThreadStore.MarkAllAsRead"); err != nil {
+
+		return err
+	}
+
+	return s.ThreadStore.MarkAllAsRead(userID, threadIds)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) MarkAllAsReadByChannels(userID string, channelIDs []string) error {
+
+	if err := s.Root.Injector.inject("ThreadStore.MarkAllAsReadByChannels"); err != nil {
+
+		return err
+	}
+
+	return s.ThreadStore.MarkAllAsReadByChannels(userID, channelIDs)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) MarkAllAsReadByTeam(userID string, teamID string) error {
+
+	if err := s.Root.Injector.inject("ThreadStore.MarkAllAsReadByTeam"); err != nil {
+
+		return err
+	}
+
+	return s.ThreadStore.MarkAllAsReadByTeam(userID, teamID)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) MarkAsRead(userID string, threadID string, timestamp int64) error {
+
+	if err := s.Root.Injector.inject("ThreadStore.MarkAsRead"); err != nil {
+
+		return err
+	}
+
+	return s.ThreadStore.MarkAsRead(userID, threadID, timestamp)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs model.RetentionPolicyBatchConfigs, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.PermanentDeleteBatchForRetentionPolicies"); err != nil {
+		var result int64
+		var resultVar1 model.RetentionPolicyCursor
+		return result, resultVar1, err
+	}
+
+	return s.ThreadStore.PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs, cursor)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) PermanentDeleteBatchThreadMembershipsForRetentionPolicies(retentionPolicyBatchConfigs model.RetentionPolicyBatchConfigs, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.PermanentDeleteBatchThreadMembershipsForRetentionPolicies"); err != nil {
+		var result int64
+		var resultVar1 model.RetentionPolicyCursor
+		return result, resultVar1, err
+	}
+
+	return s.ThreadStore.PermanentDeleteBatchThreadMembershipsForRetentionPolicies(retentionPolicyBatchConfigs, cursor)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) SaveMultipleMemberships(memberships []*model.ThreadMembership) ([]*model.ThreadMembership, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.SaveMultipleMemberships"); err != nil {
+		var result []*model.ThreadMembership
+		return result, err
+	}
+
+	return s.ThreadStore.SaveMultipleMemberships(memberships)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+
+	if err := s.Root.Injector.inject("ThreadStore.UpdateMembership"); err != nil {
+		var result *model.ThreadMembership
+		return result, err
+	}
+
+	return s.ThreadStore.UpdateMembership(membership)
+
+}
+
+func (s *FaultInjectionLayerThreadStore) UpdateTeamIdForChannelThreads(channelId string, teamId string) error {
+
+	if err := s.Root.Injector.inject("ThreadStore.UpdateTeamIdForChannelThreads"); err != nil {
+
+		return err
+	}
+
+	return s.ThreadStore.UpdateTeamIdForChannelThreads(channelId, teamId)
+
+}
+
+func (s *FaultInjectionLayerTokenStore) Cleanup(expiryTime int64) {
+
+	s.Root.Injector.delay("TokenStore.Cleanup")
+
+	s.TokenStore.Cleanup(expiryTime)
+
+}
+
+func (s *FaultInjectionLayerTokenStore) ConsumeOnce(tokenType string, tokenStr string) (*model.Token, error) {
+
+	if err := s.Root.Injector.inject("TokenStore.ConsumeOnce"); err != nil {
+		var result *model.Token
+		return result, err
+	}
+
+	return s.TokenStore.ConsumeOnce(tokenType, tokenStr)
+
+}
+
+func (s *FaultInjectionLayerTokenStore) Delete(token string) error {
+
+	if err := s.Root.Injector.inject("TokenStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.TokenStore.Delete(token)
+
+}
+
+func (s *FaultInjectionLayerTokenStore) GetAllTokensByType(tokenType string) ([]*model.Token, error) {
+
+	if err := s.Root.Injector.inject("TokenStore.GetAllTokensByType"); err != nil {
+		var result []*model.Token
+		return result, err
+	}
+
+	return s.TokenStore.GetAllTokensByType(tokenType)
+
+}
+
+func (s *FaultInjectionLayerTokenStore) GetByToken(token string) (*model.Token, error) {
+
+	if err := s.Root.Injector.inject("TokenStore.GetByToken"); err != nil {
+		var result *model.Token
+		return result, err
+	}
+
+	return s.TokenStore.GetByToken(token)
+
+}
+
+func (s *FaultInjectionLayerTokenStore) RemoveAllTokensByType(tokenType string) error {
+
+	if err := s.Root.Injector.inject("TokenStore.RemoveAllTokensByType"); err != nil {
+
+		return err
+	}
+
+	return s.TokenStore.RemoveAllTokensByType(tokenType)
+
+}
+
+func (s *FaultInjectionLayerTokenStore) RemoveTokensByTypeAndUserId(tokenType string, userID string) error {
+
+	if err := s.Root.Injector.inject("TokenStore.RemoveTokensByTypeAndUserId"); err != nil {
+
+		return err
+	}
+
+	return s.TokenStore.RemoveTokensByTypeAndUserId(tokenType, userID)
+
+}
+
+func (s *FaultInjectionLayerTokenStore) Save(recovery *model.Token) error {
+
+	if err := s.Root.Injector.inject("TokenStore.Save"); err != nil {
+
+		return err
+	}
+
+	return s.TokenStore.Save(recovery)
+
+}
+
+func (s *FaultInjectionLayerUploadSessionStore) Delete(id string) error {
+
+	if err := s.Root.Injector.inject("UploadSessionStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.UploadSessionStore.Delete(id)
+
+}
+
+func (s *FaultInjectionLayerUploadSessionStore) Get(rctx request.CTX, id string) (*model.UploadSession, error) {
+
+	if err := s.Root.Injector.inject("UploadSessionStore.Get"); err != nil {
+		var result *model.UploadSession
+		return result, err
+	}
+
+	return s.UploadSessionStore.Get(rctx, id)
+
+}
+
+func (s *FaultInjectionLayerUploadSessionStore) GetForUser(userID string) ([]*model.UploadSession, error) {
+
+	if err := s.Root.Injector.inject("UploadSessionStore.GetForUser"); err != nil {
+		var result []*model.UploadSession
+		return result, err
+	}
+
+	return s.UploadSessionStore.GetForUser(userID)
+
+}
+
+func (s *FaultInjectionLayerUploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
+
+	if err := s.Root.Injector.inject("UploadSessionStore.Save"); err != nil {
+		var result *model.UploadSession
+		return result, err
+	}
+
+	return s.UploadSessionStore.Save(session)
+
+}
+
+func (s *FaultInjectionLayerUploadSessionStore) Update(session *model.UploadSession) error {
+
+	if err := s.Root.Injector.inject("UploadSessionStore.Update"); err != nil {
+
+		return err
+	}
+
+	return s.UploadSessionStore.Update(session)
+
+}
+
+func (s *FaultInjectionLayerUserStore) AnalyticsActiveCount(timestamp int64, options model.UserCountOptions) (int64, error) {
+
+	if err := s.Root.Injector.inject("UserStore.AnalyticsActiveCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.UserStore.AnalyticsActiveCount(timestamp, options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int64, error) {
+
+	if err := s.Root.Injector.inject("UserStore.AnalyticsActiveCountForPeriod"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.UserStore.AnalyticsActiveCountForPeriod(startTime, endTime, options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) AnalyticsGetExternalUsers(hostDomain string) (bool, error) {
+
+	if err := s.Root.Injector.inject("UserStore.AnalyticsGetExternalUsers"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.UserStore.AnalyticsGetExternalUsers(hostDomain)
+
+}
+
+func (s *FaultInjectionLayerUserStore) AnalyticsGetGuestCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("UserStore.AnalyticsGetGuestCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.UserStore.AnalyticsGetGuestCount()
+
+}
+
+func (s *FaultInjectionLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("UserStore.AnalyticsGetInactiveUsersCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.UserStore.AnalyticsGetInactiveUsersCount()
+
+}
+
+func (s *FaultInjectionLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
+
+	if err := s.Root.Injector.inject("UserStore.AnalyticsGetSystemAdminCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.UserStore.AnalyticsGetSystemAdminCount()
+
+}
+
+func (s *FaultInjectionLayerUserStore) AutocompleteUsersInChannel(rctx request.CTX, teamID string, channelID string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInChannel, error) {
+
+	if err := s.Root.Injector.inject("UserStore.AutocompleteUsersInChannel"); err != nil {
+		var result *model.UserAutocompleteInChannel
+		return result, err
+	}
+
+	return s.UserStore.AutocompleteUsersInChannel(rctx, teamID, channelID, term, options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) ClearAllCustomRoleAssignments() error {
+
+	if err := s.Root.Injector.inject("UserStore.ClearAllCustomRoleAssignments"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.ClearAllCustomRoleAssignments()
+
+}
+
+func (s *FaultInjectionLayerUserStore) ClearCaches() {
+
+	s.Root.Injector.delay("UserStore.ClearCaches")
+
+	s.UserStore.ClearCaches()
+
+}
+
+func (s *FaultInjectionLayerUserStore) Count(options model.UserCountOptions) (int64, error) {
+
+	if err := s.Root.Injector.inject("UserStore.Count"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.UserStore.Count(options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) DeactivateGuests() ([]string, error) {
+
+	if err := s.Root.Injector.inject("UserStore.DeactivateGuests"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.UserStore.DeactivateGuests()
+
+}
+
+func (s *FaultInjectionLayerUserStore) DeleteGuestAccessExpiry(userID string) error {
+
+	if err := s.Root.Injector.inject("UserStore.DeleteGuestAccessExpiry"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.DeleteGuestAccessExpiry(userID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) DemoteUserToGuest(userID string) (*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.DemoteUserToGuest"); err != nil {
+		var result *model.User
+		return result, err
+	}
+
+	return s.UserStore.DemoteUserToGuest(userID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) Get(ctx context.Context, id string) (*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.Get"); err != nil {
+		var result *model.User
+		return result, err
+	}
+
+	return s.UserStore.Get(ctx, id)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetAll() ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetAll"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetAll()
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetAllAfter(limit int, afterID string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetAllAfter"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetAllAfter(limit, afterID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetAllNotInAuthService"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetAllNotInAuthService(authServices)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetAllProfiles"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetAllProfiles(options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetAllProfilesInChannel(ctx context.Context, channelID string, allowFromCache bool) (map[string]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetAllProfilesInChannel"); err != nil {
+		var result map[string]*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetAllProfilesInChannel(ctx, channelID, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetAllUsingAuthService(authService string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetAllUsingAuthService"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetAllUsingAuthService(authService)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetAnyUnreadPostCountForChannel(userID string, channelID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetAnyUnreadPostCountForChannel"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.UserStore.GetAnyUnreadPostCountForChannel(userID, channelID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetByAuth(authData *string, authService string) (*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetByAuth"); err != nil {
+		var result *model.User
+		return result, err
+	}
+
+	return s.UserStore.GetByAuth(authData, authService)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetByEmail(email string) (*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetByEmail"); err != nil {
+		var result *model.User
+		return result, err
+	}
+
+	return s.UserStore.GetByEmail(email)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetByRemoteID(remoteID string) (*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetByRemoteID"); err != nil {
+		var result *model.User
+		return result, err
+	}
+
+	return s.UserStore.GetByRemoteID(remoteID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetByUsername(username string) (*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetByUsername"); err != nil {
+		var result *model.User
+		return result, err
+	}
+
+	return s.UserStore.GetByUsername(username)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetChannelGroupUsers"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetChannelGroupUsers(channelID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetEtagForAllProfiles() string {
+
+	s.Root.Injector.delay("UserStore.GetEtagForAllProfiles")
+
+	return s.UserStore.GetEtagForAllProfiles()
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetEtagForProfiles(teamID string) string {
+
+	s.Root.Injector.delay("UserStore.GetEtagForProfiles")
+
+	return s.UserStore.GetEtagForProfiles(teamID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetEtagForProfilesNotInTeam(teamID string) string {
+
+	s.Root.Injector.delay("UserStore.GetEtagForProfilesNotInTeam")
+
+	return s.UserStore.GetEtagForProfilesNotInTeam(teamID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetForLogin(loginID string, allowSignInWithUsername bool, allowSignInWithEmail bool) (*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetForLogin"); err != nil {
+		var result *model.User
+		return result, err
+	}
+
+	return s.UserStore.GetForLogin(loginID, allowSignInWithUsername, allowSignInWithEmail)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetGuestAccessExpiriesBefore(before int64, excludeReminded bool, limit int) ([]*model.GuestAccessExpiry, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetGuestAccessExpiriesBefore"); err != nil {
+		var result []*model.GuestAccessExpiry
+		return result, err
+	}
+
+	return s.UserStore.GetGuestAccessExpiriesBefore(before, excludeReminded, limit)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetGuestAccessExpiry(userID string) (*model.GuestAccessExpiry, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetGuestAccessExpiry"); err != nil {
+		var result *model.GuestAccessExpiry
+		return result, err
+	}
+
+	return s.UserStore.GetGuestAccessExpiry(userID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetKnownUsers(userID string) ([]string, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetKnownUsers"); err != nil {
+		var result []string
+		return result, err
+	}
+
+	return s.UserStore.GetKnownUsers(userID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetMany(rctx request.CTX, ids []string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetMany"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetMany(rctx, ids)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetMfaUsedTimestamps(userID string) ([]int, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetMfaUsedTimestamps"); err != nil {
+		var result []int
+		return result, err
+	}
+
+	return s.UserStore.GetMfaUsedTimestamps(userID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetNewUsersForTeam(teamID string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetNewUsersForTeam"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetNewUsersForTeam(teamID, offset, limit, viewRestrictions)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetProfileByGroupChannelIdsForUser(userID string, channelIds []string) (map[string][]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetProfileByGroupChannelIdsForUser"); err != nil {
+		var result map[string][]*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetProfileByGroupChannelIdsForUser(userID, channelIds)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetProfileByIds(rctx request.CTX, userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetProfileByIds"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetProfileByIds(rctx, userIds, options, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetProfiles"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetProfiles(options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetProfilesByUsernames"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetProfilesByUsernames(usernames, viewRestrictions)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetProfilesInChannel(options *model.UserGetOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetProfilesInChannel"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetProfilesInChannel(options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetProfilesInChannelByAdmin(options *model.UserGetOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetProfilesInChannelByAdmin"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetProfilesInChannelByAdmin(options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetProfilesInChannelByStatus(options *model.UserGetOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetProfilesInChannelByStatus"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetProfilesInChannelByStatus(options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetProfilesNotInChannel(teamID string, channelID string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetProfilesNotInChannel"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetProfilesNotInChannel(teamID, channelID, groupConstrained, offset, limit, viewRestrictions)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetProfilesNotInTeam(teamID string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetProfilesNotInTeam"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetProfilesNotInTeam(teamID, groupConstrained, offset, limit, viewRestrictions)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetProfilesWithoutTeam(options *model.UserGetOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetProfilesWithoutTeam"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetProfilesWithoutTeam(options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetRecentlyActiveUsersForTeam(teamID string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetRecentlyActiveUsersForTeam"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetRecentlyActiveUsersForTeam(teamID, offset, limit, viewRestrictions)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetSystemAdminProfiles"); err != nil {
+		var result map[string]*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetSystemAdminProfiles()
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetTeamGroupUsers"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetTeamGroupUsers(teamID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetUnreadCount(userID string, isCRTEnabled bool) (int64, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetUnreadCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.UserStore.GetUnreadCount(userID, isCRTEnabled)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetUnreadCountForChannel(userID string, channelID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetUnreadCountForChannel"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.UserStore.GetUnreadCountForChannel(userID, channelID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetUserCountForReport(filter *model.UserReportOptions) (int64, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetUserCountForReport"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.UserStore.GetUserCountForReport(filter)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetUserReport(filter *model.UserReportOptions) ([]*model.UserReportQuery, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetUserReport"); err != nil {
+		var result []*model.UserReportQuery
+		return result, err
+	}
+
+	return s.UserStore.GetUserReport(filter)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetUsersBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.UserForIndexing, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetUsersBatchForIndexing"); err != nil {
+		var result []*model.UserForIndexing
+		return result, err
+	}
+
+	return s.UserStore.GetUsersBatchForIndexing(startTime, startFileID, limit)
+
+}
+
+func (s *FaultInjectionLayerUserStore) GetUsersWithInvalidEmails(page int, perPage int, restrictedDomains string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.GetUsersWithInvalidEmails"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.GetUsersWithInvalidEmails(page, perPage, restrictedDomains)
+
+}
+
+func (s *FaultInjectionLayerUserStore) InferSystemInstallDate() (int64, error) {
+
+	if err := s.Root.Injector.inject("UserStore.InferSystemInstallDate"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.UserStore.InferSystemInstallDate()
+
+}
+
+func (s *FaultInjectionLayerUserStore) InsertUsers(users []*model.User) error {
+
+	if err := s.Root.Injector.inject("UserStore.InsertUsers"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.InsertUsers(users)
+
+}
+
+func (s *FaultInjectionLayerUserStore) InvalidateProfileCacheForUser(userID string) {
+
+	s.Root.Injector.delay("UserStore.InvalidateProfileCacheForUser")
+
+	s.UserStore.InvalidateProfileCacheForUser(userID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) InvalidateProfilesInChannelCache(channelID string) {
+
+	s.Root.Injector.delay("UserStore.InvalidateProfilesInChannelCache")
+
+	s.UserStore.InvalidateProfilesInChannelCache(channelID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) InvalidateProfilesInChannelCacheByUser(userID string) {
+
+	s.Root.Injector.delay("UserStore.InvalidateProfilesInChannelCacheByUser")
+
+	s.UserStore.InvalidateProfilesInChannelCacheByUser(userID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) IsEmpty(excludeBots bool) (bool, error) {
+
+	if err := s.Root.Injector.inject("UserStore.IsEmpty"); err != nil {
+		var result bool
+		return result, err
+	}
+
+	return s.UserStore.IsEmpty(excludeBots)
+
+}
+
+func (s *FaultInjectionLayerUserStore) MarkGuestAccessExpiryReminded(userID string, remindedAt int64) error {
+
+	if err := s.Root.Injector.inject("UserStore.MarkGuestAccessExpiryReminded"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.MarkGuestAccessExpiryReminded(userID, remindedAt)
+
+}
+
+func (s *FaultInjectionLayerUserStore) PermanentDelete(rctx request.CTX, userID string) error {
+
+	if err := s.Root.Injector.inject("UserStore.PermanentDelete"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.PermanentDelete(rctx, userID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) PromoteGuestToUser(userID string) error {
+
+	if err := s.Root.Injector.inject("UserStore.PromoteGuestToUser"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.PromoteGuestToUser(userID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) RefreshPostStatsForUsers() error {
+
+	if err := s.Root.Injector.inject("UserStore.RefreshPostStatsForUsers"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.RefreshPostStatsForUsers()
+
+}
+
+func (s *FaultInjectionLayerUserStore) ResetAuthDataToEmailForUsers(service string, userIDs []string, includeDeleted bool, dryRun bool) (int, error) {
+
+	if err := s.Root.Injector.inject("UserStore.ResetAuthDataToEmailForUsers"); err != nil {
+		var result int
+		return result, err
+	}
+
+	return s.UserStore.ResetAuthDataToEmailForUsers(service, userIDs, includeDeleted, dryRun)
+
+}
+
+func (s *FaultInjectionLayerUserStore) ResetLastPictureUpdate(userID string) error {
+
+	if err := s.Root.Injector.inject("UserStore.ResetLastPictureUpdate"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.ResetLastPictureUpdate(userID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) Save(rctx request.CTX, user *model.User) (*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.Save"); err != nil {
+		var result *model.User
+		return result, err
+	}
+
+	return s.UserStore.Save(rctx, user)
+
+}
+
+func (s *FaultInjectionLayerUserStore) SaveGuestAccessExpiry(expiry *model.GuestAccessExpiry) (*model.GuestAccessExpiry, error) {
+
+	if err := s.Root.Injector.inject("UserStore.SaveGuestAccessExpiry"); err != nil {
+		var result *model.GuestAccessExpiry
+		return result, err
+	}
+
+	return s.UserStore.SaveGuestAccessExpiry(expiry)
+
+}
+
+func (s *FaultInjectionLayerUserStore) Search(rctx request.CTX, teamID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.Search"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.Search(rctx, teamID, term, options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) SearchCommonContentFlaggingReviewers(term string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.SearchCommonContentFlaggingReviewers"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.SearchCommonContentFlaggingReviewers(term)
+
+}
+
+func (s *FaultInjectionLayerUserStore) SearchInChannel(channelID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.SearchInChannel"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.SearchInChannel(channelID, term, options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) SearchInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.SearchInGroup"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.SearchInGroup(groupID, term, options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) SearchNotInChannel(teamID string, channelID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.SearchNotInChannel"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.SearchNotInChannel(teamID, channelID, term, options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) SearchNotInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.SearchNotInGroup"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.SearchNotInGroup(groupID, term, options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) SearchNotInTeam(notInTeamID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.SearchNotInTeam"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.SearchNotInTeam(notInTeamID, term, options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) SearchTeamContentFlaggingReviewers(teamId string, term string) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.SearchTeamContentFlaggingReviewers"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.SearchTeamContentFlaggingReviewers(teamId, term)
+
+}
+
+func (s *FaultInjectionLayerUserStore) SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Injector.inject("UserStore.SearchWithoutTeam"); err != nil {
+		var result []*model.User
+		return result, err
+	}
+
+	return s.UserStore.SearchWithoutTeam(term, options)
+
+}
+
+func (s *FaultInjectionLayerUserStore) StoreMfaUsedTimestamps(userID string, ts []int) error {
+
+	if err := s.Root.Injector.inject("UserStore.StoreMfaUsedTimestamps"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.StoreMfaUsedTimestamps(userID, ts)
+
+}
+
+func (s *FaultInjectionLayerUserStore) Update(rctx request.CTX, user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+
+	if err := s.Root.Injector.inject("UserStore.Update"); err != nil {
+		var result *model.UserUpdate
+		return result, err
+	}
+
+	return s.UserStore.Update(rctx, user, allowRoleUpdate)
+
+}
+
+func (s *FaultInjectionLayerUserStore) UpdateAuthData(userID string, service string, authData *string, email string, resetMfa bool) (string, error) {
+
+	if err := s.Root.Injector.inject("UserStore.UpdateAuthData"); err != nil {
+		var result string
+		return result, err
+	}
+
+	return s.UserStore.UpdateAuthData(userID, service, authData, email, resetMfa)
+
+}
+
+func (s *FaultInjectionLayerUserStore) UpdateFailedPasswordAttempts(userID string, attempts int) error {
+
+	if err := s.Root.Injector.inject("UserStore.UpdateFailedPasswordAttempts"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.UpdateFailedPasswordAttempts(userID, attempts)
+
+}
+
+func (s *FaultInjectionLayerUserStore) UpdateLastLogin(userID string, lastLogin int64) error {
+
+	if err := s.Root.Injector.inject("UserStore.UpdateLastLogin"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.UpdateLastLogin(userID, lastLogin)
+
+}
+
+func (s *FaultInjectionLayerUserStore) UpdateLastPictureUpdate(userID string) error {
+
+	if err := s.Root.Injector.inject("UserStore.UpdateLastPictureUpdate"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.UpdateLastPictureUpdate(userID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) UpdateMfaActive(userID string, active bool) error {
+
+	if err := s.Root.Injector.inject("UserStore.UpdateMfaActive"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.UpdateMfaActive(userID, active)
+
+}
+
+func (s *FaultInjectionLayerUserStore) UpdateMfaSecret(userID string, secret string) error {
+
+	if err := s.Root.Injector.inject("UserStore.UpdateMfaSecret"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.UpdateMfaSecret(userID, secret)
+
+}
+
+func (s *FaultInjectionLayerUserStore) UpdateNotifyProps(userID string, props map[string]string) error {
+
+	if err := s.Root.Injector.inject("UserStore.UpdateNotifyProps"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.UpdateNotifyProps(userID, props)
+
+}
+
+func (s *FaultInjectionLayerUserStore) UpdatePassword(userID string, newPassword string) error {
+
+	if err := s.Root.Injector.inject("UserStore.UpdatePassword"); err != nil {
+
+		return err
+	}
+
+	return s.UserStore.UpdatePassword(userID, newPassword)
+
+}
+
+func (s *FaultInjectionLayerUserStore) UpdateUpdateAt(userID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("UserStore.UpdateUpdateAt"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.UserStore.UpdateUpdateAt(userID)
+
+}
+
+func (s *FaultInjectionLayerUserStore) VerifyEmail(userID string, email string) (string, error) {
+
+	if err := s.Root.Injector.inject("UserStore.VerifyEmail"); err != nil {
+		var result string
+		return result, err
+	}
+
+	return s.UserStore.VerifyEmail(userID, email)
+
+}
+
+func (s *FaultInjectionLayerUserAccessTokenStore) Delete(tokenID string) error {
+
+	if err := s.Root.Injector.inject("UserAccessTokenStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.UserAccessTokenStore.Delete(tokenID)
+
+}
+
+func (s *FaultInjectionLayerUserAccessTokenStore) DeleteAllForUser(userID string) error {
+
+	if err := s.Root.Injector.inject("UserAccessTokenStore.DeleteAllForUser"); err != nil {
+
+		return err
+	}
+
+	return s.UserAccessTokenStore.DeleteAllForUser(userID)
+
+}
+
+func (s *FaultInjectionLayerUserAccessTokenStore) Get(tokenID string) (*model.UserAccessToken, error) {
+
+	if err := s.Root.Injector.inject("UserAccessTokenStore.Get"); err != nil {
+		var result *model.UserAccessToken
+		return result, err
+	}
+
+	return s.UserAccessTokenStore.Get(tokenID)
+
+}
+
+func (s *FaultInjectionLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, error) {
+
+	if err := s.Root.Injector.inject("UserAccessTokenStore.GetAll"); err != nil {
+		var result []*model.UserAccessToken
+		return result, err
+	}
+
+	return s.UserAccessTokenStore.GetAll(offset, limit)
+
+}
+
+func (s *FaultInjectionLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, error) {
+
+	if err := s.Root.Injector.inject("UserAccessTokenStore.GetByToken"); err != nil {
+		var result *model.UserAccessToken
+		return result, err
+	}
+
+	return s.UserAccessTokenStore.GetByToken(tokenString)
+
+}
+
+func (s *FaultInjectionLayerUserAccessTokenStore) GetByUser(userID string, page int, perPage int) ([]*model.UserAccessToken, error) {
+
+	if err := s.Root.Injector.inject("UserAccessTokenStore.GetByUser"); err != nil {
+		var result []*model.UserAccessToken
+		return result, err
+	}
+
+	return s.UserAccessTokenStore.GetByUser(userID, page, perPage)
+
+}
+
+func (s *FaultInjectionLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
+
+	if err := s.Root.Injector.inject("UserAccessTokenStore.Save"); err != nil {
+		var result *model.UserAccessToken
+		return result, err
+	}
+
+	return s.UserAccessTokenStore.Save(token)
+
+}
+
+func (s *FaultInjectionLayerUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, error) {
+
+	if err := s.Root.Injector.inject("UserAccessTokenStore.Search"); err != nil {
+		var result []*model.UserAccessToken
+		return result, err
+	}
+
+	return s.UserAccessTokenStore.Search(term)
+
+}
+
+func (s *FaultInjectionLayerUserAccessTokenStore) UpdateTokenDisable(tokenID string) error {
+
+	if err := s.Root.Injector.inject("UserAccessTokenStore.UpdateTokenDisable"); err != nil {
+
+		return err
+	}
+
+	return s.UserAccessTokenStore.UpdateTokenDisable(tokenID)
+
+}
+
+func (s *FaultInjectionLayerUserAccessTokenStore) UpdateTokenEnable(tokenID string) error {
+
+	if err := s.Root.Injector.inject("UserAccessTokenStore.UpdateTokenEnable"); err != nil {
+
+		return err
+	}
+
+	return s.UserAccessTokenStore.UpdateTokenEnable(tokenID)
+
+}
+
+func (s *FaultInjectionLayerUserTermsOfServiceStore) Delete(userID string, termsOfServiceID string) error {
+
+	if err := s.Root.Injector.inject("UserTermsOfServiceStore.Delete"); err != nil {
+
+		return err
+	}
+
+	return s.UserTermsOfServiceStore.Delete(userID, termsOfServiceID)
+
+}
+
+func (s *FaultInjectionLayerUserTermsOfServiceStore) GetByUser(userID string) (*model.UserTermsOfService, error) {
+
+	if err := s.Root.Injector.inject("UserTermsOfServiceStore.GetByUser"); err != nil {
+		var result *model.UserTermsOfService
+		return result, err
+	}
+
+	return s.UserTermsOfServiceStore.GetByUser(userID)
+
+}
+
+func (s *FaultInjectionLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
+
+	if err := s.Root.Injector.inject("UserTermsOfServiceStore.Save"); err != nil {
+		var result *model.UserTermsOfService
+		return result, err
+	}
+
+	return s.UserTermsOfServiceStore.Save(userTermsOfService)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) AnalyticsIncomingCount(teamID string, userID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.AnalyticsIncomingCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.WebhookStore.AnalyticsIncomingCount(teamID, userID)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) AnalyticsOutgoingCount(teamID string) (int64, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.AnalyticsOutgoingCount"); err != nil {
+		var result int64
+		return result, err
+	}
+
+	return s.WebhookStore.AnalyticsOutgoingCount(teamID)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) ClearCaches() {
+
+	s.Root.Injector.delay("WebhookStore.ClearCaches")
+
+	s.WebhookStore.ClearCaches()
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) DeleteIncoming(webhookID string, timestamp int64) error {
+
+	if err := s.Root.Injector.inject("WebhookStore.DeleteIncoming"); err != nil {
+
+		return err
+	}
+
+	return s.WebhookStore.DeleteIncoming(webhookID, timestamp)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) DeleteOutgoing(webhookID string, timestamp int64) error {
+
+	if err := s.Root.Injector.inject("WebhookStore.DeleteOutgoing"); err != nil {
+
+		return err
+	}
+
+	return s.WebhookStore.DeleteOutgoing(webhookID, timestamp)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetIncoming(id string, allowFromCache bool) (*model.IncomingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetIncoming"); err != nil {
+		var result *model.IncomingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetIncoming(id, allowFromCache)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetIncomingByChannel(channelID string) ([]*model.IncomingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetIncomingByChannel"); err != nil {
+		var result []*model.IncomingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetIncomingByChannel(channelID)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetIncomingByTeam(teamID string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetIncomingByTeam"); err != nil {
+		var result []*model.IncomingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetIncomingByTeam(teamID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetIncomingByTeamByUser(teamID string, userID string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetIncomingByTeamByUser"); err != nil {
+		var result []*model.IncomingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetIncomingByTeamByUser(teamID, userID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*model.IncomingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetIncomingList"); err != nil {
+		var result []*model.IncomingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetIncomingList(offset, limit)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetIncomingListByUser(userID string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetIncomingListByUser"); err != nil {
+		var result []*model.IncomingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetIncomingListByUser(userID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetOutgoing"); err != nil {
+		var result *model.OutgoingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetOutgoing(id)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetOutgoingByChannel(channelID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetOutgoingByChannel"); err != nil {
+		var result []*model.OutgoingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetOutgoingByChannel(channelID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetOutgoingByChannelByUser(channelID string, userID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetOutgoingByChannelByUser"); err != nil {
+		var result []*model.OutgoingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetOutgoingByChannelByUser(channelID, userID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetOutgoingByTeam(teamID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetOutgoingByTeam"); err != nil {
+		var result []*model.OutgoingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetOutgoingByTeam(teamID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetOutgoingByTeamByUser(teamID string, userID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetOutgoingByTeamByUser"); err != nil {
+		var result []*model.OutgoingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetOutgoingByTeamByUser(teamID, userID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetOutgoingList"); err != nil {
+		var result []*model.OutgoingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetOutgoingList(offset, limit)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) GetOutgoingListByUser(userID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.GetOutgoingListByUser"); err != nil {
+		var result []*model.OutgoingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.GetOutgoingListByUser(userID, offset, limit)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) InvalidateWebhookCache(webhook string) {
+
+	s.Root.Injector.delay("WebhookStore.InvalidateWebhookCache")
+
+	s.WebhookStore.InvalidateWebhookCache(webhook)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) PermanentDeleteIncomingByChannel(channelID string) error {
+
+	if err := s.Root.Injector.inject("WebhookStore.PermanentDeleteIncomingByChannel"); err != nil {
+
+		return err
+	}
+
+	return s.WebhookStore.PermanentDeleteIncomingByChannel(channelID)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) PermanentDeleteIncomingByUser(userID string) error {
+
+	if err := s.Root.Injector.inject("WebhookStore.PermanentDeleteIncomingByUser"); err != nil {
+
+		return err
+	}
+
+	return s.WebhookStore.PermanentDeleteIncomingByUser(userID)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelID string) error {
+
+	if err := s.Root.Injector.inject("WebhookStore.PermanentDeleteOutgoingByChannel"); err != nil {
+
+		return err
+	}
+
+	return s.WebhookStore.PermanentDeleteOutgoingByChannel(channelID)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) PermanentDeleteOutgoingByUser(userID string) error {
+
+	if err := s.Root.Injector.inject("WebhookStore.PermanentDeleteOutgoingByUser"); err != nil {
+
+		return err
+	}
+
+	return s.WebhookStore.PermanentDeleteOutgoingByUser(userID)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.SaveIncoming"); err != nil {
+		var result *model.IncomingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.SaveIncoming(webhook)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.SaveOutgoing"); err != nil {
+		var result *model.OutgoingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.SaveOutgoing(webhook)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.UpdateIncoming"); err != nil {
+		var result *model.IncomingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.UpdateIncoming(webhook)
+
+}
+
+func (s *FaultInjectionLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Injector.inject("WebhookStore.UpdateOutgoing"); err != nil {
+		var result *model.OutgoingWebhook
+		return result, err
+	}
+
+	return s.WebhookStore.UpdateOutgoing(hook)
+
+}
+
+func (s *FaultInjectionLayer) Close() {
+	s.Store.Close()
+}
+
+func (s *FaultInjectionLayer) DropAllTables() {
+	s.Store.DropAllTables()
+}
+
+func (s *FaultInjectionLayer) LockToMaster() {
+	s.Store.LockToMaster()
+}
+
+func (s *FaultInjectionLayer) MarkSystemRanUnitTests() {
+	s.Store.MarkSystemRanUnitTests()
+}
+
+func (s *FaultInjectionLayer) TotalMasterDbConnections() int {
+	return s.Store.TotalMasterDbConnections()
+}
+
+func (s *FaultInjectionLayer) TotalReadDbConnections() int {
+	return s.Store.TotalReadDbConnections()
+}
+
+func (s *FaultInjectionLayer) TotalSearchDbConnections() int {
+	return s.Store.TotalSearchDbConnections()
+}
+
+func (s *FaultInjectionLayer) UnlockFromMaster() {
+	s.Store.UnlockFromMaster()
+}
+
+// New returns a store layer injecting the faults of the injector in the store methods.
+func New(childStore store.Store, injector *Injector) *FaultInjectionLayer {
+	newStore := FaultInjectionLayer{
+		Store:    childStore,
+		Injector: injector,
+	}
+
+	newStore.AccessControlPolicyStore = &FaultInjectionLayerAccessControlPolicyStore{AccessControlPolicyStore: childStore.AccessControlPolicy(), Root: &newStore}
+	newStore.AttributesStore = &FaultInjectionLayerAttributesStore{AttributesStore: childStore.Attributes(), Root: &newStore}
+	newStore.AuditStore = &FaultInjectionLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.BotStore = &FaultInjectionLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
+	newStore.ChannelStore = &FaultInjectionLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelBookmarkStore = &FaultInjectionLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
+	newStore.ChannelMemberHistoryStore = &FaultInjectionLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ClusterDiscoveryStore = &FaultInjectionLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
+	newStore.CommandStore = &FaultInjectionLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
+	newStore.CommandWebhookStore = &FaultInjectionLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
+	newStore.ComplianceStore = &FaultInjectionLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.ContentFlaggingStore = &FaultInjectionLayerContentFlaggingStore{ContentFlaggingStore: childStore.ContentFlagging(), Root: &newStore}
+	newStore.DesktopTokensStore = &FaultInjectionLayerDesktopTokensStore{DesktopTokensStore: childStore.DesktopTokens(), Root: &newStore}
+	newStore.DraftStore = &FaultInjectionLayerDraftStore{DraftStore: childStore.Draft(), Root: &newStore}
+	newStore.EmojiStore = &FaultInjectionLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.FileInfoStore = &FaultInjectionLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
+	newStore.GroupStore = &FaultInjectionLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.JobStore = &FaultInjectionLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.LicenseStore = &FaultInjectionLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
+	newStore.LinkMetadataStore = &FaultInjectionLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.NotifyAdminStore = &FaultInjectionLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
+	newStore.OAuthStore = &FaultInjectionLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OutgoingEmailStore = &FaultInjectionLayerOutgoingEmailStore{OutgoingEmailStore: childStore.OutgoingEmail(), Root: &newStore}
+	newStore.OutgoingOAuthConnectionStore = &FaultInjectionLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PluginStore = &FaultInjectionLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PostStore = &FaultInjectionLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PostAcknowledgementStore = &FaultInjectionLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
+	newStore.PostPersistentNotificationStore = &FaultInjectionLayerPostPersistentNotificationStore{PostPersistentNotificationStore: childStore.PostPersistentNotification(), Root: &newStore}
+	newStore.PostPriorityStore = &FaultInjectionLayerPostPriorityStore{PostPriorityStore: childStore.PostPriority(), Root: &newStore}
+	newStore.PreferenceStore = &FaultInjectionLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
+	newStore.ProductNoticesStore = &FaultInjectionLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
+	newStore.PropertyFieldStore = &FaultInjectionLayerPropertyFieldStore{PropertyFieldStore: childStore.PropertyField(), Root: &newStore}
+	newStore.PropertyGroupStore = &FaultInjectionLayerPropertyGroupStore{PropertyGroupStore: childStore.PropertyGroup(), Root: &newStore}
+	newStore.PropertyValueStore = &FaultInjectionLayerPropertyValueStore{PropertyValueStore: childStore.PropertyValue(), Root: &newStore}
+	newStore.ReactionStore = &FaultInjectionLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.RemoteClusterStore = &FaultInjectionLayerRemoteClusterStore{RemoteClusterStore: childStore.RemoteCluster(), Root: &newStore}
+	newStore.RetentionPolicyStore = &FaultInjectionLayerRetentionPolicyStore{RetentionPolicyStore: childStore.RetentionPolicy(), Root: &newStore}
+	newStore.RoleStore = &FaultInjectionLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.ScheduledPostStore = &FaultInjectionLayerScheduledPostStore{ScheduledPostStore: childStore.ScheduledPost(), Root: &newStore}
+	newStore.SchemeStore = &FaultInjectionLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
+	newStore.SessionStore = &FaultInjectionLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
+	newStore.SharedChannelStore = &FaultInjectionLayerSharedChannelStore{SharedChannelStore: childStore.SharedChannel(), Root: &newStore}
+	newStore.StatusStore = &FaultInjectionLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
+	newStore.SystemStore = &FaultInjectionLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
+	newStore.TeamStore = &FaultInjectionLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
+	newStore.TermsOfServiceStore = &FaultInjectionLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
+	newStore.ThreadStore = &FaultInjectionLayerThreadStore{ThreadStore: childStore.Thread(), Root: &newStore}
+	newStore.TokenStore = &FaultInjectionLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
+	newStore.UploadSessionStore = &FaultInjectionLayerUploadSessionStore{UploadSessionStore: childStore.UploadSession(), Root: &newStore}
+	newStore.UserStore = &FaultInjectionLayerUserStore{UserStore: childStore.User(), Root: &newStore}
+	newStore.UserAccessTokenStore = &FaultInjectionLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
+	newStore.UserTermsOfServiceStore = &FaultInjectionLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebhookStore = &FaultInjectionLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
+	return &newStore
+}
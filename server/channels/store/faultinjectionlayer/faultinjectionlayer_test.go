@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package faultinjectionlayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+)
+
+func newTestLayer(random float64) (*FaultInjectionLayer, *storetest.Store, *[]time.Duration) {
+	mockStore := &storetest.Store{}
+	injector := NewInjector()
+	injector.random = func() float64 { return random }
+	sleeps := []time.Duration{}
+	injector.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+
+	return New(mockStore, injector), mockStore, &sleeps
+}
+
+func TestFaultInjectionLayer(t *testing.T) {
+	user := &model.User{Id: model.NewId()}
+	channel := &model.Channel{Id: model.NewId()}
+
+	t.Run("calls the store without faults", func(t *testing.T) {
+		layer, mockStore, sleeps := newTestLayer(0)
+		mockStore.UserStore.On("Get", context.Background(), user.Id).Return(user, nil).Once()
+
+		result, err := layer.User().Get(context.Background(), user.Id)
+		require.NoError(t, err)
+		assert.Equal(t, user, result)
+		assert.Empty(t, *sleeps)
+		mockStore.UserStore.AssertExpectations(t)
+	})
+
+	t.Run("returns the injected error without calling the store", func(t *testing.T) {
+		layer, mockStore, _ := newTestLayer(0.3)
+		layer.Injector.SetFaults([]*model.StoreFault{{Method: "UserStore.Get", ErrorRate: 0.5}})
+
+		result, err := layer.User().Get(context.Background(), user.Id)
+		require.ErrorIs(t, err, ErrInjectedFault)
+		assert.Nil(t, result)
+		mockStore.UserStore.AssertNotCalled(t, "Get")
+	})
+
+	t.Run("returns a serialization failure", func(t *testing.T) {
+		layer, _, _ := newTestLayer(0.3)
+		layer.Injector.SetFaults([]*model.StoreFault{{Method: "ChannelStore.*", SerializationFailureRate: 0.5}})
+
+		_, err := layer.Channel().Get(channel.Id, true)
+		var pqErr *pq.Error
+		require.True(t, errors.As(err, &pqErr))
+		assert.Equal(t, pq.ErrorCode("40001"), pqErr.Code)
+	})
+
+	t.Run("adds the latency before calling the store", func(t *testing.T) {
+		layer, mockStore, sleeps := newTestLayer(0.3)
+		layer.Injector.SetFaults([]*model.StoreFault{{Method: model.StoreFaultAllMethods, LatencyMs: 200, LatencyRate: 0.5}})
+		mockStore.ChannelStore.On("Get", channel.Id, true).Return(channel, nil).Once()
+		mockStore.ChannelStore.On("ClearCaches").Once()
+
+		result, err := layer.Channel().Get(channel.Id, true)
+		require.NoError(t, err)
+		assert.Equal(t, channel, result)
+
+		layer.Channel().ClearCaches()
+		assert.Equal(t, []time.Duration{200 * time.Millisecond, 200 * time.Millisecond}, *sleeps)
+		mockStore.ChannelStore.AssertExpectations(t)
+	})
+
+	t.Run("doesn't inject the faults above the rates", func(t *testing.T) {
+		layer, mockStore, sleeps := newTestLayer(0.7)
+		layer.Injector.SetFaults([]*model.StoreFault{{Method: "UserStore.Get", LatencyMs: 200, LatencyRate: 0.5, ErrorRate: 0.5, SerializationFailureRate: 0.5}})
+		mockStore.UserStore.On("Get", context.Background(), user.Id).Return(user, nil).Once()
+
+		_, err := layer.User().Get(context.Background(), user.Id)
+		require.NoError(t, err)
+		assert.Empty(t, *sleeps)
+	})
+}
+
+func TestInjectorFault(t *testing.T) {
+	injector := NewInjector()
+	assert.Nil(t, injector.fault("PostStore.Save"))
+
+	injector.SetFaults([]*model.StoreFault{
+		{Method: "PostStore.Save", ErrorRate: 1},
+		{Method: "PostStore.*", ErrorRate: 0.5},
+		{Method: model.StoreFaultAllMethods, ErrorRate: 0.1},
+	})
+	assert.Equal(t, 1.0, injector.fault("PostStore.Save").ErrorRate)
+	assert.Equal(t, 0.5, injector.fault("PostStore.Get").ErrorRate)
+	assert.Equal(t, 0.1, injector.fault("UserStore.Get").ErrorRate)
+	assert.Len(t, injector.Faults(), 3)
+
+	injector.SetFaults(nil)
+	assert.Nil(t, injector.fault("PostStore.Save"))
+	assert.Empty(t, injector.Faults())
+}
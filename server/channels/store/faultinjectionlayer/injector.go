@@ -0,0 +1,113 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package faultinjectionlayer
+
+import (
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// ErrInjectedFault is the error returned by the store methods failing because of an injected fault.
+var ErrInjectedFault = errors.New("injected store fault")
+
+// Injector holds the faults injected in the store methods, which can be changed at runtime.
+type Injector struct {
+	mut    sync.RWMutex
+	faults map[string]*model.StoreFault
+
+	// random returns a number in [0.0, 1.0). It's only replaced by the tests.
+	random func() float64
+	// sleep is only replaced by the tests.
+	sleep func(d time.Duration)
+}
+
+func NewInjector() *Injector {
+	return &Injector{
+		faults: map[string]*model.StoreFault{},
+		random: rand.Float64,
+		sleep:  time.Sleep,
+	}
+}
+
+// Faults returns the faults being injected.
+func (i *Injector) Faults() []*model.StoreFault {
+	i.mut.RLock()
+	defer i.mut.RUnlock()
+
+	faults := make([]*model.StoreFault, 0, len(i.faults))
+	for _, fault := range i.faults {
+		faultCopy := *fault
+		faults = append(faults, &faultCopy)
+	}
+	return faults
+}
+
+// SetFaults replaces the faults being injected. The faults must be valid.
+func (i *Injector) SetFaults(faults []*model.StoreFault) {
+	faultsByMethod := make(map[string]*model.StoreFault, len(faults))
+	for _, fault := range faults {
+		faultCopy := *fault
+		faultsByMethod[fault.Method] = &faultCopy
+	}
+
+	i.mut.Lock()
+	defer i.mut.Unlock()
+	i.faults = faultsByMethod
+}
+
+// fault returns the fault of the method, matching the method first, then its store and then all
+// the methods.
+func (i *Injector) fault(method string) *model.StoreFault {
+	i.mut.RLock()
+	defer i.mut.RUnlock()
+
+	if len(i.faults) == 0 {
+		return nil
+	}
+	if fault, ok := i.faults[method]; ok {
+		return fault
+	}
+	if storeName, _, ok := strings.Cut(method, "."); ok {
+		if fault, ok := i.faults[storeName+".*"]; ok {
+			return fault
+		}
+	}
+	return i.faults[model.StoreFaultAllMethods]
+}
+
+// delay adds the latency of the fault of the method, if any.
+func (i *Injector) delay(method string) *model.StoreFault {
+	fault := i.fault(method)
+	if fault != nil && fault.LatencyMs > 0 && i.random() < fault.LatencyRate {
+		i.sleep(time.Duration(fault.LatencyMs) * time.Millisecond)
+	}
+	return fault
+}
+
+// inject adds the latency of the fault of the method, if any, and returns the error the method
+// must fail with, if any.
+func (i *Injector) inject(method string) error {
+	fault := i.delay(method)
+	if fault == nil {
+		return nil
+	}
+
+	if i.random() < fault.SerializationFailureRate {
+		return errors.Wrap(&pq.Error{
+			Code:    "40001",
+			Message: "could not serialize access due to concurrent update",
+		}, method)
+	}
+	if i.random() < fault.ErrorRate {
+		return errors.Wrap(ErrInjectedFault, method)
+	}
+	return nil
+}
@@ -34,6 +34,12 @@ func main() {
 	if err := buildRetryLayer(); err != nil {
 		log.Fatal(err)
 	}
+	if err := buildTracingLayer(); err != nil {
+		log.Fatal(err)
+	}
+	if err := buildFaultInjectionLayer(); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func buildRetryLayer() error {
@@ -62,6 +68,32 @@ func buildTimerLayer() error {
 	return os.WriteFile(path.Join("timerlayer", "timerlayer.go"), formatedCode, 0644)
 }
 
+func buildTracingLayer() error {
+	code, err := generateLayer("TracingLayer", "tracing_layer.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path.Join("tracinglayer", "tracinglayer.go"), formatedCode, 0644)
+}
+
+func buildFaultInjectionLayer() error {
+	code, err := generateLayer("FaultInjectionLayer", "fault_injection_layer.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path.Join("faultinjectionlayer", "faultinjectionlayer.go"), formatedCode, 0644)
+}
+
 type methodParam struct {
 	Name string
 	Type string
@@ -211,6 +243,23 @@ func generateLayer(name, templateFile string) ([]byte, error) {
 			}
 			return strings.Join(vars, ", ")
 		},
+		"genZeroResultsVars": func(results []string) string {
+			vars := []string{}
+			for i, typeName := range results {
+				if isError(typeName) {
+					continue
+				}
+				if typeName == "*PostReminderMetadata" {
+					typeName = "*store.PostReminderMetadata"
+				}
+				if i == 0 {
+					vars = append(vars, fmt.Sprintf("var result %s", typeName))
+				} else {
+					vars = append(vars, fmt.Sprintf("var resultVar%d %s", i, typeName))
+				}
+			}
+			return strings.Join(vars, "\n")
+		},
 		"errorToBoolean": func(results []string) string {
 			if slices.ContainsFunc(results, isError) {
 				return "err == nil"
@@ -226,6 +275,17 @@ func generateLayer(name, templateFile string) ([]byte, error) {
 			}
 			return ""
 		},
+		"contextOf": func(params []methodParam) string {
+			for _, param := range params {
+				switch param.Type {
+				case "request.CTX":
+					return fmt.Sprintf("requestContext(%s)", param.Name)
+				case "context.Context":
+					return param.Name
+				}
+			}
+			return ""
+		},
 		"joinParams": func(params []methodParam) string {
 			paramsNames := make([]string, 0, len(params))
 			for _, param := range params {
@@ -146,6 +146,29 @@ func (es *LocalCacheEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 	return err
 }
 
+func (es *LocalCacheEmojiStore) Update(emoji *model.Emoji) (*model.Emoji, error) {
+	updated, err := es.EmojiStore.Update(emoji)
+
+	if err == nil {
+		es.removeFromCache(emoji)
+	}
+
+	return updated, err
+}
+
+func (es *LocalCacheEmojiStore) DeleteCategory(id string) error {
+	err := es.EmojiStore.DeleteCategory(id)
+
+	if err == nil {
+		// The emojis of the category were uncategorized.
+		es.rootStore.doClearCacheCluster(es.rootStore.emojiCacheById)
+	}
+
+	return err
+}
+
+// addToCache caches the emoji by its id and name. Aliases aren't cached by name, so looking up an
+// emoji by one of its aliases always reaches the database.
 func (es *LocalCacheEmojiStore) addToCache(emoji *model.Emoji) {
 	es.rootStore.doStandardAddToCache(es.rootStore.emojiCacheById, emoji.Id, emoji)
 	es.rootStore.doStandardAddToCache(es.rootStore.emojiIdCacheByName, emoji.Name, emoji.Id)
@@ -158,6 +158,7 @@ func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterf
 		InvalidateClusterEvent: model.ClusterEventInvalidateCacheForRoles,
 		Striped:                true,
 		StripedBuckets:         max(runtime.NumCPU()-1, 1),
+		NearCache:              true,
 	}); err != nil {
 		return
 	}
@@ -166,6 +167,7 @@ func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterf
 		Name:                   "RolePermission",
 		DefaultExpiry:          RoleCacheSec * time.Second,
 		InvalidateClusterEvent: model.ClusterEventInvalidateCacheForRolePermissions,
+		NearCache:              true,
 	}); err != nil {
 		return
 	}
@@ -267,6 +269,7 @@ func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterf
 		Name:                   "ChannelMembersForUser",
 		DefaultExpiry:          AllChannelMembersForUserCacheDuration,
 		InvalidateClusterEvent: model.ClusterEventInvalidateCacheForUser,
+		NearCache:              true,
 	}); err != nil {
 		return
 	}
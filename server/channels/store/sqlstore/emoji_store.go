@@ -6,6 +6,8 @@ package sqlstore
 import (
 	"database/sql"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/pkg/errors"
 
@@ -20,54 +22,207 @@ type SqlEmojiStore struct {
 	*SqlStore
 	metrics einterfaces.MetricsInterface
 
-	emojiSelectQuery sq.SelectBuilder
+	emojiSelectQuery         sq.SelectBuilder
+	emojiCategorySelectQuery sq.SelectBuilder
+}
+
+type emojiAlias struct {
+	Alias   string
+	EmojiId string
 }
 
 func newSqlEmojiStore(sqlStore *SqlStore, metrics einterfaces.MetricsInterface) store.EmojiStore {
 	emojiSelectQuery := sqlStore.getQueryBuilder().
-		Select("Id", "CreateAt", "UpdateAt", "DeleteAt", "CreatorId", "Name").
+		Select("Id", "CreateAt", "UpdateAt", "DeleteAt", "CreatorId", "Name", "CategoryId").
 		From("Emoji").
 		Where(sq.Eq{"DeleteAt": 0})
 
+	emojiCategorySelectQuery := sqlStore.getQueryBuilder().
+		Select("Id", "Name", "DisplayName", "CreateAt", "UpdateAt").
+		From("EmojiCategories")
+
 	return &SqlEmojiStore{
-		SqlStore:         sqlStore,
-		metrics:          metrics,
-		emojiSelectQuery: emojiSelectQuery,
+		SqlStore:                 sqlStore,
+		metrics:                  metrics,
+		emojiSelectQuery:         emojiSelectQuery,
+		emojiCategorySelectQuery: emojiCategorySelectQuery,
 	}
 }
 
-func (es SqlEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+func (es SqlEmojiStore) Save(emoji *model.Emoji) (_ *model.Emoji, err error) {
 	emoji.PreSave()
-	if err := emoji.IsValid(); err != nil {
-		return nil, err
+	if appErr := emoji.IsValid(); appErr != nil {
+		return nil, appErr
 	}
 
-	if _, err := es.GetMaster().NamedExec(`INSERT INTO Emoji
-		(Id, CreateAt, UpdateAt, DeleteAt, CreatorId, Name)
+	transaction, err := es.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	if _, err = transaction.NamedExec(`INSERT INTO Emoji
+		(Id, CreateAt, UpdateAt, DeleteAt, CreatorId, Name, CategoryId)
 		VALUES
-		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :CreatorId, :Name)`, emoji); err != nil {
+		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :CreatorId, :Name, :CategoryId)`, emoji); err != nil {
 		return nil, errors.Wrap(err, "error saving emoji")
 	}
 
+	if err = es.saveAliasesT(transaction, emoji); err != nil {
+		return nil, err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return emoji, nil
+}
+
+// Update updates the category and replaces the aliases of the emoji.
+func (es SqlEmojiStore) Update(emoji *model.Emoji) (_ *model.Emoji, err error) {
+	emoji.PreUpdate()
+	if appErr := emoji.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	transaction, err := es.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	query := es.getQueryBuilder().
+		Update("Emoji").
+		Set("CategoryId", emoji.CategoryId).
+		Set("UpdateAt", emoji.UpdateAt).
+		Where(sq.Eq{"Id": emoji.Id, "DeleteAt": 0})
+
+	result, err := transaction.ExecBuilder(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update emoji with id=%s", emoji.Id)
+	}
+	if rows, rowsErr := result.RowsAffected(); rowsErr == nil && rows == 0 {
+		return nil, store.NewErrNotFound("Emoji", emoji.Id)
+	}
+
+	if _, err = transaction.ExecBuilder(es.getQueryBuilder().Delete("EmojiAliases").Where(sq.Eq{"EmojiId": emoji.Id})); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete aliases of emoji with id=%s", emoji.Id)
+	}
+
+	if err = es.saveAliasesT(transaction, emoji); err != nil {
+		return nil, err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
 	return emoji, nil
 }
 
+func (es SqlEmojiStore) saveAliasesT(transaction *sqlxTxWrapper, emoji *model.Emoji) error {
+	if len(emoji.Aliases) == 0 {
+		return nil
+	}
+
+	query := es.getQueryBuilder().
+		Insert("EmojiAliases").
+		Columns("Alias", "EmojiId")
+	for _, alias := range emoji.Aliases {
+		query = query.Values(alias, emoji.Id)
+	}
+
+	if _, err := transaction.ExecBuilder(query); err != nil {
+		if IsUniqueConstraintError(err, []string{"PRIMARY", "emojialiases_pkey"}) {
+			return store.NewErrConflict("EmojiAliases", err, "emoji_id="+emoji.Id)
+		}
+		return errors.Wrapf(err, "failed to save aliases of emoji with id=%s", emoji.Id)
+	}
+
+	return nil
+}
+
+// populateAliases sets the aliases of the given emojis.
+func (es SqlEmojiStore) populateAliases(db sqlxExecutor, emojis []*model.Emoji) error {
+	if len(emojis) == 0 {
+		return nil
+	}
+
+	emojiByID := make(map[string]*model.Emoji, len(emojis))
+	for _, emoji := range emojis {
+		emoji.Aliases = nil
+		emojiByID[emoji.Id] = emoji
+	}
+
+	query := es.getQueryBuilder().
+		Select("Alias", "EmojiId").
+		From("EmojiAliases").
+		Where(sq.Eq{"EmojiId": slices.Collect(maps.Keys(emojiByID))}).
+		OrderBy("Alias")
+
+	aliases := []*emojiAlias{}
+	if err := db.SelectBuilder(&aliases, query); err != nil {
+		return errors.Wrap(err, "could not get emoji aliases")
+	}
+
+	for _, alias := range aliases {
+		if emoji, ok := emojiByID[alias.EmojiId]; ok {
+			emoji.Aliases = append(emoji.Aliases, alias.Alias)
+		}
+	}
+
+	return nil
+}
+
 func (es SqlEmojiStore) Get(rctx request.CTX, id string, allowFromCache bool) (*model.Emoji, error) {
 	return es.getBy(rctx, "Id", id)
 }
 
+// GetByName returns the emoji having the given name, either as its name or as one of its aliases.
 func (es SqlEmojiStore) GetByName(rctx request.CTX, name string, allowFromCache bool) (*model.Emoji, error) {
-	return es.getBy(rctx, "Name", name)
+	aliasSQL, aliasArgs, err := es.getQueryBuilder().
+		Select("EmojiId").
+		From("EmojiAliases").
+		Where(sq.Eq{"Alias": name}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "emoji_alias_tosql")
+	}
+
+	return es.getByCondition(rctx, sq.Or{
+		sq.Eq{"Name": name},
+		sq.Expr("Id IN ("+aliasSQL+")", aliasArgs...),
+	}, "Name="+name)
 }
 
+// GetMultipleByName returns the emojis having one of the given names, either as their name or as
+// one of their aliases.
 func (es SqlEmojiStore) GetMultipleByName(rctx request.CTX, names []string) ([]*model.Emoji, error) {
-	query := es.emojiSelectQuery.Where(sq.Eq{"Name": names})
+	aliasQuery := es.getQueryBuilder().
+		Select("EmojiId").
+		From("EmojiAliases").
+		Where(sq.Eq{"Alias": names})
+	aliasSQL, aliasArgs, err := aliasQuery.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "emoji_alias_tosql")
+	}
 
+	query := es.emojiSelectQuery.Where(sq.Or{
+		sq.Eq{"Name": names},
+		sq.Expr("Id IN ("+aliasSQL+")", aliasArgs...),
+	})
+
+	db := es.DBXFromContext(rctx.Context())
 	emojis := []*model.Emoji{}
-	if err := es.DBXFromContext(rctx.Context()).SelectBuilder(&emojis, query); err != nil {
+	if err := db.SelectBuilder(&emojis, query); err != nil {
 		return nil, errors.Wrapf(err, "error getting emojis by names %v", names)
 	}
 
+	if err := es.populateAliases(db, emojis); err != nil {
+		return nil, err
+	}
+
 	return emojis, nil
 }
 
@@ -84,6 +239,11 @@ func (es SqlEmojiStore) GetList(offset, limit int, sort string) ([]*model.Emoji,
 	if err := es.GetReplica().SelectBuilder(&emojis, query); err != nil {
 		return nil, errors.Wrap(err, "could not get list of emojis")
 	}
+
+	if err := es.populateAliases(es.GetReplica(), emojis); err != nil {
+		return nil, err
+	}
+
 	return emojis, nil
 }
 
@@ -102,6 +262,11 @@ func (es SqlEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 		return store.NewErrNotFound("Emoji", emoji.Id).Wrap(err)
 	}
 
+	// The aliases of deleted emojis are freed for other emojis.
+	if _, err := es.GetMaster().ExecBuilder(es.getQueryBuilder().Delete("EmojiAliases").Where(sq.Eq{"EmojiId": emoji.Id})); err != nil {
+		return errors.Wrapf(err, "could not delete aliases of emoji with id=%s", emoji.Id)
+	}
+
 	return nil
 }
 
@@ -116,30 +281,140 @@ func (es SqlEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*mode
 	}
 	term += name + "%"
 
+	aliasSQL, aliasArgs, err := es.getQueryBuilder().
+		Select("EmojiId").
+		From("EmojiAliases").
+		Where(sq.Like{"Alias": term}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "emoji_alias_tosql")
+	}
+
 	query := es.emojiSelectQuery.
-		Where(sq.Like{"Name": term}).
+		Where(sq.Or{
+			sq.Like{"Name": term},
+			sq.Expr("Id IN ("+aliasSQL+")", aliasArgs...),
+		}).
 		OrderBy("Name").
 		Limit(uint64(limit))
 
 	if err := es.GetReplica().SelectBuilder(&emojis, query); err != nil {
 		return nil, errors.Wrapf(err, "could not search emojis by name %s", name)
 	}
+
+	if err := es.populateAliases(es.GetReplica(), emojis); err != nil {
+		return nil, err
+	}
+
 	return emojis, nil
 }
 
 // getBy returns one active (not deleted) emoji, found by any one column (what/key).
 func (es SqlEmojiStore) getBy(rctx request.CTX, what, key string) (*model.Emoji, error) {
+	return es.getByCondition(rctx, sq.Eq{what: key}, fmt.Sprintf("%s=%s", what, key))
+}
+
+func (es SqlEmojiStore) getByCondition(rctx request.CTX, condition sq.Sqlizer, description string) (*model.Emoji, error) {
 	var emoji model.Emoji
 
-	query := es.emojiSelectQuery.Where(sq.Eq{what: key})
+	query := es.emojiSelectQuery.Where(condition)
 
-	err := es.DBXFromContext(rctx.Context()).GetBuilder(&emoji, query)
+	db := es.DBXFromContext(rctx.Context())
+	err := db.GetBuilder(&emoji, query)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, store.NewErrNotFound("Emoji", fmt.Sprintf("%s=%s", what, key))
+			return nil, store.NewErrNotFound("Emoji", description)
 		}
-		return nil, errors.Wrapf(err, "could not get emoji by %s with value %s", what, key)
+		return nil, errors.Wrapf(err, "could not get emoji by %s", description)
+	}
+
+	if err := es.populateAliases(db, []*model.Emoji{&emoji}); err != nil {
+		return nil, err
 	}
 
 	return &emoji, nil
 }
+
+func (es SqlEmojiStore) SaveCategory(category *model.EmojiCategory) (*model.EmojiCategory, error) {
+	category.PreSave()
+	if appErr := category.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	query := es.getQueryBuilder().
+		Insert("EmojiCategories").
+		Columns("Id", "Name", "DisplayName", "CreateAt", "UpdateAt").
+		Values(category.Id, category.Name, category.DisplayName, category.CreateAt, category.UpdateAt)
+
+	if _, err := es.GetMaster().ExecBuilder(query); err != nil {
+		if IsUniqueConstraintError(err, []string{"Name", "emojicategories_name_key"}) {
+			return nil, store.NewErrConflict("EmojiCategories", err, "name="+category.Name)
+		}
+		return nil, errors.Wrap(err, "error saving emoji category")
+	}
+
+	return category, nil
+}
+
+func (es SqlEmojiStore) GetCategory(id string) (*model.EmojiCategory, error) {
+	return es.getCategoryBy("Id", id)
+}
+
+func (es SqlEmojiStore) GetCategoryByName(name string) (*model.EmojiCategory, error) {
+	return es.getCategoryBy("Name", name)
+}
+
+func (es SqlEmojiStore) getCategoryBy(what, key string) (*model.EmojiCategory, error) {
+	var category model.EmojiCategory
+
+	if err := es.GetReplica().GetBuilder(&category, es.emojiCategorySelectQuery.Where(sq.Eq{what: key})); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("EmojiCategory", fmt.Sprintf("%s=%s", what, key))
+		}
+		return nil, errors.Wrapf(err, "could not get emoji category by %s with value %s", what, key)
+	}
+
+	return &category, nil
+}
+
+func (es SqlEmojiStore) GetAllCategories() ([]*model.EmojiCategory, error) {
+	categories := []*model.EmojiCategory{}
+
+	if err := es.GetReplica().SelectBuilder(&categories, es.emojiCategorySelectQuery.OrderBy("Name")); err != nil {
+		return nil, errors.Wrap(err, "could not get emoji categories")
+	}
+
+	return categories, nil
+}
+
+// DeleteCategory deletes the category, leaving its emojis uncategorized.
+func (es SqlEmojiStore) DeleteCategory(id string) (err error) {
+	transaction, err := es.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	result, err := transaction.ExecBuilder(es.getQueryBuilder().Delete("EmojiCategories").Where(sq.Eq{"Id": id}))
+	if err != nil {
+		return errors.Wrapf(err, "could not delete emoji category with id=%s", id)
+	}
+	if rows, rowsErr := result.RowsAffected(); rowsErr == nil && rows == 0 {
+		return store.NewErrNotFound("EmojiCategory", id)
+	}
+
+	query := es.getQueryBuilder().
+		Update("Emoji").
+		Set("CategoryId", "").
+		Set("UpdateAt", model.GetMillis()).
+		Where(sq.Eq{"CategoryId": id})
+	if _, err = transaction.ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "could not uncategorize the emojis of category with id=%s", id)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
@@ -71,6 +71,7 @@ func newSqlGroupStore(sqlStore *SqlStore) store.GroupStore {
 			"UserGroups.UpdateAt",
 			"UserGroups.DeleteAt",
 			"UserGroups.AllowReference",
+			"UserGroups.MembershipRule",
 		).
 		From("UserGroups")
 
@@ -126,9 +127,9 @@ func (s *SqlGroupStore) Create(group *model.Group) (*model.Group, error) {
 	group.UpdateAt = group.CreateAt
 
 	if _, err := s.GetMaster().NamedExec(`INSERT INTO UserGroups
-		(Id, Name, DisplayName, Description, Source, RemoteId, CreateAt, UpdateAt, DeleteAt, AllowReference)
+		(Id, Name, DisplayName, Description, Source, RemoteId, CreateAt, UpdateAt, DeleteAt, AllowReference, MembershipRule)
 		VALUES
-		(:Id, :Name, :DisplayName, :Description, :Source, :RemoteId, :CreateAt, :UpdateAt, :DeleteAt, :AllowReference)`, group); err != nil {
+		(:Id, :Name, :DisplayName, :Description, :Source, :RemoteId, :CreateAt, :UpdateAt, :DeleteAt, :AllowReference, :MembershipRule)`, group); err != nil {
 		if IsUniqueConstraintError(err, []string{"Name", "groups_name_key"}) {
 			return nil, errors.Wrapf(err, "Group with name %s already exists", *group.Name)
 		}
@@ -159,8 +160,8 @@ func (s *SqlGroupStore) CreateWithUserIds(g *model.GroupWithUserIds) (_ *model.G
 
 	groupInsertQuery, groupInsertArgs, err := s.getQueryBuilder().
 		Insert("UserGroups").
-		Columns("Id", "Name", "DisplayName", "Description", "Source", "RemoteId", "CreateAt", "UpdateAt", "DeleteAt", "AllowReference").
-		Values(g.Id, g.Name, g.DisplayName, g.Description, g.Source, g.RemoteId, g.CreateAt, g.UpdateAt, 0, g.AllowReference).
+		Columns("Id", "Name", "DisplayName", "Description", "Source", "RemoteId", "CreateAt", "UpdateAt", "DeleteAt", "AllowReference", "MembershipRule").
+		Values(g.Id, g.Name, g.DisplayName, g.Description, g.Source, g.RemoteId, g.CreateAt, g.UpdateAt, 0, g.AllowReference, g.MembershipRule).
 		ToSql()
 	if err != nil {
 		return nil, err
@@ -395,7 +396,8 @@ func (s *SqlGroupStore) Update(group *model.Group) (*model.Group, error) {
 
 	res, err := s.GetMaster().NamedExec(`UPDATE UserGroups
 		SET Name=:Name, DisplayName=:DisplayName, Description=:Description, Source=:Source,
-		RemoteId=:RemoteId, CreateAt=:CreateAt, UpdateAt=:UpdateAt, DeleteAt=:DeleteAt, AllowReference=:AllowReference
+		RemoteId=:RemoteId, CreateAt=:CreateAt, UpdateAt=:UpdateAt, DeleteAt=:DeleteAt, AllowReference=:AllowReference,
+		MembershipRule=:MembershipRule
 		WHERE Id=:Id`, group)
 	if err != nil {
 		if IsUniqueConstraintError(err, []string{"Name", "groups_name_key"}) {
@@ -1137,6 +1139,7 @@ type group struct {
 	AllowReference              bool
 	ChannelMemberCount          *int
 	ChannelMemberTimezonesCount *int
+	MembershipRule              string
 }
 
 func (g group) ToModel() *model.Group {
@@ -1155,6 +1158,7 @@ func (g group) ToModel() *model.Group {
 		MemberCount:                 g.MemberCount,
 		ChannelMemberCount:          g.ChannelMemberCount,
 		ChannelMemberTimezonesCount: g.ChannelMemberTimezonesCount,
+		MembershipRule:              g.MembershipRule,
 	}
 }
 
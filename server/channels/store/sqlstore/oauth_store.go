@@ -19,6 +19,7 @@ type SqlOAuthStore struct {
 	oAuthAppsSelectQuery sq.SelectBuilder
 	oAuthAccessDataQuery sq.SelectBuilder
 	oAuthAuthDataQuery   sq.SelectBuilder
+	oAuthDeviceQuery     sq.SelectBuilder
 }
 
 func newSqlOAuthStore(sqlStore *SqlStore) store.OAuthStore {
@@ -36,9 +37,13 @@ func newSqlOAuthStore(sqlStore *SqlStore) store.OAuthStore {
 		From("OAuthAccessData")
 
 	s.oAuthAuthDataQuery = s.getQueryBuilder().
-		Select("ClientId", "UserId", "Code", "ExpiresIn", "CreateAt", "RedirectUri", "State", "Scope", "CodeChallenge", "CodeChallengeMethod", "Resource").
+		Select("ClientId", "UserId", "Code", "ExpiresIn", "CreateAt", "RedirectUri", "State", "Scope", "CodeChallenge", "CodeChallengeMethod", "Resource", "Nonce").
 		From("OAuthAuthData")
 
+	s.oAuthDeviceQuery = s.getQueryBuilder().
+		Select("DeviceCode", "UserCode", "ClientId", "UserId", "Scope", "Status", "CreateAt", "ExpiresAt", "PollInterval", "LastPolledAt").
+		From("OAuthDeviceAuthorizations")
+
 	return &s
 }
 
@@ -246,7 +251,7 @@ func (as SqlOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.A
 		return nil, err
 	}
 
-	if _, err := as.GetMaster().NamedExec("UPDATE OAuthAccessData SET Token = :Token, ExpiresAt = :ExpiresAt, RefreshToken = :RefreshToken, Audience = :Audience WHERE ClientId = :ClientId AND UserID = :UserId", accessData); err != nil {
+	if _, err := as.GetMaster().NamedExec("UPDATE OAuthAccessData SET Token = :Token, ExpiresAt = :ExpiresAt, RefreshToken = :RefreshToken, Scope = :Scope, Audience = :Audience WHERE ClientId = :ClientId AND UserID = :UserId", accessData); err != nil {
 		return nil, errors.Wrapf(err, "failed to update OAuthAccessData with userId=%s and clientId=%s", accessData.UserId, accessData.ClientId)
 	}
 	return accessData, nil
@@ -273,9 +278,9 @@ func (as SqlOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData,
 	}
 
 	if _, err := as.GetMaster().NamedExec(`INSERT INTO OAuthAuthData
-		(ClientId, UserId, Code, ExpiresIn, CreateAt, RedirectUri, State, Scope, CodeChallenge, CodeChallengeMethod, Resource)
+		(ClientId, UserId, Code, ExpiresIn, CreateAt, RedirectUri, State, Scope, CodeChallenge, CodeChallengeMethod, Resource, Nonce)
 		VALUES
-		(:ClientId, :UserId, :Code, :ExpiresIn, :CreateAt, :RedirectUri, :State, :Scope, :CodeChallenge, :CodeChallengeMethod, :Resource)`, authData); err != nil {
+		(:ClientId, :UserId, :Code, :ExpiresIn, :CreateAt, :RedirectUri, :State, :Scope, :CodeChallenge, :CodeChallengeMethod, :Resource, :Nonce)`, authData); err != nil {
 		return nil, errors.Wrap(err, "failed to save AuthData")
 	}
 	return authData, nil
@@ -368,5 +373,99 @@ func (as SqlOAuthStore) deleteAppExtras(transaction *sqlxTxWrapper, clientId str
 		return errors.Wrapf(err, "failed to delete Preferences with name=%s", clientId)
 	}
 
+	if _, err := transaction.Exec("DELETE FROM OAuthDeviceAuthorizations WHERE ClientId = ?", clientId); err != nil {
+		return errors.Wrapf(err, "failed to delete OAuthDeviceAuthorizations with clientId=%s", clientId)
+	}
+
+	return nil
+}
+
+func (as SqlOAuthStore) SaveDeviceAuthorization(authorization *model.DeviceAuthorization) (*model.DeviceAuthorization, error) {
+	authorization.PreSave()
+	if err := authorization.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := as.GetMaster().NamedExec(`INSERT INTO OAuthDeviceAuthorizations
+		(DeviceCode, UserCode, ClientId, UserId, Scope, Status, CreateAt, ExpiresAt, PollInterval, LastPolledAt)
+		VALUES
+		(:DeviceCode, :UserCode, :ClientId, :UserId, :Scope, :Status, :CreateAt, :ExpiresAt, :PollInterval, :LastPolledAt)`, authorization); err != nil {
+		if IsUniqueConstraintError(err, []string{"UserCode", "idx_oauthdeviceauthorizations_usercode"}) {
+			return nil, store.NewErrConflict("OAuthDeviceAuthorization", err, "user_code")
+		}
+		return nil, errors.Wrap(err, "failed to save OAuthDeviceAuthorization")
+	}
+
+	return authorization, nil
+}
+
+func (as SqlOAuthStore) GetDeviceAuthorization(deviceCode string) (*model.DeviceAuthorization, error) {
+	return as.getDeviceAuthorization(sq.Eq{"DeviceCode": deviceCode})
+}
+
+func (as SqlOAuthStore) GetDeviceAuthorizationByUserCode(userCode string) (*model.DeviceAuthorization, error) {
+	return as.getDeviceAuthorization(sq.Eq{"UserCode": userCode})
+}
+
+func (as SqlOAuthStore) getDeviceAuthorization(where sq.Eq) (*model.DeviceAuthorization, error) {
+	var authorization model.DeviceAuthorization
+	if err := as.GetMaster().GetBuilder(&authorization, as.oAuthDeviceQuery.Where(where)); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("OAuthDeviceAuthorization", fmt.Sprintf("%v", where))
+		}
+		return nil, errors.Wrap(err, "failed to get OAuthDeviceAuthorization")
+	}
+
+	return &authorization, nil
+}
+
+// UpdateDeviceAuthorizationStatus records the decision of the user on a pending authorization. It
+// returns a not found error if the authorization doesn't exist or was already decided.
+func (as SqlOAuthStore) UpdateDeviceAuthorizationStatus(deviceCode, userID, status string) error {
+	result, err := as.GetMaster().Exec(`UPDATE OAuthDeviceAuthorizations SET UserId = ?, Status = ?
+		WHERE DeviceCode = ? AND Status = ?`, userID, status, deviceCode, model.DeviceAuthorizationStatusPending)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update status of OAuthDeviceAuthorization")
+	}
+
+	if rows, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, "failed to get rows affected")
+	} else if rows == 0 {
+		return store.NewErrNotFound("OAuthDeviceAuthorization", "pending")
+	}
+
+	return nil
+}
+
+func (as SqlOAuthStore) UpdateDeviceAuthorizationPoll(deviceCode string, lastPolledAt int64, pollInterval int32) error {
+	if _, err := as.GetMaster().Exec("UPDATE OAuthDeviceAuthorizations SET LastPolledAt = ?, PollInterval = ? WHERE DeviceCode = ?", lastPolledAt, pollInterval, deviceCode); err != nil {
+		return errors.Wrap(err, "failed to update poll time of OAuthDeviceAuthorization")
+	}
+
+	return nil
+}
+
+// RemoveDeviceAuthorization deletes an authorization. It returns a not found error if the
+// authorization was already removed, so that a device code can only be exchanged once.
+func (as SqlOAuthStore) RemoveDeviceAuthorization(deviceCode string) error {
+	result, err := as.GetMaster().Exec("DELETE FROM OAuthDeviceAuthorizations WHERE DeviceCode = ?", deviceCode)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete OAuthDeviceAuthorization")
+	}
+
+	if rows, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, "failed to get rows affected")
+	} else if rows == 0 {
+		return store.NewErrNotFound("OAuthDeviceAuthorization", "device_code")
+	}
+
+	return nil
+}
+
+func (as SqlOAuthStore) RemoveExpiredDeviceAuthorizations(expiredBefore int64) error {
+	if _, err := as.GetMaster().Exec("DELETE FROM OAuthDeviceAuthorizations WHERE ExpiresAt < ?", expiredBefore); err != nil {
+		return errors.Wrap(err, "failed to delete expired OAuthDeviceAuthorizations")
+	}
+
 	return nil
 }
@@ -0,0 +1,200 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"strings"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlOutgoingEmailStore struct {
+	*SqlStore
+
+	outgoingEmailSelectColumns []string
+	outgoingEmailQuery         sq.SelectBuilder
+}
+
+func newSqlOutgoingEmailStore(sqlStore *SqlStore) store.OutgoingEmailStore {
+	s := &SqlOutgoingEmailStore{
+		SqlStore: sqlStore,
+		// To and References are reserved words, so they are stored under
+		// different column names and aliased back when selecting.
+		outgoingEmailSelectColumns: []string{
+			"Id",
+			"CreateAt",
+			"UpdateAt",
+			`ToAddress AS "to"`,
+			"Cc",
+			"ReplyTo",
+			"Subject",
+			"HTMLBody",
+			"MessageId",
+			"InReplyTo",
+			`ReferencesHeader AS "references"`,
+			"Category",
+			"Status",
+			"Attempts",
+			"NextAttemptAt",
+			"LastError",
+		},
+	}
+
+	s.outgoingEmailQuery = s.getQueryBuilder().
+		Select(s.outgoingEmailSelectColumns...).
+		From("OutgoingEmails")
+
+	return s
+}
+
+func (s *SqlOutgoingEmailStore) Save(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
+	email.PreSave()
+	if err := email.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("OutgoingEmails").
+		Columns("Id", "CreateAt", "UpdateAt", "ToAddress", "Cc", "ReplyTo", "Subject", "HTMLBody", "MessageId", "InReplyTo", "ReferencesHeader", "Category", "Status", "Attempts", "NextAttemptAt", "LastError").
+		Values(email.Id, email.CreateAt, email.UpdateAt, email.To, email.Cc, email.ReplyTo, email.Subject, email.HTMLBody, email.MessageId, email.InReplyTo, email.References, email.Category, email.Status, email.Attempts, email.NextAttemptAt, email.LastError)
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return nil, errors.Wrap(err, "failed to save OutgoingEmail")
+	}
+
+	return email, nil
+}
+
+func (s *SqlOutgoingEmailStore) Get(id string) (*model.OutgoingEmail, error) {
+	var email model.OutgoingEmail
+	if err := s.GetMaster().GetBuilder(&email, s.outgoingEmailQuery.Where(sq.Eq{"Id": id})); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, store.NewErrNotFound("OutgoingEmail", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get OutgoingEmail with id=%s", id)
+	}
+
+	return &email, nil
+}
+
+func (s *SqlOutgoingEmailStore) Update(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
+	email.PreUpdate()
+	if err := email.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Update("OutgoingEmails").
+		Set("UpdateAt", email.UpdateAt).
+		Set("Status", email.Status).
+		Set("Attempts", email.Attempts).
+		Set("NextAttemptAt", email.NextAttemptAt).
+		Set("LastError", email.LastError).
+		Where(sq.Eq{"Id": email.Id})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update OutgoingEmail with id=%s", email.Id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get rows affected")
+	}
+	if count == 0 {
+		return nil, store.NewErrNotFound("OutgoingEmail", email.Id)
+	}
+
+	return email, nil
+}
+
+func (s *SqlOutgoingEmailStore) Delete(id string) error {
+	query := s.getQueryBuilder().
+		Delete("OutgoingEmails").
+		Where(sq.Eq{"Id": id})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete OutgoingEmail with id=%s", id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get rows affected")
+	}
+	if count == 0 {
+		return store.NewErrNotFound("OutgoingEmail", id)
+	}
+
+	return nil
+}
+
+func (s *SqlOutgoingEmailStore) ClaimPending(now int64, leaseMillis int64, limit int) ([]*model.OutgoingEmail, error) {
+	// Lock the due rows, skipping the ones another node is already claiming, and
+	// push their next attempt past the lease so they are not picked up again
+	// while being delivered.
+	due := s.getQueryBuilder().
+		Select("Id").
+		From("OutgoingEmails").
+		Where(sq.And{
+			sq.Eq{"Status": model.OutgoingEmailStatusPending},
+			sq.LtOrEq{"NextAttemptAt": now},
+		}).
+		OrderBy("NextAttemptAt ASC").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	dueSQL, dueArgs, err := due.PlaceholderFormat(sq.Question).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "claim_pending_outgoing_emails_tosql")
+	}
+
+	query := s.getQueryBuilder().
+		Update("OutgoingEmails").
+		Set("NextAttemptAt", now+leaseMillis).
+		Set("UpdateAt", now).
+		Where(sq.Expr("Id IN ("+dueSQL+")", dueArgs...)).
+		Suffix("RETURNING " + strings.Join(s.outgoingEmailSelectColumns, ", "))
+
+	emails := []*model.OutgoingEmail{}
+	if err := s.GetMaster().SelectBuilder(&emails, query); err != nil {
+		return nil, errors.Wrap(err, "failed to claim pending OutgoingEmails")
+	}
+
+	return emails, nil
+}
+
+func (s *SqlOutgoingEmailStore) GetByStatus(status string, offset, limit int) ([]*model.OutgoingEmail, error) {
+	query := s.outgoingEmailQuery.
+		Where(sq.Eq{"Status": status}).
+		OrderBy("CreateAt ASC", "Id ASC").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+
+	emails := []*model.OutgoingEmail{}
+	if err := s.GetReplica().SelectBuilder(&emails, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get OutgoingEmails with status=%s", status)
+	}
+
+	return emails, nil
+}
+
+func (s *SqlOutgoingEmailStore) CountByStatus(status string) (int64, error) {
+	query := s.getQueryBuilder().
+		Select("COUNT(*)").
+		From("OutgoingEmails").
+		Where(sq.Eq{"Status": status})
+
+	var count int64
+	if err := s.GetReplica().GetBuilder(&count, query); err != nil {
+		return 0, errors.Wrapf(err, "failed to count OutgoingEmails with status=%s", status)
+	}
+
+	return count, nil
+}
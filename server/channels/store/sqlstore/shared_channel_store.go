@@ -981,3 +981,244 @@ func (s SqlSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syncTime in
 	}
 	return nil
 }
+
+func sharedChannelContentPolicyFields(prefix string) []string {
+	if prefix != "" && !strings.HasSuffix(prefix, ".") {
+		prefix = prefix + "."
+	}
+	return []string{
+		prefix + "RemoteId",
+		prefix + "BlockedFileTypes",
+		prefix + "MaxFileSize",
+		prefix + "RedactPatterns",
+		prefix + "RedactReplacement",
+		prefix + "StripPermalinks",
+		prefix + "RequireApproval",
+		prefix + "CreatorId",
+		prefix + "CreateAt",
+		prefix + "UpdateAt",
+	}
+}
+
+// SaveContentPolicy inserts or replaces the content policy for a remote cluster.
+func (s SqlSharedChannelStore) SaveContentPolicy(policy *model.SharedChannelContentPolicy) (*model.SharedChannelContentPolicy, error) {
+	policy.PreSave()
+	if err := policy.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().Insert("SharedChannelContentPolicies").
+		Columns(sharedChannelContentPolicyFields("")...).
+		Values(policy.RemoteId, policy.BlockedFileTypes, policy.MaxFileSize, policy.RedactPatterns, policy.RedactReplacement,
+			policy.StripPermalinks, policy.RequireApproval, policy.CreatorId, policy.CreateAt, policy.UpdateAt).
+		SuffixExpr(sq.Expr("ON CONFLICT (RemoteId) DO UPDATE SET BlockedFileTypes = ?, MaxFileSize = ?, RedactPatterns = ?, RedactReplacement = ?, StripPermalinks = ?, RequireApproval = ?, UpdateAt = ?",
+			policy.BlockedFileTypes, policy.MaxFileSize, policy.RedactPatterns, policy.RedactReplacement, policy.StripPermalinks, policy.RequireApproval, policy.UpdateAt)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "save_shared_channel_content_policy_tosql")
+	}
+
+	if _, err := s.GetMaster().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save SharedChannelContentPolicy with RemoteId=%s", policy.RemoteId)
+	}
+	return s.getContentPolicy(s.GetMaster(), policy.RemoteId)
+}
+
+// GetContentPolicy fetches the content policy for a remote cluster.
+func (s SqlSharedChannelStore) GetContentPolicy(remoteID string) (*model.SharedChannelContentPolicy, error) {
+	return s.getContentPolicy(s.GetReplica(), remoteID)
+}
+
+func (s SqlSharedChannelStore) getContentPolicy(db *sqlxDBWrapper, remoteID string) (*model.SharedChannelContentPolicy, error) {
+	var policy model.SharedChannelContentPolicy
+
+	query, args, err := s.getQueryBuilder().
+		Select(sharedChannelContentPolicyFields("")...).
+		From("SharedChannelContentPolicies").
+		Where(sq.Eq{"RemoteId": remoteID}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "get_shared_channel_content_policy_tosql")
+	}
+
+	if err := db.Get(&policy, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("SharedChannelContentPolicy", remoteID)
+		}
+		return nil, errors.Wrapf(err, "failed to find SharedChannelContentPolicy with RemoteId=%s", remoteID)
+	}
+	return &policy, nil
+}
+
+// DeleteContentPolicy removes the content policy for a remote cluster.
+// Returns true if a policy was found and deleted.
+func (s SqlSharedChannelStore) DeleteContentPolicy(remoteID string) (bool, error) {
+	query, args, err := s.getQueryBuilder().
+		Delete("SharedChannelContentPolicies").
+		Where(sq.Eq{"RemoteId": remoteID}).
+		ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "delete_shared_channel_content_policy_tosql")
+	}
+
+	result, err := s.GetMaster().Exec(query, args...)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete SharedChannelContentPolicy")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to determine rows affected")
+	}
+	return count > 0, nil
+}
+
+func sharedChannelHeldPostFields(prefix string) []string {
+	if prefix != "" && !strings.HasSuffix(prefix, ".") {
+		prefix = prefix + "."
+	}
+	return []string{
+		prefix + "PostId",
+		prefix + "RemoteId",
+		prefix + "ChannelId",
+		prefix + "UserId",
+		prefix + "Status",
+		prefix + "ReviewerId",
+		prefix + "CreateAt",
+		prefix + "ReviewedAt",
+	}
+}
+
+// SaveHeldPost inserts a held post record or updates the status of an existing one.
+func (s SqlSharedChannelStore) SaveHeldPost(held *model.SharedChannelHeldPost) (*model.SharedChannelHeldPost, error) {
+	held.PreSave()
+	if err := held.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().Insert("SharedChannelHeldPosts").
+		Columns(sharedChannelHeldPostFields("")...).
+		Values(held.PostId, held.RemoteId, held.ChannelId, held.UserId, held.Status, held.ReviewerId, held.CreateAt, held.ReviewedAt).
+		SuffixExpr(sq.Expr("ON CONFLICT (PostId, RemoteId) DO UPDATE SET Status = ?, ReviewerId = ?, ReviewedAt = ?",
+			held.Status, held.ReviewerId, held.ReviewedAt)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "save_shared_channel_held_post_tosql")
+	}
+
+	if _, err := s.GetMaster().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save SharedChannelHeldPost with PostId=%s, RemoteId=%s", held.PostId, held.RemoteId)
+	}
+	return held, nil
+}
+
+// GetHeldPost fetches the held post record for a post and remote cluster.
+func (s SqlSharedChannelStore) GetHeldPost(postID string, remoteID string) (*model.SharedChannelHeldPost, error) {
+	var held model.SharedChannelHeldPost
+
+	query, args, err := s.getQueryBuilder().
+		Select(sharedChannelHeldPostFields("")...).
+		From("SharedChannelHeldPosts").
+		Where(sq.Eq{"PostId": postID}).
+		Where(sq.Eq{"RemoteId": remoteID}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "get_shared_channel_held_post_tosql")
+	}
+
+	if err := s.GetMaster().Get(&held, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("SharedChannelHeldPost", postID)
+		}
+		return nil, errors.Wrapf(err, "failed to find SharedChannelHeldPost with PostId=%s, RemoteId=%s", postID, remoteID)
+	}
+	return &held, nil
+}
+
+// GetHeldPosts returns the held post records for a remote cluster, oldest first. An empty
+// status returns records of any status.
+func (s SqlSharedChannelStore) GetHeldPosts(remoteID string, status string, offset, limit int) ([]*model.SharedChannelHeldPost, error) {
+	held := []*model.SharedChannelHeldPost{}
+
+	query := s.getQueryBuilder().
+		Select(sharedChannelHeldPostFields("")...).
+		From("SharedChannelHeldPosts").
+		Where(sq.Eq{"RemoteId": remoteID}).
+		OrderBy("CreateAt ASC", "PostId ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	if status != "" {
+		query = query.Where(sq.Eq{"Status": status})
+	}
+
+	squery, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "get_shared_channel_held_posts_tosql")
+	}
+
+	if err := s.GetReplica().Select(&held, squery, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get SharedChannelHeldPosts with RemoteId=%s", remoteID)
+	}
+	return held, nil
+}
+
+func sharedChannelFilterLogFields(prefix string) []string {
+	if prefix != "" && !strings.HasSuffix(prefix, ".") {
+		prefix = prefix + "."
+	}
+	return []string{
+		prefix + "Id",
+		prefix + "RemoteId",
+		prefix + "ChannelId",
+		prefix + "PostId",
+		prefix + "FileId",
+		prefix + "UserId",
+		prefix + "Action",
+		prefix + "Details",
+		prefix + "CreateAt",
+	}
+}
+
+// SaveFilterLogEntry records an action taken by a shared channel content policy.
+func (s SqlSharedChannelStore) SaveFilterLogEntry(entry *model.SharedChannelFilterLogEntry) (*model.SharedChannelFilterLogEntry, error) {
+	entry.PreSave()
+	if err := entry.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().Insert("SharedChannelFilterLog").
+		Columns(sharedChannelFilterLogFields("")...).
+		Values(entry.Id, entry.RemoteId, entry.ChannelId, entry.PostId, entry.FileId, entry.UserId, entry.Action, entry.Details, entry.CreateAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "save_shared_channel_filter_log_entry_tosql")
+	}
+
+	if _, err := s.GetMaster().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save SharedChannelFilterLog entry with RemoteId=%s", entry.RemoteId)
+	}
+	return entry, nil
+}
+
+// GetFilterLog returns the content policy actions taken for a remote cluster, newest first.
+func (s SqlSharedChannelStore) GetFilterLog(remoteID string, offset, limit int) ([]*model.SharedChannelFilterLogEntry, error) {
+	entries := []*model.SharedChannelFilterLogEntry{}
+
+	query, args, err := s.getQueryBuilder().
+		Select(sharedChannelFilterLogFields("")...).
+		From("SharedChannelFilterLog").
+		Where(sq.Eq{"RemoteId": remoteID}).
+		OrderBy("CreateAt DESC", "Id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "get_shared_channel_filter_log_tosql")
+	}
+
+	if err := s.GetReplica().Select(&entries, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get SharedChannelFilterLog with RemoteId=%s", remoteID)
+	}
+	return entries, nil
+}
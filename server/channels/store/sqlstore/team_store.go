@@ -25,8 +25,9 @@ const (
 type SqlTeamStore struct {
 	*SqlStore
 
-	teamsQuery       sq.SelectBuilder
-	teamMembersQuery sq.SelectBuilder
+	teamsQuery           sq.SelectBuilder
+	teamMembersQuery     sq.SelectBuilder
+	teamInviteLinksQuery sq.SelectBuilder
 }
 
 type teamMember struct {
@@ -251,6 +252,11 @@ func newSqlTeamStore(sqlStore *SqlStore) store.TeamStore {
 			"TeamMembers.CreateAt",
 		).
 		From("TeamMembers")
+
+	s.teamInviteLinksQuery = s.getQueryBuilder().
+		Select("Id", "TeamId", "CreatorId", "Name", "Role", "ChannelIds", "CreateAt", "UpdateAt", "DeleteAt", "ExpiresAt", "MaxUses", "UseCount").
+		From("TeamInviteLinks")
+
 	return s
 }
 
@@ -700,6 +706,13 @@ func (s SqlTeamStore) PermanentDelete(teamId string) error {
 	if _, err = s.GetMaster().Exec(sql, args...); err != nil {
 		return errors.Wrapf(err, "failed to delete Team with id=%s", teamId)
 	}
+
+	if _, err = s.GetMaster().Exec("DELETE FROM TeamInviteLinkUses WHERE LinkId IN (SELECT Id FROM TeamInviteLinks WHERE TeamId = ?)", teamId); err != nil {
+		return errors.Wrapf(err, "failed to delete TeamInviteLinkUses with teamId=%s", teamId)
+	}
+	if _, err = s.GetMaster().Exec("DELETE FROM TeamInviteLinks WHERE TeamId = ?", teamId); err != nil {
+		return errors.Wrapf(err, "failed to delete TeamInviteLinks with teamId=%s", teamId)
+	}
 	return nil
 }
 
@@ -1758,3 +1771,128 @@ func (s SqlTeamStore) GroupSyncedTeamCount() (int64, error) {
 
 	return count, nil
 }
+
+func (s SqlTeamStore) SaveInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
+	link.PreSave()
+	if err := link.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMaster().NamedExec(`INSERT INTO TeamInviteLinks
+		(Id, TeamId, CreatorId, Name, Role, ChannelIds, CreateAt, UpdateAt, DeleteAt, ExpiresAt, MaxUses, UseCount)
+		VALUES
+		(:Id, :TeamId, :CreatorId, :Name, :Role, :ChannelIds, :CreateAt, :UpdateAt, :DeleteAt, :ExpiresAt, :MaxUses, :UseCount)`, link); err != nil {
+		return nil, errors.Wrapf(err, "failed to save TeamInviteLink with teamId=%s", link.TeamId)
+	}
+
+	return link, nil
+}
+
+// UpdateInviteLink updates the settings and the deletion time of a link. The use count is only
+// changed by ClaimInviteLinkUse and ReleaseInviteLinkUse.
+func (s SqlTeamStore) UpdateInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
+	link.PreUpdate()
+	if err := link.IsValid(); err != nil {
+		return nil, err
+	}
+
+	result, err := s.GetMaster().NamedExec(`UPDATE TeamInviteLinks
+		SET Name = :Name, ChannelIds = :ChannelIds, UpdateAt = :UpdateAt, DeleteAt = :DeleteAt, ExpiresAt = :ExpiresAt, MaxUses = :MaxUses
+		WHERE Id = :Id`, link)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update TeamInviteLink with id=%s", link.Id)
+	}
+
+	if rows, err := result.RowsAffected(); err != nil {
+		return nil, errors.Wrap(err, "failed to get rows affected")
+	} else if rows == 0 {
+		return nil, store.NewErrNotFound("TeamInviteLink", link.Id)
+	}
+
+	return link, nil
+}
+
+func (s SqlTeamStore) GetInviteLink(id string) (*model.TeamInviteLink, error) {
+	query := s.teamInviteLinksQuery.Where(sq.Eq{"Id": id})
+
+	var link model.TeamInviteLink
+	if err := s.GetReplica().GetBuilder(&link, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("TeamInviteLink", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get TeamInviteLink with id=%s", id)
+	}
+
+	return &link, nil
+}
+
+func (s SqlTeamStore) GetInviteLinksForTeam(teamID string, includeDeleted bool) ([]*model.TeamInviteLink, error) {
+	query := s.teamInviteLinksQuery.
+		Where(sq.Eq{"TeamId": teamID}).
+		OrderBy("CreateAt DESC")
+	if !includeDeleted {
+		query = query.Where(sq.Eq{"DeleteAt": 0})
+	}
+
+	links := []*model.TeamInviteLink{}
+	if err := s.GetReplica().SelectBuilder(&links, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get TeamInviteLinks with teamId=%s", teamID)
+	}
+
+	return links, nil
+}
+
+// ClaimInviteLinkUse counts a use of the link. It returns a not found error if the link was
+// revoked, has expired, or has reached its maximum number of uses, so that concurrent joins can't
+// use a link more often than allowed.
+func (s SqlTeamStore) ClaimInviteLinkUse(id string, now int64) error {
+	result, err := s.GetMaster().Exec(`UPDATE TeamInviteLinks SET UseCount = UseCount + 1
+		WHERE Id = ? AND DeleteAt = 0 AND (ExpiresAt = 0 OR ExpiresAt > ?) AND (MaxUses = 0 OR UseCount < MaxUses)`, id, now)
+	if err != nil {
+		return errors.Wrapf(err, "failed to claim use of TeamInviteLink with id=%s", id)
+	}
+
+	if rows, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, "failed to get rows affected")
+	} else if rows == 0 {
+		return store.NewErrNotFound("TeamInviteLink", "usable")
+	}
+
+	return nil
+}
+
+// ReleaseInviteLinkUse gives back a use claimed by a join that failed.
+func (s SqlTeamStore) ReleaseInviteLinkUse(id string) error {
+	if _, err := s.GetMaster().Exec("UPDATE TeamInviteLinks SET UseCount = UseCount - 1 WHERE Id = ? AND UseCount > 0", id); err != nil {
+		return errors.Wrapf(err, "failed to release use of TeamInviteLink with id=%s", id)
+	}
+
+	return nil
+}
+
+func (s SqlTeamStore) SaveInviteLinkUse(use *model.TeamInviteLinkUse) error {
+	if _, err := s.GetMaster().NamedExec(`INSERT INTO TeamInviteLinkUses (LinkId, UserId, UseAt)
+		VALUES (:LinkId, :UserId, :UseAt)`, use); err != nil {
+		return errors.Wrapf(err, "failed to save TeamInviteLinkUse with linkId=%s", use.LinkId)
+	}
+
+	return nil
+}
+
+// GetInviteLinkUses returns the uses of a link, the most recent first.
+func (s SqlTeamStore) GetInviteLinkUses(linkID string, offset, limit int) ([]*model.TeamInviteLinkUse, error) {
+	query := s.getQueryBuilder().
+		Select("LinkId", "UserId", "UseAt").
+		From("TeamInviteLinkUses").
+		Where(sq.Eq{"LinkId": linkID}).
+		OrderBy("UseAt DESC").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+
+	uses := []*model.TeamInviteLinkUse{}
+	if err := s.GetReplica().SelectBuilder(&uses, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get TeamInviteLinkUses with linkId=%s", linkID)
+	}
+
+	return uses, nil
+}
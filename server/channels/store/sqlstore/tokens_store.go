@@ -120,3 +120,13 @@ func (s SqlTokenStore) RemoveAllTokensByType(tokenType string) error {
 	}
 	return nil
 }
+
+func (s SqlTokenStore) RemoveTokensByTypeAndUserId(tokenType, userID string) error {
+	// The extra data is only parsed for the tokens of the given type, as the extra data of the
+	// other types isn't necessarily JSON.
+	query := `DELETE FROM Tokens WHERE Type = ? AND CASE WHEN Type = ? THEN Extra::jsonb->>'UserId' = ? ELSE false END`
+	if _, err := s.GetMaster().Exec(query, tokenType, tokenType, userID); err != nil {
+		return errors.Wrapf(err, "failed to remove Tokens with Type=%s and UserId=%s", tokenType, userID)
+	}
+	return nil
+}
@@ -626,6 +626,14 @@ func (us SqlUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.U
 		query = query.Where(sq.Gt{"Users.UpdateAt": options.UpdatedAfter})
 	}
 
+	if options.ExcludeBots {
+		query = query.Where("b.UserId IS NULL")
+	}
+
+	if options.ExcludeRemote {
+		query = query.Where(sq.Or{sq.Eq{"Users.RemoteId": ""}, sq.Eq{"Users.RemoteId": nil}})
+	}
+
 	users := []*model.User{}
 	if err := us.GetReplica().SelectBuilder(&users, query); err != nil {
 		return nil, errors.Wrap(err, "failed to get User profiles")
@@ -1393,6 +1401,9 @@ func (us SqlUserStore) PermanentDelete(rctx request.CTX, userId string) error {
 	if _, err := us.GetMaster().Exec("DELETE FROM Users WHERE Id = ?", userId); err != nil {
 		return errors.Wrapf(err, "failed to delete User with userId=%s", userId)
 	}
+	if _, err := us.GetMaster().Exec("DELETE FROM GuestAccessExpiries WHERE UserId = ?", userId); err != nil {
+		return errors.Wrapf(err, "failed to delete GuestAccessExpiry with userId=%s", userId)
+	}
 	return nil
 }
 
@@ -2526,3 +2537,74 @@ func (us SqlUserStore) GetUserReport(filter *model.UserReportOptions) ([]*model.
 
 	return userResults, nil
 }
+
+func (us SqlUserStore) SaveGuestAccessExpiry(expiry *model.GuestAccessExpiry) (*model.GuestAccessExpiry, error) {
+	expiry.PreSave()
+	expiry.ReminderSentAt = 0
+	if err := expiry.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := us.GetMaster().NamedExec(`INSERT INTO GuestAccessExpiries
+		(UserId, ExpiresAt, ReminderSentAt, CreateAt, UpdateAt)
+		VALUES
+		(:UserId, :ExpiresAt, :ReminderSentAt, :CreateAt, :UpdateAt)
+		ON CONFLICT (UserId) DO UPDATE
+		SET ExpiresAt = :ExpiresAt, ReminderSentAt = :ReminderSentAt, UpdateAt = :UpdateAt`, expiry); err != nil {
+		return nil, errors.Wrapf(err, "failed to save GuestAccessExpiry with userId=%s", expiry.UserId)
+	}
+
+	return expiry, nil
+}
+
+func (us SqlUserStore) GetGuestAccessExpiry(userID string) (*model.GuestAccessExpiry, error) {
+	query := us.getQueryBuilder().
+		Select("UserId", "ExpiresAt", "ReminderSentAt", "CreateAt", "UpdateAt").
+		From("GuestAccessExpiries").
+		Where(sq.Eq{"UserId": userID})
+
+	var expiry model.GuestAccessExpiry
+	if err := us.GetReplica().GetBuilder(&expiry, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("GuestAccessExpiry", userID)
+		}
+		return nil, errors.Wrapf(err, "failed to get GuestAccessExpiry with userId=%s", userID)
+	}
+
+	return &expiry, nil
+}
+
+func (us SqlUserStore) DeleteGuestAccessExpiry(userID string) error {
+	if _, err := us.GetMaster().Exec("DELETE FROM GuestAccessExpiries WHERE UserId = ?", userID); err != nil {
+		return errors.Wrapf(err, "failed to delete GuestAccessExpiry with userId=%s", userID)
+	}
+
+	return nil
+}
+
+func (us SqlUserStore) GetGuestAccessExpiriesBefore(before int64, excludeReminded bool, limit int) ([]*model.GuestAccessExpiry, error) {
+	query := us.getQueryBuilder().
+		Select("UserId", "ExpiresAt", "ReminderSentAt", "CreateAt", "UpdateAt").
+		From("GuestAccessExpiries").
+		Where(sq.LtOrEq{"ExpiresAt": before}).
+		OrderBy("ExpiresAt ASC").
+		Limit(uint64(limit))
+	if excludeReminded {
+		query = query.Where(sq.Eq{"ReminderSentAt": 0})
+	}
+
+	expiries := []*model.GuestAccessExpiry{}
+	if err := us.GetReplica().SelectBuilder(&expiries, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get GuestAccessExpiries")
+	}
+
+	return expiries, nil
+}
+
+func (us SqlUserStore) MarkGuestAccessExpiryReminded(userID string, remindedAt int64) error {
+	if _, err := us.GetMaster().Exec("UPDATE GuestAccessExpiries SET ReminderSentAt = ? WHERE UserId = ?", remindedAt, userID); err != nil {
+		return errors.Wrapf(err, "failed to mark GuestAccessExpiry with userId=%s as reminded", userID)
+	}
+
+	return nil
+}
@@ -97,6 +97,7 @@ type Store interface {
 	Attributes() AttributesStore
 	GetSchemaDefinition() (*model.SupportPacketDatabaseSchema, error)
 	ContentFlagging() ContentFlaggingStore
+	OutgoingEmail() OutgoingEmailStore
 }
 
 type RetentionPolicyStore interface {
@@ -185,6 +186,17 @@ type TeamStore interface {
 	GetCommonTeamIDsForTwoUsers(userID, otherUserID string) ([]string, error)
 
 	GetCommonTeamIDsForMultipleUsers(userIDs []string) ([]string, error)
+
+	SaveInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, error)
+	UpdateInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, error)
+	GetInviteLink(id string) (*model.TeamInviteLink, error)
+	GetInviteLinksForTeam(teamID string, includeDeleted bool) ([]*model.TeamInviteLink, error)
+	// ClaimInviteLinkUse counts a use of a link, failing with a not found error if the link can't
+	// be used anymore.
+	ClaimInviteLinkUse(id string, now int64) error
+	ReleaseInviteLinkUse(id string) error
+	SaveInviteLinkUse(use *model.TeamInviteLinkUse) error
+	GetInviteLinkUses(linkID string, offset, limit int) ([]*model.TeamInviteLinkUse, error)
 }
 
 type ChannelStore interface {
@@ -508,6 +520,13 @@ type UserStore interface {
 	GetUserCountForReport(filter *model.UserReportOptions) (int64, error)
 	SearchCommonContentFlaggingReviewers(term string) ([]*model.User, error)
 	SearchTeamContentFlaggingReviewers(teamId, term string) ([]*model.User, error)
+	// SaveGuestAccessExpiry sets the access expiry of a guest, resetting the reminder.
+	SaveGuestAccessExpiry(expiry *model.GuestAccessExpiry) (*model.GuestAccessExpiry, error)
+	GetGuestAccessExpiry(userID string) (*model.GuestAccessExpiry, error)
+	DeleteGuestAccessExpiry(userID string) error
+	// GetGuestAccessExpiriesBefore returns the expiries before the given time, the earliest first.
+	GetGuestAccessExpiriesBefore(before int64, excludeReminded bool, limit int) ([]*model.GuestAccessExpiry, error)
+	MarkGuestAccessExpiryReminded(userID string, remindedAt int64) error
 }
 
 type BotStore interface {
@@ -599,6 +618,13 @@ type OAuthStore interface {
 	GetPreviousAccessData(userID, clientID string) (*model.AccessData, error)
 	RemoveAccessData(token string) error
 	RemoveAllAccessData() error
+	SaveDeviceAuthorization(authorization *model.DeviceAuthorization) (*model.DeviceAuthorization, error)
+	GetDeviceAuthorization(deviceCode string) (*model.DeviceAuthorization, error)
+	GetDeviceAuthorizationByUserCode(userCode string) (*model.DeviceAuthorization, error)
+	UpdateDeviceAuthorizationStatus(deviceCode, userID, status string) error
+	UpdateDeviceAuthorizationPoll(deviceCode string, lastPolledAt int64, pollInterval int32) error
+	RemoveDeviceAuthorization(deviceCode string) error
+	RemoveExpiredDeviceAuthorizations(expiredBefore int64) error
 }
 
 type OutgoingOAuthConnectionStore interface {
@@ -699,6 +725,9 @@ type TokenStore interface {
 	Cleanup(expiryTime int64)
 	GetAllTokensByType(tokenType string) ([]*model.Token, error)
 	RemoveAllTokensByType(tokenType string) error
+	// RemoveTokensByTypeAndUserId removes the tokens of the given type whose JSON extra data has
+	// the given UserId.
+	RemoveTokensByTypeAndUserId(tokenType, userID string) error
 }
 
 type DesktopTokensStore interface {
@@ -717,6 +746,12 @@ type EmojiStore interface {
 	GetList(offset, limit int, sort string) ([]*model.Emoji, error)
 	Delete(emoji *model.Emoji, timestamp int64) error
 	Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error)
+	Update(emoji *model.Emoji) (*model.Emoji, error)
+	SaveCategory(category *model.EmojiCategory) (*model.EmojiCategory, error)
+	GetCategory(id string) (*model.EmojiCategory, error)
+	GetCategoryByName(name string) (*model.EmojiCategory, error)
+	GetAllCategories() ([]*model.EmojiCategory, error)
+	DeleteCategory(id string) error
 }
 
 type StatusStore interface {
@@ -1037,6 +1072,17 @@ type SharedChannelStore interface {
 	UpsertAttachment(remote *model.SharedChannelAttachment) (string, error)
 	GetAttachment(fileID string, remoteID string) (*model.SharedChannelAttachment, error)
 	UpdateAttachmentLastSyncAt(id string, syncTime int64) error
+
+	SaveContentPolicy(policy *model.SharedChannelContentPolicy) (*model.SharedChannelContentPolicy, error)
+	GetContentPolicy(remoteID string) (*model.SharedChannelContentPolicy, error)
+	DeleteContentPolicy(remoteID string) (bool, error)
+
+	SaveHeldPost(held *model.SharedChannelHeldPost) (*model.SharedChannelHeldPost, error)
+	GetHeldPost(postID string, remoteID string) (*model.SharedChannelHeldPost, error)
+	GetHeldPosts(remoteID string, status string, offset, limit int) ([]*model.SharedChannelHeldPost, error)
+
+	SaveFilterLogEntry(entry *model.SharedChannelFilterLogEntry) (*model.SharedChannelFilterLogEntry, error)
+	GetFilterLog(remoteID string, offset, limit int) ([]*model.SharedChannelFilterLogEntry, error)
 }
 
 type PostPriorityStore interface {
@@ -1153,6 +1199,19 @@ type ContentFlaggingStore interface {
 	ClearCaches()
 }
 
+type OutgoingEmailStore interface {
+	Save(email *model.OutgoingEmail) (*model.OutgoingEmail, error)
+	Get(id string) (*model.OutgoingEmail, error)
+	Update(email *model.OutgoingEmail) (*model.OutgoingEmail, error)
+	Delete(id string) error
+	// ClaimPending atomically leases up to limit pending emails that are due at
+	// now, pushing their next attempt to now+leaseMillis so that other nodes
+	// don't pick them up concurrently.
+	ClaimPending(now int64, leaseMillis int64, limit int) ([]*model.OutgoingEmail, error)
+	GetByStatus(status string, offset, limit int) ([]*model.OutgoingEmail, error)
+	CountByStatus(status string) (int64, error)
+}
+
 // ChannelSearchOpts contains options for searching channels.
 //
 // NotAssociatedToGroup will exclude channels that have associated, active GroupChannels records.
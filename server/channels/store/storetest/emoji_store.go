@@ -22,6 +22,8 @@ func TestEmojiStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("EmojiGetMultipleByName", func(t *testing.T) { testEmojiGetMultipleByName(t, rctx, ss) })
 	t.Run("EmojiGetList", func(t *testing.T) { testEmojiGetList(t, rctx, ss) })
 	t.Run("EmojiSearch", func(t *testing.T) { testEmojiSearch(t, rctx, ss) })
+	t.Run("EmojiAliases", func(t *testing.T) { testEmojiAliases(t, rctx, ss) })
+	t.Run("EmojiCategories", func(t *testing.T) { testEmojiCategories(t, rctx, ss) })
 }
 
 func testEmojiSaveDelete(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -302,3 +304,131 @@ func testEmojiSearch(t *testing.T, rctx request.CTX, ss store.Store) {
 		assert.Equal(t, shouldFind[i], found, emoji.Name)
 	}
 }
+
+func testEmojiAliases(t *testing.T, rctx request.CTX, ss store.Store) {
+	alias1 := "alias_" + model.NewId()[:10]
+	alias2 := "alias_" + model.NewId()[:10]
+
+	emoji := &model.Emoji{
+		CreatorId: model.NewId(),
+		Name:      model.NewId(),
+		Aliases:   []string{alias1},
+	}
+	_, err := ss.Emoji().Save(emoji)
+	require.NoError(t, err)
+	defer func() {
+		err = ss.Emoji().Delete(emoji, time.Now().Unix())
+		require.NoError(t, err)
+	}()
+
+	t.Run("get by alias", func(t *testing.T) {
+		received, err := ss.Emoji().GetByName(rctx, alias1, false)
+		require.NoError(t, err)
+		assert.Equal(t, emoji.Id, received.Id)
+		assert.Equal(t, emoji.Name, received.Name)
+		assert.Equal(t, []string{alias1}, received.Aliases)
+	})
+
+	t.Run("get multiple by alias", func(t *testing.T) {
+		received, err := ss.Emoji().GetMultipleByName(rctx, []string{alias1, emoji.Name})
+		require.NoError(t, err)
+		require.Len(t, received, 1)
+		assert.Equal(t, emoji.Id, received[0].Id)
+	})
+
+	t.Run("search by alias", func(t *testing.T) {
+		received, err := ss.Emoji().Search(alias1, true, 100)
+		require.NoError(t, err)
+		require.Len(t, received, 1)
+		assert.Equal(t, emoji.Id, received[0].Id)
+	})
+
+	t.Run("aliases are unique", func(t *testing.T) {
+		other := &model.Emoji{
+			CreatorId: model.NewId(),
+			Name:      model.NewId(),
+			Aliases:   []string{alias1},
+		}
+		_, err := ss.Emoji().Save(other)
+		require.Error(t, err)
+		var cErr *store.ErrConflict
+		assert.ErrorAs(t, err, &cErr)
+
+		_, err = ss.Emoji().GetByName(rctx, other.Name, false)
+		require.Error(t, err, "the emoji shouldn't have been saved")
+	})
+
+	t.Run("update replaces the aliases", func(t *testing.T) {
+		emoji.Aliases = []string{alias2}
+		_, err := ss.Emoji().Update(emoji)
+		require.NoError(t, err)
+
+		_, err = ss.Emoji().GetByName(rctx, alias1, false)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		received, err := ss.Emoji().GetByName(rctx, alias2, false)
+		require.NoError(t, err)
+		assert.Equal(t, emoji.Id, received.Id)
+	})
+
+	t.Run("update of a missing emoji", func(t *testing.T) {
+		_, err := ss.Emoji().Update(&model.Emoji{
+			Id:        model.NewId(),
+			CreatorId: model.NewId(),
+			Name:      model.NewId(),
+			CreateAt:  model.GetMillis(),
+		})
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testEmojiCategories(t *testing.T, rctx request.CTX, ss store.Store) {
+	category := &model.EmojiCategory{Name: "category_" + model.NewId()[:10]}
+	_, err := ss.Emoji().SaveCategory(category)
+	require.NoError(t, err)
+	assert.Equal(t, category.Name, category.DisplayName)
+
+	_, err = ss.Emoji().SaveCategory(&model.EmojiCategory{Name: category.Name})
+	var cErr *store.ErrConflict
+	require.ErrorAs(t, err, &cErr, "shouldn't be able to save a category with a duplicate name")
+
+	received, err := ss.Emoji().GetCategory(category.Id)
+	require.NoError(t, err)
+	assert.Equal(t, category, received)
+
+	received, err = ss.Emoji().GetCategoryByName(category.Name)
+	require.NoError(t, err)
+	assert.Equal(t, category.Id, received.Id)
+
+	categories, err := ss.Emoji().GetAllCategories()
+	require.NoError(t, err)
+	assert.Contains(t, categories, category)
+
+	emoji := &model.Emoji{
+		CreatorId:  model.NewId(),
+		Name:       model.NewId(),
+		CategoryId: category.Id,
+	}
+	_, err = ss.Emoji().Save(emoji)
+	require.NoError(t, err)
+	defer func() {
+		err = ss.Emoji().Delete(emoji, time.Now().Unix())
+		require.NoError(t, err)
+	}()
+
+	err = ss.Emoji().DeleteCategory(category.Id)
+	require.NoError(t, err)
+
+	_, err = ss.Emoji().GetCategory(category.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	receivedEmoji, err := ss.Emoji().Get(rctx, emoji.Id, false)
+	require.NoError(t, err)
+	assert.Empty(t, receivedEmoji.CategoryId, "the emojis of a deleted category should be uncategorized")
+
+	err = ss.Emoji().DeleteCategory(category.Id)
+	require.ErrorAs(t, err, &nfErr)
+}
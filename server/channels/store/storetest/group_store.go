@@ -34,6 +34,7 @@ func TestGroupStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("Update", func(t *testing.T) { testGroupStoreUpdate(t, rctx, ss) })
 	t.Run("Delete", func(t *testing.T) { testGroupStoreDelete(t, rctx, ss) })
 	t.Run("Restore", func(t *testing.T) { testGroupStoreRestore(t, rctx, ss) })
+	t.Run("MembershipRule", func(t *testing.T) { testGroupStoreMembershipRule(t, rctx, ss) })
 	t.Run("ToModelChannelAssociations", func(t *testing.T) { testGroupStoreToModelChannelAssociations(t, rctx, ss) })
 
 	t.Run("GetMemberUsers", func(t *testing.T) { testGroupGetMemberUsers(t, rctx, ss) })
@@ -101,6 +102,62 @@ func TestGroupStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("GroupCountBySource", func(t *testing.T) { groupTestGroupCountBySource(t, rctx, ss) })
 }
 
+func testGroupStoreMembershipRule(t *testing.T, rctx request.CTX, ss store.Store) {
+	g1, err := ss.Group().Create(&model.Group{
+		Name:           model.NewPointer(model.NewId()),
+		DisplayName:    model.NewId(),
+		Source:         model.GroupSourceAttribute,
+		AllowReference: true,
+		MembershipRule: `user.attributes.Department == "Engineering"`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, `user.attributes.Department == "Engineering"`, g1.MembershipRule)
+
+	d1, err := ss.Group().Get(g1.Id)
+	require.NoError(t, err)
+	require.Equal(t, g1.MembershipRule, d1.MembershipRule)
+
+	d1.MembershipRule = `user.attributes.Location == "Berlin"`
+	_, err = ss.Group().Update(d1)
+	require.NoError(t, err)
+
+	groups, err := ss.Group().GetAllBySource(model.GroupSourceAttribute)
+	require.NoError(t, err)
+	var found *model.Group
+	for _, group := range groups {
+		if group.Id == g1.Id {
+			found = group
+		}
+	}
+	require.NotNil(t, found)
+	require.Equal(t, `user.attributes.Location == "Berlin"`, found.MembershipRule)
+
+	g2, err := ss.Group().CreateWithUserIds(&model.GroupWithUserIds{
+		Group: model.Group{
+			Name:           model.NewPointer(model.NewId()),
+			DisplayName:    model.NewId(),
+			Source:         model.GroupSourceAttribute,
+			AllowReference: true,
+			MembershipRule: `user.attributes.Location == "Berlin"`,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `user.attributes.Location == "Berlin"`, g2.MembershipRule)
+
+	// Only attribute groups may have a rule
+	_, err = ss.Group().Create(&model.Group{
+		Name:           model.NewPointer(model.NewId()),
+		DisplayName:    model.NewId(),
+		Source:         model.GroupSourceCustom,
+		AllowReference: true,
+		MembershipRule: `user.attributes.Location == "Berlin"`,
+	})
+	require.Error(t, err)
+	var appErr *model.AppError
+	require.True(t, errors.As(err, &appErr))
+	require.Equal(t, "model.group.membership_rule.source.app_error", appErr.Id)
+}
+
 func testGroupStoreCreate(t *testing.T, rctx request.CTX, ss store.Store) {
 	// Save a new group
 	g1 := &model.Group{
@@ -33,6 +33,24 @@ func (_m *EmojiStore) Delete(emoji *model.Emoji, timestamp int64) error {
 	return r0
 }
 
+// DeleteCategory provides a mock function with given fields: id
+func (_m *EmojiStore) DeleteCategory(id string) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: rctx, id, allowFromCache
 func (_m *EmojiStore) Get(rctx request.CTX, id string, allowFromCache bool) (*model.Emoji, error) {
 	ret := _m.Called(rctx, id, allowFromCache)
@@ -63,6 +81,36 @@ func (_m *EmojiStore) Get(rctx request.CTX, id string, allowFromCache bool) (*mo
 	return r0, r1
 }
 
+// GetAllCategories provides a mock function with no fields
+func (_m *EmojiStore) GetAllCategories() ([]*model.EmojiCategory, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllCategories")
+	}
+
+	var r0 []*model.EmojiCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*model.EmojiCategory, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*model.EmojiCategory); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.EmojiCategory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByName provides a mock function with given fields: rctx, name, allowFromCache
 func (_m *EmojiStore) GetByName(rctx request.CTX, name string, allowFromCache bool) (*model.Emoji, error) {
 	ret := _m.Called(rctx, name, allowFromCache)
@@ -93,6 +141,66 @@ func (_m *EmojiStore) GetByName(rctx request.CTX, name string, allowFromCache bo
 	return r0, r1
 }
 
+// GetCategory provides a mock function with given fields: id
+func (_m *EmojiStore) GetCategory(id string) (*model.EmojiCategory, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategory")
+	}
+
+	var r0 *model.EmojiCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.EmojiCategory, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.EmojiCategory); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EmojiCategory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCategoryByName provides a mock function with given fields: name
+func (_m *EmojiStore) GetCategoryByName(name string) (*model.EmojiCategory, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategoryByName")
+	}
+
+	var r0 *model.EmojiCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.EmojiCategory, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.EmojiCategory); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EmojiCategory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetList provides a mock function with given fields: offset, limit, sort
 func (_m *EmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
 	ret := _m.Called(offset, limit, sort)
@@ -183,6 +291,36 @@ func (_m *EmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 	return r0, r1
 }
 
+// SaveCategory provides a mock function with given fields: category
+func (_m *EmojiStore) SaveCategory(category *model.EmojiCategory) (*model.EmojiCategory, error) {
+	ret := _m.Called(category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCategory")
+	}
+
+	var r0 *model.EmojiCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.EmojiCategory) (*model.EmojiCategory, error)); ok {
+		return rf(category)
+	}
+	if rf, ok := ret.Get(0).(func(*model.EmojiCategory) *model.EmojiCategory); ok {
+		r0 = rf(category)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EmojiCategory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.EmojiCategory) error); ok {
+		r1 = rf(category)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Search provides a mock function with given fields: name, prefixOnly, limit
 func (_m *EmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
 	ret := _m.Called(name, prefixOnly, limit)
@@ -213,6 +351,36 @@ func (_m *EmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.
 	return r0, r1
 }
 
+// Update provides a mock function with given fields: emoji
+func (_m *EmojiStore) Update(emoji *model.Emoji) (*model.Emoji, error) {
+	ret := _m.Called(emoji)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.Emoji
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Emoji) (*model.Emoji, error)); ok {
+		return rf(emoji)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Emoji) *model.Emoji); ok {
+		r0 = rf(emoji)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Emoji)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Emoji) error); ok {
+		r1 = rf(emoji)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewEmojiStore creates a new instance of EmojiStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewEmojiStore(t interface {
@@ -272,6 +272,66 @@ func (_m *OAuthStore) GetAuthorizedApps(userID string, offset int, limit int) ([
 	return r0, r1
 }
 
+// GetDeviceAuthorization provides a mock function with given fields: deviceCode
+func (_m *OAuthStore) GetDeviceAuthorization(deviceCode string) (*model.DeviceAuthorization, error) {
+	ret := _m.Called(deviceCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceAuthorization")
+	}
+
+	var r0 *model.DeviceAuthorization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.DeviceAuthorization, error)); ok {
+		return rf(deviceCode)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.DeviceAuthorization); ok {
+		r0 = rf(deviceCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DeviceAuthorization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(deviceCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeviceAuthorizationByUserCode provides a mock function with given fields: userCode
+func (_m *OAuthStore) GetDeviceAuthorizationByUserCode(userCode string) (*model.DeviceAuthorization, error) {
+	ret := _m.Called(userCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceAuthorizationByUserCode")
+	}
+
+	var r0 *model.DeviceAuthorization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.DeviceAuthorization, error)); ok {
+		return rf(userCode)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.DeviceAuthorization); ok {
+		r0 = rf(userCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DeviceAuthorization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPreviousAccessData provides a mock function with given fields: userID, clientID
 func (_m *OAuthStore) GetPreviousAccessData(userID string, clientID string) (*model.AccessData, error) {
 	ret := _m.Called(userID, clientID)
@@ -410,6 +470,42 @@ func (_m *OAuthStore) RemoveAuthDataByUserId(userID string) error {
 	return r0
 }
 
+// RemoveDeviceAuthorization provides a mock function with given fields: deviceCode
+func (_m *OAuthStore) RemoveDeviceAuthorization(deviceCode string) error {
+	ret := _m.Called(deviceCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDeviceAuthorization")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(deviceCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveExpiredDeviceAuthorizations provides a mock function with given fields: expiredBefore
+func (_m *OAuthStore) RemoveExpiredDeviceAuthorizations(expiredBefore int64) error {
+	ret := _m.Called(expiredBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveExpiredDeviceAuthorizations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(expiredBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveAccessData provides a mock function with given fields: accessData
 func (_m *OAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
 	ret := _m.Called(accessData)
@@ -500,6 +596,36 @@ func (_m *OAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, e
 	return r0, r1
 }
 
+// SaveDeviceAuthorization provides a mock function with given fields: authorization
+func (_m *OAuthStore) SaveDeviceAuthorization(authorization *model.DeviceAuthorization) (*model.DeviceAuthorization, error) {
+	ret := _m.Called(authorization)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDeviceAuthorization")
+	}
+
+	var r0 *model.DeviceAuthorization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.DeviceAuthorization) (*model.DeviceAuthorization, error)); ok {
+		return rf(authorization)
+	}
+	if rf, ok := ret.Get(0).(func(*model.DeviceAuthorization) *model.DeviceAuthorization); ok {
+		r0 = rf(authorization)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DeviceAuthorization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.DeviceAuthorization) error); ok {
+		r1 = rf(authorization)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateAccessData provides a mock function with given fields: accessData
 func (_m *OAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
 	ret := _m.Called(accessData)
@@ -560,6 +686,42 @@ func (_m *OAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 	return r0, r1
 }
 
+// UpdateDeviceAuthorizationPoll provides a mock function with given fields: deviceCode, lastPolledAt, pollInterval
+func (_m *OAuthStore) UpdateDeviceAuthorizationPoll(deviceCode string, lastPolledAt int64, pollInterval int32) error {
+	ret := _m.Called(deviceCode, lastPolledAt, pollInterval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeviceAuthorizationPoll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64, int32) error); ok {
+		r0 = rf(deviceCode, lastPolledAt, pollInterval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDeviceAuthorizationStatus provides a mock function with given fields: deviceCode, userID, status
+func (_m *OAuthStore) UpdateDeviceAuthorizationStatus(deviceCode string, userID string, status string) error {
+	ret := _m.Called(deviceCode, userID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeviceAuthorizationStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(deviceCode, userID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewOAuthStore creates a new instance of OAuthStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewOAuthStore(t interface {
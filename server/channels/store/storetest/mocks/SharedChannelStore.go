@@ -42,6 +42,34 @@ func (_m *SharedChannelStore) Delete(channelID string) (bool, error) {
 	return r0, r1
 }
 
+// DeleteContentPolicy provides a mock function with given fields: remoteID
+func (_m *SharedChannelStore) DeleteContentPolicy(remoteID string) (bool, error) {
+	ret := _m.Called(remoteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContentPolicy")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(remoteID)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(remoteID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(remoteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteRemote provides a mock function with given fields: remoteID
 func (_m *SharedChannelStore) DeleteRemote(remoteID string) (bool, error) {
 	ret := _m.Called(remoteID)
@@ -188,6 +216,126 @@ func (_m *SharedChannelStore) GetAttachment(fileID string, remoteID string) (*mo
 	return r0, r1
 }
 
+// GetContentPolicy provides a mock function with given fields: remoteID
+func (_m *SharedChannelStore) GetContentPolicy(remoteID string) (*model.SharedChannelContentPolicy, error) {
+	ret := _m.Called(remoteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContentPolicy")
+	}
+
+	var r0 *model.SharedChannelContentPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.SharedChannelContentPolicy, error)); ok {
+		return rf(remoteID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.SharedChannelContentPolicy); ok {
+		r0 = rf(remoteID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SharedChannelContentPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(remoteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilterLog provides a mock function with given fields: remoteID, offset, limit
+func (_m *SharedChannelStore) GetFilterLog(remoteID string, offset int, limit int) ([]*model.SharedChannelFilterLogEntry, error) {
+	ret := _m.Called(remoteID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilterLog")
+	}
+
+	var r0 []*model.SharedChannelFilterLogEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]*model.SharedChannelFilterLogEntry, error)); ok {
+		return rf(remoteID, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.SharedChannelFilterLogEntry); ok {
+		r0 = rf(remoteID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SharedChannelFilterLogEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(remoteID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHeldPost provides a mock function with given fields: postID, remoteID
+func (_m *SharedChannelStore) GetHeldPost(postID string, remoteID string) (*model.SharedChannelHeldPost, error) {
+	ret := _m.Called(postID, remoteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHeldPost")
+	}
+
+	var r0 *model.SharedChannelHeldPost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*model.SharedChannelHeldPost, error)); ok {
+		return rf(postID, remoteID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *model.SharedChannelHeldPost); ok {
+		r0 = rf(postID, remoteID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SharedChannelHeldPost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(postID, remoteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHeldPosts provides a mock function with given fields: remoteID, status, offset, limit
+func (_m *SharedChannelStore) GetHeldPosts(remoteID string, status string, offset int, limit int) ([]*model.SharedChannelHeldPost, error) {
+	ret := _m.Called(remoteID, status, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHeldPosts")
+	}
+
+	var r0 []*model.SharedChannelHeldPost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int, int) ([]*model.SharedChannelHeldPost, error)); ok {
+		return rf(remoteID, status, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int, int) []*model.SharedChannelHeldPost); ok {
+		r0 = rf(remoteID, status, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SharedChannelHeldPost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int, int) error); ok {
+		r1 = rf(remoteID, status, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRemote provides a mock function with given fields: id
 func (_m *SharedChannelStore) GetRemote(id string) (*model.SharedChannelRemote, error) {
 	ret := _m.Called(id)
@@ -574,6 +722,96 @@ func (_m *SharedChannelStore) SaveAttachment(remote *model.SharedChannelAttachme
 	return r0, r1
 }
 
+// SaveContentPolicy provides a mock function with given fields: policy
+func (_m *SharedChannelStore) SaveContentPolicy(policy *model.SharedChannelContentPolicy) (*model.SharedChannelContentPolicy, error) {
+	ret := _m.Called(policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveContentPolicy")
+	}
+
+	var r0 *model.SharedChannelContentPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.SharedChannelContentPolicy) (*model.SharedChannelContentPolicy, error)); ok {
+		return rf(policy)
+	}
+	if rf, ok := ret.Get(0).(func(*model.SharedChannelContentPolicy) *model.SharedChannelContentPolicy); ok {
+		r0 = rf(policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SharedChannelContentPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.SharedChannelContentPolicy) error); ok {
+		r1 = rf(policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveFilterLogEntry provides a mock function with given fields: entry
+func (_m *SharedChannelStore) SaveFilterLogEntry(entry *model.SharedChannelFilterLogEntry) (*model.SharedChannelFilterLogEntry, error) {
+	ret := _m.Called(entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveFilterLogEntry")
+	}
+
+	var r0 *model.SharedChannelFilterLogEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.SharedChannelFilterLogEntry) (*model.SharedChannelFilterLogEntry, error)); ok {
+		return rf(entry)
+	}
+	if rf, ok := ret.Get(0).(func(*model.SharedChannelFilterLogEntry) *model.SharedChannelFilterLogEntry); ok {
+		r0 = rf(entry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SharedChannelFilterLogEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.SharedChannelFilterLogEntry) error); ok {
+		r1 = rf(entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveHeldPost provides a mock function with given fields: held
+func (_m *SharedChannelStore) SaveHeldPost(held *model.SharedChannelHeldPost) (*model.SharedChannelHeldPost, error) {
+	ret := _m.Called(held)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveHeldPost")
+	}
+
+	var r0 *model.SharedChannelHeldPost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.SharedChannelHeldPost) (*model.SharedChannelHeldPost, error)); ok {
+		return rf(held)
+	}
+	if rf, ok := ret.Get(0).(func(*model.SharedChannelHeldPost) *model.SharedChannelHeldPost); ok {
+		r0 = rf(held)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SharedChannelHeldPost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.SharedChannelHeldPost) error); ok {
+		r1 = rf(held)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveRemote provides a mock function with given fields: remote
 func (_m *SharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
 	ret := _m.Called(remote)
@@ -706,6 +706,26 @@ func (_m *Store) OAuth() store.OAuthStore {
 	return r0
 }
 
+// OutgoingEmail provides a mock function with no fields
+func (_m *Store) OutgoingEmail() store.OutgoingEmailStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutgoingEmail")
+	}
+
+	var r0 store.OutgoingEmailStore
+	if rf, ok := ret.Get(0).(func() store.OutgoingEmailStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.OutgoingEmailStore)
+		}
+	}
+
+	return r0
+}
+
 // OutgoingOAuthConnection provides a mock function with no fields
 func (_m *Store) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	ret := _m.Called()
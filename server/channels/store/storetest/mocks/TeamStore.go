@@ -71,6 +71,24 @@ func (_m *TeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64, error) {
 	return r0, r1
 }
 
+// ClaimInviteLinkUse provides a mock function with given fields: id, now
+func (_m *TeamStore) ClaimInviteLinkUse(id string, now int64) error {
+	ret := _m.Called(id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimInviteLinkUse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(id, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ClearAllCustomRoleAssignments provides a mock function with no fields
 func (_m *TeamStore) ClearAllCustomRoleAssignments() error {
 	ret := _m.Called()
@@ -542,6 +560,96 @@ func (_m *TeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUserID stri
 	return r0, r1
 }
 
+// GetInviteLink provides a mock function with given fields: id
+func (_m *TeamStore) GetInviteLink(id string) (*model.TeamInviteLink, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInviteLink")
+	}
+
+	var r0 *model.TeamInviteLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.TeamInviteLink, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.TeamInviteLink); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TeamInviteLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInviteLinkUses provides a mock function with given fields: linkID, offset, limit
+func (_m *TeamStore) GetInviteLinkUses(linkID string, offset int, limit int) ([]*model.TeamInviteLinkUse, error) {
+	ret := _m.Called(linkID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInviteLinkUses")
+	}
+
+	var r0 []*model.TeamInviteLinkUse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]*model.TeamInviteLinkUse, error)); ok {
+		return rf(linkID, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.TeamInviteLinkUse); ok {
+		r0 = rf(linkID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TeamInviteLinkUse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(linkID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInviteLinksForTeam provides a mock function with given fields: teamID, includeDeleted
+func (_m *TeamStore) GetInviteLinksForTeam(teamID string, includeDeleted bool) ([]*model.TeamInviteLink, error) {
+	ret := _m.Called(teamID, includeDeleted)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInviteLinksForTeam")
+	}
+
+	var r0 []*model.TeamInviteLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, bool) ([]*model.TeamInviteLink, error)); ok {
+		return rf(teamID, includeDeleted)
+	}
+	if rf, ok := ret.Get(0).(func(string, bool) []*model.TeamInviteLink); ok {
+		r0 = rf(teamID, includeDeleted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TeamInviteLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(teamID, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMany provides a mock function with given fields: ids
 func (_m *TeamStore) GetMany(ids []string) ([]*model.Team, error) {
 	ret := _m.Called(ids)
@@ -951,6 +1059,24 @@ func (_m *TeamStore) PermanentDelete(teamID string) error {
 	return r0
 }
 
+// ReleaseInviteLinkUse provides a mock function with given fields: id
+func (_m *TeamStore) ReleaseInviteLinkUse(id string) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseInviteLinkUse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveAllMembersByTeam provides a mock function with given fields: teamID
 func (_m *TeamStore) RemoveAllMembersByTeam(teamID string) error {
 	ret := _m.Called(teamID)
@@ -1071,6 +1197,54 @@ func (_m *TeamStore) Save(team *model.Team) (*model.Team, error) {
 	return r0, r1
 }
 
+// SaveInviteLink provides a mock function with given fields: link
+func (_m *TeamStore) SaveInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
+	ret := _m.Called(link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveInviteLink")
+	}
+
+	var r0 *model.TeamInviteLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.TeamInviteLink) (*model.TeamInviteLink, error)); ok {
+		return rf(link)
+	}
+	if rf, ok := ret.Get(0).(func(*model.TeamInviteLink) *model.TeamInviteLink); ok {
+		r0 = rf(link)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TeamInviteLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.TeamInviteLink) error); ok {
+		r1 = rf(link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveInviteLinkUse provides a mock function with given fields: use
+func (_m *TeamStore) SaveInviteLinkUse(use *model.TeamInviteLinkUse) error {
+	ret := _m.Called(use)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveInviteLinkUse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.TeamInviteLinkUse) error); ok {
+		r0 = rf(use)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveMember provides a mock function with given fields: rctx, member, maxUsersPerTeam
 func (_m *TeamStore) SaveMember(rctx request.CTX, member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
 	ret := _m.Called(rctx, member, maxUsersPerTeam)
@@ -1288,6 +1462,36 @@ func (_m *TeamStore) Update(team *model.Team) (*model.Team, error) {
 	return r0, r1
 }
 
+// UpdateInviteLink provides a mock function with given fields: link
+func (_m *TeamStore) UpdateInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
+	ret := _m.Called(link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateInviteLink")
+	}
+
+	var r0 *model.TeamInviteLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.TeamInviteLink) (*model.TeamInviteLink, error)); ok {
+		return rf(link)
+	}
+	if rf, ok := ret.Get(0).(func(*model.TeamInviteLink) *model.TeamInviteLink); ok {
+		r0 = rf(link)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TeamInviteLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.TeamInviteLink) error); ok {
+		r1 = rf(link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateLastTeamIconUpdate provides a mock function with given fields: teamID, curTime
 func (_m *TeamStore) UpdateLastTeamIconUpdate(teamID string, curTime int64) error {
 	ret := _m.Called(teamID, curTime)
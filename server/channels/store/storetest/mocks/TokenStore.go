@@ -145,6 +145,24 @@ func (_m *TokenStore) RemoveAllTokensByType(tokenType string) error {
 	return r0
 }
 
+// RemoveTokensByTypeAndUserId provides a mock function with given fields: tokenType, userID
+func (_m *TokenStore) RemoveTokensByTypeAndUserId(tokenType string, userID string) error {
+	ret := _m.Called(tokenType, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTokensByTypeAndUserId")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(tokenType, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: recovery
 func (_m *TokenStore) Save(recovery *model.Token) error {
 	ret := _m.Called(recovery)
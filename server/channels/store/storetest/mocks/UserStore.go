@@ -299,6 +299,24 @@ func (_m *UserStore) DeactivateGuests() ([]string, error) {
 	return r0, r1
 }
 
+// DeleteGuestAccessExpiry provides a mock function with given fields: userID
+func (_m *UserStore) DeleteGuestAccessExpiry(userID string) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGuestAccessExpiry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DemoteUserToGuest provides a mock function with given fields: userID
 func (_m *UserStore) DemoteUserToGuest(userID string) (*model.User, error) {
 	ret := _m.Called(userID)
@@ -801,6 +819,66 @@ func (_m *UserStore) GetForLogin(loginID string, allowSignInWithUsername bool, a
 	return r0, r1
 }
 
+// GetGuestAccessExpiriesBefore provides a mock function with given fields: before, excludeReminded, limit
+func (_m *UserStore) GetGuestAccessExpiriesBefore(before int64, excludeReminded bool, limit int) ([]*model.GuestAccessExpiry, error) {
+	ret := _m.Called(before, excludeReminded, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuestAccessExpiriesBefore")
+	}
+
+	var r0 []*model.GuestAccessExpiry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, bool, int) ([]*model.GuestAccessExpiry, error)); ok {
+		return rf(before, excludeReminded, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, bool, int) []*model.GuestAccessExpiry); ok {
+		r0 = rf(before, excludeReminded, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.GuestAccessExpiry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, bool, int) error); ok {
+		r1 = rf(before, excludeReminded, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGuestAccessExpiry provides a mock function with given fields: userID
+func (_m *UserStore) GetGuestAccessExpiry(userID string) (*model.GuestAccessExpiry, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuestAccessExpiry")
+	}
+
+	var r0 *model.GuestAccessExpiry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.GuestAccessExpiry, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.GuestAccessExpiry); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GuestAccessExpiry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetKnownUsers provides a mock function with given fields: userID
 func (_m *UserStore) GetKnownUsers(userID string) ([]string, error) {
 	ret := _m.Called(userID)
@@ -1574,6 +1652,24 @@ func (_m *UserStore) IsEmpty(excludeBots bool) (bool, error) {
 	return r0, r1
 }
 
+// MarkGuestAccessExpiryReminded provides a mock function with given fields: userID, remindedAt
+func (_m *UserStore) MarkGuestAccessExpiryReminded(userID string, remindedAt int64) error {
+	ret := _m.Called(userID, remindedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkGuestAccessExpiryReminded")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(userID, remindedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PermanentDelete provides a mock function with given fields: rctx, userID
 func (_m *UserStore) PermanentDelete(rctx request.CTX, userID string) error {
 	ret := _m.Called(rctx, userID)
@@ -1704,6 +1800,36 @@ func (_m *UserStore) Save(rctx request.CTX, user *model.User) (*model.User, erro
 	return r0, r1
 }
 
+// SaveGuestAccessExpiry provides a mock function with given fields: expiry
+func (_m *UserStore) SaveGuestAccessExpiry(expiry *model.GuestAccessExpiry) (*model.GuestAccessExpiry, error) {
+	ret := _m.Called(expiry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveGuestAccessExpiry")
+	}
+
+	var r0 *model.GuestAccessExpiry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.GuestAccessExpiry) (*model.GuestAccessExpiry, error)); ok {
+		return rf(expiry)
+	}
+	if rf, ok := ret.Get(0).(func(*model.GuestAccessExpiry) *model.GuestAccessExpiry); ok {
+		r0 = rf(expiry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GuestAccessExpiry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.GuestAccessExpiry) error); ok {
+		r1 = rf(expiry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Search provides a mock function with given fields: rctx, teamID, term, options
 func (_m *UserStore) Search(rctx request.CTX, teamID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
 	ret := _m.Called(rctx, teamID, term, options)
@@ -30,6 +30,7 @@ func TestOAuthStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("OAuthGetAuthorizedApps", func(t *testing.T) { testOAuthGetAuthorizedApps(t, rctx, ss) })
 	t.Run("OAuthGetAccessDataByUserForApp", func(t *testing.T) { testOAuthGetAccessDataByUserForApp(t, rctx, ss) })
 	t.Run("DeleteApp", func(t *testing.T) { testOAuthStoreDeleteApp(t, rctx, ss) })
+	t.Run("DeviceAuthorization", func(t *testing.T) { testOAuthStoreDeviceAuthorization(t, rctx, ss) })
 }
 
 func testOAuthStoreSaveApp(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -168,6 +169,14 @@ func testOAuthUpdateAccessData(t *testing.T, rctx request.CTX, ss store.Store) {
 	ra1, err := ss.OAuth().UpdateAccessData(&a1)
 	require.NoError(t, err)
 	require.NotEqual(t, ra1.RefreshToken, refreshToken, "refresh tokens didn't match")
+
+	// Scope changes are persisted
+	a1.Scope = "openid email"
+	_, err = ss.OAuth().UpdateAccessData(&a1)
+	require.NoError(t, err)
+	ra1, err = ss.OAuth().GetAccessData(a1.Token)
+	require.NoError(t, err)
+	require.Equal(t, "openid email", ra1.Scope)
 }
 
 func testOAuthStoreGetAccessData(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -259,6 +268,20 @@ func testOAuthStoreGetAuthData(t *testing.T, rctx request.CTX, ss store.Store) {
 
 	_, err = ss.OAuth().GetAuthData(a1.Code)
 	require.NoError(t, err)
+
+	a2 := model.AuthData{}
+	a2.ClientId = model.NewId()
+	a2.UserId = model.NewId()
+	a2.Code = model.NewId()
+	a2.RedirectUri = "http://example.com"
+	a2.Scope = "openid profile"
+	a2.Nonce = "n-0S6_WzA2Mj"
+	_, err = ss.OAuth().SaveAuthData(&a2)
+	require.NoError(t, err)
+
+	received, err := ss.OAuth().GetAuthData(a2.Code)
+	require.NoError(t, err)
+	require.Equal(t, a2.Nonce, received.Nonce)
 }
 
 func testOAuthStoreRemoveAuthData(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -396,3 +419,103 @@ func testOAuthStoreDeleteApp(t *testing.T, rctx request.CTX, ss store.Store) {
 	_, err = ss.OAuth().GetAccessData(s1.Token)
 	require.Error(t, err, "should error - access data should be deleted")
 }
+
+func testOAuthStoreDeviceAuthorization(t *testing.T, rctx request.CTX, ss store.Store) {
+	clientID := model.NewId()
+	userID := model.NewId()
+
+	t.Run("save and get", func(t *testing.T) {
+		da, err := ss.OAuth().SaveDeviceAuthorization(model.NewDeviceAuthorization(clientID, ""))
+		require.NoError(t, err)
+		assert.NotZero(t, da.CreateAt)
+		assert.Equal(t, da.CreateAt+model.DeviceCodeExpireTime*1000, da.ExpiresAt)
+
+		byDeviceCode, err := ss.OAuth().GetDeviceAuthorization(da.DeviceCode)
+		require.NoError(t, err)
+		assert.Equal(t, da, byDeviceCode)
+
+		byUserCode, err := ss.OAuth().GetDeviceAuthorizationByUserCode(da.UserCode)
+		require.NoError(t, err)
+		assert.Equal(t, da, byUserCode)
+
+		_, err = ss.OAuth().GetDeviceAuthorization(model.NewRandomString(model.DeviceCodeLength))
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("user codes are unique", func(t *testing.T) {
+		da, err := ss.OAuth().SaveDeviceAuthorization(model.NewDeviceAuthorization(clientID, ""))
+		require.NoError(t, err)
+
+		duplicate := model.NewDeviceAuthorization(clientID, "")
+		duplicate.UserCode = da.UserCode
+		_, err = ss.OAuth().SaveDeviceAuthorization(duplicate)
+		var cErr *store.ErrConflict
+		require.ErrorAs(t, err, &cErr)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ss.OAuth().SaveDeviceAuthorization(model.NewDeviceAuthorization("invalid", ""))
+		require.Error(t, err)
+	})
+
+	t.Run("status can only be updated once", func(t *testing.T) {
+		da, err := ss.OAuth().SaveDeviceAuthorization(model.NewDeviceAuthorization(clientID, ""))
+		require.NoError(t, err)
+
+		err = ss.OAuth().UpdateDeviceAuthorizationStatus(da.DeviceCode, userID, model.DeviceAuthorizationStatusApproved)
+		require.NoError(t, err)
+
+		updated, err := ss.OAuth().GetDeviceAuthorization(da.DeviceCode)
+		require.NoError(t, err)
+		assert.Equal(t, userID, updated.UserId)
+		assert.Equal(t, model.DeviceAuthorizationStatusApproved, updated.Status)
+
+		err = ss.OAuth().UpdateDeviceAuthorizationStatus(da.DeviceCode, model.NewId(), model.DeviceAuthorizationStatusDenied)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("update poll", func(t *testing.T) {
+		da, err := ss.OAuth().SaveDeviceAuthorization(model.NewDeviceAuthorization(clientID, ""))
+		require.NoError(t, err)
+
+		err = ss.OAuth().UpdateDeviceAuthorizationPoll(da.DeviceCode, 1234, 10)
+		require.NoError(t, err)
+
+		updated, err := ss.OAuth().GetDeviceAuthorization(da.DeviceCode)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1234), updated.LastPolledAt)
+		assert.Equal(t, int32(10), updated.PollInterval)
+	})
+
+	t.Run("remove only once", func(t *testing.T) {
+		da, err := ss.OAuth().SaveDeviceAuthorization(model.NewDeviceAuthorization(clientID, ""))
+		require.NoError(t, err)
+
+		err = ss.OAuth().RemoveDeviceAuthorization(da.DeviceCode)
+		require.NoError(t, err)
+
+		err = ss.OAuth().RemoveDeviceAuthorization(da.DeviceCode)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("remove expired", func(t *testing.T) {
+		expired := model.NewDeviceAuthorization(clientID, "")
+		expired.CreateAt = model.GetMillis() - 2*model.DeviceCodeExpireTime*1000
+		expired, err := ss.OAuth().SaveDeviceAuthorization(expired)
+		require.NoError(t, err)
+
+		pending, err := ss.OAuth().SaveDeviceAuthorization(model.NewDeviceAuthorization(clientID, ""))
+		require.NoError(t, err)
+
+		err = ss.OAuth().RemoveExpiredDeviceAuthorizations(model.GetMillis())
+		require.NoError(t, err)
+
+		_, err = ss.OAuth().GetDeviceAuthorization(expired.DeviceCode)
+		require.Error(t, err)
+		_, err = ss.OAuth().GetDeviceAuthorization(pending.DeviceCode)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestOutgoingEmailStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testOutgoingEmailSaveGetDelete(t, rctx, ss) })
+	t.Run("Update", func(t *testing.T) { testOutgoingEmailUpdate(t, rctx, ss) })
+	t.Run("ClaimPending", func(t *testing.T) { testOutgoingEmailClaimPending(t, rctx, ss) })
+	t.Run("GetByStatus", func(t *testing.T) { testOutgoingEmailGetByStatus(t, rctx, ss) })
+}
+
+func newTestOutgoingEmail() *model.OutgoingEmail {
+	return &model.OutgoingEmail{
+		To:         model.NewId() + "@example.com",
+		Subject:    "Subject " + model.NewId(),
+		HTMLBody:   "<p>body</p>",
+		References: "<ref@example.com>",
+		Category:   "test",
+	}
+}
+
+func cleanupOutgoingEmails(t *testing.T, ss store.Store) {
+	for _, status := range []string{model.OutgoingEmailStatusPending, model.OutgoingEmailStatusDead} {
+		emails, err := ss.OutgoingEmail().GetByStatus(status, 0, 1000)
+		require.NoError(t, err)
+		for _, email := range emails {
+			require.NoError(t, ss.OutgoingEmail().Delete(email.Id))
+		}
+	}
+}
+
+func testOutgoingEmailSaveGetDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Cleanup(func() { cleanupOutgoingEmails(t, ss) })
+
+	email, err := ss.OutgoingEmail().Save(newTestOutgoingEmail())
+	require.NoError(t, err)
+	require.NotEmpty(t, email.Id)
+	assert.Equal(t, model.OutgoingEmailStatusPending, email.Status)
+	assert.Equal(t, email.CreateAt, email.NextAttemptAt)
+
+	t.Run("invalid email", func(t *testing.T) {
+		_, err := ss.OutgoingEmail().Save(&model.OutgoingEmail{Subject: "no recipient"})
+		require.Error(t, err)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		got, err := ss.OutgoingEmail().Get(email.Id)
+		require.NoError(t, err)
+		assert.Equal(t, email, got)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		_, err := ss.OutgoingEmail().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, ss.OutgoingEmail().Delete(email.Id))
+
+		_, err := ss.OutgoingEmail().Get(email.Id)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		err = ss.OutgoingEmail().Delete(email.Id)
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testOutgoingEmailUpdate(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Cleanup(func() { cleanupOutgoingEmails(t, ss) })
+
+	email, err := ss.OutgoingEmail().Save(newTestOutgoingEmail())
+	require.NoError(t, err)
+
+	email.Attempts = 3
+	email.Status = model.OutgoingEmailStatusDead
+	email.LastError = "connection refused"
+	email.NextAttemptAt = email.CreateAt + 1000
+	_, err = ss.OutgoingEmail().Update(email)
+	require.NoError(t, err)
+
+	got, err := ss.OutgoingEmail().Get(email.Id)
+	require.NoError(t, err)
+	assert.Equal(t, 3, got.Attempts)
+	assert.Equal(t, model.OutgoingEmailStatusDead, got.Status)
+	assert.Equal(t, "connection refused", got.LastError)
+	assert.Equal(t, email.CreateAt+1000, got.NextAttemptAt)
+
+	t.Run("missing email", func(t *testing.T) {
+		missing := newTestOutgoingEmail()
+		missing.PreSave()
+		_, err := ss.OutgoingEmail().Update(missing)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testOutgoingEmailClaimPending(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Cleanup(func() { cleanupOutgoingEmails(t, ss) })
+
+	now := model.GetMillis()
+
+	due1 := newTestOutgoingEmail()
+	due1.NextAttemptAt = now - 2000
+	due1, err := ss.OutgoingEmail().Save(due1)
+	require.NoError(t, err)
+
+	due2 := newTestOutgoingEmail()
+	due2.NextAttemptAt = now - 1000
+	due2, err = ss.OutgoingEmail().Save(due2)
+	require.NoError(t, err)
+
+	future := newTestOutgoingEmail()
+	future.NextAttemptAt = now + 60000
+	_, err = ss.OutgoingEmail().Save(future)
+	require.NoError(t, err)
+
+	dead := newTestOutgoingEmail()
+	dead.Status = model.OutgoingEmailStatusDead
+	dead.NextAttemptAt = now - 1000
+	_, err = ss.OutgoingEmail().Save(dead)
+	require.NoError(t, err)
+
+	claimed, err := ss.OutgoingEmail().ClaimPending(now, 30000, 1)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, due1.Id, claimed[0].Id)
+	assert.Equal(t, due1.To, claimed[0].To)
+	assert.Equal(t, due1.References, claimed[0].References)
+	assert.Equal(t, now+30000, claimed[0].NextAttemptAt)
+
+	claimed, err = ss.OutgoingEmail().ClaimPending(now, 30000, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, due2.Id, claimed[0].Id)
+
+	// Everything due has been leased.
+	claimed, err = ss.OutgoingEmail().ClaimPending(now, 30000, 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	// Once the lease expires the emails can be claimed again.
+	claimed, err = ss.OutgoingEmail().ClaimPending(now+30000, 30000, 10)
+	require.NoError(t, err)
+	assert.Len(t, claimed, 2)
+}
+
+func testOutgoingEmailGetByStatus(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Cleanup(func() { cleanupOutgoingEmails(t, ss) })
+
+	var dead []*model.OutgoingEmail
+	for i := range 3 {
+		email := newTestOutgoingEmail()
+		email.Status = model.OutgoingEmailStatusDead
+		email.CreateAt = int64(1000 + i)
+		email, err := ss.OutgoingEmail().Save(email)
+		require.NoError(t, err)
+		dead = append(dead, email)
+	}
+	_, err := ss.OutgoingEmail().Save(newTestOutgoingEmail())
+	require.NoError(t, err)
+
+	emails, err := ss.OutgoingEmail().GetByStatus(model.OutgoingEmailStatusDead, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, emails, 2)
+	assert.Equal(t, dead[0].Id, emails[0].Id)
+	assert.Equal(t, dead[1].Id, emails[1].Id)
+
+	emails, err = ss.OutgoingEmail().GetByStatus(model.OutgoingEmailStatusDead, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+	assert.Equal(t, dead[2].Id, emails[0].Id)
+
+	count, err := ss.OutgoingEmail().CountByStatus(model.OutgoingEmailStatusDead)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	count, err = ss.OutgoingEmail().CountByStatus(model.OutgoingEmailStatusPending)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
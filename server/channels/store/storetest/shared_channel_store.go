@@ -45,6 +45,10 @@ func TestSharedChannelStore(t *testing.T, rctx request.CTX, ss store.Store, s Sq
 	t.Run("UpsertSharedChannelAttachment", func(t *testing.T) { testUpsertSharedChannelAttachment(t, rctx, ss) })
 	t.Run("GetSharedChannelAttachment", func(t *testing.T) { testGetSharedChannelAttachment(t, rctx, ss) })
 	t.Run("UpdateSharedChannelAttachmentLastSyncAt", func(t *testing.T) { testUpdateSharedChannelAttachmentLastSyncAt(t, rctx, ss) })
+
+	t.Run("SharedChannelContentPolicy", func(t *testing.T) { testSharedChannelContentPolicy(t, rctx, ss) })
+	t.Run("SharedChannelHeldPosts", func(t *testing.T) { testSharedChannelHeldPosts(t, rctx, ss) })
+	t.Run("SharedChannelFilterLog", func(t *testing.T) { testSharedChannelFilterLog(t, rctx, ss) })
 }
 
 func testSaveSharedChannel(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -1457,3 +1461,153 @@ func testUpdateSharedChannelAttachmentLastSyncAt(t *testing.T, rctx request.CTX,
 		require.Error(t, err, "update non-existent attachment should error", err)
 	})
 }
+
+func testSharedChannelContentPolicy(t *testing.T, rctx request.CTX, ss store.Store) {
+	remoteID := model.NewId()
+
+	t.Run("Get non-existent content policy", func(t *testing.T) {
+		policy, err := ss.SharedChannel().GetContentPolicy(remoteID)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+		require.Nil(t, policy)
+	})
+
+	t.Run("Save invalid content policy", func(t *testing.T) {
+		_, err := ss.SharedChannel().SaveContentPolicy(&model.SharedChannelContentPolicy{
+			RemoteId:       remoteID,
+			RedactPatterns: model.StringArray{"TICKET-("},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("Save, update and delete content policy", func(t *testing.T) {
+		saved, err := ss.SharedChannel().SaveContentPolicy(&model.SharedChannelContentPolicy{
+			RemoteId:         remoteID,
+			BlockedFileTypes: model.StringArray{"exe", "video/*"},
+			MaxFileSize:      1024,
+			RedactPatterns:   model.StringArray{`TICKET-\d+`},
+			StripPermalinks:  true,
+			CreatorId:        model.NewId(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, model.StringArray{"exe", "video/*"}, saved.BlockedFileTypes)
+		assert.Equal(t, model.SharedChannelContentPolicyDefaultReplacement, saved.RedactReplacement)
+
+		policy, err := ss.SharedChannel().GetContentPolicy(remoteID)
+		require.NoError(t, err)
+		assert.Equal(t, saved, policy)
+
+		policy.RequireApproval = true
+		policy.RedactPatterns = nil
+		policy.CreatorId = model.NewId()
+		updated, err := ss.SharedChannel().SaveContentPolicy(policy)
+		require.NoError(t, err)
+		assert.True(t, updated.RequireApproval)
+		assert.Empty(t, updated.RedactPatterns)
+		assert.Equal(t, saved.CreatorId, updated.CreatorId, "creator should not change on update")
+		assert.Equal(t, saved.CreateAt, updated.CreateAt)
+
+		deleted, err := ss.SharedChannel().DeleteContentPolicy(remoteID)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+
+		deleted, err = ss.SharedChannel().DeleteContentPolicy(remoteID)
+		require.NoError(t, err)
+		assert.False(t, deleted)
+	})
+}
+
+func testSharedChannelHeldPosts(t *testing.T, rctx request.CTX, ss store.Store) {
+	remoteID := model.NewId()
+	channelID := model.NewId()
+
+	var held []*model.SharedChannelHeldPost
+	for i := range 3 {
+		h, err := ss.SharedChannel().SaveHeldPost(&model.SharedChannelHeldPost{
+			PostId:    model.NewId(),
+			RemoteId:  remoteID,
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			CreateAt:  model.GetMillis() + int64(i),
+		})
+		require.NoError(t, err)
+		held = append(held, h)
+	}
+
+	t.Run("Get held post", func(t *testing.T) {
+		h, err := ss.SharedChannel().GetHeldPost(held[0].PostId, remoteID)
+		require.NoError(t, err)
+		assert.Equal(t, held[0], h)
+		assert.Equal(t, model.SharedChannelHeldPostStatusPending, h.Status)
+
+		_, err = ss.SharedChannel().GetHeldPost(held[0].PostId, model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("Update held post status", func(t *testing.T) {
+		h := *held[1]
+		h.Status = model.SharedChannelHeldPostStatusApproved
+		h.ReviewerId = model.NewId()
+		h.ReviewedAt = model.GetMillis()
+		_, err := ss.SharedChannel().SaveHeldPost(&h)
+		require.NoError(t, err)
+
+		updated, err := ss.SharedChannel().GetHeldPost(h.PostId, remoteID)
+		require.NoError(t, err)
+		assert.Equal(t, &h, updated)
+	})
+
+	t.Run("Get held posts by status", func(t *testing.T) {
+		pending, err := ss.SharedChannel().GetHeldPosts(remoteID, model.SharedChannelHeldPostStatusPending, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		assert.Equal(t, held[0].PostId, pending[0].PostId)
+		assert.Equal(t, held[2].PostId, pending[1].PostId)
+
+		all, err := ss.SharedChannel().GetHeldPosts(remoteID, "", 0, 10)
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+
+		page, err := ss.SharedChannel().GetHeldPosts(remoteID, "", 2, 10)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, held[2].PostId, page[0].PostId)
+	})
+}
+
+func testSharedChannelFilterLog(t *testing.T, rctx request.CTX, ss store.Store) {
+	remoteID := model.NewId()
+
+	var entries []*model.SharedChannelFilterLogEntry
+	for i, action := range []string{model.SharedChannelFilterActionRedact, model.SharedChannelFilterActionBlockFile, model.SharedChannelFilterActionHold} {
+		entry, err := ss.SharedChannel().SaveFilterLogEntry(&model.SharedChannelFilterLogEntry{
+			RemoteId:  remoteID,
+			ChannelId: model.NewId(),
+			PostId:    model.NewId(),
+			Action:    action,
+			Details:   "details " + strconv.Itoa(i),
+			CreateAt:  model.GetMillis() + int64(i),
+		})
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+
+	_, err := ss.SharedChannel().SaveFilterLogEntry(&model.SharedChannelFilterLogEntry{RemoteId: remoteID})
+	require.Error(t, err, "entries without an action are invalid")
+
+	log, err := ss.SharedChannel().GetFilterLog(remoteID, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, log, 2)
+	assert.Equal(t, entries[2], log[0])
+	assert.Equal(t, entries[1], log[1])
+
+	log, err = ss.SharedChannel().GetFilterLog(remoteID, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, log, 1)
+	assert.Equal(t, entries[0], log[0])
+
+	log, err = ss.SharedChannel().GetFilterLog(model.NewId(), 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, log)
+}
@@ -75,6 +75,7 @@ func TestTeamStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("GetTeamsForUserWithPagination", func(t *testing.T) { testTeamMembersWithPagination(t, rctx, ss) })
 	t.Run("GroupSyncedTeamCount", func(t *testing.T) { testGroupSyncedTeamCount(t, rctx, ss) })
 	t.Run("GetCommonTeamIDsForMultipleUsers", func(t *testing.T) { testGetCommonTeamIDsForMultipleUsers(t, rctx, ss) })
+	t.Run("InviteLinks", func(t *testing.T) { testTeamStoreInviteLinks(t, rctx, ss) })
 }
 
 func testTeamStoreSave(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -3818,3 +3819,111 @@ func testGetCommonTeamIDsForMultipleUsers(t *testing.T, rctx request.CTX, ss sto
 		require.NoError(t, err)
 	})
 }
+
+func testTeamStoreInviteLinks(t *testing.T, rctx request.CTX, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "Invite links",
+		Name:        NewTestID(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, ss.Team().PermanentDelete(team.Id))
+	}()
+
+	channelID := model.NewId()
+	link, err := ss.Team().SaveInviteLink(&model.TeamInviteLink{
+		TeamId:     team.Id,
+		CreatorId:  model.NewId(),
+		Name:       "Conference",
+		ChannelIds: model.StringArray{channelID},
+		MaxUses:    2,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, link.Id)
+
+	t.Run("get", func(t *testing.T) {
+		got, err := ss.Team().GetInviteLink(link.Id)
+		require.NoError(t, err)
+		assert.Equal(t, link.Name, got.Name)
+		assert.Equal(t, model.TeamInviteLinkRoleMember, got.Role)
+		assert.Equal(t, model.StringArray{channelID}, got.ChannelIds)
+
+		_, err = ss.Team().GetInviteLink(model.NewId())
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("claim uses up to the maximum", func(t *testing.T) {
+		now := model.GetMillis()
+		require.NoError(t, ss.Team().ClaimInviteLinkUse(link.Id, now))
+		require.NoError(t, ss.Team().ClaimInviteLinkUse(link.Id, now))
+
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, ss.Team().ClaimInviteLinkUse(link.Id, now), &nfErr)
+
+		require.NoError(t, ss.Team().ReleaseInviteLinkUse(link.Id))
+		got, err := ss.Team().GetInviteLink(link.Id)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, got.UseCount)
+	})
+
+	t.Run("expired links can't be claimed", func(t *testing.T) {
+		expired, err := ss.Team().SaveInviteLink(&model.TeamInviteLink{
+			TeamId:    team.Id,
+			CreatorId: model.NewId(),
+			Name:      "Expired",
+			ExpiresAt: model.GetMillis() - 1000,
+		})
+		require.NoError(t, err)
+
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, ss.Team().ClaimInviteLinkUse(expired.Id, model.GetMillis()), &nfErr)
+	})
+
+	t.Run("uses", func(t *testing.T) {
+		userID1 := model.NewId()
+		userID2 := model.NewId()
+		require.NoError(t, ss.Team().SaveInviteLinkUse(&model.TeamInviteLinkUse{LinkId: link.Id, UserId: userID1, UseAt: 1000}))
+		require.NoError(t, ss.Team().SaveInviteLinkUse(&model.TeamInviteLinkUse{LinkId: link.Id, UserId: userID2, UseAt: 2000}))
+
+		uses, err := ss.Team().GetInviteLinkUses(link.Id, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, uses, 2)
+		assert.Equal(t, userID2, uses[0].UserId)
+
+		uses, err = ss.Team().GetInviteLinkUses(link.Id, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, uses, 1)
+		assert.Equal(t, userID1, uses[0].UserId)
+	})
+
+	t.Run("update and revoke", func(t *testing.T) {
+		got, err := ss.Team().GetInviteLink(link.Id)
+		require.NoError(t, err)
+
+		got.Name = "Workshop"
+		got.DeleteAt = model.GetMillis()
+		got.UseCount = 100
+		_, err = ss.Team().UpdateInviteLink(got)
+		require.NoError(t, err)
+
+		got, err = ss.Team().GetInviteLink(link.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "Workshop", got.Name)
+		assert.EqualValues(t, 1, got.UseCount)
+
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, ss.Team().ClaimInviteLinkUse(link.Id, model.GetMillis()), &nfErr)
+
+		links, err := ss.Team().GetInviteLinksForTeam(team.Id, false)
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "Expired", links[0].Name)
+
+		links, err = ss.Team().GetInviteLinksForTeam(team.Id, true)
+		require.NoError(t, err)
+		assert.Len(t, links, 2)
+	})
+}
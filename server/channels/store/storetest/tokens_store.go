@@ -17,6 +17,7 @@ import (
 func TestTokensStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("TokensCleanup", func(t *testing.T) { testTokensCleanup(t, rctx, ss) })
 	t.Run("ConsumeOnce", func(t *testing.T) { testConsumeOnce(t, rctx, ss) })
+	t.Run("RemoveTokensByTypeAndUserId", func(t *testing.T) { testRemoveTokensByTypeAndUserId(t, rctx, ss) })
 }
 
 func testTokensCleanup(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -169,3 +170,35 @@ func testConsumeOnce(t *testing.T, rctx request.CTX, ss store.Store) {
 		require.NoError(t, err)
 	})
 }
+
+func testRemoveTokensByTypeAndUserId(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	newToken := func(t *testing.T, tokenType, extra string) *model.Token {
+		t.Helper()
+
+		token := model.NewToken(tokenType, extra)
+		require.NoError(t, ss.Token().Save(token))
+		return token
+	}
+
+	userToken := newToken(t, model.TokenTypeOAuth, model.MapToJSON(map[string]string{"UserId": userID}))
+	otherUserToken := newToken(t, model.TokenTypeOAuth, model.MapToJSON(map[string]string{"UserId": model.NewId()}))
+	otherTypeToken := newToken(t, model.TokenTypeSSOCodeExchange, model.MapToJSON(map[string]string{"UserId": userID}))
+	notJSONToken := newToken(t, model.TokenTypeSSOCodeExchange, "not-json")
+	defer func() {
+		for _, token := range []*model.Token{otherUserToken, otherTypeToken, notJSONToken} {
+			require.NoError(t, ss.Token().Delete(token.Token))
+		}
+	}()
+
+	require.NoError(t, ss.Token().RemoveTokensByTypeAndUserId(model.TokenTypeOAuth, userID))
+
+	_, err := ss.Token().GetByToken(userToken.Token)
+	var nfErr *store.ErrNotFound
+	assert.ErrorAs(t, err, &nfErr)
+
+	for _, token := range []*model.Token{otherUserToken, otherTypeToken, notJSONToken} {
+		_, err := ss.Token().GetByToken(token.Token)
+		assert.NoError(t, err)
+	}
+}
@@ -98,6 +98,7 @@ func TestUserStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("PromoteGuestToUser", func(t *testing.T) { testUserStorePromoteGuestToUser(t, rctx, ss) })
 	t.Run("DemoteUserToGuest", func(t *testing.T) { testUserStoreDemoteUserToGuest(t, rctx, ss) })
 	t.Run("DeactivateGuests", func(t *testing.T) { testDeactivateGuests(t, rctx, ss) })
+	t.Run("GuestAccessExpiries", func(t *testing.T) { testUserStoreGuestAccessExpiries(t, rctx, ss) })
 	t.Run("ResetLastPictureUpdate", func(t *testing.T) { testUserStoreResetLastPictureUpdate(t, rctx, ss) })
 	t.Run("GetKnownUsers", func(t *testing.T) { testGetKnownUsers(t, rctx, ss) })
 	t.Run("GetUsersWithInvalidEmails", func(t *testing.T) { testGetUsersWithInvalidEmails(t, rctx, ss) })
@@ -654,6 +655,47 @@ func testUserStoreGetAllProfiles(t *testing.T, rctx request.CTX, ss store.Store)
 		}, actual)
 	})
 
+	t.Run("exclude bots", func(t *testing.T) {
+		actual, userErr := ss.User().GetAllProfiles(&model.UserGetOptions{
+			Page:        0,
+			PerPage:     10,
+			Active:      true,
+			ExcludeBots: true,
+		})
+		require.NoError(t, userErr)
+		require.Equal(t, []*model.User{
+			sanitized(u1),
+			sanitized(u2),
+			sanitized(u4),
+			sanitized(u5),
+		}, actual)
+	})
+
+	t.Run("exclude remote users", func(t *testing.T) {
+		remoteUser, userErr := ss.User().Save(rctx, &model.User{
+			Email:    MakeEmail(),
+			Username: "u8" + model.NewId(),
+			RemoteId: model.NewPointer(model.NewId()),
+		})
+		require.NoError(t, userErr)
+		defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, remoteUser.Id)) }()
+
+		actual, userErr := ss.User().GetAllProfiles(&model.UserGetOptions{
+			Page:          0,
+			PerPage:       10,
+			Active:        true,
+			ExcludeRemote: true,
+		})
+		require.NoError(t, userErr)
+		require.Equal(t, []*model.User{
+			sanitized(u1),
+			sanitized(u2),
+			sanitized(u3),
+			sanitized(u4),
+			sanitized(u5),
+		}, actual)
+	})
+
 	t.Run("filter by UpdatedAfter", func(t *testing.T) {
 		// Update a user to ensure we have a recent update time
 		updateTime := model.GetMillis()
@@ -6849,3 +6891,64 @@ func testUserStoreSearchTeamContentFlaggingReviewers(t *testing.T, rctx request.
 		require.Empty(t, users) // No reviewers for different team
 	})
 }
+
+func testUserStoreGuestAccessExpiries(t *testing.T, rctx request.CTX, ss store.Store) {
+	now := model.GetMillis()
+	userID1 := model.NewId()
+	userID2 := model.NewId()
+	t.Cleanup(func() {
+		require.NoError(t, ss.User().DeleteGuestAccessExpiry(userID1))
+		require.NoError(t, ss.User().DeleteGuestAccessExpiry(userID2))
+	})
+
+	_, err := ss.User().GetGuestAccessExpiry(userID1)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = ss.User().SaveGuestAccessExpiry(&model.GuestAccessExpiry{UserId: userID1, ExpiresAt: now + 1000})
+	require.NoError(t, err)
+	_, err = ss.User().SaveGuestAccessExpiry(&model.GuestAccessExpiry{UserId: userID2, ExpiresAt: now - 1000})
+	require.NoError(t, err)
+
+	expiry, err := ss.User().GetGuestAccessExpiry(userID1)
+	require.NoError(t, err)
+	assert.Equal(t, now+1000, expiry.ExpiresAt)
+
+	t.Run("get the expiries before a time", func(t *testing.T) {
+		expiries, err := ss.User().GetGuestAccessExpiriesBefore(now, false, 10)
+		require.NoError(t, err)
+		require.Len(t, expiries, 1)
+		assert.Equal(t, userID2, expiries[0].UserId)
+
+		expiries, err = ss.User().GetGuestAccessExpiriesBefore(now+1000, false, 10)
+		require.NoError(t, err)
+		require.Len(t, expiries, 2)
+		assert.Equal(t, userID2, expiries[0].UserId)
+		assert.Equal(t, userID1, expiries[1].UserId)
+	})
+
+	t.Run("reminded expiries", func(t *testing.T) {
+		require.NoError(t, ss.User().MarkGuestAccessExpiryReminded(userID1, now))
+
+		expiries, err := ss.User().GetGuestAccessExpiriesBefore(now+1000, true, 10)
+		require.NoError(t, err)
+		require.Len(t, expiries, 1)
+		assert.Equal(t, userID2, expiries[0].UserId)
+
+		// Changing the expiry resets the reminder.
+		_, err = ss.User().SaveGuestAccessExpiry(&model.GuestAccessExpiry{UserId: userID1, ExpiresAt: now + 2000})
+		require.NoError(t, err)
+
+		expiry, err := ss.User().GetGuestAccessExpiry(userID1)
+		require.NoError(t, err)
+		assert.Equal(t, now+2000, expiry.ExpiresAt)
+		assert.Zero(t, expiry.ReminderSentAt)
+	})
+
+	t.Run("delete an expiry", func(t *testing.T) {
+		require.NoError(t, ss.User().DeleteGuestAccessExpiry(userID2))
+
+		_, err := ss.User().GetGuestAccessExpiry(userID2)
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
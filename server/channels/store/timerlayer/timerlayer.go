@@ -41,6 +41,7 @@ type TimerLayer struct {
 	LinkMetadataStore               store.LinkMetadataStore
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
+	OutgoingEmailStore              store.OutgoingEmailStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
 	PluginStore                     store.PluginStore
 	PostStore                       store.PostStore
@@ -161,6 +162,10 @@ func (s *TimerLayer) OAuth() store.OAuthStore {
 	return s.OAuthStore
 }
 
+func (s *TimerLayer) OutgoingEmail() store.OutgoingEmailStore {
+	return s.OutgoingEmailStore
+}
+
 func (s *TimerLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return s.OutgoingOAuthConnectionStore
 }
@@ -391,6 +396,11 @@ type TimerLayerOAuthStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerOutgoingEmailStore struct {
+	store.OutgoingEmailStore
+	Root *TimerLayer
+}
+
 type TimerLayerOutgoingOAuthConnectionStore struct {
 	store.OutgoingOAuthConnectionStore
 	Root *TimerLayer
@@ -3698,6 +3708,22 @@ func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, timestamp int64) error
 	return err
 }
 
+func (s *TimerLayerEmojiStore) DeleteCategory(id string) error {
+	start := time.Now()
+
+	err := s.EmojiStore.DeleteCategory(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.DeleteCategory", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerEmojiStore) Get(rctx request.CTX, id string, allowFromCache bool) (*model.Emoji, error) {
 	start := time.Now()
 
@@ -3714,6 +3740,22 @@ func (s *TimerLayerEmojiStore) Get(rctx request.CTX, id string, allowFromCache b
 	return result, err
 }
 
+func (s *TimerLayerEmojiStore) GetAllCategories() ([]*model.EmojiCategory, error) {
+	start := time.Now()
+
+	result, err := s.EmojiStore.GetAllCategories()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetAllCategories", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerEmojiStore) GetByName(rctx request.CTX, name string, allowFromCache bool) (*model.Emoji, error) {
 	start := time.Now()
 
@@ -3730,6 +3772,38 @@ func (s *TimerLayerEmojiStore) GetByName(rctx request.CTX, name string, allowFro
 	return result, err
 }
 
+func (s *TimerLayerEmojiStore) GetCategory(id string) (*model.EmojiCategory, error) {
+	start := time.Now()
+
+	result, err := s.EmojiStore.GetCategory(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetCategory", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEmojiStore) GetCategoryByName(name string) (*model.EmojiCategory, error) {
+	start := time.Now()
+
+	result, err := s.EmojiStore.GetCategoryByName(name)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetCategoryByName", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
 	start := time.Now()
 
@@ -3778,6 +3852,22 @@ func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 	return result, err
 }
 
+func (s *TimerLayerEmojiStore) SaveCategory(category *model.EmojiCategory) (*model.EmojiCategory, error) {
+	start := time.Now()
+
+	result, err := s.EmojiStore.SaveCategory(category)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.SaveCategory", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
 	start := time.Now()
 
@@ -3794,6 +3884,22 @@ func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 	return result, err
 }
 
+func (s *TimerLayerEmojiStore) Update(emoji *model.Emoji) (*model.Emoji, error) {
+	start := time.Now()
+
+	result, err := s.EmojiStore.Update(emoji)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerFileInfoStore) AttachToPost(rctx request.CTX, fileID string, postID string, channelID string, creatorID string) error {
 	start := time.Now()
 
@@ -5680,6 +5786,38 @@ func (s *TimerLayerOAuthStore) GetAuthorizedApps(userID string, offset int, limi
 	return result, err
 }
 
+func (s *TimerLayerOAuthStore) GetDeviceAuthorization(deviceCode string) (*model.DeviceAuthorization, error) {
+	start := time.Now()
+
+	result, err := s.OAuthStore.GetDeviceAuthorization(deviceCode)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetDeviceAuthorization", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOAuthStore) GetDeviceAuthorizationByUserCode(userCode string) (*model.DeviceAuthorization, error) {
+	start := time.Now()
+
+	result, err := s.OAuthStore.GetDeviceAuthorizationByUserCode(userCode)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetDeviceAuthorizationByUserCode", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerOAuthStore) GetPreviousAccessData(userID string, clientID string) (*model.AccessData, error) {
 	start := time.Now()
 
@@ -5792,10 +5930,10 @@ func (s *TimerLayerOAuthStore) RemoveAuthDataByUserId(userID string) error {
 	return err
 }
 
-func (s *TimerLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+func (s *TimerLayerOAuthStore) RemoveDeviceAuthorization(deviceCode string) error {
 	start := time.Now()
 
-	result, err := s.OAuthStore.SaveAccessData(accessData)
+	err := s.OAuthStore.RemoveDeviceAuthorization(deviceCode)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5803,15 +5941,15 @@ func (s *TimerLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*mo
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveDeviceAuthorization", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+func (s *TimerLayerOAuthStore) RemoveExpiredDeviceAuthorizations(expiredBefore int64) error {
 	start := time.Now()
 
-	result, err := s.OAuthStore.SaveApp(app)
+	err := s.OAuthStore.RemoveExpiredDeviceAuthorizations(expiredBefore)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5819,15 +5957,15 @@ func (s *TimerLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveExpiredDeviceAuthorizations", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+func (s *TimerLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
 	start := time.Now()
 
-	result, err := s.OAuthStore.SaveAuthData(authData)
+	result, err := s.OAuthStore.SaveAccessData(accessData)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5835,15 +5973,15 @@ func (s *TimerLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.Au
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAuthData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAccessData", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+func (s *TimerLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 	start := time.Now()
 
-	result, err := s.OAuthStore.UpdateAccessData(accessData)
+	result, err := s.OAuthStore.SaveApp(app)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5851,15 +5989,15 @@ func (s *TimerLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveApp", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+func (s *TimerLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
 	start := time.Now()
 
-	result, err := s.OAuthStore.UpdateApp(app)
+	result, err := s.OAuthStore.SaveAuthData(authData)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5867,15 +6005,15 @@ func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAuthData", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOutgoingOAuthConnectionStore) DeleteConnection(rctx request.CTX, id string) error {
+func (s *TimerLayerOAuthStore) SaveDeviceAuthorization(authorization *model.DeviceAuthorization) (*model.DeviceAuthorization, error) {
 	start := time.Now()
 
-	err := s.OutgoingOAuthConnectionStore.DeleteConnection(rctx, id)
+	result, err := s.OAuthStore.SaveDeviceAuthorization(authorization)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5883,15 +6021,15 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) DeleteConnection(rctx request.C
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.DeleteConnection", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveDeviceAuthorization", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnection(rctx request.CTX, id string) (*model.OutgoingOAuthConnection, error) {
+func (s *TimerLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
 	start := time.Now()
 
-	result, err := s.OutgoingOAuthConnectionStore.GetConnection(rctx, id)
+	result, err := s.OAuthStore.UpdateAccessData(accessData)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5899,15 +6037,15 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnection(rctx request.CTX,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.GetConnection", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateAccessData", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnections(rctx request.CTX, filters model.OutgoingOAuthConnectionGetConnectionsFilter) ([]*model.OutgoingOAuthConnection, error) {
+func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 	start := time.Now()
 
-	result, err := s.OutgoingOAuthConnectionStore.GetConnections(rctx, filters)
+	result, err := s.OAuthStore.UpdateApp(app)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5915,15 +6053,15 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnections(rctx request.CTX
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.GetConnections", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateApp", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOutgoingOAuthConnectionStore) SaveConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+func (s *TimerLayerOAuthStore) UpdateDeviceAuthorizationPoll(deviceCode string, lastPolledAt int64, pollInterval int32) error {
 	start := time.Now()
 
-	result, err := s.OutgoingOAuthConnectionStore.SaveConnection(rctx, conn)
+	err := s.OAuthStore.UpdateDeviceAuthorizationPoll(deviceCode, lastPolledAt, pollInterval)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5931,15 +6069,15 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) SaveConnection(rctx request.CTX
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.SaveConnection", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateDeviceAuthorizationPoll", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+func (s *TimerLayerOAuthStore) UpdateDeviceAuthorizationStatus(deviceCode string, userID string, status string) error {
 	start := time.Now()
 
-	result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(rctx, conn)
+	err := s.OAuthStore.UpdateDeviceAuthorizationStatus(deviceCode, userID, status)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5947,15 +6085,15 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(rctx request.C
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.UpdateConnection", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateDeviceAuthorizationStatus", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *TimerLayerOutgoingEmailStore) ClaimPending(now int64, leaseMillis int64, limit int) ([]*model.OutgoingEmail, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+	result, err := s.OutgoingEmailStore.ClaimPending(now, leaseMillis, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5963,15 +6101,15 @@ func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, o
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.ClaimPending", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *TimerLayerOutgoingEmailStore) CountByStatus(status string) (int64, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+	result, err := s.OutgoingEmailStore.CountByStatus(status)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5979,15 +6117,15 @@ func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldV
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSet", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.CountByStatus", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) Delete(pluginID string, key string) error {
+func (s *TimerLayerOutgoingEmailStore) Delete(id string) error {
 	start := time.Now()
 
-	err := s.PluginStore.Delete(pluginID, key)
+	err := s.OutgoingEmailStore.Delete(id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5995,15 +6133,15 @@ func (s *TimerLayerPluginStore) Delete(pluginID string, key string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerPluginStore) DeleteAllExpired() error {
+func (s *TimerLayerOutgoingEmailStore) Get(id string) (*model.OutgoingEmail, error) {
 	start := time.Now()
 
-	err := s.PluginStore.DeleteAllExpired()
+	result, err := s.OutgoingEmailStore.Get(id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6011,15 +6149,15 @@ func (s *TimerLayerPluginStore) DeleteAllExpired() error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllExpired", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.Get", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
+func (s *TimerLayerOutgoingEmailStore) GetByStatus(status string, offset int, limit int) ([]*model.OutgoingEmail, error) {
 	start := time.Now()
 
-	err := s.PluginStore.DeleteAllForPlugin(PluginID)
+	result, err := s.OutgoingEmailStore.GetByStatus(status, offset, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6027,15 +6165,15 @@ func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllForPlugin", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.GetByStatus", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
+func (s *TimerLayerOutgoingEmailStore) Save(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.Get(pluginID, key)
+	result, err := s.OutgoingEmailStore.Save(email)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6043,15 +6181,15 @@ func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginK
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
+func (s *TimerLayerOutgoingEmailStore) Update(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.List(pluginID, page, perPage)
+	result, err := s.OutgoingEmailStore.Update(email)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6059,15 +6197,15 @@ func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.List", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.Update", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+func (s *TimerLayerOutgoingOAuthConnectionStore) DeleteConnection(rctx request.CTX, id string) error {
 	start := time.Now()
 
-	result, err := s.PluginStore.SaveOrUpdate(keyVal)
+	err := s.OutgoingOAuthConnectionStore.DeleteConnection(rctx, id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6075,15 +6213,15 @@ func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*mod
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SaveOrUpdate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.DeleteConnection", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnection(rctx request.CTX, id string) (*model.OutgoingOAuthConnection, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.SetWithOptions(pluginID, key, value, options)
+	result, err := s.OutgoingOAuthConnectionStore.GetConnection(rctx, id)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6091,15 +6229,15 @@ func (s *TimerLayerPluginStore) SetWithOptions(pluginID string, key string, valu
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SetWithOptions", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.GetConnection", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
+func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnections(rctx request.CTX, filters model.OutgoingOAuthConnectionGetConnectionsFilter) ([]*model.OutgoingOAuthConnection, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.AnalyticsPostCount(options)
+	result, err := s.OutgoingOAuthConnectionStore.GetConnections(rctx, filters)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6107,15 +6245,15 @@ func (s *TimerLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.GetConnections", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) AnalyticsPostCountByTeam(teamID string) (int64, error) {
+func (s *TimerLayerOutgoingOAuthConnectionStore) SaveConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.AnalyticsPostCountByTeam(teamID)
+	result, err := s.OutgoingOAuthConnectionStore.SaveConnection(rctx, conn)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6123,15 +6261,15 @@ func (s *TimerLayerPostStore) AnalyticsPostCountByTeam(teamID string) (int64, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCountByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.SaveConnection", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+	result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(rctx, conn)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6139,15 +6277,15 @@ func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsP
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCountsByDay", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.UpdateConnection", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error) {
+func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
+	result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6155,30 +6293,31 @@ func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsUserCountsWithPostsByDay", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndDelete", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) ClearCaches() {
+func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	start := time.Now()
 
-	s.PostStore.ClearCaches()
+	result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ClearCaches", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSet", success, elapsed)
 	}
+	return result, err
 }
 
-func (s *TimerLayerPostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
+func (s *TimerLayerPluginStore) Delete(pluginID string, key string) error {
 	start := time.Now()
 
-	err := s.PostStore.Delete(rctx, postID, timestamp, deleteByID)
+	err := s.PluginStore.Delete(pluginID, key)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6186,15 +6325,15 @@ func (s *TimerLayerPostStore) Delete(rctx request.CTX, postID string, timestamp
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerPostStore) DeleteAllPostRemindersForPost(postId string) error {
+func (s *TimerLayerPluginStore) DeleteAllExpired() error {
 	start := time.Now()
 
-	err := s.PostStore.DeleteAllPostRemindersForPost(postId)
+	err := s.PluginStore.DeleteAllExpired()
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6202,15 +6341,15 @@ func (s *TimerLayerPostStore) DeleteAllPostRemindersForPost(postId string) error
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.DeleteAllPostRemindersForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllExpired", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerPostStore) Get(rctx request.CTX, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
 	start := time.Now()
 
-	result, err := s.PostStore.Get(rctx, id, opts, userID, sanitizeOptions)
+	err := s.PluginStore.DeleteAllForPlugin(PluginID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6218,9 +6357,200 @@ func (s *TimerLayerPostStore) Get(rctx request.CTX, id string, opts model.GetPos
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllForPlugin", success, elapsed)
 	}
-	return result, err
+	return err
+}
+
+func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.Get(pluginID, key)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.List(pluginID, page, perPage)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.List", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.SaveOrUpdate(keyVal)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SaveOrUpdate", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.SetWithOptions(pluginID, key, value, options)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SetWithOptions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.AnalyticsPostCount(options)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCount", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) AnalyticsPostCountByTeam(teamID string) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.AnalyticsPostCountByTeam(teamID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCountByTeam", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCountsByDay", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsUserCountsWithPostsByDay", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) ClearCaches() {
+	start := time.Now()
+
+	s.PostStore.ClearCaches()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ClearCaches", success, elapsed)
+	}
+}
+
+func (s *TimerLayerPostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
+	start := time.Now()
+
+	err := s.PostStore.Delete(rctx, postID, timestamp, deleteByID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostStore) DeleteAllPostRemindersForPost(postId string) error {
+	start := time.Now()
+
+	err := s.PostStore.DeleteAllPostRemindersForPost(postId)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.DeleteAllPostRemindersForPost", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostStore) Get(rctx request.CTX, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.Get(rctx, id, opts, userID, sanitizeOptions)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Get", success, elapsed)
+	}
+	return result, err
 }
 
 func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
@@ -9246,6 +9576,22 @@ func (s *TimerLayerSharedChannelStore) Delete(channelID string) (bool, error) {
 	return result, err
 }
 
+func (s *TimerLayerSharedChannelStore) DeleteContentPolicy(remoteID string) (bool, error) {
+	start := time.Now()
+
+	result, err := s.SharedChannelStore.DeleteContentPolicy(remoteID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.DeleteContentPolicy", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerSharedChannelStore) DeleteRemote(remoteID string) (bool, error) {
 	start := time.Now()
 
@@ -9326,6 +9672,70 @@ func (s *TimerLayerSharedChannelStore) GetAttachment(fileID string, remoteID str
 	return result, err
 }
 
+func (s *TimerLayerSharedChannelStore) GetContentPolicy(remoteID string) (*model.SharedChannelContentPolicy, error) {
+	start := time.Now()
+
+	result, err := s.SharedChannelStore.GetContentPolicy(remoteID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetContentPolicy", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetFilterLog(remoteID string, offset int, limit int) ([]*model.SharedChannelFilterLogEntry, error) {
+	start := time.Now()
+
+	result, err := s.SharedChannelStore.GetFilterLog(remoteID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetFilterLog", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetHeldPost(postID string, remoteID string) (*model.SharedChannelHeldPost, error) {
+	start := time.Now()
+
+	result, err := s.SharedChannelStore.GetHeldPost(postID, remoteID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetHeldPost", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetHeldPosts(remoteID string, status string, offset int, limit int) ([]*model.SharedChannelHeldPost, error) {
+	start := time.Now()
+
+	result, err := s.SharedChannelStore.GetHeldPosts(remoteID, status, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetHeldPosts", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerSharedChannelStore) GetRemote(id string) (*model.SharedChannelRemote, error) {
 	start := time.Now()
 
@@ -9534,6 +9944,54 @@ func (s *TimerLayerSharedChannelStore) SaveAttachment(remote *model.SharedChanne
 	return result, err
 }
 
+func (s *TimerLayerSharedChannelStore) SaveContentPolicy(policy *model.SharedChannelContentPolicy) (*model.SharedChannelContentPolicy, error) {
+	start := time.Now()
+
+	result, err := s.SharedChannelStore.SaveContentPolicy(policy)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.SaveContentPolicy", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) SaveFilterLogEntry(entry *model.SharedChannelFilterLogEntry) (*model.SharedChannelFilterLogEntry, error) {
+	start := time.Now()
+
+	result, err := s.SharedChannelStore.SaveFilterLogEntry(entry)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.SaveFilterLogEntry", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) SaveHeldPost(held *model.SharedChannelHeldPost) (*model.SharedChannelHeldPost, error) {
+	start := time.Now()
+
+	result, err := s.SharedChannelStore.SaveHeldPost(held)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.SaveHeldPost", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
 	start := time.Now()
 
@@ -9966,6 +10424,22 @@ func (s *TimerLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64,
 	return result, err
 }
 
+func (s *TimerLayerTeamStore) ClaimInviteLinkUse(id string, now int64) error {
+	start := time.Now()
+
+	err := s.TeamStore.ClaimInviteLinkUse(id, now)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClaimInviteLinkUse", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerTeamStore) ClearAllCustomRoleAssignments() error {
 	start := time.Now()
 
@@ -10237,6 +10711,54 @@ func (s *TimerLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUs
 	return result, err
 }
 
+func (s *TimerLayerTeamStore) GetInviteLink(id string) (*model.TeamInviteLink, error) {
+	start := time.Now()
+
+	result, err := s.TeamStore.GetInviteLink(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetInviteLink", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerTeamStore) GetInviteLinkUses(linkID string, offset int, limit int) ([]*model.TeamInviteLinkUse, error) {
+	start := time.Now()
+
+	result, err := s.TeamStore.GetInviteLinkUses(linkID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetInviteLinkUses", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerTeamStore) GetInviteLinksForTeam(teamID string, includeDeleted bool) ([]*model.TeamInviteLink, error) {
+	start := time.Now()
+
+	result, err := s.TeamStore.GetInviteLinksForTeam(teamID, includeDeleted)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetInviteLinksForTeam", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
 	start := time.Now()
 
@@ -10476,6 +10998,22 @@ func (s *TimerLayerTeamStore) PermanentDelete(teamID string) error {
 	return err
 }
 
+func (s *TimerLayerTeamStore) ReleaseInviteLinkUse(id string) error {
+	start := time.Now()
+
+	err := s.TeamStore.ReleaseInviteLinkUse(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ReleaseInviteLinkUse", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
 	start := time.Now()
 
@@ -10572,6 +11110,38 @@ func (s *TimerLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
 	return result, err
 }
 
+func (s *TimerLayerTeamStore) SaveInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
+	start := time.Now()
+
+	result, err := s.TeamStore.SaveInviteLink(link)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SaveInviteLink", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerTeamStore) SaveInviteLinkUse(use *model.TeamInviteLinkUse) error {
+	start := time.Now()
+
+	err := s.TeamStore.SaveInviteLinkUse(use)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SaveInviteLinkUse", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerTeamStore) SaveMember(rctx request.CTX, member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
 	start := time.Now()
 
@@ -10684,6 +11254,22 @@ func (s *TimerLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
 	return result, err
 }
 
+func (s *TimerLayerTeamStore) UpdateInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
+	start := time.Now()
+
+	result, err := s.TeamStore.UpdateInviteLink(link)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateInviteLink", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curTime int64) error {
 	start := time.Now()
 
@@ -11339,6 +11925,22 @@ func (s *TimerLayerTokenStore) RemoveAllTokensByType(tokenType string) error {
 	return err
 }
 
+func (s *TimerLayerTokenStore) RemoveTokensByTypeAndUserId(tokenType string, userID string) error {
+	start := time.Now()
+
+	err := s.TokenStore.RemoveTokensByTypeAndUserId(tokenType, userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.RemoveTokensByTypeAndUserId", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerTokenStore) Save(recovery *model.Token) error {
 	start := time.Now()
 
@@ -11610,6 +12212,22 @@ func (s *TimerLayerUserStore) DeactivateGuests() ([]string, error) {
 	return result, err
 }
 
+func (s *TimerLayerUserStore) DeleteGuestAccessExpiry(userID string) error {
+	start := time.Now()
+
+	err := s.UserStore.DeleteGuestAccessExpiry(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.DeleteGuestAccessExpiry", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerUserStore) DemoteUserToGuest(userID string) (*model.User, error) {
 	start := time.Now()
 
@@ -11898,6 +12516,38 @@ func (s *TimerLayerUserStore) GetForLogin(loginID string, allowSignInWithUsernam
 	return result, err
 }
 
+func (s *TimerLayerUserStore) GetGuestAccessExpiriesBefore(before int64, excludeReminded bool, limit int) ([]*model.GuestAccessExpiry, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.GetGuestAccessExpiriesBefore(before, excludeReminded, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetGuestAccessExpiriesBefore", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerUserStore) GetGuestAccessExpiry(userID string) (*model.GuestAccessExpiry, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.GetGuestAccessExpiry(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetGuestAccessExpiry", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) GetKnownUsers(userID string) ([]string, error) {
 	start := time.Now()
 
@@ -12359,6 +13009,22 @@ func (s *TimerLayerUserStore) IsEmpty(excludeBots bool) (bool, error) {
 	return result, err
 }
 
+func (s *TimerLayerUserStore) MarkGuestAccessExpiryReminded(userID string, remindedAt int64) error {
+	start := time.Now()
+
+	err := s.UserStore.MarkGuestAccessExpiryReminded(userID, remindedAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.MarkGuestAccessExpiryReminded", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerUserStore) PermanentDelete(rctx request.CTX, userID string) error {
 	start := time.Now()
 
@@ -12455,6 +13121,22 @@ func (s *TimerLayerUserStore) Save(rctx request.CTX, user *model.User) (*model.U
 	return result, err
 }
 
+func (s *TimerLayerUserStore) SaveGuestAccessExpiry(expiry *model.GuestAccessExpiry) (*model.GuestAccessExpiry, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.SaveGuestAccessExpiry(expiry)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SaveGuestAccessExpiry", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) Search(rctx request.CTX, teamID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
 	start := time.Now()
 
@@ -13489,6 +14171,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
 	newStore.NotifyAdminStore = &TimerLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OutgoingEmailStore = &TimerLayerOutgoingEmailStore{OutgoingEmailStore: childStore.OutgoingEmail(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &TimerLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
@@ -0,0 +1,1561 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package tracinglayer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
+)
+
+type TracingLayer struct {
+	store.Store
+	AccessControlPolicyStore        store.AccessControlPolicyStore
+	AttributesStore                 store.AttributesStore
+	AuditStore                      store.AuditStore
+	BotStore                        store.BotStore
+	ChannelStore                    store.ChannelStore
+	ChannelBookmarkStore            store.ChannelBookmarkStore
+	ChannelMemberHistoryStore       store.ChannelMemberHistoryStore
+	ClusterDiscoveryStore           store.ClusterDiscoveryStore
+	CommandStore                    store.CommandStore
+	CommandWebhookStore             store.CommandWebhookStore
+	ComplianceStore                 store.ComplianceStore
+	ContentFlaggingStore            store.ContentFlaggingStore
+	DesktopTokensStore              store.DesktopTokensStore
+	DraftStore                      store.DraftStore
+	EmojiStore                      store.EmojiStore
+	FileInfoStore                   store.FileInfoStore
+	GroupStore                      store.GroupStore
+	JobStore                        store.JobStore
+	LicenseStore                    store.LicenseStore
+	LinkMetadataStore               store.LinkMetadataStore
+	NotifyAdminStore                store.NotifyAdminStore
+	OAuthStore                      store.OAuthStore
+	OutgoingEmailStore              store.OutgoingEmailStore
+	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
+	PluginStore                     store.PluginStore
+	PostStore                       store.PostStore
+	PostAcknowledgementStore        store.PostAcknowledgementStore
+	PostPersistentNotificationStore store.PostPersistentNotificationStore
+	PostPriorityStore               store.PostPriorityStore
+	PreferenceStore                 store.PreferenceStore
+	ProductNoticesStore             store.ProductNoticesStore
+	PropertyFieldStore              store.PropertyFieldStore
+	PropertyGroupStore              store.PropertyGroupStore
+	PropertyValueStore              store.PropertyValueStore
+	ReactionStore                   store.ReactionStore
+	RemoteClusterStore              store.RemoteClusterStore
+	RetentionPolicyStore            store.RetentionPolicyStore
+	RoleStore                       store.RoleStore
+	ScheduledPostStore              store.ScheduledPostStore
+	SchemeStore                     store.SchemeStore
+	SessionStore                    store.SessionStore
+	SharedChannelStore              store.SharedChannelStore
+	StatusStore                     store.StatusStore
+	SystemStore                     store.SystemStore
+	TeamStore                       store.TeamStore
+	TermsOfServiceStore             store.TermsOfServiceStore
+	ThreadStore                     store.ThreadStore
+	TokenStore                      store.TokenStore
+	UploadSessionStore              store.UploadSessionStore
+	UserStore                       store.UserStore
+	UserAccessTokenStore            store.UserAccessTokenStore
+	UserTermsOfServiceStore         store.UserTermsOfServiceStore
+	WebhookStore                    store.WebhookStore
+}
+
+func (s *TracingLayer) AccessControlPolicy() store.AccessControlPolicyStore {
+	return s.AccessControlPolicyStore
+}
+
+func (s *TracingLayer) Attributes() store.AttributesStore {
+	return s.AttributesStore
+}
+
+func (s *TracingLayer) Audit() store.AuditStore {
+	return s.AuditStore
+}
+
+func (s *TracingLayer) Bot() store.BotStore {
+	return s.BotStore
+}
+
+func (s *TracingLayer) Channel() store.ChannelStore {
+	return s.ChannelStore
+}
+
+func (s *TracingLayer) ChannelBookmark() store.ChannelBookmarkStore {
+	return s.ChannelBookmarkStore
+}
+
+func (s *TracingLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
+	return s.ChannelMemberHistoryStore
+}
+
+func (s *TracingLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
+	return s.ClusterDiscoveryStore
+}
+
+func (s *TracingLayer) Command() store.CommandStore {
+	return s.CommandStore
+}
+
+func (s *TracingLayer) CommandWebhook() store.CommandWebhookStore {
+	return s.CommandWebhookStore
+}
+
+func (s *TracingLayer) Compliance() store.ComplianceStore {
+	return s.ComplianceStore
+}
+
+func (s *TracingLayer) ContentFlagging() store.ContentFlaggingStore {
+	return s.ContentFlaggingStore
+}
+
+func (s *TracingLayer) DesktopTokens() store.DesktopTokensStore {
+	return s.DesktopTokensStore
+}
+
+func (s *TracingLayer) Draft() store.DraftStore {
+	return s.DraftStore
+}
+
+func (s *TracingLayer) Emoji() store.EmojiStore {
+	return s.EmojiStore
+}
+
+func (s *TracingLayer) FileInfo() store.FileInfoStore {
+	return s.FileInfoStore
+}
+
+func (s *TracingLayer) Group() store.GroupStore {
+	return s.GroupStore
+}
+
+func (s *TracingLayer) Job() store.JobStore {
+	return s.JobStore
+}
+
+func (s *TracingLayer) License() store.LicenseStore {
+	return s.LicenseStore
+}
+
+func (s *TracingLayer) LinkMetadata() store.LinkMetadataStore {
+	return s.LinkMetadataStore
+}
+
+func (s *TracingLayer) NotifyAdmin() store.NotifyAdminStore {
+	return s.NotifyAdminStore
+}
+
+func (s *TracingLayer) OAuth() store.OAuthStore {
+	return s.OAuthStore
+}
+
+func (s *TracingLayer) OutgoingEmail() store.OutgoingEmailStore {
+	return s.OutgoingEmailStore
+}
+
+func (s *TracingLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
+	return s.OutgoingOAuthConnectionStore
+}
+
+func (s *TracingLayer) Plugin() store.PluginStore {
+	return s.PluginStore
+}
+
+func (s *TracingLayer) Post() store.PostStore {
+	return s.PostStore
+}
+
+func (s *TracingLayer) PostAcknowledgement() store.PostAcknowledgementStore {
+	return s.PostAcknowledgementStore
+}
+
+func (s *TracingLayer) PostPersistentNotification() store.PostPersistentNotificationStore {
+	return s.PostPersistentNotificationStore
+}
+
+func (s *TracingLayer) PostPriority() store.PostPriorityStore {
+	return s.PostPriorityStore
+}
+
+func (s *TracingLayer) Preference() store.PreferenceStore {
+	return s.PreferenceStore
+}
+
+func (s *TracingLayer) ProductNotices() store.ProductNoticesStore {
+	return s.ProductNoticesStore
+}
+
+func (s *TracingLayer) PropertyField() store.PropertyFieldStore {
+	return s.PropertyFieldStore
+}
+
+func (s *TracingLayer) PropertyGroup() store.PropertyGroupStore {
+	return s.PropertyGroupStore
+}
+
+func (s *TracingLayer) PropertyValue() store.PropertyValueStore {
+	return s.PropertyValueStore
+}
+
+func (s *TracingLayer) Reaction() store.ReactionStore {
+	return s.ReactionStore
+}
+
+func (s *TracingLayer) RemoteCluster() store.RemoteClusterStore {
+	return s.RemoteClusterStore
+}
+
+func (s *TracingLayer) RetentionPolicy() store.RetentionPolicyStore {
+	return s.RetentionPolicyStore
+}
+
+func (s *TracingLayer) Role() store.RoleStore {
+	return s.RoleStore
+}
+
+func (s *TracingLayer) ScheduledPost() store.ScheduledPostStore {
+	return s.ScheduledPostStore
+}
+
+func (s *TracingLayer) Scheme() store.SchemeStore {
+	return s.SchemeStore
+}
+
+func (s *TracingLayer) Session() store.SessionStore {
+	return s.SessionStore
+}
+
+func (s *TracingLayer) SharedChannel() store.SharedChannelStore {
+	return s.SharedChannelStore
+}
+
+func (s *TracingLayer) Status() store.StatusStore {
+	return s.StatusStore
+}
+
+func (s *TracingLayer) System() store.SystemStore {
+	return s.SystemStore
+}
+
+func (s *TracingLayer) Team() store.TeamStore {
+	return s.TeamStore
+}
+
+func (s *TracingLayer) TermsOfService() store.TermsOfServiceStore {
+	return s.TermsOfServiceStore
+}
+
+func (s *TracingLayer) Thread() store.ThreadStore {
+	return s.ThreadStore
+}
+
+func (s *TracingLayer) Token() store.TokenStore {
+	return s.TokenStore
+}
+
+func (s *TracingLayer) UploadSession() store.UploadSessionStore {
+	return s.UploadSessionStore
+}
+
+func (s *TracingLayer) User() store.UserStore {
+	return s.UserStore
+}
+
+func (s *TracingLayer) UserAccessToken() store.UserAccessTokenStore {
+	return s.UserAccessTokenStore
+}
+
+func (s *TracingLayer) UserTermsOfService() store.UserTermsOfServiceStore {
+	return s.UserTermsOfServiceStore
+}
+
+func (s *TracingLayer) Webhook() store.WebhookStore {
+	return s.WebhookStore
+}
+
+type TracingLayerAccessControlPolicyStore struct {
+	store.AccessControlPolicyStore
+	Root *TracingLayer
+}
+
+type TracingLayerAttributesStore struct {
+	store.AttributesStore
+	Root *TracingLayer
+}
+
+type TracingLayerAuditStore struct {
+	store.AuditStore
+	Root *TracingLayer
+}
+
+type TracingLayerBotStore struct {
+	store.BotStore
+	Root *TracingLayer
+}
+
+type TracingLayerChannelStore struct {
+	store.ChannelStore
+	Root *TracingLayer
+}
+
+type TracingLayerChannelBookmarkStore struct {
+	store.ChannelBookmarkStore
+	Root *TracingLayer
+}
+
+type TracingLayerChannelMemberHistoryStore struct {
+	store.ChannelMemberHistoryStore
+	Root *TracingLayer
+}
+
+type TracingLayerClusterDiscoveryStore struct {
+	store.ClusterDiscoveryStore
+	Root *TracingLayer
+}
+
+type TracingLayerCommandStore struct {
+	store.CommandStore
+	Root *TracingLayer
+}
+
+type TracingLayerCommandWebhookStore struct {
+	store.CommandWebhookStore
+	Root *TracingLayer
+}
+
+type TracingLayerComplianceStore struct {
+	store.ComplianceStore
+	Root *TracingLayer
+}
+
+type TracingLayerContentFlaggingStore struct {
+	store.ContentFlaggingStore
+	Root *TracingLayer
+}
+
+type TracingLayerDesktopTokensStore struct {
+	store.DesktopTokensStore
+	Root *TracingLayer
+}
+
+type TracingLayerDraftStore struct {
+	store.DraftStore
+	Root *TracingLayer
+}
+
+type TracingLayerEmojiStore struct {
+	store.EmojiStore
+	Root *TracingLayer
+}
+
+type TracingLayerFileInfoStore struct {
+	store.FileInfoStore
+	Root *TracingLayer
+}
+
+type TracingLayerGroupStore struct {
+	store.GroupStore
+	Root *TracingLayer
+}
+
+type TracingLayerJobStore struct {
+	store.JobStore
+	Root *TracingLayer
+}
+
+type TracingLayerLicenseStore struct {
+	store.LicenseStore
+	Root *TracingLayer
+}
+
+type TracingLayerLinkMetadataStore struct {
+	store.LinkMetadataStore
+	Root *TracingLayer
+}
+
+type TracingLayerNotifyAdminStore struct {
+	store.NotifyAdminStore
+	Root *TracingLayer
+}
+
+type TracingLayerOAuthStore struct {
+	store.OAuthStore
+	Root *TracingLayer
+}
+
+type TracingLayerOutgoingEmailStore struct {
+	store.OutgoingEmailStore
+	Root *TracingLayer
+}
+
+type TracingLayerOutgoingOAuthConnectionStore struct {
+	store.OutgoingOAuthConnectionStore
+	Root *TracingLayer
+}
+
+type TracingLayerPluginStore struct {
+	store.PluginStore
+	Root *TracingLayer
+}
+
+type TracingLayerPostStore struct {
+	store.PostStore
+	Root *TracingLayer
+}
+
+type TracingLayerPostAcknowledgementStore struct {
+	store.PostAcknowledgementStore
+	Root *TracingLayer
+}
+
+type TracingLayerPostPersistentNotificationStore struct {
+	store.PostPersistentNotificationStore
+	Root *TracingLayer
+}
+
+type TracingLayerPostPriorityStore struct {
+	store.PostPriorityStore
+	Root *TracingLayer
+}
+
+type TracingLayerPreferenceStore struct {
+	store.PreferenceStore
+	Root *TracingLayer
+}
+
+type TracingLayerProductNoticesStore struct {
+	store.ProductNoticesStore
+	Root *TracingLayer
+}
+
+type TracingLayerPropertyFieldStore struct {
+	store.PropertyFieldStore
+	Root *TracingLayer
+}
+
+type TracingLayerPropertyGroupStore struct {
+	store.PropertyGroupStore
+	Root *TracingLayer
+}
+
+type TracingLayerPropertyValueStore struct {
+	store.PropertyValueStore
+	Root *TracingLayer
+}
+
+type TracingLayerReactionStore struct {
+	store.ReactionStore
+	Root *TracingLayer
+}
+
+type TracingLayerRemoteClusterStore struct {
+	store.RemoteClusterStore
+	Root *TracingLayer
+}
+
+type TracingLayerRetentionPolicyStore struct {
+	store.RetentionPolicyStore
+	Root *TracingLayer
+}
+
+type TracingLayerRoleStore struct {
+	store.RoleStore
+	Root *TracingLayer
+}
+
+type TracingLayerScheduledPostStore struct {
+	store.ScheduledPostStore
+	Root *TracingLayer
+}
+
+type TracingLayerSchemeStore struct {
+	store.SchemeStore
+	Root *TracingLayer
+}
+
+type TracingLayerSessionStore struct {
+	store.SessionStore
+	Root *TracingLayer
+}
+
+type TracingLayerSharedChannelStore struct {
+	store.SharedChannelStore
+	Root *TracingLayer
+}
+
+type TracingLayerStatusStore struct {
+	store.StatusStore
+	Root *TracingLayer
+}
+
+type TracingLayerSystemStore struct {
+	store.SystemStore
+	Root *TracingLayer
+}
+
+type TracingLayerTeamStore struct {
+	store.TeamStore
+	Root *TracingLayer
+}
+
+type TracingLayerTermsOfServiceStore struct {
+	store.TermsOfServiceStore
+	Root *TracingLayer
+}
+
+type TracingLayerThreadStore struct {
+	store.ThreadStore
+	Root *TracingLayer
+}
+
+type TracingLayerTokenStore struct {
+	store.TokenStore
+	Root *TracingLayer
+}
+
+type TracingLayerUploadSessionStore struct {
+	store.UploadSessionStore
+	Root *TracingLayer
+}
+
+type TracingLayerUserStore struct {
+	store.UserStore
+	Root *TracingLayer
+}
+
+type TracingLayerUserAccessTokenStore struct {
+	store.UserAccessTokenStore
+	Root *TracingLayer
+}
+
+type TracingLayerUserTermsOfServiceStore struct {
+	store.UserTermsOfServiceStore
+	Root *TracingLayer
+}
+
+type TracingLayerWebhookStore struct {
+	store.WebhookStore
+	Root *TracingLayer
+}
+
+// requestContext returns the context of the request, if any.
+func requestContext(rctx request.CTX) context.Context {
+	if rctx == nil {
+		return nil
+	}
+	return rctx.Context()
+}
+
+// startSpan starts a span for the store method, only if the context is already being traced.
+func startSpan(ctx context.Context, method string) trace.Span {
+	if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+	_, span := tracing.StartSpan(ctx, method, attribute.String("db.operation", method))
+	return span
+}
+
+func endSpan(span trace.Span, err error) {
+	if span != nil {
+		tracing.EndSpan(span, err)
+	}
+}
+
+func (s *TracingLayerAccessControlPolicyStore) Delete(rctx request.CTX, id string) error {
+	span := startSpan(requestContext(rctx), "AccessControlPolicyStore.Delete")
+
+	err := s.AccessControlPolicyStore.Delete(rctx, id)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerAccessControlPolicyStore) Get(rctx request.CTX, id string) (*model.AccessControlPolicy, error) {
+	span := startSpan(requestContext(rctx), "AccessControlPolicyStore.Get")
+
+	result, err := s.AccessControlPolicyStore.Get(rctx, id)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerAccessControlPolicyStore) Save(rctx request.CTX, policy *model.AccessControlPolicy) (*model.AccessControlPolicy, error) {
+	span := startSpan(requestContext(rctx), "AccessControlPolicyStore.Save")
+
+	result, err := s.AccessControlPolicyStore.Save(rctx, policy)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerAccessControlPolicyStore) SearchPolicies(rctx request.CTX, opts model.AccessControlPolicySearch) ([]*model.AccessControlPolicy, int64, error) {
+	span := startSpan(requestContext(rctx), "AccessControlPolicyStore.SearchPolicies")
+
+	result, resultVar1, err := s.AccessControlPolicyStore.SearchPolicies(rctx, opts)
+	endSpan(span, err)
+	return result, resultVar1, err
+
+}
+
+func (s *TracingLayerAccessControlPolicyStore) SetActiveStatus(rctx request.CTX, id string, active bool) (*model.AccessControlPolicy, error) {
+	span := startSpan(requestContext(rctx), "AccessControlPolicyStore.SetActiveStatus")
+
+	result, err := s.AccessControlPolicyStore.SetActiveStatus(rctx, id, active)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerAttributesStore) GetChannelMembersToRemove(rctx request.CTX, channelID string, opts model.SubjectSearchOptions) ([]*model.ChannelMember, error) {
+	span := startSpan(requestContext(rctx), "AttributesStore.GetChannelMembersToRemove")
+
+	result, err := s.AttributesStore.GetChannelMembersToRemove(rctx, channelID, opts)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerAttributesStore) GetSubject(rctx request.CTX, ID string, groupID string) (*model.Subject, error) {
+	span := startSpan(requestContext(rctx), "AttributesStore.GetSubject")
+
+	result, err := s.AttributesStore.GetSubject(rctx, ID, groupID)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerAttributesStore) SearchUsers(rctx request.CTX, opts model.SubjectSearchOptions) ([]*model.User, int64, error) {
+	span := startSpan(requestContext(rctx), "AttributesStore.SearchUsers")
+
+	result, resultVar1, err := s.AttributesStore.SearchUsers(rctx, opts)
+	endSpan(span, err)
+	return result, resultVar1, err
+
+}
+
+func (s *TracingLayerChannelStore) Autocomplete(rctx request.CTX, userID string, term string, includeDeleted bool, isGuest bool) (model.ChannelListWithTeamData, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.Autocomplete")
+
+	result, err := s.ChannelStore.Autocomplete(rctx, userID, term, includeDeleted, isGuest)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) AutocompleteInTeam(rctx request.CTX, teamID string, userID string, term string, includeDeleted bool, isGuest bool) (model.ChannelList, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.AutocompleteInTeam")
+
+	result, err := s.ChannelStore.AutocompleteInTeam(rctx, teamID, userID, term, includeDeleted, isGuest)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) CreateDirectChannel(rctx request.CTX, userID *model.User, otherUserID *model.User, channelOptions ...model.ChannelOption) (*model.Channel, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.CreateDirectChannel")
+
+	result, err := s.ChannelStore.CreateDirectChannel(rctx, userID, otherUserID, channelOptions...)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) CreateInitialSidebarCategories(rctx request.CTX, userID string, teamID string) (*model.OrderedSidebarCategories, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.CreateInitialSidebarCategories")
+
+	result, err := s.ChannelStore.CreateInitialSidebarCategories(rctx, userID, teamID)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) GetAllChannelMembersForUser(rctx request.CTX, userID string, allowFromCache bool, includeDeleted bool) (map[string]string, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.GetAllChannelMembersForUser")
+
+	result, err := s.ChannelStore.GetAllChannelMembersForUser(rctx, userID, allowFromCache, includeDeleted)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) GetChannelsWithUnreadsAndWithMentions(rctx request.CTX, channelIDs []string, userID string, userNotifyProps model.StringMap) ([]string, []string, map[string]int64, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.GetChannelsWithUnreadsAndWithMentions")
+
+	result, resultVar1, resultVar2, err := s.ChannelStore.GetChannelsWithUnreadsAndWithMentions(rctx, channelIDs, userID, userNotifyProps)
+	endSpan(span, err)
+	return result, resultVar1, resultVar2, err
+
+}
+
+func (s *TracingLayerChannelStore) GetMember(rctx request.CTX, channelID string, userID string) (*model.ChannelMember, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.GetMember")
+
+	result, err := s.ChannelStore.GetMember(rctx, channelID, userID)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) GetMemberCountsByGroup(rctx request.CTX, channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.GetMemberCountsByGroup")
+
+	result, err := s.ChannelStore.GetMemberCountsByGroup(rctx, channelID, includeTimezones)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) GetMemberLastViewedAt(rctx request.CTX, channelID string, userID string) (int64, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.GetMemberLastViewedAt")
+
+	result, err := s.ChannelStore.GetMemberLastViewedAt(rctx, channelID, userID)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) GetTeamMembersForChannel(rctx request.CTX, channelID string) ([]string, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.GetTeamMembersForChannel")
+
+	result, err := s.ChannelStore.GetTeamMembersForChannel(rctx, channelID)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) PermanentDelete(rctx request.CTX, channelID string) error {
+	span := startSpan(requestContext(rctx), "ChannelStore.PermanentDelete")
+
+	err := s.ChannelStore.PermanentDelete(rctx, channelID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerChannelStore) PermanentDeleteMembersByChannel(rctx request.CTX, channelID string) error {
+	span := startSpan(requestContext(rctx), "ChannelStore.PermanentDeleteMembersByChannel")
+
+	err := s.ChannelStore.PermanentDeleteMembersByChannel(rctx, channelID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerChannelStore) PermanentDeleteMembersByUser(rctx request.CTX, userID string) error {
+	span := startSpan(requestContext(rctx), "ChannelStore.PermanentDeleteMembersByUser")
+
+	err := s.ChannelStore.PermanentDeleteMembersByUser(rctx, userID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerChannelStore) RemoveAllDeactivatedMembers(rctx request.CTX, channelID string) error {
+	span := startSpan(requestContext(rctx), "ChannelStore.RemoveAllDeactivatedMembers")
+
+	err := s.ChannelStore.RemoveAllDeactivatedMembers(rctx, channelID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerChannelStore) RemoveMember(rctx request.CTX, channelID string, userID string) error {
+	span := startSpan(requestContext(rctx), "ChannelStore.RemoveMember")
+
+	err := s.ChannelStore.RemoveMember(rctx, channelID, userID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerChannelStore) RemoveMembers(rctx request.CTX, channelID string, userIds []string) error {
+	span := startSpan(requestContext(rctx), "ChannelStore.RemoveMembers")
+
+	err := s.ChannelStore.RemoveMembers(rctx, channelID, userIds)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerChannelStore) Save(rctx request.CTX, channel *model.Channel, maxChannelsPerTeam int64, channelOptions ...model.ChannelOption) (*model.Channel, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.Save")
+
+	result, err := s.ChannelStore.Save(rctx, channel, maxChannelsPerTeam, channelOptions...)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) SaveDirectChannel(rctx request.CTX, channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.SaveDirectChannel")
+
+	result, err := s.ChannelStore.SaveDirectChannel(rctx, channel, member1, member2)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) SaveMember(rctx request.CTX, member *model.ChannelMember) (*model.ChannelMember, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.SaveMember")
+
+	result, err := s.ChannelStore.SaveMember(rctx, member)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) Update(rctx request.CTX, channel *model.Channel) (*model.Channel, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.Update")
+
+	result, err := s.ChannelStore.Update(rctx, channel)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerChannelStore) UpdateMember(rctx request.CTX, member *model.ChannelMember) (*model.ChannelMember, error) {
+	span := startSpan(requestContext(rctx), "ChannelStore.UpdateMember")
+
+	result, err := s.ChannelStore.UpdateMember(rctx, member)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerComplianceStore) MessageExport(rctx request.CTX, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
+	span := startSpan(requestContext(rctx), "ComplianceStore.MessageExport")
+
+	result, resultVar1, err := s.ComplianceStore.MessageExport(rctx, cursor, limit)
+	endSpan(span, err)
+	return result, resultVar1, err
+
+}
+
+func (s *TracingLayerEmojiStore) Get(rctx request.CTX, id string, allowFromCache bool) (*model.Emoji, error) {
+	span := startSpan(requestContext(rctx), "EmojiStore.Get")
+
+	result, err := s.EmojiStore.Get(rctx, id, allowFromCache)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerEmojiStore) GetByName(rctx request.CTX, name string, allowFromCache bool) (*model.Emoji, error) {
+	span := startSpan(requestContext(rctx), "EmojiStore.GetByName")
+
+	result, err := s.EmojiStore.GetByName(rctx, name, allowFromCache)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerEmojiStore) GetMultipleByName(rctx request.CTX, names []string) ([]*model.Emoji, error) {
+	span := startSpan(requestContext(rctx), "EmojiStore.GetMultipleByName")
+
+	result, err := s.EmojiStore.GetMultipleByName(rctx, names)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerFileInfoStore) AttachToPost(rctx request.CTX, fileID string, postID string, channelID string, creatorID string) error {
+	span := startSpan(requestContext(rctx), "FileInfoStore.AttachToPost")
+
+	err := s.FileInfoStore.AttachToPost(rctx, fileID, postID, channelID, creatorID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerFileInfoStore) DeleteForPost(rctx request.CTX, postID string) (string, error) {
+	span := startSpan(requestContext(rctx), "FileInfoStore.DeleteForPost")
+
+	result, err := s.FileInfoStore.DeleteForPost(rctx, postID)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerFileInfoStore) DeleteForPostByIds(rctx request.CTX, postId string, fileIDs []string) error {
+	span := startSpan(requestContext(rctx), "FileInfoStore.DeleteForPostByIds")
+
+	err := s.FileInfoStore.DeleteForPostByIds(rctx, postId, fileIDs)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerFileInfoStore) PermanentDelete(rctx request.CTX, fileID string) error {
+	span := startSpan(requestContext(rctx), "FileInfoStore.PermanentDelete")
+
+	err := s.FileInfoStore.PermanentDelete(rctx, fileID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerFileInfoStore) PermanentDeleteBatch(rctx request.CTX, endTime int64, limit int64) (int64, error) {
+	span := startSpan(requestContext(rctx), "FileInfoStore.PermanentDeleteBatch")
+
+	result, err := s.FileInfoStore.PermanentDeleteBatch(rctx, endTime, limit)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerFileInfoStore) PermanentDeleteByUser(rctx request.CTX, userID string) (int64, error) {
+	span := startSpan(requestContext(rctx), "FileInfoStore.PermanentDeleteByUser")
+
+	result, err := s.FileInfoStore.PermanentDeleteByUser(rctx, userID)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerFileInfoStore) PermanentDeleteForPost(rctx request.CTX, postID string) error {
+	span := startSpan(requestContext(rctx), "FileInfoStore.PermanentDeleteForPost")
+
+	err := s.FileInfoStore.PermanentDeleteForPost(rctx, postID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerFileInfoStore) RestoreForPostByIds(rctx request.CTX, postId string, fileIDs []string) error {
+	span := startSpan(requestContext(rctx), "FileInfoStore.RestoreForPostByIds")
+
+	err := s.FileInfoStore.RestoreForPostByIds(rctx, postId, fileIDs)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerFileInfoStore) Save(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
+	span := startSpan(requestContext(rctx), "FileInfoStore.Save")
+
+	result, err := s.FileInfoStore.Save(rctx, info)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerFileInfoStore) Search(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.FileInfoList, error) {
+	span := startSpan(requestContext(rctx), "FileInfoStore.Search")
+
+	result, err := s.FileInfoStore.Search(rctx, paramsList, userID, teamID, page, perPage)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerFileInfoStore) SetContent(rctx request.CTX, fileID string, content string) error {
+	span := startSpan(requestContext(rctx), "FileInfoStore.SetContent")
+
+	err := s.FileInfoStore.SetContent(rctx, fileID, content)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerFileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
+	span := startSpan(requestContext(rctx), "FileInfoStore.Upsert")
+
+	result, err := s.FileInfoStore.Upsert(rctx, info)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerJobStore) Get(rctx request.CTX, id string) (*model.Job, error) {
+	span := startSpan(requestContext(rctx), "JobStore.Get")
+
+	result, err := s.JobStore.Get(rctx, id)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerJobStore) GetAllByStatus(rctx request.CTX, status string) ([]*model.Job, error) {
+	span := startSpan(requestContext(rctx), "JobStore.GetAllByStatus")
+
+	result, err := s.JobStore.GetAllByStatus(rctx, status)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerJobStore) GetAllByType(rctx request.CTX, jobType string) ([]*model.Job, error) {
+	span := startSpan(requestContext(rctx), "JobStore.GetAllByType")
+
+	result, err := s.JobStore.GetAllByType(rctx, jobType)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerJobStore) GetAllByTypeAndStatus(rctx request.CTX, jobType string, status string) ([]*model.Job, error) {
+	span := startSpan(requestContext(rctx), "JobStore.GetAllByTypeAndStatus")
+
+	result, err := s.JobStore.GetAllByTypeAndStatus(rctx, jobType, status)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerJobStore) GetAllByTypePage(rctx request.CTX, jobType string, offset int, limit int) ([]*model.Job, error) {
+	span := startSpan(requestContext(rctx), "JobStore.GetAllByTypePage")
+
+	result, err := s.JobStore.GetAllByTypePage(rctx, jobType, offset, limit)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerJobStore) GetAllByTypesAndStatusesPage(rctx request.CTX, jobType []string, status []string, offset int, limit int) ([]*model.Job, error) {
+	span := startSpan(requestContext(rctx), "JobStore.GetAllByTypesAndStatusesPage")
+
+	result, err := s.JobStore.GetAllByTypesAndStatusesPage(rctx, jobType, status, offset, limit)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerJobStore) GetAllByTypesPage(rctx request.CTX, jobTypes []string, offset int, limit int) ([]*model.Job, error) {
+	span := startSpan(requestContext(rctx), "JobStore.GetAllByTypesPage")
+
+	result, err := s.JobStore.GetAllByTypesPage(rctx, jobTypes, offset, limit)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerJobStore) GetByTypeAndData(rctx request.CTX, jobType string, data map[string]string, useMaster bool, statuses ...string) ([]*model.Job, error) {
+	span := startSpan(requestContext(rctx), "JobStore.GetByTypeAndData")
+
+	result, err := s.JobStore.GetByTypeAndData(rctx, jobType, data, useMaster, statuses...)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerLicenseStore) Get(rctx request.CTX, id string) (*model.LicenseRecord, error) {
+	span := startSpan(requestContext(rctx), "LicenseStore.Get")
+
+	result, err := s.LicenseStore.Get(rctx, id)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerOutgoingOAuthConnectionStore) DeleteConnection(rctx request.CTX, id string) error {
+	span := startSpan(requestContext(rctx), "OutgoingOAuthConnectionStore.DeleteConnection")
+
+	err := s.OutgoingOAuthConnectionStore.DeleteConnection(rctx, id)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerOutgoingOAuthConnectionStore) GetConnection(rctx request.CTX, id string) (*model.OutgoingOAuthConnection, error) {
+	span := startSpan(requestContext(rctx), "OutgoingOAuthConnectionStore.GetConnection")
+
+	result, err := s.OutgoingOAuthConnectionStore.GetConnection(rctx, id)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerOutgoingOAuthConnectionStore) GetConnections(rctx request.CTX, filters model.OutgoingOAuthConnectionGetConnectionsFilter) ([]*model.OutgoingOAuthConnection, error) {
+	span := startSpan(requestContext(rctx), "OutgoingOAuthConnectionStore.GetConnections")
+
+	result, err := s.OutgoingOAuthConnectionStore.GetConnections(rctx, filters)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerOutgoingOAuthConnectionStore) SaveConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+	span := startSpan(requestContext(rctx), "OutgoingOAuthConnectionStore.SaveConnection")
+
+	result, err := s.OutgoingOAuthConnectionStore.SaveConnection(rctx, conn)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerOutgoingOAuthConnectionStore) UpdateConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+	span := startSpan(requestContext(rctx), "OutgoingOAuthConnectionStore.UpdateConnection")
+
+	result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(rctx, conn)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerPostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
+	span := startSpan(requestContext(rctx), "PostStore.Delete")
+
+	err := s.PostStore.Delete(rctx, postID, timestamp, deleteByID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerPostStore) Get(rctx request.CTX, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	span := startSpan(requestContext(rctx), "PostStore.Get")
+
+	result, err := s.PostStore.Get(rctx, id, opts, userID, sanitizeOptions)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerPostStore) GetPosts(rctx request.CTX, options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	span := startSpan(requestContext(rctx), "PostStore.GetPosts")
+
+	result, err := s.PostStore.GetPosts(rctx, options, allowFromCache, sanitizeOptions)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerPostStore) GetPostsAfter(rctx request.CTX, options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	span := startSpan(requestContext(rctx), "PostStore.GetPostsAfter")
+
+	result, err := s.PostStore.GetPostsAfter(rctx, options, sanitizeOptions)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerPostStore) GetPostsBefore(rctx request.CTX, options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	span := startSpan(requestContext(rctx), "PostStore.GetPostsBefore")
+
+	result, err := s.PostStore.GetPostsBefore(rctx, options, sanitizeOptions)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerPostStore) GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	span := startSpan(requestContext(rctx), "PostStore.GetPostsSince")
+
+	result, err := s.PostStore.GetPostsSince(rctx, options, allowFromCache, sanitizeOptions)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerPostStore) GetSingle(rctx request.CTX, id string, inclDeleted bool) (*model.Post, error) {
+	span := startSpan(requestContext(rctx), "PostStore.GetSingle")
+
+	result, err := s.PostStore.GetSingle(rctx, id, inclDeleted)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerPostStore) Overwrite(rctx request.CTX, post *model.Post) (*model.Post, error) {
+	span := startSpan(requestContext(rctx), "PostStore.Overwrite")
+
+	result, err := s.PostStore.Overwrite(rctx, post)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerPostStore) OverwriteMultiple(rctx request.CTX, posts []*model.Post) ([]*model.Post, int, error) {
+	span := startSpan(requestContext(rctx), "PostStore.OverwriteMultiple")
+
+	result, resultVar1, err := s.PostStore.OverwriteMultiple(rctx, posts)
+	endSpan(span, err)
+	return result, resultVar1, err
+
+}
+
+func (s *TracingLayerPostStore) PermanentDelete(rctx request.CTX, postID string) error {
+	span := startSpan(requestContext(rctx), "PostStore.PermanentDelete")
+
+	err := s.PostStore.PermanentDelete(rctx, postID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerPostStore) PermanentDeleteByChannel(rctx request.CTX, channelID string) error {
+	span := startSpan(requestContext(rctx), "PostStore.PermanentDeleteByChannel")
+
+	err := s.PostStore.PermanentDeleteByChannel(rctx, channelID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerPostStore) PermanentDeleteByUser(rctx request.CTX, userID string) error {
+	span := startSpan(requestContext(rctx), "PostStore.PermanentDeleteByUser")
+
+	err := s.PostStore.PermanentDeleteByUser(rctx, userID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerPostStore) Save(rctx request.CTX, post *model.Post) (*model.Post, error) {
+	span := startSpan(requestContext(rctx), "PostStore.Save")
+
+	result, err := s.PostStore.Save(rctx, post)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerPostStore) SaveMultiple(rctx request.CTX, posts []*model.Post) ([]*model.Post, int, error) {
+	span := startSpan(requestContext(rctx), "PostStore.SaveMultiple")
+
+	result, resultVar1, err := s.PostStore.SaveMultiple(rctx, posts)
+	endSpan(span, err)
+	return result, resultVar1, err
+
+}
+
+func (s *TracingLayerPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
+	span := startSpan(requestContext(rctx), "PostStore.SearchPostsForUser")
+
+	result, err := s.PostStore.SearchPostsForUser(rctx, paramsList, userID, teamID, page, perPage)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerPostStore) Update(rctx request.CTX, newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+	span := startSpan(requestContext(rctx), "PostStore.Update")
+
+	result, err := s.PostStore.Update(rctx, newPost, oldPost)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
+	span := startSpan(ctx, "RoleStore.GetByName")
+
+	result, err := s.RoleStore.GetByName(ctx, name)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerSessionStore) Get(rctx request.CTX, sessionIDOrToken string) (*model.Session, error) {
+	span := startSpan(requestContext(rctx), "SessionStore.Get")
+
+	result, err := s.SessionStore.Get(rctx, sessionIDOrToken)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerSessionStore) GetLRUSessions(rctx request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, error) {
+	span := startSpan(requestContext(rctx), "SessionStore.GetLRUSessions")
+
+	result, err := s.SessionStore.GetLRUSessions(rctx, userID, limit, offset)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerSessionStore) GetSessions(rctx request.CTX, userID string) ([]*model.Session, error) {
+	span := startSpan(requestContext(rctx), "SessionStore.GetSessions")
+
+	result, err := s.SessionStore.GetSessions(rctx, userID)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerSessionStore) Save(rctx request.CTX, session *model.Session) (*model.Session, error) {
+	span := startSpan(requestContext(rctx), "SessionStore.Save")
+
+	result, err := s.SessionStore.Save(rctx, session)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerTeamStore) GetMember(rctx request.CTX, teamID string, userID string) (*model.TeamMember, error) {
+	span := startSpan(requestContext(rctx), "TeamStore.GetMember")
+
+	result, err := s.TeamStore.GetMember(rctx, teamID, userID)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerTeamStore) GetTeamsForUser(rctx request.CTX, userID string, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, error) {
+	span := startSpan(requestContext(rctx), "TeamStore.GetTeamsForUser")
+
+	result, err := s.TeamStore.GetTeamsForUser(rctx, userID, excludeTeamID, includeDeleted)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerTeamStore) RemoveAllMembersByUser(rctx request.CTX, userID string) error {
+	span := startSpan(requestContext(rctx), "TeamStore.RemoveAllMembersByUser")
+
+	err := s.TeamStore.RemoveAllMembersByUser(rctx, userID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerTeamStore) RemoveMember(rctx request.CTX, teamID string, userID string) error {
+	span := startSpan(requestContext(rctx), "TeamStore.RemoveMember")
+
+	err := s.TeamStore.RemoveMember(rctx, teamID, userID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerTeamStore) RemoveMembers(rctx request.CTX, teamID string, userIds []string) error {
+	span := startSpan(requestContext(rctx), "TeamStore.RemoveMembers")
+
+	err := s.TeamStore.RemoveMembers(rctx, teamID, userIds)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerTeamStore) SaveMember(rctx request.CTX, member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+	span := startSpan(requestContext(rctx), "TeamStore.SaveMember")
+
+	result, err := s.TeamStore.SaveMember(rctx, member, maxUsersPerTeam)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerTeamStore) UpdateMember(rctx request.CTX, member *model.TeamMember) (*model.TeamMember, error) {
+	span := startSpan(requestContext(rctx), "TeamStore.UpdateMember")
+
+	result, err := s.TeamStore.UpdateMember(rctx, member)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerThreadStore) GetThreadForUser(rctx request.CTX, threadMembership *model.ThreadMembership, extended bool, postPriorityIsEnabled bool) (*model.ThreadResponse, error) {
+	span := startSpan(requestContext(rctx), "ThreadStore.GetThreadForUser")
+
+	result, err := s.ThreadStore.GetThreadForUser(rctx, threadMembership, extended, postPriorityIsEnabled)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerThreadStore) GetThreadsForUser(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
+	span := startSpan(requestContext(rctx), "ThreadStore.GetThreadsForUser")
+
+	result, err := s.ThreadStore.GetThreadsForUser(rctx, userID, teamID, opts)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerUploadSessionStore) Get(rctx request.CTX, id string) (*model.UploadSession, error) {
+	span := startSpan(requestContext(rctx), "UploadSessionStore.Get")
+
+	result, err := s.UploadSessionStore.Get(rctx, id)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerUserStore) AutocompleteUsersInChannel(rctx request.CTX, teamID string, channelID string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInChannel, error) {
+	span := startSpan(requestContext(rctx), "UserStore.AutocompleteUsersInChannel")
+
+	result, err := s.UserStore.AutocompleteUsersInChannel(rctx, teamID, channelID, term, options)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerUserStore) Get(ctx context.Context, id string) (*model.User, error) {
+	span := startSpan(ctx, "UserStore.Get")
+
+	result, err := s.UserStore.Get(ctx, id)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerUserStore) GetAllProfilesInChannel(ctx context.Context, channelID string, allowFromCache bool) (map[string]*model.User, error) {
+	span := startSpan(ctx, "UserStore.GetAllProfilesInChannel")
+
+	result, err := s.UserStore.GetAllProfilesInChannel(ctx, channelID, allowFromCache)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerUserStore) GetMany(rctx request.CTX, ids []string) ([]*model.User, error) {
+	span := startSpan(requestContext(rctx), "UserStore.GetMany")
+
+	result, err := s.UserStore.GetMany(rctx, ids)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerUserStore) GetProfileByIds(rctx request.CTX, userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error) {
+	span := startSpan(requestContext(rctx), "UserStore.GetProfileByIds")
+
+	result, err := s.UserStore.GetProfileByIds(rctx, userIds, options, allowFromCache)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerUserStore) PermanentDelete(rctx request.CTX, userID string) error {
+	span := startSpan(requestContext(rctx), "UserStore.PermanentDelete")
+
+	err := s.UserStore.PermanentDelete(rctx, userID)
+	endSpan(span, err)
+	return err
+
+}
+
+func (s *TracingLayerUserStore) Save(rctx request.CTX, user *model.User) (*model.User, error) {
+	span := startSpan(requestContext(rctx), "UserStore.Save")
+
+	result, err := s.UserStore.Save(rctx, user)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerUserStore) Search(rctx request.CTX, teamID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	span := startSpan(requestContext(rctx), "UserStore.Search")
+
+	result, err := s.UserStore.Search(rctx, teamID, term, options)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayerUserStore) Update(rctx request.CTX, user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+	span := startSpan(requestContext(rctx), "UserStore.Update")
+
+	result, err := s.UserStore.Update(rctx, user, allowRoleUpdate)
+	endSpan(span, err)
+	return result, err
+
+}
+
+func (s *TracingLayer) Close() {
+	s.Store.Close()
+}
+
+func (s *TracingLayer) DropAllTables() {
+	s.Store.DropAllTables()
+}
+
+func (s *TracingLayer) LockToMaster() {
+	s.Store.LockToMaster()
+}
+
+func (s *TracingLayer) MarkSystemRanUnitTests() {
+	s.Store.MarkSystemRanUnitTests()
+}
+
+func (s *TracingLayer) TotalMasterDbConnections() int {
+	return s.Store.TotalMasterDbConnections()
+}
+
+func (s *TracingLayer) TotalReadDbConnections() int {
+	return s.Store.TotalReadDbConnections()
+}
+
+func (s *TracingLayer) TotalSearchDbConnections() int {
+	return s.Store.TotalSearchDbConnections()
+}
+
+func (s *TracingLayer) UnlockFromMaster() {
+	s.Store.UnlockFromMaster()
+}
+
+// New returns a store layer tracing the methods called with the context of a traced request.
+func New(childStore store.Store) *TracingLayer {
+	newStore := TracingLayer{
+		Store: childStore,
+	}
+
+	newStore.AccessControlPolicyStore = &TracingLayerAccessControlPolicyStore{AccessControlPolicyStore: childStore.AccessControlPolicy(), Root: &newStore}
+	newStore.AttributesStore = &TracingLayerAttributesStore{AttributesStore: childStore.Attributes(), Root: &newStore}
+	newStore.AuditStore = &TracingLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.BotStore = &TracingLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
+	newStore.ChannelStore = &TracingLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelBookmarkStore = &TracingLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
+	newStore.ChannelMemberHistoryStore = &TracingLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ClusterDiscoveryStore = &TracingLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
+	newStore.CommandStore = &TracingLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
+	newStore.CommandWebhookStore = &TracingLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
+	newStore.ComplianceStore = &TracingLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.ContentFlaggingStore = &TracingLayerContentFlaggingStore{ContentFlaggingStore: childStore.ContentFlagging(), Root: &newStore}
+	newStore.DesktopTokensStore = &TracingLayerDesktopTokensStore{DesktopTokensStore: childStore.DesktopTokens(), Root: &newStore}
+	newStore.DraftStore = &TracingLayerDraftStore{DraftStore: childStore.Draft(), Root: &newStore}
+	newStore.EmojiStore = &TracingLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.FileInfoStore = &TracingLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
+	newStore.GroupStore = &TracingLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.JobStore = &TracingLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.LicenseStore = &TracingLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
+	newStore.LinkMetadataStore = &TracingLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.NotifyAdminStore = &TracingLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
+	newStore.OAuthStore = &TracingLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OutgoingEmailStore = &TracingLayerOutgoingEmailStore{OutgoingEmailStore: childStore.OutgoingEmail(), Root: &newStore}
+	newStore.OutgoingOAuthConnectionStore = &TracingLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PluginStore = &TracingLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PostStore = &TracingLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PostAcknowledgementStore = &TracingLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
+	newStore.PostPersistentNotificationStore = &TracingLayerPostPersistentNotificationStore{PostPersistentNotificationStore: childStore.PostPersistentNotification(), Root: &newStore}
+	newStore.PostPriorityStore = &TracingLayerPostPriorityStore{PostPriorityStore: childStore.PostPriority(), Root: &newStore}
+	newStore.PreferenceStore = &TracingLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
+	newStore.ProductNoticesStore = &TracingLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
+	newStore.PropertyFieldStore = &TracingLayerPropertyFieldStore{PropertyFieldStore: childStore.PropertyField(), Root: &newStore}
+	newStore.PropertyGroupStore = &TracingLayerPropertyGroupStore{PropertyGroupStore: childStore.PropertyGroup(), Root: &newStore}
+	newStore.PropertyValueStore = &TracingLayerPropertyValueStore{PropertyValueStore: childStore.PropertyValue(), Root: &newStore}
+	newStore.ReactionStore = &TracingLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.RemoteClusterStore = &TracingLayerRemoteClusterStore{RemoteClusterStore: childStore.RemoteCluster(), Root: &newStore}
+	newStore.RetentionPolicyStore = &TracingLayerRetentionPolicyStore{RetentionPolicyStore: childStore.RetentionPolicy(), Root: &newStore}
+	newStore.RoleStore = &TracingLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.ScheduledPostStore = &TracingLayerScheduledPostStore{ScheduledPostStore: childStore.ScheduledPost(), Root: &newStore}
+	newStore.SchemeStore = &TracingLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
+	newStore.SessionStore = &TracingLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
+	newStore.SharedChannelStore = &TracingLayerSharedChannelStore{SharedChannelStore: childStore.SharedChannel(), Root: &newStore}
+	newStore.StatusStore = &TracingLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
+	newStore.SystemStore = &TracingLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
+	newStore.TeamStore = &TracingLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
+	newStore.TermsOfServiceStore = &TracingLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
+	newStore.ThreadStore = &TracingLayerThreadStore{ThreadStore: childStore.Thread(), Root: &newStore}
+	newStore.TokenStore = &TracingLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
+	newStore.UploadSessionStore = &TracingLayerUploadSessionStore{UploadSessionStore: childStore.UploadSession(), Root: &newStore}
+	newStore.UserStore = &TracingLayerUserStore{UserStore: childStore.User(), Root: &newStore}
+	newStore.UserAccessTokenStore = &TracingLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
+	newStore.UserTermsOfServiceStore = &TracingLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebhookStore = &TracingLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
+	return &newStore
+}
@@ -344,6 +344,17 @@ func (c *Context) RequireInviteId() *Context {
 	return c
 }
 
+func (c *Context) RequireInviteLinkId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.InviteLinkId) {
+		c.SetInvalidURLParam("invite_link_id")
+	}
+	return c
+}
+
 func (c *Context) RequireTokenId() *Context {
 	if c.Err != nil {
 		return c
@@ -768,6 +779,17 @@ func (c *Context) RequireContentReviewerId() *Context {
 	return c
 }
 
+func (c *Context) RequireOutgoingEmailId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.OutgoingEmailId) {
+		c.SetInvalidURLParam("email_id")
+	}
+	return c
+}
+
 func (c *Context) GetRemoteID(r *http.Request) string {
 	return r.Header.Get(model.HeaderRemoteclusterId)
 }
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/klauspost/compress/gzhttp"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
@@ -23,6 +24,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/app"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 func GetHandlerName(h func(*Context, http.ResponseWriter, *http.Request)) string {
@@ -78,6 +80,8 @@ type Handler struct {
 	IsLocal                   bool
 	DisableWhenBusy           bool
 	FileAPI                   bool
+	// RateLimitRouteGroup is the route group whose rate limit policies apply to the handler.
+	RateLimitRouteGroup string
 
 	cspShaDirective string
 }
@@ -154,6 +158,11 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	requestID := model.NewId()
+	ctx, span := tracing.StartSpan(tracing.Extract(context.Background(), r.Header), h.HandlerName,
+		attribute.String("http.request.method", r.Method),
+		attribute.String("url.path", r.URL.Path),
+		attribute.String("request_id", requestID),
+	)
 	var rateLimitExceeded bool
 	defer func() {
 		responseLogFields := []mlog.Field{
@@ -177,11 +186,18 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		if !rateLimitExceeded {
 			h.recordMetrics(c, r, now, statusCode)
 		}
+
+		span.SetAttributes(attribute.Int("http.response.status_code", w.(*responseWriterWrapper).StatusCode()))
+		if c.Err != nil {
+			tracing.EndSpan(span, c.Err)
+		} else {
+			span.End()
+		}
 	}()
 
 	t, _ := i18n.GetTranslationsAndLocaleFromRequest(r)
 	c.AppContext = request.NewContext(
-		context.Background(),
+		ctx,
 		requestID,
 		utils.GetIPAddress(r, c.App.Config().ServiceSettings.TrustedProxyIPHeader),
 		r.Header.Get("X-Forwarded-For"),
@@ -269,6 +285,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			c.Err = model.NewAppError("ServeHTTP", "api.context.token_provided.app_error", nil, "token="+token, http.StatusUnauthorized)
 		} else {
 			c.AppContext = c.AppContext.WithSession(session)
+			c.App.UpdateSessionLastIPAddress(c.AppContext, session, c.AppContext.IPAddress())
 		}
 
 		// Rate limit by UserID
@@ -351,6 +368,13 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Rate limit by route group, once the principal is known
+	if c.Err == nil && h.RateLimitRouteGroup != "" && c.App.Srv().RateLimiter != nil {
+		if c.App.Srv().RateLimiter.RouteRateLimit(h.RateLimitRouteGroup, c.AppContext.Session(), w, r) {
+			return
+		}
+	}
+
 	if c.Err == nil {
 		h.HandleFunc(c, w, r)
 	}
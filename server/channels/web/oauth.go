@@ -31,15 +31,27 @@ func (w *Web) InitOAuth() {
 	// OAuth 2.0 Authorization Server Metadata endpoint (RFC 8414)
 	w.MainRouter.Handle(model.OAuthMetadataEndpoint, w.APIHandlerTrustRequester(getAuthorizationServerMetadata)).Methods(http.MethodGet)
 
+	// OpenID Connect provider endpoints
+	w.MainRouter.Handle(model.OIDCDiscoveryEndpoint, w.APIHandlerTrustRequester(getOpenIDProviderMetadata)).Methods(http.MethodGet)
+	w.MainRouter.Handle(model.OIDCJWKSEndpoint, w.APIHandlerTrustRequester(getOIDCJSONWebKeySet)).Methods(http.MethodGet)
+	w.MainRouter.Handle(model.OIDCUserInfoEndpoint, w.APISessionRequired(getOIDCUserInfo)).Methods(http.MethodGet, http.MethodPost)
+
 	// API version independent OAuth 2.0 as a service provider endpoints
 	w.MainRouter.Handle(model.OAuthAuthorizeEndpoint, w.APIHandlerTrustRequester(authorizeOAuthPage)).Methods(http.MethodGet)
 	w.MainRouter.Handle(model.OAuthAuthorizeEndpoint, w.APISessionRequired(authorizeOAuthApp)).Methods(http.MethodPost)
 	w.MainRouter.Handle(model.OAuthDeauthorizeEndpoint, w.APISessionRequired(deauthorizeOAuthApp)).Methods(http.MethodPost)
 	w.MainRouter.Handle(model.OAuthAccessTokenEndpoint, w.APIHandlerTrustRequester(getAccessToken)).Methods(http.MethodPost)
+	w.MainRouter.Handle(model.OAuthIntrospectEndpoint, w.APIHandlerTrustRequester(introspectOAuthToken)).Methods(http.MethodPost)
+
+	// OAuth 2.0 device authorization grant endpoints (RFC 8628)
+	w.MainRouter.Handle(model.OAuthDeviceAuthorizationEndpoint, w.APIHandlerTrustRequester(createOAuthDeviceAuthorization)).Methods(http.MethodPost)
+	w.MainRouter.Handle(model.OAuthDeviceVerificationEndpoint, w.APIHandlerTrustRequester(oauthDevicePage)).Methods(http.MethodGet)
+	w.MainRouter.Handle(model.OAuthDeviceVerificationEndpoint, w.APIHandlerTrustRequester(verifyOAuthDevice)).Methods(http.MethodPost)
 
 	// API version independent OAuth as a client endpoints
 	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9]+}/complete", w.APIHandler(completeOAuth)).Methods(http.MethodGet)
 	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9]+}/login", w.APIHandler(loginWithOAuth)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9]+}/logout", w.APIHandler(logoutWithOAuth)).Methods(http.MethodPost)
 	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9]+}/mobile_login", w.APIHandler(mobileLoginWithOAuth)).Methods(http.MethodGet)
 	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9]+}/signup", w.APIHandler(signupWithOAuth)).Methods(http.MethodGet)
 
@@ -133,6 +145,7 @@ func authorizeOAuthPage(c *Context, w http.ResponseWriter, r *http.Request) {
 		CodeChallenge:       r.URL.Query().Get("code_challenge"),
 		CodeChallengeMethod: r.URL.Query().Get("code_challenge_method"),
 		Resource:            r.URL.Query().Get("resource"),
+		Nonce:               r.URL.Query().Get("nonce"),
 	}
 
 	loginHint := r.URL.Query().Get("login_hint")
@@ -194,9 +207,15 @@ func authorizeOAuthPage(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	isAuthorized := false
 
-	if _, err := c.App.GetPreferenceByCategoryAndNameForUser(c.AppContext, c.AppContext.Session().UserId, model.PreferenceCategoryAuthorizedOAuthApp, authRequest.ClientId); err == nil {
-		// when we support scopes we should check if the scopes match
+	if pref, err := c.App.GetPreferenceByCategoryAndNameForUser(c.AppContext, c.AppContext.Session().UserId, model.PreferenceCategoryAuthorizedOAuthApp, authRequest.ClientId); err == nil {
+		// The user has to consent again if the app asks for scopes that weren't granted before
 		isAuthorized = true
+		for _, scope := range strings.Fields(authRequest.Scope) {
+			if !model.HasScope(pref.Value, scope) {
+				isAuthorized = false
+				break
+			}
+		}
 	}
 
 	// Automatically allow if the app is trusted
@@ -232,6 +251,11 @@ func getAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.FormValue("grant_type") == model.GrantTypeDeviceCode {
+		getDeviceAccessToken(c, w, r)
+		return
+	}
+
 	code := r.FormValue("code")
 	refreshToken := r.FormValue("refresh_token")
 
@@ -247,6 +271,7 @@ func getAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 			c.Err = model.NewAppError("getAccessToken", "api.oauth.get_access_token.missing_refresh_token.app_error", nil, "", http.StatusBadRequest)
 			return
 		}
+	case model.GrantTypeClientCredentials:
 	default:
 		c.Err = model.NewAppError("getAccessToken", "api.oauth.get_access_token.bad_grant.app_error", nil, "", http.StatusBadRequest)
 		return
@@ -292,6 +317,43 @@ func getAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// introspectOAuthToken implements the token introspection endpoint of RFC 7662 for resource
+// servers holding the credentials of the client the token was issued to.
+func introspectOAuthToken(c *Context, w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(c, w, model.NewAppError("introspectOAuthToken", "api.oauth.get_access_token.bad_request.app_error", nil, "", http.StatusBadRequest))
+		return
+	}
+
+	clientId := r.FormValue("client_id")
+	token := r.FormValue("token")
+	if token == "" {
+		writeOAuthError(c, w, model.NewAppError("introspectOAuthToken", "api.oauth.introspect.missing_token.app_error", nil, "", http.StatusBadRequest))
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventIntrospectOAuthToken, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("client_id", clientId)
+
+	introspection, err := c.App.IntrospectOAuthToken(c.AppContext, clientId, r.FormValue("client_secret"), token)
+	if err != nil {
+		writeOAuthError(c, w, err)
+		return
+	}
+
+	auditRec.AddMeta("active", introspection.Active)
+	auditRec.Success()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	if err := json.NewEncoder(w).Encode(introspection); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
 func completeOAuth(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireService()
 	if c.Err != nil {
@@ -485,6 +547,41 @@ func loginWithOAuth(c *Context, w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, authURL, http.StatusFound)
 }
 
+// logoutWithOAuth logs the user out and returns the URL to redirect to which, for providers
+// supporting RP-initiated logout, ends the user's session at the provider as well. It's a POST
+// so that sessions authenticated by cookie go through the CSRF check.
+func logoutWithOAuth(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireService()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventLogoutWithOAuth, model.AuditStatusFail)
+	auditRec.AddMeta("service", c.Params.Service)
+	defer c.LogAuditRec(auditRec)
+
+	c.RemoveSessionCookie(w, r)
+	if c.AppContext.Session().Id != "" {
+		if err := c.App.RevokeSessionById(c.AppContext, c.AppContext.Session().Id); err != nil {
+			c.Err = err
+			return
+		}
+	}
+
+	logoutURL, err := c.App.GetOAuthLogoutURL(c.AppContext, c.Params.Service)
+	if err != nil {
+		// The user is logged out of Mattermost regardless.
+		c.Logger.Warn("Failed to get the OAuth logout URL", mlog.String("service", c.Params.Service), mlog.Err(err))
+		logoutURL = c.GetSiteURLHeader() + "/login"
+	}
+
+	auditRec.Success()
+
+	if _, err := w.Write([]byte(model.MapToJSON(map[string]string{"redirect": logoutURL}))); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
 func mobileLoginWithOAuth(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireService()
 	if c.Err != nil {
@@ -624,3 +721,47 @@ func getAuthorizationServerMetadata(c *Context, w http.ResponseWriter, r *http.R
 		c.Logger.Warn("Error writing authorization server metadata response", mlog.Err(err))
 	}
 }
+
+func getOpenIDProviderMetadata(c *Context, w http.ResponseWriter, r *http.Request) {
+	metadata, err := c.App.GetOpenIDProviderMetadata(c.AppContext)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(metadata); err != nil {
+		c.Logger.Warn("Error writing OpenID provider metadata response", mlog.Err(err))
+	}
+}
+
+func getOIDCJSONWebKeySet(c *Context, w http.ResponseWriter, r *http.Request) {
+	keySet, err := c.App.GetOIDCJSONWebKeySet(c.AppContext)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "max-age=3600")
+	if err := json.NewEncoder(w).Encode(keySet); err != nil {
+		c.Logger.Warn("Error writing JSON web key set response", mlog.Err(err))
+	}
+}
+
+func getOIDCUserInfo(c *Context, w http.ResponseWriter, r *http.Request) {
+	userInfo, err := c.App.GetOIDCUserInfo(c.AppContext, c.AppContext.Session())
+	if err != nil {
+		if err.StatusCode == http.StatusForbidden {
+			w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="openid"`)
+		}
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(userInfo); err != nil {
+		c.Logger.Warn("Error writing userinfo response", mlog.Err(err))
+	}
+}
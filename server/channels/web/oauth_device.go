@@ -0,0 +1,212 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package web
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/templates"
+)
+
+// oauthErrorCodes maps the errors of the device authorization grant and of token introspection
+// to the error codes defined in RFC 8628 section 3.5 and RFC 6749 section 5.2.
+var oauthErrorCodes = map[string]string{
+	"app.oauth.device.invalid_client.app_error":        model.OAuthErrorInvalidClient,
+	"app.oauth.device.invalid_grant.app_error":         model.OAuthErrorInvalidGrant,
+	"app.oauth.device.authorization_pending.app_error": model.OAuthErrorAuthorizationPending,
+	"app.oauth.device.slow_down.app_error":             model.OAuthErrorSlowDown,
+	"app.oauth.device.access_denied.app_error":         model.OAuthErrorAccessDenied,
+	"app.oauth.device.expired_token.app_error":         model.OAuthErrorExpiredToken,
+	"app.oauth.introspect.invalid_client.app_error":    model.OAuthErrorInvalidClient,
+}
+
+// writeOAuthError writes an error response as defined in RFC 6749 section 5.2, which device
+// clients rely on to tell whether to keep polling.
+func writeOAuthError(c *Context, w http.ResponseWriter, appErr *model.AppError) {
+	code, ok := oauthErrorCodes[appErr.Id]
+	if !ok {
+		code = model.OAuthErrorInvalidRequest
+		if appErr.StatusCode >= http.StatusInternalServerError {
+			code = model.OAuthErrorServerError
+			c.Logger.Error("OAuth device authorization failed", mlog.Err(appErr))
+		}
+	}
+
+	appErr.Translate(c.AppContext.T)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	w.WriteHeader(appErr.StatusCode)
+
+	if err := json.NewEncoder(w).Encode(&model.OAuthError{Code: code, Description: appErr.Message}); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
+func createOAuthDeviceAuthorization(c *Context, w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(c, w, model.NewAppError("createOAuthDeviceAuthorization", "api.oauth.get_access_token.bad_request.app_error", nil, "", http.StatusBadRequest))
+		return
+	}
+
+	clientID := r.FormValue("client_id")
+
+	auditRec := c.MakeAuditRecord(model.AuditEventAuthorizeOAuthDevice, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("client_id", clientID)
+
+	deviceRsp, err := c.App.CreateOAuthDeviceAuthorization(c.AppContext, clientID, r.FormValue("client_secret"), r.FormValue("scope"))
+	if err != nil {
+		writeOAuthError(c, w, err)
+		return
+	}
+
+	auditRec.Success()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	if err := json.NewEncoder(w).Encode(deviceRsp); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
+func getDeviceAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
+	clientID := r.FormValue("client_id")
+	deviceCode := r.FormValue("device_code")
+	if deviceCode == "" {
+		writeOAuthError(c, w, model.NewAppError("getAccessToken", "api.oauth.get_access_token.missing_device_code.app_error", nil, "", http.StatusBadRequest))
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventGetAccessToken, model.AuditStatusFail)
+	auditRec.AddMeta("grant_type", model.GrantTypeDeviceCode)
+	auditRec.AddMeta("client_id", clientID)
+
+	accessRsp, err := c.App.GetOAuthAccessTokenForDeviceCode(c.AppContext, clientID, r.FormValue("client_secret"), deviceCode)
+	if err != nil {
+		// Polls of pending authorizations are expected and not worth an audit record.
+		if code := oauthErrorCodes[err.Id]; code != model.OAuthErrorAuthorizationPending && code != model.OAuthErrorSlowDown {
+			c.LogAuditRec(auditRec)
+		}
+		writeOAuthError(c, w, err)
+		return
+	}
+
+	auditRec.Success()
+	c.LogAuditRec(auditRec)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	if err := json.NewEncoder(w).Encode(accessRsp); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
+// oauthDevicePage is the verification page of the device authorization grant, where the user
+// enters the code displayed on the device and approves or denies the request.
+func oauthDevicePage(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !*c.App.Config().ServiceSettings.EnableOAuthServiceProvider {
+		err := model.NewAppError("oauthDevicePage", "api.oauth.authorize_oauth.disabled.app_error", nil, "", http.StatusNotImplemented)
+		utils.RenderWebAppError(c.App.Config(), w, r, err, c.App.AsymmetricSigningKey())
+		return
+	}
+
+	if c.AppContext.Session().UserId == "" {
+		http.Redirect(w, r, c.GetSiteURLHeader()+"/login?redirect_to="+url.QueryEscape(r.RequestURI), http.StatusFound)
+		return
+	}
+
+	renderOAuthDevicePage(c, w, r.URL.Query().Get("user_code"), "")
+}
+
+func verifyOAuthDevice(c *Context, w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		c.Err = model.NewAppError("verifyOAuthDevice", "api.oauth.get_access_token.bad_request.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	// The form is posted by the browser without the CSRF header, so the token is checked here.
+	session := c.AppContext.Session()
+	if session.UserId == "" || session.IsOAuth || subtle.ConstantTimeCompare([]byte(r.FormValue("csrf")), []byte(session.GetCSRF())) != 1 {
+		c.Err = model.NewAppError("verifyOAuthDevice", "api.context.session_expired.app_error", nil, "", http.StatusUnauthorized)
+		return
+	}
+
+	userCode := r.FormValue("user_code")
+	approve := r.FormValue("action") == "approve"
+
+	auditRec := c.MakeAuditRecord(model.AuditEventVerifyOAuthDevice, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("approve", approve)
+
+	authorization, err := c.App.VerifyOAuthDeviceAuthorization(c.AppContext, session.UserId, userCode, approve)
+	if err != nil {
+		if err.StatusCode >= http.StatusInternalServerError {
+			c.Err = err
+			return
+		}
+		renderOAuthDevicePage(c, w, userCode, "")
+		return
+	}
+	auditRec.AddMeta("client_id", authorization.ClientId)
+	auditRec.Success()
+
+	if approve {
+		renderOAuthDevicePage(c, w, "", c.AppContext.T("web.oauth_device.approved"))
+	} else {
+		renderOAuthDevicePage(c, w, "", c.AppContext.T("web.oauth_device.denied"))
+	}
+}
+
+func renderOAuthDevicePage(c *Context, w http.ResponseWriter, userCode, message string) {
+	props := map[string]any{
+		"Title": c.AppContext.T("web.oauth_device.title"),
+	}
+
+	switch {
+	case message != "":
+		props["Message"] = message
+	case userCode != "":
+		_, oauthApp, appErr := c.App.GetOAuthDeviceAuthorizationByUserCode(userCode)
+		if appErr != nil {
+			appErr.Translate(c.AppContext.T)
+			props["Error"] = appErr.Message
+			props["EnterCodeString"] = c.AppContext.T("web.oauth_device.enter_code")
+			props["ContinueString"] = c.AppContext.T("web.oauth_device.continue")
+			props["UserCode"] = userCode
+			break
+		}
+
+		props["AppName"] = oauthApp.Name
+		props["ConfirmString"] = c.AppContext.T("web.oauth_device.confirm", map[string]any{"AppName": oauthApp.Name})
+		props["ApproveString"] = c.AppContext.T("web.oauth_device.approve")
+		props["DenyString"] = c.AppContext.T("web.oauth_device.deny")
+		props["UserCode"] = model.FormatDeviceUserCode(model.NormalizeDeviceUserCode(userCode))
+		props["CSRF"] = c.AppContext.Session().GetCSRF()
+	default:
+		props["EnterCodeString"] = c.AppContext.T("web.oauth_device.enter_code")
+		props["ContinueString"] = c.AppContext.T("web.oauth_device.continue")
+	}
+
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "frame-ancestors 'none'")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := c.App.Srv().TemplatesContainer().Render(w, "oauth_device", templates.Data{Props: props}); err != nil {
+		c.Logger.Error("Failed to render template", mlog.Err(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package web
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestOAuthDeviceAuthorizationEndpoints(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	th := Setup(t).InitBasic(t)
+	th.Login(t, apiClient, th.BasicUser)
+	defer apiClient.ClearOAuthToken()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOAuthServiceProvider = true
+		*cfg.ServiceSettings.SiteURL = apiClient.URL
+	})
+
+	session, appErr := th.App.GetSession(apiClient.AuthToken)
+	require.Nil(t, appErr)
+
+	postVerification := func(t *testing.T, form url.Values) *http.Response {
+		t.Helper()
+
+		rq, err := http.NewRequest(http.MethodPost, apiClient.URL+model.OAuthDeviceVerificationEndpoint, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		rq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rq.Header.Set(model.HeaderAuth, model.HeaderBearer+" "+apiClient.AuthToken)
+
+		rp, err := apiClient.HTTPClient.Do(rq)
+		require.NoError(t, err)
+		return rp
+	}
+
+	deviceClient := model.NewAPIv4Client(apiClient.URL)
+
+	deviceRsp, _, err := deviceClient.CreateOAuthDeviceAuthorization(context.Background(), model.OAuthAppMmctlClientId, "")
+	require.NoError(t, err)
+	assert.Equal(t, apiClient.URL+model.OAuthDeviceVerificationEndpoint, deviceRsp.VerificationURI)
+
+	t.Run("polling before approval", func(t *testing.T) {
+		_, resp, err := deviceClient.GetOAuthDeviceAccessToken(context.Background(), model.OAuthAppMmctlClientId, deviceRsp.DeviceCode)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		var oauthErr *model.OAuthError
+		require.ErrorAs(t, err, &oauthErr)
+		assert.Equal(t, model.OAuthErrorAuthorizationPending, oauthErr.Code)
+	})
+
+	t.Run("verification page", func(t *testing.T) {
+		r, err := HTTPGet(deviceRsp.VerificationURIComplete, apiClient.HTTPClient, model.HeaderBearer+" "+apiClient.AuthToken, true)
+		require.NoError(t, err)
+		defer closeBody(r)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), deviceRsp.UserCode)
+		assert.Contains(t, string(body), session.GetCSRF())
+		assert.Equal(t, "DENY", r.Header.Get("X-Frame-Options"))
+	})
+
+	t.Run("approval requires the CSRF token", func(t *testing.T) {
+		r := postVerification(t, url.Values{"user_code": {deviceRsp.UserCode}, "action": {"approve"}})
+		defer closeBody(r)
+		assert.Equal(t, http.StatusUnauthorized, r.StatusCode)
+	})
+
+	t.Run("approval", func(t *testing.T) {
+		r := postVerification(t, url.Values{"user_code": {deviceRsp.UserCode}, "action": {"approve"}, "csrf": {session.GetCSRF()}})
+		defer closeBody(r)
+		assert.Equal(t, http.StatusOK, r.StatusCode)
+
+		accessRsp, _, err := deviceClient.GetOAuthDeviceAccessToken(context.Background(), model.OAuthAppMmctlClientId, deviceRsp.DeviceCode)
+		require.NoError(t, err)
+		require.NotEmpty(t, accessRsp.AccessToken)
+
+		client := model.NewAPIv4Client(apiClient.URL)
+		client.AuthType = model.HeaderBearer
+		client.AuthToken = accessRsp.AccessToken
+		user, _, err := client.GetMe(context.Background(), "")
+		require.NoError(t, err)
+		assert.Equal(t, th.BasicUser.Id, user.Id)
+
+		_, _, err = deviceClient.GetOAuthDeviceAccessToken(context.Background(), model.OAuthAppMmctlClientId, deviceRsp.DeviceCode)
+		var oauthErr *model.OAuthError
+		require.ErrorAs(t, err, &oauthErr)
+		assert.Equal(t, model.OAuthErrorInvalidGrant, oauthErr.Code)
+	})
+
+	t.Run("unknown client", func(t *testing.T) {
+		_, _, err := deviceClient.CreateOAuthDeviceAuthorization(context.Background(), model.NewId(), "")
+		var oauthErr *model.OAuthError
+		require.ErrorAs(t, err, &oauthErr)
+		assert.Equal(t, model.OAuthErrorInvalidClient, oauthErr.Code)
+	})
+}
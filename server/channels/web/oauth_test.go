@@ -399,6 +399,94 @@ func TestOAuthAccessToken(t *testing.T) {
 	apiClient.ClearOAuthToken()
 }
 
+func TestOIDCProviderEndpoints(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	th := Setup(t).InitBasic(t)
+	th.Login(t, apiClient, th.SystemAdminUser)
+	defer apiClient.ClearOAuthToken()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOAuthServiceProvider = true
+		*cfg.ServiceSettings.SiteURL = apiClient.URL
+	})
+
+	t.Run("discovery document", func(t *testing.T) {
+		r, err := HTTPGet(apiClient.URL+model.OIDCDiscoveryEndpoint, apiClient.HTTPClient, "", true)
+		require.NoError(t, err)
+		defer closeBody(r)
+
+		var metadata model.OpenIDProviderMetadata
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&metadata))
+		assert.Equal(t, apiClient.URL, metadata.Issuer)
+		assert.Equal(t, apiClient.URL+model.OIDCJWKSEndpoint, metadata.JWKSURI)
+		assert.Equal(t, apiClient.URL+model.OIDCUserInfoEndpoint, metadata.UserInfoEndpoint)
+		assert.Contains(t, metadata.ScopesSupported, model.ScopeOpenID)
+	})
+
+	t.Run("key set", func(t *testing.T) {
+		r, err := HTTPGet(apiClient.URL+model.OIDCJWKSEndpoint, apiClient.HTTPClient, "", true)
+		require.NoError(t, err)
+		defer closeBody(r)
+
+		var keySet model.JSONWebKeySet
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&keySet))
+		require.NotEmpty(t, keySet.Keys)
+		assert.Equal(t, "RSA", keySet.Keys[0].Kty)
+		assert.NotEmpty(t, keySet.Keys[0].Kid)
+	})
+
+	t.Run("ID token and userinfo", func(t *testing.T) {
+		oauthApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+			Name:         "TestOIDC" + model.NewId(),
+			Homepage:     "https://nowhere.com",
+			Description:  "test",
+			CallbackUrls: []string{"https://nowhere.com"},
+			CreatorId:    th.SystemAdminUser.Id,
+			ClientSecret: model.NewId(),
+		})
+		require.Nil(t, appErr)
+
+		redirect, _, err := apiClient.AuthorizeOAuthApp(context.Background(), &model.AuthorizeRequest{
+			ResponseType: model.AuthCodeResponseType,
+			ClientId:     oauthApp.Id,
+			RedirectURI:  oauthApp.CallbackUrls[0],
+			Scope:        "openid email",
+			State:        "123",
+			Nonce:        "abc",
+		})
+		require.NoError(t, err)
+		rurl, err := url.Parse(redirect)
+		require.NoError(t, err)
+
+		rsp, _, err := apiClient.GetOAuthAccessToken(context.Background(), url.Values{
+			"grant_type":    []string{model.AccessTokenGrantType},
+			"client_id":     []string{oauthApp.Id},
+			"client_secret": []string{oauthApp.ClientSecret},
+			"code":          []string{rurl.Query().Get("code")},
+			"redirect_uri":  []string{oauthApp.CallbackUrls[0]},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, rsp.IDToken)
+		assert.Len(t, strings.Split(rsp.IDToken, "."), 3)
+
+		r, err := HTTPGet(apiClient.URL+model.OIDCUserInfoEndpoint, apiClient.HTTPClient, model.HeaderBearer+" "+rsp.AccessToken, true)
+		require.NoError(t, err)
+		defer closeBody(r)
+
+		var userInfo model.OIDCUserInfo
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&userInfo))
+		assert.Equal(t, th.SystemAdminUser.Id, userInfo.Sub)
+		assert.Equal(t, th.SystemAdminUser.Email, userInfo.Email)
+		assert.Empty(t, userInfo.PreferredUsername)
+
+		_, err = HTTPGet(apiClient.URL+model.OIDCUserInfoEndpoint, apiClient.HTTPClient, model.HeaderBearer+" "+apiClient.AuthToken, true)
+		require.Error(t, err, "should have failed - not an OAuth access token")
+	})
+}
+
 func TestMobileLoginWithOAuth(t *testing.T) {
 	th := Setup(t).InitBasic(t)
 
@@ -1137,3 +1225,127 @@ func TestGetAccessToken(t *testing.T) {
 		assert.Empty(t, tokenResponse.RefreshToken)
 	})
 }
+
+func TestOAuthClientCredentialsAndIntrospection(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOAuthServiceProvider = true
+		*cfg.ServiceSettings.EnableBotAccountCreation = true
+	})
+
+	oauthApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "TestApp" + model.NewId(),
+		Homepage:     "https://nowhere.com",
+		Description:  "test",
+		CallbackUrls: []string{"https://nowhere.com"},
+		CreatorId:    th.SystemAdminUser.Id,
+	})
+	require.Nil(t, appErr)
+
+	client := model.NewAPIv4Client(apiClient.URL)
+
+	tokenRsp, _, err := client.GetOAuthClientCredentialsToken(context.Background(), oauthApp.Id, oauthApp.ClientSecret)
+	require.NoError(t, err)
+	require.NotEmpty(t, tokenRsp.AccessToken)
+	assert.Empty(t, tokenRsp.RefreshToken)
+
+	botClient := model.NewAPIv4Client(apiClient.URL)
+	botClient.SetOAuthToken(tokenRsp.AccessToken)
+	bot, _, err := botClient.GetMe(context.Background(), "")
+	require.NoError(t, err)
+	assert.True(t, bot.IsBot)
+
+	t.Run("wrong client secret", func(t *testing.T) {
+		_, resp, err := client.IntrospectOAuthToken(context.Background(), oauthApp.Id, model.NewId(), tokenRsp.AccessToken)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		var oauthErr *model.OAuthError
+		require.ErrorAs(t, err, &oauthErr)
+		assert.Equal(t, model.OAuthErrorInvalidClient, oauthErr.Code)
+	})
+
+	t.Run("active token", func(t *testing.T) {
+		introspection, _, err := client.IntrospectOAuthToken(context.Background(), oauthApp.Id, oauthApp.ClientSecret, tokenRsp.AccessToken)
+		require.NoError(t, err)
+		assert.True(t, introspection.Active)
+		assert.Equal(t, oauthApp.Id, introspection.ClientId)
+		assert.Equal(t, bot.Id, introspection.Sub)
+		assert.Equal(t, bot.Username, introspection.Username)
+		assert.NotZero(t, introspection.Exp)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		introspection, _, err := client.IntrospectOAuthToken(context.Background(), oauthApp.Id, oauthApp.ClientSecret, model.NewId())
+		require.NoError(t, err)
+		assert.False(t, introspection.Active)
+		assert.Empty(t, introspection.Sub)
+	})
+
+	t.Run("revoked token", func(t *testing.T) {
+		require.Nil(t, th.App.RevokeAccessToken(th.Context, tokenRsp.AccessToken))
+
+		introspection, _, err := client.IntrospectOAuthToken(context.Background(), oauthApp.Id, oauthApp.ClientSecret, tokenRsp.AccessToken)
+		require.NoError(t, err)
+		assert.False(t, introspection.Active)
+	})
+}
+
+func TestLogoutWithOAuth(t *testing.T) {
+	th := Setup(t).InitBasic(t)
+
+	newSession := func(t *testing.T) *model.Session {
+		t.Helper()
+		session := &model.Session{UserId: th.BasicUser.Id, Roles: model.SystemUserRoleId}
+		session.GenerateCSRF()
+		th.App.SetSessionExpireInHours(session, 24)
+		session, appErr := th.App.CreateSession(th.Context, session)
+		require.Nil(t, appErr)
+		return session
+	}
+
+	newRequest := func(method string, session *model.Session) *http.Request {
+		request := httptest.NewRequest(method, "/oauth/openid/logout", nil)
+		request.AddCookie(&http.Cookie{Name: model.SessionCookieToken, Value: session.Token})
+		request.AddCookie(&http.Cookie{Name: model.SessionCookieCsrf, Value: session.GetCSRF()})
+		return request
+	}
+
+	t.Run("GET isn't allowed", func(t *testing.T) {
+		session := newSession(t)
+		response := httptest.NewRecorder()
+		th.Web.MainRouter.ServeHTTP(response, newRequest(http.MethodGet, session))
+		assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+
+		_, appErr := th.App.GetSession(session.Token)
+		assert.Nil(t, appErr)
+	})
+
+	t.Run("the CSRF token is required", func(t *testing.T) {
+		session := newSession(t)
+		response := httptest.NewRecorder()
+		th.Web.MainRouter.ServeHTTP(response, newRequest(http.MethodPost, session))
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+
+		_, appErr := th.App.GetSession(session.Token)
+		assert.Nil(t, appErr)
+	})
+
+	t.Run("logs out", func(t *testing.T) {
+		session := newSession(t)
+		request := newRequest(http.MethodPost, session)
+		request.Header.Set(model.HeaderCsrfToken, session.GetCSRF())
+		response := httptest.NewRecorder()
+		th.Web.MainRouter.ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.NotEmpty(t, model.MapFromJSON(response.Body)["redirect"])
+
+		_, appErr := th.App.GetSession(session.Token)
+		assert.NotNil(t, appErr)
+	})
+}
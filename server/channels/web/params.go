@@ -29,6 +29,7 @@ type Params struct {
 	OtherUserId                        string
 	TeamId                             string
 	InviteId                           string
+	InviteLinkId                       string
 	TokenId                            string
 	ThreadId                           string
 	Timestamp                          int64
@@ -110,6 +111,9 @@ type Params struct {
 	AccessControlPolicyEnforced        bool
 	ExcludeAccessControlPolicyEnforced bool
 	ContentReviewerId                  string
+	OutgoingEmailId                    string
+	EmailTemplateName                  string
+	Locale                             string
 
 	//Bookmarks
 	ChannelBookmarkId string
@@ -135,6 +139,7 @@ func ParamsFromRequest(r *http.Request) *Params {
 	params.TeamId = props["team_id"]
 	params.CategoryId = props["category_id"]
 	params.InviteId = props["invite_id"]
+	params.InviteLinkId = props["invite_link_id"]
 	params.TokenId = props["token_id"]
 	params.ThreadId = props["thread_id"]
 
@@ -287,11 +292,20 @@ func ParamsFromRequest(r *http.Request) *Params {
 	params.AccessControlPolicyEnforced, _ = strconv.ParseBool(query.Get("access_control_policy_enforced"))
 	params.ExcludeAccessControlPolicyEnforced, _ = strconv.ParseBool(query.Get("exclude_access_control_policy_enforced"))
 	params.ContentReviewerId = props["content_reviewer_id"]
+	params.OutgoingEmailId = props["email_id"]
+	params.EmailTemplateName = props["template_name"]
+	params.Locale = props["locale"]
 
 	if val := query.Get("group_source"); val != "" {
 		switch val {
 		case "custom":
 			params.GroupSource = model.GroupSourceCustom
+		case "attribute":
+			params.GroupSource = model.GroupSourceAttribute
+		case "oidc":
+			params.GroupSource = model.GroupSourceOIDC
+		case "scim":
+			params.GroupSource = model.GroupSourceScim
 		default:
 			params.GroupSource = model.GroupSourceLdap
 		}
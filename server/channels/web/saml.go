@@ -200,7 +200,7 @@ func completeSaml(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 
 	var hookErr error
-	c.App.Channels().RunMultiHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+	c.App.Channels().RunMultiHookWithContext(c.AppContext.Context(), func(hooks plugin.Hooks, manifest *model.Manifest) bool {
 		hookErr = hooks.OnSAMLLogin(pluginContext, user, assertion)
 		return hookErr == nil
 	}, plugin.OnSAMLLoginID)
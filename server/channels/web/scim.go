@@ -0,0 +1,405 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package web
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// scimErrorTypes maps the errors of the SCIM endpoints to the scimType values defined in RFC 7644
+// section 3.12.
+var scimErrorTypes = map[string]string{
+	"app.scim.invalid_filter.app_error":     model.ScimErrorTypeInvalidFilter,
+	"app.scim.unsupported_filter.app_error": model.ScimErrorTypeInvalidFilter,
+	"app.scim.invalid_path.app_error":       model.ScimErrorTypeInvalidPath,
+	"app.scim.invalid_value.app_error":      model.ScimErrorTypeInvalidValue,
+	"app.scim.invalid_username.app_error":   model.ScimErrorTypeInvalidValue,
+	"app.scim.missing_email.app_error":      model.ScimErrorTypeInvalidValue,
+	"app.scim.invalid_member.app_error":     model.ScimErrorTypeInvalidValue,
+	"app.scim.user_exists.app_error":        model.ScimErrorTypeUniqueness,
+	"app.scim.group_exists.app_error":       model.ScimErrorTypeUniqueness,
+	"api.scim.invalid_body.app_error":       model.ScimErrorTypeInvalidSyntax,
+}
+
+func (w *Web) InitSCIM() {
+	router := w.MainRouter.PathPrefix(model.ScimBasePath).Subrouter()
+
+	router.Handle("/ServiceProviderConfig", w.scimHandler(getScimServiceProviderConfig)).Methods(http.MethodGet)
+	router.Handle("/ResourceTypes", w.scimHandler(getScimResourceTypes)).Methods(http.MethodGet)
+	router.Handle("/ResourceTypes/{name}", w.scimHandler(getScimResourceType)).Methods(http.MethodGet)
+	router.Handle("/Schemas", w.scimHandler(getScimSchemas)).Methods(http.MethodGet)
+	router.Handle("/Schemas/{name}", w.scimHandler(getScimSchema)).Methods(http.MethodGet)
+
+	router.Handle("/Users", w.scimHandler(listScimUsers)).Methods(http.MethodGet)
+	router.Handle("/Users", w.scimHandler(createScimUser)).Methods(http.MethodPost)
+	router.Handle("/Users/{user_id:[A-Za-z0-9]+}", w.scimHandler(getScimUser)).Methods(http.MethodGet)
+	router.Handle("/Users/{user_id:[A-Za-z0-9]+}", w.scimHandler(replaceScimUser)).Methods(http.MethodPut)
+	router.Handle("/Users/{user_id:[A-Za-z0-9]+}", w.scimHandler(patchScimUser)).Methods(http.MethodPatch)
+	router.Handle("/Users/{user_id:[A-Za-z0-9]+}", w.scimHandler(deleteScimUser)).Methods(http.MethodDelete)
+
+	router.Handle("/Groups", w.scimHandler(listScimGroups)).Methods(http.MethodGet)
+	router.Handle("/Groups", w.scimHandler(createScimGroup)).Methods(http.MethodPost)
+	router.Handle("/Groups/{group_id:[A-Za-z0-9]+}", w.scimHandler(getScimGroup)).Methods(http.MethodGet)
+	router.Handle("/Groups/{group_id:[A-Za-z0-9]+}", w.scimHandler(replaceScimGroup)).Methods(http.MethodPut)
+	router.Handle("/Groups/{group_id:[A-Za-z0-9]+}", w.scimHandler(patchScimGroup)).Methods(http.MethodPatch)
+	router.Handle("/Groups/{group_id:[A-Za-z0-9]+}", w.scimHandler(deleteScimGroup)).Methods(http.MethodDelete)
+}
+
+// scimHandler authenticates the identity provider with the bearer token of ScimSettings, which
+// grants access to the SCIM endpoints only.
+func (w *Web) scimHandler(h func(*Context, http.ResponseWriter, *http.Request)) http.Handler {
+	return w.APIHandlerTrustRequester(func(c *Context, w http.ResponseWriter, r *http.Request) {
+		settings := c.App.Config().ScimSettings
+		if !*settings.Enable {
+			writeScimError(c, w, model.NewAppError("scimHandler", "api.scim.disabled.app_error", nil, "", http.StatusNotImplemented))
+			return
+		}
+
+		token, found := strings.CutPrefix(r.Header.Get(model.HeaderAuth), model.HeaderBearer+" ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(*settings.BearerToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", model.HeaderBearer)
+			writeScimError(c, w, model.NewAppError("scimHandler", "api.scim.invalid_token.app_error", nil, "", http.StatusUnauthorized))
+			return
+		}
+
+		h(c, w, r)
+	})
+}
+
+// writeScimError writes an error response as defined in RFC 7644 section 3.12.
+func writeScimError(c *Context, w http.ResponseWriter, appErr *model.AppError) {
+	if appErr.StatusCode >= http.StatusInternalServerError {
+		c.Logger.Error("SCIM request failed", mlog.Err(appErr))
+	}
+
+	appErr.Translate(c.AppContext.T)
+
+	w.Header().Set("Content-Type", model.ScimContentType)
+	w.WriteHeader(appErr.StatusCode)
+
+	if err := json.NewEncoder(w).Encode(model.NewScimError(appErr.StatusCode, scimErrorTypes[appErr.Id], appErr.Message)); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
+func writeScimResponse(c *Context, w http.ResponseWriter, status int, resource any) {
+	w.Header().Set("Content-Type", model.ScimContentType)
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(resource); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
+func decodeScimBody(r *http.Request, where string, v any) *model.AppError {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return model.NewAppError(where, "api.scim.invalid_body.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	return nil
+}
+
+func scimListParams(r *http.Request) (*model.ScimListParams, *model.AppError) {
+	query := r.URL.Query()
+	params := &model.ScimListParams{
+		StartIndex: 1,
+		Count:      model.ScimDefaultCount,
+	}
+
+	if val, err := strconv.Atoi(query.Get("startIndex")); err == nil && val > 1 {
+		params.StartIndex = val
+	}
+	if val, err := strconv.Atoi(query.Get("count")); err == nil {
+		params.Count = min(max(val, 0), model.ScimMaxCount)
+	}
+
+	if filter := query.Get("filter"); filter != "" {
+		parsed, err := model.ParseScimFilter(filter)
+		if err != nil {
+			return nil, model.NewAppError("scimListParams", "app.scim.invalid_filter.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+		params.Filter = parsed
+	}
+
+	for attribute := range strings.SplitSeq(query.Get("excludedAttributes"), ",") {
+		if attribute = strings.TrimSpace(attribute); attribute != "" {
+			params.ExcludedAttributes = append(params.ExcludedAttributes, attribute)
+		}
+	}
+
+	return params, nil
+}
+
+func getScimServiceProviderConfig(c *Context, w http.ResponseWriter, r *http.Request) {
+	writeScimResponse(c, w, http.StatusOK, model.NewScimServiceProviderConfig(c.App.GetSiteURL()+model.ScimBasePath))
+}
+
+func getScimResourceTypes(c *Context, w http.ResponseWriter, r *http.Request) {
+	resourceTypes := model.NewScimResourceTypes(c.App.GetSiteURL() + model.ScimBasePath)
+
+	resources := make([]any, 0, len(resourceTypes))
+	for _, resourceType := range resourceTypes {
+		resources = append(resources, resourceType)
+	}
+	writeScimResponse(c, w, http.StatusOK, model.NewScimListResponse(resources, int64(len(resources)), 1))
+}
+
+func getScimResourceType(c *Context, w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	for _, resourceType := range model.NewScimResourceTypes(c.App.GetSiteURL() + model.ScimBasePath) {
+		if resourceType.Id == name {
+			writeScimResponse(c, w, http.StatusOK, resourceType)
+			return
+		}
+	}
+
+	writeScimError(c, w, model.NewAppError("getScimResourceType", "api.scim.resource_type_not_found.app_error", nil, "name="+name, http.StatusNotFound))
+}
+
+func getScimSchemas(c *Context, w http.ResponseWriter, r *http.Request) {
+	schemas := model.NewScimSchemaDefinitions(c.App.GetSiteURL() + model.ScimBasePath)
+
+	resources := make([]any, 0, len(schemas))
+	for _, schema := range schemas {
+		resources = append(resources, schema)
+	}
+	writeScimResponse(c, w, http.StatusOK, model.NewScimListResponse(resources, int64(len(resources)), 1))
+}
+
+func getScimSchema(c *Context, w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	for _, schema := range model.NewScimSchemaDefinitions(c.App.GetSiteURL() + model.ScimBasePath) {
+		if schema.Id == name {
+			writeScimResponse(c, w, http.StatusOK, schema)
+			return
+		}
+	}
+
+	writeScimError(c, w, model.NewAppError("getScimSchema", "api.scim.schema_not_found.app_error", nil, "name="+name, http.StatusNotFound))
+}
+
+func listScimUsers(c *Context, w http.ResponseWriter, r *http.Request) {
+	params, appErr := scimListParams(r)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	users, appErr := c.App.ListScimUsers(c.AppContext, params)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, users)
+}
+
+func getScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	user, appErr := c.App.GetScimUser(c.AppContext, c.Params.UserId)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, user)
+}
+
+func createScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	var scimUser model.ScimUser
+	if appErr := decodeScimBody(r, "createScimUser", &scimUser); appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCreateScimUser, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_name", scimUser.UserName)
+	auditRec.AddMeta("external_id", scimUser.ExternalId)
+
+	user, appErr := c.App.CreateScimUser(c.AppContext, &scimUser)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("user_id", user.Id)
+
+	w.Header().Set("Location", user.Meta.Location)
+	writeScimResponse(c, w, http.StatusCreated, user)
+}
+
+func replaceScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	var scimUser model.ScimUser
+	if appErr := decodeScimBody(r, "replaceScimUser", &scimUser); appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventUpdateScimUser, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	user, appErr := c.App.ReplaceScimUser(c.AppContext, c.Params.UserId, &scimUser)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	writeScimResponse(c, w, http.StatusOK, user)
+}
+
+func patchScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	var patch model.ScimPatchRequest
+	if appErr := decodeScimBody(r, "patchScimUser", &patch); appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventUpdateScimUser, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	user, appErr := c.App.PatchScimUser(c.AppContext, c.Params.UserId, patch.Operations)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	writeScimResponse(c, w, http.StatusOK, user)
+}
+
+func deleteScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord(model.AuditEventDeleteScimUser, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if appErr := c.App.DeleteScimUser(c.AppContext, c.Params.UserId); appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func listScimGroups(c *Context, w http.ResponseWriter, r *http.Request) {
+	params, appErr := scimListParams(r)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	groups, appErr := c.App.ListScimGroups(c.AppContext, params)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, groups)
+}
+
+func getScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	params := &model.ScimListParams{ExcludedAttributes: strings.Split(r.URL.Query().Get("excludedAttributes"), ",")}
+
+	group, appErr := c.App.GetScimGroup(c.AppContext, c.Params.GroupId, params.Excludes("members"))
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, group)
+}
+
+func createScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	var scimGroup model.ScimGroup
+	if appErr := decodeScimBody(r, "createScimGroup", &scimGroup); appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCreateScimGroup, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("display_name", scimGroup.DisplayName)
+	auditRec.AddMeta("external_id", scimGroup.ExternalId)
+
+	group, appErr := c.App.CreateScimGroup(c.AppContext, &scimGroup)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("group_id", group.Id)
+
+	w.Header().Set("Location", group.Meta.Location)
+	writeScimResponse(c, w, http.StatusCreated, group)
+}
+
+func replaceScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	var scimGroup model.ScimGroup
+	if appErr := decodeScimBody(r, "replaceScimGroup", &scimGroup); appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventUpdateScimGroup, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("group_id", c.Params.GroupId)
+
+	group, appErr := c.App.ReplaceScimGroup(c.AppContext, c.Params.GroupId, &scimGroup)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	writeScimResponse(c, w, http.StatusOK, group)
+}
+
+// patchScimGroup responds without a body, so that identity providers patching the members of
+// large groups don't receive them all.
+func patchScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	var patch model.ScimPatchRequest
+	if appErr := decodeScimBody(r, "patchScimGroup", &patch); appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventUpdateScimGroup, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("group_id", c.Params.GroupId)
+
+	if appErr := c.App.PatchScimGroup(c.AppContext, c.Params.GroupId, patch.Operations); appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func deleteScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord(model.AuditEventDeleteScimGroup, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("group_id", c.Params.GroupId)
+
+	if appErr := c.App.DeleteScimGroup(c.AppContext, c.Params.GroupId); appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	w.WriteHeader(http.StatusNoContent)
+}
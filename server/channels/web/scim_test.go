@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSCIMEndpoints(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	th := Setup(t).InitBasic(t)
+
+	token := model.NewRandomString(model.ScimSettingsMinBearerTokenLength)
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ScimSettings.Enable = true
+		*cfg.ScimSettings.BearerToken = token
+		*cfg.ServiceSettings.SiteURL = apiClient.URL
+	})
+
+	doRequest := func(t *testing.T, method, path, bearer, body string) *http.Response {
+		t.Helper()
+
+		rq, err := http.NewRequest(method, apiClient.URL+model.ScimBasePath+path, strings.NewReader(body))
+		require.NoError(t, err)
+		rq.Header.Set("Content-Type", model.ScimContentType)
+		if bearer != "" {
+			rq.Header.Set(model.HeaderAuth, model.HeaderBearer+" "+bearer)
+		}
+
+		rp, err := apiClient.HTTPClient.Do(rq)
+		require.NoError(t, err)
+		t.Cleanup(func() { rp.Body.Close() })
+		return rp
+	}
+
+	t.Run("requires the bearer token", func(t *testing.T) {
+		rp := doRequest(t, http.MethodGet, "/Users", "", "")
+		assert.Equal(t, http.StatusUnauthorized, rp.StatusCode)
+
+		rp = doRequest(t, http.MethodGet, "/Users", model.NewRandomString(model.ScimSettingsMinBearerTokenLength), "")
+		assert.Equal(t, http.StatusUnauthorized, rp.StatusCode)
+
+		var scimErr model.ScimError
+		require.NoError(t, json.NewDecoder(rp.Body).Decode(&scimErr))
+		assert.Equal(t, "401", scimErr.Status)
+		assert.Equal(t, []string{model.ScimSchemaError}, scimErr.Schemas)
+	})
+
+	t.Run("service provider config", func(t *testing.T) {
+		rp := doRequest(t, http.MethodGet, "/ServiceProviderConfig", token, "")
+		require.Equal(t, http.StatusOK, rp.StatusCode)
+		assert.Equal(t, model.ScimContentType, rp.Header.Get("Content-Type"))
+
+		rp = doRequest(t, http.MethodGet, "/Schemas/"+model.ScimSchemaGroup, token, "")
+		assert.Equal(t, http.StatusOK, rp.StatusCode)
+	})
+
+	t.Run("provision a user", func(t *testing.T) {
+		rp := doRequest(t, http.MethodPost, "/Users", token, `{
+			"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+			"userName": "scim.user@example.com",
+			"emails": [{"value": "scim.user@example.com", "primary": true}],
+			"active": true
+		}`)
+		require.Equal(t, http.StatusCreated, rp.StatusCode)
+
+		var scimUser model.ScimUser
+		require.NoError(t, json.NewDecoder(rp.Body).Decode(&scimUser))
+		assert.Equal(t, scimUser.Meta.Location, rp.Header.Get("Location"))
+
+		rp = doRequest(t, http.MethodPatch, "/Users/"+scimUser.Id, token, `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "replace", "path": "active", "value": false}]
+		}`)
+		require.Equal(t, http.StatusOK, rp.StatusCode)
+
+		user, appErr := th.App.GetUser(scimUser.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, user.DeleteAt)
+
+		rp = doRequest(t, http.MethodDelete, "/Users/"+scimUser.Id, token, "")
+		assert.Equal(t, http.StatusNoContent, rp.StatusCode)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		rp := doRequest(t, http.MethodGet, `/Users?filter=userName%20or%20x`, token, "")
+		require.Equal(t, http.StatusBadRequest, rp.StatusCode)
+
+		data, err := io.ReadAll(rp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), model.ScimErrorTypeInvalidFilter)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ScimSettings.Enable = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ScimSettings.Enable = true })
+
+		rp := doRequest(t, http.MethodGet, "/Users", token, "")
+		assert.Equal(t, http.StatusNotImplemented, rp.StatusCode)
+	})
+}
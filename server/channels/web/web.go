@@ -33,6 +33,7 @@ func New(srv *app.Server) *Web {
 	web.InitOAuth()
 	web.InitWebhooks()
 	web.InitSaml()
+	web.InitSCIM()
 	web.InitStatic()
 
 	return web
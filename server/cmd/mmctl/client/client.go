@@ -38,6 +38,11 @@ type Client interface {
 	RemoveTeamMember(ctx context.Context, teamID, userID string) (*model.Response, error)
 	SoftDeleteTeam(ctx context.Context, teamID string) (*model.Response, error)
 	PermanentDeleteTeam(ctx context.Context, teamID string) (*model.Response, error)
+	CreateTeamInviteLink(ctx context.Context, link *model.TeamInviteLink) (*model.TeamInviteLink, *model.Response, error)
+	GetTeamInviteLinks(ctx context.Context, teamID string, includeRevoked bool) ([]*model.TeamInviteLink, *model.Response, error)
+	GetTeamInviteLink(ctx context.Context, teamID, linkID string) (*model.TeamInviteLink, *model.Response, error)
+	RevokeTeamInviteLink(ctx context.Context, teamID, linkID string) (*model.Response, error)
+	GetTeamInviteLinkUses(ctx context.Context, teamID, linkID string, page, perPage int) ([]*model.TeamInviteLinkUse, *model.Response, error)
 	RestoreTeam(ctx context.Context, teamID string) (*model.Team, *model.Response, error)
 	UpdateTeamPrivacy(ctx context.Context, teamID string, privacy string) (*model.Team, *model.Response, error)
 	SearchTeams(ctx context.Context, search *model.TeamSearch) ([]*model.Team, *model.Response, error)
@@ -86,6 +91,9 @@ type Client interface {
 	ConvertBotToUser(ctx context.Context, userID string, userPatch *model.UserPatch, setSystemAdmin bool) (*model.User, *model.Response, error)
 	PromoteGuestToUser(ctx context.Context, userID string) (*model.Response, error)
 	DemoteUserToGuest(ctx context.Context, guestID string) (*model.Response, error)
+	GetGuestAccessExpiry(ctx context.Context, guestID string) (*model.GuestAccessExpiry, *model.Response, error)
+	SetGuestAccessExpiry(ctx context.Context, guestID string, expiresAt int64) (*model.GuestAccessExpiry, *model.Response, error)
+	RemoveGuestAccessExpiry(ctx context.Context, guestID string) (*model.Response, error)
 	CreateCommand(ctx context.Context, cmd *model.Command) (*model.Command, *model.Response, error)
 	ListCommands(ctx context.Context, teamID string, customOnly bool) ([]*model.Command, *model.Response, error)
 	GetCommandById(ctx context.Context, cmdID string) (*model.Command, *model.Response, error)
@@ -116,6 +124,9 @@ type Client interface {
 	SetServerBusy(ctx context.Context, secs int) (*model.Response, error)
 	ClearServerBusy(ctx context.Context) (*model.Response, error)
 	GetServerBusy(ctx context.Context) (*model.ServerBusyState, *model.Response, error)
+	GetStoreFaults(ctx context.Context) ([]*model.StoreFault, *model.Response, error)
+	SetStoreFaults(ctx context.Context, faults []*model.StoreFault) (*model.Response, error)
+	ClearStoreFaults(ctx context.Context) (*model.Response, error)
 	CheckIntegrity(ctx context.Context) ([]model.IntegrityCheckResult, *model.Response, error)
 	InstallPluginFromURL(context.Context, string, bool) (*model.Manifest, *model.Response, error)
 	InstallMarketplacePlugin(context.Context, *model.InstallMarketplacePluginRequest) (*model.Manifest, *model.Response, error)
@@ -173,4 +184,16 @@ type Client interface {
 	ListCPAValues(ctx context.Context, userID string) (map[string]json.RawMessage, *model.Response, error)
 	PatchCPAValues(ctx context.Context, values map[string]json.RawMessage) (map[string]json.RawMessage, *model.Response, error)
 	PatchCPAValuesForUser(ctx context.Context, userID string, values map[string]json.RawMessage) (map[string]json.RawMessage, *model.Response, error)
+	GetOutgoingEmails(ctx context.Context, status string, page, perPage int) (*model.OutgoingEmailsWithCount, *model.Response, error)
+	RetryOutgoingEmail(ctx context.Context, emailID string) (*model.OutgoingEmail, *model.Response, error)
+	DeleteOutgoingEmail(ctx context.Context, emailID string) (*model.Response, error)
+	UploadDKIMPrivateKey(ctx context.Context, data []byte, fileName string) (*model.Response, error)
+	RemoveDKIMPrivateKey(ctx context.Context) (*model.Response, error)
+	CreateEmoji(ctx context.Context, emoji *model.Emoji, image []byte, filename string) (*model.Emoji, *model.Response, error)
+	GetSortedEmojiList(ctx context.Context, page, perPage int, sort string) ([]*model.Emoji, *model.Response, error)
+	GetEmojiByName(ctx context.Context, name string) (*model.Emoji, *model.Response, error)
+	DeleteEmoji(ctx context.Context, emojiID string) (*model.Response, error)
+	GetEmojiCategories(ctx context.Context) ([]*model.EmojiCategory, *model.Response, error)
+	ImportEmojiPack(ctx context.Context, data []byte, filename string) (*model.EmojiPackImportResult, *model.Response, error)
+	ExportEmojiPack(ctx context.Context) ([]byte, *model.Response, error)
 }
@@ -36,7 +36,8 @@ var LoginCmd = &cobra.Command{
 	Example: `  auth login https://mattermost.example.com
   auth login https://mattermost.example.com --name local-server --username sysadmin --password-file mysupersecret.txt
   auth login https://mattermost.example.com --name local-server --username sysadmin --password-file mysupersecret.txt --mfa-token 123456
-  auth login https://mattermost.example.com --name local-server --access-token myaccesstoken`,
+  auth login https://mattermost.example.com --name local-server --access-token myaccesstoken
+  auth login https://mattermost.example.com --name local-server --device`,
 	Args: cobra.ExactArgs(1),
 	RunE: loginCmdF,
 }
@@ -103,6 +104,7 @@ func init() {
 	_ = LoginCmd.Flags().MarkHidden("password")
 	LoginCmd.Flags().StringP("password-file", "f", "", "Password file to be read for the credentials")
 	LoginCmd.Flags().Bool("no-activate", false, "If present, it won't activate the credentials after login")
+	LoginCmd.Flags().Bool("device", false, "Login through the browser by confirming a code, which supports SSO and MFA")
 
 	RenewCmd.Flags().StringP("password", "p", "", "Password for the credentials")
 	_ = RenewCmd.Flags().MarkHidden("password")
@@ -163,6 +165,14 @@ func loginCmdF(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	device, err := cmd.Flags().GetBool("device")
+	if err != nil {
+		return err
+	}
+	if device && (username != "" || password != "" || accessToken != "" || mfaToken != "") {
+		return errors.New("--device cannot be used together with username, password, access token or MFA token")
+	}
+
 	allowInsecureSHA1 := viper.GetBool("insecure-sha1-intermediate")
 	allowInsecureTLS := viper.GetBool("insecure-tls-version")
 
@@ -194,6 +204,21 @@ func loginCmdF(cmd *cobra.Command, args []string) error {
 		name = strings.TrimSpace(name)
 	}
 
+	if device {
+		c, user, err := InitClientWithDeviceAuthorization(ctx, instanceURL, allowInsecureSHA1, allowInsecureTLS)
+		if err != nil {
+			return fmt.Errorf("could not initiate client: %w", err)
+		}
+
+		return storeLoginCredentials(cmd, Credentials{
+			Name:        name,
+			InstanceURL: instanceURL,
+			Username:    user.Username,
+			AuthToken:   c.AuthToken,
+			AuthMethod:  MethodDevice,
+		})
+	}
+
 	if accessToken != "" && username != "" {
 		return errors.New("you must use --access-token or --username, but not both")
 	}
@@ -242,26 +267,28 @@ func loginCmdF(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	credentials := Credentials{
+	return storeLoginCredentials(cmd, Credentials{
 		Name:        name,
 		InstanceURL: instanceURL,
 		Username:    username,
 		AuthToken:   accessToken,
 		AuthMethod:  method,
-	}
+	})
+}
 
+func storeLoginCredentials(cmd *cobra.Command, credentials Credentials) error {
 	if err := SaveCredentials(credentials); err != nil {
 		return err
 	}
 
 	noActivate, _ := cmd.Flags().GetBool("no-activate")
 	if !noActivate {
-		if err := SetCurrent(name); err != nil {
+		if err := SetCurrent(credentials.Name); err != nil {
 			return err
 		}
 	}
 
-	printer.Print(fmt.Sprintf("\n  credentials for %q: \"%s@%s\" stored\n", name, username, instanceURL))
+	printer.Print(fmt.Sprintf("\n  credentials for %q: \"%s@%s\" stored\n", credentials.Name, credentials.Username, credentials.InstanceURL))
 	return nil
 }
 
@@ -410,6 +437,14 @@ func renewCmdF(cmd *cobra.Command, args []string) error {
 		}
 		credentials.AuthToken = c.AuthToken
 
+	case MethodDevice:
+		c, user, err := InitClientWithDeviceAuthorization(ctx, credentials.InstanceURL, allowInsecureSHA1, allowInsecureTLS)
+		if err != nil {
+			return err
+		}
+		credentials.Username = user.Username
+		credentials.AuthToken = c.AuthToken
+
 	default:
 		return errors.Errorf("invalid auth method %q", credentials.AuthMethod)
 	}
@@ -18,6 +18,7 @@ const (
 	MethodPassword = "P"
 	MethodToken    = "T"
 	MethodMFA      = "M"
+	MethodDevice   = "D"
 
 	userHomeVar      = "$HOME"
 	configFileName   = "config"
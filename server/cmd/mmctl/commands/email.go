@@ -0,0 +1,234 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/spf13/cobra"
+)
+
+var EmailCmd = &cobra.Command{
+	Use:   "email",
+	Short: "Management of outgoing email",
+}
+
+var EmailQueueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Management of the outbound email queue",
+}
+
+var EmailQueueListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List queued emails",
+	Long:  "List the emails in the outbound queue. By default only the emails that exhausted their delivery attempts are listed.",
+	Example: `  email queue list
+  email queue list --status pending --page 1 --per-page 20`,
+	Args: cobra.NoArgs,
+	RunE: withClient(emailQueueListCmdF),
+}
+
+var EmailQueueRetryCmd = &cobra.Command{
+	Use:     "retry [emails]",
+	Short:   "Retry queued emails",
+	Long:    "Reset the delivery attempts of the given emails so that they are sent again.",
+	Example: "  email queue retry emailID1 emailID2",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(emailQueueRetryCmdF),
+}
+
+var EmailQueueDeleteCmd = &cobra.Command{
+	Use:     "delete [emails]",
+	Short:   "Delete queued emails",
+	Long:    "Remove the given emails from the outbound queue without sending them.",
+	Example: "  email queue delete emailID1 emailID2",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(emailQueueDeleteCmdF),
+}
+
+var EmailDKIMCmd = &cobra.Command{
+	Use:   "dkim",
+	Short: "Management of the DKIM signing key",
+}
+
+var EmailDKIMUploadCmd = &cobra.Command{
+	Use:     "upload [private-key]",
+	Short:   "Upload the DKIM private key",
+	Long:    "Upload the PEM encoded RSA or Ed25519 private key used to DKIM sign outgoing email. Replaces the current key.",
+	Example: "  email dkim upload /path/to/dkim.pem",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(emailDKIMUploadCmdF),
+}
+
+var EmailDKIMRemoveCmd = &cobra.Command{
+	Use:     "remove",
+	Short:   "Remove the DKIM private key",
+	Long:    "Remove the DKIM private key and disable DKIM signing.",
+	Example: "  email dkim remove",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(emailDKIMRemoveCmdF),
+}
+
+func init() {
+	EmailQueueListCmd.Flags().String("status", model.OutgoingEmailStatusDead, "Status of the emails to list, either \"dead\" or \"pending\"")
+	EmailQueueListCmd.Flags().Int("page", 0, "Page number to fetch for the list of emails")
+	EmailQueueListCmd.Flags().Int("per-page", DefaultPageSize, "Number of emails to be fetched")
+	EmailQueueListCmd.Flags().Bool("all", false, "Fetch all emails. --page flag will be ignored if provided")
+
+	EmailQueueCmd.AddCommand(
+		EmailQueueListCmd,
+		EmailQueueRetryCmd,
+		EmailQueueDeleteCmd,
+	)
+
+	EmailDKIMCmd.AddCommand(
+		EmailDKIMUploadCmd,
+		EmailDKIMRemoveCmd,
+	)
+
+	EmailCmd.AddCommand(
+		EmailQueueCmd,
+		EmailDKIMCmd,
+	)
+
+	RootCmd.AddCommand(EmailCmd)
+}
+
+func emailQueueListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	status, err := cmd.Flags().GetString("status")
+	if err != nil {
+		return err
+	}
+	if status != model.OutgoingEmailStatusDead && status != model.OutgoingEmailStatusPending {
+		return fmt.Errorf("invalid email status: %s", status)
+	}
+	page, err := cmd.Flags().GetInt("page")
+	if err != nil {
+		return err
+	}
+	perPage, err := cmd.Flags().GetInt("per-page")
+	if err != nil {
+		return err
+	}
+	showAll, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return err
+	}
+
+	if showAll {
+		page = 0
+	}
+
+	for {
+		emails, _, err := c.GetOutgoingEmails(context.TODO(), status, page, perPage)
+		if err != nil {
+			return fmt.Errorf("failed to get queued emails: %w", err)
+		}
+
+		if len(emails.Emails) == 0 {
+			if !showAll || page == 0 {
+				printer.Print("No emails found")
+			}
+			return nil
+		}
+
+		for _, email := range emails.Emails {
+			printer.PrintT(fmt.Sprintf(`  ID: {{.Id}}
+  To: {{.To}}
+  Subject: {{.Subject}}
+  Status: {{.Status}}
+  Attempts: {{.Attempts}}
+  Created: %s
+  Next attempt: %s
+  Last error: {{.LastError}}
+`,
+				time.UnixMilli(email.CreateAt), time.UnixMilli(email.NextAttemptAt)), email)
+		}
+
+		if !showAll {
+			break
+		}
+
+		page++
+	}
+
+	return nil
+}
+
+func emailQueueRetryCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	var result *multierror.Error
+	for _, id := range args {
+		if !model.IsValidId(id) {
+			result = multierror.Append(result, fmt.Errorf("invalid email ID: %s", id))
+			continue
+		}
+
+		email, _, err := c.RetryOutgoingEmail(context.TODO(), id)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to retry email %s: %w", id, err))
+			continue
+		}
+
+		printer.PrintT("Email {{.Id}} queued for delivery", email)
+	}
+
+	return result.ErrorOrNil()
+}
+
+func emailQueueDeleteCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	var result *multierror.Error
+	for _, id := range args {
+		if !model.IsValidId(id) {
+			result = multierror.Append(result, fmt.Errorf("invalid email ID: %s", id))
+			continue
+		}
+
+		if _, err := c.DeleteOutgoingEmail(context.TODO(), id); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to delete email %s: %w", id, err))
+			continue
+		}
+
+		printer.Print(fmt.Sprintf("Email %s deleted", id))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func emailDKIMUploadCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	fileBytes, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	if len(fileBytes) == 0 {
+		return errors.New("the private key file is empty")
+	}
+
+	if _, err := c.UploadDKIMPrivateKey(context.TODO(), fileBytes, filepath.Base(args[0])); err != nil {
+		return err
+	}
+
+	printer.Print("Uploaded DKIM private key")
+
+	return nil
+}
+
+func emailDKIMRemoveCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	if _, err := c.RemoveDKIMPrivateKey(context.TODO()); err != nil {
+		return err
+	}
+
+	printer.Print("Removed DKIM private key")
+
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestEmailQueueListCmd() {
+	s.Run("List dead emails", func() {
+		printer.Clean()
+
+		email := &model.OutgoingEmail{Id: model.NewId(), To: "user@example.com", Subject: "subject", Status: model.OutgoingEmailStatusDead, Attempts: 8}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("status", model.OutgoingEmailStatusDead, "")
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 10, "")
+		cmd.Flags().Bool("all", false, "")
+
+		s.client.
+			EXPECT().
+			GetOutgoingEmails(context.TODO(), model.OutgoingEmailStatusDead, 0, 10).
+			Return(&model.OutgoingEmailsWithCount{Emails: []*model.OutgoingEmail{email}, TotalCount: 1}, &model.Response{}, nil).
+			Times(1)
+
+		err := emailQueueListCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(email, printer.GetLines()[0])
+	})
+
+	s.Run("No emails", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("status", model.OutgoingEmailStatusPending, "")
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 10, "")
+		cmd.Flags().Bool("all", true, "")
+
+		s.client.
+			EXPECT().
+			GetOutgoingEmails(context.TODO(), model.OutgoingEmailStatusPending, 0, 10).
+			Return(&model.OutgoingEmailsWithCount{Emails: []*model.OutgoingEmail{}}, &model.Response{}, nil).
+			Times(1)
+
+		err := emailQueueListCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("No emails found", printer.GetLines()[0])
+	})
+
+	s.Run("Invalid status", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("status", "sent", "")
+
+		err := emailQueueListCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, "invalid email status: sent")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmailQueueRetryCmd() {
+	s.Run("Retry emails", func() {
+		printer.Clean()
+
+		id := model.NewId()
+		email := &model.OutgoingEmail{Id: id, Status: model.OutgoingEmailStatusPending}
+
+		s.client.
+			EXPECT().
+			RetryOutgoingEmail(context.TODO(), id).
+			Return(email, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := emailQueueRetryCmdF(s.client, &cobra.Command{}, []string{id, "invalid"})
+		s.Require().ErrorContains(err, "invalid email ID: invalid")
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(email, printer.GetLines()[0])
+	})
+
+	s.Run("Fail to retry email", func() {
+		printer.Clean()
+
+		id := model.NewId()
+		s.client.
+			EXPECT().
+			RetryOutgoingEmail(context.TODO(), id).
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("mock error")).
+			Times(1)
+
+		err := emailQueueRetryCmdF(s.client, &cobra.Command{}, []string{id})
+		s.Require().ErrorContains(err, "mock error")
+		s.Require().Empty(printer.GetLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmailQueueDeleteCmd() {
+	printer.Clean()
+
+	id := model.NewId()
+	s.client.
+		EXPECT().
+		DeleteOutgoingEmail(context.TODO(), id).
+		Return(&model.Response{StatusCode: http.StatusOK}, nil).
+		Times(1)
+
+	err := emailQueueDeleteCmdF(s.client, &cobra.Command{}, []string{id})
+	s.Require().NoError(err)
+	s.Require().Len(printer.GetLines(), 1)
+	s.Require().Equal("Email "+id+" deleted", printer.GetLines()[0])
+}
+
+func (s *MmctlUnitTestSuite) TestEmailDKIMCmds() {
+	s.Run("Upload key", func() {
+		printer.Clean()
+
+		keyFile := filepath.Join(s.T().TempDir(), "dkim.pem")
+		s.Require().NoError(os.WriteFile(keyFile, []byte("key"), 0600))
+
+		s.client.
+			EXPECT().
+			UploadDKIMPrivateKey(context.TODO(), []byte("key"), "dkim.pem").
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := emailDKIMUploadCmdF(s.client, &cobra.Command{}, []string{keyFile})
+		s.Require().NoError(err)
+		s.Require().Equal("Uploaded DKIM private key", printer.GetLines()[0])
+	})
+
+	s.Run("Remove key", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			RemoveDKIMPrivateKey(context.TODO()).
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := emailDKIMRemoveCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().NoError(err)
+		s.Require().Equal("Removed DKIM private key", printer.GetLines()[0])
+	})
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/spf13/cobra"
+)
+
+var EmojiCmd = &cobra.Command{
+	Use:   "emoji",
+	Short: "Management of custom emojis",
+}
+
+var EmojiListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List custom emojis",
+	Example: `  emoji list
+  emoji list --all`,
+	Args: cobra.NoArgs,
+	RunE: withClient(emojiListCmdF),
+}
+
+var EmojiCreateCmd = &cobra.Command{
+	Use:     "create [name] [image]",
+	Short:   "Create a custom emoji",
+	Long:    "Create a custom emoji from an image, optionally with aliases and in a category.",
+	Example: "  emoji create party_parrot parrot.gif --alias parrot --alias partyparrot --category animals",
+	Args:    cobra.ExactArgs(2),
+	RunE:    withClient(emojiCreateCmdF),
+}
+
+var EmojiDeleteCmd = &cobra.Command{
+	Use:     "delete [emojis]",
+	Short:   "Delete custom emojis",
+	Long:    "Delete custom emojis by name. The reactions using the emojis are removed.",
+	Example: "  emoji delete party_parrot thumbsup_custom",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(emojiDeleteCmdF),
+}
+
+var EmojiImportCmd = &cobra.Command{
+	Use:   "import [emoji-pack]",
+	Short: "Import an emoji pack",
+	Long: `Create the custom emojis of an emoji pack. An emoji pack is a zip file holding a manifest.json file and the images of the emojis.
+Emojis whose name is already taken are skipped.`,
+	Example: "  emoji import emojis.zip",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(emojiImportCmdF),
+}
+
+var EmojiExportCmd = &cobra.Command{
+	Use:     "export [emoji-pack]",
+	Short:   "Export the custom emojis as an emoji pack",
+	Long:    "Export all the custom emojis, their aliases and categories as an emoji pack which can be imported on another server.",
+	Example: "  emoji export emojis.zip",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(emojiExportCmdF),
+}
+
+func init() {
+	EmojiListCmd.Flags().Int("page", 0, "Page number to fetch for the list of emojis")
+	EmojiListCmd.Flags().Int("per-page", DefaultPageSize, "Number of emojis to be fetched")
+	EmojiListCmd.Flags().Bool("all", false, "Fetch all emojis. --page flag will be ignored if provided")
+
+	EmojiCreateCmd.Flags().StringSlice("alias", nil, "Alias of the emoji, can be repeated")
+	EmojiCreateCmd.Flags().String("category", "", "Name of the category of the emoji")
+
+	EmojiCmd.AddCommand(
+		EmojiListCmd,
+		EmojiCreateCmd,
+		EmojiDeleteCmd,
+		EmojiImportCmd,
+		EmojiExportCmd,
+	)
+
+	RootCmd.AddCommand(EmojiCmd)
+}
+
+func emojiListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	page, err := cmd.Flags().GetInt("page")
+	if err != nil {
+		return err
+	}
+	perPage, err := cmd.Flags().GetInt("per-page")
+	if err != nil {
+		return err
+	}
+	showAll, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return err
+	}
+
+	if showAll {
+		page = 0
+	}
+
+	for {
+		emojis, _, err := c.GetSortedEmojiList(context.TODO(), page, perPage, model.EmojiSortByName)
+		if err != nil {
+			return fmt.Errorf("failed to get emojis: %w", err)
+		}
+
+		if len(emojis) == 0 {
+			if !showAll || page == 0 {
+				printer.Print("No emojis found")
+			}
+			return nil
+		}
+
+		for _, emoji := range emojis {
+			printer.PrintT("{{.Id}}: {{.Name}}{{if .Aliases}} (aliases: {{range $i, $alias := .Aliases}}{{if $i}}, {{end}}{{$alias}}{{end}}){{end}}", emoji)
+		}
+
+		if !showAll {
+			break
+		}
+
+		page++
+	}
+
+	return nil
+}
+
+func emojiCreateCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	aliases, err := cmd.Flags().GetStringSlice("alias")
+	if err != nil {
+		return err
+	}
+	categoryName, err := cmd.Flags().GetString("category")
+	if err != nil {
+		return err
+	}
+
+	image, err := os.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+	if len(image) == 0 {
+		return errors.New("the image file is empty")
+	}
+
+	me, _, err := c.GetUser(context.TODO(), model.Me, "")
+	if err != nil {
+		return fmt.Errorf("failed to get the current user: %w", err)
+	}
+
+	emoji := &model.Emoji{
+		CreatorId: me.Id,
+		Name:      args[0],
+		Aliases:   aliases,
+	}
+
+	if categoryName != "" {
+		categories, _, err := c.GetEmojiCategories(context.TODO())
+		if err != nil {
+			return fmt.Errorf("failed to get emoji categories: %w", err)
+		}
+
+		for _, category := range categories {
+			if category.Name == categoryName {
+				emoji.CategoryId = category.Id
+				break
+			}
+		}
+
+		if emoji.CategoryId == "" {
+			return fmt.Errorf("can't find emoji category '%s'", categoryName)
+		}
+	}
+
+	createdEmoji, _, err := c.CreateEmoji(context.TODO(), emoji, image, filepath.Base(args[1]))
+	if err != nil {
+		return fmt.Errorf("failed to create emoji: %w", err)
+	}
+
+	printer.PrintT("Emoji {{.Name}} created with id {{.Id}}", createdEmoji)
+
+	return nil
+}
+
+func emojiDeleteCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	var result *multierror.Error
+	for _, name := range args {
+		emoji, _, err := c.GetEmojiByName(context.TODO(), name)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("can't find emoji '%s': %w", name, err))
+			continue
+		}
+
+		if _, err := c.DeleteEmoji(context.TODO(), emoji.Id); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to delete emoji '%s': %w", name, err))
+			continue
+		}
+
+		printer.Print(fmt.Sprintf("Emoji %s deleted", emoji.Name))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func emojiImportCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return errors.New("the emoji pack is empty")
+	}
+
+	importResult, _, err := c.ImportEmojiPack(context.TODO(), data, filepath.Base(args[0]))
+	if err != nil {
+		return fmt.Errorf("failed to import emoji pack: %w", err)
+	}
+
+	for _, emoji := range importResult.Created {
+		printer.PrintT("Emoji {{.Name}} created", emoji)
+	}
+
+	for _, name := range importResult.Skipped {
+		printer.Print(fmt.Sprintf("Emoji %s skipped, the name is already taken", name))
+	}
+
+	var result *multierror.Error
+	names := make([]string, 0, len(importResult.Errors))
+	for name := range importResult.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		result = multierror.Append(result, fmt.Errorf("failed to import emoji '%s': %s", name, importResult.Errors[name]))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func emojiExportCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	data, _, err := c.ExportEmojiPack(context.TODO())
+	if err != nil {
+		return fmt.Errorf("failed to export emoji pack: %w", err)
+	}
+
+	if err := os.WriteFile(args[0], data, 0600); err != nil {
+		return fmt.Errorf("failed to write emoji pack: %w", err)
+	}
+
+	printer.Print(fmt.Sprintf("Emoji pack exported to %s", args[0]))
+
+	return nil
+}
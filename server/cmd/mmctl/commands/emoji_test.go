@@ -0,0 +1,192 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestEmojiListCmd() {
+	s.Run("List emojis", func() {
+		printer.Clean()
+
+		emoji := &model.Emoji{Id: model.NewId(), Name: "party_parrot", Aliases: []string{"parrot"}}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 10, "")
+		cmd.Flags().Bool("all", false, "")
+
+		s.client.
+			EXPECT().
+			GetSortedEmojiList(context.TODO(), 0, 10, model.EmojiSortByName).
+			Return([]*model.Emoji{emoji}, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiListCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(emoji, printer.GetLines()[0])
+	})
+
+	s.Run("No emojis", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 10, "")
+		cmd.Flags().Bool("all", true, "")
+
+		s.client.
+			EXPECT().
+			GetSortedEmojiList(context.TODO(), 0, 10, model.EmojiSortByName).
+			Return([]*model.Emoji{}, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiListCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("No emojis found", printer.GetLines()[0])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiCreateCmd() {
+	imagePath := filepath.Join(s.T().TempDir(), "parrot.gif")
+	s.Require().NoError(os.WriteFile(imagePath, []byte("image"), 0600))
+
+	me := &model.User{Id: model.NewId()}
+	category := &model.EmojiCategory{Id: model.NewId(), Name: "animals"}
+
+	newCmd := func(category string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("alias", []string{"parrot"}, "")
+		cmd.Flags().String("category", category, "")
+		return cmd
+	}
+
+	s.Run("Create an emoji in a category", func() {
+		printer.Clean()
+
+		emoji := &model.Emoji{CreatorId: me.Id, Name: "party_parrot", Aliases: []string{"parrot"}, CategoryId: category.Id}
+		created := &model.Emoji{Id: model.NewId(), CreatorId: me.Id, Name: "party_parrot", Aliases: []string{"parrot"}, CategoryId: category.Id}
+
+		s.client.
+			EXPECT().
+			GetUser(context.TODO(), model.Me, "").
+			Return(me, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetEmojiCategories(context.TODO()).
+			Return([]*model.EmojiCategory{category}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateEmoji(context.TODO(), emoji, []byte("image"), "parrot.gif").
+			Return(created, &model.Response{StatusCode: http.StatusCreated}, nil).
+			Times(1)
+
+		err := emojiCreateCmdF(s.client, newCmd("animals"), []string{"party_parrot", imagePath})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(created, printer.GetLines()[0])
+	})
+
+	s.Run("Unknown category", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetUser(context.TODO(), model.Me, "").
+			Return(me, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetEmojiCategories(context.TODO()).
+			Return([]*model.EmojiCategory{category}, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiCreateCmdF(s.client, newCmd("plants"), []string{"party_parrot", imagePath})
+		s.Require().EqualError(err, "can't find emoji category 'plants'")
+		s.Require().Empty(printer.GetLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiDeleteCmd() {
+	printer.Clean()
+
+	emoji := &model.Emoji{Id: model.NewId(), Name: "party_parrot"}
+	s.client.
+		EXPECT().
+		GetEmojiByName(context.TODO(), "party_parrot").
+		Return(emoji, &model.Response{}, nil).
+		Times(1)
+	s.client.
+		EXPECT().
+		DeleteEmoji(context.TODO(), emoji.Id).
+		Return(&model.Response{StatusCode: http.StatusOK}, nil).
+		Times(1)
+	s.client.
+		EXPECT().
+		GetEmojiByName(context.TODO(), "missing").
+		Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("mock error")).
+		Times(1)
+
+	err := emojiDeleteCmdF(s.client, &cobra.Command{}, []string{"party_parrot", "missing"})
+	s.Require().ErrorContains(err, "can't find emoji 'missing'")
+	s.Require().Len(printer.GetLines(), 1)
+	s.Require().Equal("Emoji party_parrot deleted", printer.GetLines()[0])
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiImportCmd() {
+	printer.Clean()
+
+	packPath := filepath.Join(s.T().TempDir(), "emojis.zip")
+	s.Require().NoError(os.WriteFile(packPath, []byte("pack"), 0600))
+
+	created := &model.Emoji{Id: model.NewId(), Name: "party_parrot"}
+	s.client.
+		EXPECT().
+		ImportEmojiPack(context.TODO(), []byte("pack"), "emojis.zip").
+		Return(&model.EmojiPackImportResult{
+			Created: []*model.Emoji{created},
+			Skipped: []string{"thumbsup_custom"},
+			Errors:  map[string]string{"broken": "invalid image"},
+		}, &model.Response{}, nil).
+		Times(1)
+
+	err := emojiImportCmdF(s.client, &cobra.Command{}, []string{packPath})
+	s.Require().ErrorContains(err, "failed to import emoji 'broken': invalid image")
+	s.Require().Len(printer.GetLines(), 2)
+	s.Require().Equal(created, printer.GetLines()[0])
+	s.Require().Equal("Emoji thumbsup_custom skipped, the name is already taken", printer.GetLines()[1])
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiExportCmd() {
+	printer.Clean()
+
+	packPath := filepath.Join(s.T().TempDir(), "emojis.zip")
+	s.client.
+		EXPECT().
+		ExportEmojiPack(context.TODO()).
+		Return([]byte("pack"), &model.Response{}, nil).
+		Times(1)
+
+	err := emojiExportCmdF(s.client, &cobra.Command{}, []string{packPath})
+	s.Require().NoError(err)
+
+	data, err := os.ReadFile(packPath)
+	s.Require().NoError(err)
+	s.Require().Equal([]byte("pack"), data)
+	s.Require().Len(printer.GetLines(), 1)
+}
@@ -216,6 +216,66 @@ func InitClientWithMFA(ctx context.Context, username, password, mfaToken, instan
 	return client, resp.ServerVersion, nil
 }
 
+// deviceAuthorizationIntervalUnit is the unit of the polling interval sent by the server. It is
+// only changed by tests.
+var deviceAuthorizationIntervalUnit = time.Second
+
+// InitClientWithDeviceAuthorization logs in through the OAuth device authorization grant: the user
+// opens the verification page in a browser and confirms the code while mmctl polls the server for
+// the access token.
+func InitClientWithDeviceAuthorization(ctx context.Context, instanceURL string, allowInsecureSHA1, allowInsecureTLS bool) (*model.Client4, *model.User, error) {
+	client := NewAPIv4Client(instanceURL, allowInsecureSHA1, allowInsecureTLS)
+
+	deviceRsp, _, err := client.CreateOAuthDeviceAuthorization(ctx, model.OAuthAppMmctlClientId, "")
+	if err != nil {
+		return nil, nil, checkInsecureTLSError(err, allowInsecureTLS)
+	}
+
+	verificationURI := deviceRsp.VerificationURI
+	if deviceRsp.VerificationURIComplete != "" {
+		verificationURI = deviceRsp.VerificationURIComplete
+	}
+	fmt.Printf("To login, open the following URL in your browser and confirm the code %s:\n\n  %s\n\nWaiting for confirmation...\n", deviceRsp.UserCode, verificationURI)
+
+	interval := time.Duration(deviceRsp.Interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(interval * deviceAuthorizationIntervalUnit):
+		}
+
+		accessRsp, _, err := client.GetOAuthDeviceAccessToken(ctx, model.OAuthAppMmctlClientId, deviceRsp.DeviceCode)
+		if err == nil {
+			client.AuthType = model.HeaderBearer
+			client.AuthToken = accessRsp.AccessToken
+
+			user, _, err := client.GetMe(ctx, "")
+			if err != nil {
+				return nil, nil, err
+			}
+			return client, user, nil
+		}
+
+		var oauthErr *model.OAuthError
+		if !errors.As(err, &oauthErr) {
+			return nil, nil, err
+		}
+
+		switch oauthErr.Code {
+		case model.OAuthErrorAuthorizationPending:
+		case model.OAuthErrorSlowDown:
+			interval += model.DeviceCodeSlowDownIncrement
+		case model.OAuthErrorAccessDenied:
+			return nil, nil, errors.New("the login request was denied")
+		case model.OAuthErrorExpiredToken:
+			return nil, nil, errors.New("the code expired before the login request was confirmed")
+		default:
+			return nil, nil, err
+		}
+	}
+}
+
 func InitClientWithCredentials(ctx context.Context, credentials *Credentials, allowInsecureSHA1, allowInsecureTLS bool) (*model.Client4, string, error) {
 	client := NewAPIv4Client(credentials.InstanceURL, allowInsecureSHA1, allowInsecureTLS)
 
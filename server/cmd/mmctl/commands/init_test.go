@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost/server/public/model"
@@ -194,3 +195,76 @@ func TestNewAPIv4Client(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestInitClientWithDeviceAuthorization(t *testing.T) {
+	originalUnit := deviceAuthorizationIntervalUnit
+	deviceAuthorizationIntervalUnit = time.Millisecond
+	defer func() { deviceAuthorizationIntervalUnit = originalUnit }()
+
+	newServer := func(t *testing.T, tokenErrors []string) *httptest.Server {
+		t.Helper()
+
+		deviceCode := model.NewRandomString(model.DeviceCodeLength)
+		polls := 0
+
+		router := mux.NewRouter()
+		router.Handle(model.OAuthDeviceAuthorizationEndpoint, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, model.OAuthAppMmctlClientId, r.FormValue("client_id"))
+			err := json.NewEncoder(w).Encode(&model.DeviceAuthorizationResponse{
+				DeviceCode:      deviceCode,
+				UserCode:        "BCDF-GHJK",
+				VerificationURI: "http://localhost/oauth/device",
+				ExpiresIn:       model.DeviceCodeExpireTime,
+				Interval:        1,
+			})
+			require.NoError(t, err)
+		})).Methods(http.MethodPost)
+		router.Handle(model.OAuthAccessTokenEndpoint, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, model.GrantTypeDeviceCode, r.FormValue("grant_type"))
+			require.Equal(t, deviceCode, r.FormValue("device_code"))
+
+			if polls < len(tokenErrors) {
+				polls++
+				w.WriteHeader(http.StatusBadRequest)
+				err := json.NewEncoder(w).Encode(&model.OAuthError{Code: tokenErrors[polls-1]})
+				require.NoError(t, err)
+				return
+			}
+
+			err := json.NewEncoder(w).Encode(&model.AccessResponse{AccessToken: "devicetoken", TokenType: model.AccessTokenType})
+			require.NoError(t, err)
+		})).Methods(http.MethodPost)
+		router.Handle("/api/v4/users/me", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, model.HeaderBearer+" devicetoken", r.Header.Get(model.HeaderAuth))
+			err := json.NewEncoder(w).Encode(&model.User{Id: model.NewId(), Username: "deviceuser"})
+			require.NoError(t, err)
+		}))
+
+		s := httptest.NewServer(router)
+		t.Cleanup(s.Close)
+		return s
+	}
+
+	t.Run("should poll until the login request is confirmed", func(t *testing.T) {
+		s := newServer(t, []string{model.OAuthErrorAuthorizationPending, model.OAuthErrorSlowDown, model.OAuthErrorAuthorizationPending})
+
+		client, user, err := InitClientWithDeviceAuthorization(context.Background(), s.URL, false, false)
+		require.NoError(t, err)
+		require.Equal(t, "devicetoken", client.AuthToken)
+		require.Equal(t, "deviceuser", user.Username)
+	})
+
+	t.Run("should fail if the login request is denied", func(t *testing.T) {
+		s := newServer(t, []string{model.OAuthErrorAuthorizationPending, model.OAuthErrorAccessDenied})
+
+		_, _, err := InitClientWithDeviceAuthorization(context.Background(), s.URL, false, false)
+		require.EqualError(t, err, "the login request was denied")
+	})
+
+	t.Run("should fail if the code expires", func(t *testing.T) {
+		s := newServer(t, []string{model.OAuthErrorExpiredToken})
+
+		_, _, err := InitClientWithDeviceAuthorization(context.Background(), s.URL, false, false)
+		require.EqualError(t, err, "the code expired before the login request was confirmed")
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
 
@@ -73,7 +74,7 @@ var PluginDisableCmd = &cobra.Command{
 var PluginListCmd = &cobra.Command{
 	Use:     "list",
 	Short:   "List plugins",
-	Long:    "List all enabled and disabled plugins installed on your Mattermost server.",
+	Long:    "List all enabled and disabled plugins installed on your Mattermost server, along with their dependencies and whether they are met.",
 	Example: `  plugin list`,
 	RunE:    withClient(pluginListCmdF),
 }
@@ -191,17 +192,68 @@ func pluginListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	if format == printer.FormatJSON || json {
 		printer.Print(pluginsResp)
 	} else {
+		active := make(map[string]*model.PluginInfo, len(pluginsResp.Active))
+		installed := make(map[string]*model.PluginInfo, len(pluginsResp.Active)+len(pluginsResp.Inactive))
+		for _, plugin := range pluginsResp.Active {
+			active[plugin.Id] = plugin
+			installed[plugin.Id] = plugin
+		}
+		for _, plugin := range pluginsResp.Inactive {
+			installed[plugin.Id] = plugin
+		}
+
 		printer.Print("Listing enabled plugins")
 		for _, plugin := range pluginsResp.Active {
 			printer.PrintT("{{.Manifest.Id}}: {{.Manifest.Name}}, Version: {{.Manifest.Version}}", plugin)
+			printPluginDependencies(plugin, active, installed)
 		}
 
 		printer.Print("")
 		printer.Print("Listing disabled plugins")
 		for _, plugin := range pluginsResp.Inactive {
 			printer.PrintT("{{.Manifest.Id}}: {{.Manifest.Name}}, Version: {{.Manifest.Version}}", plugin)
+			printPluginDependencies(plugin, active, installed)
 		}
 	}
 
 	return nil
 }
+
+// printPluginDependencies prints the dependencies of a plugin and whether the installed plugins meet them.
+func printPluginDependencies(plugin *model.PluginInfo, active, installed map[string]*model.PluginInfo) {
+	for _, dependency := range plugin.Dependencies {
+		kind := "requires"
+		if dependency.Optional {
+			kind = "optionally requires"
+		}
+
+		versionRange := dependency.Version
+		if versionRange == "" {
+			versionRange = "any version"
+		}
+
+		printer.Print(fmt.Sprintf("  %s %s (%s): %s", kind, dependency.Id, versionRange, pluginDependencyStatus(dependency, active, installed)))
+	}
+}
+
+// pluginDependencyStatus describes whether the installed plugins meet a dependency.
+func pluginDependencyStatus(dependency *model.ManifestDependency, active, installed map[string]*model.PluginInfo) string {
+	plugin, ok := installed[dependency.Id]
+	if !ok {
+		return "unmet, not installed"
+	}
+
+	satisfied, err := dependency.IsSatisfiedBy(plugin.Version)
+	if err != nil {
+		return "unmet, " + err.Error()
+	}
+	if !satisfied {
+		return fmt.Sprintf("unmet, version %s is installed", plugin.Version)
+	}
+
+	if _, ok := active[dependency.Id]; !ok {
+		return "unmet, not enabled"
+	}
+
+	return "met"
+}
@@ -517,6 +517,79 @@ func (s *MmctlUnitTestSuite) TestPluginListCmd() {
 		}
 	})
 
+	s.Run("List Plain Plugins with dependencies", func() {
+		printer.Clean()
+		printer.SetFormat(printer.FormatPlain)
+		defer printer.SetFormat(printer.FormatJSON)
+
+		mockList := &model.PluginsResponse{
+			Active: []*model.PluginInfo{
+				{
+					Manifest: model.Manifest{
+						Id:      "id1",
+						Name:    "name1",
+						Version: "1.2.0",
+						Dependencies: []*model.ManifestDependency{
+							{Id: "id2", Version: ">=1.0.0"},
+							{Id: "id3", Version: ">=2.0.0"},
+							{Id: "id4", Optional: true},
+						},
+					},
+				},
+				{
+					Manifest: model.Manifest{
+						Id:      "id2",
+						Name:    "name2",
+						Version: "1.5.0",
+					},
+				},
+			}, Inactive: []*model.PluginInfo{
+				{
+					Manifest: model.Manifest{
+						Id:      "id3",
+						Name:    "name3",
+						Version: "1.0.0",
+						Dependencies: []*model.ManifestDependency{
+							{Id: "id5"},
+							{Id: "id2", Version: "<1.0.0"},
+						},
+					},
+				},
+				{
+					Manifest: model.Manifest{
+						Id:      "id4",
+						Name:    "name4",
+						Version: "1.0.0",
+					},
+				},
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetPlugins(context.TODO()).
+			Return(mockList, &model.Response{}, nil).
+			Times(1)
+
+		err := pluginListCmdF(s.client, &cobra.Command{}, nil)
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Equal([]any{
+			"Listing enabled plugins",
+			"id1: name1, Version: 1.2.0",
+			"  requires id2 (>=1.0.0): met",
+			"  requires id3 (>=2.0.0): unmet, version 1.0.0 is installed",
+			"  optionally requires id4 (any version): unmet, not enabled",
+			"id2: name2, Version: 1.5.0",
+			"",
+			"Listing disabled plugins",
+			"id3: name3, Version: 1.0.0",
+			"  requires id5 (any version): unmet, not installed",
+			"  requires id2 (<1.0.0): unmet, version 1.5.0 is installed",
+			"id4: name4, Version: 1.0.0",
+		}, printer.GetLines())
+	})
+
 	s.Run("GetPlugins returns error", func() {
 		printer.Clean()
 		mockError := errors.New("mock error")
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -77,7 +78,59 @@ var SystemSupportPacketCmd = &cobra.Command{
 	RunE:    withClient(systemSupportPacketCmdF),
 }
 
+var SystemStoreFaultsCmd = &cobra.Command{
+	Use:   "storefaults",
+	Short: "Management of the store faults",
+	Long: `Management of the faults injected in the store methods, to test how the server behaves when the database is slow or failing.
+The server must be started with the StoreFaultInjection feature flag enabled, and the commands are only available in local mode.`,
+}
+
+var SystemStoreFaultsListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List the store faults",
+	Long:    `List the faults injected in the store methods.`,
+	Example: `  system storefaults list --local`,
+	Args:    cobra.NoArgs,
+	RunE:    withClient(storeFaultsListCmdF),
+}
+
+var SystemStoreFaultsSetCmd = &cobra.Command{
+	Use:   "set --method [method]",
+	Short: "Set the faults of a store method",
+	Long: `Set the faults injected in a store method, such as "PostStore.Save", in all the methods of a store, such as "PostStore.*", or in all the store methods with "*".
+The faults of a more specific method take precedence.`,
+	Example: `  system storefaults set --method PostStore.Save --latency 500ms --latency-rate 0.5 --error-rate 0.1 --local
+  system storefaults set --method "ChannelStore.*" --serialization-failure-rate 0.2 --local`,
+	Args: cobra.NoArgs,
+	RunE: withClient(storeFaultsSetCmdF),
+}
+
+var SystemStoreFaultsClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Clear the store faults",
+	Long:  `Stop injecting faults in the store methods, or only in the given method.`,
+	Example: `  system storefaults clear --local
+  system storefaults clear --method PostStore.Save --local`,
+	Args: cobra.NoArgs,
+	RunE: withClient(storeFaultsClearCmdF),
+}
+
 func init() {
+	SystemStoreFaultsSetCmd.Flags().String("method", "", "The store method, such as PostStore.Save, PostStore.* or *.")
+	SystemStoreFaultsSetCmd.Flags().Duration("latency", 0, "The latency added to the calls.")
+	SystemStoreFaultsSetCmd.Flags().Float64("latency-rate", 1, "The rate of the calls the latency is added to, between 0 and 1.")
+	SystemStoreFaultsSetCmd.Flags().Float64("error-rate", 0, "The rate of the calls failing with an error, between 0 and 1.")
+	SystemStoreFaultsSetCmd.Flags().Float64("serialization-failure-rate", 0, "The rate of the calls failing with a serialization failure, between 0 and 1.")
+	_ = SystemStoreFaultsSetCmd.MarkFlagRequired("method")
+
+	SystemStoreFaultsClearCmd.Flags().String("method", "", "Only clear the faults of this store method.")
+
+	SystemStoreFaultsCmd.AddCommand(
+		SystemStoreFaultsListCmd,
+		SystemStoreFaultsSetCmd,
+		SystemStoreFaultsClearCmd,
+	)
+
 	SystemSetBusyCmd.Flags().UintP("seconds", "s", 3600, "Number of seconds until server is automatically marked as not busy.")
 	_ = SystemSetBusyCmd.MarkFlagRequired("seconds")
 
@@ -90,6 +143,7 @@ func init() {
 		SystemVersionCmd,
 		SystemStatusCmd,
 		SystemSupportPacketCmd,
+		SystemStoreFaultsCmd,
 	)
 	RootCmd.AddCommand(SystemCmd)
 }
@@ -215,3 +269,91 @@ func systemSupportPacketCmdF(c client.Client, cmd *cobra.Command, _ []string) er
 	printer.PrintT("Downloaded Support Packet to {{ .filename }}", map[string]string{"filename": filename})
 	return nil
 }
+
+func storeFaultsListCmdF(c client.Client, cmd *cobra.Command, _ []string) error {
+	faults, _, err := c.GetStoreFaults(context.TODO())
+	if err != nil {
+		return fmt.Errorf("unable to get the store faults: %w", err)
+	}
+
+	if len(faults) == 0 {
+		printer.Print("No store faults")
+		return nil
+	}
+
+	for _, fault := range faults {
+		printer.PrintT("{{.Method}}: latency {{.LatencyMs}}ms at rate {{.LatencyRate}}, error rate {{.ErrorRate}}, serialization failure rate {{.SerializationFailureRate}}", fault)
+	}
+	return nil
+}
+
+func storeFaultsSetCmdF(c client.Client, cmd *cobra.Command, _ []string) error {
+	printer.SetSingle(true)
+
+	fault := &model.StoreFault{}
+	var err error
+	if fault.Method, err = cmd.Flags().GetString("method"); err != nil {
+		return err
+	}
+	latency, err := cmd.Flags().GetDuration("latency")
+	if err != nil {
+		return err
+	}
+	fault.LatencyMs = latency.Milliseconds()
+	if fault.LatencyRate, err = cmd.Flags().GetFloat64("latency-rate"); err != nil {
+		return err
+	}
+	if fault.ErrorRate, err = cmd.Flags().GetFloat64("error-rate"); err != nil {
+		return err
+	}
+	if fault.SerializationFailureRate, err = cmd.Flags().GetFloat64("serialization-failure-rate"); err != nil {
+		return err
+	}
+	if appErr := fault.IsValid(); appErr != nil {
+		return fmt.Errorf("invalid store fault: %w", appErr)
+	}
+
+	faults, _, err := c.GetStoreFaults(context.TODO())
+	if err != nil {
+		return fmt.Errorf("unable to get the store faults: %w", err)
+	}
+	faults = slices.DeleteFunc(faults, func(f *model.StoreFault) bool { return f.Method == fault.Method })
+	faults = append(faults, fault)
+
+	if _, err := c.SetStoreFaults(context.TODO(), faults); err != nil {
+		return fmt.Errorf("unable to set the store faults: %w", err)
+	}
+
+	printer.PrintT("Store faults set for {{.Method}}", fault)
+	return nil
+}
+
+func storeFaultsClearCmdF(c client.Client, cmd *cobra.Command, _ []string) error {
+	printer.SetSingle(true)
+
+	method, err := cmd.Flags().GetString("method")
+	if err != nil {
+		return err
+	}
+
+	if method == "" {
+		if _, err := c.ClearStoreFaults(context.TODO()); err != nil {
+			return fmt.Errorf("unable to clear the store faults: %w", err)
+		}
+		printer.PrintT("Store faults cleared", map[string]string{"status": "ok"})
+		return nil
+	}
+
+	faults, _, err := c.GetStoreFaults(context.TODO())
+	if err != nil {
+		return fmt.Errorf("unable to get the store faults: %w", err)
+	}
+	faults = slices.DeleteFunc(faults, func(f *model.StoreFault) bool { return f.Method == method })
+
+	if _, err := c.SetStoreFaults(context.TODO(), faults); err != nil {
+		return fmt.Errorf("unable to set the store faults: %w", err)
+	}
+
+	printer.PrintT("Store faults cleared", map[string]string{"status": "ok"})
+	return nil
+}
@@ -441,3 +441,144 @@ func (s *MmctlUnitTestSuite) TestSupportPacketCmdF() {
 		s.Require().Equal(printer.GetLines()[0], "Downloading Support Packet")
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestStoreFaultsListCmd() {
+	s.Run("List the store faults", func() {
+		printer.Clean()
+		faults := []*model.StoreFault{{Method: "PostStore.Save", ErrorRate: 0.5}}
+
+		s.client.
+			EXPECT().
+			GetStoreFaults(context.TODO()).
+			Return(faults, &model.Response{}, nil).
+			Times(1)
+
+		err := storeFaultsListCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(faults[0], printer.GetLines()[0])
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("List without store faults", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetStoreFaults(context.TODO()).
+			Return([]*model.StoreFault{}, &model.Response{}, nil).
+			Times(1)
+
+		err := storeFaultsListCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("No store faults", printer.GetLines()[0])
+	})
+
+	s.Run("List fails", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetStoreFaults(context.TODO()).
+			Return(nil, &model.Response{StatusCode: http.StatusNotImplemented}, errors.New("mock error")).
+			Times(1)
+
+		err := storeFaultsListCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestStoreFaultsSetCmd() {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("method", "", "")
+		cmd.Flags().Duration("latency", 0, "")
+		cmd.Flags().Float64("latency-rate", 1, "")
+		cmd.Flags().Float64("error-rate", 0, "")
+		cmd.Flags().Float64("serialization-failure-rate", 0, "")
+		return cmd
+	}
+
+	s.Run("Set the faults of a method, replacing its previous ones", func() {
+		printer.Clean()
+		cmd := newCmd()
+		s.Require().NoError(cmd.Flags().Set("method", "PostStore.Save"))
+		s.Require().NoError(cmd.Flags().Set("latency", "250ms"))
+		s.Require().NoError(cmd.Flags().Set("error-rate", "0.1"))
+
+		expected := &model.StoreFault{Method: "PostStore.Save", LatencyMs: 250, LatencyRate: 1, ErrorRate: 0.1}
+		other := &model.StoreFault{Method: "UserStore.*", SerializationFailureRate: 0.2}
+
+		s.client.
+			EXPECT().
+			GetStoreFaults(context.TODO()).
+			Return([]*model.StoreFault{{Method: "PostStore.Save", ErrorRate: 1}, other}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			SetStoreFaults(context.TODO(), []*model.StoreFault{other, expected}).
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := storeFaultsSetCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(expected, printer.GetLines()[0])
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Set an invalid fault", func() {
+		printer.Clean()
+		cmd := newCmd()
+		s.Require().NoError(cmd.Flags().Set("method", "PostStore.Save"))
+		s.Require().NoError(cmd.Flags().Set("error-rate", "2"))
+
+		err := storeFaultsSetCmdF(s.client, cmd, []string{})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestStoreFaultsClearCmd() {
+	s.Run("Clear all the store faults", func() {
+		printer.Clean()
+		cmd := &cobra.Command{}
+		cmd.Flags().String("method", "", "")
+
+		s.client.
+			EXPECT().
+			ClearStoreFaults(context.TODO()).
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := storeFaultsClearCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(map[string]string{"status": "ok"}, printer.GetLines()[0])
+	})
+
+	s.Run("Clear the faults of a method", func() {
+		printer.Clean()
+		cmd := &cobra.Command{}
+		cmd.Flags().String("method", "", "")
+		s.Require().NoError(cmd.Flags().Set("method", "PostStore.Save"))
+		other := &model.StoreFault{Method: "*", LatencyMs: 10, LatencyRate: 1}
+
+		s.client.
+			EXPECT().
+			GetStoreFaults(context.TODO()).
+			Return([]*model.StoreFault{{Method: "PostStore.Save", ErrorRate: 1}, other}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			SetStoreFaults(context.TODO(), []*model.StoreFault{other}).
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := storeFaultsClearCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+}
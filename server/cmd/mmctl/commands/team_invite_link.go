@@ -0,0 +1,215 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+const teamInviteLinkTemplate = "{{.Id}}: {{.Name}} (role: {{.Role}}, uses: {{.UseCount}}/{{if .MaxUses}}{{.MaxUses}}{{else}}unlimited{{end}}{{if .DeleteAt}}, revoked{{end}})"
+
+var TeamInviteLinkCmd = &cobra.Command{
+	Use:   "invite-link",
+	Short: "Management of team invite links",
+}
+
+var TeamInviteLinkCreateCmd = &cobra.Command{
+	Use:   "create [team]",
+	Short: "Create a team invite link",
+	Long:  "Create a named invite link for a team. The link can expire, be limited to a number of uses and add the users joining through it to channels.",
+	Example: `  team invite-link create myteam --name "Conference"
+  team invite-link create myteam --name "Contractors" --role guest --channels town-square,projects --expires-in 72h --max-uses 10`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(teamInviteLinkCreateCmdF),
+}
+
+var TeamInviteLinkListCmd = &cobra.Command{
+	Use:     "list [team]",
+	Short:   "List the invite links of a team",
+	Example: "  team invite-link list myteam --all",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(teamInviteLinkListCmdF),
+}
+
+var TeamInviteLinkShowCmd = &cobra.Command{
+	Use:     "show [team] [linkID]",
+	Short:   "Show a team invite link",
+	Example: "  team invite-link show myteam linkID",
+	Args:    cobra.ExactArgs(2),
+	RunE:    withClient(teamInviteLinkShowCmdF),
+}
+
+var TeamInviteLinkRevokeCmd = &cobra.Command{
+	Use:     "revoke [team] [linkIDs]",
+	Short:   "Revoke team invite links",
+	Long:    "Revoke team invite links, so that they can't be used anymore. The usage history of the links is kept.",
+	Example: "  team invite-link revoke myteam linkID",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withClient(teamInviteLinkRevokeCmdF),
+}
+
+var TeamInviteLinkUsesCmd = &cobra.Command{
+	Use:     "uses [team] [linkID]",
+	Short:   "List the users who joined through a team invite link",
+	Example: "  team invite-link uses myteam linkID",
+	Args:    cobra.ExactArgs(2),
+	RunE:    withClient(teamInviteLinkUsesCmdF),
+}
+
+func init() {
+	TeamInviteLinkCreateCmd.Flags().String("name", "", "Name of the invite link (required)")
+	TeamInviteLinkCreateCmd.Flags().String("role", model.TeamInviteLinkRoleMember, "Role of the users joining through the link, member or guest")
+	TeamInviteLinkCreateCmd.Flags().StringSlice("channels", []string{}, "Names or IDs of the channels to add the users joining through the link to")
+	TeamInviteLinkCreateCmd.Flags().Duration("expires-in", 0, "Time after which the link expires, e.g. 72h. The link doesn't expire if not set")
+	TeamInviteLinkCreateCmd.Flags().Int64("max-uses", 0, "Maximum number of users who can join through the link. Unlimited if not set")
+	_ = TeamInviteLinkCreateCmd.MarkFlagRequired("name")
+
+	TeamInviteLinkListCmd.Flags().Bool("all", false, "Include the revoked links")
+
+	TeamInviteLinkUsesCmd.Flags().Int("page", 0, "Page number to fetch for the list of uses")
+	TeamInviteLinkUsesCmd.Flags().Int("per-page", 200, "Number of uses to be fetched")
+
+	TeamInviteLinkCmd.AddCommand(
+		TeamInviteLinkCreateCmd,
+		TeamInviteLinkListCmd,
+		TeamInviteLinkShowCmd,
+		TeamInviteLinkRevokeCmd,
+		TeamInviteLinkUsesCmd,
+	)
+
+	TeamCmd.AddCommand(TeamInviteLinkCmd)
+}
+
+func teamInviteLinkCreateCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+
+	team := getTeamFromTeamArg(c, args[0])
+	if team == nil {
+		return errors.New("unable to find team '" + args[0] + "'")
+	}
+
+	name, _ := cmd.Flags().GetString("name")
+	role, _ := cmd.Flags().GetString("role")
+	channelArgs, _ := cmd.Flags().GetStringSlice("channels")
+	expiresIn, _ := cmd.Flags().GetDuration("expires-in")
+	maxUses, _ := cmd.Flags().GetInt64("max-uses")
+
+	if expiresIn < 0 {
+		return errors.New("expires-in must be positive")
+	}
+
+	link := &model.TeamInviteLink{
+		TeamId:     team.Id,
+		Name:       name,
+		Role:       role,
+		ChannelIds: model.StringArray{},
+		MaxUses:    maxUses,
+	}
+	if expiresIn > 0 {
+		link.ExpiresAt = model.GetMillisForTime(time.Now().Add(expiresIn))
+	}
+
+	for _, channelArg := range channelArgs {
+		channel, _, _ := c.GetChannelByNameIncludeDeleted(context.TODO(), channelArg, team.Id, "")
+		if channel == nil {
+			channel, _, _ = c.GetChannel(context.TODO(), channelArg, "")
+		}
+		if channel == nil || channel.TeamId != team.Id {
+			return fmt.Errorf("unable to find channel '%s' in team %s", channelArg, team.Name)
+		}
+		link.ChannelIds = append(link.ChannelIds, channel.Id)
+	}
+
+	createdLink, _, err := c.CreateTeamInviteLink(context.TODO(), link)
+	if err != nil {
+		return fmt.Errorf("unable to create invite link: %w", err)
+	}
+
+	printer.PrintT("Created invite link "+teamInviteLinkTemplate, createdLink)
+	return nil
+}
+
+func teamInviteLinkListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	team := getTeamFromTeamArg(c, args[0])
+	if team == nil {
+		return errors.New("unable to find team '" + args[0] + "'")
+	}
+
+	all, _ := cmd.Flags().GetBool("all")
+	links, _, err := c.GetTeamInviteLinks(context.TODO(), team.Id, all)
+	if err != nil {
+		return fmt.Errorf("unable to list invite links of team %s: %w", team.Name, err)
+	}
+
+	for _, link := range links {
+		printer.PrintT(teamInviteLinkTemplate, link)
+	}
+	return nil
+}
+
+func teamInviteLinkShowCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+
+	team := getTeamFromTeamArg(c, args[0])
+	if team == nil {
+		return errors.New("unable to find team '" + args[0] + "'")
+	}
+
+	link, _, err := c.GetTeamInviteLink(context.TODO(), team.Id, args[1])
+	if err != nil {
+		return fmt.Errorf("unable to get invite link '%s': %w", args[1], err)
+	}
+
+	printer.PrintT(teamInviteLinkTemplate, link)
+	return nil
+}
+
+func teamInviteLinkRevokeCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	team := getTeamFromTeamArg(c, args[0])
+	if team == nil {
+		return errors.New("unable to find team '" + args[0] + "'")
+	}
+
+	var errs *multierror.Error
+	for _, linkID := range args[1:] {
+		if _, err := c.RevokeTeamInviteLink(context.TODO(), team.Id, linkID); err != nil {
+			err = fmt.Errorf("unable to revoke invite link '%s': %w", linkID, err)
+			printer.PrintError(err.Error())
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		printer.PrintT("Revoked invite link '{{.id}}'", map[string]any{"id": linkID})
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func teamInviteLinkUsesCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	team := getTeamFromTeamArg(c, args[0])
+	if team == nil {
+		return errors.New("unable to find team '" + args[0] + "'")
+	}
+
+	page, _ := cmd.Flags().GetInt("page")
+	perPage, _ := cmd.Flags().GetInt("per-page")
+	uses, _, err := c.GetTeamInviteLinkUses(context.TODO(), team.Id, args[1], page, perPage)
+	if err != nil {
+		return fmt.Errorf("unable to get the uses of invite link '%s': %w", args[1], err)
+	}
+
+	for _, use := range uses {
+		printer.PrintT("{{.UserId}} joined at {{.UseAt}}", use)
+	}
+	return nil
+}
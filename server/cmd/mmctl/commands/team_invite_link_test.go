@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestTeamInviteLinkCreateCmd() {
+	teamArg := "example-team-id"
+	mockTeam := &model.Team{Id: teamArg, Name: "example-team"}
+
+	s.Run("Create an invite link with channels", func() {
+		printer.Clean()
+		mockChannel := &model.Channel{Id: model.NewId(), TeamId: teamArg}
+		createdLink := &model.TeamInviteLink{Id: model.NewId(), TeamId: teamArg, Name: "Conference", Role: model.TeamInviteLinkRoleMember, MaxUses: 10}
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), teamArg, "").
+			Return(mockTeam, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetChannelByNameIncludeDeleted(context.TODO(), "town-square", teamArg, "").
+			Return(mockChannel, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			CreateTeamInviteLink(context.TODO(), &model.TeamInviteLink{
+				TeamId:     teamArg,
+				Name:       "Conference",
+				Role:       model.TeamInviteLinkRoleMember,
+				ChannelIds: model.StringArray{mockChannel.Id},
+				MaxUses:    10,
+			}).
+			Return(createdLink, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "Conference", "")
+		cmd.Flags().String("role", model.TeamInviteLinkRoleMember, "")
+		cmd.Flags().StringSlice("channels", []string{"town-square"}, "")
+		cmd.Flags().Duration("expires-in", 0, "")
+		cmd.Flags().Int64("max-uses", 10, "")
+
+		err := teamInviteLinkCreateCmdF(s.client, cmd, []string{teamArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(createdLink, printer.GetLines()[0])
+	})
+
+	s.Run("Create an invite link with a channel of another team", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), teamArg, "").
+			Return(mockTeam, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetChannelByNameIncludeDeleted(context.TODO(), "other-channel", teamArg, "").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), "other-channel", "").
+			Return(&model.Channel{Id: model.NewId(), TeamId: model.NewId()}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "Conference", "")
+		cmd.Flags().String("role", model.TeamInviteLinkRoleMember, "")
+		cmd.Flags().StringSlice("channels", []string{"other-channel"}, "")
+		cmd.Flags().Duration("expires-in", 0, "")
+		cmd.Flags().Int64("max-uses", 0, "")
+
+		err := teamInviteLinkCreateCmdF(s.client, cmd, []string{teamArg})
+		s.Require().EqualError(err, "unable to find channel 'other-channel' in team example-team")
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestTeamInviteLinkListCmd() {
+	teamArg := "example-team-id"
+	mockTeam := &model.Team{Id: teamArg}
+
+	s.Run("List the invite links of a team", func() {
+		printer.Clean()
+		links := []*model.TeamInviteLink{{Id: model.NewId(), Name: "Conference"}, {Id: model.NewId(), Name: "Contractors"}}
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), teamArg, "").
+			Return(mockTeam, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetTeamInviteLinks(context.TODO(), teamArg, true).
+			Return(links, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("all", true, "")
+
+		err := teamInviteLinkListCmdF(s.client, cmd, []string{teamArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(links[0], printer.GetLines()[0])
+		s.Require().Equal(links[1], printer.GetLines()[1])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestTeamInviteLinkRevokeCmd() {
+	teamArg := "example-team-id"
+	mockTeam := &model.Team{Id: teamArg}
+	linkID := model.NewId()
+	unknownLinkID := model.NewId()
+
+	s.Run("Revoke invite links", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), teamArg, "").
+			Return(mockTeam, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			RevokeTeamInviteLink(context.TODO(), teamArg, linkID).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			RevokeTeamInviteLink(context.TODO(), teamArg, unknownLinkID).
+			Return(&model.Response{}, errors.New("not found")).
+			Times(1)
+
+		err := teamInviteLinkRevokeCmdF(s.client, &cobra.Command{}, []string{teamArg, linkID, unknownLinkID})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(map[string]any{"id": linkID}, printer.GetLines()[0])
+		s.Require().Len(printer.GetErrorLines(), 1)
+		s.Require().Equal("unable to revoke invite link '"+unknownLinkID+"': not found", printer.GetErrorLines()[0])
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+var UserGuestExpiryCmd = &cobra.Command{
+	Use:   "guest-expiry",
+	Short: "Management of guest access expiries",
+	Long:  "Manage the time after which guests are deactivated and removed from their channels.",
+}
+
+var UserGuestExpirySetCmd = &cobra.Command{
+	Use:     "set [guests]",
+	Short:   "Set the access expiry of guests",
+	Long:    "Set the time after which guests are deactivated and removed from their channels. The guests are reminded before their access expires.",
+	Example: "  user guest-expiry set guest1 guest2 --expires-in 720h",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(userGuestExpirySetCmdF),
+}
+
+var UserGuestExpiryShowCmd = &cobra.Command{
+	Use:     "show [guest]",
+	Short:   "Show the access expiry of a guest",
+	Example: "  user guest-expiry show guest1",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(userGuestExpiryShowCmdF),
+}
+
+var UserGuestExpiryRemoveCmd = &cobra.Command{
+	Use:     "remove [guests]",
+	Short:   "Remove the access expiry of guests",
+	Example: "  user guest-expiry remove guest1 guest2",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(userGuestExpiryRemoveCmdF),
+}
+
+func init() {
+	UserGuestExpirySetCmd.Flags().Duration("expires-in", 0, "Time after which the guests are deactivated, e.g. 720h (required)")
+	_ = UserGuestExpirySetCmd.MarkFlagRequired("expires-in")
+
+	UserGuestExpiryCmd.AddCommand(
+		UserGuestExpirySetCmd,
+		UserGuestExpiryShowCmd,
+		UserGuestExpiryRemoveCmd,
+	)
+
+	UserCmd.AddCommand(UserGuestExpiryCmd)
+}
+
+func userGuestExpirySetCmdF(c client.Client, cmd *cobra.Command, userArgs []string) error {
+	expiresIn, _ := cmd.Flags().GetDuration("expires-in")
+	if expiresIn <= 0 {
+		return errors.New("expires-in must be positive")
+	}
+	expiresAt := model.GetMillisForTime(time.Now().Add(expiresIn))
+
+	var errs *multierror.Error
+	for i, user := range getUsersFromUserArgs(c, userArgs) {
+		if user == nil {
+			err := fmt.Errorf("can't find guest '%s'", userArgs[i])
+			errs = multierror.Append(errs, err)
+			printer.PrintError(err.Error())
+			continue
+		}
+
+		expiry, _, err := c.SetGuestAccessExpiry(context.TODO(), user.Id, expiresAt)
+		if err != nil {
+			err = fmt.Errorf("unable to set access expiry of guest %s: %w", userArgs[i], err)
+			errs = multierror.Append(errs, err)
+			printer.PrintError(err.Error())
+			continue
+		}
+
+		printer.PrintT("Access of guest {{.username}} expires at {{.expires_at}}.", map[string]any{
+			"username":   user.Username,
+			"expires_at": time.UnixMilli(expiry.ExpiresAt).UTC().Format(time.RFC3339),
+		})
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func userGuestExpiryShowCmdF(c client.Client, _ *cobra.Command, userArgs []string) error {
+	user := getUserFromUserArg(c, userArgs[0])
+	if user == nil {
+		return fmt.Errorf("can't find guest '%s'", userArgs[0])
+	}
+
+	expiry, _, err := c.GetGuestAccessExpiry(context.TODO(), user.Id)
+	if err != nil {
+		return fmt.Errorf("unable to get access expiry of guest %s: %w", userArgs[0], err)
+	}
+
+	printer.PrintT("Access of guest {{.username}} expires at {{.expires_at}}.", map[string]any{
+		"username":   user.Username,
+		"expires_at": time.UnixMilli(expiry.ExpiresAt).UTC().Format(time.RFC3339),
+	})
+	return nil
+}
+
+func userGuestExpiryRemoveCmdF(c client.Client, _ *cobra.Command, userArgs []string) error {
+	var errs *multierror.Error
+	for i, user := range getUsersFromUserArgs(c, userArgs) {
+		if user == nil {
+			err := fmt.Errorf("can't find guest '%s'", userArgs[i])
+			errs = multierror.Append(errs, err)
+			printer.PrintError(err.Error())
+			continue
+		}
+
+		if _, err := c.RemoveGuestAccessExpiry(context.TODO(), user.Id); err != nil {
+			err = fmt.Errorf("unable to remove access expiry of guest %s: %w", userArgs[i], err)
+			errs = multierror.Append(errs, err)
+			printer.PrintError(err.Error())
+			continue
+		}
+
+		printer.PrintT("Access expiry of guest {{.Username}} removed.", user)
+	}
+
+	return errs.ErrorOrNil()
+}
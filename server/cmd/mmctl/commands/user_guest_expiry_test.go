@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestUserGuestExpirySetCmd() {
+	emailArg := "guest@example.com"
+	mockUser := &model.User{Id: model.NewId(), Username: "guest", Email: emailArg}
+
+	s.Run("Set the access expiry of a guest", func() {
+		printer.Clean()
+		expiresAt := model.GetMillisForTime(time.Date(2030, time.January, 2, 15, 4, 0, 0, time.UTC))
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), emailArg, "").
+			Return(mockUser, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			SetGuestAccessExpiry(context.TODO(), mockUser.Id, gomock.Any()).
+			Return(&model.GuestAccessExpiry{UserId: mockUser.Id, ExpiresAt: expiresAt}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Duration("expires-in", 720*time.Hour, "")
+
+		err := userGuestExpirySetCmdF(s.client, cmd, []string{emailArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(map[string]any{"username": "guest", "expires_at": "2030-01-02T15:04:00Z"}, printer.GetLines()[0])
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Set a non positive access expiry", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Duration("expires-in", 0, "")
+
+		err := userGuestExpirySetCmdF(s.client, cmd, []string{emailArg})
+		s.Require().EqualError(err, "expires-in must be positive")
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.Run("Fail to set the access expiry of a regular user", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), emailArg, "").
+			Return(mockUser, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			SetGuestAccessExpiry(context.TODO(), mockUser.Id, gomock.Any()).
+			Return(nil, &model.Response{}, errors.New("not a guest")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Duration("expires-in", time.Hour, "")
+
+		err := userGuestExpirySetCmdF(s.client, cmd, []string{emailArg})
+		s.Require().ErrorContains(err, "unable to set access expiry of guest "+emailArg+": not a guest")
+		s.Require().Len(printer.GetLines(), 0)
+		s.Require().Len(printer.GetErrorLines(), 1)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestUserGuestExpiryRemoveCmd() {
+	s.Run("Remove the access expiry of a guest", func() {
+		printer.Clean()
+		emailArg := "guest@example.com"
+		mockUser := &model.User{Id: model.NewId(), Username: "guest", Email: emailArg}
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), emailArg, "").
+			Return(mockUser, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			RemoveGuestAccessExpiry(context.TODO(), mockUser.Id).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := userGuestExpiryRemoveCmdF(s.client, &cobra.Command{}, []string{emailArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(mockUser, printer.GetLines()[0])
+	})
+}
@@ -135,6 +135,21 @@ func (mr *MockClientMockRecorder) ClearServerBusy(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearServerBusy", reflect.TypeOf((*MockClient)(nil).ClearServerBusy), arg0)
 }
 
+// ClearStoreFaults mocks base method.
+func (m *MockClient) ClearStoreFaults(arg0 context.Context) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearStoreFaults", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearStoreFaults indicates an expected call of ClearStoreFaults.
+func (mr *MockClientMockRecorder) ClearStoreFaults(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearStoreFaults", reflect.TypeOf((*MockClient)(nil).ClearStoreFaults), arg0)
+}
+
 // ConvertBotToUser mocks base method.
 func (m *MockClient) ConvertBotToUser(arg0 context.Context, arg1 string, arg2 *model.UserPatch, arg3 bool) (*model.User, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -231,6 +246,22 @@ func (mr *MockClientMockRecorder) CreateCommand(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCommand", reflect.TypeOf((*MockClient)(nil).CreateCommand), arg0, arg1)
 }
 
+// CreateEmoji mocks base method.
+func (m *MockClient) CreateEmoji(arg0 context.Context, arg1 *model.Emoji, arg2 []byte, arg3 string) (*model.Emoji, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmoji", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Emoji)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateEmoji indicates an expected call of CreateEmoji.
+func (mr *MockClientMockRecorder) CreateEmoji(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmoji", reflect.TypeOf((*MockClient)(nil).CreateEmoji), arg0, arg1, arg2, arg3)
+}
+
 // CreateIncomingWebhook mocks base method.
 func (m *MockClient) CreateIncomingWebhook(arg0 context.Context, arg1 *model.IncomingWebhook) (*model.IncomingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -311,6 +342,22 @@ func (mr *MockClientMockRecorder) CreateTeam(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockClient)(nil).CreateTeam), arg0, arg1)
 }
 
+// CreateTeamInviteLink mocks base method.
+func (m *MockClient) CreateTeamInviteLink(arg0 context.Context, arg1 *model.TeamInviteLink) (*model.TeamInviteLink, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeamInviteLink", arg0, arg1)
+	ret0, _ := ret[0].(*model.TeamInviteLink)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateTeamInviteLink indicates an expected call of CreateTeamInviteLink.
+func (mr *MockClientMockRecorder) CreateTeamInviteLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeamInviteLink", reflect.TypeOf((*MockClient)(nil).CreateTeamInviteLink), arg0, arg1)
+}
+
 // CreateUpload mocks base method.
 func (m *MockClient) CreateUpload(arg0 context.Context, arg1 *model.UploadSession) (*model.UploadSession, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -404,6 +451,21 @@ func (mr *MockClientMockRecorder) DeleteCommand(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCommand", reflect.TypeOf((*MockClient)(nil).DeleteCommand), arg0, arg1)
 }
 
+// DeleteEmoji mocks base method.
+func (m *MockClient) DeleteEmoji(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmoji", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEmoji indicates an expected call of DeleteEmoji.
+func (mr *MockClientMockRecorder) DeleteEmoji(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmoji", reflect.TypeOf((*MockClient)(nil).DeleteEmoji), arg0, arg1)
+}
+
 // DeleteExport mocks base method.
 func (m *MockClient) DeleteExport(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -449,6 +511,21 @@ func (mr *MockClientMockRecorder) DeleteIncomingWebhook(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncomingWebhook", reflect.TypeOf((*MockClient)(nil).DeleteIncomingWebhook), arg0, arg1)
 }
 
+// DeleteOutgoingEmail mocks base method.
+func (m *MockClient) DeleteOutgoingEmail(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOutgoingEmail", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOutgoingEmail indicates an expected call of DeleteOutgoingEmail.
+func (mr *MockClientMockRecorder) DeleteOutgoingEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutgoingEmail", reflect.TypeOf((*MockClient)(nil).DeleteOutgoingEmail), arg0, arg1)
+}
+
 // DeleteOutgoingWebhook mocks base method.
 func (m *MockClient) DeleteOutgoingWebhook(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -617,6 +694,22 @@ func (mr *MockClientMockRecorder) EnablePlugin(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnablePlugin", reflect.TypeOf((*MockClient)(nil).EnablePlugin), arg0, arg1)
 }
 
+// ExportEmojiPack mocks base method.
+func (m *MockClient) ExportEmojiPack(arg0 context.Context) ([]byte, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportEmojiPack", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExportEmojiPack indicates an expected call of ExportEmojiPack.
+func (mr *MockClientMockRecorder) ExportEmojiPack(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportEmojiPack", reflect.TypeOf((*MockClient)(nil).ExportEmojiPack), arg0)
+}
+
 // GeneratePresignedURL mocks base method.
 func (m *MockClient) GeneratePresignedURL(arg0 context.Context, arg1 string) (*model.PresignURLResponse, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -874,6 +967,38 @@ func (mr *MockClientMockRecorder) GetDeletedChannelsForTeam(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedChannelsForTeam", reflect.TypeOf((*MockClient)(nil).GetDeletedChannelsForTeam), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetEmojiByName mocks base method.
+func (m *MockClient) GetEmojiByName(arg0 context.Context, arg1 string) (*model.Emoji, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmojiByName", arg0, arg1)
+	ret0, _ := ret[0].(*model.Emoji)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEmojiByName indicates an expected call of GetEmojiByName.
+func (mr *MockClientMockRecorder) GetEmojiByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmojiByName", reflect.TypeOf((*MockClient)(nil).GetEmojiByName), arg0, arg1)
+}
+
+// GetEmojiCategories mocks base method.
+func (m *MockClient) GetEmojiCategories(arg0 context.Context) ([]*model.EmojiCategory, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmojiCategories", arg0)
+	ret0, _ := ret[0].([]*model.EmojiCategory)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEmojiCategories indicates an expected call of GetEmojiCategories.
+func (mr *MockClientMockRecorder) GetEmojiCategories(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmojiCategories", reflect.TypeOf((*MockClient)(nil).GetEmojiCategories), arg0)
+}
+
 // GetGroupsByChannel mocks base method.
 func (m *MockClient) GetGroupsByChannel(arg0 context.Context, arg1 string, arg2 model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -908,6 +1033,22 @@ func (mr *MockClientMockRecorder) GetGroupsByTeam(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsByTeam", reflect.TypeOf((*MockClient)(nil).GetGroupsByTeam), arg0, arg1, arg2)
 }
 
+// GetGuestAccessExpiry mocks base method.
+func (m *MockClient) GetGuestAccessExpiry(arg0 context.Context, arg1 string) (*model.GuestAccessExpiry, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuestAccessExpiry", arg0, arg1)
+	ret0, _ := ret[0].(*model.GuestAccessExpiry)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetGuestAccessExpiry indicates an expected call of GetGuestAccessExpiry.
+func (mr *MockClientMockRecorder) GetGuestAccessExpiry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestAccessExpiry", reflect.TypeOf((*MockClient)(nil).GetGuestAccessExpiry), arg0, arg1)
+}
+
 // GetIncomingWebhook mocks base method.
 func (m *MockClient) GetIncomingWebhook(arg0 context.Context, arg1, arg2 string) (*model.IncomingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1068,6 +1209,22 @@ func (mr *MockClientMockRecorder) GetOAuthApps(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthApps", reflect.TypeOf((*MockClient)(nil).GetOAuthApps), arg0, arg1, arg2)
 }
 
+// GetOutgoingEmails mocks base method.
+func (m *MockClient) GetOutgoingEmails(arg0 context.Context, arg1 string, arg2, arg3 int) (*model.OutgoingEmailsWithCount, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingEmails", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.OutgoingEmailsWithCount)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOutgoingEmails indicates an expected call of GetOutgoingEmails.
+func (mr *MockClientMockRecorder) GetOutgoingEmails(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingEmails", reflect.TypeOf((*MockClient)(nil).GetOutgoingEmails), arg0, arg1, arg2, arg3)
+}
+
 // GetOutgoingWebhook mocks base method.
 func (m *MockClient) GetOutgoingWebhook(arg0 context.Context, arg1 string) (*model.OutgoingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1356,6 +1513,38 @@ func (mr *MockClientMockRecorder) GetServerBusy(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerBusy", reflect.TypeOf((*MockClient)(nil).GetServerBusy), arg0)
 }
 
+// GetSortedEmojiList mocks base method.
+func (m *MockClient) GetSortedEmojiList(arg0 context.Context, arg1, arg2 int, arg3 string) ([]*model.Emoji, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSortedEmojiList", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Emoji)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSortedEmojiList indicates an expected call of GetSortedEmojiList.
+func (mr *MockClientMockRecorder) GetSortedEmojiList(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSortedEmojiList", reflect.TypeOf((*MockClient)(nil).GetSortedEmojiList), arg0, arg1, arg2, arg3)
+}
+
+// GetStoreFaults mocks base method.
+func (m *MockClient) GetStoreFaults(arg0 context.Context) ([]*model.StoreFault, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStoreFaults", arg0)
+	ret0, _ := ret[0].([]*model.StoreFault)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetStoreFaults indicates an expected call of GetStoreFaults.
+func (mr *MockClientMockRecorder) GetStoreFaults(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoreFaults", reflect.TypeOf((*MockClient)(nil).GetStoreFaults), arg0)
+}
+
 // GetTeam mocks base method.
 func (m *MockClient) GetTeam(arg0 context.Context, arg1, arg2 string) (*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1388,6 +1577,54 @@ func (mr *MockClientMockRecorder) GetTeamByName(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamByName", reflect.TypeOf((*MockClient)(nil).GetTeamByName), arg0, arg1, arg2)
 }
 
+// GetTeamInviteLink mocks base method.
+func (m *MockClient) GetTeamInviteLink(arg0 context.Context, arg1, arg2 string) (*model.TeamInviteLink, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamInviteLink", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.TeamInviteLink)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTeamInviteLink indicates an expected call of GetTeamInviteLink.
+func (mr *MockClientMockRecorder) GetTeamInviteLink(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamInviteLink", reflect.TypeOf((*MockClient)(nil).GetTeamInviteLink), arg0, arg1, arg2)
+}
+
+// GetTeamInviteLinkUses mocks base method.
+func (m *MockClient) GetTeamInviteLinkUses(arg0 context.Context, arg1, arg2 string, arg3, arg4 int) ([]*model.TeamInviteLinkUse, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamInviteLinkUses", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*model.TeamInviteLinkUse)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTeamInviteLinkUses indicates an expected call of GetTeamInviteLinkUses.
+func (mr *MockClientMockRecorder) GetTeamInviteLinkUses(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamInviteLinkUses", reflect.TypeOf((*MockClient)(nil).GetTeamInviteLinkUses), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetTeamInviteLinks mocks base method.
+func (m *MockClient) GetTeamInviteLinks(arg0 context.Context, arg1 string, arg2 bool) ([]*model.TeamInviteLink, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamInviteLinks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.TeamInviteLink)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTeamInviteLinks indicates an expected call of GetTeamInviteLinks.
+func (mr *MockClientMockRecorder) GetTeamInviteLinks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamInviteLinks", reflect.TypeOf((*MockClient)(nil).GetTeamInviteLinks), arg0, arg1, arg2)
+}
+
 // GetUpload mocks base method.
 func (m *MockClient) GetUpload(arg0 context.Context, arg1 string) (*model.UploadSession, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1548,6 +1785,22 @@ func (mr *MockClientMockRecorder) GetUsersWithCustomQueryParameters(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersWithCustomQueryParameters", reflect.TypeOf((*MockClient)(nil).GetUsersWithCustomQueryParameters), arg0, arg1, arg2, arg3, arg4)
 }
 
+// ImportEmojiPack mocks base method.
+func (m *MockClient) ImportEmojiPack(arg0 context.Context, arg1 []byte, arg2 string) (*model.EmojiPackImportResult, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportEmojiPack", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.EmojiPackImportResult)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ImportEmojiPack indicates an expected call of ImportEmojiPack.
+func (mr *MockClientMockRecorder) ImportEmojiPack(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportEmojiPack", reflect.TypeOf((*MockClient)(nil).ImportEmojiPack), arg0, arg1, arg2)
+}
+
 // InstallMarketplacePlugin mocks base method.
 func (m *MockClient) InstallMarketplacePlugin(arg0 context.Context, arg1 *model.InstallMarketplacePluginRequest) (*model.Manifest, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2015,6 +2268,36 @@ func (mr *MockClientMockRecorder) ReloadConfig(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadConfig", reflect.TypeOf((*MockClient)(nil).ReloadConfig), arg0)
 }
 
+// RemoveDKIMPrivateKey mocks base method.
+func (m *MockClient) RemoveDKIMPrivateKey(arg0 context.Context) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDKIMPrivateKey", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveDKIMPrivateKey indicates an expected call of RemoveDKIMPrivateKey.
+func (mr *MockClientMockRecorder) RemoveDKIMPrivateKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDKIMPrivateKey", reflect.TypeOf((*MockClient)(nil).RemoveDKIMPrivateKey), arg0)
+}
+
+// RemoveGuestAccessExpiry mocks base method.
+func (m *MockClient) RemoveGuestAccessExpiry(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveGuestAccessExpiry", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveGuestAccessExpiry indicates an expected call of RemoveGuestAccessExpiry.
+func (mr *MockClientMockRecorder) RemoveGuestAccessExpiry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGuestAccessExpiry", reflect.TypeOf((*MockClient)(nil).RemoveGuestAccessExpiry), arg0, arg1)
+}
+
 // RemoveLicenseFile mocks base method.
 func (m *MockClient) RemoveLicenseFile(arg0 context.Context) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2139,6 +2422,37 @@ func (mr *MockClientMockRecorder) RestoreTeam(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTeam", reflect.TypeOf((*MockClient)(nil).RestoreTeam), arg0, arg1)
 }
 
+// RetryOutgoingEmail mocks base method.
+func (m *MockClient) RetryOutgoingEmail(arg0 context.Context, arg1 string) (*model.OutgoingEmail, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryOutgoingEmail", arg0, arg1)
+	ret0, _ := ret[0].(*model.OutgoingEmail)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RetryOutgoingEmail indicates an expected call of RetryOutgoingEmail.
+func (mr *MockClientMockRecorder) RetryOutgoingEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryOutgoingEmail", reflect.TypeOf((*MockClient)(nil).RetryOutgoingEmail), arg0, arg1)
+}
+
+// RevokeTeamInviteLink mocks base method.
+func (m *MockClient) RevokeTeamInviteLink(arg0 context.Context, arg1, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTeamInviteLink", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeTeamInviteLink indicates an expected call of RevokeTeamInviteLink.
+func (mr *MockClientMockRecorder) RevokeTeamInviteLink(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTeamInviteLink", reflect.TypeOf((*MockClient)(nil).RevokeTeamInviteLink), arg0, arg1, arg2)
+}
+
 // RevokeUserAccessToken mocks base method.
 func (m *MockClient) RevokeUserAccessToken(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2185,6 +2499,22 @@ func (mr *MockClientMockRecorder) SendPasswordResetEmail(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPasswordResetEmail", reflect.TypeOf((*MockClient)(nil).SendPasswordResetEmail), arg0, arg1)
 }
 
+// SetGuestAccessExpiry mocks base method.
+func (m *MockClient) SetGuestAccessExpiry(arg0 context.Context, arg1 string, arg2 int64) (*model.GuestAccessExpiry, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGuestAccessExpiry", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.GuestAccessExpiry)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetGuestAccessExpiry indicates an expected call of SetGuestAccessExpiry.
+func (mr *MockClientMockRecorder) SetGuestAccessExpiry(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGuestAccessExpiry", reflect.TypeOf((*MockClient)(nil).SetGuestAccessExpiry), arg0, arg1, arg2)
+}
+
 // SetServerBusy mocks base method.
 func (m *MockClient) SetServerBusy(arg0 context.Context, arg1 int) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2200,6 +2530,21 @@ func (mr *MockClientMockRecorder) SetServerBusy(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetServerBusy", reflect.TypeOf((*MockClient)(nil).SetServerBusy), arg0, arg1)
 }
 
+// SetStoreFaults mocks base method.
+func (m *MockClient) SetStoreFaults(arg0 context.Context, arg1 []*model.StoreFault) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStoreFaults", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStoreFaults indicates an expected call of SetStoreFaults.
+func (mr *MockClientMockRecorder) SetStoreFaults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStoreFaults", reflect.TypeOf((*MockClient)(nil).SetStoreFaults), arg0, arg1)
+}
+
 // SoftDeleteTeam mocks base method.
 func (m *MockClient) SoftDeleteTeam(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2479,6 +2824,21 @@ func (mr *MockClientMockRecorder) UpdateUserRoles(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRoles", reflect.TypeOf((*MockClient)(nil).UpdateUserRoles), arg0, arg1, arg2)
 }
 
+// UploadDKIMPrivateKey mocks base method.
+func (m *MockClient) UploadDKIMPrivateKey(arg0 context.Context, arg1 []byte, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadDKIMPrivateKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadDKIMPrivateKey indicates an expected call of UploadDKIMPrivateKey.
+func (mr *MockClientMockRecorder) UploadDKIMPrivateKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadDKIMPrivateKey", reflect.TypeOf((*MockClient)(nil).UploadDKIMPrivateKey), arg0, arg1, arg2)
+}
+
 // UploadData mocks base method.
 func (m *MockClient) UploadData(arg0 context.Context, arg1 string, arg2 io.Reader) (*model.FileInfo, *model.Response, error) {
 	m.ctrl.T.Helper()
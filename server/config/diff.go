@@ -49,11 +49,14 @@ var configSensitivePaths = map[string]bool{
 	"GoogleSettings.Secret":                                  true,
 	"Office365Settings.Secret":                               true,
 	"OpenIdSettings.Secret":                                  true,
+	"ScimSettings.BearerToken":                               true,
 	"ElasticsearchSettings.Password":                         true,
 	"MessageExportSettings.GlobalRelaySettings.SMTPUsername": true,
 	"MessageExportSettings.GlobalRelaySettings.SMTPPassword": true,
 	"MessageExportSettings.GlobalRelaySettings.EmailAddress": true,
 	"ServiceSettings.SplitKey":                               true,
+	"ConnectedWorkspacesSettings.MatrixAppServiceToken":      true,
+	"ConnectedWorkspacesSettings.MatrixHomeserverToken":      true,
 	"PluginSettings.Plugins":                                 true,
 }
 
@@ -0,0 +1,170 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	// SecretReferenceFilePrefix marks a secret setting whose value is read from the file at
+	// the given path, e.g. "file:/run/secrets/smtp".
+	SecretReferenceFilePrefix = "file:"
+	// SecretReferenceEnvPrefix marks a secret setting whose value is read from the given
+	// environment variable, e.g. "env:SMTP_PASS".
+	SecretReferenceEnvPrefix = "env:"
+
+	// SecretReferenceSupportPacketValue replaces the secret settings set via a reference in
+	// the support packet.
+	SecretReferenceSupportPacketValue = "set via reference"
+)
+
+// secretSettings returns the secret settings of the given config that accept a reference,
+// by setting path. The elements of the list settings are indexed, e.g.
+// "SqlSettings.DataSourceReplicas[0]".
+func secretSettings(cfg *model.Config) map[string]*string {
+	settings := map[string]*string{
+		"LdapSettings.BindPassword":                         cfg.LdapSettings.BindPassword,
+		"FileSettings.PublicLinkSalt":                       cfg.FileSettings.PublicLinkSalt,
+		"FileSettings.AmazonS3AccessKeyId":                  cfg.FileSettings.AmazonS3AccessKeyId,
+		"FileSettings.AmazonS3SecretAccessKey":              cfg.FileSettings.AmazonS3SecretAccessKey,
+		"EmailSettings.SMTPPassword":                        cfg.EmailSettings.SMTPPassword,
+		"GitLabSettings.Secret":                             cfg.GitLabSettings.Secret,
+		"GoogleSettings.Secret":                             cfg.GoogleSettings.Secret,
+		"Office365Settings.Secret":                          cfg.Office365Settings.Secret,
+		"OpenIdSettings.Secret":                             cfg.OpenIdSettings.Secret,
+		"ScimSettings.BearerToken":                          cfg.ScimSettings.BearerToken,
+		"SqlSettings.DataSource":                            cfg.SqlSettings.DataSource,
+		"SqlSettings.AtRestEncryptKey":                      cfg.SqlSettings.AtRestEncryptKey,
+		"ElasticsearchSettings.Password":                    cfg.ElasticsearchSettings.Password,
+		"ServiceSettings.SplitKey":                          cfg.ServiceSettings.SplitKey,
+		"CacheSettings.RedisPassword":                       cfg.CacheSettings.RedisPassword,
+		"ConnectedWorkspacesSettings.MatrixAppServiceToken": cfg.ConnectedWorkspacesSettings.MatrixAppServiceToken,
+		"ConnectedWorkspacesSettings.MatrixHomeserverToken": cfg.ConnectedWorkspacesSettings.MatrixHomeserverToken,
+	}
+	if cfg.MessageExportSettings.GlobalRelaySettings != nil {
+		settings["MessageExportSettings.GlobalRelaySettings.SMTPPassword"] = cfg.MessageExportSettings.GlobalRelaySettings.SMTPPassword
+	}
+	for i := range cfg.SqlSettings.DataSourceReplicas {
+		settings[fmt.Sprintf("SqlSettings.DataSourceReplicas[%d]", i)] = &cfg.SqlSettings.DataSourceReplicas[i]
+	}
+	for i := range cfg.SqlSettings.DataSourceSearchReplicas {
+		settings[fmt.Sprintf("SqlSettings.DataSourceSearchReplicas[%d]", i)] = &cfg.SqlSettings.DataSourceSearchReplicas[i]
+	}
+	for i, replicaLagSettings := range cfg.SqlSettings.ReplicaLagSettings {
+		if replicaLagSettings != nil {
+			settings[fmt.Sprintf("SqlSettings.ReplicaLagSettings[%d].DataSource", i)] = replicaLagSettings.DataSource
+		}
+	}
+
+	for path, value := range settings {
+		if value == nil {
+			delete(settings, path)
+		}
+	}
+	return settings
+}
+
+// isSecretReference returns whether the value of a secret setting is a reference.
+func isSecretReference(value string) bool {
+	return strings.HasPrefix(value, SecretReferenceFilePrefix) || strings.HasPrefix(value, SecretReferenceEnvPrefix)
+}
+
+// resolveSecretReference returns the secret the given reference points to.
+func resolveSecretReference(reference string) (string, error) {
+	if path, ok := strings.CutPrefix(reference, SecretReferenceFilePrefix); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to read secret file %q", path)
+		}
+		// Secret files usually end with a newline that isn't part of the secret.
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	name, _ := strings.CutPrefix(reference, SecretReferenceEnvPrefix)
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", errors.Errorf("environment variable %q is not set", name)
+	}
+	return value, nil
+}
+
+// resolveSecretReferences returns a copy of the given config with the secret settings set via
+// a reference replaced by the secrets they point to, along with the references by setting path.
+func resolveSecretReferences(cfg *model.Config) (*model.Config, map[string]string, error) {
+	resolvedCfg := cfg.Clone()
+	references := map[string]string{}
+
+	for path, value := range secretSettings(resolvedCfg) {
+		if !isSecretReference(*value) {
+			continue
+		}
+
+		secret, err := resolveSecretReference(*value)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to resolve the secret reference of %s", path)
+		}
+		references[path] = *value
+		*value = secret
+	}
+
+	return resolvedCfg, references, nil
+}
+
+// restoreSecretReferences sets back the references of the secret settings of cfg that still
+// hold the secret resolved in resolvedCfg, so that a resolved secret isn't persisted when a
+// config read from the store is saved back.
+func restoreSecretReferences(cfg, resolvedCfg *model.Config, references map[string]string) {
+	settings := secretSettings(cfg)
+	resolvedSettings := secretSettings(resolvedCfg)
+	for path, reference := range references {
+		value, ok := settings[path]
+		if !ok {
+			continue
+		}
+		if resolved, ok := resolvedSettings[path]; ok && *value == *resolved {
+			*value = reference
+		}
+	}
+}
+
+// ApplySecretReferences sets the secret settings of the given config to the given values, by
+// setting path. It's used to mask the secrets set via a reference when exposing the config.
+func ApplySecretReferences(cfg *model.Config, values map[string]string) {
+	settings := secretSettings(cfg)
+	for path, value := range values {
+		if setting, ok := settings[path]; ok {
+			*setting = value
+		}
+	}
+}
+
+// NewSecretReferences returns the paths of the secret settings of the given config set to a
+// reference other than their current one, i.e. the references a config update introduces.
+func NewSecretReferences(cfg *model.Config, references map[string]string) []string {
+	var paths []string
+	for path, value := range secretSettings(cfg) {
+		if isSecretReference(*value) && references[path] != *value {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// SecretReferences returns the references of the secret settings set via a reference, by
+// setting path.
+func (s *Store) SecretReferences() map[string]string {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+	return maps.Clone(s.secretReferences)
+}
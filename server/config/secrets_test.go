@@ -0,0 +1,189 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestResolveSecretReferences(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "smtp")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file-secret\n"), 0600))
+	t.Setenv("TEST_GITLAB_SECRET", "env-secret")
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.EmailSettings.SMTPPassword = model.NewPointer("file:" + secretFile)
+	cfg.GitLabSettings.Secret = model.NewPointer("env:TEST_GITLAB_SECRET")
+	cfg.ElasticsearchSettings.Password = model.NewPointer("plaintext")
+	cfg.CacheSettings.RedisPassword = model.NewPointer("env:TEST_GITLAB_SECRET")
+	cfg.SqlSettings.DataSourceReplicas = []string{"plaintext", "file:" + secretFile}
+	cfg.SqlSettings.ReplicaLagSettings = []*model.ReplicaLagSettings{nil, {DataSource: model.NewPointer("env:TEST_GITLAB_SECRET")}}
+
+	t.Run("resolves the references", func(t *testing.T) {
+		resolvedCfg, references, err := resolveSecretReferences(cfg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "file-secret", *resolvedCfg.EmailSettings.SMTPPassword)
+		assert.Equal(t, "env-secret", *resolvedCfg.GitLabSettings.Secret)
+		assert.Equal(t, "plaintext", *resolvedCfg.ElasticsearchSettings.Password)
+		assert.Equal(t, "env-secret", *resolvedCfg.CacheSettings.RedisPassword)
+		assert.Equal(t, []string{"plaintext", "file-secret"}, resolvedCfg.SqlSettings.DataSourceReplicas)
+		assert.Equal(t, "env-secret", *resolvedCfg.SqlSettings.ReplicaLagSettings[1].DataSource)
+		assert.Equal(t, map[string]string{
+			"EmailSettings.SMTPPassword":                   "file:" + secretFile,
+			"GitLabSettings.Secret":                        "env:TEST_GITLAB_SECRET",
+			"CacheSettings.RedisPassword":                  "env:TEST_GITLAB_SECRET",
+			"SqlSettings.DataSourceReplicas[1]":            "file:" + secretFile,
+			"SqlSettings.ReplicaLagSettings[1].DataSource": "env:TEST_GITLAB_SECRET",
+		}, references)
+
+		assert.Equal(t, "file:"+secretFile, *cfg.EmailSettings.SMTPPassword, "the given config must not be changed")
+		assert.Equal(t, "file:"+secretFile, cfg.SqlSettings.DataSourceReplicas[1], "the given config must not be changed")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		missingCfg := cfg.Clone()
+		missingCfg.EmailSettings.SMTPPassword = model.NewPointer("file:" + filepath.Join(t.TempDir(), "missing"))
+		_, _, err := resolveSecretReferences(missingCfg)
+		require.Error(t, err)
+	})
+
+	t.Run("missing environment variable", func(t *testing.T) {
+		missingCfg := cfg.Clone()
+		missingCfg.GitLabSettings.Secret = model.NewPointer("env:TEST_MISSING_SECRET")
+		_, _, err := resolveSecretReferences(missingCfg)
+		require.Error(t, err)
+	})
+
+	t.Run("nil settings", func(t *testing.T) {
+		nilCfg := cfg.Clone()
+		nilCfg.GoogleSettings.Secret = nil
+		nilCfg.MessageExportSettings.GlobalRelaySettings = nil
+		_, _, err := resolveSecretReferences(nilCfg)
+		require.NoError(t, err)
+	})
+}
+
+func TestStoreSecretReferences(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	path, tearDown := setupConfigFile(t, cfg)
+	defer tearDown()
+
+	secretFile := filepath.Join(filepath.Dir(path), "smtp")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file-secret\n"), 0600))
+	t.Setenv("TEST_GITLAB_SECRET", "env-secret")
+
+	cfg.EmailSettings.SMTPPassword = model.NewPointer("file:" + secretFile)
+	cfg.GitLabSettings.Secret = model.NewPointer("env:TEST_GITLAB_SECRET")
+	cfgData, err := marshalConfig(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, cfgData, 0600))
+
+	fs, err := NewFileStore(path, false)
+	require.NoError(t, err)
+	configStore, err := NewStoreFromBacking(fs, nil, false)
+	require.NoError(t, err)
+	defer configStore.Close()
+
+	assertReferencesPersisted := func(t *testing.T) {
+		t.Helper()
+		actualCfg := getActualFileConfig(t, path)
+		assert.Equal(t, "file:"+secretFile, *actualCfg.EmailSettings.SMTPPassword)
+		assert.Equal(t, "env:TEST_GITLAB_SECRET", *actualCfg.GitLabSettings.Secret)
+	}
+
+	t.Run("resolves the references on load", func(t *testing.T) {
+		assert.Equal(t, "file-secret", *configStore.Get().EmailSettings.SMTPPassword)
+		assert.Equal(t, "env-secret", *configStore.Get().GitLabSettings.Secret)
+		assert.Equal(t, "file:"+secretFile, *configStore.GetNoEnv().EmailSettings.SMTPPassword)
+		assert.Equal(t, map[string]string{
+			"EmailSettings.SMTPPassword": "file:" + secretFile,
+			"GitLabSettings.Secret":      "env:TEST_GITLAB_SECRET",
+		}, configStore.SecretReferences())
+	})
+
+	t.Run("doesn't write back the secrets", func(t *testing.T) {
+		newCfg := configStore.Get().Clone()
+		newCfg.ServiceSettings.SiteURL = model.NewPointer("http://example.com")
+		_, _, err := configStore.Set(newCfg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "file-secret", *configStore.Get().EmailSettings.SMTPPassword)
+		assertReferencesPersisted(t)
+	})
+
+	t.Run("keeps the references of sanitized settings", func(t *testing.T) {
+		newCfg := configStore.Get().Clone()
+		newCfg.Sanitize(nil, nil)
+		_, _, err := configStore.Set(newCfg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "file-secret", *configStore.Get().EmailSettings.SMTPPassword)
+		assert.Equal(t, "env-secret", *configStore.Get().GitLabSettings.Secret)
+		assertReferencesPersisted(t)
+	})
+
+	t.Run("resolves the references on reload", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(secretFile, []byte("rotated-secret\n"), 0600))
+		require.NoError(t, configStore.Load())
+
+		assert.Equal(t, "rotated-secret", *configStore.Get().EmailSettings.SMTPPassword)
+		assertReferencesPersisted(t)
+	})
+
+	t.Run("keeps the current config when a reference can't be resolved", func(t *testing.T) {
+		require.NoError(t, os.Remove(secretFile))
+		require.Error(t, configStore.Load())
+
+		assert.Equal(t, "rotated-secret", *configStore.Get().EmailSettings.SMTPPassword)
+	})
+
+	t.Run("replaces a reference with a plaintext secret", func(t *testing.T) {
+		newCfg := configStore.Get().Clone()
+		newCfg.EmailSettings.SMTPPassword = model.NewPointer("plaintext")
+		_, _, err := configStore.Set(newCfg)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{"GitLabSettings.Secret": "env:TEST_GITLAB_SECRET"}, configStore.SecretReferences())
+		assert.Equal(t, "plaintext", *getActualFileConfig(t, path).EmailSettings.SMTPPassword)
+	})
+}
+
+func TestApplySecretReferences(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.EmailSettings.SMTPPassword = model.NewPointer("secret")
+	cfg.GitLabSettings.Secret = model.NewPointer("secret")
+	cfg.Sanitize(nil, nil)
+
+	ApplySecretReferences(cfg, map[string]string{
+		"EmailSettings.SMTPPassword": "env:SMTP_PASS",
+		"Unknown.Setting":            "env:UNKNOWN",
+	})
+	assert.Equal(t, "env:SMTP_PASS", *cfg.EmailSettings.SMTPPassword)
+	assert.Equal(t, model.FakeSetting, *cfg.GitLabSettings.Secret)
+}
+
+func TestNewSecretReferences(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.EmailSettings.SMTPPassword = model.NewPointer("env:SMTP_PASS")
+	cfg.GitLabSettings.Secret = model.NewPointer("file:/etc/passwd")
+	cfg.SqlSettings.AtRestEncryptKey = model.NewPointer("env:OTHER")
+	cfg.ElasticsearchSettings.Password = model.NewPointer("plaintext")
+
+	assert.Equal(t, []string{"GitLabSettings.Secret", "SqlSettings.AtRestEncryptKey"}, NewSecretReferences(cfg, map[string]string{
+		"EmailSettings.SMTPPassword":   "env:SMTP_PASS",
+		"SqlSettings.AtRestEncryptKey": "env:ENCRYPT_KEY",
+	}))
+}
@@ -32,6 +32,9 @@ type Store struct {
 	config               *model.Config
 	configNoEnv          *model.Config
 	configCustomDefaults *model.Config
+	// secretReferences holds the references of the secret settings set via a reference, by
+	// setting path.
+	secretReferences map[string]string
 
 	readOnly   bool
 	readOnlyFF bool
@@ -187,14 +190,26 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 	// We apply back environment overrides since the input config may or
 	// may not have them applied.
 	newCfg = applyEnvironmentMap(newCfg, GetEnvironment())
+
+	// The input config usually comes from the store, with the secrets set via a reference
+	// already resolved. We set the references back so that the secrets are resolved again
+	// instead of being persisted.
+	restoreSecretReferences(newCfg, oldCfg, s.secretReferences)
+	newCfgWithRefs := newCfg
+	newCfg, newSecretReferences, err := resolveSecretReferences(newCfg)
+	if err != nil {
+		return nil, nil, err
+	}
 	fixConfig(newCfg)
 
 	if err := newCfg.IsValid(); err != nil {
 		return nil, nil, errors.Wrap(err, "new configuration is invalid")
 	}
 
-	// We attempt to remove any environment override that may be present in the input config.
-	newCfgNoEnv := removeEnvOverrides(newCfg, oldCfgNoEnv, s.GetEnvironmentOverrides())
+	// We attempt to remove any environment override that may be present in the input config,
+	// persisting the references rather than the secrets they point to.
+	fixConfig(newCfgWithRefs)
+	newCfgNoEnv := removeEnvOverrides(newCfgWithRefs, oldCfgNoEnv, s.GetEnvironmentOverrides())
 
 	// Don't store feature flags unless we are on MM cloud
 	// MM cloud uses config in the DB as a cache of the feature flag
@@ -228,6 +243,7 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 
 	s.configNoEnv = newCfgNoEnv
 	s.config = newCfg
+	s.secretReferences = newSecretReferences
 
 	newCfgCopy := newCfg.Clone()
 
@@ -290,6 +306,11 @@ func (s *Store) Load() error {
 	fixConfig(loadedCfgNoEnv)
 
 	loadedCfg = applyEnvironmentMap(loadedCfg, GetEnvironment())
+	// Failing to resolve a secret keeps the current config on reload.
+	loadedCfg, secretReferences, err := resolveSecretReferences(loadedCfg)
+	if err != nil {
+		return err
+	}
 	fixConfig(loadedCfg)
 	if appErr := loadedCfg.IsValid(); appErr != nil {
 		// Translating the error before displaying it in the console.
@@ -333,6 +354,7 @@ func (s *Store) Load() error {
 
 	s.config = loadedCfg
 	s.configNoEnv = loadedCfgNoEnv
+	s.secretReferences = secretReferences
 
 	loadedCfgCopy := loadedCfg.Clone()
 
@@ -54,6 +54,10 @@ func desanitize(actual, target *model.Config) {
 		target.OpenIdSettings.Secret = actual.OpenIdSettings.Secret
 	}
 
+	if target.ScimSettings.BearerToken != nil && *target.ScimSettings.BearerToken == model.FakeSetting {
+		target.ScimSettings.BearerToken = actual.ScimSettings.BearerToken
+	}
+
 	if *target.SqlSettings.DataSource == model.FakeSetting {
 		*target.SqlSettings.DataSource = *actual.SqlSettings.DataSource
 	}
@@ -89,6 +93,14 @@ func desanitize(actual, target *model.Config) {
 		*target.ServiceSettings.SplitKey = *actual.ServiceSettings.SplitKey
 	}
 
+	if *target.ConnectedWorkspacesSettings.MatrixAppServiceToken == model.FakeSetting {
+		*target.ConnectedWorkspacesSettings.MatrixAppServiceToken = *actual.ConnectedWorkspacesSettings.MatrixAppServiceToken
+	}
+
+	if *target.ConnectedWorkspacesSettings.MatrixHomeserverToken == model.FakeSetting {
+		*target.ConnectedWorkspacesSettings.MatrixHomeserverToken = *actual.ConnectedWorkspacesSettings.MatrixHomeserverToken
+	}
+
 	for id, settings := range target.PluginSettings.Plugins {
 		for k, v := range settings {
 			if v == model.FakeSetting {
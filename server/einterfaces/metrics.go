@@ -41,6 +41,8 @@ type MetricsInterface interface {
 	IncrementMemCacheMissCounterSession()
 	IncrementMemCacheHitCounterSession()
 	IncrementMemCacheInvalidationCounterSession()
+	IncrementNearCacheHitCounter(cacheName, tier string)
+	IncrementNearCacheMissCounter(cacheName, tier string)
 
 	IncrementWebsocketEvent(eventType model.WebsocketEventType)
 	IncrementWebSocketBroadcast(eventType model.WebsocketEventType)
@@ -72,6 +74,10 @@ type MetricsInterface interface {
 	ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64)
 	ObservePluginMultiHookDuration(elapsed float64)
 	ObservePluginAPIDuration(pluginID, apiName string, success bool, elapsed float64)
+	SetPluginMemoryUsage(pluginID string, bytes float64)
+	SetPluginCPUUsage(pluginID string, percent float64)
+	SetPluginOpenFiles(pluginID string, count float64)
+	ClearPluginResourceUsage(pluginID string)
 
 	ObserveEnabledUsers(users int64)
 	GetLoggerMetricsCollector() mlog.MetricsCollector
@@ -33,6 +33,11 @@ func (_m *MetricsInterface) ClearMobileClientSessionMetadata() {
 	_m.Called()
 }
 
+// ClearPluginResourceUsage provides a mock function with given fields: pluginID
+func (_m *MetricsInterface) ClearPluginResourceUsage(pluginID string) {
+	_m.Called(pluginID)
+}
+
 // DecrementHTTPWebSockets provides a mock function with given fields: originClient
 func (_m *MetricsInterface) DecrementHTTPWebSockets(originClient string) {
 	_m.Called(originClient)
@@ -178,6 +183,16 @@ func (_m *MetricsInterface) IncrementMemCacheMissCounterSession() {
 	_m.Called()
 }
 
+// IncrementNearCacheHitCounter provides a mock function with given fields: cacheName, tier
+func (_m *MetricsInterface) IncrementNearCacheHitCounter(cacheName string, tier string) {
+	_m.Called(cacheName, tier)
+}
+
+// IncrementNearCacheMissCounter provides a mock function with given fields: cacheName, tier
+func (_m *MetricsInterface) IncrementNearCacheMissCounter(cacheName string, tier string) {
+	_m.Called(cacheName, tier)
+}
+
 // IncrementNotificationAckCounter provides a mock function with given fields: notificationType, platform
 func (_m *MetricsInterface) IncrementNotificationAckCounter(notificationType model.NotificationType, platform string) {
 	_m.Called(notificationType, platform)
@@ -563,6 +578,21 @@ func (_m *MetricsInterface) RegisterDBCollector(db *sql.DB, name string) {
 	_m.Called(db, name)
 }
 
+// SetPluginCPUUsage provides a mock function with given fields: pluginID, percent
+func (_m *MetricsInterface) SetPluginCPUUsage(pluginID string, percent float64) {
+	_m.Called(pluginID, percent)
+}
+
+// SetPluginMemoryUsage provides a mock function with given fields: pluginID, bytes
+func (_m *MetricsInterface) SetPluginMemoryUsage(pluginID string, bytes float64) {
+	_m.Called(pluginID, bytes)
+}
+
+// SetPluginOpenFiles provides a mock function with given fields: pluginID, count
+func (_m *MetricsInterface) SetPluginOpenFiles(pluginID string, count float64) {
+	_m.Called(pluginID, count)
+}
+
 // SetReplicaLagAbsolute provides a mock function with given fields: node, value
 func (_m *MetricsInterface) SetReplicaLagAbsolute(node string, value float64) {
 	_m.Called(node, value)
@@ -17,6 +17,27 @@ type OAuthProvider interface {
 	IsSameUser(rctx request.CTX, dbUser, oAuthUser *model.User) bool
 }
 
+// OAuthUserClaims are the groups and system role of a user as asserted by an OAuth provider.
+type OAuthUserClaims struct {
+	// Groups holds the remote ids of the groups the user belongs to. It is nil when the provider
+	// doesn't manage group memberships.
+	Groups []string
+	// Role is the system role of the user, or empty when the provider doesn't manage roles.
+	Role string
+}
+
+// OAuthClaimsProvider is implemented by OAuth providers that map the claims of a user to
+// Mattermost groups and roles.
+type OAuthClaimsProvider interface {
+	GetUserClaimsFromJSON(rctx request.CTX, data io.Reader, tokenUser *model.User) (*OAuthUserClaims, error)
+}
+
+// OAuthLogoutProvider is implemented by OAuth providers that can end the user's session at the
+// identity provider after they logged out of Mattermost.
+type OAuthLogoutProvider interface {
+	GetLogoutURL(rctx request.CTX, config *model.Config, service, postLogoutRedirectURI string) (string, error)
+}
+
 var oauthProviders = make(map[string]OAuthProvider)
 
 func RegisterOAuthProvider(name string, newProvider OAuthProvider) {
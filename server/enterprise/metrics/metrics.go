@@ -95,6 +95,9 @@ type MetricsInterfaceImpl struct {
 	MemCacheMissCounterSession         prometheus.Counter
 	MemCacheInvalidationCounterSession prometheus.Counter
 
+	NearCacheHitCounters  *prometheus.CounterVec
+	NearCacheMissCounters *prometheus.CounterVec
+
 	WebsocketEventCounters *prometheus.CounterVec
 
 	WebSocketBroadcastCounters                    *prometheus.CounterVec
@@ -169,6 +172,9 @@ type MetricsInterfaceImpl struct {
 	PluginMultiHookTimeHistogram       *prometheus.HistogramVec
 	PluginMultiHookServerTimeHistogram prometheus.Histogram
 	PluginAPITimeHistogram             *prometheus.HistogramVec
+	PluginMemoryUsageGauge             *prometheus.GaugeVec
+	PluginCPUUsageGauge                *prometheus.GaugeVec
+	PluginOpenFilesGauge               *prometheus.GaugeVec
 
 	LoggerQueueGauge      *DynamicGauge
 	LoggerLoggedCounters  *DynamicCounter
@@ -528,6 +534,7 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 		model.ClusterEventPluginEvent,
 		model.ClusterEventInvalidateCacheForTermsOfService,
 		model.ClusterEventBusyStateChanged,
+		model.ClusterEventResetDKIMSigner,
 	} {
 		m.ClusterEventMap[event] = m.ClusterEventTypeCounters.With(prometheus.Labels{"name": string(event)})
 	}
@@ -618,6 +625,30 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	m.Registry.MustRegister(m.MemCacheInvalidationCounters)
 	m.MemCacheInvalidationCounterSession = m.MemCacheInvalidationCounters.With(prometheus.Labels{"name": "Session"})
 
+	m.NearCacheHitCounters = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemCaching,
+			Name:        "near_cache_hit_total",
+			Help:        "Total number of near cache hits per tier",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"name", "tier"},
+	)
+	m.Registry.MustRegister(m.NearCacheHitCounters)
+
+	m.NearCacheMissCounters = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemCaching,
+			Name:        "near_cache_miss_total",
+			Help:        "Total number of near cache misses per tier",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"name", "tier"},
+	)
+	m.Registry.MustRegister(m.NearCacheMissCounters)
+
 	// Websocket Subsystem
 
 	m.WebSocketBroadcastCounters = prometheus.NewCounterVec(
@@ -888,6 +919,42 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	)
 	m.Registry.MustRegister(m.PluginAPITimeHistogram)
 
+	m.PluginMemoryUsageGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "memory_usage_bytes",
+			Help:        "Resident memory used by the server process of the plugin in bytes.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id"},
+	)
+	m.Registry.MustRegister(m.PluginMemoryUsageGauge)
+
+	m.PluginCPUUsageGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "cpu_usage_percent",
+			Help:        "CPU used by the server process of the plugin, in percent of a single core.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id"},
+	)
+	m.Registry.MustRegister(m.PluginCPUUsageGauge)
+
+	m.PluginOpenFilesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "open_files",
+			Help:        "Number of files opened by the server process of the plugin.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id"},
+	)
+	m.Registry.MustRegister(m.PluginOpenFilesGauge)
+
 	// Logging subsystem
 
 	m.LoggerQueueGauge = NewDynamicGauge(
@@ -1809,6 +1876,14 @@ func (mi *MetricsInterfaceImpl) IncrementMemCacheInvalidationCounterSession() {
 	mi.MemCacheInvalidationCounterSession.Inc()
 }
 
+func (mi *MetricsInterfaceImpl) IncrementNearCacheHitCounter(cacheName, tier string) {
+	mi.NearCacheHitCounters.With(prometheus.Labels{"name": cacheName, "tier": tier}).Inc()
+}
+
+func (mi *MetricsInterfaceImpl) IncrementNearCacheMissCounter(cacheName, tier string) {
+	mi.NearCacheMissCounters.With(prometheus.Labels{"name": cacheName, "tier": tier}).Inc()
+}
+
 func (mi *MetricsInterfaceImpl) AddMemCacheMissCounter(cacheName string, amount float64) {
 	mi.MemCacheMissCounters.With(prometheus.Labels{"name": cacheName}).Add(amount)
 }
@@ -1899,6 +1974,24 @@ func (mi *MetricsInterfaceImpl) ObservePluginAPIDuration(pluginID, apiName strin
 	mi.PluginAPITimeHistogram.With(prometheus.Labels{"plugin_id": pluginID, "api_name": apiName, "success": strconv.FormatBool(success)}).Observe(elapsed)
 }
 
+func (mi *MetricsInterfaceImpl) SetPluginMemoryUsage(pluginID string, bytes float64) {
+	mi.PluginMemoryUsageGauge.With(prometheus.Labels{"plugin_id": pluginID}).Set(bytes)
+}
+
+func (mi *MetricsInterfaceImpl) SetPluginCPUUsage(pluginID string, percent float64) {
+	mi.PluginCPUUsageGauge.With(prometheus.Labels{"plugin_id": pluginID}).Set(percent)
+}
+
+func (mi *MetricsInterfaceImpl) SetPluginOpenFiles(pluginID string, count float64) {
+	mi.PluginOpenFilesGauge.With(prometheus.Labels{"plugin_id": pluginID}).Set(count)
+}
+
+func (mi *MetricsInterfaceImpl) ClearPluginResourceUsage(pluginID string) {
+	mi.PluginMemoryUsageGauge.DeleteLabelValues(pluginID)
+	mi.PluginCPUUsageGauge.DeleteLabelValues(pluginID)
+	mi.PluginOpenFilesGauge.DeleteLabelValues(pluginID)
+}
+
 func (mi *MetricsInterfaceImpl) GetLoggerMetricsCollector() mlog.MetricsCollector {
 	return &LoggerMetricsCollector{
 		queueGauge:      mi.LoggerQueueGauge,
@@ -0,0 +1,269 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cache
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+const (
+	// nearCacheMaxExpiry bounds how long a value is kept in the local tier, in case an
+	// invalidation is lost while the subscription is reconnecting or the value expires
+	// earlier in the external cache.
+	nearCacheMaxExpiry = 1 * time.Minute
+
+	NearCacheTierLocal = "local"
+	NearCacheTierRedis = "redis"
+)
+
+// nearCacheInvalidation is the message sent to the other nodes when a near cache is changed,
+// so they drop the changed keys from their local tier.
+type nearCacheInvalidation struct {
+	Origin string   `json:"origin"`
+	Cache  string   `json:"cache"`
+	Keys   []string `json:"keys,omitempty"`
+	Purge  bool     `json:"purge,omitempty"`
+}
+
+// NearCache is a two-tier cache keeping a bounded local LRU in front of an external cache.
+// Reads are served from the local tier when possible, while writes always go to the external
+// cache and drop the keys from the local tier of every node.
+type NearCache struct {
+	local       Cache
+	remote      ExternalCache
+	localExpiry time.Duration
+	// publish sends the invalidation to the other nodes.
+	publish func(msg *nearCacheInvalidation) error
+	metrics einterfaces.MetricsInterface
+
+	// mut orders filling the local tier after reading the external cache with the
+	// invalidations. The generation is bumped on every invalidation, and a value is only
+	// added to the local tier if no invalidation happened since it was read, so that a
+	// concurrent write can't be hidden by the value it replaced.
+	mut        sync.Mutex
+	generation int64
+}
+
+func newNearCache(opts *CacheOptions, remote ExternalCache, publish func(msg *nearCacheInvalidation) error) *NearCache {
+	localExpiry := opts.DefaultExpiry
+	if localExpiry <= 0 || localExpiry > nearCacheMaxExpiry {
+		localExpiry = nearCacheMaxExpiry
+	}
+
+	return &NearCache{
+		local: NewLRU(&CacheOptions{
+			Size:          opts.Size,
+			Name:          opts.Name,
+			DefaultExpiry: opts.DefaultExpiry,
+		}),
+		remote:      remote,
+		localExpiry: localExpiry,
+		publish:     publish,
+	}
+}
+
+func (c *NearCache) currentGeneration() int64 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.generation
+}
+
+// fill adds the value read from the external cache to the local tier, unless the cache was
+// invalidated since the given generation.
+func (c *NearCache) fill(generation int64, key string, value any) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.generation != generation {
+		return
+	}
+	// The value has just been decoded, so it can't fail to be encoded again.
+	_ = c.local.SetWithExpiry(key, value, c.localExpiry)
+}
+
+func (c *NearCache) invalidateLocal(keys []string, purge bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.generation++
+	if purge {
+		_ = c.local.Purge()
+	} else {
+		_ = c.local.RemoveMulti(keys)
+	}
+}
+
+// invalidate drops the keys from the local tier of every node.
+func (c *NearCache) invalidate(keys []string, purge bool) error {
+	c.invalidateLocal(keys, purge)
+	return c.publish(&nearCacheInvalidation{
+		Cache: c.Name(),
+		Keys:  keys,
+		Purge: purge,
+	})
+}
+
+// handleInvalidation drops the keys invalidated by another node from the local tier.
+func (c *NearCache) handleInvalidation(msg *nearCacheInvalidation) {
+	c.invalidateLocal(msg.Keys, msg.Purge)
+}
+
+func (c *NearCache) incrementHit(tier string) {
+	if c.metrics != nil {
+		c.metrics.IncrementNearCacheHitCounter(c.Name(), tier)
+	}
+}
+
+func (c *NearCache) incrementMiss(tier string) {
+	if c.metrics != nil {
+		c.metrics.IncrementNearCacheMissCounter(c.Name(), tier)
+	}
+}
+
+// Purge is used to completely clear the cache.
+func (c *NearCache) Purge() error {
+	if err := c.remote.Purge(); err != nil {
+		return err
+	}
+	return c.invalidate(nil, true)
+}
+
+// SetWithDefaultExpiry adds the given key and value to the store with the default expiry. If
+// the key already exists, it will overwrite the previous value
+func (c *NearCache) SetWithDefaultExpiry(key string, value any) error {
+	if err := c.remote.SetWithDefaultExpiry(key, value); err != nil {
+		return err
+	}
+	return c.invalidate([]string{key}, false)
+}
+
+// SetWithExpiry adds the given key and value to the cache with the given expiry. If the key
+// already exists, it will overwrite the previous value
+func (c *NearCache) SetWithExpiry(key string, value any, ttl time.Duration) error {
+	if err := c.remote.SetWithExpiry(key, value, ttl); err != nil {
+		return err
+	}
+	return c.invalidate([]string{key}, false)
+}
+
+// Increment will increment the number stored at that key by the value.
+func (c *NearCache) Increment(key string, val int) error {
+	if err := c.remote.Increment(key, val); err != nil {
+		return err
+	}
+	return c.invalidate([]string{key}, false)
+}
+
+// Decrement will decrement the number stored at that key by the value.
+func (c *NearCache) Decrement(key string, val int) error {
+	if err := c.remote.Decrement(key, val); err != nil {
+		return err
+	}
+	return c.invalidate([]string{key}, false)
+}
+
+// Get the content stored in the cache for the given key, and decode it into the value interface.
+// Returns ErrKeyNotFound if the key is missing from the cache
+func (c *NearCache) Get(key string, value any) error {
+	if err := c.local.Get(key, value); err == nil {
+		c.incrementHit(NearCacheTierLocal)
+		return nil
+	}
+	c.incrementMiss(NearCacheTierLocal)
+
+	generation := c.currentGeneration()
+	if err := c.remote.Get(key, value); err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			c.incrementMiss(NearCacheTierRedis)
+		}
+		return err
+	}
+	c.incrementHit(NearCacheTierRedis)
+
+	c.fill(generation, key, value)
+	return nil
+}
+
+// GetMulti returns values for multiple keys in a single operation, only reading the keys
+// missing from the local tier from the external cache.
+// Returns ErrKeyNotFound if the key is missing from the cache.
+func (c *NearCache) GetMulti(keys []string, values []any) []error {
+	errs := c.local.GetMulti(keys, values)
+
+	var missingIndexes []int
+	for i, err := range errs {
+		if err == nil {
+			c.incrementHit(NearCacheTierLocal)
+			continue
+		}
+		c.incrementMiss(NearCacheTierLocal)
+		missingIndexes = append(missingIndexes, i)
+	}
+	if len(missingIndexes) == 0 {
+		return errs
+	}
+
+	missingKeys := make([]string, len(missingIndexes))
+	missingValues := make([]any, len(missingIndexes))
+	for i, index := range missingIndexes {
+		missingKeys[i] = keys[index]
+		missingValues[i] = values[index]
+	}
+
+	generation := c.currentGeneration()
+	remoteErrs := c.remote.GetMulti(missingKeys, missingValues)
+	for i, index := range missingIndexes {
+		errs[index] = remoteErrs[i]
+		if remoteErrs[i] != nil {
+			if errors.Is(remoteErrs[i], ErrKeyNotFound) {
+				c.incrementMiss(NearCacheTierRedis)
+			}
+			continue
+		}
+		c.incrementHit(NearCacheTierRedis)
+		c.fill(generation, keys[index], values[index])
+	}
+
+	return errs
+}
+
+// Remove deletes the value for a given key.
+func (c *NearCache) Remove(key string) error {
+	if err := c.remote.Remove(key); err != nil {
+		return err
+	}
+	return c.invalidate([]string{key}, false)
+}
+
+// RemoveMulti deletes multiple keys in a single operation.
+func (c *NearCache) RemoveMulti(keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := c.remote.RemoveMulti(keys); err != nil {
+		return err
+	}
+	return c.invalidate(keys, false)
+}
+
+// Scan iterates over the keys of the external cache.
+func (c *NearCache) Scan(f func([]string) error) error {
+	return c.remote.Scan(f)
+}
+
+// GetInvalidateClusterEvent returns the cluster event configured when this cache was created.
+// The near caches are invalidated through the external cache instead of the cluster.
+func (c *NearCache) GetInvalidateClusterEvent() model.ClusterEvent {
+	return model.ClusterEventNone
+}
+
+// Name returns the name of the cache
+func (c *NearCache) Name() string {
+	return c.remote.Name()
+}
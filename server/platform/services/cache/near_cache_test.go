@@ -0,0 +1,292 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
+)
+
+// testExternalCache stands in for Redis, shared by the near caches of all the nodes.
+type testExternalCache struct {
+	Cache
+	mut sync.Mutex
+	// afterGet is called after reading a key, to interleave writes with the reads.
+	afterGet func(key string)
+}
+
+func newTestExternalCache() *testExternalCache {
+	return &testExternalCache{
+		Cache: NewLRU(&CacheOptions{Size: 1000, Name: "test"}),
+	}
+}
+
+func (c *testExternalCache) Get(key string, value any) error {
+	err := c.Cache.Get(key, value)
+	if c.afterGet != nil {
+		c.afterGet(key)
+	}
+	return err
+}
+
+func (c *testExternalCache) Increment(key string, val int) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	var current int64
+	if err := c.Cache.Get(key, &current); err != nil && err != ErrKeyNotFound {
+		return err
+	}
+	return c.Cache.SetWithDefaultExpiry(key, current+int64(val))
+}
+
+func (c *testExternalCache) Decrement(key string, val int) error {
+	return c.Increment(key, -val)
+}
+
+// testNearCacheNodes creates the near caches of several nodes sharing the same external
+// cache, with the invalidations delivered synchronously to the other nodes.
+func testNearCacheNodes(remote ExternalCache, count int) []*NearCache {
+	nodes := make([]*NearCache, count)
+	for i := range nodes {
+		origin := fmt.Sprintf("node%d", i)
+		nodes[i] = newNearCache(&CacheOptions{Size: 100, Name: "test"}, remote, func(msg *nearCacheInvalidation) error {
+			msg.Origin = origin
+			for j, node := range nodes {
+				if fmt.Sprintf("node%d", j) != msg.Origin {
+					node.handleInvalidation(msg)
+				}
+			}
+			return nil
+		})
+	}
+	return nodes
+}
+
+func TestNearCache(t *testing.T) {
+	t.Run("reads from the local tier after the external cache", func(t *testing.T) {
+		nodes := testNearCacheNodes(newTestExternalCache(), 1)
+		metrics := &mocks.MetricsInterface{}
+		nodes[0].metrics = metrics
+		metrics.On("IncrementNearCacheMissCounter", "test", NearCacheTierLocal).Twice()
+		metrics.On("IncrementNearCacheMissCounter", "test", NearCacheTierRedis).Once()
+		metrics.On("IncrementNearCacheHitCounter", "test", NearCacheTierRedis).Once()
+		metrics.On("IncrementNearCacheHitCounter", "test", NearCacheTierLocal).Once()
+
+		var value string
+		require.Equal(t, ErrKeyNotFound, nodes[0].Get("key", &value))
+
+		require.NoError(t, nodes[0].SetWithDefaultExpiry("key", "value"))
+		require.NoError(t, nodes[0].Get("key", &value))
+		assert.Equal(t, "value", value)
+
+		value = ""
+		require.NoError(t, nodes[0].Get("key", &value))
+		assert.Equal(t, "value", value)
+		metrics.AssertExpectations(t)
+	})
+
+	t.Run("invalidates the local tier of the other nodes", func(t *testing.T) {
+		nodes := testNearCacheNodes(newTestExternalCache(), 2)
+		var value string
+
+		readAll := func() {
+			for _, node := range nodes {
+				for _, key := range []string{"key1", "key2"} {
+					require.NoError(t, node.Get(key, &value))
+				}
+			}
+		}
+
+		require.NoError(t, nodes[0].SetWithDefaultExpiry("key1", "value1"))
+		require.NoError(t, nodes[0].SetWithDefaultExpiry("key2", "value2"))
+		readAll()
+
+		require.NoError(t, nodes[0].SetWithDefaultExpiry("key1", "updated"))
+		require.NoError(t, nodes[1].Get("key1", &value))
+		assert.Equal(t, "updated", value)
+
+		require.NoError(t, nodes[0].Remove("key1"))
+		assert.Equal(t, ErrKeyNotFound, nodes[1].Get("key1", &value))
+
+		require.NoError(t, nodes[0].SetWithDefaultExpiry("key1", "value1"))
+		readAll()
+		require.NoError(t, nodes[1].RemoveMulti([]string{"key1", "key2"}))
+		assert.Equal(t, ErrKeyNotFound, nodes[0].Get("key1", &value))
+		assert.Equal(t, ErrKeyNotFound, nodes[0].Get("key2", &value))
+
+		require.NoError(t, nodes[0].SetWithDefaultExpiry("key1", "value1"))
+		require.NoError(t, nodes[0].SetWithDefaultExpiry("key2", "value2"))
+		readAll()
+		require.NoError(t, nodes[1].Purge())
+		assert.Equal(t, ErrKeyNotFound, nodes[0].Get("key1", &value))
+		assert.Equal(t, ErrKeyNotFound, nodes[0].Get("key2", &value))
+	})
+
+	t.Run("invalidates the local tier on increments", func(t *testing.T) {
+		nodes := testNearCacheNodes(newTestExternalCache(), 2)
+		var value int64
+
+		require.NoError(t, nodes[0].Increment("count", 2))
+		require.NoError(t, nodes[1].Get("count", &value))
+		assert.Equal(t, int64(2), value)
+
+		require.NoError(t, nodes[0].Decrement("count", 1))
+		require.NoError(t, nodes[1].Get("count", &value))
+		assert.Equal(t, int64(1), value)
+	})
+
+	t.Run("reads the keys missing from the local tier", func(t *testing.T) {
+		nodes := testNearCacheNodes(newTestExternalCache(), 1)
+		require.NoError(t, nodes[0].SetWithDefaultExpiry("key1", "value1"))
+		require.NoError(t, nodes[0].SetWithDefaultExpiry("key2", "value2"))
+
+		var value string
+		require.NoError(t, nodes[0].Get("key1", &value))
+
+		values := make([]string, 3)
+		errs := nodes[0].GetMulti([]string{"key1", "key2", "key3"}, []any{&values[0], &values[1], &values[2]})
+		require.Len(t, errs, 3)
+		assert.NoError(t, errs[0])
+		assert.NoError(t, errs[1])
+		assert.Equal(t, ErrKeyNotFound, errs[2])
+		assert.Equal(t, []string{"value1", "value2", ""}, values)
+
+		value = ""
+		require.NoError(t, nodes[0].local.Get("key2", &value))
+		assert.Equal(t, "value2", value)
+	})
+
+	t.Run("doesn't keep a value replaced while being read", func(t *testing.T) {
+		remote := newTestExternalCache()
+		nodes := testNearCacheNodes(remote, 2)
+		require.NoError(t, nodes[0].SetWithDefaultExpiry("key", "old"))
+
+		// The value is replaced on the other node after being read from the external cache,
+		// but before being added to the local tier.
+		remote.afterGet = func(key string) {
+			remote.afterGet = nil
+			require.NoError(t, nodes[1].SetWithDefaultExpiry(key, "new"))
+		}
+		var value string
+		require.NoError(t, nodes[0].Get("key", &value))
+		assert.Equal(t, "old", value)
+
+		require.NoError(t, nodes[0].Get("key", &value))
+		assert.Equal(t, "new", value)
+	})
+
+	t.Run("uses a bounded expiry for the local tier", func(t *testing.T) {
+		remote := newTestExternalCache()
+		assert.Equal(t, nearCacheMaxExpiry, newNearCache(&CacheOptions{Size: 1, Name: "test"}, remote, nil).localExpiry)
+		assert.Equal(t, nearCacheMaxExpiry, newNearCache(&CacheOptions{Size: 1, Name: "test", DefaultExpiry: time.Hour}, remote, nil).localExpiry)
+		assert.Equal(t, time.Second, newNearCache(&CacheOptions{Size: 1, Name: "test", DefaultExpiry: time.Second}, remote, nil).localExpiry)
+	})
+}
+
+func TestNearCacheConcurrentWriters(t *testing.T) {
+	const (
+		nodeCount   = 3
+		keyCount    = 10
+		writesCount = 200
+	)
+	remote := newTestExternalCache()
+	nodes := testNearCacheNodes(remote, nodeCount)
+
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			for j := range writesCount {
+				key := fmt.Sprintf("key%d", j%keyCount)
+				switch j % 7 {
+				case 0:
+					assert.NoError(t, node.Remove(key))
+				case 1:
+					assert.NoError(t, node.RemoveMulti([]string{key, fmt.Sprintf("key%d", (j+1)%keyCount)}))
+				default:
+					assert.NoError(t, node.SetWithDefaultExpiry(key, fmt.Sprintf("node%d-%d", i, j)))
+				}
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			for j := range writesCount {
+				var value string
+				err := node.Get(fmt.Sprintf("key%d", j%keyCount), &value)
+				if err != nil {
+					assert.Equal(t, ErrKeyNotFound, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Once the writers are done, every node must read the last value written.
+	for i := range keyCount {
+		key := fmt.Sprintf("key%d", i)
+		var expected string
+		expectedErr := remote.Cache.Get(key, &expected)
+
+		for _, node := range nodes {
+			var value string
+			err := node.Get(key, &value)
+			assert.Equal(t, expectedErr, err, key)
+			assert.Equal(t, expected, value, key)
+		}
+	}
+}
+
+func TestRedisProviderHandleNearCacheInvalidation(t *testing.T) {
+	remote := newTestExternalCache()
+	nc := newNearCache(&CacheOptions{Size: 10, Name: "test"}, remote, func(*nearCacheInvalidation) error { return nil })
+	provider := &redisProvider{
+		nodeID:     model.NewId(),
+		nearCaches: map[string]*NearCache{"test": nc},
+	}
+
+	fillLocal := func() {
+		require.NoError(t, nc.local.SetWithDefaultExpiry("key", "value"))
+	}
+	send := func(msg *nearCacheInvalidation) {
+		buf, err := json.Marshal(msg)
+		require.NoError(t, err)
+		provider.handleNearCacheInvalidation(rueidis.PubSubMessage{Message: string(buf)})
+	}
+	var value string
+
+	fillLocal()
+	send(&nearCacheInvalidation{Origin: provider.nodeID, Cache: "test", Keys: []string{"key"}})
+	require.NoError(t, nc.local.Get("key", &value), "the own invalidations must be ignored")
+
+	send(&nearCacheInvalidation{Origin: model.NewId(), Cache: "other", Keys: []string{"key"}})
+	require.NoError(t, nc.local.Get("key", &value))
+
+	send(&nearCacheInvalidation{Origin: model.NewId(), Cache: "test", Keys: []string{"key"}})
+	require.Equal(t, ErrKeyNotFound, nc.local.Get("key", &value))
+
+	fillLocal()
+	send(&nearCacheInvalidation{Origin: model.NewId(), Cache: "test", Purge: true})
+	require.Equal(t, ErrKeyNotFound, nc.local.Get("key", &value))
+
+	fillLocal()
+	provider.handleNearCacheInvalidation(rueidis.PubSubMessage{Message: "invalid"})
+	require.NoError(t, nc.local.Get("key", &value))
+
+	provider.purgeNearCaches()
+	require.Equal(t, ErrKeyNotFound, nc.local.Get("key", &value))
+}
@@ -5,14 +5,21 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
 	"github.com/redis/rueidis"
 )
 
+// nearCacheResubscribeDelay is the delay before subscribing again to the near cache
+// invalidations after the subscription failed.
+const nearCacheResubscribeDelay = time.Second
+
 // CacheOptions contains options for initializing a cache
 type CacheOptions struct {
 	Size                   int
@@ -23,6 +30,10 @@ type CacheOptions struct {
 	// StripedBuckets is used only by LRUStriped and shouldn't be greater than the number
 	// of CPUs available on the machine running this cache.
 	StripedBuckets int
+	// NearCache is used only by the Redis provider, to keep up to Size of the values read
+	// in a local LRU in front of Redis. It should be reserved to hot keys read far more
+	// often than they are written, since every write invalidates the key on all the nodes.
+	NearCache bool
 }
 
 // Provider is a provider for Cache
@@ -77,6 +88,15 @@ type redisProvider struct {
 	client      rueidis.Client
 	cachePrefix string
 	metrics     einterfaces.MetricsInterface
+	logger      mlog.LoggerIFace
+
+	// nodeID identifies the near cache invalidations sent by this provider.
+	nodeID         string
+	nearCachesMut  sync.RWMutex
+	nearCaches     map[string]*NearCache
+	subscribeOnce  sync.Once
+	stopSubscribe  context.CancelFunc
+	subscribeEnded chan struct{}
 }
 
 type RedisOptions struct {
@@ -85,6 +105,8 @@ type RedisOptions struct {
 	RedisDB          int
 	RedisCachePrefix string
 	DisableCache     bool
+	// Logger is used to log the failures to receive the near cache invalidations.
+	Logger mlog.LoggerIFace
 }
 
 // NewProvider creates a new CacheProvider
@@ -109,7 +131,13 @@ func NewRedisProvider(opts *RedisOptions) (Provider, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &redisProvider{client: client, cachePrefix: opts.RedisCachePrefix}, nil
+	return &redisProvider{
+		client:      client,
+		cachePrefix: opts.RedisCachePrefix,
+		logger:      opts.Logger,
+		nodeID:      model.NewId(),
+		nearCaches:  map[string]*NearCache{},
+	}, nil
 }
 
 // NewCache creates a new cache with given opts
@@ -118,8 +146,107 @@ func (r *redisProvider) NewCache(opts *CacheOptions) (Cache, error) {
 		opts.Name = r.cachePrefix + ":" + opts.Name
 	}
 	rr, err := NewRedis(opts, r.client)
+	if err != nil {
+		return nil, err
+	}
 	rr.metrics = r.metrics
-	return rr, err
+	if !opts.NearCache {
+		return rr, nil
+	}
+
+	nc := newNearCache(opts, rr, r.publishNearCacheInvalidation)
+	nc.metrics = r.metrics
+	r.nearCachesMut.Lock()
+	r.nearCaches[nc.Name()] = nc
+	r.nearCachesMut.Unlock()
+
+	r.subscribeOnce.Do(r.startNearCacheSubscription)
+	return nc, nil
+}
+
+func (r *redisProvider) nearCacheChannel() string {
+	if r.cachePrefix != "" {
+		return r.cachePrefix + ":near_cache_invalidations"
+	}
+	return "near_cache_invalidations"
+}
+
+func (r *redisProvider) publishNearCacheInvalidation(msg *nearCacheInvalidation) error {
+	msg.Origin = r.nodeID
+	buf, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Do(context.Background(),
+		r.client.B().Publish().
+			Channel(r.nearCacheChannel()).
+			Message(rueidis.BinaryString(buf)).
+			Build(),
+	).Error()
+}
+
+func (r *redisProvider) handleNearCacheInvalidation(pubSubMsg rueidis.PubSubMessage) {
+	var msg nearCacheInvalidation
+	if err := json.Unmarshal([]byte(pubSubMsg.Message), &msg); err != nil {
+		if r.logger != nil {
+			r.logger.Warn("Failed to decode the near cache invalidation", mlog.Err(err))
+		}
+		return
+	}
+	// The local tier is already invalidated before sending the message.
+	if msg.Origin == r.nodeID {
+		return
+	}
+
+	r.nearCachesMut.RLock()
+	nc, ok := r.nearCaches[msg.Cache]
+	r.nearCachesMut.RUnlock()
+	if ok {
+		nc.handleInvalidation(&msg)
+	}
+}
+
+// purgeNearCaches clears the local tier of all the near caches, since the invalidations sent
+// while the subscription was down are lost.
+func (r *redisProvider) purgeNearCaches() {
+	r.nearCachesMut.RLock()
+	defer r.nearCachesMut.RUnlock()
+
+	for _, nc := range r.nearCaches {
+		nc.invalidateLocal(nil, true)
+	}
+}
+
+func (r *redisProvider) startNearCacheSubscription() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.stopSubscribe = cancel
+	r.subscribeEnded = make(chan struct{})
+
+	go func() {
+		defer close(r.subscribeEnded)
+		for {
+			err := r.client.Receive(ctx,
+				r.client.B().Subscribe().
+					Channel(r.nearCacheChannel()).
+					Build(),
+				r.handleNearCacheInvalidation,
+			)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil && r.logger != nil {
+				r.logger.Warn("Failed to receive the near cache invalidations, subscribing again", mlog.Err(err))
+			}
+			r.purgeNearCaches()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(nearCacheResubscribeDelay):
+			}
+		}
+	}()
 }
 
 // Connect opens a new connection to the cache using specific provider parameters.
@@ -141,6 +268,15 @@ func (r *redisProvider) Type() string {
 
 // Close releases any resources used by the cache provider.
 func (r *redisProvider) Close() error {
+	// Waits for the subscription to be started, if it's being started, and prevents
+	// it from being started later.
+	r.subscribeOnce.Do(func() {})
+	if r.stopSubscribe != nil {
+		r.stopSubscribe()
+	}
 	r.client.Close()
+	if r.subscribeEnded != nil {
+		<-r.subscribeEnded
+	}
 	return nil
 }
@@ -0,0 +1,122 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/throttled/throttled"
+)
+
+var redisCompareAndSwapScript = rueidis.NewLuaScript(`
+local v = redis.call('get', KEYS[1])
+if v == false or v ~= ARGV[1] then
+  return 0
+end
+redis.call('set', KEYS[1], ARGV[2], 'PX', ARGV[3])
+return 1
+`)
+
+// RedisRateLimitStore is a store for the rate limiters keeping their state in Redis, so that the
+// limits are shared by all the nodes.
+type RedisRateLimitStore struct {
+	client rueidis.Client
+	prefix string
+}
+
+var _ throttled.GCRAStore = (*RedisRateLimitStore)(nil)
+
+// NewRedisRateLimitStore returns a store for the rate limiters sharing the connection of the
+// provider, or nil if the provider doesn't use Redis.
+func NewRedisRateLimitStore(provider Provider) *RedisRateLimitStore {
+	r, ok := provider.(*redisProvider)
+	if !ok {
+		return nil
+	}
+
+	prefix := "ratelimit:"
+	if r.cachePrefix != "" {
+		prefix = r.cachePrefix + ":" + prefix
+	}
+	return &RedisRateLimitStore{client: r.client, prefix: prefix}
+}
+
+func rateLimitTTL(ttl time.Duration) time.Duration {
+	// A TTL below a millisecond would be rejected by Redis.
+	return max(ttl, time.Millisecond)
+}
+
+// GetWithTime returns the value of the key, or -1 if it doesn't exist, with the current time of
+// the Redis server, so that all the nodes share the same clock.
+func (s *RedisRateLimitStore) GetWithTime(key string) (int64, time.Time, error) {
+	resps := s.client.DoMulti(context.Background(),
+		s.client.B().Time().Build(),
+		s.client.B().Get().Key(s.prefix+key).Build(),
+	)
+
+	serverTime, err := resps[0].AsStrSlice()
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if len(serverTime) != 2 {
+		return 0, time.Time{}, errors.New("unexpected reply to TIME")
+	}
+	seconds, err := strconv.ParseInt(serverTime[0], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	microseconds, err := strconv.ParseInt(serverTime[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	now := time.Unix(seconds, microseconds*int64(time.Microsecond))
+
+	value, err := resps[1].AsInt64()
+	if rueidis.IsRedisNil(err) {
+		return -1, now, nil
+	} else if err != nil {
+		return 0, now, err
+	}
+	return value, now, nil
+}
+
+// SetIfNotExistsWithTTL sets the value of the key only if it doesn't exist, returning whether
+// it was set.
+func (s *RedisRateLimitStore) SetIfNotExistsWithTTL(key string, value int64, ttl time.Duration) (bool, error) {
+	err := s.client.Do(context.Background(),
+		s.client.B().Set().
+			Key(s.prefix+key).
+			Value(strconv.FormatInt(value, 10)).
+			Nx().
+			Px(rateLimitTTL(ttl)).
+			Build(),
+	).Error()
+	if rueidis.IsRedisNil(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CompareAndSwapWithTTL atomically sets the value of the key to new if it's old, returning
+// whether it was set. It returns false if the key doesn't exist.
+func (s *RedisRateLimitStore) CompareAndSwapWithTTL(key string, old, new int64, ttl time.Duration) (bool, error) {
+	swapped, err := redisCompareAndSwapScript.Exec(context.Background(), s.client,
+		[]string{s.prefix + key},
+		[]string{
+			strconv.FormatInt(old, 10),
+			strconv.FormatInt(new, 10),
+			strconv.FormatInt(rateLimitTTL(ttl).Milliseconds(), 10),
+		},
+	).AsInt64()
+	if err != nil {
+		return false, err
+	}
+	return swapped == 1, nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package matrixbridge
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+// BridgeID identifies the bridge as a shared channels remote. The bridge is
+// registered for shared channels the same way a plugin is, using BridgeID as
+// the plugin ID.
+const BridgeID = "com.mattermost.matrix-bridge"
+
+// RemoteName is the name of the remote the bridge is registered as.
+const RemoteName = "matrix"
+
+const (
+	PingTimeout       = 10 * time.Second
+	TransactionExpiry = 24 * time.Hour
+)
+
+// ServerIface is the server the bridge runs in.
+type ServerIface interface {
+	Config() *model.Config
+	GetStore() store.Store
+	Log() *mlog.Logger
+}
+
+// SharedChannelServiceIface is the shared channels service content received
+// from Matrix is handed over to.
+type SharedChannelServiceIface interface {
+	ReceiveSyncMsg(syncMsg *model.SyncMsg, rc *model.RemoteCluster) (model.SyncResponse, error)
+	ReceiveUploadCreate(us *model.UploadSession, rc *model.RemoteCluster) (*model.UploadSession, error)
+}
+
+// AppIface is the subset of the app the bridge needs to exchange files.
+type AppIface interface {
+	UploadData(rctx request.CTX, us *model.UploadSession, rd io.Reader) (*model.FileInfo, *model.AppError)
+	FileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
+	GetProfileImage(user *model.User) ([]byte, bool, *model.AppError)
+}
+
+// Bridge is a Matrix application service connecting shared channels to Matrix
+// rooms. Content from Mattermost is delivered to it like it is to plugins
+// registered for shared channels, and posted to Matrix by puppets of the
+// Mattermost users. Events pushed by the homeserver are turned into sync
+// messages for the shared channels service.
+type Bridge struct {
+	server ServerIface
+	scs    SharedChannelServiceIface
+	app    AppIface
+	client *Client
+	router *mux.Router
+
+	mux       sync.Mutex
+	puppets   map[string]string // Mattermost user ID -> display name last set
+	roomUsers map[string]bool   // room ID and Matrix user ID of puppets that joined
+
+	txnMux sync.Mutex // transactions are processed one at a time
+}
+
+// New creates a bridge using the Matrix settings from the Connected
+// Workspaces settings.
+func New(server ServerIface, scs SharedChannelServiceIface, app AppIface, httpClient *http.Client) *Bridge {
+	settings := server.Config().ConnectedWorkspacesSettings
+	b := &Bridge{
+		server:    server,
+		scs:       scs,
+		app:       app,
+		client:    NewClient(*settings.MatrixHomeserverURL, *settings.MatrixAppServiceToken, httpClient),
+		puppets:   make(map[string]string),
+		roomUsers: make(map[string]bool),
+	}
+	b.router = b.newRouter()
+	return b
+}
+
+func (b *Bridge) settings() model.ConnectedWorkspacesSettings {
+	return b.server.Config().ConnectedWorkspacesSettings
+}
+
+func (b *Bridge) rctx() request.CTX {
+	return request.EmptyContext(b.server.Log())
+}
+
+// senderUserID is the Matrix user of the application service itself.
+func (b *Bridge) senderUserID() string {
+	settings := b.settings()
+	return "@" + *settings.MatrixSenderLocalpart + ":" + *settings.MatrixServerName
+}
+
+// puppetUserID is the Matrix user standing in for a Mattermost user.
+func (b *Bridge) puppetUserID(userID string) string {
+	settings := b.settings()
+	return "@" + *settings.MatrixUserPrefix + userID + ":" + *settings.MatrixServerName
+}
+
+// puppetFromUserID returns the Mattermost user ID of a puppet, or false when
+// the Matrix user isn't one.
+func (b *Bridge) puppetFromUserID(matrixUserID string) (string, bool) {
+	settings := b.settings()
+	localpart, serverName, ok := splitUserID(matrixUserID)
+	if !ok || serverName != *settings.MatrixServerName {
+		return "", false
+	}
+	userID, ok := strings.CutPrefix(localpart, *settings.MatrixUserPrefix)
+	if !ok || !model.IsValidId(userID) {
+		return "", false
+	}
+	return userID, true
+}
+
+// isBridgeUser checks whether a Matrix user belongs to the bridge, in which case
+// its events originate from Mattermost.
+func (b *Bridge) isBridgeUser(matrixUserID string) bool {
+	if matrixUserID == b.senderUserID() {
+		return true
+	}
+	_, ok := b.puppetFromUserID(matrixUserID)
+	return ok
+}
+
+// remoteCluster returns the remote the bridge is registered as.
+func (b *Bridge) remoteCluster() (*model.RemoteCluster, error) {
+	return b.server.GetStore().RemoteCluster().GetByPluginID(BridgeID)
+}
+
+// OnSharedChannelsPing checks that the homeserver can be reached with the
+// application service token.
+func (b *Bridge) OnSharedChannelsPing(rc *model.RemoteCluster) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), PingTimeout)
+	defer cancel()
+
+	if _, err := b.client.WhoAmI(ctx); err != nil {
+		b.server.Log().Log(mlog.LvlSharedChannelServiceWarn, "Matrix homeserver ping failed",
+			mlog.String("remote", rc.DisplayName),
+			mlog.Err(err),
+		)
+		return false
+	}
+	return true
+}
+
+// ServeHTTP serves the application service API called by the homeserver.
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.router.ServeHTTP(w, r)
+}
@@ -0,0 +1,461 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package matrixbridge_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/services/matrixbridge"
+)
+
+// roomEvents returns the events of the bridged room of the test channel, of the
+// given type.
+func (th *TestHelper) roomEvents(t *testing.T, eventType string) []*matrixbridge.Event {
+	t.Helper()
+	room := th.HS.Room(th.roomID(t))
+	require.NotNil(t, room)
+
+	var events []*matrixbridge.Event
+	for _, ev := range room.Events {
+		if ev.Type == eventType {
+			events = append(events, ev)
+		}
+	}
+	return events
+}
+
+func (th *TestHelper) roomID(t *testing.T) string {
+	t.Helper()
+	alias := "#" + model.ConnectedWorkspacesSettingsDefaultMatrixUserPrefix + th.Channel.Id + ":" + testServerName
+	roomID := th.HS.RoomIDForAlias(alias)
+	require.NotEmpty(t, roomID, "room not created for channel")
+	return roomID
+}
+
+// sync sends a sync message for the test channel to the bridge.
+func (th *TestHelper) sync(t *testing.T, posts []*model.Post, reactions []*model.Reaction) {
+	t.Helper()
+	msg := model.NewSyncMsg(th.Channel.Id)
+	msg.Users = map[string]*model.User{th.User.Id: th.User}
+	msg.Posts = posts
+	msg.Reactions = reactions
+	for _, post := range posts {
+		th.addPost(post)
+	}
+
+	resp, err := th.Bridge.OnSharedChannelsSyncMsg(msg, th.Remote)
+	require.NoError(t, err)
+	require.Empty(t, resp.PostErrors)
+	require.Empty(t, resp.ReactionErrors)
+	require.Equal(t, []string{th.User.Id}, resp.UsersSyncd)
+}
+
+func messageContent(t *testing.T, ev *matrixbridge.Event) *matrixbridge.MessageContent {
+	t.Helper()
+	var content matrixbridge.MessageContent
+	require.NoError(t, json.Unmarshal(ev.Content, &content))
+	return &content
+}
+
+func TestOutbound(t *testing.T) {
+	th := setupTestHelper(t)
+	puppetID := th.puppetUserID(th.User.Id)
+
+	root := &model.Post{
+		Id:        model.NewId(),
+		ChannelId: th.Channel.Id,
+		UserId:    th.User.Id,
+		Message:   "hello Matrix",
+		CreateAt:  1000,
+		UpdateAt:  1000,
+	}
+	reply := &model.Post{
+		Id:        model.NewId(),
+		ChannelId: th.Channel.Id,
+		UserId:    th.User.Id,
+		RootId:    root.Id,
+		Message:   "a reply",
+		CreateAt:  1001,
+		UpdateAt:  1001,
+	}
+
+	t.Run("posts create the room and the puppet", func(t *testing.T) {
+		th.sync(t, []*model.Post{root, reply}, nil)
+
+		room := th.HS.Room(th.roomID(t))
+		assert.Equal(t, "Town Square", room.Name)
+		assert.Equal(t, "General discussion", room.Topic)
+		assert.True(t, room.Public)
+		assert.Equal(t, matrixbridge.MembershipJoin, room.Members[puppetID])
+		assert.Equal(t, "Sys Admin", th.HS.DisplayName(puppetID))
+
+		messages := th.roomEvents(t, matrixbridge.EventTypeMessage)
+		require.Len(t, messages, 2)
+		assert.Equal(t, puppetID, messages[0].Sender)
+		assert.Equal(t, "hello Matrix", messageContent(t, messages[0]).Body)
+
+		content := messageContent(t, messages[1])
+		assert.Equal(t, "a reply", content.Body)
+		require.NotNil(t, content.RelatesTo)
+		assert.Equal(t, matrixbridge.RelTypeThread, content.RelatesTo.RelType)
+		assert.Equal(t, messages[0].EventID, content.RelatesTo.EventID)
+	})
+
+	t.Run("syncing the same posts again doesn't duplicate them", func(t *testing.T) {
+		th.sync(t, []*model.Post{root, reply}, nil)
+		assert.Len(t, th.roomEvents(t, matrixbridge.EventTypeMessage), 2)
+	})
+
+	t.Run("edits replace the original event", func(t *testing.T) {
+		edited := root.Clone()
+		edited.Message = "hello again Matrix"
+		edited.EditAt = 2000
+		edited.UpdateAt = 2000
+		th.sync(t, []*model.Post{edited}, nil)
+
+		messages := th.roomEvents(t, matrixbridge.EventTypeMessage)
+		require.Len(t, messages, 3)
+		content := messageContent(t, messages[2])
+		require.NotNil(t, content.NewContent)
+		assert.Equal(t, "hello again Matrix", content.NewContent.Body)
+		assert.Equal(t, matrixbridge.RelTypeReplace, content.RelatesTo.RelType)
+		assert.Equal(t, messages[0].EventID, content.RelatesTo.EventID)
+	})
+
+	reaction := &model.Reaction{
+		UserId:    th.User.Id,
+		PostId:    root.Id,
+		EmojiName: "+1",
+		ChannelId: th.Channel.Id,
+		CreateAt:  3000,
+		UpdateAt:  3000,
+	}
+
+	t.Run("reactions are annotations", func(t *testing.T) {
+		th.sync(t, nil, []*model.Reaction{reaction})
+
+		reactions := th.roomEvents(t, matrixbridge.EventTypeReaction)
+		require.Len(t, reactions, 1)
+		var content matrixbridge.ReactionContent
+		require.NoError(t, json.Unmarshal(reactions[0].Content, &content))
+		assert.Equal(t, matrixbridge.RelTypeAnnotation, content.RelatesTo.RelType)
+		assert.Equal(t, "\U0001F44D", content.RelatesTo.Key)
+		assert.Equal(t, th.roomEvents(t, matrixbridge.EventTypeMessage)[0].EventID, content.RelatesTo.EventID)
+	})
+
+	t.Run("removed reactions are redacted", func(t *testing.T) {
+		removed := *reaction
+		removed.DeleteAt = 4000
+		removed.UpdateAt = 4000
+		th.sync(t, nil, []*model.Reaction{&removed})
+
+		redactions := th.roomEvents(t, matrixbridge.EventTypeRedaction)
+		require.Len(t, redactions, 1)
+		assert.Equal(t, th.roomEvents(t, matrixbridge.EventTypeReaction)[0].EventID, redactions[0].Redacts)
+	})
+
+	t.Run("deleted posts are redacted", func(t *testing.T) {
+		deleted := reply.Clone()
+		deleted.DeleteAt = 5000
+		deleted.UpdateAt = 5000
+		th.sync(t, []*model.Post{deleted}, nil)
+
+		redactions := th.roomEvents(t, matrixbridge.EventTypeRedaction)
+		require.Len(t, redactions, 2)
+		assert.Equal(t, th.roomEvents(t, matrixbridge.EventTypeMessage)[1].EventID, redactions[1].Redacts)
+	})
+
+	t.Run("attachments are uploaded as media", func(t *testing.T) {
+		fi := &model.FileInfo{
+			Id:        model.NewId(),
+			Name:      "image.png",
+			Path:      "data/image.png",
+			MimeType:  "image/png",
+			Extension: "png",
+			Size:      5,
+			Width:     1,
+			Height:    1,
+		}
+		th.files[fi.Path] = []byte("image")
+		post := &model.Post{
+			Id:        model.NewId(),
+			ChannelId: th.Channel.Id,
+			UserId:    th.User.Id,
+			RootId:    root.Id,
+			FileIds:   model.StringArray{fi.Id},
+			CreateAt:  6000,
+			UpdateAt:  6000,
+		}
+		th.sync(t, []*model.Post{post}, nil)
+		require.NoError(t, th.Bridge.OnSharedChannelsAttachmentSyncMsg(fi, post, th.Remote))
+
+		messages := th.roomEvents(t, matrixbridge.EventTypeMessage)
+		require.Len(t, messages, 4)
+		content := messageContent(t, messages[3])
+		assert.Equal(t, matrixbridge.MsgTypeImage, content.MsgType)
+		assert.Equal(t, "image.png", content.Body)
+		assert.Equal(t, []byte("image"), th.HS.Media(content.URL))
+		assert.Equal(t, matrixbridge.RelTypeThread, content.RelatesTo.RelType)
+
+		// already sent attachments are skipped.
+		require.NoError(t, th.Bridge.OnSharedChannelsAttachmentSyncMsg(fi, post, th.Remote))
+		assert.Len(t, th.roomEvents(t, matrixbridge.EventTypeMessage), 4)
+	})
+
+	t.Run("profile images are set as avatars", func(t *testing.T) {
+		require.NoError(t, th.Bridge.OnSharedChannelsProfileImageSyncMsg(th.User, th.Remote))
+		avatar := th.HS.AvatarURL(puppetID)
+		require.NotEmpty(t, avatar)
+		assert.Equal(t, []byte("profile image"), th.HS.Media(avatar))
+	})
+
+	t.Run("membership changes make the puppet leave", func(t *testing.T) {
+		msg := model.NewSyncMsg(th.Channel.Id)
+		msg.MembershipChanges = []*model.MembershipChangeMsg{{
+			ChannelId:  th.Channel.Id,
+			UserId:     th.User.Id,
+			IsAdd:      false,
+			ChangeTime: 7000,
+		}}
+		_, err := th.Bridge.OnSharedChannelsSyncMsg(msg, th.Remote)
+		require.NoError(t, err)
+		assert.Equal(t, matrixbridge.MembershipLeave, th.HS.Room(th.roomID(t)).Members[puppetID])
+	})
+
+	t.Run("ping", func(t *testing.T) {
+		assert.True(t, th.Bridge.OnSharedChannelsPing(th.Remote))
+	})
+}
+
+func TestInbound(t *testing.T) {
+	th := setupTestHelper(t)
+
+	// create the room by syncing the channel.
+	th.sync(t, nil, nil)
+	roomID := th.roomID(t)
+	alice := "@alice:" + testServerName
+
+	t.Run("joining users are synced", func(t *testing.T) {
+		_, err := th.HS.Join(roomID, alice, "Alice")
+		require.NoError(t, err)
+
+		msgs := th.syncMsgs()
+		require.Len(t, msgs, 2)
+		require.Len(t, msgs[0].Users, 1)
+		for _, user := range msgs[0].Users {
+			assert.Equal(t, "alice", user.Username)
+			assert.Equal(t, "Alice", user.Nickname)
+		}
+		require.Len(t, msgs[1].MembershipChanges, 1)
+		assert.True(t, msgs[1].MembershipChanges[0].IsAdd)
+		assert.Equal(t, th.Remote.RemoteId, msgs[1].MembershipChanges[0].RemoteId)
+	})
+
+	var rootID string
+
+	t.Run("messages become posts", func(t *testing.T) {
+		_, err := th.HS.SendEvent(roomID, alice, matrixbridge.EventTypeMessage, &matrixbridge.MessageContent{
+			MsgType: matrixbridge.MsgTypeText,
+			Body:    "hello Mattermost",
+		})
+		require.NoError(t, err)
+
+		msg := th.lastSyncMsg()
+		require.Len(t, msg.Posts, 1)
+		post := msg.Posts[0]
+		assert.Equal(t, "hello Mattermost", post.Message)
+		assert.Equal(t, th.Channel.Id, post.ChannelId)
+		assert.True(t, model.IsValidId(post.Id))
+		rootID = post.Id
+	})
+
+	t.Run("thread replies keep their root", func(t *testing.T) {
+		rootEvent := th.roomEvents(t, matrixbridge.EventTypeMessage)[0].EventID
+		_, err := th.HS.SendEvent(roomID, alice, matrixbridge.EventTypeMessage, &matrixbridge.MessageContent{
+			MsgType: matrixbridge.MsgTypeText,
+			Body:    "in a thread",
+			RelatesTo: &matrixbridge.RelatesTo{
+				RelType: matrixbridge.RelTypeThread,
+				EventID: rootEvent,
+			},
+		})
+		require.NoError(t, err)
+
+		msg := th.lastSyncMsg()
+		require.Len(t, msg.Posts, 1)
+		assert.Equal(t, rootID, msg.Posts[0].RootId)
+	})
+
+	t.Run("edits update the post", func(t *testing.T) {
+		rootEvent := th.roomEvents(t, matrixbridge.EventTypeMessage)[0].EventID
+		_, err := th.HS.SendEvent(roomID, alice, matrixbridge.EventTypeMessage, &matrixbridge.MessageContent{
+			MsgType: matrixbridge.MsgTypeText,
+			Body:    "* hello again",
+			NewContent: &matrixbridge.MessageContent{
+				MsgType: matrixbridge.MsgTypeText,
+				Body:    "hello again",
+			},
+			RelatesTo: &matrixbridge.RelatesTo{
+				RelType: matrixbridge.RelTypeReplace,
+				EventID: rootEvent,
+			},
+		})
+		require.NoError(t, err)
+
+		msg := th.lastSyncMsg()
+		require.Len(t, msg.Posts, 1)
+		assert.Equal(t, rootID, msg.Posts[0].Id)
+		assert.Equal(t, "hello again", msg.Posts[0].Message)
+		assert.NotZero(t, msg.Posts[0].EditAt)
+	})
+
+	var reactionEvent string
+
+	t.Run("annotations become reactions", func(t *testing.T) {
+		rootEvent := th.roomEvents(t, matrixbridge.EventTypeMessage)[0].EventID
+		var err error
+		reactionEvent, err = th.HS.SendEvent(roomID, alice, matrixbridge.EventTypeReaction, &matrixbridge.ReactionContent{
+			RelatesTo: &matrixbridge.RelatesTo{
+				RelType: matrixbridge.RelTypeAnnotation,
+				EventID: rootEvent,
+				Key:     "\U0001F44D\ufe0f",
+			},
+		})
+		require.NoError(t, err)
+
+		msg := th.lastSyncMsg()
+		require.Len(t, msg.Reactions, 1)
+		assert.Equal(t, "+1", msg.Reactions[0].EmojiName)
+		assert.Equal(t, rootID, msg.Reactions[0].PostId)
+		assert.Zero(t, msg.Reactions[0].DeleteAt)
+	})
+
+	t.Run("redactions remove reactions and delete posts", func(t *testing.T) {
+		_, err := th.HS.Redact(roomID, alice, reactionEvent)
+		require.NoError(t, err)
+
+		msg := th.lastSyncMsg()
+		require.Len(t, msg.Reactions, 1)
+		assert.NotZero(t, msg.Reactions[0].DeleteAt)
+
+		rootEvent := th.roomEvents(t, matrixbridge.EventTypeMessage)[0].EventID
+		_, err = th.HS.Redact(roomID, alice, rootEvent)
+		require.NoError(t, err)
+
+		msg = th.lastSyncMsg()
+		require.Len(t, msg.Posts, 1)
+		assert.Equal(t, rootID, msg.Posts[0].Id)
+		assert.NotZero(t, msg.Posts[0].DeleteAt)
+	})
+
+	t.Run("files become attachments", func(t *testing.T) {
+		contentURI := th.HS.UploadMedia("text/plain", []byte("some text"))
+		_, err := th.HS.SendEvent(roomID, alice, matrixbridge.EventTypeMessage, &matrixbridge.MessageContent{
+			MsgType:  matrixbridge.MsgTypeFile,
+			Body:     "see the notes",
+			Filename: "notes.txt",
+			URL:      contentURI,
+		})
+		require.NoError(t, err)
+
+		msg := th.lastSyncMsg()
+		require.Len(t, msg.Posts, 1)
+		assert.Equal(t, "see the notes", msg.Posts[0].Message)
+		require.Len(t, msg.Posts[0].FileIds, 1)
+		require.Len(t, th.uploads, 1)
+		assert.Equal(t, th.uploads[0].Id, msg.Posts[0].FileIds[0])
+		assert.Equal(t, "notes.txt", th.uploads[0].Name)
+		assert.Equal(t, []byte("some text"), th.files[th.uploads[0].Path])
+	})
+
+	t.Run("leaving users are removed", func(t *testing.T) {
+		_, err := th.HS.Leave(roomID, alice)
+		require.NoError(t, err)
+
+		msg := th.lastSyncMsg()
+		require.Len(t, msg.MembershipChanges, 1)
+		assert.False(t, msg.MembershipChanges[0].IsAdd)
+	})
+
+	t.Run("events from puppets and duplicate transactions are ignored", func(t *testing.T) {
+		count := len(th.syncMsgs())
+
+		content, err := json.Marshal(&matrixbridge.MessageContent{MsgType: matrixbridge.MsgTypeText, Body: "echo"})
+		require.NoError(t, err)
+		txn := &matrixbridge.Transaction{Events: []*matrixbridge.Event{{
+			EventID: "$echo",
+			Type:    matrixbridge.EventTypeMessage,
+			RoomID:  roomID,
+			Sender:  th.puppetUserID(th.User.Id),
+			Content: content,
+		}}}
+		require.NoError(t, th.Bridge.HandleTransaction("echo", txn))
+		assert.Len(t, th.syncMsgs(), count)
+
+		txn.Events[0].Sender = alice
+		txn.Events[0].EventID = "$dup"
+		require.NoError(t, th.Bridge.HandleTransaction("dup", txn))
+		require.NoError(t, th.Bridge.HandleTransaction("dup", txn))
+		assert.Len(t, th.syncMsgs(), count+2)
+	})
+}
+
+func TestAppServiceAPI(t *testing.T) {
+	th := setupTestHelper(t)
+
+	request := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		th.Bridge.ServeHTTP(rec, req)
+		return rec
+	}
+
+	errCode := func(t *testing.T, rec *httptest.ResponseRecorder) string {
+		t.Helper()
+		var matrixErr matrixbridge.Error
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&matrixErr))
+		return matrixErr.ErrCode
+	}
+
+	t.Run("requests need the homeserver token", func(t *testing.T) {
+		rec := request(http.MethodPost, "/_matrix/app/v1/ping", "")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, matrixbridge.ErrCodeUnauthorized, errCode(t, rec))
+
+		rec = request(http.MethodPost, "/_matrix/app/v1/ping", testASToken)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, matrixbridge.ErrCodeForbidden, errCode(t, rec))
+
+		rec = request(http.MethodPost, "/_matrix/app/v1/ping?access_token="+testHSToken, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("user queries", func(t *testing.T) {
+		rec := request(http.MethodGet, "/_matrix/app/v1/users/"+th.puppetUserID(th.User.Id), testHSToken)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		rec = request(http.MethodGet, "/_matrix/app/v1/users/"+th.puppetUserID(model.NewId()), testHSToken)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		rec = request(http.MethodGet, "/_matrix/app/v1/users/@alice:"+testServerName, testHSToken)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("unknown endpoints", func(t *testing.T) {
+		rec := request(http.MethodGet, "/_matrix/app/v1/thirdparty/protocol/irc", testHSToken)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, matrixbridge.ErrCodeUnrecognized, errCode(t, rec))
+	})
+}
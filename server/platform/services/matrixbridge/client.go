@@ -0,0 +1,272 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package matrixbridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Matrix error codes used by the bridge.
+const (
+	ErrCodeForbidden    = "M_FORBIDDEN"
+	ErrCodeUnknownToken = "M_UNKNOWN_TOKEN"
+	ErrCodeMissingToken = "M_MISSING_TOKEN"
+	ErrCodeNotFound     = "M_NOT_FOUND"
+	ErrCodeUnrecognized = "M_UNRECOGNIZED"
+	ErrCodeUserInUse    = "M_USER_IN_USE"
+	ErrCodeRoomInUse    = "M_ROOM_IN_USE"
+	ErrCodeBadJSON      = "M_BAD_JSON"
+	ErrCodeUnknown      = "M_UNKNOWN"
+)
+
+// Client calls the Matrix client-server API of the homeserver on behalf of the
+// application service, masquerading as its users when needed.
+type Client struct {
+	homeserverURL string
+	token         string
+	httpClient    *http.Client
+}
+
+// CreateRoomRequest is the body of a room creation request.
+type CreateRoomRequest struct {
+	RoomAliasName string   `json:"room_alias_name,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	Topic         string   `json:"topic,omitempty"`
+	Preset        string   `json:"preset,omitempty"`
+	Invite        []string `json:"invite,omitempty"`
+}
+
+// NewClient creates a client for the homeserver, authenticated with the
+// application service token.
+func NewClient(homeserverURL, token string, httpClient *http.Client) *Client {
+	return &Client{
+		homeserverURL: strings.TrimSuffix(homeserverURL, "/"),
+		token:         token,
+		httpClient:    httpClient,
+	}
+}
+
+// IsErrCode checks whether err is a Matrix error with the given code.
+func IsErrCode(err error, errCode string) bool {
+	var matrixErr *Error
+	return errors.As(err, &matrixErr) && matrixErr.ErrCode == errCode
+}
+
+// WhoAmI returns the user ID the application service token belongs to.
+func (c *Client) WhoAmI(ctx context.Context) (string, error) {
+	var resp struct {
+		UserID string `json:"user_id"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/_matrix/client/v3/account/whoami", "", nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.UserID, nil
+}
+
+// Register creates a user in the application service namespace. Registering
+// a user that already exists isn't an error.
+func (c *Client) Register(ctx context.Context, localpart string) error {
+	body := map[string]string{
+		"type":     "m.login.application_service",
+		"username": localpart,
+	}
+	err := c.do(ctx, http.MethodPost, "/_matrix/client/v3/register", "", body, nil)
+	if IsErrCode(err, ErrCodeUserInUse) {
+		return nil
+	}
+	return err
+}
+
+// GetDisplayName returns the display name of a user.
+func (c *Client) GetDisplayName(ctx context.Context, userID string) (string, error) {
+	var resp struct {
+		DisplayName string `json:"displayname"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/_matrix/client/v3/profile/"+url.PathEscape(userID)+"/displayname", "", nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.DisplayName, nil
+}
+
+// SetDisplayName sets the display name of a user of the application service.
+func (c *Client) SetDisplayName(ctx context.Context, userID, displayName string) error {
+	body := map[string]string{"displayname": displayName}
+	return c.do(ctx, http.MethodPut, "/_matrix/client/v3/profile/"+url.PathEscape(userID)+"/displayname", userID, body, nil)
+}
+
+// SetAvatarURL sets the avatar of a user of the application service.
+func (c *Client) SetAvatarURL(ctx context.Context, userID, contentURI string) error {
+	body := map[string]string{"avatar_url": contentURI}
+	return c.do(ctx, http.MethodPut, "/_matrix/client/v3/profile/"+url.PathEscape(userID)+"/avatar_url", userID, body, nil)
+}
+
+// CreateRoom creates a room owned by the application service sender.
+func (c *Client) CreateRoom(ctx context.Context, req *CreateRoomRequest) (string, error) {
+	var resp struct {
+		RoomID string `json:"room_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/_matrix/client/v3/createRoom", "", req, &resp); err != nil {
+		return "", err
+	}
+	return resp.RoomID, nil
+}
+
+// ResolveAlias returns the ID of the room an alias points to.
+func (c *Client) ResolveAlias(ctx context.Context, alias string) (string, error) {
+	var resp struct {
+		RoomID string `json:"room_id"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/_matrix/client/v3/directory/room/"+url.PathEscape(alias), "", nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.RoomID, nil
+}
+
+// InviteUser invites a user to a room on behalf of the application service
+// sender.
+func (c *Client) InviteUser(ctx context.Context, roomID, userID string) error {
+	body := map[string]string{"user_id": userID}
+	return c.do(ctx, http.MethodPost, "/_matrix/client/v3/rooms/"+url.PathEscape(roomID)+"/invite", "", body, nil)
+}
+
+// JoinRoom makes a user of the application service join a room.
+func (c *Client) JoinRoom(ctx context.Context, roomID, userID string) error {
+	return c.do(ctx, http.MethodPost, "/_matrix/client/v3/rooms/"+url.PathEscape(roomID)+"/join", userID, struct{}{}, nil)
+}
+
+// LeaveRoom makes a user of the application service leave a room.
+func (c *Client) LeaveRoom(ctx context.Context, roomID, userID string) error {
+	return c.do(ctx, http.MethodPost, "/_matrix/client/v3/rooms/"+url.PathEscape(roomID)+"/leave", userID, struct{}{}, nil)
+}
+
+// SendEvent sends a room event as a user of the application service and
+// returns the ID of the event.
+func (c *Client) SendEvent(ctx context.Context, roomID, userID, eventType, txnID string, content any) (string, error) {
+	var resp struct {
+		EventID string `json:"event_id"`
+	}
+	path := "/_matrix/client/v3/rooms/" + url.PathEscape(roomID) + "/send/" + url.PathEscape(eventType) + "/" + url.PathEscape(txnID)
+	if err := c.do(ctx, http.MethodPut, path, userID, content, &resp); err != nil {
+		return "", err
+	}
+	return resp.EventID, nil
+}
+
+// Redact redacts a room event as a user of the application service.
+func (c *Client) Redact(ctx context.Context, roomID, userID, eventID, txnID string) error {
+	path := "/_matrix/client/v3/rooms/" + url.PathEscape(roomID) + "/redact/" + url.PathEscape(eventID) + "/" + url.PathEscape(txnID)
+	return c.do(ctx, http.MethodPut, path, userID, struct{}{}, nil)
+}
+
+// UploadMedia uploads a file to the media repository of the homeserver and
+// returns its mxc:// content URI.
+func (c *Client) UploadMedia(ctx context.Context, userID, contentType, filename string, data io.Reader) (string, error) {
+	query := url.Values{}
+	query.Set("filename", filename)
+	if userID != "" {
+		query.Set("user_id", userID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.homeserverURL+"/_matrix/media/v3/upload?"+query.Encode(), data)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	var resp struct {
+		ContentURI string `json:"content_uri"`
+	}
+	if err := c.send(req, &resp); err != nil {
+		return "", err
+	}
+	return resp.ContentURI, nil
+}
+
+// DownloadMedia downloads a file from the media repository given its mxc://
+// content URI. The caller must close the returned reader.
+func (c *Client) DownloadMedia(ctx context.Context, contentURI string) (io.ReadCloser, string, error) {
+	serverName, mediaID, ok := strings.Cut(strings.TrimPrefix(contentURI, "mxc://"), "/")
+	if !strings.HasPrefix(contentURI, "mxc://") || !ok || serverName == "" || mediaID == "" {
+		return nil, "", fmt.Errorf("invalid content URI %q", contentURI)
+	}
+
+	path := "/_matrix/client/v1/media/download/" + url.PathEscape(serverName) + "/" + url.PathEscape(mediaID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.homeserverURL+path, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, "", readError(resp)
+	}
+	return resp.Body, resp.Header.Get("Content-Type"), nil
+}
+
+func (c *Client) do(ctx context.Context, method, path, userID string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	if userID != "" {
+		path += "?user_id=" + url.QueryEscape(userID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.homeserverURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.send(req, result)
+}
+
+func (c *Client) send(req *http.Request, result any) error {
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return readError(resp)
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("invalid response from homeserver: %w", err)
+	}
+	return nil
+}
+
+func readError(resp *http.Response) error {
+	matrixErr := &Error{StatusCode: resp.StatusCode}
+	if err := json.NewDecoder(resp.Body).Decode(matrixErr); err != nil || matrixErr.ErrCode == "" {
+		return fmt.Errorf("unexpected response from homeserver: %s", resp.Status)
+	}
+	return matrixErr
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package matrixbridge
+
+import (
+	"encoding/json"
+)
+
+// Matrix event types handled by the bridge.
+const (
+	EventTypeMessage    = "m.room.message"
+	EventTypeReaction   = "m.reaction"
+	EventTypeRedaction  = "m.room.redaction"
+	EventTypeRoomMember = "m.room.member"
+)
+
+// Matrix message types handled by the bridge.
+const (
+	MsgTypeText   = "m.text"
+	MsgTypeNotice = "m.notice"
+	MsgTypeEmote  = "m.emote"
+	MsgTypeImage  = "m.image"
+	MsgTypeFile   = "m.file"
+	MsgTypeVideo  = "m.video"
+	MsgTypeAudio  = "m.audio"
+)
+
+// Matrix event relation types handled by the bridge.
+const (
+	RelTypeThread     = "m.thread"
+	RelTypeReplace    = "m.replace"
+	RelTypeAnnotation = "m.annotation"
+)
+
+// Matrix room membership states.
+const (
+	MembershipJoin   = "join"
+	MembershipInvite = "invite"
+	MembershipLeave  = "leave"
+	MembershipBan    = "ban"
+)
+
+// Transaction is the body of a request pushing events from the homeserver to
+// the application service.
+type Transaction struct {
+	Events []*Event `json:"events"`
+}
+
+// Event is a Matrix room event.
+type Event struct {
+	EventID        string          `json:"event_id"`
+	Type           string          `json:"type"`
+	RoomID         string          `json:"room_id"`
+	Sender         string          `json:"sender"`
+	OriginServerTS int64           `json:"origin_server_ts"`
+	StateKey       *string         `json:"state_key,omitempty"`
+	Redacts        string          `json:"redacts,omitempty"`
+	Content        json.RawMessage `json:"content"`
+}
+
+// MessageContent is the content of m.room.message events.
+type MessageContent struct {
+	MsgType    string          `json:"msgtype"`
+	Body       string          `json:"body"`
+	Filename   string          `json:"filename,omitempty"`
+	URL        string          `json:"url,omitempty"`
+	Info       *FileInfo       `json:"info,omitempty"`
+	RelatesTo  *RelatesTo      `json:"m.relates_to,omitempty"`
+	NewContent *MessageContent `json:"m.new_content,omitempty"`
+}
+
+// FileInfo describes the media referenced by file message events.
+type FileInfo struct {
+	MimeType string `json:"mimetype,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	Width    int    `json:"w,omitempty"`
+	Height   int    `json:"h,omitempty"`
+}
+
+// RelatesTo links an event to another event, for threads, edits and
+// reactions.
+type RelatesTo struct {
+	RelType       string     `json:"rel_type,omitempty"`
+	EventID       string     `json:"event_id,omitempty"`
+	Key           string     `json:"key,omitempty"`
+	IsFallingBack bool       `json:"is_falling_back,omitempty"`
+	InReplyTo     *InReplyTo `json:"m.in_reply_to,omitempty"`
+}
+
+// InReplyTo references the event a message replies to.
+type InReplyTo struct {
+	EventID string `json:"event_id"`
+}
+
+// ReactionContent is the content of m.reaction events.
+type ReactionContent struct {
+	RelatesTo *RelatesTo `json:"m.relates_to"`
+}
+
+// MemberContent is the content of m.room.member events.
+type MemberContent struct {
+	Membership  string `json:"membership"`
+	DisplayName string `json:"displayname,omitempty"`
+}
+
+// Error is the standard error body of the Matrix APIs.
+type Error struct {
+	StatusCode int    `json:"-"`
+	ErrCode    string `json:"errcode"`
+	Message    string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	return e.ErrCode + ": " + e.Message
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package matrixbridge_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/services/matrixbridge"
+	"github.com/mattermost/mattermost/server/v8/platform/services/matrixbridge/matrixtest"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	testServerName = "example.org"
+	testASToken    = "as_token"
+	testHSToken    = "hs_token"
+)
+
+type testServer struct {
+	config *model.Config
+	store  store.Store
+	logger *mlog.Logger
+}
+
+func (ts *testServer) Config() *model.Config { return ts.config }
+func (ts *testServer) GetStore() store.Store { return ts.store }
+func (ts *testServer) Log() *mlog.Logger     { return ts.logger }
+
+// testSharedChannelService records the sync messages received from the bridge and
+// keeps the posts they contain so that later events can refer to them.
+type testSharedChannelService struct {
+	th *TestHelper
+}
+
+func (scs *testSharedChannelService) ReceiveSyncMsg(syncMsg *model.SyncMsg, rc *model.RemoteCluster) (model.SyncResponse, error) {
+	scs.th.mux.Lock()
+	defer scs.th.mux.Unlock()
+
+	scs.th.received = append(scs.th.received, syncMsg)
+	for _, post := range syncMsg.Posts {
+		scs.th.posts[post.Id] = post
+	}
+	for _, user := range syncMsg.Users {
+		scs.th.users[user.Id] = user
+	}
+	return model.SyncResponse{}, nil
+}
+
+func (scs *testSharedChannelService) ReceiveUploadCreate(us *model.UploadSession, rc *model.RemoteCluster) (*model.UploadSession, error) {
+	us.RemoteId = rc.RemoteId
+	us.Path = "uploads/" + us.Id
+	return us, nil
+}
+
+type testApp struct {
+	th *TestHelper
+}
+
+type readCloseSeeker struct {
+	*bytes.Reader
+}
+
+func (readCloseSeeker) Close() error { return nil }
+
+func (app *testApp) UploadData(rctx request.CTX, us *model.UploadSession, rd io.Reader) (*model.FileInfo, *model.AppError) {
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, model.NewAppError("UploadData", "app.upload.upload_data.read_file.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	app.th.mux.Lock()
+	defer app.th.mux.Unlock()
+	fi := &model.FileInfo{
+		Id:        model.NewId(),
+		CreatorId: us.UserId,
+		ChannelId: us.ChannelId,
+		Name:      us.Filename,
+		Path:      us.Path,
+		Size:      int64(len(data)),
+		RemoteId:  model.NewPointer(us.RemoteId),
+	}
+	app.th.files[fi.Path] = data
+	app.th.uploads = append(app.th.uploads, fi)
+	return fi, nil
+}
+
+func (app *testApp) FileReader(path string) (filestore.ReadCloseSeeker, *model.AppError) {
+	app.th.mux.Lock()
+	defer app.th.mux.Unlock()
+	return readCloseSeeker{bytes.NewReader(app.th.files[path])}, nil
+}
+
+func (app *testApp) GetProfileImage(user *model.User) ([]byte, bool, *model.AppError) {
+	return []byte("profile image"), false, nil
+}
+
+type TestHelper struct {
+	HS      *matrixtest.Homeserver
+	Bridge  *matrixbridge.Bridge
+	Channel *model.Channel
+	User    *model.User
+	Remote  *model.RemoteCluster
+
+	mux      sync.Mutex
+	kv       map[string][]byte
+	users    map[string]*model.User
+	posts    map[string]*model.Post
+	files    map[string][]byte
+	received []*model.SyncMsg
+	uploads  []*model.FileInfo
+}
+
+func setupTestHelper(t *testing.T) *TestHelper {
+	th := &TestHelper{
+		HS: matrixtest.NewHomeserver(testServerName, testASToken, testHSToken, model.ConnectedWorkspacesSettingsDefaultMatrixSenderLocalpart),
+		Channel: &model.Channel{
+			Id:          model.NewId(),
+			Type:        model.ChannelTypeOpen,
+			DisplayName: "Town Square",
+			Purpose:     "General discussion",
+		},
+		User: &model.User{
+			Id:        model.NewId(),
+			Username:  "sysadmin",
+			FirstName: "Sys",
+			LastName:  "Admin",
+		},
+		Remote: &model.RemoteCluster{
+			RemoteId: model.NewId(),
+			Name:     matrixbridge.RemoteName,
+			PluginID: matrixbridge.BridgeID,
+		},
+		kv:    make(map[string][]byte),
+		users: make(map[string]*model.User),
+		posts: make(map[string]*model.Post),
+		files: make(map[string][]byte),
+	}
+	th.users[th.User.Id] = th.User
+	t.Cleanup(th.HS.Close)
+
+	config := &model.Config{}
+	config.SetDefaults()
+	config.ConnectedWorkspacesSettings.EnableMatrixBridge = model.NewPointer(true)
+	config.ConnectedWorkspacesSettings.MatrixHomeserverURL = model.NewPointer(th.HS.URL())
+	config.ConnectedWorkspacesSettings.MatrixServerName = model.NewPointer(testServerName)
+	config.ConnectedWorkspacesSettings.MatrixAppServiceToken = model.NewPointer(testASToken)
+	config.ConnectedWorkspacesSettings.MatrixHomeserverToken = model.NewPointer(testHSToken)
+
+	server := &testServer{
+		config: config,
+		store:  th.mockStore(),
+		logger: mlog.CreateConsoleTestLogger(t),
+	}
+	th.Bridge = matrixbridge.New(server, &testSharedChannelService{th: th}, &testApp{th: th}, http.DefaultClient)
+
+	as := httptest.NewServer(th.Bridge)
+	t.Cleanup(as.Close)
+	th.HS.SetAppServiceURL(as.URL)
+
+	return th
+}
+
+func (th *TestHelper) mockStore() *mocks.Store {
+	mockPluginStore := &mocks.PluginStore{}
+	mockPluginStore.On("Get", matrixbridge.BridgeID, mock.AnythingOfType("string")).Return(func(pluginID, key string) (*model.PluginKeyValue, error) {
+		th.mux.Lock()
+		defer th.mux.Unlock()
+		value, ok := th.kv[key]
+		if !ok {
+			return nil, store.NewErrNotFound("PluginKeyValue", key)
+		}
+		return &model.PluginKeyValue{PluginId: pluginID, Key: key, Value: value}, nil
+	})
+	mockPluginStore.On("SaveOrUpdate", mock.AnythingOfType("*model.PluginKeyValue")).Return(func(kv *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+		th.mux.Lock()
+		defer th.mux.Unlock()
+		th.kv[kv.Key] = kv.Value
+		return kv, nil
+	})
+	mockPluginStore.On("Delete", matrixbridge.BridgeID, mock.AnythingOfType("string")).Return(func(pluginID, key string) error {
+		th.mux.Lock()
+		defer th.mux.Unlock()
+		delete(th.kv, key)
+		return nil
+	})
+
+	mockChannelStore := &mocks.ChannelStore{}
+	mockChannelStore.On("Get", th.Channel.Id, true).Return(th.Channel, nil)
+
+	mockUserStore := &mocks.UserStore{}
+	mockUserStore.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(func(ctx context.Context, id string) (*model.User, error) {
+		th.mux.Lock()
+		defer th.mux.Unlock()
+		if user, ok := th.users[id]; ok {
+			return user, nil
+		}
+		return nil, store.NewErrNotFound("User", id)
+	})
+
+	mockPostStore := &mocks.PostStore{}
+	mockPostStore.On("GetSingle", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("bool")).Return(func(rctx request.CTX, id string, inclDeleted bool) (*model.Post, error) {
+		th.mux.Lock()
+		defer th.mux.Unlock()
+		if post, ok := th.posts[id]; ok {
+			return post.Clone(), nil
+		}
+		return nil, store.NewErrNotFound("Post", id)
+	})
+
+	mockRemoteClusterStore := &mocks.RemoteClusterStore{}
+	mockRemoteClusterStore.On("GetByPluginID", matrixbridge.BridgeID).Return(th.Remote, nil)
+
+	mockStore := &mocks.Store{}
+	mockStore.On("Plugin").Return(mockPluginStore)
+	mockStore.On("Channel").Return(mockChannelStore)
+	mockStore.On("User").Return(mockUserStore)
+	mockStore.On("Post").Return(mockPostStore)
+	mockStore.On("RemoteCluster").Return(mockRemoteClusterStore)
+	return mockStore
+}
+
+// addPost adds a post as if it was created locally.
+func (th *TestHelper) addPost(post *model.Post) {
+	th.mux.Lock()
+	defer th.mux.Unlock()
+	th.posts[post.Id] = post
+}
+
+// syncMsgs returns the sync messages received from Matrix.
+func (th *TestHelper) syncMsgs() []*model.SyncMsg {
+	th.mux.Lock()
+	defer th.mux.Unlock()
+	return append([]*model.SyncMsg(nil), th.received...)
+}
+
+// lastSyncMsg returns the last sync message received from Matrix.
+func (th *TestHelper) lastSyncMsg() *model.SyncMsg {
+	msgs := th.syncMsgs()
+	if len(msgs) == 0 {
+		return nil
+	}
+	return msgs[len(msgs)-1]
+}
+
+func (th *TestHelper) puppetUserID(userID string) string {
+	return "@" + model.ConnectedWorkspacesSettingsDefaultMatrixUserPrefix + userID + ":" + testServerName
+}
@@ -0,0 +1,547 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package matrixbridge
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// ErrCodeUnauthorized is returned to homeservers that don't provide a token.
+const ErrCodeUnauthorized = "M_UNAUTHORIZED"
+
+func (b *Bridge) newRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.Use(b.authenticate)
+
+	api := router.PathPrefix("/_matrix/app/v1").Subrouter()
+	api.HandleFunc("/transactions/{txnId}", b.handleTransaction).Methods(http.MethodPut)
+	api.HandleFunc("/users/{userId}", b.handleQueryUser).Methods(http.MethodGet)
+	api.HandleFunc("/rooms/{roomAlias}", b.handleQueryRoom).Methods(http.MethodGet)
+	api.HandleFunc("/ping", b.handlePing).Methods(http.MethodPost)
+
+	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteError(w, http.StatusNotFound, ErrCodeUnrecognized, "Unrecognized request")
+	})
+	router.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteError(w, http.StatusMethodNotAllowed, ErrCodeUnrecognized, "Unrecognized request")
+	})
+	return router
+}
+
+// authenticate checks that requests carry the homeserver token.
+func (b *Bridge) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			token = r.URL.Query().Get("access_token")
+		}
+		if token == "" {
+			WriteError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "Missing homeserver token")
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(*b.settings().MatrixHomeserverToken)) != 1 {
+			WriteError(w, http.StatusForbidden, ErrCodeForbidden, "Invalid homeserver token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WriteError writes a Matrix error response.
+func WriteError(w http.ResponseWriter, statusCode int, errCode, message string) {
+	writeJSON(w, statusCode, &Error{ErrCode: errCode, Message: message})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (b *Bridge) handleTransaction(w http.ResponseWriter, r *http.Request) {
+	var txn Transaction
+	if err := json.NewDecoder(r.Body).Decode(&txn); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeBadJSON, "Invalid transaction")
+		return
+	}
+
+	if err := b.HandleTransaction(mux.Vars(r)["txnId"], &txn); err != nil {
+		b.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error processing Matrix transaction", mlog.Err(err))
+		// the homeserver retries the transaction until it succeeds.
+		WriteError(w, http.StatusInternalServerError, ErrCodeUnknown, "Cannot process transaction")
+		return
+	}
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+// handleQueryUser reports the puppets of existing Mattermost users as
+// belonging to the application service.
+func (b *Bridge) handleQueryUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := b.puppetFromUserID(mux.Vars(r)["userId"])
+	if !ok {
+		WriteError(w, http.StatusNotFound, ErrCodeNotFound, "User not found")
+		return
+	}
+
+	user, err := b.server.GetStore().User().Get(r.Context(), userID)
+	if err != nil {
+		WriteError(w, http.StatusNotFound, ErrCodeNotFound, "User not found")
+		return
+	}
+	if err := b.ensurePuppet(r.Context(), user); err != nil {
+		WriteError(w, http.StatusInternalServerError, ErrCodeUnknown, "Cannot create user")
+		return
+	}
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+// handleQueryRoom doesn't create rooms on demand: rooms are created when a
+// channel is shared with the bridge.
+func (b *Bridge) handleQueryRoom(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, http.StatusNotFound, ErrCodeNotFound, "Room not found")
+}
+
+func (b *Bridge) handlePing(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+// roomEvents are the events of a transaction for one room.
+type roomEvents struct {
+	roomID string
+	events []*Event
+}
+
+// HandleTransaction turns the events pushed by the homeserver into sync
+// messages for the channels shared with the bridge. Transactions that were
+// already processed are ignored.
+func (b *Bridge) HandleTransaction(txnID string, txn *Transaction) error {
+	b.txnMux.Lock()
+	defer b.txnMux.Unlock()
+
+	txnKey := kvKey(keyPrefixTransaction, txnID)
+	seen, err := b.kvGet(txnKey)
+	if err != nil {
+		return err
+	}
+	if seen != nil {
+		return nil
+	}
+
+	rc, err := b.remoteCluster()
+	if err != nil {
+		return fmt.Errorf("cannot get remote for the Matrix bridge: %w", err)
+	}
+
+	var rooms []*roomEvents
+	byRoom := make(map[string]*roomEvents)
+	for _, ev := range txn.Events {
+		if b.isBridgeUser(ev.Sender) {
+			continue
+		}
+		re, ok := byRoom[ev.RoomID]
+		if !ok {
+			re = &roomEvents{roomID: ev.RoomID}
+			byRoom[ev.RoomID] = re
+			rooms = append(rooms, re)
+		}
+		re.events = append(re.events, ev)
+	}
+
+	ctx := context.Background()
+	for _, re := range rooms {
+		channelID, err := b.getChannelForRoom(re.roomID)
+		if err != nil {
+			return err
+		}
+		if channelID == "" {
+			continue
+		}
+		if err := b.receiveRoomEvents(ctx, rc, channelID, re.events); err != nil {
+			b.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error receiving Matrix events",
+				mlog.String("room_id", re.roomID),
+				mlog.String("channel_id", channelID),
+				mlog.Err(err),
+			)
+		}
+	}
+
+	return b.kvSet(txnKey, []byte{1}, model.GetMillis()+TransactionExpiry.Milliseconds())
+}
+
+// receiveRoomEvents syncs the events of a room to its channel. The senders are
+// synced first so that their files can be uploaded.
+func (b *Bridge) receiveRoomEvents(ctx context.Context, rc *model.RemoteCluster, channelID string, events []*Event) error {
+	users := make(map[string]*model.User)
+	displayNames := make(map[string]string)
+	for _, ev := range events {
+		if ev.Type == EventTypeRoomMember && ev.StateKey != nil {
+			var content MemberContent
+			if err := json.Unmarshal(ev.Content, &content); err == nil && content.Membership == MembershipJoin {
+				displayNames[*ev.StateKey] = content.DisplayName
+			}
+		}
+	}
+
+	addUser := func(matrixUserID string) {
+		if _, ok := users[matrixUserID]; ok || b.isBridgeUser(matrixUserID) {
+			return
+		}
+		users[matrixUserID] = b.userFromMatrix(ctx, matrixUserID, displayNames[matrixUserID])
+	}
+
+	for _, ev := range events {
+		switch ev.Type {
+		case EventTypeMessage, EventTypeReaction:
+			addUser(ev.Sender)
+		case EventTypeRoomMember:
+			if ev.StateKey != nil {
+				if _, ok := displayNames[*ev.StateKey]; ok {
+					addUser(*ev.StateKey)
+				}
+			}
+		}
+	}
+
+	if len(users) > 0 {
+		userMsg := model.NewSyncMsg(channelID)
+		userMsg.Users = make(map[string]*model.User, len(users))
+		for _, user := range users {
+			userMsg.Users[user.Id] = user
+		}
+		if _, err := b.scs.ReceiveSyncMsg(userMsg, rc); err != nil {
+			return err
+		}
+	}
+
+	syncMsg := model.NewSyncMsg(channelID)
+	for _, ev := range events {
+		var err error
+		switch ev.Type {
+		case EventTypeMessage:
+			var post *model.Post
+			if post, err = b.postFromEvent(ctx, rc, channelID, ev); post != nil {
+				syncMsg.Posts = append(syncMsg.Posts, post)
+			}
+		case EventTypeReaction:
+			var reaction *model.Reaction
+			if reaction, err = b.reactionFromEvent(channelID, ev); reaction != nil {
+				syncMsg.Reactions = append(syncMsg.Reactions, reaction)
+			}
+		case EventTypeRedaction:
+			err = b.redactionFromEvent(channelID, ev, syncMsg)
+		case EventTypeRoomMember:
+			if change := b.membershipFromEvent(rc, channelID, ev); change != nil {
+				syncMsg.MembershipChanges = append(syncMsg.MembershipChanges, change)
+			}
+		}
+		if err != nil {
+			b.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error converting Matrix event",
+				mlog.String("event_id", ev.EventID),
+				mlog.String("type", ev.Type),
+				mlog.Err(err),
+			)
+		}
+	}
+
+	if len(syncMsg.Posts) == 0 && len(syncMsg.Reactions) == 0 && len(syncMsg.MembershipChanges) == 0 {
+		return nil
+	}
+	_, err := b.scs.ReceiveSyncMsg(syncMsg, rc)
+	return err
+}
+
+// userFromMatrix builds the Mattermost user for a Matrix user.
+func (b *Bridge) userFromMatrix(ctx context.Context, matrixUserID, displayName string) *model.User {
+	localpart, _, _ := splitUserID(matrixUserID)
+	if displayName == "" {
+		// the display name is only a nicety; the user is synced without it.
+		displayName, _ = b.client.GetDisplayName(ctx, matrixUserID)
+	}
+
+	user := &model.User{
+		Id:       idFromMatrixID(matrixUserID),
+		Username: usernameFromLocalpart(localpart),
+		Email:    model.NewId(),
+		Nickname: displayName,
+		UpdateAt: model.GetMillis(),
+	}
+	return user
+}
+
+// postFromEvent converts a message event into a new post, or into an edit of
+// an existing one.
+func (b *Bridge) postFromEvent(ctx context.Context, rc *model.RemoteCluster, channelID string, ev *Event) (*model.Post, error) {
+	var content MessageContent
+	if err := json.Unmarshal(ev.Content, &content); err != nil {
+		return nil, err
+	}
+
+	if content.RelatesTo != nil && content.RelatesTo.RelType == RelTypeReplace {
+		return b.editFromEvent(channelID, ev, &content)
+	}
+
+	post := &model.Post{
+		Id:        idFromMatrixID(ev.EventID),
+		ChannelId: channelID,
+		UserId:    idFromMatrixID(ev.Sender),
+		CreateAt:  ev.OriginServerTS,
+		UpdateAt:  ev.OriginServerTS,
+		Message:   messageFromContent(&content),
+	}
+
+	if content.RelatesTo != nil {
+		rootID, err := b.rootFromRelation(content.RelatesTo)
+		if err != nil {
+			return nil, err
+		}
+		post.RootId = rootID
+	}
+
+	switch content.MsgType {
+	case MsgTypeImage, MsgTypeFile, MsgTypeVideo, MsgTypeAudio:
+		fi, err := b.receiveFile(ctx, rc, channelID, post.UserId, &content)
+		if err != nil {
+			return nil, err
+		}
+		post.FileIds = model.StringArray{fi.Id}
+		if content.Filename == "" || content.Filename == content.Body {
+			// the body is the name of the file rather than a caption.
+			post.Message = ""
+		}
+	}
+
+	if err := b.savePostMapping(post.Id, &postEvent{EventID: ev.EventID}); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// editFromEvent applies an m.replace event to the post of the event it
+// replaces.
+func (b *Bridge) editFromEvent(channelID string, ev *Event, content *MessageContent) (*model.Post, error) {
+	postID, err := b.getPostForEvent(content.RelatesTo.EventID)
+	if err != nil || postID == "" {
+		return nil, err
+	}
+
+	existing, err := b.server.GetStore().Post().GetSingle(b.rctx(), postID, false)
+	if err != nil {
+		return nil, err
+	}
+	if existing.ChannelId != channelID {
+		return nil, fmt.Errorf("edited post %s isn't in channel %s", postID, channelID)
+	}
+
+	newContent := content.NewContent
+	if newContent == nil {
+		newContent = content
+	}
+
+	post := existing.Clone()
+	post.Message = messageFromContent(newContent)
+	post.EditAt = ev.OriginServerTS
+	post.UpdateAt = ev.OriginServerTS
+	return post, nil
+}
+
+// rootFromRelation returns the root post of the thread an event belongs to.
+// Replies outside of threads start a thread on the post they reply to.
+func (b *Bridge) rootFromRelation(relatesTo *RelatesTo) (string, error) {
+	var eventID string
+	switch {
+	case relatesTo.RelType == RelTypeThread:
+		eventID = relatesTo.EventID
+	case relatesTo.InReplyTo != nil:
+		eventID = relatesTo.InReplyTo.EventID
+	default:
+		return "", nil
+	}
+
+	postID, err := b.getPostForEvent(eventID)
+	if err != nil || postID == "" {
+		return "", err
+	}
+
+	parent, err := b.server.GetStore().Post().GetSingle(b.rctx(), postID, false)
+	if err != nil {
+		return "", err
+	}
+	if parent.RootId != "" {
+		return parent.RootId, nil
+	}
+	return parent.Id, nil
+}
+
+// receiveFile downloads the media of a file message and uploads it as a file
+// attachment.
+func (b *Bridge) receiveFile(ctx context.Context, rc *model.RemoteCluster, channelID, userID string, content *MessageContent) (*model.FileInfo, error) {
+	body, _, err := b.client.DownloadMedia(ctx, content.URL)
+	if err != nil {
+		return nil, fmt.Errorf("cannot download Matrix media %s: %w", content.URL, err)
+	}
+	defer body.Close()
+
+	maxFileSize := *b.server.Config().FileSettings.MaxFileSize
+	data, err := io.ReadAll(io.LimitReader(body, maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxFileSize {
+		return nil, fmt.Errorf("Matrix media %s is larger than the maximum file size", content.URL)
+	}
+
+	filename := content.Filename
+	if filename == "" {
+		filename = content.Body
+	}
+
+	us, err := b.scs.ReceiveUploadCreate(&model.UploadSession{
+		Id:        model.NewId(),
+		Type:      model.UploadTypeAttachment,
+		UserId:    userID,
+		ChannelId: channelID,
+		Filename:  filename,
+		FileSize:  int64(len(data)),
+	}, rc)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, appErr := b.app.UploadData(b.rctx(), us, bytes.NewReader(data))
+	if appErr != nil {
+		return nil, appErr
+	}
+	if fi == nil {
+		return nil, fmt.Errorf("upload of Matrix media %s is incomplete", content.URL)
+	}
+	return fi, nil
+}
+
+// reactionFromEvent converts an annotation into a reaction. Keys that aren't
+// emojis known to Mattermost are ignored.
+func (b *Bridge) reactionFromEvent(channelID string, ev *Event) (*model.Reaction, error) {
+	var content ReactionContent
+	if err := json.Unmarshal(ev.Content, &content); err != nil {
+		return nil, err
+	}
+	if content.RelatesTo == nil || content.RelatesTo.RelType != RelTypeAnnotation {
+		return nil, nil
+	}
+
+	emojiName, ok := emojiFromUnicode(content.RelatesTo.Key)
+	if !ok {
+		name := strings.Trim(content.RelatesTo.Key, ":")
+		if model.IsValidEmojiName(name) != nil {
+			return nil, nil
+		}
+		emojiName = name
+	}
+
+	postID, err := b.getPostForEvent(content.RelatesTo.EventID)
+	if err != nil || postID == "" {
+		return nil, err
+	}
+
+	reaction := &model.Reaction{
+		UserId:    idFromMatrixID(ev.Sender),
+		PostId:    postID,
+		EmojiName: emojiName,
+		ChannelId: channelID,
+		CreateAt:  ev.OriginServerTS,
+		UpdateAt:  ev.OriginServerTS,
+	}
+	if err := b.saveReactionMapping(reaction, ev.EventID); err != nil {
+		return nil, err
+	}
+	return reaction, nil
+}
+
+// redactionFromEvent converts a redaction into the removal of a reaction or
+// the deletion of a post.
+func (b *Bridge) redactionFromEvent(channelID string, ev *Event, syncMsg *model.SyncMsg) error {
+	redacts := ev.Redacts
+	if redacts == "" {
+		// since room version 11 the redacted event is part of the content.
+		var content struct {
+			Redacts string `json:"redacts"`
+		}
+		if err := json.Unmarshal(ev.Content, &content); err != nil {
+			return err
+		}
+		redacts = content.Redacts
+	}
+
+	reaction, err := b.getReactionForEvent(redacts)
+	if err != nil {
+		return err
+	}
+	if reaction != nil {
+		reaction.DeleteAt = ev.OriginServerTS
+		reaction.UpdateAt = ev.OriginServerTS
+		syncMsg.Reactions = append(syncMsg.Reactions, reaction)
+		return b.deleteReactionMapping(reaction, redacts)
+	}
+
+	postID, err := b.getPostForEvent(redacts)
+	if err != nil || postID == "" {
+		return err
+	}
+	syncMsg.Posts = append(syncMsg.Posts, &model.Post{
+		Id:        postID,
+		ChannelId: channelID,
+		UserId:    idFromMatrixID(ev.Sender),
+		DeleteAt:  ev.OriginServerTS,
+		UpdateAt:  ev.OriginServerTS,
+	})
+	return nil
+}
+
+// membershipFromEvent converts Matrix users joining, leaving or being banned
+// from a room into membership changes.
+func (b *Bridge) membershipFromEvent(rc *model.RemoteCluster, channelID string, ev *Event) *model.MembershipChangeMsg {
+	if ev.StateKey == nil || b.isBridgeUser(*ev.StateKey) {
+		return nil
+	}
+
+	var content MemberContent
+	if err := json.Unmarshal(ev.Content, &content); err != nil {
+		return nil
+	}
+	var isAdd bool
+	switch content.Membership {
+	case MembershipJoin:
+		isAdd = true
+	case MembershipLeave, MembershipBan:
+		isAdd = false
+	default:
+		return nil
+	}
+
+	return &model.MembershipChangeMsg{
+		ChannelId:  channelID,
+		UserId:     idFromMatrixID(*ev.StateKey),
+		IsAdd:      isAdd,
+		RemoteId:   rc.RemoteId,
+		ChangeTime: ev.OriginServerTS,
+	}
+}
+
+func messageFromContent(content *MessageContent) string {
+	if content.MsgType == MsgTypeEmote {
+		return "*" + content.Body + "*"
+	}
+	return content.Body
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package matrixbridge
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// Prefixes of the keys mapping Mattermost and Matrix identifiers in the key
+// value store.
+const (
+	keyPrefixRoom          = "room_"     // channel ID -> room ID
+	keyPrefixChannel       = "channel_"  // room ID -> channel ID
+	keyPrefixPostEvent     = "event_"    // post ID -> postEvent
+	keyPrefixEventPost     = "post_"     // event ID -> post ID
+	keyPrefixFileEvent     = "file_"     // file ID -> event ID
+	keyPrefixReactionEvent = "reaction_" // post ID, user ID and emoji name -> event ID
+	keyPrefixEventReaction = "reactevt_" // event ID -> model.Reaction
+	keyPrefixTransaction   = "txn_"      // transaction ID, expiring
+)
+
+// idEncoding is the encoding used by model.NewId.
+var idEncoding = base32.NewEncoding("ybndrfg8ejkmcpqxot1uwisza345h769").WithPadding(base32.NoPadding)
+
+// postEvent is the Matrix event a post was sent as, along with the edit that
+// was last sent for it.
+type postEvent struct {
+	EventID string `json:"event_id"`
+	EditAt  int64  `json:"edit_at"`
+}
+
+// idFromMatrixID derives a stable Mattermost ID from a Matrix identifier so
+// that the same Matrix user or event always maps to the same Mattermost
+// entity.
+func idFromMatrixID(matrixID string) string {
+	sum := sha256.Sum256([]byte(matrixID))
+	return idEncoding.EncodeToString(sum[:16])
+}
+
+// kvKey builds a key for the key value store, hashing identifiers that would
+// exceed the maximum key length.
+func kvKey(prefix string, parts ...string) string {
+	key := prefix + strings.Join(parts, "_")
+	if utf8.RuneCountInString(key) <= model.KeyValueKeyMaxRunes {
+		return key
+	}
+	sum := sha256.Sum256([]byte(key))
+	return prefix + hex.EncodeToString(sum[:])
+}
+
+// kvGet returns the value for the key, or nil when there is none.
+func (b *Bridge) kvGet(key string) ([]byte, error) {
+	kv, err := b.server.GetStore().Plugin().Get(BridgeID, key)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return kv.Value, nil
+}
+
+func (b *Bridge) kvSet(key string, value []byte, expireAt int64) error {
+	_, err := b.server.GetStore().Plugin().SaveOrUpdate(&model.PluginKeyValue{
+		PluginId: BridgeID,
+		Key:      key,
+		Value:    value,
+		ExpireAt: expireAt,
+	})
+	return err
+}
+
+func (b *Bridge) kvDelete(key string) error {
+	return b.server.GetStore().Plugin().Delete(BridgeID, key)
+}
+
+func (b *Bridge) getRoomForChannel(channelID string) (string, error) {
+	value, err := b.kvGet(kvKey(keyPrefixRoom, channelID))
+	return string(value), err
+}
+
+func (b *Bridge) getChannelForRoom(roomID string) (string, error) {
+	value, err := b.kvGet(kvKey(keyPrefixChannel, roomID))
+	return string(value), err
+}
+
+func (b *Bridge) saveRoomMapping(channelID, roomID string) error {
+	if err := b.kvSet(kvKey(keyPrefixRoom, channelID), []byte(roomID), 0); err != nil {
+		return err
+	}
+	return b.kvSet(kvKey(keyPrefixChannel, roomID), []byte(channelID), 0)
+}
+
+func (b *Bridge) getPostEvent(postID string) (*postEvent, error) {
+	value, err := b.kvGet(kvKey(keyPrefixPostEvent, postID))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	var pe postEvent
+	if err := json.Unmarshal(value, &pe); err != nil {
+		return nil, err
+	}
+	return &pe, nil
+}
+
+func (b *Bridge) getPostForEvent(eventID string) (string, error) {
+	value, err := b.kvGet(kvKey(keyPrefixEventPost, eventID))
+	return string(value), err
+}
+
+func (b *Bridge) savePostMapping(postID string, pe *postEvent) error {
+	value, err := json.Marshal(pe)
+	if err != nil {
+		return err
+	}
+	if err := b.kvSet(kvKey(keyPrefixPostEvent, postID), value, 0); err != nil {
+		return err
+	}
+	return b.kvSet(kvKey(keyPrefixEventPost, pe.EventID), []byte(postID), 0)
+}
+
+func (b *Bridge) getFileEvent(fileID string) (string, error) {
+	value, err := b.kvGet(kvKey(keyPrefixFileEvent, fileID))
+	return string(value), err
+}
+
+func (b *Bridge) saveFileMapping(fileID, postID, eventID string) error {
+	if err := b.kvSet(kvKey(keyPrefixFileEvent, fileID), []byte(eventID), 0); err != nil {
+		return err
+	}
+	return b.kvSet(kvKey(keyPrefixEventPost, eventID), []byte(postID), 0)
+}
+
+// getEventForPost returns the event threads and reactions of the post relate
+// to: the message of the post, or its first file for posts without a message.
+func (b *Bridge) getEventForPost(post *model.Post) (string, error) {
+	pe, err := b.getPostEvent(post.Id)
+	if err != nil || pe != nil {
+		if pe != nil {
+			return pe.EventID, nil
+		}
+		return "", err
+	}
+	for _, fileID := range post.FileIds {
+		if eventID, err := b.getFileEvent(fileID); err != nil || eventID != "" {
+			return eventID, err
+		}
+	}
+	return "", nil
+}
+
+func (b *Bridge) getReactionEvent(reaction *model.Reaction) (string, error) {
+	value, err := b.kvGet(kvKey(keyPrefixReactionEvent, reaction.PostId, reaction.UserId, reaction.EmojiName))
+	return string(value), err
+}
+
+func (b *Bridge) getReactionForEvent(eventID string) (*model.Reaction, error) {
+	value, err := b.kvGet(kvKey(keyPrefixEventReaction, eventID))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	var reaction model.Reaction
+	if err := json.Unmarshal(value, &reaction); err != nil {
+		return nil, err
+	}
+	return &reaction, nil
+}
+
+func (b *Bridge) saveReactionMapping(reaction *model.Reaction, eventID string) error {
+	value, err := json.Marshal(&model.Reaction{
+		UserId:    reaction.UserId,
+		PostId:    reaction.PostId,
+		EmojiName: reaction.EmojiName,
+		ChannelId: reaction.ChannelId,
+	})
+	if err != nil {
+		return err
+	}
+	if err := b.kvSet(kvKey(keyPrefixReactionEvent, reaction.PostId, reaction.UserId, reaction.EmojiName), []byte(eventID), 0); err != nil {
+		return err
+	}
+	return b.kvSet(kvKey(keyPrefixEventReaction, eventID), value, 0)
+}
+
+func (b *Bridge) deleteReactionMapping(reaction *model.Reaction, eventID string) error {
+	if err := b.kvDelete(kvKey(keyPrefixReactionEvent, reaction.PostId, reaction.UserId, reaction.EmojiName)); err != nil {
+		return err
+	}
+	return b.kvDelete(kvKey(keyPrefixEventReaction, eventID))
+}
+
+// usernameFromLocalpart turns the localpart of a Matrix user ID into a valid
+// Mattermost username.
+func usernameFromLocalpart(localpart string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(localpart) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '_' {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('-')
+		}
+	}
+
+	username := sb.String()
+	if username == "" || username[0] < 'a' || username[0] > 'z' {
+		username = "m" + username
+	}
+	if len(username) > model.UserNameMaxLength {
+		username = username[:model.UserNameMaxLength]
+	}
+	return username
+}
+
+// splitUserID splits a Matrix user ID into its localpart and server name.
+func splitUserID(userID string) (localpart, serverName string, ok bool) {
+	if !strings.HasPrefix(userID, "@") {
+		return "", "", false
+	}
+	return strings.Cut(userID[1:], ":")
+}
+
+var (
+	emojiByUnicodeOnce sync.Once
+	emojiByUnicode     map[string]string
+)
+
+const variationSelector = "\ufe0f"
+
+// emojiToUnicode returns the unicode sequence of a system emoji, as used for
+// the keys of Matrix reactions.
+func emojiToUnicode(name string) (string, bool) {
+	code, ok := model.SystemEmojis[name]
+	if !ok {
+		return "", false
+	}
+
+	var sb strings.Builder
+	for _, point := range strings.Split(code, "-") {
+		r, err := strconv.ParseUint(point, 16, 32)
+		if err != nil {
+			return "", false
+		}
+		sb.WriteRune(rune(r))
+	}
+	return sb.String(), true
+}
+
+// emojiFromUnicode returns the name of the system emoji for a Matrix reaction
+// key. Emojis with several names map to the first name alphabetically.
+func emojiFromUnicode(key string) (string, bool) {
+	emojiByUnicodeOnce.Do(func() {
+		names := make([]string, 0, len(model.SystemEmojis))
+		for name := range model.SystemEmojis {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		emojiByUnicode = make(map[string]string, len(names))
+		for _, name := range names {
+			unicode, ok := emojiToUnicode(name)
+			if !ok {
+				continue
+			}
+			unicode = strings.ReplaceAll(unicode, variationSelector, "")
+			if _, exists := emojiByUnicode[unicode]; !exists {
+				emojiByUnicode[unicode] = name
+			}
+		}
+	})
+
+	name, ok := emojiByUnicode[strings.ReplaceAll(key, variationSelector, "")]
+	return name, ok
+}
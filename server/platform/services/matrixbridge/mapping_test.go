@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package matrixbridge
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestIDFromMatrixID(t *testing.T) {
+	id := idFromMatrixID("@alice:example.org")
+	assert.True(t, model.IsValidId(id))
+	assert.Equal(t, id, idFromMatrixID("@alice:example.org"))
+	assert.NotEqual(t, id, idFromMatrixID("@bob:example.org"))
+}
+
+func TestKVKey(t *testing.T) {
+	assert.Equal(t, "post_$event:example.org", kvKey(keyPrefixEventPost, "$event:example.org"))
+
+	long := kvKey(keyPrefixEventPost, strings.Repeat("x", model.KeyValueKeyMaxRunes))
+	assert.True(t, strings.HasPrefix(long, keyPrefixEventPost))
+	assert.LessOrEqual(t, utf8.RuneCountInString(long), model.KeyValueKeyMaxRunes)
+}
+
+func TestUsernameFromLocalpart(t *testing.T) {
+	for localpart, expected := range map[string]string{
+		"alice":                 "alice",
+		"Alice.Smith":           "alice.smith",
+		"alice=smith/ops":       "alice-smith-ops",
+		"42":                    "m42",
+		"_bot":                  "m_bot",
+		strings.Repeat("a", 80): strings.Repeat("a", model.UserNameMaxLength),
+	} {
+		username := usernameFromLocalpart(localpart)
+		assert.Equal(t, expected, username)
+		assert.True(t, model.IsValidUsername(username), username)
+	}
+}
+
+func TestSplitUserID(t *testing.T) {
+	localpart, serverName, ok := splitUserID("@alice:example.org")
+	require.True(t, ok)
+	assert.Equal(t, "alice", localpart)
+	assert.Equal(t, "example.org", serverName)
+
+	_, _, ok = splitUserID("alice:example.org")
+	assert.False(t, ok)
+}
+
+func TestEmoji(t *testing.T) {
+	unicode, ok := emojiToUnicode("+1")
+	require.True(t, ok)
+	assert.Equal(t, "\U0001F44D", unicode)
+
+	name, ok := emojiFromUnicode(unicode)
+	require.True(t, ok)
+	assert.Equal(t, "+1", name)
+
+	// variation selectors are ignored.
+	name, ok = emojiFromUnicode("❤️")
+	require.True(t, ok)
+	assert.Equal(t, "heart", name)
+
+	_, ok = emojiToUnicode("not_an_emoji")
+	assert.False(t, ok)
+	_, ok = emojiFromUnicode("not an emoji")
+	assert.False(t, ok)
+}
@@ -0,0 +1,598 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package matrixtest provides an in-memory stand-in for a Synapse-compatible
+// Matrix homeserver, implementing the parts of the client-server and
+// application service APIs used by the Matrix bridge.
+package matrixtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/mattermost/server/v8/platform/services/matrixbridge"
+)
+
+// Room is a room of the homeserver.
+type Room struct {
+	ID      string
+	Alias   string
+	Name    string
+	Topic   string
+	Public  bool
+	Members map[string]string // user ID -> membership
+	Events  []*matrixbridge.Event
+}
+
+type media struct {
+	contentType string
+	data        []byte
+}
+
+// Homeserver is a Matrix homeserver with a single application service
+// registered. Only events created through the test API are pushed to the
+// application service, the way a homeserver doesn't push events sent by the
+// application service back to it.
+type Homeserver struct {
+	ServerName string
+
+	server  *httptest.Server
+	asToken string
+	hsToken string
+	sender  string
+
+	mux          sync.Mutex
+	appService   string
+	counter      int
+	users        map[string]bool
+	displayNames map[string]string
+	avatars      map[string]string
+	rooms        map[string]*Room
+	aliases      map[string]string
+	media        map[string]*media
+	txnEvents    map[string]string // user ID and transaction ID -> event ID
+}
+
+// NewHomeserver starts a homeserver. The application service authenticates
+// with asToken and is called with hsToken; sender is the localpart of its
+// user.
+func NewHomeserver(serverName, asToken, hsToken, sender string) *Homeserver {
+	hs := &Homeserver{
+		ServerName:   serverName,
+		asToken:      asToken,
+		hsToken:      hsToken,
+		sender:       "@" + sender + ":" + serverName,
+		users:        make(map[string]bool),
+		displayNames: make(map[string]string),
+		avatars:      make(map[string]string),
+		rooms:        make(map[string]*Room),
+		aliases:      make(map[string]string),
+		media:        make(map[string]*media),
+		txnEvents:    make(map[string]string),
+	}
+	hs.users[hs.sender] = true
+
+	router := mux.NewRouter()
+	client := router.PathPrefix("/_matrix/client").Subrouter()
+	client.Use(hs.authenticate)
+	client.HandleFunc("/v3/account/whoami", hs.handleWhoAmI).Methods(http.MethodGet)
+	client.HandleFunc("/v3/register", hs.handleRegister).Methods(http.MethodPost)
+	client.HandleFunc("/v3/profile/{userId}/displayname", hs.handleGetDisplayName).Methods(http.MethodGet)
+	client.HandleFunc("/v3/profile/{userId}/{field:displayname|avatar_url}", hs.handleSetProfile).Methods(http.MethodPut)
+	client.HandleFunc("/v3/createRoom", hs.handleCreateRoom).Methods(http.MethodPost)
+	client.HandleFunc("/v3/directory/room/{alias}", hs.handleResolveAlias).Methods(http.MethodGet)
+	client.HandleFunc("/v3/rooms/{roomId}/invite", hs.handleInvite).Methods(http.MethodPost)
+	client.HandleFunc("/v3/rooms/{roomId}/{membership:join|leave}", hs.handleMembership).Methods(http.MethodPost)
+	client.HandleFunc("/v3/rooms/{roomId}/send/{eventType}/{txnId}", hs.handleSend).Methods(http.MethodPut)
+	client.HandleFunc("/v3/rooms/{roomId}/redact/{eventId}/{txnId}", hs.handleRedact).Methods(http.MethodPut)
+	client.HandleFunc("/v1/media/download/{serverName}/{mediaId}", hs.handleDownload).Methods(http.MethodGet)
+	mediaRouter := router.PathPrefix("/_matrix/media").Subrouter()
+	mediaRouter.Use(hs.authenticate)
+	mediaRouter.HandleFunc("/v3/upload", hs.handleUpload).Methods(http.MethodPost)
+	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, matrixbridge.ErrCodeUnrecognized, "Unrecognized request")
+	})
+
+	hs.server = httptest.NewServer(router)
+	return hs
+}
+
+// URL is the base URL of the homeserver.
+func (hs *Homeserver) URL() string {
+	return hs.server.URL
+}
+
+// Close shuts down the homeserver.
+func (hs *Homeserver) Close() {
+	hs.server.Close()
+}
+
+// SetAppServiceURL sets the URL the application service API is served at.
+func (hs *Homeserver) SetAppServiceURL(url string) {
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	hs.appService = url
+}
+
+// Room returns a copy of a room, or nil when it doesn't exist.
+func (hs *Homeserver) Room(roomID string) *Room {
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+
+	room, ok := hs.rooms[roomID]
+	if !ok {
+		return nil
+	}
+	roomCopy := *room
+	roomCopy.Members = make(map[string]string, len(room.Members))
+	for userID, membership := range room.Members {
+		roomCopy.Members[userID] = membership
+	}
+	roomCopy.Events = append([]*matrixbridge.Event(nil), room.Events...)
+	return &roomCopy
+}
+
+// RoomIDForAlias returns the ID of the room an alias points to.
+func (hs *Homeserver) RoomIDForAlias(alias string) string {
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	return hs.aliases[alias]
+}
+
+// DisplayName returns the display name of a user.
+func (hs *Homeserver) DisplayName(userID string) string {
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	return hs.displayNames[userID]
+}
+
+// AvatarURL returns the avatar of a user.
+func (hs *Homeserver) AvatarURL(userID string) string {
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	return hs.avatars[userID]
+}
+
+// Media returns the content of an mxc:// URI.
+func (hs *Homeserver) Media(contentURI string) []byte {
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	if m, ok := hs.media[contentURI]; ok {
+		return m.data
+	}
+	return nil
+}
+
+// UploadMedia stores media as if uploaded by a Matrix user.
+func (hs *Homeserver) UploadMedia(contentType string, data []byte) string {
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	return hs.storeMedia(contentType, data)
+}
+
+// Join makes a Matrix user join a room and pushes the membership event.
+func (hs *Homeserver) Join(roomID, userID, displayName string) (string, error) {
+	return hs.setMembership(roomID, userID, matrixbridge.MembershipJoin, displayName)
+}
+
+// Leave makes a Matrix user leave a room and pushes the membership event.
+func (hs *Homeserver) Leave(roomID, userID string) (string, error) {
+	return hs.setMembership(roomID, userID, matrixbridge.MembershipLeave, "")
+}
+
+func (hs *Homeserver) setMembership(roomID, userID, membership, displayName string) (string, error) {
+	hs.mux.Lock()
+	room, ok := hs.rooms[roomID]
+	if !ok {
+		hs.mux.Unlock()
+		return "", fmt.Errorf("room %s not found", roomID)
+	}
+	hs.users[userID] = true
+	if displayName != "" {
+		hs.displayNames[userID] = displayName
+	}
+	room.Members[userID] = membership
+	ev := hs.newEvent(room, userID, matrixbridge.EventTypeRoomMember, &matrixbridge.MemberContent{
+		Membership:  membership,
+		DisplayName: displayName,
+	})
+	ev.StateKey = &userID
+	hs.mux.Unlock()
+
+	return ev.EventID, hs.push(ev)
+}
+
+// SendEvent sends an event as a Matrix user and pushes it to the application
+// service.
+func (hs *Homeserver) SendEvent(roomID, userID, eventType string, content any) (string, error) {
+	hs.mux.Lock()
+	room, ok := hs.rooms[roomID]
+	if !ok {
+		hs.mux.Unlock()
+		return "", fmt.Errorf("room %s not found", roomID)
+	}
+	if room.Members[userID] != matrixbridge.MembershipJoin {
+		hs.mux.Unlock()
+		return "", fmt.Errorf("user %s isn't in room %s", userID, roomID)
+	}
+	ev := hs.newEvent(room, userID, eventType, content)
+	hs.mux.Unlock()
+
+	return ev.EventID, hs.push(ev)
+}
+
+// Redact redacts an event as a Matrix user and pushes the redaction to the
+// application service.
+func (hs *Homeserver) Redact(roomID, userID, eventID string) (string, error) {
+	hs.mux.Lock()
+	room, ok := hs.rooms[roomID]
+	if !ok {
+		hs.mux.Unlock()
+		return "", fmt.Errorf("room %s not found", roomID)
+	}
+	ev := hs.newEvent(room, userID, matrixbridge.EventTypeRedaction, struct{}{})
+	ev.Redacts = eventID
+	hs.mux.Unlock()
+
+	return ev.EventID, hs.push(ev)
+}
+
+// newEvent adds an event to a room. The caller must hold the lock.
+func (hs *Homeserver) newEvent(room *Room, sender, eventType string, content any) *matrixbridge.Event {
+	hs.counter++
+	raw, _ := json.Marshal(content)
+	ev := &matrixbridge.Event{
+		EventID:        "$event" + strconv.Itoa(hs.counter),
+		Type:           eventType,
+		RoomID:         room.ID,
+		Sender:         sender,
+		OriginServerTS: int64(1700000000000 + hs.counter),
+		Content:        raw,
+	}
+	room.Events = append(room.Events, ev)
+	return ev
+}
+
+// push delivers an event to the application service in a transaction of its
+// own.
+func (hs *Homeserver) push(ev *matrixbridge.Event) error {
+	hs.mux.Lock()
+	appService := hs.appService
+	hs.counter++
+	txnID := strconv.Itoa(hs.counter)
+	hs.mux.Unlock()
+
+	if appService == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(&matrixbridge.Transaction{Events: []*matrixbridge.Event{ev}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPut, appService+"/_matrix/app/v1/transactions/"+txnID, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+hs.hsToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("application service returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (hs *Homeserver) storeMedia(contentType string, data []byte) string {
+	hs.counter++
+	contentURI := "mxc://" + hs.ServerName + "/media" + strconv.Itoa(hs.counter)
+	hs.media[contentURI] = &media{contentType: contentType, data: data}
+	return contentURI
+}
+
+// authenticate checks the application service token and resolves the user
+// the request is made for.
+func (hs *Homeserver) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+hs.asToken {
+			writeError(w, http.StatusUnauthorized, matrixbridge.ErrCodeUnknownToken, "Unknown token")
+			return
+		}
+		if userID := r.URL.Query().Get("user_id"); userID != "" {
+			hs.mux.Lock()
+			registered := hs.users[userID]
+			hs.mux.Unlock()
+			if !registered {
+				writeError(w, http.StatusForbidden, matrixbridge.ErrCodeForbidden, "Application service has not registered this user")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (hs *Homeserver) requestUser(r *http.Request) string {
+	if userID := r.URL.Query().Get("user_id"); userID != "" {
+		return userID
+	}
+	return hs.sender
+}
+
+func (hs *Homeserver) handleWhoAmI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]string{"user_id": hs.requestUser(r)})
+}
+
+func (hs *Homeserver) handleRegister(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Type     string `json:"type"`
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Type != "m.login.application_service" {
+		writeError(w, http.StatusBadRequest, matrixbridge.ErrCodeBadJSON, "Invalid registration")
+		return
+	}
+
+	userID := "@" + req.Username + ":" + hs.ServerName
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	if hs.users[userID] {
+		writeError(w, http.StatusBadRequest, matrixbridge.ErrCodeUserInUse, "User ID already taken")
+		return
+	}
+	hs.users[userID] = true
+	writeJSON(w, map[string]string{"user_id": userID})
+}
+
+func (hs *Homeserver) handleGetDisplayName(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["userId"]
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	if !hs.users[userID] {
+		writeError(w, http.StatusNotFound, matrixbridge.ErrCodeNotFound, "Profile not found")
+		return
+	}
+	writeJSON(w, map[string]string{"displayname": hs.displayNames[userID]})
+}
+
+func (hs *Homeserver) handleSetProfile(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if vars["userId"] != hs.requestUser(r) {
+		writeError(w, http.StatusForbidden, matrixbridge.ErrCodeForbidden, "Cannot set the profile of another user")
+		return
+	}
+
+	var req map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, matrixbridge.ErrCodeBadJSON, "Invalid profile")
+		return
+	}
+
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	if vars["field"] == "displayname" {
+		hs.displayNames[vars["userId"]] = req["displayname"]
+	} else {
+		hs.avatars[vars["userId"]] = req["avatar_url"]
+	}
+	writeJSON(w, struct{}{})
+}
+
+func (hs *Homeserver) handleCreateRoom(w http.ResponseWriter, r *http.Request) {
+	var req matrixbridge.CreateRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, matrixbridge.ErrCodeBadJSON, "Invalid room")
+		return
+	}
+
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+
+	alias := ""
+	if req.RoomAliasName != "" {
+		alias = "#" + req.RoomAliasName + ":" + hs.ServerName
+		if _, ok := hs.aliases[alias]; ok {
+			writeError(w, http.StatusBadRequest, matrixbridge.ErrCodeRoomInUse, "Room alias already taken")
+			return
+		}
+	}
+
+	hs.counter++
+	creator := hs.requestUser(r)
+	room := &Room{
+		ID:      "!room" + strconv.Itoa(hs.counter) + ":" + hs.ServerName,
+		Alias:   alias,
+		Name:    req.Name,
+		Topic:   req.Topic,
+		Public:  req.Preset == "public_chat",
+		Members: map[string]string{creator: matrixbridge.MembershipJoin},
+	}
+	for _, userID := range req.Invite {
+		room.Members[userID] = matrixbridge.MembershipInvite
+	}
+	hs.rooms[room.ID] = room
+	if alias != "" {
+		hs.aliases[alias] = room.ID
+	}
+	writeJSON(w, map[string]string{"room_id": room.ID})
+}
+
+func (hs *Homeserver) handleResolveAlias(w http.ResponseWriter, r *http.Request) {
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	roomID, ok := hs.aliases[mux.Vars(r)["alias"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, matrixbridge.ErrCodeNotFound, "Room alias not found")
+		return
+	}
+	writeJSON(w, map[string]string{"room_id": roomID})
+}
+
+func (hs *Homeserver) handleInvite(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID string `json:"user_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, matrixbridge.ErrCodeBadJSON, "Invalid invite")
+		return
+	}
+
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	room, ok := hs.rooms[mux.Vars(r)["roomId"]]
+	if !ok || room.Members[hs.requestUser(r)] != matrixbridge.MembershipJoin {
+		writeError(w, http.StatusForbidden, matrixbridge.ErrCodeForbidden, "Not in room")
+		return
+	}
+	if room.Members[req.UserID] == matrixbridge.MembershipJoin {
+		writeError(w, http.StatusForbidden, matrixbridge.ErrCodeForbidden, "User is already in the room")
+		return
+	}
+	room.Members[req.UserID] = matrixbridge.MembershipInvite
+	writeJSON(w, struct{}{})
+}
+
+func (hs *Homeserver) handleMembership(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := hs.requestUser(r)
+
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	room, ok := hs.rooms[vars["roomId"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, matrixbridge.ErrCodeNotFound, "Room not found")
+		return
+	}
+
+	if vars["membership"] == matrixbridge.MembershipJoin {
+		if !room.Public && room.Members[userID] != matrixbridge.MembershipInvite && room.Members[userID] != matrixbridge.MembershipJoin {
+			writeError(w, http.StatusForbidden, matrixbridge.ErrCodeForbidden, "You are not invited to this room")
+			return
+		}
+		room.Members[userID] = matrixbridge.MembershipJoin
+		writeJSON(w, map[string]string{"room_id": room.ID})
+		return
+	}
+
+	if room.Members[userID] != matrixbridge.MembershipJoin {
+		writeError(w, http.StatusForbidden, matrixbridge.ErrCodeForbidden, "You are not in this room")
+		return
+	}
+	room.Members[userID] = matrixbridge.MembershipLeave
+	writeJSON(w, struct{}{})
+}
+
+func (hs *Homeserver) handleSend(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := hs.requestUser(r)
+
+	content, err := io.ReadAll(r.Body)
+	if err != nil || !json.Valid(content) {
+		writeError(w, http.StatusBadRequest, matrixbridge.ErrCodeBadJSON, "Invalid content")
+		return
+	}
+
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	room, ok := hs.rooms[vars["roomId"]]
+	if !ok || room.Members[userID] != matrixbridge.MembershipJoin {
+		writeError(w, http.StatusForbidden, matrixbridge.ErrCodeForbidden, "Not in room")
+		return
+	}
+
+	txnKey := userID + "|" + vars["txnId"]
+	if eventID, ok := hs.txnEvents[txnKey]; ok {
+		writeJSON(w, map[string]string{"event_id": eventID})
+		return
+	}
+
+	ev := hs.newEvent(room, userID, vars["eventType"], json.RawMessage(content))
+	hs.txnEvents[txnKey] = ev.EventID
+	writeJSON(w, map[string]string{"event_id": ev.EventID})
+}
+
+func (hs *Homeserver) handleRedact(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := hs.requestUser(r)
+
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	room, ok := hs.rooms[vars["roomId"]]
+	if !ok || room.Members[userID] != matrixbridge.MembershipJoin {
+		writeError(w, http.StatusForbidden, matrixbridge.ErrCodeForbidden, "Not in room")
+		return
+	}
+
+	found := false
+	for _, ev := range room.Events {
+		if ev.EventID == vars["eventId"] {
+			found = true
+			break
+		}
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, matrixbridge.ErrCodeNotFound, "Event not found")
+		return
+	}
+
+	txnKey := userID + "|" + vars["txnId"]
+	if eventID, ok := hs.txnEvents[txnKey]; ok {
+		writeJSON(w, map[string]string{"event_id": eventID})
+		return
+	}
+
+	ev := hs.newEvent(room, userID, matrixbridge.EventTypeRedaction, struct{}{})
+	ev.Redacts = vars["eventId"]
+	hs.txnEvents[txnKey] = ev.EventID
+	writeJSON(w, map[string]string{"event_id": ev.EventID})
+}
+
+func (hs *Homeserver) handleUpload(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, matrixbridge.ErrCodeBadJSON, "Invalid upload")
+		return
+	}
+
+	hs.mux.Lock()
+	defer hs.mux.Unlock()
+	writeJSON(w, map[string]string{"content_uri": hs.storeMedia(r.Header.Get("Content-Type"), data)})
+}
+
+func (hs *Homeserver) handleDownload(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	contentURI := "mxc://" + vars["serverName"] + "/" + vars["mediaId"]
+
+	hs.mux.Lock()
+	m, ok := hs.media[contentURI]
+	hs.mux.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, matrixbridge.ErrCodeNotFound, "Media not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", m.contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(m.data)))
+	_, _ = w.Write(m.data)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, errCode, message string) {
+	matrixbridge.WriteError(w, statusCode, errCode, message)
+}
@@ -0,0 +1,496 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package matrixbridge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// OnSharedChannelsSyncMsg posts the content of a sync message to the Matrix
+// room of the channel, creating the room the first time the channel is synced.
+func (b *Bridge) OnSharedChannelsSyncMsg(msg *model.SyncMsg, rc *model.RemoteCluster) (model.SyncResponse, error) {
+	syncResp := model.SyncResponse{
+		UserErrors:            make([]string, 0),
+		UsersSyncd:            make([]string, 0),
+		PostErrors:            make([]string, 0),
+		ReactionErrors:        make([]string, 0),
+		AcknowledgementErrors: make([]string, 0),
+	}
+	ctx := context.Background()
+
+	for _, user := range msg.Users {
+		if user.GetRemoteID() == rc.RemoteId {
+			continue
+		}
+		if err := b.ensurePuppet(ctx, user); err != nil {
+			b.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error syncing user to Matrix",
+				mlog.String("user_id", user.Id),
+				mlog.Err(err),
+			)
+			syncResp.UserErrors = append(syncResp.UserErrors, user.Id)
+			continue
+		}
+		syncResp.UsersSyncd = append(syncResp.UsersSyncd, user.Id)
+		if syncResp.UsersLastUpdateAt < user.UpdateAt {
+			syncResp.UsersLastUpdateAt = user.UpdateAt
+		}
+	}
+
+	// global user syncs only carry users.
+	if msg.ChannelId == "" {
+		return syncResp, nil
+	}
+
+	roomID, err := b.ensureRoom(ctx, msg.ChannelId)
+	if err != nil {
+		return syncResp, fmt.Errorf("cannot get Matrix room for channel %s: %w", msg.ChannelId, err)
+	}
+
+	for _, post := range msg.Posts {
+		if err := b.sendPost(ctx, roomID, post); err != nil {
+			b.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error sending post to Matrix",
+				mlog.String("post_id", post.Id),
+				mlog.String("channel_id", post.ChannelId),
+				mlog.Err(err),
+			)
+			syncResp.PostErrors = append(syncResp.PostErrors, post.Id)
+			continue
+		}
+		if syncResp.PostsLastUpdateAt < post.UpdateAt {
+			syncResp.PostsLastUpdateAt = post.UpdateAt
+		}
+	}
+
+	for _, reaction := range msg.Reactions {
+		if err := b.sendReaction(ctx, roomID, reaction); err != nil {
+			b.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error sending reaction to Matrix",
+				mlog.String("post_id", reaction.PostId),
+				mlog.String("user_id", reaction.UserId),
+				mlog.String("emoji", reaction.EmojiName),
+				mlog.Err(err),
+			)
+			syncResp.ReactionErrors = append(syncResp.ReactionErrors, reaction.PostId)
+			continue
+		}
+		if syncResp.ReactionsLastUpdateAt < reaction.UpdateAt {
+			syncResp.ReactionsLastUpdateAt = reaction.UpdateAt
+		}
+	}
+
+	for _, change := range msg.MembershipChanges {
+		if change.RemoteId == rc.RemoteId {
+			continue
+		}
+		if err := b.syncMembership(ctx, roomID, change); err != nil {
+			b.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error syncing membership to Matrix",
+				mlog.String("channel_id", change.ChannelId),
+				mlog.String("user_id", change.UserId),
+				mlog.Bool("is_add", change.IsAdd),
+				mlog.Err(err),
+			)
+		}
+	}
+
+	// acknowledgements and statuses have no Matrix equivalent.
+	return syncResp, nil
+}
+
+// OnSharedChannelsAttachmentSyncMsg uploads a file attachment to the media
+// repository of the homeserver and posts it to the room of the channel.
+func (b *Bridge) OnSharedChannelsAttachmentSyncMsg(fi *model.FileInfo, post *model.Post, rc *model.RemoteCluster) error {
+	eventID, err := b.getFileEvent(fi.Id)
+	if err != nil || eventID != "" {
+		return err
+	}
+
+	ctx := context.Background()
+	roomID, err := b.ensureRoom(ctx, post.ChannelId)
+	if err != nil {
+		return err
+	}
+
+	puppetID, err := b.ensureJoined(ctx, roomID, post.UserId)
+	if err != nil {
+		return err
+	}
+
+	reader, appErr := b.app.FileReader(fi.Path)
+	if appErr != nil {
+		return appErr
+	}
+	defer reader.Close()
+
+	contentURI, err := b.client.UploadMedia(ctx, puppetID, fi.MimeType, fi.Name, reader)
+	if err != nil {
+		return fmt.Errorf("cannot upload file %s to Matrix: %w", fi.Id, err)
+	}
+
+	content := &MessageContent{
+		MsgType: msgTypeForFile(fi),
+		Body:    fi.Name,
+		URL:     contentURI,
+		Info: &FileInfo{
+			MimeType: fi.MimeType,
+			Size:     fi.Size,
+			Width:    fi.Width,
+			Height:   fi.Height,
+		},
+	}
+	if content.RelatesTo, err = b.threadRelation(post); err != nil {
+		return err
+	}
+
+	eventID, err = b.client.SendEvent(ctx, roomID, puppetID, EventTypeMessage, "mmfile_"+fi.Id, content)
+	if err != nil {
+		return err
+	}
+	return b.saveFileMapping(fi.Id, post.Id, eventID)
+}
+
+// OnSharedChannelsProfileImageSyncMsg sets the profile image of a user as the
+// avatar of its puppet.
+func (b *Bridge) OnSharedChannelsProfileImageSyncMsg(user *model.User, rc *model.RemoteCluster) error {
+	data, _, appErr := b.app.GetProfileImage(user)
+	if appErr != nil {
+		return appErr
+	}
+
+	ctx := context.Background()
+	if err := b.ensurePuppet(ctx, user); err != nil {
+		return err
+	}
+
+	puppetID := b.puppetUserID(user.Id)
+	contentURI, err := b.client.UploadMedia(ctx, puppetID, "image/png", "profile.png", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("cannot upload profile image of user %s to Matrix: %w", user.Id, err)
+	}
+	return b.client.SetAvatarURL(ctx, puppetID, contentURI)
+}
+
+// ensureRoom returns the room of a channel, creating it when needed.
+func (b *Bridge) ensureRoom(ctx context.Context, channelID string) (string, error) {
+	roomID, err := b.getRoomForChannel(channelID)
+	if err != nil || roomID != "" {
+		return roomID, err
+	}
+
+	channel, err := b.server.GetStore().Channel().Get(channelID, true)
+	if err != nil {
+		return "", err
+	}
+
+	settings := b.settings()
+	aliasName := *settings.MatrixUserPrefix + channel.Id
+	preset := "private_chat"
+	if channel.Type == model.ChannelTypeOpen {
+		preset = "public_chat"
+	}
+	topic := channel.Purpose
+	if topic == "" {
+		topic = channel.Header
+	}
+
+	roomID, err = b.client.CreateRoom(ctx, &CreateRoomRequest{
+		RoomAliasName: aliasName,
+		Name:          channel.DisplayName,
+		Topic:         topic,
+		Preset:        preset,
+	})
+	if IsErrCode(err, ErrCodeRoomInUse) {
+		// the room was created before its mapping could be saved.
+		roomID, err = b.client.ResolveAlias(ctx, "#"+aliasName+":"+*settings.MatrixServerName)
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot create Matrix room: %w", err)
+	}
+
+	if err := b.saveRoomMapping(channelID, roomID); err != nil {
+		return "", err
+	}
+	return roomID, nil
+}
+
+// ensurePuppet registers the puppet of a user and keeps its display name in
+// sync.
+func (b *Bridge) ensurePuppet(ctx context.Context, user *model.User) error {
+	displayName := user.GetDisplayName(model.ShowNicknameFullName)
+
+	b.mux.Lock()
+	current, ok := b.puppets[user.Id]
+	b.mux.Unlock()
+	if ok && current == displayName {
+		return nil
+	}
+
+	puppetID := b.puppetUserID(user.Id)
+	if !ok {
+		if err := b.client.Register(ctx, *b.settings().MatrixUserPrefix+user.Id); err != nil {
+			return err
+		}
+	}
+	if err := b.client.SetDisplayName(ctx, puppetID, displayName); err != nil {
+		return err
+	}
+
+	b.mux.Lock()
+	b.puppets[user.Id] = displayName
+	b.mux.Unlock()
+	return nil
+}
+
+// ensureJoined makes the puppet of a user join a room and returns its Matrix
+// user ID.
+func (b *Bridge) ensureJoined(ctx context.Context, roomID, userID string) (string, error) {
+	puppetID := b.puppetUserID(userID)
+	key := roomID + "|" + puppetID
+
+	b.mux.Lock()
+	joined := b.roomUsers[key]
+	_, registered := b.puppets[userID]
+	b.mux.Unlock()
+	if joined {
+		return puppetID, nil
+	}
+
+	if !registered {
+		user, err := b.server.GetStore().User().Get(ctx, userID)
+		if err != nil {
+			return "", err
+		}
+		if err := b.ensurePuppet(ctx, user); err != nil {
+			return "", err
+		}
+	}
+
+	err := b.client.JoinRoom(ctx, roomID, puppetID)
+	if IsErrCode(err, ErrCodeForbidden) {
+		// private rooms need an invite from the application service sender.
+		if err = b.client.InviteUser(ctx, roomID, puppetID); err == nil {
+			err = b.client.JoinRoom(ctx, roomID, puppetID)
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot join Matrix room %s: %w", roomID, err)
+	}
+
+	b.mux.Lock()
+	b.roomUsers[key] = true
+	b.mux.Unlock()
+	return puppetID, nil
+}
+
+// sendPost sends a new post, an edit or a deletion to Matrix.
+func (b *Bridge) sendPost(ctx context.Context, roomID string, post *model.Post) error {
+	pe, err := b.getPostEvent(post.Id)
+	if err != nil {
+		return err
+	}
+
+	if post.DeleteAt > 0 {
+		return b.redactPost(ctx, roomID, post, pe)
+	}
+
+	if post.IsSystemMessage() {
+		return nil
+	}
+
+	if pe != nil {
+		if post.EditAt <= pe.EditAt {
+			return nil
+		}
+		puppetID, err := b.ensureJoined(ctx, roomID, post.UserId)
+		if err != nil {
+			return err
+		}
+		content := &MessageContent{
+			MsgType: MsgTypeText,
+			Body:    "* " + post.Message,
+			NewContent: &MessageContent{
+				MsgType: MsgTypeText,
+				Body:    post.Message,
+			},
+			RelatesTo: &RelatesTo{
+				RelType: RelTypeReplace,
+				EventID: pe.EventID,
+			},
+		}
+		txnID := "mmedit_" + post.Id + "_" + strconv.FormatInt(post.EditAt, 10)
+		if _, err := b.client.SendEvent(ctx, roomID, puppetID, EventTypeMessage, txnID, content); err != nil {
+			return err
+		}
+		pe.EditAt = post.EditAt
+		return b.savePostMapping(post.Id, pe)
+	}
+
+	// posts with only file attachments are sent along with the attachments.
+	if post.Message == "" {
+		return nil
+	}
+
+	puppetID, err := b.ensureJoined(ctx, roomID, post.UserId)
+	if err != nil {
+		return err
+	}
+	content := &MessageContent{
+		MsgType: MsgTypeText,
+		Body:    post.Message,
+	}
+	if content.RelatesTo, err = b.threadRelation(post); err != nil {
+		return err
+	}
+
+	eventID, err := b.client.SendEvent(ctx, roomID, puppetID, EventTypeMessage, "mmpost_"+post.Id, content)
+	if err != nil {
+		return err
+	}
+	return b.savePostMapping(post.Id, &postEvent{EventID: eventID, EditAt: post.EditAt})
+}
+
+// redactPost redacts the events of a deleted post.
+func (b *Bridge) redactPost(ctx context.Context, roomID string, post *model.Post, pe *postEvent) error {
+	eventIDs := make([]string, 0, len(post.FileIds)+1)
+	if pe != nil {
+		eventIDs = append(eventIDs, pe.EventID)
+	}
+	for _, fileID := range post.FileIds {
+		eventID, err := b.getFileEvent(fileID)
+		if err != nil {
+			return err
+		}
+		if eventID != "" {
+			eventIDs = append(eventIDs, eventID)
+		}
+	}
+
+	var merr []error
+	for _, eventID := range eventIDs {
+		if err := b.client.Redact(ctx, roomID, b.senderUserID(), eventID, "mmredact_"+eventID); err != nil && !IsErrCode(err, ErrCodeNotFound) {
+			merr = append(merr, err)
+		}
+	}
+	return errors.Join(merr...)
+}
+
+// threadRelation returns the relation placing the event of a reply in the
+// thread of its root post.
+func (b *Bridge) threadRelation(post *model.Post) (*RelatesTo, error) {
+	if post.RootId == "" {
+		return nil, nil
+	}
+
+	root, err := b.server.GetStore().Post().GetSingle(b.rctx(), post.RootId, true)
+	if err != nil {
+		return nil, err
+	}
+	rootEventID, err := b.getEventForPost(root)
+	if err != nil || rootEventID == "" {
+		// the root post isn't bridged; send the reply as a regular message.
+		return nil, err
+	}
+
+	return &RelatesTo{
+		RelType:       RelTypeThread,
+		EventID:       rootEventID,
+		IsFallingBack: true,
+		InReplyTo:     &InReplyTo{EventID: rootEventID},
+	}, nil
+}
+
+// sendReaction sends a reaction, or the redaction of a removed reaction, to
+// Matrix.
+func (b *Bridge) sendReaction(ctx context.Context, roomID string, reaction *model.Reaction) error {
+	eventID, err := b.getReactionEvent(reaction)
+	if err != nil {
+		return err
+	}
+
+	if reaction.DeleteAt > 0 {
+		if eventID == "" {
+			return nil
+		}
+		if err := b.client.Redact(ctx, roomID, b.puppetUserID(reaction.UserId), eventID, "mmredact_"+eventID); err != nil && !IsErrCode(err, ErrCodeNotFound) {
+			return err
+		}
+		return b.deleteReactionMapping(reaction, eventID)
+	}
+
+	if eventID != "" {
+		return nil
+	}
+
+	post, err := b.server.GetStore().Post().GetSingle(b.rctx(), reaction.PostId, true)
+	if err != nil {
+		return err
+	}
+	targetID, err := b.getEventForPost(post)
+	if err != nil {
+		return err
+	}
+	if targetID == "" {
+		return fmt.Errorf("post %s isn't bridged", reaction.PostId)
+	}
+
+	puppetID, err := b.ensureJoined(ctx, roomID, reaction.UserId)
+	if err != nil {
+		return err
+	}
+
+	key, ok := emojiToUnicode(reaction.EmojiName)
+	if !ok {
+		key = ":" + reaction.EmojiName + ":"
+	}
+	content := &ReactionContent{
+		RelatesTo: &RelatesTo{
+			RelType: RelTypeAnnotation,
+			EventID: targetID,
+			Key:     key,
+		},
+	}
+	txnID := "mmreaction_" + strconv.FormatInt(reaction.CreateAt, 10) + "_" + idFromMatrixID(reaction.PostId+reaction.UserId+reaction.EmojiName)
+	eventID, err = b.client.SendEvent(ctx, roomID, puppetID, EventTypeReaction, txnID, content)
+	if err != nil {
+		return err
+	}
+	return b.saveReactionMapping(reaction, eventID)
+}
+
+// syncMembership makes the puppet of a user join or leave a room.
+func (b *Bridge) syncMembership(ctx context.Context, roomID string, change *model.MembershipChangeMsg) error {
+	if change.IsAdd {
+		_, err := b.ensureJoined(ctx, roomID, change.UserId)
+		return err
+	}
+
+	puppetID := b.puppetUserID(change.UserId)
+	if err := b.client.LeaveRoom(ctx, roomID, puppetID); err != nil && !IsErrCode(err, ErrCodeForbidden) {
+		return err
+	}
+
+	b.mux.Lock()
+	delete(b.roomUsers, roomID+"|"+puppetID)
+	b.mux.Unlock()
+	return nil
+}
+
+func msgTypeForFile(fi *model.FileInfo) string {
+	switch {
+	case fi.IsImage():
+		return MsgTypeImage
+	case strings.HasPrefix(fi.MimeType, "video/"):
+		return MsgTypeVideo
+	case strings.HasPrefix(fi.MimeType, "audio/"):
+		return MsgTypeAudio
+	default:
+		return MsgTypeFile
+	}
+}
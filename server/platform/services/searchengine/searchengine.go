@@ -4,6 +4,8 @@
 package searchengine
 
 import (
+	"sync"
+
 	"github.com/mattermost/mattermost/server/public/model"
 )
 
@@ -17,9 +19,34 @@ func (seb *Broker) RegisterElasticsearchEngine(es SearchEngineInterface) {
 	seb.ElasticsearchEngine = es
 }
 
+// RegisterPluginEngine registers the search engine implemented by a plugin, replacing the one
+// previously registered. It takes precedence over the other engines while it is active.
+func (seb *Broker) RegisterPluginEngine(engine SearchEngineInterface) {
+	seb.pluginEngineLock.Lock()
+	defer seb.pluginEngineLock.Unlock()
+	seb.pluginEngine = engine
+}
+
+// UnregisterPluginEngine unregisters the search engine implemented by a plugin.
+func (seb *Broker) UnregisterPluginEngine() {
+	seb.pluginEngineLock.Lock()
+	defer seb.pluginEngineLock.Unlock()
+	seb.pluginEngine = nil
+}
+
+// PluginEngine returns the search engine implemented by a plugin, or nil if none is registered.
+func (seb *Broker) PluginEngine() SearchEngineInterface {
+	seb.pluginEngineLock.RLock()
+	defer seb.pluginEngineLock.RUnlock()
+	return seb.pluginEngine
+}
+
 type Broker struct {
 	cfg                 *model.Config
 	ElasticsearchEngine SearchEngineInterface
+
+	pluginEngineLock sync.RWMutex
+	pluginEngine     SearchEngineInterface
 }
 
 func (seb *Broker) UpdateConfig(cfg *model.Config) *model.AppError {
@@ -27,12 +54,18 @@ func (seb *Broker) UpdateConfig(cfg *model.Config) *model.AppError {
 	if seb.ElasticsearchEngine != nil {
 		seb.ElasticsearchEngine.UpdateConfig(cfg)
 	}
+	if pluginEngine := seb.PluginEngine(); pluginEngine != nil {
+		pluginEngine.UpdateConfig(cfg)
+	}
 
 	return nil
 }
 
 func (seb *Broker) GetActiveEngines() []SearchEngineInterface {
 	engines := []SearchEngineInterface{}
+	if pluginEngine := seb.PluginEngine(); pluginEngine != nil && pluginEngine.IsActive() {
+		engines = append(engines, pluginEngine)
+	}
 	if seb.ElasticsearchEngine != nil && seb.ElasticsearchEngine.IsActive() {
 		engines = append(engines, seb.ElasticsearchEngine)
 	}
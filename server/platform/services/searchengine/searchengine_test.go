@@ -31,3 +31,43 @@ func TestActiveEngine(t *testing.T) {
 
 	assert.Equal(t, "none", b.ActiveEngine())
 }
+
+func TestPluginEngine(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+
+	b := NewBroker(cfg)
+
+	esMock := &mocks.SearchEngineInterface{}
+	esMock.On("IsActive").Return(true)
+	esMock.On("GetName").Return("elasticsearch")
+	b.ElasticsearchEngine = esMock
+
+	pluginMock := &mocks.SearchEngineInterface{}
+	pluginMock.On("GetName").Return("plugin")
+	b.RegisterPluginEngine(pluginMock)
+	assert.Equal(t, pluginMock, b.PluginEngine())
+
+	t.Run("the active plugin engine takes precedence", func(t *testing.T) {
+		call := pluginMock.On("IsActive").Return(true)
+		defer call.Unset()
+
+		assert.Equal(t, []SearchEngineInterface{pluginMock, esMock}, b.GetActiveEngines())
+		assert.Equal(t, "plugin", b.ActiveEngine())
+	})
+
+	t.Run("an inactive plugin engine is skipped", func(t *testing.T) {
+		call := pluginMock.On("IsActive").Return(false)
+		defer call.Unset()
+
+		assert.Equal(t, []SearchEngineInterface{esMock}, b.GetActiveEngines())
+		assert.Equal(t, "elasticsearch", b.ActiveEngine())
+	})
+
+	t.Run("an unregistered plugin engine is skipped", func(t *testing.T) {
+		b.UnregisterPluginEngine()
+
+		assert.Nil(t, b.PluginEngine())
+		assert.Equal(t, []SearchEngineInterface{esMock}, b.GetActiveEngines())
+	})
+}
@@ -168,18 +168,30 @@ func (scs *Service) onReceiveUploadCreate(msg model.RemoteClusterMsg, rc *model.
 		return fmt.Errorf("invalid upload session request: %w", err)
 	}
 
+	usSaved, err := scs.ReceiveUploadCreate(&us, rc)
+	if err != nil {
+		return err
+	}
+
+	response.SetPayload(usSaved)
+	return nil
+}
+
+// ReceiveUploadCreate validates and creates an upload session requested by a remote, for a
+// file attachment in a channel shared with the remote.
+func (scs *Service) ReceiveUploadCreate(us *model.UploadSession, rc *model.RemoteCluster) (*model.UploadSession, error) {
 	// make sure channel is shared for the remote sender
 	hasRemote, err := scs.server.GetStore().SharedChannel().HasRemote(us.ChannelId, rc.RemoteId)
 	if err != nil {
-		return fmt.Errorf("could not validate upload session for remote: %w", err)
+		return nil, fmt.Errorf("could not validate upload session for remote: %w", err)
 	}
 	if !hasRemote {
-		return model.NewAppError("createUpload", "api.upload.create.upload_channel_not_shared_with_remote.app_error", nil, "", http.StatusBadRequest)
+		return nil, model.NewAppError("createUpload", "api.upload.create.upload_channel_not_shared_with_remote.app_error", nil, "", http.StatusBadRequest)
 	}
 
 	// make sure file attachments are enabled
 	if scs.server.Config().FileSettings.EnableFileAttachments == nil || !*scs.server.Config().FileSettings.EnableFileAttachments {
-		return model.NewAppError("ReceiveUploadCreate",
+		return nil, model.NewAppError("ReceiveUploadCreate",
 			"api.file.attachments.disabled.app_error",
 			nil, "", http.StatusNotImplemented)
 	}
@@ -187,24 +199,22 @@ func (scs *Service) onReceiveUploadCreate(msg model.RemoteClusterMsg, rc *model.
 	// make sure the file size requested does not exceed local server config - MM server's regular
 	// upload code will ensure the actual bytes sent are within the upload session limit.
 	if scs.server.Config().FileSettings.MaxFileSize == nil || us.FileSize > *scs.server.Config().FileSettings.MaxFileSize {
-		return model.NewAppError("createUpload", "api.upload.create.upload_too_large.app_error",
+		return nil, model.NewAppError("createUpload", "api.upload.create.upload_too_large.app_error",
 			map[string]any{"channelId": us.ChannelId}, "", http.StatusRequestEntityTooLarge)
 	}
 
 	// validate upload type for shared channels - only allow attachments
 	if us.Type != model.UploadTypeAttachment {
-		return model.NewAppError("onReceiveUploadCreate", "api.upload.invalid_type_for_shared_channel.app_error",
+		return nil, model.NewAppError("onReceiveUploadCreate", "api.upload.invalid_type_for_shared_channel.app_error",
 			nil, "", http.StatusBadRequest)
 	}
 
 	us.RemoteId = rc.RemoteId // don't let remotes try to impersonate each other
 
 	// create upload session.
-	usSaved, appErr := scs.app.CreateUploadSession(request.EmptyContext(scs.server.Log()), &us)
+	usSaved, appErr := scs.app.CreateUploadSession(request.EmptyContext(scs.server.Log()), us)
 	if appErr != nil {
-		return appErr
+		return nil, appErr
 	}
-
-	response.SetPayload(usSaved)
-	return nil
+	return usSaved, nil
 }
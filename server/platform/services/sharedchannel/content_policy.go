@@ -0,0 +1,505 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+var (
+	ErrHeldPostNotPending = errors.New("held post has already been reviewed")
+	ErrHeldPostDeleted    = errors.New("held post has been deleted")
+)
+
+// contentFilter applies a remote cluster's content policy to outgoing posts and attachments.
+type contentFilter struct {
+	policy    *model.SharedChannelContentPolicy
+	patterns  []*regexp.Regexp
+	siteLinks *regexp.Regexp
+}
+
+// getContentFilter returns the content filter for a remote cluster, or nil if the remote
+// has no content policy.
+func (scs *Service) getContentFilter(remoteID string) (*contentFilter, error) {
+	policy, err := scs.server.GetStore().SharedChannel().GetContentPolicy(remoteID)
+	if isNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch content policy for remote %s: %w", remoteID, err)
+	}
+
+	cf := &contentFilter{policy: policy}
+	for _, pattern := range policy.RedactPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern in content policy for remote %s: %w", remoteID, err)
+		}
+		cf.patterns = append(cf.patterns, re)
+	}
+
+	if policy.StripPermalinks && scs.siteURL != nil && scs.siteURL.Host != "" {
+		cf.siteLinks = regexp.MustCompile(`(?i)https?://` + regexp.QuoteMeta(scs.siteURL.Host) + `(?:[/?#][^\s<>()\[\]"']*)?`)
+	}
+	return cf, nil
+}
+
+// filterCounts tallies what a content filter redacted and stripped across the texts of a post.
+type filterCounts struct {
+	redacted []int // matches of each redact pattern
+	stripped int
+}
+
+// filterMessage redacts pattern matches and strips internal links from a message. It returns
+// the filtered message and a description of what was redacted and stripped, if anything.
+// The description never includes the filtered content.
+func (cf *contentFilter) filterMessage(message string) (string, string, string) {
+	counts := cf.newFilterCounts()
+	message = cf.filterText(message, counts)
+	redacted, stripped := cf.describe(counts)
+	return message, redacted, stripped
+}
+
+// filterPost filters the message of a post along with the text and fields of its message
+// attachments. It returns a description of what was redacted and stripped, like filterMessage.
+func (cf *contentFilter) filterPost(p *model.Post) (string, string) {
+	counts := cf.newFilterCounts()
+	p.Message = cf.filterText(p.Message, counts)
+
+	if attachments := p.Attachments(); len(attachments) > 0 {
+		// the attachments may be shared with other copies of the post, so filter copies of them.
+		filtered := make([]*model.SlackAttachment, 0, len(attachments))
+		for _, attachment := range attachments {
+			copied := *attachment
+			copied.Fallback = cf.filterText(copied.Fallback, counts)
+			copied.Pretext = cf.filterText(copied.Pretext, counts)
+			copied.AuthorName = cf.filterText(copied.AuthorName, counts)
+			copied.Title = cf.filterText(copied.Title, counts)
+			copied.Text = cf.filterText(copied.Text, counts)
+			copied.Footer = cf.filterText(copied.Footer, counts)
+
+			copied.Fields = make([]*model.SlackAttachmentField, 0, len(attachment.Fields))
+			for _, field := range attachment.Fields {
+				if field == nil {
+					continue
+				}
+				copiedField := *field
+				copiedField.Title = cf.filterText(copiedField.Title, counts)
+				if value, ok := copiedField.Value.(string); ok {
+					copiedField.Value = cf.filterText(value, counts)
+				}
+				copied.Fields = append(copied.Fields, &copiedField)
+			}
+			filtered = append(filtered, &copied)
+		}
+		p.AddProp(model.PostPropsAttachments, filtered)
+	}
+
+	return cf.describe(counts)
+}
+
+// filterFileName redacts pattern matches from the name of a file. It returns the filtered name
+// and a description of what was redacted, if anything.
+func (cf *contentFilter) filterFileName(name string) (string, string) {
+	counts := cf.newFilterCounts()
+	name = cf.redact(name, counts)
+	redacted, _ := cf.describe(counts)
+	return name, redacted
+}
+
+func (cf *contentFilter) newFilterCounts() *filterCounts {
+	return &filterCounts{redacted: make([]int, len(cf.patterns))}
+}
+
+func (cf *contentFilter) redact(text string, counts *filterCounts) string {
+	for i, re := range cf.patterns {
+		text = re.ReplaceAllStringFunc(text, func(string) string {
+			counts.redacted[i]++
+			return cf.policy.RedactReplacement
+		})
+	}
+	return text
+}
+
+func (cf *contentFilter) filterText(text string, counts *filterCounts) string {
+	if text == "" {
+		return text
+	}
+
+	text = cf.redact(text, counts)
+	if !cf.policy.StripPermalinks {
+		return text
+	}
+
+	strip := func(link string) string {
+		// same channel permalinks were rewritten by processPermalinkToRemote and resolve on the remote.
+		if strings.Contains(link, "/"+permalinkMarker+"/") {
+			return link
+		}
+		counts.stripped++
+		return cf.policy.RedactReplacement
+	}
+
+	// permalinks left untouched by processPermalinkToRemote point to posts the remote cannot see.
+	text = permaLinkRegex.ReplaceAllStringFunc(text, strip)
+	if cf.siteLinks != nil {
+		text = cf.siteLinks.ReplaceAllStringFunc(text, strip)
+	}
+	return text
+}
+
+// describe summarizes the counts, without including the filtered content.
+func (cf *contentFilter) describe(counts *filterCounts) (string, string) {
+	var redacted []string
+	for i, count := range counts.redacted {
+		if count > 0 {
+			redacted = append(redacted, fmt.Sprintf("%s x%d", cf.patterns[i].String(), count))
+		}
+	}
+
+	var links string
+	if counts.stripped > 0 {
+		links = fmt.Sprintf("%d link(s)", counts.stripped)
+	}
+	return strings.Join(redacted, "; "), links
+}
+
+// fileBlockedReason returns why a file may not be sent to the remote, or an empty string if
+// it may be sent.
+func (cf *contentFilter) fileBlockedReason(fi *model.FileInfo) string {
+	if cf.policy.IsFileTypeBlocked(fi.Extension, fi.MimeType) {
+		return fmt.Sprintf("file type %s (%s) is blocked", fi.Extension, fi.MimeType)
+	}
+	if cf.policy.MaxFileSize > 0 && fi.Size > cf.policy.MaxFileSize {
+		return fmt.Sprintf("file size %d exceeds limit of %d", fi.Size, cf.policy.MaxFileSize)
+	}
+	return ""
+}
+
+// applyContentPolicy applies the remote's content policy to a post about to be synchronized.
+// Returns false if the post is being held for approval and must not be sent.
+func (scs *Service) applyContentPolicy(sd *syncData, p *model.Post) bool {
+	if sd.filter == nil {
+		return true
+	}
+
+	if sd.filter.policy.RequireApproval && !scs.isPostApproved(sd, p) {
+		sd.heldPostIDs[p.Id] = true
+		return false
+	}
+
+	redacted, stripped := sd.filter.filterPost(p)
+
+	if redacted != "" {
+		scs.logContentFiltered(&model.SharedChannelFilterLogEntry{
+			RemoteId:  sd.rc.RemoteId,
+			ChannelId: p.ChannelId,
+			PostId:    p.Id,
+			UserId:    p.UserId,
+			Action:    model.SharedChannelFilterActionRedact,
+			Details:   redacted,
+		})
+	}
+	if stripped != "" {
+		scs.logContentFiltered(&model.SharedChannelFilterLogEntry{
+			RemoteId:  sd.rc.RemoteId,
+			ChannelId: p.ChannelId,
+			PostId:    p.Id,
+			UserId:    p.UserId,
+			Action:    model.SharedChannelFilterActionStripLink,
+			Details:   stripped,
+		})
+	}
+	return true
+}
+
+// isPostApproved returns true if a moderator has approved the post for the remote. Posts seen
+// for the first time, and posts edited after approval, are held for review.
+func (scs *Service) isPostApproved(sd *syncData, p *model.Post) bool {
+	held, err := scs.server.GetStore().SharedChannel().GetHeldPost(p.Id, sd.rc.RemoteId)
+	if err != nil && !isNotFoundError(err) {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Cannot fetch held post; not syncing post",
+			mlog.String("post_id", p.Id),
+			mlog.String("remote_id", sd.rc.RemoteId),
+			mlog.Err(err),
+		)
+		return false
+	}
+
+	if held == nil {
+		// a deleted post that was never held predates the policy, so the remote has a copy.
+		if p.DeleteAt > 0 {
+			return true
+		}
+		scs.holdPost(sd, p, &model.SharedChannelHeldPost{
+			PostId:    p.Id,
+			RemoteId:  sd.rc.RemoteId,
+			ChannelId: p.ChannelId,
+			UserId:    p.UserId,
+		}, "new post")
+		return false
+	}
+
+	if held.Status != model.SharedChannelHeldPostStatusApproved {
+		return false
+	}
+
+	if p.DeleteAt == 0 && p.EditAt > held.ReviewedAt {
+		held.Status = model.SharedChannelHeldPostStatusPending
+		held.ReviewerId = ""
+		held.ReviewedAt = 0
+		scs.holdPost(sd, p, held, "edited after approval")
+		return false
+	}
+	return true
+}
+
+func (scs *Service) holdPost(sd *syncData, p *model.Post, held *model.SharedChannelHeldPost, reason string) {
+	if _, err := scs.server.GetStore().SharedChannel().SaveHeldPost(held); err != nil {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Cannot save held post",
+			mlog.String("post_id", p.Id),
+			mlog.String("remote_id", sd.rc.RemoteId),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	scs.logContentFiltered(&model.SharedChannelFilterLogEntry{
+		RemoteId:  sd.rc.RemoteId,
+		ChannelId: p.ChannelId,
+		PostId:    p.Id,
+		UserId:    p.UserId,
+		Action:    model.SharedChannelFilterActionHold,
+		Details:   reason,
+	})
+
+	if p.UserId != "" {
+		T := scs.getUserTranslations(p.UserId)
+		scs.sendEphemeralPost(p.ChannelId, p.UserId, T("sharedchannel.content_policy.post_held", map[string]any{"Remote": sd.rc.DisplayName}))
+	}
+}
+
+// filterHeldContentForSync removes reactions and acknowledgements for posts held in this sync.
+func filterHeldContentForSync(sd *syncData) {
+	if len(sd.heldPostIDs) == 0 {
+		return
+	}
+
+	reactions := make([]*model.Reaction, 0, len(sd.reactions))
+	for _, r := range sd.reactions {
+		if !sd.heldPostIDs[r.PostId] {
+			reactions = append(reactions, r)
+		}
+	}
+	sd.reactions = reactions
+
+	acknowledgements := make([]*model.PostAcknowledgement, 0, len(sd.acknowledgements))
+	for _, a := range sd.acknowledgements {
+		if !sd.heldPostIDs[a.PostId] {
+			acknowledgements = append(acknowledgements, a)
+		}
+	}
+	sd.acknowledgements = acknowledgements
+}
+
+// logContentFiltered records an action taken by a content policy.
+func (scs *Service) logContentFiltered(entry *model.SharedChannelFilterLogEntry) {
+	if _, err := scs.server.GetStore().SharedChannel().SaveFilterLogEntry(entry); err != nil {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Cannot save shared channel filter log entry",
+			mlog.String("remote_id", entry.RemoteId),
+			mlog.String("action", entry.Action),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "Content policy applied",
+		mlog.String("remote_id", entry.RemoteId),
+		mlog.String("channel_id", entry.ChannelId),
+		mlog.String("post_id", entry.PostId),
+		mlog.String("file_id", entry.FileId),
+		mlog.String("action", entry.Action),
+	)
+}
+
+// ReviewHeldPost records a moderator's decision on a post held by a content policy. Approved
+// posts are synchronized to the remote immediately.
+func (scs *Service) ReviewHeldPost(postID, remoteID, reviewerID string, approve bool) (*model.SharedChannelHeldPost, error) {
+	held, err := scs.server.GetStore().SharedChannel().GetHeldPost(postID, remoteID)
+	if err != nil {
+		return nil, err
+	}
+	if held.Status != model.SharedChannelHeldPostStatusPending {
+		return nil, ErrHeldPostNotPending
+	}
+
+	post, err := scs.server.GetStore().Post().GetSingle(request.EmptyContext(scs.server.Log()), postID, true)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch held post %s: %w", postID, err)
+	}
+	if approve && post.DeleteAt > 0 {
+		return nil, ErrHeldPostDeleted
+	}
+
+	action := model.SharedChannelFilterActionReject
+	held.Status = model.SharedChannelHeldPostStatusRejected
+	if approve {
+		action = model.SharedChannelFilterActionApprove
+		held.Status = model.SharedChannelHeldPostStatusApproved
+	}
+	held.ReviewerId = reviewerID
+	held.ReviewedAt = model.GetMillis()
+
+	if held, err = scs.server.GetStore().SharedChannel().SaveHeldPost(held); err != nil {
+		return nil, fmt.Errorf("cannot save held post %s: %w", postID, err)
+	}
+
+	scs.logContentFiltered(&model.SharedChannelFilterLogEntry{
+		RemoteId:  remoteID,
+		ChannelId: held.ChannelId,
+		PostId:    postID,
+		UserId:    reviewerID,
+		Action:    action,
+	})
+
+	if approve {
+		if err := scs.syncApprovedPost(post, remoteID); err != nil {
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Cannot sync approved post",
+				mlog.String("post_id", postID),
+				mlog.String("remote_id", remoteID),
+				mlog.Err(err),
+			)
+		}
+	}
+	return held, nil
+}
+
+// syncApprovedPost sends a newly approved post, along with its reactions, acknowledgements
+// and attachments, to the remote.
+func (scs *Service) syncApprovedPost(post *model.Post, remoteID string) error {
+	rc, err := scs.server.GetStore().RemoteCluster().Get(remoteID, false)
+	if err != nil {
+		return fmt.Errorf("cannot fetch remote cluster %s: %w", remoteID, err)
+	}
+	if !rc.IsOnline() {
+		return fmt.Errorf("remote cluster %s is offline", rc.DisplayName)
+	}
+
+	scr, err := scs.server.GetStore().SharedChannel().GetRemoteByIds(post.ChannelId, remoteID)
+	if err != nil {
+		return fmt.Errorf("cannot fetch shared channel remote for channel %s: %w", post.ChannelId, err)
+	}
+
+	sd := newSyncData(newSyncTask(post.ChannelId, "", remoteID, nil, nil), rc, scr)
+	// the regular sync owns the cursor.
+	sd.resultNextCursor = model.GetPostsSinceForSyncCursor{}
+
+	if sd.filter, err = scs.getContentFilter(remoteID); err != nil {
+		return err
+	}
+
+	if sd.reactions, err = scs.server.GetStore().Reaction().GetForPostSince(post.Id, 0, remoteID, false); err != nil {
+		return fmt.Errorf("cannot fetch reactions for post %s: %w", post.Id, err)
+	}
+	if sd.acknowledgements, err = scs.server.GetStore().PostAcknowledgement().GetForPostSince(post.Id, 0, remoteID, false); err != nil {
+		return fmt.Errorf("cannot fetch acknowledgements for post %s: %w", post.Id, err)
+	}
+
+	sd.posts = []*model.Post{post}
+	if err := scs.fetchPostUsersForSync(sd); err != nil {
+		return fmt.Errorf("cannot fetch post users for sync: %w", err)
+	}
+
+	post.Message = scs.processPermalinkToRemote(post)
+	if !scs.applyContentPolicy(sd, post) {
+		return nil
+	}
+
+	if err := scs.fetchPostAttachmentsForSync(sd); err != nil {
+		return fmt.Errorf("cannot fetch post attachments for sync: %w", err)
+	}
+	return scs.sendSyncData(sd)
+}
+
+// isAttachmentBlocked returns true if the remote's content policy forbids sending the file. Blocked
+// files are also removed from the post so the remote does not reference them.
+func (scs *Service) isAttachmentBlocked(sd *syncData, fi *model.FileInfo, post *model.Post) bool {
+	if sd.filter == nil {
+		return false
+	}
+
+	reason := sd.filter.fileBlockedReason(fi)
+	if reason == "" {
+		return false
+	}
+
+	post.FileIds = post.FileIds.Remove(fi.Id)
+	if post.Metadata != nil {
+		files := make([]*model.FileInfo, 0, len(post.Metadata.Files))
+		for _, f := range post.Metadata.Files {
+			if f.Id != fi.Id {
+				files = append(files, f)
+			}
+		}
+		post.Metadata.Files = files
+	}
+
+	scs.logContentFiltered(&model.SharedChannelFilterLogEntry{
+		RemoteId:  sd.rc.RemoteId,
+		ChannelId: post.ChannelId,
+		PostId:    post.Id,
+		FileId:    fi.Id,
+		UserId:    post.UserId,
+		Action:    model.SharedChannelFilterActionBlockFile,
+		Details:   fi.Name + ": " + reason,
+	})
+	return true
+}
+
+// filterAttachmentName applies the remote's redact patterns to the name of a file about to be
+// synchronized, along with its copy in the post metadata. The file info may come from the cache,
+// so a filtered copy of it is returned.
+func (scs *Service) filterAttachmentName(sd *syncData, fi *model.FileInfo, post *model.Post) *model.FileInfo {
+	if sd.filter == nil {
+		return fi
+	}
+
+	name, redacted := sd.filter.filterFileName(fi.Name)
+	if redacted == "" {
+		return fi
+	}
+
+	filtered := *fi
+	filtered.Name = name
+	if post.Metadata != nil {
+		files := make([]*model.FileInfo, 0, len(post.Metadata.Files))
+		for _, f := range post.Metadata.Files {
+			if f.Id == fi.Id {
+				copied := *f
+				copied.Name = name
+				f = &copied
+			}
+			files = append(files, f)
+		}
+		post.Metadata.Files = files
+	}
+
+	scs.logContentFiltered(&model.SharedChannelFilterLogEntry{
+		RemoteId:  sd.rc.RemoteId,
+		ChannelId: post.ChannelId,
+		PostId:    post.Id,
+		FileId:    fi.Id,
+		UserId:    post.UserId,
+		Action:    model.SharedChannelFilterActionRedact,
+		Details:   redacted,
+	})
+	return &filtered
+}
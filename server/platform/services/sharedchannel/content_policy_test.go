@@ -0,0 +1,311 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest/mock"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+)
+
+type contentPolicyTestHelper struct {
+	scs    *Service
+	remote *model.RemoteCluster
+
+	mux  sync.Mutex
+	held map[string]*model.SharedChannelHeldPost
+	log  []*model.SharedChannelFilterLogEntry
+}
+
+func setupContentPolicyTest(t *testing.T, policy *model.SharedChannelContentPolicy) *contentPolicyTestHelper {
+	utils.TranslationsPreInit()
+
+	siteURL, err := url.Parse("https://chat.example.com")
+	require.NoError(t, err)
+
+	th := &contentPolicyTestHelper{
+		scs: &Service{
+			server:  &MockServerIface{},
+			app:     &MockAppIface{},
+			siteURL: siteURL,
+		},
+		remote: &model.RemoteCluster{
+			RemoteId:    policy.RemoteId,
+			DisplayName: "Partner",
+		},
+		held: make(map[string]*model.SharedChannelHeldPost),
+	}
+
+	mockSharedChannelStore := &mocks.SharedChannelStore{}
+	mockSharedChannelStore.On("GetContentPolicy", policy.RemoteId).Return(policy, nil)
+	mockSharedChannelStore.On("GetHeldPost", mock.AnythingOfType("string"), policy.RemoteId).Return(func(postID, remoteID string) (*model.SharedChannelHeldPost, error) {
+		th.mux.Lock()
+		defer th.mux.Unlock()
+		if held, ok := th.held[postID]; ok {
+			copied := *held
+			return &copied, nil
+		}
+		return nil, store.NewErrNotFound("SharedChannelHeldPost", postID)
+	})
+	mockSharedChannelStore.On("SaveHeldPost", mock.AnythingOfType("*model.SharedChannelHeldPost")).Return(func(held *model.SharedChannelHeldPost) (*model.SharedChannelHeldPost, error) {
+		th.mux.Lock()
+		defer th.mux.Unlock()
+		held.PreSave()
+		copied := *held
+		th.held[held.PostId] = &copied
+		return held, nil
+	})
+	mockSharedChannelStore.On("SaveFilterLogEntry", mock.AnythingOfType("*model.SharedChannelFilterLogEntry")).Return(func(entry *model.SharedChannelFilterLogEntry) (*model.SharedChannelFilterLogEntry, error) {
+		th.mux.Lock()
+		defer th.mux.Unlock()
+		entry.PreSave()
+		th.log = append(th.log, entry)
+		return entry, nil
+	})
+
+	mockUserStore := &mocks.UserStore{}
+	mockUserStore.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(nil, store.NewErrNotFound("User", ""))
+
+	mockStore := &mocks.Store{}
+	mockStore.On("SharedChannel").Return(mockSharedChannelStore)
+	mockStore.On("User").Return(mockUserStore)
+
+	mockServer := th.scs.server.(*MockServerIface)
+	mockServer.On("GetStore").Return(mockStore)
+	mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+
+	mockApp := th.scs.app.(*MockAppIface)
+	mockApp.On("SendEphemeralPost", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("*model.Post")).Return(&model.Post{})
+
+	return th
+}
+
+func (th *contentPolicyTestHelper) newSyncData(t *testing.T) *syncData {
+	sd := newSyncData(newSyncTask(model.NewId(), "", th.remote.RemoteId, nil, nil), th.remote, &model.SharedChannelRemote{})
+	filter, err := th.scs.getContentFilter(th.remote.RemoteId)
+	require.NoError(t, err)
+	sd.filter = filter
+	return sd
+}
+
+func (th *contentPolicyTestHelper) actions() []string {
+	th.mux.Lock()
+	defer th.mux.Unlock()
+	var actions []string
+	for _, entry := range th.log {
+		actions = append(actions, entry.Action)
+	}
+	return actions
+}
+
+func TestContentFilterFilterMessage(t *testing.T) {
+	th := setupContentPolicyTest(t, &model.SharedChannelContentPolicy{
+		RemoteId:          model.NewId(),
+		RedactPatterns:    model.StringArray{`TICKET-\d+`, `[a-z0-9-]+\.corp\.internal`},
+		RedactReplacement: "***",
+		StripPermalinks:   true,
+	})
+	sd := th.newSyncData(t)
+
+	message, redacted, stripped := sd.filter.filterMessage("See TICKET-42 and TICKET-43 on build-01.corp.internal")
+	assert.Equal(t, "See *** and *** on ***", message)
+	assert.Equal(t, `TICKET-\d+ x2; [a-z0-9-]+\.corp\.internal x1`, redacted)
+	assert.Empty(t, stripped)
+
+	message, _, stripped = sd.filter.filterMessage("links https://chat.example.com/team/plshared/abc and https://chat.example.com/team/pl/def " +
+		"and [files](https://chat.example.com/files/xyz) and https://other.example.org/team/pl/ghi and https://mattermost.com/")
+	assert.Equal(t, "links https://chat.example.com/team/plshared/abc and *** and [files](***) and *** and https://mattermost.com/", message)
+	assert.Equal(t, "3 link(s)", stripped)
+}
+
+func TestContentFilterFilterPost(t *testing.T) {
+	th := setupContentPolicyTest(t, &model.SharedChannelContentPolicy{
+		RemoteId:          model.NewId(),
+		RedactPatterns:    model.StringArray{`TICKET-\d+`},
+		RedactReplacement: "***",
+		StripPermalinks:   true,
+	})
+	sd := th.newSyncData(t)
+
+	attachment := &model.SlackAttachment{
+		Fallback: "TICKET-1 failed",
+		Pretext:  "Build of TICKET-1",
+		Title:    "TICKET-1",
+		Text:     "See https://chat.example.com/team/pl/abc",
+		Fields: []*model.SlackAttachmentField{
+			{Title: "Ticket", Value: "TICKET-1"},
+			{Title: "Attempts", Value: 3},
+		},
+	}
+	post := &model.Post{Id: model.NewId(), Message: "TICKET-1 failed"}
+	post.AddProp(model.PostPropsAttachments, []*model.SlackAttachment{attachment})
+
+	redacted, stripped := sd.filter.filterPost(post)
+	assert.Equal(t, `TICKET-\d+ x5`, redacted)
+	assert.Equal(t, "1 link(s)", stripped)
+	assert.Equal(t, "*** failed", post.Message)
+
+	attachments := post.Attachments()
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "*** failed", attachments[0].Fallback)
+	assert.Equal(t, "Build of ***", attachments[0].Pretext)
+	assert.Equal(t, "***", attachments[0].Title)
+	assert.Equal(t, "See ***", attachments[0].Text)
+	require.Len(t, attachments[0].Fields, 2)
+	assert.Equal(t, "***", attachments[0].Fields[0].Value)
+	assert.Equal(t, 3, attachments[0].Fields[1].Value)
+
+	assert.Equal(t, "TICKET-1", attachment.Title, "the original attachment must be left untouched")
+	assert.Equal(t, "TICKET-1", attachment.Fields[0].Value)
+}
+
+func TestFilterPostsForSyncWithContentPolicy(t *testing.T) {
+	th := setupContentPolicyTest(t, &model.SharedChannelContentPolicy{
+		RemoteId:          model.NewId(),
+		RedactPatterns:    model.StringArray{`TICKET-\d+`},
+		RedactReplacement: "[redacted]",
+		RequireApproval:   true,
+	})
+
+	post := &model.Post{Id: model.NewId(), ChannelId: model.NewId(), UserId: model.NewId(), Message: "fixed TICKET-1"}
+
+	t.Run("new post is held", func(t *testing.T) {
+		sd := th.newSyncData(t)
+		sd.posts = []*model.Post{post.Clone()}
+		sd.reactions = []*model.Reaction{{PostId: post.Id, UserId: model.NewId(), EmojiName: "smile"}}
+
+		th.scs.filterPostsForSync(sd)
+
+		assert.Empty(t, sd.posts)
+		assert.Empty(t, sd.reactions)
+		require.Contains(t, th.held, post.Id)
+		assert.Equal(t, model.SharedChannelHeldPostStatusPending, th.held[post.Id].Status)
+		assert.Equal(t, []string{model.SharedChannelFilterActionHold}, th.actions())
+	})
+
+	t.Run("pending post stays held", func(t *testing.T) {
+		sd := th.newSyncData(t)
+		sd.posts = []*model.Post{post.Clone()}
+
+		th.scs.filterPostsForSync(sd)
+
+		assert.Empty(t, sd.posts)
+		assert.Len(t, th.actions(), 1)
+	})
+
+	t.Run("approved post is redacted and sent", func(t *testing.T) {
+		th.held[post.Id].Status = model.SharedChannelHeldPostStatusApproved
+		th.held[post.Id].ReviewedAt = model.GetMillis()
+
+		sd := th.newSyncData(t)
+		sd.posts = []*model.Post{post.Clone()}
+
+		th.scs.filterPostsForSync(sd)
+
+		require.Len(t, sd.posts, 1)
+		assert.Equal(t, "fixed [redacted]", sd.posts[0].Message)
+		assert.Equal(t, model.SharedChannelFilterActionRedact, th.actions()[1])
+	})
+
+	t.Run("edit after approval is held again", func(t *testing.T) {
+		edited := post.Clone()
+		edited.EditAt = th.held[post.Id].ReviewedAt + 1
+
+		sd := th.newSyncData(t)
+		sd.posts = []*model.Post{edited}
+
+		th.scs.filterPostsForSync(sd)
+
+		assert.Empty(t, sd.posts)
+		assert.Equal(t, model.SharedChannelHeldPostStatusPending, th.held[post.Id].Status)
+	})
+
+	t.Run("deleted post that was never held is sent", func(t *testing.T) {
+		deleted := &model.Post{Id: model.NewId(), ChannelId: post.ChannelId, UserId: post.UserId, DeleteAt: model.GetMillis()}
+
+		sd := th.newSyncData(t)
+		sd.posts = []*model.Post{deleted}
+
+		th.scs.filterPostsForSync(sd)
+
+		assert.Len(t, sd.posts, 1)
+		assert.NotContains(t, th.held, deleted.Id)
+	})
+}
+
+func TestIsAttachmentBlocked(t *testing.T) {
+	th := setupContentPolicyTest(t, &model.SharedChannelContentPolicy{
+		RemoteId:         model.NewId(),
+		BlockedFileTypes: model.StringArray{"exe", "video/*"},
+		MaxFileSize:      1024,
+	})
+	sd := th.newSyncData(t)
+
+	exe := &model.FileInfo{Id: model.NewId(), Name: "setup.exe", Extension: "exe", MimeType: "application/octet-stream", Size: 10}
+	video := &model.FileInfo{Id: model.NewId(), Name: "demo.mp4", Extension: "mp4", MimeType: "video/mp4", Size: 10}
+	large := &model.FileInfo{Id: model.NewId(), Name: "dump.txt", Extension: "txt", MimeType: "text/plain", Size: 2048}
+	small := &model.FileInfo{Id: model.NewId(), Name: "notes.txt", Extension: "txt", MimeType: "text/plain", Size: 512}
+
+	post := &model.Post{
+		Id:        model.NewId(),
+		ChannelId: model.NewId(),
+		FileIds:   model.StringArray{exe.Id, video.Id, large.Id, small.Id},
+		Metadata:  &model.PostMetadata{Files: []*model.FileInfo{exe, video, large, small}},
+	}
+
+	assert.True(t, th.scs.isAttachmentBlocked(sd, exe, post))
+	assert.True(t, th.scs.isAttachmentBlocked(sd, video, post))
+	assert.True(t, th.scs.isAttachmentBlocked(sd, large, post))
+	assert.False(t, th.scs.isAttachmentBlocked(sd, small, post))
+
+	assert.Equal(t, model.StringArray{small.Id}, post.FileIds)
+	assert.Equal(t, []*model.FileInfo{small}, post.Metadata.Files)
+
+	require.Len(t, th.log, 3)
+	for _, entry := range th.log {
+		assert.Equal(t, model.SharedChannelFilterActionBlockFile, entry.Action)
+		assert.Equal(t, post.Id, entry.PostId)
+	}
+	assert.Equal(t, "dump.txt: file size 2048 exceeds limit of 1024", th.log[2].Details)
+}
+
+func TestFilterAttachmentName(t *testing.T) {
+	th := setupContentPolicyTest(t, &model.SharedChannelContentPolicy{
+		RemoteId:          model.NewId(),
+		RedactPatterns:    model.StringArray{`TICKET-\d+`},
+		RedactReplacement: "***",
+	})
+	sd := th.newSyncData(t)
+
+	report := &model.FileInfo{Id: model.NewId(), Name: "TICKET-42 report.pdf"}
+	notes := &model.FileInfo{Id: model.NewId(), Name: "notes.txt"}
+	post := &model.Post{
+		Id:        model.NewId(),
+		ChannelId: model.NewId(),
+		FileIds:   model.StringArray{report.Id, notes.Id},
+		Metadata:  &model.PostMetadata{Files: []*model.FileInfo{report, notes}},
+	}
+
+	filtered := th.scs.filterAttachmentName(sd, report, post)
+	assert.Equal(t, "*** report.pdf", filtered.Name)
+	assert.Equal(t, "TICKET-42 report.pdf", report.Name, "the cached file info must be left untouched")
+	assert.Equal(t, "*** report.pdf", post.Metadata.Files[0].Name)
+
+	assert.Same(t, notes, th.scs.filterAttachmentName(sd, notes, post))
+
+	require.Len(t, th.log, 1)
+	assert.Equal(t, model.SharedChannelFilterActionRedact, th.log[0].Action)
+	assert.Equal(t, report.Id, th.log[0].FileId)
+	assert.NotContains(t, th.log[0].Details, "42")
+}
@@ -46,6 +46,24 @@ func (scs *Service) onReceiveSyncMessage(msg model.RemoteClusterMsg, rc *model.R
 	return scs.processSyncMessage(request.EmptyContext(scs.server.Log()), &sm, rc, response)
 }
 
+// ReceiveSyncMsg processes a sync message for a remote that isn't connected via the
+// Remote Cluster Service, such as a bridge to another chat network.
+func (scs *Service) ReceiveSyncMsg(syncMsg *model.SyncMsg, rc *model.RemoteCluster) (model.SyncResponse, error) {
+	var syncResp model.SyncResponse
+	var response remotecluster.Response
+
+	if err := scs.processSyncMessage(request.EmptyContext(scs.server.Log()), syncMsg, rc, &response); err != nil {
+		return syncResp, err
+	}
+
+	if len(response.Payload) != 0 {
+		if err := json.Unmarshal(response.Payload, &syncResp); err != nil {
+			return syncResp, fmt.Errorf("invalid sync response: %w", err)
+		}
+	}
+	return syncResp, nil
+}
+
 func (scs *Service) processGlobalUserSync(rctx request.CTX, syncMsg *model.SyncMsg, rc *model.RemoteCluster, response *remotecluster.Response) error {
 	syncResp := model.SyncResponse{
 		UserErrors: make([]string, 0),
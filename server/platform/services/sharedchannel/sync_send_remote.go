@@ -41,6 +41,10 @@ type syncData struct {
 	attachments       []attachment
 	mentionTransforms map[string]string
 
+	// filter applies the remote's content policy; nil if the remote has none.
+	filter      *contentFilter
+	heldPostIDs map[string]bool
+
 	resultRepeat                bool
 	resultNextCursor            model.GetPostsSinceForSyncCursor
 	GlobalUserSyncLastTimestamp int64
@@ -54,6 +58,7 @@ func newSyncData(task syncTask, rc *model.RemoteCluster, scr *model.SharedChanne
 		users:             make(map[string]*model.User),
 		profileImages:     make(map[string]*model.User),
 		mentionTransforms: make(map[string]string),
+		heldPostIDs:       make(map[string]bool),
 		resultNextCursor: model.GetPostsSinceForSyncCursor{
 			LastPostUpdateAt: scr.LastPostUpdateAt, LastPostUpdateID: scr.LastPostUpdateID,
 			LastPostCreateAt: scr.LastPostCreateAt, LastPostCreateID: scr.LastPostCreateID,
@@ -199,6 +204,11 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 		return fmt.Errorf("cannot fetch post users for sync %v: %w", sd, err)
 	}
 
+	// fetch the remote's content policy, if any.
+	if sd.filter, err = scs.getContentFilter(rc.RemoteId); err != nil {
+		return err
+	}
+
 	// filter out any posts that don't need to be sent.
 	scs.filterPostsForSync(sd)
 
@@ -531,6 +541,10 @@ func (scs *Service) fetchPostAttachmentsForSync(sd *syncData) error {
 		}
 
 		for _, fi := range fis {
+			if scs.isAttachmentBlocked(sd, fi, post) {
+				continue
+			}
+			fi = scs.filterAttachmentName(sd, fi, post)
 			if scs.shouldSyncAttachment(fi, sd.rc) {
 				sd.attachments = append(sd.attachments, attachment{fi: fi, post: post})
 			}
@@ -568,10 +582,16 @@ func (scs *Service) filterPostsForSync(sd *syncData) {
 		// parse out all permalinks in the message.
 		p.Message = scs.processPermalinkToRemote(p)
 
+		// apply the remote's content policy, which may hold the post for approval.
+		if !scs.applyContentPolicy(sd, p) {
+			continue
+		}
+
 		filtered = append(filtered, p)
 	}
 
 	sd.posts = filtered
+	filterHeldContentForSync(sd)
 }
 
 // sendSyncData sends all the collected users, posts, reactions, images, and attachments to the
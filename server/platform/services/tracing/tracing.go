@@ -0,0 +1,138 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package tracing exports OpenTelemetry traces of the server to an OTLP collector.
+//
+// The spans are created through the global tracer provider, which is a no-op one until tracing is
+// enabled through the MetricsSettings. The trace context of the HTTP requests is continued from their
+// W3C Trace Context headers.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	instrumentationName = "github.com/mattermost/mattermost/server/v8"
+	serviceName         = "mattermost-server"
+)
+
+var propagator = propagation.TraceContext{}
+
+// Tracer manages the tracer provider exporting the spans, following the tracing settings.
+type Tracer struct {
+	mut        sync.Mutex
+	provider   *sdktrace.TracerProvider
+	endpoint   string
+	sampleRate float64
+}
+
+// New returns a tracer with tracing disabled, until it's configured.
+func New() *Tracer {
+	return &Tracer{}
+}
+
+// Configure starts, restarts or stops exporting the spans following the settings. It's a no-op
+// if the settings relevant to tracing didn't change.
+func (t *Tracer) Configure(settings *model.MetricsSettings) error {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	enabled := settings.EnableTracing != nil && *settings.EnableTracing
+	if !enabled {
+		return t.stop()
+	}
+
+	if t.provider != nil && t.endpoint == *settings.TracingEndpoint && t.sampleRate == *settings.TracingSampleRate {
+		return nil
+	}
+
+	if err := t.stop(); err != nil {
+		return err
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(*settings.TracingEndpoint))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the trace exporter")
+	}
+
+	t.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(*settings.TracingSampleRate))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", model.CurrentVersion),
+		)),
+	)
+	t.endpoint = *settings.TracingEndpoint
+	t.sampleRate = *settings.TracingSampleRate
+	otel.SetTracerProvider(t.provider)
+
+	return nil
+}
+
+// Shutdown flushes the pending spans and stops exporting them.
+func (t *Tracer) Shutdown() error {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	return t.stop()
+}
+
+func (t *Tracer) stop() error {
+	if t.provider == nil {
+		return nil
+	}
+
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	provider := t.provider
+	t.provider = nil
+
+	if err := provider.Shutdown(context.Background()); err != nil {
+		return errors.Wrap(err, "failed to shutdown the tracer provider")
+	}
+	return nil
+}
+
+// StartSpan starts a span as a child of the span in the context, if any.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartChildSpan starts a span as a child of the span in the context, or returns a no-op span if
+// the context carries none, so that the work done outside of a trace doesn't start new ones.
+func StartChildSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, noop.Span{}
+	}
+	return StartSpan(ctx, name, attrs...)
+}
+
+// EndSpan ends the span, recording the error if it's not nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Extract returns a copy of the context carrying the remote span of the headers, if any.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
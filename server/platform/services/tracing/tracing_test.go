@@ -0,0 +1,171 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// collector is a stand-in for an OTLP collector, recording the spans it receives over HTTP.
+type collector struct {
+	mut   sync.Mutex
+	spans []*tracepb.Span
+}
+
+func newCollector(t *testing.T) (*collector, string) {
+	c := &collector{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var req collectortrace.ExportTraceServiceRequest
+		require.NoError(t, proto.Unmarshal(body, &req))
+
+		c.mut.Lock()
+		for _, resourceSpans := range req.ResourceSpans {
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				c.spans = append(c.spans, scopeSpans.Spans...)
+			}
+		}
+		c.mut.Unlock()
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return c, server.URL + "/v1/traces"
+}
+
+func (c *collector) Spans() []*tracepb.Span {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.spans
+}
+
+func tracingSettings(endpoint string, sampleRate float64) *model.MetricsSettings {
+	settings := &model.MetricsSettings{
+		EnableTracing:     model.NewPointer(true),
+		TracingEndpoint:   model.NewPointer(endpoint),
+		TracingSampleRate: model.NewPointer(sampleRate),
+	}
+	settings.SetDefaults()
+	return settings
+}
+
+func TestTracer(t *testing.T) {
+	t.Run("exports the spans to the collector", func(t *testing.T) {
+		c, endpoint := newCollector(t)
+
+		tracer := New()
+		require.NoError(t, tracer.Configure(tracingSettings(endpoint, 1)))
+
+		ctx, parent := StartSpan(context.Background(), "parent")
+		_, child := StartSpan(ctx, "child", attribute.String("key", "value"))
+		EndSpan(child, assert.AnError)
+		EndSpan(parent, nil)
+
+		require.NoError(t, tracer.Shutdown())
+
+		spans := c.Spans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "child", spans[0].Name)
+		assert.Equal(t, "parent", spans[1].Name)
+		assert.Equal(t, spans[1].TraceId, spans[0].TraceId)
+		assert.Equal(t, spans[1].SpanId, spans[0].ParentSpanId)
+		assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, spans[0].Status.Code)
+		require.Len(t, spans[0].Attributes, 1)
+		assert.Equal(t, "key", spans[0].Attributes[0].Key)
+		assert.Equal(t, "value", spans[0].Attributes[0].Value.GetStringValue())
+	})
+
+	t.Run("continues the remote trace of the headers", func(t *testing.T) {
+		c, endpoint := newCollector(t)
+
+		tracer := New()
+		require.NoError(t, tracer.Configure(tracingSettings(endpoint, 1)))
+
+		header := http.Header{}
+		header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		_, span := StartSpan(Extract(context.Background(), header), "request")
+		EndSpan(span, nil)
+		require.NoError(t, tracer.Shutdown())
+
+		spans := c.Spans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.TraceID(spans[0].TraceId).String())
+		assert.Equal(t, "00f067aa0ba902b7", trace.SpanID(spans[0].ParentSpanId).String())
+	})
+
+	t.Run("starts the child spans only within a trace", func(t *testing.T) {
+		c, endpoint := newCollector(t)
+
+		tracer := New()
+		require.NoError(t, tracer.Configure(tracingSettings(endpoint, 1)))
+
+		_, orphan := StartChildSpan(context.Background(), "orphan")
+		assert.False(t, orphan.IsRecording())
+		EndSpan(orphan, nil)
+
+		ctx, parent := StartSpan(context.Background(), "parent")
+		_, child := StartChildSpan(ctx, "child")
+		assert.True(t, child.IsRecording())
+		EndSpan(child, nil)
+		EndSpan(parent, nil)
+
+		require.NoError(t, tracer.Shutdown())
+
+		spans := c.Spans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "child", spans[0].Name)
+		assert.Equal(t, spans[1].SpanId, spans[0].ParentSpanId)
+	})
+
+	t.Run("doesn't export the spans not sampled", func(t *testing.T) {
+		c, endpoint := newCollector(t)
+
+		tracer := New()
+		require.NoError(t, tracer.Configure(tracingSettings(endpoint, 0)))
+
+		_, span := StartSpan(context.Background(), "span")
+		assert.False(t, span.IsRecording())
+		EndSpan(span, nil)
+
+		require.NoError(t, tracer.Shutdown())
+		assert.Empty(t, c.Spans())
+	})
+
+	t.Run("stops exporting the spans when disabled", func(t *testing.T) {
+		c, endpoint := newCollector(t)
+
+		tracer := New()
+		settings := tracingSettings(endpoint, 1)
+		require.NoError(t, tracer.Configure(settings))
+
+		*settings.EnableTracing = false
+		require.NoError(t, tracer.Configure(settings))
+
+		_, span := StartSpan(context.Background(), "span")
+		assert.False(t, span.IsRecording())
+		EndSpan(span, nil)
+
+		assert.Empty(t, c.Spans())
+	})
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mail
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyDKIM checks the first DKIM-Signature header of a relaxed/relaxed signed
+// message against the given public key.
+func verifyDKIM(t *testing.T, message []byte, publicKey crypto.PublicKey) map[string]string {
+	t.Helper()
+
+	rawHeaders, body, found := bytes.Cut(message, []byte("\r\n\r\n"))
+	require.True(t, found)
+
+	headers := parseDKIMHeaders(rawHeaders)
+	signatureValue, ok := headers[strings.ToLower(DKIMSignatureHeader)]
+	require.True(t, ok, "missing DKIM-Signature header")
+
+	tags := map[string]string{}
+	for tag := range strings.SplitSeq(strings.ReplaceAll(signatureValue, "\r\n", ""), ";") {
+		k, v, _ := strings.Cut(strings.TrimSpace(tag), "=")
+		tags[k] = strings.Join(strings.Fields(v), "")
+	}
+
+	bodyHash := sha256.Sum256(canonicalizeDKIMBodyRelaxed(body))
+	require.Equal(t, base64.StdEncoding.EncodeToString(bodyHash[:]), tags["bh"], "body hash mismatch")
+
+	var signedData bytes.Buffer
+	for name := range strings.SplitSeq(tags["h"], ":") {
+		signedData.WriteString(canonicalizeDKIMHeaderRelaxed(name, headers[name]))
+		signedData.WriteString("\r\n")
+	}
+	unsigned := signatureValue[:strings.LastIndex(signatureValue, "b=")+2]
+	signedData.WriteString(canonicalizeDKIMHeaderRelaxed(DKIMSignatureHeader, unsigned))
+	digest := sha256.Sum256(signedData.Bytes())
+
+	sig, err := base64.StdEncoding.DecodeString(tags["b"])
+	require.NoError(t, err)
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		require.NoError(t, rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig))
+	case ed25519.PublicKey:
+		require.True(t, ed25519.Verify(key, digest[:], sig))
+	default:
+		require.Failf(t, "unexpected key type", "%T", publicKey)
+	}
+
+	return tags
+}
+
+func TestParseDKIMPrivateKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("PKCS1 RSA key", func(t *testing.T) {
+		data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+		signer, err := ParseDKIMPrivateKey(data)
+		require.NoError(t, err)
+		assert.IsType(t, &rsa.PrivateKey{}, signer)
+	})
+
+	t.Run("PKCS8 Ed25519 key", func(t *testing.T) {
+		der, err := x509.MarshalPKCS8PrivateKey(edKey)
+		require.NoError(t, err)
+		signer, err := ParseDKIMPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+		require.NoError(t, err)
+		assert.IsType(t, ed25519.PrivateKey{}, signer)
+	})
+
+	t.Run("not a PEM file", func(t *testing.T) {
+		_, err := ParseDKIMPrivateKey([]byte("not a key"))
+		require.Error(t, err)
+	})
+}
+
+func TestCanonicalizeDKIMRelaxed(t *testing.T) {
+	assert.Equal(t, "subject:Hello world", canonicalizeDKIMHeaderRelaxed("Subject ", " Hello \t world \r\n  "))
+	assert.Equal(t, " a b\r\nc\r\n", string(canonicalizeDKIMBodyRelaxed([]byte(" a \t b \r\nc\r\n\r\n\r\n"))))
+	assert.Equal(t, "a\r\n\r\nb\r\n", string(canonicalizeDKIMBodyRelaxed([]byte("a\r\n\r\nb"))))
+	assert.Empty(t, canonicalizeDKIMBodyRelaxed([]byte("\r\n\r\n")))
+}
+
+func TestSendMailWithDKIM(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		signer    crypto.Signer
+		publicKey crypto.PublicKey
+		algorithm string
+	}{
+		"rsa":     {rsaKey, &rsaKey.PublicKey, "rsa-sha256"},
+		"ed25519": {edKey, edPublic, "ed25519-sha256"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mocm := &mockMailer{}
+			cfg := getConfig()
+			cfg.DKIM = &DKIMOptions{Domain: "example.com", Selector: "mm", Signer: tc.signer}
+
+			data := mailData{
+				mimeTo:   "to@example.com",
+				smtpTo:   "to@example.com",
+				from:     mail.Address{Name: "Mattermost", Address: "from@example.com"},
+				replyTo:  mail.Address{Address: "reply@example.com"},
+				subject:  "A subject that is long enough to be folded by the encoder, maybe",
+				htmlBody: "<p>Hello   there</p>\n<p>Second paragraph</p>",
+			}
+
+			now := time.Now()
+			err := sendMail(mocm, data, now, cfg)
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(string(mocm.data), DKIMSignatureHeader+": v=1;"))
+
+			tags := verifyDKIM(t, mocm.data, tc.publicKey)
+			assert.Equal(t, tc.algorithm, tags["a"])
+			assert.Equal(t, "example.com", tags["d"])
+			assert.Equal(t, "mm", tags["s"])
+			assert.True(t, strings.HasPrefix(tags["h"], "from:reply-to:subject:date:to"))
+		})
+	}
+
+	t.Run("unsigned without DKIM options", func(t *testing.T) {
+		mocm := &mockMailer{}
+		data := mailData{from: mail.Address{Address: "from@example.com"}, smtpTo: "to@example.com"}
+		require.NoError(t, sendMail(mocm, data, time.Now(), getConfig()))
+		assert.NotContains(t, string(mocm.data), DKIMSignatureHeader)
+	})
+}
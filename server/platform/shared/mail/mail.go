@@ -4,6 +4,7 @@
 package mail
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"net/mail"
 	"net/smtp"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/jaytaylor/html2text"
@@ -43,6 +45,7 @@ type SMTPConfig struct {
 	FeedbackName                      string
 	FeedbackEmail                     string
 	ReplyToAddress                    string
+	DKIM                              *DKIMOptions
 }
 
 type mailData struct {
@@ -352,6 +355,19 @@ func sendMail(c smtpClient, mail mailData, date time.Time, config *SMTPConfig) e
 		m.EmbedReader(name, reader)
 	}
 
+	var dkimSignature string
+	var message bytes.Buffer
+	if config.DKIM != nil {
+		if _, err = m.WriteTo(&message); err != nil {
+			return errors.Wrap(err, "failed to build the email message")
+		}
+
+		dkimSignature, err = signDKIM(message.Bytes(), config.DKIM, date)
+		if err != nil {
+			return errors.Wrap(err, "failed to sign the email message")
+		}
+	}
+
 	if err = c.Mail(mail.from.Address); err != nil {
 		return errors.Wrap(err, "failed to set the from address")
 	}
@@ -365,7 +381,15 @@ func sendMail(c smtpClient, mail mailData, date time.Time, config *SMTPConfig) e
 		return errors.Wrap(err, "failed to add email message data")
 	}
 
-	_, err = m.WriteTo(w)
+	if dkimSignature != "" {
+		header := DKIMSignatureHeader + ": " + strings.ReplaceAll(dkimSignature, "; ", ";\r\n ") + "\r\n"
+		if _, err = io.WriteString(w, header); err != nil {
+			return errors.Wrap(err, "failed to write the email message")
+		}
+		_, err = message.WriteTo(w)
+	} else {
+		_, err = m.WriteTo(w)
+	}
 	if err != nil {
 		return errors.Wrap(err, "failed to write the email message")
 	}
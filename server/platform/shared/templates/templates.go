@@ -5,6 +5,8 @@ package templates
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"html/template"
 	"io"
 	"path/filepath"
@@ -18,10 +20,13 @@ import (
 // Container represents a set of templates that can be render
 type Container struct {
 	templates *template.Template
-	mutex     sync.RWMutex
-	stop      chan struct{}
-	stopped   chan struct{}
-	watch     bool
+	// base is an unexecuted copy of templates. html/template forbids cloning
+	// a template once it has been executed, so overrides are cloned from it.
+	base    *template.Template
+	mutex   sync.RWMutex
+	stop    chan struct{}
+	stopped chan struct{}
+	watch   bool
 }
 
 // Data contains the data used to populate the template variables, it has Props
@@ -38,7 +43,9 @@ func GetTemplateDirectory() (string, bool) {
 // NewFromTemplates creates a new templates container using a
 // `template.Template` object
 func NewFromTemplate(templates *template.Template) *Container {
-	return &Container{templates: templates}
+	c := &Container{}
+	c.setTemplates(templates)
+	return c
 }
 
 // New creates a new templates container scanning a directory.
@@ -49,7 +56,7 @@ func New(directory string) (*Container, error) {
 	if err != nil {
 		return nil, err
 	}
-	c.templates = htmlTemplates
+	c.setTemplates(htmlTemplates)
 
 	return c, nil
 }
@@ -78,11 +85,11 @@ func NewWithWatcher(directory string) (*Container, <-chan error, error) {
 	}
 
 	c := &Container{
-		templates: htmlTemplates,
-		watch:     true,
-		stop:      make(chan struct{}),
-		stopped:   make(chan struct{}),
+		watch:   true,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
 	}
+	c.setTemplates(htmlTemplates)
 	errors := make(chan error)
 
 	go func() {
@@ -100,7 +107,7 @@ func NewWithWatcher(directory string) (*Container, <-chan error, error) {
 						errors <- err
 					} else {
 						c.mutex.Lock()
-						c.templates = htmlTemplates
+						c.setTemplates(htmlTemplates)
 						c.mutex.Unlock()
 					}
 				}
@@ -113,6 +120,58 @@ func NewWithWatcher(directory string) (*Container, <-chan error, error) {
 	return c, errors, nil
 }
 
+func (c *Container) setTemplates(htmlTemplates *template.Template) {
+	c.templates = htmlTemplates
+	// Cloning fails if the given templates were already executed, in which
+	// case the container can't be overridden.
+	c.base, _ = htmlTemplates.Clone()
+}
+
+func (c *Container) clone() (*template.Template, error) {
+	c.mutex.RLock()
+	base := c.base
+	c.mutex.RUnlock()
+
+	if base == nil {
+		return nil, errors.New("the templates can't be cloned")
+	}
+
+	return base.Clone()
+}
+
+// Override returns a new container with a copy of the templates where the
+// template referenced by name is replaced with the given text. The text can
+// either be the template body or define the template itself, and can use any
+// of the other templates of the container.
+func (c *Container) Override(name, text string) (*Container, error) {
+	htmlTemplates, err := c.clone()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := htmlTemplates.New(name).Parse(text); err != nil {
+		return nil, err
+	}
+
+	return NewFromTemplate(htmlTemplates), nil
+}
+
+// Validate renders the template referenced by name with the data provided,
+// failing if the template uses a prop that the data doesn't contain.
+func (c *Container) Validate(name string, data Data) error {
+	htmlTemplates, err := c.clone()
+	if err != nil {
+		return err
+	}
+
+	tmpl := htmlTemplates.Lookup(name)
+	if tmpl == nil {
+		return fmt.Errorf("template %q not found", name)
+	}
+
+	return tmpl.Option("missingkey=error").Execute(io.Discard, data)
+}
+
 // Close stops the templates watcher of the container in case you have created
 // it with watch parameter set to true
 func (c *Container) Close() {
@@ -112,3 +112,61 @@ func TestRenderUnknownTemplate(t *testing.T) {
 	assert.Error(t, mt.Render(buf, "foo", Data{}))
 	assert.Equal(t, "", buf.String())
 }
+
+func TestOverride(t *testing.T) {
+	tpl := template.New("test")
+	_, err := tpl.Parse(`{{ define "foo" }}foo{{ template "bar" . }}{{ end }}{{ define "bar" }}{{ .Props.Bar }}{{ end }}`)
+	require.NoError(t, err)
+	mt := NewFromTemplate(tpl)
+
+	data := Data{Props: map[string]any{"Bar": "bar"}}
+
+	// Render the original templates first, overrides must still be possible
+	// once they have been executed.
+	text, err := mt.RenderToString("foo", data)
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", text)
+
+	t.Run("template body", func(t *testing.T) {
+		overridden, err := mt.Override("foo", `baz{{ template "bar" . }}`)
+		require.NoError(t, err)
+
+		text, err := overridden.RenderToString("foo", data)
+		require.NoError(t, err)
+		assert.Equal(t, "bazbar", text)
+	})
+
+	t.Run("template definition", func(t *testing.T) {
+		overridden, err := mt.Override("foo", `{{ define "foo" }}qux{{ end }}`)
+		require.NoError(t, err)
+
+		text, err := overridden.RenderToString("foo", data)
+		require.NoError(t, err)
+		assert.Equal(t, "qux", text)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := mt.Override("foo", `{{ .Props.Bar `)
+		require.Error(t, err)
+	})
+
+	text, err = mt.RenderToString("foo", data)
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", text, "the original templates should be untouched")
+}
+
+func TestValidate(t *testing.T) {
+	tpl := template.New("test")
+	_, err := tpl.Parse(`{{ define "foo" }}{{ .Props.Bar }}{{ end }}`)
+	require.NoError(t, err)
+	mt := NewFromTemplate(tpl)
+
+	require.NoError(t, mt.Validate("foo", Data{Props: map[string]any{"Bar": "bar"}}))
+	require.Error(t, mt.Validate("foo", Data{Props: map[string]any{"Baz": "baz"}}))
+	require.Error(t, mt.Validate("unknown", Data{}))
+
+	// Rendering is still lenient about missing props.
+	text, err := mt.RenderToString("foo", Data{Props: map[string]any{}})
+	require.NoError(t, err)
+	assert.Equal(t, "", text)
+}
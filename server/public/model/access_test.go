@@ -52,6 +52,11 @@ func TestAccessIsValid(t *testing.T) {
 
 	ad.RedirectUri = "http://example.com"
 	require.Nil(t, ad.IsValid())
+
+	// Tokens of the client credentials grant have neither a redirect URI nor a refresh token.
+	ad.RedirectUri = ""
+	ad.RefreshToken = ""
+	require.Nil(t, ad.IsValid())
 }
 
 func TestAccessData_AudienceValidation(t *testing.T) {
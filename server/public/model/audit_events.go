@@ -134,10 +134,25 @@ const (
 	AuditEventRemoveTeamsFromPolicy    = "removeTeamsFromPolicy"    // remove teams from data retention policy
 )
 
+// Email
+const (
+	AuditEventAddDKIMPrivateKey    = "addDKIMPrivateKey"    // upload private key used to DKIM sign outgoing email
+	AuditEventDeleteOutgoingEmail  = "deleteOutgoingEmail"  // remove email from the outbound queue
+	AuditEventRemoveDKIMPrivateKey = "removeDKIMPrivateKey" // remove private key used to DKIM sign outgoing email
+	AuditEventResetEmailTemplate   = "resetEmailTemplate"   // remove email template override
+	AuditEventRetryOutgoingEmail   = "retryOutgoingEmail"   // requeue failed email for delivery
+	AuditEventUploadEmailTemplate  = "uploadEmailTemplate"  // override email template for a locale
+)
+
 // Emojis
 const (
-	AuditEventCreateEmoji = "createEmoji" // create emoji
-	AuditEventDeleteEmoji = "deleteEmoji" // delete emoji
+	AuditEventCreateEmoji         = "createEmoji"         // create emoji
+	AuditEventCreateEmojiCategory = "createEmojiCategory" // create category for custom emojis
+	AuditEventDeleteEmoji         = "deleteEmoji"         // delete emoji
+	AuditEventDeleteEmojiCategory = "deleteEmojiCategory" // delete category of custom emojis
+	AuditEventExportEmojiPack     = "exportEmojiPack"     // export custom emojis as an emoji pack
+	AuditEventImportEmojiPack     = "importEmojiPack"     // import custom emojis from an emoji pack
+	AuditEventPatchEmoji          = "patchEmoji"          // update aliases or category of emoji
 )
 
 // Exports
@@ -210,6 +225,7 @@ const (
 // OAuth
 const (
 	AuditEventAuthorizeOAuthApp                          = "authorizeOAuthApp"                          // authorize OAuth app
+	AuditEventAuthorizeOAuthDevice                       = "authorizeOAuthDevice"                       // start OAuth device authorization
 	AuditEventAuthorizeOAuthPage                         = "authorizeOAuthPage"                         // authorize OAuth page
 	AuditEventCompleteOAuth                              = "completeOAuth"                              // complete OAuth authorization flow
 	AuditEventCreateOAuthApp                             = "createOAuthApp"                             // create OAuth app
@@ -218,18 +234,22 @@ const (
 	AuditEventDeleteOAuthApp                             = "deleteOAuthApp"                             // delete OAuth app
 	AuditEventDeleteOutgoingOAuthConnection              = "deleteOutgoingOAuthConnection"              // delete outgoing OAuth connection
 	AuditEventGetAccessToken                             = "getAccessToken"                             // get OAuth access token
+	AuditEventIntrospectOAuthToken                       = "introspectOAuthToken"                       // introspect OAuth access token
 	AuditEventLoginWithOAuth                             = "loginWithOAuth"                             // login using OAuth authentication provider
+	AuditEventLogoutWithOAuth                            = "logoutWithOAuth"                            // logout and end the session at the OAuth authentication provider
 	AuditEventMobileLoginWithOAuth                       = "mobileLoginWithOAuth"                       // mobile application login using OAuth authentication provider
 	AuditEventRegenerateOAuthAppSecret                   = "regenerateOAuthAppSecret"                   // regenerate secret key for OAuth app
 	AuditEventSignupWithOAuth                            = "signupWithOAuth"                            // create account using OAuth authentication provider
 	AuditEventUpdateOAuthApp                             = "updateOAuthApp"                             // update OAuth app
 	AuditEventUpdateOutgoingOAuthConnection              = "updateOutgoingOAuthConnection"              // update outgoing OAuth connection
 	AuditEventValidateOutgoingOAuthConnectionCredentials = "validateOutgoingOAuthConnectionCredentials" // validate credentials for outgoing OAuth connection
+	AuditEventVerifyOAuthDevice                          = "verifyOAuthDevice"                          // approve or deny OAuth device authorization
 
 )
 
 // Plugins
 const (
+	AuditEventApprovePluginPermissions            = "approvePluginPermissions"            // approve permissions declared by plugin
 	AuditEventDisablePlugin                       = "disablePlugin"                       // disable installed plugin
 	AuditEventEnablePlugin                        = "enablePlugin"                        // enable installed plugin
 	AuditEventGetFirstAdminVisitMarketplaceStatus = "getFirstAdminVisitMarketplaceStatus" // get first admin visit status
@@ -261,16 +281,19 @@ const (
 
 // Remote Clusters
 const (
-	AuditEventCreateRemoteCluster            = "createRemoteCluster"            // create connection to remote Mattermost cluster
-	AuditEventDeleteRemoteCluster            = "deleteRemoteCluster"            // delete connection to remote Mattermost cluster
-	AuditEventGenerateRemoteClusterInvite    = "generateRemoteClusterInvite"    // generate invitation token for remote cluster connection
-	AuditEventInviteRemoteClusterToChannel   = "inviteRemoteClusterToChannel"   // invite remote cluster users to shared channel
-	AuditEventPatchRemoteCluster             = "patchRemoteCluster"             // update remote cluster connection settings
-	AuditEventRemoteClusterAcceptInvite      = "remoteClusterAcceptInvite"      // accept invitation from remote cluster
-	AuditEventRemoteClusterAcceptMessage     = "remoteClusterAcceptMessage"     // accept message from remote cluster
-	AuditEventRemoteUploadProfileImage       = "remoteUploadProfileImage"       // upload profile image from remote cluster
-	AuditEventUninviteRemoteClusterToChannel = "uninviteRemoteClusterToChannel" // remove remote cluster access from shared channel
-	AuditEventUploadRemoteData               = "uploadRemoteData"               // upload data to remote cluster
+	AuditEventCreateRemoteCluster              = "createRemoteCluster"              // create connection to remote Mattermost cluster
+	AuditEventDeleteRemoteCluster              = "deleteRemoteCluster"              // delete connection to remote Mattermost cluster
+	AuditEventDeleteSharedChannelContentPolicy = "deleteSharedChannelContentPolicy" // delete content policy for remote cluster
+	AuditEventGenerateRemoteClusterInvite      = "generateRemoteClusterInvite"      // generate invitation token for remote cluster connection
+	AuditEventInviteRemoteClusterToChannel     = "inviteRemoteClusterToChannel"     // invite remote cluster users to shared channel
+	AuditEventPatchRemoteCluster               = "patchRemoteCluster"               // update remote cluster connection settings
+	AuditEventRemoteClusterAcceptInvite        = "remoteClusterAcceptInvite"        // accept invitation from remote cluster
+	AuditEventRemoteClusterAcceptMessage       = "remoteClusterAcceptMessage"       // accept message from remote cluster
+	AuditEventRemoteUploadProfileImage         = "remoteUploadProfileImage"         // upload profile image from remote cluster
+	AuditEventReviewSharedChannelHeldPost      = "reviewSharedChannelHeldPost"      // approve or reject post held by content policy
+	AuditEventUninviteRemoteClusterToChannel   = "uninviteRemoteClusterToChannel"   // remove remote cluster access from shared channel
+	AuditEventUpdateSharedChannelContentPolicy = "updateSharedChannelContentPolicy" // create or update content policy for remote cluster
+	AuditEventUploadRemoteData                 = "uploadRemoteData"                 // upload data to remote cluster
 )
 
 // Roles
@@ -289,6 +312,16 @@ const (
 	AuditEventRemoveSamlPublicCertificate  = "removeSamlPublicCertificate"  // remove SAML public certificate
 )
 
+// SCIM
+const (
+	AuditEventCreateScimGroup = "createScimGroup" // provision group through SCIM
+	AuditEventCreateScimUser  = "createScimUser"  // provision user through SCIM
+	AuditEventDeleteScimGroup = "deleteScimGroup" // deprovision group through SCIM
+	AuditEventDeleteScimUser  = "deleteScimUser"  // deprovision user through SCIM
+	AuditEventUpdateScimGroup = "updateScimGroup" // replace or patch group through SCIM
+	AuditEventUpdateScimUser  = "updateScimUser"  // replace or patch user through SCIM
+)
+
 // Scheduled Posts
 const (
 	AuditEventCreateSchedulePost  = "createSchedulePost"  // create post scheduled for future delivery
@@ -320,6 +353,8 @@ const (
 	AuditEventGetOnboarding              = "getOnboarding"              // get system onboarding status
 	AuditEventInvalidateCaches           = "invalidateCaches"           // clear server caches
 	AuditEventLocalCheckIntegrity        = "localCheckIntegrity"        // check database integrity locally
+	AuditEventLocalClearStoreFaults      = "localClearStoreFaults"      // stop injecting faults in the store methods locally
+	AuditEventLocalSetStoreFaults        = "localSetStoreFaults"        // set the faults injected in the store methods locally
 	AuditEventQueryLogs                  = "queryLogs"                  // search server log entries
 	AuditEventRestartServer              = "restartServer"              // restart Mattermost server process
 	AuditEventSetServerBusy              = "setServerBusy"              // set server busy status to disallow any operations
@@ -333,6 +368,7 @@ const (
 	AuditEventAddTeamMembers              = "addTeamMembers"              // add multiple members to team
 	AuditEventAddUserToTeamFromInvite     = "addUserToTeamFromInvite"     // add user to team using invitation link
 	AuditEventCreateTeam                  = "createTeam"                  // create team
+	AuditEventCreateTeamInviteLink        = "createTeamInviteLink"        // create expiring or limited-use team invite link
 	AuditEventDeleteTeam                  = "deleteTeam"                  // delete team
 	AuditEventImportTeam                  = "importTeam"                  // import team data from external source
 	AuditEventInvalidateAllEmailInvites   = "invalidateAllEmailInvites"   // invalidate all pending email invitations
@@ -342,10 +378,12 @@ const (
 	AuditEventLocalDeleteTeam             = "localDeleteTeam"             // delete team locally
 	AuditEventLocalInviteUsersToTeam      = "localInviteUsersToTeam"      // invite users to team locally
 	AuditEventPatchTeam                   = "patchTeam"                   // update team properties
+	AuditEventPatchTeamInviteLink         = "patchTeamInviteLink"         // update team invite link
 	AuditEventRegenerateTeamInviteID      = "regenerateTeamInviteID"      // regenerate team invitation ID
 	AuditEventRemoveTeamIcon              = "removeTeamIcon"              // remove custom icon from team
 	AuditEventRemoveTeamMember            = "removeTeamMember"            // remove member from team
 	AuditEventRestoreTeam                 = "restoreTeam"                 // restore previously deleted team
+	AuditEventRevokeTeamInviteLink        = "revokeTeamInviteLink"        // revoke team invite link
 	AuditEventSetTeamIcon                 = "setTeamIcon"                 // set custom icon for team
 	AuditEventUpdateTeam                  = "updateTeam"                  // update team properties
 	AuditEventUpdateTeamMemberRoles       = "updateTeamMemberRoles"       // update roles of team members
@@ -388,20 +426,24 @@ const (
 	AuditEventLocalDeleteUser              = "localDeleteUser"              // delete user locally
 	AuditEventLocalPermanentDeleteAllUsers = "localPermanentDeleteAllUsers" // permanently delete all users locally
 	AuditEventLogin                        = "login"                        // user login to system
+	AuditEventLoginWithMagicLink           = "loginWithMagicLink"           // user login to system using emailed magic link
 	AuditEventLogout                       = "logout"                       // user logout from system
 	AuditEventMigrateAuthToLdap            = "migrateAuthToLdap"            // migrate user authentication method to LDAP
 	AuditEventMigrateAuthToSaml            = "migrateAuthToSaml"            // migrate user authentication method to SAML
 	AuditEventPatchUser                    = "patchUser"                    // update user properties
 	AuditEventPromoteGuestToUser           = "promoteGuestToUser"           // promote guest account to regular user
+	AuditEventRemoveGuestAccessExpiry      = "removeGuestAccessExpiry"      // remove guest account access expiry
 	AuditEventResetPassword                = "resetPassword"                // reset user password
 	AuditEventResetPasswordFailedAttempts  = "resetPasswordFailedAttempts"  // reset failed password attempt counter
 	AuditEventRevokeAllSessionsAllUsers    = "revokeAllSessionsAllUsers"    // revoke all active sessions for all users
 	AuditEventRevokeAllSessionsForUser     = "revokeAllSessionsForUser"     // revoke all active sessions for specific user
 	AuditEventRevokeSession                = "revokeSession"                // revoke specific user session
 	AuditEventRevokeUserAccessToken        = "revokeUserAccessToken"        // revoke user personal access token
+	AuditEventSendMagicLink                = "sendMagicLink"                // send magic link login email to user
 	AuditEventSendPasswordReset            = "sendPasswordReset"            // send password reset email to user
 	AuditEventSendVerificationEmail        = "sendVerificationEmail"        // send email verification link to user
 	AuditEventSetDefaultProfileImage       = "setDefaultProfileImage"       // set user profile image to default avatar
+	AuditEventSetGuestAccessExpiry         = "setGuestAccessExpiry"         // set time after which guest account is deactivated
 	AuditEventSetProfileImage              = "setProfileImage"              // set custom profile image for user
 	AuditEventSwitchAccountType            = "switchAccountType"            // switch user authentication method from one to another
 	AuditEventUpdatePassword               = "updatePassword"               // update user password
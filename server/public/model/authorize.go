@@ -26,6 +26,7 @@ const (
 	PKCECodeChallengeMaxLength  = 128
 	PKCECodeVerifierMinLength   = 43
 	PKCECodeVerifierMaxLength   = 128
+	OIDCNonceMaxLength          = 512
 )
 
 type AuthData struct {
@@ -40,6 +41,7 @@ type AuthData struct {
 	CodeChallenge       string `json:"code_challenge,omitempty"`
 	CodeChallengeMethod string `json:"code_challenge_method,omitempty"`
 	Resource            string `json:"resource,omitempty"`
+	Nonce               string `json:"nonce,omitempty"`
 }
 
 type AuthorizeRequest struct {
@@ -51,6 +53,7 @@ type AuthorizeRequest struct {
 	CodeChallenge       string `json:"code_challenge,omitempty"`
 	CodeChallengeMethod string `json:"code_challenge_method,omitempty"`
 	Resource            string `json:"resource,omitempty"`
+	Nonce               string `json:"nonce,omitempty"`
 }
 
 // IsValid validates the AuthData and returns an error if it isn't configured
@@ -88,6 +91,10 @@ func (ad *AuthData) IsValid() *AppError {
 		return NewAppError("AuthData.IsValid", "model.authorize.is_valid.scope.app_error", nil, "client_id="+ad.ClientID, http.StatusBadRequest)
 	}
 
+	if len(ad.Nonce) > OIDCNonceMaxLength {
+		return NewAppError("AuthData.IsValid", "model.authorize.is_valid.nonce.app_error", nil, "client_id="+ad.ClientID, http.StatusBadRequest)
+	}
+
 	// PKCE validation - if one PKCE field is present, both must be present and valid
 	if ad.CodeChallenge != "" || ad.CodeChallengeMethod != "" {
 		if err := ad.validatePKCE(); err != nil {
@@ -128,6 +135,10 @@ func (ar *AuthorizeRequest) IsValid() *AppError {
 		return NewAppError("AuthData.IsValid", "model.authorize.is_valid.scope.app_error", nil, "client_id="+ar.ClientID, http.StatusBadRequest)
 	}
 
+	if len(ar.Nonce) > OIDCNonceMaxLength {
+		return NewAppError("AuthData.IsValid", "model.authorize.is_valid.nonce.app_error", nil, "client_id="+ar.ClientID, http.StatusBadRequest)
+	}
+
 	// PKCE validation - if one PKCE field is present, both must be present and valid
 	if ar.CodeChallenge != "" || ar.CodeChallengeMethod != "" {
 		if err := ar.validatePKCE(); err != nil {
@@ -311,6 +311,22 @@ func (c *Client4) contentFlaggingRoute() string {
 	return "/content_flagging"
 }
 
+func (c *Client4) emailRoute() string {
+	return "/email"
+}
+
+func (c *Client4) outgoingEmailRoute(emailID string) string {
+	return fmt.Sprintf(c.emailRoute()+"/outbound_queue/%v", emailID)
+}
+
+func (c *Client4) emailTemplatesRoute() string {
+	return c.emailRoute() + "/templates"
+}
+
+func (c *Client4) emailTemplateRoute(name, locale string) string {
+	return fmt.Sprintf(c.emailTemplatesRoute()+"/%v/%v", name, locale)
+}
+
 func (c *Client4) postsEphemeralRoute() string {
 	return "/posts/ephemeral"
 }
@@ -551,6 +567,10 @@ func (c *Client4) serverBusyRoute() string {
 	return "/server_busy"
 }
 
+func (c *Client4) storeFaultsRoute() string {
+	return "/store_faults"
+}
+
 func (c *Client4) userTermsOfServiceRoute(userID string) string {
 	return c.userRoute(userID) + "/terms_of_service"
 }
@@ -607,6 +627,14 @@ func (c *Client4) channelRemoteRoute(remoteID, channelID string) string {
 	return fmt.Sprintf("%s/%s/channels/%s", c.remoteClusterRoute(), remoteID, channelID)
 }
 
+func (c *Client4) contentPolicyRoute(remoteID string) string {
+	return fmt.Sprintf("%s/%s/content_policy", c.remoteClusterRoute(), remoteID)
+}
+
+func (c *Client4) heldPostsRoute(remoteID string) string {
+	return fmt.Sprintf("%s/%s/held_posts", c.remoteClusterRoute(), remoteID)
+}
+
 func (c *Client4) sharedChannelsRoute() string {
 	return "/sharedchannels"
 }
@@ -872,6 +900,35 @@ func (c *Client4) LoginWithDesktopToken(ctx context.Context, token, deviceID str
 	return DecodeJSONFromResponse[*User](r)
 }
 
+// SendMagicLinkEmail emails a single-use link signing in the user with the given email address.
+func (c *Client4) SendMagicLinkEmail(ctx context.Context, email string) (*Response, error) {
+	requestBody := map[string]string{"email": email}
+	r, err := c.DoAPIPostJSON(ctx, "/users/login/magic_link/send", requestBody)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// LoginWithMagicLink authenticates a user with the token of an emailed magic link, and the MFA
+// token of the user when MFA is active.
+func (c *Client4) LoginWithMagicLink(ctx context.Context, token, mfaToken, deviceID string) (*User, *Response, error) {
+	m := make(map[string]string)
+	m["token"] = token
+	m["mfa_token"] = mfaToken
+	m["device_id"] = deviceID
+	r, err := c.DoAPIPostJSON(ctx, "/users/login/magic_link", m)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	c.AuthToken = r.Header.Get(HeaderToken)
+	c.AuthType = HeaderBearer
+
+	return DecodeJSONFromResponse[*User](r)
+}
+
 // Logout terminates the current user's session.
 func (c *Client4) Logout(ctx context.Context) (*Response, error) {
 	r, err := c.DoAPIPost(ctx, "/users/logout", "")
@@ -1405,6 +1462,49 @@ func (c *Client4) DemoteUserToGuest(ctx context.Context, guestID string) (*Respo
 	return BuildResponse(r), nil
 }
 
+// DemoteUserToGuestWithAccessExpiry converts a regular user into a guest that is deactivated
+// after the given time.
+func (c *Client4) DemoteUserToGuestWithAccessExpiry(ctx context.Context, guestID string, accessExpiresAt int64) (*Response, error) {
+	values := url.Values{}
+	values.Set("access_expires_at", strconv.FormatInt(accessExpiresAt, 10))
+	r, err := c.DoAPIPost(ctx, c.userRoute(guestID)+"/demote?"+values.Encode(), "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// GetGuestAccessExpiry returns the time after which a guest is deactivated.
+func (c *Client4) GetGuestAccessExpiry(ctx context.Context, guestID string) (*GuestAccessExpiry, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(guestID)+"/guest_access_expiry", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*GuestAccessExpiry](r)
+}
+
+// SetGuestAccessExpiry sets the time, in milliseconds, after which a guest is deactivated.
+func (c *Client4) SetGuestAccessExpiry(ctx context.Context, guestID string, expiresAt int64) (*GuestAccessExpiry, *Response, error) {
+	r, err := c.DoAPIPutJSON(ctx, c.userRoute(guestID)+"/guest_access_expiry", &GuestAccessExpiry{ExpiresAt: expiresAt})
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*GuestAccessExpiry](r)
+}
+
+// RemoveGuestAccessExpiry removes the access expiry of a guest.
+func (c *Client4) RemoveGuestAccessExpiry(ctx context.Context, guestID string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.userRoute(guestID)+"/guest_access_expiry")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // UpdateUserRoles updates a user's roles in the system. A user can have "system_user" and "system_admin" roles.
 func (c *Client4) UpdateUserRoles(ctx context.Context, userID, roles string) (*Response, error) {
 	requestBody := map[string]string{"roles": roles}
@@ -1526,6 +1626,17 @@ func (c *Client4) GetSessions(ctx context.Context, userID, etag string) ([]*Sess
 	return DecodeJSONFromResponse[[]*Session](r)
 }
 
+// GetSessionDetails returns the sessions of a user with the device, IP address and user agent
+// they were last used from. Must have permission to read the users in the System Console.
+func (c *Client4) GetSessionDetails(ctx context.Context, userID string) ([]*SessionDetails, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userID)+"/sessions/details", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*SessionDetails](r)
+}
+
 // RevokeSession revokes a user session based on the provided user id and session id strings.
 func (c *Client4) RevokeSession(ctx context.Context, userID, sessionID string) (*Response, error) {
 	requestBody := map[string]string{"session_id": sessionID}
@@ -2150,6 +2261,71 @@ func (c *Client4) RegenerateTeamInviteID(ctx context.Context, teamID string) (*T
 	return DecodeJSONFromResponse[*Team](r)
 }
 
+// CreateTeamInviteLink creates an invite link for a team.
+func (c *Client4) CreateTeamInviteLink(ctx context.Context, link *TeamInviteLink) (*TeamInviteLink, *Response, error) {
+	r, err := c.DoAPIPostJSON(ctx, c.teamRoute(link.TeamId)+"/invite_links", link)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*TeamInviteLink](r)
+}
+
+// GetTeamInviteLinks returns the invite links of a team, including the revoked ones if asked.
+func (c *Client4) GetTeamInviteLinks(ctx context.Context, teamID string, includeRevoked bool) ([]*TeamInviteLink, *Response, error) {
+	values := url.Values{}
+	values.Set("include_deleted", c.boolString(includeRevoked))
+	r, err := c.DoAPIGet(ctx, c.teamRoute(teamID)+"/invite_links?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*TeamInviteLink](r)
+}
+
+// GetTeamInviteLink returns an invite link of a team.
+func (c *Client4) GetTeamInviteLink(ctx context.Context, teamID, linkID string) (*TeamInviteLink, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.teamRoute(teamID)+"/invite_links/"+linkID, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*TeamInviteLink](r)
+}
+
+// PatchTeamInviteLink partially updates an invite link of a team.
+func (c *Client4) PatchTeamInviteLink(ctx context.Context, teamID, linkID string, patch *TeamInviteLinkPatch) (*TeamInviteLink, *Response, error) {
+	r, err := c.DoAPIPutJSON(ctx, c.teamRoute(teamID)+"/invite_links/"+linkID+"/patch", patch)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*TeamInviteLink](r)
+}
+
+// RevokeTeamInviteLink revokes an invite link of a team, so that it can't be used anymore.
+func (c *Client4) RevokeTeamInviteLink(ctx context.Context, teamID, linkID string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.teamRoute(teamID)+"/invite_links/"+linkID)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// GetTeamInviteLinkUses returns a page of the users who joined a team through an invite link.
+func (c *Client4) GetTeamInviteLinkUses(ctx context.Context, teamID, linkID string, page, perPage int) ([]*TeamInviteLinkUse, *Response, error) {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	r, err := c.DoAPIGet(ctx, c.teamRoute(teamID)+"/invite_links/"+linkID+"/uses?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*TeamInviteLinkUse](r)
+}
+
 // SoftDeleteTeam deletes the team softly (archive only, not permanent delete).
 func (c *Client4) SoftDeleteTeam(ctx context.Context, teamID string) (*Response, error) {
 	r, err := c.DoAPIDelete(ctx, c.teamRoute(teamID))
@@ -5165,6 +5341,107 @@ func (c *Client4) GetOAuthAccessToken(ctx context.Context, data url.Values) (*Ac
 	return DecodeJSONFromResponse[*AccessResponse](r)
 }
 
+// CreateOAuthDeviceAuthorization starts a device authorization grant for the given client and
+// returns the codes the user has to confirm on the verification page.
+func (c *Client4) CreateOAuthDeviceAuthorization(ctx context.Context, clientID, scope string) (*DeviceAuthorizationResponse, *Response, error) {
+	data := url.Values{}
+	data.Set("client_id", clientID)
+	if scope != "" {
+		data.Set("scope", scope)
+	}
+
+	r, err := c.doOAuthFormRequest(ctx, OAuthDeviceAuthorizationEndpoint, data)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	return DecodeJSONFromResponse[*DeviceAuthorizationResponse](r)
+}
+
+// GetOAuthClientCredentialsToken requests an access token for the bot account owned by the given
+// confidential client.
+func (c *Client4) GetOAuthClientCredentialsToken(ctx context.Context, clientID, clientSecret string) (*AccessResponse, *Response, error) {
+	data := url.Values{}
+	data.Set("grant_type", GrantTypeClientCredentials)
+	data.Set("client_id", clientID)
+	data.Set("client_secret", clientSecret)
+
+	r, err := c.doOAuthFormRequest(ctx, OAuthAccessTokenEndpoint, data)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	return DecodeJSONFromResponse[*AccessResponse](r)
+}
+
+// IntrospectOAuthToken returns the state of an access token issued to the given confidential
+// client, see RFC 7662.
+func (c *Client4) IntrospectOAuthToken(ctx context.Context, clientID, clientSecret, token string) (*TokenIntrospectionResponse, *Response, error) {
+	data := url.Values{}
+	data.Set("client_id", clientID)
+	data.Set("client_secret", clientSecret)
+	data.Set("token", token)
+
+	r, err := c.doOAuthFormRequest(ctx, OAuthIntrospectEndpoint, data)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	return DecodeJSONFromResponse[*TokenIntrospectionResponse](r)
+}
+
+// GetOAuthDeviceAccessToken polls the token endpoint with a device code. Until the user approves
+// the authorization, the returned error is an *OAuthError with the authorization_pending or
+// slow_down code.
+func (c *Client4) GetOAuthDeviceAccessToken(ctx context.Context, clientID, deviceCode string) (*AccessResponse, *Response, error) {
+	data := url.Values{}
+	data.Set("grant_type", GrantTypeDeviceCode)
+	data.Set("client_id", clientID)
+	data.Set("device_code", deviceCode)
+
+	r, err := c.doOAuthFormRequest(ctx, OAuthAccessTokenEndpoint, data)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	return DecodeJSONFromResponse[*AccessResponse](r)
+}
+
+// doOAuthFormRequest posts a form to an endpoint of the OAuth server, which reports errors as
+// described in RFC 6749 section 5.2 rather than as an AppError.
+func (c *Client4) doOAuthFormRequest(ctx context.Context, endpoint string, data url.Values) (*http.Response, error) {
+	rq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL+endpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	rq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	for k, v := range c.HTTPHeader {
+		rq.Header.Set(k, v)
+	}
+
+	rp, err := c.HTTPClient.Do(rq)
+	if err != nil {
+		return rp, err
+	}
+
+	if rp.StatusCode >= 300 {
+		defer closeBody(rp)
+
+		var oauthErr OAuthError
+		if err := json.NewDecoder(rp.Body).Decode(&oauthErr); err != nil || oauthErr.Code == "" {
+			return rp, fmt.Errorf("unexpected response from the OAuth server: %s", rp.Status)
+		}
+		return rp, &oauthErr
+	}
+
+	return rp, nil
+}
+
 // OutgoingOAuthConnection section
 
 // GetOutgoingOAuthConnections retrieves the outgoing OAuth connections.
@@ -5808,6 +6085,83 @@ func (c *Client4) AutocompleteEmoji(ctx context.Context, name string, etag strin
 	return DecodeJSONFromResponse[[]*Emoji](r)
 }
 
+// PatchEmoji updates the aliases or the category of a custom emoji.
+func (c *Client4) PatchEmoji(ctx context.Context, emojiID string, patch *EmojiPatch) (*Emoji, *Response, error) {
+	r, err := c.DoAPIPutJSON(ctx, c.emojiRoute(emojiID)+"/patch", patch)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*Emoji](r)
+}
+
+// GetEmojiCategories returns all the custom emoji categories.
+func (c *Client4) GetEmojiCategories(ctx context.Context) ([]*EmojiCategory, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.emojisRoute()+"/categories", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*EmojiCategory](r)
+}
+
+// CreateEmojiCategory creates a custom emoji category.
+func (c *Client4) CreateEmojiCategory(ctx context.Context, category *EmojiCategory) (*EmojiCategory, *Response, error) {
+	r, err := c.DoAPIPostJSON(ctx, c.emojisRoute()+"/categories", category)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*EmojiCategory](r)
+}
+
+// DeleteEmojiCategory deletes a custom emoji category. The emojis of the category are kept.
+func (c *Client4) DeleteEmojiCategory(ctx context.Context, categoryID string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.emojisRoute()+"/categories/"+categoryID)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// ImportEmojiPack creates the custom emojis of an emoji pack, a zip file holding a manifest and
+// the images of the emojis.
+func (c *Client4) ImportEmojiPack(ctx context.Context, data []byte, filename string) (*EmojiPackImportResult, *Response, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err = io.Copy(part, bytes.NewBuffer(data)); err != nil {
+		return nil, nil, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	r, err := c.doAPIRequestReader(ctx, http.MethodPost, c.APIURL+c.emojisRoute()+"/import", writer.FormDataContentType(), body, nil)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*EmojiPackImportResult](r)
+}
+
+// ExportEmojiPack returns all the custom emojis as an emoji pack.
+func (c *Client4) ExportEmojiPack(ctx context.Context) ([]byte, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.emojisRoute()+"/export", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return ReadBytesFromResponse(r)
+}
+
 // Reaction Section
 
 // SaveReaction saves an emoji reaction for a post. Returns the saved reaction if successful, otherwise an error will be returned.
@@ -6223,6 +6577,16 @@ func (c *Client4) EnablePlugin(ctx context.Context, id string) (*Response, error
 	return BuildResponse(r), nil
 }
 
+// ApprovePluginPermissions will approve the permissions declared in the manifest of an installed plugin.
+func (c *Client4) ApprovePluginPermissions(ctx context.Context, id string) (*Response, error) {
+	r, err := c.DoAPIPost(ctx, c.pluginRoute(id)+"/permissions/approve", "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // DisablePlugin will disable an enabled plugin.
 func (c *Client4) DisablePlugin(ctx context.Context, id string) (*Response, error) {
 	r, err := c.DoAPIPost(ctx, c.pluginRoute(id)+"/disable", "")
@@ -6330,6 +6694,37 @@ func (c *Client4) GetServerBusy(ctx context.Context) (*ServerBusyState, *Respons
 	return DecodeJSONFromResponse[*ServerBusyState](r)
 }
 
+// GetStoreFaults returns the faults injected in the store methods. Only available in local mode.
+func (c *Client4) GetStoreFaults(ctx context.Context) ([]*StoreFault, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.storeFaultsRoute(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*StoreFault](r)
+}
+
+// SetStoreFaults replaces the faults injected in the store methods. Only available in local mode,
+// with the StoreFaultInjection feature flag enabled.
+func (c *Client4) SetStoreFaults(ctx context.Context, faults []*StoreFault) (*Response, error) {
+	r, err := c.DoAPIPutJSON(ctx, c.storeFaultsRoute(), faults)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// ClearStoreFaults stops injecting faults in the store methods. Only available in local mode.
+func (c *Client4) ClearStoreFaults(ctx context.Context) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.storeFaultsRoute())
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // RegisterTermsOfServiceAction saves action performed by a user against a specific terms of service.
 func (c *Client4) RegisterTermsOfServiceAction(ctx context.Context, userID, termsOfServiceID string, accepted bool) (*Response, error) {
 	url := c.userTermsOfServiceRoute(userID)
@@ -7331,6 +7726,80 @@ func (c *Client4) UninviteRemoteClusterToChannel(ctx context.Context, remoteID,
 	return BuildResponse(r), nil
 }
 
+func (c *Client4) GetSharedChannelContentPolicy(ctx context.Context, remoteID string) (*SharedChannelContentPolicy, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.contentPolicyRoute(remoteID), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*SharedChannelContentPolicy](r)
+}
+
+func (c *Client4) UpdateSharedChannelContentPolicy(ctx context.Context, remoteID string, policy *SharedChannelContentPolicy) (*SharedChannelContentPolicy, *Response, error) {
+	r, err := c.DoAPIPutJSON(ctx, c.contentPolicyRoute(remoteID), policy)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*SharedChannelContentPolicy](r)
+}
+
+func (c *Client4) DeleteSharedChannelContentPolicy(ctx context.Context, remoteID string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.contentPolicyRoute(remoteID))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+func (c *Client4) GetSharedChannelFilterLog(ctx context.Context, remoteID string, page, perPage int) ([]*SharedChannelFilterLogEntry, *Response, error) {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	r, err := c.DoAPIGet(ctx, c.contentPolicyRoute(remoteID)+"/log?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*SharedChannelFilterLogEntry](r)
+}
+
+// GetSharedChannelHeldPosts returns the posts held for approval by the remote's content policy.
+// An empty status returns held posts of any status.
+func (c *Client4) GetSharedChannelHeldPosts(ctx context.Context, remoteID, status string, page, perPage int) ([]*SharedChannelHeldPost, *Response, error) {
+	values := url.Values{}
+	if status != "" {
+		values.Set("status", status)
+	}
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	r, err := c.DoAPIGet(ctx, c.heldPostsRoute(remoteID)+"?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*SharedChannelHeldPost](r)
+}
+
+func (c *Client4) ApproveSharedChannelHeldPost(ctx context.Context, remoteID, postID string) (*SharedChannelHeldPost, *Response, error) {
+	r, err := c.DoAPIPost(ctx, fmt.Sprintf("%s/%s/approve", c.heldPostsRoute(remoteID), postID), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*SharedChannelHeldPost](r)
+}
+
+func (c *Client4) RejectSharedChannelHeldPost(ctx context.Context, remoteID, postID string) (*SharedChannelHeldPost, *Response, error) {
+	r, err := c.DoAPIPost(ctx, fmt.Sprintf("%s/%s/reject", c.heldPostsRoute(remoteID), postID), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*SharedChannelHeldPost](r)
+}
+
 func (c *Client4) GetAncillaryPermissions(ctx context.Context, subsectionPermissions []string) ([]string, *Response, error) {
 	var returnedPermissions []string
 	url := fmt.Sprintf("%s/ancillary", c.permissionsRoute())
@@ -7727,3 +8196,139 @@ func (c *Client4) SearchChannelsForAccessControlPolicy(ctx context.Context, poli
 	defer closeBody(r)
 	return DecodeJSONFromResponse[*ChannelsWithCount](r)
 }
+
+// Outbound Email Queue Section
+
+// GetOutgoingEmails returns a page of emails in the outbound queue with the
+// given status, along with the total number of emails with that status.
+func (c *Client4) GetOutgoingEmails(ctx context.Context, status string, page, perPage int) (*OutgoingEmailsWithCount, *Response, error) {
+	values := url.Values{}
+	values.Set("status", status)
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	r, err := c.DoAPIGet(ctx, c.emailRoute()+"/outbound_queue?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*OutgoingEmailsWithCount](r)
+}
+
+// RetryOutgoingEmail moves an email back to the pending state so that its
+// delivery is attempted again.
+func (c *Client4) RetryOutgoingEmail(ctx context.Context, emailID string) (*OutgoingEmail, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.outgoingEmailRoute(emailID)+"/retry", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*OutgoingEmail](r)
+}
+
+// DeleteOutgoingEmail removes an email from the outbound queue.
+func (c *Client4) DeleteOutgoingEmail(ctx context.Context, emailID string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.outgoingEmailRoute(emailID))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// UploadDKIMPrivateKey uploads the PEM encoded private key used to DKIM sign
+// outgoing email.
+func (c *Client4) UploadDKIMPrivateKey(ctx context.Context, data []byte, fileName string) (*Response, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("private_key", fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file for DKIM private key upload: %w", err)
+	}
+
+	if _, err = io.Copy(part, bytes.NewBuffer(data)); err != nil {
+		return nil, fmt.Errorf("failed to copy DKIM private key data to form file: %w", err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer for DKIM private key upload: %w", err)
+	}
+
+	r, err := c.doAPIRequestReader(ctx, http.MethodPost, c.APIURL+c.emailRoute()+"/dkim/private_key", writer.FormDataContentType(), body, nil)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// RemoveDKIMPrivateKey removes the DKIM private key and disables DKIM signing.
+func (c *Client4) RemoveDKIMPrivateKey(ctx context.Context) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.emailRoute()+"/dkim/private_key")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// Email Templates Section
+
+// GetEmailTemplates returns the email templates that can be overridden, along
+// with the locales they are overridden for.
+func (c *Client4) GetEmailTemplates(ctx context.Context) ([]*EmailTemplate, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.emailTemplatesRoute(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*EmailTemplate](r)
+}
+
+// GetEmailTemplatePreview renders an email template for the locale with
+// sample data.
+func (c *Client4) GetEmailTemplatePreview(ctx context.Context, name, locale string) (*EmailTemplatePreview, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.emailTemplateRoute(name, locale)+"/preview", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*EmailTemplatePreview](r)
+}
+
+// UploadEmailTemplate overrides an email template for the locale.
+func (c *Client4) UploadEmailTemplate(ctx context.Context, name, locale string, data []byte) (*Response, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("template", name+".html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file for email template upload: %w", err)
+	}
+
+	if _, err = io.Copy(part, bytes.NewBuffer(data)); err != nil {
+		return nil, fmt.Errorf("failed to copy email template data to form file: %w", err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer for email template upload: %w", err)
+	}
+
+	r, err := c.doAPIRequestReader(ctx, http.MethodPost, c.APIURL+c.emailTemplateRoute(name, locale), writer.FormDataContentType(), body, nil)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// ResetEmailTemplate removes the override of an email template for the
+// locale.
+func (c *Client4) ResetEmailTemplate(ctx context.Context, name, locale string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.emailTemplateRoute(name, locale))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
@@ -141,7 +141,9 @@ const (
 	ExportSettingsDefaultDirectory     = "./export"
 	ExportSettingsDefaultRetentionDays = 30
 
-	EmailSettingsDefaultFeedbackOrganization = ""
+	EmailSettingsDefaultFeedbackOrganization     = ""
+	EmailSettingsDefaultOutboundQueueMaxAttempts = 8
+	EmailSettingsDefaultOutboundQueueWorkers     = 2
 
 	SupportSettingsDefaultTermsOfServiceLink = "https://mattermost.com/pl/terms-of-use/"
 	SupportSettingsDefaultPrivacyPolicyLink  = "https://mattermost.com/pl/privacy-policy/"
@@ -274,12 +276,27 @@ const (
 	CloudSettingsDefaultCwsURLTest    = "https://portal.test.cloud.mattermost.com"
 	CloudSettingsDefaultCwsAPIURLTest = "https://api.internal.test.cloud.mattermost.com"
 
-	OpenidSettingsDefaultScope = "profile openid email"
+	OpenidSettingsDefaultScope          = "profile openid email"
+	OpenidSettingsDefaultSubjectClaim   = "sub"
+	OpenidSettingsDefaultUsernameClaim  = "preferred_username"
+	OpenidSettingsDefaultEmailClaim     = "email"
+	OpenidSettingsDefaultFirstNameClaim = "given_name"
+	OpenidSettingsDefaultLastNameClaim  = "family_name"
+	OpenidSettingsDefaultNicknameClaim  = "nickname"
+
+	ScimSettingsMinBearerTokenLength = 32
+
+	MagicLinkSettingsDefaultExpiryInMinutes           = 15
+	MagicLinkSettingsMaxExpiryInMinutes               = 24 * 60
+	MagicLinkSettingsDefaultMaxRequestsPerUserPerHour = 5
+	MagicLinkSettingsDefaultMaxRequestsPerIpPerHour   = 20
 
 	LocalModeSocketPath = "/var/tmp/mattermost_local.socket"
 
-	ConnectedWorkspacesSettingsDefaultMaxPostsPerSync     = 50 // a bit more than 4 typical screenfulls of posts
-	ConnectedWorkspacesSettingsDefaultMemberSyncBatchSize = 20 // optimal batch size for syncing channel members
+	ConnectedWorkspacesSettingsDefaultMaxPostsPerSync       = 50 // a bit more than 4 typical screenfulls of posts
+	ConnectedWorkspacesSettingsDefaultMemberSyncBatchSize   = 20 // optimal batch size for syncing channel members
+	ConnectedWorkspacesSettingsDefaultMatrixSenderLocalpart = "mattermost"
+	ConnectedWorkspacesSettingsDefaultMatrixUserPrefix      = "mattermost_"
 
 	// These storage classes are the valid values for the x-amz-storage-class header. More documentation here https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObject.html#AmazonS3-PutObject-request-header-StorageClass
 	StorageClassStandard           = "STANDARD"
@@ -1123,6 +1140,9 @@ type MetricsSettings struct {
 	EnableClientMetrics       *bool    `access:"environment_performance_monitoring,write_restrictable,cloud_restrictable"`
 	EnableNotificationMetrics *bool    `access:"environment_performance_monitoring,write_restrictable,cloud_restrictable"`
 	ClientSideUserIDs         []string `access:"environment_performance_monitoring,write_restrictable,cloud_restrictable"` // telemetry: none
+	EnableTracing             *bool    `access:"environment_performance_monitoring,write_restrictable,cloud_restrictable"`
+	TracingEndpoint           *string  `access:"environment_performance_monitoring,write_restrictable,cloud_restrictable"` // telemetry: none
+	TracingSampleRate         *float64 `access:"environment_performance_monitoring,write_restrictable,cloud_restrictable"`
 }
 
 func (s *MetricsSettings) SetDefaults() {
@@ -1149,6 +1169,18 @@ func (s *MetricsSettings) SetDefaults() {
 	if s.ClientSideUserIDs == nil {
 		s.ClientSideUserIDs = []string{}
 	}
+
+	if s.EnableTracing == nil {
+		s.EnableTracing = NewPointer(false)
+	}
+
+	if s.TracingEndpoint == nil {
+		s.TracingEndpoint = NewPointer("http://localhost:4318/v1/traces")
+	}
+
+	if s.TracingSampleRate == nil {
+		s.TracingSampleRate = NewPointer(1.0)
+	}
 }
 
 func (s *MetricsSettings) isValid() *AppError {
@@ -1161,6 +1193,17 @@ func (s *MetricsSettings) isValid() *AppError {
 			return NewAppError("MetricsSettings.IsValid", "model.config.is_valid.metrics_client_side_user_id.app_error", map[string]any{"ID": id}, "", http.StatusBadRequest)
 		}
 	}
+
+	if *s.EnableTracing {
+		if u, err := url.Parse(*s.TracingEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return NewAppError("MetricsSettings.IsValid", "model.config.is_valid.metrics_tracing_endpoint.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if *s.TracingSampleRate < 0 || *s.TracingSampleRate > 1 {
+		return NewAppError("MetricsSettings.IsValid", "model.config.is_valid.metrics_tracing_sample_rate.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -1345,6 +1388,149 @@ func (s *Office365Settings) setDefaults() {
 	}
 }
 
+// OpenIdSettings configures login through a generic OpenID Connect provider. Claims may be nested,
+// e.g. "realm_access.roles".
+type OpenIdSettings struct {
+	Enable                  *bool   `access:"authentication_openid"`
+	Secret                  *string `access:"authentication_openid"` // telemetry: none
+	ID                      *string `access:"authentication_openid"` // telemetry: none
+	Scope                   *string `access:"authentication_openid"` // telemetry: none
+	AuthEndpoint            *string `access:"authentication_openid"` // telemetry: none
+	TokenEndpoint           *string `access:"authentication_openid"` // telemetry: none
+	UserAPIEndpoint         *string `access:"authentication_openid"` // telemetry: none
+	DiscoveryEndpoint       *string `access:"authentication_openid"` // telemetry: none
+	ButtonText              *string `access:"authentication_openid"` // telemetry: none
+	ButtonColor             *string `access:"authentication_openid"` // telemetry: none
+	SubjectClaim            *string `access:"authentication_openid"` // telemetry: none
+	UsernameClaim           *string `access:"authentication_openid"` // telemetry: none
+	EmailClaim              *string `access:"authentication_openid"` // telemetry: none
+	FirstNameClaim          *string `access:"authentication_openid"` // telemetry: none
+	LastNameClaim           *string `access:"authentication_openid"` // telemetry: none
+	NicknameClaim           *string `access:"authentication_openid"` // telemetry: none
+	GroupsClaim             *string `access:"authentication_openid"` // telemetry: none
+	RolesClaim              *string `access:"authentication_openid"` // telemetry: none
+	SystemAdminRoles        *string `access:"authentication_openid"` // telemetry: none
+	GuestRoles              *string `access:"authentication_openid"` // telemetry: none
+	EnableRPInitiatedLogout *bool   `access:"authentication_openid"`
+}
+
+func (s *OpenIdSettings) setDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewPointer(false)
+	}
+
+	if s.Secret == nil {
+		s.Secret = NewPointer("")
+	}
+
+	if s.ID == nil {
+		s.ID = NewPointer("")
+	}
+
+	if s.Scope == nil {
+		s.Scope = NewPointer(OpenidSettingsDefaultScope)
+	}
+
+	if s.DiscoveryEndpoint == nil {
+		s.DiscoveryEndpoint = NewPointer("")
+	}
+
+	if s.AuthEndpoint == nil {
+		s.AuthEndpoint = NewPointer("")
+	}
+
+	if s.TokenEndpoint == nil {
+		s.TokenEndpoint = NewPointer("")
+	}
+
+	if s.UserAPIEndpoint == nil {
+		s.UserAPIEndpoint = NewPointer("")
+	}
+
+	if s.ButtonText == nil {
+		s.ButtonText = NewPointer("")
+	}
+
+	if s.ButtonColor == nil {
+		s.ButtonColor = NewPointer("#145DBF")
+	}
+
+	if s.SubjectClaim == nil {
+		s.SubjectClaim = NewPointer(OpenidSettingsDefaultSubjectClaim)
+	}
+
+	if s.UsernameClaim == nil {
+		s.UsernameClaim = NewPointer(OpenidSettingsDefaultUsernameClaim)
+	}
+
+	if s.EmailClaim == nil {
+		s.EmailClaim = NewPointer(OpenidSettingsDefaultEmailClaim)
+	}
+
+	if s.FirstNameClaim == nil {
+		s.FirstNameClaim = NewPointer(OpenidSettingsDefaultFirstNameClaim)
+	}
+
+	if s.LastNameClaim == nil {
+		s.LastNameClaim = NewPointer(OpenidSettingsDefaultLastNameClaim)
+	}
+
+	if s.NicknameClaim == nil {
+		s.NicknameClaim = NewPointer(OpenidSettingsDefaultNicknameClaim)
+	}
+
+	if s.GroupsClaim == nil {
+		s.GroupsClaim = NewPointer("")
+	}
+
+	if s.RolesClaim == nil {
+		s.RolesClaim = NewPointer("")
+	}
+
+	if s.SystemAdminRoles == nil {
+		s.SystemAdminRoles = NewPointer("")
+	}
+
+	if s.GuestRoles == nil {
+		s.GuestRoles = NewPointer("")
+	}
+
+	if s.EnableRPInitiatedLogout == nil {
+		s.EnableRPInitiatedLogout = NewPointer(false)
+	}
+}
+
+func (s *OpenIdSettings) isValid() *AppError {
+	if !*s.Enable {
+		return nil
+	}
+
+	if *s.SubjectClaim == "" || *s.EmailClaim == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.openid_claims.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if (*s.SystemAdminRoles != "" || *s.GuestRoles != "") && *s.RolesClaim == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.openid_roles_claim.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (s *OpenIdSettings) SSOSettings() *SSOSettings {
+	ssoSettings := SSOSettings{}
+	ssoSettings.Enable = s.Enable
+	ssoSettings.Secret = s.Secret
+	ssoSettings.ID = s.ID
+	ssoSettings.Scope = s.Scope
+	ssoSettings.DiscoveryEndpoint = s.DiscoveryEndpoint
+	ssoSettings.AuthEndpoint = s.AuthEndpoint
+	ssoSettings.TokenEndpoint = s.TokenEndpoint
+	ssoSettings.UserAPIEndpoint = s.UserAPIEndpoint
+	ssoSettings.ButtonText = s.ButtonText
+	ssoSettings.ButtonColor = s.ButtonColor
+	return &ssoSettings
+}
+
 func (s *Office365Settings) SSOSettings() *SSOSettings {
 	ssoSettings := SSOSettings{}
 	ssoSettings.Enable = s.Enable
@@ -1358,6 +1544,225 @@ func (s *Office365Settings) SSOSettings() *SSOSettings {
 	return &ssoSettings
 }
 
+// ScimSettings configures the SCIM 2.0 provisioning API served under /scim/v2.
+type ScimSettings struct {
+	Enable      *bool   `access:"authentication_signup"`
+	BearerToken *string `access:"authentication_signup"` // telemetry: none
+	// AuthService is the authentication service of provisioned users. When set, the SCIM externalId
+	// is stored as their AuthData, otherwise they sign in with email and password.
+	AuthService *string `access:"authentication_signup"`
+}
+
+func (s *ScimSettings) setDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewPointer(false)
+	}
+
+	if s.BearerToken == nil {
+		s.BearerToken = NewPointer("")
+	}
+
+	if s.AuthService == nil {
+		s.AuthService = NewPointer("")
+	}
+}
+
+func (s *ScimSettings) isValid() *AppError {
+	if !*s.Enable {
+		return nil
+	}
+
+	if len(*s.BearerToken) < ScimSettingsMinBearerTokenLength {
+		return NewAppError("Config.IsValid", "model.config.is_valid.scim_bearer_token.app_error", map[string]any{"MinLength": ScimSettingsMinBearerTokenLength}, "", http.StatusBadRequest)
+	}
+
+	switch *s.AuthService {
+	case "", UserAuthServiceSaml, ServiceGitlab, ServiceGoogle, ServiceOffice365, ServiceOpenid:
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.scim_auth_service.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+const (
+	// SessionPolicyLimitActionRevokeOldest revokes the least recently used sessions to make room
+	// for a new one, so that the newest login wins.
+	SessionPolicyLimitActionRevokeOldest = "revoke_oldest"
+	// SessionPolicyLimitActionDeny rejects new logins once the limit is reached.
+	SessionPolicyLimitActionDeny = "deny"
+)
+
+// SessionPolicy restricts the sessions of the users having one of Roles or belonging to one of
+// GroupIds. Limits of 0 are unlimited.
+type SessionPolicy struct {
+	Name                 *string  `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	Roles                []string `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	GroupIds             []string `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	MaxSessions          *int     `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	MaxWebSessions       *int     `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	MaxDesktopSessions   *int     `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	MaxMobileSessions    *int     `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	IdleTimeoutInMinutes *int     `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	LimitAction          *string  `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+}
+
+func (p *SessionPolicy) setDefaults() {
+	if p.Name == nil {
+		p.Name = NewPointer("")
+	}
+
+	if p.Roles == nil {
+		p.Roles = []string{}
+	}
+
+	if p.GroupIds == nil {
+		p.GroupIds = []string{}
+	}
+
+	if p.MaxSessions == nil {
+		p.MaxSessions = NewPointer(0)
+	}
+
+	if p.MaxWebSessions == nil {
+		p.MaxWebSessions = NewPointer(0)
+	}
+
+	if p.MaxDesktopSessions == nil {
+		p.MaxDesktopSessions = NewPointer(0)
+	}
+
+	if p.MaxMobileSessions == nil {
+		p.MaxMobileSessions = NewPointer(0)
+	}
+
+	if p.IdleTimeoutInMinutes == nil {
+		p.IdleTimeoutInMinutes = NewPointer(0)
+	}
+
+	if p.LimitAction == nil {
+		p.LimitAction = NewPointer(SessionPolicyLimitActionRevokeOldest)
+	}
+}
+
+// MaxSessionsForDeviceType returns the limit of sessions of the given device type, 0 if unlimited.
+func (p *SessionPolicy) MaxSessionsForDeviceType(deviceType string) int {
+	switch deviceType {
+	case SessionDeviceTypeMobile:
+		return *p.MaxMobileSessions
+	case SessionDeviceTypeDesktop:
+		return *p.MaxDesktopSessions
+	default:
+		return *p.MaxWebSessions
+	}
+}
+
+// SessionPolicySettings configures the session policies. The first policy matching a user
+// applies, so that more specific policies should come first.
+type SessionPolicySettings struct {
+	Enable   *bool            `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	Policies []*SessionPolicy `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+}
+
+func (s *SessionPolicySettings) setDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewPointer(false)
+	}
+
+	if s.Policies == nil {
+		s.Policies = []*SessionPolicy{}
+	}
+
+	for _, policy := range s.Policies {
+		policy.setDefaults()
+	}
+}
+
+func (s *SessionPolicySettings) isValid() *AppError {
+	for _, policy := range s.Policies {
+		if *policy.Name == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.session_policy_name.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if len(policy.Roles) == 0 && len(policy.GroupIds) == 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.session_policy_scope.app_error", map[string]any{"Name": *policy.Name}, "", http.StatusBadRequest)
+		}
+
+		for _, groupID := range policy.GroupIds {
+			if !IsValidId(groupID) {
+				return NewAppError("Config.IsValid", "model.config.is_valid.session_policy_group_id.app_error", map[string]any{"Name": *policy.Name}, "", http.StatusBadRequest)
+			}
+		}
+
+		if *policy.MaxSessions < 0 || *policy.MaxWebSessions < 0 || *policy.MaxDesktopSessions < 0 || *policy.MaxMobileSessions < 0 || *policy.IdleTimeoutInMinutes < 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.session_policy_limits.app_error", map[string]any{"Name": *policy.Name}, "", http.StatusBadRequest)
+		}
+
+		if *policy.LimitAction != SessionPolicyLimitActionRevokeOldest && *policy.LimitAction != SessionPolicyLimitActionDeny {
+			return NewAppError("Config.IsValid", "model.config.is_valid.session_policy_limit_action.app_error", map[string]any{"Name": *policy.Name}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// MagicLinkSettings configures the passwordless login, which emails users a single-use link
+// signing them in. It is enabled separately for each authentication service.
+type MagicLinkSettings struct {
+	EnableForEmail            *bool `access:"authentication_email"`
+	EnableForLdap             *bool `access:"authentication_ldap"`
+	ExpiryInMinutes           *int  `access:"authentication_email"`
+	MaxRequestsPerUserPerHour *int  `access:"authentication_email"`
+	MaxRequestsPerIpPerHour   *int  `access:"authentication_email"`
+}
+
+func (s *MagicLinkSettings) setDefaults() {
+	if s.EnableForEmail == nil {
+		s.EnableForEmail = NewPointer(false)
+	}
+
+	if s.EnableForLdap == nil {
+		s.EnableForLdap = NewPointer(false)
+	}
+
+	if s.ExpiryInMinutes == nil {
+		s.ExpiryInMinutes = NewPointer(MagicLinkSettingsDefaultExpiryInMinutes)
+	}
+
+	if s.MaxRequestsPerUserPerHour == nil {
+		s.MaxRequestsPerUserPerHour = NewPointer(MagicLinkSettingsDefaultMaxRequestsPerUserPerHour)
+	}
+
+	if s.MaxRequestsPerIpPerHour == nil {
+		s.MaxRequestsPerIpPerHour = NewPointer(MagicLinkSettingsDefaultMaxRequestsPerIpPerHour)
+	}
+}
+
+func (s *MagicLinkSettings) isValid() *AppError {
+	if *s.ExpiryInMinutes <= 0 || *s.ExpiryInMinutes > MagicLinkSettingsMaxExpiryInMinutes {
+		return NewAppError("Config.IsValid", "model.config.is_valid.magic_link_expiry.app_error", map[string]any{"Max": MagicLinkSettingsMaxExpiryInMinutes}, "", http.StatusBadRequest)
+	}
+
+	if *s.MaxRequestsPerUserPerHour <= 0 || *s.MaxRequestsPerIpPerHour <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.magic_link_max_requests.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsEnabledForAuthService reports whether users of the given authentication service can sign in
+// with a magic link.
+func (s *MagicLinkSettings) IsEnabledForAuthService(authService string) bool {
+	switch authService {
+	case "", UserAuthServiceEmail:
+		return *s.EnableForEmail
+	case UserAuthServiceLdap:
+		return *s.EnableForLdap
+	default:
+		return false
+	}
+}
+
 type ReplicaLagSettings struct {
 	DataSource       *string `access:"environment,write_restrictable,cloud_restrictable"` // telemetry: none
 	QueryAbsoluteLag *string `access:"environment,write_restrictable,cloud_restrictable"` // telemetry: none
@@ -1918,36 +2323,44 @@ func (s *FileSettings) SetDefaults(isUpdate bool) {
 }
 
 type EmailSettings struct {
-	EnableSignUpWithEmail             *bool   `access:"authentication_email"`
-	EnableSignInWithEmail             *bool   `access:"authentication_email"`
-	EnableSignInWithUsername          *bool   `access:"authentication_email"`
-	SendEmailNotifications            *bool   `access:"site_notifications"`
-	UseChannelInEmailNotifications    *bool   `access:"experimental_features"`
-	RequireEmailVerification          *bool   `access:"authentication_email"`
-	FeedbackName                      *string `access:"site_notifications"`
-	FeedbackEmail                     *string `access:"site_notifications,cloud_restrictable"`
-	ReplyToAddress                    *string `access:"site_notifications,cloud_restrictable"`
-	FeedbackOrganization              *string `access:"site_notifications"`
-	EnableSMTPAuth                    *bool   `access:"environment_smtp,write_restrictable,cloud_restrictable"`
-	SMTPUsername                      *string `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
-	SMTPPassword                      *string `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
-	SMTPServer                        *string `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
-	SMTPPort                          *string `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
-	SMTPServerTimeout                 *int    `access:"cloud_restrictable"`
-	ConnectionSecurity                *string `access:"environment_smtp,write_restrictable,cloud_restrictable"`
-	SendPushNotifications             *bool   `access:"environment_push_notification_server"`
-	PushNotificationServer            *string `access:"environment_push_notification_server"` // telemetry: none
-	PushNotificationContents          *string `access:"site_notifications"`
-	PushNotificationBuffer            *int    // telemetry: none
-	EnableEmailBatching               *bool   `access:"site_notifications"`
-	EmailBatchingBufferSize           *int    `access:"experimental_features"`
-	EmailBatchingInterval             *int    `access:"experimental_features"`
-	EnablePreviewModeBanner           *bool   `access:"site_notifications"`
-	SkipServerCertificateVerification *bool   `access:"environment_smtp,write_restrictable,cloud_restrictable"`
-	EmailNotificationContentsType     *string `access:"site_notifications"`
-	LoginButtonColor                  *string `access:"experimental_features"`
-	LoginButtonBorderColor            *string `access:"experimental_features"`
-	LoginButtonTextColor              *string `access:"experimental_features"`
+	EnableSignUpWithEmail             *bool    `access:"authentication_email"`
+	EnableSignInWithEmail             *bool    `access:"authentication_email"`
+	EnableSignInWithUsername          *bool    `access:"authentication_email"`
+	SendEmailNotifications            *bool    `access:"site_notifications"`
+	UseChannelInEmailNotifications    *bool    `access:"experimental_features"`
+	RequireEmailVerification          *bool    `access:"authentication_email"`
+	FeedbackName                      *string  `access:"site_notifications"`
+	FeedbackEmail                     *string  `access:"site_notifications,cloud_restrictable"`
+	ReplyToAddress                    *string  `access:"site_notifications,cloud_restrictable"`
+	FeedbackOrganization              *string  `access:"site_notifications"`
+	EnableSMTPAuth                    *bool    `access:"environment_smtp,write_restrictable,cloud_restrictable"`
+	SMTPUsername                      *string  `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
+	SMTPPassword                      *string  `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
+	SMTPServer                        *string  `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
+	SMTPPort                          *string  `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
+	SMTPServerTimeout                 *int     `access:"cloud_restrictable"`
+	ConnectionSecurity                *string  `access:"environment_smtp,write_restrictable,cloud_restrictable"`
+	SendPushNotifications             *bool    `access:"environment_push_notification_server"`
+	PushNotificationServer            *string  `access:"environment_push_notification_server"` // telemetry: none
+	PushNotificationContents          *string  `access:"site_notifications"`
+	PushNotificationBuffer            *int     // telemetry: none
+	EnableEmailBatching               *bool    `access:"site_notifications"`
+	EmailBatchingBufferSize           *int     `access:"experimental_features"`
+	EmailBatchingInterval             *int     `access:"experimental_features"`
+	EnablePreviewModeBanner           *bool    `access:"site_notifications"`
+	SkipServerCertificateVerification *bool    `access:"environment_smtp,write_restrictable,cloud_restrictable"`
+	EmailNotificationContentsType     *string  `access:"site_notifications"`
+	LoginButtonColor                  *string  `access:"experimental_features"`
+	LoginButtonBorderColor            *string  `access:"experimental_features"`
+	LoginButtonTextColor              *string  `access:"experimental_features"`
+	EnableOutboundEmailQueue          *bool    `access:"environment_smtp,write_restrictable,cloud_restrictable"`
+	OutboundEmailQueueMaxAttempts     *int     `access:"environment_smtp,write_restrictable,cloud_restrictable"`
+	OutboundEmailQueueWorkers         *int     `access:"environment_smtp,write_restrictable,cloud_restrictable"`
+	EnableDKIMSigning                 *bool    `access:"environment_smtp,write_restrictable,cloud_restrictable"`
+	DKIMDomain                        *string  `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
+	DKIMSelector                      *string  `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
+	DKIMPrivateKeyFile                *string  `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
+	TemplateOverrideFiles             []string `access:"site_customization"`                                     // telemetry: none
 }
 
 func (s *EmailSettings) SetDefaults(isUpdate bool) {
@@ -2079,6 +2492,38 @@ func (s *EmailSettings) SetDefaults(isUpdate bool) {
 		s.EmailNotificationContentsType = NewPointer(EmailNotificationContentsFull)
 	}
 
+	if s.EnableOutboundEmailQueue == nil {
+		s.EnableOutboundEmailQueue = NewPointer(false)
+	}
+
+	if s.OutboundEmailQueueMaxAttempts == nil {
+		s.OutboundEmailQueueMaxAttempts = NewPointer(EmailSettingsDefaultOutboundQueueMaxAttempts)
+	}
+
+	if s.OutboundEmailQueueWorkers == nil {
+		s.OutboundEmailQueueWorkers = NewPointer(EmailSettingsDefaultOutboundQueueWorkers)
+	}
+
+	if s.EnableDKIMSigning == nil {
+		s.EnableDKIMSigning = NewPointer(false)
+	}
+
+	if s.DKIMDomain == nil {
+		s.DKIMDomain = NewPointer("")
+	}
+
+	if s.DKIMSelector == nil {
+		s.DKIMSelector = NewPointer("")
+	}
+
+	if s.DKIMPrivateKeyFile == nil {
+		s.DKIMPrivateKeyFile = NewPointer("")
+	}
+
+	if s.TemplateOverrideFiles == nil {
+		s.TemplateOverrideFiles = []string{}
+	}
+
 	if s.LoginButtonColor == nil {
 		s.LoginButtonColor = NewPointer("#0000")
 	}
@@ -2092,6 +2537,45 @@ func (s *EmailSettings) SetDefaults(isUpdate bool) {
 	}
 }
 
+const (
+	RateLimitRouteGroupLogin      = "login"
+	RateLimitRouteGroupSearch     = "search"
+	RateLimitRouteGroupFileUpload = "file_upload"
+	RateLimitRouteGroupPostCreate = "post_create"
+
+	RateLimitPrincipalUser  = "user"
+	RateLimitPrincipalBot   = "bot"
+	RateLimitPrincipalToken = "token"
+	RateLimitPrincipalIP    = "ip"
+)
+
+// RateLimitPolicy limits the requests of a principal to the routes of a group, on top of the
+// global rate limit. The requests without a session are limited by IP address.
+type RateLimitPolicy struct {
+	RouteGroup *string `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	Principal  *string `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	PerSec     *int    `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	MaxBurst   *int    `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+}
+
+func (p *RateLimitPolicy) setDefaults() {
+	if p.RouteGroup == nil {
+		p.RouteGroup = NewPointer("")
+	}
+
+	if p.Principal == nil {
+		p.Principal = NewPointer("")
+	}
+
+	if p.PerSec == nil {
+		p.PerSec = NewPointer(10)
+	}
+
+	if p.MaxBurst == nil {
+		p.MaxBurst = NewPointer(100)
+	}
+}
+
 type RateLimitSettings struct {
 	Enable           *bool  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
 	PerSec           *int   `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
@@ -2100,6 +2584,9 @@ type RateLimitSettings struct {
 	VaryByRemoteAddr *bool  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
 	VaryByUser       *bool  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
 	VaryByHeader     string `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	// Policies limit the route groups per principal. The limits are shared by the nodes when
+	// Redis is used as the cache backend.
+	Policies []*RateLimitPolicy `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
 }
 
 func (s *RateLimitSettings) SetDefaults() {
@@ -2126,6 +2613,29 @@ func (s *RateLimitSettings) SetDefaults() {
 	if s.VaryByUser == nil {
 		s.VaryByUser = NewPointer(false)
 	}
+
+	if s.Policies == nil {
+		s.Policies = []*RateLimitPolicy{
+			{RouteGroup: NewPointer(RateLimitRouteGroupLogin), Principal: NewPointer(RateLimitPrincipalIP), PerSec: NewPointer(1), MaxBurst: NewPointer(20)},
+			{RouteGroup: NewPointer(RateLimitRouteGroupSearch), Principal: NewPointer(RateLimitPrincipalUser), PerSec: NewPointer(5), MaxBurst: NewPointer(50)},
+			{RouteGroup: NewPointer(RateLimitRouteGroupFileUpload), Principal: NewPointer(RateLimitPrincipalUser), PerSec: NewPointer(5), MaxBurst: NewPointer(50)},
+			{RouteGroup: NewPointer(RateLimitRouteGroupPostCreate), Principal: NewPointer(RateLimitPrincipalBot), PerSec: NewPointer(10), MaxBurst: NewPointer(100)},
+		}
+	}
+
+	for _, policy := range s.Policies {
+		policy.setDefaults()
+	}
+}
+
+// Policy returns the policy of the principal for the route group, if any.
+func (s *RateLimitSettings) Policy(routeGroup, principal string) *RateLimitPolicy {
+	for _, policy := range s.Policies {
+		if *policy.RouteGroup == routeGroup && *policy.Principal == principal {
+			return policy
+		}
+	}
+	return nil
 }
 
 type PrivacySettings struct {
@@ -3321,6 +3831,64 @@ func (s *CloudSettings) SetDefaults() {
 
 type PluginState struct {
 	Enable bool
+
+	// ApprovedPermissions are the plugin permissions a system admin approved. A plugin declaring
+	// permissions beyond them, or no permissions at all and so requesting access to everything,
+	// is not activated.
+	ApprovedPermissions *PluginPermissions `json:",omitempty"`
+}
+
+const (
+	// PluginResourceBreachActionLog only logs the plugins exceeding their resource limits.
+	PluginResourceBreachActionLog = "log"
+	// PluginResourceBreachActionRestart restarts the plugins exceeding their resource limits,
+	// deactivating them if they keep exceeding them.
+	PluginResourceBreachActionRestart = "restart"
+	// PluginResourceBreachActionDeactivate deactivates the plugins exceeding their resource limits.
+	PluginResourceBreachActionDeactivate = "deactivate"
+)
+
+// PluginResourceLimits restricts the resources used by the server process of a plugin. Limits
+// of 0 are unlimited. They are only enforced on Linux. The open files are capped on the plugin
+// process, while the memory and CPU limits are best-effort: the usage is sampled periodically
+// by the plugin health check, which must be enabled, so bursts between two samples go unnoticed.
+type PluginResourceLimits struct {
+	MaxMemoryMB   *int    `access:"plugins,write_restrictable,cloud_restrictable"`
+	MaxCPUPercent *int    `access:"plugins,write_restrictable,cloud_restrictable"`
+	MaxOpenFiles  *int    `access:"plugins,write_restrictable,cloud_restrictable"`
+	BreachAction  *string `access:"plugins,write_restrictable,cloud_restrictable"`
+}
+
+func (l *PluginResourceLimits) setDefaults() {
+	if l.MaxMemoryMB == nil {
+		l.MaxMemoryMB = NewPointer(0)
+	}
+
+	if l.MaxCPUPercent == nil {
+		l.MaxCPUPercent = NewPointer(0)
+	}
+
+	if l.MaxOpenFiles == nil {
+		l.MaxOpenFiles = NewPointer(0)
+	}
+
+	if l.BreachAction == nil {
+		l.BreachAction = NewPointer(PluginResourceBreachActionRestart)
+	}
+}
+
+func (l *PluginResourceLimits) isValid(pluginID string) *AppError {
+	if *l.MaxMemoryMB < 0 || *l.MaxCPUPercent < 0 || *l.MaxOpenFiles < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_resource_limits.app_error", map[string]any{"PluginId": pluginID}, "", http.StatusBadRequest)
+	}
+
+	switch *l.BreachAction {
+	case PluginResourceBreachActionLog, PluginResourceBreachActionRestart, PluginResourceBreachActionDeactivate:
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_resource_breach_action.app_error", map[string]any{"PluginId": pluginID}, "", http.StatusBadRequest)
+	}
+
+	return nil
 }
 
 type PluginSettings struct {
@@ -3339,6 +3907,8 @@ type PluginSettings struct {
 	MarketplaceURL              *string                   `access:"plugins,write_restrictable,cloud_restrictable"`
 	SignaturePublicKeyFiles     []string                  `access:"plugins,write_restrictable,cloud_restrictable"`
 	ChimeraOAuthProxyURL        *string                   `access:"plugins,write_restrictable,cloud_restrictable"`
+	// ResourceLimits are the resource limits of the server processes of plugins, keyed by plugin id.
+	ResourceLimits map[string]*PluginResourceLimits `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
 }
 
 func (s *PluginSettings) SetDefaults(ls LogSettings) {
@@ -3421,6 +3991,30 @@ func (s *PluginSettings) SetDefaults(ls LogSettings) {
 	if s.ChimeraOAuthProxyURL == nil {
 		s.ChimeraOAuthProxyURL = NewPointer("")
 	}
+
+	if s.ResourceLimits == nil {
+		s.ResourceLimits = make(map[string]*PluginResourceLimits)
+	}
+
+	for _, limits := range s.ResourceLimits {
+		if limits != nil {
+			limits.setDefaults()
+		}
+	}
+}
+
+func (s *PluginSettings) isValid() *AppError {
+	for pluginID, limits := range s.ResourceLimits {
+		if limits == nil {
+			continue
+		}
+
+		if appErr := limits.isValid(pluginID); appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
 }
 
 // Sanitize cleans up the plugin settings by removing any sensitive information.
@@ -3512,6 +4106,13 @@ type ConnectedWorkspacesSettings struct {
 	GlobalUserSyncBatchSize         *int
 	MaxPostsPerSync                 *int
 	MemberSyncBatchSize             *int // Maximum number of members to process in a single batch during shared channel synchronization
+	EnableMatrixBridge              *bool
+	MatrixHomeserverURL             *string
+	MatrixServerName                *string
+	MatrixAppServiceToken           *string
+	MatrixHomeserverToken           *string
+	MatrixSenderLocalpart           *string
+	MatrixUserPrefix                *string
 }
 
 func (c *ConnectedWorkspacesSettings) SetDefaults(isUpdate bool, e ExperimentalSettings) {
@@ -3550,6 +4151,72 @@ func (c *ConnectedWorkspacesSettings) SetDefaults(isUpdate bool, e ExperimentalS
 	if c.MemberSyncBatchSize == nil {
 		c.MemberSyncBatchSize = NewPointer(ConnectedWorkspacesSettingsDefaultMemberSyncBatchSize)
 	}
+
+	if c.EnableMatrixBridge == nil {
+		c.EnableMatrixBridge = NewPointer(false)
+	}
+
+	if c.MatrixHomeserverURL == nil {
+		c.MatrixHomeserverURL = NewPointer("")
+	}
+
+	if c.MatrixServerName == nil {
+		c.MatrixServerName = NewPointer("")
+	}
+
+	if c.MatrixAppServiceToken == nil {
+		c.MatrixAppServiceToken = NewPointer("")
+	}
+
+	if c.MatrixHomeserverToken == nil {
+		c.MatrixHomeserverToken = NewPointer("")
+	}
+
+	if c.MatrixSenderLocalpart == nil {
+		c.MatrixSenderLocalpart = NewPointer(ConnectedWorkspacesSettingsDefaultMatrixSenderLocalpart)
+	}
+
+	if c.MatrixUserPrefix == nil {
+		c.MatrixUserPrefix = NewPointer(ConnectedWorkspacesSettingsDefaultMatrixUserPrefix)
+	}
+}
+
+func (c *ConnectedWorkspacesSettings) isValid() *AppError {
+	if !*c.EnableMatrixBridge {
+		return nil
+	}
+
+	if !IsValidHTTPURL(*c.MatrixHomeserverURL) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.matrix_homeserver_url.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *c.MatrixServerName == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.matrix_server_name.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *c.MatrixAppServiceToken == "" || *c.MatrixHomeserverToken == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.matrix_tokens.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !isValidMatrixLocalpart(*c.MatrixSenderLocalpart) || !isValidMatrixLocalpart(*c.MatrixUserPrefix) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.matrix_localpart.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// isValidMatrixLocalpart checks the localpart of a Matrix user ID against the
+// characters allowed by the Matrix specification.
+func isValidMatrixLocalpart(localpart string) bool {
+	if localpart == "" {
+		return false
+	}
+	for _, r := range localpart {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && !strings.ContainsRune("._=-/", r) {
+			return false
+		}
+	}
+	return true
 }
 
 type GlobalRelayMessageExportSettings struct {
@@ -3661,6 +4328,9 @@ type GuestAccountsSettings struct {
 	AllowEmailAccounts               *bool   `access:"authentication_guest_access"`
 	EnforceMultifactorAuthentication *bool   `access:"authentication_guest_access"`
 	RestrictCreationToDomains        *string `access:"authentication_guest_access"`
+	// AccessExpiryReminderDays is the number of days before the access of a guest expires to remind
+	// them. Reminders are disabled with 0.
+	AccessExpiryReminderDays *int `access:"authentication_guest_access"`
 }
 
 func (s *GuestAccountsSettings) SetDefaults() {
@@ -3683,6 +4353,10 @@ func (s *GuestAccountsSettings) SetDefaults() {
 	if s.RestrictCreationToDomains == nil {
 		s.RestrictCreationToDomains = NewPointer("")
 	}
+
+	if s.AccessExpiryReminderDays == nil {
+		s.AccessExpiryReminderDays = NewPointer(GuestAccessExpiryReminderDaysDefault)
+	}
 }
 
 type ImageProxySettings struct {
@@ -3857,7 +4531,10 @@ type Config struct {
 	GitLabSettings              SSOSettings
 	GoogleSettings              SSOSettings
 	Office365Settings           Office365Settings
-	OpenIDSettings              SSOSettings
+	OpenIDSettings              OpenIdSettings
+	ScimSettings                ScimSettings
+	SessionPolicySettings       SessionPolicySettings
+	MagicLinkSettings           MagicLinkSettings
 	LdapSettings                LdapSettings
 	ComplianceSettings          ComplianceSettings
 	LocalizationSettings        LocalizationSettings
@@ -3926,7 +4603,7 @@ func (o *Config) GetSSOService(service string) *SSOSettings {
 	case ServiceOffice365:
 		return o.Office365Settings.SSOSettings()
 	case ServiceOpenid:
-		return &o.OpenIDSettings
+		return o.OpenIDSettings.SSOSettings()
 	}
 
 	return nil
@@ -3965,7 +4642,10 @@ func (o *Config) SetDefaults() {
 	o.Office365Settings.setDefaults()
 	o.GitLabSettings.setDefaults("", "", "", "", "")
 	o.GoogleSettings.setDefaults(GoogleSettingsDefaultScope, GoogleSettingsDefaultAuthEndpoint, GoogleSettingsDefaultTokenEndpoint, GoogleSettingsDefaultUserAPIEndpoint, "")
-	o.OpenIDSettings.setDefaults(OpenidSettingsDefaultScope, "", "", "", "#145DBF")
+	o.OpenIDSettings.setDefaults()
+	o.ScimSettings.setDefaults()
+	o.SessionPolicySettings.setDefaults()
+	o.MagicLinkSettings.setDefaults()
 	o.ServiceSettings.SetDefaults(isUpdate)
 	o.PasswordSettings.SetDefaults()
 	o.TeamSettings.SetDefaults()
@@ -4054,6 +4734,26 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.OpenIDSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
+	if appErr := o.ScimSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
+	if appErr := o.SessionPolicySettings.isValid(); appErr != nil {
+		return appErr
+	}
+
+	if appErr := o.PluginSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
+	if appErr := o.MagicLinkSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
 	if *o.PasswordSettings.MinimumLength < PasswordMinimumLength || *o.PasswordSettings.MinimumLength > PasswordMaximumLength {
 		return NewAppError("Config.IsValid", "model.config.is_valid.password_length.app_error", map[string]any{"MinLength": PasswordMinimumLength, "MaxLength": PasswordMaximumLength}, "", http.StatusBadRequest)
 	}
@@ -4106,6 +4806,10 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.ConnectedWorkspacesSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
 	if appErr := o.WranglerSettings.IsValid(); appErr != nil {
 		return appErr
 	}
@@ -4291,6 +4995,28 @@ func (s *EmailSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.email_notification_contents_type.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.OutboundEmailQueueMaxAttempts <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.email_outbound_queue_max_attempts.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.OutboundEmailQueueWorkers <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.email_outbound_queue_workers.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.EnableDKIMSigning {
+		if *s.DKIMDomain == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.email_dkim_domain.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if *s.DKIMSelector == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.email_dkim_selector.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if *s.DKIMPrivateKeyFile == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.email_dkim_private_key_file.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -4307,6 +5033,31 @@ func (s *RateLimitSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_burst.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	seen := make(map[string]bool, len(s.Policies))
+	for _, policy := range s.Policies {
+		switch *policy.RouteGroup {
+		case RateLimitRouteGroupLogin, RateLimitRouteGroupSearch, RateLimitRouteGroupFileUpload, RateLimitRouteGroupPostCreate:
+		default:
+			return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_policy_route_group.app_error", map[string]any{"RouteGroup": *policy.RouteGroup}, "", http.StatusBadRequest)
+		}
+
+		switch *policy.Principal {
+		case RateLimitPrincipalUser, RateLimitPrincipalBot, RateLimitPrincipalToken, RateLimitPrincipalIP:
+		default:
+			return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_policy_principal.app_error", map[string]any{"Principal": *policy.Principal}, "", http.StatusBadRequest)
+		}
+
+		if *policy.PerSec <= 0 || *policy.MaxBurst <= 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_policy_limits.app_error", map[string]any{"RouteGroup": *policy.RouteGroup, "Principal": *policy.Principal}, "", http.StatusBadRequest)
+		}
+
+		key := *policy.RouteGroup + ":" + *policy.Principal
+		if seen[key] {
+			return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_policy_duplicate.app_error", map[string]any{"RouteGroup": *policy.RouteGroup, "Principal": *policy.Principal}, "", http.StatusBadRequest)
+		}
+		seen[key] = true
+	}
+
 	return nil
 }
 
@@ -4882,6 +5633,10 @@ func (o *Config) Sanitize(pluginManifests []*Manifest, opts *SanitizeOptions) {
 		*o.OpenIDSettings.Secret = FakeSetting
 	}
 
+	if o.ScimSettings.BearerToken != nil && *o.ScimSettings.BearerToken != "" {
+		*o.ScimSettings.BearerToken = FakeSetting
+	}
+
 	if o.SqlSettings.DataSource != nil {
 		*o.SqlSettings.DataSource = sanitizeDataSourceField(*o.SqlSettings.DataSource, "SqlSettings.DataSource")
 	}
@@ -4923,6 +5678,14 @@ func (o *Config) Sanitize(pluginManifests []*Manifest, opts *SanitizeOptions) {
 		*o.CacheSettings.RedisPassword = FakeSetting
 	}
 
+	if o.ConnectedWorkspacesSettings.MatrixAppServiceToken != nil && *o.ConnectedWorkspacesSettings.MatrixAppServiceToken != "" {
+		*o.ConnectedWorkspacesSettings.MatrixAppServiceToken = FakeSetting
+	}
+
+	if o.ConnectedWorkspacesSettings.MatrixHomeserverToken != nil && *o.ConnectedWorkspacesSettings.MatrixHomeserverToken != "" {
+		*o.ConnectedWorkspacesSettings.MatrixHomeserverToken = FakeSetting
+	}
+
 	o.PluginSettings.Sanitize(pluginManifests)
 }
 
@@ -2588,3 +2588,235 @@ func TestAutoTranslationSettingsIsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionPolicySettingsIsValid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		policy  *SessionPolicy
+		errorID string
+	}{
+		{
+			name:   "valid policy",
+			policy: &SessionPolicy{Name: NewPointer("Guests"), Roles: []string{SystemGuestRoleId}, MaxSessions: NewPointer(2)},
+		},
+		{
+			name:    "missing name",
+			policy:  &SessionPolicy{Roles: []string{SystemGuestRoleId}},
+			errorID: "model.config.is_valid.session_policy_name.app_error",
+		},
+		{
+			name:    "no roles nor groups",
+			policy:  &SessionPolicy{Name: NewPointer("Everyone")},
+			errorID: "model.config.is_valid.session_policy_scope.app_error",
+		},
+		{
+			name:    "invalid group id",
+			policy:  &SessionPolicy{Name: NewPointer("Contractors"), GroupIds: []string{"invalid"}},
+			errorID: "model.config.is_valid.session_policy_group_id.app_error",
+		},
+		{
+			name:    "negative limit",
+			policy:  &SessionPolicy{Name: NewPointer("Guests"), Roles: []string{SystemGuestRoleId}, MaxMobileSessions: NewPointer(-1)},
+			errorID: "model.config.is_valid.session_policy_limits.app_error",
+		},
+		{
+			name:    "invalid limit action",
+			policy:  &SessionPolicy{Name: NewPointer("Guests"), Roles: []string{SystemGuestRoleId}, LimitAction: NewPointer("logout")},
+			errorID: "model.config.is_valid.session_policy_limit_action.app_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			settings := SessionPolicySettings{Policies: []*SessionPolicy{tc.policy}}
+			settings.setDefaults()
+			appErr := settings.isValid()
+			if tc.errorID == "" {
+				require.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				require.Equal(t, tc.errorID, appErr.Id)
+			}
+		})
+	}
+}
+
+func TestRateLimitSettingsIsValidPolicies(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policies []*RateLimitPolicy
+		errorID  string
+	}{
+		{
+			name: "valid policies",
+			policies: []*RateLimitPolicy{
+				{RouteGroup: NewPointer(RateLimitRouteGroupSearch), Principal: NewPointer(RateLimitPrincipalUser)},
+				{RouteGroup: NewPointer(RateLimitRouteGroupSearch), Principal: NewPointer(RateLimitPrincipalToken), PerSec: NewPointer(1), MaxBurst: NewPointer(5)},
+			},
+		},
+		{
+			name:     "invalid route group",
+			policies: []*RateLimitPolicy{{RouteGroup: NewPointer("channels"), Principal: NewPointer(RateLimitPrincipalUser)}},
+			errorID:  "model.config.is_valid.rate_limit_policy_route_group.app_error",
+		},
+		{
+			name:     "invalid principal",
+			policies: []*RateLimitPolicy{{RouteGroup: NewPointer(RateLimitRouteGroupLogin), Principal: NewPointer("session")}},
+			errorID:  "model.config.is_valid.rate_limit_policy_principal.app_error",
+		},
+		{
+			name:     "invalid limits",
+			policies: []*RateLimitPolicy{{RouteGroup: NewPointer(RateLimitRouteGroupLogin), Principal: NewPointer(RateLimitPrincipalIP), PerSec: NewPointer(0)}},
+			errorID:  "model.config.is_valid.rate_limit_policy_limits.app_error",
+		},
+		{
+			name: "duplicate policies",
+			policies: []*RateLimitPolicy{
+				{RouteGroup: NewPointer(RateLimitRouteGroupPostCreate), Principal: NewPointer(RateLimitPrincipalBot)},
+				{RouteGroup: NewPointer(RateLimitRouteGroupPostCreate), Principal: NewPointer(RateLimitPrincipalBot)},
+			},
+			errorID: "model.config.is_valid.rate_limit_policy_duplicate.app_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			settings := RateLimitSettings{Policies: tc.policies}
+			settings.SetDefaults()
+			appErr := settings.isValid()
+			if tc.errorID == "" {
+				require.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				require.Equal(t, tc.errorID, appErr.Id)
+			}
+		})
+	}
+}
+
+func TestRateLimitSettingsPolicy(t *testing.T) {
+	settings := RateLimitSettings{}
+	settings.SetDefaults()
+
+	policy := settings.Policy(RateLimitRouteGroupLogin, RateLimitPrincipalIP)
+	require.NotNil(t, policy)
+	require.Equal(t, 1, *policy.PerSec)
+	require.Nil(t, settings.Policy(RateLimitRouteGroupLogin, RateLimitPrincipalUser))
+}
+
+func TestPluginResourceLimitsIsValid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		limits  *PluginResourceLimits
+		errorID string
+	}{
+		{
+			name:   "defaults",
+			limits: &PluginResourceLimits{},
+		},
+		{
+			name:   "valid limits",
+			limits: &PluginResourceLimits{MaxMemoryMB: NewPointer(512), MaxCPUPercent: NewPointer(50), MaxOpenFiles: NewPointer(1024), BreachAction: NewPointer(PluginResourceBreachActionDeactivate)},
+		},
+		{
+			name:    "negative limit",
+			limits:  &PluginResourceLimits{MaxOpenFiles: NewPointer(-1)},
+			errorID: "model.config.is_valid.plugin_resource_limits.app_error",
+		},
+		{
+			name:    "invalid breach action",
+			limits:  &PluginResourceLimits{BreachAction: NewPointer("kill")},
+			errorID: "model.config.is_valid.plugin_resource_breach_action.app_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			settings := PluginSettings{ResourceLimits: map[string]*PluginResourceLimits{"com.mattermost.plugin": tc.limits}}
+			settings.SetDefaults(LogSettings{})
+			appErr := settings.isValid()
+			if tc.errorID == "" {
+				require.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				require.Equal(t, tc.errorID, appErr.Id)
+			}
+		})
+	}
+}
+
+func TestMagicLinkSettings(t *testing.T) {
+	t.Run("defaults are valid", func(t *testing.T) {
+		settings := MagicLinkSettings{}
+		settings.setDefaults()
+		require.Nil(t, settings.isValid())
+		require.False(t, settings.IsEnabledForAuthService(UserAuthServiceEmail))
+	})
+
+	t.Run("invalid expiry", func(t *testing.T) {
+		settings := MagicLinkSettings{ExpiryInMinutes: NewPointer(0)}
+		settings.setDefaults()
+		appErr := settings.isValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.magic_link_expiry.app_error", appErr.Id)
+	})
+
+	t.Run("invalid request limits", func(t *testing.T) {
+		settings := MagicLinkSettings{MaxRequestsPerIpPerHour: NewPointer(0)}
+		settings.setDefaults()
+		appErr := settings.isValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.magic_link_max_requests.app_error", appErr.Id)
+	})
+
+	t.Run("enabled per auth service", func(t *testing.T) {
+		settings := MagicLinkSettings{EnableForLdap: NewPointer(true)}
+		settings.setDefaults()
+		require.False(t, settings.IsEnabledForAuthService(""))
+		require.True(t, settings.IsEnabledForAuthService(UserAuthServiceLdap))
+		require.False(t, settings.IsEnabledForAuthService(UserAuthServiceSaml))
+	})
+}
+
+func TestMetricsSettingsIsValidTracing(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings MetricsSettings
+		errorID  string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:     "tracing enabled",
+			settings: MetricsSettings{EnableTracing: NewPointer(true), TracingEndpoint: NewPointer("https://collector.example.com:4318/v1/traces"), TracingSampleRate: NewPointer(0.1)},
+		},
+		{
+			name:     "invalid endpoint",
+			settings: MetricsSettings{EnableTracing: NewPointer(true), TracingEndpoint: NewPointer("collector:4318")},
+			errorID:  "model.config.is_valid.metrics_tracing_endpoint.app_error",
+		},
+		{
+			name:     "invalid endpoint with tracing disabled",
+			settings: MetricsSettings{TracingEndpoint: NewPointer("collector:4318")},
+		},
+		{
+			name:     "invalid sample rate",
+			settings: MetricsSettings{TracingSampleRate: NewPointer(1.5)},
+			errorID:  "model.config.is_valid.metrics_tracing_sample_rate.app_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.settings.SetDefaults()
+			appErr := tc.settings.isValid()
+			if tc.errorID == "" {
+				require.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				require.Equal(t, tc.errorID, appErr.Id)
+			}
+		})
+	}
+}
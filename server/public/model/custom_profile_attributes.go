@@ -21,6 +21,7 @@ const (
 	CustomProfileAttributesPropertyAttrsVisibility = "visibility"
 	CustomProfileAttributesPropertyAttrsLDAP       = "ldap"
 	CustomProfileAttributesPropertyAttrsSAML       = "saml"
+	CustomProfileAttributesPropertyAttrsSCIM       = "scim"
 	CustomProfileAttributesPropertyAttrsManaged    = "managed"
 
 	// Value Types
@@ -118,11 +119,12 @@ type CPAAttrs struct {
 	ValueType  string                                                `json:"value_type"`
 	LDAP       string                                                `json:"ldap"`
 	SAML       string                                                `json:"saml"`
+	SCIM       string                                                `json:"scim"`
 	Managed    string                                                `json:"managed"`
 }
 
 func (c *CPAField) IsSynced() bool {
-	return c.Attrs.LDAP != "" || c.Attrs.SAML != ""
+	return c.Attrs.LDAP != "" || c.Attrs.SAML != "" || c.Attrs.SCIM != ""
 }
 
 func (c *CPAField) IsAdminManaged() bool {
@@ -172,6 +174,7 @@ func (c *CPAField) ToPropertyField() *PropertyField {
 		PropertyFieldAttributeOptions:                  c.Attrs.Options,
 		CustomProfileAttributesPropertyAttrsLDAP:       c.Attrs.LDAP,
 		CustomProfileAttributesPropertyAttrsSAML:       c.Attrs.SAML,
+		CustomProfileAttributesPropertyAttrsSCIM:       c.Attrs.SCIM,
 		CustomProfileAttributesPropertyAttrsManaged:    c.Attrs.Managed,
 	}
 
@@ -200,12 +203,14 @@ func (c *CPAField) SanitizeAndValidate() *AppError {
 	if !c.SupportsSyncing() {
 		c.Attrs.LDAP = ""
 		c.Attrs.SAML = ""
+		c.Attrs.SCIM = ""
 	}
 
 	// Clear sync properties if managed is set (mutual exclusivity)
 	if c.IsAdminManaged() {
 		c.Attrs.LDAP = ""
 		c.Attrs.SAML = ""
+		c.Attrs.SCIM = ""
 	}
 
 	switch c.Type {
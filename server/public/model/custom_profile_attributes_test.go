@@ -709,8 +709,40 @@ func TestCPAField_SanitizeAndValidate(t *testing.T) {
 				SAML:       "", // Should be cleaned
 			},
 		},
+		{
+			name: "date field with SCIM should clean syncing attributes",
+			field: &CPAField{
+				PropertyField: PropertyField{
+					Type: PropertyFieldTypeDate,
+				},
+				Attrs: CPAAttrs{
+					SCIM: "title",
+				},
+			},
+			expectError: false,
+			expectedAttrs: CPAAttrs{
+				Visibility: CustomProfileAttributesVisibilityDefault,
+				SCIM:       "", // Should be cleaned
+			},
+		},
 
 		// Test syncing attributes preservation for types that support syncing
+		{
+			name: "text field with SCIM should preserve syncing attributes",
+			field: &CPAField{
+				PropertyField: PropertyField{
+					Type: PropertyFieldTypeText,
+				},
+				Attrs: CPAAttrs{
+					SCIM: "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department",
+				},
+			},
+			expectError: false,
+			expectedAttrs: CPAAttrs{
+				Visibility: CustomProfileAttributesVisibilityDefault,
+				SCIM:       "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department", // Should be preserved
+			},
+		},
 		{
 			name: "text field with LDAP and SAML should preserve syncing attributes",
 			field: &CPAField{
@@ -746,6 +778,7 @@ func TestCPAField_SanitizeAndValidate(t *testing.T) {
 
 				assert.Equal(t, tt.expectedAttrs.Visibility, tt.field.Attrs.Visibility)
 				assert.Equal(t, tt.expectedAttrs.ValueType, tt.field.Attrs.ValueType)
+				assert.Equal(t, tt.expectedAttrs.SCIM, tt.field.Attrs.SCIM)
 
 				for i := range tt.expectedAttrs.Options {
 					if tt.checkOptionsID {
@@ -0,0 +1,18 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// EmailTemplate describes an email template that admins can override, along
+// with the locales it is currently overridden for.
+type EmailTemplate struct {
+	Name              string   `json:"name"`
+	OverriddenLocales []string `json:"overridden_locales"`
+}
+
+// EmailTemplatePreview is an email template rendered with sample data.
+type EmailTemplatePreview struct {
+	Name   string `json:"name"`
+	Locale string `json:"locale"`
+	HTML   string `json:"html"`
+}
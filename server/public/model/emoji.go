@@ -12,6 +12,7 @@ import (
 const (
 	EmojiNameMaxLength = 64
 	EmojiSortByName    = "name"
+	EmojiMaxAliases    = 10
 )
 
 var EmojiPattern = regexp.MustCompile(`:[a-zA-Z0-9_+-]+:`)
@@ -23,16 +24,35 @@ type Emoji struct {
 	DeleteAt  int64  `json:"delete_at"`
 	CreatorId string `json:"creator_id"`
 	Name      string `json:"name"`
+	// CategoryId is the id of the EmojiCategory grouping the emoji, if any.
+	CategoryId string `json:"category_id,omitempty"`
+	// Aliases are alternative names resolving to the emoji.
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// EmojiPatch holds the fields of an emoji which can be updated after its creation.
+type EmojiPatch struct {
+	CategoryId *string   `json:"category_id"`
+	Aliases    *[]string `json:"aliases"`
+}
+
+func (p *EmojiPatch) Auditable() map[string]any {
+	return map[string]any{
+		"category_id": p.CategoryId,
+		"aliases":     p.Aliases,
+	}
 }
 
 func (emoji *Emoji) Auditable() map[string]any {
 	return map[string]any{
-		"id":         emoji.Id,
-		"create_at":  emoji.CreateAt,
-		"update_at":  emoji.UpdateAt,
-		"delete_at":  emoji.CreateAt,
-		"creator_id": emoji.CreatorId,
-		"name":       emoji.Name,
+		"id":          emoji.Id,
+		"create_at":   emoji.CreateAt,
+		"update_at":   emoji.UpdateAt,
+		"delete_at":   emoji.CreateAt,
+		"creator_id":  emoji.CreatorId,
+		"name":        emoji.Name,
+		"category_id": emoji.CategoryId,
+		"aliases":     emoji.Aliases,
 	}
 }
 
@@ -85,7 +105,51 @@ func (emoji *Emoji) IsValid() *AppError {
 		return NewAppError("Emoji.IsValid", "model.emoji.user_id.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	return IsValidEmojiName(emoji.Name)
+	if emoji.CategoryId != "" && !IsValidId(emoji.CategoryId) {
+		return NewAppError("Emoji.IsValid", "model.emoji.category_id.app_error", nil, "id="+emoji.Id, http.StatusBadRequest)
+	}
+
+	if appErr := IsValidEmojiName(emoji.Name); appErr != nil {
+		return appErr
+	}
+
+	return emoji.isValidAliases()
+}
+
+func (emoji *Emoji) isValidAliases() *AppError {
+	if len(emoji.Aliases) > EmojiMaxAliases {
+		return NewAppError("Emoji.IsValid", "model.emoji.too_many_aliases.app_error", map[string]any{"Max": EmojiMaxAliases}, "id="+emoji.Id, http.StatusBadRequest)
+	}
+
+	seen := make(map[string]bool, len(emoji.Aliases))
+	for _, alias := range emoji.Aliases {
+		if appErr := IsValidEmojiName(alias); appErr != nil {
+			return appErr
+		}
+
+		if alias == emoji.Name || seen[alias] {
+			return NewAppError("Emoji.IsValid", "model.emoji.duplicate_alias.app_error", map[string]any{"Alias": alias}, "id="+emoji.Id, http.StatusBadRequest)
+		}
+		seen[alias] = true
+	}
+
+	return nil
+}
+
+// Names returns the name and the aliases of the emoji.
+func (emoji *Emoji) Names() []string {
+	return append([]string{emoji.Name}, emoji.Aliases...)
+}
+
+// Patch applies the given patch to the emoji.
+func (emoji *Emoji) Patch(patch *EmojiPatch) {
+	if patch.CategoryId != nil {
+		emoji.CategoryId = *patch.CategoryId
+	}
+
+	if patch.Aliases != nil {
+		emoji.Aliases = *patch.Aliases
+	}
 }
 
 func IsValidEmojiName(name string) *AppError {
@@ -107,3 +171,7 @@ func (emoji *Emoji) PreSave() {
 	emoji.CreateAt = GetMillis()
 	emoji.UpdateAt = emoji.CreateAt
 }
+
+func (emoji *Emoji) PreUpdate() {
+	emoji.UpdateAt = GetMillis()
+}
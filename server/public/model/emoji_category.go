@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	EmojiCategoryDisplayNameMaxRunes = 64
+)
+
+// EmojiCategory is an admin-defined group of custom emojis.
+type EmojiCategory struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	CreateAt    int64  `json:"create_at"`
+	UpdateAt    int64  `json:"update_at"`
+}
+
+func (c *EmojiCategory) Auditable() map[string]any {
+	return map[string]any{
+		"id":           c.Id,
+		"name":         c.Name,
+		"display_name": c.DisplayName,
+		"create_at":    c.CreateAt,
+		"update_at":    c.UpdateAt,
+	}
+}
+
+func (c *EmojiCategory) IsValid() *AppError {
+	if !IsValidId(c.Id) {
+		return NewAppError("EmojiCategory.IsValid", "model.emoji_category.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if c.Name == "" || len(c.Name) > EmojiNameMaxLength || !IsValidAlphaNumHyphenUnderscore(c.Name, false) {
+		return NewAppError("EmojiCategory.IsValid", "model.emoji_category.name.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.DisplayName == "" || utf8.RuneCountInString(c.DisplayName) > EmojiCategoryDisplayNameMaxRunes {
+		return NewAppError("EmojiCategory.IsValid", "model.emoji_category.display_name.app_error", map[string]any{"Max": EmojiCategoryDisplayNameMaxRunes}, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CreateAt == 0 {
+		return NewAppError("EmojiCategory.IsValid", "model.emoji_category.create_at.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.UpdateAt == 0 {
+		return NewAppError("EmojiCategory.IsValid", "model.emoji_category.update_at.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (c *EmojiCategory) PreSave() {
+	if c.Id == "" {
+		c.Id = NewId()
+	}
+
+	if c.DisplayName == "" {
+		c.DisplayName = c.Name
+	}
+
+	c.CreateAt = GetMillis()
+	c.UpdateAt = c.CreateAt
+}
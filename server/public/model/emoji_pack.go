@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+const (
+	EmojiPackManifestName    = "manifest.json"
+	EmojiPackManifestVersion = 1
+	EmojiPackImagesDir       = "images"
+)
+
+// EmojiPackManifest describes the custom emojis of an emoji pack. An emoji pack is a zip file
+// holding the manifest and the images of the emojis.
+type EmojiPackManifest struct {
+	Version    int                  `json:"version"`
+	Categories []*EmojiPackCategory `json:"categories,omitempty"`
+	Emojis     []*EmojiPackEmoji    `json:"emojis"`
+}
+
+type EmojiPackCategory struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+type EmojiPackEmoji struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	// Category is the name of the category of the emoji.
+	Category string `json:"category,omitempty"`
+	// Image is the path of the image of the emoji in the emoji pack.
+	Image string `json:"image"`
+}
+
+// EmojiPackImportResult reports the outcome of an emoji pack import. Emojis whose name is already
+// taken are skipped, those failing to import are reported with their error.
+type EmojiPackImportResult struct {
+	Created []*Emoji          `json:"created"`
+	Skipped []string          `json:"skipped"`
+	Errors  map[string]string `json:"errors"`
+}
+
+func (m *EmojiPackManifest) IsValid() *AppError {
+	if m.Version != EmojiPackManifestVersion {
+		return NewAppError("EmojiPackManifest.IsValid", "model.emoji_pack.version.app_error", map[string]any{"Version": EmojiPackManifestVersion}, "", http.StatusBadRequest)
+	}
+
+	for _, emoji := range m.Emojis {
+		if emoji == nil || emoji.Name == "" {
+			return NewAppError("EmojiPackManifest.IsValid", "model.emoji_pack.name.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if !IsValidEmojiPackImagePath(emoji.Image) {
+			return NewAppError("EmojiPackManifest.IsValid", "model.emoji_pack.image.app_error", map[string]any{"Name": emoji.Name}, "", http.StatusBadRequest)
+		}
+	}
+
+	for _, category := range m.Categories {
+		if category == nil || category.Name == "" {
+			return NewAppError("EmojiPackManifest.IsValid", "model.emoji_pack.category.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// IsValidEmojiPackImagePath reports whether the image path is a relative path pointing inside of
+// the emoji pack.
+func IsValidEmojiPackImagePath(imagePath string) bool {
+	if imagePath == "" || strings.HasPrefix(imagePath, "/") || strings.Contains(imagePath, "\\") {
+		return false
+	}
+
+	cleaned := path.Clean(imagePath)
+	return cleaned == imagePath && cleaned != ".." && !strings.HasPrefix(cleaned, "../")
+}
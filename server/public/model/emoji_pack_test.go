@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmojiPackManifestIsValid(t *testing.T) {
+	manifest := &EmojiPackManifest{
+		Version:    EmojiPackManifestVersion,
+		Categories: []*EmojiPackCategory{{Name: "animals"}},
+		Emojis:     []*EmojiPackEmoji{{Name: "parrot", Category: "animals", Image: "images/parrot.gif"}},
+	}
+	require.Nil(t, manifest.IsValid())
+
+	manifest.Version = 2
+	appErr := manifest.IsValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.emoji_pack.version.app_error", appErr.Id)
+
+	manifest.Version = EmojiPackManifestVersion
+	manifest.Emojis[0].Image = "../parrot.gif"
+	appErr = manifest.IsValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.emoji_pack.image.app_error", appErr.Id)
+
+	manifest.Emojis[0].Image = "images/parrot.gif"
+	manifest.Categories[0].Name = ""
+	appErr = manifest.IsValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.emoji_pack.category.app_error", appErr.Id)
+}
+
+func TestIsValidEmojiPackImagePath(t *testing.T) {
+	for imagePath, valid := range map[string]bool{
+		"parrot.gif":              true,
+		"images/parrot.gif":       true,
+		"":                        false,
+		"/images/parrot.gif":      false,
+		"../parrot.gif":           false,
+		"images/../../parrot.gif": false,
+		"images/./parrot.gif":     false,
+		"images\\parrot.gif":      false,
+		"..":                      false,
+	} {
+		assert.Equal(t, valid, IsValidEmojiPackImagePath(imagePath), imagePath)
+	}
+}
@@ -62,3 +62,59 @@ func TestEmojiIsValid(t *testing.T) {
 	emoji.Name = "croissant"
 	require.NotNil(t, emoji.IsValid())
 }
+
+func TestEmojiAliasesIsValid(t *testing.T) {
+	emoji := Emoji{
+		Id:        NewId(),
+		CreateAt:  1234,
+		UpdateAt:  1234,
+		CreatorId: NewId(),
+		Name:      "name",
+		Aliases:   []string{"alias1", "alias2"},
+	}
+
+	require.Nil(t, emoji.IsValid())
+
+	emoji.Aliases = []string{"alias1", "alias1"}
+	appErr := emoji.IsValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.emoji.duplicate_alias.app_error", appErr.Id)
+
+	emoji.Aliases = []string{"name"}
+	appErr = emoji.IsValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.emoji.duplicate_alias.app_error", appErr.Id)
+
+	emoji.Aliases = []string{"croissant"}
+	require.NotNil(t, emoji.IsValid())
+
+	emoji.Aliases = []string{"alias:"}
+	require.NotNil(t, emoji.IsValid())
+
+	emoji.Aliases = make([]string, EmojiMaxAliases+1)
+	for i := range emoji.Aliases {
+		emoji.Aliases[i] = "alias" + strings.Repeat("a", i)
+	}
+	appErr = emoji.IsValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.emoji.too_many_aliases.app_error", appErr.Id)
+
+	emoji.Aliases = nil
+	emoji.CategoryId = "category"
+	require.NotNil(t, emoji.IsValid())
+
+	emoji.CategoryId = NewId()
+	require.Nil(t, emoji.IsValid())
+}
+
+func TestEmojiPatch(t *testing.T) {
+	emoji := Emoji{Name: "name", CategoryId: NewId(), Aliases: []string{"alias"}}
+
+	emoji.Patch(&EmojiPatch{Aliases: &[]string{"other"}})
+	require.Equal(t, []string{"other"}, emoji.Aliases)
+	require.NotEmpty(t, emoji.CategoryId)
+
+	emoji.Patch(&EmojiPatch{CategoryId: NewPointer("")})
+	require.Empty(t, emoji.CategoryId)
+	require.Equal(t, []string{"name", "other"}, emoji.Names())
+}
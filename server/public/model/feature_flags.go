@@ -88,6 +88,9 @@ type FeatureFlags struct {
 
 	// FEATURE_FLAG_REMOVAL: EnableAIPluginBridge
 	EnableAIPluginBridge bool
+
+	// Wrap the store in the fault-injection layer, for resilience and load testing. Requires a restart.
+	StoreFaultInjection bool
 }
 
 func (f *FeatureFlags) SetDefaults() {
@@ -129,6 +132,8 @@ func (f *FeatureFlags) SetDefaults() {
 
 	// FEATURE_FLAG_REMOVAL: EnableAIPluginBridge - Remove this default when MVP is to be released
 	f.EnableAIPluginBridge = false
+
+	f.StoreFaultInjection = false
 }
 
 // ToMap returns the feature flags as a map[string]string
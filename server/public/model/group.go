@@ -10,17 +10,21 @@ import (
 )
 
 const (
-	GroupSourceLdap   GroupSource = "ldap"
-	GroupSourceCustom GroupSource = "custom"
+	GroupSourceLdap      GroupSource = "ldap"
+	GroupSourceCustom    GroupSource = "custom"
+	GroupSourceAttribute GroupSource = "attribute"
+	GroupSourceOIDC      GroupSource = "oidc"
+	GroupSourceScim      GroupSource = "scim"
 
 	// plugin groups must prefix their source with this
 	GroupSourcePluginPrefix GroupSource = "plugin_"
 
-	GroupNameMaxLength        = 64
-	GroupSourceMaxLength      = 64
-	GroupDisplayNameMaxLength = 128
-	GroupDescriptionMaxLength = 1024
-	GroupRemoteIDMaxLength    = 48
+	GroupNameMaxLength           = 64
+	GroupSourceMaxLength         = 64
+	GroupDisplayNameMaxLength    = 128
+	GroupDescriptionMaxLength    = 1024
+	GroupRemoteIDMaxLength       = 48
+	GroupMembershipRuleMaxLength = 4096
 )
 
 type GroupSource string
@@ -41,6 +45,9 @@ type Group struct {
 	ChannelMemberCount          *int        `db:"-" json:"channel_member_count,omitempty"`
 	ChannelMemberTimezonesCount *int        `db:"-" json:"channel_member_timezones_count,omitempty"`
 	MemberIDs                   []string    `db:"-" json:"member_ids"`
+	// MembershipRule is the CEL expression over user attributes that determines the members
+	// of a group with the attribute source. It is empty for every other source.
+	MembershipRule string `json:"membership_rule,omitempty"`
 }
 
 func (group *Group) Auditable() map[string]any {
@@ -54,6 +61,7 @@ func (group *Group) Auditable() map[string]any {
 		"has_syncables":   group.HasSyncables,
 		"member_count":    group.GetMemberCount(),
 		"allow_reference": group.AllowReference,
+		"membership_rule": group.MembershipRule,
 	}
 }
 
@@ -70,6 +78,7 @@ func (group *Group) LogClone() any {
 		"has_syncables":   group.HasSyncables,
 		"member_count":    group.GetMemberCount(),
 		"allow_reference": group.AllowReference,
+		"membership_rule": group.MembershipRule,
 	}
 }
 
@@ -89,6 +98,7 @@ func (group *GroupWithUserIds) Auditable() map[string]any {
 		"has_syncables":   group.HasSyncables,
 		"member_count":    group.GetMemberCount(),
 		"allow_reference": group.AllowReference,
+		"membership_rule": group.MembershipRule,
 		"user_ids":        group.UserIds,
 	}
 }
@@ -113,6 +123,7 @@ type GroupPatch struct {
 	DisplayName    *string `json:"display_name"`
 	Description    *string `json:"description"`
 	AllowReference *bool   `json:"allow_reference"`
+	MembershipRule *string `json:"membership_rule"`
 	// For security reasons (including preventing unintended LDAP group synchronization) do no allow a Group's RemoteId or Source field to be
 	// included in patches.
 }
@@ -195,6 +206,9 @@ func (group *Group) Patch(patch *GroupPatch) {
 	if patch.AllowReference != nil {
 		group.AllowReference = *patch.AllowReference
 	}
+	if patch.MembershipRule != nil {
+		group.MembershipRule = *patch.MembershipRule
+	}
 }
 
 func (group *Group) IsValidForCreate() *AppError {
@@ -214,6 +228,9 @@ func (group *Group) IsValidForCreate() *AppError {
 	isValidSource := false
 	if group.Source == GroupSourceLdap ||
 		group.Source == GroupSourceCustom ||
+		group.Source == GroupSourceAttribute ||
+		group.Source == GroupSourceOIDC ||
+		group.Source == GroupSourceScim ||
 		strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix)) {
 		isValidSource = true
 	}
@@ -226,15 +243,23 @@ func (group *Group) IsValidForCreate() *AppError {
 		return NewAppError("Group.IsValidForCreate", "model.group.remote_id.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if group.Source == GroupSourceAttribute {
+		if l := len(group.MembershipRule); l == 0 || l > GroupMembershipRuleMaxLength {
+			return NewAppError("Group.IsValidForCreate", "model.group.membership_rule.app_error", map[string]any{"GroupMembershipRuleMaxLength": GroupMembershipRuleMaxLength}, "", http.StatusBadRequest)
+		}
+	} else if group.MembershipRule != "" {
+		return NewAppError("Group.IsValidForCreate", "model.group.membership_rule.source.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
 func (group *Group) requiresRemoteId() bool {
-	return group.Source == GroupSourceLdap || strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix))
+	return group.Source == GroupSourceLdap || group.Source == GroupSourceOIDC || strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix))
 }
 
 func GetSyncableGroupSources() []GroupSource {
-	return []GroupSource{GroupSourceLdap}
+	return []GroupSource{GroupSourceLdap, GroupSourceAttribute, GroupSourceOIDC, GroupSourceScim}
 }
 
 func GetSyncableGroupSourcePrefixes() []GroupSource {
@@ -242,7 +267,7 @@ func GetSyncableGroupSourcePrefixes() []GroupSource {
 }
 
 func (group *Group) IsSyncable() bool {
-	return group.Source == GroupSourceLdap || strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix))
+	return group.Source == GroupSourceLdap || group.Source == GroupSourceAttribute || group.Source == GroupSourceOIDC || group.Source == GroupSourceScim || strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix))
 }
 
 func (group *Group) IsValidForUpdate() *AppError {
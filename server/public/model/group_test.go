@@ -43,6 +43,7 @@ func TestGroupAuditable(t *testing.T) {
 			"has_syncables":   true,
 			"member_count":    10,
 			"allow_reference": true,
+			"membership_rule": "",
 		}
 
 		assert.Equal(t, expected, m)
@@ -92,8 +93,65 @@ func TestGroupLogClone(t *testing.T) {
 			"has_syncables":   true,
 			"member_count":    10,
 			"allow_reference": true,
+			"membership_rule": "",
 		}
 
 		assert.Equal(t, expected, m)
 	})
 }
+
+func TestGroupIsValidForCreateMembershipRule(t *testing.T) {
+	newGroup := func(source GroupSource, rule string) *Group {
+		return &Group{
+			Name:           NewPointer(NewUsername()),
+			DisplayName:    "some display name",
+			Source:         source,
+			MembershipRule: rule,
+			AllowReference: true,
+		}
+	}
+
+	t.Run("attribute group requires a rule", func(t *testing.T) {
+		appErr := newGroup(GroupSourceAttribute, "").IsValidForCreate()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.group.membership_rule.app_error", appErr.Id)
+	})
+
+	t.Run("attribute group rule is too long", func(t *testing.T) {
+		rule := "user.attributes.Department == '" + NewRandomString(GroupMembershipRuleMaxLength) + "'"
+		appErr := newGroup(GroupSourceAttribute, rule).IsValidForCreate()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.group.membership_rule.app_error", appErr.Id)
+	})
+
+	t.Run("attribute group with a rule", func(t *testing.T) {
+		appErr := newGroup(GroupSourceAttribute, "user.attributes.Department == 'Engineering'").IsValidForCreate()
+		require.Nil(t, appErr)
+		assert.True(t, newGroup(GroupSourceAttribute, "").IsSyncable())
+	})
+
+	t.Run("other sources may not have a rule", func(t *testing.T) {
+		appErr := newGroup(GroupSourceCustom, "user.attributes.Department == 'Engineering'").IsValidForCreate()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.group.membership_rule.source.app_error", appErr.Id)
+	})
+}
+
+func TestGroupIsValidForCreateScim(t *testing.T) {
+	group := &Group{
+		DisplayName: "Engineering",
+		Source:      GroupSourceScim,
+	}
+
+	t.Run("remote id is optional", func(t *testing.T) {
+		require.Nil(t, group.IsValidForCreate())
+		assert.True(t, group.IsSyncable())
+	})
+
+	t.Run("remote id is limited in length", func(t *testing.T) {
+		group.RemoteId = NewPointer(NewRandomString(GroupRemoteIDMaxLength + 1))
+		appErr := group.IsValidForCreate()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.group.remote_id.app_error", appErr.Id)
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	GuestAccessExpiryBatchSize = 100
+
+	GuestAccessExpiryReminderDaysDefault = 3
+)
+
+// GuestAccessExpiry is the time after which a guest account is deactivated and removed from its
+// channels.
+type GuestAccessExpiry struct {
+	UserId    string `json:"user_id"`
+	ExpiresAt int64  `json:"expires_at"`
+	// ReminderSentAt is the time the guest was reminded of the expiry, 0 if not reminded yet.
+	ReminderSentAt int64 `json:"reminder_sent_at"`
+	CreateAt       int64 `json:"create_at"`
+	UpdateAt       int64 `json:"update_at"`
+}
+
+func (e *GuestAccessExpiry) Auditable() map[string]any {
+	return map[string]any{
+		"user_id":    e.UserId,
+		"expires_at": e.ExpiresAt,
+	}
+}
+
+func (e *GuestAccessExpiry) PreSave() {
+	if e.CreateAt == 0 {
+		e.CreateAt = GetMillis()
+	}
+	e.UpdateAt = GetMillis()
+}
+
+func (e *GuestAccessExpiry) IsValid() *AppError {
+	if !IsValidId(e.UserId) {
+		return NewAppError("GuestAccessExpiry.IsValid", "model.guest_access_expiry.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if e.ExpiresAt <= 0 {
+		return NewAppError("GuestAccessExpiry.IsValid", "model.guest_access_expiry.is_valid.expires_at.app_error", nil, "user_id="+e.UserId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsExpired reports whether the guest should be deactivated.
+func (e *GuestAccessExpiry) IsExpired(now int64) bool {
+	return now >= e.ExpiresAt
+}
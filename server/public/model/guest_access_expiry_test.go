@@ -0,0 +1,35 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestAccessExpiryIsValid(t *testing.T) {
+	expiry := &GuestAccessExpiry{UserId: NewId(), ExpiresAt: GetMillis()}
+	require.Nil(t, expiry.IsValid())
+
+	expiry.UserId = "invalid"
+	appErr := expiry.IsValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.guest_access_expiry.is_valid.user_id.app_error", appErr.Id)
+
+	expiry.UserId = NewId()
+	expiry.ExpiresAt = 0
+	appErr = expiry.IsValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.guest_access_expiry.is_valid.expires_at.app_error", appErr.Id)
+}
+
+func TestGuestAccessExpiryIsExpired(t *testing.T) {
+	expiry := &GuestAccessExpiry{UserId: NewId(), ExpiresAt: 1000}
+
+	assert.False(t, expiry.IsExpired(999))
+	assert.True(t, expiry.IsExpired(1000))
+	assert.True(t, expiry.IsExpired(1001))
+}
@@ -11,12 +11,15 @@ type GuestsInvite struct {
 	Emails   []string `json:"emails"`
 	Channels []string `json:"channels"`
 	Message  string   `json:"message"`
+	// AccessExpiresAt is the time after which the invited guests are deactivated, 0 for never.
+	AccessExpiresAt int64 `json:"access_expires_at,omitempty"`
 }
 
 func (i *GuestsInvite) Auditable() map[string]any {
 	return map[string]any{
-		"emails":   i.Emails,
-		"channels": i.Channels,
+		"emails":            i.Emails,
+		"channels":          i.Channels,
+		"access_expires_at": i.AccessExpiresAt,
 	}
 }
 
@@ -42,5 +45,9 @@ func (i *GuestsInvite) IsValid() *AppError {
 			return NewAppError("GuestsInvite.IsValid", "model.guest.is_valid.channel.app_error", nil, "channel="+channel, http.StatusBadRequest)
 		}
 	}
+
+	if i.AccessExpiresAt < 0 {
+		return NewAppError("GuestsInvite.IsValid", "model.guest.is_valid.access_expires_at.app_error", nil, "", http.StatusBadRequest)
+	}
 	return nil
 }
@@ -45,6 +45,9 @@ const (
 	JobTypeMobileSessionMetadata         = "mobile_session_metadata"
 	JobTypeAccessControlSync             = "access_control_sync"
 	JobTypePushProxyAuth                 = "push_proxy_auth"
+	JobTypeAttributeGroupSync            = "attribute_group_sync"
+	JobTypeGuestAccessExpiry             = "guest_access_expiry"
+	JobTypePluginSearchIndexing          = "plugin_search_indexing"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -77,6 +80,9 @@ var AllJobTypes = [...]string{
 	JobTypeCleanupDesktopTokens,
 	JobTypeRefreshMaterializedViews,
 	JobTypeMobileSessionMetadata,
+	JobTypeAttributeGroupSync,
+	JobTypeGuestAccessExpiry,
+	JobTypePluginSearchIndexing,
 }
 
 type Job struct {
@@ -209,6 +209,10 @@ type Manifest struct {
 	// Minimum server version: 5.6
 	MinServerVersion string `json:"min_server_version,omitempty" yaml:"min_server_version,omitempty"`
 
+	// Dependencies are the other plugins your plugin relies on. A plugin is only activated once
+	// its required dependencies are active and is deactivated when one of them goes away.
+	Dependencies []*ManifestDependency `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+
 	// Server defines the server-side portion of your plugin.
 	Server *ManifestServer `json:"server,omitempty" yaml:"server,omitempty"`
 
@@ -221,6 +225,11 @@ type Manifest struct {
 
 	// Plugins can store any kind of data in Props to allow other plugins to use it.
 	Props map[string]any `json:"props,omitempty" yaml:"props,omitempty"`
+
+	// Permissions declares the capabilities the server portion of your plugin needs. A system
+	// admin has to approve them before the plugin is enabled and whenever an upgrade extends them.
+	// Leaving it empty gives the plugin access to the whole plugin API.
+	Permissions *PluginPermissions `json:"permissions,omitempty" yaml:"permissions,omitempty"`
 }
 
 type ManifestServer struct {
@@ -238,6 +247,53 @@ type ManifestServer struct {
 	Executable string `json:"executable" yaml:"executable"`
 }
 
+type ManifestDependency struct {
+	// The id of the plugin depended on.
+	Id string `json:"id" yaml:"id"`
+
+	// Version is the semantic version range the plugin depended on must satisfy, e.g.
+	// ">=1.2.0 <2.0.0". Any version is accepted when empty.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+
+	// Optional dependencies don't prevent the activation of your plugin when they are missing,
+	// but are activated before your plugin when they are present.
+	Optional bool `json:"optional,omitempty" yaml:"optional,omitempty"`
+}
+
+func (d *ManifestDependency) IsValid() error {
+	if !IsValidPluginId(d.Id) {
+		return errors.Errorf("invalid plugin ID %q", d.Id)
+	}
+
+	if d.Version != "" {
+		if _, err := semver.ParseRange(d.Version); err != nil {
+			return errors.Wrapf(err, "failed to parse version range of %s", d.Id)
+		}
+	}
+
+	return nil
+}
+
+// IsSatisfiedBy returns whether the given version of the plugin depended on satisfies the
+// version range of the dependency.
+func (d *ManifestDependency) IsSatisfiedBy(version string) (bool, error) {
+	if d.Version == "" {
+		return true, nil
+	}
+
+	versionRange, err := semver.ParseRange(d.Version)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse version range of %s", d.Id)
+	}
+
+	v, err := semver.Parse(version)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse version of %s", d.Id)
+	}
+
+	return versionRange(v), nil
+}
+
 type ManifestWebapp struct {
 	// The path to your webapp bundle. This should be relative to the root of your bundle and the
 	// location of the manifest file.
@@ -352,6 +408,29 @@ func (m *Manifest) IsValid() error {
 		}
 	}
 
+	seenDependencies := make(map[string]bool, len(m.Dependencies))
+	for _, dependency := range m.Dependencies {
+		if dependency == nil {
+			return errors.New("invalid dependency")
+		}
+		if err := dependency.IsValid(); err != nil {
+			return errors.Wrap(err, "invalid dependency")
+		}
+		if dependency.Id == m.Id {
+			return errors.New("a plugin can't depend on itself")
+		}
+		if seenDependencies[dependency.Id] {
+			return errors.Errorf("duplicate dependency %s", dependency.Id)
+		}
+		seenDependencies[dependency.Id] = true
+	}
+
+	if m.Permissions != nil {
+		if err := m.Permissions.IsValid(); err != nil {
+			return errors.Wrap(err, "invalid permissions")
+		}
+	}
+
 	return nil
 }
 
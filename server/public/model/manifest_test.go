@@ -31,6 +31,12 @@ func TestIsValid(t *testing.T) {
 		{"SettingSchema error", &Manifest{Id: "com.company.test", Name: "some name", HomepageURL: "http://someurl.com", SupportURL: "http://someotherurl.com", Version: "5.10.0", MinServerVersion: "5.10.8", SettingsSchema: &PluginSettingsSchema{
 			Settings: []*PluginSetting{{Type: "Invalid"}},
 		}}, true},
+		{"Invalid dependency ID", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "some id"}}}, true},
+		{"Invalid dependency version", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "com.company.other", Version: "version"}}}, true},
+		{"Dependency on itself", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "com.company.test"}}}, true},
+		{"Duplicate dependency", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "com.company.other"}, {Id: "com.company.other", Optional: true}}}, true},
+		{"Valid dependencies", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "com.company.other", Version: ">=1.2.0 <2.0.0"}, {Id: "com.company.optional", Optional: true}}}, false},
+		{"Invalid permissions", &Manifest{Id: "com.company.test", Name: "some name", Permissions: &PluginPermissions{API: []string{"unknown"}}}, true},
 		{"Minimal valid manifest", &Manifest{Id: "com.company.test", Name: "some name"}, false},
 		{"Happy case", &Manifest{
 			Id:               "com.company.test",
@@ -124,6 +130,36 @@ func TestIsValid(t *testing.T) {
 	}
 }
 
+func TestManifestDependencyIsSatisfiedBy(t *testing.T) {
+	testCases := []struct {
+		Title       string
+		Range       string
+		Version     string
+		Expected    bool
+		ExpectError bool
+	}{
+		{"Any version", "", "0.1.0", true, false},
+		{"Version in range", ">=1.2.0 <2.0.0", "1.5.3", true, false},
+		{"Version below range", ">=1.2.0 <2.0.0", "1.1.0", false, false},
+		{"Version above range", ">=1.2.0 <2.0.0", "2.0.0", false, false},
+		{"Alternative ranges", "<1.0.0 || >=3.0.0", "3.1.0", true, false},
+		{"Invalid version", ">=1.2.0", "version", false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Title, func(t *testing.T) {
+			dependency := &ManifestDependency{Id: "com.company.test", Version: tc.Range}
+			satisfied, err := dependency.IsSatisfiedBy(tc.Version)
+			if tc.ExpectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, satisfied)
+		})
+	}
+}
+
 func TestIsValidSettingsSchema(t *testing.T) {
 	testCases := []struct {
 		Title          string
@@ -193,6 +193,11 @@ func (a *OAuthApp) validatePublicClientGrant(grantType, clientSecret, codeVerifi
 		return NewAppError("OAuthApp.validatePublicClientGrant", "model.oauth.validate_grant.public_client_refresh_token.app_error", nil, "app_id="+a.Id, http.StatusBadRequest)
 	}
 
+	// Public clients cannot authenticate, so they cannot act on their own behalf
+	if grantType == GrantTypeClientCredentials {
+		return NewAppError("OAuthApp.validatePublicClientGrant", "model.oauth.validate_grant.public_client_credentials.app_error", nil, "app_id="+a.Id, http.StatusBadRequest)
+	}
+
 	// Public clients must use PKCE for authorization code grant
 	if grantType == AccessTokenGrantType && codeVerifier == "" {
 		return NewAppError("OAuthApp.validatePublicClientGrant", "model.oauth.validate_grant.pkce_required.app_error", nil, "app_id="+a.Id, http.StatusBadRequest)
@@ -0,0 +1,186 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/rand"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+const (
+	GrantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+	OAuthDeviceAuthorizationEndpoint = "/oauth/device_authorization"
+	OAuthDeviceVerificationEndpoint  = "/oauth/device"
+
+	// OAuthAppMmctlClientId is the client id mmctl uses for the device authorization grant. It
+	// resolves to an OAuth app the server creates on first use.
+	OAuthAppMmctlClientId = "mmctl"
+
+	DeviceCodeExpireTime        = 60 * 10 // 10 minutes
+	DeviceCodePollInterval      = 5       // seconds
+	DeviceCodeSlowDownIncrement = 5       // seconds
+	DeviceCodeLength            = 64
+	DeviceUserCodeLength        = 8
+	DeviceUserCodeCharset       = "BCDFGHJKLMNPQRSTVWXZ"
+	DeviceCodeMaxScopeLength    = 128
+)
+
+const (
+	DeviceAuthorizationStatusPending  = "pending"
+	DeviceAuthorizationStatusApproved = "approved"
+	DeviceAuthorizationStatusDenied   = "denied"
+)
+
+// Error codes of the token and device authorization endpoints, see RFC 6749 section 5.2 and
+// RFC 8628 section 3.5.
+const (
+	OAuthErrorInvalidRequest       = "invalid_request"
+	OAuthErrorInvalidClient        = "invalid_client"
+	OAuthErrorInvalidGrant         = "invalid_grant"
+	OAuthErrorServerError          = "server_error"
+	OAuthErrorAuthorizationPending = "authorization_pending"
+	OAuthErrorSlowDown             = "slow_down"
+	OAuthErrorAccessDenied         = "access_denied"
+	OAuthErrorExpiredToken         = "expired_token"
+)
+
+// DeviceAuthorization is a pending device authorization grant (RFC 8628). The device polls the
+// token endpoint with the device code while the user approves the request by entering the user
+// code on the verification page.
+type DeviceAuthorization struct {
+	DeviceCode   string `json:"device_code"`
+	UserCode     string `json:"user_code"`
+	ClientId     string `json:"client_id"`
+	UserId       string `json:"user_id"`
+	Scope        string `json:"scope"`
+	Status       string `json:"status"`
+	CreateAt     int64  `json:"create_at"`
+	ExpiresAt    int64  `json:"expires_at"`
+	PollInterval int32  `json:"poll_interval"`
+	LastPolledAt int64  `json:"last_polled_at"`
+}
+
+// DeviceAuthorizationResponse is returned by the device authorization endpoint.
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int32  `json:"interval"`
+}
+
+// OAuthError is an error response as defined in RFC 6749 section 5.2.
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+func NewDeviceAuthorization(clientID, scope string) *DeviceAuthorization {
+	if scope == "" {
+		scope = DefaultScope
+	}
+
+	return &DeviceAuthorization{
+		DeviceCode:   NewRandomString(DeviceCodeLength),
+		UserCode:     NewDeviceUserCode(),
+		ClientId:     clientID,
+		Scope:        scope,
+		Status:       DeviceAuthorizationStatusPending,
+		PollInterval: DeviceCodePollInterval,
+	}
+}
+
+func (da *DeviceAuthorization) PreSave() {
+	if da.CreateAt == 0 {
+		da.CreateAt = GetMillis()
+	}
+
+	if da.ExpiresAt == 0 {
+		da.ExpiresAt = da.CreateAt + DeviceCodeExpireTime*1000
+	}
+}
+
+func (da *DeviceAuthorization) IsValid() *AppError {
+	if len(da.DeviceCode) != DeviceCodeLength {
+		return NewAppError("DeviceAuthorization.IsValid", "model.device_authorization.is_valid.device_code.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(da.UserCode) != DeviceUserCodeLength {
+		return NewAppError("DeviceAuthorization.IsValid", "model.device_authorization.is_valid.user_code.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(da.ClientId) {
+		return NewAppError("DeviceAuthorization.IsValid", "model.device_authorization.is_valid.client_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if da.UserId != "" && !IsValidId(da.UserId) {
+		return NewAppError("DeviceAuthorization.IsValid", "model.device_authorization.is_valid.user_id.app_error", nil, "client_id="+da.ClientId, http.StatusBadRequest)
+	}
+
+	if len(da.Scope) > DeviceCodeMaxScopeLength {
+		return NewAppError("DeviceAuthorization.IsValid", "model.device_authorization.is_valid.scope.app_error", nil, "client_id="+da.ClientId, http.StatusBadRequest)
+	}
+
+	switch da.Status {
+	case DeviceAuthorizationStatusPending, DeviceAuthorizationStatusApproved, DeviceAuthorizationStatusDenied:
+	default:
+		return NewAppError("DeviceAuthorization.IsValid", "model.device_authorization.is_valid.status.app_error", nil, "client_id="+da.ClientId, http.StatusBadRequest)
+	}
+
+	if da.CreateAt == 0 || da.ExpiresAt <= da.CreateAt {
+		return NewAppError("DeviceAuthorization.IsValid", "model.device_authorization.is_valid.expires_at.app_error", nil, "client_id="+da.ClientId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (da *DeviceAuthorization) IsExpired() bool {
+	return GetMillis() > da.ExpiresAt
+}
+
+// NewDeviceUserCode returns a random user code drawn from a consonant-only alphabet, which avoids
+// ambiguous characters and accidental words.
+func NewDeviceUserCode() string {
+	var b strings.Builder
+	charsetLen := big.NewInt(int64(len(DeviceUserCodeCharset)))
+	for range DeviceUserCodeLength {
+		n, err := rand.Int(rand.Reader, charsetLen)
+		if err != nil {
+			panic(err)
+		}
+		b.WriteByte(DeviceUserCodeCharset[n.Int64()])
+	}
+	return b.String()
+}
+
+// FormatDeviceUserCode splits a user code in two halves for display, e.g. "BCDF-GHJK".
+func FormatDeviceUserCode(userCode string) string {
+	if len(userCode) != DeviceUserCodeLength {
+		return userCode
+	}
+	return userCode[:DeviceUserCodeLength/2] + "-" + userCode[DeviceUserCodeLength/2:]
+}
+
+// NormalizeDeviceUserCode turns user input into the stored form of a user code, ignoring case,
+// dashes and whitespace.
+func NormalizeDeviceUserCode(input string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(input) {
+		if strings.ContainsRune(DeviceUserCodeCharset, r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
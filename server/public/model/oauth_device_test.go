@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceAuthorizationIsValid(t *testing.T) {
+	da := NewDeviceAuthorization(NewId(), "")
+	da.PreSave()
+	require.Nil(t, da.IsValid())
+	assert.Equal(t, DefaultScope, da.Scope)
+	assert.Equal(t, DeviceAuthorizationStatusPending, da.Status)
+	assert.Equal(t, da.CreateAt+DeviceCodeExpireTime*1000, da.ExpiresAt)
+
+	for name, tc := range map[string]struct {
+		update  func(da *DeviceAuthorization)
+		errorID string
+	}{
+		"device code":  {func(da *DeviceAuthorization) { da.DeviceCode = "short" }, "model.device_authorization.is_valid.device_code.app_error"},
+		"user code":    {func(da *DeviceAuthorization) { da.UserCode = "BCDF" }, "model.device_authorization.is_valid.user_code.app_error"},
+		"client id":    {func(da *DeviceAuthorization) { da.ClientId = "invalid" }, "model.device_authorization.is_valid.client_id.app_error"},
+		"user id":      {func(da *DeviceAuthorization) { da.UserId = "invalid" }, "model.device_authorization.is_valid.user_id.app_error"},
+		"scope":        {func(da *DeviceAuthorization) { da.Scope = strings.Repeat("a", DeviceCodeMaxScopeLength+1) }, "model.device_authorization.is_valid.scope.app_error"},
+		"status":       {func(da *DeviceAuthorization) { da.Status = "unknown" }, "model.device_authorization.is_valid.status.app_error"},
+		"expires at":   {func(da *DeviceAuthorization) { da.ExpiresAt = da.CreateAt }, "model.device_authorization.is_valid.expires_at.app_error"},
+		"no create at": {func(da *DeviceAuthorization) { da.CreateAt = 0 }, "model.device_authorization.is_valid.expires_at.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := *da
+			tc.update(&invalid)
+			appErr := invalid.IsValid()
+			require.NotNil(t, appErr)
+			assert.Equal(t, tc.errorID, appErr.Id)
+		})
+	}
+}
+
+func TestDeviceAuthorizationIsExpired(t *testing.T) {
+	da := NewDeviceAuthorization(NewId(), "")
+	da.PreSave()
+	assert.False(t, da.IsExpired())
+
+	da.ExpiresAt = GetMillis() - 1
+	assert.True(t, da.IsExpired())
+}
+
+func TestDeviceUserCode(t *testing.T) {
+	userCode := NewDeviceUserCode()
+	require.Len(t, userCode, DeviceUserCodeLength)
+	for _, r := range userCode {
+		assert.True(t, strings.ContainsRune(DeviceUserCodeCharset, r))
+	}
+
+	formatted := FormatDeviceUserCode(userCode)
+	assert.Equal(t, userCode[:4]+"-"+userCode[4:], formatted)
+	assert.Equal(t, userCode, NormalizeDeviceUserCode(formatted))
+	assert.Equal(t, userCode, NormalizeDeviceUserCode(" "+strings.ToLower(formatted)+"\n"))
+
+	assert.Equal(t, "BCD", FormatDeviceUserCode("BCD"))
+}
+
+func TestOAuthError(t *testing.T) {
+	assert.Equal(t, OAuthErrorSlowDown, (&OAuthError{Code: OAuthErrorSlowDown}).Error())
+	assert.Equal(t, "invalid_grant: expired", (&OAuthError{Code: OAuthErrorInvalidGrant, Description: "expired"}).Error())
+}
@@ -15,11 +15,14 @@ type AuthorizationServerMetadata struct {
 	GrantTypesSupported               []string `json:"grant_types_supported,omitempty"`
 	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported,omitempty"`
 	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported,omitempty"`
+	DeviceAuthorizationEndpoint       string   `json:"device_authorization_endpoint,omitempty"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint,omitempty"`
 }
 
 const (
 	GrantTypeAuthorizationCode = "authorization_code"
 	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeClientCredentials = "client_credentials"
 
 	ResponseTypeCode = "code"
 
@@ -35,6 +38,7 @@ const (
 	OAuthDeauthorizeEndpoint  = "/oauth/deauthorize"
 	OAuthAppsRegisterEndpoint = "/api/v4/oauth/apps/register"
 	OAuthMetadataEndpoint     = "/.well-known/oauth-authorization-server"
+	OAuthIntrospectEndpoint   = "/oauth/introspect"
 )
 
 func GetDefaultMetadata(siteURL string) (*AuthorizationServerMetadata, error) {
@@ -46,16 +50,28 @@ func GetDefaultMetadata(siteURL string) (*AuthorizationServerMetadata, error) {
 	if err != nil {
 		return nil, err
 	}
+	deviceAuthorizationEndpoint, err := url.JoinPath(siteURL, OAuthDeviceAuthorizationEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	introspectionEndpoint, err := url.JoinPath(siteURL, OAuthIntrospectEndpoint)
+	if err != nil {
+		return nil, err
+	}
 	return &AuthorizationServerMetadata{
-		Issuer:                siteURL,
-		AuthorizationEndpoint: authorizationEndpoint,
-		TokenEndpoint:         tokenEndpoint,
+		Issuer:                      siteURL,
+		AuthorizationEndpoint:       authorizationEndpoint,
+		TokenEndpoint:               tokenEndpoint,
+		DeviceAuthorizationEndpoint: deviceAuthorizationEndpoint,
+		IntrospectionEndpoint:       introspectionEndpoint,
 		ResponseTypesSupported: []string{
 			ResponseTypeCode,
 		},
 		GrantTypesSupported: []string{
 			GrantTypeAuthorizationCode,
 			GrantTypeRefreshToken,
+			GrantTypeDeviceCode,
+			GrantTypeClientCredentials,
 		},
 		TokenEndpointAuthMethodsSupported: []string{
 			ClientAuthMethodClientSecretPost,
@@ -0,0 +1,173 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+
+	SubjectTypePublic = "public"
+
+	OIDCSigningAlgorithmRS256 = "RS256"
+	OIDCSigningKeyBits        = 2048
+
+	// OIDCSigningKeyRotationPeriod is how long a signing key is used to sign new ID tokens. The
+	// key of the previous period is still published so that recently issued tokens can be verified.
+	OIDCSigningKeyRotationPeriod = 30 * 24 * time.Hour
+	OIDCIDTokenExpiry            = time.Hour
+)
+
+const (
+	OIDCDiscoveryEndpoint = "/.well-known/openid-configuration"
+	OIDCJWKSEndpoint      = "/oauth/jwks"
+	OIDCUserInfoEndpoint  = "/oauth/userinfo"
+)
+
+// OpenIDProviderMetadata is the OpenID Connect discovery document. It extends the OAuth 2.0
+// authorization server metadata with the OIDC specific fields.
+type OpenIDProviderMetadata struct {
+	AuthorizationServerMetadata
+	UserInfoEndpoint                 string   `json:"userinfo_endpoint"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	ClaimsSupported                  []string `json:"claims_supported,omitempty"`
+	EndSessionEndpoint               string   `json:"end_session_endpoint,omitempty"`
+}
+
+// JSONWebKey is the public part of a signing key as defined in RFC 7517.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// OIDCSigningKey is a private key used to sign ID tokens, as stored in the Systems table.
+type OIDCSigningKey struct {
+	Id         string `json:"id"`
+	CreateAt   int64  `json:"create_at"`
+	PrivateKey []byte `json:"private_key"`
+}
+
+// OIDCProfileClaims are the user claims released in ID tokens and by the userinfo endpoint,
+// depending on the granted scopes.
+type OIDCProfileClaims struct {
+	Name              string   `json:"name,omitempty"`
+	GivenName         string   `json:"given_name,omitempty"`
+	FamilyName        string   `json:"family_name,omitempty"`
+	Nickname          string   `json:"nickname,omitempty"`
+	PreferredUsername string   `json:"preferred_username,omitempty"`
+	Picture           string   `json:"picture,omitempty"`
+	Locale            string   `json:"locale,omitempty"`
+	UpdatedAt         int64    `json:"updated_at,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+	Email             string   `json:"email,omitempty"`
+	EmailVerified     *bool    `json:"email_verified,omitempty"`
+}
+
+type OIDCUserInfo struct {
+	Sub string `json:"sub"`
+	OIDCProfileClaims
+}
+
+// HasScope returns true if the space separated scope string contains the given scope.
+func HasScope(scope, want string) bool {
+	return slices.Contains(strings.Fields(scope), want)
+}
+
+// OIDCSigningKeySystemName returns the name of the Systems entry holding the signing key of the
+// given rotation period.
+func OIDCSigningKeySystemName(period int64) string {
+	return SystemOIDCSigningKeyPrefix + strconv.FormatInt(period, 10)
+}
+
+// NewRSAJSONWebKey returns the JSON Web Key representation of the given RSA public key.
+func NewRSAJSONWebKey(kid string, key *rsa.PublicKey) JSONWebKey {
+	return JSONWebKey{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: OIDCSigningAlgorithmRS256,
+		Kid: kid,
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// RSAPublicKey returns the public key of an RSA JSON Web Key.
+func (k *JSONWebKey) RSAPublicKey() (*rsa.PublicKey, error) {
+	if k.Kty != "RSA" {
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 2 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid RSA key")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+func GetOpenIDProviderMetadata(siteURL string) (*OpenIDProviderMetadata, error) {
+	metadata, err := GetDefaultMetadata(siteURL)
+	if err != nil {
+		return nil, err
+	}
+	userInfoEndpoint, err := url.JoinPath(siteURL, OIDCUserInfoEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	jwksURI, err := url.JoinPath(siteURL, OIDCJWKSEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata.ScopesSupported = append(metadata.ScopesSupported, ScopeOpenID, ScopeProfile, ScopeEmail)
+
+	return &OpenIDProviderMetadata{
+		AuthorizationServerMetadata: *metadata,
+		UserInfoEndpoint:            userInfoEndpoint,
+		JWKSURI:                     jwksURI,
+		SubjectTypesSupported: []string{
+			SubjectTypePublic,
+		},
+		IDTokenSigningAlgValuesSupported: []string{
+			OIDCSigningAlgorithmRS256,
+		},
+		ClaimsSupported: []string{
+			"sub", "iss", "aud", "exp", "iat", "nonce",
+			"name", "given_name", "family_name", "nickname", "preferred_username", "picture", "locale", "updated_at", "groups",
+			"email", "email_verified",
+		},
+	}, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/rsa"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasScope(t *testing.T) {
+	assert.True(t, HasScope("openid profile email", ScopeOpenID))
+	assert.True(t, HasScope(" openid  email ", ScopeEmail))
+	assert.False(t, HasScope("openid profile", ScopeEmail))
+	assert.False(t, HasScope("openidprofile", ScopeOpenID))
+	assert.False(t, HasScope("", ScopeOpenID))
+}
+
+func TestNewRSAJSONWebKey(t *testing.T) {
+	key := &rsa.PublicKey{N: big.NewInt(0xabcdef), E: 65537}
+
+	jwk := NewRSAJSONWebKey("kid1", key)
+	assert.Equal(t, "RSA", jwk.Kty)
+	assert.Equal(t, "sig", jwk.Use)
+	assert.Equal(t, OIDCSigningAlgorithmRS256, jwk.Alg)
+	assert.Equal(t, "kid1", jwk.Kid)
+	assert.Equal(t, "q83v", jwk.N)
+	assert.Equal(t, "AQAB", jwk.E)
+}
+
+func TestJSONWebKeyRSAPublicKey(t *testing.T) {
+	key := &rsa.PublicKey{N: big.NewInt(0xabcdef), E: 65537}
+
+	jwk := NewRSAJSONWebKey("kid1", key)
+	publicKey, err := jwk.RSAPublicKey()
+	require.NoError(t, err)
+	assert.Equal(t, key, publicKey)
+
+	_, err = (&JSONWebKey{Kty: "EC", Kid: "kid2"}).RSAPublicKey()
+	assert.Error(t, err)
+
+	_, err = (&JSONWebKey{Kty: "RSA", N: "q83v", E: "!"}).RSAPublicKey()
+	assert.Error(t, err)
+}
+
+func TestGetOpenIDProviderMetadata(t *testing.T) {
+	metadata, err := GetOpenIDProviderMetadata("https://mattermost.example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://mattermost.example.com", metadata.Issuer)
+	assert.Equal(t, "https://mattermost.example.com/oauth/authorize", metadata.AuthorizationEndpoint)
+	assert.Equal(t, "https://mattermost.example.com/oauth/userinfo", metadata.UserInfoEndpoint)
+	assert.Equal(t, "https://mattermost.example.com/oauth/jwks", metadata.JWKSURI)
+	assert.Equal(t, []string{OIDCSigningAlgorithmRS256}, metadata.IDTokenSigningAlgValuesSupported)
+	assert.Subset(t, metadata.ScopesSupported, []string{ScopeUser, ScopeOpenID, ScopeProfile, ScopeEmail})
+}
+
+func TestAuthorizeRequestNonce(t *testing.T) {
+	ar := AuthorizeRequest{
+		ClientId:     NewId(),
+		ResponseType: AuthCodeResponseType,
+		RedirectURI:  "https://example.com/callback",
+		Nonce:        NewRandomString(OIDCNonceMaxLength),
+	}
+	require.Nil(t, ar.IsValid())
+
+	ar.Nonce = NewRandomString(OIDCNonceMaxLength + 1)
+	appErr := ar.IsValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.authorize.is_valid.nonce.app_error", appErr.Id)
+}
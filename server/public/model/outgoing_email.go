@@ -0,0 +1,133 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	// OutgoingEmailStatusPending marks an email that is waiting to be sent, either
+	// for the first time or after a failed attempt.
+	OutgoingEmailStatusPending = "pending"
+	// OutgoingEmailStatusDead marks an email that exhausted its delivery attempts
+	// and will not be retried unless an administrator requeues it.
+	OutgoingEmailStatusDead = "dead"
+
+	OutgoingEmailSubjectMaxRunes   = 998
+	OutgoingEmailCategoryMaxRunes  = 64
+	OutgoingEmailLastErrorMaxRunes = 1024
+)
+
+// OutgoingEmail is an email persisted in the outbound mail queue. Emails are
+// removed from the queue once they have been delivered. The HTML body is stored
+// encrypted, since it may carry tokens such as password reset links.
+type OutgoingEmail struct {
+	Id            string `json:"id"`
+	CreateAt      int64  `json:"create_at"`
+	UpdateAt      int64  `json:"update_at"`
+	To            string `json:"to"`
+	Cc            string `json:"cc,omitempty"`
+	ReplyTo       string `json:"reply_to,omitempty"`
+	Subject       string `json:"subject"`
+	HTMLBody      string `json:"html_body,omitempty"`
+	MessageId     string `json:"message_id,omitempty"`
+	InReplyTo     string `json:"in_reply_to,omitempty"`
+	References    string `json:"references,omitempty"`
+	Category      string `json:"category,omitempty"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	NextAttemptAt int64  `json:"next_attempt_at"`
+	LastError     string `json:"last_error,omitempty"`
+}
+
+type OutgoingEmailsWithCount struct {
+	Emails     []*OutgoingEmail `json:"emails"`
+	TotalCount int64            `json:"total_count"`
+}
+
+func (e *OutgoingEmail) Auditable() map[string]any {
+	return map[string]any{
+		"id":              e.Id,
+		"create_at":       e.CreateAt,
+		"update_at":       e.UpdateAt,
+		"subject":         e.Subject,
+		"category":        e.Category,
+		"status":          e.Status,
+		"attempts":        e.Attempts,
+		"next_attempt_at": e.NextAttemptAt,
+	}
+}
+
+func (e *OutgoingEmail) PreSave() {
+	if e.Id == "" {
+		e.Id = NewId()
+	}
+
+	if e.CreateAt == 0 {
+		e.CreateAt = GetMillis()
+	}
+	e.UpdateAt = e.CreateAt
+
+	if e.Status == "" {
+		e.Status = OutgoingEmailStatusPending
+	}
+
+	if e.NextAttemptAt == 0 {
+		e.NextAttemptAt = e.CreateAt
+	}
+
+	e.LastError = truncateRunes(e.LastError, OutgoingEmailLastErrorMaxRunes)
+}
+
+func (e *OutgoingEmail) PreUpdate() {
+	e.UpdateAt = GetMillis()
+	e.LastError = truncateRunes(e.LastError, OutgoingEmailLastErrorMaxRunes)
+}
+
+// Sanitize removes the body of the email, which may contain tokens or message
+// contents, before returning it to a client.
+func (e *OutgoingEmail) Sanitize() {
+	e.HTMLBody = ""
+}
+
+func (e *OutgoingEmail) IsValid() *AppError {
+	if !IsValidId(e.Id) {
+		return NewAppError("OutgoingEmail.IsValid", "model.outgoing_email.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if e.CreateAt == 0 {
+		return NewAppError("OutgoingEmail.IsValid", "model.outgoing_email.is_valid.create_at.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if e.UpdateAt == 0 {
+		return NewAppError("OutgoingEmail.IsValid", "model.outgoing_email.is_valid.update_at.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if e.To == "" {
+		return NewAppError("OutgoingEmail.IsValid", "model.outgoing_email.is_valid.to.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(e.Subject) > OutgoingEmailSubjectMaxRunes {
+		return NewAppError("OutgoingEmail.IsValid", "model.outgoing_email.is_valid.subject.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(e.Category) > OutgoingEmailCategoryMaxRunes {
+		return NewAppError("OutgoingEmail.IsValid", "model.outgoing_email.is_valid.category.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if e.Status != OutgoingEmailStatusPending && e.Status != OutgoingEmailStatusDead {
+		return NewAppError("OutgoingEmail.IsValid", "model.outgoing_email.is_valid.status.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func truncateRunes(s string, maxRunes int) string {
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	return string([]rune(s)[:maxRunes])
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Groups of plugin API methods a plugin can request access to. Methods which
+// only touch the plugin's own state, like the KV store or its configuration,
+// are always available and don't belong to any group. The unsanitized
+// configuration holds the credentials of the server, so it has a group of its
+// own rather than being part of config.read.
+const (
+	PluginAPIGroupConfigRead     = "config.read"
+	PluginAPIGroupConfigSecrets  = "config.secrets"
+	PluginAPIGroupConfigWrite    = "config.write"
+	PluginAPIGroupUsersRead      = "users.read"
+	PluginAPIGroupUsersWrite     = "users.write"
+	PluginAPIGroupUsersRoles     = "users.roles"
+	PluginAPIGroupSessions       = "sessions"
+	PluginAPIGroupTeamsRead      = "teams.read"
+	PluginAPIGroupTeamsWrite     = "teams.write"
+	PluginAPIGroupChannelsRead   = "channels.read"
+	PluginAPIGroupChannelsWrite  = "channels.write"
+	PluginAPIGroupPostsRead      = "posts.read"
+	PluginAPIGroupPostsWrite     = "posts.write"
+	PluginAPIGroupFilesRead      = "files.read"
+	PluginAPIGroupFilesWrite     = "files.write"
+	PluginAPIGroupEmojisRead     = "emojis.read"
+	PluginAPIGroupGroupsRead     = "groups.read"
+	PluginAPIGroupGroupsWrite    = "groups.write"
+	PluginAPIGroupPluginsManage  = "plugins.manage"
+	PluginAPIGroupBotsRead       = "bots.read"
+	PluginAPIGroupBotsWrite      = "bots.write"
+	PluginAPIGroupCommands       = "commands"
+	PluginAPIGroupOAuth          = "oauth"
+	PluginAPIGroupEmail          = "email"
+	PluginAPIGroupLicense        = "license"
+	PluginAPIGroupSharedChannels = "shared_channels"
+	PluginAPIGroupProperties     = "properties"
+	PluginAPIGroupSearchEngine   = "search_engine"
+)
+
+var pluginAPIGroups = []string{
+	PluginAPIGroupConfigRead,
+	PluginAPIGroupConfigSecrets,
+	PluginAPIGroupConfigWrite,
+	PluginAPIGroupUsersRead,
+	PluginAPIGroupUsersWrite,
+	PluginAPIGroupUsersRoles,
+	PluginAPIGroupSessions,
+	PluginAPIGroupTeamsRead,
+	PluginAPIGroupTeamsWrite,
+	PluginAPIGroupChannelsRead,
+	PluginAPIGroupChannelsWrite,
+	PluginAPIGroupPostsRead,
+	PluginAPIGroupPostsWrite,
+	PluginAPIGroupFilesRead,
+	PluginAPIGroupFilesWrite,
+	PluginAPIGroupEmojisRead,
+	PluginAPIGroupGroupsRead,
+	PluginAPIGroupGroupsWrite,
+	PluginAPIGroupPluginsManage,
+	PluginAPIGroupBotsRead,
+	PluginAPIGroupBotsWrite,
+	PluginAPIGroupCommands,
+	PluginAPIGroupOAuth,
+	PluginAPIGroupEmail,
+	PluginAPIGroupLicense,
+	PluginAPIGroupSharedChannels,
+	PluginAPIGroupProperties,
+	PluginAPIGroupSearchEngine,
+}
+
+// PluginAPIGroups returns all the groups of plugin API methods.
+func PluginAPIGroups() []string {
+	return slices.Clone(pluginAPIGroups)
+}
+
+// PluginPermissions are the capabilities a server plugin declares in its manifest.
+//
+// A plugin declaring permissions can only call the API methods of the declared groups and only
+// receives the declared hooks, besides the lifecycle hooks every plugin receives. A manifest
+// without permissions is a legacy plugin with access to everything.
+type PluginPermissions struct {
+	// API holds the groups of API methods the plugin calls, e.g. "posts.read".
+	API []string `json:"api,omitempty" yaml:"api,omitempty"`
+
+	// Hooks holds the names of the hooks the plugin implements, e.g. "MessageHasBeenPosted".
+	Hooks []string `json:"hooks,omitempty" yaml:"hooks,omitempty"`
+
+	// Database is whether the plugin gets direct access to the database through the store.
+	Database bool `json:"database,omitempty" yaml:"database,omitempty"`
+
+	// OutboundHTTP is whether the plugin makes outbound HTTP requests. The server doesn't mediate
+	// the network access of the plugin process, so this is shown to the admin approving the
+	// plugin but is not enforced.
+	OutboundHTTP bool `json:"outbound_http,omitempty" yaml:"outbound_http,omitempty"`
+}
+
+func (p *PluginPermissions) IsValid() error {
+	for i, group := range p.API {
+		if !slices.Contains(pluginAPIGroups, group) {
+			return fmt.Errorf("unknown API group %q", group)
+		}
+		if slices.Contains(p.API[:i], group) {
+			return fmt.Errorf("duplicate API group %q", group)
+		}
+	}
+
+	for i, hook := range p.Hooks {
+		if strings.TrimSpace(hook) == "" {
+			return errors.New("empty hook name")
+		}
+		if slices.Contains(p.Hooks[:i], hook) {
+			return fmt.Errorf("duplicate hook %q", hook)
+		}
+	}
+
+	return nil
+}
+
+// HasAPIGroup returns whether the permissions grant the given group of API methods.
+func (p *PluginPermissions) HasAPIGroup(group string) bool {
+	return slices.Contains(p.API, group)
+}
+
+// HasHook returns whether the permissions grant the given hook.
+func (p *PluginPermissions) HasHook(hook string) bool {
+	return slices.Contains(p.Hooks, hook)
+}
+
+// IsSubsetOf returns whether every permission in p is also granted by other. Nil permissions
+// grant everything, so they are only a subset of nil permissions.
+func (p *PluginPermissions) IsSubsetOf(other *PluginPermissions) bool {
+	if other == nil {
+		return true
+	}
+	if p == nil {
+		return false
+	}
+
+	for _, group := range p.API {
+		if !other.HasAPIGroup(group) {
+			return false
+		}
+	}
+	for _, hook := range p.Hooks {
+		if !other.HasHook(hook) {
+			return false
+		}
+	}
+
+	return (!p.Database || other.Database) && (!p.OutboundHTTP || other.OutboundHTTP)
+}
+
+func (p *PluginPermissions) Auditable() map[string]any {
+	return map[string]any{
+		"api":           p.API,
+		"hooks":         p.Hooks,
+		"database":      p.Database,
+		"outbound_http": p.OutboundHTTP,
+	}
+}
+
+func (p *PluginPermissions) Clone() *PluginPermissions {
+	if p == nil {
+		return nil
+	}
+
+	return &PluginPermissions{
+		API:          slices.Clone(p.API),
+		Hooks:        slices.Clone(p.Hooks),
+		Database:     p.Database,
+		OutboundHTTP: p.OutboundHTTP,
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginPermissionsIsValid(t *testing.T) {
+	testCases := []struct {
+		Title       string
+		Permissions *PluginPermissions
+		ExpectError bool
+	}{
+		{"Empty permissions", &PluginPermissions{}, false},
+		{"Valid permissions", &PluginPermissions{API: []string{PluginAPIGroupPostsRead, PluginAPIGroupUsersRead}, Hooks: []string{"MessageHasBeenPosted"}, Database: true}, false},
+		{"Unknown API group", &PluginPermissions{API: []string{"posts.everything"}}, true},
+		{"Duplicate API group", &PluginPermissions{API: []string{PluginAPIGroupPostsRead, PluginAPIGroupPostsRead}}, true},
+		{"Empty hook", &PluginPermissions{Hooks: []string{" "}}, true},
+		{"Duplicate hook", &PluginPermissions{Hooks: []string{"MessageHasBeenPosted", "MessageHasBeenPosted"}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Title, func(t *testing.T) {
+			err := tc.Permissions.IsValid()
+			if tc.ExpectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPluginPermissionsIsSubsetOf(t *testing.T) {
+	approved := &PluginPermissions{
+		API:   []string{PluginAPIGroupPostsRead, PluginAPIGroupPostsWrite},
+		Hooks: []string{"MessageHasBeenPosted"},
+	}
+
+	testCases := []struct {
+		Title       string
+		Permissions *PluginPermissions
+		Approved    *PluginPermissions
+		Expected    bool
+	}{
+		{"Same permissions", approved.Clone(), approved, true},
+		{"Fewer permissions", &PluginPermissions{API: []string{PluginAPIGroupPostsRead}}, approved, true},
+		{"Extra API group", &PluginPermissions{API: []string{PluginAPIGroupPostsRead, PluginAPIGroupUsersRoles}}, approved, false},
+		{"Extra hook", &PluginPermissions{Hooks: []string{"UserHasLoggedIn"}}, approved, false},
+		{"Extra database access", &PluginPermissions{Database: true}, approved, false},
+		{"Extra outbound HTTP", &PluginPermissions{OutboundHTTP: true}, approved, false},
+		{"Nil permissions grant everything", &PluginPermissions{API: []string{PluginAPIGroupConfigWrite}, Database: true}, nil, true},
+		{"Nil permissions are only a subset of nil permissions", nil, approved, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Title, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, tc.Permissions.IsSubsetOf(tc.Approved))
+		})
+	}
+}
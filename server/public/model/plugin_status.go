@@ -22,6 +22,11 @@ type PluginStatus struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Version     string `json:"version"`
+
+	// Permissions are the permissions declared in the manifest of the plugin and
+	// ApprovedPermissions the ones approved by a system admin.
+	Permissions         *PluginPermissions `json:"permissions,omitempty"`
+	ApprovedPermissions *PluginPermissions `json:"approved_permissions,omitempty"`
 }
 
 type PluginStatuses []*PluginStatus
@@ -0,0 +1,387 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	ScimBasePath    = "/scim/v2"
+	ScimContentType = "application/scim+json"
+
+	ScimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaEnterpriseUser        = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	ScimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ScimSchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	ScimSchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+	ScimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	ScimResourceTypeUser  = "User"
+	ScimResourceTypeGroup = "Group"
+
+	ScimErrorTypeInvalidFilter = "invalidFilter"
+	ScimErrorTypeInvalidPath   = "invalidPath"
+	ScimErrorTypeInvalidValue  = "invalidValue"
+	ScimErrorTypeInvalidSyntax = "invalidSyntax"
+	ScimErrorTypeUniqueness    = "uniqueness"
+	ScimErrorTypeMutability    = "mutability"
+
+	ScimDefaultCount = 100
+	ScimMaxCount     = 200
+)
+
+type ScimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// ScimMultiValue is an entry of a multi-valued attribute such as emails or group members.
+type ScimMultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type ScimManager struct {
+	Value       string `json:"value,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+type ScimEnterpriseUser struct {
+	EmployeeNumber string       `json:"employeeNumber,omitempty"`
+	CostCenter     string       `json:"costCenter,omitempty"`
+	Organization   string       `json:"organization,omitempty"`
+	Division       string       `json:"division,omitempty"`
+	Department     string       `json:"department,omitempty"`
+	Manager        *ScimManager `json:"manager,omitempty"`
+}
+
+// ScimUser is a user resource as defined in RFC 7643 section 4.1.
+type ScimUser struct {
+	Schemas           []string            `json:"schemas"`
+	Id                string              `json:"id,omitempty"`
+	ExternalId        string              `json:"externalId,omitempty"`
+	UserName          string              `json:"userName"`
+	Name              *ScimName           `json:"name,omitempty"`
+	DisplayName       string              `json:"displayName,omitempty"`
+	NickName          string              `json:"nickName,omitempty"`
+	Title             string              `json:"title,omitempty"`
+	UserType          string              `json:"userType,omitempty"`
+	PreferredLanguage string              `json:"preferredLanguage,omitempty"`
+	Locale            string              `json:"locale,omitempty"`
+	Timezone          string              `json:"timezone,omitempty"`
+	Active            *bool               `json:"active,omitempty"`
+	Password          string              `json:"password,omitempty"`
+	Emails            []ScimMultiValue    `json:"emails,omitempty"`
+	PhoneNumbers      []ScimMultiValue    `json:"phoneNumbers,omitempty"`
+	Groups            []ScimMultiValue    `json:"groups,omitempty"`
+	EnterpriseUser    *ScimEnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta              *ScimMeta           `json:"meta,omitempty"`
+}
+
+// PrimaryEmail returns the email marked as primary, or the first one if none is.
+func (u *ScimUser) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// ScimGroup is a group resource as defined in RFC 7643 section 4.2.
+type ScimGroup struct {
+	Schemas     []string         `json:"schemas"`
+	Id          string           `json:"id,omitempty"`
+	ExternalId  string           `json:"externalId,omitempty"`
+	DisplayName string           `json:"displayName"`
+	Members     []ScimMultiValue `json:"members,omitempty"`
+	Meta        *ScimMeta        `json:"meta,omitempty"`
+}
+
+type ScimListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int64    `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+func NewScimListResponse(resources []any, totalResults int64, startIndex int) *ScimListResponse {
+	if resources == nil {
+		resources = []any{}
+	}
+	return &ScimListResponse{
+		Schemas:      []string{ScimSchemaListResponse},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// ScimListParams are the pagination and filtering query parameters of a list request. StartIndex
+// is 1-based.
+type ScimListParams struct {
+	Filter             *ScimFilter
+	StartIndex         int
+	Count              int
+	ExcludedAttributes []string
+}
+
+// Excludes reports whether the client asked for the given attribute to be left out.
+func (p *ScimListParams) Excludes(attribute string) bool {
+	for _, excluded := range p.ExcludedAttributes {
+		if strings.EqualFold(excluded, attribute) {
+			return true
+		}
+	}
+	return false
+}
+
+type ScimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func NewScimError(status int, scimType, detail string) *ScimError {
+	return &ScimError{
+		Schemas:  []string{ScimSchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+type ScimSupported struct {
+	Supported bool `json:"supported"`
+}
+
+type ScimBulkConfig struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type ScimFilterConfig struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type ScimAuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+type ScimServiceProviderConfig struct {
+	Schemas               []string                   `json:"schemas"`
+	Patch                 ScimSupported              `json:"patch"`
+	Bulk                  ScimBulkConfig             `json:"bulk"`
+	Filter                ScimFilterConfig           `json:"filter"`
+	ChangePassword        ScimSupported              `json:"changePassword"`
+	Sort                  ScimSupported              `json:"sort"`
+	Etag                  ScimSupported              `json:"etag"`
+	AuthenticationSchemes []ScimAuthenticationScheme `json:"authenticationSchemes"`
+	Meta                  *ScimMeta                  `json:"meta,omitempty"`
+}
+
+// NewScimServiceProviderConfig describes the features of the SCIM API, baseURL being the URL
+// of the /scim/v2 endpoint.
+func NewScimServiceProviderConfig(baseURL string) *ScimServiceProviderConfig {
+	return &ScimServiceProviderConfig{
+		Schemas: []string{ScimSchemaServiceProviderConfig},
+		Patch:   ScimSupported{Supported: true},
+		Filter:  ScimFilterConfig{Supported: true, MaxResults: ScimMaxCount},
+		AuthenticationSchemes: []ScimAuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "OAuth Bearer Token",
+			Description: "Authentication with the bearer token set in the SCIM settings",
+			Primary:     true,
+		}},
+		Meta: &ScimMeta{
+			ResourceType: "ServiceProviderConfig",
+			Location:     baseURL + "/ServiceProviderConfig",
+		},
+	}
+}
+
+type ScimSchemaExtension struct {
+	Schema   string `json:"schema"`
+	Required bool   `json:"required"`
+}
+
+type ScimResourceType struct {
+	Schemas          []string              `json:"schemas"`
+	Id               string                `json:"id"`
+	Name             string                `json:"name"`
+	Endpoint         string                `json:"endpoint"`
+	Description      string                `json:"description"`
+	Schema           string                `json:"schema"`
+	SchemaExtensions []ScimSchemaExtension `json:"schemaExtensions,omitempty"`
+	Meta             *ScimMeta             `json:"meta,omitempty"`
+}
+
+// NewScimResourceTypes lists the resource types served by the SCIM API.
+func NewScimResourceTypes(baseURL string) []*ScimResourceType {
+	return []*ScimResourceType{
+		{
+			Schemas:          []string{ScimSchemaResourceType},
+			Id:               ScimResourceTypeUser,
+			Name:             ScimResourceTypeUser,
+			Endpoint:         "/Users",
+			Description:      "User Account",
+			Schema:           ScimSchemaUser,
+			SchemaExtensions: []ScimSchemaExtension{{Schema: ScimSchemaEnterpriseUser}},
+			Meta:             &ScimMeta{ResourceType: "ResourceType", Location: baseURL + "/ResourceTypes/" + ScimResourceTypeUser},
+		},
+		{
+			Schemas:     []string{ScimSchemaResourceType},
+			Id:          ScimResourceTypeGroup,
+			Name:        ScimResourceTypeGroup,
+			Endpoint:    "/Groups",
+			Description: "Group",
+			Schema:      ScimSchemaGroup,
+			Meta:        &ScimMeta{ResourceType: "ResourceType", Location: baseURL + "/ResourceTypes/" + ScimResourceTypeGroup},
+		},
+	}
+}
+
+type ScimSchemaAttribute struct {
+	Name          string                `json:"name"`
+	Type          string                `json:"type"`
+	MultiValued   bool                  `json:"multiValued"`
+	Required      bool                  `json:"required"`
+	CaseExact     bool                  `json:"caseExact"`
+	Mutability    string                `json:"mutability"`
+	Returned      string                `json:"returned"`
+	Uniqueness    string                `json:"uniqueness"`
+	SubAttributes []ScimSchemaAttribute `json:"subAttributes,omitempty"`
+}
+
+type ScimSchemaDefinition struct {
+	Schemas     []string              `json:"schemas"`
+	Id          string                `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Attributes  []ScimSchemaAttribute `json:"attributes"`
+	Meta        *ScimMeta             `json:"meta,omitempty"`
+}
+
+func scimAttribute(name, attributeType string, subAttributes ...ScimSchemaAttribute) ScimSchemaAttribute {
+	return ScimSchemaAttribute{
+		Name:          name,
+		Type:          attributeType,
+		Mutability:    "readWrite",
+		Returned:      "default",
+		Uniqueness:    "none",
+		SubAttributes: subAttributes,
+	}
+}
+
+func scimMultiValuedAttribute(name string, subAttributes ...ScimSchemaAttribute) ScimSchemaAttribute {
+	attribute := scimAttribute(name, "complex", subAttributes...)
+	attribute.MultiValued = true
+	return attribute
+}
+
+// NewScimSchemaDefinitions describes the attributes of the resources served by the SCIM API.
+// Only the attributes that are stored are listed.
+func NewScimSchemaDefinitions(baseURL string) []*ScimSchemaDefinition {
+	userName := scimAttribute("userName", "string")
+	userName.Required = true
+	userName.Uniqueness = "server"
+
+	active := scimAttribute("active", "boolean")
+
+	password := scimAttribute("password", "string")
+	password.Mutability = "writeOnly"
+	password.Returned = "never"
+
+	groups := scimMultiValuedAttribute("groups",
+		scimAttribute("value", "string"),
+		scimAttribute("display", "string"),
+		scimAttribute("$ref", "reference"),
+	)
+	groups.Mutability = "readOnly"
+
+	displayName := scimAttribute("displayName", "string")
+	displayName.Required = true
+
+	multiValue := []ScimSchemaAttribute{
+		scimAttribute("value", "string"),
+		scimAttribute("type", "string"),
+		scimAttribute("primary", "boolean"),
+	}
+
+	schema := func(id, name, description string, attributes ...ScimSchemaAttribute) *ScimSchemaDefinition {
+		return &ScimSchemaDefinition{
+			Schemas:     []string{ScimSchemaSchema},
+			Id:          id,
+			Name:        name,
+			Description: description,
+			Attributes:  attributes,
+			Meta:        &ScimMeta{ResourceType: "Schema", Location: baseURL + "/Schemas/" + id},
+		}
+	}
+
+	return []*ScimSchemaDefinition{
+		schema(ScimSchemaUser, ScimResourceTypeUser, "User Account",
+			userName,
+			scimAttribute("name", "complex",
+				scimAttribute("formatted", "string"),
+				scimAttribute("givenName", "string"),
+				scimAttribute("familyName", "string"),
+			),
+			scimAttribute("displayName", "string"),
+			scimAttribute("nickName", "string"),
+			scimAttribute("title", "string"),
+			active,
+			password,
+			scimMultiValuedAttribute("emails", multiValue...),
+			scimMultiValuedAttribute("phoneNumbers", multiValue...),
+			groups,
+		),
+		schema(ScimSchemaEnterpriseUser, "EnterpriseUser", "Enterprise User",
+			scimAttribute("employeeNumber", "string"),
+			scimAttribute("costCenter", "string"),
+			scimAttribute("organization", "string"),
+			scimAttribute("division", "string"),
+			scimAttribute("department", "string"),
+			scimAttribute("manager", "complex",
+				scimAttribute("value", "string"),
+				scimAttribute("displayName", "string"),
+			),
+		),
+		schema(ScimSchemaGroup, ScimResourceTypeGroup, "Group",
+			displayName,
+			scimMultiValuedAttribute("members",
+				scimAttribute("value", "string"),
+				scimAttribute("display", "string"),
+				scimAttribute("$ref", "reference"),
+			),
+		),
+	}
+}
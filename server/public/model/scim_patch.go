@@ -0,0 +1,819 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	ScimPatchOpAdd     = "add"
+	ScimPatchOpReplace = "replace"
+	ScimPatchOpRemove  = "remove"
+)
+
+var (
+	ErrScimInvalidFilter = errors.New("invalid SCIM filter")
+	ErrScimInvalidPath   = errors.New("invalid SCIM path")
+	ErrScimInvalidValue  = errors.New("invalid SCIM value")
+)
+
+type ScimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []ScimPatchOperation `json:"Operations"`
+}
+
+type ScimPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// ScimFilter is a filter expression as defined in RFC 7644 section 3.4.2.2. Only conditions
+// joined by "and" are supported, which covers the queries issued by the common identity providers.
+type ScimFilter struct {
+	Conditions []ScimFilterCondition
+}
+
+// ScimFilterCondition compares an attribute to a value. Conditions on a value path such as
+// members[value eq "id"] have no operator and match if any value does.
+type ScimFilterCondition struct {
+	Path     string
+	Operator string
+	Value    string
+
+	path *scimPath
+}
+
+// scimPath is an attribute path, e.g. emails[type eq "work"].value. Schema is set for the
+// attributes of an extension.
+type scimPath struct {
+	schema  string
+	attr    string
+	filter  *ScimFilter
+	subAttr string
+}
+
+var scimFilterOperators = []string{"eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le", "pr"}
+
+func ParseScimFilter(filter string) (*ScimFilter, error) {
+	tokens, err := tokenizeScimFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty filter", ErrScimInvalidFilter)
+	}
+
+	result := &ScimFilter{}
+	for len(tokens) > 0 {
+		condition := ScimFilterCondition{Path: tokens[0]}
+		tokens = tokens[1:]
+
+		if !strings.HasSuffix(condition.Path, "]") {
+			if len(tokens) == 0 {
+				return nil, fmt.Errorf("%w: missing operator after %q", ErrScimInvalidFilter, condition.Path)
+			}
+			condition.Operator = strings.ToLower(tokens[0])
+			tokens = tokens[1:]
+			if !slices.Contains(scimFilterOperators, condition.Operator) {
+				return nil, fmt.Errorf("%w: unsupported operator %q", ErrScimInvalidFilter, condition.Operator)
+			}
+
+			if condition.Operator != "pr" {
+				if len(tokens) == 0 {
+					return nil, fmt.Errorf("%w: missing value after %q", ErrScimInvalidFilter, condition.Operator)
+				}
+				condition.Value = unquoteScimValue(tokens[0])
+				tokens = tokens[1:]
+			}
+		}
+
+		path, err := parseScimPath(condition.Path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrScimInvalidFilter, err)
+		}
+		condition.path = path
+		result.Conditions = append(result.Conditions, condition)
+
+		if len(tokens) > 0 {
+			if !strings.EqualFold(tokens[0], "and") || len(tokens) == 1 {
+				return nil, fmt.Errorf("%w: only conditions joined by \"and\" are supported", ErrScimInvalidFilter)
+			}
+			tokens = tokens[1:]
+		}
+	}
+
+	return result, nil
+}
+
+// tokenizeScimFilter splits a filter on spaces, keeping quoted strings and value filters between
+// brackets whole. Quoted strings keep their quotes so they can be told apart from keywords.
+func tokenizeScimFilter(filter string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+	depth := 0
+
+	for i := 0; i < len(filter); i++ {
+		c := filter[i]
+		switch {
+		case inQuotes:
+			current.WriteByte(c)
+			if c == '\\' && i+1 < len(filter) {
+				i++
+				current.WriteByte(filter[i])
+			} else if c == '"' {
+				inQuotes = false
+			}
+		case c == '"':
+			inQuotes = true
+			current.WriteByte(c)
+		case c == '[':
+			depth++
+			current.WriteByte(c)
+		case c == ']':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("%w: unbalanced brackets", ErrScimInvalidFilter)
+			}
+			current.WriteByte(c)
+		case c == '(' || c == ')':
+			if depth == 0 {
+				return nil, fmt.Errorf("%w: grouping is not supported", ErrScimInvalidFilter)
+			}
+			current.WriteByte(c)
+		case c == ' ' && depth == 0:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	if inQuotes || depth != 0 {
+		return nil, fmt.Errorf("%w: unterminated expression", ErrScimInvalidFilter)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
+
+func unquoteScimValue(token string) string {
+	if value, err := strconv.Unquote(token); err == nil && strings.HasPrefix(token, `"`) {
+		return value
+	}
+	return strings.ToLower(token)
+}
+
+func parseScimPath(path string) (*scimPath, error) {
+	result := &scimPath{}
+	rest := strings.TrimSpace(path)
+
+	if len(rest) > 4 && strings.EqualFold(rest[:4], "urn:") {
+		found := false
+		for _, schema := range []string{ScimSchemaEnterpriseUser, ScimSchemaUser, ScimSchemaGroup} {
+			isExtension := schema == ScimSchemaEnterpriseUser
+			if strings.EqualFold(rest, schema) && isExtension {
+				result.schema = schema
+				return result, nil
+			}
+			if len(rest) > len(schema)+1 && strings.EqualFold(rest[:len(schema)+1], schema+":") {
+				rest = rest[len(schema)+1:]
+				if isExtension {
+					result.schema = schema
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown schema in %q", ErrScimInvalidPath, path)
+		}
+	}
+
+	if start := strings.IndexByte(rest, '['); start >= 0 {
+		end := strings.LastIndexByte(rest, ']')
+		if end < start {
+			return nil, fmt.Errorf("%w: unbalanced brackets in %q", ErrScimInvalidPath, path)
+		}
+		filter, err := ParseScimFilter(rest[start+1 : end])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrScimInvalidPath, err)
+		}
+		result.attr = rest[:start]
+		result.filter = filter
+		rest = rest[end+1:]
+		if rest != "" {
+			if rest[0] != '.' {
+				return nil, fmt.Errorf("%w: %q", ErrScimInvalidPath, path)
+			}
+			result.subAttr = rest[1:]
+		}
+	} else if dot := strings.IndexByte(rest, '.'); dot >= 0 {
+		result.attr, result.subAttr = rest[:dot], rest[dot+1:]
+	} else {
+		result.attr = rest
+	}
+
+	if result.attr == "" || strings.ContainsAny(result.attr, ".:\" ") || strings.ContainsAny(result.subAttr, ".[]\" ") {
+		return nil, fmt.Errorf("%w: %q", ErrScimInvalidPath, path)
+	}
+
+	return result, nil
+}
+
+// EqualityValue returns the value compared for equality to the given top-level attribute, so that
+// the resources to filter can be looked up directly.
+func (f *ScimFilter) EqualityValue(attr string) (string, bool) {
+	for _, condition := range f.Conditions {
+		if condition.Operator == "eq" && condition.path.schema == "" && condition.path.filter == nil &&
+			condition.path.subAttr == "" && strings.EqualFold(condition.path.attr, attr) {
+			return condition.Value, true
+		}
+	}
+	return "", false
+}
+
+// References reports whether any condition of the filter is on the given top-level attribute.
+func (f *ScimFilter) References(attr string) bool {
+	for _, condition := range f.Conditions {
+		if strings.EqualFold(condition.path.attr, attr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches evaluates the filter against a resource. String comparisons are case-insensitive.
+func (f *ScimFilter) Matches(resource any) bool {
+	data, err := toScimMap(resource)
+	if err != nil {
+		return false
+	}
+	return f.matches(data)
+}
+
+func (f *ScimFilter) matches(resource map[string]any) bool {
+	for _, condition := range f.Conditions {
+		if !condition.matches(resource) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *ScimFilterCondition) matches(resource map[string]any) bool {
+	values := c.path.lookup(resource)
+
+	switch c.Operator {
+	case "":
+		return len(values) > 0
+	case "pr":
+		return slices.ContainsFunc(values, func(v any) bool { return v != nil && v != "" })
+	case "ne":
+		return !slices.ContainsFunc(values, func(v any) bool { return compareScimValue(v, "eq", c.Value) })
+	}
+
+	return slices.ContainsFunc(values, func(v any) bool { return compareScimValue(v, c.Operator, c.Value) })
+}
+
+func compareScimValue(value any, operator, expected string) bool {
+	var actual string
+	switch v := value.(type) {
+	case string:
+		actual = v
+	case bool:
+		actual = strconv.FormatBool(v)
+	case float64:
+		actual = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return false
+	}
+
+	actual = strings.ToLower(actual)
+	expected = strings.ToLower(expected)
+
+	switch operator {
+	case "eq":
+		return actual == expected
+	case "co":
+		return strings.Contains(actual, expected)
+	case "sw":
+		return strings.HasPrefix(actual, expected)
+	case "ew":
+		return strings.HasSuffix(actual, expected)
+	case "gt":
+		return actual > expected
+	case "ge":
+		return actual >= expected
+	case "lt":
+		return actual < expected
+	case "le":
+		return actual <= expected
+	}
+	return false
+}
+
+// lookup returns the values at the path, flattening multi-valued attributes.
+func (p *scimPath) lookup(resource map[string]any) []any {
+	container := resource
+	if p.schema != "" {
+		extension, ok := resource[scimKey(resource, p.schema)].(map[string]any)
+		if !ok {
+			return nil
+		}
+		container = extension
+	}
+
+	value, ok := container[scimKey(container, p.attr)]
+	if !ok || value == nil {
+		return nil
+	}
+
+	elements, isArray := value.([]any)
+	if !isArray {
+		elements = []any{value}
+	}
+
+	var values []any
+	for _, element := range elements {
+		item, isMap := element.(map[string]any)
+		if p.filter != nil && (!isMap || !p.filter.matches(item)) {
+			continue
+		}
+		if p.subAttr == "" {
+			values = append(values, element)
+		} else if isMap {
+			if subValue, ok := item[scimKey(item, p.subAttr)]; ok {
+				values = append(values, subValue)
+			}
+		}
+	}
+
+	return values
+}
+
+// template returns the sub-attributes asserted by the equality conditions of a value filter, used
+// to create the value a path such as emails[type eq "work"].value refers to.
+func (f *ScimFilter) template() map[string]any {
+	item := map[string]any{}
+	for _, condition := range f.Conditions {
+		if condition.Operator == "eq" && condition.path.schema == "" && condition.path.filter == nil && condition.path.subAttr == "" {
+			item[condition.path.attr] = condition.Value
+		}
+	}
+	return item
+}
+
+// scimKey returns the key of the attribute in the map, attribute names being case-insensitive.
+func scimKey(m map[string]any, attr string) string {
+	if _, ok := m[attr]; ok {
+		return attr
+	}
+	for key := range m {
+		if strings.EqualFold(key, attr) {
+			return key
+		}
+	}
+	return attr
+}
+
+func toScimMap(resource any) (map[string]any, error) {
+	if m, ok := resource.(map[string]any); ok {
+		return m, nil
+	}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ScimAttributeString returns the first value at the path of a resource as a string, e.g. for
+// "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department".
+func ScimAttributeString(resource any, path string) (string, error) {
+	p, err := parseScimPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := toScimMap(resource)
+	if err != nil {
+		return "", err
+	}
+
+	for _, value := range p.lookup(data) {
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+	}
+	return "", nil
+}
+
+// ApplyScimPatch applies the operations of a PATCH request, see RFC 7644 section 3.5.2, to the
+// resource pointed to.
+func ApplyScimPatch(resource any, operations []ScimPatchOperation) error {
+	data, err := toScimMap(resource)
+	if err != nil {
+		return err
+	}
+
+	for _, operation := range operations {
+		if err := applyScimOperation(data, operation); err != nil {
+			return err
+		}
+	}
+	normalizeScimValues(data)
+
+	patched, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	// Reset the resource first so that removed attributes don't survive the decoding.
+	reflect.ValueOf(resource).Elem().SetZero()
+	if err := json.Unmarshal(patched, resource); err != nil {
+		return fmt.Errorf("%w: %w", ErrScimInvalidValue, err)
+	}
+
+	return nil
+}
+
+func applyScimOperation(resource map[string]any, operation ScimPatchOperation) error {
+	op := strings.ToLower(operation.Op)
+	if op != ScimPatchOpAdd && op != ScimPatchOpReplace && op != ScimPatchOpRemove {
+		return fmt.Errorf("%w: unknown operation %q", ErrScimInvalidValue, operation.Op)
+	}
+
+	if operation.Path == "" {
+		if op == ScimPatchOpRemove {
+			return fmt.Errorf("%w: remove requires a path", ErrScimInvalidPath)
+		}
+		values, ok := operation.Value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: an object is required without a path", ErrScimInvalidValue)
+		}
+		for key, value := range values {
+			if err := applyScimOperation(resource, ScimPatchOperation{Op: op, Path: key, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	p, err := parseScimPath(operation.Path)
+	if err != nil {
+		return err
+	}
+
+	container := resource
+	if p.schema != "" {
+		key := scimKey(resource, p.schema)
+		extension, _ := resource[key].(map[string]any)
+
+		if p.attr == "" {
+			if op == ScimPatchOpRemove {
+				delete(resource, key)
+				return nil
+			}
+			value, ok := operation.Value.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%w: an object is required for %q", ErrScimInvalidValue, operation.Path)
+			}
+			if op == ScimPatchOpReplace || extension == nil {
+				resource[key] = value
+				return nil
+			}
+			for k, v := range value {
+				extension[scimKey(extension, k)] = v
+			}
+			return nil
+		}
+
+		if extension == nil {
+			if op == ScimPatchOpRemove {
+				return nil
+			}
+			extension = map[string]any{}
+			resource[key] = extension
+		}
+		container = extension
+	}
+
+	key := scimKey(container, p.attr)
+	current := container[key]
+
+	if p.filter == nil {
+		if p.subAttr == "" {
+			return applyScimValue(container, key, op, operation.Value)
+		}
+
+		complexValue, ok := current.(map[string]any)
+		if !ok {
+			if current != nil {
+				return fmt.Errorf("%w: %q is not a complex attribute", ErrScimInvalidPath, p.attr)
+			}
+			if op == ScimPatchOpRemove {
+				return nil
+			}
+			complexValue = map[string]any{}
+			container[key] = complexValue
+		}
+		return applyScimValue(complexValue, scimKey(complexValue, p.subAttr), op, operation.Value)
+	}
+
+	elements, ok := current.([]any)
+	if !ok && current != nil {
+		return fmt.Errorf("%w: %q is not a multi-valued attribute", ErrScimInvalidPath, p.attr)
+	}
+
+	kept := []any{}
+	matched := false
+	for _, element := range elements {
+		item, isMap := element.(map[string]any)
+		if !isMap || !p.filter.matches(item) {
+			kept = append(kept, element)
+			continue
+		}
+		matched = true
+
+		if p.subAttr == "" {
+			if op == ScimPatchOpRemove {
+				continue
+			}
+			value, ok := operation.Value.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%w: an object is required for %q", ErrScimInvalidValue, operation.Path)
+			}
+			if op == ScimPatchOpReplace {
+				item = value
+			} else {
+				for k, v := range value {
+					item[scimKey(item, k)] = v
+				}
+			}
+		} else if err := applyScimValue(item, scimKey(item, p.subAttr), op, operation.Value); err != nil {
+			return err
+		}
+		kept = append(kept, item)
+	}
+
+	// Entra sets e.g. emails[type eq "work"].value for users that have no work email yet.
+	if !matched && op != ScimPatchOpRemove {
+		item := p.filter.template()
+		if p.subAttr != "" {
+			item[p.subAttr] = operation.Value
+		} else if value, ok := operation.Value.(map[string]any); ok {
+			for k, v := range value {
+				item[scimKey(item, k)] = v
+			}
+		} else {
+			return fmt.Errorf("%w: an object is required for %q", ErrScimInvalidValue, operation.Path)
+		}
+		kept = append(kept, item)
+	}
+
+	container[key] = kept
+	return nil
+}
+
+func applyScimValue(container map[string]any, key, op string, value any) error {
+	switch op {
+	case ScimPatchOpRemove:
+		current, isArray := container[key].([]any)
+		if value == nil || !isArray {
+			delete(container, key)
+			return nil
+		}
+		// Entra removes group members by listing them in the value rather than in the path.
+		removed := scimValues(value)
+		container[key] = slices.DeleteFunc(current, func(element any) bool {
+			return slices.Contains(removed, scimValueOf(element))
+		})
+
+	case ScimPatchOpReplace:
+		container[key] = value
+
+	case ScimPatchOpAdd:
+		current, isArray := container[key].([]any)
+		switch v := value.(type) {
+		case []any:
+			if !isArray {
+				container[key] = v
+				return nil
+			}
+			for _, element := range v {
+				if !slices.ContainsFunc(current, func(e any) bool { return scimValueOf(e) == scimValueOf(element) }) {
+					current = append(current, element)
+				}
+			}
+			container[key] = current
+		case map[string]any:
+			if existing, ok := container[key].(map[string]any); ok {
+				for k, subValue := range v {
+					existing[scimKey(existing, k)] = subValue
+				}
+			} else if isArray {
+				container[key] = append(current, v)
+			} else {
+				container[key] = v
+			}
+		default:
+			if isArray {
+				container[key] = append(current, v)
+			} else {
+				container[key] = v
+			}
+		}
+	}
+
+	return nil
+}
+
+// scimValueOf identifies a value of a multi-valued attribute by its "value" sub-attribute.
+func scimValueOf(element any) string {
+	if item, ok := element.(map[string]any); ok {
+		element = item[scimKey(item, "value")]
+	}
+	return fmt.Sprint(element)
+}
+
+func scimValues(value any) []string {
+	elements, ok := value.([]any)
+	if !ok {
+		elements = []any{value}
+	}
+
+	values := make([]string, 0, len(elements))
+	for _, element := range elements {
+		values = append(values, scimValueOf(element))
+	}
+	return values
+}
+
+// normalizeScimValues converts the "True" and "False" strings Entra sends for boolean attributes,
+// and the manager it sends as a plain identifier.
+func normalizeScimValues(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, subValue := range v {
+			s, isString := subValue.(string)
+			switch {
+			case isString && (strings.EqualFold(key, "active") || strings.EqualFold(key, "primary")):
+				if b, err := strconv.ParseBool(s); err == nil {
+					v[key] = b
+				}
+			case isString && strings.EqualFold(key, "manager"):
+				v[key] = map[string]any{"value": s}
+			default:
+				normalizeScimValues(subValue)
+			}
+		}
+	case []any:
+		for _, element := range v {
+			normalizeScimValues(element)
+		}
+	}
+}
+
+// ScimMembersPatch is the change to the members of a group requested by PATCH operations. Members
+// are applied to the memberships directly rather than by patching a complete member list.
+type ScimMembersPatch struct {
+	// Replace is set when the members are replaced by Set. Add and Remove are used otherwise.
+	Replace bool
+	Set     []string
+	Add     []string
+	Remove  []string
+}
+
+// IsEmpty reports whether the operations leave the members untouched.
+func (p *ScimMembersPatch) IsEmpty() bool {
+	return !p.Replace && len(p.Add) == 0 && len(p.Remove) == 0
+}
+
+// ExtractScimMembersPatch folds the operations on the members of a group into a ScimMembersPatch.
+// The other operations are returned.
+func ExtractScimMembersPatch(operations []ScimPatchOperation) (*ScimMembersPatch, []ScimPatchOperation, error) {
+	patch := &ScimMembersPatch{}
+	var rest []ScimPatchOperation
+
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+
+		if operation.Path == "" {
+			values, ok := operation.Value.(map[string]any)
+			if !ok {
+				rest = append(rest, operation)
+				continue
+			}
+			membersKey := scimKey(values, "members")
+			members, ok := values[membersKey]
+			if !ok {
+				rest = append(rest, operation)
+				continue
+			}
+
+			others := make(map[string]any, len(values))
+			for k, v := range values {
+				if k != membersKey {
+					others[k] = v
+				}
+			}
+			if len(others) > 0 {
+				rest = append(rest, ScimPatchOperation{Op: operation.Op, Value: others})
+			}
+			if err := patch.apply(op, nil, members); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		p, err := parseScimPath(operation.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if p.schema != "" || !strings.EqualFold(p.attr, "members") {
+			rest = append(rest, operation)
+			continue
+		}
+		if err := patch.apply(op, p, operation.Value); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return patch, rest, nil
+}
+
+func (p *ScimMembersPatch) apply(op string, path *scimPath, value any) error {
+	var ids []string
+	if path != nil && path.filter != nil {
+		id, ok := path.filter.EqualityValue("value")
+		if !ok || path.subAttr != "" || op != ScimPatchOpRemove {
+			return fmt.Errorf("%w: members can only be removed by value", ErrScimInvalidPath)
+		}
+		ids = []string{id}
+	} else if value != nil {
+		ids = scimValues(value)
+	}
+
+	switch op {
+	case ScimPatchOpReplace:
+		p.Replace = true
+		p.Set = ids
+		p.Add, p.Remove = nil, nil
+	case ScimPatchOpAdd:
+		if p.Replace {
+			p.Set = appendScimIDs(p.Set, ids)
+		} else {
+			p.Add = appendScimIDs(p.Add, ids)
+			p.Remove = slices.DeleteFunc(p.Remove, func(id string) bool { return slices.Contains(ids, id) })
+		}
+	case ScimPatchOpRemove:
+		if value == nil && (path == nil || path.filter == nil) {
+			p.Replace = true
+			p.Set = nil
+			p.Add, p.Remove = nil, nil
+		} else if p.Replace {
+			p.Set = slices.DeleteFunc(p.Set, func(id string) bool { return slices.Contains(ids, id) })
+		} else {
+			p.Remove = appendScimIDs(p.Remove, ids)
+			p.Add = slices.DeleteFunc(p.Add, func(id string) bool { return slices.Contains(ids, id) })
+		}
+	default:
+		return fmt.Errorf("%w: unknown operation %q", ErrScimInvalidValue, op)
+	}
+
+	return nil
+}
+
+func appendScimIDs(ids []string, added []string) []string {
+	for _, id := range added {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestScimUser() *ScimUser {
+	return &ScimUser{
+		Schemas:  []string{ScimSchemaUser},
+		Id:       "user1",
+		UserName: "jdoe",
+		Name:     &ScimName{GivenName: "Jane", FamilyName: "Doe"},
+		Active:   NewPointer(true),
+		Emails: []ScimMultiValue{
+			{Value: "jane@example.com", Type: "work", Primary: true},
+			{Value: "jane@home.example.com", Type: "home"},
+		},
+		Groups: []ScimMultiValue{{Value: "group1", Display: "Engineering"}},
+	}
+}
+
+func TestParseScimFilter(t *testing.T) {
+	t.Run("valid filters", func(t *testing.T) {
+		for _, filter := range []string{
+			`userName eq "jdoe"`,
+			`userName Eq "jdoe"`,
+			`externalId eq "00u1" and active eq true`,
+			`emails[type eq "work"].value co "example"`,
+			`id eq "group1" and members[value eq "user1"]`,
+			`urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department eq "R&D"`,
+			`title pr`,
+			`userName eq "with \"quotes\" and spaces"`,
+		} {
+			_, err := ParseScimFilter(filter)
+			assert.NoError(t, err, filter)
+		}
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		for _, filter := range []string{
+			``,
+			`userName`,
+			`userName eq`,
+			`userName like "jdoe"`,
+			`userName eq "jdoe" or userName eq "jsmith"`,
+			`(userName eq "jdoe")`,
+			`userName eq "jdoe`,
+			`emails[type eq "work".value eq "x"`,
+			`urn:unknown:schema:attr eq "x"`,
+		} {
+			_, err := ParseScimFilter(filter)
+			assert.ErrorIs(t, err, ErrScimInvalidFilter, filter)
+		}
+	})
+
+	t.Run("equality value", func(t *testing.T) {
+		filter, err := ParseScimFilter(`active eq true and UserName eq "JDoe"`)
+		require.NoError(t, err)
+
+		value, ok := filter.EqualityValue("userName")
+		assert.True(t, ok)
+		assert.Equal(t, "JDoe", value)
+
+		_, ok = filter.EqualityValue("externalId")
+		assert.False(t, ok)
+		assert.True(t, filter.References("active"))
+		assert.False(t, filter.References("members"))
+	})
+}
+
+func TestScimFilterMatches(t *testing.T) {
+	user := newTestScimUser()
+	user.EnterpriseUser = &ScimEnterpriseUser{Department: "Sales"}
+
+	for filter, expected := range map[string]bool{
+		`userName eq "JDOE"`:                             true,
+		`userName eq "jsmith"`:                           false,
+		`userName ne "jsmith"`:                           true,
+		`userName sw "jd"`:                               true,
+		`userName ew "oe"`:                               true,
+		`name.givenName eq "jane"`:                       true,
+		`emails.value eq "jane@home.example.com"`:        true,
+		`emails[type eq "work"].value ew "@example.com"`: true,
+		`emails[type eq "home"].value ew "@example.com"`: false,
+		`active eq true`:                                 true,
+		`active eq false`:                                false,
+		`title pr`:                                       false,
+		`title ne "manager"`:                             true,
+		`userName pr and active eq true`:                 true,
+		`groups[value eq "group1"]`:                      true,
+		`groups[value eq "group2"]`:                      false,
+		`urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department eq "sales"`: true,
+		`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "jdoe"`:                    true,
+	} {
+		parsed, err := ParseScimFilter(filter)
+		require.NoError(t, err, filter)
+		assert.Equal(t, expected, parsed.Matches(user), filter)
+	}
+}
+
+func TestScimAttributeString(t *testing.T) {
+	user := newTestScimUser()
+	user.EnterpriseUser = &ScimEnterpriseUser{Department: "Sales"}
+
+	value, err := ScimAttributeString(user, "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department")
+	require.NoError(t, err)
+	assert.Equal(t, "Sales", value)
+
+	value, err = ScimAttributeString(user, `emails[type eq "home"].value`)
+	require.NoError(t, err)
+	assert.Equal(t, "jane@home.example.com", value)
+
+	value, err = ScimAttributeString(user, "active")
+	require.NoError(t, err)
+	assert.Equal(t, "true", value)
+
+	value, err = ScimAttributeString(user, "title")
+	require.NoError(t, err)
+	assert.Empty(t, value)
+
+	_, err = ScimAttributeString(user, "emails[type eq")
+	assert.ErrorIs(t, err, ErrScimInvalidPath)
+}
+
+func decodeScimOperations(t *testing.T, data string) []ScimPatchOperation {
+	t.Helper()
+
+	var request ScimPatchRequest
+	require.NoError(t, json.Unmarshal([]byte(data), &request))
+	return request.Operations
+}
+
+func TestApplyScimPatch(t *testing.T) {
+	t.Run("replace attributes with paths", func(t *testing.T) {
+		user := newTestScimUser()
+		err := ApplyScimPatch(user, decodeScimOperations(t, `{"Operations": [
+			{"op": "replace", "path": "name.givenName", "value": "Janet"},
+			{"op": "Replace", "path": "title", "value": "Engineer"},
+			{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "janet@example.com"},
+			{"op": "add", "path": "phoneNumbers[type eq \"mobile\"].value", "value": "+1 555 0100"}
+		]}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "Janet", user.Name.GivenName)
+		assert.Equal(t, "Doe", user.Name.FamilyName)
+		assert.Equal(t, "Engineer", user.Title)
+		assert.Equal(t, "janet@example.com", user.PrimaryEmail())
+		require.Len(t, user.PhoneNumbers, 1)
+		assert.Equal(t, ScimMultiValue{Value: "+1 555 0100", Type: "mobile"}, user.PhoneNumbers[0])
+	})
+
+	t.Run("replace without path", func(t *testing.T) {
+		user := newTestScimUser()
+		err := ApplyScimPatch(user, decodeScimOperations(t, `{"Operations": [
+			{"op": "replace", "value": {
+				"active": false,
+				"name.familyName": "Smith",
+				"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department": "Sales"
+			}}
+		]}`))
+		require.NoError(t, err)
+
+		assert.False(t, *user.Active)
+		assert.Equal(t, "Smith", user.Name.FamilyName)
+		require.NotNil(t, user.EnterpriseUser)
+		assert.Equal(t, "Sales", user.EnterpriseUser.Department)
+	})
+
+	t.Run("Entra string booleans and manager", func(t *testing.T) {
+		user := newTestScimUser()
+		err := ApplyScimPatch(user, decodeScimOperations(t, `{"Operations": [
+			{"op": "Replace", "path": "active", "value": "False"},
+			{"op": "Add", "path": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager", "value": "manager1"}
+		]}`))
+		require.NoError(t, err)
+
+		assert.False(t, *user.Active)
+		require.NotNil(t, user.EnterpriseUser)
+		assert.Equal(t, "manager1", user.EnterpriseUser.Manager.Value)
+	})
+
+	t.Run("remove attributes", func(t *testing.T) {
+		user := newTestScimUser()
+		err := ApplyScimPatch(user, decodeScimOperations(t, `{"Operations": [
+			{"op": "remove", "path": "name.givenName"},
+			{"op": "remove", "path": "emails[type eq \"home\"]"},
+			{"op": "remove", "path": "title"}
+		]}`))
+		require.NoError(t, err)
+
+		assert.Empty(t, user.Name.GivenName)
+		require.Len(t, user.Emails, 1)
+		assert.Equal(t, "jane@example.com", user.Emails[0].Value)
+	})
+
+	t.Run("add to multi-valued attribute", func(t *testing.T) {
+		user := newTestScimUser()
+		err := ApplyScimPatch(user, decodeScimOperations(t, `{"Operations": [
+			{"op": "add", "path": "emails", "value": [
+				{"value": "jane@example.com", "type": "work"},
+				{"value": "jane@other.example.com", "type": "other"}
+			]}
+		]}`))
+		require.NoError(t, err)
+
+		require.Len(t, user.Emails, 3)
+		assert.Equal(t, "jane@other.example.com", user.Emails[2].Value)
+	})
+
+	t.Run("invalid operations", func(t *testing.T) {
+		user := newTestScimUser()
+		assert.ErrorIs(t, ApplyScimPatch(user, []ScimPatchOperation{{Op: "move", Path: "title"}}), ErrScimInvalidValue)
+		assert.ErrorIs(t, ApplyScimPatch(user, []ScimPatchOperation{{Op: "remove"}}), ErrScimInvalidPath)
+		assert.ErrorIs(t, ApplyScimPatch(user, []ScimPatchOperation{{Op: "replace", Value: "value"}}), ErrScimInvalidValue)
+		assert.ErrorIs(t, ApplyScimPatch(user, []ScimPatchOperation{{Op: "replace", Path: "name[givenName eq \"x\"]", Value: "y"}}), ErrScimInvalidPath)
+		assert.ErrorIs(t, ApplyScimPatch(user, []ScimPatchOperation{{Op: "replace", Path: "userName", Value: 42}}), ErrScimInvalidValue)
+	})
+}
+
+func TestExtractScimMembersPatch(t *testing.T) {
+	t.Run("Okta", func(t *testing.T) {
+		patch, rest, err := ExtractScimMembersPatch(decodeScimOperations(t, `{"Operations": [
+			{"op": "replace", "value": {"id": "group1", "displayName": "Engineering"}},
+			{"op": "add", "path": "members", "value": [{"value": "user1"}, {"value": "user2"}]},
+			{"op": "remove", "path": "members[value eq \"user3\"]"}
+		]}`))
+		require.NoError(t, err)
+
+		assert.False(t, patch.Replace)
+		assert.Equal(t, []string{"user1", "user2"}, patch.Add)
+		assert.Equal(t, []string{"user3"}, patch.Remove)
+		require.Len(t, rest, 1)
+		assert.Empty(t, rest[0].Path)
+	})
+
+	t.Run("Entra", func(t *testing.T) {
+		patch, rest, err := ExtractScimMembersPatch(decodeScimOperations(t, `{"Operations": [
+			{"op": "Add", "path": "members", "value": [{"value": "user1"}]},
+			{"op": "Remove", "path": "members", "value": [{"value": "user1"}, {"value": "user2"}]}
+		]}`))
+		require.NoError(t, err)
+
+		assert.Empty(t, rest)
+		assert.Empty(t, patch.Add)
+		assert.Equal(t, []string{"user1", "user2"}, patch.Remove)
+	})
+
+	t.Run("replace and remove all", func(t *testing.T) {
+		patch, _, err := ExtractScimMembersPatch(decodeScimOperations(t, `{"Operations": [
+			{"op": "add", "value": {"displayName": "Sales", "members": [{"value": "user1"}]}},
+			{"op": "replace", "path": "members", "value": [{"value": "user2"}]},
+			{"op": "add", "path": "members", "value": [{"value": "user3"}]}
+		]}`))
+		require.NoError(t, err)
+		assert.True(t, patch.Replace)
+		assert.Equal(t, []string{"user2", "user3"}, patch.Set)
+
+		patch, _, err = ExtractScimMembersPatch([]ScimPatchOperation{{Op: "remove", Path: "members"}})
+		require.NoError(t, err)
+		assert.True(t, patch.Replace)
+		assert.Empty(t, patch.Set)
+		assert.False(t, patch.IsEmpty())
+	})
+
+	t.Run("no member operations", func(t *testing.T) {
+		patch, rest, err := ExtractScimMembersPatch([]ScimPatchOperation{{Op: "replace", Path: "displayName", Value: "Sales"}})
+		require.NoError(t, err)
+		assert.True(t, patch.IsEmpty())
+		assert.Len(t, rest, 1)
+	})
+
+	t.Run("invalid member filter", func(t *testing.T) {
+		_, _, err := ExtractScimMembersPatch([]ScimPatchOperation{{Op: "add", Path: `members[value eq "user1"]`, Value: "x"}})
+		assert.ErrorIs(t, err, ErrScimInvalidPath)
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScimUserPrimaryEmail(t *testing.T) {
+	user := &ScimUser{}
+	assert.Empty(t, user.PrimaryEmail())
+
+	user.Emails = []ScimMultiValue{{Value: "home@example.com"}, {Value: "work@example.com", Primary: true}}
+	assert.Equal(t, "work@example.com", user.PrimaryEmail())
+
+	user.Emails[1].Primary = false
+	assert.Equal(t, "home@example.com", user.PrimaryEmail())
+}
+
+func TestScimUserJSON(t *testing.T) {
+	var user ScimUser
+	err := json.Unmarshal([]byte(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User", "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"],
+		"userName": "jdoe",
+		"externalId": "00u1",
+		"active": true,
+		"emails": [{"value": "jane@example.com", "primary": true}],
+		"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "Sales"}
+	}`), &user)
+	require.NoError(t, err)
+
+	assert.Equal(t, "jdoe", user.UserName)
+	assert.Equal(t, "00u1", user.ExternalId)
+	assert.True(t, *user.Active)
+	assert.Equal(t, "jane@example.com", user.PrimaryEmail())
+	require.NotNil(t, user.EnterpriseUser)
+	assert.Equal(t, "Sales", user.EnterpriseUser.Department)
+}
+
+func TestNewScimListResponse(t *testing.T) {
+	response := NewScimListResponse(nil, 0, 1)
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:ListResponse"],
+		"totalResults": 0,
+		"startIndex": 1,
+		"itemsPerPage": 0,
+		"Resources": []
+	}`, string(data))
+
+	response = NewScimListResponse([]any{&ScimGroup{DisplayName: "Sales"}}, 10, 5)
+	assert.Equal(t, 1, response.ItemsPerPage)
+	assert.EqualValues(t, 10, response.TotalResults)
+}
+
+func TestScimListParamsExcludes(t *testing.T) {
+	params := &ScimListParams{ExcludedAttributes: []string{"Members"}}
+	assert.True(t, params.Excludes("members"))
+	assert.False(t, params.Excludes("displayName"))
+}
+
+func TestNewScimSchemaDefinitions(t *testing.T) {
+	schemas := NewScimSchemaDefinitions("https://example.com/scim/v2")
+	require.Len(t, schemas, 3)
+
+	ids := make([]string, 0, len(schemas))
+	for _, schema := range schemas {
+		ids = append(ids, schema.Id)
+		assert.Equal(t, "https://example.com/scim/v2/Schemas/"+schema.Id, schema.Meta.Location)
+	}
+	assert.Equal(t, []string{ScimSchemaUser, ScimSchemaEnterpriseUser, ScimSchemaGroup}, ids)
+}
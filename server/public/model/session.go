@@ -33,6 +33,13 @@ const (
 	SessionTypeCloudKey                   = "CloudKey"
 	SessionTypeRemoteclusterToken         = "RemoteClusterToken"
 	SessionPropIsGuest                    = "is_guest"
+	SessionPropLastIpAddress              = "last_ip_address"
+	SessionPropUserAgent                  = "user_agent"
+	SessionPropIdleTimeoutInMinutes       = "idle_timeout_in_minutes"
+	SessionPropPolicyName                 = "session_policy"
+	SessionDeviceTypeWeb                  = "web"
+	SessionDeviceTypeDesktop              = "desktop"
+	SessionDeviceTypeMobile               = "mobile"
 	SessionActivityTimeout                = 1000 * 60 * 5  // 5 minutes
 	SessionUserAccessTokenExpiryHours     = 100 * 365 * 24 // 100 years
 )
@@ -183,6 +190,18 @@ func (s *Session) IsMobileApp() bool {
 	return s.DeviceId != "" || s.IsMobile()
 }
 
+// DeviceType returns whether the session was created from a web browser, the desktop app or a
+// mobile device.
+func (s *Session) DeviceType() string {
+	if s.IsMobileApp() {
+		return SessionDeviceTypeMobile
+	}
+	if strings.HasPrefix(s.Props[SessionPropBrowser], "Desktop App") {
+		return SessionDeviceTypeDesktop
+	}
+	return SessionDeviceTypeWeb
+}
+
 func (s *Session) IsMobile() bool {
 	val, ok := s.Props[UserAuthServiceIsMobile]
 	if !ok {
@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// SessionDetails describes a session to administrators, without its token.
+type SessionDetails struct {
+	Id             string `json:"id"`
+	UserId         string `json:"user_id"`
+	DeviceType     string `json:"device_type"`
+	Platform       string `json:"platform"`
+	Os             string `json:"os"`
+	Browser        string `json:"browser"`
+	LastIpAddress  string `json:"last_ip_address"`
+	UserAgent      string `json:"user_agent"`
+	PolicyName     string `json:"policy_name,omitempty"`
+	CreateAt       int64  `json:"create_at"`
+	LastActivityAt int64  `json:"last_activity_at"`
+	ExpiresAt      int64  `json:"expires_at"`
+}
+
+func (s *Session) Details() *SessionDetails {
+	return &SessionDetails{
+		Id:             s.Id,
+		UserId:         s.UserId,
+		DeviceType:     s.DeviceType(),
+		Platform:       s.Props[SessionPropPlatform],
+		Os:             s.Props[SessionPropOs],
+		Browser:        s.Props[SessionPropBrowser],
+		LastIpAddress:  s.Props[SessionPropLastIpAddress],
+		UserAgent:      s.Props[SessionPropUserAgent],
+		PolicyName:     s.Props[SessionPropPolicyName],
+		CreateAt:       s.CreateAt,
+		LastActivityAt: s.LastActivityAt,
+		ExpiresAt:      s.ExpiresAt,
+	}
+}
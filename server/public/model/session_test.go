@@ -153,3 +153,11 @@ func TestIsIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionDeviceType(t *testing.T) {
+	assert.Equal(t, SessionDeviceTypeMobile, (&Session{DeviceId: NewId()}).DeviceType())
+	assert.Equal(t, SessionDeviceTypeMobile, (&Session{Props: StringMap{UserAuthServiceIsMobile: "true"}}).DeviceType())
+	assert.Equal(t, SessionDeviceTypeDesktop, (&Session{Props: StringMap{SessionPropBrowser: "Desktop App/5.6.0"}}).DeviceType())
+	assert.Equal(t, SessionDeviceTypeWeb, (&Session{Props: StringMap{SessionPropBrowser: "Chrome/120.0.0"}}).DeviceType())
+	assert.Equal(t, SessionDeviceTypeWeb, (&Session{}).DeviceType())
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	SharedChannelContentPolicyDefaultReplacement = "[redacted]"
+	SharedChannelContentPolicyMaxPatterns        = 50
+	SharedChannelContentPolicyMaxPatternLength   = 512
+	SharedChannelContentPolicyMaxFileTypes       = 100
+
+	SharedChannelHeldPostStatusPending  = "pending"
+	SharedChannelHeldPostStatusApproved = "approved"
+	SharedChannelHeldPostStatusRejected = "rejected"
+
+	SharedChannelFilterActionRedact     = "redact"
+	SharedChannelFilterActionStripLink  = "strip_link"
+	SharedChannelFilterActionBlockFile  = "block_file"
+	SharedChannelFilterActionHold       = "hold"
+	SharedChannelFilterActionApprove    = "approve"
+	SharedChannelFilterActionReject     = "reject"
+	SharedChannelFilterLogDetailsMaxLen = 1024
+)
+
+// SharedChannelContentPolicy describes how content is filtered before being synchronized
+// to a specific remote cluster. Remotes without a policy receive content unchanged.
+type SharedChannelContentPolicy struct {
+	RemoteId string `json:"remote_id"`
+	// BlockedFileTypes lists file extensions (without the leading dot) and MIME types
+	// that are never sent to the remote. MIME types may use a trailing wildcard, e.g. "video/*".
+	BlockedFileTypes StringArray `json:"blocked_file_types"`
+	// MaxFileSize is the largest attachment, in bytes, sent to the remote. Zero means no limit.
+	MaxFileSize int64 `json:"max_file_size"`
+	// RedactPatterns are regular expressions whose matches are replaced in post messages.
+	RedactPatterns    StringArray `json:"redact_patterns"`
+	RedactReplacement string      `json:"redact_replacement"`
+	// StripPermalinks removes every link to this server that would not resolve on the
+	// remote, including permalinks to posts in other channels.
+	StripPermalinks bool `json:"strip_permalinks"`
+	// RequireApproval holds new and edited posts until a moderator approves them.
+	RequireApproval bool   `json:"require_approval"`
+	CreatorId       string `json:"creator_id"`
+	CreateAt        int64  `json:"create_at"`
+	UpdateAt        int64  `json:"update_at"`
+}
+
+func (p *SharedChannelContentPolicy) Auditable() map[string]any {
+	return map[string]any{
+		"remote_id":          p.RemoteId,
+		"blocked_file_types": p.BlockedFileTypes,
+		"max_file_size":      p.MaxFileSize,
+		"redact_patterns":    p.RedactPatterns,
+		"redact_replacement": p.RedactReplacement,
+		"strip_permalinks":   p.StripPermalinks,
+		"require_approval":   p.RequireApproval,
+		"creator_id":         p.CreatorId,
+		"create_at":          p.CreateAt,
+		"update_at":          p.UpdateAt,
+	}
+}
+
+func (p *SharedChannelContentPolicy) PreSave() {
+	if p.CreateAt == 0 {
+		p.CreateAt = GetMillis()
+	}
+	p.UpdateAt = GetMillis()
+
+	if p.RedactReplacement == "" {
+		p.RedactReplacement = SharedChannelContentPolicyDefaultReplacement
+	}
+
+	for i, fileType := range p.BlockedFileTypes {
+		p.BlockedFileTypes[i] = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(fileType)), ".")
+	}
+}
+
+func (p *SharedChannelContentPolicy) IsValid() *AppError {
+	if !IsValidId(p.RemoteId) {
+		return NewAppError("SharedChannelContentPolicy.IsValid", "model.shared_channel_content_policy.is_valid.remote_id.app_error", nil, "RemoteId="+p.RemoteId, http.StatusBadRequest)
+	}
+
+	if p.MaxFileSize < 0 {
+		return NewAppError("SharedChannelContentPolicy.IsValid", "model.shared_channel_content_policy.is_valid.max_file_size.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(p.BlockedFileTypes) > SharedChannelContentPolicyMaxFileTypes {
+		return NewAppError("SharedChannelContentPolicy.IsValid", "model.shared_channel_content_policy.is_valid.blocked_file_types.app_error", map[string]any{"Max": SharedChannelContentPolicyMaxFileTypes}, "", http.StatusBadRequest)
+	}
+	for _, fileType := range p.BlockedFileTypes {
+		if fileType == "" {
+			return NewAppError("SharedChannelContentPolicy.IsValid", "model.shared_channel_content_policy.is_valid.blocked_file_types.app_error", map[string]any{"Max": SharedChannelContentPolicyMaxFileTypes}, "empty file type", http.StatusBadRequest)
+		}
+	}
+
+	if len(p.RedactPatterns) > SharedChannelContentPolicyMaxPatterns {
+		return NewAppError("SharedChannelContentPolicy.IsValid", "model.shared_channel_content_policy.is_valid.redact_patterns.app_error", map[string]any{"Max": SharedChannelContentPolicyMaxPatterns}, "", http.StatusBadRequest)
+	}
+	for _, pattern := range p.RedactPatterns {
+		if pattern == "" || len(pattern) > SharedChannelContentPolicyMaxPatternLength {
+			return NewAppError("SharedChannelContentPolicy.IsValid", "model.shared_channel_content_policy.is_valid.redact_pattern.app_error", map[string]any{"Pattern": pattern}, "", http.StatusBadRequest)
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return NewAppError("SharedChannelContentPolicy.IsValid", "model.shared_channel_content_policy.is_valid.redact_pattern.app_error", map[string]any{"Pattern": pattern}, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	if p.CreateAt == 0 {
+		return NewAppError("SharedChannelContentPolicy.IsValid", "model.channel.is_valid.create_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if p.UpdateAt == 0 {
+		return NewAppError("SharedChannelContentPolicy.IsValid", "model.channel.is_valid.update_at.app_error", nil, "", http.StatusBadRequest)
+	}
+	return nil
+}
+
+// IsFileTypeBlocked returns true if a file with the given extension or MIME type may not
+// be sent to the remote.
+func (p *SharedChannelContentPolicy) IsFileTypeBlocked(extension, mimeType string) bool {
+	extension = strings.TrimPrefix(strings.ToLower(extension), ".")
+	mimeType = strings.ToLower(mimeType)
+
+	for _, blocked := range p.BlockedFileTypes {
+		switch {
+		case strings.HasSuffix(blocked, "/*"):
+			if strings.HasPrefix(mimeType, strings.TrimSuffix(blocked, "*")) {
+				return true
+			}
+		case strings.Contains(blocked, "/"):
+			if blocked == mimeType {
+				return true
+			}
+		case blocked == extension:
+			return true
+		}
+	}
+	return false
+}
+
+// SharedChannelHeldPost records a post that is waiting for, or has received, a moderator's
+// decision before being synchronized to a remote.
+type SharedChannelHeldPost struct {
+	PostId     string `json:"post_id"`
+	RemoteId   string `json:"remote_id"`
+	ChannelId  string `json:"channel_id"`
+	UserId     string `json:"user_id"`
+	Status     string `json:"status"`
+	ReviewerId string `json:"reviewer_id"`
+	CreateAt   int64  `json:"create_at"`
+	ReviewedAt int64  `json:"reviewed_at"`
+}
+
+func (h *SharedChannelHeldPost) Auditable() map[string]any {
+	return map[string]any{
+		"post_id":     h.PostId,
+		"remote_id":   h.RemoteId,
+		"channel_id":  h.ChannelId,
+		"user_id":     h.UserId,
+		"status":      h.Status,
+		"reviewer_id": h.ReviewerId,
+		"create_at":   h.CreateAt,
+		"reviewed_at": h.ReviewedAt,
+	}
+}
+
+func (h *SharedChannelHeldPost) PreSave() {
+	if h.CreateAt == 0 {
+		h.CreateAt = GetMillis()
+	}
+	if h.Status == "" {
+		h.Status = SharedChannelHeldPostStatusPending
+	}
+}
+
+func (h *SharedChannelHeldPost) IsValid() *AppError {
+	if !IsValidId(h.PostId) {
+		return NewAppError("SharedChannelHeldPost.IsValid", "model.channel.is_valid.id.app_error", nil, "PostId="+h.PostId, http.StatusBadRequest)
+	}
+
+	if !IsValidId(h.RemoteId) {
+		return NewAppError("SharedChannelHeldPost.IsValid", "model.channel.is_valid.id.app_error", nil, "RemoteId="+h.RemoteId, http.StatusBadRequest)
+	}
+
+	if !IsValidId(h.ChannelId) {
+		return NewAppError("SharedChannelHeldPost.IsValid", "model.channel.is_valid.id.app_error", nil, "ChannelId="+h.ChannelId, http.StatusBadRequest)
+	}
+
+	switch h.Status {
+	case SharedChannelHeldPostStatusPending, SharedChannelHeldPostStatusApproved, SharedChannelHeldPostStatusRejected:
+	default:
+		return NewAppError("SharedChannelHeldPost.IsValid", "model.shared_channel_held_post.is_valid.status.app_error", nil, "Status="+h.Status, http.StatusBadRequest)
+	}
+
+	if h.CreateAt == 0 {
+		return NewAppError("SharedChannelHeldPost.IsValid", "model.channel.is_valid.create_at.app_error", nil, "", http.StatusBadRequest)
+	}
+	return nil
+}
+
+// SharedChannelFilterLogEntry records a single action taken by a content policy. Details
+// never contain the filtered content itself.
+type SharedChannelFilterLogEntry struct {
+	Id        string `json:"id"`
+	RemoteId  string `json:"remote_id"`
+	ChannelId string `json:"channel_id"`
+	PostId    string `json:"post_id"`
+	FileId    string `json:"file_id"`
+	UserId    string `json:"user_id"`
+	Action    string `json:"action"`
+	Details   string `json:"details"`
+	CreateAt  int64  `json:"create_at"`
+}
+
+func (e *SharedChannelFilterLogEntry) PreSave() {
+	if e.Id == "" {
+		e.Id = NewId()
+	}
+	if e.CreateAt == 0 {
+		e.CreateAt = GetMillis()
+	}
+	if len(e.Details) > SharedChannelFilterLogDetailsMaxLen {
+		e.Details = e.Details[:SharedChannelFilterLogDetailsMaxLen]
+	}
+}
+
+func (e *SharedChannelFilterLogEntry) IsValid() *AppError {
+	if !IsValidId(e.Id) {
+		return NewAppError("SharedChannelFilterLogEntry.IsValid", "model.channel.is_valid.id.app_error", nil, "Id="+e.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(e.RemoteId) {
+		return NewAppError("SharedChannelFilterLogEntry.IsValid", "model.channel.is_valid.id.app_error", nil, "RemoteId="+e.RemoteId, http.StatusBadRequest)
+	}
+
+	if e.Action == "" {
+		return NewAppError("SharedChannelFilterLogEntry.IsValid", "model.shared_channel_filter_log.is_valid.action.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if e.CreateAt == 0 {
+		return NewAppError("SharedChannelFilterLogEntry.IsValid", "model.channel.is_valid.create_at.app_error", nil, "", http.StatusBadRequest)
+	}
+	return nil
+}
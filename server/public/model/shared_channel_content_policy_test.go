@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedChannelContentPolicyIsValid(t *testing.T) {
+	id := NewId()
+	now := GetMillis()
+	data := []struct {
+		name   string
+		policy *SharedChannelContentPolicy
+		valid  bool
+	}{
+		{name: "Zero value", policy: &SharedChannelContentPolicy{}, valid: false},
+		{name: "Negative max_file_size", policy: &SharedChannelContentPolicy{RemoteId: id, CreateAt: now, UpdateAt: now,
+			MaxFileSize: -1}, valid: false},
+		{name: "Empty file type", policy: &SharedChannelContentPolicy{RemoteId: id, CreateAt: now, UpdateAt: now,
+			BlockedFileTypes: StringArray{""}}, valid: false},
+		{name: "Invalid pattern", policy: &SharedChannelContentPolicy{RemoteId: id, CreateAt: now, UpdateAt: now,
+			RedactPatterns: StringArray{"TICKET-("}}, valid: false},
+		{name: "Too long pattern", policy: &SharedChannelContentPolicy{RemoteId: id, CreateAt: now, UpdateAt: now,
+			RedactPatterns: StringArray{strings.Repeat("a", SharedChannelContentPolicyMaxPatternLength+1)}}, valid: false},
+		{name: "Missing create_at", policy: &SharedChannelContentPolicy{RemoteId: id}, valid: false},
+		{name: "Valid policy", policy: &SharedChannelContentPolicy{RemoteId: id, CreateAt: now, UpdateAt: now,
+			BlockedFileTypes: StringArray{"exe", "video/*"}, MaxFileSize: 1024, RedactPatterns: StringArray{`TICKET-\d+`},
+			StripPermalinks: true, RequireApproval: true}, valid: true},
+	}
+
+	for _, item := range data {
+		appErr := item.policy.IsValid()
+		if item.valid {
+			assert.Nil(t, appErr, item.name)
+		} else {
+			assert.NotNil(t, appErr, item.name)
+		}
+	}
+}
+
+func TestSharedChannelContentPolicyPreSave(t *testing.T) {
+	now := GetMillis()
+
+	p := SharedChannelContentPolicy{RemoteId: NewId(), BlockedFileTypes: StringArray{".EXE", " Video/* "}}
+	p.PreSave()
+
+	require.GreaterOrEqual(t, p.CreateAt, now)
+	require.GreaterOrEqual(t, p.UpdateAt, now)
+	assert.Equal(t, SharedChannelContentPolicyDefaultReplacement, p.RedactReplacement)
+	assert.Equal(t, StringArray{"exe", "video/*"}, p.BlockedFileTypes)
+}
+
+func TestSharedChannelContentPolicyIsFileTypeBlocked(t *testing.T) {
+	p := SharedChannelContentPolicy{BlockedFileTypes: StringArray{"exe", "video/*", "application/zip"}}
+
+	assert.True(t, p.IsFileTypeBlocked("EXE", "application/octet-stream"))
+	assert.True(t, p.IsFileTypeBlocked("mp4", "video/mp4"))
+	assert.True(t, p.IsFileTypeBlocked("zip", "application/zip"))
+	assert.False(t, p.IsFileTypeBlocked("png", "image/png"))
+	assert.False(t, p.IsFileTypeBlocked("gz", "application/gzip"))
+}
+
+func TestSharedChannelHeldPostIsValid(t *testing.T) {
+	id := NewId()
+
+	h := SharedChannelHeldPost{PostId: id, RemoteId: id, ChannelId: id}
+	h.PreSave()
+	require.Nil(t, h.IsValid())
+	assert.Equal(t, SharedChannelHeldPostStatusPending, h.Status)
+
+	h.Status = "unknown"
+	assert.NotNil(t, h.IsValid())
+}
+
+func TestSharedChannelFilterLogEntryPreSave(t *testing.T) {
+	e := SharedChannelFilterLogEntry{RemoteId: NewId(), Action: SharedChannelFilterActionRedact, Details: strings.Repeat("x", 2000)}
+	e.PreSave()
+
+	require.Nil(t, e.IsValid())
+	assert.Len(t, e.Details, SharedChannelFilterLogDetailsMaxLen)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"regexp"
+)
+
+// StoreFaultAllMethods matches all the store methods.
+const StoreFaultAllMethods = "*"
+
+var storeFaultMethodRegexp = regexp.MustCompile(`^[A-Za-z]+Store\.([A-Za-z]+|\*)$`)
+
+// StoreFault describes the faults injected in the calls to the matching store methods, to test
+// how the server behaves when the database is slow or failing. The faults are only injected when
+// the StoreFaultInjection feature flag is enabled.
+type StoreFault struct {
+	// Method is a store method, such as "PostStore.Save", all the methods of a store, such as
+	// "PostStore.*", or all the store methods with "*".
+	Method string `json:"method"`
+	// LatencyMs is the latency added to LatencyRate of the calls.
+	LatencyMs   int64   `json:"latency_ms"`
+	LatencyRate float64 `json:"latency_rate"`
+	// ErrorRate is the rate of the calls failing with an error.
+	ErrorRate float64 `json:"error_rate"`
+	// SerializationFailureRate is the rate of the calls failing with a serialization failure,
+	// which the retry layer retries.
+	SerializationFailureRate float64 `json:"serialization_failure_rate"`
+}
+
+func (f *StoreFault) Auditable() map[string]any {
+	return map[string]any{
+		"method":                     f.Method,
+		"latency_ms":                 f.LatencyMs,
+		"latency_rate":               f.LatencyRate,
+		"error_rate":                 f.ErrorRate,
+		"serialization_failure_rate": f.SerializationFailureRate,
+	}
+}
+
+func (f *StoreFault) IsValid() *AppError {
+	if f.Method != StoreFaultAllMethods && !storeFaultMethodRegexp.MatchString(f.Method) {
+		return NewAppError("StoreFault.IsValid", "model.store_fault.is_valid.method.app_error", map[string]any{"Method": f.Method}, "", http.StatusBadRequest)
+	}
+
+	if f.LatencyMs < 0 {
+		return NewAppError("StoreFault.IsValid", "model.store_fault.is_valid.latency.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, rate := range []float64{f.LatencyRate, f.ErrorRate, f.SerializationFailureRate} {
+		if rate < 0 || rate > 1 {
+			return NewAppError("StoreFault.IsValid", "model.store_fault.is_valid.rate.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
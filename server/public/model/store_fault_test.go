@@ -0,0 +1,58 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreFaultIsValid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		fault   StoreFault
+		errorID string
+	}{
+		{
+			name:  "method",
+			fault: StoreFault{Method: "PostStore.Save", LatencyMs: 100, LatencyRate: 1, ErrorRate: 0.5},
+		},
+		{
+			name:  "all the methods of a store",
+			fault: StoreFault{Method: "PostStore.*", SerializationFailureRate: 0.1},
+		},
+		{
+			name:  "all the methods",
+			fault: StoreFault{Method: StoreFaultAllMethods, ErrorRate: 1},
+		},
+		{
+			name:    "invalid method",
+			fault:   StoreFault{Method: "Post.Save"},
+			errorID: "model.store_fault.is_valid.method.app_error",
+		},
+		{
+			name:    "negative latency",
+			fault:   StoreFault{Method: "PostStore.Save", LatencyMs: -1},
+			errorID: "model.store_fault.is_valid.latency.app_error",
+		},
+		{
+			name:    "invalid rate",
+			fault:   StoreFault{Method: "PostStore.Save", ErrorRate: 1.1},
+			errorID: "model.store_fault.is_valid.rate.app_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			appErr := tc.fault.IsValid()
+			if tc.errorID == "" {
+				require.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				require.Equal(t, tc.errorID, appErr.Id)
+			}
+		})
+	}
+}
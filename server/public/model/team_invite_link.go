@@ -0,0 +1,175 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	TeamInviteLinkRoleMember = "member"
+	TeamInviteLinkRoleGuest  = "guest"
+
+	TeamInviteLinkNameMaxRunes   = 64
+	TeamInviteLinkMaxChannels    = 50
+	TeamInviteLinkUsesPerPageMax = 200
+)
+
+// TeamInviteLink is a named invite link of a team. Unlike the static invite id of the team, a
+// link can expire, be limited to a number of uses, add the users joining through it to channels,
+// and be revoked. The id of the link is used as the invite id.
+type TeamInviteLink struct {
+	Id         string      `json:"id"`
+	TeamId     string      `json:"team_id"`
+	CreatorId  string      `json:"creator_id"`
+	Name       string      `json:"name"`
+	Role       string      `json:"role"`
+	ChannelIds StringArray `json:"channel_ids"`
+	CreateAt   int64       `json:"create_at"`
+	UpdateAt   int64       `json:"update_at"`
+	DeleteAt   int64       `json:"delete_at"`
+	// ExpiresAt is the time after which the link can't be used anymore, 0 for never.
+	ExpiresAt int64 `json:"expires_at"`
+	// MaxUses is the number of users that can join through the link, 0 for unlimited.
+	MaxUses  int64 `json:"max_uses"`
+	UseCount int64 `json:"use_count"`
+}
+
+type TeamInviteLinkPatch struct {
+	Name       *string   `json:"name"`
+	ChannelIds *[]string `json:"channel_ids"`
+	ExpiresAt  *int64    `json:"expires_at"`
+	MaxUses    *int64    `json:"max_uses"`
+}
+
+// TeamInviteLinkUse records a user joining a team through an invite link.
+type TeamInviteLinkUse struct {
+	LinkId string `json:"link_id"`
+	UserId string `json:"user_id"`
+	UseAt  int64  `json:"use_at"`
+}
+
+func (l *TeamInviteLink) Auditable() map[string]any {
+	return map[string]any{
+		"id":          l.Id,
+		"team_id":     l.TeamId,
+		"creator_id":  l.CreatorId,
+		"role":        l.Role,
+		"channel_ids": l.ChannelIds,
+		"create_at":   l.CreateAt,
+		"delete_at":   l.DeleteAt,
+		"expires_at":  l.ExpiresAt,
+		"max_uses":    l.MaxUses,
+		"use_count":   l.UseCount,
+	}
+}
+
+func (p *TeamInviteLinkPatch) Auditable() map[string]any {
+	return map[string]any{
+		"name":        p.Name,
+		"channel_ids": p.ChannelIds,
+		"expires_at":  p.ExpiresAt,
+		"max_uses":    p.MaxUses,
+	}
+}
+
+func (l *TeamInviteLink) PreSave() {
+	if l.Id == "" {
+		l.Id = NewId()
+	}
+
+	if l.Role == "" {
+		l.Role = TeamInviteLinkRoleMember
+	}
+
+	if l.ChannelIds == nil {
+		l.ChannelIds = StringArray{}
+	}
+
+	l.CreateAt = GetMillis()
+	l.UpdateAt = l.CreateAt
+	l.UseCount = 0
+}
+
+func (l *TeamInviteLink) PreUpdate() {
+	l.UpdateAt = GetMillis()
+}
+
+func (l *TeamInviteLink) IsValid() *AppError {
+	if !IsValidId(l.Id) {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(l.TeamId) {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.team_id.app_error", nil, "id="+l.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(l.CreatorId) {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.creator_id.app_error", nil, "id="+l.Id, http.StatusBadRequest)
+	}
+
+	if l.Name == "" || utf8.RuneCountInString(l.Name) > TeamInviteLinkNameMaxRunes {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.name.app_error", map[string]any{"MaxLength": TeamInviteLinkNameMaxRunes}, "id="+l.Id, http.StatusBadRequest)
+	}
+
+	if l.Role != TeamInviteLinkRoleMember && l.Role != TeamInviteLinkRoleGuest {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.role.app_error", nil, "id="+l.Id, http.StatusBadRequest)
+	}
+
+	if len(l.ChannelIds) > TeamInviteLinkMaxChannels {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.too_many_channels.app_error", map[string]any{"Max": TeamInviteLinkMaxChannels}, "id="+l.Id, http.StatusBadRequest)
+	}
+	for _, channelID := range l.ChannelIds {
+		if !IsValidId(channelID) {
+			return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.channel_id.app_error", nil, "id="+l.Id, http.StatusBadRequest)
+		}
+	}
+
+	// Guests can only see the channels they are added to.
+	if l.Role == TeamInviteLinkRoleGuest && len(l.ChannelIds) == 0 {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.guest_channels.app_error", nil, "id="+l.Id, http.StatusBadRequest)
+	}
+
+	if l.ExpiresAt < 0 {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.expires_at.app_error", nil, "id="+l.Id, http.StatusBadRequest)
+	}
+
+	if l.MaxUses < 0 {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.max_uses.app_error", nil, "id="+l.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (l *TeamInviteLink) Patch(patch *TeamInviteLinkPatch) {
+	if patch.Name != nil {
+		l.Name = *patch.Name
+	}
+
+	if patch.ChannelIds != nil {
+		l.ChannelIds = *patch.ChannelIds
+	}
+
+	if patch.ExpiresAt != nil {
+		l.ExpiresAt = *patch.ExpiresAt
+	}
+
+	if patch.MaxUses != nil {
+		l.MaxUses = *patch.MaxUses
+	}
+}
+
+func (l *TeamInviteLink) IsExpired(now int64) bool {
+	return l.ExpiresAt != 0 && now >= l.ExpiresAt
+}
+
+func (l *TeamInviteLink) IsExhausted() bool {
+	return l.MaxUses != 0 && l.UseCount >= l.MaxUses
+}
+
+// IsUsable reports whether users can join through the link.
+func (l *TeamInviteLink) IsUsable(now int64) bool {
+	return l.DeleteAt == 0 && !l.IsExpired(now) && !l.IsExhausted()
+}
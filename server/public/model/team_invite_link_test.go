@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamInviteLinkIsValid(t *testing.T) {
+	newLink := func() *TeamInviteLink {
+		link := &TeamInviteLink{
+			TeamId:    NewId(),
+			CreatorId: NewId(),
+			Name:      "Conference",
+		}
+		link.PreSave()
+		return link
+	}
+
+	link := newLink()
+	require.Nil(t, link.IsValid())
+	assert.Equal(t, TeamInviteLinkRoleMember, link.Role)
+	assert.NotNil(t, link.ChannelIds)
+
+	for name, tc := range map[string]struct {
+		update func(link *TeamInviteLink)
+		id     string
+	}{
+		"missing team":      {func(link *TeamInviteLink) { link.TeamId = "" }, "model.team_invite_link.is_valid.team_id.app_error"},
+		"missing name":      {func(link *TeamInviteLink) { link.Name = "" }, "model.team_invite_link.is_valid.name.app_error"},
+		"long name":         {func(link *TeamInviteLink) { link.Name = strings.Repeat("a", TeamInviteLinkNameMaxRunes+1) }, "model.team_invite_link.is_valid.name.app_error"},
+		"unknown role":      {func(link *TeamInviteLink) { link.Role = "admin" }, "model.team_invite_link.is_valid.role.app_error"},
+		"invalid channel":   {func(link *TeamInviteLink) { link.ChannelIds = StringArray{"channel"} }, "model.team_invite_link.is_valid.channel_id.app_error"},
+		"guest no channels": {func(link *TeamInviteLink) { link.Role = TeamInviteLinkRoleGuest }, "model.team_invite_link.is_valid.guest_channels.app_error"},
+		"negative max uses": {func(link *TeamInviteLink) { link.MaxUses = -1 }, "model.team_invite_link.is_valid.max_uses.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			link := newLink()
+			tc.update(link)
+
+			appErr := link.IsValid()
+			require.NotNil(t, appErr)
+			assert.Equal(t, tc.id, appErr.Id)
+		})
+	}
+
+	t.Run("guest with channels", func(t *testing.T) {
+		link := newLink()
+		link.Role = TeamInviteLinkRoleGuest
+		link.ChannelIds = StringArray{NewId()}
+		assert.Nil(t, link.IsValid())
+	})
+}
+
+func TestTeamInviteLinkIsUsable(t *testing.T) {
+	now := GetMillis()
+
+	link := &TeamInviteLink{}
+	assert.True(t, link.IsUsable(now))
+
+	link.ExpiresAt = now + 1000
+	assert.True(t, link.IsUsable(now))
+	assert.False(t, link.IsUsable(now+1000))
+
+	link.MaxUses = 2
+	link.UseCount = 1
+	assert.True(t, link.IsUsable(now))
+	link.UseCount = 2
+	assert.False(t, link.IsUsable(now))
+
+	link.MaxUses = 0
+	link.DeleteAt = now
+	assert.False(t, link.IsUsable(now))
+}
+
+func TestTeamInviteLinkPatch(t *testing.T) {
+	link := &TeamInviteLink{Name: "Conference", MaxUses: 10}
+	link.Patch(&TeamInviteLinkPatch{
+		Name:       NewPointer("Workshop"),
+		ChannelIds: &[]string{"channel1"},
+	})
+
+	assert.Equal(t, "Workshop", link.Name)
+	assert.Equal(t, StringArray{"channel1"}, link.ChannelIds)
+	assert.EqualValues(t, 10, link.MaxUses)
+}
@@ -42,6 +42,10 @@ type UserGetOptions struct {
 	PerPage int
 	// Filters the users that have been updated after the given time
 	UpdatedAfter int64
+	// Filters out bot accounts
+	ExcludeBots bool
+	// Filters out the users of remote clusters
+	ExcludeRemote bool
 }
 
 type UserGetByIdsOptions struct {
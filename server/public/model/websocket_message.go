@@ -50,6 +50,7 @@ const (
 	WebsocketEventReactionRemoved                     WebsocketEventType = "reaction_removed"
 	WebsocketEventResponse                            WebsocketEventType = "response"
 	WebsocketEventEmojiAdded                          WebsocketEventType = "emoji_added"
+	WebsocketEventEmojiUpdated                        WebsocketEventType = "emoji_updated"
 	WebsocketEventChannelViewed                       WebsocketEventType = "channel_viewed"
 	WebsocketEventMultipleChannelsViewed              WebsocketEventType = "multiple_channels_viewed"
 	WebsocketEventPluginStatusesChanged               WebsocketEventType = "plugin_statuses_changed"
@@ -1564,6 +1564,36 @@ type API interface {
 	// @tag Audit
 	// Minimum server version: 10.10
 	LogAuditRecWithLevel(rec *model.AuditRecord, level mlog.Level)
+
+	// SetGuestAccessExpiry sets the time, in milliseconds, after which a guest is deactivated
+	// and removed from its channels. An expiresAt of 0 removes the expiry.
+	//
+	// @tag User
+	// Minimum server version: 11.3
+	SetGuestAccessExpiry(userID string, expiresAt int64) (*model.GuestAccessExpiry, *model.AppError)
+
+	// GetGuestAccessExpiry gets the access expiry of a guest.
+	//
+	// @tag User
+	// Minimum server version: 11.3
+	GetGuestAccessExpiry(userID string) (*model.GuestAccessExpiry, *model.AppError)
+
+	// RegisterSearchEngine registers the plugin as a search engine. While the plugin is active,
+	// searches are routed to the SearchEngineSearch* hooks, falling back to the database search
+	// on errors, and the indexing hooks receive the posts, files, channels and users to index.
+	// Run an indexing job of type plugin_search_indexing to index the existing content.
+	//
+	// Only one plugin can be registered as a search engine at a time.
+	//
+	// @tag Search
+	// Minimum server version: 11.3
+	RegisterSearchEngine() *model.AppError
+
+	// UnregisterSearchEngine stops routing the searches to the plugin.
+	//
+	// @tag Search
+	// Minimum server version: 11.3
+	UnregisterSearchEngine() *model.AppError
 }
 
 var handshake = plugin.HandshakeConfig{
@@ -1671,3 +1671,31 @@ func (api *apiTimerLayer) LogAuditRecWithLevel(rec *model.AuditRecord, level mlo
 	api.apiImpl.LogAuditRecWithLevel(rec, level)
 	api.recordTime(startTime, "LogAuditRecWithLevel", true)
 }
+
+func (api *apiTimerLayer) SetGuestAccessExpiry(userID string, expiresAt int64) (*model.GuestAccessExpiry, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.SetGuestAccessExpiry(userID, expiresAt)
+	api.recordTime(startTime, "SetGuestAccessExpiry", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) GetGuestAccessExpiry(userID string) (*model.GuestAccessExpiry, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetGuestAccessExpiry(userID)
+	api.recordTime(startTime, "GetGuestAccessExpiry", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) RegisterSearchEngine() *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RegisterSearchEngine()
+	api.recordTime(startTime, "RegisterSearchEngine", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) UnregisterSearchEngine() *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UnregisterSearchEngine()
+	api.recordTime(startTime, "UnregisterSearchEngine", _returnsA == nil)
+	return _returnsA
+}
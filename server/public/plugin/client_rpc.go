@@ -30,12 +30,27 @@ import (
 
 var hookNameToId = make(map[string]int)
 
+// hookIdToName is built on first use, once the hooks have registered their names in their init.
+var hookIdToName = sync.OnceValue(func() map[int]string {
+	names := make(map[int]string, len(hookNameToId))
+	for name, id := range hookNameToId {
+		names[id] = name
+	}
+	return names
+})
+
+// HookName returns the name of the hook with the given id, or an empty string if it's unknown.
+func HookName(hookId int) string {
+	return hookIdToName()[hookId]
+}
+
 type hooksRPCClient struct {
 	client      *rpc.Client
 	log         *mlog.Logger
 	muxBroker   *plugin.MuxBroker
 	apiImpl     API
 	driver      Driver
+	permissions *model.PluginPermissions
 	implemented [TotalHooksID]bool
 	doneWg      sync.WaitGroup
 }
@@ -48,10 +63,11 @@ type hooksRPCServer struct {
 
 // Implements hashicorp/go-plugin/plugin.Plugin interface to connect the hooks of a plugin
 type hooksPlugin struct {
-	hooks      any
-	apiImpl    API
-	driverImpl Driver
-	log        *mlog.Logger
+	hooks       any
+	apiImpl     API
+	driverImpl  Driver
+	permissions *model.PluginPermissions
+	log         *mlog.Logger
 }
 
 func (p *hooksPlugin) Server(b *plugin.MuxBroker) (any, error) {
@@ -60,11 +76,12 @@ func (p *hooksPlugin) Server(b *plugin.MuxBroker) (any, error) {
 
 func (p *hooksPlugin) Client(b *plugin.MuxBroker, client *rpc.Client) (any, error) {
 	return &hooksRPCClient{
-		client:    client,
-		log:       p.log,
-		muxBroker: b,
-		apiImpl:   p.apiImpl,
-		driver:    p.driverImpl,
+		client:      client,
+		log:         p.log,
+		muxBroker:   b,
+		apiImpl:     p.apiImpl,
+		driver:      p.driverImpl,
+		permissions: p.permissions,
 	}, nil
 }
 
@@ -76,6 +93,10 @@ type apiRPCClient struct {
 type apiRPCServer struct {
 	impl      API
 	muxBroker *plugin.MuxBroker
+
+	// permissions are the permissions declared by the plugin calling the API, nil if the plugin
+	// doesn't declare any.
+	permissions *model.PluginPermissions
 }
 
 // ErrorString is a fallback for sending unregistered implementations of the error interface across
@@ -243,8 +264,9 @@ func (g *hooksRPCClient) OnActivate() error {
 	go func() {
 		defer g.doneWg.Done()
 		g.muxBroker.AcceptAndServe(muxId, &apiRPCServer{
-			impl:      g.apiImpl,
-			muxBroker: g.muxBroker,
+			impl:        g.apiImpl,
+			muxBroker:   g.muxBroker,
+			permissions: g.permissions,
 		})
 	}()
 
@@ -1143,6 +1165,11 @@ func (s *apiRPCServer) InstallPlugin(args *Z_InstallPluginArgs, returns *Z_Insta
 	pluginReader := connectIOReader(receivePluginConnection)
 	defer pluginReader.Close()
 
+	if appErr := s.checkAPIPermission("InstallPlugin"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
+
 	returns.A, returns.B = hook.InstallPlugin(pluginReader, args.B)
 	return nil
 }
@@ -1195,6 +1222,11 @@ func (s *apiRPCServer) UploadData(args *Z_UploadDataArgs, returns *Z_UploadDataR
 	pluginReader := connectIOReader(receivePluginConnection)
 	defer pluginReader.Close()
 
+	if appErr := s.checkAPIPermission("UploadData"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
+
 	returns.A, returns.B = hook.UploadData(args.A, pluginReader)
 	return nil
 }
@@ -1234,6 +1234,595 @@ func (s *hooksRPCServer) OnSAMLLogin(args *Z_OnSAMLLoginArgs, returns *Z_OnSAMLL
 	return nil
 }
 
+func init() {
+	hookNameToId["ChannelWillBeUpdated"] = ChannelWillBeUpdatedID
+}
+
+type Z_ChannelWillBeUpdatedArgs struct {
+	A *Context
+	B *model.Channel
+	C *model.Channel
+}
+
+type Z_ChannelWillBeUpdatedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) ChannelWillBeUpdated(c *Context, newChannel, oldChannel *model.Channel) string {
+	_args := &Z_ChannelWillBeUpdatedArgs{c, newChannel, oldChannel}
+	_returns := &Z_ChannelWillBeUpdatedReturns{}
+	if g.implemented[ChannelWillBeUpdatedID] {
+		if err := g.client.Call("Plugin.ChannelWillBeUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelWillBeUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) ChannelWillBeUpdated(args *Z_ChannelWillBeUpdatedArgs, returns *Z_ChannelWillBeUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelWillBeUpdated(c *Context, newChannel, oldChannel *model.Channel) string
+	}); ok {
+		returns.A = hook.ChannelWillBeUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelWillBeUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelHasBeenUpdated"] = ChannelHasBeenUpdatedID
+}
+
+type Z_ChannelHasBeenUpdatedArgs struct {
+	A *Context
+	B *model.Channel
+	C *model.Channel
+}
+
+type Z_ChannelHasBeenUpdatedReturns struct {
+}
+
+func (g *hooksRPCClient) ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel) {
+	_args := &Z_ChannelHasBeenUpdatedArgs{c, newChannel, oldChannel}
+	_returns := &Z_ChannelHasBeenUpdatedReturns{}
+	if g.implemented[ChannelHasBeenUpdatedID] {
+		if err := g.client.Call("Plugin.ChannelHasBeenUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ChannelHasBeenUpdated(args *Z_ChannelHasBeenUpdatedArgs, returns *Z_ChannelHasBeenUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel)
+	}); ok {
+		hook.ChannelHasBeenUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelHasBeenUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelWillBeArchived"] = ChannelWillBeArchivedID
+}
+
+type Z_ChannelWillBeArchivedArgs struct {
+	A *Context
+	B *model.Channel
+}
+
+type Z_ChannelWillBeArchivedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) ChannelWillBeArchived(c *Context, channel *model.Channel) string {
+	_args := &Z_ChannelWillBeArchivedArgs{c, channel}
+	_returns := &Z_ChannelWillBeArchivedReturns{}
+	if g.implemented[ChannelWillBeArchivedID] {
+		if err := g.client.Call("Plugin.ChannelWillBeArchived", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelWillBeArchived to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) ChannelWillBeArchived(args *Z_ChannelWillBeArchivedArgs, returns *Z_ChannelWillBeArchivedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelWillBeArchived(c *Context, channel *model.Channel) string
+	}); ok {
+		returns.A = hook.ChannelWillBeArchived(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelWillBeArchived called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelHasBeenArchived"] = ChannelHasBeenArchivedID
+}
+
+type Z_ChannelHasBeenArchivedArgs struct {
+	A *Context
+	B *model.Channel
+}
+
+type Z_ChannelHasBeenArchivedReturns struct {
+}
+
+func (g *hooksRPCClient) ChannelHasBeenArchived(c *Context, channel *model.Channel) {
+	_args := &Z_ChannelHasBeenArchivedArgs{c, channel}
+	_returns := &Z_ChannelHasBeenArchivedReturns{}
+	if g.implemented[ChannelHasBeenArchivedID] {
+		if err := g.client.Call("Plugin.ChannelHasBeenArchived", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelHasBeenArchived to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ChannelHasBeenArchived(args *Z_ChannelHasBeenArchivedArgs, returns *Z_ChannelHasBeenArchivedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelHasBeenArchived(c *Context, channel *model.Channel)
+	}); ok {
+		hook.ChannelHasBeenArchived(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelHasBeenArchived called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelHasBeenRestored"] = ChannelHasBeenRestoredID
+}
+
+type Z_ChannelHasBeenRestoredArgs struct {
+	A *Context
+	B *model.Channel
+}
+
+type Z_ChannelHasBeenRestoredReturns struct {
+}
+
+func (g *hooksRPCClient) ChannelHasBeenRestored(c *Context, channel *model.Channel) {
+	_args := &Z_ChannelHasBeenRestoredArgs{c, channel}
+	_returns := &Z_ChannelHasBeenRestoredReturns{}
+	if g.implemented[ChannelHasBeenRestoredID] {
+		if err := g.client.Call("Plugin.ChannelHasBeenRestored", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelHasBeenRestored to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ChannelHasBeenRestored(args *Z_ChannelHasBeenRestoredArgs, returns *Z_ChannelHasBeenRestoredReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelHasBeenRestored(c *Context, channel *model.Channel)
+	}); ok {
+		hook.ChannelHasBeenRestored(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelHasBeenRestored called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelHasBeenDeleted"] = ChannelHasBeenDeletedID
+}
+
+type Z_ChannelHasBeenDeletedArgs struct {
+	A *Context
+	B *model.Channel
+}
+
+type Z_ChannelHasBeenDeletedReturns struct {
+}
+
+func (g *hooksRPCClient) ChannelHasBeenDeleted(c *Context, channel *model.Channel) {
+	_args := &Z_ChannelHasBeenDeletedArgs{c, channel}
+	_returns := &Z_ChannelHasBeenDeletedReturns{}
+	if g.implemented[ChannelHasBeenDeletedID] {
+		if err := g.client.Call("Plugin.ChannelHasBeenDeleted", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelHasBeenDeleted to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ChannelHasBeenDeleted(args *Z_ChannelHasBeenDeletedArgs, returns *Z_ChannelHasBeenDeletedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelHasBeenDeleted(c *Context, channel *model.Channel)
+	}); ok {
+		hook.ChannelHasBeenDeleted(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelHasBeenDeleted called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["TeamHasBeenCreated"] = TeamHasBeenCreatedID
+}
+
+type Z_TeamHasBeenCreatedArgs struct {
+	A *Context
+	B *model.Team
+}
+
+type Z_TeamHasBeenCreatedReturns struct {
+}
+
+func (g *hooksRPCClient) TeamHasBeenCreated(c *Context, team *model.Team) {
+	_args := &Z_TeamHasBeenCreatedArgs{c, team}
+	_returns := &Z_TeamHasBeenCreatedReturns{}
+	if g.implemented[TeamHasBeenCreatedID] {
+		if err := g.client.Call("Plugin.TeamHasBeenCreated", _args, _returns); err != nil {
+			g.log.Error("RPC call TeamHasBeenCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) TeamHasBeenCreated(args *Z_TeamHasBeenCreatedArgs, returns *Z_TeamHasBeenCreatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		TeamHasBeenCreated(c *Context, team *model.Team)
+	}); ok {
+		hook.TeamHasBeenCreated(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook TeamHasBeenCreated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["TeamWillBeUpdated"] = TeamWillBeUpdatedID
+}
+
+type Z_TeamWillBeUpdatedArgs struct {
+	A *Context
+	B *model.Team
+	C *model.Team
+}
+
+type Z_TeamWillBeUpdatedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) TeamWillBeUpdated(c *Context, newTeam, oldTeam *model.Team) string {
+	_args := &Z_TeamWillBeUpdatedArgs{c, newTeam, oldTeam}
+	_returns := &Z_TeamWillBeUpdatedReturns{}
+	if g.implemented[TeamWillBeUpdatedID] {
+		if err := g.client.Call("Plugin.TeamWillBeUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call TeamWillBeUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) TeamWillBeUpdated(args *Z_TeamWillBeUpdatedArgs, returns *Z_TeamWillBeUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		TeamWillBeUpdated(c *Context, newTeam, oldTeam *model.Team) string
+	}); ok {
+		returns.A = hook.TeamWillBeUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook TeamWillBeUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["TeamHasBeenUpdated"] = TeamHasBeenUpdatedID
+}
+
+type Z_TeamHasBeenUpdatedArgs struct {
+	A *Context
+	B *model.Team
+	C *model.Team
+}
+
+type Z_TeamHasBeenUpdatedReturns struct {
+}
+
+func (g *hooksRPCClient) TeamHasBeenUpdated(c *Context, newTeam, oldTeam *model.Team) {
+	_args := &Z_TeamHasBeenUpdatedArgs{c, newTeam, oldTeam}
+	_returns := &Z_TeamHasBeenUpdatedReturns{}
+	if g.implemented[TeamHasBeenUpdatedID] {
+		if err := g.client.Call("Plugin.TeamHasBeenUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call TeamHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) TeamHasBeenUpdated(args *Z_TeamHasBeenUpdatedArgs, returns *Z_TeamHasBeenUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		TeamHasBeenUpdated(c *Context, newTeam, oldTeam *model.Team)
+	}); ok {
+		hook.TeamHasBeenUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook TeamHasBeenUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["TeamWillBeArchived"] = TeamWillBeArchivedID
+}
+
+type Z_TeamWillBeArchivedArgs struct {
+	A *Context
+	B *model.Team
+}
+
+type Z_TeamWillBeArchivedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) TeamWillBeArchived(c *Context, team *model.Team) string {
+	_args := &Z_TeamWillBeArchivedArgs{c, team}
+	_returns := &Z_TeamWillBeArchivedReturns{}
+	if g.implemented[TeamWillBeArchivedID] {
+		if err := g.client.Call("Plugin.TeamWillBeArchived", _args, _returns); err != nil {
+			g.log.Error("RPC call TeamWillBeArchived to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) TeamWillBeArchived(args *Z_TeamWillBeArchivedArgs, returns *Z_TeamWillBeArchivedReturns) error {
+	if hook, ok := s.impl.(interface {
+		TeamWillBeArchived(c *Context, team *model.Team) string
+	}); ok {
+		returns.A = hook.TeamWillBeArchived(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook TeamWillBeArchived called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["TeamHasBeenArchived"] = TeamHasBeenArchivedID
+}
+
+type Z_TeamHasBeenArchivedArgs struct {
+	A *Context
+	B *model.Team
+}
+
+type Z_TeamHasBeenArchivedReturns struct {
+}
+
+func (g *hooksRPCClient) TeamHasBeenArchived(c *Context, team *model.Team) {
+	_args := &Z_TeamHasBeenArchivedArgs{c, team}
+	_returns := &Z_TeamHasBeenArchivedReturns{}
+	if g.implemented[TeamHasBeenArchivedID] {
+		if err := g.client.Call("Plugin.TeamHasBeenArchived", _args, _returns); err != nil {
+			g.log.Error("RPC call TeamHasBeenArchived to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) TeamHasBeenArchived(args *Z_TeamHasBeenArchivedArgs, returns *Z_TeamHasBeenArchivedReturns) error {
+	if hook, ok := s.impl.(interface {
+		TeamHasBeenArchived(c *Context, team *model.Team)
+	}); ok {
+		hook.TeamHasBeenArchived(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook TeamHasBeenArchived called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["TeamHasBeenRestored"] = TeamHasBeenRestoredID
+}
+
+type Z_TeamHasBeenRestoredArgs struct {
+	A *Context
+	B *model.Team
+}
+
+type Z_TeamHasBeenRestoredReturns struct {
+}
+
+func (g *hooksRPCClient) TeamHasBeenRestored(c *Context, team *model.Team) {
+	_args := &Z_TeamHasBeenRestoredArgs{c, team}
+	_returns := &Z_TeamHasBeenRestoredReturns{}
+	if g.implemented[TeamHasBeenRestoredID] {
+		if err := g.client.Call("Plugin.TeamHasBeenRestored", _args, _returns); err != nil {
+			g.log.Error("RPC call TeamHasBeenRestored to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) TeamHasBeenRestored(args *Z_TeamHasBeenRestoredArgs, returns *Z_TeamHasBeenRestoredReturns) error {
+	if hook, ok := s.impl.(interface {
+		TeamHasBeenRestored(c *Context, team *model.Team)
+	}); ok {
+		hook.TeamHasBeenRestored(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook TeamHasBeenRestored called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["UserHasBeenUpdated"] = UserHasBeenUpdatedID
+}
+
+type Z_UserHasBeenUpdatedArgs struct {
+	A *Context
+	B *model.User
+	C *model.User
+}
+
+type Z_UserHasBeenUpdatedReturns struct {
+}
+
+func (g *hooksRPCClient) UserHasBeenUpdated(c *Context, newUser, oldUser *model.User) {
+	_args := &Z_UserHasBeenUpdatedArgs{c, newUser, oldUser}
+	_returns := &Z_UserHasBeenUpdatedReturns{}
+	if g.implemented[UserHasBeenUpdatedID] {
+		if err := g.client.Call("Plugin.UserHasBeenUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call UserHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) UserHasBeenUpdated(args *Z_UserHasBeenUpdatedArgs, returns *Z_UserHasBeenUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserHasBeenUpdated(c *Context, newUser, oldUser *model.User)
+	}); ok {
+		hook.UserHasBeenUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook UserHasBeenUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["UserRolesHaveChanged"] = UserRolesHaveChangedID
+}
+
+type Z_UserRolesHaveChangedArgs struct {
+	A *Context
+	B *model.User
+	C string
+}
+
+type Z_UserRolesHaveChangedReturns struct {
+}
+
+func (g *hooksRPCClient) UserRolesHaveChanged(c *Context, user *model.User, oldRoles string) {
+	_args := &Z_UserRolesHaveChangedArgs{c, user, oldRoles}
+	_returns := &Z_UserRolesHaveChangedReturns{}
+	if g.implemented[UserRolesHaveChangedID] {
+		if err := g.client.Call("Plugin.UserRolesHaveChanged", _args, _returns); err != nil {
+			g.log.Error("RPC call UserRolesHaveChanged to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) UserRolesHaveChanged(args *Z_UserRolesHaveChangedArgs, returns *Z_UserRolesHaveChangedReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserRolesHaveChanged(c *Context, user *model.User, oldRoles string)
+	}); ok {
+		hook.UserRolesHaveChanged(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook UserRolesHaveChanged called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["MessageHasBeenPinned"] = MessageHasBeenPinnedID
+}
+
+type Z_MessageHasBeenPinnedArgs struct {
+	A *Context
+	B *model.Post
+}
+
+type Z_MessageHasBeenPinnedReturns struct {
+}
+
+func (g *hooksRPCClient) MessageHasBeenPinned(c *Context, post *model.Post) {
+	_args := &Z_MessageHasBeenPinnedArgs{c, post}
+	_returns := &Z_MessageHasBeenPinnedReturns{}
+	if g.implemented[MessageHasBeenPinnedID] {
+		if err := g.client.Call("Plugin.MessageHasBeenPinned", _args, _returns); err != nil {
+			g.log.Error("RPC call MessageHasBeenPinned to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) MessageHasBeenPinned(args *Z_MessageHasBeenPinnedArgs, returns *Z_MessageHasBeenPinnedReturns) error {
+	if hook, ok := s.impl.(interface {
+		MessageHasBeenPinned(c *Context, post *model.Post)
+	}); ok {
+		hook.MessageHasBeenPinned(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook MessageHasBeenPinned called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["MessageHasBeenUnpinned"] = MessageHasBeenUnpinnedID
+}
+
+type Z_MessageHasBeenUnpinnedArgs struct {
+	A *Context
+	B *model.Post
+}
+
+type Z_MessageHasBeenUnpinnedReturns struct {
+}
+
+func (g *hooksRPCClient) MessageHasBeenUnpinned(c *Context, post *model.Post) {
+	_args := &Z_MessageHasBeenUnpinnedArgs{c, post}
+	_returns := &Z_MessageHasBeenUnpinnedReturns{}
+	if g.implemented[MessageHasBeenUnpinnedID] {
+		if err := g.client.Call("Plugin.MessageHasBeenUnpinned", _args, _returns); err != nil {
+			g.log.Error("RPC call MessageHasBeenUnpinned to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) MessageHasBeenUnpinned(args *Z_MessageHasBeenUnpinnedArgs, returns *Z_MessageHasBeenUnpinnedReturns) error {
+	if hook, ok := s.impl.(interface {
+		MessageHasBeenUnpinned(c *Context, post *model.Post)
+	}); ok {
+		hook.MessageHasBeenUnpinned(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook MessageHasBeenUnpinned called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["MessageHasBeenAcknowledged"] = MessageHasBeenAcknowledgedID
+}
+
+type Z_MessageHasBeenAcknowledgedArgs struct {
+	A *Context
+	B *model.Post
+	C *model.PostAcknowledgement
+}
+
+type Z_MessageHasBeenAcknowledgedReturns struct {
+}
+
+func (g *hooksRPCClient) MessageHasBeenAcknowledged(c *Context, post *model.Post, acknowledgement *model.PostAcknowledgement) {
+	_args := &Z_MessageHasBeenAcknowledgedArgs{c, post, acknowledgement}
+	_returns := &Z_MessageHasBeenAcknowledgedReturns{}
+	if g.implemented[MessageHasBeenAcknowledgedID] {
+		if err := g.client.Call("Plugin.MessageHasBeenAcknowledged", _args, _returns); err != nil {
+			g.log.Error("RPC call MessageHasBeenAcknowledged to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) MessageHasBeenAcknowledged(args *Z_MessageHasBeenAcknowledgedArgs, returns *Z_MessageHasBeenAcknowledgedReturns) error {
+	if hook, ok := s.impl.(interface {
+		MessageHasBeenAcknowledged(c *Context, post *model.Post, acknowledgement *model.PostAcknowledgement)
+	}); ok {
+		hook.MessageHasBeenAcknowledged(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook MessageHasBeenAcknowledged called but not implemented."))
+	}
+	return nil
+}
+
 type Z_RegisterCommandArgs struct {
 	A *model.Command
 }
@@ -1252,6 +1841,10 @@ func (g *apiRPCClient) RegisterCommand(command *model.Command) error {
 }
 
 func (s *apiRPCServer) RegisterCommand(args *Z_RegisterCommandArgs, returns *Z_RegisterCommandReturns) error {
+	if appErr := s.checkAPIPermission("RegisterCommand"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RegisterCommand(command *model.Command) error
 	}); ok {
@@ -1282,6 +1875,10 @@ func (g *apiRPCClient) UnregisterCommand(teamID, trigger string) error {
 }
 
 func (s *apiRPCServer) UnregisterCommand(args *Z_UnregisterCommandArgs, returns *Z_UnregisterCommandReturns) error {
+	if appErr := s.checkAPIPermission("UnregisterCommand"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UnregisterCommand(teamID, trigger string) error
 	}); ok {
@@ -1312,6 +1909,10 @@ func (g *apiRPCClient) ExecuteSlashCommand(commandArgs *model.CommandArgs) (*mod
 }
 
 func (s *apiRPCServer) ExecuteSlashCommand(args *Z_ExecuteSlashCommandArgs, returns *Z_ExecuteSlashCommandReturns) error {
+	if appErr := s.checkAPIPermission("ExecuteSlashCommand"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ExecuteSlashCommand(commandArgs *model.CommandArgs) (*model.CommandResponse, error)
 	}); ok {
@@ -1340,6 +1941,9 @@ func (g *apiRPCClient) GetConfig() *model.Config {
 }
 
 func (s *apiRPCServer) GetConfig(args *Z_GetConfigArgs, returns *Z_GetConfigReturns) error {
+	if appErr := s.checkAPIPermission("GetConfig"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetConfig() *model.Config
 	}); ok {
@@ -1367,6 +1971,9 @@ func (g *apiRPCClient) GetUnsanitizedConfig() *model.Config {
 }
 
 func (s *apiRPCServer) GetUnsanitizedConfig(args *Z_GetUnsanitizedConfigArgs, returns *Z_GetUnsanitizedConfigReturns) error {
+	if appErr := s.checkAPIPermission("GetUnsanitizedConfig"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetUnsanitizedConfig() *model.Config
 	}); ok {
@@ -1395,6 +2002,10 @@ func (g *apiRPCClient) SaveConfig(config *model.Config) *model.AppError {
 }
 
 func (s *apiRPCServer) SaveConfig(args *Z_SaveConfigArgs, returns *Z_SaveConfigReturns) error {
+	if appErr := s.checkAPIPermission("SaveConfig"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SaveConfig(config *model.Config) *model.AppError
 	}); ok {
@@ -1422,6 +2033,9 @@ func (g *apiRPCClient) GetPluginConfig() map[string]any {
 }
 
 func (s *apiRPCServer) GetPluginConfig(args *Z_GetPluginConfigArgs, returns *Z_GetPluginConfigReturns) error {
+	if appErr := s.checkAPIPermission("GetPluginConfig"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetPluginConfig() map[string]any
 	}); ok {
@@ -1450,6 +2064,10 @@ func (g *apiRPCClient) SavePluginConfig(config map[string]any) *model.AppError {
 }
 
 func (s *apiRPCServer) SavePluginConfig(args *Z_SavePluginConfigArgs, returns *Z_SavePluginConfigReturns) error {
+	if appErr := s.checkAPIPermission("SavePluginConfig"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SavePluginConfig(config map[string]any) *model.AppError
 	}); ok {
@@ -1478,6 +2096,10 @@ func (g *apiRPCClient) GetBundlePath() (string, error) {
 }
 
 func (s *apiRPCServer) GetBundlePath(args *Z_GetBundlePathArgs, returns *Z_GetBundlePathReturns) error {
+	if appErr := s.checkAPIPermission("GetBundlePath"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetBundlePath() (string, error)
 	}); ok {
@@ -1506,6 +2128,9 @@ func (g *apiRPCClient) GetLicense() *model.License {
 }
 
 func (s *apiRPCServer) GetLicense(args *Z_GetLicenseArgs, returns *Z_GetLicenseReturns) error {
+	if appErr := s.checkAPIPermission("GetLicense"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetLicense() *model.License
 	}); ok {
@@ -1533,6 +2158,9 @@ func (g *apiRPCClient) IsEnterpriseReady() bool {
 }
 
 func (s *apiRPCServer) IsEnterpriseReady(args *Z_IsEnterpriseReadyArgs, returns *Z_IsEnterpriseReadyReturns) error {
+	if appErr := s.checkAPIPermission("IsEnterpriseReady"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		IsEnterpriseReady() bool
 	}); ok {
@@ -1560,6 +2188,9 @@ func (g *apiRPCClient) GetServerVersion() string {
 }
 
 func (s *apiRPCServer) GetServerVersion(args *Z_GetServerVersionArgs, returns *Z_GetServerVersionReturns) error {
+	if appErr := s.checkAPIPermission("GetServerVersion"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetServerVersion() string
 	}); ok {
@@ -1588,6 +2219,10 @@ func (g *apiRPCClient) GetSystemInstallDate() (int64, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetSystemInstallDate(args *Z_GetSystemInstallDateArgs, returns *Z_GetSystemInstallDateReturns) error {
+	if appErr := s.checkAPIPermission("GetSystemInstallDate"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetSystemInstallDate() (int64, *model.AppError)
 	}); ok {
@@ -1615,6 +2250,9 @@ func (g *apiRPCClient) GetDiagnosticId() string {
 }
 
 func (s *apiRPCServer) GetDiagnosticId(args *Z_GetDiagnosticIdArgs, returns *Z_GetDiagnosticIdReturns) error {
+	if appErr := s.checkAPIPermission("GetDiagnosticId"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetDiagnosticId() string
 	}); ok {
@@ -1642,6 +2280,9 @@ func (g *apiRPCClient) GetTelemetryId() string {
 }
 
 func (s *apiRPCServer) GetTelemetryId(args *Z_GetTelemetryIdArgs, returns *Z_GetTelemetryIdReturns) error {
+	if appErr := s.checkAPIPermission("GetTelemetryId"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetTelemetryId() string
 	}); ok {
@@ -1671,6 +2312,10 @@ func (g *apiRPCClient) CreateUser(user *model.User) (*model.User, *model.AppErro
 }
 
 func (s *apiRPCServer) CreateUser(args *Z_CreateUserArgs, returns *Z_CreateUserReturns) error {
+	if appErr := s.checkAPIPermission("CreateUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateUser(user *model.User) (*model.User, *model.AppError)
 	}); ok {
@@ -1699,6 +2344,10 @@ func (g *apiRPCClient) DeleteUser(userID string) *model.AppError {
 }
 
 func (s *apiRPCServer) DeleteUser(args *Z_DeleteUserArgs, returns *Z_DeleteUserReturns) error {
+	if appErr := s.checkAPIPermission("DeleteUser"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteUser(userID string) *model.AppError
 	}); ok {
@@ -1728,6 +2377,10 @@ func (g *apiRPCClient) GetUsers(options *model.UserGetOptions) ([]*model.User, *
 }
 
 func (s *apiRPCServer) GetUsers(args *Z_GetUsersArgs, returns *Z_GetUsersReturns) error {
+	if appErr := s.checkAPIPermission("GetUsers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUsers(options *model.UserGetOptions) ([]*model.User, *model.AppError)
 	}); ok {
@@ -1757,6 +2410,10 @@ func (g *apiRPCClient) GetUsersByIds(userIDs []string) ([]*model.User, *model.Ap
 }
 
 func (s *apiRPCServer) GetUsersByIds(args *Z_GetUsersByIdsArgs, returns *Z_GetUsersByIdsReturns) error {
+	if appErr := s.checkAPIPermission("GetUsersByIds"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUsersByIds(userIDs []string) ([]*model.User, *model.AppError)
 	}); ok {
@@ -1786,6 +2443,10 @@ func (g *apiRPCClient) GetUser(userID string) (*model.User, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetUser(args *Z_GetUserArgs, returns *Z_GetUserReturns) error {
+	if appErr := s.checkAPIPermission("GetUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUser(userID string) (*model.User, *model.AppError)
 	}); ok {
@@ -1815,6 +2476,10 @@ func (g *apiRPCClient) GetUserByEmail(email string) (*model.User, *model.AppErro
 }
 
 func (s *apiRPCServer) GetUserByEmail(args *Z_GetUserByEmailArgs, returns *Z_GetUserByEmailReturns) error {
+	if appErr := s.checkAPIPermission("GetUserByEmail"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUserByEmail(email string) (*model.User, *model.AppError)
 	}); ok {
@@ -1844,6 +2509,10 @@ func (g *apiRPCClient) GetUserByUsername(name string) (*model.User, *model.AppEr
 }
 
 func (s *apiRPCServer) GetUserByUsername(args *Z_GetUserByUsernameArgs, returns *Z_GetUserByUsernameReturns) error {
+	if appErr := s.checkAPIPermission("GetUserByUsername"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUserByUsername(name string) (*model.User, *model.AppError)
 	}); ok {
@@ -1873,6 +2542,10 @@ func (g *apiRPCClient) GetUsersByUsernames(usernames []string) ([]*model.User, *
 }
 
 func (s *apiRPCServer) GetUsersByUsernames(args *Z_GetUsersByUsernamesArgs, returns *Z_GetUsersByUsernamesReturns) error {
+	if appErr := s.checkAPIPermission("GetUsersByUsernames"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUsersByUsernames(usernames []string) ([]*model.User, *model.AppError)
 	}); ok {
@@ -1904,6 +2577,10 @@ func (g *apiRPCClient) GetUsersInTeam(teamID string, page int, perPage int) ([]*
 }
 
 func (s *apiRPCServer) GetUsersInTeam(args *Z_GetUsersInTeamArgs, returns *Z_GetUsersInTeamReturns) error {
+	if appErr := s.checkAPIPermission("GetUsersInTeam"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUsersInTeam(teamID string, page int, perPage int) ([]*model.User, *model.AppError)
 	}); ok {
@@ -1935,6 +2612,10 @@ func (g *apiRPCClient) GetPreferenceForUser(userID, category, name string) (mode
 }
 
 func (s *apiRPCServer) GetPreferenceForUser(args *Z_GetPreferenceForUserArgs, returns *Z_GetPreferenceForUserReturns) error {
+	if appErr := s.checkAPIPermission("GetPreferenceForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPreferenceForUser(userID, category, name string) (model.Preference, *model.AppError)
 	}); ok {
@@ -1964,6 +2645,10 @@ func (g *apiRPCClient) GetPreferencesForUser(userID string) ([]model.Preference,
 }
 
 func (s *apiRPCServer) GetPreferencesForUser(args *Z_GetPreferencesForUserArgs, returns *Z_GetPreferencesForUserReturns) error {
+	if appErr := s.checkAPIPermission("GetPreferencesForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPreferencesForUser(userID string) ([]model.Preference, *model.AppError)
 	}); ok {
@@ -1993,6 +2678,10 @@ func (g *apiRPCClient) UpdatePreferencesForUser(userID string, preferences []mod
 }
 
 func (s *apiRPCServer) UpdatePreferencesForUser(args *Z_UpdatePreferencesForUserArgs, returns *Z_UpdatePreferencesForUserReturns) error {
+	if appErr := s.checkAPIPermission("UpdatePreferencesForUser"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdatePreferencesForUser(userID string, preferences []model.Preference) *model.AppError
 	}); ok {
@@ -2022,6 +2711,10 @@ func (g *apiRPCClient) DeletePreferencesForUser(userID string, preferences []mod
 }
 
 func (s *apiRPCServer) DeletePreferencesForUser(args *Z_DeletePreferencesForUserArgs, returns *Z_DeletePreferencesForUserReturns) error {
+	if appErr := s.checkAPIPermission("DeletePreferencesForUser"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeletePreferencesForUser(userID string, preferences []model.Preference) *model.AppError
 	}); ok {
@@ -2051,6 +2744,10 @@ func (g *apiRPCClient) GetSession(sessionID string) (*model.Session, *model.AppE
 }
 
 func (s *apiRPCServer) GetSession(args *Z_GetSessionArgs, returns *Z_GetSessionReturns) error {
+	if appErr := s.checkAPIPermission("GetSession"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetSession(sessionID string) (*model.Session, *model.AppError)
 	}); ok {
@@ -2080,6 +2777,10 @@ func (g *apiRPCClient) CreateSession(session *model.Session) (*model.Session, *m
 }
 
 func (s *apiRPCServer) CreateSession(args *Z_CreateSessionArgs, returns *Z_CreateSessionReturns) error {
+	if appErr := s.checkAPIPermission("CreateSession"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateSession(session *model.Session) (*model.Session, *model.AppError)
 	}); ok {
@@ -2109,6 +2810,10 @@ func (g *apiRPCClient) ExtendSessionExpiry(sessionID string, newExpiry int64) *m
 }
 
 func (s *apiRPCServer) ExtendSessionExpiry(args *Z_ExtendSessionExpiryArgs, returns *Z_ExtendSessionExpiryReturns) error {
+	if appErr := s.checkAPIPermission("ExtendSessionExpiry"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ExtendSessionExpiry(sessionID string, newExpiry int64) *model.AppError
 	}); ok {
@@ -2137,6 +2842,10 @@ func (g *apiRPCClient) RevokeSession(sessionID string) *model.AppError {
 }
 
 func (s *apiRPCServer) RevokeSession(args *Z_RevokeSessionArgs, returns *Z_RevokeSessionReturns) error {
+	if appErr := s.checkAPIPermission("RevokeSession"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RevokeSession(sessionID string) *model.AppError
 	}); ok {
@@ -2166,6 +2875,10 @@ func (g *apiRPCClient) CreateUserAccessToken(token *model.UserAccessToken) (*mod
 }
 
 func (s *apiRPCServer) CreateUserAccessToken(args *Z_CreateUserAccessTokenArgs, returns *Z_CreateUserAccessTokenReturns) error {
+	if appErr := s.checkAPIPermission("CreateUserAccessToken"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateUserAccessToken(token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError)
 	}); ok {
@@ -2194,6 +2907,10 @@ func (g *apiRPCClient) RevokeUserAccessToken(tokenID string) *model.AppError {
 }
 
 func (s *apiRPCServer) RevokeUserAccessToken(args *Z_RevokeUserAccessTokenArgs, returns *Z_RevokeUserAccessTokenReturns) error {
+	if appErr := s.checkAPIPermission("RevokeUserAccessToken"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RevokeUserAccessToken(tokenID string) *model.AppError
 	}); ok {
@@ -2223,6 +2940,10 @@ func (g *apiRPCClient) GetTeamIcon(teamID string) ([]byte, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetTeamIcon(args *Z_GetTeamIconArgs, returns *Z_GetTeamIconReturns) error {
+	if appErr := s.checkAPIPermission("GetTeamIcon"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamIcon(teamID string) ([]byte, *model.AppError)
 	}); ok {
@@ -2252,6 +2973,10 @@ func (g *apiRPCClient) SetTeamIcon(teamID string, data []byte) *model.AppError {
 }
 
 func (s *apiRPCServer) SetTeamIcon(args *Z_SetTeamIconArgs, returns *Z_SetTeamIconReturns) error {
+	if appErr := s.checkAPIPermission("SetTeamIcon"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SetTeamIcon(teamID string, data []byte) *model.AppError
 	}); ok {
@@ -2280,6 +3005,10 @@ func (g *apiRPCClient) RemoveTeamIcon(teamID string) *model.AppError {
 }
 
 func (s *apiRPCServer) RemoveTeamIcon(args *Z_RemoveTeamIconArgs, returns *Z_RemoveTeamIconReturns) error {
+	if appErr := s.checkAPIPermission("RemoveTeamIcon"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RemoveTeamIcon(teamID string) *model.AppError
 	}); ok {
@@ -2309,6 +3038,10 @@ func (g *apiRPCClient) UpdateUser(user *model.User) (*model.User, *model.AppErro
 }
 
 func (s *apiRPCServer) UpdateUser(args *Z_UpdateUserArgs, returns *Z_UpdateUserReturns) error {
+	if appErr := s.checkAPIPermission("UpdateUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateUser(user *model.User) (*model.User, *model.AppError)
 	}); ok {
@@ -2338,6 +3071,10 @@ func (g *apiRPCClient) GetUserStatus(userID string) (*model.Status, *model.AppEr
 }
 
 func (s *apiRPCServer) GetUserStatus(args *Z_GetUserStatusArgs, returns *Z_GetUserStatusReturns) error {
+	if appErr := s.checkAPIPermission("GetUserStatus"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUserStatus(userID string) (*model.Status, *model.AppError)
 	}); ok {
@@ -2367,6 +3104,10 @@ func (g *apiRPCClient) GetUserStatusesByIds(userIds []string) ([]*model.Status,
 }
 
 func (s *apiRPCServer) GetUserStatusesByIds(args *Z_GetUserStatusesByIdsArgs, returns *Z_GetUserStatusesByIdsReturns) error {
+	if appErr := s.checkAPIPermission("GetUserStatusesByIds"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUserStatusesByIds(userIds []string) ([]*model.Status, *model.AppError)
 	}); ok {
@@ -2397,6 +3138,10 @@ func (g *apiRPCClient) UpdateUserStatus(userID, status string) (*model.Status, *
 }
 
 func (s *apiRPCServer) UpdateUserStatus(args *Z_UpdateUserStatusArgs, returns *Z_UpdateUserStatusReturns) error {
+	if appErr := s.checkAPIPermission("UpdateUserStatus"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateUserStatus(userID, status string) (*model.Status, *model.AppError)
 	}); ok {
@@ -2427,6 +3172,10 @@ func (g *apiRPCClient) SetUserStatusTimedDND(userId string, endtime int64) (*mod
 }
 
 func (s *apiRPCServer) SetUserStatusTimedDND(args *Z_SetUserStatusTimedDNDArgs, returns *Z_SetUserStatusTimedDNDReturns) error {
+	if appErr := s.checkAPIPermission("SetUserStatusTimedDND"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SetUserStatusTimedDND(userId string, endtime int64) (*model.Status, *model.AppError)
 	}); ok {
@@ -2456,6 +3205,10 @@ func (g *apiRPCClient) UpdateUserActive(userID string, active bool) *model.AppEr
 }
 
 func (s *apiRPCServer) UpdateUserActive(args *Z_UpdateUserActiveArgs, returns *Z_UpdateUserActiveReturns) error {
+	if appErr := s.checkAPIPermission("UpdateUserActive"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateUserActive(userID string, active bool) *model.AppError
 	}); ok {
@@ -2485,6 +3238,10 @@ func (g *apiRPCClient) UpdateUserCustomStatus(userID string, customStatus *model
 }
 
 func (s *apiRPCServer) UpdateUserCustomStatus(args *Z_UpdateUserCustomStatusArgs, returns *Z_UpdateUserCustomStatusReturns) error {
+	if appErr := s.checkAPIPermission("UpdateUserCustomStatus"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateUserCustomStatus(userID string, customStatus *model.CustomStatus) *model.AppError
 	}); ok {
@@ -2513,6 +3270,10 @@ func (g *apiRPCClient) RemoveUserCustomStatus(userID string) *model.AppError {
 }
 
 func (s *apiRPCServer) RemoveUserCustomStatus(args *Z_RemoveUserCustomStatusArgs, returns *Z_RemoveUserCustomStatusReturns) error {
+	if appErr := s.checkAPIPermission("RemoveUserCustomStatus"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RemoveUserCustomStatus(userID string) *model.AppError
 	}); ok {
@@ -2545,6 +3306,10 @@ func (g *apiRPCClient) GetUsersInChannel(channelID, sortBy string, page, perPage
 }
 
 func (s *apiRPCServer) GetUsersInChannel(args *Z_GetUsersInChannelArgs, returns *Z_GetUsersInChannelReturns) error {
+	if appErr := s.checkAPIPermission("GetUsersInChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUsersInChannel(channelID, sortBy string, page, perPage int) ([]*model.User, *model.AppError)
 	}); ok {
@@ -2575,6 +3340,10 @@ func (g *apiRPCClient) GetLDAPUserAttributes(userID string, attributes []string)
 }
 
 func (s *apiRPCServer) GetLDAPUserAttributes(args *Z_GetLDAPUserAttributesArgs, returns *Z_GetLDAPUserAttributesReturns) error {
+	if appErr := s.checkAPIPermission("GetLDAPUserAttributes"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetLDAPUserAttributes(userID string, attributes []string) (map[string]string, *model.AppError)
 	}); ok {
@@ -2604,6 +3373,10 @@ func (g *apiRPCClient) CreateTeam(team *model.Team) (*model.Team, *model.AppErro
 }
 
 func (s *apiRPCServer) CreateTeam(args *Z_CreateTeamArgs, returns *Z_CreateTeamReturns) error {
+	if appErr := s.checkAPIPermission("CreateTeam"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateTeam(team *model.Team) (*model.Team, *model.AppError)
 	}); ok {
@@ -2632,6 +3405,10 @@ func (g *apiRPCClient) DeleteTeam(teamID string) *model.AppError {
 }
 
 func (s *apiRPCServer) DeleteTeam(args *Z_DeleteTeamArgs, returns *Z_DeleteTeamReturns) error {
+	if appErr := s.checkAPIPermission("DeleteTeam"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteTeam(teamID string) *model.AppError
 	}); ok {
@@ -2660,6 +3437,10 @@ func (g *apiRPCClient) GetTeams() ([]*model.Team, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetTeams(args *Z_GetTeamsArgs, returns *Z_GetTeamsReturns) error {
+	if appErr := s.checkAPIPermission("GetTeams"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeams() ([]*model.Team, *model.AppError)
 	}); ok {
@@ -2689,6 +3470,10 @@ func (g *apiRPCClient) GetTeam(teamID string) (*model.Team, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetTeam(args *Z_GetTeamArgs, returns *Z_GetTeamReturns) error {
+	if appErr := s.checkAPIPermission("GetTeam"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeam(teamID string) (*model.Team, *model.AppError)
 	}); ok {
@@ -2718,6 +3503,10 @@ func (g *apiRPCClient) GetTeamByName(name string) (*model.Team, *model.AppError)
 }
 
 func (s *apiRPCServer) GetTeamByName(args *Z_GetTeamByNameArgs, returns *Z_GetTeamByNameReturns) error {
+	if appErr := s.checkAPIPermission("GetTeamByName"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamByName(name string) (*model.Team, *model.AppError)
 	}); ok {
@@ -2747,6 +3536,10 @@ func (g *apiRPCClient) GetTeamsUnreadForUser(userID string) ([]*model.TeamUnread
 }
 
 func (s *apiRPCServer) GetTeamsUnreadForUser(args *Z_GetTeamsUnreadForUserArgs, returns *Z_GetTeamsUnreadForUserReturns) error {
+	if appErr := s.checkAPIPermission("GetTeamsUnreadForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamsUnreadForUser(userID string) ([]*model.TeamUnread, *model.AppError)
 	}); ok {
@@ -2776,6 +3569,10 @@ func (g *apiRPCClient) UpdateTeam(team *model.Team) (*model.Team, *model.AppErro
 }
 
 func (s *apiRPCServer) UpdateTeam(args *Z_UpdateTeamArgs, returns *Z_UpdateTeamReturns) error {
+	if appErr := s.checkAPIPermission("UpdateTeam"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateTeam(team *model.Team) (*model.Team, *model.AppError)
 	}); ok {
@@ -2805,6 +3602,10 @@ func (g *apiRPCClient) SearchTeams(term string) ([]*model.Team, *model.AppError)
 }
 
 func (s *apiRPCServer) SearchTeams(args *Z_SearchTeamsArgs, returns *Z_SearchTeamsReturns) error {
+	if appErr := s.checkAPIPermission("SearchTeams"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SearchTeams(term string) ([]*model.Team, *model.AppError)
 	}); ok {
@@ -2834,6 +3635,10 @@ func (g *apiRPCClient) GetTeamsForUser(userID string) ([]*model.Team, *model.App
 }
 
 func (s *apiRPCServer) GetTeamsForUser(args *Z_GetTeamsForUserArgs, returns *Z_GetTeamsForUserReturns) error {
+	if appErr := s.checkAPIPermission("GetTeamsForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamsForUser(userID string) ([]*model.Team, *model.AppError)
 	}); ok {
@@ -2864,6 +3669,10 @@ func (g *apiRPCClient) CreateTeamMember(teamID, userID string) (*model.TeamMembe
 }
 
 func (s *apiRPCServer) CreateTeamMember(args *Z_CreateTeamMemberArgs, returns *Z_CreateTeamMemberReturns) error {
+	if appErr := s.checkAPIPermission("CreateTeamMember"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateTeamMember(teamID, userID string) (*model.TeamMember, *model.AppError)
 	}); ok {
@@ -2895,6 +3704,10 @@ func (g *apiRPCClient) CreateTeamMembers(teamID string, userIds []string, reques
 }
 
 func (s *apiRPCServer) CreateTeamMembers(args *Z_CreateTeamMembersArgs, returns *Z_CreateTeamMembersReturns) error {
+	if appErr := s.checkAPIPermission("CreateTeamMembers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateTeamMembers(teamID string, userIds []string, requestorId string) ([]*model.TeamMember, *model.AppError)
 	}); ok {
@@ -2926,6 +3739,10 @@ func (g *apiRPCClient) CreateTeamMembersGracefully(teamID string, userIds []stri
 }
 
 func (s *apiRPCServer) CreateTeamMembersGracefully(args *Z_CreateTeamMembersGracefullyArgs, returns *Z_CreateTeamMembersGracefullyReturns) error {
+	if appErr := s.checkAPIPermission("CreateTeamMembersGracefully"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateTeamMembersGracefully(teamID string, userIds []string, requestorId string) ([]*model.TeamMemberWithError, *model.AppError)
 	}); ok {
@@ -2956,6 +3773,10 @@ func (g *apiRPCClient) DeleteTeamMember(teamID, userID, requestorId string) *mod
 }
 
 func (s *apiRPCServer) DeleteTeamMember(args *Z_DeleteTeamMemberArgs, returns *Z_DeleteTeamMemberReturns) error {
+	if appErr := s.checkAPIPermission("DeleteTeamMember"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteTeamMember(teamID, userID, requestorId string) *model.AppError
 	}); ok {
@@ -2987,6 +3808,10 @@ func (g *apiRPCClient) GetTeamMembers(teamID string, page, perPage int) ([]*mode
 }
 
 func (s *apiRPCServer) GetTeamMembers(args *Z_GetTeamMembersArgs, returns *Z_GetTeamMembersReturns) error {
+	if appErr := s.checkAPIPermission("GetTeamMembers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamMembers(teamID string, page, perPage int) ([]*model.TeamMember, *model.AppError)
 	}); ok {
@@ -3017,6 +3842,10 @@ func (g *apiRPCClient) GetTeamMember(teamID, userID string) (*model.TeamMember,
 }
 
 func (s *apiRPCServer) GetTeamMember(args *Z_GetTeamMemberArgs, returns *Z_GetTeamMemberReturns) error {
+	if appErr := s.checkAPIPermission("GetTeamMember"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamMember(teamID, userID string) (*model.TeamMember, *model.AppError)
 	}); ok {
@@ -3048,6 +3877,10 @@ func (g *apiRPCClient) GetTeamMembersForUser(userID string, page int, perPage in
 }
 
 func (s *apiRPCServer) GetTeamMembersForUser(args *Z_GetTeamMembersForUserArgs, returns *Z_GetTeamMembersForUserReturns) error {
+	if appErr := s.checkAPIPermission("GetTeamMembersForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamMembersForUser(userID string, page int, perPage int) ([]*model.TeamMember, *model.AppError)
 	}); ok {
@@ -3079,6 +3912,10 @@ func (g *apiRPCClient) UpdateTeamMemberRoles(teamID, userID, newRoles string) (*
 }
 
 func (s *apiRPCServer) UpdateTeamMemberRoles(args *Z_UpdateTeamMemberRolesArgs, returns *Z_UpdateTeamMemberRolesReturns) error {
+	if appErr := s.checkAPIPermission("UpdateTeamMemberRoles"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateTeamMemberRoles(teamID, userID, newRoles string) (*model.TeamMember, *model.AppError)
 	}); ok {
@@ -3108,6 +3945,10 @@ func (g *apiRPCClient) CreateChannel(channel *model.Channel) (*model.Channel, *m
 }
 
 func (s *apiRPCServer) CreateChannel(args *Z_CreateChannelArgs, returns *Z_CreateChannelReturns) error {
+	if appErr := s.checkAPIPermission("CreateChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateChannel(channel *model.Channel) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3136,6 +3977,10 @@ func (g *apiRPCClient) DeleteChannel(channelId string) *model.AppError {
 }
 
 func (s *apiRPCServer) DeleteChannel(args *Z_DeleteChannelArgs, returns *Z_DeleteChannelReturns) error {
+	if appErr := s.checkAPIPermission("DeleteChannel"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteChannel(channelId string) *model.AppError
 	}); ok {
@@ -3167,6 +4012,10 @@ func (g *apiRPCClient) GetPublicChannelsForTeam(teamID string, page, perPage int
 }
 
 func (s *apiRPCServer) GetPublicChannelsForTeam(args *Z_GetPublicChannelsForTeamArgs, returns *Z_GetPublicChannelsForTeamReturns) error {
+	if appErr := s.checkAPIPermission("GetPublicChannelsForTeam"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPublicChannelsForTeam(teamID string, page, perPage int) ([]*model.Channel, *model.AppError)
 	}); ok {
@@ -3196,6 +4045,10 @@ func (g *apiRPCClient) GetChannel(channelId string) (*model.Channel, *model.AppE
 }
 
 func (s *apiRPCServer) GetChannel(args *Z_GetChannelArgs, returns *Z_GetChannelReturns) error {
+	if appErr := s.checkAPIPermission("GetChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannel(channelId string) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3227,6 +4080,10 @@ func (g *apiRPCClient) GetChannelByName(teamID, name string, includeDeleted bool
 }
 
 func (s *apiRPCServer) GetChannelByName(args *Z_GetChannelByNameArgs, returns *Z_GetChannelByNameReturns) error {
+	if appErr := s.checkAPIPermission("GetChannelByName"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelByName(teamID, name string, includeDeleted bool) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3258,6 +4115,10 @@ func (g *apiRPCClient) GetChannelByNameForTeamName(teamName, channelName string,
 }
 
 func (s *apiRPCServer) GetChannelByNameForTeamName(args *Z_GetChannelByNameForTeamNameArgs, returns *Z_GetChannelByNameForTeamNameReturns) error {
+	if appErr := s.checkAPIPermission("GetChannelByNameForTeamName"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelByNameForTeamName(teamName, channelName string, includeDeleted bool) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3289,6 +4150,10 @@ func (g *apiRPCClient) GetChannelsForTeamForUser(teamID, userID string, includeD
 }
 
 func (s *apiRPCServer) GetChannelsForTeamForUser(args *Z_GetChannelsForTeamForUserArgs, returns *Z_GetChannelsForTeamForUserReturns) error {
+	if appErr := s.checkAPIPermission("GetChannelsForTeamForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelsForTeamForUser(teamID, userID string, includeDeleted bool) ([]*model.Channel, *model.AppError)
 	}); ok {
@@ -3318,6 +4183,10 @@ func (g *apiRPCClient) GetChannelStats(channelId string) (*model.ChannelStats, *
 }
 
 func (s *apiRPCServer) GetChannelStats(args *Z_GetChannelStatsArgs, returns *Z_GetChannelStatsReturns) error {
+	if appErr := s.checkAPIPermission("GetChannelStats"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelStats(channelId string) (*model.ChannelStats, *model.AppError)
 	}); ok {
@@ -3348,6 +4217,10 @@ func (g *apiRPCClient) GetDirectChannel(userId1, userId2 string) (*model.Channel
 }
 
 func (s *apiRPCServer) GetDirectChannel(args *Z_GetDirectChannelArgs, returns *Z_GetDirectChannelReturns) error {
+	if appErr := s.checkAPIPermission("GetDirectChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetDirectChannel(userId1, userId2 string) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3377,6 +4250,10 @@ func (g *apiRPCClient) GetGroupChannel(userIds []string) (*model.Channel, *model
 }
 
 func (s *apiRPCServer) GetGroupChannel(args *Z_GetGroupChannelArgs, returns *Z_GetGroupChannelReturns) error {
+	if appErr := s.checkAPIPermission("GetGroupChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupChannel(userIds []string) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3406,6 +4283,10 @@ func (g *apiRPCClient) UpdateChannel(channel *model.Channel) (*model.Channel, *m
 }
 
 func (s *apiRPCServer) UpdateChannel(args *Z_UpdateChannelArgs, returns *Z_UpdateChannelReturns) error {
+	if appErr := s.checkAPIPermission("UpdateChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateChannel(channel *model.Channel) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3436,6 +4317,10 @@ func (g *apiRPCClient) SearchChannels(teamID string, term string) ([]*model.Chan
 }
 
 func (s *apiRPCServer) SearchChannels(args *Z_SearchChannelsArgs, returns *Z_SearchChannelsReturns) error {
+	if appErr := s.checkAPIPermission("SearchChannels"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SearchChannels(teamID string, term string) ([]*model.Channel, *model.AppError)
 	}); ok {
@@ -3467,6 +4352,10 @@ func (g *apiRPCClient) CreateChannelSidebarCategory(userID, teamID string, newCa
 }
 
 func (s *apiRPCServer) CreateChannelSidebarCategory(args *Z_CreateChannelSidebarCategoryArgs, returns *Z_CreateChannelSidebarCategoryReturns) error {
+	if appErr := s.checkAPIPermission("CreateChannelSidebarCategory"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateChannelSidebarCategory(userID, teamID string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.AppError)
 	}); ok {
@@ -3497,6 +4386,10 @@ func (g *apiRPCClient) GetChannelSidebarCategories(userID, teamID string) (*mode
 }
 
 func (s *apiRPCServer) GetChannelSidebarCategories(args *Z_GetChannelSidebarCategoriesArgs, returns *Z_GetChannelSidebarCategoriesReturns) error {
+	if appErr := s.checkAPIPermission("GetChannelSidebarCategories"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelSidebarCategories(userID, teamID string) (*model.OrderedSidebarCategories, *model.AppError)
 	}); ok {
@@ -3528,6 +4421,10 @@ func (g *apiRPCClient) UpdateChannelSidebarCategories(userID, teamID string, cat
 }
 
 func (s *apiRPCServer) UpdateChannelSidebarCategories(args *Z_UpdateChannelSidebarCategoriesArgs, returns *Z_UpdateChannelSidebarCategoriesReturns) error {
+	if appErr := s.checkAPIPermission("UpdateChannelSidebarCategories"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateChannelSidebarCategories(userID, teamID string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, *model.AppError)
 	}); ok {
@@ -3557,6 +4454,10 @@ func (g *apiRPCClient) SearchUsers(search *model.UserSearch) ([]*model.User, *mo
 }
 
 func (s *apiRPCServer) SearchUsers(args *Z_SearchUsersArgs, returns *Z_SearchUsersReturns) error {
+	if appErr := s.checkAPIPermission("SearchUsers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SearchUsers(search *model.UserSearch) ([]*model.User, *model.AppError)
 	}); ok {
@@ -3587,6 +4488,10 @@ func (g *apiRPCClient) SearchPostsInTeam(teamID string, paramsList []*model.Sear
 }
 
 func (s *apiRPCServer) SearchPostsInTeam(args *Z_SearchPostsInTeamArgs, returns *Z_SearchPostsInTeamReturns) error {
+	if appErr := s.checkAPIPermission("SearchPostsInTeam"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SearchPostsInTeam(teamID string, paramsList []*model.SearchParams) ([]*model.Post, *model.AppError)
 	}); ok {
@@ -3618,6 +4523,10 @@ func (g *apiRPCClient) SearchPostsInTeamForUser(teamID string, userID string, se
 }
 
 func (s *apiRPCServer) SearchPostsInTeamForUser(args *Z_SearchPostsInTeamForUserArgs, returns *Z_SearchPostsInTeamForUserReturns) error {
+	if appErr := s.checkAPIPermission("SearchPostsInTeamForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SearchPostsInTeamForUser(teamID string, userID string, searchParams model.SearchParameter) (*model.PostSearchResults, *model.AppError)
 	}); ok {
@@ -3648,6 +4557,10 @@ func (g *apiRPCClient) AddChannelMember(channelId, userID string) (*model.Channe
 }
 
 func (s *apiRPCServer) AddChannelMember(args *Z_AddChannelMemberArgs, returns *Z_AddChannelMemberReturns) error {
+	if appErr := s.checkAPIPermission("AddChannelMember"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		AddChannelMember(channelId, userID string) (*model.ChannelMember, *model.AppError)
 	}); ok {
@@ -3679,6 +4592,10 @@ func (g *apiRPCClient) AddUserToChannel(channelId, userID, asUserId string) (*mo
 }
 
 func (s *apiRPCServer) AddUserToChannel(args *Z_AddUserToChannelArgs, returns *Z_AddUserToChannelReturns) error {
+	if appErr := s.checkAPIPermission("AddUserToChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		AddUserToChannel(channelId, userID, asUserId string) (*model.ChannelMember, *model.AppError)
 	}); ok {
@@ -3709,6 +4626,10 @@ func (g *apiRPCClient) GetChannelMember(channelId, userID string) (*model.Channe
 }
 
 func (s *apiRPCServer) GetChannelMember(args *Z_GetChannelMemberArgs, returns *Z_GetChannelMemberReturns) error {
+	if appErr := s.checkAPIPermission("GetChannelMember"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelMember(channelId, userID string) (*model.ChannelMember, *model.AppError)
 	}); ok {
@@ -3740,6 +4661,10 @@ func (g *apiRPCClient) GetChannelMembers(channelId string, page, perPage int) (m
 }
 
 func (s *apiRPCServer) GetChannelMembers(args *Z_GetChannelMembersArgs, returns *Z_GetChannelMembersReturns) error {
+	if appErr := s.checkAPIPermission("GetChannelMembers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelMembers(channelId string, page, perPage int) (model.ChannelMembers, *model.AppError)
 	}); ok {
@@ -3770,6 +4695,10 @@ func (g *apiRPCClient) GetChannelMembersByIds(channelId string, userIds []string
 }
 
 func (s *apiRPCServer) GetChannelMembersByIds(args *Z_GetChannelMembersByIdsArgs, returns *Z_GetChannelMembersByIdsReturns) error {
+	if appErr := s.checkAPIPermission("GetChannelMembersByIds"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelMembersByIds(channelId string, userIds []string) (model.ChannelMembers, *model.AppError)
 	}); ok {
@@ -3802,6 +4731,10 @@ func (g *apiRPCClient) GetChannelMembersForUser(teamID, userID string, page, per
 }
 
 func (s *apiRPCServer) GetChannelMembersForUser(args *Z_GetChannelMembersForUserArgs, returns *Z_GetChannelMembersForUserReturns) error {
+	if appErr := s.checkAPIPermission("GetChannelMembersForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelMembersForUser(teamID, userID string, page, perPage int) ([]*model.ChannelMember, *model.AppError)
 	}); ok {
@@ -3833,6 +4766,10 @@ func (g *apiRPCClient) UpdateChannelMemberRoles(channelId, userID, newRoles stri
 }
 
 func (s *apiRPCServer) UpdateChannelMemberRoles(args *Z_UpdateChannelMemberRolesArgs, returns *Z_UpdateChannelMemberRolesReturns) error {
+	if appErr := s.checkAPIPermission("UpdateChannelMemberRoles"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateChannelMemberRoles(channelId, userID, newRoles string) (*model.ChannelMember, *model.AppError)
 	}); ok {
@@ -3864,6 +4801,10 @@ func (g *apiRPCClient) UpdateChannelMemberNotifications(channelId, userID string
 }
 
 func (s *apiRPCServer) UpdateChannelMemberNotifications(args *Z_UpdateChannelMemberNotificationsArgs, returns *Z_UpdateChannelMemberNotificationsReturns) error {
+	if appErr := s.checkAPIPermission("UpdateChannelMemberNotifications"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateChannelMemberNotifications(channelId, userID string, notifications map[string]string) (*model.ChannelMember, *model.AppError)
 	}); ok {
@@ -3893,6 +4834,10 @@ func (g *apiRPCClient) PatchChannelMembersNotifications(members []*model.Channel
 }
 
 func (s *apiRPCServer) PatchChannelMembersNotifications(args *Z_PatchChannelMembersNotificationsArgs, returns *Z_PatchChannelMembersNotificationsReturns) error {
+	if appErr := s.checkAPIPermission("PatchChannelMembersNotifications"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		PatchChannelMembersNotifications(members []*model.ChannelMemberIdentifier, notifyProps map[string]string) *model.AppError
 	}); ok {
@@ -3922,6 +4867,10 @@ func (g *apiRPCClient) GetGroup(groupId string) (*model.Group, *model.AppError)
 }
 
 func (s *apiRPCServer) GetGroup(args *Z_GetGroupArgs, returns *Z_GetGroupReturns) error {
+	if appErr := s.checkAPIPermission("GetGroup"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroup(groupId string) (*model.Group, *model.AppError)
 	}); ok {
@@ -3951,6 +4900,10 @@ func (g *apiRPCClient) GetGroupByName(name string) (*model.Group, *model.AppErro
 }
 
 func (s *apiRPCServer) GetGroupByName(args *Z_GetGroupByNameArgs, returns *Z_GetGroupByNameReturns) error {
+	if appErr := s.checkAPIPermission("GetGroupByName"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupByName(name string) (*model.Group, *model.AppError)
 	}); ok {
@@ -3982,6 +4935,10 @@ func (g *apiRPCClient) GetGroupMemberUsers(groupID string, page, perPage int) ([
 }
 
 func (s *apiRPCServer) GetGroupMemberUsers(args *Z_GetGroupMemberUsersArgs, returns *Z_GetGroupMemberUsersReturns) error {
+	if appErr := s.checkAPIPermission("GetGroupMemberUsers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupMemberUsers(groupID string, page, perPage int) ([]*model.User, *model.AppError)
 	}); ok {
@@ -4011,6 +4968,10 @@ func (g *apiRPCClient) GetGroupsBySource(groupSource model.GroupSource) ([]*mode
 }
 
 func (s *apiRPCServer) GetGroupsBySource(args *Z_GetGroupsBySourceArgs, returns *Z_GetGroupsBySourceReturns) error {
+	if appErr := s.checkAPIPermission("GetGroupsBySource"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupsBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError)
 	}); ok {
@@ -4040,6 +5001,10 @@ func (g *apiRPCClient) GetGroupsForUser(userID string) ([]*model.Group, *model.A
 }
 
 func (s *apiRPCServer) GetGroupsForUser(args *Z_GetGroupsForUserArgs, returns *Z_GetGroupsForUserReturns) error {
+	if appErr := s.checkAPIPermission("GetGroupsForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupsForUser(userID string) ([]*model.Group, *model.AppError)
 	}); ok {
@@ -4069,6 +5034,10 @@ func (g *apiRPCClient) DeleteChannelMember(channelId, userID string) *model.AppE
 }
 
 func (s *apiRPCServer) DeleteChannelMember(args *Z_DeleteChannelMemberArgs, returns *Z_DeleteChannelMemberReturns) error {
+	if appErr := s.checkAPIPermission("DeleteChannelMember"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteChannelMember(channelId, userID string) *model.AppError
 	}); ok {
@@ -4098,6 +5067,10 @@ func (g *apiRPCClient) CreatePost(post *model.Post) (*model.Post, *model.AppErro
 }
 
 func (s *apiRPCServer) CreatePost(args *Z_CreatePostArgs, returns *Z_CreatePostReturns) error {
+	if appErr := s.checkAPIPermission("CreatePost"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreatePost(post *model.Post) (*model.Post, *model.AppError)
 	}); ok {
@@ -4127,6 +5100,10 @@ func (g *apiRPCClient) AddReaction(reaction *model.Reaction) (*model.Reaction, *
 }
 
 func (s *apiRPCServer) AddReaction(args *Z_AddReactionArgs, returns *Z_AddReactionReturns) error {
+	if appErr := s.checkAPIPermission("AddReaction"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		AddReaction(reaction *model.Reaction) (*model.Reaction, *model.AppError)
 	}); ok {
@@ -4155,6 +5132,10 @@ func (g *apiRPCClient) RemoveReaction(reaction *model.Reaction) *model.AppError
 }
 
 func (s *apiRPCServer) RemoveReaction(args *Z_RemoveReactionArgs, returns *Z_RemoveReactionReturns) error {
+	if appErr := s.checkAPIPermission("RemoveReaction"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RemoveReaction(reaction *model.Reaction) *model.AppError
 	}); ok {
@@ -4184,6 +5165,10 @@ func (g *apiRPCClient) GetReactions(postId string) ([]*model.Reaction, *model.Ap
 }
 
 func (s *apiRPCServer) GetReactions(args *Z_GetReactionsArgs, returns *Z_GetReactionsReturns) error {
+	if appErr := s.checkAPIPermission("GetReactions"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetReactions(postId string) ([]*model.Reaction, *model.AppError)
 	}); ok {
@@ -4213,6 +5198,9 @@ func (g *apiRPCClient) SendEphemeralPost(userID string, post *model.Post) *model
 }
 
 func (s *apiRPCServer) SendEphemeralPost(args *Z_SendEphemeralPostArgs, returns *Z_SendEphemeralPostReturns) error {
+	if appErr := s.checkAPIPermission("SendEphemeralPost"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		SendEphemeralPost(userID string, post *model.Post) *model.Post
 	}); ok {
@@ -4242,6 +5230,9 @@ func (g *apiRPCClient) UpdateEphemeralPost(userID string, post *model.Post) *mod
 }
 
 func (s *apiRPCServer) UpdateEphemeralPost(args *Z_UpdateEphemeralPostArgs, returns *Z_UpdateEphemeralPostReturns) error {
+	if appErr := s.checkAPIPermission("UpdateEphemeralPost"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateEphemeralPost(userID string, post *model.Post) *model.Post
 	}); ok {
@@ -4270,6 +5261,9 @@ func (g *apiRPCClient) DeleteEphemeralPost(userID, postId string) {
 }
 
 func (s *apiRPCServer) DeleteEphemeralPost(args *Z_DeleteEphemeralPostArgs, returns *Z_DeleteEphemeralPostReturns) error {
+	if appErr := s.checkAPIPermission("DeleteEphemeralPost"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteEphemeralPost(userID, postId string)
 	}); ok {
@@ -4298,6 +5292,10 @@ func (g *apiRPCClient) DeletePost(postId string) *model.AppError {
 }
 
 func (s *apiRPCServer) DeletePost(args *Z_DeletePostArgs, returns *Z_DeletePostReturns) error {
+	if appErr := s.checkAPIPermission("DeletePost"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeletePost(postId string) *model.AppError
 	}); ok {
@@ -4327,6 +5325,10 @@ func (g *apiRPCClient) GetPostThread(postId string) (*model.PostList, *model.App
 }
 
 func (s *apiRPCServer) GetPostThread(args *Z_GetPostThreadArgs, returns *Z_GetPostThreadReturns) error {
+	if appErr := s.checkAPIPermission("GetPostThread"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPostThread(postId string) (*model.PostList, *model.AppError)
 	}); ok {
@@ -4356,6 +5358,10 @@ func (g *apiRPCClient) GetPost(postId string) (*model.Post, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetPost(args *Z_GetPostArgs, returns *Z_GetPostReturns) error {
+	if appErr := s.checkAPIPermission("GetPost"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPost(postId string) (*model.Post, *model.AppError)
 	}); ok {
@@ -4386,6 +5392,10 @@ func (g *apiRPCClient) GetPostsSince(channelId string, time int64) (*model.PostL
 }
 
 func (s *apiRPCServer) GetPostsSince(args *Z_GetPostsSinceArgs, returns *Z_GetPostsSinceReturns) error {
+	if appErr := s.checkAPIPermission("GetPostsSince"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPostsSince(channelId string, time int64) (*model.PostList, *model.AppError)
 	}); ok {
@@ -4418,6 +5428,10 @@ func (g *apiRPCClient) GetPostsAfter(channelId, postId string, page, perPage int
 }
 
 func (s *apiRPCServer) GetPostsAfter(args *Z_GetPostsAfterArgs, returns *Z_GetPostsAfterReturns) error {
+	if appErr := s.checkAPIPermission("GetPostsAfter"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPostsAfter(channelId, postId string, page, perPage int) (*model.PostList, *model.AppError)
 	}); ok {
@@ -4450,6 +5464,10 @@ func (g *apiRPCClient) GetPostsBefore(channelId, postId string, page, perPage in
 }
 
 func (s *apiRPCServer) GetPostsBefore(args *Z_GetPostsBeforeArgs, returns *Z_GetPostsBeforeReturns) error {
+	if appErr := s.checkAPIPermission("GetPostsBefore"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPostsBefore(channelId, postId string, page, perPage int) (*model.PostList, *model.AppError)
 	}); ok {
@@ -4481,6 +5499,10 @@ func (g *apiRPCClient) GetPostsForChannel(channelId string, page, perPage int) (
 }
 
 func (s *apiRPCServer) GetPostsForChannel(args *Z_GetPostsForChannelArgs, returns *Z_GetPostsForChannelReturns) error {
+	if appErr := s.checkAPIPermission("GetPostsForChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPostsForChannel(channelId string, page, perPage int) (*model.PostList, *model.AppError)
 	}); ok {
@@ -4510,6 +5532,10 @@ func (g *apiRPCClient) GetTeamStats(teamID string) (*model.TeamStats, *model.App
 }
 
 func (s *apiRPCServer) GetTeamStats(args *Z_GetTeamStatsArgs, returns *Z_GetTeamStatsReturns) error {
+	if appErr := s.checkAPIPermission("GetTeamStats"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamStats(teamID string) (*model.TeamStats, *model.AppError)
 	}); ok {
@@ -4539,6 +5565,10 @@ func (g *apiRPCClient) UpdatePost(post *model.Post) (*model.Post, *model.AppErro
 }
 
 func (s *apiRPCServer) UpdatePost(args *Z_UpdatePostArgs, returns *Z_UpdatePostReturns) error {
+	if appErr := s.checkAPIPermission("UpdatePost"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdatePost(post *model.Post) (*model.Post, *model.AppError)
 	}); ok {
@@ -4568,6 +5598,10 @@ func (g *apiRPCClient) GetProfileImage(userID string) ([]byte, *model.AppError)
 }
 
 func (s *apiRPCServer) GetProfileImage(args *Z_GetProfileImageArgs, returns *Z_GetProfileImageReturns) error {
+	if appErr := s.checkAPIPermission("GetProfileImage"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetProfileImage(userID string) ([]byte, *model.AppError)
 	}); ok {
@@ -4597,6 +5631,10 @@ func (g *apiRPCClient) SetProfileImage(userID string, data []byte) *model.AppErr
 }
 
 func (s *apiRPCServer) SetProfileImage(args *Z_SetProfileImageArgs, returns *Z_SetProfileImageReturns) error {
+	if appErr := s.checkAPIPermission("SetProfileImage"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SetProfileImage(userID string, data []byte) *model.AppError
 	}); ok {
@@ -4628,6 +5666,10 @@ func (g *apiRPCClient) GetEmojiList(sortBy string, page, perPage int) ([]*model.
 }
 
 func (s *apiRPCServer) GetEmojiList(args *Z_GetEmojiListArgs, returns *Z_GetEmojiListReturns) error {
+	if appErr := s.checkAPIPermission("GetEmojiList"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetEmojiList(sortBy string, page, perPage int) ([]*model.Emoji, *model.AppError)
 	}); ok {
@@ -4657,6 +5699,10 @@ func (g *apiRPCClient) GetEmojiByName(name string) (*model.Emoji, *model.AppErro
 }
 
 func (s *apiRPCServer) GetEmojiByName(args *Z_GetEmojiByNameArgs, returns *Z_GetEmojiByNameReturns) error {
+	if appErr := s.checkAPIPermission("GetEmojiByName"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetEmojiByName(name string) (*model.Emoji, *model.AppError)
 	}); ok {
@@ -4686,6 +5732,10 @@ func (g *apiRPCClient) GetEmoji(emojiId string) (*model.Emoji, *model.AppError)
 }
 
 func (s *apiRPCServer) GetEmoji(args *Z_GetEmojiArgs, returns *Z_GetEmojiReturns) error {
+	if appErr := s.checkAPIPermission("GetEmoji"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetEmoji(emojiId string) (*model.Emoji, *model.AppError)
 	}); ok {
@@ -4716,6 +5766,10 @@ func (g *apiRPCClient) CopyFileInfos(userID string, fileIds []string) ([]string,
 }
 
 func (s *apiRPCServer) CopyFileInfos(args *Z_CopyFileInfosArgs, returns *Z_CopyFileInfosReturns) error {
+	if appErr := s.checkAPIPermission("CopyFileInfos"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CopyFileInfos(userID string, fileIds []string) ([]string, *model.AppError)
 	}); ok {
@@ -4745,6 +5799,10 @@ func (g *apiRPCClient) GetFileInfo(fileId string) (*model.FileInfo, *model.AppEr
 }
 
 func (s *apiRPCServer) GetFileInfo(args *Z_GetFileInfoArgs, returns *Z_GetFileInfoReturns) error {
+	if appErr := s.checkAPIPermission("GetFileInfo"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetFileInfo(fileId string) (*model.FileInfo, *model.AppError)
 	}); ok {
@@ -4774,6 +5832,10 @@ func (g *apiRPCClient) SetFileSearchableContent(fileID string, content string) *
 }
 
 func (s *apiRPCServer) SetFileSearchableContent(args *Z_SetFileSearchableContentArgs, returns *Z_SetFileSearchableContentReturns) error {
+	if appErr := s.checkAPIPermission("SetFileSearchableContent"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SetFileSearchableContent(fileID string, content string) *model.AppError
 	}); ok {
@@ -4805,6 +5867,10 @@ func (g *apiRPCClient) GetFileInfos(page, perPage int, opt *model.GetFileInfosOp
 }
 
 func (s *apiRPCServer) GetFileInfos(args *Z_GetFileInfosArgs, returns *Z_GetFileInfosReturns) error {
+	if appErr := s.checkAPIPermission("GetFileInfos"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetFileInfos(page, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, *model.AppError)
 	}); ok {
@@ -4834,6 +5900,10 @@ func (g *apiRPCClient) GetFile(fileId string) ([]byte, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetFile(args *Z_GetFileArgs, returns *Z_GetFileReturns) error {
+	if appErr := s.checkAPIPermission("GetFile"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetFile(fileId string) ([]byte, *model.AppError)
 	}); ok {
@@ -4863,6 +5933,10 @@ func (g *apiRPCClient) GetFileLink(fileId string) (string, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetFileLink(args *Z_GetFileLinkArgs, returns *Z_GetFileLinkReturns) error {
+	if appErr := s.checkAPIPermission("GetFileLink"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetFileLink(fileId string) (string, *model.AppError)
 	}); ok {
@@ -4892,6 +5966,10 @@ func (g *apiRPCClient) ReadFile(path string) ([]byte, *model.AppError) {
 }
 
 func (s *apiRPCServer) ReadFile(args *Z_ReadFileArgs, returns *Z_ReadFileReturns) error {
+	if appErr := s.checkAPIPermission("ReadFile"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ReadFile(path string) ([]byte, *model.AppError)
 	}); ok {
@@ -4922,6 +6000,10 @@ func (g *apiRPCClient) GetEmojiImage(emojiId string) ([]byte, string, *model.App
 }
 
 func (s *apiRPCServer) GetEmojiImage(args *Z_GetEmojiImageArgs, returns *Z_GetEmojiImageReturns) error {
+	if appErr := s.checkAPIPermission("GetEmojiImage"); appErr != nil {
+		returns.C = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetEmojiImage(emojiId string) ([]byte, string, *model.AppError)
 	}); ok {
@@ -4953,6 +6035,10 @@ func (g *apiRPCClient) UploadFile(data []byte, channelId string, filename string
 }
 
 func (s *apiRPCServer) UploadFile(args *Z_UploadFileArgs, returns *Z_UploadFileReturns) error {
+	if appErr := s.checkAPIPermission("UploadFile"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UploadFile(data []byte, channelId string, filename string) (*model.FileInfo, *model.AppError)
 	}); ok {
@@ -4981,6 +6067,10 @@ func (g *apiRPCClient) OpenInteractiveDialog(dialog model.OpenDialogRequest) *mo
 }
 
 func (s *apiRPCServer) OpenInteractiveDialog(args *Z_OpenInteractiveDialogArgs, returns *Z_OpenInteractiveDialogReturns) error {
+	if appErr := s.checkAPIPermission("OpenInteractiveDialog"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		OpenInteractiveDialog(dialog model.OpenDialogRequest) *model.AppError
 	}); ok {
@@ -5009,6 +6099,10 @@ func (g *apiRPCClient) GetPlugins() ([]*model.Manifest, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetPlugins(args *Z_GetPluginsArgs, returns *Z_GetPluginsReturns) error {
+	if appErr := s.checkAPIPermission("GetPlugins"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPlugins() ([]*model.Manifest, *model.AppError)
 	}); ok {
@@ -5037,6 +6131,10 @@ func (g *apiRPCClient) EnablePlugin(id string) *model.AppError {
 }
 
 func (s *apiRPCServer) EnablePlugin(args *Z_EnablePluginArgs, returns *Z_EnablePluginReturns) error {
+	if appErr := s.checkAPIPermission("EnablePlugin"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		EnablePlugin(id string) *model.AppError
 	}); ok {
@@ -5065,6 +6163,10 @@ func (g *apiRPCClient) DisablePlugin(id string) *model.AppError {
 }
 
 func (s *apiRPCServer) DisablePlugin(args *Z_DisablePluginArgs, returns *Z_DisablePluginReturns) error {
+	if appErr := s.checkAPIPermission("DisablePlugin"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DisablePlugin(id string) *model.AppError
 	}); ok {
@@ -5093,6 +6195,10 @@ func (g *apiRPCClient) RemovePlugin(id string) *model.AppError {
 }
 
 func (s *apiRPCServer) RemovePlugin(args *Z_RemovePluginArgs, returns *Z_RemovePluginReturns) error {
+	if appErr := s.checkAPIPermission("RemovePlugin"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RemovePlugin(id string) *model.AppError
 	}); ok {
@@ -5122,6 +6228,10 @@ func (g *apiRPCClient) GetPluginStatus(id string) (*model.PluginStatus, *model.A
 }
 
 func (s *apiRPCServer) GetPluginStatus(args *Z_GetPluginStatusArgs, returns *Z_GetPluginStatusReturns) error {
+	if appErr := s.checkAPIPermission("GetPluginStatus"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPluginStatus(id string) (*model.PluginStatus, *model.AppError)
 	}); ok {
@@ -5151,6 +6261,10 @@ func (g *apiRPCClient) KVSet(key string, value []byte) *model.AppError {
 }
 
 func (s *apiRPCServer) KVSet(args *Z_KVSetArgs, returns *Z_KVSetReturns) error {
+	if appErr := s.checkAPIPermission("KVSet"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVSet(key string, value []byte) *model.AppError
 	}); ok {
@@ -5182,6 +6296,10 @@ func (g *apiRPCClient) KVCompareAndSet(key string, oldValue, newValue []byte) (b
 }
 
 func (s *apiRPCServer) KVCompareAndSet(args *Z_KVCompareAndSetArgs, returns *Z_KVCompareAndSetReturns) error {
+	if appErr := s.checkAPIPermission("KVCompareAndSet"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVCompareAndSet(key string, oldValue, newValue []byte) (bool, *model.AppError)
 	}); ok {
@@ -5212,6 +6330,10 @@ func (g *apiRPCClient) KVCompareAndDelete(key string, oldValue []byte) (bool, *m
 }
 
 func (s *apiRPCServer) KVCompareAndDelete(args *Z_KVCompareAndDeleteArgs, returns *Z_KVCompareAndDeleteReturns) error {
+	if appErr := s.checkAPIPermission("KVCompareAndDelete"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVCompareAndDelete(key string, oldValue []byte) (bool, *model.AppError)
 	}); ok {
@@ -5243,6 +6365,10 @@ func (g *apiRPCClient) KVSetWithOptions(key string, value []byte, options model.
 }
 
 func (s *apiRPCServer) KVSetWithOptions(args *Z_KVSetWithOptionsArgs, returns *Z_KVSetWithOptionsReturns) error {
+	if appErr := s.checkAPIPermission("KVSetWithOptions"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVSetWithOptions(key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError)
 	}); ok {
@@ -5273,6 +6399,10 @@ func (g *apiRPCClient) KVSetWithExpiry(key string, value []byte, expireInSeconds
 }
 
 func (s *apiRPCServer) KVSetWithExpiry(args *Z_KVSetWithExpiryArgs, returns *Z_KVSetWithExpiryReturns) error {
+	if appErr := s.checkAPIPermission("KVSetWithExpiry"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVSetWithExpiry(key string, value []byte, expireInSeconds int64) *model.AppError
 	}); ok {
@@ -5302,6 +6432,10 @@ func (g *apiRPCClient) KVGet(key string) ([]byte, *model.AppError) {
 }
 
 func (s *apiRPCServer) KVGet(args *Z_KVGetArgs, returns *Z_KVGetReturns) error {
+	if appErr := s.checkAPIPermission("KVGet"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVGet(key string) ([]byte, *model.AppError)
 	}); ok {
@@ -5330,6 +6464,10 @@ func (g *apiRPCClient) KVDelete(key string) *model.AppError {
 }
 
 func (s *apiRPCServer) KVDelete(args *Z_KVDeleteArgs, returns *Z_KVDeleteReturns) error {
+	if appErr := s.checkAPIPermission("KVDelete"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVDelete(key string) *model.AppError
 	}); ok {
@@ -5357,6 +6495,10 @@ func (g *apiRPCClient) KVDeleteAll() *model.AppError {
 }
 
 func (s *apiRPCServer) KVDeleteAll(args *Z_KVDeleteAllArgs, returns *Z_KVDeleteAllReturns) error {
+	if appErr := s.checkAPIPermission("KVDeleteAll"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVDeleteAll() *model.AppError
 	}); ok {
@@ -5387,6 +6529,10 @@ func (g *apiRPCClient) KVList(page, perPage int) ([]string, *model.AppError) {
 }
 
 func (s *apiRPCServer) KVList(args *Z_KVListArgs, returns *Z_KVListReturns) error {
+	if appErr := s.checkAPIPermission("KVList"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVList(page, perPage int) ([]string, *model.AppError)
 	}); ok {
@@ -5416,6 +6562,9 @@ func (g *apiRPCClient) PublishWebSocketEvent(event string, payload map[string]an
 }
 
 func (s *apiRPCServer) PublishWebSocketEvent(args *Z_PublishWebSocketEventArgs, returns *Z_PublishWebSocketEventReturns) error {
+	if appErr := s.checkAPIPermission("PublishWebSocketEvent"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		PublishWebSocketEvent(event string, payload map[string]any, broadcast *model.WebsocketBroadcast)
 	}); ok {
@@ -5445,6 +6594,9 @@ func (g *apiRPCClient) HasPermissionTo(userID string, permission *model.Permissi
 }
 
 func (s *apiRPCServer) HasPermissionTo(args *Z_HasPermissionToArgs, returns *Z_HasPermissionToReturns) error {
+	if appErr := s.checkAPIPermission("HasPermissionTo"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		HasPermissionTo(userID string, permission *model.Permission) bool
 	}); ok {
@@ -5475,6 +6627,9 @@ func (g *apiRPCClient) HasPermissionToTeam(userID, teamID string, permission *mo
 }
 
 func (s *apiRPCServer) HasPermissionToTeam(args *Z_HasPermissionToTeamArgs, returns *Z_HasPermissionToTeamReturns) error {
+	if appErr := s.checkAPIPermission("HasPermissionToTeam"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		HasPermissionToTeam(userID, teamID string, permission *model.Permission) bool
 	}); ok {
@@ -5505,6 +6660,9 @@ func (g *apiRPCClient) HasPermissionToChannel(userID, channelId string, permissi
 }
 
 func (s *apiRPCServer) HasPermissionToChannel(args *Z_HasPermissionToChannelArgs, returns *Z_HasPermissionToChannelReturns) error {
+	if appErr := s.checkAPIPermission("HasPermissionToChannel"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		HasPermissionToChannel(userID, channelId string, permission *model.Permission) bool
 	}); ok {
@@ -5534,6 +6692,9 @@ func (g *apiRPCClient) RolesGrantPermission(roleNames []string, permissionId str
 }
 
 func (s *apiRPCServer) RolesGrantPermission(args *Z_RolesGrantPermissionArgs, returns *Z_RolesGrantPermissionReturns) error {
+	if appErr := s.checkAPIPermission("RolesGrantPermission"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		RolesGrantPermission(roleNames []string, permissionId string) bool
 	}); ok {
@@ -5564,6 +6725,10 @@ func (g *apiRPCClient) SendMail(to, subject, htmlBody string) *model.AppError {
 }
 
 func (s *apiRPCServer) SendMail(args *Z_SendMailArgs, returns *Z_SendMailReturns) error {
+	if appErr := s.checkAPIPermission("SendMail"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SendMail(to, subject, htmlBody string) *model.AppError
 	}); ok {
@@ -5593,6 +6758,10 @@ func (g *apiRPCClient) CreateBot(bot *model.Bot) (*model.Bot, *model.AppError) {
 }
 
 func (s *apiRPCServer) CreateBot(args *Z_CreateBotArgs, returns *Z_CreateBotReturns) error {
+	if appErr := s.checkAPIPermission("CreateBot"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateBot(bot *model.Bot) (*model.Bot, *model.AppError)
 	}); ok {
@@ -5623,6 +6792,10 @@ func (g *apiRPCClient) PatchBot(botUserId string, botPatch *model.BotPatch) (*mo
 }
 
 func (s *apiRPCServer) PatchBot(args *Z_PatchBotArgs, returns *Z_PatchBotReturns) error {
+	if appErr := s.checkAPIPermission("PatchBot"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		PatchBot(botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError)
 	}); ok {
@@ -5653,6 +6826,10 @@ func (g *apiRPCClient) GetBot(botUserId string, includeDeleted bool) (*model.Bot
 }
 
 func (s *apiRPCServer) GetBot(args *Z_GetBotArgs, returns *Z_GetBotReturns) error {
+	if appErr := s.checkAPIPermission("GetBot"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetBot(botUserId string, includeDeleted bool) (*model.Bot, *model.AppError)
 	}); ok {
@@ -5682,6 +6859,10 @@ func (g *apiRPCClient) GetBots(options *model.BotGetOptions) ([]*model.Bot, *mod
 }
 
 func (s *apiRPCServer) GetBots(args *Z_GetBotsArgs, returns *Z_GetBotsReturns) error {
+	if appErr := s.checkAPIPermission("GetBots"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetBots(options *model.BotGetOptions) ([]*model.Bot, *model.AppError)
 	}); ok {
@@ -5712,6 +6893,10 @@ func (g *apiRPCClient) UpdateBotActive(botUserId string, active bool) (*model.Bo
 }
 
 func (s *apiRPCServer) UpdateBotActive(args *Z_UpdateBotActiveArgs, returns *Z_UpdateBotActiveReturns) error {
+	if appErr := s.checkAPIPermission("UpdateBotActive"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateBotActive(botUserId string, active bool) (*model.Bot, *model.AppError)
 	}); ok {
@@ -5740,6 +6925,10 @@ func (g *apiRPCClient) PermanentDeleteBot(botUserId string) *model.AppError {
 }
 
 func (s *apiRPCServer) PermanentDeleteBot(args *Z_PermanentDeleteBotArgs, returns *Z_PermanentDeleteBotReturns) error {
+	if appErr := s.checkAPIPermission("PermanentDeleteBot"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		PermanentDeleteBot(botUserId string) *model.AppError
 	}); ok {
@@ -5770,6 +6959,10 @@ func (g *apiRPCClient) PublishUserTyping(userID, channelId, parentId string) *mo
 }
 
 func (s *apiRPCServer) PublishUserTyping(args *Z_PublishUserTypingArgs, returns *Z_PublishUserTypingReturns) error {
+	if appErr := s.checkAPIPermission("PublishUserTyping"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		PublishUserTyping(userID, channelId, parentId string) *model.AppError
 	}); ok {
@@ -5799,6 +6992,10 @@ func (g *apiRPCClient) CreateCommand(cmd *model.Command) (*model.Command, error)
 }
 
 func (s *apiRPCServer) CreateCommand(args *Z_CreateCommandArgs, returns *Z_CreateCommandReturns) error {
+	if appErr := s.checkAPIPermission("CreateCommand"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateCommand(cmd *model.Command) (*model.Command, error)
 	}); ok {
@@ -5829,6 +7026,10 @@ func (g *apiRPCClient) ListCommands(teamID string) ([]*model.Command, error) {
 }
 
 func (s *apiRPCServer) ListCommands(args *Z_ListCommandsArgs, returns *Z_ListCommandsReturns) error {
+	if appErr := s.checkAPIPermission("ListCommands"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ListCommands(teamID string) ([]*model.Command, error)
 	}); ok {
@@ -5859,6 +7060,10 @@ func (g *apiRPCClient) ListCustomCommands(teamID string) ([]*model.Command, erro
 }
 
 func (s *apiRPCServer) ListCustomCommands(args *Z_ListCustomCommandsArgs, returns *Z_ListCustomCommandsReturns) error {
+	if appErr := s.checkAPIPermission("ListCustomCommands"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ListCustomCommands(teamID string) ([]*model.Command, error)
 	}); ok {
@@ -5889,6 +7094,10 @@ func (g *apiRPCClient) ListPluginCommands(teamID string) ([]*model.Command, erro
 }
 
 func (s *apiRPCServer) ListPluginCommands(args *Z_ListPluginCommandsArgs, returns *Z_ListPluginCommandsReturns) error {
+	if appErr := s.checkAPIPermission("ListPluginCommands"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ListPluginCommands(teamID string) ([]*model.Command, error)
 	}); ok {
@@ -5918,6 +7127,10 @@ func (g *apiRPCClient) ListBuiltInCommands() ([]*model.Command, error) {
 }
 
 func (s *apiRPCServer) ListBuiltInCommands(args *Z_ListBuiltInCommandsArgs, returns *Z_ListBuiltInCommandsReturns) error {
+	if appErr := s.checkAPIPermission("ListBuiltInCommands"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ListBuiltInCommands() ([]*model.Command, error)
 	}); ok {
@@ -5948,6 +7161,10 @@ func (g *apiRPCClient) GetCommand(commandID string) (*model.Command, error) {
 }
 
 func (s *apiRPCServer) GetCommand(args *Z_GetCommandArgs, returns *Z_GetCommandReturns) error {
+	if appErr := s.checkAPIPermission("GetCommand"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetCommand(commandID string) (*model.Command, error)
 	}); ok {
@@ -5979,6 +7196,10 @@ func (g *apiRPCClient) UpdateCommand(commandID string, updatedCmd *model.Command
 }
 
 func (s *apiRPCServer) UpdateCommand(args *Z_UpdateCommandArgs, returns *Z_UpdateCommandReturns) error {
+	if appErr := s.checkAPIPermission("UpdateCommand"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateCommand(commandID string, updatedCmd *model.Command) (*model.Command, error)
 	}); ok {
@@ -6008,6 +7229,10 @@ func (g *apiRPCClient) DeleteCommand(commandID string) error {
 }
 
 func (s *apiRPCServer) DeleteCommand(args *Z_DeleteCommandArgs, returns *Z_DeleteCommandReturns) error {
+	if appErr := s.checkAPIPermission("DeleteCommand"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteCommand(commandID string) error
 	}); ok {
@@ -6038,6 +7263,10 @@ func (g *apiRPCClient) CreateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *mo
 }
 
 func (s *apiRPCServer) CreateOAuthApp(args *Z_CreateOAuthAppArgs, returns *Z_CreateOAuthAppReturns) error {
+	if appErr := s.checkAPIPermission("CreateOAuthApp"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError)
 	}); ok {
@@ -6067,6 +7296,10 @@ func (g *apiRPCClient) GetOAuthApp(appID string) (*model.OAuthApp, *model.AppErr
 }
 
 func (s *apiRPCServer) GetOAuthApp(args *Z_GetOAuthAppArgs, returns *Z_GetOAuthAppReturns) error {
+	if appErr := s.checkAPIPermission("GetOAuthApp"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetOAuthApp(appID string) (*model.OAuthApp, *model.AppError)
 	}); ok {
@@ -6096,6 +7329,10 @@ func (g *apiRPCClient) UpdateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *mo
 }
 
 func (s *apiRPCServer) UpdateOAuthApp(args *Z_UpdateOAuthAppArgs, returns *Z_UpdateOAuthAppReturns) error {
+	if appErr := s.checkAPIPermission("UpdateOAuthApp"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError)
 	}); ok {
@@ -6124,6 +7361,10 @@ func (g *apiRPCClient) DeleteOAuthApp(appID string) *model.AppError {
 }
 
 func (s *apiRPCServer) DeleteOAuthApp(args *Z_DeleteOAuthAppArgs, returns *Z_DeleteOAuthAppReturns) error {
+	if appErr := s.checkAPIPermission("DeleteOAuthApp"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteOAuthApp(appID string) *model.AppError
 	}); ok {
@@ -6153,6 +7394,10 @@ func (g *apiRPCClient) PublishPluginClusterEvent(ev model.PluginClusterEvent, op
 }
 
 func (s *apiRPCServer) PublishPluginClusterEvent(args *Z_PublishPluginClusterEventArgs, returns *Z_PublishPluginClusterEventReturns) error {
+	if appErr := s.checkAPIPermission("PublishPluginClusterEvent"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		PublishPluginClusterEvent(ev model.PluginClusterEvent, opts model.PluginClusterEventSendOptions) error
 	}); ok {
@@ -6185,6 +7430,10 @@ func (g *apiRPCClient) RequestTrialLicense(requesterID string, users int, termsA
 }
 
 func (s *apiRPCServer) RequestTrialLicense(args *Z_RequestTrialLicenseArgs, returns *Z_RequestTrialLicenseReturns) error {
+	if appErr := s.checkAPIPermission("RequestTrialLicense"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RequestTrialLicense(requesterID string, users int, termsAccepted bool, receiveEmailsAccepted bool) *model.AppError
 	}); ok {
@@ -6213,6 +7462,10 @@ func (g *apiRPCClient) GetCloudLimits() (*model.ProductLimits, error) {
 }
 
 func (s *apiRPCServer) GetCloudLimits(args *Z_GetCloudLimitsArgs, returns *Z_GetCloudLimitsReturns) error {
+	if appErr := s.checkAPIPermission("GetCloudLimits"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetCloudLimits() (*model.ProductLimits, error)
 	}); ok {
@@ -6243,6 +7496,10 @@ func (g *apiRPCClient) EnsureBotUser(bot *model.Bot) (string, error) {
 }
 
 func (s *apiRPCServer) EnsureBotUser(args *Z_EnsureBotUserArgs, returns *Z_EnsureBotUserReturns) error {
+	if appErr := s.checkAPIPermission("EnsureBotUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		EnsureBotUser(bot *model.Bot) (string, error)
 	}); ok {
@@ -6273,6 +7530,10 @@ func (g *apiRPCClient) RegisterCollectionAndTopic(collectionType, topicType stri
 }
 
 func (s *apiRPCServer) RegisterCollectionAndTopic(args *Z_RegisterCollectionAndTopicArgs, returns *Z_RegisterCollectionAndTopicReturns) error {
+	if appErr := s.checkAPIPermission("RegisterCollectionAndTopic"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RegisterCollectionAndTopic(collectionType, topicType string) error
 	}); ok {
@@ -6303,6 +7564,10 @@ func (g *apiRPCClient) CreateUploadSession(us *model.UploadSession) (*model.Uplo
 }
 
 func (s *apiRPCServer) CreateUploadSession(args *Z_CreateUploadSessionArgs, returns *Z_CreateUploadSessionReturns) error {
+	if appErr := s.checkAPIPermission("CreateUploadSession"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateUploadSession(us *model.UploadSession) (*model.UploadSession, error)
 	}); ok {
@@ -6333,6 +7598,10 @@ func (g *apiRPCClient) GetUploadSession(uploadID string) (*model.UploadSession,
 }
 
 func (s *apiRPCServer) GetUploadSession(args *Z_GetUploadSessionArgs, returns *Z_GetUploadSessionReturns) error {
+	if appErr := s.checkAPIPermission("GetUploadSession"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUploadSession(uploadID string) (*model.UploadSession, error)
 	}); ok {
@@ -6363,6 +7632,10 @@ func (g *apiRPCClient) SendPushNotification(notification *model.PushNotification
 }
 
 func (s *apiRPCServer) SendPushNotification(args *Z_SendPushNotificationArgs, returns *Z_SendPushNotificationReturns) error {
+	if appErr := s.checkAPIPermission("SendPushNotification"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SendPushNotification(notification *model.PushNotification, userID string) *model.AppError
 	}); ok {
@@ -6393,6 +7666,10 @@ func (g *apiRPCClient) UpdateUserAuth(userID string, userAuth *model.UserAuth) (
 }
 
 func (s *apiRPCServer) UpdateUserAuth(args *Z_UpdateUserAuthArgs, returns *Z_UpdateUserAuthReturns) error {
+	if appErr := s.checkAPIPermission("UpdateUserAuth"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateUserAuth(userID string, userAuth *model.UserAuth) (*model.UserAuth, *model.AppError)
 	}); ok {
@@ -6422,6 +7699,10 @@ func (g *apiRPCClient) RegisterPluginForSharedChannels(opts model.RegisterPlugin
 }
 
 func (s *apiRPCServer) RegisterPluginForSharedChannels(args *Z_RegisterPluginForSharedChannelsArgs, returns *Z_RegisterPluginForSharedChannelsReturns) error {
+	if appErr := s.checkAPIPermission("RegisterPluginForSharedChannels"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RegisterPluginForSharedChannels(opts model.RegisterPluginOpts) (remoteID string, err error)
 	}); ok {
@@ -6451,6 +7732,10 @@ func (g *apiRPCClient) UnregisterPluginForSharedChannels(pluginID string) error
 }
 
 func (s *apiRPCServer) UnregisterPluginForSharedChannels(args *Z_UnregisterPluginForSharedChannelsArgs, returns *Z_UnregisterPluginForSharedChannelsReturns) error {
+	if appErr := s.checkAPIPermission("UnregisterPluginForSharedChannels"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UnregisterPluginForSharedChannels(pluginID string) error
 	}); ok {
@@ -6481,6 +7766,10 @@ func (g *apiRPCClient) ShareChannel(sc *model.SharedChannel) (*model.SharedChann
 }
 
 func (s *apiRPCServer) ShareChannel(args *Z_ShareChannelArgs, returns *Z_ShareChannelReturns) error {
+	if appErr := s.checkAPIPermission("ShareChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ShareChannel(sc *model.SharedChannel) (*model.SharedChannel, error)
 	}); ok {
@@ -6511,6 +7800,10 @@ func (g *apiRPCClient) UpdateSharedChannel(sc *model.SharedChannel) (*model.Shar
 }
 
 func (s *apiRPCServer) UpdateSharedChannel(args *Z_UpdateSharedChannelArgs, returns *Z_UpdateSharedChannelReturns) error {
+	if appErr := s.checkAPIPermission("UpdateSharedChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateSharedChannel(sc *model.SharedChannel) (*model.SharedChannel, error)
 	}); ok {
@@ -6541,6 +7834,10 @@ func (g *apiRPCClient) UnshareChannel(channelID string) (unshared bool, err erro
 }
 
 func (s *apiRPCServer) UnshareChannel(args *Z_UnshareChannelArgs, returns *Z_UnshareChannelReturns) error {
+	if appErr := s.checkAPIPermission("UnshareChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UnshareChannel(channelID string) (unshared bool, err error)
 	}); ok {
@@ -6572,6 +7869,10 @@ func (g *apiRPCClient) UpdateSharedChannelCursor(channelID, remoteID string, cus
 }
 
 func (s *apiRPCServer) UpdateSharedChannelCursor(args *Z_UpdateSharedChannelCursorArgs, returns *Z_UpdateSharedChannelCursorReturns) error {
+	if appErr := s.checkAPIPermission("UpdateSharedChannelCursor"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateSharedChannelCursor(channelID, remoteID string, cusror model.GetPostsSinceForSyncCursor) error
 	}); ok {
@@ -6601,6 +7902,10 @@ func (g *apiRPCClient) SyncSharedChannel(channelID string) error {
 }
 
 func (s *apiRPCServer) SyncSharedChannel(args *Z_SyncSharedChannelArgs, returns *Z_SyncSharedChannelReturns) error {
+	if appErr := s.checkAPIPermission("SyncSharedChannel"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SyncSharedChannel(channelID string) error
 	}); ok {
@@ -6633,6 +7938,10 @@ func (g *apiRPCClient) InviteRemoteToChannel(channelID string, remoteID string,
 }
 
 func (s *apiRPCServer) InviteRemoteToChannel(args *Z_InviteRemoteToChannelArgs, returns *Z_InviteRemoteToChannelReturns) error {
+	if appErr := s.checkAPIPermission("InviteRemoteToChannel"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		InviteRemoteToChannel(channelID string, remoteID string, userID string, shareIfNotShared bool) error
 	}); ok {
@@ -6663,6 +7972,10 @@ func (g *apiRPCClient) UninviteRemoteFromChannel(channelID string, remoteID stri
 }
 
 func (s *apiRPCServer) UninviteRemoteFromChannel(args *Z_UninviteRemoteFromChannelArgs, returns *Z_UninviteRemoteFromChannelReturns) error {
+	if appErr := s.checkAPIPermission("UninviteRemoteFromChannel"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UninviteRemoteFromChannel(channelID string, remoteID string) error
 	}); ok {
@@ -6694,6 +8007,10 @@ func (g *apiRPCClient) UpsertGroupMember(groupID string, userID string) (*model.
 }
 
 func (s *apiRPCServer) UpsertGroupMember(args *Z_UpsertGroupMemberArgs, returns *Z_UpsertGroupMemberReturns) error {
+	if appErr := s.checkAPIPermission("UpsertGroupMember"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpsertGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError)
 	}); ok {
@@ -6724,6 +8041,10 @@ func (g *apiRPCClient) UpsertGroupMembers(groupID string, userIDs []string) ([]*
 }
 
 func (s *apiRPCServer) UpsertGroupMembers(args *Z_UpsertGroupMembersArgs, returns *Z_UpsertGroupMembersReturns) error {
+	if appErr := s.checkAPIPermission("UpsertGroupMembers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpsertGroupMembers(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError)
 	}); ok {
@@ -6754,6 +8075,10 @@ func (g *apiRPCClient) GetGroupByRemoteID(remoteID string, groupSource model.Gro
 }
 
 func (s *apiRPCServer) GetGroupByRemoteID(args *Z_GetGroupByRemoteIDArgs, returns *Z_GetGroupByRemoteIDReturns) error {
+	if appErr := s.checkAPIPermission("GetGroupByRemoteID"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError)
 	}); ok {
@@ -6783,6 +8108,10 @@ func (g *apiRPCClient) CreateGroup(group *model.Group) (*model.Group, *model.App
 }
 
 func (s *apiRPCServer) CreateGroup(args *Z_CreateGroupArgs, returns *Z_CreateGroupReturns) error {
+	if appErr := s.checkAPIPermission("CreateGroup"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateGroup(group *model.Group) (*model.Group, *model.AppError)
 	}); ok {
@@ -6812,6 +8141,10 @@ func (g *apiRPCClient) UpdateGroup(group *model.Group) (*model.Group, *model.App
 }
 
 func (s *apiRPCServer) UpdateGroup(args *Z_UpdateGroupArgs, returns *Z_UpdateGroupReturns) error {
+	if appErr := s.checkAPIPermission("UpdateGroup"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateGroup(group *model.Group) (*model.Group, *model.AppError)
 	}); ok {
@@ -6841,6 +8174,10 @@ func (g *apiRPCClient) DeleteGroup(groupID string) (*model.Group, *model.AppErro
 }
 
 func (s *apiRPCServer) DeleteGroup(args *Z_DeleteGroupArgs, returns *Z_DeleteGroupReturns) error {
+	if appErr := s.checkAPIPermission("DeleteGroup"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteGroup(groupID string) (*model.Group, *model.AppError)
 	}); ok {
@@ -6870,6 +8207,10 @@ func (g *apiRPCClient) RestoreGroup(groupID string) (*model.Group, *model.AppErr
 }
 
 func (s *apiRPCServer) RestoreGroup(args *Z_RestoreGroupArgs, returns *Z_RestoreGroupReturns) error {
+	if appErr := s.checkAPIPermission("RestoreGroup"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RestoreGroup(groupID string) (*model.Group, *model.AppError)
 	}); ok {
@@ -6900,6 +8241,10 @@ func (g *apiRPCClient) DeleteGroupMember(groupID string, userID string) (*model.
 }
 
 func (s *apiRPCServer) DeleteGroupMember(args *Z_DeleteGroupMemberArgs, returns *Z_DeleteGroupMemberReturns) error {
+	if appErr := s.checkAPIPermission("DeleteGroupMember"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError)
 	}); ok {
@@ -6931,6 +8276,10 @@ func (g *apiRPCClient) GetGroupSyncable(groupID string, syncableID string, synca
 }
 
 func (s *apiRPCServer) GetGroupSyncable(args *Z_GetGroupSyncableArgs, returns *Z_GetGroupSyncableReturns) error {
+	if appErr := s.checkAPIPermission("GetGroupSyncable"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError)
 	}); ok {
@@ -6961,6 +8310,10 @@ func (g *apiRPCClient) GetGroupSyncables(groupID string, syncableType model.Grou
 }
 
 func (s *apiRPCServer) GetGroupSyncables(args *Z_GetGroupSyncablesArgs, returns *Z_GetGroupSyncablesReturns) error {
+	if appErr := s.checkAPIPermission("GetGroupSyncables"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupSyncables(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, *model.AppError)
 	}); ok {
@@ -6990,6 +8343,10 @@ func (g *apiRPCClient) UpsertGroupSyncable(groupSyncable *model.GroupSyncable) (
 }
 
 func (s *apiRPCServer) UpsertGroupSyncable(args *Z_UpsertGroupSyncableArgs, returns *Z_UpsertGroupSyncableReturns) error {
+	if appErr := s.checkAPIPermission("UpsertGroupSyncable"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpsertGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError)
 	}); ok {
@@ -7019,6 +8376,10 @@ func (g *apiRPCClient) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (
 }
 
 func (s *apiRPCServer) UpdateGroupSyncable(args *Z_UpdateGroupSyncableArgs, returns *Z_UpdateGroupSyncableReturns) error {
+	if appErr := s.checkAPIPermission("UpdateGroupSyncable"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError)
 	}); ok {
@@ -7050,6 +8411,10 @@ func (g *apiRPCClient) DeleteGroupSyncable(groupID string, syncableID string, sy
 }
 
 func (s *apiRPCServer) DeleteGroupSyncable(args *Z_DeleteGroupSyncableArgs, returns *Z_DeleteGroupSyncableReturns) error {
+	if appErr := s.checkAPIPermission("DeleteGroupSyncable"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError)
 	}); ok {
@@ -7080,6 +8445,10 @@ func (g *apiRPCClient) UpdateUserRoles(userID, newRoles string) (*model.User, *m
 }
 
 func (s *apiRPCServer) UpdateUserRoles(args *Z_UpdateUserRolesArgs, returns *Z_UpdateUserRolesReturns) error {
+	if appErr := s.checkAPIPermission("UpdateUserRoles"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateUserRoles(userID, newRoles string) (*model.User, *model.AppError)
 	}); ok {
@@ -7107,6 +8476,9 @@ func (g *apiRPCClient) GetPluginID() string {
 }
 
 func (s *apiRPCServer) GetPluginID(args *Z_GetPluginIDArgs, returns *Z_GetPluginIDReturns) error {
+	if appErr := s.checkAPIPermission("GetPluginID"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetPluginID() string
 	}); ok {
@@ -7139,6 +8511,10 @@ func (g *apiRPCClient) GetGroups(page, perPage int, opts model.GroupSearchOpts,
 }
 
 func (s *apiRPCServer) GetGroups(args *Z_GetGroupsArgs, returns *Z_GetGroupsReturns) error {
+	if appErr := s.checkAPIPermission("GetGroups"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroups(page, perPage int, opts model.GroupSearchOpts, viewRestrictions *model.ViewUsersRestrictions) ([]*model.Group, *model.AppError)
 	}); ok {
@@ -7167,6 +8543,10 @@ func (g *apiRPCClient) CreateDefaultSyncableMemberships(params model.CreateDefau
 }
 
 func (s *apiRPCServer) CreateDefaultSyncableMemberships(args *Z_CreateDefaultSyncableMembershipsArgs, returns *Z_CreateDefaultSyncableMembershipsReturns) error {
+	if appErr := s.checkAPIPermission("CreateDefaultSyncableMemberships"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateDefaultSyncableMemberships(params model.CreateDefaultMembershipParams) *model.AppError
 	}); ok {
@@ -7194,6 +8574,10 @@ func (g *apiRPCClient) DeleteGroupConstrainedMemberships() *model.AppError {
 }
 
 func (s *apiRPCServer) DeleteGroupConstrainedMemberships(args *Z_DeleteGroupConstrainedMembershipsArgs, returns *Z_DeleteGroupConstrainedMembershipsReturns) error {
+	if appErr := s.checkAPIPermission("DeleteGroupConstrainedMemberships"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteGroupConstrainedMemberships() *model.AppError
 	}); ok {
@@ -7223,6 +8607,10 @@ func (g *apiRPCClient) CreatePropertyField(field *model.PropertyField) (*model.P
 }
 
 func (s *apiRPCServer) CreatePropertyField(args *Z_CreatePropertyFieldArgs, returns *Z_CreatePropertyFieldReturns) error {
+	if appErr := s.checkAPIPermission("CreatePropertyField"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreatePropertyField(field *model.PropertyField) (*model.PropertyField, error)
 	}); ok {
@@ -7254,6 +8642,10 @@ func (g *apiRPCClient) GetPropertyField(groupID, fieldID string) (*model.Propert
 }
 
 func (s *apiRPCServer) GetPropertyField(args *Z_GetPropertyFieldArgs, returns *Z_GetPropertyFieldReturns) error {
+	if appErr := s.checkAPIPermission("GetPropertyField"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPropertyField(groupID, fieldID string) (*model.PropertyField, error)
 	}); ok {
@@ -7285,6 +8677,10 @@ func (g *apiRPCClient) GetPropertyFields(groupID string, ids []string) ([]*model
 }
 
 func (s *apiRPCServer) GetPropertyFields(args *Z_GetPropertyFieldsArgs, returns *Z_GetPropertyFieldsReturns) error {
+	if appErr := s.checkAPIPermission("GetPropertyFields"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPropertyFields(groupID string, ids []string) ([]*model.PropertyField, error)
 	}); ok {
@@ -7316,6 +8712,10 @@ func (g *apiRPCClient) UpdatePropertyField(groupID string, field *model.Property
 }
 
 func (s *apiRPCServer) UpdatePropertyField(args *Z_UpdatePropertyFieldArgs, returns *Z_UpdatePropertyFieldReturns) error {
+	if appErr := s.checkAPIPermission("UpdatePropertyField"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdatePropertyField(groupID string, field *model.PropertyField) (*model.PropertyField, error)
 	}); ok {
@@ -7346,6 +8746,10 @@ func (g *apiRPCClient) DeletePropertyField(groupID, fieldID string) error {
 }
 
 func (s *apiRPCServer) DeletePropertyField(args *Z_DeletePropertyFieldArgs, returns *Z_DeletePropertyFieldReturns) error {
+	if appErr := s.checkAPIPermission("DeletePropertyField"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeletePropertyField(groupID, fieldID string) error
 	}); ok {
@@ -7377,6 +8781,10 @@ func (g *apiRPCClient) SearchPropertyFields(groupID string, opts model.PropertyF
 }
 
 func (s *apiRPCServer) SearchPropertyFields(args *Z_SearchPropertyFieldsArgs, returns *Z_SearchPropertyFieldsReturns) error {
+	if appErr := s.checkAPIPermission("SearchPropertyFields"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SearchPropertyFields(groupID string, opts model.PropertyFieldSearchOpts) ([]*model.PropertyField, error)
 	}); ok {
@@ -7408,6 +8816,10 @@ func (g *apiRPCClient) CountPropertyFields(groupID string, includeDeleted bool)
 }
 
 func (s *apiRPCServer) CountPropertyFields(args *Z_CountPropertyFieldsArgs, returns *Z_CountPropertyFieldsReturns) error {
+	if appErr := s.checkAPIPermission("CountPropertyFields"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CountPropertyFields(groupID string, includeDeleted bool) (int64, error)
 	}); ok {
@@ -7441,6 +8853,10 @@ func (g *apiRPCClient) CountPropertyFieldsForTarget(groupID, targetType, targetI
 }
 
 func (s *apiRPCServer) CountPropertyFieldsForTarget(args *Z_CountPropertyFieldsForTargetArgs, returns *Z_CountPropertyFieldsForTargetReturns) error {
+	if appErr := s.checkAPIPermission("CountPropertyFieldsForTarget"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CountPropertyFieldsForTarget(groupID, targetType, targetID string, includeDeleted bool) (int64, error)
 	}); ok {
@@ -7471,6 +8887,10 @@ func (g *apiRPCClient) CreatePropertyValue(value *model.PropertyValue) (*model.P
 }
 
 func (s *apiRPCServer) CreatePropertyValue(args *Z_CreatePropertyValueArgs, returns *Z_CreatePropertyValueReturns) error {
+	if appErr := s.checkAPIPermission("CreatePropertyValue"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreatePropertyValue(value *model.PropertyValue) (*model.PropertyValue, error)
 	}); ok {
@@ -7502,6 +8922,10 @@ func (g *apiRPCClient) GetPropertyValue(groupID, valueID string) (*model.Propert
 }
 
 func (s *apiRPCServer) GetPropertyValue(args *Z_GetPropertyValueArgs, returns *Z_GetPropertyValueReturns) error {
+	if appErr := s.checkAPIPermission("GetPropertyValue"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPropertyValue(groupID, valueID string) (*model.PropertyValue, error)
 	}); ok {
@@ -7533,6 +8957,10 @@ func (g *apiRPCClient) GetPropertyValues(groupID string, ids []string) ([]*model
 }
 
 func (s *apiRPCServer) GetPropertyValues(args *Z_GetPropertyValuesArgs, returns *Z_GetPropertyValuesReturns) error {
+	if appErr := s.checkAPIPermission("GetPropertyValues"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPropertyValues(groupID string, ids []string) ([]*model.PropertyValue, error)
 	}); ok {
@@ -7564,6 +8992,10 @@ func (g *apiRPCClient) UpdatePropertyValue(groupID string, value *model.Property
 }
 
 func (s *apiRPCServer) UpdatePropertyValue(args *Z_UpdatePropertyValueArgs, returns *Z_UpdatePropertyValueReturns) error {
+	if appErr := s.checkAPIPermission("UpdatePropertyValue"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdatePropertyValue(groupID string, value *model.PropertyValue) (*model.PropertyValue, error)
 	}); ok {
@@ -7594,6 +9026,10 @@ func (g *apiRPCClient) UpsertPropertyValue(value *model.PropertyValue) (*model.P
 }
 
 func (s *apiRPCServer) UpsertPropertyValue(args *Z_UpsertPropertyValueArgs, returns *Z_UpsertPropertyValueReturns) error {
+	if appErr := s.checkAPIPermission("UpsertPropertyValue"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpsertPropertyValue(value *model.PropertyValue) (*model.PropertyValue, error)
 	}); ok {
@@ -7624,6 +9060,10 @@ func (g *apiRPCClient) DeletePropertyValue(groupID, valueID string) error {
 }
 
 func (s *apiRPCServer) DeletePropertyValue(args *Z_DeletePropertyValueArgs, returns *Z_DeletePropertyValueReturns) error {
+	if appErr := s.checkAPIPermission("DeletePropertyValue"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeletePropertyValue(groupID, valueID string) error
 	}); ok {
@@ -7655,6 +9095,10 @@ func (g *apiRPCClient) SearchPropertyValues(groupID string, opts model.PropertyV
 }
 
 func (s *apiRPCServer) SearchPropertyValues(args *Z_SearchPropertyValuesArgs, returns *Z_SearchPropertyValuesReturns) error {
+	if appErr := s.checkAPIPermission("SearchPropertyValues"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SearchPropertyValues(groupID string, opts model.PropertyValueSearchOpts) ([]*model.PropertyValue, error)
 	}); ok {
@@ -7685,6 +9129,10 @@ func (g *apiRPCClient) RegisterPropertyGroup(name string) (*model.PropertyGroup,
 }
 
 func (s *apiRPCServer) RegisterPropertyGroup(args *Z_RegisterPropertyGroupArgs, returns *Z_RegisterPropertyGroupReturns) error {
+	if appErr := s.checkAPIPermission("RegisterPropertyGroup"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RegisterPropertyGroup(name string) (*model.PropertyGroup, error)
 	}); ok {
@@ -7715,6 +9163,10 @@ func (g *apiRPCClient) GetPropertyGroup(name string) (*model.PropertyGroup, erro
 }
 
 func (s *apiRPCServer) GetPropertyGroup(args *Z_GetPropertyGroupArgs, returns *Z_GetPropertyGroupReturns) error {
+	if appErr := s.checkAPIPermission("GetPropertyGroup"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPropertyGroup(name string) (*model.PropertyGroup, error)
 	}); ok {
@@ -7747,6 +9199,10 @@ func (g *apiRPCClient) GetPropertyFieldByName(groupID, targetID, name string) (*
 }
 
 func (s *apiRPCServer) GetPropertyFieldByName(args *Z_GetPropertyFieldByNameArgs, returns *Z_GetPropertyFieldByNameReturns) error {
+	if appErr := s.checkAPIPermission("GetPropertyFieldByName"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPropertyFieldByName(groupID, targetID, name string) (*model.PropertyField, error)
 	}); ok {
@@ -7778,6 +9234,10 @@ func (g *apiRPCClient) UpdatePropertyFields(groupID string, fields []*model.Prop
 }
 
 func (s *apiRPCServer) UpdatePropertyFields(args *Z_UpdatePropertyFieldsArgs, returns *Z_UpdatePropertyFieldsReturns) error {
+	if appErr := s.checkAPIPermission("UpdatePropertyFields"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdatePropertyFields(groupID string, fields []*model.PropertyField) ([]*model.PropertyField, error)
 	}); ok {
@@ -7809,6 +9269,10 @@ func (g *apiRPCClient) UpdatePropertyValues(groupID string, values []*model.Prop
 }
 
 func (s *apiRPCServer) UpdatePropertyValues(args *Z_UpdatePropertyValuesArgs, returns *Z_UpdatePropertyValuesReturns) error {
+	if appErr := s.checkAPIPermission("UpdatePropertyValues"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdatePropertyValues(groupID string, values []*model.PropertyValue) ([]*model.PropertyValue, error)
 	}); ok {
@@ -7839,6 +9303,10 @@ func (g *apiRPCClient) UpsertPropertyValues(values []*model.PropertyValue) ([]*m
 }
 
 func (s *apiRPCServer) UpsertPropertyValues(args *Z_UpsertPropertyValuesArgs, returns *Z_UpsertPropertyValuesReturns) error {
+	if appErr := s.checkAPIPermission("UpsertPropertyValues"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpsertPropertyValues(values []*model.PropertyValue) ([]*model.PropertyValue, error)
 	}); ok {
@@ -7870,6 +9338,10 @@ func (g *apiRPCClient) DeletePropertyValuesForTarget(groupID, targetType, target
 }
 
 func (s *apiRPCServer) DeletePropertyValuesForTarget(args *Z_DeletePropertyValuesForTargetArgs, returns *Z_DeletePropertyValuesForTargetReturns) error {
+	if appErr := s.checkAPIPermission("DeletePropertyValuesForTarget"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeletePropertyValuesForTarget(groupID, targetType, targetID string) error
 	}); ok {
@@ -7900,6 +9372,10 @@ func (g *apiRPCClient) DeletePropertyValuesForField(groupID, fieldID string) err
 }
 
 func (s *apiRPCServer) DeletePropertyValuesForField(args *Z_DeletePropertyValuesForFieldArgs, returns *Z_DeletePropertyValuesForFieldReturns) error {
+	if appErr := s.checkAPIPermission("DeletePropertyValuesForField"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeletePropertyValuesForField(groupID, fieldID string) error
 	}); ok {
@@ -7910,3 +9386,132 @@ func (s *apiRPCServer) DeletePropertyValuesForField(args *Z_DeletePropertyValues
 	}
 	return nil
 }
+
+type Z_SetGuestAccessExpiryArgs struct {
+	A string
+	B int64
+}
+
+type Z_SetGuestAccessExpiryReturns struct {
+	A *model.GuestAccessExpiry
+	B *model.AppError
+}
+
+func (g *apiRPCClient) SetGuestAccessExpiry(userID string, expiresAt int64) (*model.GuestAccessExpiry, *model.AppError) {
+	_args := &Z_SetGuestAccessExpiryArgs{userID, expiresAt}
+	_returns := &Z_SetGuestAccessExpiryReturns{}
+	if err := g.client.Call("Plugin.SetGuestAccessExpiry", _args, _returns); err != nil {
+		log.Printf("RPC call to SetGuestAccessExpiry API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) SetGuestAccessExpiry(args *Z_SetGuestAccessExpiryArgs, returns *Z_SetGuestAccessExpiryReturns) error {
+	if appErr := s.checkAPIPermission("SetGuestAccessExpiry"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
+	if hook, ok := s.impl.(interface {
+		SetGuestAccessExpiry(userID string, expiresAt int64) (*model.GuestAccessExpiry, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.SetGuestAccessExpiry(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API SetGuestAccessExpiry called but not implemented."))
+	}
+	return nil
+}
+
+type Z_GetGuestAccessExpiryArgs struct {
+	A string
+}
+
+type Z_GetGuestAccessExpiryReturns struct {
+	A *model.GuestAccessExpiry
+	B *model.AppError
+}
+
+func (g *apiRPCClient) GetGuestAccessExpiry(userID string) (*model.GuestAccessExpiry, *model.AppError) {
+	_args := &Z_GetGuestAccessExpiryArgs{userID}
+	_returns := &Z_GetGuestAccessExpiryReturns{}
+	if err := g.client.Call("Plugin.GetGuestAccessExpiry", _args, _returns); err != nil {
+		log.Printf("RPC call to GetGuestAccessExpiry API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) GetGuestAccessExpiry(args *Z_GetGuestAccessExpiryArgs, returns *Z_GetGuestAccessExpiryReturns) error {
+	if appErr := s.checkAPIPermission("GetGuestAccessExpiry"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
+	if hook, ok := s.impl.(interface {
+		GetGuestAccessExpiry(userID string) (*model.GuestAccessExpiry, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.GetGuestAccessExpiry(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API GetGuestAccessExpiry called but not implemented."))
+	}
+	return nil
+}
+
+type Z_RegisterSearchEngineArgs struct {
+}
+
+type Z_RegisterSearchEngineReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) RegisterSearchEngine() *model.AppError {
+	_args := &Z_RegisterSearchEngineArgs{}
+	_returns := &Z_RegisterSearchEngineReturns{}
+	if err := g.client.Call("Plugin.RegisterSearchEngine", _args, _returns); err != nil {
+		log.Printf("RPC call to RegisterSearchEngine API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) RegisterSearchEngine(args *Z_RegisterSearchEngineArgs, returns *Z_RegisterSearchEngineReturns) error {
+	if appErr := s.checkAPIPermission("RegisterSearchEngine"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
+	if hook, ok := s.impl.(interface {
+		RegisterSearchEngine() *model.AppError
+	}); ok {
+		returns.A = hook.RegisterSearchEngine()
+	} else {
+		return encodableError(fmt.Errorf("API RegisterSearchEngine called but not implemented."))
+	}
+	return nil
+}
+
+type Z_UnregisterSearchEngineArgs struct {
+}
+
+type Z_UnregisterSearchEngineReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) UnregisterSearchEngine() *model.AppError {
+	_args := &Z_UnregisterSearchEngineArgs{}
+	_returns := &Z_UnregisterSearchEngineReturns{}
+	if err := g.client.Call("Plugin.UnregisterSearchEngine", _args, _returns); err != nil {
+		log.Printf("RPC call to UnregisterSearchEngine API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) UnregisterSearchEngine(args *Z_UnregisterSearchEngineArgs, returns *Z_UnregisterSearchEngineReturns) error {
+	if appErr := s.checkAPIPermission("UnregisterSearchEngine"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
+	if hook, ok := s.impl.(interface {
+		UnregisterSearchEngine() *model.AppError
+	}); ok {
+		returns.A = hook.UnregisterSearchEngine()
+	} else {
+		return encodableError(fmt.Errorf("API UnregisterSearchEngine called but not implemented."))
+	}
+	return nil
+}
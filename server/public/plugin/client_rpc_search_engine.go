@@ -0,0 +1,745 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// The search engine hooks are in this file because, unlike the generated hooks, their client
+// returns an error when the plugin can't be reached or doesn't implement the hook, so that the
+// server can fall back to the database search.
+
+// ErrSearchEngineHookNotImplemented is returned by the search engine hooks the plugin doesn't
+// implement.
+var ErrSearchEngineHookNotImplemented = errors.New("the search engine hook is not implemented by the plugin")
+
+// callSearchEngineHook calls a search engine hook of the plugin, returning the RPC errors.
+func (g *hooksRPCClient) callSearchEngineHook(hookID int, hookName string, args, returns any) error {
+	if !g.implemented[hookID] {
+		return ErrSearchEngineHookNotImplemented
+	}
+
+	if err := g.client.Call("Plugin."+hookName, args, returns); err != nil {
+		g.log.Error("RPC call "+hookName+" to plugin failed.", mlog.Err(err))
+		return errors.Wrapf(err, "RPC call %s to plugin failed", hookName)
+	}
+
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineIndexPost"] = SearchEngineIndexPostID
+}
+
+type Z_SearchEngineIndexPostArgs struct {
+	A *Context
+	B *model.Post
+	C string
+}
+
+type Z_SearchEngineIndexPostReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineIndexPost(c *Context, post *model.Post, teamID string) error {
+	_args := &Z_SearchEngineIndexPostArgs{c, post, teamID}
+	_returns := &Z_SearchEngineIndexPostReturns{}
+	if err := g.callSearchEngineHook(SearchEngineIndexPostID, "SearchEngineIndexPost", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineIndexPost(args *Z_SearchEngineIndexPostArgs, returns *Z_SearchEngineIndexPostReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineIndexPost(c *Context, post *model.Post, teamID string) error
+	}); ok {
+		returns.A = hook.SearchEngineIndexPost(args.A, args.B, args.C)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineIndexPost called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineDeletePost"] = SearchEngineDeletePostID
+}
+
+type Z_SearchEngineDeletePostArgs struct {
+	A *Context
+	B *model.Post
+}
+
+type Z_SearchEngineDeletePostReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineDeletePost(c *Context, post *model.Post) error {
+	_args := &Z_SearchEngineDeletePostArgs{c, post}
+	_returns := &Z_SearchEngineDeletePostReturns{}
+	if err := g.callSearchEngineHook(SearchEngineDeletePostID, "SearchEngineDeletePost", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineDeletePost(args *Z_SearchEngineDeletePostArgs, returns *Z_SearchEngineDeletePostReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineDeletePost(c *Context, post *model.Post) error
+	}); ok {
+		returns.A = hook.SearchEngineDeletePost(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineDeletePost called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineSearchPosts"] = SearchEngineSearchPostsID
+}
+
+type Z_SearchEngineSearchPostsArgs struct {
+	A *Context
+	B model.ChannelList
+	C []*model.SearchParams
+	D int
+	E int
+}
+
+type Z_SearchEngineSearchPostsReturns struct {
+	A []string
+	B model.PostSearchMatches
+	C error
+}
+
+func (g *hooksRPCClient) SearchEngineSearchPosts(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page int, perPage int) ([]string, model.PostSearchMatches, error) {
+	_args := &Z_SearchEngineSearchPostsArgs{c, channels, searchParams, page, perPage}
+	_returns := &Z_SearchEngineSearchPostsReturns{}
+	if err := g.callSearchEngineHook(SearchEngineSearchPostsID, "SearchEngineSearchPosts", _args, _returns); err != nil {
+		return nil, nil, err
+	}
+	return _returns.A, _returns.B, _returns.C
+}
+
+func (s *hooksRPCServer) SearchEngineSearchPosts(args *Z_SearchEngineSearchPostsArgs, returns *Z_SearchEngineSearchPostsReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineSearchPosts(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page int, perPage int) ([]string, model.PostSearchMatches, error)
+	}); ok {
+		returns.A, returns.B, returns.C = hook.SearchEngineSearchPosts(args.A, args.B, args.C, args.D, args.E)
+		returns.C = encodableError(returns.C)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineSearchPosts called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineIndexFile"] = SearchEngineIndexFileID
+}
+
+type Z_SearchEngineIndexFileArgs struct {
+	A *Context
+	B *model.FileInfo
+	C string
+}
+
+type Z_SearchEngineIndexFileReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineIndexFile(c *Context, file *model.FileInfo, channelID string) error {
+	_args := &Z_SearchEngineIndexFileArgs{c, file, channelID}
+	_returns := &Z_SearchEngineIndexFileReturns{}
+	if err := g.callSearchEngineHook(SearchEngineIndexFileID, "SearchEngineIndexFile", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineIndexFile(args *Z_SearchEngineIndexFileArgs, returns *Z_SearchEngineIndexFileReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineIndexFile(c *Context, file *model.FileInfo, channelID string) error
+	}); ok {
+		returns.A = hook.SearchEngineIndexFile(args.A, args.B, args.C)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineIndexFile called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineDeleteFile"] = SearchEngineDeleteFileID
+}
+
+type Z_SearchEngineDeleteFileArgs struct {
+	A *Context
+	B string
+}
+
+type Z_SearchEngineDeleteFileReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineDeleteFile(c *Context, fileID string) error {
+	_args := &Z_SearchEngineDeleteFileArgs{c, fileID}
+	_returns := &Z_SearchEngineDeleteFileReturns{}
+	if err := g.callSearchEngineHook(SearchEngineDeleteFileID, "SearchEngineDeleteFile", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineDeleteFile(args *Z_SearchEngineDeleteFileArgs, returns *Z_SearchEngineDeleteFileReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineDeleteFile(c *Context, fileID string) error
+	}); ok {
+		returns.A = hook.SearchEngineDeleteFile(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineDeleteFile called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineSearchFiles"] = SearchEngineSearchFilesID
+}
+
+type Z_SearchEngineSearchFilesArgs struct {
+	A *Context
+	B model.ChannelList
+	C []*model.SearchParams
+	D int
+	E int
+}
+
+type Z_SearchEngineSearchFilesReturns struct {
+	A []string
+	B error
+}
+
+func (g *hooksRPCClient) SearchEngineSearchFiles(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page int, perPage int) ([]string, error) {
+	_args := &Z_SearchEngineSearchFilesArgs{c, channels, searchParams, page, perPage}
+	_returns := &Z_SearchEngineSearchFilesReturns{}
+	if err := g.callSearchEngineHook(SearchEngineSearchFilesID, "SearchEngineSearchFiles", _args, _returns); err != nil {
+		return nil, err
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) SearchEngineSearchFiles(args *Z_SearchEngineSearchFilesArgs, returns *Z_SearchEngineSearchFilesReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineSearchFiles(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page int, perPage int) ([]string, error)
+	}); ok {
+		returns.A, returns.B = hook.SearchEngineSearchFiles(args.A, args.B, args.C, args.D, args.E)
+		returns.B = encodableError(returns.B)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineSearchFiles called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineIndexChannel"] = SearchEngineIndexChannelID
+}
+
+type Z_SearchEngineIndexChannelArgs struct {
+	A *Context
+	B *model.Channel
+	C []string
+	D []string
+}
+
+type Z_SearchEngineIndexChannelReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineIndexChannel(c *Context, channel *model.Channel, userIDs []string, teamMemberIDs []string) error {
+	_args := &Z_SearchEngineIndexChannelArgs{c, channel, userIDs, teamMemberIDs}
+	_returns := &Z_SearchEngineIndexChannelReturns{}
+	if err := g.callSearchEngineHook(SearchEngineIndexChannelID, "SearchEngineIndexChannel", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineIndexChannel(args *Z_SearchEngineIndexChannelArgs, returns *Z_SearchEngineIndexChannelReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineIndexChannel(c *Context, channel *model.Channel, userIDs []string, teamMemberIDs []string) error
+	}); ok {
+		returns.A = hook.SearchEngineIndexChannel(args.A, args.B, args.C, args.D)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineIndexChannel called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineDeleteChannel"] = SearchEngineDeleteChannelID
+}
+
+type Z_SearchEngineDeleteChannelArgs struct {
+	A *Context
+	B *model.Channel
+}
+
+type Z_SearchEngineDeleteChannelReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineDeleteChannel(c *Context, channel *model.Channel) error {
+	_args := &Z_SearchEngineDeleteChannelArgs{c, channel}
+	_returns := &Z_SearchEngineDeleteChannelReturns{}
+	if err := g.callSearchEngineHook(SearchEngineDeleteChannelID, "SearchEngineDeleteChannel", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineDeleteChannel(args *Z_SearchEngineDeleteChannelArgs, returns *Z_SearchEngineDeleteChannelReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineDeleteChannel(c *Context, channel *model.Channel) error
+	}); ok {
+		returns.A = hook.SearchEngineDeleteChannel(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineDeleteChannel called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineSearchChannels"] = SearchEngineSearchChannelsID
+}
+
+type Z_SearchEngineSearchChannelsArgs struct {
+	A *Context
+	B string
+	C string
+	D string
+	E bool
+	F bool
+}
+
+type Z_SearchEngineSearchChannelsReturns struct {
+	A []string
+	B error
+}
+
+func (g *hooksRPCClient) SearchEngineSearchChannels(c *Context, teamID string, userID string, term string, isGuest bool, includeDeleted bool) ([]string, error) {
+	_args := &Z_SearchEngineSearchChannelsArgs{c, teamID, userID, term, isGuest, includeDeleted}
+	_returns := &Z_SearchEngineSearchChannelsReturns{}
+	if err := g.callSearchEngineHook(SearchEngineSearchChannelsID, "SearchEngineSearchChannels", _args, _returns); err != nil {
+		return nil, err
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) SearchEngineSearchChannels(args *Z_SearchEngineSearchChannelsArgs, returns *Z_SearchEngineSearchChannelsReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineSearchChannels(c *Context, teamID string, userID string, term string, isGuest bool, includeDeleted bool) ([]string, error)
+	}); ok {
+		returns.A, returns.B = hook.SearchEngineSearchChannels(args.A, args.B, args.C, args.D, args.E, args.F)
+		returns.B = encodableError(returns.B)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineSearchChannels called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineIndexUser"] = SearchEngineIndexUserID
+}
+
+type Z_SearchEngineIndexUserArgs struct {
+	A *Context
+	B *model.User
+	C []string
+	D []string
+}
+
+type Z_SearchEngineIndexUserReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineIndexUser(c *Context, user *model.User, teamIDs []string, channelIDs []string) error {
+	_args := &Z_SearchEngineIndexUserArgs{c, user, teamIDs, channelIDs}
+	_returns := &Z_SearchEngineIndexUserReturns{}
+	if err := g.callSearchEngineHook(SearchEngineIndexUserID, "SearchEngineIndexUser", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineIndexUser(args *Z_SearchEngineIndexUserArgs, returns *Z_SearchEngineIndexUserReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineIndexUser(c *Context, user *model.User, teamIDs []string, channelIDs []string) error
+	}); ok {
+		returns.A = hook.SearchEngineIndexUser(args.A, args.B, args.C, args.D)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineIndexUser called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineDeleteUser"] = SearchEngineDeleteUserID
+}
+
+type Z_SearchEngineDeleteUserArgs struct {
+	A *Context
+	B *model.User
+}
+
+type Z_SearchEngineDeleteUserReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineDeleteUser(c *Context, user *model.User) error {
+	_args := &Z_SearchEngineDeleteUserArgs{c, user}
+	_returns := &Z_SearchEngineDeleteUserReturns{}
+	if err := g.callSearchEngineHook(SearchEngineDeleteUserID, "SearchEngineDeleteUser", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineDeleteUser(args *Z_SearchEngineDeleteUserArgs, returns *Z_SearchEngineDeleteUserReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineDeleteUser(c *Context, user *model.User) error
+	}); ok {
+		returns.A = hook.SearchEngineDeleteUser(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineDeleteUser called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineSearchUsersInChannel"] = SearchEngineSearchUsersInChannelID
+}
+
+type Z_SearchEngineSearchUsersInChannelArgs struct {
+	A *Context
+	B string
+	C string
+	D []string
+	E string
+	F *model.UserSearchOptions
+}
+
+type Z_SearchEngineSearchUsersInChannelReturns struct {
+	A []string
+	B []string
+	C error
+}
+
+func (g *hooksRPCClient) SearchEngineSearchUsersInChannel(c *Context, teamID string, channelID string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, error) {
+	_args := &Z_SearchEngineSearchUsersInChannelArgs{c, teamID, channelID, restrictedToChannels, term, options}
+	_returns := &Z_SearchEngineSearchUsersInChannelReturns{}
+	if err := g.callSearchEngineHook(SearchEngineSearchUsersInChannelID, "SearchEngineSearchUsersInChannel", _args, _returns); err != nil {
+		return nil, nil, err
+	}
+	return _returns.A, _returns.B, _returns.C
+}
+
+func (s *hooksRPCServer) SearchEngineSearchUsersInChannel(args *Z_SearchEngineSearchUsersInChannelArgs, returns *Z_SearchEngineSearchUsersInChannelReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineSearchUsersInChannel(c *Context, teamID string, channelID string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, error)
+	}); ok {
+		returns.A, returns.B, returns.C = hook.SearchEngineSearchUsersInChannel(args.A, args.B, args.C, args.D, args.E, args.F)
+		returns.C = encodableError(returns.C)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineSearchUsersInChannel called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineSearchUsersInTeam"] = SearchEngineSearchUsersInTeamID
+}
+
+type Z_SearchEngineSearchUsersInTeamArgs struct {
+	A *Context
+	B string
+	C []string
+	D string
+	E *model.UserSearchOptions
+}
+
+type Z_SearchEngineSearchUsersInTeamReturns struct {
+	A []string
+	B error
+}
+
+func (g *hooksRPCClient) SearchEngineSearchUsersInTeam(c *Context, teamID string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, error) {
+	_args := &Z_SearchEngineSearchUsersInTeamArgs{c, teamID, restrictedToChannels, term, options}
+	_returns := &Z_SearchEngineSearchUsersInTeamReturns{}
+	if err := g.callSearchEngineHook(SearchEngineSearchUsersInTeamID, "SearchEngineSearchUsersInTeam", _args, _returns); err != nil {
+		return nil, err
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) SearchEngineSearchUsersInTeam(args *Z_SearchEngineSearchUsersInTeamArgs, returns *Z_SearchEngineSearchUsersInTeamReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineSearchUsersInTeam(c *Context, teamID string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, error)
+	}); ok {
+		returns.A, returns.B = hook.SearchEngineSearchUsersInTeam(args.A, args.B, args.C, args.D, args.E)
+		returns.B = encodableError(returns.B)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineSearchUsersInTeam called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEnginePurgeIndexes"] = SearchEnginePurgeIndexesID
+}
+
+type Z_SearchEnginePurgeIndexesArgs struct {
+	A *Context
+}
+
+type Z_SearchEnginePurgeIndexesReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEnginePurgeIndexes(c *Context) error {
+	_args := &Z_SearchEnginePurgeIndexesArgs{c}
+	_returns := &Z_SearchEnginePurgeIndexesReturns{}
+	if err := g.callSearchEngineHook(SearchEnginePurgeIndexesID, "SearchEnginePurgeIndexes", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEnginePurgeIndexes(args *Z_SearchEnginePurgeIndexesArgs, returns *Z_SearchEnginePurgeIndexesReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEnginePurgeIndexes(c *Context) error
+	}); ok {
+		returns.A = hook.SearchEnginePurgeIndexes(args.A)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEnginePurgeIndexes called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineDeleteChannelPosts"] = SearchEngineDeleteChannelPostsID
+}
+
+type Z_SearchEngineDeleteChannelPostsArgs struct {
+	A *Context
+	B string
+}
+
+type Z_SearchEngineDeleteChannelPostsReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineDeleteChannelPosts(c *Context, channelID string) error {
+	_args := &Z_SearchEngineDeleteChannelPostsArgs{c, channelID}
+	_returns := &Z_SearchEngineDeleteChannelPostsReturns{}
+	if err := g.callSearchEngineHook(SearchEngineDeleteChannelPostsID, "SearchEngineDeleteChannelPosts", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineDeleteChannelPosts(args *Z_SearchEngineDeleteChannelPostsArgs, returns *Z_SearchEngineDeleteChannelPostsReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineDeleteChannelPosts(c *Context, channelID string) error
+	}); ok {
+		returns.A = hook.SearchEngineDeleteChannelPosts(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineDeleteChannelPosts called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineDeleteUserPosts"] = SearchEngineDeleteUserPostsID
+}
+
+type Z_SearchEngineDeleteUserPostsArgs struct {
+	A *Context
+	B string
+}
+
+type Z_SearchEngineDeleteUserPostsReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineDeleteUserPosts(c *Context, userID string) error {
+	_args := &Z_SearchEngineDeleteUserPostsArgs{c, userID}
+	_returns := &Z_SearchEngineDeleteUserPostsReturns{}
+	if err := g.callSearchEngineHook(SearchEngineDeleteUserPostsID, "SearchEngineDeleteUserPosts", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineDeleteUserPosts(args *Z_SearchEngineDeleteUserPostsArgs, returns *Z_SearchEngineDeleteUserPostsReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineDeleteUserPosts(c *Context, userID string) error
+	}); ok {
+		returns.A = hook.SearchEngineDeleteUserPosts(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineDeleteUserPosts called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineDeletePostFiles"] = SearchEngineDeletePostFilesID
+}
+
+type Z_SearchEngineDeletePostFilesArgs struct {
+	A *Context
+	B string
+}
+
+type Z_SearchEngineDeletePostFilesReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineDeletePostFiles(c *Context, postID string) error {
+	_args := &Z_SearchEngineDeletePostFilesArgs{c, postID}
+	_returns := &Z_SearchEngineDeletePostFilesReturns{}
+	if err := g.callSearchEngineHook(SearchEngineDeletePostFilesID, "SearchEngineDeletePostFiles", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineDeletePostFiles(args *Z_SearchEngineDeletePostFilesArgs, returns *Z_SearchEngineDeletePostFilesReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineDeletePostFiles(c *Context, postID string) error
+	}); ok {
+		returns.A = hook.SearchEngineDeletePostFiles(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineDeletePostFiles called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineDeleteUserFiles"] = SearchEngineDeleteUserFilesID
+}
+
+type Z_SearchEngineDeleteUserFilesArgs struct {
+	A *Context
+	B string
+}
+
+type Z_SearchEngineDeleteUserFilesReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineDeleteUserFiles(c *Context, userID string) error {
+	_args := &Z_SearchEngineDeleteUserFilesArgs{c, userID}
+	_returns := &Z_SearchEngineDeleteUserFilesReturns{}
+	if err := g.callSearchEngineHook(SearchEngineDeleteUserFilesID, "SearchEngineDeleteUserFiles", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineDeleteUserFiles(args *Z_SearchEngineDeleteUserFilesArgs, returns *Z_SearchEngineDeleteUserFilesReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineDeleteUserFiles(c *Context, userID string) error
+	}); ok {
+		returns.A = hook.SearchEngineDeleteUserFiles(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineDeleteUserFiles called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineDeleteFilesBefore"] = SearchEngineDeleteFilesBeforeID
+}
+
+type Z_SearchEngineDeleteFilesBeforeArgs struct {
+	A *Context
+	B int64
+	C int64
+}
+
+type Z_SearchEngineDeleteFilesBeforeReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineDeleteFilesBefore(c *Context, endTime int64, limit int64) error {
+	_args := &Z_SearchEngineDeleteFilesBeforeArgs{c, endTime, limit}
+	_returns := &Z_SearchEngineDeleteFilesBeforeReturns{}
+	if err := g.callSearchEngineHook(SearchEngineDeleteFilesBeforeID, "SearchEngineDeleteFilesBefore", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineDeleteFilesBefore(args *Z_SearchEngineDeleteFilesBeforeArgs, returns *Z_SearchEngineDeleteFilesBeforeReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineDeleteFilesBefore(c *Context, endTime int64, limit int64) error
+	}); ok {
+		returns.A = hook.SearchEngineDeleteFilesBefore(args.A, args.B, args.C)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineDeleteFilesBefore called but not implemented"))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineDeletePostsBefore"] = SearchEngineDeletePostsBeforeID
+}
+
+type Z_SearchEngineDeletePostsBeforeArgs struct {
+	A *Context
+	B int64
+}
+
+type Z_SearchEngineDeletePostsBeforeReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineDeletePostsBefore(c *Context, cutoff int64) error {
+	_args := &Z_SearchEngineDeletePostsBeforeArgs{c, cutoff}
+	_returns := &Z_SearchEngineDeletePostsBeforeReturns{}
+	if err := g.callSearchEngineHook(SearchEngineDeletePostsBeforeID, "SearchEngineDeletePostsBefore", _args, _returns); err != nil {
+		return err
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineDeletePostsBefore(args *Z_SearchEngineDeletePostsBeforeArgs, returns *Z_SearchEngineDeletePostsBeforeReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineDeletePostsBefore(c *Context, cutoff int64) error
+	}); ok {
+		returns.A = hook.SearchEngineDeletePostsBefore(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("hook SearchEngineDeletePostsBefore called but not implemented"))
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// SortByDependencies sorts plugins in tiers, such that the plugins of a tier only depend on
+// plugins of earlier tiers or on plugins missing from the list. The plugins of a tier can thus
+// be activated concurrently once the earlier tiers are active. Plugins caught in a dependency
+// cycle end up in the last tier.
+func SortByDependencies(plugins []*model.BundleInfo) [][]*model.BundleInfo {
+	pending := make(map[string]*model.BundleInfo, len(plugins))
+	for _, plugin := range plugins {
+		if plugin.Manifest != nil {
+			pending[plugin.Manifest.Id] = plugin
+		}
+	}
+
+	var tiers [][]*model.BundleInfo
+	for len(pending) > 0 {
+		var tier []*model.BundleInfo
+		for _, plugin := range plugins {
+			if plugin.Manifest == nil || pending[plugin.Manifest.Id] == nil {
+				continue
+			}
+
+			ready := true
+			for _, dependency := range plugin.Manifest.Dependencies {
+				if _, ok := pending[dependency.Id]; ok {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				tier = append(tier, plugin)
+			}
+		}
+
+		if len(tier) == 0 {
+			// The remaining plugins depend on each other.
+			for _, plugin := range plugins {
+				if plugin.Manifest != nil && pending[plugin.Manifest.Id] != nil {
+					tier = append(tier, plugin)
+				}
+			}
+		}
+
+		for _, plugin := range tier {
+			delete(pending, plugin.Manifest.Id)
+		}
+		tiers = append(tiers, tier)
+	}
+
+	return tiers
+}
+
+// checkDependencies returns an error if a dependency of the plugin prevents its activation: a
+// required dependency isn't active, or an active dependency doesn't satisfy its version range.
+func (env *Environment) checkDependencies(manifest *model.Manifest) error {
+	for _, dependency := range manifest.Dependencies {
+		rp, ok := env.registeredPlugins.Load(dependency.Id)
+		if !ok || !env.IsActive(dependency.Id) {
+			if dependency.Optional {
+				continue
+			}
+			return fmt.Errorf("required dependency %s is not active", dependency.Id)
+		}
+
+		version := rp.(registeredPlugin).BundleInfo.Manifest.Version
+		satisfied, err := dependency.IsSatisfiedBy(version)
+		if err != nil {
+			return err
+		}
+		if !satisfied {
+			return fmt.Errorf("dependency %s version %s doesn't satisfy %s", dependency.Id, version, dependency.Version)
+		}
+	}
+
+	return nil
+}
+
+// activeDependents returns the active plugins requiring the plugin with the given id, directly
+// or through other plugins, sorted in activation order.
+func (env *Environment) activeDependents(id string) []*model.BundleInfo {
+	var active []*model.BundleInfo
+	env.registeredPlugins.Range(func(_, value any) bool {
+		rp := value.(registeredPlugin)
+		if env.IsActive(rp.BundleInfo.Manifest.Id) {
+			active = append(active, rp.BundleInfo)
+		}
+		return true
+	})
+
+	dependents := map[string]*model.BundleInfo{}
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, plugin := range active {
+			if _, ok := dependents[plugin.Manifest.Id]; ok {
+				continue
+			}
+			for _, dependency := range plugin.Manifest.Dependencies {
+				if dependency.Id == current && !dependency.Optional {
+					dependents[plugin.Manifest.Id] = plugin
+					queue = append(queue, plugin.Manifest.Id)
+					break
+				}
+			}
+		}
+	}
+
+	var sorted []*model.BundleInfo
+	for _, tier := range SortByDependencies(active) {
+		for _, plugin := range tier {
+			if _, ok := dependents[plugin.Manifest.Id]; ok {
+				sorted = append(sorted, plugin)
+			}
+		}
+	}
+
+	return sorted
+}
+
+// deactivateDependents deactivates the active plugins requiring the plugin with the given id,
+// dependents first. They are activated again by activateDependents.
+func (env *Environment) deactivateDependents(id string) {
+	dependents := env.activeDependents(id)
+	for i := len(dependents) - 1; i >= 0; i-- {
+		dependentID := dependents[i].Manifest.Id
+		if env.deactivate(dependentID) {
+			env.logger.Info("Plugin deactivated along with its dependency", mlog.String("plugin_id", dependentID), mlog.String("dependency", id))
+			env.SetPluginError(dependentID, fmt.Sprintf("deactivated because its dependency %s was deactivated", id))
+			env.setDeactivatedWith(dependentID, id)
+		}
+	}
+}
+
+// activateDependents activates again, in activation order, the plugins deactivated along with a
+// dependency whose dependencies are satisfied now that the plugin with the given id is active.
+// Each plugin activated this way does the same for its own dependents.
+func (env *Environment) activateDependents(id string) {
+	var deactivated []*model.BundleInfo
+	env.registeredPlugins.Range(func(_, value any) bool {
+		rp := value.(registeredPlugin)
+		if rp.deactivatedWith != "" {
+			deactivated = append(deactivated, rp.BundleInfo)
+		}
+		return true
+	})
+
+	for _, tier := range SortByDependencies(deactivated) {
+		for _, plugin := range tier {
+			dependentID := plugin.Manifest.Id
+			if env.IsActive(dependentID) {
+				continue
+			}
+			if err := env.checkDependencies(plugin.Manifest); err != nil {
+				env.SetPluginError(dependentID, err.Error())
+				continue
+			}
+
+			if _, _, err := env.Activate(dependentID); err != nil {
+				env.logger.Error("Failed to activate plugin again along with its dependency", mlog.String("plugin_id", dependentID), mlog.String("dependency", id), mlog.Err(err))
+				continue
+			}
+			env.logger.Info("Plugin activated again along with its dependency", mlog.String("plugin_id", dependentID), mlog.String("dependency", id))
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func newDependencyTestBundle(id, version string, dependencies ...*model.ManifestDependency) *model.BundleInfo {
+	return &model.BundleInfo{
+		Manifest: &model.Manifest{
+			Id:           id,
+			Version:      version,
+			Dependencies: dependencies,
+		},
+	}
+}
+
+func bundleIDs(tier []*model.BundleInfo) []string {
+	ids := make([]string, 0, len(tier))
+	for _, bundle := range tier {
+		ids = append(ids, bundle.Manifest.Id)
+	}
+	return ids
+}
+
+func TestSortByDependencies(t *testing.T) {
+	t.Run("plugins are sorted after their dependencies", func(t *testing.T) {
+		tiers := SortByDependencies([]*model.BundleInfo{
+			newDependencyTestBundle("c", "1.0.0", &model.ManifestDependency{Id: "b"}, &model.ManifestDependency{Id: "a", Optional: true}),
+			newDependencyTestBundle("b", "1.0.0", &model.ManifestDependency{Id: "a"}),
+			newDependencyTestBundle("a", "1.0.0"),
+			newDependencyTestBundle("d", "1.0.0", &model.ManifestDependency{Id: "missing"}),
+		})
+
+		require.Len(t, tiers, 3)
+		assert.Equal(t, []string{"a", "d"}, bundleIDs(tiers[0]))
+		assert.Equal(t, []string{"b"}, bundleIDs(tiers[1]))
+		assert.Equal(t, []string{"c"}, bundleIDs(tiers[2]))
+	})
+
+	t.Run("plugins in a cycle are sorted last", func(t *testing.T) {
+		tiers := SortByDependencies([]*model.BundleInfo{
+			newDependencyTestBundle("a", "1.0.0", &model.ManifestDependency{Id: "b"}),
+			newDependencyTestBundle("b", "1.0.0", &model.ManifestDependency{Id: "a"}),
+			newDependencyTestBundle("c", "1.0.0"),
+		})
+
+		require.Len(t, tiers, 2)
+		assert.Equal(t, []string{"c"}, bundleIDs(tiers[0]))
+		assert.Equal(t, []string{"a", "b"}, bundleIDs(tiers[1]))
+	})
+}
+
+func newDependencyTestEnvironment(t *testing.T, active ...*model.BundleInfo) *Environment {
+	env := &Environment{logger: mlog.CreateConsoleTestLogger(t)}
+	for _, bundle := range active {
+		rp := newRegisteredPlugin(bundle)
+		rp.State = model.PluginStateRunning
+		env.registeredPlugins.Store(bundle.Manifest.Id, rp)
+	}
+	return env
+}
+
+func TestCheckDependencies(t *testing.T) {
+	env := newDependencyTestEnvironment(t,
+		newDependencyTestBundle("a", "1.2.0"),
+		newDependencyTestBundle("b", "2.0.0"),
+	)
+
+	testCases := []struct {
+		Title         string
+		Dependencies  []*model.ManifestDependency
+		ExpectedError string
+	}{
+		{"No dependencies", nil, ""},
+		{"Active dependencies", []*model.ManifestDependency{{Id: "a", Version: ">=1.0.0 <2.0.0"}, {Id: "b"}}, ""},
+		{"Missing optional dependency", []*model.ManifestDependency{{Id: "c", Optional: true}}, ""},
+		{"Missing required dependency", []*model.ManifestDependency{{Id: "c"}}, "required dependency c is not active"},
+		{"Unsatisfied version", []*model.ManifestDependency{{Id: "b", Version: "<2.0.0"}}, "dependency b version 2.0.0 doesn't satisfy <2.0.0"},
+		{"Unsatisfied version of an optional dependency", []*model.ManifestDependency{{Id: "a", Version: ">=1.3.0", Optional: true}}, "dependency a version 1.2.0 doesn't satisfy >=1.3.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Title, func(t *testing.T) {
+			err := env.checkDependencies(&model.Manifest{Id: "plugin", Dependencies: tc.Dependencies})
+			if tc.ExpectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.ExpectedError)
+			}
+		})
+	}
+}
+
+func TestDeactivateDependents(t *testing.T) {
+	env := newDependencyTestEnvironment(t,
+		newDependencyTestBundle("a", "1.0.0"),
+		newDependencyTestBundle("b", "1.0.0", &model.ManifestDependency{Id: "a"}),
+		newDependencyTestBundle("c", "1.0.0", &model.ManifestDependency{Id: "b"}),
+		newDependencyTestBundle("d", "1.0.0", &model.ManifestDependency{Id: "a", Optional: true}),
+	)
+
+	assert.Equal(t, []string{"b", "c"}, bundleIDs(env.activeDependents("a")))
+
+	require.True(t, env.Deactivate("a"))
+
+	assert.False(t, env.IsActive("a"))
+	assert.False(t, env.IsActive("b"))
+	assert.False(t, env.IsActive("c"))
+	assert.True(t, env.IsActive("d"))
+	assert.Equal(t, "deactivated because its dependency a was deactivated", env.getPluginError("b"))
+	assert.Empty(t, env.getPluginError("d"))
+}
+
+// installDependencyTestPlugin writes a webapp only plugin to the plugin directory, replacing any
+// previous version, as installing a plugin bundle does.
+func installDependencyTestPlugin(t *testing.T, env *Environment, bundle *model.BundleInfo) {
+	manifest := bundle.Manifest
+	manifest.Webapp = &model.ManifestWebapp{BundlePath: "webapp/dist/main.js"}
+
+	dir := filepath.Join(env.pluginDir, manifest.Id)
+	require.NoError(t, os.RemoveAll(dir))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "webapp", "dist"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "webapp", "dist", "main.js"), []byte("// "+manifest.Version), 0600))
+
+	manifestJSON, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.json"), manifestJSON, 0600))
+}
+
+func TestActivateDependents(t *testing.T) {
+	env := &Environment{
+		logger:          mlog.CreateConsoleTestLogger(t),
+		pluginDir:       t.TempDir(),
+		webappPluginDir: t.TempDir(),
+	}
+
+	installDependencyTestPlugin(t, env, newDependencyTestBundle("a", "1.0.0"))
+	installDependencyTestPlugin(t, env, newDependencyTestBundle("b", "1.0.0", &model.ManifestDependency{Id: "a", Version: "<2.0.0"}))
+	installDependencyTestPlugin(t, env, newDependencyTestBundle("c", "1.0.0", &model.ManifestDependency{Id: "b"}))
+	installDependencyTestPlugin(t, env, newDependencyTestBundle("d", "1.0.0", &model.ManifestDependency{Id: "a"}))
+	for _, id := range []string{"a", "b", "c", "d"} {
+		_, activated, err := env.Activate(id)
+		require.NoError(t, err)
+		require.True(t, activated)
+	}
+
+	// upgrade deactivates the plugin, then activates the new version, as installing a bundle does.
+	upgrade := func(t *testing.T, bundle *model.BundleInfo) {
+		t.Helper()
+		require.True(t, env.Deactivate(bundle.Manifest.Id))
+		env.RemovePlugin(bundle.Manifest.Id)
+		installDependencyTestPlugin(t, env, bundle)
+		_, activated, err := env.Activate(bundle.Manifest.Id)
+		require.NoError(t, err)
+		require.True(t, activated)
+	}
+
+	t.Run("upgrading a dependency activates its dependents again", func(t *testing.T) {
+		upgrade(t, newDependencyTestBundle("a", "1.1.0"))
+
+		for _, id := range []string{"a", "b", "c", "d"} {
+			assert.True(t, env.IsActive(id), id)
+			assert.Empty(t, env.getPluginError(id), id)
+		}
+	})
+
+	t.Run("dependents no longer satisfied stay deactivated", func(t *testing.T) {
+		upgrade(t, newDependencyTestBundle("a", "2.0.0"))
+
+		assert.False(t, env.IsActive("b"))
+		assert.False(t, env.IsActive("c"))
+		assert.True(t, env.IsActive("d"))
+		assert.Equal(t, "dependency a version 2.0.0 doesn't satisfy <2.0.0", env.getPluginError("b"))
+		assert.Equal(t, "required dependency b is not active", env.getPluginError("c"))
+	})
+
+	t.Run("dependents satisfied again are activated again", func(t *testing.T) {
+		upgrade(t, newDependencyTestBundle("a", "1.2.0"))
+
+		assert.True(t, env.IsActive("b"))
+		assert.True(t, env.IsActive("c"))
+		assert.Empty(t, env.getPluginError("b"))
+		assert.Empty(t, env.getPluginError("c"))
+	})
+
+	t.Run("dependents deactivated on purpose stay deactivated", func(t *testing.T) {
+		require.True(t, env.Deactivate("a"))
+		require.False(t, env.Deactivate("c"))
+
+		_, activated, err := env.Activate("a")
+		require.NoError(t, err)
+		require.True(t, activated)
+
+		assert.True(t, env.IsActive("b"))
+		assert.False(t, env.IsActive("c"))
+		assert.True(t, env.IsActive("d"))
+	})
+}
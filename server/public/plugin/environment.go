@@ -33,6 +33,9 @@ type registeredPlugin struct {
 	State      int
 	Error      string
 
+	// deactivatedWith is the dependency the plugin was deactivated along with, if any.
+	deactivatedWith string
+
 	supervisor *supervisor
 }
 
@@ -60,6 +63,8 @@ type Environment struct {
 	prepackagedPlugins               []*PrepackagedPlugin
 	transitionallyPrepackagedPlugins []*PrepackagedPlugin
 	prepackagedPluginsLock           sync.RWMutex
+	resourceLimits                   map[string]*model.PluginResourceLimits
+	resourceLimitsLock               sync.RWMutex
 }
 
 func NewEnvironment(
@@ -170,6 +175,14 @@ func (env *Environment) SetPluginError(id string, err string) {
 	}
 }
 
+func (env *Environment) setDeactivatedWith(id string, dependency string) {
+	if rp, ok := env.registeredPlugins.Load(id); ok {
+		p := rp.(registeredPlugin)
+		p.deactivatedWith = dependency
+		env.registeredPlugins.Store(id, p)
+	}
+}
+
 func (env *Environment) getPluginError(id string) string {
 	if rp, ok := env.registeredPlugins.Load(id); ok {
 		return rp.(registeredPlugin).Error
@@ -239,6 +252,7 @@ func (env *Environment) Statuses() (model.PluginStatuses, error) {
 			Name:        plugin.Manifest.Name,
 			Description: plugin.Manifest.Description,
 			Version:     plugin.Manifest.Version,
+			Permissions: plugin.Manifest.Permissions,
 		}
 
 		pluginStatuses = append(pluginStatuses, status)
@@ -305,6 +319,13 @@ func (env *Environment) startPluginServer(pluginInfo *model.BundleInfo, opts ...
 }
 
 func (env *Environment) Activate(id string) (manifest *model.Manifest, activated bool, reterr error) {
+	// Runs last, once the plugin is reported as running.
+	defer func() {
+		if activated {
+			env.activateDependents(id)
+		}
+	}()
+
 	defer func() {
 		if reterr != nil {
 			env.SetPluginError(id, reterr.Error())
@@ -351,6 +372,11 @@ func (env *Environment) Activate(id string) (manifest *model.Manifest, activated
 		return nil, false, err
 	}
 
+	err = env.checkDependencies(pluginInfo.Manifest)
+	if err != nil {
+		return nil, false, err
+	}
+
 	componentActivated := false
 
 	if pluginInfo.Manifest.HasWebapp() {
@@ -365,7 +391,7 @@ func (env *Environment) Activate(id string) (manifest *model.Manifest, activated
 	}
 
 	if pluginInfo.Manifest.HasServer() {
-		err = env.startPluginServer(pluginInfo, WithExecutableFromManifest(pluginInfo))
+		err = env.startPluginServer(pluginInfo, WithExecutableFromManifest(pluginInfo), WithResourceLimits(env.getResourceLimits(id)))
 		if err != nil {
 			return nil, false, err
 		}
@@ -445,8 +471,20 @@ func (env *Environment) RemovePlugin(id string) {
 	}
 }
 
-// Deactivates the plugin with the given id.
+// Deactivates the plugin with the given id, along with the active plugins requiring it. Those
+// are activated again once the plugin is, if it still satisfies them.
 func (env *Environment) Deactivate(id string) bool {
+	if env.IsActive(id) {
+		env.deactivateDependents(id)
+	}
+
+	// the plugin was deactivated on purpose, so it mustn't come back along with a dependency.
+	env.setDeactivatedWith(id, "")
+
+	return env.deactivate(id)
+}
+
+func (env *Environment) deactivate(id string) bool {
 	p, ok := env.registeredPlugins.Load(id)
 	if !ok {
 		return false
@@ -468,12 +506,22 @@ func (env *Environment) Deactivate(id string) bool {
 		rp.supervisor.Shutdown()
 	}
 
+	// don't keep exporting the last sample of a process that is gone.
+	if env.metrics != nil {
+		env.metrics.ClearPluginResourceUsage(id)
+	}
+
 	return true
 }
 
-// RestartPlugin deactivates, then activates the plugin with the given id.
+// RestartPlugin deactivates, then activates the plugin with the given id. The plugins requiring
+// it are activated again afterwards.
 func (env *Environment) RestartPlugin(id string) error {
-	env.Deactivate(id)
+	if env.IsActive(id) {
+		env.deactivateDependents(id)
+	}
+	env.deactivate(id)
+
 	_, _, err := env.Activate(id)
 	return err
 }
@@ -649,6 +697,37 @@ func (env *Environment) SetPrepackagedPlugins(plugins, transitionalPlugins []*Pr
 	env.prepackagedPluginsLock.Unlock()
 }
 
+// SetResourceLimits saves the resource limits of plugins, keyed by plugin id, in the environment.
+// They are applied to the plugins activated afterwards, and checked by the health check job.
+func (env *Environment) SetResourceLimits(limits map[string]*model.PluginResourceLimits) {
+	env.resourceLimitsLock.Lock()
+	env.resourceLimits = limits
+	env.resourceLimitsLock.Unlock()
+}
+
+// getResourceLimits returns the resource limits of a plugin, nil if the plugin has none.
+func (env *Environment) getResourceLimits(id string) *model.PluginResourceLimits {
+	env.resourceLimitsLock.RLock()
+	defer env.resourceLimitsLock.RUnlock()
+
+	return env.resourceLimits[id]
+}
+
+// GetResourceUsage samples the resources used by the server process of an active plugin.
+func (env *Environment) GetResourceUsage(id string) (*ResourceUsage, error) {
+	p, ok := env.registeredPlugins.Load(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	sup := p.(registeredPlugin).supervisor
+	if sup == nil {
+		return nil, fmt.Errorf("plugin %s has no active server process", id)
+	}
+
+	return sup.SampleResourceUsage()
+}
+
 func newRegisteredPlugin(bundle *model.BundleInfo) registeredPlugin {
 	state := model.PluginStateNotRunning
 	return registeredPlugin{State: state, BundleInfo: bundle}
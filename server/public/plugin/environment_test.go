@@ -74,3 +74,40 @@ func TestAvailablePlugins(t *testing.T) {
 		require.Len(t, bundles, 0)
 	})
 }
+
+// resourceUsageMetrics records the resource usage gauges set for the plugins.
+type resourceUsageMetrics struct {
+	usage map[string]float64
+}
+
+func (m *resourceUsageMetrics) ObservePluginHookDuration(pluginID, hookName string, success bool, elapsed float64) {
+}
+func (m *resourceUsageMetrics) ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64) {
+}
+func (m *resourceUsageMetrics) ObservePluginMultiHookDuration(elapsed float64) {}
+func (m *resourceUsageMetrics) ObservePluginAPIDuration(pluginID, apiName string, success bool, elapsed float64) {
+}
+func (m *resourceUsageMetrics) SetPluginMemoryUsage(pluginID string, bytes float64) {
+	m.usage[pluginID] = bytes
+}
+func (m *resourceUsageMetrics) SetPluginCPUUsage(pluginID string, percent float64) {}
+func (m *resourceUsageMetrics) SetPluginOpenFiles(pluginID string, count float64)  {}
+func (m *resourceUsageMetrics) ClearPluginResourceUsage(pluginID string) {
+	delete(m.usage, pluginID)
+}
+
+func TestDeactivateClearsResourceUsage(t *testing.T) {
+	metrics := &resourceUsageMetrics{usage: make(map[string]float64)}
+	env := newDependencyTestEnvironment(t,
+		newDependencyTestBundle("a", "1.0.0"),
+		newDependencyTestBundle("b", "1.0.0"),
+	)
+	env.metrics = metrics
+	metrics.SetPluginMemoryUsage("a", 1024)
+	metrics.SetPluginMemoryUsage("b", 2048)
+
+	require.True(t, env.Deactivate("a"))
+
+	require.NotContains(t, metrics.usage, "a")
+	require.Contains(t, metrics.usage, "b")
+}
@@ -7,6 +7,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
@@ -40,6 +42,7 @@ func (job *PluginHealthCheckJob) run() {
 			activePlugins := job.env.Active()
 			for _, plugin := range activePlugins {
 				job.CheckPlugin(plugin.Manifest.Id)
+				job.CheckResources(plugin.Manifest.Id)
 			}
 		case <-job.cancel:
 			return
@@ -57,6 +60,54 @@ func (job *PluginHealthCheckJob) CheckPlugin(id string) {
 	}
 
 	mlog.Warn("Health check failed for plugin", mlog.String("id", id), mlog.Err(err))
+	job.restartOrDeactivate(id)
+}
+
+// CheckResources samples the resources used by the plugin, exports them through the metrics,
+// then handles the breach of the plugin's resource limits according to their breach action.
+// Plugins are either restarted, or deactivated if they keep breaching their limits, as when
+// failing the health check, or directly deactivated.
+//
+// The memory and CPU limits are best-effort: they're only checked against a sample taken every
+// HealthCheckInterval, so a plugin can exceed them between two samples, and the CPU usage is
+// averaged over the interval. Only the open files are capped on the plugin process itself.
+func (job *PluginHealthCheckJob) CheckResources(id string) {
+	usage, err := job.env.GetResourceUsage(id)
+	if err != nil {
+		if !errors.Is(err, ErrResourceUsageUnsupported) {
+			mlog.Debug("Failed to sample the resource usage of plugin", mlog.String("id", id), mlog.Err(err))
+		}
+		return
+	}
+
+	if job.env.metrics != nil {
+		job.env.metrics.SetPluginMemoryUsage(id, float64(usage.MemoryBytes))
+		job.env.metrics.SetPluginCPUUsage(id, usage.CPUPercent)
+		job.env.metrics.SetPluginOpenFiles(id, float64(usage.OpenFiles))
+	}
+
+	limits := job.env.getResourceLimits(id)
+	breach := usage.exceededLimit(limits)
+	if breach == "" {
+		return
+	}
+
+	mlog.Warn("Plugin exceeded its resource limits", mlog.String("id", id), mlog.String("breach", breach))
+
+	switch *limits.BreachAction {
+	case model.PluginResourceBreachActionRestart:
+		job.restartOrDeactivate(id)
+	case model.PluginResourceBreachActionDeactivate:
+		mlog.Debug("Deactivating plugin due to exceeded resource limits", mlog.String("id", id))
+		job.env.Deactivate(id)
+		job.env.setPluginState(id, model.PluginStateFailedToStayRunning)
+		job.env.SetPluginError(id, "deactivated because it exceeded its resource limits: "+breach)
+	}
+}
+
+// restartOrDeactivate restarts a failing plugin, or deactivates it if it failed too many times
+// recently.
+func (job *PluginHealthCheckJob) restartOrDeactivate(id string) {
 	timestamps := job.getStoredTimestamps(id)
 	timestamps = append(timestamps, time.Now())
 
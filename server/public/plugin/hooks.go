@@ -64,6 +64,43 @@ const (
 	GenerateSupportDataID                     = 45
 	OnSAMLLoginID                             = 46
 	EmailNotificationWillBeSentID             = 47
+	ChannelWillBeUpdatedID                    = 48
+	ChannelHasBeenUpdatedID                   = 49
+	ChannelWillBeArchivedID                   = 50
+	ChannelHasBeenArchivedID                  = 51
+	ChannelHasBeenRestoredID                  = 52
+	ChannelHasBeenDeletedID                   = 53
+	TeamHasBeenCreatedID                      = 54
+	TeamWillBeUpdatedID                       = 55
+	TeamHasBeenUpdatedID                      = 56
+	TeamWillBeArchivedID                      = 57
+	TeamHasBeenArchivedID                     = 58
+	TeamHasBeenRestoredID                     = 59
+	UserHasBeenUpdatedID                      = 60
+	UserRolesHaveChangedID                    = 61
+	MessageHasBeenPinnedID                    = 62
+	MessageHasBeenUnpinnedID                  = 63
+	MessageHasBeenAcknowledgedID              = 64
+	SearchEngineIndexPostID                   = 65
+	SearchEngineDeletePostID                  = 66
+	SearchEngineSearchPostsID                 = 67
+	SearchEngineIndexFileID                   = 68
+	SearchEngineDeleteFileID                  = 69
+	SearchEngineSearchFilesID                 = 70
+	SearchEngineIndexChannelID                = 71
+	SearchEngineDeleteChannelID               = 72
+	SearchEngineSearchChannelsID              = 73
+	SearchEngineIndexUserID                   = 74
+	SearchEngineDeleteUserID                  = 75
+	SearchEngineSearchUsersInChannelID        = 76
+	SearchEngineSearchUsersInTeamID           = 77
+	SearchEnginePurgeIndexesID                = 78
+	SearchEngineDeleteChannelPostsID          = 79
+	SearchEngineDeleteUserPostsID             = 80
+	SearchEngineDeletePostFilesID             = 81
+	SearchEngineDeleteUserFilesID             = 82
+	SearchEngineDeleteFilesBeforeID           = 83
+	SearchEngineDeletePostsBeforeID           = 84
 	TotalHooksID                              = iota
 )
 
@@ -418,4 +455,244 @@ type Hooks interface {
 	//
 	// Minimum server version: 10.7
 	OnSAMLLogin(c *Context, user *model.User, assertion *saml2.AssertionInfo) error
+
+	// ChannelWillBeUpdated is invoked before the update of a channel is committed to the database.
+	//
+	// To reject the update, return a non-empty string describing why the update was rejected.
+	// To allow the update, return an empty string.
+	//
+	// Note that this method will be called for channels updated by plugins, including the plugin
+	// that updated the channel.
+	//
+	// Minimum server version: 11.3
+	ChannelWillBeUpdated(c *Context, newChannel, oldChannel *model.Channel) string
+
+	// ChannelHasBeenUpdated is invoked after the update of a channel has been committed to the database.
+	//
+	// Minimum server version: 11.3
+	ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel)
+
+	// ChannelWillBeArchived is invoked before a channel is archived.
+	//
+	// To reject the archival, return a non-empty string describing why the archival was rejected.
+	// To allow the archival, return an empty string.
+	//
+	// Minimum server version: 11.3
+	ChannelWillBeArchived(c *Context, channel *model.Channel) string
+
+	// ChannelHasBeenArchived is invoked after a channel has been archived.
+	//
+	// Minimum server version: 11.3
+	ChannelHasBeenArchived(c *Context, channel *model.Channel)
+
+	// ChannelHasBeenRestored is invoked after an archived channel has been restored.
+	//
+	// Minimum server version: 11.3
+	ChannelHasBeenRestored(c *Context, channel *model.Channel)
+
+	// ChannelHasBeenDeleted is invoked after a channel, its members and its posts have been
+	// permanently deleted from the database.
+	//
+	// Minimum server version: 11.3
+	ChannelHasBeenDeleted(c *Context, channel *model.Channel)
+
+	// TeamHasBeenCreated is invoked after the team has been committed to the database.
+	//
+	// Minimum server version: 11.3
+	TeamHasBeenCreated(c *Context, team *model.Team)
+
+	// TeamWillBeUpdated is invoked before the update of a team is committed to the database.
+	//
+	// To reject the update, return a non-empty string describing why the update was rejected.
+	// To allow the update, return an empty string.
+	//
+	// Minimum server version: 11.3
+	TeamWillBeUpdated(c *Context, newTeam, oldTeam *model.Team) string
+
+	// TeamHasBeenUpdated is invoked after the update of a team has been committed to the database.
+	//
+	// Minimum server version: 11.3
+	TeamHasBeenUpdated(c *Context, newTeam, oldTeam *model.Team)
+
+	// TeamWillBeArchived is invoked before a team is archived.
+	//
+	// To reject the archival, return a non-empty string describing why the archival was rejected.
+	// To allow the archival, return an empty string.
+	//
+	// Minimum server version: 11.3
+	TeamWillBeArchived(c *Context, team *model.Team) string
+
+	// TeamHasBeenArchived is invoked after a team has been archived.
+	//
+	// Minimum server version: 11.3
+	TeamHasBeenArchived(c *Context, team *model.Team)
+
+	// TeamHasBeenRestored is invoked after an archived team has been restored.
+	//
+	// Minimum server version: 11.3
+	TeamHasBeenRestored(c *Context, team *model.Team)
+
+	// UserHasBeenUpdated is invoked after the update of a user has been committed to the database.
+	// Role changes are reported by UserRolesHaveChanged instead.
+	//
+	// Minimum server version: 11.3
+	UserHasBeenUpdated(c *Context, newUser, oldUser *model.User)
+
+	// UserRolesHaveChanged is invoked after the system roles of a user have been updated.
+	// The user holds the new roles.
+	//
+	// Minimum server version: 11.3
+	UserRolesHaveChanged(c *Context, user *model.User, oldRoles string)
+
+	// MessageHasBeenPinned is invoked after a post has been pinned to its channel.
+	//
+	// Minimum server version: 11.3
+	MessageHasBeenPinned(c *Context, post *model.Post)
+
+	// MessageHasBeenUnpinned is invoked after a post has been unpinned from its channel.
+	//
+	// Minimum server version: 11.3
+	MessageHasBeenUnpinned(c *Context, post *model.Post)
+
+	// MessageHasBeenAcknowledged is invoked after a user acknowledged a post requesting an
+	// acknowledgement.
+	//
+	// Minimum server version: 11.3
+	MessageHasBeenAcknowledged(c *Context, post *model.Post, acknowledgement *model.PostAcknowledgement)
+
+	// SearchEngineIndexPost is invoked when a post must be added to or updated in the index of the
+	// search engine registered by the plugin with API.RegisterSearchEngine.
+	//
+	// Minimum server version: 11.3
+	SearchEngineIndexPost(c *Context, post *model.Post, teamID string) error
+
+	// SearchEngineDeletePost is invoked when a post must be removed from the index of the search
+	// engine registered by the plugin.
+	//
+	// Minimum server version: 11.3
+	SearchEngineDeletePost(c *Context, post *model.Post) error
+
+	// SearchEngineSearchPosts is invoked to search the posts of the given channels with the search
+	// engine registered by the plugin. It returns the IDs of the matching posts and the terms
+	// matched in each of them.
+	//
+	// The server falls back to the database search if an error is returned.
+	//
+	// Minimum server version: 11.3
+	SearchEngineSearchPosts(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, error)
+
+	// SearchEngineIndexFile is invoked when a file must be added to or updated in the index of the
+	// search engine registered by the plugin.
+	//
+	// Minimum server version: 11.3
+	SearchEngineIndexFile(c *Context, file *model.FileInfo, channelID string) error
+
+	// SearchEngineDeleteFile is invoked when a file must be removed from the index of the search
+	// engine registered by the plugin.
+	//
+	// Minimum server version: 11.3
+	SearchEngineDeleteFile(c *Context, fileID string) error
+
+	// SearchEngineSearchFiles is invoked to search the files of the given channels with the search
+	// engine registered by the plugin. It returns the IDs of the matching files.
+	//
+	// The server falls back to the database search if an error is returned.
+	//
+	// Minimum server version: 11.3
+	SearchEngineSearchFiles(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, error)
+
+	// SearchEngineIndexChannel is invoked when a channel must be added to or updated in the index of
+	// the search engine registered by the plugin. The userIDs are only populated for private
+	// channels.
+	//
+	// Minimum server version: 11.3
+	SearchEngineIndexChannel(c *Context, channel *model.Channel, userIDs, teamMemberIDs []string) error
+
+	// SearchEngineDeleteChannel is invoked when a channel must be removed from the index of the
+	// search engine registered by the plugin.
+	//
+	// Minimum server version: 11.3
+	SearchEngineDeleteChannel(c *Context, channel *model.Channel) error
+
+	// SearchEngineSearchChannels is invoked to autocomplete the channels of a team visible to the
+	// user with the search engine registered by the plugin. It returns the IDs of the matching
+	// channels.
+	//
+	// The server falls back to the database search if an error is returned.
+	//
+	// Minimum server version: 11.3
+	SearchEngineSearchChannels(c *Context, teamID, userID, term string, isGuest, includeDeleted bool) ([]string, error)
+
+	// SearchEngineIndexUser is invoked when a user must be added to or updated in the index of the
+	// search engine registered by the plugin, along with the teams and channels of the user.
+	//
+	// Minimum server version: 11.3
+	SearchEngineIndexUser(c *Context, user *model.User, teamIDs, channelIDs []string) error
+
+	// SearchEngineDeleteUser is invoked when a user must be removed from the index of the search
+	// engine registered by the plugin.
+	//
+	// Minimum server version: 11.3
+	SearchEngineDeleteUser(c *Context, user *model.User) error
+
+	// SearchEngineSearchUsersInChannel is invoked to autocomplete the users of a channel with the
+	// search engine registered by the plugin. It returns the IDs of the matching users in the
+	// channel and of the matching users of the team outside of the channel.
+	//
+	// The server falls back to the database search if an error is returned.
+	//
+	// Minimum server version: 11.3
+	SearchEngineSearchUsersInChannel(c *Context, teamID, channelID string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, error)
+
+	// SearchEngineSearchUsersInTeam is invoked to search the users of a team, or of all the teams if
+	// the teamID is empty, with the search engine registered by the plugin. It returns the IDs of
+	// the matching users.
+	//
+	// The server falls back to the database search if an error is returned.
+	//
+	// Minimum server version: 11.3
+	SearchEngineSearchUsersInTeam(c *Context, teamID string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, error)
+
+	// SearchEnginePurgeIndexes is invoked when all the documents indexed by the search engine
+	// registered by the plugin must be removed, typically before indexing everything again.
+	//
+	// Minimum server version: 11.3
+	SearchEnginePurgeIndexes(c *Context) error
+
+	// SearchEngineDeleteChannelPosts is invoked when all the posts of a channel must be removed from
+	// the index of the search engine registered by the plugin.
+	//
+	// Minimum server version: 11.3
+	SearchEngineDeleteChannelPosts(c *Context, channelID string) error
+
+	// SearchEngineDeleteUserPosts is invoked when all the posts of a user must be removed from the
+	// index of the search engine registered by the plugin.
+	//
+	// Minimum server version: 11.3
+	SearchEngineDeleteUserPosts(c *Context, userID string) error
+
+	// SearchEngineDeletePostFiles is invoked when all the files attached to a post must be removed
+	// from the index of the search engine registered by the plugin.
+	//
+	// Minimum server version: 11.3
+	SearchEngineDeletePostFiles(c *Context, postID string) error
+
+	// SearchEngineDeleteUserFiles is invoked when all the files of a user must be removed from the
+	// index of the search engine registered by the plugin.
+	//
+	// Minimum server version: 11.3
+	SearchEngineDeleteUserFiles(c *Context, userID string) error
+
+	// SearchEngineDeleteFilesBefore is invoked when at most limit files created before endTime, in
+	// milliseconds, must be removed from the index of the search engine registered by the plugin.
+	//
+	// Minimum server version: 11.3
+	SearchEngineDeleteFilesBefore(c *Context, endTime int64, limit int64) error
+
+	// SearchEngineDeletePostsBefore is invoked by the data retention job when the posts created
+	// before cutoff, in milliseconds, must be removed from the index of the search engine
+	// registered by the plugin.
+	//
+	// Minimum server version: 11.3
+	SearchEngineDeletePostsBefore(c *Context, cutoff int64) error
 }
@@ -306,3 +306,249 @@ func (hooks *hooksTimerLayer) OnSAMLLogin(c *Context, user *model.User, assertio
 	hooks.recordTime(startTime, "OnSAMLLogin", _returnsA == nil)
 	return _returnsA
 }
+
+func (hooks *hooksTimerLayer) ChannelWillBeUpdated(c *Context, newChannel, oldChannel *model.Channel) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.ChannelWillBeUpdated(c, newChannel, oldChannel)
+	hooks.recordTime(startTime, "ChannelWillBeUpdated", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.ChannelHasBeenUpdated(c, newChannel, oldChannel)
+	hooks.recordTime(startTime, "ChannelHasBeenUpdated", true)
+}
+
+func (hooks *hooksTimerLayer) ChannelWillBeArchived(c *Context, channel *model.Channel) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.ChannelWillBeArchived(c, channel)
+	hooks.recordTime(startTime, "ChannelWillBeArchived", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) ChannelHasBeenArchived(c *Context, channel *model.Channel) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.ChannelHasBeenArchived(c, channel)
+	hooks.recordTime(startTime, "ChannelHasBeenArchived", true)
+}
+
+func (hooks *hooksTimerLayer) ChannelHasBeenRestored(c *Context, channel *model.Channel) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.ChannelHasBeenRestored(c, channel)
+	hooks.recordTime(startTime, "ChannelHasBeenRestored", true)
+}
+
+func (hooks *hooksTimerLayer) ChannelHasBeenDeleted(c *Context, channel *model.Channel) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.ChannelHasBeenDeleted(c, channel)
+	hooks.recordTime(startTime, "ChannelHasBeenDeleted", true)
+}
+
+func (hooks *hooksTimerLayer) TeamHasBeenCreated(c *Context, team *model.Team) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.TeamHasBeenCreated(c, team)
+	hooks.recordTime(startTime, "TeamHasBeenCreated", true)
+}
+
+func (hooks *hooksTimerLayer) TeamWillBeUpdated(c *Context, newTeam, oldTeam *model.Team) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.TeamWillBeUpdated(c, newTeam, oldTeam)
+	hooks.recordTime(startTime, "TeamWillBeUpdated", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) TeamHasBeenUpdated(c *Context, newTeam, oldTeam *model.Team) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.TeamHasBeenUpdated(c, newTeam, oldTeam)
+	hooks.recordTime(startTime, "TeamHasBeenUpdated", true)
+}
+
+func (hooks *hooksTimerLayer) TeamWillBeArchived(c *Context, team *model.Team) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.TeamWillBeArchived(c, team)
+	hooks.recordTime(startTime, "TeamWillBeArchived", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) TeamHasBeenArchived(c *Context, team *model.Team) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.TeamHasBeenArchived(c, team)
+	hooks.recordTime(startTime, "TeamHasBeenArchived", true)
+}
+
+func (hooks *hooksTimerLayer) TeamHasBeenRestored(c *Context, team *model.Team) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.TeamHasBeenRestored(c, team)
+	hooks.recordTime(startTime, "TeamHasBeenRestored", true)
+}
+
+func (hooks *hooksTimerLayer) UserHasBeenUpdated(c *Context, newUser, oldUser *model.User) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.UserHasBeenUpdated(c, newUser, oldUser)
+	hooks.recordTime(startTime, "UserHasBeenUpdated", true)
+}
+
+func (hooks *hooksTimerLayer) UserRolesHaveChanged(c *Context, user *model.User, oldRoles string) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.UserRolesHaveChanged(c, user, oldRoles)
+	hooks.recordTime(startTime, "UserRolesHaveChanged", true)
+}
+
+func (hooks *hooksTimerLayer) MessageHasBeenPinned(c *Context, post *model.Post) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.MessageHasBeenPinned(c, post)
+	hooks.recordTime(startTime, "MessageHasBeenPinned", true)
+}
+
+func (hooks *hooksTimerLayer) MessageHasBeenUnpinned(c *Context, post *model.Post) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.MessageHasBeenUnpinned(c, post)
+	hooks.recordTime(startTime, "MessageHasBeenUnpinned", true)
+}
+
+func (hooks *hooksTimerLayer) MessageHasBeenAcknowledged(c *Context, post *model.Post, acknowledgement *model.PostAcknowledgement) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.MessageHasBeenAcknowledged(c, post, acknowledgement)
+	hooks.recordTime(startTime, "MessageHasBeenAcknowledged", true)
+}
+
+func (hooks *hooksTimerLayer) SearchEngineIndexPost(c *Context, post *model.Post, teamID string) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineIndexPost(c, post, teamID)
+	hooks.recordTime(startTime, "SearchEngineIndexPost", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineDeletePost(c *Context, post *model.Post) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineDeletePost(c, post)
+	hooks.recordTime(startTime, "SearchEngineDeletePost", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineSearchPosts(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB, _returnsC := hooks.hooksImpl.SearchEngineSearchPosts(c, channels, searchParams, page, perPage)
+	hooks.recordTime(startTime, "SearchEngineSearchPosts", _returnsC == nil)
+	return _returnsA, _returnsB, _returnsC
+}
+
+func (hooks *hooksTimerLayer) SearchEngineIndexFile(c *Context, file *model.FileInfo, channelID string) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineIndexFile(c, file, channelID)
+	hooks.recordTime(startTime, "SearchEngineIndexFile", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineDeleteFile(c *Context, fileID string) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineDeleteFile(c, fileID)
+	hooks.recordTime(startTime, "SearchEngineDeleteFile", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineSearchFiles(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.SearchEngineSearchFiles(c, channels, searchParams, page, perPage)
+	hooks.recordTime(startTime, "SearchEngineSearchFiles", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (hooks *hooksTimerLayer) SearchEngineIndexChannel(c *Context, channel *model.Channel, userIDs, teamMemberIDs []string) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineIndexChannel(c, channel, userIDs, teamMemberIDs)
+	hooks.recordTime(startTime, "SearchEngineIndexChannel", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineDeleteChannel(c *Context, channel *model.Channel) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineDeleteChannel(c, channel)
+	hooks.recordTime(startTime, "SearchEngineDeleteChannel", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineSearchChannels(c *Context, teamID, userID, term string, isGuest, includeDeleted bool) ([]string, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.SearchEngineSearchChannels(c, teamID, userID, term, isGuest, includeDeleted)
+	hooks.recordTime(startTime, "SearchEngineSearchChannels", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (hooks *hooksTimerLayer) SearchEngineIndexUser(c *Context, user *model.User, teamIDs, channelIDs []string) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineIndexUser(c, user, teamIDs, channelIDs)
+	hooks.recordTime(startTime, "SearchEngineIndexUser", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineDeleteUser(c *Context, user *model.User) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineDeleteUser(c, user)
+	hooks.recordTime(startTime, "SearchEngineDeleteUser", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineSearchUsersInChannel(c *Context, teamID, channelID string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB, _returnsC := hooks.hooksImpl.SearchEngineSearchUsersInChannel(c, teamID, channelID, restrictedToChannels, term, options)
+	hooks.recordTime(startTime, "SearchEngineSearchUsersInChannel", _returnsC == nil)
+	return _returnsA, _returnsB, _returnsC
+}
+
+func (hooks *hooksTimerLayer) SearchEngineSearchUsersInTeam(c *Context, teamID string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.SearchEngineSearchUsersInTeam(c, teamID, restrictedToChannels, term, options)
+	hooks.recordTime(startTime, "SearchEngineSearchUsersInTeam", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (hooks *hooksTimerLayer) SearchEnginePurgeIndexes(c *Context) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEnginePurgeIndexes(c)
+	hooks.recordTime(startTime, "SearchEnginePurgeIndexes", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineDeleteChannelPosts(c *Context, channelID string) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineDeleteChannelPosts(c, channelID)
+	hooks.recordTime(startTime, "SearchEngineDeleteChannelPosts", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineDeleteUserPosts(c *Context, userID string) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineDeleteUserPosts(c, userID)
+	hooks.recordTime(startTime, "SearchEngineDeleteUserPosts", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineDeletePostFiles(c *Context, postID string) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineDeletePostFiles(c, postID)
+	hooks.recordTime(startTime, "SearchEngineDeletePostFiles", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineDeleteUserFiles(c *Context, userID string) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineDeleteUserFiles(c, userID)
+	hooks.recordTime(startTime, "SearchEngineDeleteUserFiles", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineDeleteFilesBefore(c *Context, endTime int64, limit int64) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineDeleteFilesBefore(c, endTime, limit)
+	hooks.recordTime(startTime, "SearchEngineDeleteFilesBefore", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineDeletePostsBefore(c *Context, cutoff int64) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineDeletePostsBefore(c, cutoff)
+	hooks.recordTime(startTime, "SearchEngineDeletePostsBefore", _returnsA == nil)
+	return _returnsA
+}
@@ -41,6 +41,26 @@ var excludedPluginHooks = []string{
 	"ServeHTTP",
 	"UploadData",
 	"ServeMetrics",
+	"SearchEngineIndexPost",
+	"SearchEngineDeletePost",
+	"SearchEngineSearchPosts",
+	"SearchEngineIndexFile",
+	"SearchEngineDeleteFile",
+	"SearchEngineSearchFiles",
+	"SearchEngineIndexChannel",
+	"SearchEngineDeleteChannel",
+	"SearchEngineSearchChannels",
+	"SearchEngineIndexUser",
+	"SearchEngineDeleteUser",
+	"SearchEngineSearchUsersInChannel",
+	"SearchEngineSearchUsersInTeam",
+	"SearchEnginePurgeIndexes",
+	"SearchEngineDeleteChannelPosts",
+	"SearchEngineDeleteUserPosts",
+	"SearchEngineDeletePostFiles",
+	"SearchEngineDeleteUserFiles",
+	"SearchEngineDeleteFilesBefore",
+	"SearchEngineDeletePostsBefore",
 }
 
 type IHookEntry struct {
@@ -95,6 +115,38 @@ func FieldListToNames(fieldList *ast.FieldList, variadicForm bool) string {
 	return strings.Join(result, ", ")
 }
 
+// FieldListToPermissionDenied returns the statements reporting appErr through the last error
+// of the returns, or as the error of the RPC call if the method doesn't return any error.
+func FieldListToPermissionDenied(structPrefix string, fieldList *ast.FieldList, fileset *token.FileSet) string {
+	if fieldList == nil {
+		return "return encodableError(appErr)"
+	}
+
+	errorName := ""
+	nextLetter := 'A'
+	for _, field := range fieldList.List {
+		typeNameBuffer := &bytes.Buffer{}
+		err := printer.Fprint(typeNameBuffer, fileset, field.Type)
+		if err != nil {
+			panic(err)
+		}
+
+		count := max(len(field.Names), 1)
+		for range count {
+			if typeNameBuffer.String() == "error" || typeNameBuffer.String() == "*model.AppError" {
+				errorName = string(nextLetter)
+			}
+			nextLetter++
+		}
+	}
+
+	if errorName == "" {
+		return "return encodableError(appErr)"
+	}
+
+	return structPrefix + errorName + " = appErr\nreturn nil"
+}
+
 func FieldListToEncodedErrors(structPrefix string, fieldList *ast.FieldList, fileset *token.FileSet) string {
 	result := []string{}
 	if fieldList == nil {
@@ -377,6 +429,9 @@ func (g *apiRPCClient) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
 }
 
 func (s *apiRPCServer) {{.Name}}(args *{{.Name | obscure}}Args, returns *{{.Name | obscure}}Returns) error {
+	if appErr := s.checkAPIPermission("{{.Name}}"); appErr != nil {
+		{{permissionDenied "returns." .Return}}
+	}
 	if hook, ok := s.impl.(interface {
 		{{.Name}}{{funcStyle .Params}} {{funcStyle .Return}}
 	}); ok {
@@ -495,6 +550,9 @@ func generateHooksGlue(info *PluginInterfaceInfo) {
 		"destruct": func(structPrefix string, fields *ast.FieldList) string {
 			return FieldListDestruct(structPrefix, fields, info.FileSet)
 		},
+		"permissionDenied": func(structPrefix string, fields *ast.FieldList) string {
+			return FieldListToPermissionDenied(structPrefix, fields, info.FileSet)
+		},
 		"shouldRecordSuccess": func(structPrefix string, fields *ast.FieldList) string {
 			return FieldListToRecordSuccess(structPrefix, fields)
 		},
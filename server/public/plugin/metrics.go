@@ -8,4 +8,8 @@ type metricsInterface interface {
 	ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64)
 	ObservePluginMultiHookDuration(elapsed float64)
 	ObservePluginAPIDuration(pluginID, apiName string, success bool, elapsed float64)
+	SetPluginMemoryUsage(pluginID string, bytes float64)
+	SetPluginCPUUsage(pluginID string, percent float64)
+	SetPluginOpenFiles(pluginID string, count float64)
+	ClearPluginResourceUsage(pluginID string)
 }
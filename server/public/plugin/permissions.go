@@ -0,0 +1,325 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// apiGroupAlwaysAllowed marks the API methods every plugin can call, whatever permissions it
+// declares. They only touch the state of the plugin itself or can't leak data.
+const apiGroupAlwaysAllowed = ""
+
+// apiMethodGroups maps every method of the API to the group of methods a plugin has to declare
+// in its manifest permissions to call it.
+var apiMethodGroups = map[string]string{
+	"LoadPluginConfiguration":    apiGroupAlwaysAllowed,
+	"GetPluginConfig":            apiGroupAlwaysAllowed,
+	"SavePluginConfig":           apiGroupAlwaysAllowed,
+	"GetBundlePath":              apiGroupAlwaysAllowed,
+	"GetLicense":                 apiGroupAlwaysAllowed,
+	"IsEnterpriseReady":          apiGroupAlwaysAllowed,
+	"GetServerVersion":           apiGroupAlwaysAllowed,
+	"GetSystemInstallDate":       apiGroupAlwaysAllowed,
+	"GetDiagnosticId":            apiGroupAlwaysAllowed,
+	"GetTelemetryId":             apiGroupAlwaysAllowed,
+	"GetPluginID":                apiGroupAlwaysAllowed,
+	"GetCloudLimits":             apiGroupAlwaysAllowed,
+	"RegisterCommand":            apiGroupAlwaysAllowed,
+	"UnregisterCommand":          apiGroupAlwaysAllowed,
+	"EnsureBotUser":              apiGroupAlwaysAllowed,
+	"RegisterCollectionAndTopic": apiGroupAlwaysAllowed,
+	"PublishWebSocketEvent":      apiGroupAlwaysAllowed,
+	"PublishPluginClusterEvent":  apiGroupAlwaysAllowed,
+	"OpenInteractiveDialog":      apiGroupAlwaysAllowed,
+	"PluginHTTP":                 apiGroupAlwaysAllowed,
+	"HasPermissionTo":            apiGroupAlwaysAllowed,
+	"HasPermissionToTeam":        apiGroupAlwaysAllowed,
+	"HasPermissionToChannel":     apiGroupAlwaysAllowed,
+	"RolesGrantPermission":       apiGroupAlwaysAllowed,
+	"LogDebug":                   apiGroupAlwaysAllowed,
+	"LogInfo":                    apiGroupAlwaysAllowed,
+	"LogError":                   apiGroupAlwaysAllowed,
+	"LogWarn":                    apiGroupAlwaysAllowed,
+	"LogAuditRec":                apiGroupAlwaysAllowed,
+	"LogAuditRecWithLevel":       apiGroupAlwaysAllowed,
+	"KVSet":                      apiGroupAlwaysAllowed,
+	"KVCompareAndSet":            apiGroupAlwaysAllowed,
+	"KVCompareAndDelete":         apiGroupAlwaysAllowed,
+	"KVSetWithOptions":           apiGroupAlwaysAllowed,
+	"KVSetWithExpiry":            apiGroupAlwaysAllowed,
+	"KVGet":                      apiGroupAlwaysAllowed,
+	"KVDelete":                   apiGroupAlwaysAllowed,
+	"KVDeleteAll":                apiGroupAlwaysAllowed,
+	"KVList":                     apiGroupAlwaysAllowed,
+
+	"GetConfig":            model.PluginAPIGroupConfigRead,
+	"GetUnsanitizedConfig": model.PluginAPIGroupConfigSecrets,
+	"SaveConfig":           model.PluginAPIGroupConfigWrite,
+
+	"GetUsers":                 model.PluginAPIGroupUsersRead,
+	"GetUsersByIds":            model.PluginAPIGroupUsersRead,
+	"GetUser":                  model.PluginAPIGroupUsersRead,
+	"GetUserByEmail":           model.PluginAPIGroupUsersRead,
+	"GetUserByUsername":        model.PluginAPIGroupUsersRead,
+	"GetUsersByUsernames":      model.PluginAPIGroupUsersRead,
+	"GetUsersInTeam":           model.PluginAPIGroupUsersRead,
+	"GetUsersInChannel":        model.PluginAPIGroupUsersRead,
+	"SearchUsers":              model.PluginAPIGroupUsersRead,
+	"GetUserStatus":            model.PluginAPIGroupUsersRead,
+	"GetUserStatusesByIds":     model.PluginAPIGroupUsersRead,
+	"GetPreferenceForUser":     model.PluginAPIGroupUsersRead,
+	"GetPreferencesForUser":    model.PluginAPIGroupUsersRead,
+	"GetProfileImage":          model.PluginAPIGroupUsersRead,
+	"GetLDAPUserAttributes":    model.PluginAPIGroupUsersRead,
+	"GetGuestAccessExpiry":     model.PluginAPIGroupUsersRead,
+	"CreateUser":               model.PluginAPIGroupUsersWrite,
+	"DeleteUser":               model.PluginAPIGroupUsersWrite,
+	"UpdateUser":               model.PluginAPIGroupUsersWrite,
+	"UpdateUserActive":         model.PluginAPIGroupUsersWrite,
+	"UpdateUserAuth":           model.PluginAPIGroupUsersWrite,
+	"UpdateUserStatus":         model.PluginAPIGroupUsersWrite,
+	"SetUserStatusTimedDND":    model.PluginAPIGroupUsersWrite,
+	"UpdateUserCustomStatus":   model.PluginAPIGroupUsersWrite,
+	"RemoveUserCustomStatus":   model.PluginAPIGroupUsersWrite,
+	"UpdatePreferencesForUser": model.PluginAPIGroupUsersWrite,
+	"DeletePreferencesForUser": model.PluginAPIGroupUsersWrite,
+	"SetProfileImage":          model.PluginAPIGroupUsersWrite,
+	"SetGuestAccessExpiry":     model.PluginAPIGroupUsersWrite,
+	"UpdateUserRoles":          model.PluginAPIGroupUsersRoles,
+	"UpdateTeamMemberRoles":    model.PluginAPIGroupUsersRoles,
+	"UpdateChannelMemberRoles": model.PluginAPIGroupUsersRoles,
+
+	"GetSession":            model.PluginAPIGroupSessions,
+	"CreateSession":         model.PluginAPIGroupSessions,
+	"ExtendSessionExpiry":   model.PluginAPIGroupSessions,
+	"RevokeSession":         model.PluginAPIGroupSessions,
+	"CreateUserAccessToken": model.PluginAPIGroupSessions,
+	"RevokeUserAccessToken": model.PluginAPIGroupSessions,
+
+	"GetTeams":                    model.PluginAPIGroupTeamsRead,
+	"GetTeam":                     model.PluginAPIGroupTeamsRead,
+	"GetTeamByName":               model.PluginAPIGroupTeamsRead,
+	"GetTeamsUnreadForUser":       model.PluginAPIGroupTeamsRead,
+	"SearchTeams":                 model.PluginAPIGroupTeamsRead,
+	"GetTeamsForUser":             model.PluginAPIGroupTeamsRead,
+	"GetTeamMembers":              model.PluginAPIGroupTeamsRead,
+	"GetTeamMember":               model.PluginAPIGroupTeamsRead,
+	"GetTeamMembersForUser":       model.PluginAPIGroupTeamsRead,
+	"GetTeamStats":                model.PluginAPIGroupTeamsRead,
+	"GetTeamIcon":                 model.PluginAPIGroupTeamsRead,
+	"CreateTeam":                  model.PluginAPIGroupTeamsWrite,
+	"DeleteTeam":                  model.PluginAPIGroupTeamsWrite,
+	"UpdateTeam":                  model.PluginAPIGroupTeamsWrite,
+	"CreateTeamMember":            model.PluginAPIGroupTeamsWrite,
+	"CreateTeamMembers":           model.PluginAPIGroupTeamsWrite,
+	"CreateTeamMembersGracefully": model.PluginAPIGroupTeamsWrite,
+	"DeleteTeamMember":            model.PluginAPIGroupTeamsWrite,
+	"SetTeamIcon":                 model.PluginAPIGroupTeamsWrite,
+	"RemoveTeamIcon":              model.PluginAPIGroupTeamsWrite,
+
+	"GetPublicChannelsForTeam":         model.PluginAPIGroupChannelsRead,
+	"GetChannel":                       model.PluginAPIGroupChannelsRead,
+	"GetChannelByName":                 model.PluginAPIGroupChannelsRead,
+	"GetChannelByNameForTeamName":      model.PluginAPIGroupChannelsRead,
+	"GetChannelsForTeamForUser":        model.PluginAPIGroupChannelsRead,
+	"GetChannelStats":                  model.PluginAPIGroupChannelsRead,
+	"SearchChannels":                   model.PluginAPIGroupChannelsRead,
+	"GetChannelSidebarCategories":      model.PluginAPIGroupChannelsRead,
+	"GetChannelMember":                 model.PluginAPIGroupChannelsRead,
+	"GetChannelMembers":                model.PluginAPIGroupChannelsRead,
+	"GetChannelMembersByIds":           model.PluginAPIGroupChannelsRead,
+	"GetChannelMembersForUser":         model.PluginAPIGroupChannelsRead,
+	"CreateChannel":                    model.PluginAPIGroupChannelsWrite,
+	"DeleteChannel":                    model.PluginAPIGroupChannelsWrite,
+	"UpdateChannel":                    model.PluginAPIGroupChannelsWrite,
+	"GetDirectChannel":                 model.PluginAPIGroupChannelsWrite,
+	"GetGroupChannel":                  model.PluginAPIGroupChannelsWrite,
+	"CreateChannelSidebarCategory":     model.PluginAPIGroupChannelsWrite,
+	"UpdateChannelSidebarCategories":   model.PluginAPIGroupChannelsWrite,
+	"AddChannelMember":                 model.PluginAPIGroupChannelsWrite,
+	"AddUserToChannel":                 model.PluginAPIGroupChannelsWrite,
+	"DeleteChannelMember":              model.PluginAPIGroupChannelsWrite,
+	"UpdateChannelMemberNotifications": model.PluginAPIGroupChannelsWrite,
+	"PatchChannelMembersNotifications": model.PluginAPIGroupChannelsWrite,
+
+	"SearchPostsInTeam":        model.PluginAPIGroupPostsRead,
+	"SearchPostsInTeamForUser": model.PluginAPIGroupPostsRead,
+	"GetReactions":             model.PluginAPIGroupPostsRead,
+	"GetPostThread":            model.PluginAPIGroupPostsRead,
+	"GetPost":                  model.PluginAPIGroupPostsRead,
+	"GetPostsSince":            model.PluginAPIGroupPostsRead,
+	"GetPostsAfter":            model.PluginAPIGroupPostsRead,
+	"GetPostsBefore":           model.PluginAPIGroupPostsRead,
+	"GetPostsForChannel":       model.PluginAPIGroupPostsRead,
+	"CreatePost":               model.PluginAPIGroupPostsWrite,
+	"UpdatePost":               model.PluginAPIGroupPostsWrite,
+	"DeletePost":               model.PluginAPIGroupPostsWrite,
+	"AddReaction":              model.PluginAPIGroupPostsWrite,
+	"RemoveReaction":           model.PluginAPIGroupPostsWrite,
+	"SendEphemeralPost":        model.PluginAPIGroupPostsWrite,
+	"UpdateEphemeralPost":      model.PluginAPIGroupPostsWrite,
+	"DeleteEphemeralPost":      model.PluginAPIGroupPostsWrite,
+	"PublishUserTyping":        model.PluginAPIGroupPostsWrite,
+	"SendPushNotification":     model.PluginAPIGroupPostsWrite,
+
+	"GetFileInfo":              model.PluginAPIGroupFilesRead,
+	"GetFileInfos":             model.PluginAPIGroupFilesRead,
+	"GetFile":                  model.PluginAPIGroupFilesRead,
+	"GetFileLink":              model.PluginAPIGroupFilesRead,
+	"ReadFile":                 model.PluginAPIGroupFilesRead,
+	"GetUploadSession":         model.PluginAPIGroupFilesRead,
+	"CopyFileInfos":            model.PluginAPIGroupFilesWrite,
+	"SetFileSearchableContent": model.PluginAPIGroupFilesWrite,
+	"UploadFile":               model.PluginAPIGroupFilesWrite,
+	"CreateUploadSession":      model.PluginAPIGroupFilesWrite,
+	"UploadData":               model.PluginAPIGroupFilesWrite,
+
+	"GetEmojiList":   model.PluginAPIGroupEmojisRead,
+	"GetEmojiByName": model.PluginAPIGroupEmojisRead,
+	"GetEmoji":       model.PluginAPIGroupEmojisRead,
+	"GetEmojiImage":  model.PluginAPIGroupEmojisRead,
+
+	"GetGroup":                          model.PluginAPIGroupGroupsRead,
+	"GetGroupByName":                    model.PluginAPIGroupGroupsRead,
+	"GetGroupMemberUsers":               model.PluginAPIGroupGroupsRead,
+	"GetGroupsBySource":                 model.PluginAPIGroupGroupsRead,
+	"GetGroupsForUser":                  model.PluginAPIGroupGroupsRead,
+	"GetGroupByRemoteID":                model.PluginAPIGroupGroupsRead,
+	"GetGroupSyncable":                  model.PluginAPIGroupGroupsRead,
+	"GetGroupSyncables":                 model.PluginAPIGroupGroupsRead,
+	"GetGroups":                         model.PluginAPIGroupGroupsRead,
+	"CreateGroup":                       model.PluginAPIGroupGroupsWrite,
+	"UpdateGroup":                       model.PluginAPIGroupGroupsWrite,
+	"DeleteGroup":                       model.PluginAPIGroupGroupsWrite,
+	"RestoreGroup":                      model.PluginAPIGroupGroupsWrite,
+	"UpsertGroupMember":                 model.PluginAPIGroupGroupsWrite,
+	"UpsertGroupMembers":                model.PluginAPIGroupGroupsWrite,
+	"DeleteGroupMember":                 model.PluginAPIGroupGroupsWrite,
+	"UpsertGroupSyncable":               model.PluginAPIGroupGroupsWrite,
+	"UpdateGroupSyncable":               model.PluginAPIGroupGroupsWrite,
+	"DeleteGroupSyncable":               model.PluginAPIGroupGroupsWrite,
+	"CreateDefaultSyncableMemberships":  model.PluginAPIGroupGroupsWrite,
+	"DeleteGroupConstrainedMemberships": model.PluginAPIGroupGroupsWrite,
+
+	"GetPlugins":      model.PluginAPIGroupPluginsManage,
+	"EnablePlugin":    model.PluginAPIGroupPluginsManage,
+	"DisablePlugin":   model.PluginAPIGroupPluginsManage,
+	"RemovePlugin":    model.PluginAPIGroupPluginsManage,
+	"GetPluginStatus": model.PluginAPIGroupPluginsManage,
+	"InstallPlugin":   model.PluginAPIGroupPluginsManage,
+
+	"GetBot":             model.PluginAPIGroupBotsRead,
+	"GetBots":            model.PluginAPIGroupBotsRead,
+	"CreateBot":          model.PluginAPIGroupBotsWrite,
+	"PatchBot":           model.PluginAPIGroupBotsWrite,
+	"UpdateBotActive":    model.PluginAPIGroupBotsWrite,
+	"PermanentDeleteBot": model.PluginAPIGroupBotsWrite,
+
+	"ExecuteSlashCommand": model.PluginAPIGroupCommands,
+	"CreateCommand":       model.PluginAPIGroupCommands,
+	"ListCommands":        model.PluginAPIGroupCommands,
+	"ListCustomCommands":  model.PluginAPIGroupCommands,
+	"ListPluginCommands":  model.PluginAPIGroupCommands,
+	"ListBuiltInCommands": model.PluginAPIGroupCommands,
+	"GetCommand":          model.PluginAPIGroupCommands,
+	"UpdateCommand":       model.PluginAPIGroupCommands,
+	"DeleteCommand":       model.PluginAPIGroupCommands,
+
+	"CreateOAuthApp": model.PluginAPIGroupOAuth,
+	"GetOAuthApp":    model.PluginAPIGroupOAuth,
+	"UpdateOAuthApp": model.PluginAPIGroupOAuth,
+	"DeleteOAuthApp": model.PluginAPIGroupOAuth,
+
+	"SendMail": model.PluginAPIGroupEmail,
+
+	"RequestTrialLicense": model.PluginAPIGroupLicense,
+
+	"RegisterPluginForSharedChannels":   model.PluginAPIGroupSharedChannels,
+	"UnregisterPluginForSharedChannels": model.PluginAPIGroupSharedChannels,
+	"ShareChannel":                      model.PluginAPIGroupSharedChannels,
+	"UpdateSharedChannel":               model.PluginAPIGroupSharedChannels,
+	"UnshareChannel":                    model.PluginAPIGroupSharedChannels,
+	"UpdateSharedChannelCursor":         model.PluginAPIGroupSharedChannels,
+	"SyncSharedChannel":                 model.PluginAPIGroupSharedChannels,
+	"InviteRemoteToChannel":             model.PluginAPIGroupSharedChannels,
+	"UninviteRemoteFromChannel":         model.PluginAPIGroupSharedChannels,
+
+	"CreatePropertyField":           model.PluginAPIGroupProperties,
+	"GetPropertyField":              model.PluginAPIGroupProperties,
+	"GetPropertyFields":             model.PluginAPIGroupProperties,
+	"UpdatePropertyField":           model.PluginAPIGroupProperties,
+	"DeletePropertyField":           model.PluginAPIGroupProperties,
+	"SearchPropertyFields":          model.PluginAPIGroupProperties,
+	"CountPropertyFields":           model.PluginAPIGroupProperties,
+	"CountPropertyFieldsForTarget":  model.PluginAPIGroupProperties,
+	"CreatePropertyValue":           model.PluginAPIGroupProperties,
+	"GetPropertyValue":              model.PluginAPIGroupProperties,
+	"GetPropertyValues":             model.PluginAPIGroupProperties,
+	"UpdatePropertyValue":           model.PluginAPIGroupProperties,
+	"UpsertPropertyValue":           model.PluginAPIGroupProperties,
+	"DeletePropertyValue":           model.PluginAPIGroupProperties,
+	"SearchPropertyValues":          model.PluginAPIGroupProperties,
+	"RegisterPropertyGroup":         model.PluginAPIGroupProperties,
+	"GetPropertyGroup":              model.PluginAPIGroupProperties,
+	"GetPropertyFieldByName":        model.PluginAPIGroupProperties,
+	"UpdatePropertyFields":          model.PluginAPIGroupProperties,
+	"UpdatePropertyValues":          model.PluginAPIGroupProperties,
+	"UpsertPropertyValues":          model.PluginAPIGroupProperties,
+	"DeletePropertyValuesForTarget": model.PluginAPIGroupProperties,
+	"DeletePropertyValuesForField":  model.PluginAPIGroupProperties,
+
+	"RegisterSearchEngine":   model.PluginAPIGroupSearchEngine,
+	"UnregisterSearchEngine": model.PluginAPIGroupSearchEngine,
+}
+
+// alwaysAllowedHooks are the hooks every plugin receives, whatever permissions it declares.
+var alwaysAllowedHooks = map[string]bool{
+	"OnActivate":            true,
+	"OnDeactivate":          true,
+	"OnConfigurationChange": true,
+	"OnInstall":             true,
+	"OnSendDailyTelemetry":  true,
+	"OnPluginClusterEvent":  true,
+	"ServeHTTP":             true,
+	"ServeMetrics":          true,
+	"ExecuteCommand":        true,
+	"Implemented":           true,
+}
+
+// isAPIMethodAllowed returns whether a plugin with the given permissions can call the API method.
+// Plugins without permissions can call every method. Methods missing from apiMethodGroups are
+// denied to plugins declaring permissions.
+func isAPIMethodAllowed(permissions *model.PluginPermissions, method string) bool {
+	if permissions == nil {
+		return true
+	}
+
+	group, ok := apiMethodGroups[method]
+	if !ok {
+		return false
+	}
+
+	return group == apiGroupAlwaysAllowed || permissions.HasAPIGroup(group)
+}
+
+// isHookAllowed returns whether a plugin with the given permissions receives the hook.
+func isHookAllowed(permissions *model.PluginPermissions, hook string) bool {
+	if permissions == nil || alwaysAllowedHooks[hook] {
+		return true
+	}
+
+	return permissions.HasHook(hook)
+}
+
+// checkAPIPermission returns an error if the plugin served by s is not allowed to call the API method.
+func (s *apiRPCServer) checkAPIPermission(method string) *model.AppError {
+	if isAPIMethodAllowed(s.permissions, method) {
+		return nil
+	}
+
+	return model.NewAppError(method, "plugin.api.permission_denied.app_error", map[string]any{"Method": method, "Group": apiMethodGroups[method]}, "the "+apiMethodGroups[method]+" API permission is missing from the plugin manifest", http.StatusForbidden)
+}
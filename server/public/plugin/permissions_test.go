@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// permissionsTestAPI implements the few API methods called by the permissions tests.
+type permissionsTestAPI struct {
+	API
+	user *model.User
+}
+
+func (api *permissionsTestAPI) GetUser(userID string) (*model.User, *model.AppError) {
+	return api.user, nil
+}
+
+func (api *permissionsTestAPI) GetUnsanitizedConfig() *model.Config {
+	return &model.Config{}
+}
+
+func (api *permissionsTestAPI) KVGet(key string) ([]byte, *model.AppError) {
+	return []byte("value"), nil
+}
+
+func TestAPIMethodGroups(t *testing.T) {
+	apiType := reflect.TypeFor[API]()
+
+	t.Run("every API method belongs to a group", func(t *testing.T) {
+		for i := 0; i < apiType.NumMethod(); i++ {
+			method := apiType.Method(i).Name
+			_, ok := apiMethodGroups[method]
+			assert.True(t, ok, "API method %s is missing from apiMethodGroups", method)
+		}
+	})
+
+	t.Run("every group exists", func(t *testing.T) {
+		groups := model.PluginAPIGroups()
+		for method, group := range apiMethodGroups {
+			_, ok := apiType.MethodByName(method)
+			assert.True(t, ok, "apiMethodGroups holds unknown API method %s", method)
+			if group != apiGroupAlwaysAllowed {
+				assert.True(t, slices.Contains(groups, group), "API method %s belongs to unknown group %s", method, group)
+			}
+		}
+	})
+}
+
+func TestCheckAPIPermission(t *testing.T) {
+	user := &model.User{Id: model.NewId()}
+
+	t.Run("plugins without permissions can call every method", func(t *testing.T) {
+		api := &permissionsTestAPI{user: user}
+
+		server := &apiRPCServer{impl: api}
+		returns := &Z_GetUserReturns{}
+		require.NoError(t, server.GetUser(&Z_GetUserArgs{A: user.Id}, returns))
+		assert.Nil(t, returns.B)
+		assert.Equal(t, user, returns.A)
+	})
+
+	t.Run("plugins can call the methods of the declared groups", func(t *testing.T) {
+		api := &permissionsTestAPI{user: user}
+
+		server := &apiRPCServer{impl: api, permissions: &model.PluginPermissions{API: []string{model.PluginAPIGroupUsersRead}}}
+		returns := &Z_GetUserReturns{}
+		require.NoError(t, server.GetUser(&Z_GetUserArgs{A: user.Id}, returns))
+		assert.Nil(t, returns.B)
+		assert.Equal(t, user, returns.A)
+	})
+
+	t.Run("plugins can't call the methods of undeclared groups", func(t *testing.T) {
+		api := &permissionsTestAPI{user: user}
+
+		server := &apiRPCServer{impl: api, permissions: &model.PluginPermissions{API: []string{model.PluginAPIGroupPostsRead}}}
+		returns := &Z_GetUserReturns{}
+		require.NoError(t, server.GetUser(&Z_GetUserArgs{A: user.Id}, returns))
+		require.NotNil(t, returns.B)
+		assert.Equal(t, "plugin.api.permission_denied.app_error", returns.B.Id)
+		assert.Equal(t, http.StatusForbidden, returns.B.StatusCode)
+		assert.Nil(t, returns.A)
+	})
+
+	t.Run("methods without an error are denied through the RPC call", func(t *testing.T) {
+		api := &permissionsTestAPI{user: user}
+
+		server := &apiRPCServer{impl: api, permissions: &model.PluginPermissions{}}
+		err := server.GetUnsanitizedConfig(&Z_GetUnsanitizedConfigArgs{}, &Z_GetUnsanitizedConfigReturns{})
+		require.Error(t, err)
+		var appErr *model.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "plugin.api.permission_denied.app_error", appErr.Id)
+	})
+
+	t.Run("reading the config doesn't grant its secrets", func(t *testing.T) {
+		api := &permissionsTestAPI{user: user}
+
+		server := &apiRPCServer{impl: api, permissions: &model.PluginPermissions{API: []string{model.PluginAPIGroupConfigRead}}}
+		err := server.GetUnsanitizedConfig(&Z_GetUnsanitizedConfigArgs{}, &Z_GetUnsanitizedConfigReturns{})
+		require.Error(t, err)
+
+		server = &apiRPCServer{impl: api, permissions: &model.PluginPermissions{API: []string{model.PluginAPIGroupConfigSecrets}}}
+		returns := &Z_GetUnsanitizedConfigReturns{}
+		require.NoError(t, server.GetUnsanitizedConfig(&Z_GetUnsanitizedConfigArgs{}, returns))
+		assert.NotNil(t, returns.A)
+	})
+
+	t.Run("always allowed methods need no permissions", func(t *testing.T) {
+		api := &permissionsTestAPI{}
+
+		server := &apiRPCServer{impl: api, permissions: &model.PluginPermissions{}}
+		returns := &Z_KVGetReturns{}
+		require.NoError(t, server.KVGet(&Z_KVGetArgs{A: "key"}, returns))
+		assert.Nil(t, returns.B)
+		assert.Equal(t, []byte("value"), returns.A)
+	})
+}
+
+func TestIsHookAllowed(t *testing.T) {
+	permissions := &model.PluginPermissions{Hooks: []string{"MessageHasBeenPosted"}}
+
+	assert.True(t, isHookAllowed(nil, "UserHasLoggedIn"))
+	assert.True(t, isHookAllowed(permissions, "MessageHasBeenPosted"))
+	assert.True(t, isHookAllowed(permissions, "OnActivate"))
+	assert.True(t, isHookAllowed(permissions, "ServeHTTP"))
+	assert.False(t, isHookAllowed(permissions, "UserHasLoggedIn"))
+}
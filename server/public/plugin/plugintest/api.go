@@ -2372,6 +2372,38 @@ func (_m *API) GetGroupsForUser(userID string) ([]*model.Group, *model.AppError)
 	return r0, r1
 }
 
+// GetGuestAccessExpiry provides a mock function with given fields: userID
+func (_m *API) GetGuestAccessExpiry(userID string) (*model.GuestAccessExpiry, *model.AppError) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGuestAccessExpiry")
+	}
+
+	var r0 *model.GuestAccessExpiry
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) (*model.GuestAccessExpiry, *model.AppError)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.GuestAccessExpiry); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GuestAccessExpiry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetLDAPUserAttributes provides a mock function with given fields: userID, attributes
 func (_m *API) GetLDAPUserAttributes(userID string, attributes []string) (map[string]string, *model.AppError) {
 	ret := _m.Called(userID, attributes)
@@ -4693,6 +4725,26 @@ func (_m *API) RegisterPropertyGroup(name string) (*model.PropertyGroup, error)
 	return r0, r1
 }
 
+// RegisterSearchEngine provides a mock function with no fields
+func (_m *API) RegisterSearchEngine() *model.AppError {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterSearchEngine")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func() *model.AppError); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // RemovePlugin provides a mock function with given fields: id
 func (_m *API) RemovePlugin(id string) *model.AppError {
 	ret := _m.Called(id)
@@ -5223,6 +5275,38 @@ func (_m *API) SetFileSearchableContent(fileID string, content string) *model.Ap
 	return r0
 }
 
+// SetGuestAccessExpiry provides a mock function with given fields: userID, expiresAt
+func (_m *API) SetGuestAccessExpiry(userID string, expiresAt int64) (*model.GuestAccessExpiry, *model.AppError) {
+	ret := _m.Called(userID, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetGuestAccessExpiry")
+	}
+
+	var r0 *model.GuestAccessExpiry
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, int64) (*model.GuestAccessExpiry, *model.AppError)); ok {
+		return rf(userID, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) *model.GuestAccessExpiry); ok {
+		r0 = rf(userID, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GuestAccessExpiry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) *model.AppError); ok {
+		r1 = rf(userID, expiresAt)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // SetProfileImage provides a mock function with given fields: userID, data
 func (_m *API) SetProfileImage(userID string, data []byte) *model.AppError {
 	ret := _m.Called(userID, data)
@@ -5397,6 +5481,26 @@ func (_m *API) UnregisterPluginForSharedChannels(pluginID string) error {
 	return r0
 }
 
+// UnregisterSearchEngine provides a mock function with no fields
+func (_m *API) UnregisterSearchEngine() *model.AppError {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnregisterSearchEngine")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func() *model.AppError); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // UnshareChannel provides a mock function with given fields: channelID
 func (_m *API) UnshareChannel(channelID string) (bool, error) {
 	ret := _m.Called(channelID)
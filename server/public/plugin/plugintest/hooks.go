@@ -22,11 +22,67 @@ type Hooks struct {
 	mock.Mock
 }
 
+// ChannelHasBeenArchived provides a mock function with given fields: c, channel
+func (_m *Hooks) ChannelHasBeenArchived(c *plugin.Context, channel *model.Channel) {
+	_m.Called(c, channel)
+}
+
 // ChannelHasBeenCreated provides a mock function with given fields: c, channel
 func (_m *Hooks) ChannelHasBeenCreated(c *plugin.Context, channel *model.Channel) {
 	_m.Called(c, channel)
 }
 
+// ChannelHasBeenDeleted provides a mock function with given fields: c, channel
+func (_m *Hooks) ChannelHasBeenDeleted(c *plugin.Context, channel *model.Channel) {
+	_m.Called(c, channel)
+}
+
+// ChannelHasBeenRestored provides a mock function with given fields: c, channel
+func (_m *Hooks) ChannelHasBeenRestored(c *plugin.Context, channel *model.Channel) {
+	_m.Called(c, channel)
+}
+
+// ChannelHasBeenUpdated provides a mock function with given fields: c, newChannel, oldChannel
+func (_m *Hooks) ChannelHasBeenUpdated(c *plugin.Context, newChannel *model.Channel, oldChannel *model.Channel) {
+	_m.Called(c, newChannel, oldChannel)
+}
+
+// ChannelWillBeArchived provides a mock function with given fields: c, channel
+func (_m *Hooks) ChannelWillBeArchived(c *plugin.Context, channel *model.Channel) string {
+	ret := _m.Called(c, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelWillBeArchived")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel) string); ok {
+		r0 = rf(c, channel)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ChannelWillBeUpdated provides a mock function with given fields: c, newChannel, oldChannel
+func (_m *Hooks) ChannelWillBeUpdated(c *plugin.Context, newChannel *model.Channel, oldChannel *model.Channel) string {
+	ret := _m.Called(c, newChannel, oldChannel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelWillBeUpdated")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel, *model.Channel) string); ok {
+		r0 = rf(c, newChannel, oldChannel)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // ConfigurationWillBeSaved provides a mock function with given fields: newCfg
 func (_m *Hooks) ConfigurationWillBeSaved(newCfg *model.Config) (*model.Config, error) {
 	ret := _m.Called(newCfg)
@@ -209,16 +265,31 @@ func (_m *Hooks) Implemented() ([]string, error) {
 	return r0, r1
 }
 
+// MessageHasBeenAcknowledged provides a mock function with given fields: c, post, acknowledgement
+func (_m *Hooks) MessageHasBeenAcknowledged(c *plugin.Context, post *model.Post, acknowledgement *model.PostAcknowledgement) {
+	_m.Called(c, post, acknowledgement)
+}
+
 // MessageHasBeenDeleted provides a mock function with given fields: c, post
 func (_m *Hooks) MessageHasBeenDeleted(c *plugin.Context, post *model.Post) {
 	_m.Called(c, post)
 }
 
+// MessageHasBeenPinned provides a mock function with given fields: c, post
+func (_m *Hooks) MessageHasBeenPinned(c *plugin.Context, post *model.Post) {
+	_m.Called(c, post)
+}
+
 // MessageHasBeenPosted provides a mock function with given fields: c, post
 func (_m *Hooks) MessageHasBeenPosted(c *plugin.Context, post *model.Post) {
 	_m.Called(c, post)
 }
 
+// MessageHasBeenUnpinned provides a mock function with given fields: c, post
+func (_m *Hooks) MessageHasBeenUnpinned(c *plugin.Context, post *model.Post) {
+	_m.Called(c, post)
+}
+
 // MessageHasBeenUpdated provides a mock function with given fields: c, newPost, oldPost
 func (_m *Hooks) MessageHasBeenUpdated(c *plugin.Context, newPost *model.Post, oldPost *model.Post) {
 	_m.Called(c, newPost, oldPost)
@@ -574,6 +645,442 @@ func (_m *Hooks) RunDataRetention(nowTime int64, batchSize int64) (int64, error)
 	return r0, r1
 }
 
+// SearchEngineDeleteChannel provides a mock function with given fields: c, channel
+func (_m *Hooks) SearchEngineDeleteChannel(c *plugin.Context, channel *model.Channel) error {
+	ret := _m.Called(c, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineDeleteChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel) error); ok {
+		r0 = rf(c, channel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineDeleteChannelPosts provides a mock function with given fields: c, channelID
+func (_m *Hooks) SearchEngineDeleteChannelPosts(c *plugin.Context, channelID string) error {
+	ret := _m.Called(c, channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineDeleteChannelPosts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string) error); ok {
+		r0 = rf(c, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineDeleteFile provides a mock function with given fields: c, fileID
+func (_m *Hooks) SearchEngineDeleteFile(c *plugin.Context, fileID string) error {
+	ret := _m.Called(c, fileID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineDeleteFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string) error); ok {
+		r0 = rf(c, fileID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineDeleteFilesBefore provides a mock function with given fields: c, endTime, limit
+func (_m *Hooks) SearchEngineDeleteFilesBefore(c *plugin.Context, endTime int64, limit int64) error {
+	ret := _m.Called(c, endTime, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineDeleteFilesBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, int64, int64) error); ok {
+		r0 = rf(c, endTime, limit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineDeletePost provides a mock function with given fields: c, post
+func (_m *Hooks) SearchEngineDeletePost(c *plugin.Context, post *model.Post) error {
+	ret := _m.Called(c, post)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineDeletePost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Post) error); ok {
+		r0 = rf(c, post)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineDeletePostFiles provides a mock function with given fields: c, postID
+func (_m *Hooks) SearchEngineDeletePostFiles(c *plugin.Context, postID string) error {
+	ret := _m.Called(c, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineDeletePostFiles")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string) error); ok {
+		r0 = rf(c, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineDeletePostsBefore provides a mock function with given fields: c, cutoff
+func (_m *Hooks) SearchEngineDeletePostsBefore(c *plugin.Context, cutoff int64) error {
+	ret := _m.Called(c, cutoff)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineDeletePostsBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, int64) error); ok {
+		r0 = rf(c, cutoff)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineDeleteUser provides a mock function with given fields: c, user
+func (_m *Hooks) SearchEngineDeleteUser(c *plugin.Context, user *model.User) error {
+	ret := _m.Called(c, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineDeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.User) error); ok {
+		r0 = rf(c, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineDeleteUserFiles provides a mock function with given fields: c, userID
+func (_m *Hooks) SearchEngineDeleteUserFiles(c *plugin.Context, userID string) error {
+	ret := _m.Called(c, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineDeleteUserFiles")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string) error); ok {
+		r0 = rf(c, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineDeleteUserPosts provides a mock function with given fields: c, userID
+func (_m *Hooks) SearchEngineDeleteUserPosts(c *plugin.Context, userID string) error {
+	ret := _m.Called(c, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineDeleteUserPosts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string) error); ok {
+		r0 = rf(c, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineIndexChannel provides a mock function with given fields: c, channel, userIDs, teamMemberIDs
+func (_m *Hooks) SearchEngineIndexChannel(c *plugin.Context, channel *model.Channel, userIDs []string, teamMemberIDs []string) error {
+	ret := _m.Called(c, channel, userIDs, teamMemberIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineIndexChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel, []string, []string) error); ok {
+		r0 = rf(c, channel, userIDs, teamMemberIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineIndexFile provides a mock function with given fields: c, file, channelID
+func (_m *Hooks) SearchEngineIndexFile(c *plugin.Context, file *model.FileInfo, channelID string) error {
+	ret := _m.Called(c, file, channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineIndexFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.FileInfo, string) error); ok {
+		r0 = rf(c, file, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineIndexPost provides a mock function with given fields: c, post, teamID
+func (_m *Hooks) SearchEngineIndexPost(c *plugin.Context, post *model.Post, teamID string) error {
+	ret := _m.Called(c, post, teamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineIndexPost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Post, string) error); ok {
+		r0 = rf(c, post, teamID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineIndexUser provides a mock function with given fields: c, user, teamIDs, channelIDs
+func (_m *Hooks) SearchEngineIndexUser(c *plugin.Context, user *model.User, teamIDs []string, channelIDs []string) error {
+	ret := _m.Called(c, user, teamIDs, channelIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineIndexUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.User, []string, []string) error); ok {
+		r0 = rf(c, user, teamIDs, channelIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEnginePurgeIndexes provides a mock function with given fields: c
+func (_m *Hooks) SearchEnginePurgeIndexes(c *plugin.Context) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEnginePurgeIndexes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineSearchChannels provides a mock function with given fields: c, teamID, userID, term, isGuest, includeDeleted
+func (_m *Hooks) SearchEngineSearchChannels(c *plugin.Context, teamID string, userID string, term string, isGuest bool, includeDeleted bool) ([]string, error) {
+	ret := _m.Called(c, teamID, userID, term, isGuest, includeDeleted)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineSearchChannels")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, string, string, bool, bool) ([]string, error)); ok {
+		return rf(c, teamID, userID, term, isGuest, includeDeleted)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, string, string, bool, bool) []string); ok {
+		r0 = rf(c, teamID, userID, term, isGuest, includeDeleted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, string, string, string, bool, bool) error); ok {
+		r1 = rf(c, teamID, userID, term, isGuest, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchEngineSearchFiles provides a mock function with given fields: c, channels, searchParams, page, perPage
+func (_m *Hooks) SearchEngineSearchFiles(c *plugin.Context, channels model.ChannelList, searchParams []*model.SearchParams, page int, perPage int) ([]string, error) {
+	ret := _m.Called(c, channels, searchParams, page, perPage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineSearchFiles")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) ([]string, error)); ok {
+		return rf(c, channels, searchParams, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) []string); ok {
+		r0 = rf(c, channels, searchParams, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) error); ok {
+		r1 = rf(c, channels, searchParams, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchEngineSearchPosts provides a mock function with given fields: c, channels, searchParams, page, perPage
+func (_m *Hooks) SearchEngineSearchPosts(c *plugin.Context, channels model.ChannelList, searchParams []*model.SearchParams, page int, perPage int) ([]string, model.PostSearchMatches, error) {
+	ret := _m.Called(c, channels, searchParams, page, perPage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineSearchPosts")
+	}
+
+	var r0 []string
+	var r1 model.PostSearchMatches
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) ([]string, model.PostSearchMatches, error)); ok {
+		return rf(c, channels, searchParams, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) []string); ok {
+		r0 = rf(c, channels, searchParams, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) model.PostSearchMatches); ok {
+		r1 = rf(c, channels, searchParams, page, perPage)
+	} else {
+		r1 = ret.Get(1).(model.PostSearchMatches)
+	}
+
+	if rf, ok := ret.Get(2).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) error); ok {
+		r2 = rf(c, channels, searchParams, page, perPage)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SearchEngineSearchUsersInChannel provides a mock function with given fields: c, teamID, channelID, restrictedToChannels, term, options
+func (_m *Hooks) SearchEngineSearchUsersInChannel(c *plugin.Context, teamID string, channelID string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, error) {
+	ret := _m.Called(c, teamID, channelID, restrictedToChannels, term, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineSearchUsersInChannel")
+	}
+
+	var r0 []string
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, string, []string, string, *model.UserSearchOptions) ([]string, []string, error)); ok {
+		return rf(c, teamID, channelID, restrictedToChannels, term, options)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, string, []string, string, *model.UserSearchOptions) []string); ok {
+		r0 = rf(c, teamID, channelID, restrictedToChannels, term, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, string, string, []string, string, *model.UserSearchOptions) []string); ok {
+		r1 = rf(c, teamID, channelID, restrictedToChannels, term, options)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(*plugin.Context, string, string, []string, string, *model.UserSearchOptions) error); ok {
+		r2 = rf(c, teamID, channelID, restrictedToChannels, term, options)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SearchEngineSearchUsersInTeam provides a mock function with given fields: c, teamID, restrictedToChannels, term, options
+func (_m *Hooks) SearchEngineSearchUsersInTeam(c *plugin.Context, teamID string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, error) {
+	ret := _m.Called(c, teamID, restrictedToChannels, term, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineSearchUsersInTeam")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, []string, string, *model.UserSearchOptions) ([]string, error)); ok {
+		return rf(c, teamID, restrictedToChannels, term, options)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, []string, string, *model.UserSearchOptions) []string); ok {
+		r0 = rf(c, teamID, restrictedToChannels, term, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, string, []string, string, *model.UserSearchOptions) error); ok {
+		r1 = rf(c, teamID, restrictedToChannels, term, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ServeHTTP provides a mock function with given fields: c, w, r
 func (_m *Hooks) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Request) {
 	_m.Called(c, w, r)
@@ -584,6 +1091,62 @@ func (_m *Hooks) ServeMetrics(c *plugin.Context, w http.ResponseWriter, r *http.
 	_m.Called(c, w, r)
 }
 
+// TeamHasBeenArchived provides a mock function with given fields: c, team
+func (_m *Hooks) TeamHasBeenArchived(c *plugin.Context, team *model.Team) {
+	_m.Called(c, team)
+}
+
+// TeamHasBeenCreated provides a mock function with given fields: c, team
+func (_m *Hooks) TeamHasBeenCreated(c *plugin.Context, team *model.Team) {
+	_m.Called(c, team)
+}
+
+// TeamHasBeenRestored provides a mock function with given fields: c, team
+func (_m *Hooks) TeamHasBeenRestored(c *plugin.Context, team *model.Team) {
+	_m.Called(c, team)
+}
+
+// TeamHasBeenUpdated provides a mock function with given fields: c, newTeam, oldTeam
+func (_m *Hooks) TeamHasBeenUpdated(c *plugin.Context, newTeam *model.Team, oldTeam *model.Team) {
+	_m.Called(c, newTeam, oldTeam)
+}
+
+// TeamWillBeArchived provides a mock function with given fields: c, team
+func (_m *Hooks) TeamWillBeArchived(c *plugin.Context, team *model.Team) string {
+	ret := _m.Called(c, team)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TeamWillBeArchived")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Team) string); ok {
+		r0 = rf(c, team)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TeamWillBeUpdated provides a mock function with given fields: c, newTeam, oldTeam
+func (_m *Hooks) TeamWillBeUpdated(c *plugin.Context, newTeam *model.Team, oldTeam *model.Team) string {
+	ret := _m.Called(c, newTeam, oldTeam)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TeamWillBeUpdated")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Team, *model.Team) string); ok {
+		r0 = rf(c, newTeam, oldTeam)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // UserHasBeenCreated provides a mock function with given fields: c, user
 func (_m *Hooks) UserHasBeenCreated(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
@@ -594,6 +1157,11 @@ func (_m *Hooks) UserHasBeenDeactivated(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
 }
 
+// UserHasBeenUpdated provides a mock function with given fields: c, newUser, oldUser
+func (_m *Hooks) UserHasBeenUpdated(c *plugin.Context, newUser *model.User, oldUser *model.User) {
+	_m.Called(c, newUser, oldUser)
+}
+
 // UserHasJoinedChannel provides a mock function with given fields: c, channelMember, actor
 func (_m *Hooks) UserHasJoinedChannel(c *plugin.Context, channelMember *model.ChannelMember, actor *model.User) {
 	_m.Called(c, channelMember, actor)
@@ -619,6 +1187,11 @@ func (_m *Hooks) UserHasLoggedIn(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
 }
 
+// UserRolesHaveChanged provides a mock function with given fields: c, user, oldRoles
+func (_m *Hooks) UserRolesHaveChanged(c *plugin.Context, user *model.User, oldRoles string) {
+	_m.Called(c, user, oldRoles)
+}
+
 // UserWillLogIn provides a mock function with given fields: c, user
 func (_m *Hooks) UserWillLogIn(c *plugin.Context, user *model.User) string {
 	ret := _m.Called(c, user)
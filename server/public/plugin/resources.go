@@ -0,0 +1,162 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// clockTicksPerSecond is the unit of the CPU times of /proc/<pid>/stat. It is fixed to 100 by
+// the kernel ABI on the architectures we support.
+const clockTicksPerSecond = 100
+
+// ErrResourceUsageUnsupported is returned when the resource usage of plugin processes can't be
+// sampled on the current platform.
+var ErrResourceUsageUnsupported = errors.New("sampling the resource usage of plugins is not supported on this platform")
+
+// ResourceUsage is a sample of the resources used by the server process of a plugin.
+type ResourceUsage struct {
+	MemoryBytes uint64
+	// CPUPercent is the CPU time used since the previous sample, relative to a single core.
+	CPUPercent float64
+	OpenFiles  int
+}
+
+// exceededLimit describes the first of the limits exceeded by the usage, or returns an empty
+// string if the usage is within the limits.
+func (u *ResourceUsage) exceededLimit(limits *model.PluginResourceLimits) string {
+	if limits == nil {
+		return ""
+	}
+
+	if limits.MaxMemoryMB != nil && *limits.MaxMemoryMB > 0 && u.MemoryBytes > uint64(*limits.MaxMemoryMB)*1024*1024 {
+		return fmt.Sprintf("memory usage of %d MB exceeds the limit of %d MB", u.MemoryBytes/1024/1024, *limits.MaxMemoryMB)
+	}
+
+	if limits.MaxCPUPercent != nil && *limits.MaxCPUPercent > 0 && u.CPUPercent > float64(*limits.MaxCPUPercent) {
+		return fmt.Sprintf("CPU usage of %.0f%% exceeds the limit of %d%%", u.CPUPercent, *limits.MaxCPUPercent)
+	}
+
+	if limits.MaxOpenFiles != nil && *limits.MaxOpenFiles > 0 && u.OpenFiles > *limits.MaxOpenFiles {
+		return fmt.Sprintf("%d open files exceed the limit of %d", u.OpenFiles, *limits.MaxOpenFiles)
+	}
+
+	return ""
+}
+
+// WithResourceLimits restricts the resources of the plugin process launched by the supervisor.
+// It must come after the option setting the command of the plugin.
+//
+// The memory limit is a soft limit enforced by the Go runtime of the plugin, and the CPU limit
+// caps the number of threads running Go code at once. Both are additionally enforced by the
+// health check job, which samples the resource usage of plugins. The open files limit is
+// enforced by the kernel on Linux.
+func WithResourceLimits(limits *model.PluginResourceLimits) func(*supervisor, *plugin.ClientConfig) error {
+	return func(sup *supervisor, clientConfig *plugin.ClientConfig) error {
+		if limits == nil || clientConfig.Cmd == nil {
+			return nil
+		}
+
+		sup.resourceLimits = limits
+
+		var env []string
+		if limits.MaxMemoryMB != nil && *limits.MaxMemoryMB > 0 {
+			env = append(env, fmt.Sprintf("GOMEMLIMIT=%dMiB", *limits.MaxMemoryMB))
+		}
+		if limits.MaxCPUPercent != nil && *limits.MaxCPUPercent > 0 {
+			env = append(env, fmt.Sprintf("GOMAXPROCS=%d", int(math.Ceil(float64(*limits.MaxCPUPercent)/100))))
+		}
+
+		if len(env) > 0 {
+			if clientConfig.Cmd.Env == nil {
+				clientConfig.Cmd.Env = os.Environ()
+			}
+			clientConfig.Cmd.Env = append(clientConfig.Cmd.Env, env...)
+		}
+
+		return nil
+	}
+}
+
+// SampleResourceUsage samples the resources used by the plugin process.
+func (sup *supervisor) SampleResourceUsage() (*ResourceUsage, error) {
+	sup.usageLock.Lock()
+	defer sup.usageLock.Unlock()
+
+	if sup.pid == 0 {
+		return nil, errors.New("the process of the plugin is unknown")
+	}
+
+	cpuTime, memoryBytes, openFiles, err := readProcessResourceUsage(sup.pid)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &ResourceUsage{
+		MemoryBytes: memoryBytes,
+		OpenFiles:   openFiles,
+	}
+
+	now := time.Now()
+	if !sup.lastSampleTime.IsZero() {
+		if elapsed := now.Sub(sup.lastSampleTime); elapsed > 0 {
+			usage.CPUPercent = float64(cpuTime-sup.lastCPUTime) / float64(elapsed) * 100
+		}
+	}
+	sup.lastCPUTime = cpuTime
+	sup.lastSampleTime = now
+
+	return usage, nil
+}
+
+// parseProcStat returns the CPU time, user and system, from the content of /proc/<pid>/stat.
+func parseProcStat(stat string) (time.Duration, error) {
+	// The command name is enclosed in parentheses and may contain spaces.
+	end := strings.LastIndexByte(stat, ')')
+	if end == -1 {
+		return 0, errors.New("invalid process stat: missing command name")
+	}
+
+	// The fields following the command name start at the state, the 3rd field.
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 13 {
+		return 0, errors.New("invalid process stat: missing fields")
+	}
+
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid process stat: invalid user time")
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid process stat: invalid system time")
+	}
+
+	return time.Duration(utime+stime) * time.Second / clockTicksPerSecond, nil
+}
+
+// parseProcStatm returns the resident set size, in pages, from the content of /proc/<pid>/statm.
+func parseProcStatm(statm string) (uint64, error) {
+	fields := strings.Fields(statm)
+	if len(fields) < 2 {
+		return 0, errors.New("invalid process statm: missing fields")
+	}
+
+	rss, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid process statm: invalid resident set size")
+	}
+
+	return rss, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build linux
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// setProcessResourceLimits applies the limits enforced by the kernel to a running process.
+func setProcessResourceLimits(pid int, limits *model.PluginResourceLimits) error {
+	if limits.MaxOpenFiles != nil && *limits.MaxOpenFiles > 0 {
+		rlimit := &unix.Rlimit{Cur: uint64(*limits.MaxOpenFiles), Max: uint64(*limits.MaxOpenFiles)}
+		if err := unix.Prlimit(pid, unix.RLIMIT_NOFILE, rlimit, nil); err != nil {
+			return errors.Wrap(err, "failed to limit the open files")
+		}
+	}
+
+	return nil
+}
+
+// readProcessResourceUsage returns the CPU time, resident memory and open files of a process.
+func readProcessResourceUsage(pid int) (time.Duration, uint64, int, error) {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, 0, 0, errors.Wrap(err, "failed to read the process stat")
+	}
+	cpuTime, err := parseProcStat(string(stat))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	statm, err := os.ReadFile(fmt.Sprintf("/proc/%d/statm", pid))
+	if err != nil {
+		return 0, 0, 0, errors.Wrap(err, "failed to read the process statm")
+	}
+	rss, err := parseProcStatm(string(statm))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	fds, err := os.ReadDir(fmt.Sprintf("/proc/%d/fd", pid))
+	if err != nil {
+		return 0, 0, 0, errors.Wrap(err, "failed to list the process open files")
+	}
+
+	return cpuTime, rss * uint64(os.Getpagesize()), len(fds), nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build linux
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/utils"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func TestSupervisorResourceLimits(t *testing.T) {
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend := filepath.Join(dir, "backend.exe")
+	utils.CompileGo(t, `
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`, backend)
+
+	err = os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(`{"id": "foo", "server": {"executable": "backend.exe"}}`), 0600)
+	require.NoError(t, err)
+
+	limits := &model.PluginResourceLimits{
+		MaxMemoryMB:   model.NewPointer(0),
+		MaxCPUPercent: model.NewPointer(0),
+		MaxOpenFiles:  model.NewPointer(128),
+		BreachAction:  model.NewPointer(model.PluginResourceBreachActionLog),
+	}
+
+	bundle := model.BundleInfoForPath(dir)
+	logger := mlog.CreateConsoleTestLogger(t)
+	supervisor, err := newSupervisor(bundle, nil, nil, logger, nil, WithExecutableFromManifest(bundle), WithResourceLimits(limits))
+	require.NoError(t, err)
+	require.NotNil(t, supervisor)
+	defer supervisor.Shutdown()
+
+	require.NotZero(t, supervisor.pid)
+
+	var rlimit unix.Rlimit
+	require.NoError(t, unix.Prlimit(supervisor.pid, unix.RLIMIT_NOFILE, nil, &rlimit))
+	assert.Equal(t, uint64(128), rlimit.Cur)
+	assert.Equal(t, uint64(128), rlimit.Max)
+
+	usage, err := supervisor.SampleResourceUsage()
+	require.NoError(t, err)
+	assert.NotZero(t, usage.MemoryBytes)
+	assert.NotZero(t, usage.OpenFiles)
+	assert.Zero(t, usage.CPUPercent)
+
+	usage, err = supervisor.SampleResourceUsage()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, usage.CPUPercent, float64(0))
+}
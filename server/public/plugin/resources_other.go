@@ -0,0 +1,22 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build !linux
+
+package plugin
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// setProcessResourceLimits is a no-op, the kernel limits are only applied on Linux.
+func setProcessResourceLimits(pid int, limits *model.PluginResourceLimits) error {
+	return nil
+}
+
+// readProcessResourceUsage is only supported on Linux.
+func readProcessResourceUsage(pid int) (time.Duration, uint64, int, error) {
+	return 0, 0, 0, ErrResourceUsageUnsupported
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestParseProcStat(t *testing.T) {
+	cpuTime, err := parseProcStat("1234 (plugin (v2) exe) S 1 1234 1234 0 -1 4194560 1210 0 0 0 250 50 0 0 20 0 12 0 1000 1000000 2000 18446744073709551615")
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Second, cpuTime)
+
+	_, err = parseProcStat("1234 plugin S 1")
+	assert.Error(t, err)
+
+	_, err = parseProcStat("1234 (plugin) S 1 1234")
+	assert.Error(t, err)
+}
+
+func TestParseProcStatm(t *testing.T) {
+	rss, err := parseProcStatm("183542 4213 2405 3056 0 33410 0\n")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4213), rss)
+
+	_, err = parseProcStatm("183542")
+	assert.Error(t, err)
+}
+
+func TestResourceUsageExceededLimit(t *testing.T) {
+	usage := &ResourceUsage{MemoryBytes: 300 * 1024 * 1024, CPUPercent: 120, OpenFiles: 64}
+
+	assert.Empty(t, usage.exceededLimit(nil))
+
+	limits := &model.PluginResourceLimits{}
+	limits.MaxMemoryMB = model.NewPointer(0)
+	limits.MaxCPUPercent = model.NewPointer(0)
+	limits.MaxOpenFiles = model.NewPointer(0)
+	assert.Empty(t, usage.exceededLimit(limits))
+
+	limits.MaxMemoryMB = model.NewPointer(512)
+	limits.MaxCPUPercent = model.NewPointer(200)
+	limits.MaxOpenFiles = model.NewPointer(64)
+	assert.Empty(t, usage.exceededLimit(limits))
+
+	limits.MaxMemoryMB = model.NewPointer(256)
+	assert.Equal(t, "memory usage of 300 MB exceeds the limit of 256 MB", usage.exceededLimit(limits))
+
+	limits.MaxMemoryMB = model.NewPointer(0)
+	limits.MaxCPUPercent = model.NewPointer(100)
+	assert.Equal(t, "CPU usage of 120% exceeds the limit of 100%", usage.exceededLimit(limits))
+
+	limits.MaxCPUPercent = model.NewPointer(0)
+	limits.MaxOpenFiles = model.NewPointer(32)
+	assert.Equal(t, "64 open files exceed the limit of 32", usage.exceededLimit(limits))
+}
+
+func TestWithResourceLimits(t *testing.T) {
+	sup := &supervisor{}
+	clientConfig := &plugin.ClientConfig{Cmd: exec.Command("backend.exe")}
+
+	limits := &model.PluginResourceLimits{
+		MaxMemoryMB:   model.NewPointer(256),
+		MaxCPUPercent: model.NewPointer(150),
+		MaxOpenFiles:  model.NewPointer(0),
+	}
+	require.NoError(t, WithResourceLimits(limits)(sup, clientConfig))
+
+	assert.Equal(t, limits, sup.resourceLimits)
+	assert.Contains(t, clientConfig.Cmd.Env, "GOMEMLIMIT=256MiB")
+	assert.Contains(t, clientConfig.Cmd.Env, "GOMAXPROCS=2")
+}
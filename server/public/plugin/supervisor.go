@@ -31,14 +31,25 @@ type supervisor struct {
 	implemented  [TotalHooksID]bool
 	hooksClient  *hooksRPCClient
 	isReattached bool
+
+	pid            int
+	resourceLimits *model.PluginResourceLimits
+	usageLock      sync.Mutex
+	lastCPUTime    time.Duration
+	lastSampleTime time.Time
 }
 
 type driverForPlugin struct {
 	AppDriver
-	pluginID string
+	pluginID    string
+	permissions *model.PluginPermissions
 }
 
 func (d *driverForPlugin) Conn(isMaster bool) (string, error) {
+	if d.permissions != nil && !d.permissions.Database {
+		return "", fmt.Errorf("plugin %s is not allowed to access the database, the database permission is missing from the plugin manifest", d.pluginID)
+	}
+
 	return d.AppDriver.ConnWithPluginID(isMaster, d.pluginID)
 }
 
@@ -90,7 +101,7 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver,
 		pluginID: pluginInfo.Manifest.Id,
 	}
 	if driver != nil {
-		sup.appDriver = &driverForPlugin{AppDriver: driver, pluginID: pluginInfo.Manifest.Id}
+		sup.appDriver = &driverForPlugin{AppDriver: driver, pluginID: pluginInfo.Manifest.Id, permissions: pluginInfo.Manifest.Permissions}
 	}
 
 	defer func() {
@@ -108,9 +119,10 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver,
 
 	pluginMap := map[string]plugin.Plugin{
 		"hooks": &hooksPlugin{
-			log:        wrappedLogger,
-			driverImpl: sup.appDriver,
-			apiImpl:    &apiTimerLayer{pluginInfo.Manifest.Id, apiImpl, metrics},
+			log:         wrappedLogger,
+			driverImpl:  sup.appDriver,
+			apiImpl:     &apiTimerLayer{pluginInfo.Manifest.Id, apiImpl, metrics},
+			permissions: pluginInfo.Manifest.Permissions,
 		},
 	}
 
@@ -136,6 +148,17 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver,
 		return nil, err
 	}
 
+	if clientConfig.Cmd != nil && clientConfig.Cmd.Process != nil {
+		sup.pid = clientConfig.Cmd.Process.Pid
+		if sup.resourceLimits != nil {
+			if err := setProcessResourceLimits(sup.pid, sup.resourceLimits); err != nil {
+				wrappedLogger.Warn("Failed to apply the resource limits of the plugin", mlog.Err(err))
+			}
+		}
+	} else if clientConfig.Reattach != nil {
+		sup.pid = clientConfig.Reattach.Pid
+	}
+
 	raw, err := rpcClient.Dispense("hooks")
 	if err != nil {
 		return nil, err
@@ -153,9 +176,21 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver,
 		return nil, err
 	}
 	for _, hookName := range impl {
-		if hookId, ok := hookNameToId[hookName]; ok {
-			sup.implemented[hookId] = true
+		hookId, ok := hookNameToId[hookName]
+		if !ok {
+			continue
 		}
+
+		// Hooks missing from the permissions of the plugin are never run.
+		if !isHookAllowed(pluginInfo.Manifest.Permissions, hookName) {
+			wrappedLogger.Warn("Plugin implements a hook missing from its permissions, the hook won't be run", mlog.String("hook", hookName))
+			if sup.hooksClient != nil {
+				sup.hooksClient.implemented[hookId] = false
+			}
+			continue
+		}
+
+		sup.implemented[hookId] = true
 	}
 
 	return &sup, nil